@@ -137,9 +137,7 @@ func (app *Application) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 }
 
 func (app *Application) Commit() types.ResponseCommit {
-	// Using a memdb - just return the big endian size of the db
-	appHash := make([]byte, 8)
-	binary.PutVarint(appHash, app.state.Size)
+	appHash := app.appHash()
 	app.state.AppHash = appHash
 	app.state.Height++
 	saveState(app.state)
@@ -151,6 +149,18 @@ func (app *Application) Commit() types.ResponseCommit {
 	return resp
 }
 
+// GetAppHash returns the app hash of the state, as committed by Commit.
+func (app *Application) GetAppHash(req types.RequestGetAppHash) types.ResponseGetAppHash {
+	return types.ResponseGetAppHash{AppHash: app.appHash()}
+}
+
+// Using a memdb - just return the big endian size of the db
+func (app *Application) appHash() []byte {
+	appHash := make([]byte, 8)
+	binary.PutVarint(appHash, app.state.Size)
+	return appHash
+}
+
 // Returns an associated value or nil if missing.
 func (app *Application) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
 	if reqQuery.Prove {
@@ -328,9 +328,21 @@ func testClient(t *testing.T, app abcicli.Client, tx []byte, key, value string)
 	ar, err = app.DeliverTxSync(types.RequestDeliverTx{Tx: tx})
 	require.NoError(t, err)
 	require.False(t, ar.IsErr(), ar)
+	// the app hash before the commit is the one committed
+	resHash, err := app.GetAppHashSync(types.RequestGetAppHash{})
+	require.NoError(t, err)
 	// commit
-	_, err = app.CommitSync()
+	resCommit, err := app.CommitSync()
+	require.NoError(t, err)
+	require.Equal(t, resCommit.Data, resHash.AppHash)
+
+	// the fraud proofs are not supported
+	resProof, err := app.GenerateFraudProofSync(types.RequestGenerateFraudProof{})
+	require.NoError(t, err)
+	require.Nil(t, resProof.FraudProof)
+	resVerify, err := app.VerifyFraudProofSync(types.RequestVerifyFraudProof{})
 	require.NoError(t, err)
+	require.False(t, resVerify.Success)
 
 	info, err := app.InfoSync(types.RequestInfo{})
 	require.NoError(t, err)
@@ -173,6 +173,20 @@ func (app *PersistentKVStoreApplication) ApplySnapshotChunk(
 	return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ABORT}
 }
 
+func (app *PersistentKVStoreApplication) GetAppHash(req types.RequestGetAppHash) types.ResponseGetAppHash {
+	return app.app.GetAppHash(req)
+}
+
+func (app *PersistentKVStoreApplication) GenerateFraudProof(
+	req types.RequestGenerateFraudProof) types.ResponseGenerateFraudProof {
+	return types.ResponseGenerateFraudProof{}
+}
+
+func (app *PersistentKVStoreApplication) VerifyFraudProof(
+	req types.RequestVerifyFraudProof) types.ResponseVerifyFraudProof {
+	return types.ResponseVerifyFraudProof{}
+}
+
 //---------------------------------------------
 // update validators
 
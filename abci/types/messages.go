@@ -159,6 +159,24 @@ func ToRequestApplySnapshotChunk(req RequestApplySnapshotChunk) *Request {
 	}
 }
 
+func ToRequestGetAppHash(req RequestGetAppHash) *Request {
+	return &Request{
+		Value: &Request_GetAppHash{&req},
+	}
+}
+
+func ToRequestGenerateFraudProof(req RequestGenerateFraudProof) *Request {
+	return &Request{
+		Value: &Request_GenerateFraudProof{&req},
+	}
+}
+
+func ToRequestVerifyFraudProof(req RequestVerifyFraudProof) *Request {
+	return &Request{
+		Value: &Request_VerifyFraudProof{&req},
+	}
+}
+
 //----------------------------------------
 
 func ToResponseException(errStr string) *Response {
@@ -256,3 +274,21 @@ func ToResponseApplySnapshotChunk(res ResponseApplySnapshotChunk) *Response {
 		Value: &Response_ApplySnapshotChunk{&res},
 	}
 }
+
+func ToResponseGetAppHash(res ResponseGetAppHash) *Response {
+	return &Response{
+		Value: &Response_GetAppHash{&res},
+	}
+}
+
+func ToResponseGenerateFraudProof(res ResponseGenerateFraudProof) *Response {
+	return &Response{
+		Value: &Response_GenerateFraudProof{&res},
+	}
+}
+
+func ToResponseVerifyFraudProof(res ResponseVerifyFraudProof) *Response {
+	return &Response{
+		Value: &Response_VerifyFraudProof{&res},
+	}
+}
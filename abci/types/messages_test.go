@@ -9,6 +9,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
+	cmtcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -112,3 +113,35 @@ func TestWriteReadMessage2(t *testing.T) {
 		assert.True(t, proto.Equal(c, msg))
 	}
 }
+
+func TestWriteReadFraudProof(t *testing.T) {
+	proofOp := &cmtcrypto.ProofOp{Type: "ics23:iavl", Key: []byte("key"), Data: []byte("proof")}
+	req := ToRequestVerifyFraudProof(RequestVerifyFraudProof{
+		FraudProof: &FraudProof{
+			BlockHeight:          4,
+			PreStateAppHash:      []byte("pre"),
+			ExpectedValidAppHash: []byte("expected"),
+			StateWitness: map[string]*StateWitness{
+				"bank": {
+					Proof:    proofOp,
+					RootHash: []byte("root"),
+					WitnessData: []*WitnessData{
+						{Key: []byte("key"), Value: []byte("value"), Proofs: []*cmtcrypto.ProofOp{proofOp}},
+					},
+				},
+			},
+			FraudulentDeliverTx: &RequestDeliverTx{Tx: []byte("tx")},
+		},
+		ExpectedValidAppHash: []byte("expected"),
+	})
+
+	buf := new(bytes.Buffer)
+	err := WriteMessage(req, buf)
+	assert.Nil(t, err)
+
+	msg := new(Request)
+	err = ReadMessage(buf, msg)
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(req, msg))
+	assert.Equal(t, []byte("root"), msg.GetVerifyFraudProof().FraudProof.StateWitness["bank"].RootHash)
+}
@@ -120,7 +120,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{34, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +157,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{36, 0}
 }
 
 type Request struct {
@@ -177,6 +177,9 @@ type Request struct {
 	//	*Request_OfferSnapshot
 	//	*Request_LoadSnapshotChunk
 	//	*Request_ApplySnapshotChunk
+	//	*Request_GetAppHash
+	//	*Request_GenerateFraudProof
+	//	*Request_VerifyFraudProof
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -264,6 +267,15 @@ type Request_LoadSnapshotChunk struct {
 type Request_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *RequestApplySnapshotChunk `protobuf:"bytes,15,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
+type Request_GetAppHash struct {
+	GetAppHash *RequestGetAppHash `protobuf:"bytes,16,opt,name=get_app_hash,json=getAppHash,proto3,oneof" json:"get_app_hash,omitempty"`
+}
+type Request_GenerateFraudProof struct {
+	GenerateFraudProof *RequestGenerateFraudProof `protobuf:"bytes,17,opt,name=generate_fraud_proof,json=generateFraudProof,proto3,oneof" json:"generate_fraud_proof,omitempty"`
+}
+type Request_VerifyFraudProof struct {
+	VerifyFraudProof *RequestVerifyFraudProof `protobuf:"bytes,18,opt,name=verify_fraud_proof,json=verifyFraudProof,proto3,oneof" json:"verify_fraud_proof,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -280,6 +292,9 @@ func (*Request_ListSnapshots) isRequest_Value()      {}
 func (*Request_OfferSnapshot) isRequest_Value()      {}
 func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
+func (*Request_GetAppHash) isRequest_Value()         {}
+func (*Request_GenerateFraudProof) isRequest_Value() {}
+func (*Request_VerifyFraudProof) isRequest_Value()   {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -393,6 +408,27 @@ func (m *Request) GetApplySnapshotChunk() *RequestApplySnapshotChunk {
 	return nil
 }
 
+func (m *Request) GetGetAppHash() *RequestGetAppHash {
+	if x, ok := m.GetValue().(*Request_GetAppHash); ok {
+		return x.GetAppHash
+	}
+	return nil
+}
+
+func (m *Request) GetGenerateFraudProof() *RequestGenerateFraudProof {
+	if x, ok := m.GetValue().(*Request_GenerateFraudProof); ok {
+		return x.GenerateFraudProof
+	}
+	return nil
+}
+
+func (m *Request) GetVerifyFraudProof() *RequestVerifyFraudProof {
+	if x, ok := m.GetValue().(*Request_VerifyFraudProof); ok {
+		return x.VerifyFraudProof
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -411,6 +447,9 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_OfferSnapshot)(nil),
 		(*Request_LoadSnapshotChunk)(nil),
 		(*Request_ApplySnapshotChunk)(nil),
+		(*Request_GetAppHash)(nil),
+		(*Request_GenerateFraudProof)(nil),
+		(*Request_VerifyFraudProof)(nil),
 	}
 }
 
@@ -846,6 +885,11 @@ func (m *RequestBeginBlock) GetConsensusMessages() []*types.Any {
 type RequestCheckTx struct {
 	Tx   []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=tendermint.abci.CheckTxType" json:"type,omitempty"`
+	// The context of the check: the height of the next block, the time of the
+	// last one, and the address of the proposer of the next block.
+	Height          int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Time            time.Time `protobuf:"bytes,4,opt,name=time,proto3,stdtime" json:"time"`
+	ProposerAddress []byte    `protobuf:"bytes,5,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
 }
 
 func (m *RequestCheckTx) Reset()         { *m = RequestCheckTx{} }
@@ -895,6 +939,27 @@ func (m *RequestCheckTx) GetType() CheckTxType {
 	return CheckTxType_New
 }
 
+func (m *RequestCheckTx) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RequestCheckTx) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
+func (m *RequestCheckTx) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
+	}
+	return nil
+}
+
 type RequestDeliverTx struct {
 	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 }
@@ -1231,6 +1296,160 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 	return ""
 }
 
+type RequestGetAppHash struct {
+}
+
+func (m *RequestGetAppHash) Reset()         { *m = RequestGetAppHash{} }
+func (m *RequestGetAppHash) String() string { return proto.CompactTextString(m) }
+func (*RequestGetAppHash) ProtoMessage()    {}
+func (*RequestGetAppHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{16}
+}
+func (m *RequestGetAppHash) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGetAppHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGetAppHash.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGetAppHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGetAppHash.Merge(m, src)
+}
+func (m *RequestGetAppHash) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGetAppHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGetAppHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGetAppHash proto.InternalMessageInfo
+
+// Generates a fraud proof of the state transitions of a block, from its
+// BeginBlock, DeliverTx and EndBlock requests, against the state of the
+// application before it.
+type RequestGenerateFraudProof struct {
+	BeginBlockRequest RequestBeginBlock   `protobuf:"bytes,1,opt,name=begin_block_request,json=beginBlockRequest,proto3" json:"begin_block_request"`
+	DeliverTxRequests []*RequestDeliverTx `protobuf:"bytes,2,rep,name=deliver_tx_requests,json=deliverTxRequests,proto3" json:"deliver_tx_requests,omitempty"`
+	EndBlockRequest   *RequestEndBlock    `protobuf:"bytes,3,opt,name=end_block_request,json=endBlockRequest,proto3" json:"end_block_request,omitempty"`
+}
+
+func (m *RequestGenerateFraudProof) Reset()         { *m = RequestGenerateFraudProof{} }
+func (m *RequestGenerateFraudProof) String() string { return proto.CompactTextString(m) }
+func (*RequestGenerateFraudProof) ProtoMessage()    {}
+func (*RequestGenerateFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{17}
+}
+func (m *RequestGenerateFraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGenerateFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGenerateFraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGenerateFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGenerateFraudProof.Merge(m, src)
+}
+func (m *RequestGenerateFraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGenerateFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGenerateFraudProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGenerateFraudProof proto.InternalMessageInfo
+
+func (m *RequestGenerateFraudProof) GetBeginBlockRequest() RequestBeginBlock {
+	if m != nil {
+		return m.BeginBlockRequest
+	}
+	return RequestBeginBlock{}
+}
+
+func (m *RequestGenerateFraudProof) GetDeliverTxRequests() []*RequestDeliverTx {
+	if m != nil {
+		return m.DeliverTxRequests
+	}
+	return nil
+}
+
+func (m *RequestGenerateFraudProof) GetEndBlockRequest() *RequestEndBlock {
+	if m != nil {
+		return m.EndBlockRequest
+	}
+	return nil
+}
+
+// Verifies a fraud proof.
+type RequestVerifyFraudProof struct {
+	FraudProof *FraudProof `protobuf:"bytes,1,opt,name=fraud_proof,json=fraudProof,proto3" json:"fraud_proof,omitempty"`
+	// The app hash expected from applying the fraudulent state transition to
+	// the state witnesses of the fraud proof.
+	ExpectedValidAppHash []byte `protobuf:"bytes,2,opt,name=expected_valid_app_hash,json=expectedValidAppHash,proto3" json:"expected_valid_app_hash,omitempty"`
+}
+
+func (m *RequestVerifyFraudProof) Reset()         { *m = RequestVerifyFraudProof{} }
+func (m *RequestVerifyFraudProof) String() string { return proto.CompactTextString(m) }
+func (*RequestVerifyFraudProof) ProtoMessage()    {}
+func (*RequestVerifyFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{18}
+}
+func (m *RequestVerifyFraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestVerifyFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestVerifyFraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestVerifyFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestVerifyFraudProof.Merge(m, src)
+}
+func (m *RequestVerifyFraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestVerifyFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestVerifyFraudProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestVerifyFraudProof proto.InternalMessageInfo
+
+func (m *RequestVerifyFraudProof) GetFraudProof() *FraudProof {
+	if m != nil {
+		return m.FraudProof
+	}
+	return nil
+}
+
+func (m *RequestVerifyFraudProof) GetExpectedValidAppHash() []byte {
+	if m != nil {
+		return m.ExpectedValidAppHash
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//
@@ -1250,6 +1469,9 @@ type Response struct {
 	//	*Response_OfferSnapshot
 	//	*Response_LoadSnapshotChunk
 	//	*Response_ApplySnapshotChunk
+	//	*Response_GetAppHash
+	//	*Response_GenerateFraudProof
+	//	*Response_VerifyFraudProof
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1257,7 +1479,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1340,6 +1562,15 @@ type Response_LoadSnapshotChunk struct {
 type Response_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *ResponseApplySnapshotChunk `protobuf:"bytes,16,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
+type Response_GetAppHash struct {
+	GetAppHash *ResponseGetAppHash `protobuf:"bytes,17,opt,name=get_app_hash,json=getAppHash,proto3,oneof" json:"get_app_hash,omitempty"`
+}
+type Response_GenerateFraudProof struct {
+	GenerateFraudProof *ResponseGenerateFraudProof `protobuf:"bytes,18,opt,name=generate_fraud_proof,json=generateFraudProof,proto3,oneof" json:"generate_fraud_proof,omitempty"`
+}
+type Response_VerifyFraudProof struct {
+	VerifyFraudProof *ResponseVerifyFraudProof `protobuf:"bytes,19,opt,name=verify_fraud_proof,json=verifyFraudProof,proto3,oneof" json:"verify_fraud_proof,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1357,6 +1588,9 @@ func (*Response_ListSnapshots) isResponse_Value()      {}
 func (*Response_OfferSnapshot) isResponse_Value()      {}
 func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
+func (*Response_GetAppHash) isResponse_Value()         {}
+func (*Response_GenerateFraudProof) isResponse_Value() {}
+func (*Response_VerifyFraudProof) isResponse_Value()   {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1477,6 +1711,27 @@ func (m *Response) GetApplySnapshotChunk() *ResponseApplySnapshotChunk {
 	return nil
 }
 
+func (m *Response) GetGetAppHash() *ResponseGetAppHash {
+	if x, ok := m.GetValue().(*Response_GetAppHash); ok {
+		return x.GetAppHash
+	}
+	return nil
+}
+
+func (m *Response) GetGenerateFraudProof() *ResponseGenerateFraudProof {
+	if x, ok := m.GetValue().(*Response_GenerateFraudProof); ok {
+		return x.GenerateFraudProof
+	}
+	return nil
+}
+
+func (m *Response) GetVerifyFraudProof() *ResponseVerifyFraudProof {
+	if x, ok := m.GetValue().(*Response_VerifyFraudProof); ok {
+		return x.VerifyFraudProof
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1496,6 +1751,9 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_OfferSnapshot)(nil),
 		(*Response_LoadSnapshotChunk)(nil),
 		(*Response_ApplySnapshotChunk)(nil),
+		(*Response_GetAppHash)(nil),
+		(*Response_GenerateFraudProof)(nil),
+		(*Response_VerifyFraudProof)(nil),
 	}
 }
 
@@ -1508,7 +1766,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1552,7 +1810,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1595,7 +1853,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1636,7 +1894,7 @@ func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1712,7 +1970,7 @@ func (m *ResponseSetOption) Reset()         { *m = ResponseSetOption{} }
 func (m *ResponseSetOption) String() string { return proto.CompactTextString(m) }
 func (*ResponseSetOption) ProtoMessage()    {}
 func (*ResponseSetOption) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseSetOption) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1774,7 +2032,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1855,7 +2113,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1957,7 +2215,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2012,7 +2270,7 @@ func (m *ConsensusMessageResponse) Reset()         { *m = ConsensusMessageRespon
 func (m *ConsensusMessageResponse) String() string { return proto.CompactTextString(m) }
 func (*ConsensusMessageResponse) ProtoMessage()    {}
 func (*ConsensusMessageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ConsensusMessageResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2106,7 +2364,7 @@ func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2227,7 +2485,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2317,13 +2575,14 @@ type ResponseEndBlock struct {
 	ConsensusParamUpdates *ConsensusParams  `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
 	Events                []Event           `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
 	RollappParamUpdates   *RollappParams    `protobuf:"bytes,4,opt,name=rollapp_param_updates,json=rollappParamUpdates,proto3" json:"rollapp_param_updates,omitempty"`
+	KeyRotations          []KeyRotation     `protobuf:"bytes,5,rep,name=key_rotations,json=keyRotations,proto3" json:"key_rotations"`
 }
 
 func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2380,6 +2639,13 @@ func (m *ResponseEndBlock) GetRollappParamUpdates() *RollappParams {
 	return nil
 }
 
+func (m *ResponseEndBlock) GetKeyRotations() []KeyRotation {
+	if m != nil {
+		return m.KeyRotations
+	}
+	return nil
+}
+
 type ResponseCommit struct {
 	// reserve 1
 	Data         []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2390,7 +2656,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2441,7 +2707,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2485,7 +2751,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2529,7 +2795,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2575,7 +2841,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2625,27 +2891,22 @@ func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 	return nil
 }
 
-// ConsensusParams contains all consensus-relevant parameters
-// that can be adjusted by the abci app
-type ConsensusParams struct {
-	Block     *BlockParams            `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
-	Evidence  *types1.EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
-	Validator *types1.ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
-	Version   *types1.VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+type ResponseGetAppHash struct {
+	AppHash []byte `protobuf:"bytes,1,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
 }
 
-func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
-func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
-func (*ConsensusParams) ProtoMessage()    {}
-func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+func (m *ResponseGetAppHash) Reset()         { *m = ResponseGetAppHash{} }
+func (m *ResponseGetAppHash) String() string { return proto.CompactTextString(m) }
+func (*ResponseGetAppHash) ProtoMessage()    {}
+func (*ResponseGetAppHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
-func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
+func (m *ResponseGetAppHash) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseGetAppHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ConsensusParams.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseGetAppHash.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2655,66 +2916,85 @@ func (m *ConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *ConsensusParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConsensusParams.Merge(m, src)
+func (m *ResponseGetAppHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGetAppHash.Merge(m, src)
 }
-func (m *ConsensusParams) XXX_Size() int {
+func (m *ResponseGetAppHash) XXX_Size() int {
 	return m.Size()
 }
-func (m *ConsensusParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConsensusParams.DiscardUnknown(m)
+func (m *ResponseGetAppHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGetAppHash.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConsensusParams proto.InternalMessageInfo
+var xxx_messageInfo_ResponseGetAppHash proto.InternalMessageInfo
 
-func (m *ConsensusParams) GetBlock() *BlockParams {
+func (m *ResponseGetAppHash) GetAppHash() []byte {
 	if m != nil {
-		return m.Block
+		return m.AppHash
 	}
 	return nil
 }
 
-func (m *ConsensusParams) GetEvidence() *types1.EvidenceParams {
-	if m != nil {
-		return m.Evidence
-	}
-	return nil
+type ResponseGenerateFraudProof struct {
+	FraudProof *FraudProof `protobuf:"bytes,1,opt,name=fraud_proof,json=fraudProof,proto3" json:"fraud_proof,omitempty"`
 }
 
-func (m *ConsensusParams) GetValidator() *types1.ValidatorParams {
-	if m != nil {
-		return m.Validator
+func (m *ResponseGenerateFraudProof) Reset()         { *m = ResponseGenerateFraudProof{} }
+func (m *ResponseGenerateFraudProof) String() string { return proto.CompactTextString(m) }
+func (*ResponseGenerateFraudProof) ProtoMessage()    {}
+func (*ResponseGenerateFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{38}
+}
+func (m *ResponseGenerateFraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseGenerateFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseGenerateFraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *ResponseGenerateFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGenerateFraudProof.Merge(m, src)
+}
+func (m *ResponseGenerateFraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseGenerateFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGenerateFraudProof.DiscardUnknown(m)
 }
 
-func (m *ConsensusParams) GetVersion() *types1.VersionParams {
+var xxx_messageInfo_ResponseGenerateFraudProof proto.InternalMessageInfo
+
+func (m *ResponseGenerateFraudProof) GetFraudProof() *FraudProof {
 	if m != nil {
-		return m.Version
+		return m.FraudProof
 	}
 	return nil
 }
 
-// BlockParams contains limits on the block size.
-type BlockParams struct {
-	// Note: must be greater than 0
-	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
-	// Note: must be greater or equal to -1
-	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+type ResponseVerifyFraudProof struct {
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 }
 
-func (m *BlockParams) Reset()         { *m = BlockParams{} }
-func (m *BlockParams) String() string { return proto.CompactTextString(m) }
-func (*BlockParams) ProtoMessage()    {}
-func (*BlockParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+func (m *ResponseVerifyFraudProof) Reset()         { *m = ResponseVerifyFraudProof{} }
+func (m *ResponseVerifyFraudProof) String() string { return proto.CompactTextString(m) }
+func (*ResponseVerifyFraudProof) ProtoMessage()    {}
+func (*ResponseVerifyFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
-func (m *BlockParams) XXX_Unmarshal(b []byte) error {
+func (m *ResponseVerifyFraudProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BlockParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseVerifyFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BlockParams.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseVerifyFraudProof.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2724,23 +3004,165 @@ func (m *BlockParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *BlockParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlockParams.Merge(m, src)
+func (m *ResponseVerifyFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseVerifyFraudProof.Merge(m, src)
 }
-func (m *BlockParams) XXX_Size() int {
+func (m *ResponseVerifyFraudProof) XXX_Size() int {
 	return m.Size()
 }
-func (m *BlockParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlockParams.DiscardUnknown(m)
+func (m *ResponseVerifyFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseVerifyFraudProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlockParams proto.InternalMessageInfo
+var xxx_messageInfo_ResponseVerifyFraudProof proto.InternalMessageInfo
 
-func (m *BlockParams) GetMaxBytes() int64 {
+func (m *ResponseVerifyFraudProof) GetSuccess() bool {
 	if m != nil {
-		return m.MaxBytes
+		return m.Success
 	}
-	return 0
+	return false
+}
+
+// ConsensusParams contains all consensus-relevant parameters
+// that can be adjusted by the abci app
+type ConsensusParams struct {
+	Block     *BlockParams            `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Evidence  *types1.EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Validator *types1.ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
+	Version   *types1.VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Timeout   *types1.TimeoutParams   `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Synchrony *types1.SynchronyParams `protobuf:"bytes,6,opt,name=synchrony,proto3" json:"synchrony,omitempty"`
+	Feature   *types1.FeatureParams   `protobuf:"bytes,7,opt,name=feature,proto3" json:"feature,omitempty"`
+}
+
+func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
+func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
+func (*ConsensusParams) ProtoMessage()    {}
+func (*ConsensusParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{40}
+}
+func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConsensusParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConsensusParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusParams.Merge(m, src)
+}
+func (m *ConsensusParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConsensusParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusParams proto.InternalMessageInfo
+
+func (m *ConsensusParams) GetBlock() *BlockParams {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetEvidence() *types1.EvidenceParams {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetValidator() *types1.ValidatorParams {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetVersion() *types1.VersionParams {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetTimeout() *types1.TimeoutParams {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetSynchrony() *types1.SynchronyParams {
+	if m != nil {
+		return m.Synchrony
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetFeature() *types1.FeatureParams {
+	if m != nil {
+		return m.Feature
+	}
+	return nil
+}
+
+// BlockParams contains limits on the block size.
+type BlockParams struct {
+	// Note: must be greater than 0
+	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Note: must be greater or equal to -1
+	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+}
+
+func (m *BlockParams) Reset()         { *m = BlockParams{} }
+func (m *BlockParams) String() string { return proto.CompactTextString(m) }
+func (*BlockParams) ProtoMessage()    {}
+func (*BlockParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{41}
+}
+func (m *BlockParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockParams.Merge(m, src)
+}
+func (m *BlockParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockParams proto.InternalMessageInfo
+
+func (m *BlockParams) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
 }
 
 func (m *BlockParams) GetMaxGas() int64 {
@@ -2759,7 +3181,7 @@ func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2814,7 +3236,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2868,7 +3290,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{44}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2932,7 +3354,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{45}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3000,7 +3422,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{46}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3047,13 +3469,16 @@ func (m *Validator) GetPower() int64 {
 type ValidatorUpdate struct {
 	PubKey crypto.PublicKey `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
 	Power  int64            `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	// the proof of possession of the private key, required for the BLS12-381
+	// keys entering the validator set
+	ProofOfPossession []byte `protobuf:"bytes,3,opt,name=proof_of_possession,json=proofOfPossession,proto3" json:"proof_of_possession,omitempty"`
 }
 
 func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{47}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3096,6 +3521,92 @@ func (m *ValidatorUpdate) GetPower() int64 {
 	return 0
 }
 
+func (m *ValidatorUpdate) GetProofOfPossession() []byte {
+	if m != nil {
+		return m.ProofOfPossession
+	}
+	return nil
+}
+
+// KeyRotation
+type KeyRotation struct {
+	OldPubKey crypto.PublicKey `protobuf:"bytes,1,opt,name=old_pub_key,json=oldPubKey,proto3" json:"old_pub_key"`
+	NewPubKey crypto.PublicKey `protobuf:"bytes,2,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key"`
+	Height    int64            `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Signature []byte           `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// the proof of possession of the private key of the new key, required for
+	// the BLS12-381 keys
+	ProofOfPossession []byte `protobuf:"bytes,5,opt,name=proof_of_possession,json=proofOfPossession,proto3" json:"proof_of_possession,omitempty"`
+}
+
+func (m *KeyRotation) Reset()         { *m = KeyRotation{} }
+func (m *KeyRotation) String() string { return proto.CompactTextString(m) }
+func (*KeyRotation) ProtoMessage()    {}
+func (*KeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{48}
+}
+func (m *KeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyRotation.Merge(m, src)
+}
+func (m *KeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyRotation proto.InternalMessageInfo
+
+func (m *KeyRotation) GetOldPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.OldPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *KeyRotation) GetNewPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *KeyRotation) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *KeyRotation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *KeyRotation) GetProofOfPossession() []byte {
+	if m != nil {
+		return m.ProofOfPossession
+	}
+	return nil
+}
+
 // VoteInfo
 type VoteInfo struct {
 	Validator       Validator `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator"`
@@ -3106,7 +3617,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{42}
+	return fileDescriptor_252557cfdd89a31a, []int{49}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3167,7 +3678,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{43}
+	return fileDescriptor_252557cfdd89a31a, []int{50}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3240,7 +3751,7 @@ func (m *RollappParams) Reset()         { *m = RollappParams{} }
 func (m *RollappParams) String() string { return proto.CompactTextString(m) }
 func (*RollappParams) ProtoMessage()    {}
 func (*RollappParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{44}
+	return fileDescriptor_252557cfdd89a31a, []int{51}
 }
 func (m *RollappParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3295,7 +3806,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{45}
+	return fileDescriptor_252557cfdd89a31a, []int{52}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3359,251 +3870,529 @@ func (m *Snapshot) GetMetadata() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
-	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
-	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
-	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
-	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
-	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
-	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
-	proto.RegisterType((*RequestInfo)(nil), "tendermint.abci.RequestInfo")
-	proto.RegisterType((*RequestSetOption)(nil), "tendermint.abci.RequestSetOption")
-	proto.RegisterType((*RequestInitChain)(nil), "tendermint.abci.RequestInitChain")
-	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
-	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
-	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
-	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
-	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
-	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
-	proto.RegisterType((*RequestListSnapshots)(nil), "tendermint.abci.RequestListSnapshots")
-	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
-	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
-	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
-	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
-	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
-	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
-	proto.RegisterType((*ResponseFlush)(nil), "tendermint.abci.ResponseFlush")
-	proto.RegisterType((*ResponseInfo)(nil), "tendermint.abci.ResponseInfo")
-	proto.RegisterType((*ResponseSetOption)(nil), "tendermint.abci.ResponseSetOption")
-	proto.RegisterType((*ResponseInitChain)(nil), "tendermint.abci.ResponseInitChain")
-	proto.RegisterType((*ResponseQuery)(nil), "tendermint.abci.ResponseQuery")
-	proto.RegisterType((*ResponseBeginBlock)(nil), "tendermint.abci.ResponseBeginBlock")
-	proto.RegisterType((*ConsensusMessageResponse)(nil), "tendermint.abci.ConsensusMessageResponse")
-	proto.RegisterType((*ResponseCheckTx)(nil), "tendermint.abci.ResponseCheckTx")
-	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
-	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
-	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
-	proto.RegisterType((*ResponseListSnapshots)(nil), "tendermint.abci.ResponseListSnapshots")
-	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
-	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
-	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
-	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
-	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
-	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
-	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
-	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
-	proto.RegisterType((*TxResult)(nil), "tendermint.abci.TxResult")
-	proto.RegisterType((*Validator)(nil), "tendermint.abci.Validator")
-	proto.RegisterType((*ValidatorUpdate)(nil), "tendermint.abci.ValidatorUpdate")
-	proto.RegisterType((*VoteInfo)(nil), "tendermint.abci.VoteInfo")
-	proto.RegisterType((*Evidence)(nil), "tendermint.abci.Evidence")
-	proto.RegisterType((*RollappParams)(nil), "tendermint.abci.RollappParams")
-	proto.RegisterType((*Snapshot)(nil), "tendermint.abci.Snapshot")
-}
-
-func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
-
-var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2998 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcb, 0x77, 0x23, 0xc5,
-	0xd5, 0xd7, 0xfb, 0x71, 0x6d, 0x3d, 0x5c, 0x63, 0x8c, 0x46, 0x0c, 0xf6, 0xd0, 0x73, 0xe0, 0x63,
-	0x06, 0xb0, 0x3f, 0xcc, 0x81, 0x8f, 0xf9, 0x20, 0x01, 0x4b, 0xa3, 0x41, 0x66, 0x8c, 0xed, 0x94,
-	0x35, 0x43, 0x5e, 0xd0, 0xb4, 0xd4, 0x65, 0xa9, 0xb1, 0xd4, 0xdd, 0x74, 0x97, 0x8c, 0xc5, 0x32,
-	0x39, 0xd9, 0xb0, 0x22, 0xbb, 0x6c, 0xf8, 0x3f, 0xb2, 0xca, 0x2e, 0xe7, 0x90, 0x93, 0x0d, 0xcb,
-	0x2c, 0x72, 0x20, 0x07, 0x4e, 0x36, 0xf9, 0x03, 0x92, 0x55, 0x4e, 0x72, 0xea, 0xd1, 0x2f, 0x49,
-	0x6d, 0xc9, 0x90, 0x5d, 0x76, 0x5d, 0xb7, 0xee, 0xbd, 0x5d, 0x55, 0x7d, 0xeb, 0x77, 0x7f, 0x75,
-	0xbb, 0xe0, 0x09, 0x4a, 0x4c, 0x9d, 0x38, 0x23, 0xc3, 0xa4, 0x3b, 0x5a, 0xb7, 0x67, 0xec, 0xd0,
-	0x89, 0x4d, 0xdc, 0x6d, 0xdb, 0xb1, 0xa8, 0x85, 0x2a, 0x41, 0xe7, 0x36, 0xeb, 0xac, 0x3f, 0x19,
-	0xd2, 0xee, 0x39, 0x13, 0x9b, 0x5a, 0x3b, 0xb6, 0x63, 0x59, 0xa7, 0x42, 0xbf, 0x7e, 0x23, 0xd4,
-	0xcd, 0xfd, 0x84, 0xbd, 0x45, 0x7a, 0xa5, 0xf1, 0x19, 0x99, 0x78, 0xbd, 0x4f, 0xce, 0xd8, 0xda,
-	0x9a, 0xa3, 0x8d, 0xbc, 0xee, 0xad, 0xbe, 0x65, 0xf5, 0x87, 0x64, 0x87, 0xb7, 0xba, 0xe3, 0xd3,
-	0x1d, 0x6a, 0x8c, 0x88, 0x4b, 0xb5, 0x91, 0x2d, 0x15, 0xd6, 0xfb, 0x56, 0xdf, 0xe2, 0x8f, 0x3b,
-	0xec, 0x49, 0x4a, 0xaf, 0x4f, 0x9b, 0x69, 0xe6, 0x44, 0x74, 0x29, 0xbf, 0x2e, 0x40, 0x1e, 0x93,
-	0x8f, 0xc6, 0xc4, 0xa5, 0x68, 0x17, 0x32, 0xa4, 0x37, 0xb0, 0x6a, 0xc9, 0x9b, 0xc9, 0x67, 0x57,
-	0x76, 0x6f, 0x6c, 0x4f, 0xcd, 0x7b, 0x5b, 0xea, 0xb5, 0x7a, 0x03, 0xab, 0x9d, 0xc0, 0x5c, 0x17,
-	0xbd, 0x0c, 0xd9, 0xd3, 0xe1, 0xd8, 0x1d, 0xd4, 0x52, 0xdc, 0xe8, 0xc9, 0x38, 0xa3, 0xfb, 0x4c,
-	0xa9, 0x9d, 0xc0, 0x42, 0x9b, 0xbd, 0xca, 0x30, 0x4f, 0xad, 0x5a, 0xfa, 0xf2, 0x57, 0xed, 0x9b,
-	0xa7, 0xfc, 0x55, 0x4c, 0x17, 0x35, 0x00, 0x5c, 0x42, 0x55, 0xcb, 0xa6, 0x86, 0x65, 0xd6, 0x32,
-	0xdc, 0xf2, 0xa9, 0x38, 0xcb, 0x13, 0x42, 0x8f, 0xb8, 0x62, 0x3b, 0x81, 0x8b, 0xae, 0xd7, 0x60,
-	0x3e, 0x0c, 0xd3, 0xa0, 0x6a, 0x6f, 0xa0, 0x19, 0x66, 0x2d, 0x7b, 0xb9, 0x8f, 0x7d, 0xd3, 0xa0,
-	0x4d, 0xa6, 0xc8, 0x7c, 0x18, 0x5e, 0x83, 0x4d, 0xf9, 0xa3, 0x31, 0x71, 0x26, 0xb5, 0xdc, 0xe5,
-	0x53, 0xfe, 0x11, 0x53, 0x62, 0x53, 0xe6, 0xda, 0xa8, 0x05, 0x2b, 0x5d, 0xd2, 0x37, 0x4c, 0xb5,
-	0x3b, 0xb4, 0x7a, 0x67, 0xb5, 0x3c, 0x37, 0x56, 0xe2, 0x8c, 0x1b, 0x4c, 0xb5, 0xc1, 0x34, 0xdb,
-	0x09, 0x0c, 0x5d, 0xbf, 0x85, 0x5e, 0x87, 0x42, 0x6f, 0x40, 0x7a, 0x67, 0x2a, 0xbd, 0xa8, 0x15,
-	0xb8, 0x8f, 0xad, 0x38, 0x1f, 0x4d, 0xa6, 0xd7, 0xb9, 0x68, 0x27, 0x70, 0xbe, 0x27, 0x1e, 0xd9,
-	0xfc, 0x75, 0x32, 0x34, 0xce, 0x89, 0xc3, 0xec, 0x8b, 0x97, 0xcf, 0xff, 0x9e, 0xd0, 0xe4, 0x1e,
-	0x8a, 0xba, 0xd7, 0x40, 0x6f, 0x40, 0x91, 0x98, 0xba, 0x9c, 0x06, 0x70, 0x17, 0x37, 0x63, 0x63,
-	0xc5, 0xd4, 0xbd, 0x49, 0x14, 0x88, 0x7c, 0x46, 0xaf, 0x42, 0xae, 0x67, 0x8d, 0x46, 0x06, 0xad,
-	0xad, 0x70, 0xeb, 0xcd, 0xd8, 0x09, 0x70, 0xad, 0x76, 0x02, 0x4b, 0x7d, 0x74, 0x08, 0xe5, 0xa1,
-	0xe1, 0x52, 0xd5, 0x35, 0x35, 0xdb, 0x1d, 0x58, 0xd4, 0xad, 0xad, 0x72, 0x0f, 0x4f, 0xc7, 0x79,
-	0x38, 0x30, 0x5c, 0x7a, 0xe2, 0x29, 0xb7, 0x13, 0xb8, 0x34, 0x0c, 0x0b, 0x98, 0x3f, 0xeb, 0xf4,
-	0x94, 0x38, 0xbe, 0xc3, 0x5a, 0xe9, 0x72, 0x7f, 0x47, 0x4c, 0xdb, 0xb3, 0x67, 0xfe, 0xac, 0xb0,
-	0x00, 0xfd, 0x0c, 0xae, 0x0d, 0x2d, 0x4d, 0xf7, 0xdd, 0xa9, 0xbd, 0xc1, 0xd8, 0x3c, 0xab, 0x95,
-	0xb9, 0xd3, 0xdb, 0xb1, 0x83, 0xb4, 0x34, 0xdd, 0x73, 0xd1, 0x64, 0x06, 0xed, 0x04, 0x5e, 0x1b,
-	0x4e, 0x0b, 0xd1, 0xfb, 0xb0, 0xae, 0xd9, 0xf6, 0x70, 0x32, 0xed, 0xbd, 0xc2, 0xbd, 0xdf, 0x89,
-	0xf3, 0xbe, 0xc7, 0x6c, 0xa6, 0xdd, 0x23, 0x6d, 0x46, 0xda, 0xc8, 0x43, 0xf6, 0x5c, 0x1b, 0x8e,
-	0x89, 0xf2, 0x3f, 0xb0, 0x12, 0xda, 0xea, 0xa8, 0x06, 0xf9, 0x11, 0x71, 0x5d, 0xad, 0x4f, 0x38,
-	0x32, 0x14, 0xb1, 0xd7, 0x54, 0xca, 0xb0, 0x1a, 0xde, 0xde, 0xca, 0xc8, 0x37, 0x64, 0x1b, 0x97,
-	0x19, 0x9e, 0x13, 0xc7, 0x65, 0xbb, 0x55, 0x1a, 0xca, 0x26, 0xba, 0x05, 0x25, 0x1e, 0x3e, 0xaa,
-	0xd7, 0xcf, 0xd0, 0x23, 0x83, 0x57, 0xb9, 0xf0, 0x91, 0x54, 0xda, 0x82, 0x15, 0x7b, 0xd7, 0xf6,
-	0x55, 0xd2, 0x5c, 0x05, 0xec, 0x5d, 0x5b, 0x2a, 0x28, 0xff, 0x0f, 0xd5, 0xe9, 0xdd, 0x8e, 0xaa,
-	0x90, 0x3e, 0x23, 0x13, 0xf9, 0x3e, 0xf6, 0x88, 0xd6, 0xe5, 0xb4, 0xf8, 0x3b, 0x8a, 0x58, 0xce,
-	0xf1, 0xef, 0x29, 0xdf, 0xd8, 0xdf, 0xe6, 0xe8, 0x55, 0xc8, 0x30, 0x40, 0x95, 0x00, 0x58, 0xdf,
-	0x16, 0xb0, 0xb9, 0xed, 0xc1, 0xe6, 0x76, 0xc7, 0x43, 0xdb, 0x46, 0xe1, 0x8b, 0xaf, 0xb6, 0x12,
-	0x9f, 0x7d, 0xbd, 0x95, 0xc4, 0xdc, 0x02, 0x5d, 0x67, 0xbb, 0x52, 0x33, 0x4c, 0xd5, 0xd0, 0xe5,
-	0x7b, 0xf2, 0xbc, 0xbd, 0xaf, 0xa3, 0x07, 0x50, 0xed, 0x59, 0xa6, 0x4b, 0x4c, 0x77, 0xec, 0xaa,
-	0x02, 0xcd, 0x25, 0xec, 0xcd, 0xee, 0x9a, 0xa6, 0xa7, 0x78, 0xcc, 0xf5, 0x70, 0xa5, 0x17, 0x15,
-	0xa0, 0xfb, 0x00, 0xe7, 0xda, 0xd0, 0xd0, 0x35, 0x6a, 0x39, 0x6e, 0x2d, 0x73, 0x33, 0x3d, 0xd7,
-	0xcd, 0x23, 0x4f, 0xe5, 0xa1, 0xad, 0x6b, 0x94, 0x34, 0x32, 0x6c, 0xb4, 0x38, 0x64, 0x89, 0x9e,
-	0x81, 0x8a, 0x66, 0xdb, 0xaa, 0x4b, 0x35, 0x4a, 0xd4, 0xee, 0x84, 0x12, 0x97, 0x83, 0xe1, 0x2a,
-	0x2e, 0x69, 0xb6, 0x7d, 0xc2, 0xa4, 0x0d, 0x26, 0x44, 0x4f, 0x43, 0x99, 0x01, 0x9f, 0xa1, 0x0d,
-	0xd5, 0x01, 0x31, 0xfa, 0x03, 0xca, 0x41, 0x2f, 0x8d, 0x4b, 0x52, 0xda, 0xe6, 0x42, 0x74, 0x1b,
-	0xaa, 0x7d, 0x62, 0x12, 0xd7, 0x70, 0x55, 0x8e, 0x34, 0xee, 0x78, 0xc4, 0x01, 0xae, 0x88, 0x2b,
-	0x52, 0xde, 0x94, 0x62, 0x45, 0xf7, 0x63, 0x86, 0xe3, 0x23, 0x42, 0x90, 0xd1, 0x35, 0xaa, 0xf1,
-	0x35, 0x5f, 0xc5, 0xfc, 0x99, 0xc9, 0x6c, 0x8d, 0x0e, 0xe4, 0x4a, 0xf2, 0x67, 0xb4, 0x01, 0x39,
-	0x39, 0x82, 0x34, 0x1f, 0x81, 0x6c, 0xb1, 0xcf, 0x6b, 0x3b, 0xd6, 0x39, 0xe1, 0x09, 0xa1, 0x80,
-	0x45, 0x43, 0xf9, 0x43, 0x0a, 0xd6, 0x66, 0x90, 0x94, 0xf9, 0x1d, 0x68, 0xee, 0xc0, 0x7b, 0x17,
-	0x7b, 0x46, 0xaf, 0x30, 0xbf, 0x9a, 0x4e, 0x1c, 0x99, 0xc1, 0x6a, 0xe1, 0xd5, 0x14, 0x89, 0xbb,
-	0xcd, 0xfb, 0xe5, 0x2a, 0x4a, 0x6d, 0x74, 0x04, 0xd5, 0xa1, 0xe6, 0x52, 0x55, 0x20, 0x93, 0x1a,
-	0xca, 0x66, 0xb3, 0x78, 0x7c, 0xa0, 0x79, 0x58, 0xc6, 0xf6, 0x85, 0x74, 0x54, 0x1e, 0x46, 0xa4,
-	0x08, 0xc3, 0x7a, 0x77, 0xf2, 0x89, 0x66, 0x52, 0xc3, 0x24, 0xea, 0xcc, 0x47, 0xbe, 0x3e, 0xe3,
-	0xb4, 0x75, 0x6e, 0xe8, 0xc4, 0xec, 0x79, 0x5f, 0xf7, 0x9a, 0x6f, 0xfc, 0x28, 0xf8, 0xcc, 0x4d,
-	0x40, 0x41, 0xec, 0xc9, 0x5d, 0xcb, 0xbe, 0x34, 0xf3, 0xb8, 0x3e, 0x13, 0xde, 0x7b, 0xe6, 0x04,
-	0xaf, 0xf9, 0xfa, 0xef, 0x48, 0x75, 0x05, 0x43, 0x39, 0x9a, 0x50, 0x50, 0x19, 0x52, 0xf4, 0x42,
-	0xae, 0x62, 0x8a, 0x5e, 0xa0, 0xff, 0x85, 0x0c, 0x5b, 0x29, 0xbe, 0x82, 0xe5, 0x39, 0xd9, 0x5c,
-	0xda, 0x75, 0x26, 0x36, 0xc1, 0x5c, 0x53, 0x51, 0xfc, 0xdd, 0xe7, 0x27, 0x99, 0x69, 0xaf, 0xca,
-	0x6d, 0xa8, 0x4c, 0x65, 0x91, 0x50, 0x10, 0x24, 0xc3, 0x41, 0xa0, 0x54, 0xa0, 0x14, 0x49, 0x19,
-	0xca, 0x06, 0xac, 0xcf, 0xcb, 0x00, 0xca, 0xc0, 0x97, 0x47, 0x90, 0x1c, 0xbd, 0x0c, 0x05, 0x3f,
-	0x05, 0x88, 0xdd, 0x3f, 0xbb, 0xe0, 0x9e, 0x32, 0xf6, 0x55, 0xd9, 0xb6, 0x67, 0xdb, 0x88, 0x07,
-	0x55, 0x8a, 0x0f, 0x3c, 0xaf, 0xd9, 0x76, 0x5b, 0x73, 0x07, 0xca, 0x07, 0x50, 0x8b, 0x83, 0xf7,
-	0xa9, 0x69, 0x64, 0xfc, 0x58, 0xde, 0x80, 0xdc, 0xa9, 0xe5, 0x8c, 0x34, 0xca, 0x9d, 0x95, 0xb0,
-	0x6c, 0xb1, 0x18, 0x17, 0x50, 0x9f, 0xe6, 0x62, 0xd1, 0x50, 0x54, 0xb8, 0x1e, 0x0b, 0xf1, 0xcc,
-	0xc4, 0x30, 0x75, 0x22, 0xd6, 0xb3, 0x84, 0x45, 0x23, 0x70, 0x24, 0x06, 0x2b, 0x1a, 0xec, 0xb5,
-	0x2e, 0x9f, 0x2b, 0xf7, 0x5f, 0xc4, 0xb2, 0xa5, 0xfc, 0xb5, 0x00, 0x05, 0x4c, 0x5c, 0x9b, 0x45,
-	0x04, 0x6a, 0x40, 0x91, 0x5c, 0xf4, 0x88, 0x20, 0x5f, 0xc9, 0x58, 0xf2, 0x22, 0xb4, 0x5b, 0x9e,
-	0x26, 0x63, 0x0e, 0xbe, 0x19, 0x7a, 0x49, 0x12, 0xcc, 0x78, 0xae, 0x28, 0xcd, 0xc3, 0x0c, 0xf3,
-	0x15, 0x8f, 0x61, 0xa6, 0x63, 0xc9, 0x82, 0xb0, 0x9a, 0xa2, 0x98, 0x2f, 0x49, 0x8a, 0x99, 0x59,
-	0xf0, 0xb2, 0x08, 0xc7, 0x6c, 0x46, 0x38, 0x66, 0x76, 0xc1, 0x34, 0x63, 0x48, 0x66, 0x33, 0x42,
-	0x32, 0x73, 0x0b, 0x9c, 0xc4, 0xb0, 0xcc, 0x57, 0x3c, 0x96, 0x99, 0x5f, 0x30, 0xed, 0x29, 0x9a,
-	0x79, 0x3f, 0x4a, 0x33, 0x05, 0x45, 0xbc, 0x15, 0x6b, 0x1d, 0xcb, 0x33, 0x7f, 0x10, 0xe2, 0x99,
-	0xc5, 0x58, 0x92, 0x27, 0x9c, 0xcc, 0x21, 0x9a, 0xcd, 0x08, 0xd1, 0x84, 0x05, 0x6b, 0x10, 0xc3,
-	0x34, 0xdf, 0x0c, 0x33, 0xcd, 0x95, 0x58, 0xb2, 0x2a, 0x83, 0x66, 0x1e, 0xd5, 0xbc, 0xeb, 0x53,
-	0xcd, 0xd5, 0x58, 0xae, 0x2c, 0xe7, 0x30, 0xcd, 0x35, 0x8f, 0x66, 0xb8, 0xa6, 0xe0, 0x86, 0xcf,
-	0xc4, 0xba, 0x58, 0x40, 0x36, 0x8f, 0x66, 0xc8, 0x66, 0x79, 0x81, 0xc3, 0x05, 0x6c, 0xf3, 0xe7,
-	0xf3, 0xd9, 0x66, 0x3c, 0x1f, 0x94, 0xc3, 0x5c, 0x8e, 0x6e, 0xaa, 0x31, 0x74, 0xb3, 0xca, 0xdd,
-	0x3f, 0x17, 0xeb, 0xfe, 0xea, 0x7c, 0xf3, 0x36, 0xcb, 0xd5, 0x53, 0xc0, 0xc1, 0xa0, 0x8a, 0x38,
-	0x8e, 0xe5, 0x48, 0x2a, 0x27, 0x1a, 0xca, 0xb3, 0x8c, 0x3d, 0x04, 0x20, 0x71, 0x09, 0x37, 0xe5,
-	0x29, 0x21, 0x04, 0x0c, 0xca, 0x6f, 0x93, 0x81, 0x2d, 0x4f, 0xb8, 0x61, 0xe6, 0x51, 0x94, 0xcc,
-	0x23, 0x44, 0x59, 0x53, 0x51, 0xca, 0xba, 0x05, 0x2b, 0x0c, 0xea, 0xa7, 0xd8, 0xa8, 0x66, 0x7b,
-	0x6c, 0x14, 0xdd, 0x81, 0x35, 0x4e, 0x08, 0x04, 0xb1, 0x95, 0xf8, 0x9e, 0xe1, 0x69, 0xaa, 0xc2,
-	0x3a, 0x44, 0x70, 0x0a, 0xa0, 0x7f, 0x01, 0xae, 0x85, 0x74, 0xfd, 0x14, 0x22, 0x28, 0x58, 0xd5,
-	0xd7, 0xde, 0x93, 0xb9, 0xe4, 0x9d, 0x60, 0x81, 0x02, 0xa6, 0x8b, 0x20, 0xd3, 0xb3, 0x74, 0x22,
-	0x01, 0x9e, 0x3f, 0x33, 0xf6, 0x3b, 0xb4, 0xfa, 0x12, 0xc6, 0xd9, 0x23, 0xd3, 0xf2, 0x51, 0xb0,
-	0x28, 0x40, 0x4e, 0xf9, 0x7d, 0x2a, 0xf0, 0x17, 0x90, 0xdf, 0x79, 0x3c, 0x35, 0xf9, 0x9f, 0xe1,
-	0xa9, 0xa9, 0xef, 0xcc, 0x53, 0xc3, 0x09, 0x36, 0x1d, 0x49, 0xb0, 0xa8, 0x05, 0x65, 0xc7, 0x1a,
-	0x0e, 0x59, 0xb7, 0x1c, 0x6d, 0x26, 0x0e, 0x29, 0x85, 0x9a, 0x1c, 0x6b, 0xc9, 0x09, 0x37, 0xd1,
-	0x5d, 0xb8, 0xee, 0x51, 0xd7, 0xae, 0x63, 0xe8, 0x7d, 0xa2, 0xb2, 0x40, 0x88, 0x70, 0xe2, 0x0d,
-	0xa9, 0xd0, 0xe0, 0xfd, 0xf7, 0x34, 0xaa, 0x71, 0x72, 0xac, 0xfc, 0x23, 0x19, 0xc4, 0x98, 0x4f,
-	0x66, 0xbf, 0xdb, 0x37, 0x09, 0xf2, 0x75, 0x96, 0x47, 0x8c, 0xcc, 0xd7, 0xf2, 0x34, 0x93, 0xe3,
-	0xc3, 0x88, 0x9e, 0x66, 0xf2, 0x22, 0x83, 0xf3, 0x06, 0x7a, 0x15, 0x8a, 0xbc, 0x02, 0xa5, 0x5a,
-	0xb6, 0x2b, 0x21, 0xff, 0x89, 0xf0, 0x32, 0x88, 0x42, 0xd3, 0xf6, 0x31, 0xd3, 0x39, 0xb2, 0x5d,
-	0x5c, 0xb0, 0xe5, 0x53, 0x88, 0x8a, 0x14, 0x23, 0xb4, 0xfa, 0x06, 0x14, 0xd9, 0xe8, 0x5d, 0x5b,
-	0xeb, 0x11, 0x0e, 0xdf, 0x45, 0x1c, 0x08, 0x94, 0x3f, 0x26, 0x01, 0xcd, 0x66, 0x10, 0xd4, 0x86,
-	0x1c, 0x39, 0x27, 0x26, 0x65, 0x81, 0xc3, 0xbe, 0xf8, 0xc6, 0x1c, 0xd2, 0x4a, 0x4c, 0xda, 0xa8,
-	0xb1, 0xef, 0xfc, 0xb7, 0xaf, 0xb6, 0xaa, 0x42, 0xfb, 0x79, 0x6b, 0x64, 0x50, 0x32, 0xb2, 0xe9,
-	0x04, 0x4b, 0x7b, 0x74, 0x06, 0x37, 0x66, 0x89, 0xab, 0xea, 0xc8, 0x57, 0x7a, 0x11, 0x75, 0x3b,
-	0x3e, 0x30, 0x25, 0x7b, 0xf5, 0x06, 0x89, 0xeb, 0x33, 0xbc, 0xd6, 0xeb, 0x72, 0x95, 0x53, 0xa8,
-	0xc5, 0xd9, 0xa1, 0x8d, 0x08, 0x0c, 0xb1, 0x34, 0xcb, 0x9b, 0xe8, 0x19, 0x48, 0x59, 0x67, 0x92,
-	0xc8, 0xcc, 0x65, 0xd2, 0xed, 0x04, 0x4e, 0x59, 0x67, 0x0d, 0x80, 0x82, 0x37, 0x6a, 0xe5, 0xcf,
-	0x29, 0xc6, 0x68, 0x23, 0x29, 0x73, 0x6e, 0xc4, 0x78, 0xc0, 0x94, 0x0a, 0x1d, 0x89, 0x96, 0x8b,
-	0xa2, 0x4d, 0x80, 0xbe, 0xe6, 0xaa, 0x1f, 0x6b, 0x26, 0x25, 0xba, 0x0c, 0xa5, 0x90, 0x04, 0xd5,
-	0xa1, 0xc0, 0x5a, 0x63, 0x97, 0xe8, 0xf2, 0x20, 0xe7, 0xb7, 0x43, 0x1f, 0x2f, 0xff, 0x3d, 0x3f,
-	0x5e, 0x24, 0x76, 0x0a, 0x53, 0xb1, 0x13, 0x62, 0x9b, 0xc5, 0x30, 0xdb, 0x64, 0x63, 0xb3, 0x1d,
-	0xc3, 0x72, 0x0c, 0x3a, 0xe1, 0x01, 0x97, 0xc6, 0x7e, 0x1b, 0xdd, 0x82, 0xd2, 0x88, 0x8c, 0x6c,
-	0xcb, 0x1a, 0xaa, 0xe2, 0x6b, 0xac, 0x70, 0xd3, 0x55, 0x29, 0x6c, 0xf1, 0xdc, 0xf0, 0xab, 0x10,
-	0xac, 0x05, 0xa7, 0x8a, 0xff, 0xba, 0x05, 0x56, 0xbe, 0xe6, 0xa5, 0x8d, 0x28, 0x29, 0x42, 0x27,
-	0xb0, 0xe6, 0xc3, 0xaa, 0x3a, 0xe6, 0x70, 0xeb, 0xed, 0xd2, 0x65, 0x71, 0xb9, 0x7a, 0x1e, 0x15,
-	0xbb, 0xe8, 0xc7, 0xf0, 0xf8, 0x54, 0xca, 0xf0, 0x5d, 0xa7, 0x96, 0xcc, 0x1c, 0x8f, 0x45, 0x33,
-	0x87, 0xe7, 0x39, 0x58, 0xab, 0xf4, 0xf7, 0x5c, 0x2b, 0x0c, 0x8f, 0x45, 0xd2, 0x84, 0x3f, 0xc2,
-	0xe5, 0xb2, 0xc5, 0xb5, 0x70, 0xb6, 0x90, 0xa3, 0x53, 0xf6, 0xd9, 0x89, 0x38, 0x4c, 0x1b, 0xe7,
-	0x46, 0xd4, 0x2d, 0x28, 0x39, 0x84, 0x6a, 0x86, 0xa9, 0x46, 0x0a, 0x17, 0xab, 0x42, 0x28, 0x98,
-	0x80, 0x72, 0x0c, 0x8f, 0xcd, 0xa5, 0x8f, 0xe8, 0xff, 0xa0, 0x18, 0x30, 0xcf, 0x64, 0x4c, 0x0d,
-	0xc0, 0x3f, 0x92, 0x06, 0xba, 0xca, 0xef, 0x92, 0x81, 0xcb, 0xe8, 0x21, 0xb7, 0x05, 0x39, 0x87,
-	0xb8, 0xe3, 0xa1, 0x38, 0x76, 0x96, 0x77, 0x5f, 0x58, 0x8e, 0x78, 0x32, 0xe9, 0x78, 0x48, 0xb1,
-	0x34, 0x56, 0xde, 0x87, 0x9c, 0x90, 0xa0, 0x15, 0xc8, 0x3f, 0x3c, 0x7c, 0x70, 0x78, 0xf4, 0xee,
-	0x61, 0x35, 0x81, 0x00, 0x72, 0x7b, 0xcd, 0x66, 0xeb, 0xb8, 0x53, 0x4d, 0xa2, 0x22, 0x64, 0xf7,
-	0x1a, 0x47, 0xb8, 0x53, 0x4d, 0x31, 0x31, 0x6e, 0xbd, 0xdd, 0x6a, 0x76, 0xaa, 0x69, 0xb4, 0x06,
-	0x25, 0xf1, 0xac, 0xde, 0x3f, 0xc2, 0xef, 0xec, 0x75, 0xaa, 0x99, 0x90, 0xe8, 0xa4, 0x75, 0x78,
-	0xaf, 0x85, 0xab, 0x59, 0xe5, 0x45, 0x76, 0xae, 0x8d, 0xa1, 0xaa, 0xc1, 0x09, 0x36, 0x19, 0x3a,
-	0xc1, 0x2a, 0xbf, 0x49, 0x41, 0x3d, 0x9e, 0x7f, 0xa2, 0xb7, 0xa7, 0x26, 0xbe, 0x7b, 0x05, 0xf2,
-	0x3a, 0x35, 0x7b, 0xf4, 0x34, 0x94, 0x1d, 0x72, 0x4a, 0x68, 0x6f, 0x20, 0xf8, 0xb0, 0xc8, 0x45,
-	0x25, 0x5c, 0x92, 0x52, 0x6e, 0xe4, 0x0a, 0xb5, 0x0f, 0x49, 0x8f, 0xaa, 0x02, 0xde, 0x44, 0x20,
-	0x17, 0x99, 0x1a, 0x93, 0x9e, 0x08, 0xa1, 0xf2, 0xc1, 0x95, 0xd6, 0xb2, 0x08, 0x59, 0xdc, 0xea,
-	0xe0, 0x9f, 0x54, 0xd3, 0x08, 0x41, 0x99, 0x3f, 0xaa, 0x27, 0x87, 0x7b, 0xc7, 0x27, 0xed, 0x23,
-	0xb6, 0x96, 0xd7, 0xa0, 0xe2, 0xad, 0xa5, 0x27, 0xcc, 0x2a, 0xff, 0x4a, 0x42, 0x65, 0x6a, 0xd3,
-	0xa1, 0x5d, 0xc8, 0x8a, 0x33, 0x55, 0xdc, 0x9f, 0x1e, 0x8e, 0x19, 0x72, 0x07, 0x08, 0x55, 0xf4,
-	0x3a, 0x14, 0x88, 0xac, 0x38, 0xcd, 0xdb, 0xdc, 0xa2, 0x52, 0xe6, 0xd5, 0xa4, 0xa4, 0xa9, 0x6f,
-	0x81, 0xde, 0x80, 0xa2, 0x8f, 0x1e, 0xf2, 0x20, 0xff, 0xd4, 0xac, 0xb9, 0x8f, 0x3b, 0xd2, 0x3e,
-	0xb0, 0x41, 0x77, 0x03, 0x62, 0x9e, 0x99, 0x3d, 0xc9, 0x49, 0x73, 0xa1, 0x20, 0x8d, 0x3d, 0x7d,
-	0xa5, 0x09, 0x2b, 0xa1, 0xf9, 0xa0, 0x27, 0xa0, 0x38, 0xd2, 0x2e, 0x24, 0xc1, 0x13, 0x65, 0xa4,
-	0xc2, 0x48, 0xbb, 0x10, 0xf5, 0xce, 0xc7, 0x21, 0xcf, 0x3a, 0xfb, 0x9a, 0x40, 0xb0, 0x34, 0xce,
-	0x8d, 0xb4, 0x8b, 0xb7, 0x34, 0x57, 0x79, 0x0f, 0xca, 0xd1, 0x2a, 0x1e, 0x8b, 0x44, 0xc7, 0x1a,
-	0x9b, 0x3a, 0xf7, 0x91, 0xc5, 0xa2, 0x81, 0x5e, 0x86, 0xec, 0xb9, 0x45, 0x7d, 0x86, 0x32, 0xbb,
-	0x65, 0x1f, 0x59, 0x94, 0x84, 0xaa, 0x80, 0x42, 0x5b, 0xf9, 0x04, 0xb2, 0x1c, 0xd0, 0x18, 0x90,
-	0xf0, 0x52, 0x9a, 0x3c, 0x94, 0xb0, 0x67, 0xf4, 0x1e, 0x80, 0x46, 0xa9, 0x63, 0x74, 0xc7, 0x81,
-	0xe3, 0xad, 0xf9, 0x80, 0xb8, 0xe7, 0xe9, 0x35, 0x6e, 0x48, 0x64, 0x5c, 0x0f, 0x4c, 0x43, 0xe8,
-	0x18, 0x72, 0xa8, 0x1c, 0x42, 0x39, 0x6a, 0x1b, 0x2e, 0xa2, 0xaf, 0xce, 0x29, 0xa2, 0xfb, 0xb4,
-	0xd3, 0x27, 0xad, 0x69, 0x51, 0x7b, 0xe5, 0x0d, 0xe5, 0xd3, 0x24, 0x14, 0x3a, 0x17, 0x32, 0xac,
-	0x63, 0x2a, 0x76, 0x81, 0x69, 0x2a, 0x5c, 0x9f, 0x12, 0x25, 0xc0, 0xb4, 0x5f, 0x58, 0x7c, 0xd3,
-	0xdf, 0xb8, 0x99, 0x65, 0x2b, 0x08, 0x5e, 0x99, 0x56, 0x82, 0xd5, 0x6b, 0x50, 0xf4, 0xa3, 0x8a,
-	0x9d, 0xee, 0x34, 0x5d, 0x77, 0x88, 0xeb, 0xca, 0xb9, 0x79, 0x4d, 0x5e, 0x45, 0xb6, 0x3e, 0x96,
-	0x15, 0xb0, 0x34, 0x16, 0x0d, 0x45, 0x87, 0xca, 0x54, 0x2a, 0x44, 0xaf, 0x41, 0xde, 0x1e, 0x77,
-	0x55, 0x6f, 0x79, 0xa6, 0x36, 0x8f, 0xc7, 0xb3, 0xc7, 0xdd, 0xa1, 0xd1, 0x7b, 0x40, 0x26, 0xde,
-	0x60, 0xec, 0x71, 0xf7, 0x81, 0x58, 0x45, 0xf1, 0x96, 0x54, 0xf8, 0x2d, 0xe7, 0x50, 0xf0, 0x82,
-	0x02, 0xfd, 0x30, 0xbc, 0x4f, 0xbc, 0xdf, 0x10, 0xb1, 0xe9, 0x59, 0xba, 0x0f, 0x6d, 0x93, 0x3b,
-	0xb0, 0xe6, 0x1a, 0x7d, 0x93, 0xe8, 0x6a, 0x70, 0xbe, 0xe4, 0x6f, 0x2b, 0xe0, 0x8a, 0xe8, 0x38,
-	0xf0, 0x0e, 0x97, 0xca, 0x3f, 0x93, 0x50, 0xf0, 0x36, 0x2c, 0x7a, 0x31, 0x14, 0x77, 0xe5, 0x39,
-	0xd5, 0x32, 0x4f, 0x31, 0xa8, 0xe1, 0x46, 0xc7, 0x9a, 0xba, 0xfa, 0x58, 0xe3, 0x2a, 0xfa, 0xde,
-	0x5f, 0x98, 0xcc, 0x95, 0xff, 0xc2, 0x3c, 0x0f, 0x88, 0x5a, 0x54, 0x1b, 0xaa, 0xe7, 0x16, 0x35,
-	0xcc, 0xbe, 0x2a, 0x16, 0x5b, 0xb0, 0xb4, 0x2a, 0xef, 0x79, 0xc4, 0x3b, 0x8e, 0xf9, 0xba, 0xbf,
-	0x09, 0xa5, 0x48, 0xae, 0x67, 0xd1, 0xa7, 0x7b, 0xe5, 0x80, 0x94, 0xae, 0xb1, 0x23, 0xbf, 0xee,
-	0xb8, 0x91, 0x7f, 0x54, 0x25, 0x0c, 0xba, 0xe3, 0x7a, 0x3f, 0xa0, 0x7e, 0x91, 0x84, 0x82, 0x9f,
-	0x5d, 0xaf, 0x5a, 0xd4, 0xdd, 0x80, 0x9c, 0x4c, 0x20, 0xa2, 0xaa, 0x2b, 0x5b, 0xfe, 0x4f, 0x8a,
-	0x4c, 0xe8, 0x27, 0x45, 0x1d, 0x0a, 0x23, 0x42, 0x35, 0x4e, 0x31, 0xc4, 0x99, 0xd4, 0x6f, 0xdf,
-	0xb9, 0x0b, 0x2b, 0xa1, 0xfa, 0x3a, 0xdb, 0xbb, 0x87, 0xad, 0x77, 0xab, 0x89, 0x7a, 0xfe, 0xd3,
-	0xcf, 0x6f, 0xa6, 0x0f, 0xc9, 0xc7, 0x2c, 0xea, 0x71, 0xab, 0xd9, 0x6e, 0x35, 0x1f, 0x54, 0x93,
-	0xf5, 0x95, 0x4f, 0x3f, 0xbf, 0x99, 0xc7, 0x84, 0x97, 0xe9, 0xee, 0xb4, 0x61, 0x35, 0xfc, 0x5d,
-	0xa3, 0x39, 0x08, 0x41, 0xf9, 0xde, 0xc3, 0xe3, 0x83, 0xfd, 0xe6, 0x5e, 0xa7, 0xa5, 0x3e, 0x3a,
-	0xea, 0xb4, 0xaa, 0x49, 0xf4, 0x38, 0x5c, 0x3b, 0xd8, 0x7f, 0xab, 0xdd, 0x51, 0x9b, 0x07, 0xfb,
-	0xad, 0xc3, 0x8e, 0xba, 0xd7, 0xe9, 0xec, 0x35, 0x1f, 0x54, 0x53, 0xbb, 0xbf, 0x04, 0xa8, 0xec,
-	0x35, 0x9a, 0xfb, 0x2c, 0x7f, 0x1a, 0x3d, 0x4d, 0x96, 0x41, 0x33, 0xbc, 0x46, 0x73, 0xe9, 0x45,
-	0x82, 0xfa, 0xe5, 0x55, 0x60, 0x74, 0x1f, 0xb2, 0xbc, 0x7c, 0x83, 0x2e, 0xbf, 0x59, 0x50, 0x5f,
-	0x50, 0x16, 0x66, 0x83, 0xe1, 0x1b, 0xec, 0xd2, 0xab, 0x06, 0xf5, 0xcb, 0xab, 0xc4, 0x08, 0x43,
-	0x31, 0xa8, 0xbf, 0x2c, 0xbe, 0x7a, 0x50, 0x5f, 0xa2, 0x72, 0xcc, 0x7c, 0x06, 0x87, 0x95, 0xc5,
-	0xbf, 0xe2, 0xeb, 0x4b, 0x40, 0x20, 0x3a, 0x80, 0xbc, 0x77, 0xbe, 0x5c, 0x74, 0x39, 0xa0, 0xbe,
-	0xb0, 0xaa, 0xcb, 0x3e, 0x81, 0xa8, 0x6e, 0x5c, 0x7e, 0xd3, 0xa1, 0xbe, 0xa0, 0x44, 0x8d, 0xf6,
-	0x21, 0x27, 0xd9, 0xf2, 0x82, 0x1f, 0xfe, 0xf5, 0x45, 0x55, 0x5a, 0xb6, 0x68, 0x41, 0xe1, 0x6a,
-	0xf1, 0xfd, 0x8d, 0xfa, 0x12, 0xd5, 0x77, 0xf4, 0x10, 0x20, 0x54, 0xca, 0x58, 0xe2, 0x62, 0x46,
-	0x7d, 0x99, 0xaa, 0x3a, 0x3a, 0x82, 0x82, 0x7f, 0x08, 0x5b, 0x78, 0x4d, 0xa2, 0xbe, 0xb8, 0xbc,
-	0x8d, 0xde, 0x87, 0x52, 0xf4, 0xa4, 0xb0, 0xdc, 0xe5, 0x87, 0xfa, 0x92, 0x75, 0x6b, 0xe6, 0x3f,
-	0x7a, 0x6c, 0x58, 0xee, 0x32, 0x44, 0x7d, 0xc9, 0x32, 0x36, 0xfa, 0x10, 0xd6, 0x66, 0x69, 0xfd,
-	0xf2, 0x77, 0x23, 0xea, 0x57, 0x28, 0x6c, 0xa3, 0x11, 0xa0, 0x39, 0xc7, 0x81, 0x2b, 0x5c, 0x95,
-	0xa8, 0x5f, 0xa5, 0xce, 0xdd, 0x68, 0x7d, 0xf1, 0xcd, 0x66, 0xf2, 0xcb, 0x6f, 0x36, 0x93, 0x7f,
-	0xf9, 0x66, 0x33, 0xf9, 0xd9, 0xb7, 0x9b, 0x89, 0x2f, 0xbf, 0xdd, 0x4c, 0xfc, 0xe9, 0xdb, 0xcd,
-	0xc4, 0x4f, 0x9f, 0xeb, 0x1b, 0x74, 0x30, 0xee, 0x6e, 0xf7, 0xac, 0xd1, 0x4e, 0xf8, 0x8a, 0xd7,
-	0xbc, 0x6b, 0x67, 0xdd, 0x1c, 0x4f, 0x75, 0x2f, 0xfd, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x29, 0xa7,
-	0xcd, 0xe6, 0x96, 0x26, 0x00, 0x00,
+// FraudProof proves a state transition of a block fraudulent: applied to the
+// state witnesses of the app hash before it, the fraudulent request, the first
+// one whose app hash mismatches, results in another app hash than the expected
+// valid one.
+type FraudProof struct {
+	BlockHeight          int64  `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	PreStateAppHash      []byte `protobuf:"bytes,2,opt,name=pre_state_app_hash,json=preStateAppHash,proto3" json:"pre_state_app_hash,omitempty"`
+	ExpectedValidAppHash []byte `protobuf:"bytes,3,opt,name=expected_valid_app_hash,json=expectedValidAppHash,proto3" json:"expected_valid_app_hash,omitempty"`
+	// The state witnesses of the stores, by store name.
+	StateWitness map[string]*StateWitness `protobuf:"bytes,4,rep,name=state_witness,json=stateWitness,proto3" json:"state_witness,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The fraudulent request, only one of them is set.
+	FraudulentBeginBlock *RequestBeginBlock `protobuf:"bytes,5,opt,name=fraudulent_begin_block,json=fraudulentBeginBlock,proto3" json:"fraudulent_begin_block,omitempty"`
+	FraudulentDeliverTx  *RequestDeliverTx  `protobuf:"bytes,6,opt,name=fraudulent_deliver_tx,json=fraudulentDeliverTx,proto3" json:"fraudulent_deliver_tx,omitempty"`
+	FraudulentEndBlock   *RequestEndBlock   `protobuf:"bytes,7,opt,name=fraudulent_end_block,json=fraudulentEndBlock,proto3" json:"fraudulent_end_block,omitempty"`
+}
+
+func (m *FraudProof) Reset()         { *m = FraudProof{} }
+func (m *FraudProof) String() string { return proto.CompactTextString(m) }
+func (*FraudProof) ProtoMessage()    {}
+func (*FraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{53}
+}
+func (m *FraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FraudProof.Merge(m, src)
+}
+func (m *FraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *FraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_FraudProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FraudProof proto.InternalMessageInfo
+
+func (m *FraudProof) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *FraudProof) GetPreStateAppHash() []byte {
+	if m != nil {
+		return m.PreStateAppHash
+	}
+	return nil
+}
+
+func (m *FraudProof) GetExpectedValidAppHash() []byte {
+	if m != nil {
+		return m.ExpectedValidAppHash
+	}
+	return nil
+}
+
+func (m *FraudProof) GetStateWitness() map[string]*StateWitness {
+	if m != nil {
+		return m.StateWitness
+	}
+	return nil
+}
+
+func (m *FraudProof) GetFraudulentBeginBlock() *RequestBeginBlock {
+	if m != nil {
+		return m.FraudulentBeginBlock
+	}
+	return nil
+}
+
+func (m *FraudProof) GetFraudulentDeliverTx() *RequestDeliverTx {
+	if m != nil {
+		return m.FraudulentDeliverTx
+	}
+	return nil
+}
+
+func (m *FraudProof) GetFraudulentEndBlock() *RequestEndBlock {
+	if m != nil {
+		return m.FraudulentEndBlock
+	}
+	return nil
+}
+
+// StateWitness is the witness data of a store, with the proof of the root hash
+// of the store in the app hash.
+type StateWitness struct {
+	Proof       *crypto.ProofOp `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	RootHash    []byte          `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	WitnessData []*WitnessData  `protobuf:"bytes,3,rep,name=witness_data,json=witnessData,proto3" json:"witness_data,omitempty"`
+}
+
+func (m *StateWitness) Reset()         { *m = StateWitness{} }
+func (m *StateWitness) String() string { return proto.CompactTextString(m) }
+func (*StateWitness) ProtoMessage()    {}
+func (*StateWitness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{54}
+}
+func (m *StateWitness) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateWitness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateWitness.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateWitness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateWitness.Merge(m, src)
+}
+func (m *StateWitness) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateWitness) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateWitness.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateWitness proto.InternalMessageInfo
+
+func (m *StateWitness) GetProof() *crypto.ProofOp {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *StateWitness) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
+	}
+	return nil
+}
+
+func (m *StateWitness) GetWitnessData() []*WitnessData {
+	if m != nil {
+		return m.WitnessData
+	}
+	return nil
+}
+
+// WitnessData is a key and value of a store, with their proofs in the root
+// hash of the store.
+type WitnessData struct {
+	Key    []byte            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value  []byte            `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Proofs []*crypto.ProofOp `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty"`
+}
+
+func (m *WitnessData) Reset()         { *m = WitnessData{} }
+func (m *WitnessData) String() string { return proto.CompactTextString(m) }
+func (*WitnessData) ProtoMessage()    {}
+func (*WitnessData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{55}
+}
+func (m *WitnessData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WitnessData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WitnessData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WitnessData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WitnessData.Merge(m, src)
+}
+func (m *WitnessData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WitnessData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WitnessData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WitnessData proto.InternalMessageInfo
+
+func (m *WitnessData) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *WitnessData) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WitnessData) GetProofs() []*crypto.ProofOp {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
+	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
+	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
+	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
+	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
+	proto.RegisterType((*RequestInfo)(nil), "tendermint.abci.RequestInfo")
+	proto.RegisterType((*RequestSetOption)(nil), "tendermint.abci.RequestSetOption")
+	proto.RegisterType((*RequestInitChain)(nil), "tendermint.abci.RequestInitChain")
+	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
+	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
+	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
+	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
+	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
+	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
+	proto.RegisterType((*RequestListSnapshots)(nil), "tendermint.abci.RequestListSnapshots")
+	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
+	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
+	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestGetAppHash)(nil), "tendermint.abci.RequestGetAppHash")
+	proto.RegisterType((*RequestGenerateFraudProof)(nil), "tendermint.abci.RequestGenerateFraudProof")
+	proto.RegisterType((*RequestVerifyFraudProof)(nil), "tendermint.abci.RequestVerifyFraudProof")
+	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
+	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
+	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
+	proto.RegisterType((*ResponseFlush)(nil), "tendermint.abci.ResponseFlush")
+	proto.RegisterType((*ResponseInfo)(nil), "tendermint.abci.ResponseInfo")
+	proto.RegisterType((*ResponseSetOption)(nil), "tendermint.abci.ResponseSetOption")
+	proto.RegisterType((*ResponseInitChain)(nil), "tendermint.abci.ResponseInitChain")
+	proto.RegisterType((*ResponseQuery)(nil), "tendermint.abci.ResponseQuery")
+	proto.RegisterType((*ResponseBeginBlock)(nil), "tendermint.abci.ResponseBeginBlock")
+	proto.RegisterType((*ConsensusMessageResponse)(nil), "tendermint.abci.ConsensusMessageResponse")
+	proto.RegisterType((*ResponseCheckTx)(nil), "tendermint.abci.ResponseCheckTx")
+	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
+	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
+	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
+	proto.RegisterType((*ResponseListSnapshots)(nil), "tendermint.abci.ResponseListSnapshots")
+	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
+	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
+	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponseGetAppHash)(nil), "tendermint.abci.ResponseGetAppHash")
+	proto.RegisterType((*ResponseGenerateFraudProof)(nil), "tendermint.abci.ResponseGenerateFraudProof")
+	proto.RegisterType((*ResponseVerifyFraudProof)(nil), "tendermint.abci.ResponseVerifyFraudProof")
+	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
+	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
+	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
+	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
+	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
+	proto.RegisterType((*TxResult)(nil), "tendermint.abci.TxResult")
+	proto.RegisterType((*Validator)(nil), "tendermint.abci.Validator")
+	proto.RegisterType((*ValidatorUpdate)(nil), "tendermint.abci.ValidatorUpdate")
+	proto.RegisterType((*KeyRotation)(nil), "tendermint.abci.KeyRotation")
+	proto.RegisterType((*VoteInfo)(nil), "tendermint.abci.VoteInfo")
+	proto.RegisterType((*Evidence)(nil), "tendermint.abci.Evidence")
+	proto.RegisterType((*RollappParams)(nil), "tendermint.abci.RollappParams")
+	proto.RegisterType((*Snapshot)(nil), "tendermint.abci.Snapshot")
+	proto.RegisterType((*FraudProof)(nil), "tendermint.abci.FraudProof")
+	proto.RegisterMapType((map[string]*StateWitness)(nil), "tendermint.abci.FraudProof.StateWitnessEntry")
+	proto.RegisterType((*StateWitness)(nil), "tendermint.abci.StateWitness")
+	proto.RegisterType((*WitnessData)(nil), "tendermint.abci.WitnessData")
+}
+
+func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
+
+var fileDescriptor_252557cfdd89a31a = []byte{
+	// 3726 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5b, 0xcd, 0x8f, 0x23, 0xd7,
+	0x71, 0x1f, 0x7e, 0x0d, 0xc9, 0xe2, 0xc7, 0x90, 0x6f, 0x66, 0x77, 0xb9, 0xd4, 0x6a, 0x77, 0xdd,
+	0x82, 0x15, 0xad, 0x64, 0xcd, 0xda, 0xa3, 0x48, 0x91, 0x6c, 0x27, 0xd6, 0x90, 0xe2, 0x2e, 0xc7,
+	0xbb, 0x9a, 0x19, 0xbf, 0x99, 0x5d, 0xc9, 0x4e, 0xbc, 0xed, 0x26, 0xfb, 0x91, 0x6c, 0x0f, 0xd9,
+	0xdd, 0xee, 0x6e, 0xce, 0x0e, 0x7d, 0x0c, 0x90, 0x8b, 0x0e, 0x81, 0x81, 0x04, 0x41, 0x2e, 0xce,
+	0x39, 0xe7, 0x1c, 0x82, 0x9c, 0x72, 0x0b, 0xe0, 0x20, 0x01, 0xe2, 0x63, 0x0e, 0x81, 0x13, 0x48,
+	0x37, 0xff, 0x01, 0xc9, 0x21, 0x08, 0x10, 0xbc, 0xaf, 0xee, 0xd7, 0x24, 0x9b, 0xe4, 0x58, 0xbe,
+	0xf9, 0xc6, 0x57, 0xaf, 0xaa, 0xfa, 0x7d, 0x56, 0xd5, 0xaf, 0xea, 0x11, 0x5e, 0x09, 0x88, 0x6d,
+	0x12, 0x6f, 0x62, 0xd9, 0xc1, 0x43, 0xa3, 0xd7, 0xb7, 0x1e, 0x06, 0x33, 0x97, 0xf8, 0xfb, 0xae,
+	0xe7, 0x04, 0x0e, 0xda, 0x89, 0x3a, 0xf7, 0x69, 0x67, 0xf3, 0x55, 0x85, 0xbb, 0xef, 0xcd, 0xdc,
+	0xc0, 0x79, 0xe8, 0x7a, 0x8e, 0x33, 0xe0, 0xfc, 0xcd, 0x3b, 0x4a, 0x37, 0xd3, 0xa3, 0x6a, 0x6b,
+	0xde, 0x59, 0x14, 0xbe, 0x20, 0x33, 0xd9, 0xfb, 0xea, 0x82, 0xac, 0x6b, 0x78, 0xc6, 0x44, 0x76,
+	0xdf, 0x1b, 0x3a, 0xce, 0x70, 0x4c, 0x1e, 0xb2, 0x56, 0x6f, 0x3a, 0x78, 0x18, 0x58, 0x13, 0xe2,
+	0x07, 0xc6, 0xc4, 0x15, 0x0c, 0x7b, 0x43, 0x67, 0xe8, 0xb0, 0x9f, 0x0f, 0xe9, 0x2f, 0x41, 0xbd,
+	0x3d, 0x2f, 0x66, 0xd8, 0x33, 0xde, 0xa5, 0xfd, 0x05, 0x40, 0x1e, 0x93, 0x9f, 0x4c, 0x89, 0x1f,
+	0xa0, 0x03, 0xc8, 0x92, 0xfe, 0xc8, 0x69, 0xa4, 0xee, 0xa7, 0xde, 0x28, 0x1d, 0xdc, 0xd9, 0x9f,
+	0x9b, 0xf7, 0xbe, 0xe0, 0xeb, 0xf4, 0x47, 0x4e, 0x77, 0x0b, 0x33, 0x5e, 0xf4, 0x2e, 0xe4, 0x06,
+	0xe3, 0xa9, 0x3f, 0x6a, 0xa4, 0x99, 0xd0, 0xab, 0x49, 0x42, 0x8f, 0x28, 0x53, 0x77, 0x0b, 0x73,
+	0x6e, 0xfa, 0x29, 0xcb, 0x1e, 0x38, 0x8d, 0xcc, 0xea, 0x4f, 0x1d, 0xd9, 0x03, 0xf6, 0x29, 0xca,
+	0x8b, 0x5a, 0x00, 0x3e, 0x09, 0x74, 0xc7, 0x0d, 0x2c, 0xc7, 0x6e, 0x64, 0x99, 0xe4, 0x57, 0x92,
+	0x24, 0xcf, 0x48, 0x70, 0xc2, 0x18, 0xbb, 0x5b, 0xb8, 0xe8, 0xcb, 0x06, 0xd5, 0x61, 0xd9, 0x56,
+	0xa0, 0xf7, 0x47, 0x86, 0x65, 0x37, 0x72, 0xab, 0x75, 0x1c, 0xd9, 0x56, 0xd0, 0xa6, 0x8c, 0x54,
+	0x87, 0x25, 0x1b, 0x74, 0xca, 0x3f, 0x99, 0x12, 0x6f, 0xd6, 0xd8, 0x5e, 0x3d, 0xe5, 0xef, 0x51,
+	0x26, 0x3a, 0x65, 0xc6, 0x8d, 0x3a, 0x50, 0xea, 0x91, 0xa1, 0x65, 0xeb, 0xbd, 0xb1, 0xd3, 0xbf,
+	0x68, 0xe4, 0x99, 0xb0, 0x96, 0x24, 0xdc, 0xa2, 0xac, 0x2d, 0xca, 0xd9, 0xdd, 0xc2, 0xd0, 0x0b,
+	0x5b, 0xe8, 0xdb, 0x50, 0xe8, 0x8f, 0x48, 0xff, 0x42, 0x0f, 0xae, 0x1a, 0x05, 0xa6, 0xe3, 0x5e,
+	0x92, 0x8e, 0x36, 0xe5, 0x3b, 0xbf, 0xea, 0x6e, 0xe1, 0x7c, 0x9f, 0xff, 0xa4, 0xf3, 0x37, 0xc9,
+	0xd8, 0xba, 0x24, 0x1e, 0x95, 0x2f, 0xae, 0x9e, 0xff, 0x47, 0x9c, 0x93, 0x69, 0x28, 0x9a, 0xb2,
+	0x81, 0xbe, 0x03, 0x45, 0x62, 0x9b, 0x62, 0x1a, 0xc0, 0x54, 0xdc, 0x4f, 0x3c, 0x2b, 0xb6, 0x29,
+	0x27, 0x51, 0x20, 0xe2, 0x37, 0x7a, 0x1f, 0xb6, 0xfb, 0xce, 0x64, 0x62, 0x05, 0x8d, 0x12, 0x93,
+	0xbe, 0x9b, 0x38, 0x01, 0xc6, 0xd5, 0xdd, 0xc2, 0x82, 0x1f, 0x1d, 0x43, 0x75, 0x6c, 0xf9, 0x81,
+	0xee, 0xdb, 0x86, 0xeb, 0x8f, 0x9c, 0xc0, 0x6f, 0x94, 0x99, 0x86, 0xaf, 0x26, 0x69, 0x78, 0x6a,
+	0xf9, 0xc1, 0x99, 0x64, 0xee, 0x6e, 0xe1, 0xca, 0x58, 0x25, 0x50, 0x7d, 0xce, 0x60, 0x40, 0xbc,
+	0x50, 0x61, 0xa3, 0xb2, 0x5a, 0xdf, 0x09, 0xe5, 0x96, 0xf2, 0x54, 0x9f, 0xa3, 0x12, 0xd0, 0x1f,
+	0xc3, 0xee, 0xd8, 0x31, 0xcc, 0x50, 0x9d, 0xde, 0x1f, 0x4d, 0xed, 0x8b, 0x46, 0x95, 0x29, 0x7d,
+	0x90, 0x38, 0x48, 0xc7, 0x30, 0xa5, 0x8a, 0x36, 0x15, 0xe8, 0x6e, 0xe1, 0xfa, 0x78, 0x9e, 0x88,
+	0x5e, 0xc0, 0x9e, 0xe1, 0xba, 0xe3, 0xd9, 0xbc, 0xf6, 0x1d, 0xa6, 0xfd, 0xcd, 0x24, 0xed, 0x87,
+	0x54, 0x66, 0x5e, 0x3d, 0x32, 0x16, 0xa8, 0xe8, 0x11, 0x94, 0x87, 0x24, 0xd0, 0x0d, 0xd7, 0xd5,
+	0x47, 0x86, 0x3f, 0x6a, 0xd4, 0x56, 0x9f, 0xd0, 0xc7, 0x84, 0xaa, 0xee, 0x1a, 0xec, 0x5a, 0xc3,
+	0x30, 0x6c, 0xd1, 0x71, 0x0e, 0x89, 0x4d, 0x3c, 0x23, 0x20, 0xfa, 0xc0, 0x33, 0xa6, 0xa6, 0xce,
+	0xac, 0x63, 0xa3, 0xbe, 0x7a, 0x9c, 0x8f, 0x85, 0xcc, 0x23, 0x2a, 0x72, 0x4a, 0x25, 0xe8, 0x38,
+	0x87, 0x0b, 0x54, 0xf4, 0x29, 0xa0, 0x4b, 0xe2, 0x59, 0x83, 0x59, 0x4c, 0x3b, 0x62, 0xda, 0xdf,
+	0x48, 0xd2, 0xfe, 0x9c, 0x49, 0xc4, 0x74, 0xd7, 0x2e, 0xe7, 0x68, 0xad, 0x3c, 0xe4, 0x2e, 0x8d,
+	0xf1, 0x94, 0x68, 0xbf, 0x07, 0x25, 0xc5, 0xd8, 0xa1, 0x06, 0xe4, 0x27, 0xc4, 0xf7, 0x8d, 0x21,
+	0x61, 0xb6, 0xb1, 0x88, 0x65, 0x53, 0xab, 0x42, 0x59, 0x35, 0x70, 0xda, 0x04, 0x4a, 0x8a, 0xe9,
+	0xa2, 0x82, 0x97, 0xc4, 0xf3, 0xa9, 0xbd, 0x12, 0x82, 0xa2, 0x89, 0x5e, 0x83, 0x0a, 0xbb, 0x40,
+	0xba, 0xec, 0xa7, 0xf6, 0x33, 0x8b, 0xcb, 0x8c, 0xf8, 0x5c, 0x30, 0xdd, 0x83, 0x92, 0x7b, 0xe0,
+	0x86, 0x2c, 0x19, 0xc6, 0x02, 0xee, 0x81, 0x2b, 0x18, 0xb4, 0x6f, 0x42, 0x6d, 0xde, 0xde, 0xa1,
+	0x1a, 0x64, 0x2e, 0xc8, 0x4c, 0x7c, 0x8f, 0xfe, 0x44, 0x7b, 0x62, 0x5a, 0xec, 0x1b, 0x45, 0x2c,
+	0xe6, 0xf8, 0xdf, 0x69, 0xa8, 0xcd, 0x1b, 0x3a, 0xf4, 0x3e, 0x64, 0xa9, 0x4b, 0x11, 0x2e, 0xa0,
+	0xb9, 0xcf, 0x1d, 0xc7, 0xbe, 0x74, 0x1c, 0xfb, 0xe7, 0xd2, 0xdf, 0xb4, 0x0a, 0xbf, 0xf8, 0xd5,
+	0xbd, 0xad, 0x9f, 0xfd, 0xe7, 0xbd, 0x14, 0x66, 0x12, 0xe8, 0x36, 0xb5, 0x4b, 0x86, 0x65, 0xeb,
+	0x96, 0x29, 0xbe, 0x93, 0x67, 0xed, 0x23, 0x13, 0x3d, 0x81, 0x5a, 0xdf, 0xb1, 0x7d, 0x62, 0xfb,
+	0x53, 0x5f, 0xe7, 0xfe, 0xac, 0x91, 0x49, 0xb0, 0x1b, 0x6d, 0xc9, 0x78, 0xca, 0xf8, 0xf0, 0x4e,
+	0x3f, 0x4e, 0x40, 0x8f, 0x00, 0x2e, 0x8d, 0xb1, 0x65, 0x1a, 0x81, 0xe3, 0xf9, 0x8d, 0xec, 0xfd,
+	0xcc, 0x52, 0x35, 0xcf, 0x25, 0xcb, 0x33, 0xd7, 0x34, 0x02, 0xd2, 0xca, 0xd2, 0xd1, 0x62, 0x45,
+	0x12, 0xbd, 0x0e, 0x3b, 0xf4, 0xa4, 0xfb, 0x01, 0x3d, 0xa6, 0xbd, 0x59, 0x40, 0x7c, 0xe6, 0x0e,
+	0xca, 0xb8, 0x62, 0xb8, 0xee, 0x19, 0xa5, 0xb6, 0x28, 0x11, 0x7d, 0x15, 0xaa, 0xd4, 0xf4, 0x5b,
+	0xc6, 0x58, 0x1f, 0x11, 0x6b, 0x38, 0x0a, 0x98, 0xd9, 0xcf, 0xe0, 0x8a, 0xa0, 0x76, 0x19, 0x11,
+	0x3d, 0x80, 0x1a, 0x3d, 0xaa, 0xbe, 0xe5, 0xeb, 0xcc, 0xd6, 0xfa, 0xd3, 0x09, 0x33, 0xf1, 0x45,
+	0xbc, 0x23, 0xe8, 0x6d, 0x41, 0xd6, 0x4c, 0x28, 0xab, 0x1e, 0x02, 0x21, 0xc8, 0x9a, 0x46, 0x60,
+	0xb0, 0x35, 0x2f, 0x63, 0xf6, 0x9b, 0xd2, 0x5c, 0x23, 0x18, 0x89, 0x95, 0x64, 0xbf, 0xd1, 0x4d,
+	0xd8, 0x16, 0x23, 0xc8, 0xb0, 0x11, 0x88, 0x16, 0xdd, 0x5e, 0xd7, 0x73, 0x2e, 0x09, 0x73, 0x89,
+	0x05, 0xcc, 0x1b, 0xda, 0x3f, 0xa7, 0xa1, 0xbe, 0xe0, 0x4b, 0xa8, 0x5e, 0x76, 0xb7, 0xc5, 0xb7,
+	0xe8, 0x6f, 0xf4, 0x1e, 0xd5, 0x6b, 0x98, 0xc4, 0x13, 0x3e, 0xbc, 0xa1, 0xae, 0x26, 0x0f, 0x5d,
+	0xba, 0xac, 0x5f, 0xac, 0xa2, 0xe0, 0x46, 0x27, 0x50, 0x1b, 0x1b, 0x7e, 0xa0, 0x73, 0xdb, 0xac,
+	0x2b, 0xfe, 0x7c, 0xd1, 0x23, 0x3d, 0x35, 0xa4, 0x35, 0xa7, 0xf7, 0x42, 0x28, 0xaa, 0x8e, 0x63,
+	0x54, 0x84, 0x61, 0xaf, 0x37, 0xfb, 0xa9, 0x61, 0x07, 0x96, 0x4d, 0xf4, 0x85, 0x4d, 0xbe, 0xbd,
+	0xa0, 0xb4, 0x73, 0x69, 0x99, 0xc4, 0xee, 0xcb, 0xdd, 0xdd, 0x0d, 0x85, 0x9f, 0x47, 0xdb, 0xdc,
+	0x06, 0x14, 0x9d, 0x3d, 0x71, 0x6b, 0xe9, 0x4e, 0x53, 0x8d, 0x7b, 0x0b, 0xc7, 0xfb, 0xd0, 0x9e,
+	0xe1, 0x7a, 0xc8, 0xff, 0xb1, 0x60, 0xd7, 0xfe, 0x2d, 0x05, 0xd5, 0xb8, 0x4f, 0x45, 0x55, 0x48,
+	0x07, 0x57, 0x62, 0x19, 0xd3, 0xc1, 0x15, 0xfa, 0x3a, 0x64, 0xe9, 0x52, 0xb1, 0x25, 0xac, 0x2e,
+	0x09, 0x68, 0x84, 0xdc, 0xf9, 0xcc, 0x25, 0x98, 0x71, 0x26, 0x6e, 0xa7, 0xbc, 0x82, 0xd9, 0x6b,
+	0x5f, 0xc1, 0x07, 0x50, 0x73, 0x3d, 0xc7, 0x75, 0x7c, 0xe2, 0xe9, 0x86, 0x69, 0x7a, 0xc4, 0x97,
+	0x67, 0x7a, 0x47, 0xd2, 0x0f, 0x39, 0x59, 0xd3, 0xa0, 0x36, 0xef, 0xe4, 0xe7, 0xa7, 0xa4, 0x3d,
+	0x80, 0x9d, 0x39, 0x2f, 0xae, 0x8c, 0x39, 0xa5, 0x8e, 0x59, 0xdb, 0x81, 0x4a, 0xcc, 0x65, 0x6b,
+	0x37, 0x61, 0x6f, 0x99, 0x07, 0xd6, 0x46, 0xb0, 0xb7, 0xcc, 0x93, 0xa2, 0x77, 0xa1, 0x10, 0xba,
+	0x60, 0x6e, 0x7b, 0x16, 0xb7, 0x5b, 0x32, 0xe3, 0x90, 0x95, 0x1a, 0x9d, 0xd0, 0x5d, 0xa5, 0xd9,
+	0xc0, 0xf3, 0x06, 0xf7, 0x42, 0xda, 0x8f, 0xa0, 0x91, 0xe4, 0x5e, 0xe7, 0xa6, 0x91, 0x0d, 0x97,
+	0xfe, 0x26, 0x6c, 0x0f, 0x1c, 0x6f, 0x62, 0x04, 0x4c, 0x59, 0x05, 0x8b, 0x16, 0xbd, 0x61, 0xdc,
+	0xd5, 0x66, 0x18, 0x99, 0x37, 0x34, 0x1d, 0x6e, 0x27, 0xba, 0x58, 0x2a, 0x62, 0xd9, 0x26, 0xe1,
+	0xeb, 0x59, 0xc1, 0xbc, 0x11, 0x29, 0xe2, 0x83, 0xe5, 0x0d, 0xfa, 0x59, 0x9f, 0xcd, 0x95, 0xe9,
+	0x2f, 0x62, 0xd1, 0xd2, 0x76, 0xa1, 0xbe, 0xe0, 0x6b, 0xb5, 0xbf, 0x4a, 0xc3, 0xed, 0x44, 0x8f,
+	0x89, 0x3e, 0x85, 0x5d, 0x25, 0xc8, 0xd4, 0x3d, 0xce, 0xd8, 0x48, 0xad, 0x76, 0xe5, 0x91, 0x81,
+	0x10, 0x57, 0xa9, 0x1e, 0x05, 0x9c, 0x82, 0x05, 0x7d, 0x0f, 0x76, 0xa3, 0xc8, 0x51, 0x2a, 0xf6,
+	0x1b, 0xe9, 0xfb, 0x99, 0x8d, 0x42, 0x48, 0x5c, 0x0f, 0x03, 0x48, 0xd1, 0xe5, 0xa3, 0xa7, 0x50,
+	0x0f, 0x03, 0xc9, 0x70, 0xa8, 0x99, 0xcd, 0x02, 0x4a, 0xbc, 0x23, 0xc3, 0x49, 0xd1, 0xa1, 0xfd,
+	0x79, 0x0a, 0x6e, 0x25, 0x38, 0x7b, 0xf4, 0x6d, 0x28, 0xa9, 0xb1, 0x02, 0x5f, 0x8e, 0x57, 0x16,
+	0xbe, 0x11, 0x49, 0x60, 0x18, 0x44, 0xd2, 0xef, 0xc2, 0x2d, 0x72, 0xe5, 0x92, 0x7e, 0x40, 0x4c,
+	0x6e, 0x96, 0xf4, 0xb9, 0x43, 0xb7, 0x27, 0xbb, 0x99, 0xe1, 0x91, 0x3b, 0xf5, 0x6b, 0x80, 0x02,
+	0x26, 0xbe, 0x4b, 0xcd, 0x09, 0x6a, 0x41, 0x91, 0x5c, 0xf5, 0x09, 0xc7, 0x2e, 0xc9, 0xdb, 0xc1,
+	0xb9, 0x3b, 0x92, 0x93, 0x06, 0xde, 0xa1, 0x18, 0x7a, 0x47, 0xe0, 0xb3, 0x64, 0xa8, 0x25, 0xc4,
+	0x55, 0x80, 0xf6, 0x9e, 0x04, 0x68, 0x99, 0xc4, 0x58, 0x9b, 0x4b, 0xcd, 0x21, 0xb4, 0x77, 0x04,
+	0x42, 0xcb, 0xae, 0xf9, 0x58, 0x0c, 0xa2, 0xb5, 0x63, 0x10, 0x2d, 0xb7, 0x66, 0x9a, 0x09, 0x18,
+	0xad, 0x1d, 0xc3, 0x68, 0xdb, 0x6b, 0x94, 0x24, 0x80, 0xb4, 0xf7, 0x24, 0x48, 0xcb, 0xaf, 0x99,
+	0xf6, 0x1c, 0x4a, 0x7b, 0x14, 0x47, 0x69, 0x1c, 0x61, 0xbd, 0x96, 0x28, 0x9d, 0x08, 0xd3, 0xfe,
+	0x50, 0x81, 0x69, 0xc5, 0xc4, 0x23, 0xcd, 0x95, 0x2c, 0xc1, 0x69, 0xed, 0x18, 0x4e, 0x83, 0x35,
+	0x6b, 0x90, 0x00, 0xd4, 0x3e, 0x54, 0x81, 0x5a, 0x29, 0x11, 0xeb, 0x89, 0x43, 0xb3, 0x0c, 0xa9,
+	0x7d, 0x10, 0x22, 0xb5, 0x72, 0x22, 0xd4, 0x14, 0x73, 0x98, 0x87, 0x6a, 0x27, 0x0b, 0x50, 0x8d,
+	0x43, 0xab, 0xd7, 0x13, 0x55, 0xac, 0xc1, 0x6a, 0x27, 0x0b, 0x58, 0xad, 0xba, 0x46, 0xe1, 0x1a,
+	0xb0, 0xf6, 0x27, 0xcb, 0xc1, 0x5a, 0x32, 0x9c, 0x12, 0xc3, 0xdc, 0x0c, 0xad, 0xe9, 0x09, 0x68,
+	0x8d, 0xa3, 0xaa, 0xb7, 0x12, 0xd5, 0x6f, 0x0c, 0xd7, 0x1e, 0xcf, 0xc1, 0xb5, 0xfa, 0x9a, 0xa3,
+	0x9a, 0x88, 0xd7, 0xf4, 0x04, 0xbc, 0x86, 0xd6, 0x8c, 0x74, 0x63, 0xc0, 0xf6, 0xfd, 0xa5, 0x80,
+	0x6d, 0x37, 0x11, 0x14, 0x73, 0xf5, 0xd7, 0x43, 0x6c, 0x0f, 0xa0, 0x2e, 0x05, 0x43, 0xeb, 0x49,
+	0xdd, 0x2d, 0xf1, 0x3c, 0xc7, 0x13, 0x60, 0x88, 0x37, 0xb4, 0x37, 0xa0, 0x1c, 0xb2, 0xae, 0x46,
+	0x77, 0x2c, 0xac, 0x51, 0xac, 0xa3, 0xf6, 0x0f, 0x29, 0x28, 0xab, 0x86, 0x2f, 0x16, 0xbb, 0x17,
+	0x45, 0xec, 0xae, 0x80, 0xbe, 0x74, 0x1c, 0xf4, 0xdd, 0x83, 0x12, 0xdd, 0xae, 0x39, 0x3c, 0x67,
+	0xb8, 0x12, 0xcf, 0xa1, 0x37, 0xa1, 0xce, 0x42, 0x6a, 0xee, 0x12, 0x45, 0x8c, 0x92, 0x65, 0xa1,
+	0xd6, 0x0e, 0xed, 0xe0, 0x37, 0x94, 0x91, 0xd1, 0xdb, 0xb0, 0xab, 0xf0, 0x86, 0xc7, 0x80, 0x07,
+	0x7c, 0xb5, 0x90, 0x5b, 0x7a, 0xa3, 0x8f, 0xa1, 0xbe, 0x60, 0x77, 0xe9, 0xf0, 0xfb, 0x8e, 0x49,
+	0x44, 0x90, 0xc2, 0x7e, 0x53, 0xfc, 0x38, 0x76, 0x86, 0x22, 0x14, 0xa1, 0x3f, 0x29, 0x57, 0xe8,
+	0x0a, 0x8a, 0xdc, 0xd2, 0x6b, 0xff, 0x94, 0x86, 0xfa, 0x82, 0x09, 0x5e, 0x8a, 0xf4, 0x52, 0xbf,
+	0x1d, 0xa4, 0x97, 0xfe, 0x8d, 0x91, 0x9e, 0x1a, 0x24, 0x66, 0x62, 0x41, 0x22, 0xea, 0x40, 0xd5,
+	0x73, 0xc6, 0x63, 0xda, 0x2d, 0x46, 0x9b, 0x4d, 0x72, 0x17, 0x9c, 0x4d, 0x8c, 0xb5, 0xe2, 0xa9,
+	0x4d, 0xf4, 0x01, 0xdc, 0x96, 0xe0, 0xaf, 0xe7, 0x59, 0xe6, 0x90, 0xe8, 0xf4, 0x20, 0xc4, 0x50,
+	0xe5, 0x4d, 0xc1, 0xd0, 0x62, 0xfd, 0x1f, 0x19, 0x81, 0xc1, 0xe0, 0xa5, 0xf6, 0x3f, 0x29, 0xa8,
+	0xc4, 0x5c, 0xd1, 0x6f, 0xbe, 0x27, 0x51, 0xcc, 0x99, 0x63, 0x27, 0x86, 0x37, 0x64, 0x3e, 0x60,
+	0x9b, 0x0d, 0x23, 0x9e, 0x0f, 0xc8, 0x33, 0x1a, 0x6f, 0xa0, 0xf7, 0xa1, 0xc8, 0x2e, 0xa6, 0xee,
+	0xb8, 0x7e, 0xa3, 0xb0, 0x18, 0x21, 0xf1, 0x64, 0xf5, 0x3e, 0xbb, 0x77, 0x27, 0xae, 0x8f, 0x0b,
+	0xae, 0xf8, 0xa5, 0x84, 0xd3, 0xc5, 0x18, 0x92, 0xb9, 0x03, 0x45, 0x3a, 0x7a, 0xdf, 0x35, 0xfa,
+	0x84, 0xf9, 0xb0, 0x22, 0x8e, 0x08, 0xda, 0xbf, 0xa4, 0x00, 0x2d, 0xba, 0x51, 0xd4, 0x85, 0x6d,
+	0x72, 0x49, 0xec, 0x80, 0x1e, 0x1c, 0xba, 0xe3, 0x37, 0x97, 0xc0, 0x3e, 0x62, 0x07, 0xad, 0x06,
+	0xdd, 0xe7, 0x5f, 0xff, 0xea, 0x5e, 0x8d, 0x73, 0x7f, 0xcd, 0x99, 0x58, 0x01, 0x99, 0xb8, 0xc1,
+	0x0c, 0x0b, 0x79, 0x74, 0x01, 0x77, 0x16, 0xa1, 0x9f, 0xee, 0x89, 0x4f, 0xca, 0x13, 0xf5, 0x20,
+	0xf9, 0x60, 0x0a, 0xfc, 0x27, 0x07, 0x89, 0x9b, 0x0b, 0xc8, 0x50, 0x76, 0xf9, 0xda, 0x00, 0x1a,
+	0x49, 0x72, 0xe8, 0x66, 0xcc, 0x0c, 0xd1, 0x58, 0x83, 0x35, 0xd1, 0xeb, 0x90, 0x76, 0x2e, 0x44,
+	0x34, 0xb7, 0x14, 0x8b, 0x76, 0xb7, 0x70, 0xda, 0xb9, 0x68, 0x01, 0x14, 0xe4, 0xa8, 0xb5, 0xff,
+	0x48, 0x53, 0x54, 0x16, 0x8b, 0x1b, 0x96, 0x9e, 0x18, 0x69, 0x98, 0xd2, 0x4a, 0x52, 0x61, 0xb3,
+	0x53, 0x74, 0x17, 0x60, 0x68, 0xf8, 0xfa, 0x4b, 0xc3, 0x0e, 0x88, 0x29, 0x8e, 0x92, 0x42, 0x41,
+	0x4d, 0x28, 0xd0, 0xd6, 0xd4, 0x27, 0xa6, 0x48, 0x85, 0x84, 0x6d, 0x65, 0xf3, 0xf2, 0x5f, 0x72,
+	0xf3, 0x62, 0x67, 0xa7, 0x30, 0x77, 0x76, 0x14, 0xc4, 0x54, 0x54, 0x11, 0x13, 0x1d, 0x9b, 0xeb,
+	0x59, 0x8e, 0x67, 0x05, 0x33, 0x76, 0xe0, 0x32, 0x38, 0x6c, 0xd3, 0x8c, 0xdb, 0x84, 0x4c, 0x5c,
+	0xc7, 0x19, 0xeb, 0x7c, 0x37, 0x4a, 0x4c, 0xb4, 0x2c, 0x88, 0x1d, 0xe6, 0x1b, 0xfe, 0x4c, 0x31,
+	0x6b, 0x11, 0x32, 0xfe, 0x9d, 0x5b, 0x60, 0xed, 0x6f, 0x33, 0x50, 0x93, 0xeb, 0x10, 0xa2, 0xff,
+	0x33, 0xa8, 0x87, 0x66, 0x55, 0x9f, 0x32, 0x73, 0x2b, 0x6f, 0xe9, 0xa6, 0x76, 0xb9, 0x76, 0x19,
+	0x27, 0xfb, 0xe8, 0x53, 0xb8, 0x35, 0xe7, 0x32, 0x42, 0xd5, 0xe9, 0x0d, 0x3d, 0xc7, 0x8d, 0xb8,
+	0xe7, 0x90, 0x9a, 0xa3, 0xb5, 0xca, 0x7c, 0xc9, 0xb5, 0xc2, 0x70, 0x23, 0xe6, 0x26, 0xc2, 0x11,
+	0x6e, 0xe6, 0x2d, 0x76, 0x55, 0x6f, 0x21, 0x47, 0xf7, 0x18, 0x2a, 0x17, 0x64, 0xa6, 0x7b, 0x4e,
+	0x60, 0x50, 0x57, 0x2c, 0x73, 0x52, 0x8b, 0x99, 0xa3, 0x27, 0x64, 0x86, 0x05, 0x93, 0x58, 0xc4,
+	0xf2, 0x45, 0x44, 0xf2, 0xb5, 0x23, 0xa8, 0xca, 0x9d, 0xe2, 0x41, 0xf8, 0xd2, 0xa3, 0xf9, 0x1a,
+	0x54, 0x3c, 0x12, 0xd0, 0xfc, 0x6c, 0x2c, 0xe9, 0x54, 0xe6, 0x44, 0x1e, 0x52, 0x68, 0xa7, 0x70,
+	0x63, 0x69, 0x30, 0x8e, 0xfe, 0x00, 0x8a, 0x51, 0x1c, 0x9f, 0x4a, 0x48, 0xc7, 0x49, 0x76, 0x1c,
+	0xf1, 0x6a, 0xff, 0x98, 0x82, 0x1b, 0x4b, 0xc3, 0x71, 0xd4, 0x81, 0x6d, 0x8f, 0xf8, 0xd3, 0x31,
+	0x4f, 0x4e, 0x54, 0x0f, 0xde, 0xde, 0x2c, 0x8c, 0xa7, 0xd4, 0xe9, 0x38, 0xc0, 0x42, 0x58, 0x7b,
+	0x01, 0xdb, 0x9c, 0x82, 0x4a, 0x90, 0x7f, 0x76, 0xfc, 0xe4, 0xf8, 0xe4, 0x93, 0xe3, 0xda, 0x16,
+	0x02, 0xd8, 0x3e, 0x6c, 0xb7, 0x3b, 0xa7, 0xe7, 0xb5, 0x14, 0x2a, 0x42, 0xee, 0xb0, 0x75, 0x82,
+	0xcf, 0x6b, 0x69, 0x4a, 0xc6, 0x9d, 0xef, 0x76, 0xda, 0xe7, 0xb5, 0x0c, 0xaa, 0x43, 0x85, 0xff,
+	0xd6, 0x1f, 0x9d, 0xe0, 0x8f, 0x0f, 0xcf, 0x6b, 0x59, 0x85, 0x74, 0xd6, 0x39, 0xfe, 0xa8, 0x83,
+	0x6b, 0x39, 0xed, 0x1b, 0x70, 0x5b, 0x8e, 0x63, 0x31, 0x8f, 0x14, 0xa6, 0x73, 0x52, 0x4a, 0x3a,
+	0x47, 0xfb, 0xeb, 0x34, 0x34, 0x93, 0xa3, 0x79, 0xf4, 0xdd, 0xb9, 0x89, 0x1f, 0x5c, 0x03, 0x0a,
+	0xcc, 0xcd, 0x9e, 0x26, 0xa7, 0x3d, 0x32, 0x20, 0x41, 0x7f, 0xc4, 0xd1, 0x05, 0x77, 0x6a, 0x15,
+	0x5c, 0x11, 0x54, 0x26, 0xe4, 0x73, 0xb6, 0x1f, 0x93, 0x7e, 0xa0, 0x73, 0x3b, 0xc9, 0x6f, 0x44,
+	0x11, 0x57, 0x38, 0xf5, 0x8c, 0x13, 0xb5, 0x1f, 0x5d, 0x6b, 0x2d, 0x8b, 0x90, 0xc3, 0x9d, 0x73,
+	0xfc, 0xfd, 0x5a, 0x06, 0x21, 0xa8, 0xb2, 0x9f, 0xfa, 0xd9, 0xf1, 0xe1, 0xe9, 0x59, 0xf7, 0x84,
+	0xae, 0xe5, 0x2e, 0xec, 0xc8, 0xb5, 0x94, 0xc4, 0x9c, 0xf6, 0x10, 0xd0, 0x22, 0x1c, 0x89, 0x05,
+	0x68, 0xa9, 0x78, 0x16, 0xef, 0x07, 0xd0, 0x4c, 0x86, 0x1b, 0x5f, 0x2e, 0xad, 0xa3, 0xfd, 0x3e,
+	0x34, 0xa4, 0xee, 0x85, 0x84, 0x51, 0x03, 0xf2, 0xfe, 0xb4, 0xdf, 0x27, 0x3e, 0x8f, 0x5f, 0x0b,
+	0x58, 0x36, 0xb5, 0xbf, 0xcf, 0xc0, 0xce, 0x9c, 0x01, 0x42, 0x07, 0x90, 0xe3, 0x20, 0x3b, 0xa9,
+	0x72, 0xce, 0xec, 0x27, 0x67, 0xc6, 0xb9, 0x9e, 0xac, 0xe3, 0x12, 0x91, 0xbf, 0x5e, 0x66, 0xe8,
+	0x78, 0xde, 0x5d, 0x66, 0xb8, 0x85, 0x68, 0x28, 0x41, 0x6b, 0xb0, 0xa1, 0x25, 0x6d, 0x64, 0x16,
+	0xa1, 0x3d, 0x17, 0x0f, 0x6d, 0xb0, 0x90, 0x8f, 0x64, 0xd0, 0x07, 0x11, 0x48, 0xc9, 0x2e, 0x42,
+	0x7b, 0x21, 0xce, 0x19, 0x84, 0xb0, 0xe4, 0xa7, 0xa2, 0x34, 0xdd, 0xec, 0x4c, 0x83, 0x46, 0x2e,
+	0x49, 0xf4, 0x9c, 0x33, 0x48, 0x51, 0xc1, 0x4f, 0x87, 0xed, 0xcf, 0xec, 0xfe, 0xc8, 0x73, 0x6c,
+	0x59, 0x3e, 0x5f, 0x32, 0xec, 0x33, 0xc9, 0x22, 0x87, 0x1d, 0xca, 0xd0, 0x6f, 0x0f, 0x88, 0x11,
+	0x4c, 0x3d, 0xd2, 0xc8, 0x27, 0x7d, 0xfb, 0x11, 0x67, 0x90, 0xdf, 0x16, 0xfc, 0x5a, 0x1b, 0x4a,
+	0xca, 0x36, 0xa0, 0x57, 0xa0, 0x38, 0x31, 0xae, 0x44, 0x8c, 0xce, 0xb3, 0xd9, 0x85, 0x89, 0x71,
+	0xc5, 0x8b, 0x3e, 0xb7, 0x20, 0x4f, 0x3b, 0x87, 0x06, 0x77, 0x42, 0x19, 0xbc, 0x3d, 0x31, 0xae,
+	0x1e, 0x1b, 0xbe, 0xf6, 0x43, 0xa8, 0xc6, 0x4b, 0x19, 0xd4, 0x06, 0x78, 0xce, 0xd4, 0x36, 0x99,
+	0x8e, 0x1c, 0xe6, 0x0d, 0xfa, 0x46, 0xe0, 0xd2, 0x09, 0xc2, 0x20, 0x73, 0xd1, 0x58, 0x3e, 0x77,
+	0x02, 0xa2, 0x94, 0x42, 0x38, 0xb7, 0xf6, 0x53, 0xc8, 0x31, 0x9f, 0x44, 0x4d, 0x38, 0x2b, 0x27,
+	0x08, 0x5c, 0x49, 0x7f, 0xa3, 0x1f, 0x02, 0x18, 0x41, 0xe0, 0x59, 0xbd, 0x69, 0xa4, 0xf8, 0xde,
+	0x72, 0x9f, 0x76, 0x28, 0xf9, 0x5a, 0x77, 0x84, 0x73, 0xdb, 0x8b, 0x44, 0x15, 0x07, 0xa7, 0x28,
+	0xd4, 0x8e, 0xa1, 0x1a, 0x97, 0x55, 0x2b, 0x89, 0xe5, 0x25, 0x95, 0xc4, 0x10, 0x39, 0x84, 0xb8,
+	0x23, 0xc3, 0x0b, 0x50, 0xac, 0xa1, 0x7d, 0x96, 0x82, 0xc2, 0xf9, 0x95, 0x30, 0x28, 0x09, 0x85,
+	0x83, 0x48, 0x34, 0xad, 0xa6, 0xc9, 0x79, 0x25, 0x22, 0x13, 0x16, 0x57, 0x3e, 0x0c, 0x4d, 0x66,
+	0x76, 0xd3, 0x4c, 0x98, 0xac, 0x55, 0x09, 0x37, 0xf1, 0x2d, 0x28, 0x86, 0x97, 0x81, 0x5e, 0x6e,
+	0x59, 0x1e, 0x91, 0xe6, 0x86, 0x37, 0xe9, 0x70, 0x5c, 0xe7, 0xa5, 0x48, 0xc4, 0x67, 0x30, 0x6f,
+	0x68, 0x7f, 0x99, 0x82, 0x9d, 0xb9, 0x70, 0x06, 0x7d, 0x0b, 0xf2, 0xee, 0xb4, 0xa7, 0xcb, 0xf5,
+	0x99, 0xbb, 0xf4, 0x12, 0x2b, 0x4d, 0x7b, 0x63, 0xab, 0xff, 0x84, 0xcc, 0xe4, 0x68, 0xdc, 0x69,
+	0xef, 0x09, 0x5f, 0x46, 0xfe, 0x99, 0xb4, 0xf2, 0x19, 0xb4, 0x0f, 0xbb, 0x02, 0x80, 0x0d, 0x74,
+	0xd7, 0xf1, 0x7d, 0xe2, 0x87, 0x59, 0x82, 0x32, 0xae, 0x73, 0xb4, 0x35, 0x38, 0x0d, 0x3b, 0xb4,
+	0xff, 0x4d, 0x41, 0x49, 0x09, 0x0e, 0x50, 0x0b, 0x4a, 0xce, 0xd8, 0xd4, 0xaf, 0x3f, 0xac, 0xa2,
+	0x33, 0x36, 0x4f, 0xf9, 0xc8, 0x5a, 0x50, 0xb2, 0xc9, 0xcb, 0x50, 0x47, 0x7a, 0x73, 0x1d, 0x36,
+	0x79, 0x29, 0x74, 0x24, 0x15, 0xb6, 0xee, 0x40, 0xd1, 0xb7, 0x86, 0x36, 0xbf, 0xbd, 0x59, 0x36,
+	0xab, 0x88, 0x90, 0x34, 0xfb, 0x5c, 0xd2, 0xec, 0x2f, 0xa1, 0x20, 0xef, 0x10, 0xfa, 0x23, 0xd5,
+	0x1a, 0xca, 0xd2, 0x75, 0x62, 0x40, 0x2a, 0x47, 0x1c, 0x8a, 0xd0, 0xb4, 0x0b, 0x1d, 0x08, 0x31,
+	0xf5, 0x28, 0xa3, 0xc2, 0xe6, 0x5e, 0xc0, 0x3b, 0xbc, 0xe3, 0xa9, 0x4c, 0xa7, 0x68, 0xff, 0x97,
+	0x82, 0x82, 0x34, 0xcb, 0xe8, 0x1b, 0xca, 0x35, 0xad, 0x2e, 0x49, 0x92, 0x4b, 0x46, 0xa5, 0xec,
+	0x17, 0x1b, 0x6b, 0xfa, 0xfa, 0x63, 0xfd, 0xed, 0x97, 0x0d, 0xbf, 0x06, 0x28, 0x70, 0x02, 0x63,
+	0xac, 0x5f, 0x3a, 0x81, 0x65, 0x0f, 0x75, 0x7e, 0x34, 0x39, 0x2e, 0xa9, 0xb1, 0x9e, 0xe7, 0xac,
+	0xe3, 0x94, 0x5d, 0x86, 0x0f, 0xa1, 0x12, 0x8b, 0x6e, 0xe9, 0x65, 0x35, 0x65, 0x02, 0x2c, 0x6d,
+	0x1a, 0x34, 0xc9, 0x65, 0x7a, 0x7e, 0xec, 0x5d, 0x43, 0x05, 0x83, 0xe9, 0xf9, 0xf2, 0xd1, 0xc2,
+	0x9f, 0xa6, 0xa0, 0x10, 0x86, 0x81, 0xd7, 0x2d, 0xc5, 0xdd, 0x84, 0x6d, 0x11, 0xe9, 0xf0, 0x5a,
+	0x9c, 0x68, 0x85, 0x85, 0xed, 0xac, 0x52, 0xd8, 0x6e, 0x42, 0x61, 0x42, 0x02, 0x83, 0xc5, 0xc2,
+	0xfc, 0x1c, 0x85, 0x6d, 0xed, 0x5f, 0xb3, 0x00, 0x8a, 0xbf, 0xff, 0x0a, 0x94, 0x63, 0x39, 0x37,
+	0x6e, 0xa5, 0x4a, 0x3d, 0x25, 0xdf, 0xf6, 0x16, 0x20, 0xd7, 0x23, 0xe2, 0xc1, 0xc0, 0x5c, 0x01,
+	0x68, 0xc7, 0xf5, 0x08, 0x7b, 0x33, 0x20, 0x43, 0x9a, 0x15, 0x25, 0xa3, 0x4c, 0x72, 0xc9, 0x08,
+	0x61, 0xa8, 0x70, 0xfd, 0x2f, 0xad, 0xc0, 0x26, 0xbe, 0x2c, 0x7d, 0xbf, 0xbd, 0x22, 0xa4, 0xd9,
+	0x67, 0xdf, 0xfd, 0x84, 0xf3, 0x77, 0xec, 0xc0, 0x9b, 0xe1, 0xb2, 0xaf, 0x90, 0xd0, 0xa7, 0x70,
+	0x93, 0x05, 0x3d, 0xd3, 0x31, 0xb1, 0x03, 0x5d, 0x2d, 0x6e, 0xe4, 0x36, 0xad, 0x0a, 0xe2, 0xbd,
+	0x48, 0x43, 0x44, 0x45, 0xcf, 0xe0, 0x86, 0xa2, 0x59, 0xa9, 0x57, 0x6c, 0x6f, 0xf8, 0xae, 0x0c,
+	0xef, 0x46, 0xf2, 0x21, 0x91, 0x3e, 0x03, 0x50, 0xd4, 0x46, 0x15, 0x8c, 0xfc, 0x86, 0x95, 0x41,
+	0x14, 0x49, 0x4b, 0x5a, 0xf3, 0x05, 0xd4, 0x17, 0xd6, 0x69, 0xc9, 0x4b, 0x99, 0x77, 0x54, 0xff,
+	0xb6, 0xac, 0xea, 0xa5, 0x2a, 0x11, 0xee, 0xef, 0x9b, 0xe9, 0xf7, 0x53, 0xda, 0xdf, 0xa4, 0xa0,
+	0xac, 0xf6, 0xa1, 0xaf, 0x43, 0x4e, 0x0d, 0x4a, 0x9b, 0xc9, 0x99, 0x34, 0xcc, 0x19, 0x69, 0x40,
+	0xe2, 0x39, 0x4e, 0xa0, 0x1e, 0xab, 0x02, 0x25, 0xb0, 0x83, 0xf1, 0x1d, 0x28, 0x8b, 0x23, 0xc1,
+	0x52, 0x8b, 0x02, 0xd1, 0x2e, 0x06, 0x9a, 0xe2, 0xf3, 0x34, 0xbf, 0x88, 0x4b, 0x2f, 0xa3, 0x86,
+	0x66, 0x41, 0x49, 0xe9, 0xdb, 0xd8, 0xb5, 0x1f, 0xc0, 0x36, 0x1b, 0x9d, 0xc4, 0xd0, 0xab, 0xe6,
+	0x21, 0x38, 0xdf, 0xfc, 0x00, 0x4a, 0xca, 0x6b, 0x07, 0xfa, 0xa9, 0xe3, 0xce, 0x27, 0xb5, 0xad,
+	0x66, 0xfe, 0xb3, 0x9f, 0xdf, 0xcf, 0x1c, 0x93, 0x97, 0xd4, 0xff, 0xe2, 0x4e, 0xbb, 0xdb, 0x69,
+	0x3f, 0xa9, 0xa5, 0x9a, 0xa5, 0xcf, 0x7e, 0x7e, 0x3f, 0x8f, 0x09, 0x2b, 0x7c, 0xbd, 0xd9, 0x85,
+	0xb2, 0x6a, 0x32, 0xe3, 0x38, 0x04, 0x41, 0xf5, 0xa3, 0x67, 0xa7, 0x4f, 0x8f, 0xda, 0x87, 0xe7,
+	0x1d, 0xfd, 0xf9, 0xc9, 0x79, 0xa7, 0x96, 0x42, 0xb7, 0x60, 0xf7, 0xe9, 0xd1, 0xe3, 0xee, 0xb9,
+	0xde, 0x7e, 0x7a, 0xd4, 0x39, 0x3e, 0xd7, 0x0f, 0xcf, 0xcf, 0x0f, 0xdb, 0x4f, 0x6a, 0xe9, 0x83,
+	0xbf, 0x2b, 0xc3, 0xce, 0x61, 0xab, 0x7d, 0x44, 0x31, 0x94, 0xd5, 0x37, 0x44, 0x61, 0x31, 0xcb,
+	0x12, 0xfe, 0x2b, 0x5f, 0xb6, 0x36, 0x57, 0xd7, 0x55, 0xd1, 0x23, 0xc8, 0xb1, 0x5a, 0x00, 0x5a,
+	0xfd, 0xd4, 0xb5, 0xb9, 0xa6, 0xd0, 0x4a, 0x07, 0xc3, 0x7c, 0xd7, 0xca, 0xb7, 0xaf, 0xcd, 0xd5,
+	0x75, 0x57, 0x84, 0xa1, 0x18, 0x25, 0xf3, 0xd7, 0xbf, 0x85, 0x6d, 0x6e, 0x50, 0x8b, 0xa5, 0x3a,
+	0xa3, 0xbb, 0xb8, 0xfe, 0x0e, 0x37, 0x37, 0x08, 0xc6, 0xd0, 0x53, 0xc8, 0xcb, 0x64, 0xe5, 0xba,
+	0xd7, 0xaa, 0xcd, 0xb5, 0x75, 0x52, 0xba, 0x05, 0x3c, 0x55, 0xbe, 0xfa, 0xe9, 0x6d, 0x73, 0x4d,
+	0xd1, 0x17, 0x1d, 0xc1, 0xb6, 0xc8, 0x98, 0xac, 0x79, 0x81, 0xda, 0x5c, 0x57, 0xf7, 0xa4, 0x8b,
+	0x16, 0x55, 0x41, 0xd6, 0x3f, 0x28, 0x6e, 0x6e, 0x50, 0xcf, 0x46, 0xcf, 0x00, 0x14, 0x63, 0xbb,
+	0x81, 0x99, 0x6e, 0x6e, 0x52, 0xa7, 0x46, 0x27, 0x50, 0x08, 0x33, 0x7a, 0x6b, 0x8d, 0x69, 0x73,
+	0x7d, 0xc1, 0x18, 0xbd, 0x80, 0x4a, 0x3c, 0x5b, 0xb4, 0xd9, 0x6b, 0xdc, 0xe6, 0x86, 0x95, 0x60,
+	0xaa, 0x3f, 0x9e, 0x3a, 0xda, 0xec, 0x75, 0x6e, 0x73, 0xc3, 0xc2, 0x30, 0xfa, 0x31, 0xd4, 0x17,
+	0x53, 0x3b, 0x9b, 0x3f, 0xd6, 0x6d, 0x5e, 0xa3, 0x54, 0x8c, 0x26, 0x80, 0x96, 0xa4, 0x84, 0xae,
+	0xf1, 0x76, 0xb7, 0x79, 0x9d, 0xca, 0x31, 0x3d, 0x42, 0x4a, 0x9e, 0x65, 0x83, 0xa7, 0xbc, 0xcd,
+	0x4d, 0xea, 0xc7, 0x74, 0x16, 0x4b, 0xb2, 0x31, 0xd7, 0x78, 0xd9, 0xdb, 0xbc, 0x4e, 0x55, 0x19,
+	0x0d, 0xa1, 0xb6, 0x90, 0xa0, 0xd9, 0xf8, 0xa1, 0x6f, 0x73, 0xf3, 0x0a, 0x73, 0xab, 0xf3, 0x8b,
+	0xcf, 0xef, 0xa6, 0x7e, 0xf9, 0xf9, 0xdd, 0xd4, 0x7f, 0x7d, 0x7e, 0x37, 0xf5, 0xb3, 0x2f, 0xee,
+	0x6e, 0xfd, 0xf2, 0x8b, 0xbb, 0x5b, 0xff, 0xfe, 0xc5, 0xdd, 0xad, 0x1f, 0xbc, 0x35, 0xb4, 0x82,
+	0xd1, 0xb4, 0xb7, 0xdf, 0x77, 0x26, 0x0f, 0xd5, 0xbf, 0x68, 0x2c, 0xfb, 0xdb, 0x48, 0x6f, 0x9b,
+	0x05, 0xdd, 0xef, 0xfc, 0xff, 0x00, 0x9a, 0x1a, 0x1e, 0x17, 0x56, 0x32, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3633,6 +4422,9 @@ type ABCIApplicationClient interface {
 	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
+	GetAppHash(ctx context.Context, in *RequestGetAppHash, opts ...grpc.CallOption) (*ResponseGetAppHash, error)
+	GenerateFraudProof(ctx context.Context, in *RequestGenerateFraudProof, opts ...grpc.CallOption) (*ResponseGenerateFraudProof, error)
+	VerifyFraudProof(ctx context.Context, in *RequestVerifyFraudProof, opts ...grpc.CallOption) (*ResponseVerifyFraudProof, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3778,6 +4570,33 @@ func (c *aBCIApplicationClient) ApplySnapshotChunk(ctx context.Context, in *Requ
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) GetAppHash(ctx context.Context, in *RequestGetAppHash, opts ...grpc.CallOption) (*ResponseGetAppHash, error) {
+	out := new(ResponseGetAppHash)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/GetAppHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) GenerateFraudProof(ctx context.Context, in *RequestGenerateFraudProof, opts ...grpc.CallOption) (*ResponseGenerateFraudProof, error) {
+	out := new(ResponseGenerateFraudProof)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/GenerateFraudProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) VerifyFraudProof(ctx context.Context, in *RequestVerifyFraudProof, opts ...grpc.CallOption) (*ResponseVerifyFraudProof, error) {
+	out := new(ResponseVerifyFraudProof)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/VerifyFraudProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3795,6 +4614,9 @@ type ABCIApplicationServer interface {
 	OfferSnapshot(context.Context, *RequestOfferSnapshot) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
+	GetAppHash(context.Context, *RequestGetAppHash) (*ResponseGetAppHash, error)
+	GenerateFraudProof(context.Context, *RequestGenerateFraudProof) (*ResponseGenerateFraudProof, error)
+	VerifyFraudProof(context.Context, *RequestVerifyFraudProof) (*ResponseVerifyFraudProof, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3846,6 +4668,15 @@ func (*UnimplementedABCIApplicationServer) LoadSnapshotChunk(ctx context.Context
 func (*UnimplementedABCIApplicationServer) ApplySnapshotChunk(ctx context.Context, req *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplySnapshotChunk not implemented")
 }
+func (*UnimplementedABCIApplicationServer) GetAppHash(ctx context.Context, req *RequestGetAppHash) (*ResponseGetAppHash, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAppHash not implemented")
+}
+func (*UnimplementedABCIApplicationServer) GenerateFraudProof(ctx context.Context, req *RequestGenerateFraudProof) (*ResponseGenerateFraudProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateFraudProof not implemented")
+}
+func (*UnimplementedABCIApplicationServer) VerifyFraudProof(ctx context.Context, req *RequestVerifyFraudProof) (*ResponseVerifyFraudProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyFraudProof not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -4121,6 +4952,60 @@ func _ABCIApplication_ApplySnapshotChunk_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_GetAppHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetAppHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).GetAppHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/GetAppHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).GetAppHash(ctx, req.(*RequestGetAppHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_GenerateFraudProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGenerateFraudProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).GenerateFraudProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/GenerateFraudProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).GenerateFraudProof(ctx, req.(*RequestGenerateFraudProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_VerifyFraudProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVerifyFraudProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).VerifyFraudProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/VerifyFraudProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).VerifyFraudProof(ctx, req.(*RequestVerifyFraudProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -4185,6 +5070,18 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplySnapshotChunk",
 			Handler:    _ABCIApplication_ApplySnapshotChunk_Handler,
 		},
+		{
+			MethodName: "GetAppHash",
+			Handler:    _ABCIApplication_GetAppHash_Handler,
+		},
+		{
+			MethodName: "GenerateFraudProof",
+			Handler:    _ABCIApplication_GenerateFraudProof_Handler,
+		},
+		{
+			MethodName: "VerifyFraudProof",
+			Handler:    _ABCIApplication_VerifyFraudProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -4537,14 +5434,83 @@ func (m *Request_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	}
 	return len(dAtA) - i, nil
 }
-func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
+func (m *Request_GetAppHash) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_GetAppHash) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GetAppHash != nil {
+		{
+			size, err := m.GetAppHash.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_GenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_GenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GenerateFraudProof != nil {
+		{
+			size, err := m.GenerateFraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_VerifyFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_VerifyFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.VerifyFraudProof != nil {
+		{
+			size, err := m.VerifyFraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
 func (m *RequestEcho) MarshalTo(dAtA []byte) (int, error) {
@@ -4739,12 +5705,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err17 != nil {
-		return 0, err17
+	n20, err20 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err20 != nil {
+		return 0, err20
 	}
-	i -= n17
-	i = encodeVarintTypes(dAtA, i, uint64(n17))
+	i -= n20
+	i = encodeVarintTypes(dAtA, i, uint64(n20))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -4900,6 +5866,26 @@ func (m *RequestCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ProposerAddress) > 0 {
+		i -= len(m.ProposerAddress)
+		copy(dAtA[i:], m.ProposerAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProposerAddress)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	n23, err23 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err23 != nil {
+		return 0, err23
+	}
+	i -= n23
+	i = encodeVarintTypes(dAtA, i, uint64(n23))
+	i--
+	dAtA[i] = 0x22
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Type != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
 		i--
@@ -5141,6 +6127,130 @@ func (m *RequestApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestGetAppHash) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGetAppHash) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGetAppHash) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestGenerateFraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EndBlockRequest != nil {
+		{
+			size, err := m.EndBlockRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.DeliverTxRequests) > 0 {
+		for iNdEx := len(m.DeliverTxRequests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeliverTxRequests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.BeginBlockRequest.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestVerifyFraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestVerifyFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestVerifyFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ExpectedValidAppHash) > 0 {
+		i -= len(m.ExpectedValidAppHash)
+		copy(dAtA[i:], m.ExpectedValidAppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ExpectedValidAppHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.FraudProof != nil {
+		{
+			size, err := m.FraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5511,6 +6621,75 @@ func (m *Response_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, er
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_GetAppHash) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_GetAppHash) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GetAppHash != nil {
+		{
+			size, err := m.GetAppHash.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_GenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_GenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GenerateFraudProof != nil {
+		{
+			size, err := m.GenerateFraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_VerifyFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_VerifyFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.VerifyFraudProof != nil {
+		{
+			size, err := m.VerifyFraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6167,6 +7346,20 @@ func (m *ResponseEndBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.KeyRotations) > 0 {
+		for iNdEx := len(m.KeyRotations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.KeyRotations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if m.RollappParamUpdates != nil {
 		{
 			size, err := m.RollappParamUpdates.MarshalToSizedBuffer(dAtA[:i])
@@ -6382,20 +7575,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA44 := make([]byte, len(m.RefetchChunks)*10)
-		var j43 int
+		dAtA54 := make([]byte, len(m.RefetchChunks)*10)
+		var j53 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA44[j43] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA54[j53] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j43++
+				j53++
 			}
-			dAtA44[j43] = uint8(num)
-			j43++
+			dAtA54[j53] = uint8(num)
+			j53++
 		}
-		i -= j43
-		copy(dAtA[i:], dAtA44[:j43])
-		i = encodeVarintTypes(dAtA, i, uint64(j43))
+		i -= j53
+		copy(dAtA[i:], dAtA54[:j53])
+		i = encodeVarintTypes(dAtA, i, uint64(j53))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6407,7 +7600,7 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
-func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+func (m *ResponseGetAppHash) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6417,25 +7610,159 @@ func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseGetAppHash) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ResponseGetAppHash) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Version != nil {
-		{
-			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseGenerateFraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseGenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseGenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FraudProof != nil {
+		{
+			size, err := m.FraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseVerifyFraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseVerifyFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseVerifyFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Success {
+		i--
+		if m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Feature != nil {
+		{
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Synchrony != nil {
+		{
+			size, err := m.Synchrony.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Timeout != nil {
+		{
+			size, err := m.Timeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Version != nil {
+		{
+			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0x22
 	}
@@ -6749,6 +8076,13 @@ func (m *ValidatorUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ProofOfPossession) > 0 {
+		i -= len(m.ProofOfPossession)
+		copy(dAtA[i:], m.ProofOfPossession)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProofOfPossession)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Power != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
 		i--
@@ -6767,6 +8101,68 @@ func (m *ValidatorUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *KeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ProofOfPossession) > 0 {
+		i -= len(m.ProofOfPossession)
+		copy(dAtA[i:], m.ProofOfPossession)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProofOfPossession)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size, err := m.NewPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.OldPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *VoteInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6835,12 +8231,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n52, err52 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err52 != nil {
-		return 0, err52
+	n68, err68 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err68 != nil {
+		return 0, err68
 	}
-	i -= n52
-	i = encodeVarintTypes(dAtA, i, uint64(n52))
+	i -= n68
+	i = encodeVarintTypes(dAtA, i, uint64(n68))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6953,58 +8349,269 @@ func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *FraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
-	}
-	return n
+
+func (m *FraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Request_Echo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *FraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Echo != nil {
-		l = m.Echo.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	return n
-}
-func (m *Request_Flush) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.FraudulentEndBlock != nil {
+		{
+			size, err := m.FraudulentEndBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	var l int
-	_ = l
-	if m.Flush != nil {
-		l = m.Flush.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if m.FraudulentDeliverTx != nil {
+		{
+			size, err := m.FraudulentDeliverTx.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
 	}
-	return n
-}
-func (m *Request_Info) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.FraudulentBeginBlock != nil {
+		{
+			size, err := m.FraudulentBeginBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	var l int
+	if len(m.StateWitness) > 0 {
+		for k := range m.StateWitness {
+			v := m.StateWitness[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintTypes(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintTypes(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintTypes(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ExpectedValidAppHash) > 0 {
+		i -= len(m.ExpectedValidAppHash)
+		copy(dAtA[i:], m.ExpectedValidAppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ExpectedValidAppHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PreStateAppHash) > 0 {
+		i -= len(m.PreStateAppHash)
+		copy(dAtA[i:], m.PreStateAppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.PreStateAppHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StateWitness) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateWitness) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateWitness) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.WitnessData) > 0 {
+		for iNdEx := len(m.WitnessData) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.WitnessData[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WitnessData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WitnessData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WitnessData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Proofs) > 0 {
+		for iNdEx := len(m.Proofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Proofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *Request_Echo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Echo != nil {
+		l = m.Echo.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Flush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Flush != nil {
+		l = m.Flush.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Info) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
 	_ = l
 	if m.Info != nil {
 		l = m.Info.Size()
@@ -7156,6 +8763,42 @@ func (m *Request_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_GetAppHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GetAppHash != nil {
+		l = m.GetAppHash.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_GenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GenerateFraudProof != nil {
+		l = m.GenerateFraudProof.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_VerifyFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VerifyFraudProof != nil {
+		l = m.VerifyFraudProof.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7315,6 +8958,15 @@ func (m *RequestCheckTx) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovTypes(uint64(m.Type))
 	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7416,6 +9068,53 @@ func (m *RequestApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *RequestGetAppHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RequestGenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.BeginBlockRequest.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.DeliverTxRequests) > 0 {
+		for _, e := range m.DeliverTxRequests {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.EndBlockRequest != nil {
+		l = m.EndBlockRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestVerifyFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FraudProof != nil {
+		l = m.FraudProof.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ExpectedValidAppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7620,54 +9319,90 @@ func (m *Response_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
-func (m *ResponseException) Size() (n int) {
+func (m *Response_GetAppHash) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.GetAppHash != nil {
+		l = m.GetAppHash.Size()
+		n += 2 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-
-func (m *ResponseEcho) Size() (n int) {
+func (m *Response_GenerateFraudProof) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.GenerateFraudProof != nil {
+		l = m.GenerateFraudProof.Size()
+		n += 2 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-
-func (m *ResponseFlush) Size() (n int) {
+func (m *Response_VerifyFraudProof) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.VerifyFraudProof != nil {
+		l = m.VerifyFraudProof.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
 	return n
 }
-
-func (m *ResponseInfo) Size() (n int) {
+func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Data)
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
+func (m *ResponseEcho) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseFlush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ResponseInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
 	}
 	if m.AppVersion != 0 {
 		n += 1 + sovTypes(uint64(m.AppVersion))
@@ -7947,6 +9682,12 @@ func (m *ResponseEndBlock) Size() (n int) {
 		l = m.RollappParamUpdates.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.KeyRotations) > 0 {
+		for _, e := range m.KeyRotations {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -8031,6 +9772,44 @@ func (m *ResponseApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *ResponseGetAppHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseGenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FraudProof != nil {
+		l = m.FraudProof.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseVerifyFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Success {
+		n += 2
+	}
+	return n
+}
+
 func (m *ConsensusParams) Size() (n int) {
 	if m == nil {
 		return 0
@@ -8053,6 +9832,18 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Version.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Timeout != nil {
+		l = m.Timeout.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Synchrony != nil {
+		l = m.Synchrony.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8176,6 +9967,34 @@ func (m *ValidatorUpdate) Size() (n int) {
 	if m.Power != 0 {
 		n += 1 + sovTypes(uint64(m.Power))
 	}
+	l = len(m.ProofOfPossession)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.OldPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.NewPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ProofOfPossession)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8257,6 +10076,97 @@ func (m *Snapshot) Size() (n int) {
 	return n
 }
 
+func (m *FraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BlockHeight))
+	}
+	l = len(m.PreStateAppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ExpectedValidAppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.StateWitness) > 0 {
+		for k, v := range m.StateWitness {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovTypes(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovTypes(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovTypes(uint64(mapEntrySize))
+		}
+	}
+	if m.FraudulentBeginBlock != nil {
+		l = m.FraudulentBeginBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.FraudulentDeliverTx != nil {
+		l = m.FraudulentDeliverTx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.FraudulentEndBlock != nil {
+		l = m.FraudulentEndBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *StateWitness) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.WitnessData) > 0 {
+		for _, e := range m.WitnessData {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WitnessData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Proofs) > 0 {
+		for _, e := range m.Proofs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -8817,61 +10727,46 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_ApplySnapshotChunk{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetAppHash", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestEcho) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &RequestGetAppHash{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestEcho: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestEcho: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Value = &Request_GetAppHash{v}
+			iNdEx = postIndex
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GenerateFraudProof", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -8881,35 +10776,155 @@ func (m *RequestEcho) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			v := &RequestGenerateFraudProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			m.Value = &Request_GenerateFraudProof{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyFraudProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestVerifyFraudProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_VerifyFraudProof{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestEcho) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestEcho: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
 		}
@@ -9929,6 +11944,92 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposerAddress = append(m.ProposerAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerAddress == nil {
+				m.ProposerAddress = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10565,7 +12666,7 @@ func (m *RequestApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RequestGetAppHash) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10588,15 +12689,338 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestGetAppHash: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestGetAppHash: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestGenerateFraudProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestGenerateFraudProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestGenerateFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlockRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BeginBlockRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxRequests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverTxRequests = append(m.DeliverTxRequests, &RequestDeliverTx{})
+			if err := m.DeliverTxRequests[len(m.DeliverTxRequests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndBlockRequest == nil {
+				m.EndBlockRequest = &RequestEndBlock{}
+			}
+			if err := m.EndBlockRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestVerifyFraudProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestVerifyFraudProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestVerifyFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FraudProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FraudProof == nil {
+				m.FraudProof = &FraudProof{}
+			}
+			if err := m.FraudProof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedValidAppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpectedValidAppHash = append(m.ExpectedValidAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ExpectedValidAppHash == nil {
+				m.ExpectedValidAppHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11154,8 +13578,113 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Response_ApplySnapshotChunk{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetAppHash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseGetAppHash{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_GetAppHash{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenerateFraudProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseGenerateFraudProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_GenerateFraudProof{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyFraudProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseVerifyFraudProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_VerifyFraudProof{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
 			if err != nil {
 				return err
@@ -13268,6 +15797,40 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyRotations = append(m.KeyRotations, KeyRotation{})
+			if err := m.KeyRotations[len(m.KeyRotations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13806,7 +16369,7 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
+func (m *ResponseGetAppHash) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13829,17 +16392,17 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConsensusParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseGetAppHash: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseGetAppHash: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13849,103 +16412,79 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Block == nil {
-				m.Block = &BlockParams{}
-			}
-			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Evidence == nil {
-				m.Evidence = &types1.EvidenceParams{}
-			}
-			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseGenerateFraudProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Validator == nil {
-				m.Validator = &types1.ValidatorParams{}
-			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseGenerateFraudProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseGenerateFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FraudProof", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13972,10 +16511,10 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Version == nil {
-				m.Version = &types1.VersionParams{}
+			if m.FraudProof == nil {
+				m.FraudProof = &FraudProof{}
 			}
-			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.FraudProof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14000,7 +16539,7 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockParams) Unmarshal(dAtA []byte) error {
+func (m *ResponseVerifyFraudProof) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14023,7 +16562,379 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseVerifyFraudProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseVerifyFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Success = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConsensusParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &BlockParams{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Evidence == nil {
+				m.Evidence = &types1.EvidenceParams{}
+			}
+			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validator == nil {
+				m.Validator = &types1.ValidatorParams{}
+			}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Version == nil {
+				m.Version = &types1.VersionParams{}
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timeout == nil {
+				m.Timeout = &types1.TimeoutParams{}
+			}
+			if err := m.Timeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Synchrony", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Synchrony == nil {
+				m.Synchrony = &types1.SynchronyParams{}
+			}
+			if err := m.Synchrony.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Feature == nil {
+				m.Feature = &types1.FeatureParams{}
+			}
+			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
 			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
@@ -14031,9 +16942,643 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+			}
+			m.MaxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGas |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LastCommitInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LastCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, VoteInfo{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Event) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attributes = append(m.Attributes, EventAttribute{})
+			if err := m.Attributes[len(m.Attributes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventAttribute) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventAttribute: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventAttribute: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Index = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tx == nil {
+				m.Tx = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Validator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
 			}
-			m.MaxBytes = 0
+			m.Power = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14043,16 +17588,99 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxBytes |= int64(b&0x7F) << shift
+				m.Power |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
 			}
-			m.MaxGas = 0
+			m.Power = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14062,11 +17690,45 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxGas |= int64(b&0x7F) << shift
+				m.Power |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOfPossession", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProofOfPossession = append(m.ProofOfPossession[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProofOfPossession == nil {
+				m.ProofOfPossession = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14088,7 +17750,7 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
+func (m *KeyRotation) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14111,17 +17773,17 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LastCommitInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: KeyRotation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LastCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldPubKey", wireType)
 			}
-			m.Round = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14131,14 +17793,28 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Round |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.OldPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPubKey", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14165,66 +17841,34 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Votes = append(m.Votes, VoteInfo{})
-			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.NewPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Event) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Event: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14234,29 +17878,31 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOfPossession", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14266,24 +17912,24 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attributes = append(m.Attributes, EventAttribute{})
-			if err := m.Attributes[len(m.Attributes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ProofOfPossession = append(m.ProofOfPossession[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProofOfPossession == nil {
+				m.ProofOfPossession = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -14307,7 +17953,7 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EventAttribute) Unmarshal(dAtA []byte) error {
+func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14330,17 +17976,17 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EventAttribute: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EventAttribute: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14350,63 +17996,28 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -14423,7 +18034,7 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Index = bool(v != 0)
+			m.SignedLastBlock = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14445,7 +18056,7 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TxResult) Unmarshal(dAtA []byte) error {
+func (m *Evidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14468,17 +18079,17 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			m.Height = 0
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14488,16 +18099,16 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				m.Type |= EvidenceType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
-			m.Index = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14507,16 +18118,49 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14526,31 +18170,30 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
-			if m.Tx == nil {
-				m.Tx = []byte{}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
 			}
-			var msglen int
+			m.TotalVotingPower = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14560,25 +18203,11 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.TotalVotingPower |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14600,7 +18229,7 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Validator) Unmarshal(dAtA []byte) error {
+func (m *RollappParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14623,17 +18252,17 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
+			return fmt.Errorf("proto: RollappParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RollappParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Da", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14643,31 +18272,29 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
-			if m.Address == nil {
-				m.Address = []byte{}
-			}
+			m.Da = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DrsVersion", wireType)
 			}
-			m.Power = 0
+			m.DrsVersion = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14677,7 +18304,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= int64(b&0x7F) << shift
+				m.DrsVersion |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -14703,7 +18330,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
+func (m *Snapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14726,17 +18353,74 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
+			}
+			m.Chunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Chunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14746,30 +18430,31 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			m.Power = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14779,11 +18464,26 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14805,7 +18505,7 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteInfo) Unmarshal(dAtA []byte) error {
+func (m *FraudProof) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14828,17 +18528,17 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: FraudProof: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
 			}
-			var msglen int
+			m.BlockHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14848,30 +18548,16 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.BlockHeight |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreStateAppHash", wireType)
 			}
-			var v int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14881,67 +18567,31 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SignedLastBlock = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Evidence) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.PreStateAppHash = append(m.PreStateAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.PreStateAppHash == nil {
+				m.PreStateAppHash = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedValidAppHash", wireType)
 			}
-			m.Type = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14951,14 +18601,29 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= EvidenceType(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpectedValidAppHash = append(m.ExpectedValidAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ExpectedValidAppHash == nil {
+				m.ExpectedValidAppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StateWitness", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14985,15 +18650,111 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.StateWitness == nil {
+				m.StateWitness = make(map[string]*StateWitness)
+			}
+			var mapkey string
+			var mapvalue *StateWitness
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthTypes
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthTypes
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthTypes
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthTypes
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &StateWitness{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipTypes(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthTypes
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
 			}
+			m.StateWitness[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FraudulentBeginBlock", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15003,14 +18764,31 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FraudulentBeginBlock == nil {
+				m.FraudulentBeginBlock = &RequestBeginBlock{}
+			}
+			if err := m.FraudulentBeginBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FraudulentDeliverTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15037,15 +18815,18 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+			if m.FraudulentDeliverTx == nil {
+				m.FraudulentDeliverTx = &RequestDeliverTx{}
+			}
+			if err := m.FraudulentDeliverTx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FraudulentEndBlock", wireType)
 			}
-			m.TotalVotingPower = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15055,11 +18836,28 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalVotingPower |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FraudulentEndBlock == nil {
+				m.FraudulentEndBlock = &RequestEndBlock{}
+			}
+			if err := m.FraudulentEndBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15081,7 +18879,7 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RollappParams) Unmarshal(dAtA []byte) error {
+func (m *StateWitness) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15104,17 +18902,17 @@ func (m *RollappParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RollappParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: StateWitness: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RollappParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StateWitness: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Da", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15124,29 +18922,33 @@ func (m *RollappParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Da = string(dAtA[iNdEx:postIndex])
+			if m.Proof == nil {
+				m.Proof = &crypto.ProofOp{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DrsVersion", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
 			}
-			m.DrsVersion = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15156,11 +18958,60 @@ func (m *RollappParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DrsVersion |= uint32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WitnessData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WitnessData = append(m.WitnessData, &WitnessData{})
+			if err := m.WitnessData[len(m.WitnessData)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15182,7 +19033,7 @@ func (m *RollappParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Snapshot) Unmarshal(dAtA []byte) error {
+func (m *WitnessData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15205,17 +19056,17 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
+			return fmt.Errorf("proto: WitnessData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WitnessData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.Height = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15225,52 +19076,29 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.Format = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Format |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Chunks = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Chunks |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
-		case 4:
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -15297,16 +19125,16 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proofs", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15316,24 +19144,24 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
-			if m.Metadata == nil {
-				m.Metadata = []byte{}
+			m.Proofs = append(m.Proofs, &crypto.ProofOp{})
+			if err := m.Proofs[len(m.Proofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -104,6 +104,28 @@ func (pool *BlockPool) OnStart() error {
 	return nil
 }
 
+// OnReset implements service.Service by dropping all requesters and peers, so
+// the pool can be started again after the node fell back from consensus.
+func (pool *BlockPool) OnReset() error {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	for _, requester := range pool.requesters {
+		// requesters of a stopped pool may have already exited
+		_ = requester.Stop()
+	}
+	for _, peer := range pool.peers {
+		if peer.timeout != nil {
+			peer.timeout.Stop()
+		}
+	}
+	pool.requesters = make(map[int64]*bpRequester)
+	pool.peers = make(map[p2p.ID]*bpPeer)
+	pool.maxPeerHeight = 0
+	atomic.StoreInt32(&pool.numPending, 0)
+	return nil
+}
+
 // spawns requesters as needed
 func (pool *BlockPool) makeRequestersRoutine() {
 	for {
@@ -1,6 +1,7 @@
 package v0
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -9,6 +10,7 @@ import (
 
 	bc "github.com/tendermint/tendermint/blockchain"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
 	sm "github.com/tendermint/tendermint/state"
@@ -30,12 +32,18 @@ const (
 	statusUpdateIntervalSeconds = 10
 	// check if we should switch to consensus reactor
 	switchToConsensusIntervalSeconds = 1
+
+	// default number of consecutive caught up checks required before
+	// switching to consensus
+	defaultSwitchHysteresis = 1
 )
 
 type consensusReactor interface {
 	// for when we switch from blockchain reactor and fast sync to
 	// the consensus machine
 	SwitchToConsensus(state sm.State, skipWAL bool)
+	// for when an operator forces the node back into fast sync
+	SwitchToFastSync() (sm.State, error)
 }
 
 type peerError struct {
@@ -51,21 +59,42 @@ func (e peerError) Error() string {
 type BlockchainReactor struct {
 	p2p.BaseReactor
 
-	// immutable
-	initialState sm.State
-
 	blockExec *sm.BlockExecutor
 	store     *store.BlockStore
 	pool      *BlockPool
-	fastSync  bool
+	eventBus  *types.EventBus
+
+	mtx          cmtsync.RWMutex
+	initialState sm.State
+	fastSync     bool
 
-	requestsCh <-chan BlockRequest
-	errorsCh   <-chan peerError
+	// number of blocks to apply while caught up, or of consecutive caught up
+	// checks without any block to apply, before switching to consensus
+	switchHysteresis int
+
+	requestsCh    <-chan BlockRequest
+	errorsCh      <-chan peerError
+	forceSwitchCh chan struct{}
+}
+
+// ReactorOption sets an optional parameter on the BlockchainReactor.
+type ReactorOption func(*BlockchainReactor)
+
+// WithSwitchHysteresis sets the number of blocks the node must apply while
+// caught up before switching to consensus, or, if the chain doesn't progress,
+// e.g. when the whole network restarts, the number of consecutive checks, one
+// per second, it must be caught up without any block to apply.
+func WithSwitchHysteresis(n int) ReactorOption {
+	return func(bcR *BlockchainReactor) {
+		if n > 0 {
+			bcR.switchHysteresis = n
+		}
+	}
 }
 
 // NewBlockchainReactor returns new reactor instance.
 func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
-	fastSync bool) *BlockchainReactor {
+	fastSync bool, options ...ReactorOption) *BlockchainReactor {
 
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
@@ -84,18 +113,28 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 	pool := NewBlockPool(startHeight, requestsCh, errorsCh)
 
 	bcR := &BlockchainReactor{
-		initialState: state,
-		blockExec:    blockExec,
-		store:        store,
-		pool:         pool,
-		fastSync:     fastSync,
-		requestsCh:   requestsCh,
-		errorsCh:     errorsCh,
+		initialState:     state,
+		blockExec:        blockExec,
+		store:            store,
+		pool:             pool,
+		fastSync:         fastSync,
+		switchHysteresis: defaultSwitchHysteresis,
+		requestsCh:       requestsCh,
+		errorsCh:         errorsCh,
+		forceSwitchCh:    make(chan struct{}, 1),
+	}
+	for _, option := range options {
+		option(bcR)
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
 }
 
+// SetEventBus sets the event bus used to publish fast sync status changes.
+func (bcR *BlockchainReactor) SetEventBus(b *types.EventBus) {
+	bcR.eventBus = b
+}
+
 // SetLogger implements service.Service by setting the logger on reactor and pool.
 func (bcR *BlockchainReactor) SetLogger(l log.Logger) {
 	bcR.BaseService.Logger = l
@@ -104,7 +143,7 @@ func (bcR *BlockchainReactor) SetLogger(l log.Logger) {
 
 // OnStart implements service.Service.
 func (bcR *BlockchainReactor) OnStart() error {
-	if bcR.fastSync {
+	if bcR.IsFastSyncing() {
 		err := bcR.pool.Start()
 		if err != nil {
 			return err
@@ -116,27 +155,114 @@ func (bcR *BlockchainReactor) OnStart() error {
 
 // SwitchToFastSync is called by the state sync reactor when switching to fast sync.
 func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
-	bcR.fastSync = true
-	bcR.initialState = state
+	bcR.setFastSync(true, state)
 
 	bcR.pool.height = state.LastBlockHeight + 1
 	err := bcR.pool.Start()
 	if err != nil {
 		return err
 	}
+	bcR.publishStatus(false, state.LastBlockHeight, false)
 	go bcR.poolRoutine(true)
 	return nil
 }
 
+// IsFastSyncing returns true if the reactor is currently fast syncing.
+func (bcR *BlockchainReactor) IsFastSyncing() bool {
+	bcR.mtx.RLock()
+	defer bcR.mtx.RUnlock()
+	return bcR.fastSync
+}
+
+// setFastSync sets whether the reactor is fast syncing, from state if it is.
+// The forced switch to consensus pending, if any, is dropped, since it was
+// meant for the previous mode.
+func (bcR *BlockchainReactor) setFastSync(fastSync bool, state sm.State) {
+	bcR.mtx.Lock()
+	defer bcR.mtx.Unlock()
+	bcR.fastSync = fastSync
+	if fastSync {
+		bcR.initialState = state
+	}
+	select {
+	case <-bcR.forceSwitchCh:
+	default:
+	}
+}
+
+// ForceSwitchToConsensus makes the reactor switch to consensus at the next
+// check, regardless of whether the node is caught up with its peers.
+func (bcR *BlockchainReactor) ForceSwitchToConsensus() error {
+	// the mode changes with mtx held, so that the switch is never left
+	// pending for the next mode, see setFastSync
+	bcR.mtx.RLock()
+	defer bcR.mtx.RUnlock()
+	if !bcR.fastSync {
+		return errors.New("node is not fast syncing")
+	}
+	select {
+	case bcR.forceSwitchCh <- struct{}{}:
+	default:
+		// a switch is already pending
+	}
+	return nil
+}
+
+// ForceSwitchToFastSync stops the consensus state-machine and resumes fast
+// sync from the latest committed state.
+func (bcR *BlockchainReactor) ForceSwitchToFastSync() error {
+	if bcR.IsFastSyncing() {
+		return errors.New("node is already fast syncing")
+	}
+	conR, ok := bcR.Switch.Reactor("CONSENSUS").(consensusReactor)
+	if !ok {
+		return errors.New("consensus reactor not found")
+	}
+	state, err := conR.SwitchToFastSync()
+	if err != nil {
+		return err
+	}
+
+	// The pool is stopped once we switch to consensus. Reset fails if the
+	// pool was never started, i.e. the node did not fast sync on startup, in
+	// which case it can be started as is.
+	_ = bcR.pool.Reset()
+
+	bcR.setFastSync(true, state)
+
+	bcR.pool.height = state.LastBlockHeight + 1
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+	bcR.publishStatus(false, state.LastBlockHeight, true)
+	go bcR.BroadcastStatusRequest() //nolint: errcheck
+	go bcR.poolRoutine(false)
+	return nil
+}
+
 // OnStop implements service.Service.
 func (bcR *BlockchainReactor) OnStop() {
-	if bcR.fastSync {
+	if bcR.IsFastSyncing() {
 		if err := bcR.pool.Stop(); err != nil {
 			bcR.Logger.Error("Error stopping pool", "err", err)
 		}
 	}
 }
 
+func (bcR *BlockchainReactor) publishStatus(complete bool, height int64, forced bool) {
+	if bcR.eventBus == nil {
+		return
+	}
+	err := bcR.eventBus.PublishEventFastSyncStatus(types.EventDataFastSyncStatus{
+		Complete: complete,
+		Height:   height,
+		Forced:   forced,
+	})
+	if err != nil {
+		bcR.Logger.Error("Failed to publish fast sync status", "err", err)
+	}
+}
+
 // GetChannels implements Reactor
 func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -265,14 +391,36 @@ func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
 
 	blocksSynced := uint64(0)
 
+	// blocks applied while caught up, and consecutive caught up checks
+	// without any block applied, see switchHysteresis
+	caughtUpBlocks, idleChecks := 0, 0
+	lastCheckBlocksSynced := blocksSynced
+
+	bcR.mtx.RLock()
 	chainID := bcR.initialState.ChainID
 	state := bcR.initialState
+	bcR.mtx.RUnlock()
 
 	lastHundred := time.Now()
 	lastRate := 0.0
 
 	didProcessCh := make(chan struct{}, 1)
 
+	switchToConsensus := func(forced bool) {
+		if err := bcR.pool.Stop(); err != nil {
+			bcR.Logger.Error("Error stopping pool", "err", err)
+		}
+		bcR.setFastSync(false, state)
+		conR, ok := bcR.Switch.Reactor("CONSENSUS").(consensusReactor)
+		if ok {
+			conR.SwitchToConsensus(state, blocksSynced > 0 || stateSynced)
+		}
+		// else {
+		// should only happen during testing
+		// }
+		bcR.publishStatus(true, state.LastBlockHeight, forced)
+	}
+
 	go func() {
 		for {
 			select {
@@ -314,21 +462,30 @@ FOR_LOOP:
 			outbound, inbound, _ := bcR.Switch.NumPeers()
 			bcR.Logger.Debug("Consensus ticker", "numPending", numPending, "total", lenRequesters,
 				"outbound", outbound, "inbound", inbound)
-			if bcR.pool.IsCaughtUp() {
-				bcR.Logger.Info("Time to switch to consensus reactor!", "height", height)
-				if err := bcR.pool.Stop(); err != nil {
-					bcR.Logger.Error("Error stopping pool", "err", err)
-				}
-				conR, ok := bcR.Switch.Reactor("CONSENSUS").(consensusReactor)
-				if ok {
-					conR.SwitchToConsensus(state, blocksSynced > 0 || stateSynced)
-				}
-				// else {
-				// should only happen during testing
-				// }
-
-				break FOR_LOOP
+			applied := blocksSynced > lastCheckBlocksSynced
+			lastCheckBlocksSynced = blocksSynced
+			if !bcR.pool.IsCaughtUp() {
+				caughtUpBlocks, idleChecks = 0, 0
+				continue FOR_LOOP
+			}
+			if applied {
+				idleChecks = 0
+			} else {
+				idleChecks++
 			}
+			if caughtUpBlocks < bcR.switchHysteresis && idleChecks < bcR.switchHysteresis {
+				bcR.Logger.Debug("Caught up, waiting before switching to consensus",
+					"blocks", caughtUpBlocks, "idle_checks", idleChecks, "required", bcR.switchHysteresis)
+				continue FOR_LOOP
+			}
+			bcR.Logger.Info("Time to switch to consensus reactor!", "height", height)
+			switchToConsensus(false)
+			break FOR_LOOP
+
+		case <-bcR.forceSwitchCh:
+			bcR.Logger.Info("Forced switch to consensus reactor", "height", bcR.pool.height)
+			switchToConsensus(true)
+			break FOR_LOOP
 
 		case <-trySyncTicker.C: // chan time
 			select {
@@ -403,6 +560,11 @@ FOR_LOOP:
 				panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
 			}
 			blocksSynced++
+			if bcR.pool.IsCaughtUp() {
+				caughtUpBlocks++
+			} else {
+				caughtUpBlocks = 0
+			}
 
 			if blocksSynced%100 == 0 {
 				lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
@@ -1,6 +1,7 @@
 package v0
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -194,6 +195,68 @@ func TestNoBlockResponse(t *testing.T) {
 	}
 }
 
+func TestForceSwitchToConsensus(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	reactorPair := newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	reactorPair.reactor.SetEventBus(eventBus)
+
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryFastSyncStatus)
+	require.NoError(t, err)
+
+	// without peers the node never catches up on its own
+	p2p.MakeConnectedSwitches(config.P2P, 1, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKCHAIN", reactorPair.reactor)
+		return s
+	}, p2p.Connect2Switches)
+	defer func() {
+		require.NoError(t, reactorPair.reactor.Stop())
+		require.NoError(t, reactorPair.app.Stop())
+	}()
+
+	require.True(t, reactorPair.reactor.IsFastSyncing())
+	require.NoError(t, reactorPair.reactor.ForceSwitchToConsensus())
+
+	select {
+	case msg := <-sub.Out():
+		data, ok := msg.Data().(types.EventDataFastSyncStatus)
+		require.True(t, ok)
+		assert.True(t, data.Complete)
+		assert.True(t, data.Forced)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a fast sync status event")
+	}
+
+	assert.False(t, reactorPair.reactor.IsFastSyncing())
+	assert.Error(t, reactorPair.reactor.ForceSwitchToConsensus())
+}
+
+func TestModeSwitchDropsForcedSwitch(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+	reactorPair := newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
+	defer reactorPair.app.Stop() //nolint:errcheck // ignore for tests
+	reactor := reactorPair.reactor
+
+	// a forced switch pending when the node switches on its own doesn't
+	// apply to the next fast sync
+	require.NoError(t, reactor.ForceSwitchToConsensus())
+	require.Len(t, reactor.forceSwitchCh, 1)
+	reactor.setFastSync(false, sm.State{})
+	assert.Empty(t, reactor.forceSwitchCh)
+	assert.Error(t, reactor.ForceSwitchToConsensus())
+
+	reactor.setFastSync(true, reactor.initialState)
+	require.NoError(t, reactor.ForceSwitchToConsensus())
+	assert.Len(t, reactor.forceSwitchCh, 1)
+}
+
 func TestLegacyReactorReceiveBasic(t *testing.T) {
 	config = cfg.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/progressbar"
+	"github.com/tendermint/tendermint/store"
+)
+
+// verifyBatchSize is the number of heights verified between the updates of
+// the progress.
+const verifyBatchSize = 1000
+
+var (
+	verifyFrom   int64
+	verifyTo     int64
+	verifyRepair bool
+	verifyYes    bool
+
+	archiveFrom int64
+	archiveTo   int64
+)
+
+// BlockStoreCmd groups the commands on the block store of a stopped node.
+var BlockStoreCmd = &cobra.Command{
+	Use:   "blockstore",
+	Short: "Inspect the block store of a stopped node",
+}
+
+var blockStoreVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the consistency of the blocks of the block store",
+	Long: `Check that every height of the block store, between its base and its height,
+has a meta, all the parts of its block, matching the meta, and a canonical
+commit of the block, or a seen commit at the latest height, e.g. after an
+unclean shutdown. The node must be stopped.
+
+The problems found are reported by height, and the command fails if any is. The
+missing or corrupted metas of the blocks whose parts are complete can be
+rebuilt from the parts with --repair, after a confirmation, unless --yes is
+set. The other problems can be repaired with the repair command, or by
+restoring the data from a backup. The block store is opened read-only, unless
+--repair is set.`,
+	Example: `
+	cometbft blockstore verify
+	cometbft blockstore verify --from 1000 --to 2000 --repair
+	`,
+	Args: cobra.NoArgs,
+	RunE: verifyBlockStore,
+}
+
+var blockStoreExportCmd = &cobra.Command{
+	Use:   "export <archive>",
+	Short: "Export the blocks of the block store to an archive",
+	Long: `Export the blocks of the block store, with their commits, to an archive file,
+which can be imported into the block store of another node with the import
+command, e.g. to bootstrap it. The node must be stopped.`,
+	Example: `
+	cometbft blockstore export blocks.archive
+	cometbft blockstore export blocks.archive --from 1000 --to 2000
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: exportBlockStore,
+}
+
+var blockStoreImportCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Import the blocks of an archive into the block store",
+	Long: `Import the blocks of an archive written by the export command into the block
+store, which is created if it doesn't exist. The blocks are checked against
+their metas and commits, and must follow those of the block store: the blocks
+it holds already are skipped. The signatures of the commits aren't verified,
+so the archive must come from a trusted source. The node must be stopped.`,
+	Example: `
+	cometbft blockstore import blocks.archive
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: importBlockStore,
+}
+
+var blockStoreIndexTimesCmd = &cobra.Command{
+	Use:   "index-times",
+	Short: "Index the blocks of the block store by time",
+	Long: `Index the blocks of the block store by time, for the block_by_time RPC
+endpoint. The blocks saved since the index was introduced are indexed when
+saved, so it's only needed once for the blocks saved before. The node must be
+stopped.`,
+	Args: cobra.NoArgs,
+	RunE: indexBlockStoreTimes,
+}
+
+func init() {
+	blockStoreVerifyCmd.Flags().Int64Var(&verifyFrom, "from", 0,
+		"first height to verify, defaults to the base of the block store")
+	blockStoreVerifyCmd.Flags().Int64Var(&verifyTo, "to", 0,
+		"last height to verify, defaults to the height of the block store")
+	blockStoreVerifyCmd.Flags().BoolVar(&verifyRepair, "repair", false,
+		"rebuild the missing or corrupted metas from the parts of their blocks")
+	blockStoreVerifyCmd.Flags().BoolVar(&verifyYes, "yes", false,
+		"repair without asking for a confirmation")
+	BlockStoreCmd.AddCommand(blockStoreVerifyCmd)
+
+	blockStoreExportCmd.Flags().Int64Var(&archiveFrom, "from", 0,
+		"first height to export, defaults to the base of the block store")
+	blockStoreExportCmd.Flags().Int64Var(&archiveTo, "to", 0,
+		"last height to export, defaults to the height of the block store")
+	BlockStoreCmd.AddCommand(blockStoreExportCmd)
+	BlockStoreCmd.AddCommand(blockStoreImportCmd)
+	BlockStoreCmd.AddCommand(blockStoreIndexTimesCmd)
+}
+
+// openBlockStore opens the block store of the node, which must exist unless
+// create is set.
+func openBlockStore(create bool) (*store.BlockStore, error) {
+	db, err := openBlockStoreDB(create)
+	if err != nil {
+		return nil, err
+	}
+	return store.NewBlockStore(db, store.WithCompression(config.Storage.BlockCompression)), nil
+}
+
+// openReadOnlyBlockStore opens the existing block store of the node
+// read-only, for the commands which don't write to it.
+func openReadOnlyBlockStore() (*store.BlockStore, error) {
+	db, err := openBlockStoreDB(false)
+	if err != nil {
+		return nil, err
+	}
+	return store.NewReadOnlyBlockStore(db), nil
+}
+
+func openBlockStoreDB(create bool) (dbm.DB, error) {
+	if !create && !cmtos.FileExists(filepath.Join(config.DBDirOf("blockstore"), "blockstore.db")) {
+		return nil, fmt.Errorf("no blockstore found in %v", config.DBDirOf("blockstore"))
+	}
+	return dbm.NewDB("blockstore", dbm.BackendType(config.DBBackendOf("blockstore")), config.DBDirOf("blockstore"))
+}
+
+func verifyBlockStore(cmd *cobra.Command, args []string) error {
+	open := openReadOnlyBlockStore
+	if verifyRepair {
+		open = func() (*store.BlockStore, error) { return openBlockStore(false) }
+	}
+	blockStore, err := open()
+	if err != nil {
+		return err
+	}
+	defer blockStore.Close()
+
+	from, to := verifyFrom, verifyTo
+	if from == 0 {
+		from = blockStore.Base()
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "verifying the blocks %d to %d\n", from, to)
+
+	var (
+		problems []store.Problem
+		bar      progressbar.Bar
+	)
+	bar.NewOption(from-1, to)
+	for start := from; start <= to; start += verifyBatchSize {
+		end := start + verifyBatchSize - 1
+		if end > to {
+			end = to
+		}
+		found, err := blockStore.Verify(start, end)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, found...)
+		bar.Play(end)
+	}
+	bar.Finish()
+
+	var repairable []int64
+	for _, p := range problems {
+		fmt.Fprintln(out, p)
+		if p.Repairable {
+			repairable = append(repairable, p.Height)
+		}
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "no problems found")
+		return nil
+	}
+
+	if verifyRepair && len(repairable) > 0 {
+		apply := verifyYes
+		if !apply {
+			action := fmt.Sprintf("rebuild the metas of %d blocks from their parts", len(repairable))
+			if apply, err = confirm(cmd, action); err != nil {
+				return err
+			}
+		}
+		if apply {
+			for _, height := range repairable {
+				if err := blockStore.RepairBlockMeta(height); err != nil {
+					return fmt.Errorf("repairing the meta of block %d: %w", height, err)
+				}
+			}
+			fmt.Fprintf(out, "rebuilt the metas of %d blocks\n", len(repairable))
+			if len(repairable) == len(problems) {
+				return nil
+			}
+			return fmt.Errorf("%d problems found, %d repaired", len(problems), len(repairable))
+		}
+	} else if len(repairable) > 0 {
+		fmt.Fprintf(out, "%d problems can be repaired with --repair\n", len(repairable))
+	}
+	return fmt.Errorf("%d problems found", len(problems))
+}
+
+func exportBlockStore(cmd *cobra.Command, args []string) error {
+	blockStore, err := openReadOnlyBlockStore()
+	if err != nil {
+		return err
+	}
+	defer blockStore.Close()
+
+	from, to := archiveFrom, archiveTo
+	if from == 0 {
+		from = blockStore.Base()
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+	f, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := blockStore.Export(f, from, to); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "exported the blocks %d to %d to %s\n", from, to, args[0])
+	return nil
+}
+
+func importBlockStore(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	blockStore, err := openBlockStore(true)
+	if err != nil {
+		return err
+	}
+	defer blockStore.Close()
+
+	height := blockStore.Height()
+	if err := blockStore.Import(f); err != nil {
+		return err
+	}
+	if blockStore.Height() == height {
+		fmt.Fprintln(cmd.OutOrStdout(), "no new blocks to import")
+		return nil
+	}
+	from := height + 1
+	if height == 0 {
+		from = blockStore.Base()
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "imported the blocks %d to %d\n", from, blockStore.Height())
+	return nil
+}
+
+func indexBlockStoreTimes(cmd *cobra.Command, args []string) error {
+	blockStore, err := openBlockStore(false)
+	if err != nil {
+		return err
+	}
+	defer blockStore.Close()
+
+	indexed, err := blockStore.IndexBlockTimes()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "indexed the times of %d blocks\n", indexed)
+	return nil
+}
@@ -17,6 +17,8 @@ var CompactGoLevelDBCmd = &cobra.Command{
 	Use:     "experimental-compact-goleveldb",
 	Aliases: []string{"experimental_compact_goleveldb"},
 	Short:   "force compacts the CometBFT storage engine (only GoLevelDB supported)",
+	Deprecated: "use compact-db instead, which also compacts the evidence and tx indexer " +
+		"databases and shows the progress",
 	Long: `
 This is a temporary utility command that performs a force compaction on the state 
 and blockstores to reduce disk space for a pruning node. This should only be run 
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/tendermint/tendermint/libs/progressbar"
+)
+
+// nodeDBs are the databases of the node, each with its backend and directory,
+// see config.DBDirOf.
+var nodeDBs = []string{"blockstore", "state", "evidence", "tx_index"}
+
+var compactDBNames []string
+
+// CompactDBCmd compacts the databases of the node.
+var CompactDBCmd = &cobra.Command{
+	Use:   "compact-db",
+	Short: "Compact the databases of the node to reclaim the space of the deleted data",
+	Long: `Compact the block store, state, evidence and tx indexer databases of the
+node, reclaiming the disk space of the data deleted by pruning, which is
+otherwise only reclaimed gradually. The node must be stopped.
+
+The databases are compacted one after the other, by slices of their key space,
+showing the progress of each of them and the space it reclaims. Only the
+goleveldb backend is supported.`,
+	Example: `
+	cometbft compact-db
+	cometbft compact-db --db blockstore,state
+	`,
+	RunE: compactDB,
+}
+
+func init() {
+	CompactDBCmd.Flags().StringSliceVar(&compactDBNames, "db", nodeDBs,
+		"databases to compact: blockstore, state, evidence, tx_index")
+}
+
+func compactDB(cmd *cobra.Command, args []string) error {
+	for _, name := range compactDBNames {
+		if !containsString(nodeDBs, name) {
+			return fmt.Errorf("unknown database %q, expected one of %v", name, nodeDBs)
+		}
+		if backend := config.DBBackendOf(name); backend != "goleveldb" {
+			return fmt.Errorf("compaction is only supported with goleveldb, not %s of the %s database", backend, name)
+		}
+	}
+
+	var reclaimed int64
+	for i, name := range compactDBNames {
+		path := filepath.Join(config.DBDirOf(name), name+".db")
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			fmt.Printf("(%d/%d) skipping %s, %s does not exist\n", i+1, len(compactDBNames), name, path)
+			continue
+		}
+
+		before, err := dirSize(path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("(%d/%d) compacting %s, %s\n", i+1, len(compactDBNames), name, formatBytes(before))
+		start := time.Now()
+
+		var bar progressbar.Bar
+		err = compactGoLevelDB(path, func(compacted, total int64) {
+			if compacted == 0 {
+				bar.NewOption(0, total)
+				return
+			}
+			bar.Play(compacted)
+		})
+		bar.Finish()
+		if err != nil {
+			return fmt.Errorf("compacting %s: %w", name, err)
+		}
+
+		after, err := dirSize(path)
+		if err != nil {
+			return err
+		}
+		reclaimed += before - after
+		fmt.Printf("compacted %s in %s, %s reclaimed\n", name, time.Since(start).Round(time.Second),
+			formatBytes(before-after))
+	}
+	fmt.Printf("compaction finished, %s reclaimed\n", formatBytes(reclaimed))
+	return nil
+}
+
+// compactGoLevelDB compacts the goleveldb database at path, by slices of the
+// key space split by their first byte. progress is called before compacting
+// and after each slice, with the approximate size of the slices compacted and
+// of the database.
+func compactGoLevelDB(path string, progress func(compacted, total int64)) error {
+	db, err := leveldb.OpenFile(path, &opt.Options{
+		DisableSeeksCompaction: true,
+		ErrorIfMissing:         true,
+	})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	slices := make([]util.Range, 256)
+	for i := range slices {
+		if i > 0 {
+			slices[i].Start = []byte{byte(i)}
+		}
+		if i < len(slices)-1 {
+			slices[i].Limit = []byte{byte(i + 1)}
+		}
+	}
+	sizes, err := db.SizeOf(slices)
+	if err != nil {
+		return err
+	}
+
+	var compacted int64
+	total := sizes.Sum()
+	progress(0, total)
+	for i, slice := range slices {
+		if err := db.CompactRange(slice); err != nil {
+			return err
+		}
+		if sizes[i] > 0 {
+			compacted += sizes[i]
+			progress(compacted, total)
+		}
+	}
+	return nil
+}
+
+// dirSize returns the total size of the files in the directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// formatBytes formats a number of bytes with a binary unit.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit || value <= -unit {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTPE"[exp])
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactGoLevelDB(t *testing.T) {
+	dir := t.TempDir()
+	db, err := dbm.NewGoLevelDB("blockstore", dir)
+	require.NoError(t, err)
+	value := make([]byte, 1024)
+	for i := 0; i < 2000; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("P:%d", i)), value))
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("H:%d", i)), value))
+	}
+	// delete most of the data, as a pruning node does
+	for i := 0; i < 1900; i++ {
+		require.NoError(t, db.Delete([]byte(fmt.Sprintf("P:%d", i))))
+		require.NoError(t, db.Delete([]byte(fmt.Sprintf("H:%d", i))))
+	}
+	require.NoError(t, db.Close())
+
+	path := filepath.Join(dir, "blockstore.db")
+	before, err := dirSize(path)
+	require.NoError(t, err)
+
+	var calls, lastCompacted, lastTotal int64
+	err = compactGoLevelDB(path, func(compacted, total int64) {
+		calls++
+		lastCompacted, lastTotal = compacted, total
+	})
+	require.NoError(t, err)
+	assert.Greater(t, calls, int64(1))
+	assert.Equal(t, lastTotal, lastCompacted)
+
+	after, err := dirSize(path)
+	require.NoError(t, err)
+	assert.Less(t, after, before)
+
+	// the remaining data is kept
+	db, err = dbm.NewGoLevelDB("blockstore", dir)
+	require.NoError(t, err)
+	defer db.Close()
+	ok, err := db.Has([]byte("P:1999"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = db.Has([]byte("P:0"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	err = compactGoLevelDB(filepath.Join(dir, "missing.db"), func(int64, int64) {})
+	assert.Error(t, err)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 GiB", formatBytes(2<<30))
+	assert.Equal(t, "-1.0 MiB", formatBytes(-1<<20))
+}
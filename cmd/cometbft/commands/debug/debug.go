@@ -24,7 +24,7 @@ var (
 // debugging running CometBFT processes.
 var DebugCmd = &cobra.Command{
 	Use:   "debug",
-	Short: "A utility to kill or watch a CometBFT process while aggregating debugging data",
+	Short: "A utility to kill, watch or snapshot a CometBFT process while aggregating debugging data",
 }
 
 func init() {
@@ -38,4 +38,5 @@ func init() {
 
 	DebugCmd.AddCommand(killCmd)
 	DebugCmd.AddCommand(dumpCmd)
+	DebugCmd.AddCommand(snapshotCmd)
 }
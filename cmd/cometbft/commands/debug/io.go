@@ -2,6 +2,7 @@ package debug
 
 import (
 	"archive/zip"
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -100,6 +101,61 @@ func copyFile(src, dest string) error {
 	return os.Chmod(dest, srcInfo.Mode())
 }
 
+// copyDir copies the files of the directory src into the directory dest,
+// which is created. It returns an error upon failure.
+func copyDir(src, dest string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if err := copyFile(filepath.Join(src, e.Name()), filepath.Join(dest, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tailFile copies the last n lines of the file src to dest. It returns an
+// error upon failure.
+func tailFile(src, dest string, n int) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	// keep the last n lines in a ring buffer
+	lines := make([]string, 0, n)
+	next := 0
+	scanner := bufio.NewScanner(srcFile)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) < n {
+			lines = append(lines, scanner.Text())
+			continue
+		}
+		lines[next] = scanner.Text()
+		next = (next + 1) % n
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(lines); i++ {
+		sb.WriteString(lines[(next+i)%len(lines)])
+		sb.WriteByte('\n')
+	}
+	return os.WriteFile(dest, []byte(sb.String()), os.ModePerm)
+}
+
 // writeStateToFile pretty JSON encodes an object and writes it to file composed
 // of dir and filename. It returns an error upon failure to encode or write to
 // file.
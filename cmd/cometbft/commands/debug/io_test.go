@@ -0,0 +1,32 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "node.log")
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, strings.Repeat("x", i))
+	}
+	require.NoError(t, os.WriteFile(src, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+	dest := filepath.Join(dir, "logs.txt")
+	require.NoError(t, tailFile(src, dest, 3))
+	bz, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	require.Equal(t, strings.Join(lines[7:], "\n")+"\n", string(bz))
+
+	// the whole file is copied if it's shorter
+	require.NoError(t, tailFile(src, dest, 100))
+	bz, err = os.ReadFile(dest)
+	require.NoError(t, err)
+	require.Equal(t, strings.Join(lines, "\n")+"\n", string(bz))
+}
@@ -0,0 +1,148 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/cli"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+var (
+	cpuDuration time.Duration
+	logFile     string
+	logLines    int
+
+	flagCPUDuration = "cpu-duration"
+	flagLogFile     = "log-file"
+	flagLogLines    = "log-lines"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot [compressed-output-file]",
+	Short: "Capture the debugging data of a running CometBFT process into a single archive",
+	Long: `Capture the debugging data of a running CometBFT process, without stopping it,
+into a compressed archive to attach to a bug report: the node status, network
+info and consensus state, the goroutine dump and the heap and CPU profiles if
+the profiling server is enabled, the profiles captured continuously by the node,
+the tail of its log file, and its WAL and config.
+
+The data which can't be captured, e.g. the consensus state of a stuck node, is
+skipped with an error, and the archive is created with the rest.
+
+Example:
+$ cometbft debug snapshot /path/to/snapshot.zip --pprof-laddr http://localhost:6060 --log-file /var/log/cometbft.log`,
+	Args: cobra.ExactArgs(1),
+	RunE: snapshotCmdHandler,
+}
+
+func init() {
+	snapshotCmd.Flags().StringVar(
+		&profAddr,
+		flagProfAddr,
+		"",
+		"the profiling server address (<host>:<port>)",
+	)
+	snapshotCmd.Flags().DurationVar(
+		&cpuDuration,
+		flagCPUDuration,
+		10*time.Second,
+		"the duration of the CPU profile, 0 skips it",
+	)
+	snapshotCmd.Flags().StringVar(
+		&logFile,
+		flagLogFile,
+		"",
+		"the log file of the CometBFT process, whose tail is included",
+	)
+	snapshotCmd.Flags().IntVar(
+		&logLines,
+		flagLogLines,
+		10000,
+		"the number of lines of the log file included",
+	)
+}
+
+func snapshotCmdHandler(_ *cobra.Command, args []string) error {
+	outFile := args[0]
+	if outFile == "" {
+		return errors.New("invalid output file")
+	}
+
+	if logLines <= 0 {
+		return errors.New("log-lines must be positive")
+	}
+
+	rpc, err := rpchttp.New(nodeRPCAddr, "/websocket")
+	if err != nil {
+		return fmt.Errorf("failed to create new http client: %w", err)
+	}
+
+	home := viper.GetString(cli.HomeFlag)
+	conf := cfg.DefaultConfig()
+	conf = conf.SetRoot(home)
+
+	tmpDir, err := os.MkdirTemp(os.TempDir(), "cometbft_debug_tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	captureSnapshot(tmpDir, home, conf, rpc)
+
+	logger.Info("archiving and compressing debug directory...")
+	return zipDir(tmpDir, outFile)
+}
+
+// snapshotStep captures a piece of the debugging data of the node.
+type snapshotStep struct {
+	name string
+	run  func() error
+}
+
+// captureSnapshot writes the debugging data of the node into dir, skipping the
+// data which can't be captured.
+func captureSnapshot(dir, home string, conf *cfg.Config, rpc *rpchttp.HTTP) {
+	steps := []snapshotStep{
+		{"node status", func() error { return dumpStatus(rpc, dir, "status.json") }},
+		{"node network info", func() error { return dumpNetInfo(rpc, dir, "net_info.json") }},
+		{"node consensus state", func() error { return dumpConsensusState(rpc, dir, "consensus_state.json") }},
+	}
+	if profAddr != "" {
+		steps = append(steps,
+			snapshotStep{"node goroutine dump", func() error { return dumpProfile(dir, profAddr, "goroutine", 2) }},
+			snapshotStep{"node heap profile", func() error { return dumpProfile(dir, profAddr, "heap", 2) }},
+		)
+		if cpuDuration > 0 {
+			steps = append(steps, snapshotStep{"node CPU profile", func() error {
+				return dumpCPUProfile(dir, profAddr, cpuDuration)
+			}})
+		}
+	}
+	steps = append(steps,
+		snapshotStep{"node continuous profiles", func() error {
+			return copyDir(conf.Instrumentation.ProfilingDir(), filepath.Join(dir, "profiles"))
+		}},
+		snapshotStep{"node WAL", func() error { return copyWAL(conf, dir) }},
+		snapshotStep{"node configuration", func() error { return copyConfig(home, dir) }},
+	)
+	if logFile != "" {
+		steps = append(steps, snapshotStep{"node logs", func() error {
+			return tailFile(logFile, filepath.Join(dir, "logs.txt"), logLines)
+		}})
+	}
+
+	for _, step := range steps {
+		logger.Info(fmt.Sprintf("getting %s...", step.name))
+		if err := step.run(); err != nil {
+			logger.Error(fmt.Sprintf("failed to get %s, skipping it", step.name), "error", err)
+		}
+	}
+}
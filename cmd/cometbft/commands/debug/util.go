@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	cfg "github.com/tendermint/tendermint/config"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
@@ -81,3 +82,26 @@ func dumpProfile(dir, addr, profile string, debug int) error {
 
 	return os.WriteFile(path.Join(dir, fmt.Sprintf("%s.out", profile)), body, os.ModePerm)
 }
+
+// dumpCPUProfile gets a CPU profile of the given duration from the profiling
+// server and writes it to the cpu.pb.gz file in dir.
+func dumpCPUProfile(dir, addr string, duration time.Duration) error {
+	endpoint := fmt.Sprintf("%s/debug/pprof/profile?seconds=%d", addr, int(duration.Seconds()))
+
+	//nolint:gosec,nolintlint
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to query for CPU profile: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query for CPU profile: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read CPU profile response body: %w", err)
+	}
+
+	return os.WriteFile(path.Join(dir, "cpu.pb.gz"), body, os.ModePerm)
+}
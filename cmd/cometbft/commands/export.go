@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/progressbar"
+	"github.com/tendermint/tendermint/state"
+)
+
+var (
+	exportFrom   int64
+	exportTo     int64
+	exportOutput string
+	exportFormat string
+)
+
+// The columns of the exported files. Columns are only ever appended, so that
+// the files of different versions can be loaded with the same schema.
+var (
+	exportBlockColumns = []string{"height", "time", "hash", "proposer_address", "num_txs", "app_hash"}
+	exportTxColumns    = []string{"height", "index", "hash", "code", "codespace", "gas_wanted", "gas_used"}
+	exportEventColumns = []string{"height", "tx_index", "source", "event_index", "type", "key", "value", "indexed"}
+)
+
+// The sources of the exported events.
+const (
+	exportSourceBeginBlock = "begin_block"
+	exportSourceEndBlock   = "end_block"
+	exportSourceTx         = "tx"
+)
+
+// ExportCmd exports the blocks, transactions and events of a height range to
+// files.
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the blocks, txs and events over a height range to files",
+	Long: `Export the blocks, transactions and events stored by the node over a
+height range, to be loaded in a data warehouse. Three files are written in the
+output directory:
+
+  blocks.csv: height, time, hash, proposer_address, num_txs, app_hash
+  txs.csv:    height, index, hash, code, codespace, gas_wanted, gas_used
+  events.csv: height, tx_index, source, event_index, type, key, value, indexed
+
+The source of an event is begin_block, end_block or tx, and its tx_index is
+empty unless it is emitted by a transaction. Hashes and addresses are
+hex-encoded, times are in RFC 3339 format, in UTC. Only the csv format is
+supported.
+
+The heights default to the whole range of the block store. The node must be
+stopped, and must not discard its ABCI responses.`,
+	Example: `
+	cometbft export --output ./export
+	cometbft export --from 100 --to 200 --output ./export
+	`,
+	RunE: export,
+}
+
+func init() {
+	ExportCmd.Flags().Int64Var(&exportFrom, "from", 0,
+		"first height to export, defaults to the base height of the block store")
+	ExportCmd.Flags().Int64Var(&exportTo, "to", 0,
+		"last height to export, defaults to the latest height of the block store")
+	ExportCmd.Flags().StringVar(&exportOutput, "output", "export",
+		"directory to write the exported files to")
+	ExportCmd.Flags().StringVar(&exportFormat, "format", "csv",
+		"format of the exported files")
+}
+
+func export(cmd *cobra.Command, args []string) error {
+	if exportFormat != "csv" {
+		return fmt.Errorf("unsupported format %q, only csv is supported", exportFormat)
+	}
+	if config.Storage.DiscardABCIResponses {
+		return errors.New("the ABCI responses are discarded (storage.discard_abci_responses), " +
+			"events can't be exported")
+	}
+
+	bs, ss, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	defer ss.Close()
+	if bs.Height() == 0 {
+		return errors.New("the block store is empty")
+	}
+	from, to, err := heightRange(bs, exportFrom, exportTo)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(exportOutput, 0o755); err != nil {
+		return err
+	}
+	w := &exportWriter{}
+	for _, f := range []struct {
+		name    string
+		columns []string
+		w       **csv.Writer
+	}{
+		{"blocks.csv", exportBlockColumns, &w.blocks},
+		{"txs.csv", exportTxColumns, &w.txs},
+		{"events.csv", exportEventColumns, &w.events},
+	} {
+		file, err := os.Create(filepath.Join(exportOutput, f.name))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		*f.w = csv.NewWriter(file)
+		if err := (*f.w).Write(f.columns); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+
+	fmt.Printf("exporting heights %d to %d to %s\n", from, to, exportOutput)
+	if err := w.export(cmd.Context(), bs, ss, from, to); err != nil {
+		return err
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	fmt.Println("export finished")
+	return nil
+}
+
+// exportWriter writes the exported blocks, transactions and events as CSV
+// records.
+type exportWriter struct {
+	blocks *csv.Writer
+	txs    *csv.Writer
+	events *csv.Writer
+}
+
+// export writes the records of the blocks from one height to another.
+func (w *exportWriter) export(ctx context.Context, bs state.BlockStore, ss state.Store, from, to int64) error {
+	var bar progressbar.Bar
+	bar.NewOption(from-1, to)
+	defer bar.Finish()
+
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("export terminated at height %d: %w", height, err)
+		}
+		if err := w.exportHeight(bs, ss, height); err != nil {
+			return err
+		}
+		bar.Play(height)
+	}
+	return nil
+}
+
+func (w *exportWriter) exportHeight(bs state.BlockStore, ss state.Store, height int64) error {
+	b := bs.LoadBlock(height)
+	if b == nil {
+		return fmt.Errorf("not able to load block at height %d from the blockstore", height)
+	}
+	r, err := ss.LoadABCIResponses(height)
+	if err != nil {
+		return fmt.Errorf("not able to load ABCI Response at height %d from the statestore: %w", height, err)
+	}
+
+	h := strconv.FormatInt(height, 10)
+	err = w.blocks.Write([]string{
+		h,
+		b.Time.UTC().Format(time.RFC3339Nano),
+		fmt.Sprintf("%X", b.Hash()),
+		fmt.Sprintf("%X", b.ProposerAddress),
+		strconv.Itoa(len(b.Txs)),
+		fmt.Sprintf("%X", b.AppHash),
+	})
+	if err != nil {
+		return err
+	}
+
+	if r.BeginBlock != nil {
+		if err := w.writeEvents(h, "", exportSourceBeginBlock, r.BeginBlock.Events); err != nil {
+			return err
+		}
+	}
+	for i, tx := range b.Txs {
+		if i >= len(r.DeliverTxs) || r.DeliverTxs[i] == nil {
+			return fmt.Errorf("missing the result of tx %d at height %d", i, height)
+		}
+		res := r.DeliverTxs[i]
+		index := strconv.Itoa(i)
+		err := w.txs.Write([]string{
+			h,
+			index,
+			fmt.Sprintf("%X", tx.Hash()),
+			strconv.FormatUint(uint64(res.Code), 10),
+			res.Codespace,
+			strconv.FormatInt(res.GasWanted, 10),
+			strconv.FormatInt(res.GasUsed, 10),
+		})
+		if err != nil {
+			return err
+		}
+		if err := w.writeEvents(h, index, exportSourceTx, res.Events); err != nil {
+			return err
+		}
+	}
+	if r.EndBlock != nil {
+		if err := w.writeEvents(h, "", exportSourceEndBlock, r.EndBlock.Events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEvents writes a record for each attribute of the events, or for the
+// event itself if it has none.
+func (w *exportWriter) writeEvents(height, txIndex, source string, events []abci.Event) error {
+	for i, event := range events {
+		attrs := event.Attributes
+		if len(attrs) == 0 {
+			attrs = []abci.EventAttribute{{}}
+		}
+		for _, attr := range attrs {
+			err := w.events.Write([]string{
+				height,
+				txIndex,
+				source,
+				strconv.Itoa(i),
+				event.Type,
+				string(attr.Key),
+				string(attr.Value),
+				strconv.FormatBool(attr.Index),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flush writes the buffered records to the files.
+func (w *exportWriter) flush() error {
+	for _, cw := range []*csv.Writer{w.blocks, w.txs, w.events} {
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	restartHeight        int64
+	restartChainID       string
+	restartInitialHeight int64
+	restartAppState      string
+	restartGenesisTime   string
+	restartNoStart       bool
+	restartYes           bool
+)
+
+// restartDataEntries are the entries of the data directory moved to the
+// backup directory at a genesis restart.
+var restartDataEntries = []string{"blockstore.db", "state.db", "cs.wal", "evidence.db", "tx_index.db"}
+
+// NewExportAndRestartCmd returns the command restarting the chain from a new
+// genesis exported from its state at a height, e.g. after a fork, and then
+// starting the node with nodeProvider.
+func NewExportAndRestartCmd(nodeProvider nm.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-and-restart",
+		Short: "Restart the chain from a genesis exported from its state at a height",
+		Long: `Restart the chain from a new genesis, exported from its state at a height, e.g.
+to recover from a fork with a "genesis restart":
+
+  1. the new genesis is built with the new chain ID and initial height, and the
+     validators and consensus params of the height following the export height,
+     with the app state exported by the application at the export height,
+  2. the block store, state, evidence, WAL and tx index are moved to a backup
+     directory in the data directory, and the old genesis to a backup file,
+  3. the state of the private validator is reset, and the new genesis is saved,
+  4. the node is started, unless --no-start is set.
+
+The node must be stopped. The application must export its state at the export
+height to the file given with --app-state, e.g. with its export command, and
+reset its data: it is initialized from the exported state by InitChain.`,
+		Example: `
+	cometbft export-and-restart --chain-id rollapp_2-1 --app-state app_state.json
+	cometbft export-and-restart --height 1000 --chain-id rollapp_2-1 --initial-height 1001 \
+		--app-state app_state.json --no-start
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportAndRestart(cmd, nodeProvider)
+		},
+	}
+	cmd.Flags().Int64Var(&restartHeight, "height", 0,
+		"height to export the state at, defaults to the latest height of the state")
+	cmd.Flags().StringVar(&restartChainID, "chain-id", "",
+		"chain ID of the new genesis, which must differ from the current one")
+	cmd.Flags().Int64Var(&restartInitialHeight, "initial-height", 0,
+		"initial height of the new genesis, defaults to the export height + 1")
+	cmd.Flags().StringVar(&restartAppState, "app-state", "",
+		"file holding the JSON app state exported by the application at the export height")
+	cmd.Flags().StringVar(&restartGenesisTime, "genesis-time", "",
+		"genesis time of the new genesis, in RFC 3339 format, defaults to now")
+	cmd.Flags().BoolVar(&restartNoStart, "no-start", false,
+		"don't start the node after the restart")
+	cmd.Flags().BoolVar(&restartYes, "yes", false,
+		"restart without asking for a confirmation")
+	AddNodeFlags(cmd)
+	return cmd
+}
+
+func exportAndRestart(cmd *cobra.Command, nodeProvider nm.Provider) error {
+	if restartChainID == "" {
+		return errors.New("the chain ID of the new genesis must be set with --chain-id")
+	}
+	if restartAppState == "" {
+		return errors.New("the app state exported by the application must be set with --app-state")
+	}
+	appState, err := os.ReadFile(restartAppState)
+	if err != nil {
+		return fmt.Errorf("reading the app state: %w", err)
+	}
+	genesisTime := time.Now().UTC()
+	if restartGenesisTime != "" {
+		if genesisTime, err = time.Parse(time.RFC3339Nano, restartGenesisTime); err != nil {
+			return fmt.Errorf("invalid genesis time: %w", err)
+		}
+	}
+	oldGenDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+
+	genDoc, height, err := exportGenesis(oldGenDoc, appState, genesisTime)
+	if err != nil {
+		return err
+	}
+
+	backupDir := filepath.Join(config.DBDir(), fmt.Sprintf("%s-%d.bak", oldGenDoc.ChainID, height))
+	restart := fmt.Sprintf("restart chain %s at height %d as chain %s at height %d, moving its data to %s",
+		oldGenDoc.ChainID, height, genDoc.ChainID, genDoc.InitialHeight, backupDir)
+	if !restartYes {
+		if apply, err := confirm(cmd, restart); !apply {
+			return err
+		}
+	}
+
+	if err := backupData(config.DBDir(), backupDir); err != nil {
+		return err
+	}
+	if err := backupSeparateDBs(filepath.Base(backupDir)); err != nil {
+		return err
+	}
+	genesisBackup := fmt.Sprintf("%s.%s.bak", config.GenesisFile(), oldGenDoc.ChainID)
+	if err := os.Rename(config.GenesisFile(), genesisBackup); err != nil {
+		return fmt.Errorf("backing up the genesis: %w", err)
+	}
+	if cmtos.FileExists(config.PrivValidatorKeyFile()) {
+		privval.LoadFilePVEmptyState(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()).Reset()
+	}
+	if err := genDoc.SaveAs(config.GenesisFile()); err != nil {
+		return fmt.Errorf("saving the new genesis: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "restarted as chain %s at height %d; the old genesis is in %s\n",
+		genDoc.ChainID, genDoc.InitialHeight, genesisBackup)
+
+	if restartNoStart {
+		return nil
+	}
+	return runNode(cmd, nodeProvider)
+}
+
+// exportGenesis loads the state of the node, and returns the new genesis of
+// its state at the export height, and that height.
+func exportGenesis(
+	oldGenDoc *types.GenesisDoc,
+	appState []byte,
+	genesisTime time.Time,
+) (*types.GenesisDoc, int64, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	st, err := stateStore.Load()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load the state: %w", err)
+	}
+	height := restartHeight
+	if height == 0 {
+		height = st.LastBlockHeight
+	}
+	if height <= 0 || height > st.LastBlockHeight {
+		return nil, 0, fmt.Errorf("invalid export height %d, the state is at height %d", height, st.LastBlockHeight)
+	}
+	if oldGenDoc.ChainID == restartChainID {
+		return nil, 0, fmt.Errorf("the new chain ID must differ from the current one, %s", oldGenDoc.ChainID)
+	}
+	initialHeight := restartInitialHeight
+	if initialHeight == 0 {
+		initialHeight = height + 1
+	}
+	genDoc, err := buildRestartGenesis(stateStore, height, restartChainID, initialHeight, genesisTime, appState)
+	return genDoc, height, err
+}
+
+// buildRestartGenesis returns the genesis of the chain chainID starting at
+// initialHeight from the state at height: its validators and consensus params
+// are those of the next height, and its app state is appState.
+func buildRestartGenesis(
+	stateStore state.Store,
+	height int64,
+	chainID string,
+	initialHeight int64,
+	genesisTime time.Time,
+	appState []byte,
+) (*types.GenesisDoc, error) {
+	if !json.Valid(appState) {
+		return nil, errors.New("the app state isn't valid JSON")
+	}
+	vals, err := stateStore.LoadValidators(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("loading the validators of height %d: %w", height+1, err)
+	}
+	params, err := stateStore.LoadConsensusParams(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("loading the consensus params of height %d: %w", height+1, err)
+	}
+
+	genDoc := &types.GenesisDoc{
+		GenesisTime:     genesisTime,
+		ChainID:         chainID,
+		InitialHeight:   initialHeight,
+		ConsensusParams: &params,
+		AppState:        appState,
+	}
+	for _, val := range vals.Validators {
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			Address:           val.Address,
+			PubKey:            val.PubKey,
+			Power:             val.VotingPower,
+			ProofOfPossession: val.ProofOfPossession,
+		})
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("invalid new genesis: %w", err)
+	}
+	return genDoc, nil
+}
+
+// backupData moves the entries of the data directory dbDir reset at a genesis
+// restart to backupDir.
+// backupSeparateDBs moves the databases of the node kept outside of its data
+// directory, see config.DBDirOf, to a backup directory named backupName next
+// to them, since they may be on another disk.
+func backupSeparateDBs(backupName string) error {
+	for _, name := range nodeDBs {
+		dir := config.DBDirOf(name)
+		path := filepath.Join(dir, name+".db")
+		if dir == config.DBDir() || !cmtos.FileExists(path) {
+			continue
+		}
+		backupDir := filepath.Join(dir, backupName)
+		if err := cmtos.EnsureDir(backupDir, 0o700); err != nil {
+			return err
+		}
+		if err := os.Rename(path, filepath.Join(backupDir, name+".db")); err != nil {
+			return fmt.Errorf("backing up %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func backupData(dbDir, backupDir string) error {
+	if cmtos.FileExists(backupDir) {
+		return fmt.Errorf("the backup directory %s exists already", backupDir)
+	}
+	if err := cmtos.EnsureDir(backupDir, 0o700); err != nil {
+		return err
+	}
+	for _, entry := range restartDataEntries {
+		path := filepath.Join(dbDir, entry)
+		if !cmtos.FileExists(path) {
+			continue
+		}
+		if err := os.Rename(path, filepath.Join(backupDir, entry)); err != nil {
+			return fmt.Errorf("backing up %s: %w", entry, err)
+		}
+	}
+	return nil
+}
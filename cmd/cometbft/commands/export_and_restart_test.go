@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBuildRestartGenesis(t *testing.T) {
+	val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	params := types.DefaultConsensusParams()
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", int64(11)).Return(types.NewValidatorSet([]*types.Validator{val}), nil)
+	stateStore.On("LoadConsensusParams", int64(11)).Return(*params, nil)
+	genesisTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	genDoc, err := buildRestartGenesis(stateStore, 10, "rollapp_2-1", 11, genesisTime, []byte(`{"bank":{}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "rollapp_2-1", genDoc.ChainID)
+	assert.EqualValues(t, 11, genDoc.InitialHeight)
+	assert.Equal(t, genesisTime, genDoc.GenesisTime)
+	assert.Equal(t, params, genDoc.ConsensusParams)
+	assert.JSONEq(t, `{"bank":{}}`, string(genDoc.AppState))
+	require.Len(t, genDoc.Validators, 1)
+	assert.Equal(t, val.PubKey, genDoc.Validators[0].PubKey)
+	assert.EqualValues(t, 10, genDoc.Validators[0].Power)
+
+	_, err = buildRestartGenesis(stateStore, 10, "rollapp_2-1", 11, genesisTime, []byte(`{`))
+	assert.Error(t, err)
+
+	stateStore = &mocks.Store{}
+	stateStore.On("LoadValidators", int64(11)).Return(nil, errors.New("pruned"))
+	_, err = buildRestartGenesis(stateStore, 10, "rollapp_2-1", 11, genesisTime, []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestBackupData(t *testing.T) {
+	dbDir := t.TempDir()
+	for _, entry := range []string{"blockstore.db", "state.db", "cs.wal", "priv_validator_state.json"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dbDir, entry), 0o700))
+	}
+	backupDir := filepath.Join(dbDir, "rollapp_1-1-10.bak")
+
+	require.NoError(t, backupData(dbDir, backupDir))
+	for _, entry := range []string{"blockstore.db", "state.db", "cs.wal"} {
+		assert.NoDirExists(t, filepath.Join(dbDir, entry))
+		assert.DirExists(t, filepath.Join(backupDir, entry))
+	}
+	assert.DirExists(t, filepath.Join(dbDir, "priv_validator_state.json"))
+
+	// an existing backup isn't overwritten
+	assert.Error(t, backupData(dbDir, backupDir))
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/state"
+)
+
+var (
+	exportStateHeight int64
+	exportStateOutput string
+)
+
+// ExportStateCmd exports the state of the chain at a height to a JSON
+// document.
+var ExportStateCmd = &cobra.Command{
+	Use:   "export-state",
+	Short: "Export the state of the chain at a height",
+	Long: `Export the state of the chain once the block at a height is committed, to a
+JSON document holding its chain ID and height, the app hash and results hash of
+the block, and the validators and consensus params of the next height. The
+document is deterministic, so that the exports of several nodes can be compared.
+
+Along with the app state exported by the application, it holds what is needed
+to start a new chain from the state, e.g. for a hard fork: see also the
+export-and-restart command. The height defaults to the latest height of the
+state. The node must be stopped.`,
+	Example: `
+	cometbft export-state
+	cometbft export-state --height 1000 --output state.json
+	`,
+	RunE: exportState,
+}
+
+func init() {
+	ExportStateCmd.Flags().Int64Var(&exportStateHeight, "height", 0,
+		"height to export the state at, defaults to the latest height of the state")
+	ExportStateCmd.Flags().StringVar(&exportStateOutput, "output", "",
+		"file to write the state to, defaults to the standard output")
+}
+
+func exportState(cmd *cobra.Command, args []string) error {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	exported, err := state.Export(stateStore, blockStore, exportStateHeight)
+	if err != nil {
+		return err
+	}
+	bz, err := cmtjson.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+	bz = append(bz, '\n')
+	if exportStateOutput == "" {
+		_, err = cmd.OutOrStdout().Write(bz)
+		return err
+	}
+	if err := os.WriteFile(exportStateOutput, bz, 0o644); err != nil {
+		return fmt.Errorf("writing the state: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "exported the state at height %d to %s\n", exported.Height, exportStateOutput)
+	return nil
+}
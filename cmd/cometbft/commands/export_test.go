@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestExport(t *testing.T) {
+	block := &types.Block{
+		Header: types.Header{
+			Height:          2,
+			Time:            time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+			ProposerAddress: []byte{0xab},
+		},
+		Data: types.Data{Txs: types.Txs{types.Tx("tx")}},
+	}
+	abciResp := &cmtstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{{Type: "mint"}}},
+		DeliverTxs: []*abci.ResponseDeliverTx{{
+			Code:      2,
+			Codespace: "bank",
+			GasWanted: 10,
+			GasUsed:   7,
+			Events: []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: []byte("sender"), Value: []byte("foo, bar"), Index: true},
+				{Key: []byte("amount"), Value: []byte("1"), Index: false},
+			}}},
+		}},
+		EndBlock: &abci.ResponseEndBlock{},
+	}
+
+	mockBlockStore := &mocks.BlockStore{}
+	mockBlockStore.On("LoadBlock", int64(2)).Return(block)
+	mockBlockStore.On("LoadBlock", int64(3)).Return(nil)
+	mockStateStore := &mocks.Store{}
+	mockStateStore.On("LoadABCIResponses", int64(2)).Return(abciResp, nil)
+
+	var blocks, txs, events bytes.Buffer
+	w := &exportWriter{
+		blocks: csv.NewWriter(&blocks),
+		txs:    csv.NewWriter(&txs),
+		events: csv.NewWriter(&events),
+	}
+	require.NoError(t, w.export(context.Background(), mockBlockStore, mockStateStore, 2, 2))
+	require.NoError(t, w.flush())
+
+	assert.Equal(t,
+		fmt.Sprintf("2,2023-01-02T03:04:05Z,%X,AB,1,\n", block.Hash()),
+		blocks.String())
+	assert.Equal(t,
+		fmt.Sprintf("2,0,%X,2,bank,10,7\n", types.Tx("tx").Hash()),
+		txs.String())
+	assert.Equal(t, "2,,begin_block,0,mint,,,false\n"+
+		"2,0,tx,0,transfer,sender,\"foo, bar\",true\n"+
+		"2,0,tx,0,transfer,amount,1,false\n",
+		events.String())
+
+	require.ErrorContains(t, w.export(context.Background(), mockBlockStore, mockStateStore, 2, 3),
+		"not able to load block at height 3")
+
+	mockStateStore = &mocks.Store{}
+	mockStateStore.On("LoadABCIResponses", int64(2)).Return(nil, errors.New("not found"))
+	require.Error(t, w.export(context.Background(), mockBlockStore, mockStateStore, 2, 2))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/p2p"
 )
@@ -18,13 +19,20 @@ var GenNodeKeyCmd = &cobra.Command{
 	RunE:    genNodeKey,
 }
 
+var nodeKeyType string
+
+func init() {
+	GenNodeKeyCmd.Flags().StringVar(&nodeKeyType, "key-type", ed25519.KeyType,
+		"type of the node key to generate: ed25519 or sr25519")
+}
+
 func genNodeKey(cmd *cobra.Command, args []string) error {
 	nodeKeyFile := config.NodeKeyFile()
 	if cmtos.FileExists(nodeKeyFile) {
 		return fmt.Errorf("node key at %s already exists", nodeKeyFile)
 	}
 
-	nodeKey, err := p2p.LoadOrGenNodeKey(nodeKeyFile)
+	nodeKey, err := p2p.LoadOrGenNodeKeyWithKeyType(nodeKeyFile, nodeKeyType)
 	if err != nil {
 		return err
 	}
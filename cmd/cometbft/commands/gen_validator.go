@@ -7,6 +7,7 @@ import (
 
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
 )
 
 // GenValidatorCmd allows the generation of a keypair for a
@@ -15,15 +16,24 @@ var GenValidatorCmd = &cobra.Command{
 	Use:     "gen-validator",
 	Aliases: []string{"gen_validator"},
 	Short:   "Generate new validator keypair",
-	Run:     genValidator,
+	RunE:    genValidator,
 }
 
-func genValidator(cmd *cobra.Command, args []string) {
-	pv := privval.GenFilePV("", "")
+func init() {
+	GenValidatorCmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519,
+		"type of the validator key to generate: ed25519, secp256k1, sr25519 or bls12_381")
+}
+
+func genValidator(cmd *cobra.Command, args []string) error {
+	pv, err := privval.GenFilePVWithKeyType("", "", keyType)
+	if err != nil {
+		return err
+	}
 	jsbz, err := cmtjson.Marshal(pv)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	fmt.Printf(`%v
 `, string(jsbz))
+	return nil
 }
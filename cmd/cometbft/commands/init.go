@@ -21,6 +21,15 @@ var InitFilesCmd = &cobra.Command{
 	RunE:  initFiles,
 }
 
+var keyType string
+
+func init() {
+	InitFilesCmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519,
+		"type of the validator key to generate: ed25519, secp256k1, sr25519 or bls12_381")
+	InitFilesCmd.Flags().StringVar(&nodeKeyType, "node-key-type", types.ABCIPubKeyTypeEd25519,
+		"type of the node key to generate: ed25519 or sr25519")
+}
+
 func initFiles(cmd *cobra.Command, args []string) error {
 	return initFilesWithConfig(config)
 }
@@ -35,7 +44,11 @@ func initFilesWithConfig(config *cfg.Config) error {
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
-		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
+		var err error
+		pv, err = privval.GenFilePVWithKeyType(privValKeyFile, privValStateFile, keyType)
+		if err != nil {
+			return err
+		}
 		pv.Save()
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
@@ -45,7 +58,7 @@ func initFilesWithConfig(config *cfg.Config) error {
 	if cmtos.FileExists(nodeKeyFile) {
 		logger.Info("Found node key", "path", nodeKeyFile)
 	} else {
-		if _, err := p2p.LoadOrGenNodeKey(nodeKeyFile); err != nil {
+		if _, err := p2p.LoadOrGenNodeKeyWithKeyType(nodeKeyFile, nodeKeyType); err != nil {
 			return err
 		}
 		logger.Info("Generated node key", "path", nodeKeyFile)
@@ -65,10 +78,16 @@ func initFilesWithConfig(config *cfg.Config) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		proof, err := types.ProofOfPossession(pv.Key.PrivKey)
+		if err != nil {
+			return fmt.Errorf("can't prove the possession of the key: %w", err)
+		}
+		genDoc.ConsensusParams.Validator.PubKeyTypes = []string{pubKey.Type()}
 		genDoc.Validators = []types.GenesisValidator{{
-			Address: pubKey.Address(),
-			PubKey:  pubKey,
-			Power:   10,
+			Address:           pubKey.Address(),
+			PubKey:            pubKey,
+			Power:             10,
+			ProofOfPossession: proof,
 		}}
 
 		if err := genDoc.SaveAs(genFile); err != nil {
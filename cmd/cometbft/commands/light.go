@@ -15,6 +15,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	"github.com/tendermint/tendermint/crypto/batch"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtos "github.com/tendermint/tendermint/libs/os"
@@ -67,6 +68,8 @@ var (
 	trustedHash    []byte
 	trustLevelStr  string
 
+	sigVerifyBatchSize int
+
 	verbose bool
 
 	primaryKey   = []byte("primary")
@@ -98,6 +101,9 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().IntVar(&sigVerifyBatchSize, "sig-verify-batch-size", batch.DefaultMaxBatchSize,
+		"maximum number of commit signatures verified in a single batch (0 to verify them one by one)",
+	)
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -114,6 +120,8 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	chainID = args[0]
 	logger.Info("Creating client...", "chainID", chainID)
 
+	batch.SetMaxBatchSize(sigVerifyBatchSize)
+
 	witnessesAddrs := []string{}
 	if witnessAddrsJoined != "" {
 		witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+)
+
+// migrateBatchSize is the size of the batches of keys copied by migrate-db.
+const migrateBatchSize = 16 << 20
+
+var (
+	migrateDBNames     []string
+	migrateFromDir     string
+	migrateFromBackend string
+)
+
+// MigrateDBCmd copies the databases of the node to the backend and directory
+// set for them in the storage section of the config.
+var MigrateDBCmd = &cobra.Command{
+	Use:   "migrate-db",
+	Short: "Copy the databases of the node to the backend and directory set for them",
+	Long: `Copy the databases of the node, from db_backend and db_dir by default, to the
+backend and directory set for each of them in the storage section of the
+config, e.g. storage.block_store_db_backend and storage.block_store_db_dir, to
+move them to another backend or disk. The node must be stopped.
+
+The databases are copied key by key, and the existing ones aren't overwritten.
+The source databases are kept, to be removed once the node runs with the copies.`,
+	Example: `
+	cometbft migrate-db --db blockstore
+	cometbft migrate-db --db blockstore,state --from-dir /mnt/old/data
+	`,
+	RunE: migrateDB,
+}
+
+func init() {
+	MigrateDBCmd.Flags().StringSliceVar(&migrateDBNames, "db", nil,
+		"databases to copy: blockstore, state, evidence, tx_index")
+	MigrateDBCmd.Flags().StringVar(&migrateFromBackend, "from-backend", "",
+		"backend of the databases to copy, defaults to db_backend")
+	MigrateDBCmd.Flags().StringVar(&migrateFromDir, "from-dir", "",
+		"directory of the databases to copy, defaults to db_dir")
+	_ = MigrateDBCmd.MarkFlagRequired("db")
+}
+
+func migrateDB(cmd *cobra.Command, args []string) error {
+	fromBackend, fromDir := migrateFromBackend, migrateFromDir
+	if fromBackend == "" {
+		fromBackend = config.DBBackend
+	}
+	if fromDir == "" {
+		fromDir = config.DBDir()
+	} else if !filepath.IsAbs(fromDir) {
+		fromDir = filepath.Join(config.RootDir, fromDir)
+	}
+	for _, name := range migrateDBNames {
+		if !containsString(nodeDBs, name) {
+			return fmt.Errorf("unknown database %q, expected one of %v", name, nodeDBs)
+		}
+		toBackend, toDir := config.DBBackendOf(name), config.DBDirOf(name)
+		if toBackend == fromBackend && filepath.Clean(toDir) == filepath.Clean(fromDir) {
+			return fmt.Errorf("the %s database is already in %s with %s, set another backend or directory "+
+				"in the storage section of the config", name, toDir, toBackend)
+		}
+		if !cmtos.FileExists(filepath.Join(fromDir, name+".db")) {
+			return fmt.Errorf("no %s database found in %s", name, fromDir)
+		}
+		if cmtos.FileExists(filepath.Join(toDir, name+".db")) {
+			return fmt.Errorf("the %s database exists already in %s", name, toDir)
+		}
+	}
+
+	for i, name := range migrateDBNames {
+		toBackend, toDir := config.DBBackendOf(name), config.DBDirOf(name)
+		fmt.Printf("(%d/%d) copying %s from %s in %s to %s in %s\n", i+1, len(migrateDBNames), name,
+			fromBackend, fromDir, toBackend, toDir)
+		copied, err := migrateNodeDB(name, fromBackend, fromDir, toBackend, toDir)
+		if err != nil {
+			return fmt.Errorf("copying %s: %w", name, err)
+		}
+		fmt.Printf("copied %d keys of %s\n", copied, name)
+	}
+	fmt.Println("migration finished, the source databases can be removed once the node runs with the copies")
+	return nil
+}
+
+func migrateNodeDB(name, fromBackend, fromDir, toBackend, toDir string) (int64, error) {
+	src, err := dbm.NewDB(name, dbm.BackendType(fromBackend), fromDir)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+	if err := cmtos.EnsureDir(toDir, 0o700); err != nil {
+		return 0, err
+	}
+	dst, err := dbm.NewDB(name, dbm.BackendType(toBackend), toDir)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+	return copyDB(src, dst)
+}
+
+// copyDB copies all the keys of src to dst, by batches of up to
+// migrateBatchSize bytes, and returns the number of keys copied.
+func copyDB(src, dst dbm.DB) (int64, error) {
+	it, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	batch := dst.NewBatch()
+	defer batch.Close()
+	var copied, size int64
+	for ; it.Valid(); it.Next() {
+		if err := batch.Set(it.Key(), it.Value()); err != nil {
+			return copied, err
+		}
+		copied++
+		size += int64(len(it.Key()) + len(it.Value()))
+		if size >= migrateBatchSize {
+			if err := batch.Write(); err != nil {
+				return copied, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+			defer batch.Close()
+			size = 0
+		}
+	}
+	if err := it.Error(); err != nil {
+		return copied, err
+	}
+	return copied, batch.WriteSync()
+}
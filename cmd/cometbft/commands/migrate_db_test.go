@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyDB(t *testing.T) {
+	src, dst := dbm.NewMemDB(), dbm.NewMemDB()
+	// more than a batch of keys
+	value := make([]byte, 1<<20)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, src.Set([]byte(fmt.Sprintf("key%02d", i)), value))
+	}
+	require.NoError(t, src.Set([]byte("small"), []byte{1}))
+
+	copied, err := copyDB(src, dst)
+	require.NoError(t, err)
+	assert.EqualValues(t, 21, copied)
+	for i := 0; i < 20; i++ {
+		bz, err := dst.Get([]byte(fmt.Sprintf("key%02d", i)))
+		require.NoError(t, err)
+		assert.Equal(t, value, bz)
+	}
+	bz, err := dst.Get([]byte("small"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, bz)
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/state/indexer"
+)
+
+var (
+	migrateIndexerSink   string
+	migrateIndexerDryRun bool
+)
+
+// MigrateIndexerCmd migrates the indexed data to the latest schema version.
+var MigrateIndexerCmd = &cobra.Command{
+	Use:   "migrate-indexer",
+	Short: "Migrate the indexed data to the latest schema version",
+	Long: `Apply the pending migrations of the schema of the kv or psql indexer, in
+place, without re-indexing the chain. The node applies them when it starts, so
+this command is only needed to apply them ahead of an upgrade, or to list them
+with --dry-run.
+
+The sink defaults to tx_index.indexer, and is configured by the tx_index
+section of the config. The node must be stopped when using the kv sink.`,
+	Example: `
+	cometbft migrate-indexer --dry-run
+	cometbft migrate-indexer --sink psql
+	`,
+	RunE: migrateIndexer,
+}
+
+func init() {
+	MigrateIndexerCmd.Flags().StringVar(&migrateIndexerSink, "sink", "",
+		"sink to migrate (kv or psql), defaults to tx_index.indexer")
+	MigrateIndexerCmd.Flags().BoolVar(&migrateIndexerDryRun, "dry-run", false,
+		"list the pending migrations without applying them")
+}
+
+func migrateIndexer(cmd *cobra.Command, args []string) error {
+	sink := config.TxIndex.Indexer
+	if migrateIndexerSink != "" {
+		sink = migrateIndexerSink
+	}
+	_, ti, err := loadEventSink(config, sink)
+	if err != nil {
+		return fmt.Errorf("loading sink %q: %w", sink, err)
+	}
+	m, ok := ti.(indexer.Migrator)
+	if !ok {
+		return fmt.Errorf("the %s sink has no versioned schema", sink)
+	}
+
+	version, err := m.SchemaVersion()
+	if err != nil {
+		return fmt.Errorf("loading the schema version: %w", err)
+	}
+	fmt.Printf("schema version %d, latest version %d\n", version, indexer.LatestSchemaVersion(m))
+
+	n, err := indexer.Migrate(cmd.Context(), m, migrateIndexerDryRun, func(migration indexer.Migration) {
+		if migrateIndexerDryRun {
+			fmt.Printf("pending migration to version %d: %s\n", migration.Version, migration.Description)
+		} else {
+			fmt.Printf("migrated to version %d: %s\n", migration.Version, migration.Description)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case n == 0:
+		fmt.Println("the schema is up to date")
+	case migrateIndexerDryRun:
+		fmt.Printf("%d migrations pending\n", n)
+	default:
+		fmt.Println("migration finished")
+	}
+	return nil
+}
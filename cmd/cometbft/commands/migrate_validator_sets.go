@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/state"
+)
+
+var (
+	migrateValSetsFrom int64
+	migrateValSetsTo   int64
+)
+
+// MigrateValidatorSetsCmd converts the validator sets stored by the state
+// store to the format set by storage.validator_set_delta_interval.
+var MigrateValidatorSetsCmd = &cobra.Command{
+	Use:   "migrate-validator-sets",
+	Short: "Convert the stored validator sets to the format set in the config",
+	Long: `Convert the validator sets stored by the state store to the format set by
+storage.validator_set_delta_interval: if set, the sets stored in full at the
+heights they changed are stored as deltas from the previous height, with a full
+set every validator_set_delta_interval changes; if 0, the deltas are stored in
+full again, e.g. before a downgrade to a version without the deltas.
+
+The heights default to the whole range of the state store. The node must be
+stopped.`,
+	Example: `
+	cometbft migrate-validator-sets
+	cometbft migrate-validator-sets --from 1000 --to 2000
+	`,
+	RunE: migrateValidatorSets,
+}
+
+func init() {
+	MigrateValidatorSetsCmd.Flags().Int64Var(&migrateValSetsFrom, "from", 0,
+		"first height to convert, defaults to the initial height")
+	MigrateValidatorSetsCmd.Flags().Int64Var(&migrateValSetsTo, "to", 0,
+		"last height to convert, defaults to the latest height of the validator sets")
+}
+
+func migrateValidatorSets(cmd *cobra.Command, args []string) error {
+	if !cmtos.FileExists(filepath.Join(config.DBDirOf("state"), "state.db")) {
+		return fmt.Errorf("no statestore found in %v", config.DBDirOf("state"))
+	}
+	db, err := dbm.NewDB("state", dbm.BackendType(config.DBBackendOf("state")), config.DBDirOf("state"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	options := state.StoreOptions{
+		DiscardABCIResponses:      config.Storage.DiscardABCIResponses,
+		ValidatorSetDeltaInterval: config.Storage.ValidatorSetDeltaInterval,
+	}
+
+	st, err := state.NewStore(db, options).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load the state: %w", err)
+	}
+	if st.IsEmpty() {
+		return errors.New("no state found")
+	}
+	from, to := migrateValSetsFrom, migrateValSetsTo
+	if from == 0 {
+		from = st.InitialHeight
+	}
+	if to == 0 {
+		// the validators of the next height are stored along with the state
+		to = st.LastBlockHeight + 2
+	}
+
+	format := "in full"
+	if options.ValidatorSetDeltaInterval > 0 {
+		format = fmt.Sprintf("as deltas, with a full set every %d changes", options.ValidatorSetDeltaInterval)
+	}
+	fmt.Printf("converting the validator sets of heights %d to %d to be stored %s\n", from, to, format)
+	converted, err := state.MigrateValidatorSets(db, options, from, to)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("converted %d validator sets\n", converted)
+	return nil
+}
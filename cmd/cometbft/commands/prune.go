@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/txindex"
+)
+
+var (
+	pruneRetainHeight int64
+	pruneDryRun       bool
+	pruneCompact      bool
+)
+
+// PruneCmd prunes the blocks, states and indexed data of the node below a
+// height.
+var PruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune the blocks, states and indexed data below a height",
+	Long: `Delete the blocks, the states and the kv indexer entries of the heights below
+the retain height, as the node does when the application sets a retain height,
+and report the space reclaimed. The node must be stopped.
+
+The stores are pruned consistently, in the order the node prunes them, and the
+kv indexer records the height it is pruned up to, so that the node does not
+prune it again. With --dry-run, the heights which would be pruned are listed,
+with an estimate of the size of their blocks, and nothing is deleted.
+
+With goleveldb, the pruned databases are then compacted to reclaim the space of
+the deleted data, unless --compact=false is set.`,
+	Example: `
+	cometbft prune --retain-height 1000000 --dry-run
+	cometbft prune --retain-height 1000000
+	`,
+	RunE: prune,
+}
+
+func init() {
+	PruneCmd.Flags().Int64Var(&pruneRetainHeight, "retain-height", 0,
+		"height of the first block to retain")
+	PruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false,
+		"list the heights which would be pruned without deleting them")
+	PruneCmd.Flags().BoolVar(&pruneCompact, "compact", true,
+		"compact the pruned goleveldb databases to reclaim the space")
+}
+
+// prunedDBs are the databases of the node which are pruned.
+var prunedDBs = []string{"blockstore", "state", "tx_index"}
+
+func prune(cmd *cobra.Command, args []string) error {
+	if pruneRetainHeight <= 0 {
+		return errors.New("the retain height must be greater than 0")
+	}
+
+	sizes := make(map[string]int64, len(prunedDBs))
+	for _, name := range prunedDBs {
+		size, err := dbSize(config, name)
+		if err != nil {
+			return err
+		}
+		sizes[name] = size
+	}
+
+	if err := pruneDBs(config); err != nil {
+		return err
+	}
+	if pruneDryRun {
+		fmt.Println("dry run, nothing was pruned")
+		return nil
+	}
+
+	var reclaimed int64
+	compacted := true
+	for _, name := range prunedDBs {
+		path := filepath.Join(config.DBDirOf(name), name+".db")
+		if !cmtos.FileExists(path) {
+			continue
+		}
+		compact := pruneCompact && config.DBBackendOf(name) == string(dbm.GoLevelDBBackend)
+		compacted = compacted && compact
+		if compact {
+			fmt.Printf("compacting %s\n", name)
+			if err := compactGoLevelDB(path, func(int64, int64) {}); err != nil {
+				return fmt.Errorf("compacting %s: %w", name, err)
+			}
+		}
+		size, err := dbSize(config, name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s reclaimed\n", name, formatBytes(sizes[name]-size))
+		reclaimed += sizes[name] - size
+	}
+	fmt.Printf("pruning finished, %s reclaimed\n", formatBytes(reclaimed))
+	if !compacted {
+		fmt.Println("the space of the pruned data is reclaimed when the databases are compacted")
+	}
+	return nil
+}
+
+// pruneDBs prunes the block store, the state store and the kv indexer of the
+// node, which are closed when it returns.
+func pruneDBs(config *cfg.Config) error {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	pruner, indexStore, err := loadIndexPruner(config, blockStore)
+	if err != nil {
+		return err
+	}
+	if indexStore != nil {
+		defer indexStore.Close()
+	}
+
+	return pruneStores(blockStore, stateStore, pruner, pruneRetainHeight, pruneDryRun)
+}
+
+// loadIndexPruner returns a pruner of the kv indexer of the node, and the
+// database it stores in, or nil if the node does not index with the kv indexer.
+func loadIndexPruner(config *cfg.Config, blockStore state.BlockStore) (*txindex.Pruner, dbm.DB, error) {
+	switch strings.ToLower(config.TxIndex.Indexer) {
+	case "kv":
+	case "null":
+		return nil, nil, nil
+	default:
+		fmt.Printf("the %s indexer does not support pruning, skipping it\n", config.TxIndex.Indexer)
+		return nil, nil, nil
+	}
+	if !cmtos.FileExists(filepath.Join(config.DBDirOf("tx_index"), "tx_index.db")) {
+		return nil, nil, nil
+	}
+
+	store, err := dbm.NewDB("tx_index", dbm.BackendType(config.DBBackendOf("tx_index")), config.DBDirOf("tx_index"))
+	if err != nil {
+		return nil, nil, err
+	}
+	blockIndexer, txIndexer := newKVEventSink(config, store)
+	// the pruned height is persisted where the node persists it
+	pruner := txindex.NewPruner(txIndexer, blockIndexer, blockStore,
+		dbm.NewPrefixDB(store, []byte("indexer_progress")), 0, false, 0)
+	return pruner, store, nil
+}
+
+// pruneStores prunes the blocks and the states below retainHeight, and the
+// index entries with pruner if not nil. With dryRun, it only reports the
+// heights which would be pruned.
+func pruneStores(
+	blockStore state.BlockStore,
+	stateStore state.Store,
+	pruner *txindex.Pruner,
+	retainHeight int64,
+	dryRun bool,
+) error {
+	base, height := blockStore.Base(), blockStore.Height()
+	if retainHeight > height {
+		return fmt.Errorf("cannot retain height %d above the latest height %d", retainHeight, height)
+	}
+	// the validators at the retain height are kept by the state pruning
+	if retainHeight > base {
+		if _, err := stateStore.LoadValidators(retainHeight); err != nil {
+			return fmt.Errorf("no state at the retain height %d: %w", retainHeight, err)
+		}
+	}
+
+	var prunedHeight int64
+	if pruner != nil {
+		var err error
+		if prunedHeight, err = pruner.PrunedHeight(); err != nil {
+			return err
+		}
+	}
+
+	if retainHeight <= base {
+		fmt.Printf("blocks and states: nothing to prune, the base height is %d\n", base)
+	} else if dryRun {
+		var size int64
+		for h := base; h < retainHeight; h++ {
+			if meta := blockStore.LoadBlockMeta(h); meta != nil {
+				size += int64(meta.BlockSize)
+			}
+		}
+		fmt.Printf("blocks and states: would prune heights %d to %d (%d blocks, %s of blocks)\n",
+			base, retainHeight-1, retainHeight-base, formatBytes(size))
+	} else {
+		pruned, err := blockStore.PruneBlocks(retainHeight)
+		if err != nil {
+			return fmt.Errorf("failed to prune block store: %w", err)
+		}
+		fmt.Printf("blocks: pruned heights %d to %d (%d blocks)\n", base, retainHeight-1, pruned)
+		if err := stateStore.PruneStates(base, retainHeight); err != nil {
+			return fmt.Errorf("failed to prune state database: %w", err)
+		}
+		fmt.Printf("states: pruned heights %d to %d\n", base, retainHeight-1)
+	}
+
+	switch {
+	case pruner == nil:
+	case retainHeight <= prunedHeight+1:
+		fmt.Printf("indexes: nothing to prune, pruned up to height %d\n", prunedHeight)
+	case dryRun:
+		fmt.Printf("indexes: would prune heights %d to %d\n", prunedHeight+1, retainHeight-1)
+	default:
+		pruned, err := pruner.PruneTo(retainHeight)
+		if err != nil {
+			return fmt.Errorf("failed to prune the indexes: %w", err)
+		}
+		fmt.Printf("indexes: pruned heights %d to %d (%d entries)\n", prunedHeight+1, retainHeight-1, pruned)
+	}
+	return nil
+}
+
+// dbSize returns the size of the database of the node with the given name, or
+// 0 if it does not exist.
+func dbSize(config *cfg.Config, name string) (int64, error) {
+	path := filepath.Join(config.DBDirOf(name), name+".db")
+	if !cmtos.FileExists(path) {
+		return 0, nil
+	}
+	return dirSize(path)
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	cmtcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPruneStores(t *testing.T) {
+	store := dbm.NewMemDB()
+	blockIndexer, txIndexer := newKVEventSink(cmtcfg.DefaultConfig(), store)
+	for h := int64(1); h <= 10; h++ {
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockHeader{Header: types.Header{Height: h}}))
+	}
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(2))
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("LoadBlockMeta", mock.Anything).Return(&types.BlockMeta{BlockSize: 100})
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", int64(5)).Return(&types.ValidatorSet{}, nil)
+	stateStore.On("LoadValidators", int64(9)).Return(nil, errors.New("not found"))
+	pruner := txindex.NewPruner(txIndexer, blockIndexer, blockStore, dbm.NewMemDB(), 0, false, 0)
+
+	// a dry run deletes nothing
+	require.NoError(t, pruneStores(blockStore, stateStore, pruner, 5, true))
+	blockStore.AssertNotCalled(t, "PruneBlocks", mock.Anything)
+	stateStore.AssertNotCalled(t, "PruneStates", mock.Anything, mock.Anything)
+	ok, err := blockIndexer.Has(1)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.ErrorContains(t, pruneStores(blockStore, stateStore, pruner, 11, false), "above the latest height")
+	require.ErrorContains(t, pruneStores(blockStore, stateStore, pruner, 9, false), "no state at the retain height")
+
+	blockStore.On("PruneBlocks", int64(5)).Return(uint64(3), nil)
+	stateStore.On("PruneStates", int64(2), int64(5)).Return(nil)
+	require.NoError(t, pruneStores(blockStore, stateStore, pruner, 5, false))
+	blockStore.AssertCalled(t, "PruneBlocks", int64(5))
+	stateStore.AssertCalled(t, "PruneStates", int64(2), int64(5))
+	for h := int64(1); h <= 10; h++ {
+		ok, err := blockIndexer.Has(h)
+		require.NoError(t, err)
+		require.Equal(t, h >= 5, ok)
+	}
+	prunedHeight, err := pruner.PrunedHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), prunedHeight)
+
+	// the pruning errors are returned
+	blockStore = &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(2))
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("PruneBlocks", int64(5)).Return(uint64(0), errors.New("failed"))
+	require.ErrorContains(t, pruneStores(blockStore, stateStore, nil, 5, false), "failed to prune block store")
+}
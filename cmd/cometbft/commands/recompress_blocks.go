@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/progressbar"
+	"github.com/tendermint/tendermint/store"
+)
+
+var recompressCompression string
+
+// RecompressBlocksCmd rewrites the blocks of the block store with a
+// compression.
+var RecompressBlocksCmd = &cobra.Command{
+	Use:   "recompress-blocks",
+	Short: "Rewrite the blocks of the block store with a compression",
+	Long: `Rewrite the block parts and metas of the block store with a compression, e.g.
+to compress the blocks saved before storage.block_compression was enabled, or to
+decompress them all before disabling it. The node must be stopped.
+
+The blocks are read whatever their compression, so the node runs with a block
+store holding blocks of several compressions, and the blocks can be rewritten
+at any time. The compression defaults to storage.block_compression. The blocks
+saved before the block parts and metas were checksummed are checksummed too.`,
+	Example: `
+	cometbft recompress-blocks
+	cometbft recompress-blocks --compression none
+	`,
+	RunE: recompressBlocks,
+}
+
+func init() {
+	RecompressBlocksCmd.Flags().StringVar(&recompressCompression, "compression", "",
+		"compression of the blocks rewritten: none or snappy, defaults to storage.block_compression")
+}
+
+func recompressBlocks(cmd *cobra.Command, args []string) error {
+	compression := recompressCompression
+	if compression == "" {
+		compression = config.Storage.BlockCompression
+	}
+	storage := cfg.StorageConfig{BlockCompression: compression}
+	if err := storage.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if !os.FileExists(filepath.Join(config.DBDirOf("blockstore"), "blockstore.db")) {
+		return fmt.Errorf("no blockstore found in %v", config.DBDirOf("blockstore"))
+	}
+	db, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackendOf("blockstore")), config.DBDirOf("blockstore"))
+	if err != nil {
+		return err
+	}
+	blockStore := store.NewBlockStore(db, store.WithCompression(compression))
+	defer blockStore.Close()
+
+	fmt.Printf("rewriting the blocks %d to %d with compression %s\n",
+		blockStore.Base(), blockStore.Height(), compression)
+	var bar progressbar.Bar
+	bar.NewOption(blockStore.Base(), blockStore.Height())
+	rewritten, err := blockStore.RecompressBlocks(bar.Play)
+	bar.Finish()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d blocks rewritten\n", rewritten)
+	if rewritten > 0 && config.DBBackendOf("blockstore") == string(dbm.GoLevelDBBackend) {
+		fmt.Println("run compact-db to reclaim the space of the rewritten blocks")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/state/txindex"
+)
+
+var (
+	reindexFrom  int64
+	reindexTo    int64
+	reindexSinks string
+)
+
+// ReindexCmd rebuilds the indexes of a height range into one or more sinks.
+var ReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the tx and block indexes over a height range",
+	Long: `Replay the blocks and ABCI responses stored by the node through the
+indexer pipeline, to rebuild or backfill indexes after changing the indexer
+configuration, without resyncing the chain.
+
+The heights default to the whole range of the block store. The sinks default to
+tx_index.indexer, and are configured by the tx_index section of the config.
+Several sinks can be given, separated by commas, and are filled one after the
+other. Blocks already indexed are skipped by the psql, sqlite and clickhouse
+sinks, and overwritten by the kv sink.
+
+The node must be stopped, and must not discard its ABCI responses.`,
+	Example: `
+	cometbft reindex
+	cometbft reindex --from 100 --to 200
+	cometbft reindex --sink=sqlite,clickhouse
+	`,
+	RunE: reindex,
+}
+
+func init() {
+	ReindexCmd.Flags().Int64Var(&reindexFrom, "from", 0,
+		"first height to re-index, defaults to the base height of the block store")
+	ReindexCmd.Flags().Int64Var(&reindexTo, "to", 0,
+		"last height to re-index, defaults to the latest height of the block store")
+	ReindexCmd.Flags().StringVar(&reindexSinks, "sink", "",
+		"comma separated list of sinks to re-index into (kv, psql, sqlite or clickhouse), "+
+			"defaults to tx_index.indexer")
+}
+
+func reindex(cmd *cobra.Command, args []string) error {
+	if config.Storage.DiscardABCIResponses {
+		return errors.New("the ABCI responses are discarded (storage.discard_abci_responses), " +
+			"blocks can't be re-indexed")
+	}
+
+	sinks := []string{config.TxIndex.Indexer}
+	if reindexSinks != "" {
+		sinks = strings.Split(reindexSinks, ",")
+	}
+
+	bs, ss, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+	defer ss.Close()
+	if bs.Height() == 0 {
+		return errors.New("the block store is empty")
+	}
+	from, to, err := heightRange(bs, reindexFrom, reindexTo)
+	if err != nil {
+		return err
+	}
+
+	ef, err := txindex.NewEventFilter(config.TxIndex.IndexEvents, config.TxIndex.ExcludeEvents)
+	if err != nil {
+		return err
+	}
+
+	// load all the sinks first, to fail before re-indexing anything
+	riArgs := make([]eventReIndexArgs, len(sinks))
+	for i, sink := range sinks {
+		sinks[i] = strings.TrimSpace(sink)
+		bi, ti, err := loadEventSink(config, sinks[i])
+		if err != nil {
+			return fmt.Errorf("loading sink %q: %w", sinks[i], err)
+		}
+		riArgs[i] = eventReIndexArgs{
+			startHeight:  from,
+			endHeight:    to,
+			blockIndexer: bi,
+			txIndexer:    ti,
+			eventFilter:  ef,
+			blockStore:   bs,
+			stateStore:   ss,
+		}
+	}
+
+	for i, sink := range sinks {
+		fmt.Printf("re-indexing heights %d to %d into the %s sink\n", from, to, sink)
+		if err := eventReIndex(cmd, riArgs[i]); err != nil {
+			return fmt.Errorf("re-indexing into the %s sink: %w", sink, err)
+		}
+	}
+
+	fmt.Println("re-index finished")
+	return nil
+}
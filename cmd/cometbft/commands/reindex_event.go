@@ -14,7 +14,9 @@ import (
 	"github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	"github.com/tendermint/tendermint/state/indexer/sink/clickhouse"
 	"github.com/tendermint/tendermint/state/indexer/sink/psql"
+	"github.com/tendermint/tendermint/state/indexer/sink/sqlite"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/types"
@@ -69,11 +71,18 @@ want to use this command.
 			return
 		}
 
+		ef, err := txindex.NewEventFilter(config.TxIndex.IndexEvents, config.TxIndex.ExcludeEvents)
+		if err != nil {
+			fmt.Println(reindexFailed, err)
+			return
+		}
+
 		riArgs := eventReIndexArgs{
 			startHeight:  startHeight,
 			endHeight:    endHeight,
 			blockIndexer: bi,
 			txIndexer:    ti,
+			eventFilter:  ef,
 			blockStore:   bs,
 			stateStore:   ss,
 		}
@@ -96,7 +105,13 @@ func init() {
 }
 
 func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer, error) {
-	switch strings.ToLower(cfg.TxIndex.Indexer) {
+	return loadEventSink(cfg, cfg.TxIndex.Indexer)
+}
+
+// loadEventSink returns the indexers of the given sink type, configured by the
+// tx_index section of cfg.
+func loadEventSink(cfg *cmtcfg.Config, sink string) (indexer.BlockIndexer, txindex.TxIndexer, error) {
+	switch strings.ToLower(sink) {
 	case "null":
 		return nil, nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
 	case "psql":
@@ -104,30 +119,59 @@ func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer
 		if conn == "" {
 			return nil, nil, errors.New("the psql connection settings cannot be empty")
 		}
-		es, err := psql.NewEventSink(conn, cfg.ChainID())
+		es, err := psql.NewEventSink(conn, cfg.ChainID(),
+			psql.WithFullTextAttributes(cfg.TxIndex.FullTextAttributes))
+		if err != nil {
+			return nil, nil, err
+		}
+		return es.BlockIndexer(), es.TxIndexer(), nil
+	case "clickhouse":
+		conn := cfg.TxIndex.ClickhouseConn
+		if conn == "" {
+			return nil, nil, errors.New("the clickhouse connection settings cannot be empty")
+		}
+		es, err := clickhouse.NewEventSink(conn, cfg.ChainID())
+		if err != nil {
+			return nil, nil, err
+		}
+		return es.BlockIndexer(), es.TxIndexer(), nil
+	case "sqlite":
+		es, err := sqlite.NewEventSink(cfg.TxIndex.SqliteFile(), cfg.ChainID(),
+			sqlite.WithFullTextAttributes(cfg.TxIndex.FullTextAttributes))
 		if err != nil {
 			return nil, nil, err
 		}
 		return es.BlockIndexer(), es.TxIndexer(), nil
 	case "kv":
-		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackendOf("tx_index")), cfg.DBDirOf("tx_index"))
 		if err != nil {
 			return nil, nil, err
 		}
-
-		txIndexer := kv.NewTxIndex(store)
-		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+		blockIndexer, txIndexer := newKVEventSink(cfg, store)
 		return blockIndexer, txIndexer, nil
 	default:
-		return nil, nil, fmt.Errorf("unsupported event sink type: %s", cfg.TxIndex.Indexer)
+		return nil, nil, fmt.Errorf("unsupported event sink type: %s", sink)
 	}
 }
 
+// newKVEventSink returns the kv indexers storing in store, configured by the
+// tx_index section of cfg.
+func newKVEventSink(cfg *cmtcfg.Config, store dbm.DB) (*blockidxkv.BlockerIndexer, *kv.TxIndex) {
+	txIndexer := kv.NewTxIndex(store, kv.WithAccountAttributes(
+		cfg.TxIndex.SenderAttributes,
+		cfg.TxIndex.RecipientAttributes,
+		cfg.TxIndex.FeePayerAttributes,
+	))
+	blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+	return blockIndexer, txIndexer
+}
+
 type eventReIndexArgs struct {
 	startHeight  int64
 	endHeight    int64
 	blockIndexer indexer.BlockIndexer
 	txIndexer    txindex.TxIndexer
+	eventFilter  *txindex.EventFilter
 	blockStore   state.BlockStore
 	stateStore   state.Store
 }
@@ -160,6 +204,11 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 				ResultEndBlock:   *r.EndBlock,
 			}
 
+			// index the block first, as sinks attach transactions to their block
+			if err := args.blockIndexer.Index(args.eventFilter.BlockHeader(e)); err != nil {
+				return fmt.Errorf("block event re-index at height %d failed: %w", i, err)
+			}
+
 			var batch *txindex.Batch
 			if e.NumTxs > 0 {
 				batch = txindex.NewBatch(e.NumTxs)
@@ -177,14 +226,10 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 					}
 				}
 
-				if err := args.txIndexer.AddBatch(batch); err != nil {
+				if err := args.txIndexer.AddBatch(args.eventFilter.Batch(batch)); err != nil {
 					return fmt.Errorf("tx event re-index at height %d failed: %w", i, err)
 				}
 			}
-
-			if err := args.blockIndexer.Index(e); err != nil {
-				return fmt.Errorf("block event re-index at height %d failed: %w", i, err)
-			}
 		}
 
 		bar.Play(i)
@@ -194,6 +239,18 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 }
 
 func checkValidHeight(bs state.BlockStore) error {
+	start, end, err := heightRange(bs, startHeight, endHeight)
+	if err != nil {
+		return err
+	}
+	startHeight, endHeight = start, end
+	return nil
+}
+
+// heightRange returns the range of heights to re-index, given the requested
+// start and end heights, which default to the base and latest heights of the
+// block store when 0.
+func heightRange(bs state.BlockStore, startHeight, endHeight int64) (int64, int64, error) {
 	base := bs.Base()
 
 	if startHeight == 0 {
@@ -202,14 +259,14 @@ func checkValidHeight(bs state.BlockStore) error {
 	}
 
 	if startHeight < base {
-		return fmt.Errorf("%s (requested start height: %d, base height: %d)",
+		return 0, 0, fmt.Errorf("%s (requested start height: %d, base height: %d)",
 			ErrHeightNotAvailable, startHeight, base)
 	}
 
 	height := bs.Height()
 
 	if startHeight > height {
-		return fmt.Errorf(
+		return 0, 0, fmt.Errorf(
 			"%s (requested start height: %d, store height: %d)", ErrHeightNotAvailable, startHeight, height)
 	}
 
@@ -219,15 +276,15 @@ func checkValidHeight(bs state.BlockStore) error {
 	}
 
 	if endHeight < base {
-		return fmt.Errorf(
+		return 0, 0, fmt.Errorf(
 			"%s (requested end height: %d, base height: %d)", ErrHeightNotAvailable, endHeight, base)
 	}
 
 	if endHeight < startHeight {
-		return fmt.Errorf(
+		return 0, 0, fmt.Errorf(
 			"%s (requested the end height: %d is less than the start height: %d)",
 			ErrInvalidRequest, startHeight, endHeight)
 	}
 
-	return nil
+	return startHeight, endHeight, nil
 }
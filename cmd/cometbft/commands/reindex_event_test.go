@@ -89,7 +89,8 @@ func TestLoadEventSink(t *testing.T) {
 		{"", "", true},
 		{"NULL", "", true},
 		{"KV", "", false},
-		{"PSQL", "", true}, // true because empty connect url
+		{"PSQL", "", true},       // true because empty connect url
+		{"CLICKHOUSE", "", true}, // true because empty connect url
 		// skip to test PSQL connect with correct url
 		{"UnsupportedSinkType", "wrongUrl", true},
 	}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	cmtcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReindex(t *testing.T) {
+	defer func(cfg *cmtcfg.Config) { config = cfg }(config)
+	config = cmtcfg.TestConfig()
+	config.DBPath = t.TempDir()
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	// the stores do not exist
+	require.Error(t, reindex(setupReIndexEventCmd(), nil))
+
+	db, err := dbm.NewDB("state", dbm.GoLevelDBBackend, config.DBDir())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	db, err = dbm.NewDB("blockstore", dbm.GoLevelDBBackend, config.DBDir())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	require.ErrorContains(t, reindex(setupReIndexEventCmd(), nil), "the block store is empty")
+
+	db, err = dbm.NewDB("blockstore", dbm.GoLevelDBBackend, config.DBDir())
+	require.NoError(t, err)
+	block := types.MakeBlock(1, []types.Tx{types.Tx("tx")}, &types.Commit{}, nil)
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	store.NewBlockStore(db).SaveBlock(block, parts, &types.Commit{Height: 1})
+	require.NoError(t, db.Close())
+
+	// the sinks are loaded before re-indexing anything
+	defer func(sinks string) { reindexSinks = sinks }(reindexSinks)
+	reindexSinks = "null, unsupported"
+	require.ErrorContains(t, reindex(setupReIndexEventCmd(), nil), `loading sink "null"`)
+
+	config.Storage.DiscardABCIResponses = true
+	require.ErrorContains(t, reindex(setupReIndexEventCmd(), nil), "discard_abci_responses")
+}
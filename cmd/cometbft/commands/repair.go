@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	cmtstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+)
+
+var (
+	repairAllowWrites bool
+	repairYes         bool
+)
+
+// RepairCmd repairs the block store of a stopped node, e.g. after a crash
+// corrupted it.
+var RepairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Repair the block store of a stopped node",
+	Long: `Repair the block store of a stopped node, e.g. after a crash corrupted it,
+without editing the database by hand.
+
+The repairs only describe what they would change, unless --allow-writes is set,
+and then ask for a confirmation, unless --yes is set. Back up the data
+directory before repairing it.`,
+}
+
+var repairDeleteBlockCmd = &cobra.Command{
+	Use:   "delete-block [height]",
+	Short: "Delete the latest block, e.g. when it's corrupted",
+	Long: `Delete the latest block and its seen commit, even if its meta is corrupted, so
+that the node fetches it again from its peers. The state must be rolled back
+below the block first, with the rollback command.`,
+	Example: `
+	cometbft rollback
+	cometbft repair delete-block 1000000 --allow-writes
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: repairDeleteBlock,
+}
+
+var repairResetSeenCommitCmd = &cobra.Command{
+	Use:   "reset-seen-commit [height]",
+	Short: "Replace the seen commit of a height with its canonical commit",
+	Long: `Replace the seen commit of a height, e.g. when it's corrupted, with the
+canonical commit of the block, included in the next block. The latest height has
+no canonical commit yet.`,
+	Args: cobra.ExactArgs(1),
+	RunE: repairResetSeenCommit,
+}
+
+var repairRebuildStateCmd = &cobra.Command{
+	Use:   "rebuild-block-store-state",
+	Short: "Rebuild the base and height record of the block store",
+	Long: `Rebuild the record of the base and height of the block store, e.g. when it's
+corrupted or doesn't match the blocks stored, from the blocks found.`,
+	Args: cobra.NoArgs,
+	RunE: repairRebuildState,
+}
+
+func init() {
+	RepairCmd.PersistentFlags().BoolVar(&repairAllowWrites, "allow-writes", false,
+		"apply the repair, instead of only describing it")
+	RepairCmd.PersistentFlags().BoolVar(&repairYes, "yes", false,
+		"apply the repair without asking for a confirmation")
+	RepairCmd.AddCommand(repairDeleteBlockCmd, repairResetSeenCommitCmd, repairRebuildStateCmd)
+}
+
+func repairDeleteBlock(cmd *cobra.Command, args []string) error {
+	height, err := parseRepairHeight(args[0])
+	if err != nil {
+		return err
+	}
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	if err := checkDeleteBlock(blockStore, stateStore, height); err != nil {
+		return err
+	}
+	if apply, err := confirmRepair(cmd, fmt.Sprintf("delete block %d and its seen commit", height)); !apply {
+		return err
+	}
+	if err := blockStore.DeleteLatestBlock(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "deleted block %d\n", height)
+	return nil
+}
+
+// checkDeleteBlock checks that the block at height is the latest one, and that
+// the state was rolled back below it.
+func checkDeleteBlock(blockStore state.BlockStore, stateStore state.Store, height int64) error {
+	if latest := blockStore.Height(); height != latest {
+		return fmt.Errorf("only the latest block, at height %d, can be deleted", latest)
+	}
+	st, err := stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load the state: %w", err)
+	}
+	if st.LastBlockHeight >= height {
+		return fmt.Errorf("the state is at height %d, roll it back with the rollback command first",
+			st.LastBlockHeight)
+	}
+	return nil
+}
+
+func repairResetSeenCommit(cmd *cobra.Command, args []string) error {
+	height, err := parseRepairHeight(args[0])
+	if err != nil {
+		return err
+	}
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	if height < blockStore.Base() || height >= blockStore.Height() {
+		return fmt.Errorf("the height must be between the base %d and the latest height %d (excluded)",
+			blockStore.Base(), blockStore.Height())
+	}
+	if apply, err := confirmRepair(cmd, fmt.Sprintf("replace the seen commit of height %d with its canonical commit",
+		height)); !apply {
+		return err
+	}
+	if err := blockStore.ResetSeenCommit(height); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "reset the seen commit of height %d\n", height)
+	return nil
+}
+
+func repairRebuildState(cmd *cobra.Command, args []string) error {
+	if !cmtos.FileExists(filepath.Join(config.DBDirOf("blockstore"), "blockstore.db")) {
+		return fmt.Errorf("no blockstore found in %v", config.DBDirOf("blockstore"))
+	}
+	db, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackendOf("blockstore")), config.DBDirOf("blockstore"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rebuilt, count, err := store.RebuildBlockStoreState(db)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("no blocks found in the block store")
+	}
+	if missing := rebuilt.Height - rebuilt.Base + 1 - count; missing > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "warning: %d blocks are missing between the base and the height\n", missing)
+	}
+
+	current, err := loadBlockStoreState(db)
+	if err != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "the current record is corrupted: %v\n", err)
+	} else if current == rebuilt {
+		fmt.Fprintf(cmd.OutOrStdout(), "the record matches the blocks, base %d and height %d\n",
+			current.Base, current.Height)
+		return nil
+	}
+	if apply, err := confirmRepair(cmd, fmt.Sprintf("set the base of the block store to %d and its height to %d",
+		rebuilt.Base, rebuilt.Height)); !apply {
+		return err
+	}
+	store.SaveBlockStoreState(&rebuilt, db)
+	fmt.Fprintf(cmd.OutOrStdout(), "rebuilt the block store record, base %d and height %d\n",
+		rebuilt.Base, rebuilt.Height)
+	return nil
+}
+
+// loadBlockStoreState loads the record of the block store, returning an error
+// instead of panicking if it's corrupted.
+func loadBlockStoreState(db dbm.DB) (bss cmtstore.BlockStoreState, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return store.LoadBlockStoreState(db), nil
+}
+
+// confirmRepair describes the repair and returns whether to apply it: writes
+// must be allowed and the operator must confirm it, unless --yes is set.
+func confirmRepair(cmd *cobra.Command, repair string) (bool, error) {
+	out := cmd.OutOrStdout()
+	if !repairAllowWrites {
+		fmt.Fprintf(out, "would %s; run with --allow-writes to apply\n", repair)
+		return false, nil
+	}
+	if repairYes {
+		return true, nil
+	}
+	return confirm(cmd, repair)
+}
+
+// confirm asks for a confirmation of action on the input of cmd.
+func confirm(cmd *cobra.Command, action string) (bool, error) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "about to %s; type 'yes' to continue: ", action)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("reading the confirmation: %w", err)
+	}
+	if strings.TrimSpace(answer) != "yes" {
+		fmt.Fprintln(out, "aborted")
+		return false, nil
+	}
+	return true, nil
+}
+
+func parseRepairHeight(arg string) (int64, error) {
+	height, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || height <= 0 {
+		return 0, fmt.Errorf("invalid height %q", arg)
+	}
+	return height, nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/mocks"
+)
+
+func TestConfirmRepair(t *testing.T) {
+	defer func() { repairAllowWrites, repairYes = false, false }()
+
+	run := func(input string) (bool, string) {
+		cmd := &cobra.Command{}
+		var out bytes.Buffer
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetOut(&out)
+		apply, err := confirmRepair(cmd, "delete block 5")
+		require.NoError(t, err)
+		return apply, out.String()
+	}
+
+	// writes are not allowed by default
+	apply, out := run("yes\n")
+	require.False(t, apply)
+	require.Contains(t, out, "would delete block 5")
+
+	repairAllowWrites = true
+	apply, out = run("no\n")
+	require.False(t, apply)
+	require.Contains(t, out, "aborted")
+	apply, _ = run("yes\n")
+	require.True(t, apply)
+
+	repairYes = true
+	apply, _ = run("")
+	require.True(t, apply)
+}
+
+func TestCheckDeleteBlock(t *testing.T) {
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(10))
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(sm.State{LastBlockHeight: 10}, nil).Once()
+
+	require.ErrorContains(t, checkDeleteBlock(blockStore, stateStore, 9), "only the latest block")
+	require.ErrorContains(t, checkDeleteBlock(blockStore, stateStore, 10), "roll it back")
+
+	stateStore.On("Load").Return(sm.State{LastBlockHeight: 9}, nil)
+	require.NoError(t, checkDeleteBlock(blockStore, stateStore, 10))
+}
@@ -1,9 +1,18 @@
 package commands
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/consensus"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
 )
 
 // ReplayCmd allows replaying of messages from the WAL.
@@ -11,7 +20,7 @@ var ReplayCmd = &cobra.Command{
 	Use:   "replay",
 	Short: "Replay messages from WAL",
 	Run: func(cmd *cobra.Command, args []string) {
-		consensus.RunReplayFile(config.BaseConfig, config.Consensus, false)
+		consensus.RunReplayFile(config, false)
 	},
 }
 
@@ -22,6 +31,94 @@ var ReplayConsoleCmd = &cobra.Command{
 	Aliases: []string{"replay_console"},
 	Short:   "Replay messages from WAL in a console",
 	Run: func(cmd *cobra.Command, args []string) {
-		consensus.RunReplayFile(config.BaseConfig, config.Consensus, true)
+		consensus.RunReplayFile(config, true)
 	},
 }
+
+var (
+	replayWALHeight   int64
+	replayWALProxyApp string
+)
+
+// ReplayWALCmd replays the WAL against the app in a sandbox, up to a height.
+var ReplayWALCmd = &cobra.Command{
+	Use:     "replay-wal",
+	Aliases: []string{"replay_wal"},
+	Short:   "Replay the WAL against the app in a sandbox, up to a height",
+	Long: `Replay the consensus WAL of a stopped node, from genesis to the end of a height,
+against a fresh instance of the app, and check that the app hash it ends with
+is the one of the blocks of the node.
+
+The blocks and the states of the replay are kept in memory, and the WAL is only
+read, so the data of the node are left as is. The WAL must hold all the heights
+from genesis, i.e. not be pruned nor compacted, and the app must start from
+genesis: a built-in app is run in a temporary directory, while an external one
+must be started anew, at --proxy_app.`,
+	Example: `
+	cometbft replay-wal --height 100
+	cometbft replay-wal --height 100 --proxy_app tcp://127.0.0.1:36658
+	`,
+	Args: cobra.NoArgs,
+	RunE: replayWAL,
+}
+
+func init() {
+	ReplayWALCmd.Flags().Int64Var(&replayWALHeight, "height", 0,
+		"height to replay the WAL up to")
+	ReplayWALCmd.Flags().StringVar(&replayWALProxyApp, "proxy_app", "",
+		"address of the fresh instance of the app, defaults to the proxy_app of the config")
+}
+
+func replayWAL(cmd *cobra.Command, args []string) error {
+	if replayWALHeight <= 0 {
+		return errors.New("--height must be greater than 0")
+	}
+	walFile := config.Consensus.WalFile()
+	if !cmtos.FileExists(walFile) {
+		return fmt.Errorf("no WAL found at %v", walFile)
+	}
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+
+	proxyApp := replayWALProxyApp
+	if proxyApp == "" {
+		proxyApp = config.ProxyApp
+	}
+	appDir, err := os.MkdirTemp("", "replay-wal")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(appDir)
+	clientCreator := proxy.DefaultClientCreator(proxyApp, config.ABCI, appDir)
+
+	state, err := consensus.ReplayFile(config.Consensus, genDoc, clientCreator, walFile, replayWALHeight, logger)
+	if err != nil {
+		return fmt.Errorf("failed to replay the WAL: %w", err)
+	}
+	fmt.Printf("replayed the WAL up to height %d, app hash %X\n", state.LastBlockHeight, state.AppHash)
+
+	// the app hash of a height is in the header of the next one
+	if !cmtos.FileExists(filepath.Join(config.DBDirOf("blockstore"), "blockstore.db")) {
+		return nil
+	}
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+	meta := blockStore.LoadBlockMeta(state.LastBlockHeight + 1)
+	if meta == nil {
+		return nil
+	}
+	if !bytes.Equal(meta.Header.AppHash, state.AppHash) {
+		return fmt.Errorf("the app hash of the replay differs from the one of the block %d: %X",
+			meta.Header.Height, meta.Header.AppHash)
+	}
+	fmt.Printf("the app hash matches the one of the block %d\n", meta.Header.Height)
+	return nil
+}
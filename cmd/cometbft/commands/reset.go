@@ -33,7 +33,11 @@ var ResetStateCmd = &cobra.Command{
 			return err
 		}
 
-		return resetState(config.DBDir(), logger)
+		if err := resetState(config.DBDir(), logger); err != nil {
+			return err
+		}
+		removeSeparateDBs(logger)
+		return nil
 	},
 }
 
@@ -57,13 +61,17 @@ func resetAllCmd(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	return resetAll(
+	if err := resetAll(
 		config.DBDir(),
 		config.P2P.AddrBookFile(),
 		config.PrivValidatorKeyFile(),
 		config.PrivValidatorStateFile(),
 		logger,
-	)
+	); err != nil {
+		return err
+	}
+	removeSeparateDBs(logger)
+	return nil
 }
 
 // XXX: this is totally unsafe.
@@ -155,6 +163,26 @@ func resetState(dbDir string, logger log.Logger) error {
 	return nil
 }
 
+// removeSeparateDBs removes the databases of the node kept outside of its
+// data directory, see config.DBDirOf.
+func removeSeparateDBs(logger log.Logger) {
+	for _, name := range nodeDBs {
+		dir := config.DBDirOf(name)
+		if dir == config.DBDir() {
+			continue
+		}
+		path := filepath.Join(dir, name+".db")
+		if !cmtos.FileExists(path) {
+			continue
+		}
+		if err := os.RemoveAll(path); err == nil {
+			logger.Info("Removed "+name+".db", "dir", path)
+		} else {
+			logger.Error("error removing "+name+".db", "dir", path, "err", err)
+		}
+	}
+}
+
 func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	if _, err := os.Stat(privValKeyFile); err == nil {
 		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
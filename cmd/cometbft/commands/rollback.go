@@ -14,9 +14,11 @@ import (
 	"github.com/tendermint/tendermint/store"
 )
 
+var rollbackHeight int64
+
 var RollbackStateCmd = &cobra.Command{
 	Use:   "rollback",
-	Short: "rollback CometBFT state by one height",
+	Short: "rollback CometBFT state by one height, or to a height",
 	Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when CometBFT has persisted an incorrect app hash and is thus unable to make
@@ -24,9 +26,18 @@ progress. Rollback overwrites a state at height n with the state at height n - 1
 The application should also roll back to height n - 1. No blocks are removed, so upon
 restarting CometBFT the transactions in block n will be re-executed against the
 application.
+
+With --height, the state is rolled back to the given height instead, e.g. to recover
+from a bad batch of blocks: the validator sets, consensus params and ABCI responses of
+the heights above it are deleted, and so are the blocks above it. The application
+should also roll back to the height.
 `,
+	Example: `
+	cometbft rollback
+	cometbft rollback --height 1000
+	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		height, hash, err := RollbackState(config)
+		height, hash, err := RollbackState(config, rollbackHeight)
 		if err != nil {
 			return fmt.Errorf("failed to rollback state: %w", err)
 		}
@@ -36,10 +47,16 @@ application.
 	},
 }
 
+func init() {
+	RollbackStateCmd.Flags().Int64Var(&rollbackHeight, "height", 0,
+		"height to roll the state and the blocks back to, defaults to one height below the state")
+}
+
 // RollbackState takes the state at the current height n and overwrites it with the state
-// at height n - 1. Note state here refers to CometBFT state not application state.
+// at height n - 1, or at height if it is set, also deleting the blocks above it. Note state
+// here refers to CometBFT state not application state.
 // Returns the latest state height and app hash alongside an error if there was one.
-func RollbackState(config *cfg.Config) (int64, []byte, error) {
+func RollbackState(config *cfg.Config, height int64) (int64, []byte, error) {
 	// use the parsed config to load the block and state store
 	blockStore, stateStore, err := loadStateAndBlockStore(config)
 	if err != nil {
@@ -50,35 +67,41 @@ func RollbackState(config *cfg.Config) (int64, []byte, error) {
 		_ = stateStore.Close()
 	}()
 
+	if height > 0 {
+		return state.RollbackToHeight(blockStore, stateStore, height)
+	}
 	// rollback the last state
 	return state.Rollback(blockStore, stateStore)
 }
 
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
-	dbType := dbm.BackendType(config.DBBackend)
-
-	if !os.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
-		return nil, nil, fmt.Errorf("no blockstore found in %v", config.DBDir())
+	if !os.FileExists(filepath.Join(config.DBDirOf("blockstore"), "blockstore.db")) {
+		return nil, nil, fmt.Errorf("no blockstore found in %v", config.DBDirOf("blockstore"))
 	}
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackendOf("blockstore")),
+		config.DBDirOf("blockstore"))
 	if err != nil {
 		return nil, nil, err
 	}
-	blockStore := store.NewBlockStore(blockStoreDB)
+	blockStore := store.NewBlockStore(blockStoreDB,
+		store.WithCompression(config.Storage.BlockCompression),
+		store.WithPruningMode(config.Storage.PruningMode),
+	)
 
-	if !os.FileExists(filepath.Join(config.DBDir(), "state.db")) {
-		return nil, nil, fmt.Errorf("no statestore found in %v", config.DBDir())
+	if !os.FileExists(filepath.Join(config.DBDirOf("state"), "state.db")) {
+		return nil, nil, fmt.Errorf("no statestore found in %v", config.DBDirOf("state"))
 	}
 
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(config.DBBackendOf("state")), config.DBDirOf("state"))
 	if err != nil {
 		return nil, nil, err
 	}
 	stateStore := state.NewStore(stateDB, state.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		DiscardABCIResponses:      config.Storage.DiscardABCIResponses,
+		ValidatorSetDeltaInterval: config.Storage.ValidatorSetDeltaInterval,
 	})
 
 	return blockStore, stateStore, nil
@@ -86,7 +86,8 @@ var RootCmd = &cobra.Command{
 			logger = log.NewTracingLogger(logger)
 		}
 
-		logger = logger.With("module", "main")
+		// the log settings can be changed when the node reloads its configuration
+		logger = log.NewSwappableLogger(logger).With("module", "main")
 		return nil
 	},
 }
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+var keyRotationHeight int64
+
+// RotateValidatorKeyCmd prepares the rotation of the validator key.
+var RotateValidatorKeyCmd = &cobra.Command{
+	Use:   "rotate-validator-key",
+	Short: "Prepare the rotation of this node's validator key to a new key",
+	Long: `Generate the key this node's validator rotates to, save it to the private
+validator key file, and print the key rotation signed by the current key.
+
+The key rotation must be submitted to the application, which returns it in
+EndBlock of the block two blocks before the rotation height. The node switches
+to the new key once the rotation takes effect, without leaving the validator
+set. Running the command again replaces the new key.`,
+	RunE: rotateValidatorKey,
+}
+
+func init() {
+	RotateValidatorKeyCmd.Flags().Int64Var(&keyRotationHeight, "height", 0,
+		"height from which the validator signs with the new key")
+	RotateValidatorKeyCmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519,
+		"type of the validator key to generate: ed25519, secp256k1, sr25519 or bls12_381")
+}
+
+func rotateValidatorKey(cmd *cobra.Command, args []string) error {
+	if keyRotationHeight <= 0 {
+		return errors.New("--height must be positive")
+	}
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !cmtos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file: %w", err)
+	}
+
+	pv := privval.LoadFilePVEmptyState(keyFilePath, "")
+	if _, err := pv.GenNextKey(keyType); err != nil {
+		return err
+	}
+	kr, err := pv.SignKeyRotation(genDoc.ChainID, keyRotationHeight)
+	if err != nil {
+		return err
+	}
+
+	jsbz, err := cmtjson.Marshal(kr)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(`%v
+`, string(jsbz))
+	return nil
+}
@@ -6,11 +6,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	cfg "github.com/tendermint/tendermint/config"
 	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/service"
 	nm "github.com/tendermint/tendermint/node"
 )
 
@@ -31,6 +36,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"socket address to listen on for connections from external priv_validator process")
 
 	// node flags
+	cmd.Flags().String("mode", config.Mode, "node mode (validator | full | seed | archive)")
 	cmd.Flags().Bool("fast_sync", config.FastSyncMode, "fast blockchain syncing")
 	cmd.Flags().BytesHexVar(
 		&genesisHash,
@@ -83,6 +89,18 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"consensus.create_empty_blocks_interval",
 		config.Consensus.CreateEmptyBlocksInterval.String(),
 		"the possible interval between empty blocks")
+	cmd.Flags().Bool(
+		"consensus.lazy_block_production",
+		config.Consensus.LazyBlockProduction,
+		"produce blocks as soon as there are txs, and empty blocks only after the max idle interval")
+	cmd.Flags().String(
+		"consensus.lazy_block_min_interval",
+		config.Consensus.LazyBlockMinInterval.String(),
+		"the min interval between blocks in the lazy block production mode")
+	cmd.Flags().String(
+		"consensus.lazy_block_max_idle_interval",
+		config.Consensus.LazyBlockMaxIdleInterval.String(),
+		"the interval without txs after which an empty block is produced in the lazy block production mode")
 
 	// db flags
 	cmd.Flags().String(
@@ -103,37 +121,82 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 		Aliases: []string{"node", "run"},
 		Short:   "Run the CometBFT node",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkGenesisHash(config); err != nil {
-				return err
-			}
+			return runNode(cmd, nodeProvider)
+		},
+	}
 
-			n, err := nodeProvider(config, logger)
-			if err != nil {
-				return fmt.Errorf("failed to create node: %w", err)
-			}
+	AddNodeFlags(cmd)
+	return cmd
+}
 
-			if err := n.Start(); err != nil {
-				return fmt.Errorf("failed to start node: %w", err)
+// runNode creates a node with nodeProvider, starts it and runs it until it
+// receives SIGTERM or CTRL-C.
+func runNode(cmd *cobra.Command, nodeProvider nm.Provider) error {
+	if err := checkGenesisHash(config); err != nil {
+		return err
+	}
+
+	n, err := nodeProvider(config, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create node: %w", err)
+	}
+	n.SetConfigLoader(func() (*cfg.Config, error) {
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, err
+		}
+		return ParseConfig(cmd)
+	})
+
+	if err := n.Start(); err != nil {
+		return fmt.Errorf("failed to start node: %w", err)
+	}
+
+	logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
+
+	// Stop upon receiving SIGTERM or CTRL-C, giving up after the
+	// shutdown deadline.
+	cmtos.TrapSignal(logger, func() {
+		if n.IsRunning() {
+			if err := stopWithDeadline(n, config.ShutdownTimeout); err != nil {
+				logger.Error("unable to stop the node", "error", err)
 			}
+		}
+	})
 
-			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
+	// Reload the configuration upon receiving SIGHUP.
+	trapReloadSignal(n)
 
-			// Stop upon receiving SIGTERM or CTRL-C.
-			cmtos.TrapSignal(logger, func() {
-				if n.IsRunning() {
-					if err := n.Stop(); err != nil {
-						logger.Error("unable to stop the node", "error", err)
-					}
-				}
-			})
+	// Run forever.
+	select {}
+}
 
-			// Run forever.
-			select {}
-		},
-	}
+// trapReloadSignal reloads the configuration of the node upon receiving
+// SIGHUP.
+func trapReloadSignal(n *nm.Node) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for range c {
+			if _, err := n.ReloadConfig(); err != nil {
+				logger.Error("failed to reload the configuration", "err", err)
+			}
+		}
+	}()
+}
 
-	AddNodeFlags(cmd)
-	return cmd
+// stopWithDeadline stops s, returning an error if it doesn't stop within
+// timeout.
+func stopWithDeadline(s service.Service, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Stop()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("not stopped after the shutdown deadline of %v", timeout)
+	}
 }
 
 func checkGenesisHash(config *cfg.Config) error {
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+type slowService struct {
+	service.BaseService
+	delay time.Duration
+}
+
+func newSlowService(delay time.Duration) *slowService {
+	s := &slowService{delay: delay}
+	s.BaseService = *service.NewBaseService(nil, "slowService", s)
+	return s
+}
+
+func (s *slowService) OnStop() {
+	time.Sleep(s.delay)
+}
+
+func TestStopWithDeadline(t *testing.T) {
+	s := newSlowService(0)
+	require.NoError(t, s.Start())
+	require.NoError(t, stopWithDeadline(s, time.Second))
+	require.False(t, s.IsRunning())
+
+	s = newSlowService(time.Second)
+	require.NoError(t, s.Start())
+	require.ErrorContains(t, stopWithDeadline(s, 10*time.Millisecond), "shutdown deadline")
+}
@@ -2,10 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/privval"
 )
@@ -19,6 +22,16 @@ var ShowValidatorCmd = &cobra.Command{
 }
 
 func showValidator(cmd *cobra.Command, args []string) error {
+	if config.PrivValidatorKeyShare != "" {
+		return showThresholdValidator()
+	}
+	if config.PrivValidatorPKCS11Module != "" {
+		return showPKCS11Validator()
+	}
+	if config.PrivValidatorLedger {
+		return showLedgerValidator()
+	}
+
 	keyFilePath := config.PrivValidatorKeyFile()
 	if !cmtos.FileExists(keyFilePath) {
 		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
@@ -39,3 +52,58 @@ func showValidator(cmd *cobra.Command, args []string) error {
 	fmt.Println(string(bz))
 	return nil
 }
+
+func showThresholdValidator() error {
+	share, err := privval.LoadKeyShare(config.PrivValidatorKeyShareFile())
+	if err != nil {
+		return fmt.Errorf("can't load key share: %w", err)
+	}
+
+	bz, err := cmtjson.Marshal(ed25519.PubKey(share.PubKey))
+	if err != nil {
+		return fmt.Errorf("failed to marshal threshold validator pubkey: %w", err)
+	}
+
+	fmt.Println(string(bz))
+	return nil
+}
+
+func showPKCS11Validator() error {
+	pubKey, err := privval.PKCS11PubKey(privval.PKCS11Config{
+		Module:     config.PrivValidatorPKCS11Module,
+		TokenLabel: config.PrivValidatorPKCS11Token,
+		PIN:        config.PrivValidatorPKCS11PIN,
+		KeyLabel:   config.PrivValidatorPKCS11Key,
+	})
+	if err != nil {
+		return fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	bz, err := cmtjson.Marshal(pubKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal private validator pubkey: %w", err)
+	}
+
+	fmt.Println(string(bz))
+	return nil
+}
+
+func showLedgerValidator() error {
+	// prompts go to stderr, to keep the public key alone on stdout
+	pubKey, err := privval.LedgerPubKey(log.NewTMLogger(log.NewSyncWriter(os.Stderr)), privval.LedgerConfig{
+		Device:         config.PrivValidatorLedgerDevice,
+		HDPath:         config.PrivValidatorLedgerHDPath,
+		ConfirmTimeout: config.PrivValidatorLedgerConfirmTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	bz, err := cmtjson.Marshal(pubKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal private validator pubkey: %w", err)
+	}
+
+	fmt.Println(string(bz))
+	return nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/threshold"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+)
+
+var (
+	keyShareThreshold int
+	keyShareTotal     int
+	keyShareOutputDir string
+)
+
+// SplitValidatorKeyCmd splits the validator key into shares for threshold
+// signing.
+var SplitValidatorKeyCmd = &cobra.Command{
+	Use:   "split-validator-key",
+	Short: "Split this node's validator key into shares for threshold signing",
+	Long: `Split this node's ed25519 validator key into shares, any threshold of which
+can sign together. Each share must be copied to its cosigner, and the original
+key file destroyed afterwards: the shares are only useful if the key no longer
+exists in one place.
+
+This command is a trusted dealer, holding the whole key and all the shares: it
+must be run on an ephemeral, offline machine.`,
+	RunE: splitValidatorKey,
+}
+
+func init() {
+	SplitValidatorKeyCmd.Flags().IntVar(&keyShareThreshold, "threshold", 2,
+		"number of shares required to sign")
+	SplitValidatorKeyCmd.Flags().IntVar(&keyShareTotal, "shares", 3,
+		"total number of shares")
+	SplitValidatorKeyCmd.Flags().StringVar(&keyShareOutputDir, "output-dir", ".",
+		"directory to write the key shares to")
+}
+
+func splitValidatorKey(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !cmtos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+
+	pv := privval.LoadFilePVEmptyState(keyFilePath, "")
+	privKey, ok := pv.Key.PrivKey.(ed25519.PrivKey)
+	if !ok {
+		return fmt.Errorf("threshold signing requires an ed25519 key, got %s", pv.Key.PrivKey.Type())
+	}
+
+	shares, err := threshold.SplitKey(privKey, keyShareThreshold, keyShareTotal, crypto.CReader())
+	if err != nil {
+		return err
+	}
+
+	for _, share := range shares {
+		path := filepath.Join(keyShareOutputDir, fmt.Sprintf("priv_validator_key_share_%d.json", share.ID))
+		if err := privval.SaveKeyShare(share, path); err != nil {
+			return fmt.Errorf("failed to save key share: %w", err)
+		}
+		logger.Info("Saved key share", "id", share.ID, "path", path)
+	}
+	return nil
+}
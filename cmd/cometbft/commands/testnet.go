@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/hd"
 	"github.com/tendermint/tendermint/libs/bytes"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/p2p"
@@ -34,10 +35,17 @@ var (
 	hostnames               []string
 	p2pPort                 int
 	randomMonikers          bool
+	hdMnemonic              string
+	hdPassword              string
 )
 
 const (
 	nodeDirPerm = 0755
+
+	// derivation paths of the validator and node keys of the i-th node, in
+	// --hd-mnemonic mode
+	hdValidatorKeyPath = "m/44'/118'/0'/0'/%d'"
+	hdNodeKeyPath      = "m/44'/118'/1'/0'/%d'"
 )
 
 func init() {
@@ -74,6 +82,11 @@ func init() {
 		"P2P Port")
 	TestnetFilesCmd.Flags().BoolVar(&randomMonikers, "random-monikers", false,
 		"randomize the moniker for each generated node")
+	TestnetFilesCmd.Flags().StringVar(&hdMnemonic, "hd-mnemonic", "",
+		"derive the validator and node keys of all the nodes from this BIP39 mnemonic"+
+			" (the validator and node keys of node i are derived at m/44'/118'/0'/0'/i' and m/44'/118'/1'/0'/i')")
+	TestnetFilesCmd.Flags().StringVar(&hdPassword, "hd-password", "",
+		"BIP39 password used with --hd-mnemonic")
 }
 
 // TestnetFilesCmd allows initialisation of files for a CometBFT testnet.
@@ -87,6 +100,10 @@ Note, strict routability for addresses is turned off in the config file.
 
 Optionally, it will fill in persistent_peers list in config file using either hostnames or IPs.
 
+With --hd-mnemonic, the keys of all the nodes are derived from a single BIP39
+mnemonic, so that running the command again with the same mnemonic recreates
+the same validators and node IDs.
+
 Example:
 
 	cometbft testnet --v 4 --o ./output --populate-persistent-peers --starting-ip-address 192.168.10.2
@@ -118,6 +135,15 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var seed []byte
+	if hdMnemonic != "" {
+		var err error
+		seed, err = hd.SeedFromMnemonic(hdMnemonic, hdPassword)
+		if err != nil {
+			return err
+		}
+	}
+
 	genVals := make([]types.GenesisValidator, nValidators)
 
 	for i := 0; i < nValidators; i++ {
@@ -136,6 +162,13 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		if seed != nil {
+			if err := deriveKeys(config, seed, i); err != nil {
+				_ = os.RemoveAll(outputDir)
+				return err
+			}
+		}
+
 		if err := initFilesWithConfig(config); err != nil {
 			return err
 		}
@@ -148,11 +181,16 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		proof, err := types.ProofOfPossession(pv.Key.PrivKey)
+		if err != nil {
+			return fmt.Errorf("can't prove the possession of the key: %w", err)
+		}
 		genVals[i] = types.GenesisValidator{
-			Address: pubKey.Address(),
-			PubKey:  pubKey,
-			Power:   1,
-			Name:    nodeDirName,
+			Address:           pubKey.Address(),
+			PubKey:            pubKey,
+			Power:             1,
+			Name:              nodeDirName,
+			ProofOfPossession: proof,
 		}
 	}
 
@@ -172,6 +210,13 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		if seed != nil {
+			if err := deriveKeys(config, seed, i+nValidators); err != nil {
+				_ = os.RemoveAll(outputDir)
+				return err
+			}
+		}
+
 		if err := initFilesWithConfig(config); err != nil {
 			return err
 		}
@@ -226,6 +271,22 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// deriveKeys saves the validator and node keys of the i-th node, derived from
+// the seed.
+func deriveKeys(config *cfg.Config, seed []byte, i int) error {
+	pvKey, err := hd.DerivePrivKey(seed, fmt.Sprintf(hdValidatorKeyPath, i), keyType)
+	if err != nil {
+		return err
+	}
+	privval.NewFilePV(pvKey, config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()).Save()
+
+	nodeKey, err := hd.DeriveEd25519(seed, fmt.Sprintf(hdNodeKeyPath, i))
+	if err != nil {
+		return err
+	}
+	return (&p2p.NodeKey{PrivKey: nodeKey}).SaveAs(config.NodeKeyFile())
+}
+
 func hostnameOrIP(i int) string {
 	if len(hostnames) > 0 && i < len(hostnames) {
 		return hostnames[i]
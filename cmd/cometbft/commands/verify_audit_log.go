@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/privval"
+)
+
+// VerifyAuditLogCmd verifies the audit log of the private validator.
+var VerifyAuditLogCmd = &cobra.Command{
+	Use:   "verify-audit-log [file]",
+	Short: "Verify the audit log of the messages signed by this node's validator",
+	Long: `Verify the chain of hashes of the private validator audit log, and report
+the messages signed twice for the same type, chain, height and round with
+different contents, which indicate a possible equivocation.
+
+The audit log defaults to priv_validator_audit_log_file of the configuration.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: verifyAuditLog,
+}
+
+func verifyAuditLog(cmd *cobra.Command, args []string) error {
+	filePath := config.PrivValidatorAuditLogFile()
+	if len(args) > 0 {
+		filePath = args[0]
+	}
+	if filePath == "" {
+		return errors.New("no audit log file given, and priv_validator_audit_log_file is not set")
+	}
+
+	entries, err := privval.VerifyAuditLog(filePath)
+	if err != nil {
+		return fmt.Errorf("audit log verification failed after %d entries: %w", len(entries), err)
+	}
+	if len(entries) == 0 {
+		fmt.Println("Audit log is empty")
+		return nil
+	}
+	fmt.Printf("Verified %d entries, last hash %v\n", len(entries), entries[len(entries)-1].Hash)
+
+	conflicts := privval.FindAuditLogConflicts(entries)
+	for _, c := range conflicts {
+		fmt.Printf("Possible equivocation: %v at height %d, round %d of %s signed by entries %d and %d\n",
+			c[0].Type, c[0].Height, c[0].Round, c[0].ChainID, c[0].Index, c[1].Index)
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("found %d possible equivocations", len(conflicts))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/consensus"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+)
+
+// WALCmd groups the commands on the consensus WAL of a stopped node.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Maintain the consensus WAL of a stopped node",
+}
+
+var walCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Compact the consensus WAL",
+	Long: `Rewrite the consensus WAL into a single file, holding the messages from the end
+of the height before the last one, which is all that recovering from a crash
+needs, and remove its other files. The node must be stopped.
+
+The WAL is rotated and pruned while the node runs, according to the
+wal_max_segment_size and wal_max_segment_age options.`,
+	Example: `
+	cometbft wal compact
+	`,
+	Args: cobra.NoArgs,
+	RunE: compactWAL,
+}
+
+func init() {
+	WALCmd.AddCommand(walCompactCmd)
+}
+
+func compactWAL(cmd *cobra.Command, args []string) error {
+	walFile := config.Consensus.WalFile()
+	if !cmtos.FileExists(walFile) {
+		return fmt.Errorf("no WAL found at %v", walFile)
+	}
+	saved, err := consensus.CompactWAL(walFile)
+	if err != nil {
+		return fmt.Errorf("failed to compact the WAL: %w", err)
+	}
+	fmt.Printf("compacted %s, %s reclaimed\n", walFile, formatBytes(saved))
+	return nil
+}
@@ -19,18 +19,34 @@ func main() {
 		cmd.ProbeUpnpCmd,
 		cmd.LightCmd,
 		cmd.ReIndexEventCmd,
+		cmd.ReindexCmd,
+		cmd.ExportCmd,
+		cmd.ExportStateCmd,
+		cmd.MigrateIndexerCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
+		cmd.ReplayWALCmd,
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ResetStateCmd,
+		cmd.RotateValidatorKeyCmd,
 		cmd.ShowValidatorCmd,
+		cmd.VerifyAuditLogCmd,
+		cmd.SplitValidatorKeyCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.CompactDBCmd,
+		cmd.MigrateDBCmd,
+		cmd.MigrateValidatorSetsCmd,
+		cmd.PruneCmd,
+		cmd.RepairCmd,
+		cmd.RecompressBlocksCmd,
+		cmd.BlockStoreCmd,
+		cmd.WALCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
@@ -47,6 +63,7 @@ func main() {
 
 	// Create & start node
 	rootCmd.AddCommand(cmd.NewRunNodeCmd(nodeFunc))
+	rootCmd.AddCommand(cmd.NewExportAndRestartCmd(nodeFunc))
 
 	cmd := cli.PrepareBaseCmd(rootCmd, "CMT", os.ExpandEnv(filepath.Join("$HOME", cfg.DefaultTendermintDir)))
 	if err := cmd.Execute(); err != nil {
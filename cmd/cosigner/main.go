@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+
+	"github.com/tendermint/tendermint/privval"
+)
+
+func main() {
+	var (
+		laddr         = flag.String("laddr", "tcp://0.0.0.0:26660", "Address to listen on for the validator node")
+		keySharePath  = flag.String("key-share", "", "threshold key share file path")
+		cosignerState = flag.String("cosigner-state", "", "cosigner state file path")
+
+		logger = log.NewTMLogger(
+			log.NewSyncWriter(os.Stdout),
+		).With("module", "cosigner")
+	)
+	flag.Parse()
+
+	logger.Info(
+		"Starting cosigner",
+		"laddr", *laddr,
+		"keySharePath", *keySharePath,
+		"cosignerStatePath", *cosignerState,
+	)
+
+	share, err := privval.LoadKeyShare(*keySharePath)
+	if err != nil {
+		logger.Error("Failed to load key share", "err", err)
+		os.Exit(1)
+	}
+	cosigner, err := privval.NewLocalCosigner(share, *cosignerState)
+	if err != nil {
+		logger.Error("Failed to create cosigner", "err", err)
+		os.Exit(1)
+	}
+
+	cs := privval.NewCosignerServer(logger, *laddr, cosigner)
+	if err := cs.Start(); err != nil {
+		panic(err)
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	cmtos.TrapSignal(logger, func() {
+		err := cs.Stop()
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// Run forever.
+	select {}
+}
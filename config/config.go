@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -25,9 +26,37 @@ const (
 	DefaultLogLevel = "info"
 
 	// Mempool versions. V1 is prioritized mempool, v0 is regular mempool.
-	// Default is v0.
-	MempoolV0 = "v0"
-	MempoolV1 = "v1"
+	// Default is v0, also used when the version is empty. Priority is an alias
+	// of v1.
+	MempoolV0       = "v0"
+	MempoolV1       = "v1"
+	MempoolPriority = "priority"
+
+	// MempoolDefaultLane is the lane of the v0 mempool of the txs which the app
+	// assigns to no lane, or to a lane which isn't configured.
+	MempoolDefaultLane = "default"
+
+	// Mempool broadcast modes. In the flood mode, the txs are gossiped to all
+	// the peers, and in the sequencer mode only to the sequencer peers.
+	MempoolBroadcastFlood     = "flood"
+	MempoolBroadcastSequencer = "sequencer"
+
+	// ModeValidator is a full node signing for consensus with its private
+	// validator, when it's in the validator set.
+	ModeValidator = "validator"
+	// ModeFull is a node following the chain without a private validator.
+	ModeFull = "full"
+	// ModeSeed is a node only crawling the network and sharing peer addresses
+	// with PEX, without syncing the chain.
+	ModeSeed = "seed"
+	// ModeArchive is a full node keeping all the blocks and their index.
+	ModeArchive = "archive"
+
+	// Timeout escalations. The timeouts of the rounds increase by their delta
+	// each round in the linear escalation, and by twice the previous increase
+	// in the exponential one.
+	TimeoutEscalationLinear      = "linear"
+	TimeoutEscalationExponential = "exponential"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -47,6 +76,9 @@ var (
 	defaultPrivValKeyName   = "priv_validator_key.json"
 	defaultPrivValStateName = "priv_validator_state.json"
 
+	defaultPrivValCosignerStateName = "priv_validator_cosigner_state.json"
+	defaultPrivValWatchdogStateName = "priv_validator_watchdog_state.json"
+
 	defaultNodeKeyName  = "node_key.json"
 	defaultAddrBookName = "addrbook.json"
 
@@ -55,6 +87,9 @@ var (
 	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(defaultDataDir, defaultPrivValStateName)
 
+	defaultPrivValCosignerStatePath = filepath.Join(defaultDataDir, defaultPrivValCosignerStateName)
+	defaultPrivValWatchdogStatePath = filepath.Join(defaultDataDir, defaultPrivValWatchdogStateName)
+
 	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
 
@@ -76,6 +111,8 @@ type Config struct {
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	Storage         *StorageConfig         `mapstructure:"storage"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx_index"`
+	DA              *DAConfig              `mapstructure:"da"`
+	Settlement      *SettlementConfig      `mapstructure:"settlement"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
 }
 
@@ -91,6 +128,8 @@ func DefaultConfig() *Config {
 		Consensus:       DefaultConsensusConfig(),
 		Storage:         DefaultStorageConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
+		DA:              DefaultDAConfig(),
+		Settlement:      DefaultSettlementConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
 	}
 }
@@ -107,6 +146,8 @@ func TestConfig() *Config {
 		Consensus:       TestConsensusConfig(),
 		Storage:         TestStorageConfig(),
 		TxIndex:         TestTxIndexConfig(),
+		DA:              TestDAConfig(),
+		Settlement:      TestSettlementConfig(),
 		Instrumentation: TestInstrumentationConfig(),
 	}
 }
@@ -118,6 +159,9 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.TxIndex.RootDir = root
+	cfg.Settlement.RootDir = root
+	cfg.Instrumentation.RootDir = root
 	return cfg
 }
 
@@ -145,12 +189,81 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.Storage.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [storage] section: %w", err)
+	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx_index] section: %w", err)
+	}
+	if err := cfg.DA.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [da] section: %w", err)
+	}
+	if err := cfg.Settlement.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [settlement] section: %w", err)
+	}
+	// only the sequencer posts the state updates
+	if cfg.Settlement.Enabled() && cfg.NodeMode() == ModeValidator && !cfg.DA.Enabled() {
+		return errors.New("settlement requires a da.layer, the state updates point to the blocks on it")
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	return cfg.validateMode()
+}
+
+// validateMode checks that the settings of the sections agree with the mode.
+func (cfg *Config) validateMode() error {
+	switch cfg.NodeMode() {
+	case ModeSeed:
+		if cfg.Mode != ModeSeed && cfg.Mode != ModeValidator {
+			return fmt.Errorf("p2p.seed_mode can't be set in %s mode", cfg.Mode)
+		}
+		if !cfg.P2P.PexReactor {
+			return errors.New("seed mode requires p2p.pex")
+		}
+		if cfg.StateSync.Enable {
+			return errors.New("statesync.enable can't be set in seed mode")
+		}
+	case ModeArchive:
+		if cfg.TxIndex.Indexer == "null" {
+			return errors.New("archive mode requires a tx_index.indexer")
+		}
+		if cfg.TxIndex.PruningEnabled() {
+			return errors.New("tx_index.retain-blocks and tx_index.prune-with-block-store can't be set in archive mode")
+		}
+	}
 	return nil
 }
 
+// NodeMode returns the operating mode of the node, which is ModeSeed if the
+// deprecated p2p.seed_mode is set.
+func (cfg *Config) NodeMode() string {
+	if cfg.P2P.SeedMode {
+		return ModeSeed
+	}
+	return cfg.Mode
+}
+
+// DBBackendOf returns the backend of the database of the node with the given
+// name, "blockstore", "state", "tx_index" or "evidence": the one set for it in
+// the storage section, or db_backend.
+func (cfg *Config) DBBackendOf(name string) string {
+	if backend, _ := cfg.Storage.database(name); backend != "" {
+		return backend
+	}
+	return cfg.DBBackend
+}
+
+// DBDirOf returns the full path to the directory of the database of the node
+// with the given name, like DBBackendOf: the one set for it in the storage
+// section, or db_dir.
+func (cfg *Config) DBDirOf(name string) string {
+	if _, dir := cfg.Storage.database(name); dir != "" {
+		return rootify(dir, cfg.RootDir)
+	}
+	return cfg.DBDir()
+}
+
 //-----------------------------------------------------------------------------
 // BaseConfig
 
@@ -170,6 +283,10 @@ type BaseConfig struct { //nolint: maligned
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
+	// Operating mode of the node, enabling the reactors and services it runs:
+	// validator | full | seed | archive
+	Mode string `mapstructure:"mode"`
+
 	// If this node is many blocks behind the tip of the chain, FastSync
 	// allows them to catchup quickly by downloading blocks in parallel
 	// and verifying their commits
@@ -215,9 +332,70 @@ type BaseConfig struct { //nolint: maligned
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
 	// TCP or UNIX socket address for CometBFT to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process. A comma separated
+	// list of addresses can be given to fail over between several remote
+	// signers holding the same key.
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Path to the JSON file containing this node's share of a threshold
+	// validator key. If set, the validator signs with the threshold signing
+	// protocol, together with PrivValidatorCosigners.
+	PrivValidatorKeyShare string `mapstructure:"priv_validator_key_share_file"`
+
+	// Path to the JSON file containing the last sign state of the local key share
+	PrivValidatorCosignerState string `mapstructure:"priv_validator_cosigner_state_file"`
+
+	// Comma separated list of remote cosigners holding the other shares of the
+	// threshold validator key, as <share ID>@<address>
+	PrivValidatorCosigners string `mapstructure:"priv_validator_cosigners"`
+
+	// Path to the PKCS#11 module (shared library) of an HSM holding the
+	// validator key. If set, the validator signs with the key in the HSM.
+	PrivValidatorPKCS11Module string `mapstructure:"priv_validator_pkcs11_module"`
+
+	// Label of the HSM token holding the validator key
+	PrivValidatorPKCS11Token string `mapstructure:"priv_validator_pkcs11_token"`
+
+	// Label of the validator key pair in the HSM token
+	PrivValidatorPKCS11Key string `mapstructure:"priv_validator_pkcs11_key"`
+
+	// PIN of the HSM token user. Prefer setting it with the
+	// CMT_PRIV_VALIDATOR_PKCS11_PIN environment variable.
+	PrivValidatorPKCS11PIN string `mapstructure:"priv_validator_pkcs11_pin"`
+
+	// If true, the validator signs with a key held in a Ledger device, through
+	// the Tendermint validator app.
+	PrivValidatorLedger bool `mapstructure:"priv_validator_ledger"`
+
+	// Path of the HID device of the Ledger. If empty, the first Ledger found
+	// is used.
+	PrivValidatorLedgerDevice string `mapstructure:"priv_validator_ledger_device"`
+
+	// Derivation path of the validator key in the Ledger device
+	PrivValidatorLedgerHDPath string `mapstructure:"priv_validator_ledger_hd_path"`
+
+	// Time to wait for the user to confirm a request on the Ledger device
+	PrivValidatorLedgerConfirmTimeout time.Duration `mapstructure:"priv_validator_ledger_confirm_timeout"`
+
+	// If true, signing requests go through a watchdog, which refuses to sign
+	// and halts signing on anomalies that could lead to an equivocation.
+	PrivValidatorWatchdog bool `mapstructure:"priv_validator_watchdog"`
+
+	// Path to the JSON file containing the last sign state of the watchdog
+	PrivValidatorWatchdogState string `mapstructure:"priv_validator_watchdog_state_file"`
+
+	// Maximum number of signatures per second allowed by the watchdog.
+	// 0 disables the limit.
+	PrivValidatorMaxSignRate int `mapstructure:"priv_validator_max_sign_rate"`
+
+	// Maximum distance between the height of a message to sign and the height
+	// of the node, beyond which the watchdog halts signing.
+	PrivValidatorMaxHeightDrift int64 `mapstructure:"priv_validator_max_height_drift"`
+
+	// Path to the hash-chained audit log of the messages signed by the
+	// private validator. Empty disables the audit log.
+	PrivValidatorAuditLog string `mapstructure:"priv_validator_audit_log_file"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
@@ -227,6 +405,14 @@ type BaseConfig struct { //nolint: maligned
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
+
+	// Maximum number of commit signatures verified in a single batch, when the
+	// validators' keys support batch verification. 0 verifies them one by one.
+	SigVerifyBatchSize int `mapstructure:"sig_verify_batch_size"`
+
+	// Deadline for stopping the node gracefully on SIGTERM or CTRL-C, after
+	// which the process exits even if some services haven't stopped yet.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a CometBFT node
@@ -237,14 +423,26 @@ func DefaultBaseConfig() BaseConfig {
 		PrivValidatorState: defaultPrivValStatePath,
 		NodeKey:            defaultNodeKeyPath,
 		Moniker:            defaultMoniker,
+		Mode:               ModeValidator,
 		ProxyApp:           "tcp://127.0.0.1:26658",
 		ABCI:               "socket",
 		LogLevel:           DefaultLogLevel,
 		LogFormat:          LogFormatPlain,
 		FastSyncMode:       true,
 		FilterPeers:        false,
+		SigVerifyBatchSize: 64,
+		ShutdownTimeout:    30 * time.Second,
 		DBBackend:          "goleveldb",
 		DBPath:             "data",
+
+		PrivValidatorCosignerState: defaultPrivValCosignerStatePath,
+
+		PrivValidatorLedgerHDPath:         "m/44'/118'/0'/0'/0'",
+		PrivValidatorLedgerConfirmTimeout: time.Minute,
+
+		PrivValidatorWatchdogState:  defaultPrivValWatchdogStatePath,
+		PrivValidatorMaxSignRate:    50,
+		PrivValidatorMaxHeightDrift: 1,
 	}
 }
 
@@ -277,6 +475,32 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorKeyShareFile returns the full path to the threshold key share file
+func (cfg BaseConfig) PrivValidatorKeyShareFile() string {
+	return rootify(cfg.PrivValidatorKeyShare, cfg.RootDir)
+}
+
+// PrivValidatorCosignerStateFile returns the full path to the
+// priv_validator_cosigner_state.json file
+func (cfg BaseConfig) PrivValidatorCosignerStateFile() string {
+	return rootify(cfg.PrivValidatorCosignerState, cfg.RootDir)
+}
+
+// PrivValidatorWatchdogStateFile returns the full path to the
+// priv_validator_watchdog_state.json file
+func (cfg BaseConfig) PrivValidatorWatchdogStateFile() string {
+	return rootify(cfg.PrivValidatorWatchdogState, cfg.RootDir)
+}
+
+// PrivValidatorAuditLogFile returns the full path to the audit log of the
+// private validator, or an empty string if it is disabled.
+func (cfg BaseConfig) PrivValidatorAuditLogFile() string {
+	if cfg.PrivValidatorAuditLog == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorAuditLog, cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
@@ -290,11 +514,58 @@ func (cfg BaseConfig) DBDir() string {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
+	switch cfg.Mode {
+	case ModeValidator, ModeFull, ModeSeed, ModeArchive:
+	default:
+		return errors.New("unknown mode (must be 'validator', 'full', 'seed' or 'archive')")
+	}
+	if cfg.Mode != ModeValidator && (cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorKeyShare != "" ||
+		cfg.PrivValidatorPKCS11Module != "" || cfg.PrivValidatorLedger) {
+		return fmt.Errorf("a private validator can't be set in %s mode", cfg.Mode)
+	}
 	switch cfg.LogFormat {
 	case LogFormatPlain, LogFormatJSON:
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.PrivValidatorKeyShare != "" && cfg.PrivValidatorListenAddr != "" {
+		return errors.New("priv_validator_key_share_file and priv_validator_laddr can't both be set")
+	}
+	if cfg.PrivValidatorCosigners != "" && cfg.PrivValidatorKeyShare == "" {
+		return errors.New("priv_validator_cosigners requires priv_validator_key_share_file")
+	}
+	if cfg.PrivValidatorPKCS11Module != "" {
+		if cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorKeyShare != "" {
+			return errors.New("priv_validator_pkcs11_module can't be set with priv_validator_laddr " +
+				"or priv_validator_key_share_file")
+		}
+		if cfg.PrivValidatorPKCS11Token == "" || cfg.PrivValidatorPKCS11Key == "" {
+			return errors.New("priv_validator_pkcs11_module requires priv_validator_pkcs11_token " +
+				"and priv_validator_pkcs11_key")
+		}
+	}
+	if cfg.PrivValidatorLedger {
+		if cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorKeyShare != "" ||
+			cfg.PrivValidatorPKCS11Module != "" {
+			return errors.New("priv_validator_ledger can't be set with priv_validator_laddr, " +
+				"priv_validator_key_share_file or priv_validator_pkcs11_module")
+		}
+		if cfg.PrivValidatorLedgerConfirmTimeout <= 0 {
+			return errors.New("priv_validator_ledger_confirm_timeout must be positive")
+		}
+	}
+	if cfg.SigVerifyBatchSize < 0 {
+		return errors.New("sig_verify_batch_size can't be negative")
+	}
+	if cfg.PrivValidatorMaxSignRate < 0 {
+		return errors.New("priv_validator_max_sign_rate can't be negative")
+	}
+	if cfg.PrivValidatorMaxHeightDrift < 0 {
+		return errors.New("priv_validator_max_height_drift can't be negative")
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		return errors.New("shutdown_timeout must be positive")
+	}
 	return nil
 }
 
@@ -571,6 +842,8 @@ type P2PConfig struct { //nolint: maligned
 	// peers. If another node asks it for addresses, it responds and disconnects.
 	//
 	// Does not work if the peer-exchange reactor is disabled.
+	//
+	// Deprecated: set mode to "seed" instead.
 	SeedMode bool `mapstructure:"seed_mode"`
 
 	// Comma separated list of peer IDs to keep private (will not be gossiped to
@@ -686,7 +959,9 @@ func DefaultFuzzConnConfig() *FuzzConnConfig {
 type MempoolConfig struct {
 	// Mempool version to use:
 	//  1) "v0" - (default) FIFO mempool.
-	//  2) "v1" - prioritized mempool.
+	//  2) "v1" or "priority" - prioritized mempool: the txs are reaped by
+	//  decreasing priority, as returned by the app in CheckTx, e.g. from their
+	//  fee per gas, and the lowest-priority txs are evicted when it's full.
 	Version string `mapstructure:"version"`
 	// RootDir is the root directory for all data. This should be configured via
 	// the $CMTHOME env variable or --home cmd flag rather than overriding this
@@ -698,17 +973,40 @@ type MempoolConfig struct {
 	// mempool may become invalid. If this does not apply to your application,
 	// you can disable rechecking.
 	Recheck bool `mapstructure:"recheck"`
+	// Number of dedicated ABCI connections the txs are rechecked over in
+	// parallel after each block, 0 to recheck them on the mempool connection.
+	// Only for the apps whose CheckTx is stateless per connection, i.e. doesn't
+	// depend on the txs previously checked on the same connection, as the txs
+	// of a sender may be rechecked over different connections.
+	RecheckConnections int `mapstructure:"recheck_connections"`
 	// Broadcast (default: true) defines whether the mempool should relay
 	// transactions to other peers. Setting this to false will stop the mempool
 	// from relaying transactions to other peers until they are included in a
 	// block. In other words, if Broadcast is disabled, only the peer you send
 	// the tx to will see it until it is included in a block.
 	Broadcast bool `mapstructure:"broadcast"`
+	// BroadcastMode (default: "flood") defines the peers the transactions are
+	// relayed to, if Broadcast is enabled:
+	//  1) "flood" - all the peers.
+	//  2) "sequencer" - only the SequencerPeerIDs, for the transactions to be
+	//  pushed to the sequencer rather than flooded over the network, e.g. on
+	//  the full nodes of a rollapp with a single sequencer.
+	BroadcastMode string `mapstructure:"broadcast_mode"`
+	// Comma separated list of the node IDs of the peers the transactions are
+	// relayed to in the sequencer broadcast mode, e.g. the sequencer, or the
+	// sentries in front of it. Empty on the sequencer itself.
+	SequencerPeerIDs string `mapstructure:"sequencer_peer_ids"`
 	// WalPath (default: "") configures the location of the Write Ahead Log
 	// (WAL) for the mempool. The WAL is disabled by default. To enable, set
 	// WalPath to where you want the WAL to be written (e.g.
 	// "data/mempool.wal").
 	WalPath string `mapstructure:"wal_dir"`
+	// Persist (default: false) defines whether the txs of the mempool are
+	// persisted in a journal, data/mempool.journal, for them to be rechecked
+	// and added back to the mempool when the node restarts.
+	Persist bool `mapstructure:"persist"`
+	// Maximum size of the journal of the mempool, if persisted
+	PersistMaxBytes int64 `mapstructure:"persist_max_bytes"`
 	// Maximum number of transactions in the mempool
 	Size int `mapstructure:"size"`
 	// Limit the total size of all txs in the mempool.
@@ -717,10 +1015,22 @@ type MempoolConfig struct {
 	MaxTxsBytes int64 `mapstructure:"max_txs_bytes"`
 	// Size of the cache (used to filter transactions we saw earlier) in transactions
 	CacheSize int `mapstructure:"cache_size"`
+	// Number of the latest txs whose status is tracked along their lifecycle,
+	// from received to committed or evicted, for the tx_status RPC endpoint,
+	// 0 to not track them.
+	TxStatusSize int `mapstructure:"tx_status_size"`
 	// Do not remove invalid transactions from the cache (default: false)
 	// Set to true if it's not possible for any invalid transaction to become
 	// valid again in the future.
 	KeepInvalidTxsInCache bool `mapstructure:"keep-invalid-txs-in-cache"`
+	// Size of the cache of the invalid transactions kept in the cache, apart
+	// from the valid ones, 0 to keep them along with the valid ones. Only used
+	// with KeepInvalidTxsInCache.
+	InvalidCacheSize int `mapstructure:"invalid_cache_size"`
+	// Time after which an invalid transaction is forgotten from the cache of
+	// the invalid transactions, so that it can be resubmitted, e.g. once it
+	// became valid, 0 to keep them until they are evicted by newer ones.
+	InvalidCacheTTL time.Duration `mapstructure:"invalid_cache_ttl"`
 	// Maximum size of a single transaction
 	// NOTE: the max size of a tx transmitted over the network is {max_tx_bytes}.
 	MaxTxBytes int `mapstructure:"max_tx_bytes"`
@@ -728,6 +1038,14 @@ type MempoolConfig struct {
 	// Including space needed by encoding (one varint per transaction).
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max_batch_bytes"`
+	// Maximum number of txs received from each peer per second, 0 for no limit.
+	// The txs received over it are dropped before being checked, so that a peer
+	// flooding the mempool can't starve the others.
+	PeerMaxTxsPerSecond int `mapstructure:"peer_max_txs_per_second"`
+	// Maximum number of txs of each sender in the mempool, as returned by the
+	// app in CheckTx, 0 for no limit. The txs of a sender over it are rejected,
+	// so that a single account can't fill the mempool.
+	MaxTxsPerSender int `mapstructure:"max_txs_per_sender"`
 
 	// TTLDuration, if non-zero, defines the maximum amount of time a transaction
 	// can exist for in the mempool.
@@ -744,6 +1062,27 @@ type MempoolConfig struct {
 	// has existed in the mempool at least TTLNumBlocks number of blocks or if
 	// it's insertion time into the mempool is beyond TTLDuration.
 	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
+
+	// Lanes of the v0 mempool, in the order their txs are reaped for the
+	// blocks. The app assigns a tx to a lane in CheckTx, e.g. to keep its
+	// system txs apart from the txs of the users, and each lane has its own
+	// limits and gossip rate. The txs assigned to no lane, or to a lane not
+	// listed, are in the default lane, reaped last and limited by Size and
+	// MaxTxsBytes.
+	Lanes []MempoolLaneConfig `mapstructure:"lanes"`
+}
+
+// MempoolLaneConfig defines the configuration of a lane of the v0 mempool.
+type MempoolLaneConfig struct {
+	// Name of the lane, as returned by the app in CheckTx
+	Name string `mapstructure:"name"`
+	// Maximum number of transactions in the lane
+	Size int `mapstructure:"size"`
+	// Limit the total size of all txs in the lane
+	MaxTxsBytes int64 `mapstructure:"max_txs_bytes"`
+	// Maximum number of txs of the lane sent to each peer per second, 0 for no
+	// limit
+	GossipRate int `mapstructure:"gossip_rate"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool
@@ -755,12 +1094,15 @@ func DefaultMempoolConfig() *MempoolConfig {
 		WalPath:   "",
 		// Each signature verification takes .5ms, Size reduced until we implement
 		// ABCI Recheck
-		Size:         5000,
-		MaxTxsBytes:  1024 * 1024 * 1024, // 1GB
-		CacheSize:    10000,
-		MaxTxBytes:   1024 * 1024, // 1MB
-		TTLDuration:  0 * time.Second,
-		TTLNumBlocks: 0,
+		Size:            5000,
+		MaxTxsBytes:     1024 * 1024 * 1024, // 1GB
+		PersistMaxBytes: 1024 * 1024 * 1024, // 1GB
+		CacheSize:       10000,
+		TxStatusSize:    10000,
+		MaxTxBytes:      1024 * 1024, // 1MB
+		TTLDuration:     0 * time.Second,
+		TTLNumBlocks:    0,
+		BroadcastMode:   MempoolBroadcastFlood,
 	}
 }
 
@@ -776,6 +1118,12 @@ func (cfg *MempoolConfig) WalDir() string {
 	return rootify(cfg.WalPath, cfg.RootDir)
 }
 
+// JournalFile returns the full path to the journal persisting the txs of the
+// mempool.
+func (cfg *MempoolConfig) JournalFile() string {
+	return rootify(filepath.Join(defaultDataDir, "mempool.journal"), cfg.RootDir)
+}
+
 // WalEnabled returns true if the WAL is enabled.
 func (cfg *MempoolConfig) WalEnabled() bool {
 	return cfg.WalPath != ""
@@ -784,6 +1132,16 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	switch cfg.Version {
+	case "", MempoolV0, MempoolV1, MempoolPriority:
+	default:
+		return fmt.Errorf("unknown mempool version %q", cfg.Version)
+	}
+	switch cfg.BroadcastMode {
+	case MempoolBroadcastFlood, MempoolBroadcastSequencer:
+	default:
+		return fmt.Errorf("unknown broadcast_mode %q", cfg.BroadcastMode)
+	}
 	if cfg.Size < 0 {
 		return errors.New("size can't be negative")
 	}
@@ -796,6 +1154,46 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max_tx_bytes can't be negative")
 	}
+	if cfg.PersistMaxBytes < 0 {
+		return errors.New("persist_max_bytes can't be negative")
+	}
+	if cfg.TxStatusSize < 0 {
+		return errors.New("tx_status_size can't be negative")
+	}
+	if cfg.InvalidCacheSize < 0 {
+		return errors.New("invalid_cache_size can't be negative")
+	}
+	if cfg.InvalidCacheTTL < 0 {
+		return errors.New("invalid_cache_ttl can't be negative")
+	}
+	if cfg.RecheckConnections < 0 {
+		return errors.New("recheck_connections can't be negative")
+	}
+	if cfg.PeerMaxTxsPerSecond < 0 {
+		return errors.New("peer_max_txs_per_second can't be negative")
+	}
+	if cfg.MaxTxsPerSender < 0 {
+		return errors.New("max_txs_per_sender can't be negative")
+	}
+	if cfg.Persist && cfg.PersistMaxBytes == 0 {
+		return errors.New("persist_max_bytes must be positive to persist the mempool")
+	}
+	names := make(map[string]bool, len(cfg.Lanes))
+	for _, lane := range cfg.Lanes {
+		switch {
+		case lane.Name == "" || lane.Name == MempoolDefaultLane:
+			return fmt.Errorf("invalid lane name %q", lane.Name)
+		case names[lane.Name]:
+			return fmt.Errorf("duplicate lane %q", lane.Name)
+		case lane.Size <= 0:
+			return fmt.Errorf("size of lane %q must be positive", lane.Name)
+		case lane.MaxTxsBytes <= 0:
+			return fmt.Errorf("max_txs_bytes of lane %q must be positive", lane.Name)
+		case lane.GossipRate < 0:
+			return fmt.Errorf("gossip_rate of lane %q can't be negative", lane.Name)
+		}
+		names[lane.Name] = true
+	}
 	return nil
 }
 
@@ -813,6 +1211,17 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+
+	// RPC servers used to cross-check the app hash and header of a restored
+	// snapshot before joining consensus. They should be operated
+	// independently from rpc_servers. Disabled when empty.
+	WitnessServers []string `mapstructure:"witness_servers"`
+	// Minimum number of witnesses which must agree with the restored state.
+	MinWitnessAgreement int `mapstructure:"min_witness_agreement"`
+
+	// Verify the snapshots with the light blocks and consensus params served
+	// by the peers, rather than by rpc_servers, which are then optional.
+	UseP2P bool `mapstructure:"use_p2p"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -842,11 +1251,11 @@ func TestStateSyncConfig() *StateSyncConfig {
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
 	if cfg.Enable {
-		if len(cfg.RPCServers) == 0 {
+		if len(cfg.RPCServers) == 0 && !cfg.UseP2P {
 			return errors.New("rpc_servers is required")
 		}
 
-		if len(cfg.RPCServers) < 2 {
+		if len(cfg.RPCServers) < 2 && !cfg.UseP2P {
 			return errors.New("at least two rpc_servers entries is required")
 		}
 
@@ -877,6 +1286,20 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 			return fmt.Errorf("invalid trusted_hash: %w", err)
 		}
 
+		for _, server := range cfg.WitnessServers {
+			if len(server) == 0 {
+				return errors.New("found empty witness_servers entry")
+			}
+		}
+
+		if cfg.MinWitnessAgreement < 0 {
+			return errors.New("min_witness_agreement can't be negative")
+		}
+
+		if cfg.MinWitnessAgreement > len(cfg.WitnessServers) {
+			return errors.New("min_witness_agreement can't be greater than the number of witness_servers")
+		}
+
 		if cfg.ChunkRequestTimeout < 5*time.Second {
 			return errors.New("chunk_request_timeout must be at least 5 seconds")
 		}
@@ -895,22 +1318,35 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // FastSyncConfig defines the configuration for the CometBFT fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// Number of blocks the node must apply while caught up with its peers
+	// before switching from fast sync to consensus, or, if the chain doesn't
+	// progress, of consecutive checks (one per second) it must be caught up
+	// without any block to apply. Prevents the node from flapping between
+	// modes near the tip of the chain.
+	SwitchHysteresis int `mapstructure:"switch_hysteresis"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
 func DefaultFastSyncConfig() *FastSyncConfig {
 	return &FastSyncConfig{
-		Version: "v0",
+		Version:          "v0",
+		SwitchHysteresis: 3,
 	}
 }
 
 // TestFastSyncConfig returns a default configuration for the fast sync.
 func TestFastSyncConfig() *FastSyncConfig {
-	return DefaultFastSyncConfig()
+	cfg := DefaultFastSyncConfig()
+	cfg.SwitchHysteresis = 1
+	return cfg
 }
 
 // ValidateBasic performs basic validation.
 func (cfg *FastSyncConfig) ValidateBasic() error {
+	if cfg.SwitchHysteresis < 1 {
+		return errors.New("switch_hysteresis must be at least 1")
+	}
 	switch cfg.Version {
 	case "v0":
 		return nil
@@ -933,6 +1369,12 @@ type ConsensusConfig struct {
 	WalPath string `mapstructure:"wal_file"`
 	walFile string // overrides WalPath if set
 
+	// The size and age at which the file of the WAL is rotated, if not 0. The
+	// files before the one holding the #ENDHEIGHT before the last one are
+	// removed, as they aren't needed to recover from a crash.
+	WalMaxSegmentSize int64         `mapstructure:"wal_max_segment_size"`
+	WalMaxSegmentAge  time.Duration `mapstructure:"wal_max_segment_age"`
+
 	// How long we wait for a proposal block before prevoting nil
 	TimeoutPropose time.Duration `mapstructure:"timeout_propose"`
 	// How much timeout_propose increases with each round
@@ -951,13 +1393,48 @@ type ConsensusConfig struct {
 	// NOTE: when modifying, make sure to update time_iota_ms genesis parameter
 	TimeoutCommit time.Duration `mapstructure:"timeout_commit"`
 
+	// The timeouts above are replaced by those of the consensus params, if not
+	// 0, but for those overridden locally here, if not 0
+	TimeoutProposeOverride   time.Duration `mapstructure:"timeout_propose_override"`
+	TimeoutPrevoteOverride   time.Duration `mapstructure:"timeout_prevote_override"`
+	TimeoutPrecommitOverride time.Duration `mapstructure:"timeout_precommit_override"`
+	TimeoutCommitOverride    time.Duration `mapstructure:"timeout_commit_override"`
+
+	// How the propose, prevote and precommit timeouts increase with the rounds,
+	// linear or exponential, capped at TimeoutEscalationMax if not 0
+	TimeoutEscalation    string        `mapstructure:"timeout_escalation"`
+	TimeoutEscalationMax time.Duration `mapstructure:"timeout_escalation_max"`
+
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip_timeout_commit"`
 
+	// Skip TimeoutCommit, as SkipTimeoutCommit does, when the node is the only
+	// validator, e.g. a sequencer. The other timeouts don't apply to such a
+	// node, which has all the votes as soon as it votes.
+	SingleValidatorFastPath bool `mapstructure:"single_validator_fast_path"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
 
+	// Propose a block as soon as txs are available, but not before
+	// LazyBlockMinInterval since the last commit, which replaces TimeoutCommit,
+	// and an empty block after LazyBlockMaxIdleInterval since the last commit,
+	// if not 0, without txs. It replaces CreateEmptyBlocks and
+	// CreateEmptyBlocksInterval, and disables SkipTimeoutCommit and
+	// SingleValidatorFastPath.
+	LazyBlockProduction      bool          `mapstructure:"lazy_block_production"`
+	LazyBlockMinInterval     time.Duration `mapstructure:"lazy_block_min_interval"`
+	LazyBlockMaxIdleInterval time.Duration `mapstructure:"lazy_block_max_idle_interval"`
+
+	// Commit in the proposals to ErasureCodingParity percent of the parts of
+	// the block as parity parts, erasure coded, and gossip them for the peers
+	// to reconstruct the blocks from any of their parts and parity parts as
+	// many as the parts. Only from the ErasureCodingEnableHeight of the
+	// feature consensus params on.
+	ErasureCoding       bool `mapstructure:"erasure_coding"`
+	ErasureCodingParity int  `mapstructure:"erasure_coding_parity"`
+
 	// Reactor sleep duration parameters
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer_gossip_sleep_duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
@@ -969,6 +1446,8 @@ type ConsensusConfig struct {
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(defaultDataDir, "cs.wal", "wal"),
+		WalMaxSegmentSize:           10 * 1024 * 1024, // 10MB
+		WalMaxSegmentAge:            0,
 		TimeoutPropose:              3000 * time.Millisecond,
 		TimeoutProposeDelta:         500 * time.Millisecond,
 		TimeoutPrevote:              1000 * time.Millisecond,
@@ -976,9 +1455,17 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutPrecommit:            1000 * time.Millisecond,
 		TimeoutPrecommitDelta:       500 * time.Millisecond,
 		TimeoutCommit:               1000 * time.Millisecond,
+		TimeoutEscalation:           TimeoutEscalationLinear,
+		TimeoutEscalationMax:        0,
 		SkipTimeoutCommit:           false,
+		SingleValidatorFastPath:     false,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
+		LazyBlockProduction:         false,
+		LazyBlockMinInterval:        1000 * time.Millisecond,
+		LazyBlockMaxIdleInterval:    1 * time.Hour,
+		ErasureCoding:               false,
+		ErasureCodingParity:         50,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
@@ -1005,33 +1492,25 @@ func TestConsensusConfig() *ConsensusConfig {
 
 // WaitForTxs returns true if the consensus should wait for transactions before entering the propose step
 func (cfg *ConsensusConfig) WaitForTxs() bool {
-	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0
-}
-
-// Propose returns the amount of time to wait for a proposal
-func (cfg *ConsensusConfig) Propose(round int32) time.Duration {
-	return time.Duration(
-		cfg.TimeoutPropose.Nanoseconds()+cfg.TimeoutProposeDelta.Nanoseconds()*int64(round),
-	) * time.Nanosecond
+	return cfg.LazyBlockProduction || !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0
 }
 
-// Prevote returns the amount of time to wait for straggler votes after receiving any +2/3 prevotes
-func (cfg *ConsensusConfig) Prevote(round int32) time.Duration {
-	return time.Duration(
-		cfg.TimeoutPrevote.Nanoseconds()+cfg.TimeoutPrevoteDelta.Nanoseconds()*int64(round),
-	) * time.Nanosecond
-}
-
-// Precommit returns the amount of time to wait for straggler votes after receiving any +2/3 precommits
-func (cfg *ConsensusConfig) Precommit(round int32) time.Duration {
-	return time.Duration(
-		cfg.TimeoutPrecommit.Nanoseconds()+cfg.TimeoutPrecommitDelta.Nanoseconds()*int64(round),
-	) * time.Nanosecond
+// EmptyBlocksInterval returns the time after which an empty block is proposed
+// without txs, when waiting for txs, or 0 if never.
+func (cfg *ConsensusConfig) EmptyBlocksInterval() time.Duration {
+	if cfg.LazyBlockProduction {
+		return cfg.LazyBlockMaxIdleInterval
+	}
+	return cfg.CreateEmptyBlocksInterval
 }
 
 // Commit returns the amount of time to wait for straggler votes after receiving +2/3 precommits
-// for a single block (ie. a commit).
+// for a single block (ie. a commit), or the min interval between the blocks
+// in the lazy block production mode.
 func (cfg *ConsensusConfig) Commit(t time.Time) time.Time {
+	if cfg.LazyBlockProduction {
+		return t.Add(cfg.LazyBlockMinInterval)
+	}
 	return t.Add(cfg.TimeoutCommit)
 }
 
@@ -1051,6 +1530,12 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
+	if cfg.WalMaxSegmentSize < 0 {
+		return errors.New("wal_max_segment_size can't be negative")
+	}
+	if cfg.WalMaxSegmentAge < 0 {
+		return errors.New("wal_max_segment_age can't be negative")
+	}
 	if cfg.TimeoutPropose < 0 {
 		return errors.New("timeout_propose can't be negative")
 	}
@@ -1072,9 +1557,46 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return errors.New("timeout_commit can't be negative")
 	}
+	if cfg.TimeoutProposeOverride < 0 {
+		return errors.New("timeout_propose_override can't be negative")
+	}
+	if cfg.TimeoutPrevoteOverride < 0 {
+		return errors.New("timeout_prevote_override can't be negative")
+	}
+	if cfg.TimeoutPrecommitOverride < 0 {
+		return errors.New("timeout_precommit_override can't be negative")
+	}
+	if cfg.TimeoutCommitOverride < 0 {
+		return errors.New("timeout_commit_override can't be negative")
+	}
+	switch cfg.TimeoutEscalation {
+	case "", TimeoutEscalationLinear, TimeoutEscalationExponential:
+	default:
+		return fmt.Errorf("unknown timeout_escalation %q, expected linear or exponential", cfg.TimeoutEscalation)
+	}
+	if cfg.TimeoutEscalationMax < 0 {
+		return errors.New("timeout_escalation_max can't be negative")
+	}
+	if cfg.TimeoutEscalationMax > 0 && (cfg.TimeoutEscalationMax < cfg.TimeoutPropose ||
+		cfg.TimeoutEscalationMax < cfg.TimeoutPrevote || cfg.TimeoutEscalationMax < cfg.TimeoutPrecommit) {
+		return errors.New("timeout_escalation_max can't be less than timeout_propose, timeout_prevote " +
+			"or timeout_precommit")
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create_empty_blocks_interval can't be negative")
 	}
+	if cfg.LazyBlockMinInterval < 0 {
+		return errors.New("lazy_block_min_interval can't be negative")
+	}
+	if cfg.LazyBlockMaxIdleInterval < 0 {
+		return errors.New("lazy_block_max_idle_interval can't be negative")
+	}
+	if cfg.LazyBlockMaxIdleInterval > 0 && cfg.LazyBlockMaxIdleInterval < cfg.LazyBlockMinInterval {
+		return errors.New("lazy_block_max_idle_interval can't be less than lazy_block_min_interval")
+	}
+	if cfg.ErasureCodingParity < 1 || cfg.ErasureCodingParity > 100 {
+		return errors.New("erasure_coding_parity must be between 1 and 100")
+	}
 	if cfg.PeerGossipSleepDuration < 0 {
 		return errors.New("peer_gossip_sleep_duration can't be negative")
 	}
@@ -1097,13 +1619,90 @@ type StorageConfig struct {
 	// required for `/block_results` RPC queries, and to reindex events in the
 	// command-line tool.
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
+
+	// Number of the latest heights whose ABCI responses are kept by the state
+	// store, if not 0, and what is kept of them: "all", "tx_results", the
+	// results of the transactions, without the events of BeginBlock and
+	// EndBlock, or "no_events", the responses without any of their events.
+	// They're ignored if DiscardABCIResponses is set.
+	ABCIResponsesKeepRecent int64  `mapstructure:"abci_responses_keep_recent"`
+	ABCIResponsesRetention  string `mapstructure:"abci_responses_retention"`
+
+	// Set to true to save the ABCI responses of the blocks in the block store
+	// too, pruned along with the blocks, so that `/block_results` serves them
+	// even if the state store discards them.
+	SaveBlockResults bool `mapstructure:"save_block_results"`
+
+	// Set to true to prune the blocks below the retain height returned by the
+	// application in the background, instead of before moving to the next
+	// height. The pruning resumes after a restart.
+	AsyncPruning bool `mapstructure:"async_pruning"`
+
+	// Compression of the block parts and metas saved to the block store:
+	// "none" or "snappy". The blocks saved with another compression are still
+	// read, and can be rewritten with the recompress-blocks command.
+	BlockCompression string `mapstructure:"block_compression"`
+
+	// What is pruned of the blocks below the retain height: "blocks", the
+	// whole blocks, or "bodies", their transactions and evidence only, keeping
+	// the headers and commits of all the heights.
+	PruningMode string `mapstructure:"pruning_mode"`
+
+	// Minimum age of the blocks pruned, e.g. 168h to keep a week of blocks:
+	// the blocks below the retain height returned by the application are only
+	// pruned once older, whichever keeps more blocks. It requires AsyncPruning.
+	// 0 disables it.
+	BlockRetentionPeriod time.Duration `mapstructure:"block_retention_period"`
+
+	// Interval between two runs of the pruning of the states, the validator
+	// sets, consensus params and ABCI responses, with their own policy instead
+	// of along with the blocks: the states of the StatePruningKeepRecent latest
+	// heights and of the heights multiple of StatePruningKeepEvery, if not 0,
+	// are kept, as well as those of the blocks of the block store. 0 prunes the
+	// states along with the blocks.
+	StatePruningInterval   time.Duration `mapstructure:"state_pruning_interval"`
+	StatePruningKeepRecent int64         `mapstructure:"state_pruning_keep_recent"`
+	StatePruningKeepEvery  int64         `mapstructure:"state_pruning_keep_every"`
+
+	// Number of changes of the validator set between two sets stored in full
+	// by the state store: the changes in between are stored as deltas from the
+	// set of the previous height, rebuilt when loaded. It saves space with
+	// large validator sets changing often, at the cost of loading up to that
+	// many deltas. 0 stores the sets in full. The sets stored can be converted
+	// with the migrate-validator-sets command.
+	ValidatorSetDeltaInterval int64 `mapstructure:"validator_set_delta_interval"`
+
+	// Maximum size, in bytes, of the blocks and block parts loaded recently
+	// cached in memory, e.g. for the gossip of the parts and the RPC queries
+	// of the recent blocks. 0 disables the cache.
+	BlockCacheSize int64 `mapstructure:"block_cache_size"`
+
+	// Backend and directory of each database of the node, overriding
+	// db_backend and db_dir if set, e.g. to keep the block store on a
+	// dedicated disk. The databases can be moved with the migrate-db command.
+	BlockStoreDBBackend string `mapstructure:"block_store_db_backend"`
+	BlockStoreDBDir     string `mapstructure:"block_store_db_dir"`
+	StateDBBackend      string `mapstructure:"state_db_backend"`
+	StateDBDir          string `mapstructure:"state_db_dir"`
+	TxIndexDBBackend    string `mapstructure:"tx_index_db_backend"`
+	TxIndexDBDir        string `mapstructure:"tx_index_db_dir"`
+	EvidenceDBBackend   string `mapstructure:"evidence_db_backend"`
+	EvidenceDBDir       string `mapstructure:"evidence_db_dir"`
 }
 
 // DefaultStorageConfig returns the default configuration options relating to
 // CometBFT storage optimization.
 func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
-		DiscardABCIResponses: false,
+		DiscardABCIResponses:   false,
+		ABCIResponsesRetention: "all",
+		SaveBlockResults:       false,
+		AsyncPruning:           false,
+		BlockCompression:       "none",
+		PruningMode:            "blocks",
+		BlockRetentionPeriod:   0,
+		StatePruningInterval:   0,
+		BlockCacheSize:         0,
 	}
 }
 
@@ -1111,8 +1710,85 @@ func DefaultStorageConfig() *StorageConfig {
 // testing.
 func TestStorageConfig() *StorageConfig {
 	return &StorageConfig{
-		DiscardABCIResponses: false,
+		DiscardABCIResponses:   false,
+		ABCIResponsesRetention: "all",
+		SaveBlockResults:       false,
+		AsyncPruning:           false,
+		BlockCompression:       "none",
+		PruningMode:            "blocks",
+		BlockRetentionPeriod:   0,
+		StatePruningInterval:   0,
+		BlockCacheSize:         0,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StorageConfig) ValidateBasic() error {
+	switch cfg.BlockCompression {
+	case "", "none", "snappy":
+	default:
+		return fmt.Errorf("unknown block_compression %q, expected none or snappy", cfg.BlockCompression)
+	}
+	if cfg.ABCIResponsesKeepRecent < 0 {
+		return errors.New("abci_responses_keep_recent can't be negative")
+	}
+	switch cfg.ABCIResponsesRetention {
+	case "", "all", "tx_results", "no_events":
+	default:
+		return fmt.Errorf("unknown abci_responses_retention %q, expected all, tx_results or no_events",
+			cfg.ABCIResponsesRetention)
+	}
+	switch cfg.PruningMode {
+	case "", "blocks", "bodies":
+	default:
+		return fmt.Errorf("unknown pruning_mode %q, expected blocks or bodies", cfg.PruningMode)
+	}
+	if cfg.BlockRetentionPeriod < 0 {
+		return errors.New("block_retention_period can't be negative")
+	}
+	if cfg.BlockRetentionPeriod > 0 && !cfg.AsyncPruning {
+		return errors.New("block_retention_period requires async_pruning")
+	}
+	if cfg.StatePruningInterval < 0 {
+		return errors.New("state_pruning_interval can't be negative")
 	}
+	if cfg.StatePruningKeepRecent < 0 {
+		return errors.New("state_pruning_keep_recent can't be negative")
+	}
+	if cfg.StatePruningKeepEvery < 0 {
+		return errors.New("state_pruning_keep_every can't be negative")
+	}
+	if cfg.ValidatorSetDeltaInterval < 0 {
+		return errors.New("validator_set_delta_interval can't be negative")
+	}
+	if cfg.BlockCacheSize < 0 {
+		return errors.New("block_cache_size can't be negative")
+	}
+	for _, name := range []string{"blockstore", "state", "tx_index", "evidence"} {
+		switch backend, _ := cfg.database(name); backend {
+		case "", "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb", "memdb":
+		default:
+			return fmt.Errorf("unknown backend %q of the %s database", backend, name)
+		}
+	}
+	return nil
+}
+
+// database returns the backend and directory set for the database of the node
+// with the given name, if any.
+func (cfg *StorageConfig) database(name string) (backend, dir string) {
+	switch name {
+	case "blockstore":
+		return cfg.BlockStoreDBBackend, cfg.BlockStoreDBDir
+	case "state":
+		return cfg.StateDBBackend, cfg.StateDBDir
+	case "tx_index":
+		return cfg.TxIndexDBBackend, cfg.TxIndexDBDir
+	case "evidence":
+		return cfg.EvidenceDBBackend, cfg.EvidenceDBDir
+	}
+	return "", ""
 }
 
 // -----------------------------------------------------------------------------
@@ -1129,6 +1805,8 @@ func TestStorageConfig() *StorageConfig {
 // TxIndexConfig defines the configuration for the transaction indexer,
 // including composite keys to index.
 type TxIndexConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	// What indexer to use for transactions
 	//
 	// Options:
@@ -1136,30 +1814,427 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "sqlite" - the indexer services backed by a SQLite database file.
+	//   5) "clickhouse" - the indexer services backed by ClickHouse.
 	Indexer string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// Path to the SQLite database file of the "sqlite" indexer
+	SqlitePath string `mapstructure:"sqlite-path"`
+
+	// The ClickHouse HTTP interface connection configuration, the format:
+	// http://<user>:<password>@<host>:<port>/<db>
+	ClickhouseConn string `mapstructure:"clickhouse-conn"`
+
+	// Maximum number of blocks waiting to be indexed. When greater than 0,
+	// blocks are indexed asynchronously, off the commit path, and block
+	// commits only wait for the indexer once the queue is full. The last
+	// height indexed is persisted, and the blocks committed but not indexed
+	// when the node stopped are indexed when it restarts.
+	// 0 indexes blocks synchronously.
+	QueueSize int `mapstructure:"queue-size"`
+
+	// Number of blocks indexed concurrently when QueueSize is greater than 0.
+	Workers int `mapstructure:"workers"`
+
+	// Maximum number of queued blocks a worker indexes at once, e.g. in a
+	// single database transaction, when QueueSize is greater than 0. Blocks
+	// are only batched when the indexer falls behind, e.g. while the node
+	// syncs. Only the "sqlite" and "psql" indexers index blocks in bulk.
+	BatchSize int `mapstructure:"batch-size"`
+
+	// Event types, e.g. "transfer", or attribute composite keys, e.g.
+	// "transfer.sender", to index among those marked as indexed by the
+	// application. If empty, all of them are indexed.
+	IndexEvents []string `mapstructure:"index-events"`
+
+	// Event types or attribute composite keys not to index.
+	ExcludeEvents []string `mapstructure:"exclude-events"`
+
+	// Composite keys of the event attributes, e.g. "message.sender", holding
+	// the senders, recipients and fee payers of the transactions. The "kv"
+	// indexer indexes the transactions by these accounts, which are searched
+	// with the tx.sender, tx.recipient and tx.fee_payer keys.
+	SenderAttributes    []string `mapstructure:"sender-attributes"`
+	RecipientAttributes []string `mapstructure:"recipient-attributes"`
+	FeePayerAttributes  []string `mapstructure:"fee-payer-attributes"`
+
+	// Composite keys of the attributes whose values are full-text indexed by
+	// the "sqlite" and "psql" indexers, e.g. "tx.memo".
+	FullTextAttributes []string `mapstructure:"full-text-attributes"`
+
+	// Number of recent blocks whose index entries are retained. 0 retains the
+	// entries of all the blocks.
+	RetainBlocks uint64 `mapstructure:"retain-blocks"`
+
+	// Delete the index entries of the blocks pruned from the block store.
+	PruneWithBlockStore bool `mapstructure:"prune-with-block-store"`
+
+	// Interval between two runs of the pruner.
+	PruneInterval time.Duration `mapstructure:"prune-interval"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: "kv",
+		Indexer:       "kv",
+		SqlitePath:    filepath.Join(defaultDataDir, "tx_index.sqlite"),
+		QueueSize:     0,
+		Workers:       1,
+		BatchSize:     100,
+		PruneInterval: 10 * time.Minute,
 	}
 }
 
+// PruningEnabled returns true if the index entries of old blocks are pruned.
+func (cfg *TxIndexConfig) PruningEnabled() bool {
+	return cfg.RetainBlocks > 0 || cfg.PruneWithBlockStore
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if cfg.QueueSize < 0 {
+		return errors.New("queue-size can't be negative")
+	}
+	if cfg.QueueSize > 0 && cfg.Workers <= 0 {
+		return errors.New("workers must be positive when queue-size is set")
+	}
+	if cfg.QueueSize > 0 && cfg.BatchSize <= 0 {
+		return errors.New("batch-size must be positive when queue-size is set")
+	}
+	for _, key := range cfg.IndexEvents {
+		if key == "" {
+			return errors.New("index-events can't contain an empty event type")
+		}
+	}
+	for _, key := range cfg.ExcludeEvents {
+		if key == "" {
+			return errors.New("exclude-events can't contain an empty event type")
+		}
+	}
+	for name, keys := range map[string][]string{
+		"sender-attributes":    cfg.SenderAttributes,
+		"recipient-attributes": cfg.RecipientAttributes,
+		"fee-payer-attributes": cfg.FeePayerAttributes,
+		"full-text-attributes": cfg.FullTextAttributes,
+	} {
+		for _, key := range keys {
+			if i := strings.Index(key, "."); i <= 0 || i == len(key)-1 {
+				return fmt.Errorf("%s: %q is not an attribute composite key", name, key)
+			}
+		}
+	}
+	if cfg.PruningEnabled() && cfg.PruneInterval <= 0 {
+		return errors.New("prune-interval must be positive when pruning")
+	}
+	return nil
+}
+
+// SqliteFile returns the full path to the SQLite database file of the
+// "sqlite" indexer.
+func (cfg *TxIndexConfig) SqliteFile() string {
+	return rootify(cfg.SqlitePath, cfg.RootDir)
+}
+
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
 func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+//-----------------------------------------------------------------------------
+// DAConfig
+
+const (
+	// DALayerCelestia submits the blocks to Celestia, through the RPC of a
+	// celestia-node.
+	DALayerCelestia = "celestia"
+	// DALayerAvail submits the blocks to Avail, through the API of an Avail
+	// light client.
+	DALayerAvail = "avail"
+)
+
+// DAConfig defines the configuration of the submission of the committed blocks
+// to a data availability (DA) layer.
+type DAConfig struct {
+	// DA layer the committed blocks are submitted to: "celestia", "avail", or
+	// "" to disable the submission.
+	Layer string `mapstructure:"layer"`
+
+	// Address of the DA node: the RPC of a celestia-node, or the API of an
+	// Avail light client.
+	Address string `mapstructure:"address"`
+
+	// Authentication token of the celestia-node RPC.
+	AuthToken string `mapstructure:"auth_token"`
+
+	// Celestia namespace ID of the blobs, as 10 hex encoded bytes.
+	Namespace string `mapstructure:"namespace"`
+
+	// Gas price of the Celestia blob transactions. 0 uses the price estimated
+	// by the celestia-node.
+	GasPrice float64 `mapstructure:"gas_price"`
+
+	// Interval between two submissions of the blocks committed meanwhile.
+	SubmitInterval time.Duration `mapstructure:"submit_interval"`
+
+	// Maximum number of blocks, and of bytes, of a batch submitted as one blob.
+	BatchMaxBlocks int `mapstructure:"batch_max_blocks"`
+	BatchMaxBytes  int `mapstructure:"batch_max_bytes"`
+
+	// Timeout of a request to the DA node.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Time after which a submitted batch which isn't included yet is
+	// submitted again.
+	InclusionTimeout time.Duration `mapstructure:"inclusion_timeout"`
+}
+
+// DefaultDAConfig returns a default configuration for the DA submission.
+func DefaultDAConfig() *DAConfig {
+	return &DAConfig{
+		Layer:            "",
+		Address:          "http://localhost:26658",
+		SubmitInterval:   6 * time.Second,
+		BatchMaxBlocks:   100,
+		BatchMaxBytes:    1024 * 1024, // 1MB
+		Timeout:          time.Minute,
+		InclusionTimeout: 5 * time.Minute,
+	}
+}
+
+// TestDAConfig returns a configuration for testing the DA submission.
+func TestDAConfig() *DAConfig {
+	return DefaultDAConfig()
+}
+
+// Enabled returns whether the blocks are submitted to a DA layer.
+func (cfg *DAConfig) Enabled() bool {
+	return cfg.Layer != ""
+}
+
+// NamespaceBytes returns the Celestia namespace ID.
+// Panics if Namespace is not a valid hex string.
+func (cfg *DAConfig) NamespaceBytes() []byte {
+	ns, err := hex.DecodeString(cfg.Namespace)
+	if err != nil {
+		panic(err)
+	}
+	return ns
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *DAConfig) ValidateBasic() error {
+	switch cfg.Layer {
+	case "":
+		return nil
+	case DALayerCelestia:
+		ns, err := hex.DecodeString(cfg.Namespace)
+		if err != nil {
+			return fmt.Errorf("invalid namespace: %w", err)
+		}
+		if len(ns) != 10 {
+			return fmt.Errorf("namespace must be 10 bytes, got %d", len(ns))
+		}
+		if cfg.GasPrice < 0 {
+			return errors.New("gas_price can't be negative")
+		}
+	case DALayerAvail:
+	default:
+		return fmt.Errorf("unknown layer %q, expected celestia or avail", cfg.Layer)
+	}
+	if cfg.Address == "" {
+		return errors.New("address can't be empty")
+	}
+	if cfg.SubmitInterval <= 0 {
+		return errors.New("submit_interval must be positive")
+	}
+	if cfg.BatchMaxBlocks <= 0 {
+		return errors.New("batch_max_blocks must be positive")
+	}
+	if cfg.BatchMaxBytes <= 0 {
+		return errors.New("batch_max_bytes must be positive")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if cfg.InclusionTimeout <= 0 {
+		return errors.New("inclusion_timeout must be positive")
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// SettlementConfig
+
+const (
+	// SettlementHubDymension posts the state updates to the Dymension hub.
+	SettlementHubDymension = "dymension"
+
+	// SettlementKeyEthSecp256k1 is the Ethereum flavored secp256k1 key type,
+	// the default one of the Dymension hub accounts.
+	SettlementKeyEthSecp256k1 = "eth_secp256k1"
+	// SettlementKeySecp256k1 is the Cosmos SDK secp256k1 key type.
+	SettlementKeySecp256k1 = "secp256k1"
+)
+
+// SettlementConfig defines the configuration of the posting of the state
+// updates of the chain to a settlement hub.
+type SettlementConfig struct {
+	RootDir string `mapstructure:"home"`
+
+	// Hub the state updates are posted to: "dymension", or "" to disable the
+	// posting.
+	Hub string `mapstructure:"hub"`
+
+	// RPC address of a node of the hub.
+	RPCAddress string `mapstructure:"rpc_address"`
+
+	// Chain ID of the hub.
+	HubChainID string `mapstructure:"hub_chain_id"`
+
+	// ID of the rollapp on the hub. If empty, the chain ID is used.
+	RollappID string `mapstructure:"rollapp_id"`
+
+	// Path to the file holding the hex encoded private key of the sequencer
+	// account on the hub, which signs the state updates.
+	KeyFile string `mapstructure:"key_file"`
+
+	// Type of the key: "eth_secp256k1" or "secp256k1".
+	KeyType string `mapstructure:"key_type"`
+
+	// Bech32 prefix of the hub account addresses.
+	AddressPrefix string `mapstructure:"address_prefix"`
+
+	// Gas limit and fees of the state update transactions, e.g.
+	// "4000000000000000adym".
+	GasLimit uint64 `mapstructure:"gas_limit"`
+	Fees     string `mapstructure:"fees"`
+
+	// Interval between two postings of the state updates.
+	PostInterval time.Duration `mapstructure:"post_interval"`
+
+	// Timeout of a request to the hub.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Time after which a state update which isn't accepted yet is posted
+	// again.
+	AcceptanceTimeout time.Duration `mapstructure:"acceptance_timeout"`
+
+	// If true, the sequencer rotations scheduled by the hub are applied: from
+	// the height of each rotation, the next sequencer is the only validator.
+	// All the nodes of the chain must enable it.
+	SequencerRotation bool `mapstructure:"sequencer_rotation"`
+
+	// Interval between two loadings of all the rotations scheduled, besides
+	// the subscription to the new ones.
+	RotationResyncInterval time.Duration `mapstructure:"rotation_resync_interval"`
+
+	// If true, the transactions force-included by the hub are proposed first
+	// by the sequencer, and the proposals skipping those past their deadline
+	// are refused.
+	ForcedInclusion bool `mapstructure:"forced_inclusion"`
+
+	// Interval between two loadings of all the transactions force-included,
+	// besides the subscription to the new ones.
+	ForcedInclusionResyncInterval time.Duration `mapstructure:"forced_inclusion_resync_interval"`
+}
+
+// DefaultSettlementConfig returns a default configuration for the settlement.
+func DefaultSettlementConfig() *SettlementConfig {
+	return &SettlementConfig{
+		Hub:               "",
+		RPCAddress:        "tcp://localhost:36657",
+		KeyFile:           filepath.Join(defaultConfigDir, "settlement_key.hex"),
+		KeyType:           SettlementKeyEthSecp256k1,
+		AddressPrefix:     "dym",
+		GasLimit:          400000,
+		PostInterval:      30 * time.Second,
+		Timeout:           30 * time.Second,
+		AcceptanceTimeout: 5 * time.Minute,
+
+		SequencerRotation:      false,
+		RotationResyncInterval: time.Minute,
+
+		ForcedInclusion:               false,
+		ForcedInclusionResyncInterval: time.Minute,
+	}
+}
+
+// TestSettlementConfig returns a configuration for testing the settlement.
+func TestSettlementConfig() *SettlementConfig {
+	return DefaultSettlementConfig()
+}
+
+// Enabled returns whether the state updates are posted to a hub.
+func (cfg *SettlementConfig) Enabled() bool {
+	return cfg.Hub != ""
+}
+
+// KeyFilePath returns the full path to the key file.
+func (cfg *SettlementConfig) KeyFilePath() string {
+	return rootify(cfg.KeyFile, cfg.RootDir)
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *SettlementConfig) ValidateBasic() error {
+	switch cfg.Hub {
+	case "":
+		return nil
+	case SettlementHubDymension:
+	default:
+		return fmt.Errorf("unknown hub %q, expected dymension", cfg.Hub)
+	}
+	if cfg.RPCAddress == "" {
+		return errors.New("rpc_address can't be empty")
+	}
+	if cfg.HubChainID == "" {
+		return errors.New("hub_chain_id can't be empty")
+	}
+	if cfg.KeyFile == "" {
+		return errors.New("key_file can't be empty")
+	}
+	switch cfg.KeyType {
+	case SettlementKeyEthSecp256k1, SettlementKeySecp256k1:
+	default:
+		return fmt.Errorf("unknown key_type %q, expected eth_secp256k1 or secp256k1", cfg.KeyType)
+	}
+	if cfg.AddressPrefix == "" {
+		return errors.New("address_prefix can't be empty")
+	}
+	if cfg.GasLimit == 0 {
+		return errors.New("gas_limit must be positive")
+	}
+	if cfg.PostInterval <= 0 {
+		return errors.New("post_interval must be positive")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if cfg.AcceptanceTimeout <= 0 {
+		return errors.New("acceptance_timeout must be positive")
+	}
+	if cfg.RotationResyncInterval <= 0 {
+		return errors.New("rotation_resync_interval must be positive")
+	}
+	if cfg.ForcedInclusionResyncInterval <= 0 {
+		return errors.New("forced_inclusion_resync_interval must be positive")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
 // InstrumentationConfig defines the configuration for metrics reporting.
 type InstrumentationConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	// When true, Prometheus metrics are served under /metrics on
 	// PrometheusListenAddr.
 	// Check out the documentation for the list of available metrics.
@@ -1176,6 +2251,32 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// When true, the node traces the RPC requests, the consensus heights,
+	// the ABCI calls and the indexing with OpenTelemetry, and exports the
+	// spans with TracingExporter.
+	Tracing bool `mapstructure:"tracing"`
+
+	// Exporter of the spans: "otlp" sends them to TracingEndpoint with
+	// OTLP/HTTP, "stdout" writes them to the standard output.
+	TracingExporter string `mapstructure:"tracing_exporter"`
+
+	// URL of the OTLP/HTTP traces endpoint of the collector.
+	TracingEndpoint string `mapstructure:"tracing_endpoint"`
+
+	// Fraction of the traces sampled, between 0 and 1. The traces started by
+	// an RPC request carrying a sampled trace context are always sampled.
+	TracingSampleRate float64 `mapstructure:"tracing_sample_rate"`
+
+	// Interval between two captures of the CPU, heap and goroutine profiles
+	// of the node, written to ProfilingPath. 0 disables continuous profiling.
+	ProfilingInterval time.Duration `mapstructure:"profiling_interval"`
+
+	// Directory of the continuous profiles.
+	ProfilingPath string `mapstructure:"profiling_dir"`
+
+	// Number of captures kept in ProfilingPath, the older ones are deleted.
+	ProfilingRetain int `mapstructure:"profiling_retain"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -1186,6 +2287,13 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "cometbft",
+		Tracing:              false,
+		TracingExporter:      "otlp",
+		TracingEndpoint:      "http://localhost:4318/v1/traces",
+		TracingSampleRate:    1,
+		ProfilingInterval:    0,
+		ProfilingPath:        filepath.Join(defaultDataDir, "profiles"),
+		ProfilingRetain:      24,
 	}
 }
 
@@ -1201,9 +2309,38 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	switch cfg.TracingExporter {
+	case "otlp":
+		if cfg.Tracing && cfg.TracingEndpoint == "" {
+			return errors.New("tracing_endpoint can't be empty with the otlp exporter")
+		}
+	case "stdout":
+	default:
+		return fmt.Errorf("unknown tracing_exporter %q, expected otlp or stdout", cfg.TracingExporter)
+	}
+	if cfg.TracingSampleRate < 0 || cfg.TracingSampleRate > 1 {
+		return errors.New("tracing_sample_rate must be between 0 and 1")
+	}
+	if cfg.ProfilingInterval < 0 {
+		return errors.New("profiling_interval can't be negative")
+	}
+	if cfg.ProfilingInterval > 0 {
+		if cfg.ProfilingPath == "" {
+			return errors.New("profiling_dir can't be empty when profiling_interval is set")
+		}
+		if cfg.ProfilingRetain <= 0 {
+			return errors.New("profiling_retain must be positive when profiling_interval is set")
+		}
+	}
 	return nil
 }
 
+// ProfilingDir returns the full path to the directory of the continuous
+// profiles.
+func (cfg *InstrumentationConfig) ProfilingDir() string {
+	return rootify(cfg.ProfilingPath, cfg.RootDir)
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
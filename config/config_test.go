@@ -38,6 +38,46 @@ func TestConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestConfigValidateMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Mode = "light"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// only validators have a private validator
+	cfg = DefaultConfig()
+	cfg.Mode = ModeFull
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// the deprecated seed_mode selects the seed mode
+	cfg = DefaultConfig()
+	cfg.P2P.SeedMode = true
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, ModeSeed, cfg.NodeMode())
+	cfg.Mode = ModeArchive
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultConfig()
+	cfg.Mode = ModeSeed
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.P2P.PexReactor = false
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.P2P.PexReactor = true
+	cfg.StateSync.Enable = true
+	assert.Error(t, cfg.ValidateBasic())
+
+	// archive nodes index all the blocks
+	cfg = DefaultConfig()
+	cfg.Mode = ModeArchive
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TxIndex.RetainBlocks = 100
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TxIndex.RetainBlocks = 0
+	cfg.TxIndex.Indexer = "null"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestTLSConfiguration(t *testing.T) {
 	assert := assert.New(t)
 	cfg := DefaultConfig()
@@ -61,6 +101,59 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// cosigners without a key share
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorCosigners = "2@tcp://127.0.0.1:26660"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorKeyShare = "config/priv_validator_key_share.json"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// key share and remote signer
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// PKCS#11 module without a key
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorPKCS11Module = "/usr/lib/softhsm/libsofthsm2.so"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorPKCS11Token = "validator"
+	cfg.PrivValidatorPKCS11Key = "consensus"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// PKCS#11 module and remote signer
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// Ledger and PKCS#11 module
+	cfg.PrivValidatorListenAddr = ""
+	cfg.PrivValidatorLedger = true
+	assert.Error(t, cfg.ValidateBasic())
+
+	// Ledger without a confirmation timeout
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorLedger = true
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorLedgerConfirmTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	// negative batch size
+	cfg = TestBaseConfig()
+	cfg.SigVerifyBatchSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// negative watchdog limits
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorMaxSignRate = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorMaxHeightDrift = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// no shutdown deadline
+	cfg = TestBaseConfig()
+	cfg.ShutdownTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -113,6 +206,13 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"PersistMaxBytes",
+		"PeerMaxTxsPerSecond",
+		"MaxTxsPerSender",
+		"RecheckConnections",
+		"TxStatusSize",
+		"InvalidCacheSize",
+		"InvalidCacheTTL",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -120,11 +220,53 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	for _, version := range []string{"", MempoolV0, MempoolV1, MempoolPriority} {
+		cfg.Version = version
+		assert.NoError(t, cfg.ValidateBasic(), version)
+	}
+	cfg.Version = "v2"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Version = MempoolV0
+
+	cfg.BroadcastMode = MempoolBroadcastSequencer
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.BroadcastMode = "gossip"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.BroadcastMode = MempoolBroadcastFlood
+
+	cfg.Persist = true
+	assert.Error(t, cfg.ValidateBasic(), "no room for the journal")
+	cfg.PersistMaxBytes = 1000
+	assert.NoError(t, cfg.ValidateBasic())
+
+	lane := MempoolLaneConfig{Name: "system", Size: 10, MaxTxsBytes: 1000, GossipRate: 5}
+	cfg.Lanes = []MempoolLaneConfig{lane}
+	assert.NoError(t, cfg.ValidateBasic())
+	for _, invalid := range []MempoolLaneConfig{
+		{Name: "", Size: 10, MaxTxsBytes: 1000},
+		{Name: MempoolDefaultLane, Size: 10, MaxTxsBytes: 1000},
+		lane,
+		{Name: "oracle", Size: 0, MaxTxsBytes: 1000},
+		{Name: "oracle", Size: 10, MaxTxsBytes: 0},
+		{Name: "oracle", Size: 10, MaxTxsBytes: 1000, GossipRate: -1},
+	} {
+		cfg.Lanes = []MempoolLaneConfig{lane, invalid}
+		assert.Error(t, cfg.ValidateBasic(), invalid)
+	}
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	// the rpc servers are optional when verifying with the peers
+	cfg.Enable = true
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "188F4F36CBCD2C91B57509BBF231C777E79B52EE3E0D90D06B1A25EB16E6E23D"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.UseP2P = true
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -137,6 +279,10 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Version = "v0"
+	cfg.SwitchHysteresis = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 //nolint:lll
@@ -159,6 +305,25 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"TimeoutPrecommitDelta negative":       {func(c *ConsensusConfig) { c.TimeoutPrecommitDelta = -1 }, true},
 		"TimeoutCommit":                        {func(c *ConsensusConfig) { c.TimeoutCommit = time.Second }, false},
 		"TimeoutCommit negative":               {func(c *ConsensusConfig) { c.TimeoutCommit = -1 }, true},
+		"TimeoutProposeOverride negative":      {func(c *ConsensusConfig) { c.TimeoutProposeOverride = -1 }, true},
+		"TimeoutPrevoteOverride negative":      {func(c *ConsensusConfig) { c.TimeoutPrevoteOverride = -1 }, true},
+		"TimeoutPrecommitOverride negative":    {func(c *ConsensusConfig) { c.TimeoutPrecommitOverride = -1 }, true},
+		"TimeoutCommitOverride negative":       {func(c *ConsensusConfig) { c.TimeoutCommitOverride = -1 }, true},
+		"TimeoutEscalation exponential":        {func(c *ConsensusConfig) { c.TimeoutEscalation = "exponential" }, false},
+		"TimeoutEscalation unknown":            {func(c *ConsensusConfig) { c.TimeoutEscalation = "quadratic" }, true},
+		"TimeoutEscalationMax negative":        {func(c *ConsensusConfig) { c.TimeoutEscalationMax = -1 }, true},
+		"TimeoutEscalationMax":                 {func(c *ConsensusConfig) { c.TimeoutEscalationMax = time.Minute }, false},
+		"TimeoutEscalationMax below propose":   {func(c *ConsensusConfig) { c.TimeoutEscalationMax = c.TimeoutPropose - 1 }, true},
+		"TimeoutEscalationMax below precommit": {func(c *ConsensusConfig) { c.TimeoutEscalationMax = c.TimeoutPrecommit - 1 }, true},
+		"WalMaxSegmentSize negative":           {func(c *ConsensusConfig) { c.WalMaxSegmentSize = -1 }, true},
+		"WalMaxSegmentAge negative":            {func(c *ConsensusConfig) { c.WalMaxSegmentAge = -1 }, true},
+		"LazyBlockMinInterval negative":        {func(c *ConsensusConfig) { c.LazyBlockMinInterval = -1 }, true},
+		"LazyBlockMaxIdleInterval negative":    {func(c *ConsensusConfig) { c.LazyBlockMaxIdleInterval = -1 }, true},
+		"LazyBlockMaxIdleInterval below min":   {func(c *ConsensusConfig) { c.LazyBlockMaxIdleInterval = time.Millisecond }, true},
+		"LazyBlockMaxIdleInterval zero":        {func(c *ConsensusConfig) { c.LazyBlockMaxIdleInterval = 0 }, false},
+		"ErasureCodingParity zero":             {func(c *ConsensusConfig) { c.ErasureCodingParity = 0 }, true},
+		"ErasureCodingParity above 100":        {func(c *ConsensusConfig) { c.ErasureCodingParity = 101 }, true},
+		"ErasureCodingParity 100":              {func(c *ConsensusConfig) { c.ErasureCodingParity = 100 }, false},
 		"PeerGossipSleepDuration":              {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = time.Second }, false},
 		"PeerGossipSleepDuration negative":     {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
@@ -182,6 +347,191 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestStorageConfigValidateBasic(t *testing.T) {
+	cfg := TestStorageConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.BlockCompression = "snappy"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.BlockCompression = "gzip"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.ABCIResponsesKeepRecent = 100
+	cfg.ABCIResponsesRetention = "tx_results"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.ABCIResponsesRetention = "events"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.ABCIResponsesKeepRecent = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.PruningMode = "bodies"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.PruningMode = "headers"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.BlockRetentionPeriod = 24 * time.Hour
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.AsyncPruning = true
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.BlockRetentionPeriod = -time.Hour
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.StatePruningInterval = time.Minute
+	cfg.StatePruningKeepRecent = 100
+	cfg.StatePruningKeepEvery = 1000
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.StatePruningKeepEvery = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.ValidatorSetDeltaInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.BlockCacheSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.BlockStoreDBBackend = "rocksdb"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.StateDBBackend = "pebble"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestConfigDBOf(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetRoot("/home")
+	cfg.Storage.BlockStoreDBBackend = "rocksdb"
+	cfg.Storage.BlockStoreDBDir = "/mnt/blocks"
+	cfg.Storage.StateDBDir = "state"
+
+	assert.Equal(t, "rocksdb", cfg.DBBackendOf("blockstore"))
+	assert.Equal(t, "/mnt/blocks", cfg.DBDirOf("blockstore"))
+	assert.Equal(t, "goleveldb", cfg.DBBackendOf("state"))
+	assert.Equal(t, "/home/state", cfg.DBDirOf("state"))
+	assert.Equal(t, "goleveldb", cfg.DBBackendOf("evidence"))
+	assert.Equal(t, "/home/data", cfg.DBDirOf("evidence"))
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.QueueSize = 100
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Workers = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestTxIndexConfig()
+	cfg.QueueSize = 100
+	cfg.BatchSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.QueueSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestTxIndexConfig()
+	cfg.IndexEvents = []string{"transfer", ""}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestTxIndexConfig()
+	cfg.PruneInterval = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.RetainBlocks = 100
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestTxIndexConfig()
+	cfg.SenderAttributes = []string{"message.sender", "transfer.sender"}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.RecipientAttributes = []string{"transfer"}
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestDAConfigValidateBasic(t *testing.T) {
+	cfg := TestDAConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.False(t, cfg.Enabled())
+
+	cfg.Layer = DALayerCelestia
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Namespace = "00000000000000000001"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, cfg.NamespaceBytes())
+	cfg.Namespace = "0001"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestDAConfig()
+	cfg.Layer = DALayerAvail
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.BatchMaxBytes = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestDAConfig()
+	cfg.Layer = DALayerAvail
+	cfg.SubmitInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestDAConfig()
+	cfg.Layer = "eigenda"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestSettlementConfigValidateBasic(t *testing.T) {
+	cfg := TestSettlementConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.False(t, cfg.Enabled())
+
+	cfg.Hub = SettlementHubDymension
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.HubChainID = "dymension_1100-1"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.KeyType = "ed25519"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestSettlementConfig()
+	cfg.Hub = SettlementHubDymension
+	cfg.HubChainID = "dymension_1100-1"
+	cfg.PostInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PostInterval = time.Second
+	cfg.RotationResyncInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RotationResyncInterval = time.Minute
+	cfg.ForcedInclusionResyncInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestSettlementConfig()
+	cfg.Hub = "celestia"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// the state updates point to the blocks on the DA layer
+	config := TestConfig()
+	config.Settlement.Hub = SettlementHubDymension
+	config.Settlement.HubChainID = "dymension_1100-1"
+	assert.Error(t, config.ValidateBasic())
+	config.DA.Layer = DALayerAvail
+	assert.NoError(t, config.ValidateBasic())
+
+	// but a full node doesn't post them
+	config.DA.Layer = ""
+	config.Mode = ModeFull
+	assert.NoError(t, config.ValidateBasic())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -189,4 +539,25 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestInstrumentationConfig()
+	cfg.Tracing = true
+	cfg.TracingEndpoint = ""
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TracingExporter = "stdout"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TracingExporter = "jaeger"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestInstrumentationConfig()
+	cfg.TracingSampleRate = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestInstrumentationConfig()
+	cfg.ProfilingInterval = -time.Minute
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ProfilingInterval = time.Minute
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ProfilingRetain = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
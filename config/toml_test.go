@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -92,3 +93,32 @@ func checkConfig(configFile string) bool {
 	}
 	return valid
 }
+
+func TestWriteConfigFileRoundtrip(t *testing.T) {
+	// the written config file is read back as the config written, up to the
+	// empty lists read for the nil ones
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	cfg := DefaultConfig()
+	cfg.Mempool.Lanes = []MempoolLaneConfig{
+		{Name: "system", Size: 100, MaxTxsBytes: 1 << 20, GossipRate: 10},
+		{Name: "oracle", Size: 10, MaxTxsBytes: 1 << 10},
+	}
+	WriteConfigFile(path, cfg)
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	require.NoError(t, v.ReadInConfig())
+	read := DefaultConfig()
+	require.NoError(t, v.Unmarshal(read))
+	require.NoError(t, read.ValidateBasic())
+	assert.Equal(t, cfg.Mempool.Lanes, read.Mempool.Lanes)
+	rewritten := filepath.Join(dir, "rewritten.toml")
+	WriteConfigFile(rewritten, read)
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(rewritten)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -556,6 +556,24 @@ func ensureNewRound(roundCh <-chan cmtpubsub.Message, height int64, round int32)
 	}
 }
 
+// proposeTimeout returns the time cs waits for a proposal at round, from its
+// config.
+func proposeTimeout(cs *State, round int32) time.Duration {
+	return cs.timeoutPolicy.Timeout(cs.config.TimeoutPropose, cs.config.TimeoutProposeDelta, round)
+}
+
+// prevoteTimeout returns the time cs waits for straggler prevotes at round,
+// from its config.
+func prevoteTimeout(cs *State, round int32) time.Duration {
+	return cs.timeoutPolicy.Timeout(cs.config.TimeoutPrevote, cs.config.TimeoutPrevoteDelta, round)
+}
+
+// precommitTimeout returns the time cs waits for straggler precommits at
+// round, from its config.
+func precommitTimeout(cs *State, round int32) time.Duration {
+	return cs.timeoutPolicy.Timeout(cs.config.TimeoutPrecommit, cs.config.TimeoutPrecommitDelta, round)
+}
+
 func ensureNewTimeout(timeoutCh <-chan cmtpubsub.Message, height int64, round int32, timeout int64) {
 	timeoutDuration := time.Duration(timeout*10) * time.Nanosecond
 	ensureNewEvent(timeoutCh, height, round, timeoutDuration,
@@ -0,0 +1,344 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/libs/erasure"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtmath "github.com/tendermint/tendermint/libs/math"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	cmtcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+// parityPartsCount returns the number of parity parts of a block of total
+// parts, percent of them rounded up, or 0 if the block can't be erasure coded.
+func parityPartsCount(total uint32, percent int) int {
+	k := int(total)
+	if k < 2 || k >= erasure.MaxShards {
+		return 0
+	}
+	return cmtmath.MinInt((k*percent+99)/100, erasure.MaxShards-k)
+}
+
+// encodeParityParts returns the m parity parts of the complete block parts,
+// with their proofs against the header returned.
+func encodeParityParts(blockParts *types.PartSet, m int) ([]*types.Part, types.ParityPartSetHeader, error) {
+	k := int(blockParts.Total())
+	data := make([][]byte, k)
+	for j := range data {
+		part := blockParts.GetPart(j)
+		if part == nil {
+			return nil, types.ParityPartSetHeader{}, fmt.Errorf("missing part %d of the block", j)
+		}
+		data[j] = part.Bytes
+	}
+	parity, err := erasure.Encode(data, m)
+	if err != nil {
+		return nil, types.ParityPartSetHeader{}, err
+	}
+	header := types.NewParityPartSetHeader(parity, uint32(len(data[k-1])))
+	_, proofs := merkle.ProofsFromByteSlices(parity)
+	parts := make([]*types.Part, m)
+	for i, bz := range parity {
+		parts[i] = &types.Part{Index: uint32(i), Bytes: bz, Proof: *proofs[i]}
+	}
+	return parts, header, nil
+}
+
+// parityParts holds the parity parts of the erasure coded proposal block of a
+// height and round, encoded from the complete block or received from the
+// peers, and reconstructs the parts of the block missing from them. The
+// height, round and header of the parity parts are those of the proposal of
+// the consensus state, never of the parity parts received.
+type parityParts struct {
+	mtx cmtsync.Mutex
+
+	height int64
+	round  int32
+	header types.ParityPartSetHeader
+
+	// the parity parts by index, checked against the header
+	parts map[uint32]*types.Part
+
+	// whether the parity parts were encoded from the complete block, and
+	// whether the block doesn't need the parity parts anymore
+	encoded bool
+	done    bool
+}
+
+func newParityParts() *parityParts {
+	return &parityParts{parts: make(map[uint32]*types.Part)}
+}
+
+// at resets the parity parts for the header of the proposal of height and
+// round, if after the ones held or of another header. It returns false if
+// before.
+func (pp *parityParts) at(height int64, round int32, header *types.ParityPartSetHeader) bool {
+	if height < pp.height || (height == pp.height && round < pp.round) {
+		return false
+	}
+	if height != pp.height || round != pp.round || pp.header.Total != header.Total ||
+		!bytes.Equal(pp.header.Hash, header.Hash) || pp.header.LastPartSize != header.LastPartSize {
+		pp.height, pp.round, pp.header = height, round, *header
+		pp.parts = make(map[uint32]*types.Part)
+		pp.encoded, pp.done = false, false
+	}
+	return true
+}
+
+// encode computes the parity parts of the complete block parts of the
+// proposal of height and round, once, and checks them against its header.
+func (pp *parityParts) encode(height int64, round int32, header *types.ParityPartSetHeader,
+	blockParts *types.PartSet) error {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if !pp.at(height, round, header) || pp.encoded {
+		return nil
+	}
+	pp.parts = make(map[uint32]*types.Part)
+	pp.encoded, pp.done = true, true
+
+	parts, encoded, err := encodeParityParts(blockParts, int(header.Total))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(encoded.Hash, header.Hash) || encoded.LastPartSize != header.LastPartSize {
+		return errors.New("the parity parts of the block don't match the proposal")
+	}
+	for _, part := range parts {
+		pp.parts[part.Index] = part
+	}
+	return nil
+}
+
+// add adds a parity part of the proposal of height and round received from a
+// peer, once checked against its header. It returns false if the part is not
+// needed, and an error if it doesn't match the header.
+func (pp *parityParts) add(height int64, round int32, header *types.ParityPartSetHeader,
+	part *types.Part) (bool, error) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if !pp.at(height, round, header) || pp.done {
+		return false, nil
+	}
+	if _, ok := pp.parts[part.Index]; ok {
+		return false, nil
+	}
+	if len(part.Bytes) != int(types.BlockPartSizeBytes) {
+		return false, fmt.Errorf("parity part of %d bytes, expected %d", len(part.Bytes), types.BlockPartSizeBytes)
+	}
+	if err := header.VerifyPart(part); err != nil {
+		return false, err
+	}
+	pp.parts[part.Index] = part
+	return true, nil
+}
+
+// pick returns a parity part of height and round not in has, the parity parts
+// a peer has.
+func (pp *parityParts) pick(height int64, round int32, has *bits.BitArray) (*BlockParityPartMessage, bool) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if pp.height != height || pp.round != round {
+		return nil, false
+	}
+	indexes := make([]uint32, 0, len(pp.parts))
+	for index := range pp.parts {
+		if !has.GetIndex(int(index)) {
+			indexes = append(indexes, index)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil, false
+	}
+	index := indexes[cmtrand.Intn(len(indexes))]
+	return &BlockParityPartMessage{Height: height, Round: round, Part: pp.parts[index]}, true
+}
+
+// reconstruct returns the parts missing from blockParts, the proposal block
+// parts of height and round, reconstructed from its parts and the parity parts
+// once there are as many of them as the parts of the block. Each part being
+// checked against the proposal, the parts reconstructed can only mismatch the
+// block if the proposer committed to parity parts of another block, in which
+// case the parity parts are given up on.
+func (pp *parityParts) reconstruct(height int64, round int32, blockParts *types.PartSet) ([]*types.Part, error) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if pp.height != height || pp.round != round || pp.done || blockParts == nil {
+		return nil, nil
+	}
+	k := int(blockParts.Total())
+	if k < 2 || k+int(pp.header.Total) > erasure.MaxShards || len(pp.parts) == 0 {
+		return nil, nil
+	}
+	// the parts are added concurrently by the consensus state
+	parts := make([]*types.Part, k)
+	count := 0
+	for j := range parts {
+		if parts[j] = blockParts.GetPart(j); parts[j] != nil {
+			count++
+		}
+	}
+	if count == k || count+len(pp.parts) < k {
+		return nil, nil
+	}
+
+	size := int(types.BlockPartSizeBytes)
+	shards := make([][]byte, erasure.MaxShards)
+	for index, part := range pp.parts {
+		shards[k+int(index)] = part.Bytes
+	}
+	for j, part := range parts {
+		if part == nil {
+			continue
+		}
+		shards[j] = make([]byte, size)
+		copy(shards[j], part.Bytes)
+	}
+	if err := erasure.Reconstruct(shards, k); err != nil {
+		pp.giveUp()
+		return nil, err
+	}
+
+	data := shards[:k]
+	data[k-1] = data[k-1][:pp.header.LastPartSize]
+	root, proofs := merkle.ProofsFromByteSlices(data)
+	if !bytes.Equal(root, blockParts.Header().Hash) {
+		pp.giveUp()
+		return nil, errors.New("the parts reconstructed from the parity parts don't match the block")
+	}
+	pp.done = true
+
+	var missing []*types.Part
+	for j, part := range parts {
+		if part == nil {
+			missing = append(missing, &types.Part{Index: uint32(j), Bytes: data[j], Proof: *proofs[j]})
+		}
+	}
+	return missing, nil
+}
+
+// giveUp drops the parity parts of a proposal which doesn't match its block.
+func (pp *parityParts) giveUp() {
+	pp.parts = make(map[uint32]*types.Part)
+	pp.done = true
+}
+
+// gossipParityPart sends the peer a parity part of the proposal block it
+// doesn't have, encoding the parity parts of the block first if complete.
+// It returns true if a parity part was sent.
+func (conR *Reactor) gossipParityPart(logger log.Logger, rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) bool {
+
+	if !conR.conS.config.ErasureCoding || rs.Height != prs.Height || rs.Round != prs.Round ||
+		rs.Proposal == nil || rs.Proposal.ParityPartSetHeader == nil || prs.ProposalBlockParts.IsFull() {
+		return false
+	}
+	header := rs.Proposal.ParityPartSetHeader
+	if rs.ProposalBlockParts.BitArray().IsFull() {
+		if err := conR.conS.parityParts.encode(rs.Height, rs.Round, header, rs.ProposalBlockParts); err != nil {
+			logger.Error("Failed to encode the parity parts of the block", "height", rs.Height, "err", err)
+			return false
+		}
+	}
+
+	msg, ok := conR.conS.parityParts.pick(rs.Height, rs.Round, ps.ParityParts(rs.Height, rs.Round))
+	if !ok {
+		return false
+	}
+	part, err := msg.Part.ToProto()
+	if err != nil {
+		logger.Error("Failed to convert the parity part to proto", "err", err)
+		return false
+	}
+	logger.Debug("Sending block parity part", "height", prs.Height, "round", prs.Round, "index", msg.Part.Index)
+	if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: ParityChannel,
+		Message: &cmtcons.BlockParityPart{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Part:   *part,
+		},
+	}, logger) {
+		ps.SetHasParityPart(msg.Height, msg.Round, int(msg.Part.Index))
+		return true
+	}
+	return false
+}
+
+// receiveParityPart adds a parity part received from a peer for the proposal
+// of the current height and round, and sends the parts of the proposal block
+// reconstructed from the parity parts to the consensus state, as received from
+// the peer.
+func (conR *Reactor) receiveParityPart(msg *BlockParityPartMessage, peerID p2p.ID) {
+	rs := conR.getRoundState()
+	if msg.Height != rs.Height || msg.Round != rs.Round ||
+		rs.Proposal == nil || rs.Proposal.ParityPartSetHeader == nil {
+		return
+	}
+	added, err := conR.conS.parityParts.add(rs.Height, rs.Round, rs.Proposal.ParityPartSetHeader, msg.Part)
+	if err != nil {
+		conR.Logger.Info("Invalid block parity part", "height", msg.Height, "round", msg.Round,
+			"index", msg.Part.Index, "peer", peerID, "err", err)
+		return
+	}
+	if !added || !rs.ProposalBlockParts.HasHeader(rs.Proposal.BlockID.PartSetHeader) {
+		return
+	}
+	parts, err := conR.conS.parityParts.reconstruct(rs.Height, rs.Round, rs.ProposalBlockParts)
+	if err != nil {
+		conR.Logger.Info("Failed to reconstruct the block from the parity parts",
+			"height", msg.Height, "round", msg.Round, "err", err)
+		return
+	}
+	if len(parts) == 0 {
+		return
+	}
+	conR.Logger.Debug("Reconstructed block parts from the parity parts",
+		"height", msg.Height, "round", msg.Round, "parts", len(parts))
+	conR.Metrics.BlockPartsReconstructed.Add(float64(len(parts)))
+	for _, part := range parts {
+		partMsg := &BlockPartMessage{Height: msg.Height, Round: msg.Round, Part: part}
+		conR.conS.peerMsgQueue <- msgInfo{partMsg, peerID, cmttime.Now()}
+	}
+}
+
+// addReconstructedParts adds the parts missing from the proposal block parts,
+// once reconstructed from the parity parts received before them, for the block
+// to complete when its last part needed arrives after the parity parts.
+func (cs *State) addReconstructedParts() {
+	if cs.Proposal == nil || cs.Proposal.ParityPartSetHeader == nil ||
+		!cs.ProposalBlockParts.HasHeader(cs.Proposal.BlockID.PartSetHeader) {
+		return
+	}
+	parts, err := cs.parityParts.reconstruct(cs.Proposal.Height, cs.Proposal.Round, cs.ProposalBlockParts)
+	if err != nil {
+		cs.Logger.Info("failed to reconstruct the block from the parity parts",
+			"height", cs.Proposal.Height, "round", cs.Proposal.Round, "err", err)
+		return
+	}
+	for _, part := range parts {
+		if _, err := cs.ProposalBlockParts.AddPart(part); err != nil {
+			cs.Logger.Error("failed to add a reconstructed block part", "index", part.Index, "err", err)
+			return
+		}
+	}
+	if len(parts) > 0 {
+		cs.Logger.Debug("reconstructed block parts from the parity parts",
+			"height", cs.Proposal.Height, "round", cs.Proposal.Round, "parts", len(parts))
+		cs.metrics.BlockPartsReconstructed.Add(float64(len(parts)))
+	}
+}
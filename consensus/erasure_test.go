@@ -0,0 +1,281 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/libs/erasure"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestParityPartsCount(t *testing.T) {
+	assert.Equal(t, 0, parityPartsCount(1, 50), "a single part")
+	assert.Equal(t, 1, parityPartsCount(2, 50))
+	assert.Equal(t, 4, parityPartsCount(7, 50), "rounded up")
+	assert.Equal(t, 100, parityPartsCount(100, 100))
+	assert.Equal(t, 56, parityPartsCount(200, 100), "capped at the max shards")
+	assert.Equal(t, 0, parityPartsCount(erasure.MaxShards, 10))
+}
+
+// blockParityParts returns the block parts of a block of 6 parts, and the
+// header and messages of its parity parts.
+func blockParityParts(t *testing.T, height int64, round int32) (
+	*types.PartSet, *types.ParityPartSetHeader, []*BlockParityPartMessage) {
+	blockParts := types.NewPartSetFromData(cmtrand.Bytes(5*int(types.BlockPartSizeBytes)+100), types.BlockPartSizeBytes)
+	require.EqualValues(t, 6, blockParts.Total())
+
+	_, header, err := encodeParityParts(blockParts, 3)
+	require.NoError(t, err)
+	require.NoError(t, header.ValidateBasic())
+	assert.EqualValues(t, 100, header.LastPartSize)
+
+	encoded := newParityParts()
+	require.NoError(t, encoded.encode(height, round, &header, blockParts))
+	require.Len(t, encoded.parts, 3)
+
+	var parity []*BlockParityPartMessage
+	for {
+		msg, ok := encoded.pick(height, round, parityBits(parity))
+		if !ok {
+			break
+		}
+		require.NoError(t, msg.ValidateBasic())
+		require.NoError(t, header.VerifyPart(msg.Part))
+		parity = append(parity, msg)
+	}
+	require.Len(t, parity, 3)
+	return blockParts, &header, parity
+}
+
+func parityBits(parity []*BlockParityPartMessage) *bits.BitArray {
+	bA := bits.NewBitArray(erasure.MaxShards)
+	for _, msg := range parity {
+		bA.SetIndex(int(msg.Part.Index), true)
+	}
+	return bA
+}
+
+// partialParts returns the parts of blockParts at indexes.
+func partialParts(t *testing.T, blockParts *types.PartSet, indexes ...int) *types.PartSet {
+	partial := types.NewPartSetFromHeader(blockParts.Header())
+	for _, j := range indexes {
+		_, err := partial.AddPart(blockParts.GetPart(j))
+		require.NoError(t, err)
+	}
+	return partial
+}
+
+func TestParityPartsReconstruct(t *testing.T) {
+	const height, round = 1, 0
+	blockParts, header, parity := blockParityParts(t, height, round)
+	// the parts 0, 2 and 5 are missing
+	partial := partialParts(t, blockParts, 1, 3, 4)
+
+	pp := newParityParts()
+	for i, msg := range parity {
+		added, err := pp.add(height, round, header, msg.Part)
+		require.NoError(t, err)
+		require.True(t, added)
+		added, err = pp.add(height, round, header, msg.Part)
+		require.NoError(t, err)
+		require.False(t, added, "already added")
+
+		missing, err := pp.reconstruct(height, round, partial)
+		require.NoError(t, err)
+		if i < len(parity)-1 {
+			require.Empty(t, missing, "not enough parity parts")
+		} else {
+			require.Len(t, missing, 3)
+			for _, part := range missing {
+				added, err := partial.AddPart(part)
+				require.NoError(t, err)
+				require.True(t, added)
+			}
+		}
+	}
+	require.True(t, partial.IsComplete())
+	assert.Equal(t, blockParts.GetPart(5).Bytes, partial.GetPart(5).Bytes)
+
+	missing, err := pp.reconstruct(height, round, partial)
+	require.NoError(t, err)
+	assert.Empty(t, missing, "done")
+	added, err := pp.add(height, round, header, parity[0].Part)
+	require.NoError(t, err)
+	assert.False(t, added, "done")
+}
+
+func TestParityPartsInvalidPart(t *testing.T) {
+	const height, round = 1, 1
+	blockParts, header, parity := blockParityParts(t, height, round)
+	partial := partialParts(t, blockParts, 0, 1, 2, 3)
+
+	pp := newParityParts()
+	bad := *parity[0].Part
+	bad.Bytes = cmtrand.Bytes(len(bad.Bytes))
+	_, err := pp.add(height, round, header, &bad)
+	require.ErrorIs(t, err, types.ErrPartSetInvalidProof)
+	short := *parity[1].Part
+	short.Bytes = short.Bytes[:100]
+	_, err = pp.add(height, round, header, &short)
+	require.Error(t, err, "another size")
+	wrongIndex := *parity[2].Part
+	wrongIndex.Index = (wrongIndex.Index + 1) % 3
+	_, err = pp.add(height, round, header, &wrongIndex)
+	require.ErrorIs(t, err, types.ErrPartSetUnexpectedIndex)
+
+	// the invalid parts are rejected, the valid ones kept
+	added, err := pp.add(height, round, header, parity[1].Part)
+	require.NoError(t, err)
+	require.True(t, added)
+	added, err = pp.add(height, round, header, parity[0].Part)
+	require.NoError(t, err)
+	require.True(t, added)
+	missing, err := pp.reconstruct(height, round, partial)
+	require.NoError(t, err)
+	assert.Len(t, missing, 2)
+}
+
+func TestParityPartsHeightRound(t *testing.T) {
+	const height, round = 2, 1
+	blockParts, header, parity := blockParityParts(t, height, round)
+	partial := partialParts(t, blockParts, 0, 1, 2, 3, 4)
+
+	pp := newParityParts()
+	added, err := pp.add(height, round, header, parity[0].Part)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// a previous round
+	added, err = pp.add(height, round-1, header, parity[1].Part)
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.EqualValues(t, height, pp.height)
+	assert.EqualValues(t, round, pp.round)
+
+	missing, err := pp.reconstruct(height, round, partial)
+	require.NoError(t, err)
+	assert.Len(t, missing, 1)
+
+	// the next height resets the parity parts
+	added, err = pp.add(height+1, 0, header, parity[1].Part)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Len(t, pp.parts, 1)
+	missing, err = pp.reconstruct(height, round, partialParts(t, blockParts, 0, 1, 2, 3, 4))
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestParityPartsCommitmentMismatch(t *testing.T) {
+	const height, round = 1, 0
+	blockParts, _, _ := blockParityParts(t, height, round)
+	// the parity parts of another block of as many parts
+	_, header, parity := blockParityParts(t, height, round)
+
+	pp := newParityParts()
+	require.Error(t, pp.encode(height, round, header, blockParts))
+	assert.Empty(t, pp.parts)
+	_, ok := pp.pick(height, round, parityBits(nil))
+	assert.False(t, ok)
+
+	pp = newParityParts()
+	partial := partialParts(t, blockParts, 0, 1, 2, 3)
+	for _, msg := range parity[:2] {
+		added, err := pp.add(height, round, header, msg.Part)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	_, err := pp.reconstruct(height, round, partial)
+	require.Error(t, err)
+	assert.Empty(t, pp.parts)
+	added, err := pp.add(height, round, header, parity[2].Part)
+	require.NoError(t, err)
+	assert.False(t, added, "given up on")
+}
+
+func TestReactorReceiveParityPart(t *testing.T) {
+	cs, _ := randState(1)
+	conR := NewReactor(cs, false)
+
+	const round = 0
+	height := cs.Height
+	blockParts, header, parity := blockParityParts(t, height, round)
+	proposal := &types.Proposal{
+		Height:              height,
+		Round:               round,
+		BlockID:             types.BlockID{PartSetHeader: blockParts.Header()},
+		ParityPartSetHeader: header,
+	}
+	conR.rs = &cstypes.RoundState{
+		Height:             height,
+		Round:              round,
+		Proposal:           proposal,
+		ProposalBlockParts: partialParts(t, blockParts, 0, 2, 4),
+	}
+
+	// the parity parts of other heights and rounds are dropped
+	conR.receiveParityPart(&BlockParityPartMessage{Height: height + 1, Round: round, Part: parity[0].Part}, "peer")
+	conR.receiveParityPart(&BlockParityPartMessage{Height: height, Round: round + 1, Part: parity[0].Part}, "peer")
+	assert.Empty(t, conR.conS.parityParts.parts)
+	assert.Equal(t, int64(0), conR.conS.parityParts.height)
+
+	for _, msg := range parity {
+		conR.receiveParityPart(msg, "peer")
+	}
+	for _, j := range []uint32{1, 3, 5} {
+		mi := <-cs.peerMsgQueue
+		assert.EqualValues(t, "peer", mi.PeerID)
+		msg, ok := mi.Msg.(*BlockPartMessage)
+		require.True(t, ok)
+		assert.Equal(t, height, msg.Height)
+		assert.Equal(t, j, msg.Part.Index)
+		assert.Equal(t, blockParts.GetPart(int(j)).Bytes, msg.Part.Bytes)
+	}
+	assert.Empty(t, cs.peerMsgQueue)
+}
+
+func TestStateReconstructAfterParityParts(t *testing.T) {
+	cs, _ := randState(1)
+	conR := NewReactor(cs, false)
+
+	const round = 0
+	height := cs.Height
+	// a block of 6 parts
+	tx := types.Tx("big=" + string(cmtrand.Bytes(5*int(types.BlockPartSizeBytes))))
+	block, blockParts := cs.state.MakeBlock(height, []types.Tx{tx}, &types.Commit{}, nil,
+		cs.Validators.GetProposer().Address)
+	require.EqualValues(t, 6, blockParts.Total())
+	parity, header, err := encodeParityParts(blockParts, 3)
+	require.NoError(t, err)
+
+	cs.Proposal = &types.Proposal{
+		Height:              height,
+		Round:               round,
+		BlockID:             types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()},
+		ParityPartSetHeader: &header,
+	}
+	cs.ProposalBlockParts = partialParts(t, blockParts, 0, 1)
+	conR.rs = cs.GetRoundState()
+
+	// the parity parts arrive first, too few with the parts of the block to
+	// reconstruct it
+	for _, part := range parity {
+		conR.receiveParityPart(&BlockParityPartMessage{Height: height, Round: round, Part: part}, "peer")
+	}
+	assert.Len(t, cs.parityParts.parts, 3)
+	assert.Empty(t, cs.peerMsgQueue)
+	assert.False(t, cs.ProposalBlockParts.IsComplete())
+
+	// the third part of the block is enough to reconstruct the others
+	added, err := cs.addProposalBlockPart(&BlockPartMessage{Height: height, Round: round, Part: blockParts.GetPart(4)}, "peer")
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.True(t, cs.ProposalBlockParts.IsComplete())
+	require.NotNil(t, cs.ProposalBlock)
+	assert.Equal(t, block.Hash(), cs.ProposalBlock.Hash())
+}
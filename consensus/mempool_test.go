@@ -43,6 +43,45 @@ func TestMempoolNoProgressUntilTxsAvailable(t *testing.T) {
 	ensureNoNewEventOnChannel(newBlockCh)
 }
 
+func TestMempoolSingleValidatorFastPath(t *testing.T) {
+	config := ResetConfig("consensus_mempool_fast_path_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.CreateEmptyBlocks = false
+	config.Consensus.SkipTimeoutCommit = false
+	config.Consensus.TimeoutCommit = time.Hour
+	config.Consensus.SingleValidatorFastPath = true
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	assertMempool(cs.txNotifier).EnableTxsAvailable()
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	// the blocks don't wait for the timeout commit
+	ensureNewEventOnChannel(newBlockCh) // first block gets committed
+	ensureNoNewEventOnChannel(newBlockCh)
+	deliverTxsRange(cs, 0, 1)
+	ensureNewEventOnChannel(newBlockCh) // commit txs
+	ensureNewEventOnChannel(newBlockCh) // commit updated app hash
+	ensureNoNewEventOnChannel(newBlockCh)
+}
+
+func TestMempoolSingleValidatorFastPathEmptyBlocks(t *testing.T) {
+	config := ResetConfig("consensus_mempool_fast_path_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.SkipTimeoutCommit = false
+	config.Consensus.TimeoutCommit = time.Hour
+	config.Consensus.SingleValidatorFastPath = true
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	// the empty blocks don't wait for the timeout commit either
+	ensureNewEventOnChannel(newBlockCh)
+	ensureNewEventOnChannel(newBlockCh)
+	ensureNewEventOnChannel(newBlockCh)
+}
+
 func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	config := ResetConfig("consensus_mempool_txs_available_test")
 	defer os.RemoveAll(config.RootDir)
@@ -61,6 +100,51 @@ func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	ensureNewEventOnChannel(newBlockCh)   // until the CreateEmptyBlocksInterval has passed
 }
 
+func TestMempoolLazyBlockProduction(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	defer os.RemoveAll(config.RootDir)
+
+	minInterval, maxIdleInterval := 300*time.Millisecond, 1500*time.Millisecond
+	config.Consensus.LazyBlockProduction = true
+	config.Consensus.LazyBlockMinInterval = minInterval
+	config.Consensus.LazyBlockMaxIdleInterval = maxIdleInterval
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+
+	assertMempool(cs.txNotifier).EnableTxsAvailable()
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	ensureNewEventOnChannel(newBlockCh) // first block gets committed
+	last := time.Now()
+
+	// the block with the tx and the one proving the new app hash are produced
+	// right away, but for the min interval between blocks
+	deliverTxsRange(cs, 0, 1)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-newBlockCh:
+		case <-time.After(minInterval + ensureTimeout):
+			t.Fatal("timed out waiting for a block with txs")
+		}
+		assert.Greater(t, time.Since(last), minInterval-50*time.Millisecond)
+		last = time.Now()
+	}
+
+	// then an empty block after the max idle interval only
+	select {
+	case <-newBlockCh:
+		t.Fatal("unexpected empty block before the max idle interval")
+	case <-time.After(maxIdleInterval - ensureTimeout):
+	}
+	select {
+	case <-newBlockCh:
+	case <-time.After(2 * ensureTimeout):
+		t.Fatalf("timed out waiting for an empty block, %v after the last one", time.Since(last))
+	}
+}
+
 func TestMempoolProgressInHigherRound(t *testing.T) {
 	config := ResetConfig("consensus_mempool_txs_available_test")
 	defer os.RemoveAll(config.RootDir)
@@ -72,14 +156,14 @@ func TestMempoolProgressInHigherRound(t *testing.T) {
 	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
 	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
 	timeoutCh := subscribe(cs.eventBus, types.EventQueryTimeoutPropose)
-	cs.setProposal = func(proposal *types.Proposal) error {
+	cs.setProposal = func(proposal *types.Proposal, receiveTime time.Time) error {
 		if cs.Height == 2 && cs.Round == 0 {
 			// dont set the proposal in round 0 so we timeout and
 			// go to next round
 			cs.Logger.Info("Ignoring set proposal at height 2, round 0")
 			return nil
 		}
-		return cs.defaultSetProposal(proposal)
+		return cs.defaultSetProposal(proposal, receiveTime)
 	}
 	startTestRound(cs, height, round)
 
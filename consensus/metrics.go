@@ -64,6 +64,8 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+	// Number of block parts reconstructed from the erasure coded parity parts.
+	BlockPartsReconstructed metrics.Counter
 
 	// Histogram of step duration.
 	StepDuration metrics.Histogram
@@ -88,6 +90,27 @@ type Metrics struct {
 	// timestamp and the timestamp of the latest prevote in a round where 100%
 	// of the voting power on the network issued prevotes.
 	FullPrevoteMessageDelay metrics.Gauge
+
+	// Number of proposals prevoted nil for not being timely, with
+	// proposer-based timestamps.
+	ProposalNotTimely metrics.Counter
+
+	// Whether the signing is halted, after a vote or a proposal conflicting
+	// with one signed before was refused.
+	SigningHalted metrics.Gauge
+
+	// Time in seconds from the start of the propose step of the round to the
+	// receipt of the prevote of a validator.
+	ValidatorPrevoteDelay metrics.Histogram
+	// Time in seconds from the start of the propose step of the round to the
+	// receipt of the precommit of a validator.
+	ValidatorPrecommitDelay metrics.Histogram
+	// Number of rounds whose proposer's block wasn't received before
+	// prevoting.
+	ValidatorMissedProposals metrics.Counter
+	// Precommit delay in seconds of the validator whose precommit was received
+	// last in the round of the last commit, 0 for the others.
+	SlowestValidator metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -213,6 +236,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		BlockPartsReconstructed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_parts_reconstructed",
+			Help:      "Number of block parts reconstructed from the erasure coded parity parts.",
+		}, labels).With(labelsAndValues...),
 		BlockGossipPartsReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -241,6 +270,45 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help: "Difference in seconds between the proposal timestamp and the timestamp " +
 				"of the latest prevote that achieved 100% of the voting power in the prevote step.",
 		}, labels).With(labelsAndValues...),
+		ProposalNotTimely: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "proposal_not_timely",
+			Help:      "Number of proposals prevoted nil for not being timely.",
+		}, labels).With(labelsAndValues...),
+		SigningHalted: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "signing_halted",
+			Help:      "Whether the signing is halted after a conflicting signature was refused.",
+		}, labels).With(labelsAndValues...),
+		ValidatorPrevoteDelay: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_prevote_delay_seconds",
+			Help:      "Time from the start of the propose step to the receipt of the prevote of a validator.",
+			Buckets:   stdprometheus.ExponentialBucketsRange(0.01, 30, 10),
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorPrecommitDelay: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_precommit_delay_seconds",
+			Help:      "Time from the start of the propose step to the receipt of the precommit of a validator.",
+			Buckets:   stdprometheus.ExponentialBucketsRange(0.01, 30, 10),
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorMissedProposals: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_missed_proposals",
+			Help:      "Number of rounds whose proposer's block wasn't received before prevoting.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		SlowestValidator: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "slowest_validator",
+			Help: "Precommit delay of the validator whose precommit was received last " +
+				"in the round of the last commit, 0 for the others.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
 	}
 }
 
@@ -272,9 +340,16 @@ func NopMetrics() *Metrics {
 		FastSyncing:               discard.NewGauge(),
 		StateSyncing:              discard.NewGauge(),
 		BlockParts:                discard.NewCounter(),
+		BlockPartsReconstructed:   discard.NewCounter(),
 		BlockGossipPartsReceived:  discard.NewCounter(),
 		QuorumPrevoteMessageDelay: discard.NewGauge(),
 		FullPrevoteMessageDelay:   discard.NewGauge(),
+		ProposalNotTimely:         discard.NewCounter(),
+		SigningHalted:             discard.NewGauge(),
+		ValidatorPrevoteDelay:     discard.NewHistogram(),
+		ValidatorPrecommitDelay:   discard.NewHistogram(),
+		ValidatorMissedProposals:  discard.NewCounter(),
+		SlowestValidator:          discard.NewGauge(),
 	}
 }
 
@@ -72,6 +72,18 @@ func MsgToProto(msg Message) (*cmtcons.Message, error) {
 		}
 		return m.Wrap().(*cmtcons.Message), nil
 
+	case *BlockParityPartMessage:
+		parts, err := msg.Part.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		m := &cmtcons.BlockParityPart{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Part:   *parts,
+		}
+		return m.Wrap().(*cmtcons.Message), nil
+
 	case *VoteMessage:
 		vote := msg.Vote.ToProto()
 		m := &cmtcons.Vote{
@@ -188,6 +200,16 @@ func MsgFromProto(p *cmtcons.Message) (Message, error) {
 			Round:  msg.Round,
 			Part:   parts,
 		}
+	case *cmtcons.BlockParityPart:
+		parts, err := types.PartFromProto(&msg.Part)
+		if err != nil {
+			return nil, fmt.Errorf("blockparitypart msg to proto error: %w", err)
+		}
+		pb = &BlockParityPartMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Part:   parts,
+		}
 	case *cmtcons.Vote:
 		vote, err := types.VoteFromProto(msg.Vote)
 		if err != nil {
@@ -285,6 +307,9 @@ func WALToProto(msg WALMessage) (*cmtcons.WALMessage, error) {
 				},
 			},
 		}
+		if !msg.ReceiveTime.IsZero() {
+			pb.GetMsgInfo().ReceiveTime = &msg.ReceiveTime
+		}
 	case timeoutInfo:
 		pb = cmtcons.WALMessage{
 			Sum: &cmtcons.WALMessage_TimeoutInfo{
@@ -330,10 +355,14 @@ func WALFromProto(msg *cmtcons.WALMessage) (WALMessage, error) {
 		if err != nil {
 			return nil, fmt.Errorf("msgInfo from proto error: %w", err)
 		}
-		pb = msgInfo{
+		mi := msgInfo{
 			Msg:    walMsg,
 			PeerID: p2p.ID(msg.MsgInfo.PeerID),
 		}
+		if msg.MsgInfo.ReceiveTime != nil {
+			mi.ReceiveTime = *msg.MsgInfo.ReceiveTime
+		}
+		pb = mi
 
 	case *cmtcons.WALMessage_TimeoutInfo:
 		tis, err := cmtmath.SafeConvertUint8(int64(msg.TimeoutInfo.Step))
@@ -115,6 +115,17 @@ func TestMsgToProto(t *testing.T) {
 			Part:   *pbParts,
 		}).Wrap().(*cmtcons.Message),
 
+			false},
+		{"successful BlockParityPartMessage", &BlockParityPartMessage{
+			Height: 100,
+			Round:  1,
+			Part:   &parts,
+		}, (&cmtcons.BlockParityPart{
+			Height: 100,
+			Round:  1,
+			Part:   *pbParts,
+		}).Wrap().(*cmtcons.Message),
+
 			false},
 		{"successful ProposalPOLMessage", &ProposalPOLMessage{
 			Height:           1,
@@ -381,6 +392,9 @@ func TestConsMsgsVectors(t *testing.T) {
 		{"BlockPart", &cmtcons.Message{Sum: &cmtcons.Message_BlockPart{
 			BlockPart: &cmtcons.BlockPart{Height: 1, Round: 1, Part: *pbParts}}},
 			"2a36080110011a3008011204746573741a26080110011a206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d"},
+		{"BlockParityPart", &cmtcons.Message{Sum: &cmtcons.Message_BlockParityPart{
+			BlockParityPart: &cmtcons.BlockParityPart{Height: 1, Round: 1, Part: *pbParts}}},
+			"5236080110011a3008011204746573741a26080110011a206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d"},
 		{"Vote", &cmtcons.Message{Sum: &cmtcons.Message_Vote{
 			Vote: &cmtcons.Vote{Vote: vpb}}},
 			"32700a6e0802100122480a206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d1224080112206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d2a0608c0b89fdc0532146164645f6d6f72655f6578636c616d6174696f6e3801"},
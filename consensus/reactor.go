@@ -10,6 +10,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/libs/erasure"
 	cmtevents "github.com/tendermint/tendermint/libs/events"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
@@ -27,6 +28,7 @@ const (
 	DataChannel        = byte(0x21)
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
+	ParityChannel      = byte(0x24)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
@@ -143,6 +145,31 @@ conR:
 	}
 }
 
+// SwitchToFastSync stops the consensus state-machine and puts the reactor back
+// into fast_sync mode. It returns the latest committed state, from which fast
+// sync should resume. The state-machine is restarted by SwitchToConsensus.
+func (conR *Reactor) SwitchToFastSync() (sm.State, error) {
+	if conR.WaitSync() {
+		return sm.State{}, errors.New("already in fast_sync mode")
+	}
+	conR.Logger.Info("SwitchToFastSync")
+
+	if err := conR.conS.Stop(); err != nil {
+		return sm.State{}, fmt.Errorf("failed to stop consensus state: %w", err)
+	}
+	conR.conS.Wait()
+	if err := conR.conS.Reset(); err != nil {
+		return sm.State{}, fmt.Errorf("failed to reset consensus state: %w", err)
+	}
+
+	conR.mtx.Lock()
+	conR.waitSync = true
+	conR.mtx.Unlock()
+	conR.Metrics.FastSyncing.Set(1)
+
+	return conR.conS.GetState(), nil
+}
+
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
@@ -179,6 +206,15 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
 		},
+		{
+			ID: ParityChannel, // the parity parts of the erasure coded blocks
+			// a channel of its own for the peers not supporting them
+			Priority:            10,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &cmtcons.Message{},
+		},
 	}
 }
 
@@ -328,13 +364,26 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		switch msg := msg.(type) {
 		case *ProposalMessage:
 			ps.SetHasProposal(msg.Proposal)
-			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID(), cmttime.Now()}
 		case *ProposalPOLMessage:
 			ps.ApplyProposalPOLMessage(msg)
 		case *BlockPartMessage:
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
-			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID(), cmttime.Now()}
+		default:
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
+	case ParityChannel:
+		if conR.WaitSync() {
+			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+			return
+		}
+		switch msg := msg.(type) {
+		case *BlockParityPartMessage:
+			ps.SetHasParityPart(msg.Height, msg.Round, int(msg.Part.Index))
+			conR.receiveParityPart(msg, e.Src.ID())
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -354,7 +403,7 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
 
-			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID(), cmttime.Now()}
 
 		default:
 			// don't punish (leave room for soft upgrades)
@@ -575,6 +624,8 @@ OUTER_LOOP:
 
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+			// along with a parity part of the block, if erasure coding
+			sentParity := conR.gossipParityPart(logger, rs, prs, ps, peer)
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				parts, err := part.ToProto()
@@ -594,6 +645,9 @@ OUTER_LOOP:
 				}
 				continue OUTER_LOOP
 			}
+			if sentParity {
+				continue OUTER_LOOP
+			}
 		}
 
 		// If the peer is on a previous height that we have, help catch up.
@@ -984,9 +1038,9 @@ func (conR *Reactor) peerStatsRoutine() {
 					conR.Switch.MarkPeerAsGood(peer)
 				}
 			}
-		case <-conR.conS.Quit():
-			return
-
+		// NOTE: the consensus state may be stopped and restarted when the
+		// node falls back to fast sync, so only the reactor quitting ends
+		// this routine.
 		case <-conR.Quit():
 			return
 		}
@@ -1039,6 +1093,12 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// the parity parts of the erasure coded proposal block of parityHeight and
+	// parityRound the peer has
+	parityHeight int64
+	parityRound  int32
+	parityParts  *bits.BitArray
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1151,6 +1211,31 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
+// SetHasParityPart sets the given parity part of the proposal block as known
+// for the peer.
+func (ps *PeerState) SetHasParityPart(height int64, round int32, index int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.parityParts == nil || ps.parityHeight != height || ps.parityRound != round {
+		ps.parityHeight, ps.parityRound = height, round
+		ps.parityParts = bits.NewBitArray(erasure.MaxShards)
+	}
+	ps.parityParts.SetIndex(index, true)
+}
+
+// ParityParts returns the parity parts of the proposal block of height and
+// round known for the peer.
+func (ps *PeerState) ParityParts(height int64, round int32) *bits.BitArray {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.parityHeight != height || ps.parityRound != round {
+		return nil
+	}
+	return ps.parityParts.Copy()
+}
+
 // PickSendVote picks a vote and sends it to the peer.
 // Returns true if vote was sent.
 func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
@@ -1522,6 +1607,7 @@ func init() {
 	cmtjson.RegisterType(&ProposalMessage{}, "tendermint/Proposal")
 	cmtjson.RegisterType(&ProposalPOLMessage{}, "tendermint/ProposalPOL")
 	cmtjson.RegisterType(&BlockPartMessage{}, "tendermint/BlockPart")
+	cmtjson.RegisterType(&BlockParityPartMessage{}, "tendermint/BlockParityPart")
 	cmtjson.RegisterType(&VoteMessage{}, "tendermint/Vote")
 	cmtjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	cmtjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
@@ -1709,6 +1795,40 @@ func (m *BlockPartMessage) String() string {
 
 //-------------------------------------
 
+// BlockParityPartMessage is sent when gossipping a parity part of the erasure
+// coded proposed block, with its proof against the ParityPartSetHeader of the
+// proposal. The parity part is of the size of the block parts, the last one
+// padded with zeros.
+type BlockParityPartMessage struct {
+	Height int64
+	Round  int32
+	Part   *types.Part
+}
+
+// ValidateBasic performs basic validation.
+func (m *BlockParityPartMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.Part.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Part: %v", err)
+	}
+	if m.Part.Index >= erasure.MaxShards {
+		return fmt.Errorf("index %d is too big, max: %d", m.Part.Index, erasure.MaxShards-1)
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *BlockParityPartMessage) String() string {
+	return fmt.Sprintf("[BlockParityPart H:%v R:%v P:%v]", m.Height, m.Round, m.Part)
+}
+
+//-------------------------------------
+
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
@@ -27,7 +27,9 @@ import (
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/erasure"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
@@ -124,6 +126,79 @@ func TestReactorBasic(t *testing.T) {
 	}, css)
 }
 
+// Ensure the validators gossip the parity parts of the blocks with erasure coding
+func TestReactorErasureCoding(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true),
+		func() abci.Application { return kvstore.NewApplication() },
+		func(c *cfg.Config) { c.Consensus.ErasureCoding = true })
+	defer cleanup()
+	for _, cs := range css {
+		cs.state.ConsensusParams.Feature.ErasureCodingEnableHeight = 1
+	}
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	// a block of 4 parts
+	tx := []byte("big=" + string(cmtrand.Bytes(3*int(types.BlockPartSizeBytes))))
+	for j := range css {
+		err := assertMempool(css[j].txNotifier).CheckTx(tx, nil, mempl.TxInfo{})
+		require.NoError(t, err)
+	}
+
+	heights := make([]int64, N)
+	timeoutWaitGroup(t, N, func(j int) {
+		for {
+			block := (<-blocksSubs[j].Out()).Data().(types.EventDataNewBlock).Block
+			if len(block.Txs) > 0 {
+				assert.Equal(t, types.Tx(tx), block.Txs[0])
+				heights[j] = block.Height
+				return
+			}
+		}
+	}, css)
+
+	sent := false
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			ps := peer.Get(types.PeerStateKey).(*PeerState)
+			ps.mtx.Lock()
+			if ps.parityHeight >= heights[0] {
+				sent = true
+			}
+			ps.mtx.Unlock()
+		}
+	}
+	assert.True(t, sent, "no parity parts gossiped")
+}
+
+// Ensure a validator can fall back to fast sync and rejoin consensus
+func TestReactorSwitchToFastSync(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	// wait till everyone makes the first new block
+	timeoutWaitGroup(t, N, func(j int) {
+		<-blocksSubs[j].Out()
+	}, css)
+
+	state, err := reactors[0].SwitchToFastSync()
+	require.NoError(t, err)
+	assert.True(t, reactors[0].WaitSync())
+	_, err = reactors[0].SwitchToFastSync()
+	assert.Error(t, err)
+
+	reactors[0].SwitchToConsensus(state, false)
+	assert.False(t, reactors[0].WaitSync())
+
+	// wait till everyone makes another block
+	timeoutWaitGroup(t, N, func(j int) {
+		<-blocksSubs[j].Out()
+	}, css)
+}
+
 // Ensure we can process blocks with evidence
 func TestReactorWithEvidence(t *testing.T) {
 	nValidators := 4
@@ -911,6 +986,33 @@ func TestBlockPartMessageValidateBasic(t *testing.T) {
 	assert.Equal(t, true, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
 }
 
+func TestBlockParityPartMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName  string
+		malleate  func(*BlockParityPartMessage)
+		expectErr bool
+	}{
+		{"Valid Message", func(m *BlockParityPartMessage) {}, false},
+		{"Invalid Message", func(m *BlockParityPartMessage) { m.Height = -1 }, true},
+		{"Invalid Message", func(m *BlockParityPartMessage) { m.Round = -1 }, true},
+		{"Invalid Index", func(m *BlockParityPartMessage) { m.Part.Index = erasure.MaxShards }, true},
+		{"Too Big Bytes", func(m *BlockParityPartMessage) { m.Part.Bytes = make([]byte, types.BlockPartSizeBytes+1) }, true},
+		{"Invalid Proof", func(m *BlockParityPartMessage) { m.Part.Proof.Index = -1 }, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			part := &types.Part{Index: 255, Bytes: []byte("test")}
+			part.Proof.LeafHash = tmhash.Sum([]byte("leaf"))
+			message := &BlockParityPartMessage{Height: 0, Round: 0, Part: part}
+			tc.malleate(message)
+
+			assert.Equal(t, tc.expectErr, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestHasVoteMessageValidateBasic(t *testing.T) {
 	const (
 		validSignedMsgType   cmtproto.SignedMsgType = 0x01
@@ -205,6 +205,9 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	sequencerRotations sm.SequencerRotations
+	forcedTxs          sm.ForcedTxs
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -232,6 +235,18 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetSequencerRotations sets the sequencer rotations applied to the blocks
+// replayed, as by the BlockExecutor of consensus.
+func (h *Handshaker) SetSequencerRotations(rotations sm.SequencerRotations) {
+	h.sequencerRotations = rotations
+}
+
+// SetForcedTxs sets the forced txs marked as included by the blocks replayed,
+// as by the BlockExecutor of consensus.
+func (h *Handshaker) SetForcedTxs(forcedTxs sm.ForcedTxs) {
+	h.forcedTxs = forcedTxs
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -304,6 +319,7 @@ func (h *Handshaker) ReplayBlocks(
 		validators := make([]*types.Validator, len(h.genDoc.Validators))
 		for i, val := range h.genDoc.Validators {
 			validators[i] = types.NewValidator(val.PubKey, val.Power)
+			validators[i].ProofOfPossession = val.ProofOfPossession
 		}
 		validatorSet := types.NewValidatorSet(validators)
 		nextVals := types.TM2PB.ValidatorUpdates(validatorSet)
@@ -332,6 +348,9 @@ func (h *Handshaker) ReplayBlocks(
 			}
 			// If the app returned validators or consensus params, update the state.
 			if len(res.Validators) > 0 {
+				if err := sm.ValidateProofsOfPossession(res.Validators, validatorSet); err != nil {
+					return nil, fmt.Errorf("error in the validators of InitChain: %w", err)
+				}
 				vals, err := types.PB2TM.ValidatorUpdates(res.Validators)
 				if err != nil {
 					return nil, err
@@ -496,7 +515,15 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 
 	// Use stubs for both mempool and evidence pool since no transactions nor
 	// evidence are needed here - block already exists.
-	blockExec := sm.NewBlockExecutor(h.stateStore, h.logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{})
+	var options []sm.BlockExecutorOption
+	if h.sequencerRotations != nil {
+		options = append(options, sm.BlockExecutorWithSequencerRotations(h.sequencerRotations))
+	}
+	if h.forcedTxs != nil {
+		options = append(options, sm.BlockExecutorWithForcedTxs(h.forcedTxs))
+	}
+	blockExec := sm.NewBlockExecutor(h.stateStore, h.logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{},
+		options...)
 	blockExec.SetEventBus(h.eventBus)
 
 	var err error
@@ -13,6 +13,7 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	cfg "github.com/tendermint/tendermint/config"
+	auto "github.com/tendermint/tendermint/libs/autofile"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/proxy"
@@ -30,10 +31,10 @@ const (
 // replay messages interactively or all at once
 
 // replay the wal file
-func RunReplayFile(config cfg.BaseConfig, csConfig *cfg.ConsensusConfig, console bool) {
-	consensusState := newConsensusStateForReplay(config, csConfig)
+func RunReplayFile(config *cfg.Config, console bool) {
+	consensusState := newConsensusStateForReplay(config)
 
-	if err := consensusState.ReplayFile(csConfig.WalFile(), console); err != nil {
+	if err := consensusState.ReplayFile(config.Consensus.WalFile(), console); err != nil {
 		cmtos.Exit(fmt.Sprintf("Error during consensus replay: %v", err))
 	}
 }
@@ -97,6 +98,135 @@ func (cs *State) ReplayFile(file string, console bool) error {
 	}
 }
 
+//--------------------------------------------------------
+// replay the WAL against the app, in a sandbox
+
+// errReplayDone stops the reading of the WAL once the replay reached its height.
+var errReplayDone = errors.New("replay done")
+
+// ReplayFile replays the WAL at walFile against the app of clientCreator, up to
+// the end of height, and returns the state the replay ends with.
+//
+// The replay runs in a sandbox: the blocks and the states are kept in memory,
+// starting from genDoc, and the WAL is only read. Thus the app must start from
+// genesis too, and the WAL must hold all the heights from the first one, i.e.
+// not be pruned nor compacted. The messages of the WAL, timeouts included, are
+// replayed in their order, as on a restart, and the steps of the rounds are
+// checked against the ones it recorded, so that the replay is deterministic.
+func ReplayFile(
+	csConfig *cfg.ConsensusConfig,
+	genDoc *types.GenesisDoc,
+	clientCreator proxy.ClientCreator,
+	walFile string,
+	height int64,
+	logger log.Logger,
+) (sm.State, error) {
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return sm.State{}, err
+	}
+	if height < state.InitialHeight {
+		return sm.State{}, fmt.Errorf("height %d is below the initial height %d", height, state.InitialHeight)
+	}
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: true})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return sm.State{}, fmt.Errorf("error starting proxy app connections: %v", err)
+	}
+	defer proxyApp.Stop() //nolint:errcheck // ignore for the replay
+
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(logger.With("module", "events"))
+	if err := eventBus.Start(); err != nil {
+		return sm.State{}, fmt.Errorf("failed to start event bus: %v", err)
+	}
+	defer eventBus.Stop() //nolint:errcheck // ignore for the replay
+
+	handshaker := NewHandshaker(stateStore, state, blockStore, genDoc)
+	handshaker.SetLogger(logger)
+	handshaker.SetEventBus(eventBus)
+	if err := handshaker.Handshake(proxyApp); err != nil {
+		return sm.State{}, fmt.Errorf("error during handshake: %v", err)
+	}
+	if state, err = stateStore.Load(); err != nil {
+		return sm.State{}, err
+	}
+
+	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(), mempool, evpool)
+	cs := NewState(csConfig, state.Copy(), blockExec, blockStore, mempool, evpool)
+	cs.SetLogger(logger)
+	cs.SetEventBus(eventBus)
+	cs.replayMode = true
+
+	// the timeouts are replayed from the WAL, the ticker only has to run for
+	// the state not to block on scheduling them
+	if err := cs.timeoutTicker.Start(); err != nil {
+		return sm.State{}, err
+	}
+	defer cs.timeoutTicker.Stop() //nolint:errcheck // ignore for the replay
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-cs.statsMsgQueue:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ctx := context.Background()
+	newStepSub, err := eventBus.Subscribe(ctx, subscriber, types.EventQueryNewRoundStep, msgQueueSize)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("failed to subscribe %s to %v", subscriber, types.EventQueryNewRoundStep)
+	}
+	defer eventBus.Unsubscribe(ctx, subscriber, types.EventQueryNewRoundStep) //nolint:errcheck // ignore for the replay
+
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer group.Close()
+
+	started := false
+	err = readWAL(group, group.MinIndex(), func(msg *TimedWALMessage) (err error) {
+		// an app diverging from the WAL makes the state panic
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("failed to replay the WAL at height %d: %v", cs.Height, r)
+			}
+		}()
+		if !started {
+			if m, ok := msg.Msg.(EndHeightMessage); !ok || m.Height != 0 {
+				return errors.New("the WAL doesn't start at genesis, it may have been pruned or compacted")
+			}
+			started = true
+			return nil
+		}
+		if err := cs.readReplayMessage(msg, newStepSub); err != nil {
+			return err
+		}
+		if m, ok := msg.Msg.(EndHeightMessage); ok && m.Height >= height {
+			return errReplayDone
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errReplayDone):
+		return cs.state.Copy(), nil
+	case err != nil:
+		return sm.State{}, err
+	default:
+		return sm.State{}, fmt.Errorf("the WAL ends at height %d, before the end of height %d", cs.Height, height)
+	}
+}
+
 //------------------------------------------------
 // playback manager
 
@@ -283,17 +413,17 @@ func (pb *playback) replayConsoleLoop() int {
 //--------------------------------------------------------------------------------
 
 // convenience for replay mode
-func newConsensusStateForReplay(config cfg.BaseConfig, csConfig *cfg.ConsensusConfig) *State {
-	dbType := dbm.BackendType(config.DBBackend)
+func newConsensusStateForReplay(config *cfg.Config) *State {
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStoreDB, err := dbm.NewDB("blockstore",
+		dbm.BackendType(config.DBBackendOf("blockstore")), config.DBDirOf("blockstore"))
 	if err != nil {
 		cmtos.Exit(err.Error())
 	}
 	blockStore := store.NewBlockStore(blockStoreDB)
 
 	// Get State
-	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(config.DBBackendOf("state")), config.DBDirOf("state"))
 	if err != nil {
 		cmtos.Exit(err.Error())
 	}
@@ -332,7 +462,7 @@ func newConsensusStateForReplay(config cfg.BaseConfig, csConfig *cfg.ConsensusCo
 	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
 	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mempool, evpool)
 
-	consensusState := NewState(csConfig, state.Copy(), blockExec,
+	consensusState := NewState(config.Consensus, state.Copy(), blockExec,
 		blockStore, mempool, evpool)
 
 	consensusState.SetEventBus(eventBus)
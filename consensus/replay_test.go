@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -30,6 +32,7 @@ import (
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -149,87 +152,120 @@ func TestWALCrash(t *testing.T) {
 	}
 }
 
+// TestWALCrashRandom crashes the node at random messages of the WAL, tearing
+// the message it was writing at a random byte, and checks that it recovers from
+// each of the crashes.
+func TestWALCrashRandom(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // reproducible from the seed
+
+	for i := 0; i < 5; i++ {
+		consensusReplayConfig := ResetConfig(fmt.Sprintf("%s_%d", t.Name(), i))
+		crashAt := 1 + rng.Intn(30)
+		t.Run(fmt.Sprintf("crash at message %d", crashAt), func(t *testing.T) {
+			walPanicked := make(chan error)
+			crashingWal := &crashingWAL{
+				panicCh:                 walPanicked,
+				heightToStop:            math.MaxInt64,
+				lastPanickedForMsgIndex: crashAt - 1,
+				torn:                    rng,
+			}
+			crashWALOnce(t, consensusReplayConfig, crashingWal, func(dbm.DB, *State, context.Context) {})
+		})
+	}
+}
+
 func crashWALandCheckLiveness(t *testing.T, consensusReplayConfig *cfg.Config,
 	initFn func(dbm.DB, *State, context.Context), heightToStop int64,
 ) {
 	walPanicked := make(chan error)
 	crashingWal := &crashingWAL{panicCh: walPanicked, heightToStop: heightToStop}
 
-	i := 1
-LOOP:
-	for {
+	for i := 1; ; i++ {
 		t.Logf("====== LOOP %d\n", i)
 
-		// create consensus state from a clean slate
-		logger := log.NewNopLogger()
-		blockDB := dbm.NewMemDB()
-		stateDB := blockDB
-		stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-			DiscardABCIResponses: false,
-		})
-		state, err := sm.MakeGenesisStateFromFile(consensusReplayConfig.GenesisFile())
-		require.NoError(t, err)
-		privValidator := loadPrivValidator(consensusReplayConfig)
-		cs := newStateWithConfigAndBlockStore(
-			consensusReplayConfig,
-			state,
-			privValidator,
-			kvstore.NewApplication(),
-			blockDB,
-		)
-		cs.SetLogger(logger)
-
-		// start sending transactions
-		ctx, cancel := context.WithCancel(context.Background())
-		initFn(stateDB, cs, ctx)
-
-		// clean up WAL file from the previous iteration
-		walFile := cs.config.WalFile()
-		os.Remove(walFile)
-
-		// set crashing WAL
-		csWal, err := cs.OpenWAL(walFile)
-		require.NoError(t, err)
-		crashingWal.next = csWal
+		// if we reached the required height, exit
+		err := crashWALOnce(t, consensusReplayConfig, crashingWal, initFn)
+		if _, ok := err.(ReachedHeightToStopError); ok {
+			break
+		}
+	}
+}
 
-		// reset the message counter
-		crashingWal.msgIndex = 1
-		cs.wal = crashingWal
+// crashWALOnce runs a consensus state from a clean slate with crashingWal until
+// it crashes, then makes sure a new one recovers from the WAL and makes a block.
+// It returns the error the WAL crashed with.
+func crashWALOnce(t *testing.T, consensusReplayConfig *cfg.Config,
+	crashingWal *crashingWAL, initFn func(dbm.DB, *State, context.Context),
+) error {
+	// create consensus state from a clean slate
+	logger := log.NewNopLogger()
+	blockDB := dbm.NewMemDB()
+	stateDB := blockDB
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := sm.MakeGenesisStateFromFile(consensusReplayConfig.GenesisFile())
+	require.NoError(t, err)
+	privValidator := loadPrivValidator(consensusReplayConfig)
+	cs := newStateWithConfigAndBlockStore(
+		consensusReplayConfig,
+		state,
+		privValidator,
+		kvstore.NewApplication(),
+		blockDB,
+	)
+	cs.SetLogger(logger)
 
-		// start consensus state
-		err = cs.Start()
-		require.NoError(t, err)
+	// start sending transactions
+	ctx, cancel := context.WithCancel(context.Background())
+	initFn(stateDB, cs, ctx)
 
-		i++
+	// clean up WAL file from the previous iteration
+	walFile := cs.config.WalFile()
+	os.Remove(walFile)
 
-		select {
-		case err := <-walPanicked:
-			t.Logf("WAL panicked: %v", err)
+	// set crashing WAL
+	csWal, err := cs.OpenWAL(walFile)
+	require.NoError(t, err)
+	crashingWal.next = csWal
 
-			// make sure we can make blocks after a crash
-			startNewStateAndWaitForBlock(t, consensusReplayConfig, cs.Height, blockDB, stateStore)
+	// reset the message counter
+	crashingWal.msgIndex = 1
+	cs.wal = crashingWal
 
-			// stop consensus state and transactions sender (initFn)
-			cs.Stop() //nolint:errcheck // Logging this error causes failure
-			cancel()
+	// start consensus state
+	err = cs.Start()
+	require.NoError(t, err)
 
-			// if we reached the required height, exit
-			if _, ok := err.(ReachedHeightToStopError); ok {
-				break LOOP
-			}
-		case <-time.After(10 * time.Second):
-			t.Fatal("WAL did not panic for 10 seconds (check the log)")
-		}
+	select {
+	case err := <-crashingWal.panicCh:
+		t.Logf("WAL panicked: %v", err)
+
+		// make sure we can make blocks after a crash
+		startNewStateAndWaitForBlock(t, consensusReplayConfig, cs.Height, blockDB, stateStore)
+
+		// stop consensus state and transactions sender (initFn)
+		cs.Stop() //nolint:errcheck // Logging this error causes failure
+		cancel()
+		return err
+	case <-time.After(10 * time.Second):
+		t.Fatal("WAL did not panic for 10 seconds (check the log)")
+		return nil
 	}
 }
 
 // crashingWAL is a WAL which crashes or rather simulates a crash during Save
 // (before and after). It remembers a message for which we last panicked
 // (lastPanickedForMsgIndex), so we don't panic for it in subsequent iterations.
+// With torn set, the message it crashes on is torn at a random byte, as if the
+// crash happened in the middle of its write.
 type crashingWAL struct {
 	next         WAL
 	panicCh      chan error
 	heightToStop int64
+	torn         *rand.Rand
 
 	msgIndex                int // current message index
 	lastPanickedForMsgIndex int // last message for which we panicked
@@ -271,6 +307,9 @@ func (w *crashingWAL) Write(m WALMessage) error {
 
 	if w.msgIndex > w.lastPanickedForMsgIndex {
 		w.lastPanickedForMsgIndex = w.msgIndex
+		if w.torn != nil {
+			w.tear(m)
+		}
 		_, file, line, _ := runtime.Caller(1)
 		w.panicCh <- WALWriteError{fmt.Sprintf("failed to write %T to WAL (fileline: %s:%d)", m, file, line)}
 		runtime.Goexit()
@@ -281,6 +320,21 @@ func (w *crashingWAL) Write(m WALMessage) error {
 	return w.next.Write(m)
 }
 
+// tear writes a random prefix of the encoding of m to the WAL, and flushes it.
+func (w *crashingWAL) tear(m WALMessage) {
+	var buf bytes.Buffer
+	if err := NewWALEncoder(&buf).Encode(&TimedWALMessage{Time: time.Now(), Msg: m}); err != nil {
+		panic(err)
+	}
+	group := w.next.(*BaseWAL).Group()
+	if _, err := group.Write(buf.Bytes()[:w.torn.Intn(buf.Len())]); err != nil {
+		panic(err)
+	}
+	if err := group.FlushAndSync(); err != nil {
+		panic(err)
+	}
+}
+
 func (w *crashingWAL) WriteSync(m WALMessage) error {
 	return w.Write(m)
 }
@@ -298,6 +352,72 @@ func (w *crashingWAL) Start() error { return w.next.Start() }
 func (w *crashingWAL) Stop() error  { return w.next.Stop() }
 func (w *crashingWAL) Wait()        { w.next.Wait() }
 
+func TestReplayFile(t *testing.T) {
+	config := ResetConfig(t.Name())
+	defer os.RemoveAll(config.RootDir)
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	// run a node for a few blocks, from a handshake with its app
+	app := kvstore.NewPersistentKVStoreApplication(t.TempDir())
+	blockDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(blockDB, sm.StoreOptions{DiscardABCIResponses: false})
+	blockStore := store.NewBlockStore(blockDB)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	require.NoError(t, NewHandshaker(stateStore, state, blockStore, genDoc).Handshake(proxyApp))
+	require.NoError(t, proxyApp.Stop())
+	state, err = stateStore.Load()
+	require.NoError(t, err)
+
+	cs := newStateWithConfigAndBlockStore(config, state, loadPrivValidator(config), app, blockDB)
+	cs.SetLogger(log.TestingLogger())
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	require.NoError(t, cs.Start())
+	for height := int64(1); height <= 3; height++ {
+		ensureNewBlock(newBlockCh, height)
+	}
+	require.NoError(t, cs.Stop())
+	cs.Wait()
+
+	replay := func(walFile string, height int64) (sm.State, error) {
+		app := kvstore.NewPersistentKVStoreApplication(t.TempDir())
+		return ReplayFile(config.Consensus, genDoc, proxy.NewLocalClientCreator(app), walFile, height,
+			log.TestingLogger())
+	}
+
+	// the replay makes the same blocks
+	walFile := config.Consensus.WalFile()
+	state, err = replay(walFile, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, state.LastBlockHeight)
+	assert.Equal(t, blockStore.LoadBlockMeta(3).BlockID, state.LastBlockID)
+	_, err = replay(walFile, 100)
+	assert.Error(t, err)
+
+	// a WAL which doesn't start at genesis can't be replayed
+	walBody, err := os.ReadFile(walFile)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	dec, enc := NewWALDecoder(bytes.NewReader(walBody)), NewWALEncoder(&buf)
+	_, err = dec.Decode()
+	require.NoError(t, err)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, enc.Encode(msg))
+	}
+	prunedFile := tempWALWithData(buf.Bytes())
+	defer os.Remove(prunedFile)
+	_, err = replay(prunedFile, 1)
+	assert.Error(t, err)
+}
+
 // ------------------------------------------------------------------------------------------
 type testSim struct {
 	GenesisState sm.State
@@ -0,0 +1,127 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrSigningHalted is returned instead of signing once the state refused to
+// sign a vote or a proposal conflicting with one it signed before.
+var ErrSigningHalted = errors.New("signing halted after a conflicting signature was refused")
+
+// ErrConflictingSignature is returned when the state is about to sign a vote or
+// a proposal conflicting with one it signed before, at the same height, round
+// and type, but for another block.
+type ErrConflictingSignature struct {
+	Height  int64
+	Round   int32
+	Type    cmtproto.SignedMsgType
+	Signed  types.BlockID
+	Signing types.BlockID
+}
+
+func (e ErrConflictingSignature) Error() string {
+	return fmt.Sprintf("refusing to sign a %v at %d/%d for %v, %v was signed before",
+		e.Type, e.Height, e.Round, e.Signing, e.Signed)
+}
+
+type signingKey struct {
+	round   int32
+	msgType cmtproto.SignedMsgType
+}
+
+// signingHistory records the votes and the proposals signed by the validator
+// of the node at the latest height, from the WAL on start and from the signing
+// since, for the state not to sign conflicting ones whatever its private
+// validator checks.
+type signingHistory struct {
+	height int64
+	signed map[signingKey]types.BlockID
+}
+
+func (h *signingHistory) record(height int64, round int32, msgType cmtproto.SignedMsgType, blockID types.BlockID) {
+	if height != h.height || h.signed == nil {
+		h.height = height
+		h.signed = make(map[signingKey]types.BlockID)
+	}
+	h.signed[signingKey{round, msgType}] = blockID
+}
+
+func (h *signingHistory) check(height int64, round int32, msgType cmtproto.SignedMsgType, blockID types.BlockID) error {
+	if height != h.height {
+		return nil
+	}
+	signed, ok := h.signed[signingKey{round, msgType}]
+	if !ok || signed.Equals(blockID) {
+		return nil
+	}
+	return ErrConflictingSignature{Height: height, Round: round, Type: msgType, Signed: signed, Signing: blockID}
+}
+
+func (h *signingHistory) recordVote(vote *types.Vote) {
+	h.record(vote.Height, vote.Round, vote.Type, vote.BlockID)
+}
+
+func (h *signingHistory) checkVote(vote *types.Vote) error {
+	return h.check(vote.Height, vote.Round, vote.Type, vote.BlockID)
+}
+
+func (h *signingHistory) recordProposal(proposal *types.Proposal) {
+	h.record(proposal.Height, proposal.Round, cmtproto.ProposalType, proposal.BlockID)
+}
+
+func (h *signingHistory) checkProposal(proposal *types.Proposal) error {
+	return h.check(proposal.Height, proposal.Round, cmtproto.ProposalType, proposal.BlockID)
+}
+
+// loadSigningHistory records the votes and the proposals of the validator of
+// the node at height from the WAL, where it writes them before broadcasting
+// them. A WAL without the #ENDHEIGHT before height leaves the history empty.
+func (cs *State) loadSigningHistory(height int64) error {
+	if cs.privValidatorPubKey == nil {
+		return nil
+	}
+	endHeight := height - 1
+	if height == cs.state.InitialHeight {
+		endHeight = 0
+	}
+	gr, found, err := cs.wal.SearchForEndHeight(endHeight, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	defer gr.Close()
+
+	addr := cs.privValidatorPubKey.Address()
+	dec := NewWALDecoder(gr)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF || IsDataCorruptionError(err) {
+			// the replay repairs the corrupted WAL
+			return nil
+		} else if err != nil {
+			return err
+		}
+		mi, ok := msg.Msg.(msgInfo)
+		if !ok || mi.PeerID != "" {
+			continue
+		}
+		switch m := mi.Msg.(type) {
+		case *ProposalMessage:
+			if m.Proposal.Height == height {
+				cs.signingHistory.recordProposal(m.Proposal)
+			}
+		case *VoteMessage:
+			if m.Vote.Height == height && bytes.Equal(m.Vote.ValidatorAddress, addr) {
+				cs.signingHistory.recordVote(m.Vote)
+			}
+		}
+	}
+}
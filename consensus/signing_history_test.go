@@ -0,0 +1,47 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSigningHistory(t *testing.T) {
+	blockID := func() types.BlockID {
+		return types.BlockID{
+			Hash:          cmtrand.Bytes(tmhash.Size),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(tmhash.Size)},
+		}
+	}
+	signed, other := blockID(), blockID()
+
+	var h signingHistory
+	// nothing signed yet
+	require.NoError(t, h.check(1, 0, cmtproto.PrevoteType, other))
+
+	h.record(1, 0, cmtproto.PrevoteType, signed)
+	// the same block again
+	assert.NoError(t, h.check(1, 0, cmtproto.PrevoteType, signed))
+	// another block at another round or of another type
+	assert.NoError(t, h.check(1, 1, cmtproto.PrevoteType, other))
+	assert.NoError(t, h.check(1, 0, cmtproto.PrecommitType, other))
+
+	// another block at the same height, round and type
+	err := h.check(1, 0, cmtproto.PrevoteType, types.BlockID{})
+	var conflict ErrConflictingSignature
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, signed, conflict.Signed)
+	assert.Equal(t, types.BlockID{}, conflict.Signing)
+
+	// another height
+	assert.NoError(t, h.check(2, 0, cmtproto.PrevoteType, other))
+	h.record(2, 0, cmtproto.ProposalType, other)
+	assert.NoError(t, h.check(1, 0, cmtproto.PrevoteType, types.BlockID{}), "the history of height 1 is gone")
+	assert.Error(t, h.check(2, 0, cmtproto.ProposalType, signed))
+}
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -11,10 +12,13 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto"
+	auto "github.com/tendermint/tendermint/libs/autofile"
 	cmtevents "github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/libs/fail"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
@@ -23,6 +27,8 @@ import (
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tracing"
+	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
@@ -30,10 +36,13 @@ import (
 	cmttime "github.com/tendermint/tendermint/types/time"
 )
 
+var tracer = tracing.Tracer("consensus")
+
 // Consensus sentinel errors
 var (
 	ErrInvalidProposalSignature   = errors.New("error invalid proposal signature")
 	ErrInvalidProposalPOLRound    = errors.New("error invalid proposal POL round")
+	ErrInvalidProposalParityParts = errors.New("error invalid proposal parity parts")
 	ErrAddingVote                 = errors.New("error adding vote")
 	ErrSignatureFoundInPastBlocks = errors.New("found signature from the same key")
 
@@ -46,6 +55,9 @@ var msgQueueSize = 1000
 type msgInfo struct {
 	Msg    Message `json:"msg"`
 	PeerID p2p.ID  `json:"peer_key"`
+	// ReceiveTime is the time the message was received, saved to the WAL so
+	// that the proposals replayed are judged timely as they were at first.
+	ReceiveTime time.Time `json:"receive_time"`
 }
 
 // internally generated messages which may update the state
@@ -71,6 +83,11 @@ type evidencePool interface {
 	ReportConflictingVotes(voteA, voteB *types.Vote)
 }
 
+// interface to the block store pruning in the background
+type asyncBlockPruner interface {
+	PruneBlocksAsync(retainHeight int64) error
+}
+
 // State handles execution of the consensus algorithm.
 // It processes votes and proposals, and upon reaching agreement,
 // commits blocks to the chain and executes them against the application.
@@ -123,13 +140,18 @@ type State struct {
 	replayMode   bool // so we don't log signing errors during replay
 	doWALCatchup bool // determines if we even try to do the catchup
 
+	// the votes and the proposals signed at the latest height, not to sign
+	// conflicting ones, after which the signing is halted
+	signingHistory signingHistory
+	signingHalted  bool
+
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
 	// some functions can be overwritten for testing
 	decideProposal func(height int64, round int32)
 	doPrevote      func(height int64, round int32)
-	setProposal    func(proposal *types.Proposal) error
+	setProposal    func(proposal *types.Proposal, receiveTime time.Time) error
 
 	// closed when we finish shutting down
 	done chan struct{}
@@ -140,6 +162,33 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// keep all the blocks, ignoring the retain height of the application
+	retainAllBlocks bool
+
+	// prunes the blocks in the background if set
+	asyncPruner asyncBlockPruner
+
+	// the states are pruned by a state.PruningService, not with the blocks
+	externalStatePruning bool
+
+	// the txs of the complete proposal blocks are marked as proposed, if set
+	txStatuses *mempl.TxStatuses
+
+	// escalates the timeouts of the steps with the rounds
+	timeoutPolicy TimeoutPolicy
+
+	// the latencies of the votes of the round, for the metrics
+	voteLatencies voteLatencies
+
+	// the parity parts of the erasure coded proposal block, added by the
+	// reactor and used to reconstruct the parts of the block missing
+	parityParts *parityParts
+
+	// span of the current height, whose events are its steps
+	heightSpan       trace.Span
+	heightSpanCtx    context.Context
+	heightSpanHeight int64
 }
 
 // StateOption sets an optional parameter on the State.
@@ -170,6 +219,8 @@ func NewState(
 		evpool:           evpool,
 		evsw:             cmtevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		timeoutPolicy:    TimeoutPolicyFromConfig(config),
+		parityParts:      newParityParts(),
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -211,6 +262,36 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// RetainAllBlocks keeps all the blocks, ignoring the retain height returned by
+// the application on Commit, e.g. on archive nodes.
+func RetainAllBlocks() StateOption {
+	return func(cs *State) { cs.retainAllBlocks = true }
+}
+
+// ExternalStatePruning leaves the pruning of the states to a
+// state.PruningService, instead of pruning them along with the blocks.
+func ExternalStatePruning() StateOption {
+	return func(cs *State) { cs.externalStatePruning = true }
+}
+
+// AsyncBlockPruning schedules the pruning of the blocks below the retain height
+// returned by the application on Commit with pruner, instead of pruning them
+// before moving to the next height.
+func AsyncBlockPruning(pruner asyncBlockPruner) StateOption {
+	return func(cs *State) { cs.asyncPruner = pruner }
+}
+
+// TxStatuses marks the txs of the complete proposal blocks as proposed in s.
+func TxStatuses(s *mempl.TxStatuses) StateOption {
+	return func(cs *State) { cs.txStatuses = s }
+}
+
+// StateTimeoutPolicy escalates the timeouts of the steps with the rounds with
+// policy, instead of the policy of the config.
+func StateTimeoutPolicy(policy TimeoutPolicy) StateOption {
+	return func(cs *State) { cs.timeoutPolicy = policy }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -317,6 +398,11 @@ func (cs *State) OnStart() error {
 	// We may have lost some votes if the process crashed reload from consensus
 	// log to catchup.
 	if cs.doWALCatchup {
+		// load what was signed before the replay, which may sign again
+		if err := cs.loadSigningHistory(cs.Height); err != nil {
+			cs.Logger.Error("failed to load the signing history from the WAL", "err", err)
+		}
+
 		repairAttempted := false
 
 	LOOP:
@@ -421,6 +507,18 @@ func (cs *State) OnStop() {
 	// WAL is stopped in receiveRoutine.
 }
 
+// OnReset implements service.Service. It prepares a stopped state-machine to
+// be started again, e.g. after the node fell back to fast sync.
+func (cs *State) OnReset() error {
+	cs.timeoutTicker = NewTimeoutTicker()
+	cs.timeoutTicker.SetLogger(cs.Logger)
+	// The WAL was closed by receiveRoutine; it is reopened in OnStart.
+	cs.wal = nilWAL{}
+	cs.done = make(chan struct{})
+	cs.doWALCatchup = true
+	return cs.evsw.Reset()
+}
+
 // Wait waits for the the main routine to return.
 // NOTE: be sure to Stop() the event switch and drain
 // any event channels or this may deadlock
@@ -431,7 +529,10 @@ func (cs *State) Wait() {
 // OpenWAL opens a file to log all consensus messages and timeouts for
 // deterministic accountability.
 func (cs *State) OpenWAL(walFile string) (WAL, error) {
-	wal, err := NewWAL(walFile)
+	wal, err := NewWAL(walFile,
+		auto.GroupHeadSizeLimit(cs.config.WalMaxSegmentSize),
+		auto.GroupHeadAgeLimit(cs.config.WalMaxSegmentAge),
+	)
 	if err != nil {
 		cs.Logger.Error("failed to open WAL", "file", walFile, "err", err)
 		return nil, err
@@ -457,9 +558,9 @@ func (cs *State) OpenWAL(walFile string) (WAL, error) {
 // AddVote inputs a vote.
 func (cs *State) AddVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&VoteMessage{vote}, ""}
+		cs.internalMsgQueue <- msgInfo{&VoteMessage{vote}, "", cmttime.Now()}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}
+		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, peerID, cmttime.Now()}
 	}
 
 	// TODO: wait for event?!
@@ -469,9 +570,9 @@ func (cs *State) AddVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 // SetProposal inputs a proposal.
 func (cs *State) SetProposal(proposal *types.Proposal, peerID p2p.ID) error {
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&ProposalMessage{proposal}, ""}
+		cs.internalMsgQueue <- msgInfo{&ProposalMessage{proposal}, "", cmttime.Now()}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&ProposalMessage{proposal}, peerID}
+		cs.peerMsgQueue <- msgInfo{&ProposalMessage{proposal}, peerID, cmttime.Now()}
 	}
 
 	// TODO: wait for event?!
@@ -481,9 +582,9 @@ func (cs *State) SetProposal(proposal *types.Proposal, peerID p2p.ID) error {
 // AddProposalBlockPart inputs a part of the proposal block.
 func (cs *State) AddProposalBlockPart(height int64, round int32, part *types.Part, peerID p2p.ID) error {
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, ""}
+		cs.internalMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, "", cmttime.Now()}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, peerID}
+		cs.peerMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, peerID, cmttime.Now()}
 	}
 
 	// TODO: wait for event?!
@@ -539,6 +640,55 @@ func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
+// timeouts returns the consensus config with the timeouts of the current
+// consensus params.
+func (cs *State) timeouts() *cfg.ConsensusConfig {
+	return timeoutConfig(cs.config, cs.state.ConsensusParams.Timeout)
+}
+
+// proposeTimeout returns the time to wait for a proposal at round.
+func (cs *State) proposeTimeout(round int32) time.Duration {
+	t := cs.timeouts()
+	return cs.timeoutPolicy.Timeout(t.TimeoutPropose, t.TimeoutProposeDelta, round)
+}
+
+// prevoteTimeout returns the time to wait for straggler prevotes at round,
+// after any +2/3 prevotes.
+func (cs *State) prevoteTimeout(round int32) time.Duration {
+	t := cs.timeouts()
+	return cs.timeoutPolicy.Timeout(t.TimeoutPrevote, t.TimeoutPrevoteDelta, round)
+}
+
+// precommitTimeout returns the time to wait for straggler precommits at
+// round, after any +2/3 precommits.
+func (cs *State) precommitTimeout(round int32) time.Duration {
+	t := cs.timeouts()
+	return cs.timeoutPolicy.Timeout(t.TimeoutPrecommit, t.TimeoutPrecommitDelta, round)
+}
+
+// timeoutConfig returns a copy of config with the timeouts of params in place
+// of its own if not 0, but for those it overrides.
+func timeoutConfig(config *cfg.ConsensusConfig, params cmtproto.TimeoutParams) *cfg.ConsensusConfig {
+	c := *config
+	for _, t := range []struct {
+		timeout         *time.Duration
+		param, override time.Duration
+	}{
+		{&c.TimeoutPropose, params.Propose, c.TimeoutProposeOverride},
+		{&c.TimeoutPrevote, params.Prevote, c.TimeoutPrevoteOverride},
+		{&c.TimeoutPrecommit, params.Precommit, c.TimeoutPrecommitOverride},
+		{&c.TimeoutCommit, params.Commit, c.TimeoutCommitOverride},
+	} {
+		switch {
+		case t.override > 0:
+			*t.timeout = t.override
+		case t.param > 0:
+			*t.timeout = t.param
+		}
+	}
+	return &c
+}
+
 // Attempt to schedule a timeout (by sending timeoutInfo on the tickChan)
 func (cs *State) scheduleTimeout(duration time.Duration, height int64, round int32, step cstypes.RoundStepType) {
 	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
@@ -660,13 +810,14 @@ func (cs *State) updateToState(state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(cmttime.Now())
+		cs.StartTime = timeoutConfig(cs.config, state.ConsensusParams.Timeout).Commit(cmttime.Now())
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = timeoutConfig(cs.config, state.ConsensusParams.Timeout).Commit(cs.CommitTime)
 	}
 
 	cs.Validators = validators
 	cs.Proposal = nil
+	cs.ProposalReceiveTime = time.Time{}
 	cs.ProposalBlock = nil
 	cs.ProposalBlockParts = nil
 	cs.LockedRound = -1
@@ -693,10 +844,11 @@ func (cs *State) newStep() {
 	}
 
 	cs.nSteps++
+	cs.traceStep()
 
 	// newStep is called by updateToState in NewState before the eventBus is set!
 	if cs.eventBus != nil {
-		if err := cs.eventBus.PublishEventNewRoundStep(rs); err != nil {
+		if err := cs.eventBus.PublishEventNewRoundStep(cs.RoundStateVotesEvent()); err != nil {
 			cs.Logger.Error("failed publishing new round step", "err", err)
 		}
 
@@ -704,6 +856,20 @@ func (cs *State) newStep() {
 	}
 }
 
+// traceStep records the step as an event of the span of the height, which is
+// started with the first step of the height.
+func (cs *State) traceStep() {
+	if cs.heightSpan == nil || cs.heightSpanHeight != cs.Height {
+		if cs.heightSpan != nil {
+			cs.heightSpan.End()
+		}
+		cs.heightSpanCtx, cs.heightSpan = tracer.Start(context.Background(), "consensus.height",
+			trace.WithAttributes(attribute.Int64("height", cs.Height)))
+		cs.heightSpanHeight = cs.Height
+	}
+	cs.heightSpan.AddEvent(cs.Step.String(), trace.WithAttributes(attribute.Int64("round", int64(cs.Round))))
+}
+
 //-----------------------------------------
 // the main go routines
 
@@ -818,7 +984,7 @@ func (cs *State) handleMsg(mi msgInfo) {
 	case *ProposalMessage:
 		// will not cause transition.
 		// once proposal is set, we can receive block parts
-		err = cs.setProposal(msg.Proposal)
+		err = cs.setProposal(msg.Proposal, mi.ReceiveTime)
 
 	case *BlockPartMessage:
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
@@ -1017,6 +1183,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	} else {
 		logger.Debug("resetting proposal info")
 		cs.Proposal = nil
+		cs.ProposalReceiveTime = time.Time{}
 		cs.ProposalBlock = nil
 		cs.ProposalBlockParts = nil
 	}
@@ -1032,9 +1199,12 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	// we may need an empty "proof" block, and enterPropose immediately.
 	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
 	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
-				cstypes.RoundStepNewRound)
+		if interval := cs.config.EmptyBlocksInterval(); interval > 0 {
+			if cs.config.LazyBlockProduction && !cs.CommitTime.IsZero() {
+				// the max idle interval counts from the last commit
+				interval = cs.CommitTime.Add(interval).Sub(cmttime.Now())
+			}
+			cs.scheduleTimeout(interval, height, round, cstypes.RoundStepNewRound)
 		}
 	} else {
 		cs.enterPropose(height, round)
@@ -1064,6 +1234,9 @@ func (cs *State) needProofBlock(height int64) bool {
 //	after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
 //
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
+// Enter (LazyBlockProduction): after enterNewRound(height,round), once txs are in the mempool,
+//
+//	or after LazyBlockMaxIdleInterval since the last commit
 func (cs *State) enterPropose(height int64, round int32) {
 	logger := cs.Logger.With("height", height, "round", round)
 
@@ -1077,6 +1250,8 @@ func (cs *State) enterPropose(height int64, round int32) {
 
 	logger.Debug("entering propose step", "current", log.NewLazySprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.voteLatencies.startRound(height, round, time.Now())
+
 	defer func() {
 		// Done enterPropose:
 		cs.updateRoundStep(round, cstypes.RoundStepPropose)
@@ -1091,7 +1266,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.proposeTimeout(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1124,6 +1299,21 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}
 }
 
+// isFastPath returns whether the single validator fast path applies: it is
+// enabled and the node is the only validator.
+func (cs *State) isFastPath() bool {
+	return cs.config.SingleValidatorFastPath &&
+		cs.privValidatorPubKey != nil && cs.Validators.Size() == 1 &&
+		cs.Validators.HasAddress(cs.privValidatorPubKey.Address())
+}
+
+// skipTimeoutCommit returns whether the state starts on the next height as soon
+// as it has all the precommits, but in the lazy block production mode, where
+// the min interval between the blocks replaces timeout_commit.
+func (cs *State) skipTimeoutCommit() bool {
+	return !cs.config.LazyBlockProduction && (cs.config.SkipTimeoutCommit || cs.isFastPath())
+}
+
 func (cs *State) isProposer(address []byte) bool {
 	return bytes.Equal(cs.Validators.GetProposer().Address, address)
 }
@@ -1153,16 +1343,36 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	// Make proposal
 	propBlockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
+	if types.IsPBTSEnabled(cs.state.ConsensusParams) {
+		// the proposal is timely for the time of the block it proposes
+		proposal.Timestamp = block.Time
+	}
+	m := parityPartsCount(blockParts.Total(), cs.config.ErasureCodingParity)
+	if cs.config.ErasureCoding && m > 0 && types.IsErasureCodingEnabled(cs.state.ConsensusParams, height) {
+		// commit to the parity parts of the block, for the peers to check them
+		if _, header, err := encodeParityParts(blockParts, m); err == nil {
+			proposal.ParityPartSetHeader = &header
+		} else {
+			cs.Logger.Error("propose step; failed encoding the parity parts", "height", height, "round", round, "err", err)
+		}
+	}
+	if err := cs.checkSigning(cs.signingHistory.checkProposal(proposal)); err != nil {
+		if !cs.replayMode {
+			cs.Logger.Error("propose step; refused to sign proposal", "height", height, "round", round, "err", err)
+		}
+		return
+	}
 	p := proposal.ToProto()
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, p); err == nil {
 		proposal.Signature = p.Signature
+		cs.signingHistory.recordProposal(proposal)
 
 		// send proposal and block parts on internal msg queue
-		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, ""})
+		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, "", cmttime.Now()})
 
 		for i := 0; i < int(blockParts.Total()); i++ {
 			part := blockParts.GetPart(i)
-			cs.sendInternalMessage(msgInfo{&BlockPartMessage{cs.Height, cs.Round, part}, ""})
+			cs.sendInternalMessage(msgInfo{&BlockPartMessage{cs.Height, cs.Round, part}, "", cmttime.Now()})
 		}
 
 		cs.Logger.Debug("signed proposal", "height", height, "round", round, "proposal", proposal)
@@ -1249,6 +1459,8 @@ func (cs *State) enterPrevote(height int64, round int32) {
 
 	logger.Debug("entering prevote step", "current", log.NewLazySprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.recordMissedProposal()
+
 	// Sign and broadcast vote as necessary
 	cs.doPrevote(height, round)
 
@@ -1273,6 +1485,25 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// With proposer-based timestamps, prevote nil if the proposal isn't for the
+	// time of its block, or if a new block wasn't received in time for it.
+	if types.IsPBTSEnabled(cs.state.ConsensusParams) && cs.Proposal != nil {
+		if !cs.Proposal.Timestamp.Equal(cs.ProposalBlock.Time) {
+			logger.Debug("prevote step: proposal timestamp not equal to the block time",
+				"proposal", cs.Proposal.Timestamp, "block", cs.ProposalBlock.Time)
+			cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+		if cs.Proposal.POLRound == -1 &&
+			!cs.Proposal.IsTimely(cs.ProposalReceiveTime, cs.state.ConsensusParams.Synchrony) {
+			logger.Debug("prevote step: proposal is not timely",
+				"timestamp", cs.Proposal.Timestamp, "received", cs.ProposalReceiveTime)
+			cs.metrics.ProposalNotTimely.Add(1)
+			cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+	}
+
 	// Validate proposal block
 	err := cs.blockExec.ValidateBlock(cs.state, cs.ProposalBlock)
 	if err != nil {
@@ -1282,6 +1513,13 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// Refuse the proposal if it censors overdue forced txs, prevote nil.
+	if err := cs.blockExec.ValidateForcedTxs(cs.state, cs.ProposalBlock); err != nil {
+		logger.Error("prevote step: ProposalBlock skips forced txs", "err", err)
+		cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1317,7 +1555,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.prevoteTimeout(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1361,7 +1599,7 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 	}
 
 	// At this point +2/3 prevoted for a particular block or nil.
-	if err := cs.eventBus.PublishEventPolka(cs.RoundStateEvent()); err != nil {
+	if err := cs.eventBus.PublishEventPolka(cs.RoundStateVotesEvent()); err != nil {
 		logger.Error("failed publishing polka", "err", err)
 	}
 
@@ -1476,7 +1714,7 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.precommitTimeout(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
@@ -1510,6 +1748,15 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 		panic("RunActionCommit() expects +2/3 precommits")
 	}
 
+	if err := cs.eventBus.PublishEventCommit(types.EventDataCommit{
+		Height:     height,
+		Round:      commitRound,
+		BlockID:    blockID,
+		Precommits: cs.Votes.Precommits(commitRound).BitArray(),
+	}); err != nil {
+		logger.Error("failed publishing commit", "err", err)
+	}
+
 	// The Locked* fields no longer matter.
 	// Move them over to ProposalBlock if they match the commit hash,
 	// otherwise they'll be cleared in updateToState.
@@ -1601,6 +1848,14 @@ func (cs *State) finalizeCommit(height int64) {
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 
+	ctx, span := tracer.Start(cs.heightSpanCtx, "consensus.finalize_commit", trace.WithAttributes(
+		attribute.Int64("height", height),
+		attribute.Int64("round", int64(cs.CommitRound)),
+		attribute.Int("num_txs", len(block.Txs)),
+	))
+	defer span.End()
+	logger = tracing.Logger(ctx, logger)
+
 	logger.Info(
 		"finalizing commit of block",
 		"hash", log.NewLazyBlockHash(block),
@@ -1617,7 +1872,9 @@ func (cs *State) finalizeCommit(height int64) {
 		// but may differ from the LastCommit included in the next block
 		precommits := cs.Votes.Precommits(cs.CommitRound)
 		seenCommit := precommits.MakeCommit()
+		_, span := tracer.Start(ctx, "blockstore.save_block")
 		cs.blockStore.SaveBlock(block, blockParts, seenCommit)
+		span.End()
 	} else {
 		// Happens during replay if we already saved the block but didn't commit
 		logger.Debug("calling finalizeCommit on already stored block", "height", block.Height)
@@ -1658,7 +1915,8 @@ func (cs *State) finalizeCommit(height int64) {
 		retainHeight int64
 	)
 
-	stateCopy, retainHeight, err = cs.blockExec.ApplyBlock(
+	stateCopy, retainHeight, err = cs.blockExec.ApplyBlockWithContext(
+		ctx,
 		stateCopy,
 		types.BlockID{
 			Hash:          block.Hash(),
@@ -1673,7 +1931,11 @@ func (cs *State) finalizeCommit(height int64) {
 	fail.Fail() // XXX
 
 	// Prune old heights, if requested by ABCI app.
-	if retainHeight > 0 {
+	if retainHeight > 0 && !cs.retainAllBlocks && cs.asyncPruner != nil {
+		if err := cs.asyncPruner.PruneBlocksAsync(retainHeight); err != nil {
+			logger.Error("failed to schedule the pruning of blocks", "retain_height", retainHeight, "err", err)
+		}
+	} else if retainHeight > 0 && !cs.retainAllBlocks {
 		pruned, err := cs.pruneBlocks(retainHeight)
 		if err != nil {
 			logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
@@ -1684,6 +1946,7 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.recordSlowestValidator(cs.CommitRound)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
@@ -1714,6 +1977,9 @@ func (cs *State) pruneBlocks(retainHeight int64) (uint64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("failed to prune block store: %w", err)
 	}
+	if cs.externalStatePruning {
+		return pruned, nil
+	}
 	err = cs.blockExec.Store().PruneStates(base, retainHeight)
 	if err != nil {
 		return 0, fmt.Errorf("failed to prune state database: %w", err)
@@ -1811,7 +2077,9 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 
 //-----------------------------------------------------------------------------
 
-func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
+// defaultSetProposal sets the proposal received at receiveTime, after its
+// validation.
+func (cs *State) defaultSetProposal(proposal *types.Proposal, receiveTime time.Time) error {
 	// Already have one
 	// TODO: possibly catch double proposals
 	if cs.Proposal != nil {
@@ -1829,6 +2097,13 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 		return ErrInvalidProposalPOLRound
 	}
 
+	// The parity parts change the sign bytes of the proposal, which the
+	// validators only agree on once the consensus params enable them.
+	if proposal.ParityPartSetHeader != nil &&
+		!types.IsErasureCodingEnabled(cs.state.ConsensusParams, proposal.Height) {
+		return ErrInvalidProposalParityParts
+	}
+
 	p := proposal.ToProto()
 	// Verify signature
 	if !cs.Validators.GetProposer().PubKey.VerifySignature(
@@ -1839,6 +2114,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	proposal.Signature = p.Signature
 	cs.Proposal = proposal
+	cs.ProposalReceiveTime = receiveTime
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -1847,6 +2123,9 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	}
 
 	cs.Logger.Info("received proposal", "proposal", proposal)
+	if err := cs.eventBus.PublishEventProposalReceived(cs.ProposalReceivedEvent()); err != nil {
+		cs.Logger.Error("failed publishing proposal received", "err", err)
+	}
 	return nil
 }
 
@@ -1887,6 +2166,22 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 	}
 
 	cs.metrics.BlockGossipPartsReceived.With("matches_current", "true").Add(1)
+	if added {
+		if err := cs.eventBus.PublishEventBlockPartReceived(types.EventDataBlockPartReceived{
+			Height:   height,
+			Round:    round,
+			Index:    part.Index,
+			Received: cs.ProposalBlockParts.Count(),
+			Total:    cs.ProposalBlockParts.Total(),
+			Peer:     string(peerID),
+		}); err != nil {
+			cs.Logger.Error("failed publishing block part received", "err", err)
+		}
+		if !cs.ProposalBlockParts.IsComplete() {
+			// the parity parts may have arrived before this part
+			cs.addReconstructedParts()
+		}
+	}
 
 	if cs.ProposalBlockParts.ByteSize() > cs.state.ConsensusParams.Block.MaxBytes {
 		return added, fmt.Errorf("total size of proposal block parts exceeds maximum block bytes (%d > %d)",
@@ -1911,6 +2206,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		}
 
 		cs.ProposalBlock = block
+		cs.txStatuses.SetTxs(block.Txs, mempl.TxStatusProposed, block.Height)
 
 		// NOTE: it's possible to receive complete proposal blocks for future rounds without having the proposal
 		cs.Logger.Info("received complete proposal block", "height", cs.ProposalBlock.Height, "hash", cs.ProposalBlock.Hash())
@@ -2037,7 +2333,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		cs.evsw.FireEvent(types.EventVote, vote)
 
 		// if we can skip timeoutCommit and have all the votes now,
-		if cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
+		if cs.skipTimeoutCommit() && cs.LastCommit.HasAll() {
 			// go straight to new round (skip timeout commit)
 			// cs.scheduleTimeout(time.Duration(0), cs.Height, 0, cstypes.RoundStepNewHeight)
 			cs.enterNewRound(cs.Height, 0)
@@ -2064,6 +2360,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		return added, err
 	}
 	cs.evsw.FireEvent(types.EventVote, vote)
+	cs.recordVoteLatency(vote)
 
 	switch vote.Type {
 	case cmtproto.PrevoteType:
@@ -2161,8 +2458,11 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 			cs.enterPrecommit(height, vote.Round)
 
 			if len(blockID.Hash) != 0 {
+				// the fast path applies if we are the only validator of the
+				// committed block, before the validators are updated
+				skipTimeoutCommit := cs.skipTimeoutCommit()
 				cs.enterCommit(height, vote.Round)
-				if cs.config.SkipTimeoutCommit && precommits.HasAll() {
+				if skipTimeoutCommit && precommits.HasAll() {
 					cs.enterNewRound(cs.Height, 0)
 				}
 			} else {
@@ -2209,14 +2509,45 @@ func (cs *State) signVote(
 		BlockID:          types.BlockID{Hash: hash, PartSetHeader: header},
 	}
 
+	if err := cs.checkSigning(cs.signingHistory.checkVote(vote)); err != nil {
+		return nil, err
+	}
 	v := vote.ToProto()
 	err := cs.privValidator.SignVote(cs.state.ChainID, v)
 	vote.Signature = v.Signature
 	vote.Timestamp = v.Timestamp
+	if err == nil {
+		cs.signingHistory.recordVote(vote)
+	}
 
 	return vote, err
 }
 
+// checkSigning returns ErrSigningHalted once the signing is halted, or else
+// halts it on the conflict err of the signing history, if any. A conflict
+// during the catchup replay doesn't halt it, as the state then steps again
+// through the messages of the WAL, whose signed ones are replayed next.
+func (cs *State) checkSigning(err error) error {
+	if cs.signingHalted {
+		return ErrSigningHalted
+	}
+	if err != nil && !cs.replayMode {
+		cs.Logger.Error("CONSENSUS FAILURE!!! refused to sign conflicting message; halting signing", "err", err)
+		cs.signingHalted = true
+		cs.metrics.SigningHalted.Set(1)
+	}
+	return err
+}
+
+// SigningHalted returns true once the state refused to sign a vote or a
+// proposal conflicting with one it signed before, after which it doesn't sign
+// anything until restarted.
+func (cs *State) SigningHalted() bool {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.signingHalted
+}
+
 func (cs *State) voteTime() time.Time {
 	now := cmttime.Now()
 	minVoteTime := now
@@ -2257,7 +2588,7 @@ func (cs *State) signAddVote(msgType cmtproto.SignedMsgType, hash []byte, header
 	// TODO: pass pubKey to signVote
 	vote, err := cs.signVote(msgType, hash, header)
 	if err == nil {
-		cs.sendInternalMessage(msgInfo{&VoteMessage{vote}, ""})
+		cs.sendInternalMessage(msgInfo{&VoteMessage{vote}, "", cmttime.Now()})
 		cs.Logger.Debug("signed and pushed vote", "height", cs.Height, "round", cs.Round, "vote", vote)
 		return vote
 	}
@@ -2278,6 +2609,24 @@ func (cs *State) updatePrivValidatorPubKey() error {
 	if err != nil {
 		return err
 	}
+
+	// Switch to the next key once a key rotation of the validator took effect.
+	if pv, ok := cs.privValidator.(types.KeyRotatingPrivValidator); ok && cs.Validators != nil &&
+		!cs.Validators.HasAddress(pubKey.Address()) {
+		nextPubKey, err := pv.NextPubKey()
+		if err != nil {
+			return err
+		}
+		if nextPubKey != nil && cs.Validators.HasAddress(nextPubKey.Address()) {
+			if err := pv.RotateKey(); err != nil {
+				return fmt.Errorf("failed to rotate private validator key: %w", err)
+			}
+			cs.Logger.Info("rotated private validator key", "height", cs.Height,
+				"old", pubKey.Address(), "new", nextPubKey.Address())
+			pubKey = nextPubKey
+		}
+	}
+
 	cs.privValidatorPubKey = pubKey
 	return nil
 }
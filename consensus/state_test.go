@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/counter"
+	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
@@ -239,6 +240,42 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+func TestStateProposalParityPartsGated(t *testing.T) {
+	cs1, vss := randState(1)
+	height, round := cs1.Height, cs1.Round
+
+	propBlock, propBlockParts := cs1.createProposalBlock()
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(height, round, -1, blockID)
+	proposal.ParityPartSetHeader = &types.ParityPartSetHeader{
+		Total: 1, Hash: tmhash.Sum([]byte("parity")), LastPartSize: 1,
+	}
+	p := proposal.ToProto()
+	require.NoError(t, vss[0].SignProposal(config.ChainID(), p))
+	proposal.Signature = p.Signature
+
+	// the parity parts change the sign bytes, only once the params enable them
+	assert.ErrorIs(t, cs1.defaultSetProposal(proposal, time.Now()), ErrInvalidProposalParityParts)
+	assert.Nil(t, cs1.Proposal)
+
+	cs1.state.ConsensusParams.Feature.ErasureCodingEnableHeight = height
+	require.NoError(t, cs1.defaultSetProposal(proposal, time.Now()))
+	assert.Equal(t, proposal, cs1.Proposal)
+}
+
+func TestStateProposalReceiveTime(t *testing.T) {
+	cs1, vss := randState(1)
+	height, round := cs1.Height, cs1.Round
+	proposal, _ := decideProposal(cs1, vss[0], height, round)
+
+	// the proposal is received at the time of its message, e.g. replayed from
+	// the WAL, rather than when it is handled
+	receiveTime := time.Now().Add(-time.Minute)
+	cs1.handleMsg(msgInfo{&ProposalMessage{proposal}, "", receiveTime})
+	assert.Equal(t, proposal, cs1.Proposal)
+	assert.Equal(t, receiveTime, cs1.ProposalReceiveTime)
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	cs1, vss := randState(2)
 	cs1.state.ConsensusParams.Block.MaxBytes = 2000
@@ -284,7 +321,7 @@ func TestStateOversizedBlock(t *testing.T) {
 
 	// c1 should log an error with the block part message as it exceeds the consensus params. The
 	// block is not added to cs.ProposalBlock so the node timeouts.
-	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	// and then should send nil prevote and precommit regardless of whether other validators prevote and
 	// precommit on it
@@ -340,6 +377,48 @@ func TestStateFullRound1(t *testing.T) {
 	validateLastPrecommit(t, cs, vss[0], propBlockHash)
 }
 
+func TestStateRoundEvents(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	proposalCh := subscribe(cs.eventBus, types.EventQueryProposalReceived)
+	partCh := subscribe(cs.eventBus, types.EventQueryBlockPartReceived)
+	polkaCh := subscribe(cs.eventBus, types.EventQueryPolka)
+	commitCh := subscribe(cs.eventBus, types.EventQueryCommit)
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+
+	startTestRound(cs, height, round)
+	ensureNewRound(newRoundCh, height, round)
+
+	msg := <-proposalCh
+	proposal := msg.Data().(types.EventDataProposalReceived)
+	assert.Equal(t, height, proposal.Height)
+	assert.Equal(t, round, proposal.Round)
+	assert.EqualValues(t, -1, proposal.POLRound)
+	assert.Equal(t, cs.privValidatorPubKey.Address(), proposal.Proposer.Address)
+
+	msg = <-partCh
+	part := msg.Data().(types.EventDataBlockPartReceived)
+	assert.Equal(t, height, part.Height)
+	assert.EqualValues(t, 1, part.Received)
+	assert.Equal(t, proposal.BlockID.PartSetHeader.Total, part.Total)
+	assert.Empty(t, part.Peer)
+
+	msg = <-polkaCh
+	polka := msg.Data().(types.EventDataRoundState)
+	require.NotEmpty(t, polka.Votes)
+	assert.Equal(t, round, polka.Votes[0].Round)
+	assert.True(t, polka.Votes[0].Prevotes.GetIndex(0))
+	assert.False(t, polka.Votes[0].Precommits.GetIndex(0))
+
+	msg = <-commitCh
+	commit := msg.Data().(types.EventDataCommit)
+	assert.Equal(t, height, commit.Height)
+	assert.Equal(t, round, commit.Round)
+	assert.Equal(t, proposal.BlockID, commit.BlockID)
+	assert.True(t, commit.Precommits.GetIndex(0))
+}
+
 // nil is proposed, so prevote and precommit nil
 func TestStateFullRoundNil(t *testing.T) {
 	cs, vss := randState(1)
@@ -448,7 +527,7 @@ func TestStateLockNoPOL(t *testing.T) {
 
 	// (note we're entering precommit for a second time this round)
 	// but with invalid args. then we enterPrecommitWait, and the timeout to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	///
 
@@ -462,7 +541,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	incrementRound(vs2)
 
 	// now we're on a new round and not the proposer, so wait for timeout
-	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	rs := cs1.GetRoundState()
 
@@ -481,7 +560,7 @@ func TestStateLockNoPOL(t *testing.T) {
 
 	// now we're going to enter prevote again, but with invalid args
 	// and then prevote wait, which should timeout. then wait for precommit
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Prevote(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, prevoteTimeout(cs1, round).Nanoseconds())
 
 	ensurePrecommit(voteCh, height, round) // precommit
 	// the proposed block should still be locked and our precommit added
@@ -494,7 +573,7 @@ func TestStateLockNoPOL(t *testing.T) {
 
 	// (note we're entering precommit for a second time this round, but with invalid args
 	// then we enterPrecommitWait and timeout into NewRound
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // entering new round
 	ensureNewRound(newRoundCh, height, round)
@@ -522,7 +601,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	signAddVotes(cs1, cmtproto.PrevoteType, hash, rs.ProposalBlock.MakePartSet(partSize).Header(), vs2)
 	ensurePrevote(voteCh, height, round)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Prevote(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, prevoteTimeout(cs1, round).Nanoseconds())
 	ensurePrecommit(voteCh, height, round) // precommit
 
 	validatePrecommit(t, cs1, round, 0, vss[0], nil, theBlockHash) // precommit nil but be locked on proposal
@@ -535,7 +614,7 @@ func TestStateLockNoPOL(t *testing.T) {
 		vs2) // NOTE: conflicting precommits at same height
 	ensurePrecommit(voteCh, height, round)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	cs2, _ := randState(2) // needed so generated block is different than locked block
 	// before we time out into new round, set next proposal block
@@ -568,7 +647,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 	ensurePrevote(voteCh, height, round)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Prevote(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, prevoteTimeout(cs1, round).Nanoseconds())
 	ensurePrecommit(voteCh, height, round)
 	validatePrecommit(t, cs1, round, 0, vss[0], nil, theBlockHash) // precommit nil but locked on proposal
 
@@ -642,7 +721,7 @@ func TestStateLockPOLRelock(t *testing.T) {
 	incrementRound(vs2, vs3, vs4)
 
 	// timeout to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 	//XXX: this isnt guaranteed to get there before the timeoutPropose ...
@@ -732,7 +811,7 @@ func TestStateLockPOLUnlock(t *testing.T) {
 	propBlockParts := propBlock.MakePartSet(partSize)
 
 	// timeout to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 	rs = cs1.GetRoundState()
 	lockedBlockHash := rs.LockedBlock.Hash()
 
@@ -827,7 +906,7 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	incrementRound(vs2, vs3, vs4)
 
 	// timeout to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 
@@ -871,7 +950,7 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	incrementRound(vs2, vs3, vs4)
 
 	// timeout to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 	ensureNewRound(newRoundCh, height, round)
@@ -937,7 +1016,7 @@ func TestStateLockPOLSafety1(t *testing.T) {
 
 	// cs1 precommit nil
 	ensurePrecommit(voteCh, height, round)
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	t.Log("### ONTO ROUND 1")
 
@@ -981,7 +1060,7 @@ func TestStateLockPOLSafety1(t *testing.T) {
 
 	signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	incrementRound(vs2, vs3, vs4)
 	round++ // moving to the next round
@@ -994,7 +1073,7 @@ func TestStateLockPOLSafety1(t *testing.T) {
 	*/
 
 	// timeout of propose
-	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	// finish prevote
 	ensurePrevote(voteCh, height, round)
@@ -1079,7 +1158,7 @@ func TestStateLockPOLSafety2(t *testing.T) {
 	incrementRound(vs2, vs3, vs4)
 
 	// timeout of precommit wait to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 	// in round 2 we see the polkad block from round 0
@@ -1154,7 +1233,7 @@ func TestProposeValidBlock(t *testing.T) {
 
 	signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	incrementRound(vs2, vs3, vs4)
 	round++ // moving to the next round
@@ -1164,7 +1243,7 @@ func TestProposeValidBlock(t *testing.T) {
 	t.Log("### ONTO ROUND 2")
 
 	// timeout of propose
-	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	ensurePrevote(voteCh, height, round)
 	validatePrevote(t, cs1, round, vss[0], propBlockHash)
@@ -1187,7 +1266,7 @@ func TestProposeValidBlock(t *testing.T) {
 	ensureNewRound(newRoundCh, height, round)
 	t.Log("### ONTO ROUND 3")
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 
@@ -1241,7 +1320,7 @@ func TestSetValidBlockOnDelayedPrevote(t *testing.T) {
 	// vs3 send prevote nil
 	signAddVotes(cs1, cmtproto.PrevoteType, nil, types.PartSetHeader{}, vs3)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Prevote(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, prevoteTimeout(cs1, round).Nanoseconds())
 
 	ensurePrecommit(voteCh, height, round)
 	// we should have precommitted
@@ -1291,7 +1370,7 @@ func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 	startTestRound(cs1, cs1.Height, round)
 	ensureNewRound(newRoundCh, height, round)
 
-	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	ensurePrevote(voteCh, height, round)
 	validatePrevote(t, cs1, round, vss[0], nil)
@@ -1304,7 +1383,7 @@ func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 	signAddVotes(cs1, cmtproto.PrevoteType, propBlockHash, propBlockParts.Header(), vs2, vs3, vs4)
 	ensureNewValidBlock(validBlockCh, height, round)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Prevote(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, prevoteTimeout(cs1, round).Nanoseconds())
 
 	ensurePrecommit(voteCh, height, round)
 	validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
@@ -1314,6 +1393,10 @@ func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 	}
 
 	ensureNewProposal(proposalCh, height, round)
+	// the state yields its lock between the last block part and its handling
+	require.Eventually(t, func() bool {
+		return cs1.GetRoundState().ValidRound == round
+	}, time.Second, 10*time.Millisecond)
 	rs := cs1.GetRoundState()
 
 	assert.True(t, bytes.Equal(rs.ValidBlock.Hash(), propBlockHash))
@@ -1338,7 +1421,7 @@ func TestWaitingTimeoutOnNilPolka(t *testing.T) {
 
 	signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 	ensureNewRound(newRoundCh, height, round+1)
 }
 
@@ -1372,7 +1455,7 @@ func TestWaitingTimeoutProposeOnNewRound(t *testing.T) {
 	rs := cs1.GetRoundState()
 	assert.True(t, rs.Step == cstypes.RoundStepPropose) // P0 does not prevote before timeoutPropose expires
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	ensurePrevote(voteCh, height, round)
 	validatePrevote(t, cs1, round, vss[0], nil)
@@ -1408,7 +1491,7 @@ func TestRoundSkipOnNilPolkaFromHigherRound(t *testing.T) {
 	ensurePrecommit(voteCh, height, round)
 	validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
 
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 	ensureNewRound(newRoundCh, height, round)
@@ -1436,7 +1519,7 @@ func TestWaitTimeoutProposeOnNilPolkaForTheCurrentRound(t *testing.T) {
 	incrementRound(vss[1:]...)
 	signAddVotes(cs1, cmtproto.PrevoteType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
-	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height, round, proposeTimeout(cs1, round).Nanoseconds())
 
 	ensurePrevote(voteCh, height, round)
 	validatePrevote(t, cs1, round, vss[0], nil)
@@ -1584,7 +1667,7 @@ func TestStartNextHeightCorrectlyAfterTimeout(t *testing.T) {
 
 	cs1.txNotifier.(*fakeTxNotifier).Notify()
 
-	ensureNewTimeout(timeoutProposeCh, height+1, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNewTimeout(timeoutProposeCh, height+1, round, proposeTimeout(cs1, round).Nanoseconds())
 	rs = cs1.GetRoundState()
 	assert.False(
 		t,
@@ -1775,7 +1858,7 @@ func TestStateHalt1(t *testing.T) {
 	incrementRound(vs2, vs3, vs4)
 
 	// timeout to new round
-	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+	ensureNewTimeout(timeoutWaitCh, height, round, precommitTimeout(cs1, round).Nanoseconds())
 
 	round++ // moving to the next round
 
@@ -1815,26 +1898,26 @@ func TestStateOutputsBlockPartsStats(t *testing.T) {
 	}
 
 	cs.ProposalBlockParts = types.NewPartSetFromHeader(parts.Header())
-	cs.handleMsg(msgInfo{msg, peer.ID()})
+	cs.handleMsg(msgInfo{msg, peer.ID(), time.Time{}})
 
 	statsMessage := <-cs.statsMsgQueue
 	require.Equal(t, msg, statsMessage.Msg, "")
 	require.Equal(t, peer.ID(), statsMessage.PeerID, "")
 
 	// sending the same part from different peer
-	cs.handleMsg(msgInfo{msg, "peer2"})
+	cs.handleMsg(msgInfo{msg, "peer2", time.Time{}})
 
 	// sending the part with the same height, but different round
 	msg.Round = 1
-	cs.handleMsg(msgInfo{msg, peer.ID()})
+	cs.handleMsg(msgInfo{msg, peer.ID(), time.Time{}})
 
 	// sending the part from the smaller height
 	msg.Height = 0
-	cs.handleMsg(msgInfo{msg, peer.ID()})
+	cs.handleMsg(msgInfo{msg, peer.ID(), time.Time{}})
 
 	// sending the part from the bigger height
 	msg.Height = 3
-	cs.handleMsg(msgInfo{msg, peer.ID()})
+	cs.handleMsg(msgInfo{msg, peer.ID(), time.Time{}})
 
 	select {
 	case <-cs.statsMsgQueue:
@@ -1854,20 +1937,20 @@ func TestStateOutputVoteStats(t *testing.T) {
 	vote := signVote(vss[1], cmtproto.PrecommitType, randBytes, types.PartSetHeader{})
 
 	voteMessage := &VoteMessage{vote}
-	cs.handleMsg(msgInfo{voteMessage, peer.ID()})
+	cs.handleMsg(msgInfo{voteMessage, peer.ID(), time.Time{}})
 
 	statsMessage := <-cs.statsMsgQueue
 	require.Equal(t, voteMessage, statsMessage.Msg, "")
 	require.Equal(t, peer.ID(), statsMessage.PeerID, "")
 
 	// sending the same part from different peer
-	cs.handleMsg(msgInfo{&VoteMessage{vote}, "peer2"})
+	cs.handleMsg(msgInfo{&VoteMessage{vote}, "peer2", time.Time{}})
 
 	// sending the vote for the bigger height
 	incrementHeight(vss[1])
 	vote = signVote(vss[1], cmtproto.PrecommitType, randBytes, types.PartSetHeader{})
 
-	cs.handleMsg(msgInfo{&VoteMessage{vote}, peer.ID()})
+	cs.handleMsg(msgInfo{&VoteMessage{vote}, peer.ID(), time.Time{}})
 
 	select {
 	case <-cs.statsMsgQueue:
@@ -1897,6 +1980,39 @@ func TestSignSameVoteTwice(t *testing.T) {
 	require.Equal(t, vote, vote2)
 }
 
+// 1 vals, the WAL holds a prevote of the validator at height 1, round 0 for an
+// unknown block, signed before a crash. The state restarts, prevotes nil at
+// round 0 as it doesn't get the block, refuses to sign it and halts the signing.
+func TestStateHaltsSigningOnConflict(t *testing.T) {
+	cs1, vss := randState(1)
+	height, round := cs1.Height, cs1.Round
+
+	vs1 := vss[0]
+	vs1.Height = height
+	randBytes := cmtrand.Bytes(tmhash.Size)
+	prevote := signVote(vs1, cmtproto.PrevoteType, randBytes,
+		types.PartSetHeader{Total: 1, Hash: randBytes})
+	wal, err := NewWAL(cs1.config.WalFile())
+	require.NoError(t, err)
+	require.NoError(t, wal.Start())
+	require.NoError(t, wal.WriteSync(msgInfo{&VoteMessage{prevote}, "", time.Time{}}))
+	require.NoError(t, wal.Stop())
+	wal.Wait()
+
+	require.NoError(t, cs1.Start())
+	defer cs1.Stop() //nolint:errcheck // ignore for tests
+
+	require.Eventually(t, cs1.SigningHalted, 5*time.Second, 10*time.Millisecond)
+
+	// the prevote is still the one of the WAL, and nothing was precommitted
+	rs := cs1.GetRoundState()
+	assert.Equal(t, height, rs.Height)
+	vote := rs.Votes.Prevotes(round).GetByIndex(0)
+	require.NotNil(t, vote)
+	assert.Equal(t, prevote.BlockID, vote.BlockID)
+	assert.Nil(t, rs.Votes.Precommits(round).GetByIndex(0))
+}
+
 // subscribe subscribes test client to the given query and returns a channel with cap = 1.
 func subscribe(eventBus *types.EventBus, q cmtpubsub.Query) <-chan cmtpubsub.Message {
 	sub, err := eventBus.Subscribe(context.Background(), testSubscriber, q)
@@ -1914,3 +2030,71 @@ func subscribeUnBuffered(eventBus *types.EventBus, q cmtpubsub.Query) <-chan cmt
 	}
 	return sub.Out()
 }
+
+func TestStateTimeoutParams(t *testing.T) {
+	config := cfg.TestConsensusConfig()
+	config.TimeoutPrecommitOverride = 7 * time.Second
+	params := cmtproto.TimeoutParams{
+		Propose:   5 * time.Second,
+		Precommit: 6 * time.Second,
+	}
+
+	// the timeouts of the params replace those of the config if not 0, but
+	// for those overridden locally
+	c := timeoutConfig(config, params)
+	assert.Equal(t, 5*time.Second, c.TimeoutPropose)
+	assert.Equal(t, config.TimeoutPrevote, c.TimeoutPrevote)
+	assert.Equal(t, 7*time.Second, c.TimeoutPrecommit)
+	now := time.Now()
+	assert.Equal(t, config.Commit(now), c.Commit(now))
+	assert.Equal(t, cfg.TestConsensusConfig().TimeoutPropose, config.TimeoutPropose, "the config is copied")
+
+	// and are escalated by the timeout policy
+	cs, _ := randState(1)
+	cs.state.ConsensusParams.Timeout = params
+	assert.Equal(t, 5*time.Second, cs.proposeTimeout(0))
+	assert.Equal(t, 5*time.Second+config.TimeoutProposeDelta, cs.proposeTimeout(1))
+	assert.Equal(t, config.TimeoutPrevote+config.TimeoutPrevoteDelta, cs.prevoteTimeout(1))
+	assert.Equal(t, 6*time.Second, cs.precommitTimeout(0))
+}
+
+func TestStateProposerBasedTimestamps(t *testing.T) {
+	cs1, vss := randState(2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+	cs1.state.ConsensusParams.Synchrony = cmtproto.SynchronyParams{
+		Precision:    500 * time.Millisecond,
+		MessageDelay: 2 * time.Second,
+	}
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	// the time of the block is the time of the proposer, not the genesis time
+	propBlock, _ := cs1.createProposalBlock()
+	assert.True(t, propBlock.Time.After(cs1.state.LastBlockTime))
+	assert.WithinDuration(t, time.Now(), propBlock.Time, time.Second)
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	// and propose a block an hour ahead of the clocks
+	propBlock.Time = propBlock.Time.Add(time.Hour)
+	propBlockParts := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	proposal.Timestamp = propBlock.Time
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(config.ChainID(), p))
+	proposal.Signature = p.Signature
+	require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+
+	startTestRound(cs1, height, round)
+	ensureProposal(proposalCh, height, round, blockID)
+
+	// the block is valid, but the proposal isn't timely: prevote nil
+	require.NoError(t, cs1.blockExec.ValidateBlock(cs1.state, propBlock))
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+}
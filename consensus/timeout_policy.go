@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"math"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// TimeoutPolicy escalates the timeouts of the propose, prevote and precommit
+// steps with the rounds of a height, for the validators to catch up with each
+// other when the rounds fail.
+type TimeoutPolicy interface {
+	// Timeout returns the timeout of a step at round, from its timeout and
+	// delta, e.g. timeout_propose and timeout_propose_delta.
+	Timeout(timeout, delta time.Duration, round int32) time.Duration
+}
+
+// LinearTimeouts increases the timeouts by delta each round, the default.
+type LinearTimeouts struct{}
+
+var _ TimeoutPolicy = LinearTimeouts{}
+
+// Timeout implements TimeoutPolicy.
+func (LinearTimeouts) Timeout(timeout, delta time.Duration, round int32) time.Duration {
+	if round > 0 && delta > (math.MaxInt64-timeout)/time.Duration(round) {
+		return math.MaxInt64
+	}
+	return timeout + delta*time.Duration(round)
+}
+
+// ExponentialTimeouts doubles the increase of the timeouts each round, from
+// delta: timeout + delta * (2^round - 1).
+type ExponentialTimeouts struct{}
+
+var _ TimeoutPolicy = ExponentialTimeouts{}
+
+// Timeout implements TimeoutPolicy.
+func (ExponentialTimeouts) Timeout(timeout, delta time.Duration, round int32) time.Duration {
+	if round <= 0 || delta == 0 {
+		return timeout
+	}
+	if round >= 63 || delta > (math.MaxInt64-timeout)/(1<<round-1) {
+		return math.MaxInt64
+	}
+	return timeout + delta*(1<<round-1)
+}
+
+// CappedTimeouts caps the timeouts of its policy at Max, but never below the
+// timeout of round 0, e.g. one set by the consensus params.
+type CappedTimeouts struct {
+	TimeoutPolicy
+	Max time.Duration
+}
+
+var _ TimeoutPolicy = CappedTimeouts{}
+
+// Timeout implements TimeoutPolicy.
+func (p CappedTimeouts) Timeout(timeout, delta time.Duration, round int32) time.Duration {
+	t := p.TimeoutPolicy.Timeout(timeout, delta, round)
+	switch {
+	case t <= p.Max:
+		return t
+	case timeout > p.Max:
+		return timeout
+	default:
+		return p.Max
+	}
+}
+
+// TimeoutPolicyFromConfig returns the policy of timeout_escalation, capped at
+// timeout_escalation_max if not 0.
+func TimeoutPolicyFromConfig(config *cfg.ConsensusConfig) TimeoutPolicy {
+	var policy TimeoutPolicy = LinearTimeouts{}
+	if config.TimeoutEscalation == cfg.TimeoutEscalationExponential {
+		policy = ExponentialTimeouts{}
+	}
+	if config.TimeoutEscalationMax > 0 {
+		policy = CappedTimeouts{TimeoutPolicy: policy, Max: config.TimeoutEscalationMax}
+	}
+	return policy
+}
@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestTimeoutPolicies(t *testing.T) {
+	const (
+		timeout = 3 * time.Second
+		delta   = 500 * time.Millisecond
+	)
+	capped := CappedTimeouts{TimeoutPolicy: ExponentialTimeouts{}, Max: 10 * time.Second}
+
+	testCases := []struct {
+		round                       int32
+		linear, exponential, capped time.Duration
+	}{
+		{0, timeout, timeout, timeout},
+		{1, 3500 * time.Millisecond, 3500 * time.Millisecond, 3500 * time.Millisecond},
+		{2, 4 * time.Second, 4500 * time.Millisecond, 4500 * time.Millisecond},
+		{3, 4500 * time.Millisecond, 6500 * time.Millisecond, 6500 * time.Millisecond},
+		{5, 5500 * time.Millisecond, 18500 * time.Millisecond, 10 * time.Second},
+		{100, 53 * time.Second, math.MaxInt64, 10 * time.Second},
+		{math.MaxInt32, timeout + delta*math.MaxInt32, math.MaxInt64, 10 * time.Second},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.linear, LinearTimeouts{}.Timeout(timeout, delta, tc.round), "linear %d", tc.round)
+		assert.Equal(t, tc.exponential, ExponentialTimeouts{}.Timeout(timeout, delta, tc.round), "exponential %d", tc.round)
+		assert.Equal(t, tc.capped, capped.Timeout(timeout, delta, tc.round), "capped %d", tc.round)
+	}
+
+	// the cap doesn't lower the timeout of round 0, e.g. set by the params
+	assert.Equal(t, 20*time.Second, capped.Timeout(20*time.Second, delta, 0))
+	assert.Equal(t, 20*time.Second, capped.Timeout(20*time.Second, delta, 3))
+
+	// the timeouts saturate rather than overflow
+	assert.EqualValues(t, math.MaxInt64, LinearTimeouts{}.Timeout(timeout, math.MaxInt64/2, 3))
+	assert.EqualValues(t, math.MaxInt64, ExponentialTimeouts{}.Timeout(timeout, math.MaxInt64/2, 2))
+}
+
+func TestTimeoutPolicyFromConfig(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	assert.Equal(t, LinearTimeouts{}, TimeoutPolicyFromConfig(config))
+
+	config.TimeoutEscalation = cfg.TimeoutEscalationExponential
+	assert.Equal(t, ExponentialTimeouts{}, TimeoutPolicyFromConfig(config))
+
+	config.TimeoutEscalationMax = time.Minute
+	assert.Equal(t, CappedTimeouts{TimeoutPolicy: ExponentialTimeouts{}, Max: time.Minute},
+		TimeoutPolicyFromConfig(config))
+}
+
+type timeoutPolicyFunc func(timeout, delta time.Duration, round int32) time.Duration
+
+func (f timeoutPolicyFunc) Timeout(timeout, delta time.Duration, round int32) time.Duration {
+	return f(timeout, delta, round)
+}
+
+func TestStateTimeoutPolicy(t *testing.T) {
+	cs1, _ := randState(1)
+	rounds := make(chan int32, 1)
+	StateTimeoutPolicy(timeoutPolicyFunc(func(timeout, delta time.Duration, round int32) time.Duration {
+		select {
+		case rounds <- round:
+		default:
+		}
+		return LinearTimeouts{}.Timeout(timeout, delta, round)
+	}))(cs1)
+
+	startTestRound(cs1, cs1.Height, 0)
+	defer cs1.Stop() //nolint:errcheck // ignore for tests
+
+	select {
+	case round := <-rounds:
+		require.EqualValues(t, 0, round)
+	case <-time.After(ensureTimeout):
+		t.Fatal("the timeout policy of the state wasn't used")
+	}
+}
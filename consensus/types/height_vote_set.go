@@ -258,6 +258,23 @@ func (hvs *HeightVoteSet) toAllRoundVotes() []roundVotes {
 	return allVotes
 }
 
+// RoundVotes returns the bit arrays of the prevotes and of the precommits of
+// the rounds from 0 to the max tracked round.
+func (hvs *HeightVoteSet) RoundVotes() []types.RoundVotes {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+	totalRounds := hvs.round + 1
+	votes := make([]types.RoundVotes, totalRounds)
+	for round := int32(0); round < totalRounds; round++ {
+		votes[round] = types.RoundVotes{
+			Round:      round,
+			Prevotes:   hvs.roundVoteSets[round].Prevotes.BitArray(),
+			Precommits: hvs.roundVoteSets[round].Precommits.BitArray(),
+		}
+	}
+	return votes
+}
+
 type roundVotes struct {
 	Round              int32    `json:"round"`
 	Prevotes           []string `json:"prevotes"`
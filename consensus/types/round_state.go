@@ -91,6 +91,9 @@ type RoundState struct {
 	LastCommit                *types.VoteSet      `json:"last_commit"`  // Last precommits at Height-1
 	LastValidators            *types.ValidatorSet `json:"last_validators"`
 	TriggeredTimeoutPrecommit bool                `json:"triggered_timeout_precommit"`
+
+	// Subjective time when the Proposal was received, for proposer-based timestamps
+	ProposalReceiveTime time.Time `json:"proposal_receive_time"`
 }
 
 // Compressed version of the RoundState for use in RPC
@@ -170,6 +173,32 @@ func (rs *RoundState) RoundStateEvent() types.EventDataRoundState {
 	}
 }
 
+// RoundStateVotesEvent returns the H/R/S of the RoundState, with the votes of
+// its rounds, as an event.
+func (rs *RoundState) RoundStateVotesEvent() types.EventDataRoundState {
+	event := rs.RoundStateEvent()
+	event.Votes = rs.Votes.RoundVotes()
+	return event
+}
+
+// ProposalReceivedEvent returns information about the proposal as an event.
+func (rs *RoundState) ProposalReceivedEvent() types.EventDataProposalReceived {
+	addr := rs.Validators.GetProposer().Address
+	idx, _ := rs.Validators.GetByAddress(addr)
+
+	return types.EventDataProposalReceived{
+		Height:    rs.Proposal.Height,
+		Round:     rs.Proposal.Round,
+		POLRound:  rs.Proposal.POLRound,
+		BlockID:   rs.Proposal.BlockID,
+		Timestamp: rs.Proposal.Timestamp,
+		Proposer: types.ValidatorInfo{
+			Address: addr,
+			Index:   idx,
+		},
+	}
+}
+
 // String returns a string
 func (rs *RoundState) String() string {
 	return rs.StringIndented("")
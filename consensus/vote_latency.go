@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"time"
+
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// voteLatencies tracks the time the node takes to receive the votes of the
+// validators, from the start of the propose step of the round, for the metrics
+// to attribute the slow rounds to the validators.
+type voteLatencies struct {
+	height int64
+	round  int32
+	start  time.Time
+
+	// the validator whose precommit was received last in the round
+	slowest      types.Address
+	slowestDelay time.Duration
+
+	// the validator reported last as the slowest one
+	reported string
+}
+
+// startRound starts measuring the latencies of the votes of a round at now.
+func (l *voteLatencies) startRound(height int64, round int32, now time.Time) {
+	*l = voteLatencies{height: height, round: round, start: now, reported: l.reported}
+}
+
+// observe returns the latency of vote, received at now, if it is a vote of the
+// round measured.
+func (l *voteLatencies) observe(vote *types.Vote, now time.Time) (time.Duration, bool) {
+	if l.start.IsZero() || vote.Height != l.height || vote.Round != l.round {
+		return 0, false
+	}
+	d := now.Sub(l.start)
+	if vote.Type == cmtproto.PrecommitType && (l.slowest == nil || d >= l.slowestDelay) {
+		l.slowest, l.slowestDelay = vote.ValidatorAddress, d
+	}
+	return d, true
+}
+
+// recordVoteLatency records the latency of vote, received now, in the metrics.
+func (cs *State) recordVoteLatency(vote *types.Vote) {
+	d, ok := cs.voteLatencies.observe(vote, time.Now())
+	if !ok {
+		return
+	}
+	label := []string{"validator_address", vote.ValidatorAddress.String()}
+	switch vote.Type {
+	case cmtproto.PrevoteType:
+		cs.metrics.ValidatorPrevoteDelay.With(label...).Observe(d.Seconds())
+	case cmtproto.PrecommitType:
+		cs.metrics.ValidatorPrecommitDelay.With(label...).Observe(d.Seconds())
+	}
+}
+
+// recordSlowestValidator reports the validator whose precommit was received
+// last in the round of the commit, and resets the one reported before.
+func (cs *State) recordSlowestValidator(round int32) {
+	l := &cs.voteLatencies
+	if l.slowest == nil || l.height != cs.Height || l.round != round {
+		return
+	}
+	address := l.slowest.String()
+	if l.reported != "" && l.reported != address {
+		cs.metrics.SlowestValidator.With("validator_address", l.reported).Set(0)
+	}
+	cs.metrics.SlowestValidator.With("validator_address", address).Set(l.slowestDelay.Seconds())
+	l.reported = address
+}
+
+// recordMissedProposal counts the proposal of the round as missed by its
+// proposer, when prevoting without its block.
+func (cs *State) recordMissedProposal() {
+	if cs.ProposalBlock != nil {
+		return
+	}
+	proposer := cs.Validators.GetProposer()
+	if proposer == nil {
+		return
+	}
+	cs.metrics.ValidatorMissedProposals.With("validator_address", proposer.Address.String()).Add(1)
+}
@@ -0,0 +1,75 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// labeledGauge records the values of a gauge by validator_address.
+type labeledGauge struct {
+	values  map[string]float64
+	address string
+}
+
+func (g labeledGauge) With(labelValues ...string) metrics.Gauge {
+	return labeledGauge{values: g.values, address: labelValues[1]}
+}
+
+func (g labeledGauge) Set(value float64) { g.values[g.address] = value }
+
+func (g labeledGauge) Add(delta float64) { g.values[g.address] += delta }
+
+func TestVoteLatencies(t *testing.T) {
+	vote := func(msgType cmtproto.SignedMsgType, height int64, round int32, address types.Address) *types.Vote {
+		return &types.Vote{Type: msgType, Height: height, Round: round, ValidatorAddress: address}
+	}
+	addr1, addr2 := types.Address(cmtrand.Bytes(tmhash.TruncatedSize)), types.Address(cmtrand.Bytes(tmhash.TruncatedSize))
+	start := time.Now()
+
+	var l voteLatencies
+	_, ok := l.observe(vote(cmtproto.PrevoteType, 1, 0, addr1), start)
+	require.False(t, ok, "no round measured yet")
+
+	l.startRound(1, 0, start)
+	d, ok := l.observe(vote(cmtproto.PrevoteType, 1, 0, addr1), start.Add(time.Second))
+	require.True(t, ok)
+	assert.Equal(t, time.Second, d)
+	_, ok = l.observe(vote(cmtproto.PrevoteType, 1, 1, addr1), start.Add(time.Second))
+	assert.False(t, ok, "another round")
+	_, ok = l.observe(vote(cmtproto.PrevoteType, 2, 0, addr1), start.Add(time.Second))
+	assert.False(t, ok, "another height")
+	assert.Nil(t, l.slowest, "prevotes don't count")
+
+	_, ok = l.observe(vote(cmtproto.PrecommitType, 1, 0, addr2), start.Add(3*time.Second))
+	require.True(t, ok)
+	_, ok = l.observe(vote(cmtproto.PrecommitType, 1, 0, addr1), start.Add(2*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, addr2, l.slowest)
+	assert.Equal(t, 3*time.Second, l.slowestDelay)
+
+	// the slowest validator is reported, and the one reported before reset
+	gauge := labeledGauge{values: make(map[string]float64)}
+	cs := &State{metrics: NopMetrics(), voteLatencies: l}
+	cs.metrics.SlowestValidator = gauge
+	cs.Height = 1
+	cs.recordSlowestValidator(0)
+	assert.Equal(t, map[string]float64{addr2.String(): 3}, gauge.values)
+
+	cs.Height = 2
+	cs.voteLatencies.startRound(2, 0, start)
+	_, ok = cs.voteLatencies.observe(vote(cmtproto.PrecommitType, 2, 0, addr1), start.Add(time.Second))
+	require.True(t, ok)
+	cs.recordSlowestValidator(1)
+	assert.Equal(t, map[string]float64{addr2.String(): 3}, gauge.values, "not the round of the commit")
+	cs.recordSlowestValidator(0)
+	assert.Equal(t, map[string]float64{addr1.String(): 1, addr2.String(): 0}, gauge.values)
+}
@@ -82,6 +82,11 @@ type BaseWAL struct {
 
 	flushTicker   *time.Ticker
 	flushInterval time.Duration
+
+	// the indexes of the files of the group holding the last two
+	// #ENDHEIGHTs written, the files before the first being removed
+	endHeightIndexes [2]int
+	endHeights       int
 }
 
 var _ WAL = &BaseWAL{}
@@ -186,15 +191,39 @@ func (wal *BaseWAL) Write(msg WALMessage) error {
 		return nil
 	}
 
+	// the index is taken before the write, so that it is the file of the
+	// message or an earlier one if the group rotates meanwhile
+	index := wal.group.MaxIndex()
 	if err := wal.enc.Encode(&TimedWALMessage{cmttime.Now(), msg}); err != nil {
 		wal.Logger.Error("Error writing msg to consensus wal. WARNING: recover may not be possible for the current height",
 			"err", err, "msg", msg)
 		return err
 	}
 
+	if _, ok := msg.(EndHeightMessage); ok {
+		wal.pruneBefore(index)
+	}
 	return nil
 }
 
+// pruneBefore removes the files of the group before the one holding the
+// #ENDHEIGHT before the one just written at index: recovering from a crash
+// only replays the messages after the #ENDHEIGHT of the last block, which may
+// be the one before if the crash happened while committing a block.
+func (wal *BaseWAL) pruneBefore(index int) {
+	wal.endHeightIndexes[0], wal.endHeightIndexes[1] = wal.endHeightIndexes[1], index
+	wal.endHeights++
+	if wal.endHeights < 2 || wal.endHeightIndexes[0] <= wal.group.MinIndex() {
+		return
+	}
+	removed, err := wal.group.RemoveBefore(wal.endHeightIndexes[0])
+	if err != nil {
+		wal.Logger.Error("Failed to remove the old files of the WAL", "err", err)
+		return
+	}
+	wal.Logger.Debug("Removed the old files of the WAL", "bytes", removed)
+}
+
 // WriteSync is called when we receive a msg from ourselves
 // so that we write to disk before sending signed messages.
 // NOTE: calls fsync()
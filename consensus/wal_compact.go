@@ -0,0 +1,120 @@
+package consensus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	auto "github.com/tendermint/tendermint/libs/autofile"
+)
+
+// CompactWAL rewrites the WAL of a stopped node, at walFile, into a single
+// file holding the messages from its #ENDHEIGHT before the last one, which is
+// all that recovering from a crash needs, and removes its other files. It
+// returns the number of bytes saved. A WAL with less than two #ENDHEIGHTs is
+// left as is.
+//
+// The new WAL is written to a temporary file first, which replaces the head of
+// the WAL before the other files are removed, so that the messages aren't lost
+// if the command stops meanwhile.
+func CompactWAL(walFile string) (int64, error) {
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return 0, err
+	}
+	info := group.ReadGroupInfo()
+
+	// find the #ENDHEIGHT before the last one, counting the messages
+	var (
+		numMsgs    int
+		endHeights [2]int
+		found      int
+	)
+	err = readWAL(group, info.MinIndex, func(msg *TimedWALMessage) error {
+		if _, ok := msg.Msg.(EndHeightMessage); ok {
+			endHeights[0], endHeights[1] = endHeights[1], numMsgs
+			found++
+		}
+		numMsgs++
+		return nil
+	})
+	if err != nil {
+		group.Close()
+		return 0, err
+	}
+	if found < 2 || (endHeights[0] == 0 && info.MinIndex == info.MaxIndex) {
+		group.Close()
+		return 0, nil
+	}
+
+	// then write the messages from it to the temporary file
+	tmpPath := walFile + ".compact"
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		group.Close()
+		return 0, err
+	}
+	defer os.Remove(tmpPath) //nolint:errcheck // it is gone once renamed
+	w := bufio.NewWriter(tmp)
+	enc := NewWALEncoder(w)
+	i := 0
+	err = readWAL(group, info.MinIndex, func(msg *TimedWALMessage) error {
+		i++
+		if i <= endHeights[0] {
+			return nil
+		}
+		return enc.Encode(msg)
+	})
+	group.Close()
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmpPath, walFile); err != nil {
+		return 0, err
+	}
+	for index := info.MinIndex; index < info.MaxIndex; index++ {
+		path := fmt.Sprintf("%v.%03d", walFile, index)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+	fInfo, err := os.Stat(walFile)
+	if err != nil {
+		return 0, err
+	}
+	return info.TotalSize - fInfo.Size(), nil
+}
+
+// readWAL calls fn with the messages of the files of the WAL from index on.
+func readWAL(group *auto.Group, index int, fn func(*TimedWALMessage) error) error {
+	gr, err := group.NewReader(index)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	dec := NewWALDecoder(gr)
+	for i := 0; ; i++ {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode the message %d of the WAL: %w", i, err)
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"crypto/rand"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/autofile"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	cmttypes "github.com/tendermint/tendermint/types"
 	cmttime "github.com/tendermint/tendermint/types/time"
 )
@@ -86,6 +88,11 @@ func TestWALEncoderDecoder(t *testing.T) {
 		{Time: now, Msg: EndHeightMessage{0}},
 		{Time: now, Msg: timeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: types.RoundStepPropose}},
 		{Time: now, Msg: cmttypes.EventDataRoundState{Height: 1, Round: 1, Step: ""}},
+		{Time: now, Msg: msgInfo{
+			Msg:         &HasVoteMessage{Height: 1, Round: 1, Type: cmtproto.PrevoteType, Index: 1},
+			PeerID:      "peer",
+			ReceiveTime: now,
+		}},
 	}
 
 	b := new(bytes.Buffer)
@@ -175,6 +182,98 @@ func TestWALSearchForEndHeight(t *testing.T) {
 	assert.Equal(t, rs.Height, h+1, "wrong height")
 }
 
+func TestWALPrune(t *testing.T) {
+	walDir, err := os.MkdirTemp("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	wal, err := NewWAL(walFile, autofile.GroupHeadSizeLimit(0))
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	require.NoError(t, wal.Start()) // writes the #ENDHEIGHT 0
+	defer func() {
+		if err := wal.Stop(); err != nil {
+			t.Error(err)
+		}
+		wal.Wait()
+	}()
+
+	// each height spans two files
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, wal.Write(timeoutInfo{Duration: time.Second, Height: h}))
+		wal.Group().RotateFile()
+		require.NoError(t, wal.WriteSync(EndHeightMessage{h}))
+		wal.Group().RotateFile()
+	}
+
+	// the files before the one of the #ENDHEIGHT before the last one are removed
+	assert.Equal(t, 3, wal.Group().MinIndex())
+	assert.Equal(t, 3, wal.Group().ReadGroupInfo().MinIndex)
+	for h, expected := range map[int64]bool{1: false, 2: true, 3: true} {
+		gr, found, err := wal.SearchForEndHeight(h, &WALSearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, found, h)
+		if gr != nil {
+			gr.Close()
+		}
+	}
+}
+
+func TestCompactWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6)
+	require.NoError(t, err)
+	walDir, err := os.MkdirTemp("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	// the WAL spans two files
+	require.NoError(t, os.WriteFile(walFile+".000", walBody[:len(walBody)/2], 0o600))
+	require.NoError(t, os.WriteFile(walFile, walBody[len(walBody)/2:], 0o600))
+	var endHeights []int64
+	dec := NewWALDecoder(bytes.NewReader(walBody))
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			endHeights = append(endHeights, m.Height)
+		}
+	}
+	require.Greater(t, len(endHeights), 2)
+	last := endHeights[len(endHeights)-1]
+
+	saved, err := CompactWAL(walFile)
+	require.NoError(t, err)
+	assert.Positive(t, saved)
+	assert.NoFileExists(t, walFile+".000")
+	fInfo, err := os.Stat(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(walBody), fInfo.Size()+saved)
+
+	// the WAL is kept from the #ENDHEIGHT before the last one
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	for h, expected := range map[int64]bool{last - 2: false, last - 1: true, last: true} {
+		gr, found, err := wal.SearchForEndHeight(h, &WALSearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, found, h)
+		if gr != nil {
+			gr.Close()
+		}
+	}
+	wal.Group().Close()
+
+	// and compacting it again saves nothing
+	saved, err = CompactWAL(walFile)
+	require.NoError(t, err)
+	assert.Zero(t, saved)
+}
+
 func TestWALPeriodicSync(t *testing.T) {
 	walDir, err := os.MkdirTemp("", "wal")
 	require.NoError(t, err)
@@ -0,0 +1,63 @@
+package batch
+
+import (
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+// DefaultMaxBatchSize is the default maximum number of signatures verified in
+// a single batch.
+const DefaultMaxBatchSize = 64
+
+var maxBatchSize atomic.Int64
+
+func init() {
+	maxBatchSize.Store(DefaultMaxBatchSize)
+}
+
+// MaxBatchSize returns the maximum number of signatures verified in a single
+// batch. Callers verifying more signatures split them into several batches.
+// 0 means signatures are not verified in batches.
+func MaxBatchSize() int {
+	return int(maxBatchSize.Load())
+}
+
+// SetMaxBatchSize sets the maximum number of signatures verified in a single
+// batch. 0 disables batch verification.
+func SetMaxBatchSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	maxBatchSize.Store(int64(size))
+}
+
+// CreateBatchVerifier checks if a key type implements the batch verifier
+// interface. Currently ed25519, secp256k1 and sr25519 support batch verification.
+func CreateBatchVerifier(pk crypto.PubKey) (crypto.BatchVerifier, bool) {
+	switch pk.Type() {
+	case ed25519.KeyType:
+		return ed25519.NewBatchVerifier(), true
+	case secp256k1.KeyType:
+		return secp256k1.NewBatchVerifier(), true
+	case sr25519.KeyType:
+		return sr25519.NewBatchVerifier(), true
+	}
+
+	// case where the key does not support batch verification
+	return nil, false
+}
+
+// SupportsBatchVerifier checks if a key type implements the batch verifier
+// interface.
+func SupportsBatchVerifier(pk crypto.PubKey) bool {
+	switch pk.Type() {
+	case ed25519.KeyType, secp256k1.KeyType, sr25519.KeyType:
+		return true
+	}
+
+	return false
+}
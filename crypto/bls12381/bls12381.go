@@ -0,0 +1,233 @@
+package bls12381
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+)
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKey{}
+
+const (
+	PrivKeyName = "tendermint/PrivKeyBls12_381"
+	PubKeyName  = "tendermint/PubKeyBls12_381"
+	// PrivKeySize is the size, in bytes, of private keys: a big-endian scalar.
+	PrivKeySize = bls.ScalarSize
+	// PubKeySize is the size, in bytes, of public keys: a compressed G1 point.
+	PubKeySize = bls.G1SizeCompressed
+	// SignatureSize is the size, in bytes, of signatures: a compressed G2 point.
+	SignatureSize = bls.G2SizeCompressed
+
+	KeyType = "bls12_381"
+)
+
+// dst and popDST are the domain separation tags of the proof of possession
+// scheme of the BLS signature draft (minimal public key size variant), used to
+// hash the messages, and the public keys proven, to G2. The signatures of a
+// message by several keys can only be aggregated safely once each key came
+// with a proof of possession, as otherwise a rogue key chosen from the others
+// forges their aggregate.
+var (
+	dst    = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	popDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+func init() {
+	cmtjson.RegisterType(PubKey{}, PubKeyName)
+	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+}
+
+// PrivKey implements crypto.PrivKey.
+type PrivKey []byte
+
+// Bytes returns the privkey byte format.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign produces a signature on the provided message: the hash of the message
+// to G2 multiplied by the private key.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+	sig := new(bls.G2)
+	sig.Hash(msg, dst)
+	sig.ScalarMult(sk, sig)
+	return sig.BytesCompressed(), nil
+}
+
+// PopProve produces the proof of possession of the private key: the hash of
+// its public key to G2 multiplied by the private key.
+func (privKey PrivKey) PopProve() ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+	proof := new(bls.G2)
+	proof.Hash(privKey.PubKey().Bytes(), popDST)
+	proof.ScalarMult(sk, proof)
+	return proof.BytesCompressed(), nil
+}
+
+// PubKey gets the corresponding public key from the private key.
+//
+// Panics if the private key is invalid.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	sk, err := privKey.scalar()
+	if err != nil {
+		panic(err)
+	}
+	pk := new(bls.G1)
+	pk.ScalarMult(sk, bls.G1Generator())
+	return PubKey(pk.BytesCompressed())
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBLS, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBLS[:]) == 1
+	}
+	return false
+}
+
+func (privKey PrivKey) Type() string {
+	return KeyType
+}
+
+func (privKey PrivKey) scalar() (*bls.Scalar, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(privKey))
+	}
+	sk := new(bls.Scalar)
+	if err := sk.UnmarshalBinary(privKey); err != nil {
+		return nil, err
+	}
+	if sk.IsZero() == 1 {
+		return nil, errors.New("private key is zero")
+	}
+	return sk, nil
+}
+
+// GenPrivKey generates a new BLS12-381 private key.
+// It uses OS randomness to generate the private key.
+func GenPrivKey() PrivKey {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new BLS12-381 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKey {
+	sk := new(bls.Scalar)
+	for {
+		if err := sk.Random(rand); err != nil {
+			panic(err)
+		}
+		if sk.IsZero() == 0 {
+			break
+		}
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+// GenPrivKeyFromSecret hashes the secret with SHA2, and uses the result,
+// reduced modulo the group order, as the private key.
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	sk := new(bls.Scalar)
+	sk.SetBytes(crypto.Sha256(secret))
+	if sk.IsZero() == 1 {
+		panic("secret hashes to a zero private key")
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey for BLS signatures over BLS12-381, with
+// public keys in G1 and signatures in G2.
+type PubKey []byte
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey))
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+// VerifySignature checks that e(pubKey, H(msg)) == e(G1, sig).
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	return pubKey.verify(msg, sig, dst)
+}
+
+// PopVerify checks the proof of possession of the private key of pubKey:
+// e(pubKey, H(pubKey)) == e(G1, proof).
+func (pubKey PubKey) PopVerify(proof []byte) bool {
+	return pubKey.verify(pubKey, proof, popDST)
+}
+
+func (pubKey PubKey) verify(msg []byte, sig []byte, dst []byte) bool {
+	if len(pubKey) != PubKeySize || len(sig) != SignatureSize {
+		return false
+	}
+
+	pk := new(bls.G1)
+	if err := pk.SetBytes(pubKey); err != nil || pk.IsIdentity() {
+		return false
+	}
+	s := new(bls.G2)
+	if err := s.SetBytes(sig); err != nil {
+		return false
+	}
+
+	h := new(bls.G2)
+	h.Hash(msg, dst)
+	return bls.ProdPairFrac(
+		[]*bls.G1{pk, bls.G1Generator()},
+		[]*bls.G2{h, s},
+		[]int{1, -1},
+	).IsIdentity()
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBls12_381{%X}", []byte(pubKey))
+}
+
+func (pubKey PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBLS, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBLS[:])
+	}
+	return false
+}
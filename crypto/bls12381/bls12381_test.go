@@ -0,0 +1,80 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+)
+
+func TestSignAndValidateBls12381(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls12381.SignatureSize)
+
+	// Test the signature
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+
+	// Wrong message or key
+	assert.False(t, pubKey.VerifySignature(crypto.CRandBytes(128), sig))
+	assert.False(t, bls12381.GenPrivKey().PubKey().VerifySignature(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestProofOfPossession(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey().(bls12381.PubKey)
+
+	proof, err := privKey.PopProve()
+	require.NoError(t, err)
+	require.Len(t, proof, bls12381.SignatureSize)
+	assert.True(t, pubKey.PopVerify(proof))
+
+	// The proof is not a signature of the public key, and proves a single key.
+	assert.False(t, pubKey.VerifySignature(pubKey, proof))
+	sig, err := privKey.Sign(pubKey)
+	require.NoError(t, err)
+	assert.False(t, pubKey.PopVerify(sig))
+	assert.False(t, bls12381.GenPrivKey().PubKey().(bls12381.PubKey).PopVerify(proof))
+
+	proof[7] ^= byte(0x01)
+	assert.False(t, pubKey.PopVerify(proof))
+}
+
+func TestIdentityPubKeyIsRejected(t *testing.T) {
+	// The compressed encoding of the point at infinity verifies any signature
+	// at infinity, for any message.
+	pubKey := make(bls12381.PubKey, bls12381.PubKeySize)
+	pubKey[0] = 0xc0
+	sig := make([]byte, bls12381.SignatureSize)
+	sig[0] = 0xc0
+	assert.False(t, pubKey.VerifySignature([]byte("msg"), sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := bls12381.GenPrivKeyFromSecret([]byte("secret"))
+	assert.Equal(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("secret")))
+	assert.NotEqual(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("other secret")))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+
+	bz, err := cmtjson.Marshal(privKey.PubKey())
+	require.NoError(t, err)
+	var pubKey crypto.PubKey
+	require.NoError(t, cmtjson.Unmarshal(bz, &pubKey))
+	assert.True(t, privKey.PubKey().Equals(pubKey))
+}
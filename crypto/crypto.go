@@ -40,3 +40,14 @@ type Symmetric interface {
 	Encrypt(plaintext []byte, secret []byte) (ciphertext []byte)
 	Decrypt(ciphertext []byte, secret []byte) (plaintext []byte, err error)
 }
+
+// BatchVerifier verifies a set of signatures at once, which is faster than
+// verifying them one by one.
+type BatchVerifier interface {
+	// Add appends an entry into the BatchVerifier.
+	Add(key PubKey, message, signature []byte) error
+	// Verify verifies all the entries in the BatchVerifier, and returns
+	// whether every signature in the batch is valid, along with the validity
+	// of each individual entry, in the order they were added.
+	Verify() (bool, []bool)
+}
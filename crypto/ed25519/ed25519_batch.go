@@ -0,0 +1,86 @@
+package ed25519
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements crypto.BatchVerifier for ed25519 signatures.
+//
+// The ed25519 implementation used by this package does not expose the curve
+// arithmetic a single multi-scalar multiplication over the batch requires, so
+// the batch is verified concurrently on all available CPUs instead. Every
+// signature is verified exactly as PubKey.VerifySignature does, so batch
+// verification never accepts a signature rejected one by one.
+type BatchVerifier struct {
+	entries []batchEntry
+}
+
+type batchEntry struct {
+	pubKey    ed25519.PublicKey
+	msg       []byte
+	signature []byte
+}
+
+// NewBatchVerifier returns a new, empty, ed25519 BatchVerifier.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add implements crypto.BatchVerifier. It returns an error if the key is not
+// an ed25519 key, or if the key or signature have the wrong size.
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pkEd, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not ed25519")
+	}
+	if len(pkEd) != PubKeySize {
+		return fmt.Errorf("invalid pubkey size %d", len(pkEd))
+	}
+	if len(signature) != SignatureSize {
+		return fmt.Errorf("invalid signature size %d", len(signature))
+	}
+
+	b.entries = append(b.entries, batchEntry{
+		pubKey:    ed25519.PublicKey(pkEd),
+		msg:       msg,
+		signature: signature,
+	})
+	return nil
+}
+
+// Verify implements crypto.BatchVerifier.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.entries))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(b.entries) {
+		workers = len(b.entries)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(b.entries); i += workers {
+				e := b.entries[i]
+				valid[i] = ed25519.Verify(e.pubKey, e.msg, e.signature)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, ok := range valid {
+		if !ok {
+			return false, valid
+		}
+	}
+	return true, valid
+}
@@ -0,0 +1,183 @@
+// Package hd derives private keys from a single seed, following BIP32 for
+// secp256k1 keys and SLIP-10 for ed25519 keys, with BIP39 mnemonics as seeds.
+//
+// Deriving all the keys of a network from a single mnemonic makes test
+// networks reproducible. It must not be used to derive the keys of production
+// validators from a mnemonic kept anywhere near the nodes.
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	bip39 "github.com/cosmos/go-bip39"
+	xed25519 "golang.org/x/crypto/ed25519"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// HardenedOffset is added to the index of hardened child keys.
+const HardenedOffset = uint32(0x80000000)
+
+var (
+	// ErrInvalidKey is returned when a derived key is not a valid private key.
+	// This happens with a probability lower than 2^-127 for secp256k1, and
+	// never for ed25519.
+	ErrInvalidKey = errors.New("derived key is invalid")
+	// ErrNonHardened is returned when deriving a non-hardened child of an
+	// ed25519 key, which SLIP-10 does not support.
+	ErrNonHardened = errors.New("ed25519 keys only support hardened derivation")
+)
+
+// curve parameters of SLIP-10
+var (
+	ed25519Curve   = []byte("ed25519 seed")
+	secp256k1Curve = []byte("Bitcoin seed")
+)
+
+// SeedFromMnemonic returns the BIP39 seed of the given mnemonic and password.
+// It returns an error if the mnemonic is invalid.
+func SeedFromMnemonic(mnemonic, password string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	return bip39.NewSeedWithErrorChecking(mnemonic, password)
+}
+
+// NewMnemonic returns a new random BIP39 mnemonic of 24 words.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// ParsePath parses a derivation path such as m/44'/118'/0'/0/0 into the
+// indexes of the successive child keys. Hardened indexes are marked with a
+// trailing ', h or H.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("path %q must start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, fmt.Errorf("invalid index %q in path %q", part, path)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// DerivePrivKey derives the private key of the given type (ed25519 or
+// secp256k1) at the given path from the seed.
+func DerivePrivKey(seed []byte, path, keyType string) (crypto.PrivKey, error) {
+	switch keyType {
+	case "", ed25519.KeyType:
+		return DeriveEd25519(seed, path)
+	case secp256k1.KeyType:
+		return DeriveSecp256k1(seed, path)
+	default:
+		return nil, fmt.Errorf("key derivation is not supported for key type %q", keyType)
+	}
+}
+
+// DeriveEd25519 derives the ed25519 private key at the given path from the
+// seed, following SLIP-10. All the indexes of the path must be hardened.
+func DeriveEd25519(seed []byte, path string) (ed25519.PrivKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := hmacSplit(ed25519Curve, seed)
+	for _, index := range indexes {
+		if index < HardenedOffset {
+			return nil, ErrNonHardened
+		}
+		key, chainCode = hmacSplit(chainCode, childData(key, index))
+	}
+	return ed25519.PrivKey(xed25519.NewKeyFromSeed(key)), nil
+}
+
+// DeriveSecp256k1 derives the secp256k1 private key at the given path from
+// the seed, following BIP32.
+func DeriveSecp256k1(seed []byte, path string) (secp256k1.PrivKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := hmacSplit(secp256k1Curve, seed)
+	if err := checkSecp256k1Key(key); err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		var data []byte
+		if index >= HardenedOffset {
+			data = childData(key, index)
+		} else {
+			_, pub := btcec.PrivKeyFromBytes(key)
+			data = binary.BigEndian.AppendUint32(pub.SerializeCompressed(), index)
+		}
+
+		var il []byte
+		il, chainCode = hmacSplit(chainCode, data)
+
+		var tweak, parent btcec.ModNScalar
+		if overflow := tweak.SetByteSlice(il); overflow {
+			return nil, ErrInvalidKey
+		}
+		parent.SetByteSlice(key)
+		child := tweak.Add(&parent).Bytes()
+		key = child[:]
+		if err := checkSecp256k1Key(key); err != nil {
+			return nil, err
+		}
+	}
+	return secp256k1.PrivKey(key), nil
+}
+
+// hmacSplit returns the two halves of HMAC-SHA512(key, data).
+func hmacSplit(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// childData returns the data hashed to derive the hardened child of a key.
+func childData(key []byte, index uint32) []byte {
+	data := make([]byte, 0, 1+len(key)+4)
+	data = append(data, 0)
+	data = append(data, key...)
+	return binary.BigEndian.AppendUint32(data, index)
+}
+
+func checkSecp256k1Key(key []byte) error {
+	var k btcec.ModNScalar
+	if overflow := k.SetByteSlice(key); overflow || k.IsZero() {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package hd_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/hd"
+)
+
+// Test vector 1 of BIP32 and SLIP-10.
+var testSeed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestDeriveSecp256k1(t *testing.T) {
+	for path, want := range map[string]string{
+		"m":           "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		"m/0'":        "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":      "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0H/1/2H":   "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		"m/0'/1/2'/2": "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+	} {
+		privKey, err := hd.DeriveSecp256k1(testSeed, path)
+		require.NoError(t, err, path)
+		assert.Equal(t, want, hex.EncodeToString(privKey), path)
+	}
+}
+
+func TestDeriveEd25519(t *testing.T) {
+	for path, want := range map[string]string{
+		"m":          "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		"m/0'":       "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		"m/0'/1'":    "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		"m/0H/1H/2H": "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+	} {
+		privKey, err := hd.DeriveEd25519(testSeed, path)
+		require.NoError(t, err, path)
+		// the private key is the seed followed by the public key
+		assert.Equal(t, want, hex.EncodeToString(privKey[:32]), path)
+	}
+
+	_, err := hd.DeriveEd25519(testSeed, "m/0'/1")
+	assert.ErrorIs(t, err, hd.ErrNonHardened)
+}
+
+func TestParsePath(t *testing.T) {
+	indexes, err := hd.ParsePath("m/44'/118'/0'/0/7")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{44 + hd.HardenedOffset, 118 + hd.HardenedOffset, hd.HardenedOffset, 0, 7}, indexes)
+
+	for _, path := range []string{"", "44'/0", "m/x", "m/-1", "m/2147483648", "m//0"} {
+		_, err := hd.ParsePath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestSeedFromMnemonic(t *testing.T) {
+	mnemonic, err := hd.NewMnemonic()
+	require.NoError(t, err)
+
+	seed, err := hd.SeedFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+	assert.Len(t, seed, 64)
+
+	seed2, err := hd.SeedFromMnemonic("  "+mnemonic+"\n", "")
+	require.NoError(t, err)
+	assert.Equal(t, seed, seed2)
+
+	_, err = hd.SeedFromMnemonic("not a mnemonic", "")
+	assert.Error(t, err)
+}
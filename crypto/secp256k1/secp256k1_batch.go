@@ -0,0 +1,94 @@
+package secp256k1
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	secp256k1 "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements crypto.BatchVerifier for secp256k1 signatures.
+//
+// Unlike Schnorr signatures, ECDSA signatures in the R || S format do not
+// carry the full nonce point, so they cannot be combined into a single
+// multi-scalar multiplication. Instead, the batch is verified concurrently on
+// all available CPUs, after parsing and sanity checking each entry as it is
+// added.
+type BatchVerifier struct {
+	entries []batchEntry
+}
+
+type batchEntry struct {
+	pubKey    *secp256k1.PublicKey
+	hash      []byte
+	signature *ecdsa.Signature
+}
+
+// NewBatchVerifier returns a new, empty, secp256k1 BatchVerifier.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add implements crypto.BatchVerifier. It returns an error if the key is not
+// a secp256k1 key, or if the key or signature are malformed.
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pkSecp, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not secp256k1")
+	}
+	if len(signature) != 64 {
+		return fmt.Errorf("invalid signature size %d", len(signature))
+	}
+	pub, err := secp256k1.ParsePubKey(pkSecp)
+	if err != nil {
+		return fmt.Errorf("invalid pubkey: %w", err)
+	}
+	sig := signatureFromBytes(signature)
+	// Reject malleable signatures, see PubKey.VerifySignature.
+	modifiedSignature, err := ecdsa.ParseDERSignature(sig.Serialize())
+	if err != nil || !sig.IsEqual(modifiedSignature) {
+		return fmt.Errorf("malleable signature")
+	}
+
+	b.entries = append(b.entries, batchEntry{
+		pubKey:    pub,
+		hash:      crypto.Sha256(msg),
+		signature: sig,
+	})
+	return nil
+}
+
+// Verify implements crypto.BatchVerifier.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.entries))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(b.entries) {
+		workers = len(b.entries)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(b.entries); i += workers {
+				e := b.entries[i]
+				valid[i] = e.signature.Verify(e.hash, e.pubKey)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, ok := range valid {
+		if !ok {
+			return false, valid
+		}
+	}
+	return true, valid
+}
@@ -0,0 +1,81 @@
+package sr25519
+
+import (
+	"fmt"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements crypto.BatchVerifier for sr25519 signatures.
+//
+// The batch is checked with a single multi-scalar multiplication. If it does
+// not verify, every signature is verified one by one to find the invalid ones.
+type BatchVerifier struct {
+	verifier *schnorrkel.BatchVerifier
+	entries  []batchEntry
+}
+
+type batchEntry struct {
+	pubKey    PubKey
+	msg       []byte
+	signature []byte
+}
+
+// NewBatchVerifier returns a new, empty, sr25519 BatchVerifier.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{verifier: schnorrkel.NewBatchVerifier()}
+}
+
+// Add implements crypto.BatchVerifier. It returns an error if the key is not
+// an sr25519 key, or if the key or signature are malformed.
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pkSr, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not sr25519")
+	}
+	if len(pkSr) != PubKeySize {
+		return fmt.Errorf("invalid pubkey size %d", len(pkSr))
+	}
+	if len(signature) != SignatureSize {
+		return fmt.Errorf("invalid signature size %d", len(signature))
+	}
+
+	var p [PubKeySize]byte
+	copy(p[:], pkSr)
+	pub := &schnorrkel.PublicKey{}
+	if err := pub.Decode(p); err != nil {
+		return fmt.Errorf("invalid pubkey: %w", err)
+	}
+	var s [SignatureSize]byte
+	copy(s[:], signature)
+	sig := &schnorrkel.Signature{}
+	if err := sig.Decode(s); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if err := b.verifier.Add(schnorrkel.NewSigningContext([]byte{}, msg), sig, pub); err != nil {
+		return err
+	}
+	b.entries = append(b.entries, batchEntry{pubKey: pkSr, msg: msg, signature: signature})
+	return nil
+}
+
+// Verify implements crypto.BatchVerifier.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.entries))
+	if b.verifier.Verify() {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+
+	for i, e := range b.entries {
+		valid[i] = e.pubKey.VerifySignature(e.msg, e.signature)
+	}
+	return false, valid
+}
@@ -0,0 +1,64 @@
+package sr25519_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	v := sr25519.NewBatchVerifier()
+	var msgs [][]byte
+	for i := 0; i < 64; i++ {
+		priv := sr25519.GenPrivKey()
+		msg := crypto.CRandBytes(128)
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		// corrupt the message of the fifth entry
+		if i == 4 {
+			msg = append([]byte{}, msg...)
+			msg[0] ^= 1
+		}
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+		msgs = append(msgs, msg)
+	}
+
+	ok, valid := v.Verify()
+	assert.False(t, ok)
+	require.Len(t, valid, len(msgs))
+	for i, isValid := range valid {
+		assert.Equal(t, i != 4, isValid, "entry %d", i)
+	}
+}
+
+func TestBatchVerifierValid(t *testing.T) {
+	v := sr25519.NewBatchVerifier()
+	for i := 0; i < 8; i++ {
+		priv := sr25519.GenPrivKey()
+		msg := crypto.CRandBytes(32)
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+
+	ok, valid := v.Verify()
+	assert.True(t, ok)
+	assert.Len(t, valid, 8)
+}
+
+func TestBatchVerifierAddRejectsInvalidEntries(t *testing.T) {
+	v := sr25519.NewBatchVerifier()
+	priv := sr25519.GenPrivKey()
+	msg := crypto.CRandBytes(32)
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+
+	assert.Error(t, v.Add(secp256k1.GenPrivKey().PubKey(), msg, sig))
+	assert.Error(t, v.Add(priv.PubKey(), msg, sig[:63]))
+	assert.Error(t, v.Add(sr25519.PubKey(make([]byte, 31)), msg, sig))
+}
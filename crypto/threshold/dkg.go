@@ -0,0 +1,242 @@
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"filippo.io/edwards25519"
+)
+
+var (
+	ErrInvalidDKGCommitment = errors.New("invalid DKG commitment")
+	ErrInvalidDKGShare      = errors.New("invalid DKG share")
+)
+
+// DKGCommitment is the public part of a participant of the DKG, broadcast to
+// all the others in the first round.
+type DKGCommitment struct {
+	ID int `json:"id"`
+	// Commitments are the commitments to the coefficients of the secret
+	// sharing polynomial of the participant, the first one to its secret.
+	Commitments [][]byte `json:"commitments"`
+	// ProofR and ProofZ are a Schnorr proof of knowledge of the secret, so
+	// that no participant can choose its commitment after seeing the others.
+	ProofR []byte `json:"proof_r"`
+	ProofZ []byte `json:"proof_z"`
+}
+
+// DKGShare is the share of the secret of participant From for participant To,
+// sent to it in the second round.
+type DKGShare struct {
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Secret []byte `json:"secret"`
+}
+
+// DKG is a participant of the distributed key generation of FROST, Pedersen's
+// DKG with proofs of knowledge, for the cosigners to generate their key shares
+// without the key ever existing in one place. Each of the total participants
+//
+//  1. creates its DKG with NewDKG, and broadcasts its DKGCommitment to all
+//     the others,
+//  2. calls Round2 with the commitments of all the participants, and sends
+//     each of them their DKGShare over an authenticated and confidential
+//     channel,
+//  3. calls Finish with the shares received from all the others, which
+//     returns its key share.
+//
+// The participants must make sure they all received the same commitments,
+// e.g. by comparing their hashes out of band. A DKG can only be run once.
+type DKG struct {
+	id, threshold, total int
+
+	// the secret sharing polynomial, erased by Finish
+	coefficients []*edwards25519.Scalar
+	commitment   DKGCommitment
+
+	// the commitments of all the participants, by ID, set by Round2
+	commitments map[int][]*edwards25519.Point
+}
+
+// NewDKG starts the DKG of participant id, of a key shared by total
+// participants, any threshold of which can sign.
+func NewDKG(id, threshold, total int, rand io.Reader) (*DKG, error) {
+	if threshold < 1 || threshold > total || total > maxID {
+		return nil, fmt.Errorf("invalid threshold %d of %d", threshold, total)
+	}
+	if id < 1 || id > total {
+		return nil, fmt.Errorf("invalid participant ID %d", id)
+	}
+
+	d := &DKG{id: id, threshold: threshold, total: total}
+	d.commitment = DKGCommitment{ID: id, Commitments: make([][]byte, threshold)}
+	for i := 0; i < threshold; i++ {
+		a, err := randomScalar(rand)
+		if err != nil {
+			return nil, err
+		}
+		d.coefficients = append(d.coefficients, a)
+		d.commitment.Commitments[i] = new(edwards25519.Point).ScalarBaseMult(a).Bytes()
+	}
+
+	// mu = k + a_0*c, with R = k*B and c = H(ID, A_0, R)
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+	r := new(edwards25519.Point).ScalarBaseMult(k).Bytes()
+	c := hashToScalar([]byte("dkg"), encodeID(id), d.commitment.Commitments[0], r)
+	d.commitment.ProofR = r
+	d.commitment.ProofZ = edwards25519.NewScalar().MultiplyAdd(d.coefficients[0], c, k).Bytes()
+	return d, nil
+}
+
+// Commitment returns the commitment to broadcast to the other participants.
+func (d *DKG) Commitment() DKGCommitment {
+	return d.commitment
+}
+
+// Round2 checks the commitments of all the participants, including this one,
+// and returns the shares of the secret of this participant for the others.
+func (d *DKG) Round2(commitments []DKGCommitment) ([]DKGShare, error) {
+	if d.coefficients == nil || d.commitments != nil {
+		return nil, errors.New("the DKG round 2 has already been run")
+	}
+	if len(commitments) != d.total {
+		return nil, fmt.Errorf("%w: expected %d commitments, got %d",
+			ErrInvalidDKGCommitment, d.total, len(commitments))
+	}
+	sorted := make([]DKGCommitment, len(commitments))
+	copy(sorted, commitments)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	all := make(map[int][]*edwards25519.Point, d.total)
+	for i, c := range sorted {
+		if c.ID != i+1 {
+			return nil, fmt.Errorf("%w: missing participant %d", ErrInvalidDKGCommitment, i+1)
+		}
+		points, err := d.verifyCommitment(c)
+		if err != nil {
+			return nil, fmt.Errorf("%w from participant %d: %v", ErrInvalidDKGCommitment, c.ID, err)
+		}
+		all[c.ID] = points
+	}
+	for i, bz := range d.commitment.Commitments {
+		if !bytes.Equal(sorted[d.id-1].Commitments[i], bz) {
+			return nil, fmt.Errorf("%w: the commitment of this participant was changed", ErrInvalidDKGCommitment)
+		}
+	}
+	d.commitments = all
+
+	shares := make([]DKGShare, 0, d.total-1)
+	for to := 1; to <= d.total; to++ {
+		if to == d.id {
+			continue
+		}
+		shares = append(shares, DKGShare{
+			From:   d.id,
+			To:     to,
+			Secret: evalPolynomial(d.coefficients, scalarFromUint(uint64(to))).Bytes(),
+		})
+	}
+	return shares, nil
+}
+
+// verifyCommitment decodes the commitments of a participant, and checks its
+// proof of knowledge of the secret.
+func (d *DKG) verifyCommitment(c DKGCommitment) ([]*edwards25519.Point, error) {
+	if len(c.Commitments) != d.threshold {
+		return nil, fmt.Errorf("expected %d commitments, got %d", d.threshold, len(c.Commitments))
+	}
+	points := make([]*edwards25519.Point, len(c.Commitments))
+	for i, bz := range c.Commitments {
+		p, err := decodePoint(bz)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = p
+	}
+	r, err := decodePoint(c.ProofR)
+	if err != nil {
+		return nil, err
+	}
+	z, err := decodeScalar(c.ProofZ)
+	if err != nil {
+		return nil, err
+	}
+
+	// z*B - c*A_0 == R
+	ch := hashToScalar([]byte("dkg"), encodeID(c.ID), c.Commitments[0], c.ProofR)
+	lhs := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(ch.Negate(ch), points[0], z)
+	if lhs.Equal(r) != 1 {
+		return nil, errors.New("invalid proof of knowledge")
+	}
+	return points, nil
+}
+
+// Finish checks the shares received from all the other participants against
+// their commitments, and returns the key share of this participant. The
+// secret sharing polynomial of this participant is erased.
+func (d *DKG) Finish(shares []DKGShare) (KeyShare, error) {
+	switch {
+	case d.commitments == nil:
+		return KeyShare{}, errors.New("the DKG round 2 has not been run")
+	case d.coefficients == nil:
+		return KeyShare{}, errors.New("the DKG has already finished")
+	}
+	if len(shares) != d.total-1 {
+		return KeyShare{}, fmt.Errorf("%w: expected %d shares, got %d", ErrInvalidDKGShare, d.total-1, len(shares))
+	}
+
+	x := scalarFromUint(uint64(d.id))
+	secret := evalPolynomial(d.coefficients, x)
+	received := make(map[int]bool, len(shares))
+	for _, share := range shares {
+		if share.To != d.id || share.From == d.id || d.commitments[share.From] == nil || received[share.From] {
+			return KeyShare{}, fmt.Errorf("%w from participant %d", ErrInvalidDKGShare, share.From)
+		}
+		received[share.From] = true
+		s, err := decodeScalar(share.Secret)
+		if err != nil {
+			return KeyShare{}, fmt.Errorf("%w from participant %d", ErrInvalidDKGShare, share.From)
+		}
+		// s*B must be the commitments of the participant evaluated at our ID
+		if new(edwards25519.Point).ScalarBaseMult(s).Equal(evalCommitments(d.commitments[share.From], x)) != 1 {
+			return KeyShare{}, fmt.Errorf("%w from participant %d", ErrInvalidDKGShare, share.From)
+		}
+		secret.Add(secret, s)
+	}
+
+	// The group commitments are the sums of the commitments of all the
+	// participants: the first one is the public key, and their polynomial at
+	// the ID of a participant is its verification share.
+	group := make([]*edwards25519.Point, d.threshold)
+	for i := range group {
+		group[i] = edwards25519.NewIdentityPoint()
+		for _, points := range d.commitments {
+			group[i].Add(group[i], points[i])
+		}
+	}
+	verificationShares := make([][]byte, d.total)
+	for i := range verificationShares {
+		verificationShares[i] = evalCommitments(group, scalarFromUint(uint64(i+1))).Bytes()
+	}
+
+	for _, a := range d.coefficients {
+		a.Set(edwards25519.NewScalar())
+	}
+	d.coefficients = nil
+
+	share := KeyShare{
+		ID:                 d.id,
+		Threshold:          d.threshold,
+		Total:              d.total,
+		Secret:             secret.Bytes(),
+		PubKey:             group[0].Bytes(),
+		VerificationShares: verificationShares,
+	}
+	return share, share.ValidateBasic()
+}
@@ -0,0 +1,108 @@
+package threshold
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// runDKG runs the first rounds of the DKG of total participants, returning
+// them with the shares sent to each of them.
+func runDKG(t *testing.T, threshold, total int) ([]*DKG, [][]DKGShare) {
+	participants := make([]*DKG, total)
+	commitments := make([]DKGCommitment, total)
+	for i := range participants {
+		d, err := NewDKG(i+1, threshold, total, rand.Reader)
+		require.NoError(t, err)
+		participants[i] = d
+		commitments[i] = d.Commitment()
+	}
+	received := make([][]DKGShare, total)
+	for _, d := range participants {
+		shares, err := d.Round2(commitments)
+		require.NoError(t, err)
+		require.Len(t, shares, total-1)
+		for _, share := range shares {
+			received[share.To-1] = append(received[share.To-1], share)
+		}
+	}
+	return participants, received
+}
+
+func TestDKG(t *testing.T) {
+	participants, received := runDKG(t, 2, 3)
+	shares := make([]KeyShare, len(participants))
+	for i, d := range participants {
+		share, err := d.Finish(received[i])
+		require.NoError(t, err)
+		shares[i] = share
+	}
+	for _, share := range shares[1:] {
+		assert.Equal(t, shares[0].PubKey, share.PubKey)
+		assert.Equal(t, shares[0].VerificationShares, share.VerificationShares)
+	}
+
+	pubKey := ed25519.PubKey(shares[0].PubKey)
+	msg := []byte("sign me")
+	for _, signers := range [][]KeyShare{
+		{shares[0], shares[1]},
+		{shares[1], shares[2]},
+		shares,
+	} {
+		commitments, sigShares := signWith(t, signers, msg)
+		sig, err := Aggregate(shares[0].PubKey, shares[0].VerificationShares, msg, commitments, sigShares)
+		require.NoError(t, err)
+		assert.True(t, pubKey.VerifySignature(msg, sig))
+	}
+
+	_, err := participants[0].Finish(received[0])
+	assert.Error(t, err, "already finished")
+}
+
+func TestDKGInvalidCommitment(t *testing.T) {
+	participants := make([]*DKG, 3)
+	commitments := make([]DKGCommitment, 3)
+	for i := range participants {
+		d, err := NewDKG(i+1, 2, 3, rand.Reader)
+		require.NoError(t, err)
+		participants[i] = d
+		commitments[i] = d.Commitment()
+	}
+
+	// a participant can't reuse the commitment of another one
+	forged := commitments[1]
+	forged.ID = 3
+	_, err := participants[0].Round2([]DKGCommitment{commitments[0], commitments[1], forged})
+	assert.ErrorIs(t, err, ErrInvalidDKGCommitment)
+
+	_, err = participants[0].Round2(commitments[:2])
+	assert.ErrorIs(t, err, ErrInvalidDKGCommitment)
+
+	short := commitments[2]
+	short.Commitments = short.Commitments[:1]
+	_, err = participants[0].Round2([]DKGCommitment{commitments[0], commitments[1], short})
+	assert.ErrorIs(t, err, ErrInvalidDKGCommitment)
+}
+
+func TestDKGInvalidShare(t *testing.T) {
+	participants, received := runDKG(t, 2, 3)
+
+	// a share inconsistent with the commitments of its sender
+	bad := make([]DKGShare, len(received[0]))
+	copy(bad, received[0])
+	bad[1].Secret = received[1][0].Secret
+	_, err := participants[0].Finish(bad)
+	assert.ErrorIs(t, err, ErrInvalidDKGShare)
+	assert.Contains(t, err.Error(), "participant 3")
+
+	// twice the same sender
+	_, err = participants[0].Finish([]DKGShare{received[0][0], received[0][0]})
+	assert.ErrorIs(t, err, ErrInvalidDKGShare)
+
+	_, err = participants[0].Finish(received[0])
+	require.NoError(t, err)
+}
@@ -0,0 +1,116 @@
+package threshold
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"io"
+
+	"filippo.io/edwards25519"
+)
+
+// The group operations are those of filippo.io/edwards25519, which are
+// constant time, except for the VarTime methods which are only used on public
+// values.
+
+var (
+	errInvalidPoint  = errors.New("invalid edwards25519 point encoding")
+	errInvalidScalar = errors.New("invalid edwards25519 scalar encoding")
+
+	// eightInverse is the inverse of the cofactor modulo the group order.
+	eightInverse = new(edwards25519.Scalar).Invert(scalarFromUint(8))
+)
+
+// decodePoint parses the canonical RFC 8032 encoding of a point of the
+// prime-order subgroup. The points are received from peers: non-canonical
+// encodings, points of small order and points with a small order component
+// are rejected.
+func decodePoint(b []byte) (*edwards25519.Point, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil || !bytes.Equal(p.Bytes(), b) {
+		return nil, errInvalidPoint
+	}
+	// 8*P is the identity for the points of small order, and 1/8 * 8*P
+	// differs from P for the points with a small order component.
+	cleared := new(edwards25519.Point).MultByCofactor(p)
+	if cleared.Equal(edwards25519.NewIdentityPoint()) == 1 ||
+		new(edwards25519.Point).ScalarMult(eightInverse, cleared).Equal(p) != 1 {
+		return nil, errInvalidPoint
+	}
+	return p, nil
+}
+
+// decodeScalar parses the canonical little-endian encoding of a scalar.
+func decodeScalar(b []byte) (*edwards25519.Scalar, error) {
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
+	if err != nil {
+		return nil, errInvalidScalar
+	}
+	return s, nil
+}
+
+// scalarFromUint returns the scalar n.
+func scalarFromUint(n uint64) *edwards25519.Scalar {
+	b := make([]byte, scalarSize)
+	for i := 0; i < 8; i++ {
+		b[i] = byte(n >> (8 * i))
+	}
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// hashToScalar returns the SHA-512 of the parts, domain-separated by
+// contextString, reduced modulo the group order.
+func hashToScalar(parts ...[]byte) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write([]byte(contextString))
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return challengeScalar(h.Sum(nil))
+}
+
+// challengeScalar reduces a SHA-512 digest modulo the group order, as ed25519
+// does for its challenges.
+func challengeScalar(digest []byte) *edwards25519.Scalar {
+	s, err := edwards25519.NewScalar().SetUniformBytes(digest)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// randomScalar returns a uniformly random scalar.
+func randomScalar(rand io.Reader) (*edwards25519.Scalar, error) {
+	b := make([]byte, 64)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, err
+	}
+	return edwards25519.NewScalar().SetUniformBytes(b)
+}
+
+// evalPolynomial returns the polynomial of the coefficients at x.
+func evalPolynomial(coefficients []*edwards25519.Scalar, x *edwards25519.Scalar) *edwards25519.Scalar {
+	// Horner's method
+	r := edwards25519.NewScalar()
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		r.MultiplyAdd(r, x, coefficients[i])
+	}
+	return r
+}
+
+// evalCommitments returns the polynomial of the commitments to the
+// coefficients, which are public, at x: the commitment to the value of the
+// polynomial at x.
+func evalCommitments(commitments []*edwards25519.Point, x *edwards25519.Scalar) *edwards25519.Point {
+	powers := make([]*edwards25519.Scalar, len(commitments))
+	power := scalarFromUint(1)
+	for i := range powers {
+		powers[i] = edwards25519.NewScalar().Set(power)
+		power.Multiply(power, x)
+	}
+	return new(edwards25519.Point).VarTimeMultiScalarMult(powers, commitments)
+}
@@ -0,0 +1,400 @@
+package threshold
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	// contextString domain-separates the hashes used by this package.
+	contextString = "COMETBFT-FROST-ED25519-SHA512-v1"
+
+	scalarSize = 32
+	pointSize  = 32
+
+	// maxID is the largest share ID, encoded on two bytes.
+	maxID = 0xffff
+)
+
+var (
+	ErrNonceUsed          = errors.New("nonce has already been used")
+	ErrInvalidShare       = errors.New("invalid signature share")
+	ErrInvalidCommitments = errors.New("invalid commitment list")
+)
+
+// KeyShare is a cosigner's share of a threshold ed25519 key. Any Threshold of
+// the Total shares can jointly produce a signature which verifies against
+// PubKey as a standard ed25519 signature.
+type KeyShare struct {
+	// ID is the 1-based index of the share.
+	ID        int `json:"id"`
+	Threshold int `json:"threshold"`
+	Total     int `json:"total"`
+	// Secret is the little-endian encoded secret scalar of the share.
+	Secret []byte `json:"secret"`
+	// PubKey is the ed25519 public key of the group.
+	PubKey []byte `json:"pub_key"`
+	// VerificationShares are the public keys of all shares, ordered by ID.
+	// They are used to identify cosigners sending invalid signature shares.
+	VerificationShares [][]byte `json:"verification_shares"`
+}
+
+// ValidateBasic performs basic validation of the key share.
+func (ks KeyShare) ValidateBasic() error {
+	switch {
+	case ks.Threshold < 1 || ks.Threshold > ks.Total || ks.Total > maxID:
+		return fmt.Errorf("invalid threshold %d of %d", ks.Threshold, ks.Total)
+	case ks.ID < 1 || ks.ID > ks.Total:
+		return fmt.Errorf("invalid share ID %d", ks.ID)
+	case len(ks.Secret) != scalarSize:
+		return fmt.Errorf("invalid secret size %d", len(ks.Secret))
+	case len(ks.PubKey) != pointSize:
+		return fmt.Errorf("invalid public key size %d", len(ks.PubKey))
+	case len(ks.VerificationShares) != ks.Total:
+		return fmt.Errorf("expected %d verification shares, got %d", ks.Total, len(ks.VerificationShares))
+	}
+	if _, err := decodeScalar(ks.Secret); err != nil {
+		return fmt.Errorf("invalid secret: %w", err)
+	}
+	if _, err := decodePoint(ks.PubKey); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	for i, vs := range ks.VerificationShares {
+		if _, err := decodePoint(vs); err != nil {
+			return fmt.Errorf("invalid verification share %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// SplitKey splits an ed25519 private key (in the 64 byte golang format) into
+// total shares, any threshold of which can sign. It lets operators migrate an
+// existing validator key to threshold signing.
+//
+// SplitKey is a trusted dealer: it holds the whole private key and all the
+// shares. It must be run on an ephemeral, offline machine, and the private key
+// destroyed afterwards. New keys should be generated with the DKG instead,
+// which never exist in one place.
+func SplitKey(privKey []byte, threshold, total int, rand io.Reader) ([]KeyShare, error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(privKey))
+	}
+	if threshold < 1 || threshold > total || total > maxID {
+		return nil, fmt.Errorf("invalid threshold %d of %d", threshold, total)
+	}
+
+	// Derive the secret scalar the same way ed25519 does.
+	h := sha512.Sum512(privKey[:ed25519.SeedSize])
+	secret, err := edwards25519.NewScalar().SetBytesWithClamping(h[:scalarSize])
+	if err != nil {
+		return nil, err
+	}
+
+	// f(x) = secret + a_1*x + ... + a_{t-1}*x^{t-1}
+	coefficients := []*edwards25519.Scalar{secret}
+	for i := 1; i < threshold; i++ {
+		a, err := randomScalar(rand)
+		if err != nil {
+			return nil, err
+		}
+		coefficients = append(coefficients, a)
+	}
+
+	pubKey := make([]byte, pointSize)
+	copy(pubKey, privKey[ed25519.SeedSize:])
+
+	secrets := make([]*edwards25519.Scalar, total)
+	verificationShares := make([][]byte, total)
+	for i := 1; i <= total; i++ {
+		s := evalPolynomial(coefficients, scalarFromUint(uint64(i)))
+		secrets[i-1] = s
+		verificationShares[i-1] = new(edwards25519.Point).ScalarBaseMult(s).Bytes()
+	}
+
+	shares := make([]KeyShare, total)
+	for i := range shares {
+		shares[i] = KeyShare{
+			ID:                 i + 1,
+			Threshold:          threshold,
+			Total:              total,
+			Secret:             secrets[i].Bytes(),
+			PubKey:             pubKey,
+			VerificationShares: verificationShares,
+		}
+	}
+	return shares, nil
+}
+
+// Commitment is the public part of a signing nonce, sent to the coordinator
+// in the first round of the signing protocol.
+type Commitment struct {
+	ID      int    `json:"id"`
+	Hiding  []byte `json:"hiding"`
+	Binding []byte `json:"binding"`
+}
+
+// Nonce is a single-use secret signing nonce.
+type Nonce struct {
+	hiding, binding *edwards25519.Scalar
+	commitment      Commitment
+}
+
+// NewNonce generates a fresh signing nonce for the given share.
+func NewNonce(id int, rand io.Reader) (*Nonce, error) {
+	d, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+	e, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &Nonce{
+		hiding:  d,
+		binding: e,
+		commitment: Commitment{
+			ID:      id,
+			Hiding:  new(edwards25519.Point).ScalarBaseMult(d).Bytes(),
+			Binding: new(edwards25519.Point).ScalarBaseMult(e).Bytes(),
+		},
+	}, nil
+}
+
+// Commitment returns the public commitment to the nonce.
+func (n *Nonce) Commitment() Commitment {
+	return n.commitment
+}
+
+// SignatureShare is a cosigner's share of a signature, sent to the
+// coordinator in the second round of the signing protocol.
+type SignatureShare struct {
+	ID int    `json:"id"`
+	Z  []byte `json:"z"`
+}
+
+// signingPackage holds the values shared by all cosigners for a signature.
+type signingPackage struct {
+	commitments    []Commitment
+	hiding         map[int]*edwards25519.Point
+	binding        map[int]*edwards25519.Point
+	bindingFactors map[int]*edwards25519.Scalar
+	groupR         *edwards25519.Point
+	challenge      *edwards25519.Scalar
+}
+
+func newSigningPackage(pubKey []byte, msg []byte, commitments []Commitment) (*signingPackage, error) {
+	if _, err := decodePoint(pubKey); err != nil {
+		return nil, err
+	}
+	if len(commitments) == 0 || !sort.SliceIsSorted(commitments, func(i, j int) bool {
+		return commitments[i].ID < commitments[j].ID
+	}) {
+		return nil, ErrInvalidCommitments
+	}
+
+	// The binding factors commit to the message and to all commitments, so a
+	// coordinator can't mix nonces of different signing sessions.
+	listHash := sha512.New()
+	for i, c := range commitments {
+		if c.ID < 1 || c.ID > maxID || (i > 0 && commitments[i-1].ID == c.ID) {
+			return nil, ErrInvalidCommitments
+		}
+		listHash.Write(encodeID(c.ID))
+		listHash.Write(c.Hiding)
+		listHash.Write(c.Binding)
+	}
+	listDigest := listHash.Sum(nil)
+	msgDigest := sha512.Sum512(msg)
+
+	pkg := &signingPackage{
+		commitments:    commitments,
+		hiding:         make(map[int]*edwards25519.Point, len(commitments)),
+		binding:        make(map[int]*edwards25519.Point, len(commitments)),
+		bindingFactors: make(map[int]*edwards25519.Scalar, len(commitments)),
+		groupR:         edwards25519.NewIdentityPoint(),
+	}
+	for _, c := range commitments {
+		d, err := decodePoint(c.Hiding)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodePoint(c.Binding)
+		if err != nil {
+			return nil, err
+		}
+		rho := hashToScalar([]byte("rho"), pubKey, msgDigest[:], listDigest, encodeID(c.ID))
+		pkg.hiding[c.ID], pkg.binding[c.ID], pkg.bindingFactors[c.ID] = d, e, rho
+		pkg.groupR.Add(pkg.groupR, d)
+		pkg.groupR.Add(pkg.groupR, new(edwards25519.Point).ScalarMult(rho, e))
+	}
+
+	// standard ed25519 challenge
+	h := sha512.New()
+	h.Write(pkg.groupR.Bytes())
+	h.Write(pubKey)
+	h.Write(msg)
+	pkg.challenge = challengeScalar(h.Sum(nil))
+	return pkg, nil
+}
+
+// lagrange returns the Lagrange coefficient of the given ID at x = 0.
+func (pkg *signingPackage) lagrange(id int) *edwards25519.Scalar {
+	num, den := scalarFromUint(1), scalarFromUint(1)
+	xi := scalarFromUint(uint64(id))
+	for _, c := range pkg.commitments {
+		if c.ID == id {
+			continue
+		}
+		xj := scalarFromUint(uint64(c.ID))
+		num.Multiply(num, xj)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(xj, xi))
+	}
+	return num.Multiply(num, den.Invert(den))
+}
+
+// Sign computes the signature share of the given key share over msg, using
+// the nonce previously committed to. The nonce is destroyed, and can't be
+// used again. commitments must be ordered by ID, contain at least threshold
+// entries, and include the commitment of the nonce.
+func Sign(share KeyShare, nonce *Nonce, msg []byte, commitments []Commitment) (SignatureShare, error) {
+	if nonce.hiding == nil {
+		return SignatureShare{}, ErrNonceUsed
+	}
+	secret, err := decodeScalar(share.Secret)
+	if err != nil {
+		return SignatureShare{}, err
+	}
+	if len(commitments) < share.Threshold {
+		return SignatureShare{}, fmt.Errorf("%w: need at least %d commitments, got %d",
+			ErrInvalidCommitments, share.Threshold, len(commitments))
+	}
+	found := false
+	for _, c := range commitments {
+		if c.ID == share.ID {
+			found = bytes.Equal(c.Hiding, nonce.commitment.Hiding) &&
+				bytes.Equal(c.Binding, nonce.commitment.Binding)
+			break
+		}
+	}
+	if !found {
+		return SignatureShare{}, fmt.Errorf("%w: nonce commitment of share %d not found",
+			ErrInvalidCommitments, share.ID)
+	}
+	pkg, err := newSigningPackage(share.PubKey, msg, commitments)
+	if err != nil {
+		return SignatureShare{}, err
+	}
+
+	// z_i = d_i + e_i*rho_i + lambda_i*s_i*c, in constant time
+	z := edwards25519.NewScalar().MultiplyAdd(nonce.binding, pkg.bindingFactors[share.ID], nonce.hiding)
+	sc := edwards25519.NewScalar().Multiply(secret, pkg.lagrange(share.ID))
+	z.MultiplyAdd(sc, pkg.challenge, z)
+
+	nonce.hiding.Set(edwards25519.NewScalar())
+	nonce.binding.Set(edwards25519.NewScalar())
+	nonce.hiding, nonce.binding = nil, nil
+	return SignatureShare{ID: share.ID, Z: z.Bytes()}, nil
+}
+
+// VerifyShare checks a signature share against the verification share of its
+// cosigner.
+func VerifyShare(pubKey, verificationShare, msg []byte, commitments []Commitment, share SignatureShare) error {
+	pkg, err := newSigningPackage(pubKey, msg, commitments)
+	if err != nil {
+		return err
+	}
+	return pkg.verifyShare(verificationShare, share)
+}
+
+func (pkg *signingPackage) verifyShare(verificationShare []byte, share SignatureShare) error {
+	rho, ok := pkg.bindingFactors[share.ID]
+	if !ok {
+		return ErrInvalidShare
+	}
+	z, err := decodeScalar(share.Z)
+	if err != nil {
+		return ErrInvalidShare
+	}
+	yi, err := decodePoint(verificationShare)
+	if err != nil {
+		return err
+	}
+
+	// z_i*B - c*lambda_i*Y_i == D_i + rho_i*E_i, all public values
+	cl := edwards25519.NewScalar().Multiply(pkg.challenge, pkg.lagrange(share.ID))
+	lhs := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(cl.Negate(cl), yi, z)
+	rhs := new(edwards25519.Point).ScalarMult(rho, pkg.binding[share.ID])
+	rhs.Add(rhs, pkg.hiding[share.ID])
+	if lhs.Equal(rhs) != 1 {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+// Aggregate combines the signature shares into an ed25519 signature over msg.
+// If the resulting signature is invalid, the shares are checked against the
+// verification shares and an error naming the faulty cosigners is returned.
+func Aggregate(
+	pubKey []byte,
+	verificationShares [][]byte,
+	msg []byte,
+	commitments []Commitment,
+	shares []SignatureShare,
+) ([]byte, error) {
+	pkg, err := newSigningPackage(pubKey, msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(commitments) {
+		return nil, fmt.Errorf("expected %d signature shares, got %d", len(commitments), len(shares))
+	}
+
+	z := edwards25519.NewScalar()
+	for _, share := range shares {
+		zi, err := decodeScalar(share.Z)
+		if err != nil {
+			return nil, fmt.Errorf("%w from cosigner %d", ErrInvalidShare, share.ID)
+		}
+		z.Add(z, zi)
+	}
+
+	sig := append(pkg.groupR.Bytes(), z.Bytes()...)
+	if ed25519.Verify(pubKey, msg, sig) {
+		return sig, nil
+	}
+
+	var faulty []int
+	for _, share := range shares {
+		if share.ID < 1 || share.ID > len(verificationShares) ||
+			pkg.verifyShare(verificationShares[share.ID-1], share) != nil {
+			faulty = append(faulty, share.ID)
+		}
+	}
+	return nil, fmt.Errorf("%w from cosigners %v", ErrInvalidShare, faulty)
+}
+
+func encodeID(id int) []byte {
+	return []byte{byte(id >> 8), byte(id)}
+}
+
+// GenKey generates a new ed25519 key and splits it into shares, with SplitKey.
+// The returned shares are the only copy of the key, but the whole key and all
+// the shares exist in this process: like SplitKey, GenKey is a trusted dealer
+// that must be ephemeral. It is meant for tests and local setups, the cosigners
+// of a validator should generate their shares with the DKG.
+func GenKey(threshold, total int) ([]KeyShare, error) {
+	_, privKey, err := ed25519.GenerateKey(crypto.CReader())
+	if err != nil {
+		return nil, err
+	}
+	return SplitKey(privKey, threshold, total, crypto.CReader())
+}
@@ -0,0 +1,136 @@
+package threshold
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestBasePoint(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	// the public key of an ed25519 key is its clamped secret scalar times B
+	shares, err := SplitKey(priv, 1, 1, rand.Reader)
+	require.NoError(t, err)
+	assert.Equal(t, priv.PubKey().Bytes(), shares[0].VerificationShares[0])
+	secret, err := decodeScalar(shares[0].Secret)
+	require.NoError(t, err)
+	assert.Equal(t, priv.PubKey().Bytes(), new(edwards25519.Point).ScalarBaseMult(secret).Bytes())
+}
+
+func TestDecodePoint(t *testing.T) {
+	generator := edwards25519.NewGeneratorPoint().Bytes()
+	_, err := decodePoint(generator)
+	require.NoError(t, err)
+
+	// the point of order 2, (0, -1)
+	order2 := []byte{
+		0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+	}
+	torsion, err := new(edwards25519.Point).SetBytes(order2)
+	require.NoError(t, err)
+	mixed := new(edwards25519.Point).Add(edwards25519.NewGeneratorPoint(), torsion).Bytes()
+	// y = 1 + p encodes the identity, non-canonically
+	nonCanonical := []byte{
+		0xee, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+	}
+	negZero := edwards25519.NewIdentityPoint().Bytes()
+	negZero[31] |= 0x80
+
+	for name, bz := range map[string][]byte{
+		"identity":      edwards25519.NewIdentityPoint().Bytes(),
+		"small order":   order2,
+		"mixed order":   mixed,
+		"non canonical": nonCanonical,
+		"negative zero": negZero,
+		"short":         generator[:31],
+	} {
+		_, err := decodePoint(bz)
+		assert.Error(t, err, name)
+	}
+}
+
+func signWith(t *testing.T, shares []KeyShare, msg []byte) ([]Commitment, []SignatureShare) {
+	nonces := make([]*Nonce, len(shares))
+	commitments := make([]Commitment, len(shares))
+	for i, share := range shares {
+		nonce, err := NewNonce(share.ID, rand.Reader)
+		require.NoError(t, err)
+		nonces[i] = nonce
+		commitments[i] = nonce.Commitment()
+	}
+	sigShares := make([]SignatureShare, len(shares))
+	for i, share := range shares {
+		sigShare, err := Sign(share, nonces[i], msg, commitments)
+		require.NoError(t, err)
+		sigShares[i] = sigShare
+	}
+	return commitments, sigShares
+}
+
+func TestThresholdSign(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	pubKey := priv.PubKey()
+	shares, err := SplitKey(priv, 2, 3, rand.Reader)
+	require.NoError(t, err)
+	for _, share := range shares {
+		require.NoError(t, share.ValidateBasic())
+	}
+	msg := []byte("sign me")
+
+	for _, signers := range [][]KeyShare{
+		{shares[0], shares[1]},
+		{shares[0], shares[2]},
+		{shares[1], shares[2]},
+		shares,
+	} {
+		commitments, sigShares := signWith(t, signers, msg)
+		for _, sigShare := range sigShares {
+			assert.NoError(t, VerifyShare(pubKey.Bytes(), shares[sigShare.ID-1].VerificationShares[sigShare.ID-1],
+				msg, commitments, sigShare))
+		}
+		sig, err := Aggregate(pubKey.Bytes(), shares[0].VerificationShares, msg, commitments, sigShares)
+		require.NoError(t, err)
+		assert.True(t, pubKey.VerifySignature(msg, sig))
+	}
+}
+
+func TestThresholdSignErrors(t *testing.T) {
+	shares, err := GenKey(2, 3)
+	require.NoError(t, err)
+	msg := []byte("sign me")
+
+	// not enough commitments
+	nonce, err := NewNonce(1, rand.Reader)
+	require.NoError(t, err)
+	_, err = Sign(shares[0], nonce, msg, []Commitment{nonce.Commitment()})
+	assert.ErrorIs(t, err, ErrInvalidCommitments)
+
+	// nonces can only be used once
+	other, err := NewNonce(2, rand.Reader)
+	require.NoError(t, err)
+	commitments := []Commitment{nonce.Commitment(), other.Commitment()}
+	_, err = Sign(shares[0], nonce, msg, commitments)
+	require.NoError(t, err)
+	_, err = Sign(shares[0], nonce, msg, commitments)
+	assert.ErrorIs(t, err, ErrNonceUsed)
+
+	// unordered commitments
+	nonce, err = NewNonce(1, rand.Reader)
+	require.NoError(t, err)
+	_, err = Sign(shares[0], nonce, msg, []Commitment{other.Commitment(), nonce.Commitment()})
+	assert.ErrorIs(t, err, ErrInvalidCommitments)
+
+	// a faulty share is identified
+	commitments, sigShares := signWith(t, shares[:2], msg)
+	sigShares[1].Z = sigShares[0].Z
+	_, err = Aggregate(shares[0].PubKey, shares[0].VerificationShares, msg, commitments, sigShares)
+	assert.ErrorIs(t, err, ErrInvalidShare)
+	assert.Contains(t, err.Error(), "[2]")
+}
@@ -0,0 +1,101 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// availStatusFinished is the status of the blocks whose data the Avail light
+// client verified as available.
+const availStatusFinished = "finished"
+
+// AvailClient submits the blobs to Avail, through the HTTP API (v2) of an
+// Avail light client. The application ID of the submissions is the one the
+// light client is started with.
+type AvailClient struct {
+	address string
+	client  *http.Client
+}
+
+var _ Client = (*AvailClient)(nil)
+
+// NewAvailClient returns a client of the Avail light client API at address.
+func NewAvailClient(address string, timeout time.Duration) *AvailClient {
+	return &AvailClient{
+		address: strings.TrimSuffix(address, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Layer implements Client.
+func (c *AvailClient) Layer() string {
+	return cfg.DALayerAvail
+}
+
+// Submit implements Client. The light client returns once the submission is
+// included, and the commitment is the hash of its extrinsic.
+func (c *AvailClient) Submit(ctx context.Context, blob []byte) (uint64, []byte, error) {
+	body, err := json.Marshal(struct {
+		Data []byte `json:"data"`
+	}{blob})
+	if err != nil {
+		return 0, nil, err
+	}
+	var res struct {
+		BlockNumber uint64 `json:"block_number"`
+		Hash        string `json:"hash"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/v2/submit", body, &res); err != nil {
+		return 0, nil, err
+	}
+	hash, err := hex.DecodeString(strings.TrimPrefix(res.Hash, "0x"))
+	if err != nil || len(hash) == 0 {
+		return 0, nil, fmt.Errorf("invalid extrinsic hash %q", res.Hash)
+	}
+	return res.BlockNumber, hash, nil
+}
+
+// Included implements Client. The light client only reports whether the data
+// of the block is available, the inclusion of the extrinsic in the block was
+// reported by Submit.
+func (c *AvailClient) Included(ctx context.Context, height uint64, _ []byte) (bool, error) {
+	var res struct {
+		Status string `json:"status"`
+	}
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/blocks/%d", height), nil, &res); err != nil {
+		return false, err
+	}
+	return res.Status == availStatusFinished, nil
+}
+
+func (c *AvailClient) do(ctx context.Context, method, path string, body []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.address+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%s %s: decoding the response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package da
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/libs/protoio"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// A batch of blocks is submitted as a single blob, holding the blocks in
+// ascending height order, each encoded as a length-delimited protobuf Block.
+
+// encodeBlock returns the encoding of the block in a batch.
+func encodeBlock(block *types.Block) ([]byte, error) {
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return protoio.MarshalDelimited(pb)
+}
+
+// DecodeBatch returns the blocks of a batch retrieved from the DA layer.
+func DecodeBatch(blob []byte) ([]*types.Block, error) {
+	r := protoio.NewDelimitedReader(bytes.NewReader(blob), types.MaxBlockSizeBytes)
+	var blocks []*types.Block
+	for {
+		pb := new(cmtproto.Block)
+		if _, err := r.ReadMsg(pb); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("reading block %d of the batch: %w", len(blocks), err)
+		}
+		block, err := types.BlockFromProto(pb)
+		if err != nil {
+			return nil, fmt.Errorf("decoding block %d of the batch: %w", len(blocks), err)
+		}
+		if n := len(blocks); n > 0 && block.Height != blocks[n-1].Height+1 {
+			return nil, fmt.Errorf("block %d of the batch has height %d, expected %d",
+				n, block.Height, blocks[n-1].Height+1)
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return nil, errors.New("empty batch")
+	}
+	return blocks, nil
+}
@@ -0,0 +1,165 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// celestiaNamespaceSize is the size of a Celestia namespace: a version byte,
+// followed by the 28 bytes ID. The IDs of the version 0 namespaces are 18 zero
+// bytes followed by 10 user-chosen bytes.
+const celestiaNamespaceSize = 29
+
+// CelestiaClient submits the blobs to Celestia, through the JSON-RPC API of a
+// celestia-node.
+type CelestiaClient struct {
+	address   string
+	authToken string
+	namespace []byte
+	gasPrice  float64
+	client    *http.Client
+	nextID    uint64
+}
+
+var _ Client = (*CelestiaClient)(nil)
+
+// NewCelestiaClient returns a client of the celestia-node RPC at address,
+// submitting the blobs in the version 0 namespace with the 10 bytes
+// namespaceID. If gasPrice is 0, the celestia-node estimates it.
+func NewCelestiaClient(address, authToken string, namespaceID []byte, gasPrice float64,
+	timeout time.Duration,
+) *CelestiaClient {
+	namespace := make([]byte, celestiaNamespaceSize)
+	copy(namespace[celestiaNamespaceSize-len(namespaceID):], namespaceID)
+	return &CelestiaClient{
+		address:   address,
+		authToken: authToken,
+		namespace: namespace,
+		gasPrice:  gasPrice,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// celestiaBlob is the JSON encoding of a blob by the celestia-node. The
+// commitment is computed by the celestia-node.
+type celestiaBlob struct {
+	Namespace    []byte `json:"namespace"`
+	Data         []byte `json:"data"`
+	ShareVersion uint32 `json:"share_version"`
+	Commitment   []byte `json:"commitment,omitempty"`
+}
+
+// celestiaTxConfig is the JSON encoding of the options of a blob transaction.
+type celestiaTxConfig struct {
+	GasPrice      float64 `json:"gas_price"`
+	IsGasPriceSet bool    `json:"is_gas_price_set"`
+}
+
+// Layer implements Client.
+func (c *CelestiaClient) Layer() string {
+	return cfg.DALayerCelestia
+}
+
+// Submit implements Client. The celestia-node returns once the blob is
+// included, then the commitment is looked up among the blobs of the block.
+func (c *CelestiaClient) Submit(ctx context.Context, blob []byte) (uint64, []byte, error) {
+	var txConfig *celestiaTxConfig
+	if c.gasPrice > 0 {
+		txConfig = &celestiaTxConfig{GasPrice: c.gasPrice, IsGasPriceSet: true}
+	}
+	var height uint64
+	blobs := []celestiaBlob{{Namespace: c.namespace, Data: blob}}
+	if err := c.call(ctx, "blob.Submit", &height, blobs, txConfig); err != nil {
+		return 0, nil, err
+	}
+
+	var included []celestiaBlob
+	if err := c.call(ctx, "blob.GetAll", &included, height, [][]byte{c.namespace}); err != nil {
+		return 0, nil, fmt.Errorf("getting the blobs of block %d: %w", height, err)
+	}
+	for _, b := range included {
+		if bytes.Equal(b.Data, blob) {
+			return height, b.Commitment, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("blob not found in block %d", height)
+}
+
+// Included implements Client.
+func (c *CelestiaClient) Included(ctx context.Context, height uint64, commitment []byte) (bool, error) {
+	var b celestiaBlob
+	err := c.call(ctx, "blob.Get", &b, height, c.namespace, commitment)
+	var rpcErr *celestiaRPCError
+	if errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "not found") {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(b.Commitment, commitment), nil
+}
+
+type celestiaRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *celestiaRPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// call calls the JSON-RPC method with positional params, and decodes its
+// result into result.
+func (c *CelestiaClient) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      atomic.AddUint64(&c.nextID, 1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s: %s", method, resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var res struct {
+		Result json.RawMessage   `json:"result"`
+		Error  *celestiaRPCError `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("%s: decoding the response: %w", method, err)
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%s: %w", method, res.Error)
+	}
+	if err := json.Unmarshal(res.Result, result); err != nil {
+		return fmt.Errorf("%s: decoding the result: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package da submits the blocks committed by the node to a data availability
+// (DA) layer, in batches, so that anyone can retrieve and check the chain
+// without trusting its sequencer, and keeps track of the location of each
+// block on the DA layer.
+package da
+
+import (
+	"context"
+	"fmt"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// Client submits blobs to a DA layer and checks their inclusion in it.
+type Client interface {
+	// Layer returns the name of the DA layer, e.g. "celestia".
+	Layer() string
+
+	// Submit submits the blob, and returns the height of the DA block which
+	// includes it and the commitment identifying the blob in that block.
+	Submit(ctx context.Context, blob []byte) (height uint64, commitment []byte, err error)
+
+	// Included returns whether the blob identified by the commitment is
+	// included and available in the DA block at height.
+	Included(ctx context.Context, height uint64, commitment []byte) (bool, error)
+}
+
+// NewClient returns the client of the DA layer configured.
+func NewClient(config *cfg.DAConfig) (Client, error) {
+	switch config.Layer {
+	case cfg.DALayerCelestia:
+		return NewCelestiaClient(config.Address, config.AuthToken, config.NamespaceBytes(),
+			config.GasPrice, config.Timeout), nil
+	case cfg.DALayerAvail:
+		return NewAvailClient(config.Address, config.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown DA layer %q", config.Layer)
+	}
+}
@@ -0,0 +1,135 @@
+package da_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/da"
+)
+
+func TestCelestiaClient(t *testing.T) {
+	namespaceID := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 7}
+	commitment := []byte{1, 2, 3}
+	var submitted []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result interface{}
+		switch req.Method {
+		case "blob.Submit":
+			var blobs []struct {
+				Namespace []byte `json:"namespace"`
+				Data      []byte `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(req.Params[0], &blobs))
+			require.Len(t, blobs, 1)
+			assert.Len(t, blobs[0].Namespace, 29)
+			assert.Equal(t, namespaceID, blobs[0].Namespace[19:])
+			assert.JSONEq(t, `{"gas_price":0.002,"is_gas_price_set":true}`, string(req.Params[1]))
+			submitted = blobs[0].Data
+			result = 42
+		case "blob.GetAll":
+			assert.Equal(t, "42", string(req.Params[0]))
+			result = []map[string]interface{}{
+				{"data": []byte("other"), "commitment": []byte{9}},
+				{"data": submitted, "commitment": commitment},
+			}
+		case "blob.Get":
+			if string(req.Params[0]) != "42" {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"id": req.ID, "error": map[string]interface{}{"code": 1, "message": "blob: not found"},
+				})
+				return
+			}
+			result = map[string]interface{}{"data": submitted, "commitment": commitment}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": req.ID, "result": result})
+	}))
+	defer srv.Close()
+
+	client := da.NewCelestiaClient(srv.URL, "token", namespaceID, 0.002, time.Second)
+	assert.Equal(t, cfg.DALayerCelestia, client.Layer())
+	height, c, err := client.Submit(context.Background(), []byte("batch"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, height)
+	assert.Equal(t, commitment, c)
+	assert.Equal(t, []byte("batch"), submitted)
+
+	included, err := client.Included(context.Background(), 42, commitment)
+	require.NoError(t, err)
+	assert.True(t, included)
+	included, err = client.Included(context.Background(), 43, commitment)
+	require.NoError(t, err)
+	assert.False(t, included)
+}
+
+func TestAvailClient(t *testing.T) {
+	status := "verifying-data"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/submit":
+			assert.Equal(t, http.MethodPost, r.Method)
+			var req struct {
+				Data []byte `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []byte("batch"), req.Data)
+			_, _ = w.Write([]byte(`{"block_number":7,"block_hash":"0xaa","hash":"0x0102","index":1}`))
+		case "/v2/blocks/7":
+			_, _ = w.Write([]byte(`{"status":"` + status + `","confidence":99.9}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := da.NewAvailClient(srv.URL+"/", time.Second)
+	height, commitment, err := client.Submit(context.Background(), []byte("batch"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, height)
+	assert.Equal(t, []byte{1, 2}, commitment)
+
+	included, err := client.Included(context.Background(), 7, commitment)
+	require.NoError(t, err)
+	assert.False(t, included)
+	status = "finished"
+	included, err = client.Included(context.Background(), 7, commitment)
+	require.NoError(t, err)
+	assert.True(t, included)
+
+	_, err = client.Included(context.Background(), 8, commitment)
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestNewClient(t *testing.T) {
+	config := cfg.TestDAConfig()
+	_, err := da.NewClient(config)
+	assert.Error(t, err)
+
+	config.Layer = cfg.DALayerCelestia
+	config.Namespace = "00000000000000000001"
+	client, err := da.NewClient(config)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.DALayerCelestia, client.Layer())
+
+	config.Layer = cfg.DALayerAvail
+	client, err = da.NewClient(config)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.DALayerAvail, client.Layer())
+}
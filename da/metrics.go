@@ -0,0 +1,93 @@
+package da
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "da"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height up to which all the blocks are included in the DA layer.
+	SubmittedHeight metrics.Gauge
+	// Number of committed blocks not yet included in the DA layer.
+	PendingBlocks metrics.Gauge
+	// Number of failed submissions and inclusion checks.
+	Failures metrics.Counter
+	// Number of blocks of the batches submitted.
+	BatchSize metrics.Histogram
+	// Size in bytes of the blobs submitted.
+	BlobSize metrics.Histogram
+	// Time taken to include a batch, from its submission.
+	InclusionTime metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SubmittedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "submitted_height",
+			Help:      "Height up to which all the blocks are included in the DA layer.",
+		}, labels).With(labelsAndValues...),
+		PendingBlocks: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pending_blocks",
+			Help:      "Number of committed blocks not yet included in the DA layer.",
+		}, labels).With(labelsAndValues...),
+		Failures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "failures",
+			Help:      "Number of failed submissions and inclusion checks.",
+		}, labels).With(labelsAndValues...),
+		BatchSize: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "batch_size",
+			Help:      "Number of blocks of the batches submitted.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 10),
+		}, labels).With(labelsAndValues...),
+		BlobSize: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "blob_size_bytes",
+			Help:      "Size in bytes of the blobs submitted.",
+			Buckets:   stdprometheus.ExponentialBuckets(1024, 2, 12),
+		}, labels).With(labelsAndValues...),
+		InclusionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "inclusion_time_seconds",
+			Help:      "Time taken to include a batch, from its submission.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 10),
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SubmittedHeight: discard.NewGauge(),
+		PendingBlocks:   discard.NewGauge(),
+		Failures:        discard.NewCounter(),
+		BatchSize:       discard.NewHistogram(),
+		BlobSize:        discard.NewHistogram(),
+		InclusionTime:   discard.NewHistogram(),
+	}
+}
@@ -0,0 +1,219 @@
+package da
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockStore is the block store used by the Submitter.
+type BlockStore interface {
+	Base() int64
+	Height() int64
+	LoadBlock(height int64) *types.Block
+	DAHeight() (int64, error)
+	SaveDAPointer(ptr *types.DAPointer) error
+}
+
+// Submitter is a service submitting the committed blocks to a DA layer, in
+// batches of consecutive blocks, one at a time. Once a batch is included, the
+// pointer to it is saved in the block store for each of its blocks, and the
+// next batch is submitted. A batch which isn't included in time is submitted
+// again.
+type Submitter struct {
+	service.BaseService
+
+	client           Client
+	blockStore       BlockStore
+	interval         time.Duration
+	inclusionTimeout time.Duration
+	maxBlocks        int
+	maxBytes         int
+	metrics          *Metrics
+
+	// the batch submitted and not yet included, accessed by the run routine
+	pending     *types.DAPointer
+	submittedAt time.Time
+}
+
+// SubmitterOption sets an optional parameter on the Submitter.
+type SubmitterOption func(*Submitter)
+
+// NewSubmitter returns a Submitter of the blocks of blockStore to the DA layer
+// of client.
+func NewSubmitter(
+	client Client,
+	blockStore BlockStore,
+	config *cfg.DAConfig,
+	options ...SubmitterOption,
+) *Submitter {
+	s := &Submitter{
+		client:           client,
+		blockStore:       blockStore,
+		interval:         config.SubmitInterval,
+		inclusionTimeout: config.InclusionTimeout,
+		maxBlocks:        config.BatchMaxBlocks,
+		maxBytes:         config.BatchMaxBytes,
+		metrics:          NopMetrics(),
+	}
+	s.BaseService = *service.NewBaseService(nil, "DASubmitter", s)
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) SubmitterOption {
+	return func(s *Submitter) { s.metrics = metrics }
+}
+
+// OnStart implements service.Service.
+func (s *Submitter) OnStart() error {
+	daHeight, err := s.blockStore.DAHeight()
+	if err != nil {
+		return fmt.Errorf("loading the DA height: %w", err)
+	}
+	s.metrics.SubmittedHeight.Set(float64(daHeight))
+	go s.run()
+	return nil
+}
+
+func (s *Submitter) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.Quit()
+		cancel()
+	}()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.submit(ctx)
+		case <-s.Quit():
+			return
+		}
+	}
+}
+
+// submit submits the batches of the blocks committed, as long as they are
+// included right away.
+func (s *Submitter) submit(ctx context.Context) {
+	for ctx.Err() == nil {
+		if s.pending != nil {
+			included, err := s.confirm(ctx)
+			if err != nil {
+				s.metrics.Failures.Add(1)
+				s.Logger.Error("failed to check the inclusion of the batch", "batch", s.pending, "err", err)
+				return
+			}
+			if !included {
+				return
+			}
+		}
+		submitted, err := s.submitBatch(ctx)
+		if err != nil {
+			s.metrics.Failures.Add(1)
+			s.Logger.Error("failed to submit the batch", "err", err)
+			return
+		}
+		if !submitted {
+			return
+		}
+	}
+}
+
+// confirm checks the inclusion of the pending batch, and saves its pointer
+// once it's included. It returns whether the next batch can be submitted.
+func (s *Submitter) confirm(ctx context.Context) (bool, error) {
+	ptr := s.pending
+	included, err := s.client.Included(ctx, ptr.Height, ptr.Commitment)
+	if err != nil {
+		return false, err
+	}
+	if !included {
+		if time.Since(s.submittedAt) < s.inclusionTimeout {
+			return false, nil
+		}
+		s.Logger.Error("batch not included in time, submitting it again", "batch", ptr)
+		s.pending = nil
+		return true, nil
+	}
+
+	if err := s.blockStore.SaveDAPointer(ptr); err != nil {
+		return false, fmt.Errorf("saving the DA pointer: %w", err)
+	}
+	s.metrics.InclusionTime.Observe(time.Since(s.submittedAt).Seconds())
+	s.metrics.SubmittedHeight.Set(float64(ptr.LastHeight))
+	s.Logger.Info("batch included", "first", ptr.FirstHeight, "last", ptr.LastHeight,
+		"da_height", ptr.Height)
+	s.pending = nil
+	return true, nil
+}
+
+// submitBatch submits the batch of the blocks following the ones submitted. It
+// returns whether a batch was submitted.
+func (s *Submitter) submitBatch(ctx context.Context) (bool, error) {
+	daHeight, err := s.blockStore.DAHeight()
+	if err != nil {
+		return false, err
+	}
+	base, height := s.blockStore.Base(), s.blockStore.Height()
+	first := daHeight + 1
+	if first < base {
+		if daHeight > 0 {
+			s.Logger.Error("blocks pruned before their submission, skipping them",
+				"from", first, "to", base-1)
+		}
+		first = base
+	}
+	if base == 0 || first > height {
+		s.metrics.PendingBlocks.Set(0)
+		return false, nil
+	}
+	s.metrics.PendingBlocks.Set(float64(height - first + 1))
+
+	var blob []byte
+	last := first - 1
+	for h := first; h <= height && last-first+1 < int64(s.maxBlocks); h++ {
+		block := s.blockStore.LoadBlock(h)
+		if block == nil {
+			return false, fmt.Errorf("block %d not found", h)
+		}
+		bz, err := encodeBlock(block)
+		if err != nil {
+			return false, fmt.Errorf("encoding block %d: %w", h, err)
+		}
+		// a block larger than the maximum is submitted alone
+		if len(blob) > 0 && len(blob)+len(bz) > s.maxBytes {
+			break
+		}
+		blob = append(blob, bz...)
+		last = h
+	}
+
+	submittedAt := time.Now()
+	daBlock, commitment, err := s.client.Submit(ctx, blob)
+	if err != nil {
+		return false, fmt.Errorf("submitting blocks %d to %d: %w", first, last, err)
+	}
+	s.pending = &types.DAPointer{
+		Layer:       s.client.Layer(),
+		Height:      daBlock,
+		Commitment:  commitment,
+		FirstHeight: first,
+		LastHeight:  last,
+	}
+	s.submittedAt = submittedAt
+	s.metrics.BatchSize.Observe(float64(last - first + 1))
+	s.metrics.BlobSize.Observe(float64(len(blob)))
+	s.Logger.Debug("batch submitted", "batch", s.pending)
+	return true, nil
+}
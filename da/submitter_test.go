@@ -0,0 +1,155 @@
+package da
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// mockClient includes the blobs submitted in DA blocks of their own.
+type mockClient struct {
+	blobs     [][]byte
+	included  bool
+	submitErr error
+}
+
+func (c *mockClient) Layer() string { return "mock" }
+
+func (c *mockClient) Submit(_ context.Context, blob []byte) (uint64, []byte, error) {
+	if c.submitErr != nil {
+		return 0, nil, c.submitErr
+	}
+	c.blobs = append(c.blobs, blob)
+	commitment := sha256.Sum256(blob)
+	return uint64(len(c.blobs)), commitment[:], nil
+}
+
+func (c *mockClient) Included(_ context.Context, height uint64, commitment []byte) (bool, error) {
+	return c.included, nil
+}
+
+func makeBlockStore(height int64) *store.BlockStore {
+	bs := store.NewBlockStore(dbm.NewMemDB())
+	for h := int64(1); h <= height; h++ {
+		block := types.MakeBlock(h, []types.Tx{types.Tx("tx")}, new(types.Commit), nil)
+		block.ProposerAddress = cmtrand.Bytes(crypto.AddressSize)
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		bs.SaveBlock(block, partSet, &types.Commit{Height: h})
+	}
+	return bs
+}
+
+func newTestSubmitter(client Client, bs BlockStore, maxBlocks int) *Submitter {
+	config := cfg.TestDAConfig()
+	config.BatchMaxBlocks = maxBlocks
+	s := NewSubmitter(client, bs, config)
+	s.SetLogger(log.TestingLogger())
+	return s
+}
+
+func TestSubmitter(t *testing.T) {
+	bs := makeBlockStore(5)
+	client := &mockClient{included: true}
+	s := newTestSubmitter(client, bs, 2)
+
+	s.submit(context.Background())
+	require.Len(t, client.blobs, 3)
+	heights := [][]int64{{1, 2}, {3, 4}, {5}}
+	for i, blob := range client.blobs {
+		blocks, err := DecodeBatch(blob)
+		require.NoError(t, err)
+		require.Len(t, blocks, len(heights[i]))
+		for j, block := range blocks {
+			assert.Equal(t, heights[i][j], block.Height)
+			assert.Equal(t, bs.LoadBlock(block.Height).Hash(), block.Hash())
+		}
+	}
+
+	for h := int64(1); h <= 5; h++ {
+		ptr := bs.LoadDAPointer(h)
+		require.NotNil(t, ptr)
+		assert.Equal(t, "mock", ptr.Layer)
+		assert.True(t, ptr.Contains(h))
+	}
+	assert.EqualValues(t, 3, bs.LoadDAPointer(5).Height)
+	daHeight, err := bs.DAHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, daHeight)
+
+	// nothing is submitted until a block is committed
+	s.submit(context.Background())
+	assert.Len(t, client.blobs, 3)
+}
+
+func TestSubmitterInclusion(t *testing.T) {
+	bs := makeBlockStore(3)
+	client := &mockClient{}
+	s := newTestSubmitter(client, bs, 100)
+
+	// the batch isn't included yet
+	s.submit(context.Background())
+	require.Len(t, client.blobs, 1)
+	require.NotNil(t, s.pending)
+	s.submit(context.Background())
+	assert.Len(t, client.blobs, 1)
+	assert.Nil(t, bs.LoadDAPointer(1))
+
+	// the batch is submitted again once the inclusion times out
+	s.submittedAt = time.Now().Add(-s.inclusionTimeout)
+	s.submit(context.Background())
+	require.Len(t, client.blobs, 2)
+	assert.Equal(t, client.blobs[0], client.blobs[1])
+
+	client.included = true
+	s.submit(context.Background())
+	assert.Nil(t, s.pending)
+	ptr := bs.LoadDAPointer(3)
+	require.NotNil(t, ptr)
+	assert.EqualValues(t, 2, ptr.Height)
+	assert.EqualValues(t, 1, ptr.FirstHeight)
+}
+
+func TestSubmitterFailure(t *testing.T) {
+	bs := makeBlockStore(2)
+	client := &mockClient{included: true, submitErr: errors.New("out of funds")}
+	s := newTestSubmitter(client, bs, 100)
+
+	s.submit(context.Background())
+	assert.Nil(t, s.pending)
+	assert.Nil(t, bs.LoadDAPointer(1))
+
+	client.submitErr = nil
+	s.submit(context.Background())
+	assert.NotNil(t, bs.LoadDAPointer(2))
+}
+
+func TestDecodeBatch(t *testing.T) {
+	_, err := DecodeBatch(nil)
+	assert.Error(t, err)
+
+	bs := makeBlockStore(3)
+	var blob []byte
+	for _, h := range []int64{1, 3} {
+		bz, err := encodeBlock(bs.LoadBlock(h))
+		require.NoError(t, err)
+		blob = append(blob, bz...)
+	}
+	_, err = DecodeBatch(blob)
+	assert.ErrorContains(t, err, "expected 2")
+
+	_, err = DecodeBatch(blob[:len(blob)-1])
+	assert.Error(t, err)
+}
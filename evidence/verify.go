@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/batch"
 	"github.com/tendermint/tendermint/light"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -211,6 +214,9 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	va := e.VoteA.ToProto()
 	vb := e.VoteB.ToProto()
 	// Signatures must be valid
+	if bv, ok := batch.CreateBatchVerifier(pubKey); ok && batch.MaxBatchSize() >= 2 {
+		return verifyDuplicateVoteSignaturesBatch(bv, chainID, pubKey, va, vb, e)
+	}
 	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, va), e.VoteA.Signature) {
 		return fmt.Errorf("verifying VoteA: %w", types.ErrVoteInvalidSignature)
 	}
@@ -221,6 +227,30 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	return nil
 }
 
+// verifyDuplicateVoteSignaturesBatch verifies the signatures of both votes of
+// the evidence with a batch verifier.
+func verifyDuplicateVoteSignaturesBatch(
+	bv crypto.BatchVerifier,
+	chainID string,
+	pubKey crypto.PubKey,
+	va, vb *cmtproto.Vote,
+	e *types.DuplicateVoteEvidence,
+) error {
+	if err := bv.Add(pubKey, types.VoteSignBytes(chainID, va), e.VoteA.Signature); err != nil {
+		return fmt.Errorf("verifying VoteA: %w", types.ErrVoteInvalidSignature)
+	}
+	if err := bv.Add(pubKey, types.VoteSignBytes(chainID, vb), e.VoteB.Signature); err != nil {
+		return fmt.Errorf("verifying VoteB: %w", types.ErrVoteInvalidSignature)
+	}
+	if ok, valid := bv.Verify(); !ok {
+		if !valid[0] {
+			return fmt.Errorf("verifying VoteA: %w", types.ErrVoteInvalidSignature)
+		}
+		return fmt.Errorf("verifying VoteB: %w", types.ErrVoteInvalidSignature)
+	}
+	return nil
+}
+
 // validateABCIEvidence validates the ABCI component of the light client attack
 // evidence i.e voting power and byzantine validators
 func validateABCIEvidence(
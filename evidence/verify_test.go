@@ -11,6 +11,7 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/evidence/mocks"
@@ -449,6 +450,38 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyDuplicateVoteEvidenceSecp256k1(t *testing.T) {
+	val := types.NewMockPVWithParams(secp256k1.GenPrivKey(), false, false)
+	val2 := types.NewMockPVWithParams(secp256k1.GenPrivKey(), false, false)
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1)})
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+
+	const chainID = "mychain"
+
+	vote1 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime)
+	vote2 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime)
+	badVote := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime)
+	bv := badVote.ToProto()
+	require.NoError(t, val2.SignVote(chainID, bv))
+	badVote.Signature = bv.Signature
+
+	ev := &types.DuplicateVoteEvidence{
+		VoteA:            vote1,
+		VoteB:            vote2,
+		ValidatorPower:   1,
+		TotalVotingPower: 1,
+		Timestamp:        defaultEvidenceTime,
+	}
+	assert.NoError(t, evidence.VerifyDuplicateVote(ev, chainID, valSet))
+
+	ev.VoteB = badVote
+	err := evidence.VerifyDuplicateVote(ev, chainID, valSet)
+	assert.ErrorIs(t, err, types.ErrVoteInvalidSignature)
+	assert.Contains(t, err.Error(), "VoteB")
+}
+
 func makeLunaticEvidence(
 	t *testing.T,
 	height, commonHeight int64,
@@ -0,0 +1,231 @@
+// Package finality tracks how final the blocks of the chain are: soft once
+// committed by the sequencer, DA-included once included in the data
+// availability layer, and firm once finalized by the settlement hub, after
+// its dispute period. Downstream apps can gate operations which can't be
+// reverted, e.g. withdrawals, on the firm finality.
+package finality
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The levels of finality of a block, from the weakest to the strongest.
+const (
+	LevelSoft       = "soft"
+	LevelDAIncluded = "da_included"
+	LevelFirm       = "firm"
+)
+
+// localInterval is the interval between the updates from the block store.
+const localInterval = time.Second
+
+// BlockStore is the block store used by the Tracker.
+type BlockStore interface {
+	Height() int64
+	DAHeight() (int64, error)
+}
+
+// HubClient queries the settlement of the blocks by the hub.
+type HubClient interface {
+	// LatestHeight returns the latest height accepted by the hub, or the
+	// latest height finalized by it if finalized is true, or 0 if none is.
+	LatestHeight(ctx context.Context, finalized bool) (int64, error)
+}
+
+// Publisher publishes the changes of the finality heights.
+type Publisher interface {
+	PublishEventFinality(types.EventDataFinality) error
+}
+
+// Heights are the latest heights at each level of finality. Every block up
+// to a height has at least its level.
+type Heights struct {
+	Soft       int64 `json:"soft_height"`
+	DAIncluded int64 `json:"da_included_height"`
+	Firm       int64 `json:"firm_height"`
+}
+
+// Level returns the level of finality of the block at height, or an empty
+// string if it isn't committed yet.
+func (h Heights) Level(height int64) string {
+	switch {
+	case height <= h.Firm:
+		return LevelFirm
+	case height <= h.DAIncluded:
+		return LevelDAIncluded
+	case height <= h.Soft:
+		return LevelSoft
+	default:
+		return ""
+	}
+}
+
+// Tracker is a service tracking the finality heights of the chain, from the
+// block store and, if set, from the settlement hub, and publishing their
+// changes. A block accepted by the hub is DA-included, since its state update
+// points to its DA batch, so the accepted blocks are DA-included even if this
+// node didn't submit them to the DA layer itself.
+type Tracker struct {
+	service.BaseService
+
+	blockStore  BlockStore
+	publisher   Publisher
+	hub         HubClient
+	hubInterval time.Duration
+
+	mtx     cmtsync.RWMutex
+	heights Heights
+
+	// accessed by the run routine
+	localDA int64 // latest height included in the DA layer by this node
+	hubDA   int64 // latest height accepted by the hub
+}
+
+// TrackerOption sets an optional parameter on the Tracker.
+type TrackerOption func(*Tracker)
+
+// NewTracker returns a Tracker of the finality of the blocks of blockStore,
+// publishing the changes of the heights to publisher.
+func NewTracker(blockStore BlockStore, publisher Publisher, options ...TrackerOption) *Tracker {
+	t := &Tracker{
+		blockStore: blockStore,
+		publisher:  publisher,
+	}
+	t.BaseService = *service.NewBaseService(nil, "FinalityTracker", t)
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// WithHub makes the Tracker query the blocks accepted and finalized by the
+// settlement hub, every interval.
+func WithHub(hub HubClient, interval time.Duration) TrackerOption {
+	return func(t *Tracker) {
+		t.hub = hub
+		t.hubInterval = interval
+	}
+}
+
+// Heights returns the latest heights at each level of finality.
+func (t *Tracker) Heights() Heights {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.heights
+}
+
+// OnStart implements service.Service.
+func (t *Tracker) OnStart() error {
+	if err := t.updateLocal(); err != nil {
+		return err
+	}
+	go t.run()
+	return nil
+}
+
+func (t *Tracker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-t.Quit()
+		cancel()
+	}()
+
+	var hubTicks <-chan time.Time
+	if t.hub != nil {
+		hubTicker := time.NewTicker(t.hubInterval)
+		defer hubTicker.Stop()
+		hubTicks = hubTicker.C
+		t.updateFromHub(ctx)
+	}
+	ticker := time.NewTicker(localInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.updateLocal(); err != nil {
+				t.Logger.Error("failed to load the finality heights", "err", err)
+			}
+		case <-hubTicks:
+			t.updateFromHub(ctx)
+		case <-t.Quit():
+			return
+		}
+	}
+}
+
+// updateLocal updates the heights committed and included in the DA layer by
+// this node.
+func (t *Tracker) updateLocal() error {
+	daHeight, err := t.blockStore.DAHeight()
+	if err != nil {
+		return fmt.Errorf("loading the DA height: %w", err)
+	}
+	t.localDA = daHeight
+	t.update(t.blockStore.Height(), t.Heights().Firm)
+	return nil
+}
+
+// updateFromHub updates the heights accepted and finalized by the hub.
+func (t *Tracker) updateFromHub(ctx context.Context) {
+	accepted, err := t.hub.LatestHeight(ctx, false)
+	if err != nil {
+		if ctx.Err() == nil {
+			t.Logger.Error("failed to query the latest height accepted by the hub", "err", err)
+		}
+		return
+	}
+	finalized, err := t.hub.LatestHeight(ctx, true)
+	if err != nil {
+		if ctx.Err() == nil {
+			t.Logger.Error("failed to query the latest height finalized by the hub", "err", err)
+		}
+		return
+	}
+	t.hubDA = accepted
+	t.update(t.blockStore.Height(), finalized)
+}
+
+// update sets the heights, and publishes them if they changed. The heights
+// never decrease, and a block has at least the level of finality of the ones
+// above it.
+func (t *Tracker) update(soft, firm int64) {
+	t.mtx.Lock()
+	old := t.heights
+	h := Heights{
+		Soft:       max64(old.Soft, soft),
+		DAIncluded: max64(old.DAIncluded, max64(t.localDA, t.hubDA)),
+		Firm:       max64(old.Firm, firm),
+	}
+	h.DAIncluded = max64(h.DAIncluded, h.Firm)
+	h.Soft = max64(h.Soft, h.DAIncluded)
+	t.heights = h
+	t.mtx.Unlock()
+
+	if h == old {
+		return
+	}
+	if h.Firm > old.Firm {
+		t.Logger.Debug("blocks finalized", "height", h.Firm)
+	}
+	if err := t.publisher.PublishEventFinality(types.EventDataFinality{
+		SoftHeight:       h.Soft,
+		DAIncludedHeight: h.DAIncluded,
+		FirmHeight:       h.Firm,
+	}); err != nil {
+		t.Logger.Error("failed to publish the finality heights", "err", err)
+	}
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,110 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mockBlockStore struct {
+	height, daHeight int64
+}
+
+func (bs *mockBlockStore) Height() int64            { return bs.height }
+func (bs *mockBlockStore) DAHeight() (int64, error) { return bs.daHeight, nil }
+
+type mockHub struct {
+	accepted, finalized int64
+	err                 error
+}
+
+func (h *mockHub) LatestHeight(_ context.Context, finalized bool) (int64, error) {
+	if finalized {
+		return h.finalized, h.err
+	}
+	return h.accepted, h.err
+}
+
+type mockPublisher struct {
+	mtx    cmtsync.Mutex
+	events []types.EventDataFinality
+}
+
+func (p *mockPublisher) PublishEventFinality(data types.EventDataFinality) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.events = append(p.events, data)
+	return nil
+}
+
+func TestHeightsLevel(t *testing.T) {
+	h := Heights{Soft: 10, DAIncluded: 6, Firm: 3}
+	assert.Equal(t, LevelFirm, h.Level(1))
+	assert.Equal(t, LevelFirm, h.Level(3))
+	assert.Equal(t, LevelDAIncluded, h.Level(4))
+	assert.Equal(t, LevelDAIncluded, h.Level(6))
+	assert.Equal(t, LevelSoft, h.Level(7))
+	assert.Equal(t, LevelSoft, h.Level(10))
+	assert.Equal(t, "", h.Level(11))
+}
+
+func TestTrackerUpdates(t *testing.T) {
+	blockStore := &mockBlockStore{height: 10, daHeight: 4}
+	hub := &mockHub{}
+	publisher := &mockPublisher{}
+	tracker := NewTracker(blockStore, publisher, WithHub(hub, time.Minute))
+	tracker.SetLogger(log.TestingLogger())
+	ctx := context.Background()
+
+	require.NoError(t, tracker.updateLocal())
+	assert.Equal(t, Heights{Soft: 10, DAIncluded: 4}, tracker.Heights())
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, types.EventDataFinality{SoftHeight: 10, DAIncludedHeight: 4}, publisher.events[0])
+
+	// nothing changed
+	require.NoError(t, tracker.updateLocal())
+	assert.Len(t, publisher.events, 1)
+
+	// the blocks accepted by the hub are DA-included
+	hub.accepted, hub.finalized = 6, 2
+	tracker.updateFromHub(ctx)
+	assert.Equal(t, Heights{Soft: 10, DAIncluded: 6, Firm: 2}, tracker.Heights())
+
+	// a failed query changes nothing
+	hub.accepted, hub.finalized, hub.err = 8, 8, errors.New("unreachable")
+	tracker.updateFromHub(ctx)
+	assert.Equal(t, Heights{Soft: 10, DAIncluded: 6, Firm: 2}, tracker.Heights())
+
+	// the finalized blocks are DA-included and committed, and the heights
+	// never decrease
+	hub.accepted, hub.finalized, hub.err = 5, 12, nil
+	tracker.updateFromHub(ctx)
+	assert.Equal(t, Heights{Soft: 12, DAIncluded: 12, Firm: 12}, tracker.Heights())
+
+	blockStore.height, blockStore.daHeight = 15, 14
+	require.NoError(t, tracker.updateLocal())
+	assert.Equal(t, Heights{Soft: 15, DAIncluded: 14, Firm: 12}, tracker.Heights())
+	assert.Len(t, publisher.events, 4)
+}
+
+func TestTrackerService(t *testing.T) {
+	blockStore := &mockBlockStore{height: 3, daHeight: 2}
+	publisher := &mockPublisher{}
+	tracker := NewTracker(blockStore, publisher, WithHub(&mockHub{accepted: 2, finalized: 1}, time.Minute))
+	tracker.SetLogger(log.TestingLogger())
+	require.NoError(t, tracker.Start())
+	defer tracker.Stop() //nolint:errcheck // ignore for tests
+
+	assert.Equal(t, Heights{Soft: 3, DAIncluded: 2}, tracker.Heights())
+	require.Eventually(t, func() bool {
+		return tracker.Heights().Firm == 1
+	}, time.Second, 10*time.Millisecond)
+}
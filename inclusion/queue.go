@@ -0,0 +1,139 @@
+// Package inclusion queues the transactions force-included by the settlement
+// hub, which the sequencer must include in the blocks by their deadline: the
+// sequencer proposes them first, and the validators refuse the proposals
+// skipping the overdue ones, so that the sequencer can't censor them.
+package inclusion
+
+import (
+	"fmt"
+	"sort"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+const keyPrefix = "forcedTx:"
+
+// record is a forced transaction saved in the Queue.
+type record struct {
+	ForcedTx types.ForcedTx `json:"forced_tx"`
+	// IncludedHeight is the height of the block which included the
+	// transaction, or 0 if none did yet.
+	IncludedHeight int64 `json:"included_height"`
+}
+
+// Queue persists the forced transactions, until the blocks include them and
+// afterwards, so that a forced transaction announced again isn't queued
+// again. The transactions are identified by their hash.
+type Queue struct {
+	mtx cmtsync.Mutex
+	db  dbm.DB
+}
+
+// NewQueue returns a Queue of the forced transactions in db.
+func NewQueue(db dbm.DB) *Queue {
+	return &Queue{db: db}
+}
+
+// Add saves ftx, included at includedHeight or pending if it is 0, unless it
+// is known already. It returns whether ftx was added.
+func (q *Queue) Add(ftx *types.ForcedTx, includedHeight int64) (bool, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	key := calcForcedTxKey(ftx.Tx.Hash())
+	has, err := q.db.Has(key)
+	if err != nil || has {
+		return false, err
+	}
+	bz, err := cmtjson.Marshal(record{ForcedTx: *ftx, IncludedHeight: includedHeight})
+	if err != nil {
+		return false, err
+	}
+	return true, q.db.SetSync(key, bz)
+}
+
+// Has returns whether the forced transaction of hash is known, pending or
+// included. Panics if it can't be read.
+func (q *Queue) Has(hash []byte) bool {
+	has, err := q.db.Has(calcForcedTxKey(hash))
+	if err != nil {
+		panic(err)
+	}
+	return has
+}
+
+// Pending returns the forced transactions not included yet, by deadline.
+// Panics if they can't be read.
+func (q *Queue) Pending() []types.ForcedTx {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	it, err := dbm.IteratePrefix(q.db, []byte(keyPrefix))
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+
+	var pending []types.ForcedTx
+	for ; it.Valid(); it.Next() {
+		r := decodeRecord(it.Value())
+		if r.IncludedHeight == 0 {
+			pending = append(pending, r.ForcedTx)
+		}
+	}
+	if err := it.Error(); err != nil {
+		panic(err)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Deadline < pending[j].Deadline
+	})
+	return pending
+}
+
+// Update marks the pending forced transactions among txs, those of the block
+// at height, as included.
+func (q *Queue) Update(height int64, txs types.Txs) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	batch := q.db.NewBatch()
+	defer batch.Close()
+	for _, tx := range txs {
+		key := calcForcedTxKey(tx.Hash())
+		bz, err := q.db.Get(key)
+		if err != nil {
+			return err
+		}
+		if len(bz) == 0 {
+			continue
+		}
+		r := decodeRecord(bz)
+		if r.IncludedHeight != 0 {
+			continue
+		}
+		r.IncludedHeight = height
+		if bz, err = cmtjson.Marshal(r); err != nil {
+			return err
+		}
+		if err := batch.Set(key, bz); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+func decodeRecord(bz []byte) record {
+	var r record
+	if err := cmtjson.Unmarshal(bz, &r); err != nil {
+		panic(fmt.Errorf("error reading forced transaction: %w", err))
+	}
+	return r
+}
+
+func calcForcedTxKey(hash []byte) []byte {
+	return []byte(fmt.Sprintf("%s%X", keyPrefix, hash))
+}
@@ -0,0 +1,47 @@
+package inclusion
+
+import (
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestQueue(t *testing.T) {
+	q := NewQueue(dbm.NewMemDB())
+	assert.Empty(t, q.Pending())
+
+	late := &types.ForcedTx{Tx: types.Tx("late"), Deadline: 20}
+	early := &types.ForcedTx{Tx: types.Tx("early"), Deadline: 10}
+	included := &types.ForcedTx{Tx: types.Tx("included"), Deadline: 5}
+	for _, ftx := range []*types.ForcedTx{late, early} {
+		added, err := q.Add(ftx, 0)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	added, err := q.Add(included, 3)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.True(t, q.Has(included.Tx.Hash()))
+	assert.False(t, q.Has(types.Tx("unknown").Hash()))
+
+	// by deadline, without the included ones
+	assert.Equal(t, []types.ForcedTx{*early, *late}, q.Pending())
+
+	// a known forced tx isn't added again
+	added, err = q.Add(&types.ForcedTx{Tx: types.Tx("early"), Deadline: 30}, 0)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	require.NoError(t, q.Update(8, types.Txs{types.Tx("other"), types.Tx("early")}))
+	assert.Equal(t, []types.ForcedTx{*late}, q.Pending())
+
+	// once included, a forced tx stays known
+	added, err = q.Add(early, 0)
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, []types.ForcedTx{*late}, q.Pending())
+}
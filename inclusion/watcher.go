@@ -0,0 +1,140 @@
+package inclusion
+
+import (
+	"context"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Source reads the transactions force-included by the settlement hub.
+type Source interface {
+	// ForcedTxs returns all the transactions force-included so far.
+	ForcedTxs(ctx context.Context) ([]*types.ForcedTx, error)
+
+	// Subscribe returns the transactions force-included from now on, until
+	// ctx is done.
+	Subscribe(ctx context.Context) (<-chan *types.ForcedTx, error)
+}
+
+// TxIndex returns the result of the transactions included in the chain.
+type TxIndex interface {
+	Get(hash []byte) (*abci.TxResult, error)
+}
+
+// Watcher is a service saving the transactions force-included by the hub to
+// the Queue, as they are announced. The forced transactions found in txIndex,
+// if set, are saved as included already, e.g. those the sequencer included
+// before their announcement. They are also loaded again every resync interval,
+// in case an announcement was missed.
+type Watcher struct {
+	service.BaseService
+
+	source         Source
+	queue          *Queue
+	txIndex        TxIndex
+	resyncInterval time.Duration
+}
+
+// NewWatcher returns a Watcher of the forced transactions of source, saved to
+// queue. txIndex may be nil if the transactions aren't indexed.
+func NewWatcher(source Source, queue *Queue, txIndex TxIndex, resyncInterval time.Duration) *Watcher {
+	w := &Watcher{
+		source:         source,
+		queue:          queue,
+		txIndex:        txIndex,
+		resyncInterval: resyncInterval,
+	}
+	w.BaseService = *service.NewBaseService(nil, "ForcedTxWatcher", w)
+	return w
+}
+
+// Sync saves all the transactions force-included so far.
+func (w *Watcher) Sync(ctx context.Context) error {
+	ftxs, err := w.source.ForcedTxs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ftx := range ftxs {
+		w.save(ftx)
+	}
+	return nil
+}
+
+// OnStart implements service.Service.
+func (w *Watcher) OnStart() error {
+	go w.run()
+	return nil
+}
+
+func (w *Watcher) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.Quit()
+		cancel()
+	}()
+
+	var ftxs <-chan *types.ForcedTx
+	subscribe := func() {
+		var err error
+		if ftxs, err = w.source.Subscribe(ctx); err != nil && ctx.Err() == nil {
+			w.Logger.Error("failed to subscribe to the forced transactions", "err", err)
+		}
+	}
+	subscribe()
+
+	ticker := time.NewTicker(w.resyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ftx, ok := <-ftxs:
+			if !ok {
+				ftxs = nil
+				continue
+			}
+			w.save(ftx)
+		case <-ticker.C:
+			if ftxs == nil {
+				subscribe()
+			}
+			if err := w.Sync(ctx); err != nil && ctx.Err() == nil {
+				w.Logger.Error("failed to load the forced transactions", "err", err)
+			}
+		case <-w.Quit():
+			return
+		}
+	}
+}
+
+// save saves ftx to the queue, unless it is known already.
+func (w *Watcher) save(ftx *types.ForcedTx) {
+	if err := ftx.ValidateBasic(); err != nil {
+		w.Logger.Error("invalid forced transaction", "tx", ftx, "err", err)
+		return
+	}
+	hash := ftx.Tx.Hash()
+	if w.queue.Has(hash) {
+		return
+	}
+
+	var includedHeight int64
+	if w.txIndex != nil {
+		res, err := w.txIndex.Get(hash)
+		switch {
+		case err != nil:
+			w.Logger.Error("failed to look up the forced transaction, queuing it", "tx", ftx, "err", err)
+		case res != nil:
+			includedHeight = res.Height
+		}
+	}
+	if _, err := w.queue.Add(ftx, includedHeight); err != nil {
+		w.Logger.Error("failed to save the forced transaction", "tx", ftx, "err", err)
+		return
+	}
+	if includedHeight == 0 {
+		w.Logger.Info("forced transaction queued", "tx", ftx)
+	}
+}
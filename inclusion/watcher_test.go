@@ -0,0 +1,68 @@
+package inclusion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mockSource struct {
+	ftxs []*types.ForcedTx
+	sub  chan *types.ForcedTx
+}
+
+func (s *mockSource) ForcedTxs(context.Context) ([]*types.ForcedTx, error) {
+	return s.ftxs, nil
+}
+
+func (s *mockSource) Subscribe(context.Context) (<-chan *types.ForcedTx, error) {
+	return s.sub, nil
+}
+
+// mockTxIndex holds the results of the txs included, by hash.
+type mockTxIndex map[string]*abci.TxResult
+
+func (idx mockTxIndex) Get(hash []byte) (*abci.TxResult, error) {
+	return idx[string(hash)], nil
+}
+
+func TestWatcherSync(t *testing.T) {
+	queue := NewQueue(dbm.NewMemDB())
+	pending := &types.ForcedTx{Tx: types.Tx("pending"), Deadline: 10}
+	included := &types.ForcedTx{Tx: types.Tx("included"), Deadline: 10}
+	source := &mockSource{ftxs: []*types.ForcedTx{pending, included, {Tx: types.Tx("invalid")}}}
+	txIndex := mockTxIndex{string(included.Tx.Hash()): {Height: 4}}
+	w := NewWatcher(source, queue, txIndex, time.Minute)
+	w.SetLogger(log.TestingLogger())
+
+	require.NoError(t, w.Sync(context.Background()))
+	// the forced txs included before their announcement are known but not
+	// pending
+	assert.Equal(t, []types.ForcedTx{*pending}, queue.Pending())
+	assert.True(t, queue.Has(included.Tx.Hash()))
+	assert.False(t, queue.Has(types.Tx("invalid").Hash()))
+}
+
+func TestWatcherSubscription(t *testing.T) {
+	queue := NewQueue(dbm.NewMemDB())
+	source := &mockSource{sub: make(chan *types.ForcedTx)}
+	w := NewWatcher(source, queue, nil, time.Minute)
+	w.SetLogger(log.TestingLogger())
+	require.NoError(t, w.Start())
+	defer w.Stop() //nolint:errcheck // ignore for tests
+
+	ftx := &types.ForcedTx{Tx: types.Tx("forced"), Deadline: 7}
+	source.sub <- ftx
+	require.Eventually(t, func() bool {
+		return len(queue.Pending()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, *ftx, queue.Pending()[0])
+}
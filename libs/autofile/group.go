@@ -61,6 +61,8 @@ type Group struct {
 	ticker             *time.Ticker
 	mtx                sync.Mutex
 	headSizeLimit      int64
+	headAgeLimit       time.Duration
+	headStart          time.Time // when the head was opened or last rotated
 	totalSizeLimit     int64
 	groupCheckDuration time.Duration
 	minIndex           int // Includes head
@@ -93,6 +95,7 @@ func OpenGroup(headPath string, groupOptions ...func(*Group)) (*Group, error) {
 		headBuf:            bufio.NewWriterSize(head, 4096*10),
 		Dir:                dir,
 		headSizeLimit:      defaultHeadSizeLimit,
+		headStart:          time.Now(),
 		totalSizeLimit:     defaultTotalSizeLimit,
 		groupCheckDuration: defaultGroupCheckDuration,
 		minIndex:           0,
@@ -126,6 +129,14 @@ func GroupHeadSizeLimit(limit int64) func(*Group) {
 	}
 }
 
+// GroupHeadAgeLimit sets the age at which the head is rotated, if not empty,
+// when it wasn't rotated for its size meanwhile. It is not limited by default.
+func GroupHeadAgeLimit(limit time.Duration) func(*Group) {
+	return func(g *Group) {
+		g.headAgeLimit = limit
+	}
+}
+
 // GroupTotalSizeLimit allows you to overwrite default total size limit of the group - 1GB.
 func GroupTotalSizeLimit(limit int64) func(*Group) {
 	return func(g *Group) {
@@ -242,6 +253,7 @@ func (g *Group) processTicks() {
 		select {
 		case <-g.ticker.C:
 			g.checkHeadSizeLimit()
+			g.checkHeadAgeLimit()
 			g.checkTotalSizeLimit()
 		case <-g.Quit():
 			return
@@ -265,6 +277,24 @@ func (g *Group) checkHeadSizeLimit() {
 	}
 }
 
+// NOTE: this function is called manually in tests.
+func (g *Group) checkHeadAgeLimit() {
+	g.mtx.Lock()
+	limit, start := g.headAgeLimit, g.headStart
+	g.mtx.Unlock()
+	if limit == 0 || time.Since(start) < limit {
+		return
+	}
+	size, err := g.Head.Size()
+	if err != nil {
+		g.Logger.Error("Group's head may grow without bound", "head", g.Head.Path, "err", err)
+		return
+	}
+	if size+int64(g.Buffered()) > 0 {
+		g.RotateFile()
+	}
+}
+
 func (g *Group) checkTotalSizeLimit() {
 	limit := g.TotalSizeLimit()
 	if limit == 0 {
@@ -324,6 +354,30 @@ func (g *Group) RotateFile() {
 	}
 
 	g.maxIndex++
+	g.headStart = time.Now()
+}
+
+// RemoveBefore removes the files of the group before index, but for the head.
+// It returns the number of bytes removed.
+func (g *Group) RemoveBefore(index int) (int64, error) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	var removed int64
+	for ; g.minIndex < index && g.minIndex < g.maxIndex; g.minIndex++ {
+		path := filePathForIndex(g.Head.Path, g.minIndex, g.maxIndex)
+		fInfo, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return removed, err
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed += fInfo.Size()
+	}
+	return removed, nil
 }
 
 // NewReader returns a new group reader.
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -288,3 +289,53 @@ func TestMaxIndex(t *testing.T) {
 	// Cleanup
 	destroyTestGroup(t, g)
 }
+
+func TestCheckHeadAgeLimit(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+	g.headAgeLimit = time.Hour
+
+	require.NoError(t, g.WriteLine("Line 1"))
+	g.checkHeadAgeLimit()
+	assert.Equal(t, 0, g.MaxIndex(), "the head isn't old enough")
+
+	// once old enough, the head is rotated, even with buffered lines
+	g.headStart = time.Now().Add(-time.Hour)
+	g.checkHeadAgeLimit()
+	assert.Equal(t, 1, g.MaxIndex())
+
+	// but an empty head isn't
+	g.headStart = time.Now().Add(-time.Hour)
+	g.checkHeadAgeLimit()
+	assert.Equal(t, 1, g.MaxIndex())
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
+
+func TestRemoveBefore(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, g.WriteLine("Line"))
+		require.NoError(t, g.FlushAndSync())
+		g.RotateFile()
+	}
+	require.NoError(t, g.WriteLine("Head"))
+	require.NoError(t, g.FlushAndSync())
+	assertGroupInfo(t, g.ReadGroupInfo(), 0, 3, 20, 5)
+
+	removed, err := g.RemoveBefore(2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, removed)
+	assert.Equal(t, 2, g.MinIndex())
+	assertGroupInfo(t, g.ReadGroupInfo(), 2, 3, 10, 5)
+
+	// the head is never removed
+	removed, err = g.RemoveBefore(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, removed)
+	assertGroupInfo(t, g.ReadGroupInfo(), 0, 0, 5, 5)
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
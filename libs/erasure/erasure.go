@@ -0,0 +1,210 @@
+// Package erasure implements a systematic Reed-Solomon erasure code over
+// GF(2^8), for k data shards to be recovered from any k of the k data and m
+// parity shards.
+//
+// The parity shards are computed with a Cauchy matrix, whose rows depend only
+// on k and the index of the parity shard, so that any k rows of the encoding
+// matrix are linearly independent.
+package erasure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum number of data and parity shards of a code.
+const MaxShards = 256
+
+var (
+	// ErrTooManyShards is returned when the data and parity shards exceed
+	// MaxShards.
+	ErrTooManyShards = fmt.Errorf("erasure: more than %d shards", MaxShards)
+	// ErrTooFewShards is returned when less than k shards are given to
+	// Reconstruct.
+	ErrTooFewShards = errors.New("erasure: too few shards to reconstruct the data")
+	// ErrShardSize is returned when the shards given to Reconstruct don't have
+	// the same size.
+	ErrShardSize = errors.New("erasure: shards of different sizes")
+)
+
+// mulTable[a][b] is the product of a and b in GF(2^8), and invTable[a] the
+// inverse of a.
+var (
+	mulTable [256][256]byte
+	invTable [256]byte
+)
+
+func init() {
+	// the exponentials and logarithms of the generator 2, modulo the
+	// polynomial x^8 + x^4 + x^3 + x^2 + 1
+	var exp [510]byte
+	var log [256]int
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i], exp[i+255] = byte(x), byte(x)
+		log[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			mulTable[a][b] = exp[log[a]+log[b]]
+		}
+		invTable[a] = exp[255-log[a]]
+	}
+}
+
+// coefficient returns the coefficient of the data shard j in the parity shard
+// i of a code of k data shards: 1 / ((k+i) XOR j).
+func coefficient(k, i, j int) byte {
+	return invTable[byte(k+i)^byte(j)]
+}
+
+// mulAdd adds c * in to out.
+func mulAdd(c byte, in, out []byte) {
+	if c == 0 {
+		return
+	}
+	row := &mulTable[c]
+	for n, b := range in {
+		out[n] ^= row[b]
+	}
+}
+
+// Encode returns m parity shards of the data shards. The shards have the size
+// of the largest data shard, the shorter data shards being padded with zeros.
+func Encode(data [][]byte, m int) ([][]byte, error) {
+	k := len(data)
+	if k+m > MaxShards {
+		return nil, ErrTooManyShards
+	}
+	size := 0
+	for _, shard := range data {
+		if len(shard) > size {
+			size = len(shard)
+		}
+	}
+
+	parity := make([][]byte, m)
+	for i := range parity {
+		parity[i] = make([]byte, size)
+		for j, shard := range data {
+			mulAdd(coefficient(k, i, j), shard, parity[i])
+		}
+	}
+	return parity, nil
+}
+
+// Reconstruct fills in the missing data shards of shards, the k data shards
+// followed by the parity shards, from any k of them. The missing shards are
+// nil, the others must have the same size, the data shards padded with zeros
+// as by Encode. The missing parity shards are left nil.
+func Reconstruct(shards [][]byte, k int) error {
+	if len(shards) > MaxShards {
+		return ErrTooManyShards
+	}
+	if k <= 0 || k > len(shards) {
+		return fmt.Errorf("erasure: invalid number of data shards %d of %d", k, len(shards))
+	}
+
+	// the first k shards we have, and the rows of the encoding matrix they
+	// were computed with
+	present := make([]int, 0, k)
+	size := -1
+	for n, shard := range shards {
+		if shard == nil {
+			continue
+		}
+		if size >= 0 && len(shard) != size {
+			return ErrShardSize
+		}
+		size = len(shard)
+		if len(present) < k {
+			present = append(present, n)
+		}
+	}
+	if len(present) < k {
+		return ErrTooFewShards
+	}
+	missing := false
+	for j := 0; j < k; j++ {
+		if shards[j] == nil {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	matrix := make([][]byte, k)
+	for r, n := range present {
+		matrix[r] = make([]byte, k)
+		if n < k {
+			matrix[r][n] = 1
+			continue
+		}
+		for j := 0; j < k; j++ {
+			matrix[r][j] = coefficient(k, n-k, j)
+		}
+	}
+	decode, err := invert(matrix)
+	if err != nil {
+		return err
+	}
+
+	// the data shard j is the row j of the inverse times the shards we have
+	for j := 0; j < k; j++ {
+		if shards[j] != nil {
+			continue
+		}
+		shard := make([]byte, size)
+		for r, n := range present {
+			mulAdd(decode[j][r], shards[n], shard)
+		}
+		shards[j] = shard
+	}
+	return nil
+}
+
+// invert returns the inverse of the square matrix, by Gauss-Jordan
+// elimination. It modifies matrix.
+func invert(matrix [][]byte) ([][]byte, error) {
+	n := len(matrix)
+	inverse := make([][]byte, n)
+	for r := range inverse {
+		inverse[r] = make([]byte, n)
+		inverse[r][r] = 1
+	}
+
+	for c := 0; c < n; c++ {
+		pivot := c
+		for pivot < n && matrix[pivot][c] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("erasure: singular matrix")
+		}
+		matrix[c], matrix[pivot] = matrix[pivot], matrix[c]
+		inverse[c], inverse[pivot] = inverse[pivot], inverse[c]
+
+		// scale the pivot row to 1, then eliminate the column from the others
+		if scale := invTable[matrix[c][c]]; scale != 1 {
+			for j := 0; j < n; j++ {
+				matrix[c][j] = mulTable[scale][matrix[c][j]]
+				inverse[c][j] = mulTable[scale][inverse[c][j]]
+			}
+		}
+		for r := 0; r < n; r++ {
+			if r == c || matrix[r][c] == 0 {
+				continue
+			}
+			factor := matrix[r][c]
+			mulAdd(factor, matrix[c], matrix[r])
+			mulAdd(factor, inverse[c], inverse[r])
+		}
+	}
+	return inverse, nil
+}
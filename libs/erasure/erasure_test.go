@@ -0,0 +1,101 @@
+package erasure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func shards(k, size, last int) [][]byte {
+	data := make([][]byte, k)
+	for j := range data {
+		data[j] = cmtrand.Bytes(size)
+	}
+	data[k-1] = data[k-1][:last]
+	return data
+}
+
+func padded(data [][]byte, size int) [][]byte {
+	out := make([][]byte, len(data))
+	for j, shard := range data {
+		out[j] = make([]byte, size)
+		copy(out[j], shard)
+	}
+	return out
+}
+
+func TestReconstructFromAnyShards(t *testing.T) {
+	const k, m, size = 5, 3, 64
+	data := shards(k, size, 10)
+	parity, err := Encode(data, m)
+	require.NoError(t, err)
+	require.Len(t, parity, m)
+	for _, shard := range parity {
+		require.Len(t, shard, size)
+	}
+	all := append(padded(data, size), parity...)
+
+	// every combination of m missing shards
+	for a := 0; a < k+m; a++ {
+		for b := a + 1; b < k+m; b++ {
+			for c := b + 1; c < k+m; c++ {
+				got := make([][]byte, k+m)
+				copy(got, all)
+				got[a], got[b], got[c] = nil, nil, nil
+
+				require.NoError(t, Reconstruct(got, k), "missing %d, %d and %d", a, b, c)
+				assert.Equal(t, all[:k], got[:k], "missing %d, %d and %d", a, b, c)
+			}
+		}
+	}
+}
+
+func TestReconstructMaxShards(t *testing.T) {
+	const k = 200
+	data := shards(k, 32, 32)
+	parity, err := Encode(data, MaxShards-k)
+	require.NoError(t, err)
+
+	// the first data shards are missing, recovered from all the parity shards
+	shuffled := make([][]byte, MaxShards)
+	copy(shuffled[k:], parity)
+	copy(shuffled[MaxShards-k:k], data[MaxShards-k:])
+	require.NoError(t, Reconstruct(shuffled, k))
+	assert.Equal(t, data, shuffled[:k])
+}
+
+func TestReconstructErrors(t *testing.T) {
+	data := shards(4, 16, 16)
+	parity, err := Encode(data, 2)
+	require.NoError(t, err)
+
+	_, err = Encode(shards(200, 1, 1), 57)
+	assert.Equal(t, ErrTooManyShards, err)
+
+	got := [][]byte{data[0], nil, nil, data[3], nil, parity[1]}
+	assert.Equal(t, ErrTooFewShards, Reconstruct(got, 4))
+
+	got = [][]byte{data[0], nil, data[2][:8], data[3], parity[0], nil}
+	assert.Equal(t, ErrShardSize, Reconstruct(got, 4))
+
+	assert.Error(t, Reconstruct(got, 0))
+	assert.Error(t, Reconstruct(got, 7))
+
+	// nothing missing
+	got = [][]byte{data[0], data[1], data[2], data[3], nil, nil}
+	require.NoError(t, Reconstruct(got, 4))
+	assert.Nil(t, got[4])
+}
+
+func BenchmarkEncode(b *testing.B) {
+	data := shards(32, 65536, 65536)
+	b.SetBytes(32 * 65536)
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode(data, 16); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package log
+
+import "sync/atomic"
+
+// SwappableLogger is a logger whose underlying logger can be swapped at
+// runtime, e.g. to change the log level or format when the configuration is
+// reloaded. The loggers derived from it with With are swapped along with it.
+type SwappableLogger struct {
+	root    *atomic.Value // holds the *swappedLogger shared by the derived loggers
+	keyvals []interface{}
+	derived atomic.Value // holds the *derivedLogger of the current root
+}
+
+type swappedLogger struct {
+	Logger
+}
+
+// derivedLogger caches the root logger with the keyvals of a derived logger.
+type derivedLogger struct {
+	root   *swappedLogger
+	logger Logger
+}
+
+// NewSwappableLogger returns a logger passing the log events to next, until
+// it's swapped.
+func NewSwappableLogger(next Logger) *SwappableLogger {
+	l := &SwappableLogger{root: new(atomic.Value)}
+	l.root.Store(&swappedLogger{next})
+	return l
+}
+
+// Swap replaces the underlying logger of all the loggers derived from the same
+// NewSwappableLogger.
+func (l *SwappableLogger) Swap(next Logger) {
+	l.root.Store(&swappedLogger{next})
+}
+
+func (l *SwappableLogger) logger() Logger {
+	root := l.root.Load().(*swappedLogger)
+	if d, ok := l.derived.Load().(*derivedLogger); ok && d.root == root {
+		return d.logger
+	}
+	var logger Logger = root.Logger
+	if len(l.keyvals) > 0 {
+		logger = logger.With(l.keyvals...)
+	}
+	l.derived.Store(&derivedLogger{root: root, logger: logger})
+	return logger
+}
+
+func (l *SwappableLogger) Debug(msg string, keyvals ...interface{}) {
+	l.logger().Debug(msg, keyvals...)
+}
+
+func (l *SwappableLogger) Info(msg string, keyvals ...interface{}) {
+	l.logger().Info(msg, keyvals...)
+}
+
+func (l *SwappableLogger) Error(msg string, keyvals ...interface{}) {
+	l.logger().Error(msg, keyvals...)
+}
+
+// With returns a logger with the keyvals appended, swapped along with l.
+func (l *SwappableLogger) With(keyvals ...interface{}) Logger {
+	kvs := make([]interface{}, 0, len(l.keyvals)+len(keyvals))
+	kvs = append(kvs, l.keyvals...)
+	kvs = append(kvs, keyvals...)
+	return &SwappableLogger{root: l.root, keyvals: kvs}
+}
@@ -0,0 +1,35 @@
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSwappableLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := log.NewSwappableLogger(log.NewFilter(log.NewTMJSONLoggerNoTS(&buf), log.AllowError()))
+	logger := root.With("module", "consensus").With("height", 1)
+
+	logger.Info("foo")
+	if want, have := ``, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	// the derived loggers log to the new logger, with their keyvals
+	root.Swap(log.NewFilter(log.NewTMJSONLoggerNoTS(&buf), log.AllowError(), log.AllowInfoWith("module", "consensus")))
+	logger.Info("foo")
+	want := `{"_msg":"foo","height":1,"level":"info","module":"consensus"}`
+	if have := strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	buf.Reset()
+	root.Info("foo")
+	if want, have := ``, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+}
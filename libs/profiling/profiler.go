@@ -0,0 +1,150 @@
+// Package profiling captures the CPU, heap and goroutine profiles of the node
+// continuously, keeping the latest captures on disk, so that the profiles
+// preceding an incident can be looked at after it.
+package profiling
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// maxCPUDuration is the maximum duration of the CPU profile of a capture.
+const maxCPUDuration = 10 * time.Second
+
+// timeFormat is the format of the capture time prefixing the profile files,
+// which sorts them chronologically.
+const timeFormat = "20060102T150405Z"
+
+// Profiler is a service capturing the CPU, heap and goroutine profiles of the
+// process at an interval into a directory, as <time>-<profile>.pb.gz files,
+// keeping the latest captures.
+type Profiler struct {
+	service.BaseService
+
+	dir         string
+	interval    time.Duration
+	retain      int
+	cpuDuration time.Duration
+}
+
+// NewProfiler returns a profiler capturing the profiles into dir every
+// interval, and keeping the latest retain captures.
+func NewProfiler(dir string, interval time.Duration, retain int) *Profiler {
+	cpuDuration := interval / 2
+	if cpuDuration > maxCPUDuration {
+		cpuDuration = maxCPUDuration
+	}
+	p := &Profiler{
+		dir:         dir,
+		interval:    interval,
+		retain:      retain,
+		cpuDuration: cpuDuration,
+	}
+	p.BaseService = *service.NewBaseService(nil, "Profiler", p)
+	return p
+}
+
+// OnStart implements service.Service.
+func (p *Profiler) OnStart() error {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return fmt.Errorf("creating the profiles directory: %w", err)
+	}
+	go p.run()
+	return nil
+}
+
+func (p *Profiler) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := p.capture(now); err != nil {
+				p.Logger.Error("failed to capture the profiles", "err", err)
+			}
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// capture writes the profiles of the capture at time now, then deletes the
+// oldest captures.
+func (p *Profiler) capture(now time.Time) error {
+	prefix := filepath.Join(p.dir, now.UTC().Format(timeFormat))
+	for _, name := range []string{"heap", "goroutine"} {
+		if err := writeFile(prefix+"-"+name+".pb.gz", pprof.Lookup(name).WriteTo); err != nil {
+			return err
+		}
+	}
+	if err := p.captureCPU(prefix + "-cpu.pb.gz"); err != nil {
+		// another CPU profile may be running, e.g. requested with pprof
+		p.Logger.Debug("skipping the CPU profile", "err", err)
+	}
+	return p.prune()
+}
+
+func (p *Profiler) captureCPU(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	select {
+	case <-time.After(p.cpuDuration):
+	case <-p.Quit():
+	}
+	pprof.StopCPUProfile()
+	return f.Close()
+}
+
+// prune deletes the files of the captures but the latest p.retain ones.
+func (p *Profiler) prune() error {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return err
+	}
+	captures := make(map[string][]string)
+	for _, e := range entries {
+		if i := strings.IndexByte(e.Name(), '-'); i > 0 && strings.HasSuffix(e.Name(), ".pb.gz") {
+			captures[e.Name()[:i]] = append(captures[e.Name()[:i]], e.Name())
+		}
+	}
+	times := make([]string, 0, len(captures))
+	for t := range captures {
+		times = append(times, t)
+	}
+	sort.Strings(times)
+	for i := 0; i < len(times)-p.retain; i++ {
+		for _, name := range captures[times[i]] {
+			if err := os.Remove(filepath.Join(p.dir, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, write func(w io.Writer, debug int) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
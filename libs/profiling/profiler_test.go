@@ -0,0 +1,52 @@
+package profiling
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfilerCapture(t *testing.T) {
+	dir := t.TempDir()
+	p := NewProfiler(dir, 20*time.Millisecond, 2)
+	require.NoError(t, p.Start())
+	require.NoError(t, p.Stop())
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.capture(start.Add(time.Duration(i)*time.Minute)))
+	}
+
+	// only the latest 2 captures are kept
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{
+		"20230102T030505Z-cpu.pb.gz", "20230102T030505Z-goroutine.pb.gz", "20230102T030505Z-heap.pb.gz",
+		"20230102T030605Z-cpu.pb.gz", "20230102T030605Z-goroutine.pb.gz", "20230102T030605Z-heap.pb.gz",
+	}, names)
+
+	info, err := os.Stat(filepath.Join(dir, "20230102T030605Z-heap.pb.gz"))
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+}
+
+func TestProfilerRun(t *testing.T) {
+	dir := t.TempDir()
+	p := NewProfiler(dir, 10*time.Millisecond, 1)
+	require.NoError(t, p.Start())
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) > 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Stop())
+}
@@ -0,0 +1,370 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExprKind is the kind of an expression of a query.
+type ExprKind uint8
+
+const (
+	// ExprConditions is a conjunction of conditions, held by a Query
+	// supporting Conditions.
+	ExprConditions ExprKind = iota
+	// ExprAnd is the conjunction of its arguments.
+	ExprAnd
+	// ExprOr is the disjunction of its arguments.
+	ExprOr
+	// ExprNot is the negation of its single argument.
+	ExprNot
+)
+
+// Expr is the expression tree of a query using OR, NOT or parentheses:
+//
+//	(tx.gas > 7 OR account.owner = 'Ivan') AND NOT account.frozen EXISTS
+//
+// The conditions joined by AND are grouped in the leaves of the tree, which
+// can be searched as before.
+type Expr struct {
+	Kind ExprKind
+	// Query is the conjunction of conditions of an ExprConditions expression.
+	Query *Query
+	// Args are the arguments of an ExprAnd, ExprOr or ExprNot expression.
+	Args []*Expr
+}
+
+// String returns the expression in the query syntax.
+func (e *Expr) String() string {
+	switch e.Kind {
+	case ExprConditions:
+		return e.Query.String()
+	case ExprNot:
+		return "NOT " + e.Args[0].stringArg(ExprNot)
+	default:
+		sep := " AND "
+		if e.Kind == ExprOr {
+			sep = " OR "
+		}
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = arg.stringArg(e.Kind)
+		}
+		return strings.Join(args, sep)
+	}
+}
+
+// stringArg returns the expression as an argument of an expression of the
+// parent kind, in parentheses if needed.
+func (e *Expr) stringArg(parent ExprKind) string {
+	switch e.Kind {
+	case ExprNot:
+		return e.String()
+	case ExprConditions:
+		if conditions, err := e.Query.Conditions(); parent != ExprNot || err != nil || len(conditions) <= 1 {
+			return e.String()
+		}
+	}
+	return "(" + e.String() + ")"
+}
+
+// Matches returns true if the expression matches against the given set of
+// events, false otherwise.
+func (e *Expr) Matches(events map[string][]string) (bool, error) {
+	switch e.Kind {
+	case ExprConditions:
+		return e.Query.Matches(events)
+	case ExprNot:
+		match, err := e.Args[0].Matches(events)
+		return !match, err
+	case ExprAnd:
+		for _, arg := range e.Args {
+			match, err := arg.Matches(events)
+			if err != nil || !match {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		for _, arg := range e.Args {
+			match, err := arg.Matches(events)
+			if err != nil {
+				return false, err
+			}
+			if match {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// Order is the ORDER BY clause of a query, ordering the results by the value
+// of an event attribute.
+type Order struct {
+	CompositeKey string
+	Descending   bool
+}
+
+// keywords of the extended syntax, which can't be used as tags.
+const (
+	keywordAnd   = "AND"
+	keywordOr    = "OR"
+	keywordNot   = "NOT"
+	keywordOrder = "ORDER"
+	keywordBy    = "BY"
+	keywordAsc   = "ASC"
+	keywordDesc  = "DESC"
+)
+
+type exprTokenKind uint8
+
+const (
+	tokenWord exprTokenKind = iota
+	tokenValue
+	tokenOpen
+	tokenClose
+)
+
+type exprToken struct {
+	kind       exprTokenKind
+	text       string
+	begin, end int
+}
+
+func (t exprToken) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && t.text == keyword
+}
+
+// tokenizeExpr splits s into parentheses, quoted values and words.
+func tokenizeExpr(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, exprToken{tokenOpen, "(", i, i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, exprToken{tokenClose, ")", i, i + 1})
+			i++
+		case c == '\'':
+			j := strings.IndexByte(s[i+1:], '\'')
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated value at position %d", i)
+			}
+			tokens = append(tokens, exprToken{tokenValue, s[i : i+j+2], i, i + j + 2})
+			i += j + 2
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r()'", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, exprToken{tokenWord, s[i:j], i, j})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// hasExtendedSyntax reports whether the tokens use the syntax of expressions.
+func hasExtendedSyntax(tokens []exprToken) bool {
+	for _, t := range tokens {
+		if t.kind == tokenOpen || t.isKeyword(keywordOr) || t.isKeyword(keywordNot) || t.isKeyword(keywordOrder) {
+			return true
+		}
+	}
+	return false
+}
+
+// exprParser is a recursive descent parser of the expression syntax:
+//
+//	query     = or [ "ORDER" "BY" tag [ "ASC" | "DESC" ] ]
+//	or        = and { "OR" and }
+//	and       = unary { "AND" unary }
+//	unary     = "NOT" unary | "(" or ")" | condition
+//
+// where the conditions are parsed by the grammar of query.peg.
+type exprParser struct {
+	s      string
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return exprToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *exprParser) accept(keyword string) bool {
+	if t, ok := p.peek(); ok && t.isKeyword(keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) parseQuery() (*Expr, *Order, error) {
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var order *Order
+	if p.accept(keywordOrder) {
+		if !p.accept(keywordBy) {
+			return nil, nil, errors.New("expected BY after ORDER")
+		}
+		t, ok := p.peek()
+		if !ok || t.kind != tokenWord {
+			return nil, nil, errors.New("expected an attribute after ORDER BY")
+		}
+		p.pos++
+		order = &Order{CompositeKey: t.text}
+		if p.accept(keywordDesc) {
+			order.Descending = true
+		} else {
+			p.accept(keywordAsc)
+		}
+	}
+
+	if t, ok := p.peek(); ok {
+		return nil, nil, fmt.Errorf("unexpected %q at position %d", t.text, t.begin)
+	}
+	return e, order, nil
+}
+
+func (p *exprParser) parseOr() (*Expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	args := []*Expr{e}
+	for p.accept(keywordOr) {
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+	}
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	return &Expr{Kind: ExprOr, Args: args}, nil
+}
+
+func (p *exprParser) parseAnd() (*Expr, error) {
+	e, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	args := []*Expr{e}
+	for p.accept(keywordAnd) {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+	}
+	return groupConditions(args)
+}
+
+// groupConditions returns the conjunction of args, with the conjunctions of
+// conditions among them merged into one.
+func groupConditions(args []*Expr) (*Expr, error) {
+	var (
+		conditions []string
+		others     []*Expr
+	)
+	for _, arg := range args {
+		switch {
+		case arg.Kind == ExprConditions:
+			conditions = append(conditions, arg.Query.String())
+		case arg.Kind == ExprAnd:
+			// flatten the nested conjunctions
+			for _, a := range arg.Args {
+				if a.Kind == ExprConditions {
+					conditions = append(conditions, a.Query.String())
+				} else {
+					others = append(others, a)
+				}
+			}
+		default:
+			others = append(others, arg)
+		}
+	}
+
+	var grouped []*Expr
+	if len(conditions) > 0 {
+		q, err := newConjunction(strings.Join(conditions, " AND "))
+		if err != nil {
+			return nil, err
+		}
+		grouped = append(grouped, &Expr{Kind: ExprConditions, Query: q})
+	}
+	grouped = append(grouped, others...)
+	if len(grouped) == 1 {
+		return grouped[0], nil
+	}
+	return &Expr{Kind: ExprAnd, Args: grouped}, nil
+}
+
+func (p *exprParser) parseUnary() (*Expr, error) {
+	t, ok := p.peek()
+	switch {
+	case !ok:
+		return nil, errors.New("unexpected end of query")
+	case t.isKeyword(keywordNot):
+		p.pos++
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if e.Kind == ExprNot {
+			return e.Args[0], nil
+		}
+		return &Expr{Kind: ExprNot, Args: []*Expr{e}}, nil
+	case t.kind == tokenOpen:
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || t.kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis for position %d", t.begin)
+		}
+		p.pos++
+		return e, nil
+	default:
+		return p.parseCondition()
+	}
+}
+
+// parseCondition parses a condition, which spans up to the next AND, OR,
+// ORDER keyword or closing parenthesis.
+func (p *exprParser) parseCondition() (*Expr, error) {
+	begin, end := p.tokens[p.pos].begin, p.tokens[p.pos].end
+	for ; p.pos < len(p.tokens); p.pos++ {
+		t := p.tokens[p.pos]
+		if t.kind == tokenClose || t.kind == tokenOpen || t.isKeyword(keywordAnd) || t.isKeyword(keywordOr) ||
+			t.isKeyword(keywordOrder) || t.isKeyword(keywordNot) {
+			break
+		}
+		end = t.end
+	}
+	q, err := newConjunction(p.s[begin:end])
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{Kind: ExprConditions, Query: q}, nil
+}
+
+// parseExpr parses a query using the expression syntax.
+func parseExpr(s string, tokens []exprToken) (*Expr, *Order, error) {
+	p := &exprParser{s: s, tokens: tokens}
+	return p.parseQuery()
+}
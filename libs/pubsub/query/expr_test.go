@@ -0,0 +1,83 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+func TestExpressionMatches(t *testing.T) {
+	events := map[string][]string{
+		"account.owner":  {"Ivan"},
+		"account.number": {"7"},
+		"transfer.note":  {"paid AND done (OR NOT)"},
+	}
+
+	testCases := []struct {
+		s       string
+		matches bool
+	}{
+		{"account.owner = 'Igor' OR account.number = 7", true},
+		{"account.owner = 'Igor' OR account.number = 8", false},
+		{"NOT account.owner = 'Igor'", true},
+		{"NOT account.owner = 'Ivan'", false},
+		{"account.number > 5 AND NOT account.frozen EXISTS", true},
+		{"account.number > 5 AND NOT account EXISTS", false},
+		{"(account.owner = 'Igor' OR account.owner = 'Ivan') AND account.number < 10", true},
+		{"(account.owner = 'Igor' OR account.owner = 'Ivan') AND account.number >= 10", false},
+		{"NOT (account.owner = 'Igor' OR account.number = 8)", true},
+		{"NOT NOT account.owner = 'Ivan'", true},
+		{"transfer.note = 'paid AND done (OR NOT)' OR account.number = 8", true},
+		{"account.owner = 'Ivan' AND account.number = 7 ORDER BY account.number DESC", true},
+		{"(account.owner = 'Ivan')", true},
+	}
+	for _, tc := range testCases {
+		q, err := query.New(tc.s)
+		require.NoError(t, err, tc.s)
+		match, err := q.Matches(events)
+		require.NoError(t, err, tc.s)
+		assert.Equal(t, tc.matches, match, tc.s)
+	}
+}
+
+func TestExpressionParsing(t *testing.T) {
+	for _, s := range []string{
+		"account.owner = 'Ivan' OR",
+		"(account.owner = 'Ivan'",
+		"account.owner = 'Ivan')",
+		"NOT",
+		"account.owner = 'Ivan' ORDER account.number",
+		"account.owner = 'Ivan' ORDER BY",
+		"account.owner = 'Ivan' ORDER BY account.number UP",
+		"account.owner = OR account.number = 7",
+	} {
+		_, err := query.New(s)
+		assert.Error(t, err, s)
+	}
+
+	// the conjunctions of conditions are grouped in the leaves
+	q := query.MustParse("a.b = 1 AND (c.d = 2 OR e.f = 3) AND g.h = 4 AND NOT i.j = 5")
+	e := q.Expression()
+	require.Equal(t, query.ExprAnd, e.Kind)
+	require.Len(t, e.Args, 3)
+	assert.Equal(t, query.ExprConditions, e.Args[0].Kind)
+	assert.Equal(t, "a.b = 1 AND g.h = 4", e.Args[0].Query.String())
+	assert.Equal(t, query.ExprOr, e.Args[1].Kind)
+	assert.Equal(t, query.ExprNot, e.Args[2].Kind)
+	assert.Equal(t, "a.b = 1 AND g.h = 4 AND (c.d = 2 OR e.f = 3) AND NOT i.j = 5", e.String())
+
+	_, err := q.Conditions()
+	assert.ErrorIs(t, err, query.ErrNotConjunction)
+	assert.Nil(t, q.OrderBy())
+
+	// a conjunction with an ORDER BY clause still has conditions
+	q = query.MustParse("a.b = 1 AND c.d > 2 ORDER BY c.d DESC")
+	conditions, err := q.Conditions()
+	require.NoError(t, err)
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, &query.Order{CompositeKey: "c.d", Descending: true}, q.OrderBy())
+	assert.Equal(t, query.ExprConditions, q.Expression().Kind)
+}
@@ -7,9 +7,15 @@
 // More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
 //
 // It has a support for numbers (integer and floating point), dates and times.
+//
+// Conditions can also be combined with OR, NOT and parentheses, and the
+// results of a search ordered by an attribute (see expr.go):
+//
+//	(abci.invoice.number=22 OR abci.invoice.owner='Ivan') AND NOT abci.invoice.paid EXISTS ORDER BY abci.invoice.number DESC
 package query
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -22,10 +28,17 @@ var (
 	numRegex = regexp.MustCompile(`([0-9\.]+)`)
 )
 
+// ErrNotConjunction is returned by Conditions for the queries which are not a
+// conjunction of conditions, as they use OR or NOT.
+var ErrNotConjunction = errors.New("the query is not a conjunction of conditions, it uses OR or NOT")
+
 // Query holds the query string and the query parser.
 type Query struct {
 	str    string
 	parser *QueryParser
+	// expr is set if the query is not a conjunction of conditions.
+	expr  *Expr
+	order *Order
 }
 
 // Condition represents a single condition within a query and consists of composite key
@@ -39,6 +52,27 @@ type Condition struct {
 // New parses the given string and returns a query or error if the string is
 // invalid.
 func New(s string) (*Query, error) {
+	q, err := newConjunction(s)
+	if err == nil {
+		return q, nil
+	}
+
+	tokens, tokErr := tokenizeExpr(s)
+	if tokErr != nil || !hasExtendedSyntax(tokens) {
+		return nil, err
+	}
+	e, order, err := parseExpr(s, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if e.Kind == ExprConditions {
+		return &Query{str: s, parser: e.Query.parser, order: order}, nil
+	}
+	return &Query{str: s, expr: e, order: order}, nil
+}
+
+// newConjunction parses a conjunction of conditions.
+func newConjunction(s string) (*Query, error) {
 	p := &QueryParser{Buffer: fmt.Sprintf(`"%s"`, s)}
 	p.Init()
 	if err := p.Parse(); err != nil {
@@ -62,6 +96,20 @@ func (q *Query) String() string {
 	return q.str
 }
 
+// Expression returns the expression tree of the query. The expression of a
+// conjunction of conditions is a single ExprConditions expression.
+func (q *Query) Expression() *Expr {
+	if q.expr != nil {
+		return q.expr
+	}
+	return &Expr{Kind: ExprConditions, Query: &Query{str: q.str, parser: q.parser}}
+}
+
+// OrderBy returns the ORDER BY clause of the query, or nil if it has none.
+func (q *Query) OrderBy() *Order {
+	return q.order
+}
+
 // Operator is an operator that defines some kind of relation between composite key and
 // operand (equality, etc.).
 type Operator uint8
@@ -93,6 +141,10 @@ const (
 // Conditions returns a list of conditions. It returns an error if there is any
 // error with the provided grammar in the Query.
 func (q *Query) Conditions() ([]Condition, error) {
+	if q.expr != nil {
+		return nil, ErrNotConjunction
+	}
+
 	var (
 		eventAttr string
 		op        Operator
@@ -203,6 +255,9 @@ func (q *Query) Matches(events map[string][]string) (bool, error) {
 	if len(events) == 0 {
 		return false, nil
 	}
+	if q.expr != nil {
+		return q.expr.Matches(events)
+	}
 
 	var (
 		eventAttr string
@@ -0,0 +1,309 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTLPExporter sends the spans to an OTLP/HTTP traces endpoint, such as the
+// one of the OpenTelemetry collector, with the JSON encoding of OTLP.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ sdktrace.SpanExporter = (*OTLPExporter)(nil)
+
+// NewOTLPExporter returns an exporter sending the spans to endpoint, e.g.
+// http://localhost:4318/v1/traces, failing after timeout.
+func NewOTLPExporter(endpoint string, timeout time.Duration) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	bz, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("exporting %d spans: %w", len(spans), err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("exporting %d spans: %s", len(spans), res.Status)
+	}
+	return nil
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// WriterExporter writes the spans to a writer, each batch as a line with the
+// JSON encoding of OTLP.
+type WriterExporter struct {
+	mtx sync.Mutex
+	w   io.Writer
+}
+
+var _ sdktrace.SpanExporter = (*WriterExporter)(nil)
+
+// NewWriterExporter returns an exporter writing to w, or to the standard
+// output if w is nil.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &WriterExporter{w: w}
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *WriterExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	bz, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	_, err = e.w.Write(append(bz, '\n'))
+	return err
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *WriterExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// The types below follow the JSON encoding of the OTLP
+// ExportTraceServiceRequest message, see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding.
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Events            []event    `json:"events,omitempty"`
+	Links             []link     `json:"links,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type event struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type link struct {
+	TraceID    string     `json:"traceId"`
+	SpanID     string     `json:"spanId"`
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	DoubleValue *float64    `json:"doubleValue,omitempty"`
+	ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+// newExportRequest groups the spans by resource and instrumentation scope.
+func newExportRequest(spans []sdktrace.ReadOnlySpan) exportRequest {
+	var req exportRequest
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[attribute.Distinct]map[string]int)
+	for _, s := range spans {
+		set := s.Resource().Set()
+		key := set.Equivalent()
+		ri, ok := resources[key]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			resources[key] = ri
+			scopes[key] = make(map[string]int)
+			req.ResourceSpans = append(req.ResourceSpans, resourceSpans{
+				Resource: resource{Attributes: newKeyValues(set.ToSlice())},
+			})
+		}
+		rs := &req.ResourceSpans[ri]
+
+		is := s.InstrumentationScope()
+		si, ok := scopes[key][is.Name+"@"+is.Version]
+		if !ok {
+			si = len(rs.ScopeSpans)
+			scopes[key][is.Name+"@"+is.Version] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, scopeSpans{Scope: scope{Name: is.Name, Version: is.Version}})
+		}
+		rs.ScopeSpans[si].Spans = append(rs.ScopeSpans[si].Spans, newSpan(s))
+	}
+	return req
+}
+
+func newSpan(s sdktrace.ReadOnlySpan) span {
+	sp := span{
+		TraceID:           s.SpanContext().TraceID().String(),
+		SpanID:            s.SpanContext().SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: unixNano(s.StartTime()),
+		EndTimeUnixNano:   unixNano(s.EndTime()),
+		Attributes:        newKeyValues(s.Attributes()),
+		Status:            newStatus(s.Status()),
+	}
+	if s.SpanKind() == trace.SpanKindUnspecified {
+		sp.Kind = int(trace.SpanKindInternal)
+	}
+	if s.Parent().HasSpanID() {
+		sp.ParentSpanID = s.Parent().SpanID().String()
+	}
+	for _, e := range s.Events() {
+		sp.Events = append(sp.Events, event{
+			TimeUnixNano: unixNano(e.Time),
+			Name:         e.Name,
+			Attributes:   newKeyValues(e.Attributes),
+		})
+	}
+	for _, l := range s.Links() {
+		sp.Links = append(sp.Links, link{
+			TraceID:    l.SpanContext.TraceID().String(),
+			SpanID:     l.SpanContext.SpanID().String(),
+			Attributes: newKeyValues(l.Attributes),
+		})
+	}
+	return sp
+}
+
+// newStatus converts the status of a span, whose codes differ in OTLP.
+func newStatus(s sdktrace.Status) status {
+	switch s.Code {
+	case codes.Ok:
+		return status{Code: 1}
+	case codes.Error:
+		return status{Code: 2, Message: s.Description}
+	default:
+		return status{}
+	}
+}
+
+func newKeyValues(attrs []attribute.KeyValue) []keyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]keyValue, len(attrs))
+	for i, attr := range attrs {
+		kvs[i] = keyValue{Key: string(attr.Key), Value: newAnyValue(attr.Value)}
+	}
+	return kvs
+}
+
+func newAnyValue(v attribute.Value) anyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return anyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return anyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return anyValue{DoubleValue: &f}
+	case attribute.BOOLSLICE:
+		var values []anyValue
+		for _, b := range v.AsBoolSlice() {
+			values = append(values, newAnyValue(attribute.BoolValue(b)))
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	case attribute.INT64SLICE:
+		var values []anyValue
+		for _, i := range v.AsInt64Slice() {
+			values = append(values, newAnyValue(attribute.Int64Value(i)))
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	case attribute.FLOAT64SLICE:
+		var values []anyValue
+		for _, f := range v.AsFloat64Slice() {
+			values = append(values, newAnyValue(attribute.Float64Value(f)))
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	case attribute.STRINGSLICE:
+		var values []anyValue
+		for _, s := range v.AsStringSlice() {
+			values = append(values, newAnyValue(attribute.StringValue(s)))
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	default:
+		s := v.Emit()
+		return anyValue{StringValue: &s}
+	}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
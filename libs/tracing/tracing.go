@@ -0,0 +1,97 @@
+// Package tracing traces the node with OpenTelemetry. The components start
+// their spans with the tracer returned by Tracer, which does nothing until
+// Install sets the global tracer provider.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// ExporterOTLP sends the spans to an OTLP/HTTP endpoint.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes the spans to the standard output.
+	ExporterStdout = "stdout"
+)
+
+const serviceName = "cometbft"
+
+// Tracer returns the tracer of a component of the node, e.g. "consensus",
+// starting the spans with the global tracer provider installed last.
+func Tracer(component string) trace.Tracer {
+	return globalTracer{name: serviceName + "/" + component}
+}
+
+// globalTracer resolves the global tracer provider for each span, since the
+// tracers of otel.Tracer keep the first provider installed.
+type globalTracer struct {
+	name string
+}
+
+func (t globalTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.GetTracerProvider().Tracer(t.name).Start(ctx, spanName, opts...)
+}
+
+// NewProvider returns a tracer provider sampling sampleRate of the traces,
+// unless their parent is sampled, and exporting the spans with exporter. The
+// spans are attributed to the node by attrs, e.g. its chain ID and moniker.
+func NewProvider(exporter sdktrace.SpanExporter, sampleRate float64, attrs ...attribute.KeyValue) *sdktrace.TracerProvider {
+	attrs = append([]attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}, attrs...)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRate))),
+	)
+}
+
+// NewExporter returns the exporter with the given name, ExporterOTLP sending
+// the spans to endpoint, or ExporterStdout.
+func NewExporter(name, endpoint string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterOTLP:
+		return NewOTLPExporter(endpoint, 10*time.Second), nil
+	case ExporterStdout:
+		return NewWriterExporter(nil), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", name)
+	}
+}
+
+// Install sets provider as the global tracer provider, used by the tracers
+// returned by Tracer, and the W3C trace context as the global propagator.
+func Install(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// EndSpan records err on span, if not nil, and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Logger returns logger with the trace and span IDs of the span of ctx, if
+// it's sampled, so that the log lines can be correlated with the trace.
+func Logger(ctx context.Context, logger log.Logger) log.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsSampled() {
+		return logger
+	}
+	return logger.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+}
@@ -0,0 +1,129 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/tracing"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan map[string]interface{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests <- req
+	}))
+	defer srv.Close()
+
+	provider := tracing.NewProvider(tracing.NewOTLPExporter(srv.URL+"/v1/traces", time.Second), 1,
+		attribute.String("chain_id", "test-chain"))
+	tracer := provider.Tracer("cometbft/test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttributes(attribute.Int64("height", 5))
+	tracing.EndSpan(child, errors.New("failed"))
+	parent.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	req := <-requests
+	rs := req["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(t, rs["resource"].(map[string]interface{})["attributes"],
+		map[string]interface{}{"key": "chain_id", "value": map[string]interface{}{"stringValue": "test-chain"}})
+	ss := rs["scopeSpans"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "cometbft/test", ss["scope"].(map[string]interface{})["name"])
+
+	spans := ss["spans"].([]interface{})
+	require.Len(t, spans, 2)
+	childSpan, parentSpan := spans[0].(map[string]interface{}), spans[1].(map[string]interface{})
+	assert.Equal(t, "child", childSpan["name"])
+	assert.Equal(t, parentSpan["traceId"], childSpan["traceId"])
+	assert.Equal(t, parentSpan["spanId"], childSpan["parentSpanId"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": "height", "value": map[string]interface{}{"intValue": "5"}}},
+		childSpan["attributes"])
+	assert.Equal(t, map[string]interface{}{"code": float64(2), "message": "failed"}, childSpan["status"])
+	assert.NotContains(t, parentSpan, "parentSpanId")
+}
+
+func TestOTLPExporterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	exporter := tracing.NewOTLPExporter(srv.URL, time.Second)
+	err := exporter.ExportSpans(context.Background(), nil)
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestWriterExporter(t *testing.T) {
+	var buf bytes.Buffer
+	provider := tracing.NewProvider(tracing.NewWriterExporter(&buf), 1)
+	_, span := provider.Tracer("cometbft/test").Start(context.Background(), "span")
+	span.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"name":"span"`)
+}
+
+func TestNewExporter(t *testing.T) {
+	_, err := tracing.NewExporter(tracing.ExporterStdout, "")
+	assert.NoError(t, err)
+	_, err = tracing.NewExporter(tracing.ExporterOTLP, "http://localhost:4318/v1/traces")
+	assert.NoError(t, err)
+	_, err = tracing.NewExporter("jaeger", "")
+	assert.Error(t, err)
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewTMJSONLoggerNoTS(&buf)
+
+	tracing.Logger(context.Background(), logger).Info("foo")
+	assert.NotContains(t, buf.String(), "trace_id")
+
+	provider := tracing.NewProvider(tracing.NewWriterExporter(io.Discard), 1)
+	defer provider.Shutdown(context.Background()) //nolint:errcheck
+	ctx, span := provider.Tracer("cometbft/test").Start(context.Background(), "span")
+	defer span.End()
+
+	buf.Reset()
+	tracing.Logger(ctx, logger).Info("foo")
+	assert.Contains(t, buf.String(), `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+	assert.Contains(t, buf.String(), `"span_id":"`+span.SpanContext().SpanID().String()+`"`)
+}
+
+func TestTracer(t *testing.T) {
+	defer tracing.Install(trace.NewNoopTracerProvider())
+	tracer := tracing.Tracer("test")
+
+	// the tracer uses the provider installed last
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		provider := tracing.NewProvider(tracing.NewWriterExporter(&buf), 1)
+		tracing.Install(provider)
+		_, span := tracer.Start(context.Background(), "span")
+		span.End()
+		require.NoError(t, provider.Shutdown(context.Background()))
+		assert.Contains(t, buf.String(), `"name":"cometbft/test"`)
+	}
+}
@@ -37,11 +37,14 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
 		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"mempool_contents":     rpcserver.NewRPCFunc(makeMempoolContentsFunc(c), "sender,page,per_page"),
+		"tx_status":            rpcserver.NewRPCFunc(makeTxStatusFunc(c), "hash"),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
 		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx"),
 		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx"),
+		"broadcast_tx_batch":  rpcserver.NewRPCFunc(makeBroadcastTxBatchFunc(c), "txs"),
 
 		// abci API
 		"abci_query": rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove"),
@@ -244,6 +247,30 @@ func makeNumUnconfirmedTxsFunc(c *lrpc.Client) rpcNumUnconfirmedTxsFunc {
 	}
 }
 
+type rpcMempoolContentsFunc func(
+	ctx *rpctypes.Context,
+	sender string,
+	page, perPage *int,
+) (*ctypes.ResultMempoolContents, error)
+
+func makeMempoolContentsFunc(c *lrpc.Client) rpcMempoolContentsFunc {
+	return func(
+		ctx *rpctypes.Context,
+		sender string,
+		page, perPage *int,
+	) (*ctypes.ResultMempoolContents, error) {
+		return c.MempoolContents(ctx.Context(), sender, page, perPage)
+	}
+}
+
+type rpcTxStatusFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error)
+
+func makeTxStatusFunc(c *lrpc.Client) rpcTxStatusFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+		return c.TxStatus(ctx.Context(), hash)
+	}
+}
+
 type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
 
 func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
@@ -268,6 +295,14 @@ func makeBroadcastTxAsyncFunc(c *lrpc.Client) rpcBroadcastTxAsyncFunc {
 	}
 }
 
+type rpcBroadcastTxBatchFunc func(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error)
+
+func makeBroadcastTxBatchFunc(c *lrpc.Client) rpcBroadcastTxBatchFunc {
+	return func(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+		return c.BroadcastTxBatch(ctx.Context(), txs)
+	}
+}
+
 type rpcABCIQueryFunc func(ctx *rpctypes.Context, path string,
 	data bytes.HexBytes, height int64, prove bool) (*ctypes.ResultABCIQuery, error)
 
@@ -199,6 +199,10 @@ func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.Res
 	return c.next.BroadcastTxAsync(ctx, tx)
 }
 
+func (c *Client) BroadcastTxBatch(ctx context.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+	return c.next.BroadcastTxBatch(ctx, txs)
+}
+
 func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxSync(ctx, tx)
 }
@@ -211,6 +215,19 @@ func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirm
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Client) MempoolContents(
+	ctx context.Context,
+	sender string,
+	page,
+	perPage *int,
+) (*ctypes.ResultMempoolContents, error) {
+	return c.next.MempoolContents(ctx, sender, page, perPage)
+}
+
+func (c *Client) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	return c.next.TxStatus(ctx, hash)
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
@@ -0,0 +1,35 @@
+package mempool
+
+import (
+	"strings"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// BroadcastPeers selects the peers the txs of the mempool are broadcast to,
+// after the broadcast mode of the config: all the peers in the flood mode, and
+// only the sequencer peers in the sequencer mode, for the txs to be pushed to
+// the sequencer rather than flooded over the network.
+type BroadcastPeers struct {
+	sequencerPeers map[p2p.ID]bool // nil in the flood mode
+}
+
+// NewBroadcastPeers returns the peers the txs are broadcast to after config.
+func NewBroadcastPeers(config *cfg.MempoolConfig) *BroadcastPeers {
+	if config.BroadcastMode != cfg.MempoolBroadcastSequencer {
+		return &BroadcastPeers{}
+	}
+	peers := make(map[p2p.ID]bool)
+	for _, id := range strings.Split(config.SequencerPeerIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			peers[p2p.ID(id)] = true
+		}
+	}
+	return &BroadcastPeers{sequencerPeers: peers}
+}
+
+// Includes returns whether the txs are broadcast to the peer of id.
+func (b *BroadcastPeers) Includes(id p2p.ID) bool {
+	return b.sequencerPeers == nil || b.sequencerPeers[id]
+}
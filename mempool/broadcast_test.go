@@ -0,0 +1,31 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestBroadcastPeers(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	config.SequencerPeerIDs = "a, b,,"
+
+	// all the peers in the flood mode
+	peers := NewBroadcastPeers(config)
+	assert.True(t, peers.Includes("a"))
+	assert.True(t, peers.Includes("c"))
+
+	// only the sequencer peers in the sequencer mode
+	config.BroadcastMode = cfg.MempoolBroadcastSequencer
+	peers = NewBroadcastPeers(config)
+	assert.True(t, peers.Includes("a"))
+	assert.True(t, peers.Includes("b"))
+	assert.False(t, peers.Includes("c"))
+	assert.False(t, peers.Includes(""))
+
+	// and none on the sequencer itself
+	config.SequencerPeerIDs = ""
+	assert.False(t, NewBroadcastPeers(config).Includes("a"))
+}
@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"container/list"
+	"time"
 
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/types"
@@ -23,6 +24,9 @@ type TxCache interface {
 	// Remove removes the given raw transaction from the cache.
 	Remove(tx types.Tx)
 
+	// RemoveTxKey removes the raw transaction of the given key from the cache.
+	RemoveTxKey(key types.TxKey)
+
 	// Has reports whether tx is present in the cache. Checking for presence is
 	// not treated as an access of the value.
 	Has(tx types.Tx) bool
@@ -89,10 +93,13 @@ func (c *LRUTxCache) Push(tx types.Tx) bool {
 }
 
 func (c *LRUTxCache) Remove(tx types.Tx) {
+	c.RemoveTxKey(tx.Key())
+}
+
+func (c *LRUTxCache) RemoveTxKey(key types.TxKey) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
-	key := tx.Key()
 	e := c.cacheMap[key]
 	delete(c.cacheMap, key)
 
@@ -109,12 +116,109 @@ func (c *LRUTxCache) Has(tx types.Tx) bool {
 	return ok
 }
 
+var _ TxCache = (*TTLTxCache)(nil)
+
+// TTLTxCache maintains a thread-safe LRU cache of raw transactions, whose
+// entries expire ttl after they are pushed if ttl is positive. An expired
+// transaction is not in the cache anymore, and pushing it adds it anew.
+type TTLTxCache struct {
+	mtx      cmtsync.Mutex
+	size     int
+	ttl      time.Duration
+	cacheMap map[types.TxKey]*list.Element
+	list     *list.List // of *ttlTxCacheEntry, the least recently used first
+}
+
+type ttlTxCacheEntry struct {
+	key     types.TxKey
+	expires time.Time // zero if the entry doesn't expire
+}
+
+func NewTTLTxCache(cacheSize int, ttl time.Duration) *TTLTxCache {
+	return &TTLTxCache{
+		size:     cacheSize,
+		ttl:      ttl,
+		cacheMap: make(map[types.TxKey]*list.Element, cacheSize),
+		list:     list.New(),
+	}
+}
+
+func (c *TTLTxCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.cacheMap = make(map[types.TxKey]*list.Element, c.size)
+	c.list.Init()
+}
+
+func (c *TTLTxCache) Push(tx types.Tx) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := tx.Key()
+	now := time.Now()
+
+	if e, ok := c.cacheMap[key]; ok {
+		if !c.expired(e, now) {
+			c.list.MoveToBack(e)
+			return false
+		}
+		c.remove(e)
+	}
+
+	if c.list.Len() >= c.size {
+		if front := c.list.Front(); front != nil {
+			c.remove(front)
+		}
+	}
+
+	entry := &ttlTxCacheEntry{key: key}
+	if c.ttl > 0 {
+		entry.expires = now.Add(c.ttl)
+	}
+	c.cacheMap[key] = c.list.PushBack(entry)
+
+	return true
+}
+
+func (c *TTLTxCache) Remove(tx types.Tx) {
+	c.RemoveTxKey(tx.Key())
+}
+
+func (c *TTLTxCache) RemoveTxKey(key types.TxKey) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *TTLTxCache) Has(tx types.Tx) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.cacheMap[tx.Key()]
+	return ok && !c.expired(e, time.Now())
+}
+
+func (c *TTLTxCache) expired(e *list.Element, now time.Time) bool {
+	expires := e.Value.(*ttlTxCacheEntry).expires
+	return !expires.IsZero() && !now.Before(expires)
+}
+
+func (c *TTLTxCache) remove(e *list.Element) {
+	delete(c.cacheMap, e.Value.(*ttlTxCacheEntry).key)
+	c.list.Remove(e)
+}
+
 // NopTxCache defines a no-op raw transaction cache.
 type NopTxCache struct{}
 
 var _ TxCache = (*NopTxCache)(nil)
 
-func (NopTxCache) Reset()             {}
-func (NopTxCache) Push(types.Tx) bool { return true }
-func (NopTxCache) Remove(types.Tx)    {}
-func (NopTxCache) Has(types.Tx) bool  { return false }
+func (NopTxCache) Reset()                  {}
+func (NopTxCache) Push(types.Tx) bool      { return true }
+func (NopTxCache) Remove(types.Tx)         {}
+func (NopTxCache) RemoveTxKey(types.TxKey) {}
+func (NopTxCache) Has(types.Tx) bool       { return false }
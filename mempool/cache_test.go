@@ -3,8 +3,11 @@ package mempool
 import (
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestCacheRemove(t *testing.T) {
@@ -33,3 +36,37 @@ func TestCacheRemove(t *testing.T) {
 		require.Equal(t, numTxs-(i+1), cache.list.Len())
 	}
 }
+
+func TestTTLTxCache(t *testing.T) {
+	cache := NewTTLTxCache(2, 50*time.Millisecond)
+	a, b, c := types.Tx("a"), types.Tx("b"), types.Tx("c")
+
+	require.True(t, cache.Push(a))
+	require.False(t, cache.Push(a))
+	require.True(t, cache.Has(a))
+
+	// the least recently used tx is evicted first
+	require.True(t, cache.Push(b))
+	require.False(t, cache.Push(a))
+	require.True(t, cache.Push(c))
+	require.True(t, cache.Has(a))
+	require.False(t, cache.Has(b))
+
+	cache.RemoveTxKey(c.Key())
+	require.False(t, cache.Has(c))
+
+	// and the txs expire, to be pushed anew
+	time.Sleep(60 * time.Millisecond)
+	require.False(t, cache.Has(a))
+	require.True(t, cache.Push(a))
+	require.True(t, cache.Has(a))
+
+	cache.Reset()
+	require.False(t, cache.Has(a))
+
+	// without a ttl, the txs don't expire
+	cache = NewTTLTxCache(2, 0)
+	require.True(t, cache.Push(a))
+	time.Sleep(10 * time.Millisecond)
+	require.False(t, cache.Push(a))
+}
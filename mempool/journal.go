@@ -0,0 +1,241 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// journalRecordHeaderSize is the size of the header of a record of the
+// journal: the length of the tx, then its CRC32C checksum.
+const journalRecordHeaderSize = 8
+
+var journalCRC = crc32.MakeTable(crc32.Castagnoli)
+
+// Journal persists the txs of the mempool across restarts, for them to be
+// rechecked on startup. The txs added to the mempool are appended to it, and it
+// is compacted to the txs left in the mempool after the blocks once it grows
+// to twice their size, so it may hold some committed txs, rejected when
+// rechecked. Its size is capped: the txs appended over the cap are only
+// persisted once a compaction frees enough room.
+//
+// The appends aren't synced to the disk, so the journal survives the crashes
+// of the node, but the txs of the last seconds may be lost on the crashes of
+// the machine. The records are checksummed, and a journal is only loaded up to
+// its first corrupted or truncated record.
+type Journal struct {
+	path     string
+	maxBytes int64
+	logger   log.Logger
+
+	mtx   cmtsync.Mutex
+	file  *os.File
+	size  int64
+	dirty bool // whether the journal must be compacted, e.g. it missed txs
+}
+
+// NewJournal returns the journal of the mempool at path, of at most maxBytes.
+// It must be loaded before use.
+func NewJournal(path string, maxBytes int64, logger log.Logger) *Journal {
+	return &Journal{path: path, maxBytes: maxBytes, logger: logger}
+}
+
+// Load opens the journal and returns its txs, up to its first corrupted record,
+// which is truncated along with the records after it. The txs are left in the
+// journal until its next compaction, so that they aren't lost if the node
+// stops before they are rechecked.
+func (j *Journal) Load() (types.Txs, error) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if j.file != nil {
+		return nil, errors.New("the mempool journal is already loaded")
+	}
+	if err := cmtos.EnsureDir(filepath.Dir(j.path), 0o700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(j.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		txs    types.Txs
+		offset int64
+		r      = bufio.NewReader(file)
+	)
+	for {
+		tx, err := readJournalRecord(r, j.maxBytes)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			j.logger.Error("Truncating the corrupted mempool journal", "offset", offset, "err", err)
+			break
+		}
+		txs = append(txs, tx)
+		offset += journalRecordHeaderSize + int64(len(tx))
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	j.file = file
+	j.size = offset
+	j.dirty = len(txs) > 0
+	return txs, nil
+}
+
+// Append appends a tx added to the mempool, unless the journal is full.
+func (j *Journal) Append(tx types.Tx) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if j.file == nil {
+		return errors.New("the mempool journal isn't loaded")
+	}
+	size := journalRecordHeaderSize + int64(len(tx))
+	if j.size+size > j.maxBytes {
+		j.dirty = true
+		return nil
+	}
+	if _, err := j.file.Write(journalRecord(tx)); err != nil {
+		// the partial record is truncated by the next compaction or load
+		j.dirty = true
+		return err
+	}
+	j.size += size
+	return nil
+}
+
+// NeedsCompaction returns whether the journal must be compacted to the numTxs
+// txs of the mempool, of txsBytes bytes in total: it missed txs, or holds more
+// txs which have left the mempool than txs left.
+func (j *Journal) NeedsCompaction(numTxs int, txsBytes int64) bool {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.file != nil && (j.dirty || j.size > 2*(int64(numTxs)*journalRecordHeaderSize+txsBytes))
+}
+
+// Compact replaces the content of the journal with txs, up to its cap. The new
+// journal is written to a temporary file first, so that the txs aren't lost if
+// the node stops meanwhile.
+func (j *Journal) Compact(txs types.Txs) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if j.file == nil {
+		return errors.New("the mempool journal isn't loaded")
+	}
+	tmpPath := j.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	var (
+		w     = bufio.NewWriter(tmp)
+		size  int64
+		dirty bool
+	)
+	for _, tx := range txs {
+		if size+journalRecordHeaderSize+int64(len(tx)) > j.maxBytes {
+			dirty = true
+			break
+		}
+		if _, err := w.Write(journalRecord(tx)); err != nil {
+			tmp.Close()
+			return err
+		}
+		size += journalRecordHeaderSize + int64(len(tx))
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// the temporary file is the journal now, and is kept open to append to it
+	if err := j.file.Close(); err != nil {
+		j.logger.Error("Failed to close the previous mempool journal", "err", err)
+	}
+	tmp.Close()
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		j.file = nil
+		return fmt.Errorf("failed to reopen the mempool journal: %w", err)
+	}
+	j.file = file
+	j.size = size
+	j.dirty = dirty
+	return nil
+}
+
+// Close closes the journal.
+func (j *Journal) Close() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// journalRecord returns the record of tx.
+func journalRecord(tx types.Tx) []byte {
+	record := make([]byte, journalRecordHeaderSize+len(tx))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(tx)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(tx, journalCRC))
+	copy(record[journalRecordHeaderSize:], tx)
+	return record
+}
+
+// readJournalRecord reads the tx of the next record of a journal of at most
+// maxBytes. It returns io.EOF at the end of the journal, and another error if
+// the record is corrupted or truncated.
+func readJournalRecord(r io.Reader, maxBytes int64) (types.Tx, error) {
+	var header [journalRecordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errors.New("truncated record header")
+		}
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[0:4])
+	if int64(length) > maxBytes {
+		return nil, fmt.Errorf("record length %d over the size of the journal", length)
+	}
+	tx := make(types.Tx, length)
+	if _, err := io.ReadFull(r, tx); err != nil {
+		return nil, fmt.Errorf("truncated record: %w", err)
+	}
+	if crc := crc32.Checksum(tx, journalCRC); crc != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, fmt.Errorf("record checksum mismatch: expected %X, got %X", binary.BigEndian.Uint32(header[4:8]), crc)
+	}
+	return tx, nil
+}
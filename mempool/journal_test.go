@@ -0,0 +1,67 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "mempool.journal")
+	j := NewJournal(path, 100, log.TestingLogger())
+	txs, err := j.Load()
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	assert.False(t, j.NeedsCompaction(0, 0))
+
+	// the txs over the cap are left out until a compaction
+	for _, tx := range []string{"tx1", "tx2", "tx3"} {
+		require.NoError(t, j.Append(types.Tx(tx)))
+	}
+	require.NoError(t, j.Append(make(types.Tx, 80)))
+	assert.True(t, j.NeedsCompaction(4, 89))
+	require.NoError(t, j.Close())
+
+	j = NewJournal(path, 100, log.TestingLogger())
+	txs, err = j.Load()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3")}, txs)
+	assert.True(t, j.NeedsCompaction(3, 9), "the loaded txs are added back")
+	require.NoError(t, j.Compact(types.Txs{types.Tx("tx2"), make(types.Tx, 80)}))
+	assert.False(t, j.NeedsCompaction(2, 83))
+	assert.True(t, j.NeedsCompaction(1, 3), "most txs left the mempool")
+	require.NoError(t, j.Append(types.Tx("tx4")))
+	require.NoError(t, j.Close())
+
+	// a journal is loaded up to its first truncated or corrupted record, which
+	// is truncated along with the records after it
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append(bz, journalRecord(types.Tx("tx5"))[:9]...), 0o600))
+	j = NewJournal(path, 100, log.TestingLogger())
+	txs, err = j.Load()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx2"), make(types.Tx, 80)}, txs)
+	require.NoError(t, j.Close())
+
+	bz[len(bz)-1]++
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+	j = NewJournal(path, 100, log.TestingLogger())
+	txs, err = j.Load()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx2")}, txs)
+	require.NoError(t, j.Append(types.Tx("tx6")))
+	require.NoError(t, j.Close())
+
+	j = NewJournal(path, 100, log.TestingLogger())
+	txs, err = j.Load()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx2"), types.Tx("tx6")}, txs)
+	require.NoError(t, j.Close())
+}
@@ -0,0 +1,70 @@
+package mempool
+
+import (
+	"time"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// PeerRateLimiter limits the rate of the txs received from each peer, so that
+// a peer flooding the mempool can't starve the others of CheckTx. Each peer
+// has a token bucket refilled at the rate, holding at most a second of txs.
+type PeerRateLimiter struct {
+	rate float64 // txs per second, 0 for no limit
+	now  func() time.Time
+
+	mtx     cmtsync.Mutex
+	buckets map[p2p.ID]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewPeerRateLimiter returns a limiter of rate txs per second and per peer, or
+// without limit if rate is 0.
+func NewPeerRateLimiter(rate int) *PeerRateLimiter {
+	return &PeerRateLimiter{
+		rate:    float64(rate),
+		now:     time.Now,
+		buckets: make(map[p2p.ID]*tokenBucket),
+	}
+}
+
+// Allow returns whether a tx received from peer is within its rate, and takes
+// a token from its bucket if so.
+func (l *PeerRateLimiter) Allow(peer p2p.ID) bool {
+	if l.rate == 0 {
+		return true
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[peer]
+	if !ok {
+		b = &tokenBucket{tokens: l.rate, last: now}
+		l.buckets[peer] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.rate {
+			b.tokens = l.rate
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RemovePeer forgets the bucket of a peer which disconnected.
+func (l *PeerRateLimiter) RemovePeer(peer p2p.ID) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	delete(l.buckets, peer)
+}
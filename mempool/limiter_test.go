@@ -0,0 +1,44 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestPeerRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := NewPeerRateLimiter(10)
+	l.now = func() time.Time { return now }
+
+	// a second of txs is allowed at once, per peer
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.Allow("a"), i)
+	}
+	assert.False(t, l.Allow("a"))
+	assert.True(t, l.Allow("b"))
+
+	// then the bucket is refilled at the rate, up to a second of txs
+	now = now.Add(250 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		assert.True(t, l.Allow("a"), i)
+	}
+	assert.False(t, l.Allow("a"))
+	now = now.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.Allow("a"), i)
+	}
+	assert.False(t, l.Allow("a"))
+
+	// the peers which reconnect start with a full bucket
+	l.RemovePeer("a")
+	assert.True(t, l.Allow("a"))
+
+	unlimited := NewPeerRateLimiter(0)
+	for i := 0; i < 1000; i++ {
+		assert.True(t, unlimited.Allow(p2p.ID("a")))
+	}
+}
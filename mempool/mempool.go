@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/types"
@@ -21,6 +23,37 @@ const (
 	UnknownPeerID uint16 = 0
 
 	MaxActiveIDs = math.MaxUint16
+
+	// The app returns the metadata of a tx for the mempool with the attributes
+	// of an EventType event of its ResponseCheckTx: the lane of the v0 mempool
+	// it assigns the tx to, and the nonce of the tx among the txs of its sender
+	// for the v1 mempool.
+	EventType         = "mempool"
+	LaneAttributeKey  = "lane"
+	NonceAttributeKey = "nonce"
+
+	// The app requests the refresh of the priorities of the txs of the mempool
+	// with a "true" attribute of an EventType event of its ResponseEndBlock,
+	// e.g. once it changed its fee params: the txs are rechecked after the
+	// block, even if recheck is disabled, for the app to return their new
+	// priorities.
+	RefreshPrioritiesAttributeKey = "refresh_priorities"
+
+	// The reasons of the evictions of valid txs from the mempool: they have
+	// been in the mempool for more than ttl-num-blocks blocks, or ttl-duration.
+	EvictionReasonTTLNumBlocks = "ttl-num-blocks"
+	EvictionReasonTTLDuration  = "ttl-duration"
+
+	// The reasons of the drops of txs by the rate limits: they are received
+	// from a peer over peer_max_txs_per_second, or their sender already has
+	// max_txs_per_sender txs in the mempool.
+	RateLimitReasonPeer   = "peer"
+	RateLimitReasonSender = "sender"
+
+	// The caches of the txs: that of the txs seen, and that of the invalid txs
+	// kept apart from them, with invalid_cache_size.
+	CacheValid   = "valid"
+	CacheInvalid = "invalid"
 )
 
 // Mempool defines the mempool interface.
@@ -32,10 +65,22 @@ type Mempool interface {
 	// its validity and whether it should be added to the mempool.
 	CheckTx(tx types.Tx, callback func(*abci.Response), txInfo TxInfo) error
 
+	// CheckTxBatch executes a batch of new transactions like CheckTx, with
+	// their requests sent to the application at once, and returns once it
+	// responded to all of them. The error of each transaction rejected before
+	// being checked is at its index, and the callback is called with the index
+	// and the response of each other one.
+	CheckTxBatch(txs types.Txs, callback func(int, *abci.Response), txInfo TxInfo) []error
+
 	// RemoveTxByKey removes a transaction, identified by its key,
 	// from the mempool.
 	RemoveTxByKey(txKey types.TxKey) error
 
+	// ForgetTx removes a transaction, identified by its key, from the caches
+	// of the mempool, so that it can be resubmitted, e.g. once it became
+	// valid. The transaction is left in the mempool if there.
+	ForgetTx(txKey types.TxKey)
+
 	// ReapMaxBytesMaxGas reaps transactions from the mempool up to maxBytes
 	// bytes total with the condition that the total gasWanted must be less than
 	// maxGas.
@@ -80,6 +125,15 @@ type Mempool interface {
 	// Flush removes all transactions from the mempool and caches.
 	Flush()
 
+	// FlushRechecks rechecks all the transactions against the current state of
+	// the application, and waits for those which are no longer valid to be
+	// removed, e.g. once the application rejects a stuck transaction.
+	FlushRechecks() error
+
+	// Contents returns all the transactions with their metadata, in the order
+	// they are reaped.
+	Contents() []TxMetadata
+
 	// TxsAvailable returns a channel which fires once for every height, and only
 	// when transactions are available in the mempool.
 	//
@@ -108,6 +162,83 @@ type PreCheckFunc func(types.Tx) error
 // transaction doesn't require more gas than available for the block.
 type PostCheckFunc func(types.Tx, *abci.ResponseCheckTx) error
 
+// CheckTxContext is the context of the checks of the txs by the app, as of the
+// latest committed block: the height of the next block, the time of the latest
+// one, and the address of the proposer of the next block in its first round.
+type CheckTxContext struct {
+	Height          int64
+	Time            time.Time
+	ProposerAddress []byte
+}
+
+// Request returns the request to check tx in the context.
+func (c CheckTxContext) Request(tx types.Tx, checkType abci.CheckTxType) abci.RequestCheckTx {
+	return abci.RequestCheckTx{
+		Tx:              tx,
+		Type:            checkType,
+		Height:          c.Height,
+		Time:            c.Time,
+		ProposerAddress: c.ProposerAddress,
+	}
+}
+
+// EvictionPublisher publishes the evictions of valid txs from the mempool, e.g.
+// to the event bus.
+type EvictionPublisher interface {
+	PublishEventMempoolTxEvicted(types.EventDataMempoolTxEvicted) error
+}
+
+// TxLane returns the lane of the mempool which the app assigned a tx to in
+// res, or "" if none.
+func TxLane(res *abci.ResponseCheckTx) string {
+	lane, _ := txAttribute(res, LaneAttributeKey)
+	return lane
+}
+
+// TxNonce returns the nonce of a tx among the txs of its sender, as returned
+// by the app in res, and whether it was returned.
+func TxNonce(res *abci.ResponseCheckTx) (uint64, bool) {
+	value, ok := txAttribute(res, NonceAttributeKey)
+	if !ok {
+		return 0, false
+	}
+	nonce, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return nonce, true
+}
+
+// RefreshPrioritiesRequested returns whether the app requested the refresh of
+// the priorities of the txs of the mempool in res.
+func RefreshPrioritiesRequested(res *abci.ResponseEndBlock) bool {
+	if res == nil {
+		return false
+	}
+	value, _ := eventAttribute(res.Events, RefreshPrioritiesAttributeKey)
+	return value == "true"
+}
+
+// txAttribute returns the value of an attribute of the mempool events of res.
+func txAttribute(res *abci.ResponseCheckTx, key string) (string, bool) {
+	return eventAttribute(res.Events, key)
+}
+
+// eventAttribute returns the value of an attribute of the mempool events.
+func eventAttribute(events []abci.Event, key string) (string, bool) {
+	for _, event := range events {
+		if event.Type != EventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == key {
+				return string(attr.Value), true
+			}
+		}
+	}
+	return "", false
+}
+
 // PreCheckMaxBytes checks that the size of the transaction is smaller or equal
 // to the expected maxBytes.
 func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
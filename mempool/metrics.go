@@ -19,6 +19,9 @@ type Metrics struct {
 	// Size of the mempool.
 	Size metrics.Gauge
 
+	// Size of each lane of the v0 mempool.
+	LaneSize metrics.Gauge
+
 	// Histogram of transaction sizes, in bytes.
 	TxSizeBytes metrics.Histogram
 
@@ -39,6 +42,17 @@ type Metrics struct {
 
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+
+	// Number of transactions dropped by the rate limits, by reason: over the
+	// rate of their peer, or over the cap of their sender.
+	RateLimitedTxs metrics.Counter
+
+	// Number of transactions found in the cache when received, by cache: that
+	// of the valid transactions, or that of the invalid ones.
+	CacheHits metrics.Counter
+
+	// Number of transactions not found in the cache when received.
+	CacheMisses metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -57,6 +71,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Size of the mempool (number of uncommitted transactions).",
 		}, labels).With(labelsAndValues...),
 
+		LaneSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "lane_size",
+			Help:      "Size of each lane of the mempool (number of uncommitted transactions).",
+		}, append(labels, "lane")).With(labelsAndValues...),
+
 		TxSizeBytes: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -92,17 +113,42 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+
+		RateLimitedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "num_txs_rejected_rate_limited",
+			Help:      "Number of transactions dropped by the rate limits of the peers and senders.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+
+		CacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_hits",
+			Help:      "Number of transactions found in the cache of the valid or invalid transactions when received.",
+		}, append(labels, "cache")).With(labelsAndValues...),
+
+		CacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_misses",
+			Help:      "Number of transactions not found in the cache when received.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RejectedTxs:  discard.NewCounter(),
-		EvictedTxs:   discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:           discard.NewGauge(),
+		LaneSize:       discard.NewGauge(),
+		TxSizeBytes:    discard.NewHistogram(),
+		FailedTxs:      discard.NewCounter(),
+		RejectedTxs:    discard.NewCounter(),
+		EvictedTxs:     discard.NewCounter(),
+		RecheckTimes:   discard.NewCounter(),
+		RateLimitedTxs: discard.NewCounter(),
+		CacheHits:      discard.NewCounter(),
+		CacheMisses:    discard.NewCounter(),
 	}
 }
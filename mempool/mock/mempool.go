@@ -18,7 +18,11 @@ func (Mempool) Size() int { return 0 }
 func (Mempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempool.TxInfo) error {
 	return nil
 }
+func (Mempool) CheckTxBatch(txs types.Txs, _ func(int, *abci.Response), _ mempool.TxInfo) []error {
+	return make([]error, len(txs))
+}
 func (Mempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
+func (Mempool) ForgetTx(txKey types.TxKey)              {}
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
 func (Mempool) Update(
@@ -32,10 +36,13 @@ func (Mempool) Update(
 }
 func (Mempool) Flush()                        {}
 func (Mempool) FlushAppConn() error           { return nil }
+func (Mempool) FlushRechecks() error          { return nil }
 func (Mempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (Mempool) EnableTxsAvailable()           {}
 func (Mempool) SizeBytes() int64              { return 0 }
 
+func (Mempool) Contents() []mempool.TxMetadata { return nil }
+
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
 
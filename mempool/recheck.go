@@ -0,0 +1,58 @@
+package mempool
+
+import (
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ParallelRecheck rechecks txs over conns in parallel, and returns their
+// responses in the order of txs, checked in checkTxCtx. The txs are sharded in contiguous ranges, one
+// per connection, so that the txs of a range are rechecked in their order.
+//
+// A connection stops at its first error, and the responses of the txs it left
+// unchecked are nil. The first of these errors is returned.
+func ParallelRecheck(
+	conns []proxy.AppConnMempool,
+	txs types.Txs,
+	checkTxCtx CheckTxContext,
+) ([]*abci.ResponseCheckTx, error) {
+	var (
+		responses = make([]*abci.ResponseCheckTx, len(txs))
+		errs      = make([]error, len(conns))
+		shardSize = (len(txs) + len(conns) - 1) / len(conns)
+		wg        sync.WaitGroup
+	)
+	for i, conn := range conns {
+		start := i * shardSize
+		if start >= len(txs) {
+			break
+		}
+		end := start + shardSize
+		if end > len(txs) {
+			end = len(txs)
+		}
+		wg.Add(1)
+		go func(i int, conn proxy.AppConnMempool, start, end int) {
+			defer wg.Done()
+			for j := start; j < end; j++ {
+				res, err := conn.CheckTxSync(checkTxCtx.Request(txs[j], abci.CheckTxType_Recheck))
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				responses[j] = res
+			}
+		}(i, conn, start, end)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return responses, err
+		}
+	}
+	return responses, nil
+}
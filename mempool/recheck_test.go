@@ -0,0 +1,64 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// recheckConn records the txs it rechecks and their heights, and fails after
+// failAfter of them if positive.
+type recheckConn struct {
+	proxy.AppConnMempool
+	txs       types.Txs
+	heights   []int64
+	failAfter int
+}
+
+func (c *recheckConn) CheckTxSync(req abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	if c.failAfter > 0 && len(c.txs) == c.failAfter {
+		return nil, errors.New("connection lost")
+	}
+	c.txs = append(c.txs, req.Tx)
+	c.heights = append(c.heights, req.Height)
+	return &abci.ResponseCheckTx{Data: req.Tx}, nil
+}
+
+func TestParallelRecheck(t *testing.T) {
+	txs := types.Txs{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e")}
+	conns := []*recheckConn{{}, {}, {}}
+	checkTxCtx := CheckTxContext{Height: 7}
+	responses, err := ParallelRecheck([]proxy.AppConnMempool{conns[0], conns[1], conns[2]}, txs, checkTxCtx)
+	require.NoError(t, err)
+	require.Len(t, responses, 5)
+	for i, res := range responses {
+		assert.EqualValues(t, txs[i], res.Data)
+	}
+	// the txs are sharded in contiguous ranges
+	assert.Equal(t, types.Txs{[]byte("a"), []byte("b")}, conns[0].txs)
+	assert.Equal(t, types.Txs{[]byte("c"), []byte("d")}, conns[1].txs)
+	assert.Equal(t, types.Txs{[]byte("e")}, conns[2].txs)
+	// in the context
+	assert.Equal(t, []int64{7, 7}, conns[0].heights)
+
+	// a connection stops at its first error
+	failing := &recheckConn{failAfter: 1}
+	responses, err = ParallelRecheck([]proxy.AppConnMempool{&recheckConn{}, failing}, txs, checkTxCtx)
+	require.Error(t, err)
+	assert.NotNil(t, responses[2])
+	assert.NotNil(t, responses[3])
+	assert.Nil(t, responses[4])
+
+	// and a single connection rechecks all the txs
+	conn := abcicli.NewLocalClient(nil, abci.NewBaseApplication())
+	responses, err = ParallelRecheck([]proxy.AppConnMempool{conn}, txs, checkTxCtx)
+	require.NoError(t, err)
+	assert.Len(t, responses, 5)
+}
@@ -0,0 +1,73 @@
+package mempool
+
+import (
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Codespace is the codespace of the codes of the txs rejected by the mempool
+// itself, rather than by the app: before the app checks them, or once the app
+// accepted them.
+const Codespace = "mempool"
+
+// The codes of the txs rejected by the mempool, in Codespace, for the clients
+// to tell them apart: a tx too large never fits, a tx whose fee is too low may
+// be resubmitted with a higher fee, a tx rejected by a full mempool may be
+// resubmitted later, and a duplicate tx is already known.
+const (
+	CodeTxTooLarge uint32 = iota + 1
+	CodeFeeTooLow
+	CodeMempoolFull
+	CodeDuplicate
+)
+
+// The reasons of the rejections of the txs by the mempool, for each code.
+const (
+	RejectReasonTooLarge    = "too-large"
+	RejectReasonFeeTooLow   = "fee-too-low"
+	RejectReasonMempoolFull = "mempool-full"
+	RejectReasonDuplicate   = "duplicate"
+)
+
+var rejectReasons = map[uint32]string{
+	CodeTxTooLarge:  RejectReasonTooLarge,
+	CodeFeeTooLow:   RejectReasonFeeTooLow,
+	CodeMempoolFull: RejectReasonMempoolFull,
+	CodeDuplicate:   RejectReasonDuplicate,
+}
+
+// RejectReason returns the reason of the rejection of a tx by the mempool
+// from the codespace and code of its CheckTx response, or "" if the mempool
+// didn't reject it.
+func RejectReason(codespace string, code uint32) string {
+	if codespace != Codespace {
+		return ""
+	}
+	return rejectReasons[code]
+}
+
+// RejectCode returns the code of the rejection of a tx by an error of
+// CheckTx, if it has one: the txs too large, rejected by a full mempool, or
+// already in the cache.
+func RejectCode(err error) (uint32, bool) {
+	switch {
+	case errors.As(err, &ErrTxTooLarge{}):
+		return CodeTxTooLarge, true
+	case errors.As(err, &ErrMempoolIsFull{}):
+		return CodeMempoolFull, true
+	case errors.Is(err, ErrTxInCache):
+		return CodeDuplicate, true
+	default:
+		return 0, false
+	}
+}
+
+// Reject sets the code of the rejection of a tx accepted by the app in its
+// response, along with the log of the rejection.
+func Reject(res *abci.ResponseCheckTx, code uint32, log string) {
+	res.Code = code
+	res.Codespace = Codespace
+	res.Log = log
+	res.MempoolError = log
+}
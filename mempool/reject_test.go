@@ -0,0 +1,35 @@
+package mempool
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestRejectCode(t *testing.T) {
+	for err, code := range map[error]uint32{
+		ErrTxTooLarge{Max: 1, Actual: 2}:            CodeTxTooLarge,
+		ErrMempoolIsFull{}:                          CodeMempoolFull,
+		ErrTxInCache:                                CodeDuplicate,
+		fmt.Errorf("checking tx: %w", ErrTxInCache): CodeDuplicate,
+	} {
+		c, ok := RejectCode(err)
+		assert.True(t, ok, err)
+		assert.Equal(t, code, c, err)
+	}
+	_, ok := RejectCode(ErrPreCheck{Reason: fmt.Errorf("invalid")})
+	assert.False(t, ok)
+
+	res := &abci.ResponseCheckTx{Code: abci.CodeTypeOK, Log: "ok"}
+	Reject(res, CodeFeeTooLow, "rejected")
+	assert.Equal(t, RejectReasonFeeTooLow, RejectReason(res.Codespace, res.Code))
+	assert.Equal(t, "rejected", res.Log)
+	assert.Equal(t, "rejected", res.MempoolError)
+
+	// the codes of the app are in its own codespaces
+	assert.Empty(t, RejectReason("", CodeFeeTooLow))
+	assert.Empty(t, RejectReason(Codespace, 99))
+}
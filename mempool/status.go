@@ -0,0 +1,107 @@
+package mempool
+
+import (
+	"container/list"
+	"time"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The statuses of a tx along its lifecycle: it is received, i.e. added to the
+// mempool, gossiped to a peer, then proposed in a block, and finally leaves the
+// mempool, committed, evicted or rejected when rechecked.
+const (
+	TxStatusReceived      = "received"
+	TxStatusGossiped      = "gossiped"
+	TxStatusProposed      = "proposed"
+	TxStatusCommitted     = "committed"
+	TxStatusEvicted       = "evicted"
+	TxStatusFailedRecheck = "failed_recheck"
+)
+
+// TxStatusUnknown is the status of a tx which isn't tracked, e.g. never
+// received, or forgotten since.
+const TxStatusUnknown = "unknown"
+
+// TxStatus is the latest status of a tx, with the height of the mempool when it
+// was reached, or of the block for TxStatusProposed and TxStatusCommitted.
+type TxStatus struct {
+	Status string
+	Height int64
+	Time   time.Time
+}
+
+type txStatusEntry struct {
+	key    types.TxKey
+	status TxStatus
+}
+
+// TxStatuses tracks the statuses of the latest txs, at most size of them: the
+// least recently updated are forgotten first. A nil TxStatuses tracks nothing.
+type TxStatuses struct {
+	size int
+
+	mtx     cmtsync.Mutex
+	lru     *list.List // of *txStatusEntry, the most recently updated first
+	entries map[types.TxKey]*list.Element
+}
+
+// NewTxStatuses returns the statuses of at most size txs.
+func NewTxStatuses(size int) *TxStatuses {
+	return &TxStatuses{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[types.TxKey]*list.Element, size),
+	}
+}
+
+// Get returns the status of the tx of key, if tracked.
+func (s *TxStatuses) Get(key types.TxKey) (TxStatus, bool) {
+	if s == nil {
+		return TxStatus{}, false
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return TxStatus{}, false
+	}
+	return e.Value.(*txStatusEntry).status, true
+}
+
+// Set sets the status of the tx of key, but for TxStatusGossiped, which only
+// follows TxStatusReceived: a tx proposed or gone isn't gossiped anymore, and
+// only its first gossip is tracked.
+func (s *TxStatuses) Set(key types.TxKey, status string, height int64) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.entries[key]
+	if status == TxStatusGossiped && (!ok || e.Value.(*txStatusEntry).status.Status != TxStatusReceived) {
+		return
+	}
+	entry := &txStatusEntry{key: key, status: TxStatus{Status: status, Height: height, Time: time.Now()}}
+	if ok {
+		e.Value = entry
+		s.lru.MoveToFront(e)
+		return
+	}
+	s.entries[key] = s.lru.PushFront(entry)
+	if s.lru.Len() > s.size {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*txStatusEntry).key)
+	}
+}
+
+// SetTxs sets the status of txs, e.g. those of a block.
+func (s *TxStatuses) SetTxs(txs types.Txs, status string, height int64) {
+	for _, tx := range txs {
+		s.Set(tx.Key(), status, height)
+	}
+}
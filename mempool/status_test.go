@@ -0,0 +1,50 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxStatuses(t *testing.T) {
+	s := NewTxStatuses(2)
+	a, b, c := types.Tx("a").Key(), types.Tx("b").Key(), types.Tx("c").Key()
+
+	// only the first gossip of a received tx is tracked
+	s.Set(a, TxStatusGossiped, 1)
+	_, ok := s.Get(a)
+	assert.False(t, ok)
+	s.Set(a, TxStatusReceived, 1)
+	s.Set(a, TxStatusGossiped, 2)
+	s.Set(a, TxStatusGossiped, 3)
+	status, ok := s.Get(a)
+	require.True(t, ok)
+	assert.Equal(t, TxStatusGossiped, status.Status)
+	assert.EqualValues(t, 2, status.Height)
+	assert.False(t, status.Time.IsZero())
+
+	s.SetTxs(types.Txs{types.Tx("a"), types.Tx("b")}, TxStatusProposed, 4)
+	s.Set(a, TxStatusGossiped, 4)
+	status, _ = s.Get(a)
+	assert.Equal(t, TxStatus{Status: TxStatusProposed, Height: 4, Time: status.Time}, status)
+
+	// the least recently updated tx is forgotten first
+	s.Set(a, TxStatusCommitted, 5)
+	s.Set(c, TxStatusReceived, 5)
+	_, ok = s.Get(b)
+	assert.False(t, ok)
+	status, _ = s.Get(a)
+	assert.Equal(t, TxStatusCommitted, status.Status)
+	status, _ = s.Get(c)
+	assert.Equal(t, TxStatusReceived, status.Status)
+
+	// nothing is tracked without statuses
+	var none *TxStatuses
+	none.Set(a, TxStatusReceived, 1)
+	none.SetTxs(types.Txs{types.Tx("a")}, TxStatusCommitted, 1)
+	_, ok = none.Get(a)
+	assert.False(t, ok)
+}
@@ -1,7 +1,10 @@
 package mempool
 
 import (
+	"time"
+
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
 )
 
 // TxInfo are parameters that get passed when attempting to add a tx to the
@@ -15,3 +18,15 @@ type TxInfo struct {
 	// SenderP2PID is the actual p2p.ID of the sender, used e.g. for logging.
 	SenderP2PID p2p.ID
 }
+
+// TxMetadata is a tx of the mempool, with its metadata for introspection.
+type TxMetadata struct {
+	Tx          types.Tx
+	Height      int64     // height the tx was first checked at
+	ArrivalTime time.Time // time the tx was added to the mempool
+	GasWanted   int64     // as returned by the app in CheckTx
+	Priority    int64     // as returned by the app in CheckTx
+	Sender      string    // as returned by the app in CheckTx, if any
+	Lane        string    // lane of the v0 mempool
+	Peers       []p2p.ID  // peers the tx was received from, sorted
+}
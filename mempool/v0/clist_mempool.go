@@ -3,8 +3,10 @@ package v0
 import (
 	"bytes"
 	"errors"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
@@ -23,11 +25,20 @@ import (
 // CheckTx abci message before the transaction is added to the pool. The
 // mempool uses a concurrent list structure for storing transactions that can
 // be efficiently accessed by multiple concurrent readers.
+//
+// The transactions are split into the lanes of the config, which the app
+// assigns them to in CheckTx, and the default lane. Each lane has its own
+// limits and gossip rate, and the lanes are reaped in order.
 type CListMempool struct {
 	// Atomic integers
 	height   int64 // the last block Update()'d to
 	txsBytes int64 // total size of mempool, in bytes
 
+	// The limits of the default lane, set by SetLimits. Atomic integers read
+	// by the CheckTx callbacks.
+	maxSize     int64
+	maxTxsBytes int64
+
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
@@ -40,25 +51,51 @@ type CListMempool struct {
 	preCheck  mempool.PreCheckFunc
 	postCheck mempool.PostCheckFunc
 
-	txs          *clist.CList // concurrent linked-list of good txs
+	// The context of the checks of the txs, passed to the app along with them.
+	// Protected by updateMtx.
+	checkTxCtx mempool.CheckTxContext
+
+	// Whether the next update rechecks the txs even if recheck is disabled,
+	// on the request of the app. Protected by updateMtx.
+	refreshPriorities bool
+
+	// lanes of good txs, in the order they are reaped, the default lane last
+	lanes        []*lane
+	lanesByName  map[string]*lane
 	proxyAppConn proxy.AppConnMempool
 
 	// Track whether we're rechecking txs.
 	// These are not protected by a mutex and are expected to be mutated in
 	// serial (ie. by abci responses which are called in serial).
-	recheckCursor *clist.CElement // next expected response
-	recheckEnd    *clist.CElement // re-checking stops here
+	rechecking    []*clist.CElement // txs being re-checked, nil if none
+	recheckCursor int               // index of the next expected response
+
+	// Dedicated connections to recheck the txs in parallel, if any, and the
+	// channel closed once the parallel recheck in progress is done, nil if
+	// none. Protected by updateMtx.
+	recheckConns []proxy.AppConnMempool
+	recheckDone  chan struct{}
 
 	// Map for quick access to txs to record sender in CheckTx.
 	// txsMap: txKey -> CElement
 	txsMap sync.Map
 
+	// Number of txs of each sender returned by the app, for max_txs_per_sender.
+	sendersMtx     cmtsync.Mutex
+	numTxsBySender map[string]int
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache mempool.TxCache
-
-	logger  log.Logger
-	metrics *mempool.Metrics
+	// The invalid txs kept in the cache are apart in this one if
+	// invalid_cache_size is positive, so that they don't evict the valid ones.
+	invalidCache mempool.TxCache
+
+	logger    log.Logger
+	metrics   *mempool.Metrics
+	evictions mempool.EvictionPublisher
+	journal   *mempool.Journal // nil if the txs aren't persisted
+	statuses  *mempool.TxStatuses
 }
 
 var _ mempool.Mempool = &CListMempool{}
@@ -76,21 +113,35 @@ func NewCListMempool(
 ) *CListMempool {
 
 	mp := &CListMempool{
-		config:        cfg,
-		proxyAppConn:  proxyAppConn,
-		txs:           clist.New(),
-		height:        height,
-		recheckCursor: nil,
-		recheckEnd:    nil,
-		logger:        log.NewNopLogger(),
-		metrics:       mempool.NopMetrics(),
+		config:       cfg,
+		proxyAppConn: proxyAppConn,
+		lanesByName:  make(map[string]*lane, len(cfg.Lanes)),
+		height:       height,
+		maxSize:      int64(cfg.Size),
+		maxTxsBytes:  cfg.MaxTxsBytes,
+		logger:       log.NewNopLogger(),
+		metrics:      mempool.NopMetrics(),
+		evictions:    types.NopEventBus{},
+
+		numTxsBySender: make(map[string]int),
+	}
+	for i := range cfg.Lanes {
+		l := &lane{name: cfg.Lanes[i].Name, config: &cfg.Lanes[i], txs: clist.New()}
+		mp.lanes = append(mp.lanes, l)
+		mp.lanesByName[l.name] = l
 	}
+	mp.lanes = append(mp.lanes, &lane{name: config.MempoolDefaultLane, txs: clist.New()})
 
 	if cfg.CacheSize > 0 {
 		mp.cache = mempool.NewLRUTxCache(cfg.CacheSize)
 	} else {
 		mp.cache = mempool.NopTxCache{}
 	}
+	if cfg.KeepInvalidTxsInCache && cfg.InvalidCacheSize > 0 {
+		mp.invalidCache = mempool.NewTTLTxCache(cfg.InvalidCacheSize, cfg.InvalidCacheTTL)
+	} else {
+		mp.invalidCache = mempool.NopTxCache{}
+	}
 
 	proxyAppConn.SetResponseCallback(mp.globalCb)
 
@@ -125,11 +176,42 @@ func WithPostCheck(f mempool.PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithCheckTxContext sets the context of the checks of the txs until the next
+// block. After that, SetCheckTxContext overwrites it.
+func WithCheckTxContext(c mempool.CheckTxContext) CListMempoolOption {
+	return func(mem *CListMempool) { mem.checkTxCtx = c }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *mempool.Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithJournal sets the journal persisting the txs across restarts. It must be
+// loaded.
+func WithJournal(j *mempool.Journal) CListMempoolOption {
+	return func(mem *CListMempool) { mem.journal = j }
+}
+
+// WithRecheckConns sets dedicated connections to recheck the txs over in
+// parallel after each block, rather than on the mempool connection. The app's
+// CheckTx must be stateless per connection.
+func WithRecheckConns(conns []proxy.AppConnMempool) CListMempoolOption {
+	return func(mem *CListMempool) { mem.recheckConns = conns }
+}
+
+// WithTxStatuses sets the statuses of the txs, updated along their lifecycle in
+// the mempool.
+func WithTxStatuses(s *mempool.TxStatuses) CListMempoolOption {
+	return func(mem *CListMempool) { mem.statuses = s }
+}
+
+// WithEvictionPublisher sets the publisher of the evictions of valid txs, e.g.
+// when they expire.
+func WithEvictionPublisher(p mempool.EvictionPublisher) CListMempoolOption {
+	return func(mem *CListMempool) { mem.evictions = p }
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -140,9 +222,39 @@ func (mem *CListMempool) Unlock() {
 	mem.updateMtx.Unlock()
 }
 
+// SetCheckTxContext sets the context of the checks of the txs, e.g. that of
+// the next block before the txs are rechecked.
+//
+// Lock() must be held by the caller.
+func (mem *CListMempool) SetCheckTxContext(c mempool.CheckTxContext) {
+	mem.checkTxCtx = c
+}
+
+// RefreshPriorities makes the next Update recheck the txs, even if recheck is
+// disabled, for the app to return their priorities anew, e.g. once it changed
+// its fee params.
+//
+// Lock() must be held by the caller.
+func (mem *CListMempool) RefreshPriorities() {
+	mem.refreshPriorities = true
+}
+
+// SetLimits sets the maximum number and total size of the transactions in the
+// default lane of the mempool, e.g. when the configuration is reloaded. The transactions above the
+// new limits are kept, but new ones are rejected until the mempool shrinks.
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) SetLimits(size int, maxTxsBytes int64) {
+	atomic.StoreInt64(&mem.maxSize, int64(size))
+	atomic.StoreInt64(&mem.maxTxsBytes, maxTxsBytes)
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Size() int {
-	return mem.txs.Len()
+	size := 0
+	for _, l := range mem.lanes {
+		size += l.txs.Len()
+	}
+	return size
 }
 
 // Safe for concurrent use by multiple goroutines.
@@ -152,6 +264,7 @@ func (mem *CListMempool) SizeBytes() int64 {
 
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
+	mem.waitForParallelRecheck()
 	return mem.proxyAppConn.FlushSync()
 }
 
@@ -162,34 +275,81 @@ func (mem *CListMempool) Flush() {
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
 	mem.cache.Reset()
+	mem.invalidCache.Reset()
 
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		mem.txs.Remove(e)
-		e.DetachPrev()
+	for _, l := range mem.lanes {
+		_ = atomic.SwapInt64(&l.txsBytes, 0)
+		for e := l.txs.Front(); e != nil; e = e.Next() {
+			l.txs.Remove(e)
+			e.DetachPrev()
+		}
 	}
 
 	mem.txsMap.Range(func(key, _ interface{}) bool {
 		mem.txsMap.Delete(key)
 		return true
 	})
+	mem.sendersMtx.Lock()
+	mem.numTxsBySender = make(map[string]int)
+	mem.sendersMtx.Unlock()
+
+	if mem.journal != nil {
+		if err := mem.journal.Compact(nil); err != nil {
+			mem.logger.Error("failed to flush the mempool journal", "err", err)
+		}
+	}
 }
 
-// TxsFront returns the first transaction in the ordered list for peer
-// goroutines to call .NextWait() on.
+// FlushRechecks rechecks all the txs, holding the lock of the mempool until
+// the responses are processed. It fails if a recheck is already in progress.
+func (mem *CListMempool) FlushRechecks() error {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	if mem.rechecking != nil {
+		return errors.New("a recheck is already in progress")
+	}
+	mem.waitForParallelRecheck()
+	if mem.Size() == 0 {
+		return nil
+	}
+	mem.logger.Info("recheck txs", "numtxs", mem.Size(), "height", mem.height)
+	mem.recheckTxs()
+	mem.waitForParallelRecheck()
+	err := mem.proxyAppConn.FlushSync()
+	mem.updateSizeMetrics()
+	return err
+}
+
+// TxsFront returns the first transaction in the ordered list of the default
+// lane for peer goroutines to call .NextWait() on.
 // FIXME: leaking implementation details!
 //
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsFront() *clist.CElement {
-	return mem.txs.Front()
+	return mem.defaultLane().txs.Front()
 }
 
 // TxsWaitChan returns a channel to wait on transactions. It will be closed
-// once the mempool is not empty (ie. the internal `mem.txs` has at least one
+// once the default lane is not empty (ie. its internal `txs` has at least one
 // element)
 //
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsWaitChan() <-chan struct{} {
-	return mem.txs.WaitChan()
+	return mem.defaultLane().txs.WaitChan()
+}
+
+// defaultLane returns the lane of the txs assigned to no lane of the config.
+func (mem *CListMempool) defaultLane() *lane {
+	return mem.lanes[len(mem.lanes)-1]
+}
+
+// laneOf returns the lane which the app assigned a tx to in res.
+func (mem *CListMempool) laneOf(res *abci.ResponseCheckTx) *lane {
+	if l, ok := mem.lanesByName[mempool.TxLane(res)]; ok {
+		return l
+	}
+	return mem.defaultLane()
 }
 
 // It blocks if we're waiting on Update() or Reap().
@@ -236,27 +396,65 @@ func (mem *CListMempool) CheckTx(
 		return err
 	}
 
+	if mem.invalidCache.Has(tx) {
+		mem.metrics.CacheHits.With("cache", mempool.CacheInvalid).Add(1)
+		return mempool.ErrTxInCache
+	}
 	if !mem.cache.Push(tx) { // if the transaction already exists in the cache
+		mem.metrics.CacheHits.With("cache", mempool.CacheValid).Add(1)
 		// Record a new sender for a tx we've already seen.
 		// Note it's possible a tx is still in the cache but no longer in the mempool
 		// (eg. after committing a block, txs are removed from mempool but not cache),
 		// so we only record the sender for txs still in the mempool.
 		if e, ok := mem.txsMap.Load(tx.Key()); ok {
 			memTx := e.(*clist.CElement).Value.(*mempoolTx)
-			memTx.senders.LoadOrStore(txInfo.SenderID, true)
+			memTx.senders.LoadOrStore(txInfo.SenderID, txInfo.SenderP2PID)
 			// TODO: consider punishing peer for dups,
 			// its non-trivial since invalid txs can become valid,
 			// but they can spam the same tx with little cost to them atm.
 		}
 		return mempool.ErrTxInCache
 	}
+	mem.metrics.CacheMisses.Add(1)
 
-	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
+	reqRes := mem.proxyAppConn.CheckTxAsync(mem.checkTxCtx.Request(tx, abci.CheckTxType_New))
 	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
 
 	return nil
 }
 
+// CheckTxBatch calls CheckTx for each tx, then flushes the connection to send
+// the requests at once rather than on its flush timer, and waits for the
+// responses. If the connection fails, its error is returned for all the txs
+// sent to the app.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) CheckTxBatch(
+	txs types.Txs,
+	cb func(int, *abci.Response),
+	txInfo mempool.TxInfo,
+) []error {
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		var txCb func(*abci.Response)
+		if cb != nil {
+			i := i
+			txCb = func(res *abci.Response) { cb(i, res) }
+		}
+		errs[i] = mem.CheckTx(tx, txCb, txInfo)
+	}
+
+	// the callbacks of the responses are called before the flush returns
+	if err := mem.proxyAppConn.FlushSync(); err != nil {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = err
+			}
+		}
+	}
+	return errs
+}
+
 // Global callback that will be called after every ABCI response.
 // Having a single global callback avoids needing to set a callback for each request.
 // However, processing the checkTx response requires the peerID (so we can track which txs we heard from who),
@@ -267,7 +465,7 @@ func (mem *CListMempool) CheckTx(
 // When rechecking, we don't need the peerID, so the recheck callback happens
 // here.
 func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
-	if mem.recheckCursor == nil {
+	if mem.rechecking == nil {
 		return
 	}
 
@@ -275,7 +473,7 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 	mem.resCbRecheck(req, res)
 
 	// update metrics
-	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.updateSizeMetrics()
 }
 
 // Request specific callback that should be set on individual reqRes objects
@@ -294,15 +492,15 @@ func (mem *CListMempool) reqResCb(
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
-		if mem.recheckCursor != nil {
+		if mem.rechecking != nil {
 			// this should never happen
-			panic("recheck cursor is not nil in reqResCb")
+			panic("rechecking txs in reqResCb")
 		}
 
 		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
 
 		// update metrics
-		mem.metrics.Size.Set(float64(mem.Size()))
+		mem.updateSizeMetrics()
 
 		// passed in by the caller of CheckTx, eg. the RPC
 		if externalCb != nil {
@@ -314,20 +512,42 @@ func (mem *CListMempool) reqResCb(
 // Called from:
 //   - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
-	e := mem.txs.PushBack(memTx)
+	e := memTx.lane.txs.PushBack(memTx)
 	mem.txsMap.Store(memTx.tx.Key(), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	atomic.AddInt64(&memTx.lane.txsBytes, int64(len(memTx.tx)))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
+	if memTx.appSender != "" {
+		mem.sendersMtx.Lock()
+		mem.numTxsBySender[memTx.appSender]++
+		mem.sendersMtx.Unlock()
+	}
+
+	if mem.journal != nil {
+		if err := mem.journal.Append(memTx.tx); err != nil {
+			mem.logger.Error("failed to persist the tx in the mempool journal", "tx", memTx.tx.Hash(), "err", err)
+		}
+	}
 }
 
 // Called from:
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
 func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
-	mem.txs.Remove(elem)
+	memTx := elem.Value.(*mempoolTx)
+	l := memTx.lane
+	l.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(tx.Key())
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	atomic.AddInt64(&l.txsBytes, int64(-len(tx)))
+	if memTx.appSender != "" {
+		mem.sendersMtx.Lock()
+		if mem.numTxsBySender[memTx.appSender]--; mem.numTxsBySender[memTx.appSender] <= 0 {
+			delete(mem.numTxsBySender, memTx.appSender)
+		}
+		mem.sendersMtx.Unlock()
+	}
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
@@ -340,6 +560,7 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
 			mem.removeTx(memTx.tx, e.(*clist.CElement), false)
+			mem.statuses.Set(txKey, mempool.TxStatusEvicted, mem.height)
 			return nil
 		}
 		return errors.New("transaction not found")
@@ -347,24 +568,68 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	return errors.New("invalid transaction found")
 }
 
+// ForgetTx removes a tx from the caches by its TxKey.
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ForgetTx(txKey types.TxKey) {
+	mem.cache.RemoveTxKey(txKey)
+	mem.invalidCache.RemoveTxKey(txKey)
+}
+
+// isFull returns an error if a tx of txSize fits in none of the lanes, as its
+// lane is only known once checked.
 func (mem *CListMempool) isFull(txSize int) error {
+	var err error
+	for _, l := range mem.lanes {
+		if err = mem.isLaneFull(l, txSize); err == nil {
+			return nil
+		}
+	}
+	// the error of the default lane
+	return err
+}
+
+func (mem *CListMempool) isLaneFull(l *lane, txSize int) error {
 	var (
-		memSize  = mem.Size()
-		txsBytes = mem.SizeBytes()
+		laneSize    = l.txs.Len()
+		txsBytes    = atomic.LoadInt64(&l.txsBytes)
+		maxSize     = int(atomic.LoadInt64(&mem.maxSize))
+		maxTxsBytes = atomic.LoadInt64(&mem.maxTxsBytes)
 	)
+	if l.config != nil {
+		maxSize, maxTxsBytes = l.config.Size, l.config.MaxTxsBytes
+	}
 
-	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+	if laneSize >= maxSize || int64(txSize)+txsBytes > maxTxsBytes {
 		return mempool.ErrMempoolIsFull{
-			NumTxs:      memSize,
-			MaxTxs:      mem.config.Size,
+			NumTxs:      laneSize,
+			MaxTxs:      maxSize,
 			TxsBytes:    txsBytes,
-			MaxTxsBytes: mem.config.MaxTxsBytes,
+			MaxTxsBytes: maxTxsBytes,
 		}
 	}
 
 	return nil
 }
 
+// isSenderFull returns whether sender already has max_txs_per_sender txs in
+// the mempool. The txs without a sender aren't limited.
+func (mem *CListMempool) isSenderFull(sender string) bool {
+	if sender == "" || mem.config.MaxTxsPerSender == 0 {
+		return false
+	}
+	mem.sendersMtx.Lock()
+	defer mem.sendersMtx.Unlock()
+	return mem.numTxsBySender[sender] >= mem.config.MaxTxsPerSender
+}
+
+// updateSizeMetrics sets the size metrics of the mempool and its lanes.
+func (mem *CListMempool) updateSizeMetrics() {
+	mem.metrics.Size.Set(float64(mem.Size()))
+	for _, l := range mem.lanes {
+		mem.metrics.LaneSize.With("lane", l.name).Set(float64(l.txs.Len()))
+	}
+}
+
 // callback, which is called after the app checked the tx for the first time.
 //
 // The case where the app checks the tx for the second and subsequent times is
@@ -382,27 +647,43 @@ func (mem *CListMempool) resCbFirstTime(
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Check mempool isn't full again to reduce the chance of exceeding the
-			// limits.
-			if err := mem.isFull(len(tx)); err != nil {
+			// Check the lane of the tx isn't full, now that it's known, which also
+			// reduces the chance of exceeding the limits.
+			l := mem.laneOf(r.CheckTx)
+			if err := mem.isLaneFull(l, len(tx)); err != nil {
 				// remove from cache (mempool might have a space later)
 				mem.cache.Remove(tx)
-				mem.logger.Error(err.Error())
+				mem.logger.Error(err.Error(), "lane", l.name)
+				mempool.Reject(r.CheckTx, mempool.CodeMempoolFull, err.Error())
+				return
+			}
+			if mem.isSenderFull(r.CheckTx.Sender) {
+				// remove from cache (the sender might have a slot later)
+				mem.cache.Remove(tx)
+				mem.logger.Debug("rejected transaction; too many txs for sender",
+					"tx", types.Tx(tx).Hash(), "sender", r.CheckTx.Sender)
+				mem.metrics.RateLimitedTxs.With("reason", mempool.RateLimitReasonSender).Add(1)
 				return
 			}
 
 			memTx := &mempoolTx{
 				height:    mem.height,
+				timestamp: time.Now(),
 				gasWanted: r.CheckTx.GasWanted,
+				priority:  r.CheckTx.Priority,
 				tx:        tx,
+				lane:      l,
+				appSender: r.CheckTx.Sender,
 			}
-			memTx.senders.Store(peerID, true)
+			memTx.senders.Store(peerID, peerP2PID)
 			mem.addTx(memTx)
+			mem.statuses.Set(memTx.tx.Key(), mempool.TxStatusReceived, mem.height)
 			mem.logger.Debug(
 				"added good transaction",
 				"tx", types.Tx(tx).Hash(),
 				"res", r,
 				"height", memTx.height,
+				"lane", l.name,
 				"total", mem.Size(),
 			)
 			mem.notifyTxsAvailable()
@@ -416,11 +697,7 @@ func (mem *CListMempool) resCbFirstTime(
 				"err", postCheckErr,
 			)
 			mem.metrics.FailedTxs.Add(1)
-
-			if !mem.config.KeepInvalidTxsInCache {
-				// remove from cache (it might be good later)
-				mem.cache.Remove(tx)
-			}
+			mem.cacheInvalidTx(tx)
 		}
 
 	default:
@@ -436,7 +713,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
 		tx := req.GetCheckTx().Tx
-		memTx := mem.recheckCursor.Value.(*mempoolTx)
+		memTx := mem.rechecking[mem.recheckCursor].Value.(*mempoolTx)
 
 		// Search through the remaining list of tx to recheck for a transaction that matches
 		// the one we received from the ABCI application.
@@ -454,37 +731,24 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 				"expected", memTx.tx,
 			)
 
-			if mem.recheckCursor == mem.recheckEnd {
+			if mem.recheckCursor == len(mem.rechecking)-1 {
 				// we reached the end of the recheckTx list without finding a tx
 				// matching the one we received from the ABCI application.
 				// Return without processing any tx.
-				mem.recheckCursor = nil
+				mem.rechecking = nil
 				return
 			}
 
-			mem.recheckCursor = mem.recheckCursor.Next()
-			memTx = mem.recheckCursor.Value.(*mempoolTx)
+			mem.recheckCursor++
+			memTx = mem.rechecking[mem.recheckCursor].Value.(*mempoolTx)
 		}
 
-		var postCheckErr error
-		if mem.postCheck != nil {
-			postCheckErr = mem.postCheck(tx, r.CheckTx)
-		}
-
-		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Good, nothing to do.
-		} else {
-			// Tx became invalidated due to newly committed block.
-			mem.logger.Debug("tx is no longer valid", "tx", types.Tx(tx).Hash(), "res", r, "err", postCheckErr)
-			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
-		}
-		if mem.recheckCursor == mem.recheckEnd {
-			mem.recheckCursor = nil
-		} else {
-			mem.recheckCursor = mem.recheckCursor.Next()
+		mem.handleRecheckResult(mem.rechecking[mem.recheckCursor], r.CheckTx)
+		mem.recheckCursor++
+		if mem.recheckCursor == len(mem.rechecking) {
+			mem.rechecking = nil
 		}
-		if mem.recheckCursor == nil {
+		if mem.rechecking == nil {
 			// Done!
 			mem.logger.Debug("done rechecking txs")
 
@@ -498,6 +762,39 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	}
 }
 
+// handleRecheckResult removes the tx of e if it is no longer valid.
+func (mem *CListMempool) handleRecheckResult(e *clist.CElement, res *abci.ResponseCheckTx) {
+	tx := e.Value.(*mempoolTx).tx
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(tx, res)
+	}
+
+	if (res.Code == abci.CodeTypeOK) && postCheckErr == nil {
+		// Good, the app may have changed its priority.
+		atomic.StoreInt64(&e.Value.(*mempoolTx).priority, res.Priority)
+	} else {
+		// Tx became invalidated due to newly committed block.
+		mem.logger.Debug("tx is no longer valid", "tx", tx.Hash(), "res", res, "err", postCheckErr)
+		mem.removeTx(tx, e, false)
+		mem.cacheInvalidTx(tx)
+		mem.statuses.Set(tx.Key(), mempool.TxStatusFailedRecheck, mem.height)
+	}
+}
+
+// cacheInvalidTx removes tx, found invalid, from the cache so that it can be
+// resubmitted, as it might be good later, unless KeepInvalidTxsInCache. The
+// txs kept are moved to the cache of the invalid txs, if any.
+func (mem *CListMempool) cacheInvalidTx(tx types.Tx) {
+	switch {
+	case !mem.config.KeepInvalidTxsInCache:
+		mem.cache.Remove(tx)
+	case mem.config.InvalidCacheSize > 0:
+		mem.cache.Remove(tx)
+		mem.invalidCache.Push(tx)
+	}
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsAvailable() <-chan struct{} {
 	return mem.txsAvailable
@@ -529,31 +826,33 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
-	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.Size(), max/mem.avgTxSize))
+	txs := make([]types.Tx, 0, mem.Size())
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil; e = e.Next() {
+			memTx := e.Value.(*mempoolTx)
 
-		txs = append(txs, memTx.tx)
+			txs = append(txs, memTx.tx)
 
-		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
+			dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
 
-		// Check total size requirement
-		if maxBytes > -1 && runningSize+dataSize > maxBytes {
-			return txs[:len(txs)-1]
-		}
+			// Check total size requirement
+			if maxBytes > -1 && runningSize+dataSize > maxBytes {
+				return txs[:len(txs)-1]
+			}
 
-		runningSize += dataSize
+			runningSize += dataSize
 
-		// Check total gas requirement.
-		// If maxGas is negative, skip this check.
-		// Since newTotalGas < masGas, which
-		// must be non-negative, it follows that this won't overflow.
-		newTotalGas := totalGas + memTx.gasWanted
-		if maxGas > -1 && newTotalGas > maxGas {
-			return txs[:len(txs)-1]
+			// Check total gas requirement.
+			// If maxGas is negative, skip this check.
+			// Since newTotalGas < masGas, which
+			// must be non-negative, it follows that this won't overflow.
+			newTotalGas := totalGas + memTx.gasWanted
+			if maxGas > -1 && newTotalGas > maxGas {
+				return txs[:len(txs)-1]
+			}
+			totalGas = newTotalGas
 		}
-		totalGas = newTotalGas
 	}
 	return txs
 }
@@ -564,13 +863,30 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	defer mem.updateMtx.RUnlock()
 
 	if max < 0 {
-		max = mem.txs.Len()
+		max = mem.Size()
 	}
 
-	txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-		txs = append(txs, memTx.tx)
+	txs := make([]types.Tx, 0, cmtmath.MinInt(mem.Size(), max))
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
+			memTx := e.Value.(*mempoolTx)
+			txs = append(txs, memTx.tx)
+		}
+	}
+	return txs
+}
+
+// Contents returns all the txs with their metadata, lane by lane in the order
+// they are reaped.
+func (mem *CListMempool) Contents() []mempool.TxMetadata {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	txs := make([]mempool.TxMetadata, 0, mem.Size())
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil; e = e.Next() {
+			txs = append(txs, e.Value.(*mempoolTx).metadata())
+		}
 	}
 	return txs
 }
@@ -583,6 +899,8 @@ func (mem *CListMempool) Update(
 	preCheck mempool.PreCheckFunc,
 	postCheck mempool.PostCheckFunc,
 ) error {
+	mem.waitForParallelRecheck()
+
 	// Set height
 	mem.height = height
 	mem.notifiedTxsAvailable = false
@@ -598,9 +916,8 @@ func (mem *CListMempool) Update(
 		if deliverTxResponses[i].Code == abci.CodeTypeOK {
 			// Add valid committed tx to the cache (if missing).
 			_ = mem.cache.Push(tx)
-		} else if !mem.config.KeepInvalidTxsInCache {
-			// Allow invalid transactions to be resubmitted.
-			mem.cache.Remove(tx)
+		} else {
+			mem.cacheInvalidTx(tx)
 		}
 
 		// Remove committed tx from the mempool.
@@ -618,57 +935,210 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	mem.statuses.SetTxs(txs, mempool.TxStatusCommitted, height)
+
+	mem.purgeExpiredTxs(height)
+	mem.compactJournal()
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
+	refreshPriorities := mem.refreshPriorities
+	mem.refreshPriorities = false
 	if mem.Size() > 0 {
-		if mem.config.Recheck {
+		if mem.config.Recheck || refreshPriorities {
 			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
 			mem.recheckTxs()
-			// At this point, mem.txs are being rechecked.
-			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
-			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
+			// At this point, the txs of the lanes are being rechecked.
+			// mem.recheckCursor scans mem.rechecking and possibly removes some txs.
+			// Before mem.Reap(), we should wait for mem.rechecking to be nil.
 		} else {
 			mem.notifyTxsAvailable()
 		}
 	}
 
 	// Update metrics
-	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.updateSizeMetrics()
 
 	return nil
 }
 
+// purgeExpiredTxs removes the txs which have been in the mempool for more than
+// TTLNumBlocks blocks or TTLDuration, and publishes their evictions. They are
+// removed from the cache too, so that they can be resubmitted.
+func (mem *CListMempool) purgeExpiredTxs(blockHeight int64) {
+	if mem.config.TTLNumBlocks == 0 && mem.config.TTLDuration == 0 {
+		return // nothing to do
+	}
+
+	now := time.Now()
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil; {
+			// N.B. Grab the next element first, as removing e detaches it.
+			next := e.Next()
+			memTx := e.Value.(*mempoolTx)
+			var reason string
+			switch {
+			case mem.config.TTLNumBlocks > 0 && blockHeight-memTx.Height() > mem.config.TTLNumBlocks:
+				reason = mempool.EvictionReasonTTLNumBlocks
+			case mem.config.TTLDuration > 0 && now.Sub(memTx.timestamp) > mem.config.TTLDuration:
+				reason = mempool.EvictionReasonTTLDuration
+			}
+			if reason != "" {
+				mem.removeTx(memTx.tx, e, true)
+				mem.statuses.Set(memTx.tx.Key(), mempool.TxStatusEvicted, blockHeight)
+				mem.metrics.EvictedTxs.Add(1)
+				mem.publishEviction(memTx.tx, reason)
+			}
+			e = next
+		}
+	}
+}
+
+// compactJournal compacts the journal to the txs left in the mempool, if
+// needed.
+func (mem *CListMempool) compactJournal() {
+	if mem.journal == nil || !mem.journal.NeedsCompaction(mem.Size(), mem.SizeBytes()) {
+		return
+	}
+	txs := make(types.Txs, 0, mem.Size())
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil; e = e.Next() {
+			txs = append(txs, e.Value.(*mempoolTx).tx)
+		}
+	}
+	if err := mem.journal.Compact(txs); err != nil {
+		mem.logger.Error("failed to compact the mempool journal", "err", err)
+	}
+}
+
+// publishEviction publishes the eviction of a valid tx.
+func (mem *CListMempool) publishEviction(tx types.Tx, reason string) {
+	err := mem.evictions.PublishEventMempoolTxEvicted(types.EventDataMempoolTxEvicted{
+		Tx:     tx,
+		Height: mem.height,
+		Reason: reason,
+	})
+	if err != nil {
+		mem.logger.Error("failed to publish the eviction of a tx", "tx", tx.Hash(), "err", err)
+	}
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
 	}
+	if len(mem.recheckConns) > 0 {
+		mem.recheckTxsInParallel()
+		return
+	}
 
-	mem.recheckCursor = mem.txs.Front()
-	mem.recheckEnd = mem.txs.Back()
+	rechecking := make([]*clist.CElement, 0, mem.Size())
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil; e = e.Next() {
+			rechecking = append(rechecking, e)
+		}
+	}
+	mem.rechecking = rechecking
+	mem.recheckCursor = 0
 
 	// Push txs to proxyAppConn
 	// NOTE: globalCb may be called concurrently.
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
+	for _, e := range rechecking {
 		memTx := e.Value.(*mempoolTx)
-		mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
-			Tx:   memTx.tx,
-			Type: abci.CheckTxType_Recheck,
-		})
+		mem.proxyAppConn.CheckTxAsync(mem.checkTxCtx.Request(memTx.tx, abci.CheckTxType_Recheck))
 	}
 
 	mem.proxyAppConn.FlushAsync()
 }
 
+// recheckTxsInParallel rechecks the txs over the recheck connections in the
+// background, while the mempool connection keeps checking the new txs, and
+// removes those which are no longer valid once all are rechecked, in their
+// order. FlushAppConn waits for it to be done.
+//
+// The caller must hold updateMtx exclusively.
+func (mem *CListMempool) recheckTxsInParallel() {
+	var (
+		elems = make([]*clist.CElement, 0, mem.Size())
+		txs   = make(types.Txs, 0, mem.Size())
+	)
+	for _, l := range mem.lanes {
+		for e := l.txs.Front(); e != nil; e = e.Next() {
+			elems = append(elems, e)
+			txs = append(txs, e.Value.(*mempoolTx).tx)
+		}
+	}
+	done := make(chan struct{})
+	mem.recheckDone = done
+	checkTxCtx := mem.checkTxCtx
+
+	go func() {
+		defer close(done)
+		responses, err := mempool.ParallelRecheck(mem.recheckConns, txs, checkTxCtx)
+		if err != nil {
+			mem.logger.Error("failed to recheck txs", "err", err)
+		}
+		for i, res := range responses {
+			// the txs left unchecked are kept, like those removed meanwhile
+			if res == nil || elems[i].Removed() {
+				continue
+			}
+			mem.metrics.RecheckTimes.Add(1)
+			mem.handleRecheckResult(elems[i], res)
+		}
+		mem.updateSizeMetrics()
+		mem.logger.Debug("done rechecking txs in parallel", "numtxs", len(txs))
+
+		// incase the recheck removed all txs
+		if mem.Size() > 0 {
+			mem.notifyTxsAvailable()
+		}
+	}()
+}
+
+// waitForParallelRecheck waits for the parallel recheck in progress, if any.
+//
+// The caller must hold updateMtx exclusively.
+func (mem *CListMempool) waitForParallelRecheck() {
+	if mem.recheckDone != nil {
+		<-mem.recheckDone
+		mem.recheckDone = nil
+	}
+}
+
 //--------------------------------------------------------------------------------
 
+// lane is a lane of the mempool: the txs which the app assigned to it, with
+// their own limits and gossip rate.
+type lane struct {
+	txsBytes int64 // atomic, total size of the txs, in bytes
+
+	name   string
+	config *config.MempoolLaneConfig // nil for the default lane
+	txs    *clist.CList              // concurrent linked-list of good txs
+}
+
+// gossipInterval returns the minimum interval between two txs of the lane
+// sent to a peer, or 0 if none.
+func (l *lane) gossipInterval() time.Duration {
+	if l.config == nil || l.config.GossipRate == 0 {
+		return 0
+	}
+	return time.Second / time.Duration(l.config.GossipRate)
+}
+
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64     // height that this tx had been validated in
+	timestamp time.Time // time that this tx was added to the mempool
+	gasWanted int64     // amount of gas this tx states it will require
+	priority  int64     // priority the app returned in CheckTx
+	tx        types.Tx  //
+	lane      *lane     // lane the app assigned this tx to
+	appSender string    // sender the app returned in CheckTx, if any
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
-	// senders: PeerID -> bool
+	// senders: PeerID -> p2p.ID
 	senders sync.Map
 }
 
@@ -676,3 +1146,31 @@ type mempoolTx struct {
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// Priority returns the priority of the tx, as last returned by the app.
+func (memTx *mempoolTx) Priority() int64 {
+	return atomic.LoadInt64(&memTx.priority)
+}
+
+// metadata returns the tx with its metadata. The txs submitted over RPC
+// aren't received from a peer.
+func (memTx *mempoolTx) metadata() mempool.TxMetadata {
+	var peers []p2p.ID
+	memTx.senders.Range(func(id, p2pID interface{}) bool {
+		if id.(uint16) != mempool.UnknownPeerID {
+			peers = append(peers, p2pID.(p2p.ID))
+		}
+		return true
+	})
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	return mempool.TxMetadata{
+		Tx:          memTx.tx,
+		Height:      memTx.Height(),
+		ArrivalTime: memTx.timestamp,
+		GasWanted:   memTx.gasWanted,
+		Priority:    memTx.Priority(),
+		Sender:      memTx.appSender,
+		Lane:        memTx.lane.name,
+		Peers:       peers,
+	}
+}
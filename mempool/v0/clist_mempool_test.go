@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/gogo/protobuf/proto"
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/stretchr/testify/assert"
@@ -21,10 +22,12 @@ import (
 	abciserver "github.com/tendermint/tendermint/abci/server"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
@@ -346,6 +349,62 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	}
 }
 
+// labelCounter counts the additions to a counter by label values.
+type labelCounter struct {
+	counts map[string]float64
+	lvs    string
+}
+
+func (c *labelCounter) With(labelValues ...string) metrics.Counter {
+	return &labelCounter{counts: c.counts, lvs: fmt.Sprint(labelValues)}
+}
+
+func (c *labelCounter) Add(delta float64) { c.counts[c.lvs] += delta }
+
+func TestMempoolInvalidCache(t *testing.T) {
+	app := &banApp{banned: map[string]bool{"a": true}}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.KeepInvalidTxsInCache = true
+	cfg.Mempool.InvalidCacheSize = 10
+	cfg.Mempool.InvalidCacheTTL = 200 * time.Millisecond
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+	counts := map[string]float64{}
+	mp.metrics.CacheHits = &labelCounter{counts: counts}
+	mp.metrics.CacheMisses = &labelCounter{counts: counts, lvs: "miss"}
+
+	// the invalid txs are kept apart from the valid ones
+	require.NoError(t, mp.CheckTx(types.Tx("a"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.CheckTx(types.Tx("b"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, mempool.ErrTxInCache, mp.CheckTx(types.Tx("a"), nil, mempool.TxInfo{}))
+	assert.Equal(t, mempool.ErrTxInCache, mp.CheckTx(types.Tx("b"), nil, mempool.TxInfo{}))
+	assert.False(t, mp.cache.Has(types.Tx("a")))
+	assert.True(t, mp.invalidCache.Has(types.Tx("a")))
+	assert.Equal(t, map[string]float64{"[cache invalid]": 1, "[cache valid]": 1, "miss": 2}, counts)
+
+	// until they expire, e.g. once valid
+	app.banned["a"] = false
+	time.Sleep(250 * time.Millisecond)
+	require.NoError(t, mp.CheckTx(types.Tx("a"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, 2, mp.Size())
+
+	// or are forgotten
+	app.banned["c"] = true
+	require.NoError(t, mp.CheckTx(types.Tx("c"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, mempool.ErrTxInCache, mp.CheckTx(types.Tx("c"), nil, mempool.TxInfo{}))
+	mp.ForgetTx(types.Tx("c").Key())
+	assert.NoError(t, mp.CheckTx(types.Tx("c"), nil, mempool.TxInfo{}))
+
+	// the valid txs too, but they stay in the mempool
+	mp.ForgetTx(types.Tx("b").Key())
+	assert.False(t, mp.cache.Has(types.Tx("b")))
+	assert.Equal(t, 2, mp.Size())
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -546,6 +605,441 @@ func TestMempool_CheckTxChecksTxSize(t *testing.T) {
 	}
 }
 
+func TestMempoolSetLimits(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.Size = 1
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	require.NoError(t, mp.CheckTx([]byte{0x01}, nil, mempool.TxInfo{}))
+	err := mp.CheckTx([]byte{0x02}, nil, mempool.TxInfo{})
+	assert.IsType(t, mempool.ErrMempoolIsFull{}, err)
+
+	mp.SetLimits(2, cfg.Mempool.MaxTxsBytes)
+	require.NoError(t, mp.CheckTx([]byte{0x02}, nil, mempool.TxInfo{}))
+
+	// the transactions above the new limit are kept
+	mp.SetLimits(2, 1)
+	assert.Equal(t, 2, mp.Size())
+	err = mp.CheckTx([]byte{0x03}, nil, mempool.TxInfo{})
+	assert.IsType(t, mempool.ErrMempoolIsFull{}, err)
+}
+
+// laneApp assigns the txs starting with 's' to the system lane, and those
+// starting with 'o' to an unknown lane.
+type laneApp struct {
+	abci.BaseApplication
+}
+
+func (laneApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	lanes := map[byte]string{'s': "system", 'o': "other"}
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+	if name, ok := lanes[req.Tx[0]]; ok {
+		res.Events = []abci.Event{{
+			Type:       mempool.EventType,
+			Attributes: []abci.EventAttribute{{Key: []byte(mempool.LaneAttributeKey), Value: []byte(name)}},
+		}}
+	}
+	return res
+}
+
+func TestMempoolLanes(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(laneApp{})
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.Size = 2
+	cfg.Mempool.Lanes = []config.MempoolLaneConfig{{Name: "system", Size: 2, MaxTxsBytes: 1000}}
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// the txs of the users fill the default lane, not the system lane, and
+	// those over it are rejected once their lane is known
+	var codes []uint32
+	for _, tx := range []string{"u1", "o2", "u3"} {
+		require.NoError(t, mp.CheckTx(types.Tx(tx), func(res *abci.Response) {
+			codes = append(codes, res.GetCheckTx().Code)
+		}, mempool.TxInfo{}))
+	}
+	assert.Equal(t, 2, mp.Size())
+	assert.Equal(t, []uint32{abci.CodeTypeOK, abci.CodeTypeOK, mempool.CodeMempoolFull}, codes)
+	for _, tx := range []string{"s1", "s2"} {
+		require.NoError(t, mp.CheckTx(types.Tx(tx), nil, mempool.TxInfo{}))
+	}
+	assert.Equal(t, 4, mp.Size())
+	assert.EqualValues(t, 8, mp.SizeBytes())
+	err := mp.CheckTx(types.Tx("s3"), nil, mempool.TxInfo{})
+	assert.IsType(t, mempool.ErrMempoolIsFull{}, err)
+
+	// the system lane is reaped first
+	expected := types.Txs{types.Tx("s1"), types.Tx("s2"), types.Tx("u1"), types.Tx("o2")}
+	assert.Equal(t, expected, mp.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, expected[:3], mp.ReapMaxBytesMaxGas(3*types.ComputeProtoSizeForTxs(expected[:1]), -1))
+
+	// the txs left are rechecked across the lanes
+	mp.Lock()
+	err = mp.Update(1, expected[1:3], abciResponses(2, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	assert.Nil(t, mp.rechecking)
+	assert.Equal(t, types.Txs{types.Tx("s1"), types.Tx("o2")}, mp.ReapMaxTxs(-1))
+	assert.EqualValues(t, 4, mp.SizeBytes())
+
+	mp.Flush()
+	assert.Zero(t, mp.Size())
+	require.NoError(t, mp.CheckTx(types.Tx("s3"), nil, mempool.TxInfo{}))
+}
+
+// senderApp returns the first byte of the txs as their sender, but for "-".
+type senderApp struct {
+	abci.BaseApplication
+}
+
+func (senderApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+	if req.Tx[0] != '-' {
+		res.Sender = string(req.Tx[:1])
+	}
+	return res
+}
+
+func TestMempoolMaxTxsPerSender(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(senderApp{})
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.MaxTxsPerSender = 2
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	for _, tx := range []string{"a1", "a2", "a3", "b1", "-1", "-2", "-3"} {
+		require.NoError(t, mp.CheckTx(types.Tx(tx), nil, mempool.TxInfo{}))
+	}
+	assert.Equal(t, types.Txs{
+		types.Tx("a1"), types.Tx("a2"), types.Tx("b1"), types.Tx("-1"), types.Tx("-2"), types.Tx("-3"),
+	}, mp.ReapMaxTxs(-1))
+
+	// the sender has a slot again once a tx is committed, and the rejected tx
+	// can be resubmitted
+	mp.Lock()
+	err := mp.Update(1, types.Txs{types.Tx("a1")}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	require.NoError(t, mp.CheckTx(types.Tx("a3"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.CheckTx(types.Tx("a4"), nil, mempool.TxInfo{}))
+	assert.Equal(t, 6, mp.Size())
+
+	mp.Flush()
+	require.NoError(t, mp.CheckTx(types.Tx("a4"), nil, mempool.TxInfo{}))
+	assert.Equal(t, 1, mp.Size())
+}
+
+func TestMempoolContents(t *testing.T) {
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(senderApp{}))
+	defer cleanup()
+
+	require.NoError(t, mp.CheckTx(types.Tx("a1"), nil, mempool.TxInfo{SenderID: 2, SenderP2PID: "peer2"}))
+	require.NoError(t, mp.CheckTx(types.Tx("-1"), nil, mempool.TxInfo{}))
+	err := mp.CheckTx(types.Tx("a1"), nil, mempool.TxInfo{SenderID: 1, SenderP2PID: "peer1"})
+	require.Equal(t, mempool.ErrTxInCache, err)
+
+	contents := mp.Contents()
+	require.Len(t, contents, 2)
+	assert.Equal(t, types.Tx("a1"), contents[0].Tx)
+	assert.Equal(t, "a", contents[0].Sender)
+	assert.EqualValues(t, 0, contents[0].Height)
+	assert.Equal(t, []p2p.ID{"peer1", "peer2"}, contents[0].Peers)
+	assert.Equal(t, types.Tx("-1"), contents[1].Tx)
+	assert.Empty(t, contents[1].Sender)
+	assert.Empty(t, contents[1].Peers, "the txs submitted over RPC have no peers")
+	assert.False(t, contents[1].ArrivalTime.Before(contents[0].ArrivalTime))
+}
+
+// banApp rejects the banned txs.
+type banApp struct {
+	abci.BaseApplication
+	banned map[string]bool
+}
+
+func (app *banApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if app.banned[string(req.Tx)] {
+		return abci.ResponseCheckTx{Code: 1}
+	}
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+}
+
+func TestMempoolFlushRechecks(t *testing.T) {
+	app := &banApp{banned: map[string]bool{}}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+
+	require.NoError(t, mp.FlushRechecks())
+	for _, tx := range []string{"a", "b", "c"} {
+		require.NoError(t, mp.CheckTx(types.Tx(tx), nil, mempool.TxInfo{}))
+	}
+
+	// the txs banned since they were added are removed once rechecked
+	app.banned["b"] = true
+	require.NoError(t, mp.FlushRechecks())
+	assert.Nil(t, mp.rechecking)
+	assert.Equal(t, types.Txs{types.Tx("a"), types.Tx("c")}, mp.ReapMaxTxs(-1))
+
+	mp.rechecking = []*clist.CElement{}
+	assert.Error(t, mp.FlushRechecks())
+}
+
+func TestMempoolRecheckConns(t *testing.T) {
+	app := &banApp{banned: map[string]bool{}}
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	var conns []proxy.AppConnMempool
+	for i := 0; i < 2; i++ {
+		conn, err := cc.NewABCIClient()
+		require.NoError(t, err)
+		conns = append(conns, conn)
+	}
+	WithRecheckConns(conns)(mp)
+
+	for _, tx := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, mp.CheckTx(types.Tx(tx), nil, mempool.TxInfo{}))
+	}
+
+	// the txs banned since they were added are removed once rechecked over
+	// the recheck connections, and the flush waits for the recheck
+	app.banned["b"] = true
+	app.banned["e"] = true
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{types.Tx("a")}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.NoError(t, mp.FlushAppConn())
+	mp.Unlock()
+	assert.Nil(t, mp.recheckDone)
+	assert.Equal(t, types.Txs{types.Tx("c"), types.Tx("d")}, mp.ReapMaxTxs(-1))
+
+	app.banned["c"] = true
+	require.NoError(t, mp.FlushRechecks())
+	assert.Equal(t, types.Txs{types.Tx("d")}, mp.ReapMaxTxs(-1))
+}
+
+func TestMempoolTxStatuses(t *testing.T) {
+	app := &banApp{banned: map[string]bool{}}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+	statuses := mempool.NewTxStatuses(10)
+	WithTxStatuses(statuses)(mp)
+	status := func(tx string) string {
+		s, ok := statuses.Get(types.Tx(tx).Key())
+		if !ok {
+			return mempool.TxStatusUnknown
+		}
+		return s.Status
+	}
+
+	app.banned["d"] = true
+	for _, tx := range []string{"a", "b", "c", "d"} {
+		_ = mp.CheckTx(types.Tx(tx), nil, mempool.TxInfo{})
+	}
+	assert.Equal(t, mempool.TxStatusReceived, status("a"))
+	assert.Equal(t, mempool.TxStatusUnknown, status("d"))
+
+	app.banned["b"] = true
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{types.Tx("a")}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.NoError(t, mp.FlushAppConn())
+	mp.Unlock()
+	require.NoError(t, mp.RemoveTxByKey(types.Tx("c").Key()))
+
+	assert.Equal(t, mempool.TxStatusCommitted, status("a"))
+	assert.Equal(t, mempool.TxStatusFailedRecheck, status("b"))
+	assert.Equal(t, mempool.TxStatusEvicted, status("c"))
+	s, _ := statuses.Get(types.Tx("a").Key())
+	assert.EqualValues(t, 1, s.Height)
+}
+
+// contextApp records the contexts of the checks of the txs.
+type contextApp struct {
+	abci.BaseApplication
+	reqs []abci.RequestCheckTx
+}
+
+func (app *contextApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.reqs = append(app.reqs, req)
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+}
+
+func TestMempoolCheckTxContext(t *testing.T) {
+	app := &contextApp{}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+	now := time.Now().UTC()
+	WithCheckTxContext(mempool.CheckTxContext{Height: 1, Time: now, ProposerAddress: []byte("alice")})(mp)
+
+	require.NoError(t, mp.CheckTx(types.Tx("a"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.CheckTx(types.Tx("b"), nil, mempool.TxInfo{}))
+
+	// the txs left are rechecked in the context of the next block
+	mp.Lock()
+	mp.SetCheckTxContext(mempool.CheckTxContext{Height: 2, Time: now.Add(time.Second), ProposerAddress: []byte("bob")})
+	require.NoError(t, mp.Update(1, types.Txs{types.Tx("a")}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.NoError(t, mp.FlushAppConn())
+	mp.Unlock()
+
+	require.Len(t, app.reqs, 3)
+	for _, req := range app.reqs[:2] {
+		assert.Equal(t, abci.CheckTxType_New, req.Type)
+		assert.EqualValues(t, 1, req.Height)
+		assert.Equal(t, now, req.Time)
+		assert.Equal(t, []byte("alice"), req.ProposerAddress)
+	}
+	recheck := app.reqs[2]
+	assert.Equal(t, abci.CheckTxType_Recheck, recheck.Type)
+	assert.Equal(t, types.Tx("b"), types.Tx(recheck.Tx))
+	assert.EqualValues(t, 2, recheck.Height)
+	assert.Equal(t, now.Add(time.Second), recheck.Time)
+	assert.Equal(t, []byte("bob"), recheck.ProposerAddress)
+}
+
+// priorityApp prioritizes the txs by their fee, 1 by default.
+type priorityApp struct {
+	abci.BaseApplication
+	fees map[string]int64
+}
+
+func (app *priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	priority, ok := app.fees[string(req.Tx)]
+	if !ok {
+		priority = 1
+	}
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Priority: priority}
+}
+
+func TestMempoolRefreshPriorities(t *testing.T) {
+	app := &priorityApp{fees: map[string]int64{}}
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.Recheck = false
+	mp, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(app), cfg)
+	defer cleanup()
+	priorities := func() []int64 {
+		var ps []int64
+		for _, c := range mp.Contents() {
+			ps = append(ps, c.Priority)
+		}
+		return ps
+	}
+
+	for _, tx := range []string{"a", "b"} {
+		require.NoError(t, mp.CheckTx(types.Tx(tx), nil, mempool.TxInfo{}))
+	}
+	app.fees["b"] = 5
+
+	// the txs aren't rechecked with recheck disabled
+	mp.Lock()
+	require.NoError(t, mp.Update(1, nil, nil, nil, nil))
+	mp.Unlock()
+	assert.Equal(t, []int64{1, 1}, priorities())
+
+	// but are once the app requests the refresh of their priorities
+	mp.Lock()
+	mp.RefreshPriorities()
+	require.NoError(t, mp.Update(2, nil, nil, nil, nil))
+	require.NoError(t, mp.FlushAppConn())
+	mp.Unlock()
+	assert.Equal(t, []int64{1, 5}, priorities())
+
+	// for a single block
+	app.fees["a"] = 3
+	mp.Lock()
+	require.NoError(t, mp.Update(3, nil, nil, nil, nil))
+	require.NoError(t, mp.FlushAppConn())
+	mp.Unlock()
+	assert.Equal(t, []int64{1, 5}, priorities())
+}
+
+func TestMempoolCheckTxBatch(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", cmtrand.Str(6))
+	app := &banApp{banned: map[string]bool{"b": true}}
+	_, server := newRemoteApp(t, sockPath, app)
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.MaxTxBytes = 10
+	mp, cleanup := newMempoolWithAppAndConfig(proxy.NewRemoteClientCreator(sockPath, "socket", true), cfg)
+	defer cleanup()
+
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("a"), types.Tx("too large tx"), types.Tx("c")}
+	codes := map[int]uint32{}
+	errs := mp.CheckTxBatch(txs, func(i int, res *abci.Response) {
+		codes[i] = res.GetCheckTx().Code
+	}, mempool.TxInfo{})
+
+	// the responses are all received on return
+	require.Len(t, errs, 5)
+	assert.Equal(t, map[int]uint32{0: abci.CodeTypeOK, 1: 1, 4: abci.CodeTypeOK}, codes)
+	for _, i := range []int{0, 1, 4} {
+		assert.NoError(t, errs[i], i)
+	}
+	assert.Equal(t, mempool.ErrTxInCache, errs[2])
+	assert.IsType(t, mempool.ErrTxTooLarge{}, errs[3])
+	assert.Equal(t, types.Txs{types.Tx("a"), types.Tx("c")}, mp.ReapMaxTxs(-1))
+}
+
+// evictionRecorder records the evictions published by a mempool.
+type evictionRecorder struct {
+	evictions []types.EventDataMempoolTxEvicted
+}
+
+func (r *evictionRecorder) PublishEventMempoolTxEvicted(data types.EventDataMempoolTxEvicted) error {
+	r.evictions = append(r.evictions, data)
+	return nil
+}
+
+func TestMempoolTTL(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.TTLNumBlocks = 2
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+	recorder := &evictionRecorder{}
+	WithEvictionPublisher(recorder)(mp)
+
+	update := func(height int64) {
+		mp.Lock()
+		err := mp.Update(height, nil, nil, nil, nil)
+		mp.Unlock()
+		require.NoError(t, err)
+		require.NoError(t, mp.FlushAppConn())
+	}
+
+	// the txs expire after ttl-num-blocks blocks
+	require.NoError(t, mp.CheckTx(types.Tx("a=1"), nil, mempool.TxInfo{}))
+	update(1)
+	require.NoError(t, mp.CheckTx(types.Tx("b=1"), nil, mempool.TxInfo{}))
+	update(2)
+	assert.Equal(t, 2, mp.Size())
+	assert.Empty(t, recorder.evictions)
+	update(3)
+	assert.Equal(t, types.Txs{types.Tx("b=1")}, mp.ReapMaxTxs(-1))
+	assert.Equal(t, []types.EventDataMempoolTxEvicted{
+		{Tx: types.Tx("a=1"), Height: 3, Reason: mempool.EvictionReasonTTLNumBlocks},
+	}, recorder.evictions)
+
+	// or after ttl-duration, and can be resubmitted
+	cfg.Mempool.TTLNumBlocks = 0
+	cfg.Mempool.TTLDuration = time.Millisecond
+	time.Sleep(2 * time.Millisecond)
+	update(4)
+	assert.Zero(t, mp.Size())
+	require.Len(t, recorder.evictions, 2)
+	assert.Equal(t, mempool.EvictionReasonTTLDuration, recorder.evictions[1].Reason)
+	require.NoError(t, mp.CheckTx(types.Tx("a=1"), nil, mempool.TxInfo{}))
+	assert.Equal(t, 1, mp.Size())
+}
+
 func TestMempoolTxsBytes(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -25,6 +25,8 @@ type Reactor struct {
 	config  *cfg.MempoolConfig
 	mempool *CListMempool
 	ids     *mempoolIDs
+	limiter *mempool.PeerRateLimiter // of the txs received from the peers
+	peers   *mempool.BroadcastPeers  // the txs are broadcast to
 }
 
 type mempoolIDs struct {
@@ -91,11 +93,13 @@ func newMempoolIDs() *mempoolIDs {
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mp *CListMempool) *Reactor {
 	memR := &Reactor{
 		config:  config,
-		mempool: mempool,
+		mempool: mp,
 		ids:     newMempoolIDs(),
+		limiter: mempool.NewPeerRateLimiter(config.PeerMaxTxsPerSecond),
+		peers:   mempool.NewBroadcastPeers(config),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	return memR
@@ -117,6 +121,8 @@ func (memR *Reactor) SetLogger(l log.Logger) {
 func (memR *Reactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
+	} else if memR.config.BroadcastMode == cfg.MempoolBroadcastSequencer {
+		memR.Logger.Info("Txs are only broadcast to the sequencer peers", "peers", memR.config.SequencerPeerIDs)
 	}
 	return nil
 }
@@ -142,16 +148,20 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 }
 
 // AddPeer implements Reactor.
-// It starts a broadcast routine ensuring all txs are forwarded to the given peer.
+// It starts a broadcast routine per lane ensuring all txs are forwarded to the
+// given peer, unless the txs aren't broadcast to it.
 func (memR *Reactor) AddPeer(peer p2p.Peer) {
-	if memR.config.Broadcast {
-		go memR.broadcastTxRoutine(peer)
+	if memR.config.Broadcast && memR.peers.Includes(peer.ID()) {
+		for _, l := range memR.mempool.lanes {
+			go memR.broadcastTxRoutine(peer, l)
+		}
 	}
 }
 
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
+	memR.limiter.RemovePeer(peer.ID())
 	// broadcast routine checks if peer is gone and returns
 }
 
@@ -174,6 +184,12 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		var err error
 		for _, tx := range protoTxs {
 			ntx := types.Tx(tx)
+			// the txs over the rate of the peer are dropped unchecked
+			if e.Src != nil && !memR.limiter.Allow(e.Src.ID()) {
+				memR.Logger.Debug("Dropping tx over the rate of the peer", "tx", ntx.String(), "src", e.Src)
+				memR.mempool.metrics.RateLimitedTxs.With("reason", mempool.RateLimitReasonPeer).Add(1)
+				continue
+			}
 			err = memR.mempool.CheckTx(ntx, nil, txInfo)
 			if errors.Is(err, mempool.ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", ntx.String())
@@ -212,9 +228,11 @@ type PeerState interface {
 	GetHeight() int64
 }
 
-// Send new mempool txs to peer.
-func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
+// Send new mempool txs of a lane to peer, at most at the gossip rate of the
+// lane.
+func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer, l *lane) {
 	peerID := memR.ids.GetForPeer(peer)
+	interval := l.gossipInterval()
 	var next *clist.CElement
 
 	for {
@@ -227,8 +245,8 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// start from the beginning.
 		if next == nil {
 			select {
-			case <-memR.mempool.TxsWaitChan(): // Wait until a tx is available
-				if next = memR.mempool.TxsFront(); next == nil {
+			case <-l.txs.WaitChan(): // Wait until a tx is available
+				if next = l.txs.Front(); next == nil {
 					continue
 				}
 			case <-peer.Quit():
@@ -269,6 +287,16 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 				time.Sleep(mempool.PeerCatchupSleepIntervalMS * time.Millisecond)
 				continue
 			}
+			memR.mempool.statuses.Set(memTx.tx.Key(), mempool.TxStatusGossiped, memTx.Height())
+			if interval > 0 {
+				select {
+				case <-time.After(interval):
+				case <-peer.Quit():
+					return
+				case <-memR.Quit():
+					return
+				}
+			}
 		}
 
 		select {
@@ -3,6 +3,7 @@ package v0
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"net"
 	"sync"
 	"testing"
@@ -294,6 +295,76 @@ func TestDontExhaustMaxActiveIDs(t *testing.T) {
 	}
 }
 
+func TestReactorPeerMaxTxsPerSecond(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.PeerMaxTxsPerSecond = 3
+	reactors := makeAndConnectReactors(config, 1)
+	reactor := reactors[0]
+	defer func() {
+		assert.NoError(t, reactor.Stop())
+	}()
+
+	// the txs over the rate of a peer are dropped, not those of the others
+	for i, peer := range []p2p.Peer{mock.NewPeer(nil), mock.NewPeer(nil)} {
+		reactor.InitPeer(peer)
+		txs := &memproto.Txs{}
+		for j := 0; j < 5; j++ {
+			txs.Txs = append(txs.Txs, []byte(fmt.Sprintf("%d-%d", i, j)))
+		}
+		reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: mempool.MempoolChannel, Src: peer, Message: txs})
+	}
+	require.NoError(t, reactor.mempool.FlushAppConn())
+	assert.Equal(t, 6, reactor.mempool.Size())
+}
+
+// recordingPeer is a peer recording the txs sent to it.
+type recordingPeer struct {
+	*mock.Peer
+	mtx sync.Mutex
+	txs types.Txs
+}
+
+func (p *recordingPeer) SendEnvelope(e p2p.Envelope) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, tx := range e.Message.(*memproto.Txs).Txs {
+		p.txs = append(p.txs, tx)
+	}
+	return true
+}
+
+func (p *recordingPeer) sentTxs() types.Txs {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.txs
+}
+
+func TestReactorSequencerBroadcastMode(t *testing.T) {
+	sequencer := &recordingPeer{Peer: mock.NewPeer(nil)}
+	other := &recordingPeer{Peer: mock.NewPeer(nil)}
+	config := cfg.TestConfig()
+	config.Mempool.BroadcastMode = cfg.MempoolBroadcastSequencer
+	config.Mempool.SequencerPeerIDs = string(sequencer.ID())
+	reactors := makeAndConnectReactors(config, 1)
+	reactor := reactors[0]
+	defer func() {
+		assert.NoError(t, reactor.Stop())
+	}()
+
+	for _, peer := range []*recordingPeer{sequencer, other} {
+		defer peer.Stop() //nolint:errcheck // ignore error
+		peer.Set(types.PeerStateKey, peerState{1})
+		reactor.InitPeer(peer)
+		reactor.AddPeer(peer)
+	}
+
+	// the txs are only forwarded to the sequencer peer
+	txs := checkTxs(t, reactor.mempool, 10, mempool.UnknownPeerID)
+	require.Eventually(t, func() bool { return len(sequencer.sentTxs()) == len(txs) }, timeout, 10*time.Millisecond)
+	assert.Equal(t, txs, sequencer.sentTxs())
+	assert.Empty(t, other.sentTxs())
+}
+
 func TestLegacyReactorReceiveBasic(t *testing.T) {
 	config := cfg.TestConfig()
 	const N = 1
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"container/heap"
 	"fmt"
 	"runtime"
 	"sort"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/creachadair/taskgroup"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/clist"
@@ -33,6 +35,11 @@ type TxMempoolOption func(*TxMempool)
 // Within the mempool, transactions are ordered by time of arrival, and are
 // gossiped to the rest of the network based on that order (gossip order does
 // not take priority into account).
+//
+// If the application assigns nonces to the transactions of a sender, they are
+// selected in the order of their nonces, without gaps, and a transaction
+// replaces the one of its sender with the same nonce if its priority is
+// higher, e.g. it pays a higher fee.
 type TxMempool struct {
 	// Immutable fields
 	logger       log.Logger
@@ -40,9 +47,16 @@ type TxMempool struct {
 	proxyAppConn proxy.AppConnMempool
 	metrics      *mempool.Metrics
 	cache        mempool.TxCache // seen transactions
+	invalidCache mempool.TxCache // invalid transactions kept apart, with invalid_cache_size
+	evictions    mempool.EvictionPublisher
+	journal      *mempool.Journal // nil if the transactions aren't persisted
+	recheckConns []proxy.AppConnMempool
+	statuses     *mempool.TxStatuses
 
 	// Atomically-updated fields
-	txsBytes int64 // atomic: the total size of all transactions in the mempool, in bytes
+	txsBytes    int64 // atomic: the total size of all transactions in the mempool, in bytes
+	maxSize     int64 // atomic: the maximum number of transactions, set by SetLimits
+	maxTxsBytes int64 // atomic: the maximum total size of the transactions, set by SetLimits
 
 	// Synchronized fields, protected by mtx.
 	mtx                  *sync.RWMutex
@@ -51,10 +65,15 @@ type TxMempool struct {
 	preCheck             mempool.PreCheckFunc
 	postCheck            mempool.PostCheckFunc
 	height               int64 // the latest height passed to Update
-
-	txs        *clist.CList // valid transactions (passed CheckTx)
-	txByKey    map[types.TxKey]*clist.CElement
-	txBySender map[string]*clist.CElement // for sender != ""
+	checkTxCtx           mempool.CheckTxContext
+	refreshPriorities    bool // whether the next update rechecks the transactions regardless of Recheck
+
+	txs             *clist.CList // valid transactions (passed CheckTx)
+	txByKey         map[types.TxKey]*clist.CElement
+	txBySender      map[string]*clist.CElement      // for sender != "", without a nonce
+	txBySenderNonce map[senderNonce]*clist.CElement // for sender != "", with a nonce
+	nextNonces      map[string]uint64               // after the committed nonces of the senders with txs
+	numNonceTxs     map[string]int                  // number of txs with a nonce of each sender
 }
 
 // NewTxMempool constructs a new, empty priority mempool at the specified
@@ -71,17 +90,28 @@ func NewTxMempool(
 		logger:       logger,
 		config:       cfg,
 		proxyAppConn: proxyAppConn,
+		maxSize:      int64(cfg.Size),
+		maxTxsBytes:  cfg.MaxTxsBytes,
 		metrics:      mempool.NopMetrics(),
 		cache:        mempool.NopTxCache{},
+		invalidCache: mempool.NopTxCache{},
+		evictions:    types.NopEventBus{},
 		txs:          clist.New(),
 		mtx:          new(sync.RWMutex),
 		height:       height,
 		txByKey:      make(map[types.TxKey]*clist.CElement),
 		txBySender:   make(map[string]*clist.CElement),
+
+		txBySenderNonce: make(map[senderNonce]*clist.CElement),
+		nextNonces:      make(map[string]uint64),
+		numNonceTxs:     make(map[string]int),
 	}
 	if cfg.CacheSize > 0 {
 		txmp.cache = mempool.NewLRUTxCache(cfg.CacheSize)
 	}
+	if cfg.KeepInvalidTxsInCache && cfg.InvalidCacheSize > 0 {
+		txmp.invalidCache = mempool.NewTTLTxCache(cfg.InvalidCacheSize, cfg.InvalidCacheTTL)
+	}
 
 	for _, opt := range options {
 		opt(txmp)
@@ -104,11 +134,42 @@ func WithPostCheck(f mempool.PostCheckFunc) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.postCheck = f }
 }
 
+// WithCheckTxContext sets the context of the checks of the transactions until
+// the next block. After that, SetCheckTxContext overwrites it.
+func WithCheckTxContext(c mempool.CheckTxContext) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.checkTxCtx = c }
+}
+
 // WithMetrics sets the mempool's metrics collector.
 func WithMetrics(metrics *mempool.Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// WithJournal sets the journal persisting the transactions across restarts.
+// It must be loaded.
+func WithJournal(j *mempool.Journal) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.journal = j }
+}
+
+// WithRecheckConns sets dedicated connections to recheck the transactions over
+// in parallel after each block, rather than on the mempool connection. The
+// app's CheckTx must be stateless per connection.
+func WithRecheckConns(conns []proxy.AppConnMempool) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.recheckConns = conns }
+}
+
+// WithTxStatuses sets the statuses of the transactions, updated along their
+// lifecycle in the mempool.
+func WithTxStatuses(s *mempool.TxStatuses) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.statuses = s }
+}
+
+// WithEvictionPublisher sets the publisher of the evictions of valid
+// transactions, e.g. when they expire.
+func WithEvictionPublisher(p mempool.EvictionPublisher) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.evictions = p }
+}
+
 // Lock obtains a write-lock on the mempool. A caller must be sure to explicitly
 // release the lock when finished.
 func (txmp *TxMempool) Lock() { txmp.mtx.Lock() }
@@ -116,6 +177,28 @@ func (txmp *TxMempool) Lock() { txmp.mtx.Lock() }
 // Unlock releases a write-lock on the mempool.
 func (txmp *TxMempool) Unlock() { txmp.mtx.Unlock() }
 
+// SetCheckTxContext sets the context of the checks of the transactions, e.g.
+// that of the next block before they are rechecked.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) SetCheckTxContext(c mempool.CheckTxContext) { txmp.checkTxCtx = c }
+
+// RefreshPriorities makes the next Update recheck the transactions, even if
+// Recheck is disabled, for the application to return their priorities anew,
+// e.g. once it changed its fee params.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) RefreshPriorities() { txmp.refreshPriorities = true }
+
+// SetLimits sets the maximum number and total size of the transactions in the
+// mempool, e.g. when the configuration is reloaded. The transactions above the
+// new limits are kept, but new ones are rejected until the mempool shrinks. It
+// is thread-safe.
+func (txmp *TxMempool) SetLimits(size int, maxTxsBytes int64) {
+	atomic.StoreInt64(&txmp.maxSize, int64(size))
+	atomic.StoreInt64(&txmp.maxTxsBytes, maxTxsBytes)
+}
+
 // Size returns the number of valid transactions in the mempool. It is
 // thread-safe.
 func (txmp *TxMempool) Size() int { return txmp.txs.Len() }
@@ -175,66 +258,135 @@ func (txmp *TxMempool) TxsAvailable() <-chan struct{} { return txmp.txsAvailable
 // the size of tx, and adds tx instead. If no such transactions exist, tx is
 // discarded.
 func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo mempool.TxInfo) error {
+	height, checkTxCtx, err := txmp.admitTx(tx, txInfo)
+	if err != nil {
+		return err
+	}
 
-	// During the initial phase of CheckTx, we do not need to modify any state.
-	// A transaction will not actually be added to the mempool until it survives
-	// a call to the ABCI CheckTx method and size constraint checks.
-	height, err := func() (int64, error) {
-		txmp.mtx.RLock()
-		defer txmp.mtx.RUnlock()
+	// Invoke an ABCI CheckTx for this transaction.
+	rsp, err := txmp.proxyAppConn.CheckTxSync(checkTxCtx.Request(tx, abci.CheckTxType_New))
+	if err != nil {
+		txmp.cache.Remove(tx)
+		return err
+	}
+	txmp.handleCheckTxResult(tx, height, txInfo, rsp)
+	if cb != nil {
+		cb(&abci.Response{Value: &abci.Response_CheckTx{CheckTx: rsp}})
+	}
+	return nil
+}
 
-		// Reject transactions in excess of the configured maximum transaction size.
-		if len(tx) > txmp.config.MaxTxBytes {
-			return 0, mempool.ErrTxTooLarge{Max: txmp.config.MaxTxBytes, Actual: len(tx)}
+// CheckTxBatch adds the given transactions to the mempool like CheckTx, but
+// with their ABCI CheckTx requests pipelined and flushed at once, in a single
+// round trip to the application rather than one per transaction. It returns
+// once the application responded to all of them.
+//
+// The error of each transaction rejected before the application checked it is
+// at its index in the returned slice. If cb != nil, it is called with the index
+// and the application response of each other transaction.
+func (txmp *TxMempool) CheckTxBatch(txs types.Txs, cb func(int, *abci.Response), txInfo mempool.TxInfo) []error {
+	errs := make([]error, len(txs))
+	heights := make([]int64, len(txs))
+	reqRess := make([]*abcicli.ReqRes, len(txs))
+	for i, tx := range txs {
+		var checkTxCtx mempool.CheckTxContext
+		heights[i], checkTxCtx, errs[i] = txmp.admitTx(tx, txInfo)
+		if errs[i] == nil {
+			reqRess[i] = txmp.proxyAppConn.CheckTxAsync(checkTxCtx.Request(tx, abci.CheckTxType_New))
 		}
+	}
 
-		// If a precheck hook is defined, call it before invoking the application.
-		if txmp.preCheck != nil {
-			if err := txmp.preCheck(tx); err != nil {
-				return 0, mempool.ErrPreCheck{Reason: err}
-			}
+	// The responses are received in order, before the one of the flush, so they
+	// are all set once it returns. The local client doesn't mark its requests as
+	// done, so they can't be waited for.
+	flushErr := txmp.proxyAppConn.FlushSync()
+	for i, reqRes := range reqRess {
+		if reqRes == nil {
+			continue
 		}
-
-		// Early exit if the proxy connection has an error.
-		if err := txmp.proxyAppConn.Error(); err != nil {
-			return 0, err
+		if flushErr != nil {
+			txmp.cache.Remove(txs[i])
+			errs[i] = flushErr
+			continue
 		}
+		rsp := reqRes.Response.GetCheckTx()
+		if rsp == nil {
+			txmp.cache.Remove(txs[i])
+			errs[i] = fmt.Errorf("unexpected response to CheckTx: %v", reqRes.Response)
+			continue
+		}
+		txmp.handleCheckTxResult(txs[i], heights[i], txInfo, rsp)
+		if cb != nil {
+			cb(i, reqRes.Response)
+		}
+	}
+	return errs
+}
+
+// admitTx runs the checks of a transaction before the application checks it,
+// and pushes it to the cache. It returns the height it is checked at, and the
+// context of the check.
+//
+// During the initial phase of CheckTx, we do not need to modify any state.
+// A transaction will not actually be added to the mempool until it survives
+// a call to the ABCI CheckTx method and size constraint checks.
+func (txmp *TxMempool) admitTx(tx types.Tx, txInfo mempool.TxInfo) (int64, mempool.CheckTxContext, error) {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
 
-		txKey := tx.Key()
+	// Reject transactions in excess of the configured maximum transaction size.
+	if len(tx) > txmp.config.MaxTxBytes {
+		return 0, mempool.CheckTxContext{}, mempool.ErrTxTooLarge{Max: txmp.config.MaxTxBytes, Actual: len(tx)}
+	}
 
-		// Check for the transaction in the cache.
-		if !txmp.cache.Push(tx) {
-			// If the cached transaction is also in the pool, record its sender.
-			if elt, ok := txmp.txByKey[txKey]; ok {
-				w := elt.Value.(*WrappedTx)
-				w.SetPeer(txInfo.SenderID)
-			}
-			return 0, mempool.ErrTxInCache
+	// If a precheck hook is defined, call it before invoking the application.
+	if txmp.preCheck != nil {
+		if err := txmp.preCheck(tx); err != nil {
+			return 0, mempool.CheckTxContext{}, mempool.ErrPreCheck{Reason: err}
 		}
-		return txmp.height, nil
-	}()
-	if err != nil {
-		return err
 	}
 
-	// Invoke an ABCI CheckTx for this transaction.
-	rsp, err := txmp.proxyAppConn.CheckTxSync(abci.RequestCheckTx{Tx: tx})
-	if err != nil {
-		txmp.cache.Remove(tx)
-		return err
+	// Early exit if the proxy connection has an error.
+	if err := txmp.proxyAppConn.Error(); err != nil {
+		return 0, mempool.CheckTxContext{}, err
 	}
+
+	txKey := tx.Key()
+
+	// Check for the transaction in the caches.
+	if txmp.invalidCache.Has(tx) {
+		txmp.metrics.CacheHits.With("cache", mempool.CacheInvalid).Add(1)
+		return 0, mempool.CheckTxContext{}, mempool.ErrTxInCache
+	}
+	if !txmp.cache.Push(tx) {
+		txmp.metrics.CacheHits.With("cache", mempool.CacheValid).Add(1)
+		// If the cached transaction is also in the pool, record its sender.
+		if elt, ok := txmp.txByKey[txKey]; ok {
+			w := elt.Value.(*WrappedTx)
+			w.SetPeer(txInfo.SenderID, txInfo.SenderP2PID)
+		}
+		return 0, mempool.CheckTxContext{}, mempool.ErrTxInCache
+	}
+	txmp.metrics.CacheMisses.Add(1)
+	return txmp.height, txmp.checkTxCtx, nil
+}
+
+// handleCheckTxResult adds a transaction checked by the application at height
+// to the mempool, if valid.
+func (txmp *TxMempool) handleCheckTxResult(
+	tx types.Tx,
+	height int64,
+	txInfo mempool.TxInfo,
+	rsp *abci.ResponseCheckTx,
+) {
 	wtx := &WrappedTx{
 		tx:        tx,
 		hash:      tx.Key(),
 		timestamp: time.Now().UTC(),
 		height:    height,
 	}
-	wtx.SetPeer(txInfo.SenderID)
+	wtx.SetPeer(txInfo.SenderID, txInfo.SenderP2PID)
 	txmp.addNewTransaction(wtx, rsp)
-	if cb != nil {
-		cb(&abci.Response{Value: &abci.Response_CheckTx{CheckTx: rsp}})
-	}
-	return nil
 }
 
 // RemoveTxByKey removes the transaction with the specified key from the
@@ -243,7 +395,18 @@ func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo memp
 func (txmp *TxMempool) RemoveTxByKey(txKey types.TxKey) error {
 	txmp.mtx.Lock()
 	defer txmp.mtx.Unlock()
-	return txmp.removeTxByKey(txKey)
+	if err := txmp.removeTxByKey(txKey); err != nil {
+		return err
+	}
+	txmp.statuses.Set(txKey, mempool.TxStatusEvicted, txmp.height)
+	return nil
+}
+
+// ForgetTx removes the specified transaction key from the caches, so that the
+// transaction can be resubmitted. It is thread-safe.
+func (txmp *TxMempool) ForgetTx(txKey types.TxKey) {
+	txmp.cache.RemoveTxKey(txKey)
+	txmp.invalidCache.RemoveTxKey(txKey)
 }
 
 // removeTxByKey removes the specified transaction key from the mempool.
@@ -252,7 +415,7 @@ func (txmp *TxMempool) removeTxByKey(key types.TxKey) error {
 	if elt, ok := txmp.txByKey[key]; ok {
 		w := elt.Value.(*WrappedTx)
 		delete(txmp.txByKey, key)
-		delete(txmp.txBySender, w.sender)
+		txmp.removeTxBySender(w)
 		txmp.txs.Remove(elt)
 		elt.DetachPrev()
 		elt.DetachNext()
@@ -267,13 +430,52 @@ func (txmp *TxMempool) removeTxByKey(key types.TxKey) error {
 func (txmp *TxMempool) removeTxByElement(elt *clist.CElement) {
 	w := elt.Value.(*WrappedTx)
 	delete(txmp.txByKey, w.tx.Key())
-	delete(txmp.txBySender, w.sender)
+	txmp.removeTxBySender(w)
 	txmp.txs.Remove(elt)
 	elt.DetachPrev()
 	elt.DetachNext()
 	atomic.AddInt64(&txmp.txsBytes, -w.Size())
 }
 
+// removeTxBySender removes the specified transaction from the indexes by
+// sender. The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) removeTxBySender(w *WrappedTx) {
+	if nonce, ok := w.Nonce(); ok {
+		delete(txmp.txBySenderNonce, senderNonce{sender: w.sender, nonce: nonce})
+		if txmp.numNonceTxs[w.sender]--; txmp.numNonceTxs[w.sender] == 0 {
+			delete(txmp.numNonceTxs, w.sender)
+		}
+	} else {
+		delete(txmp.txBySender, w.sender)
+	}
+}
+
+// FlushRechecks rechecks all the transactions in the mempool one by one,
+// and returns once the invalid ones are removed. The transactions added
+// meanwhile are not rechecked.
+func (txmp *TxMempool) FlushRechecks() error {
+	txmp.mtx.RLock()
+	wtxs := make([]*WrappedTx, 0, txmp.txs.Len())
+	for e := txmp.txs.Front(); e != nil; e = e.Next() {
+		wtxs = append(wtxs, e.Value.(*WrappedTx))
+	}
+	txmp.logger.Info("executing re-CheckTx for all transactions", "num_txs", len(wtxs), "height", txmp.height)
+	checkTxCtx := txmp.checkTxCtx
+	txmp.mtx.RUnlock()
+
+	if len(txmp.recheckConns) > 0 {
+		return txmp.recheckInParallel(wtxs, checkTxCtx)
+	}
+	for _, wtx := range wtxs {
+		rsp, err := txmp.proxyAppConn.CheckTxSync(checkTxCtx.Request(wtx.tx, abci.CheckTxType_Recheck))
+		if err != nil {
+			return fmt.Errorf("failed to recheck tx %X: %w", wtx.tx.Hash(), err)
+		}
+		txmp.handleRecheckResult(wtx.tx, rsp)
+	}
+	return nil
+}
+
 // Flush purges the contents of the mempool and the cache, leaving both empty.
 // The current height is not modified by this operation.
 func (txmp *TxMempool) Flush() {
@@ -289,25 +491,71 @@ func (txmp *TxMempool) Flush() {
 		cur = next
 	}
 	txmp.cache.Reset()
+	txmp.invalidCache.Reset()
+
+	if txmp.journal != nil {
+		if err := txmp.journal.Compact(nil); err != nil {
+			txmp.logger.Error("failed to flush the mempool journal", "err", err)
+		}
+	}
 }
 
 // allEntriesSorted returns a slice of all the transactions currently in the
 // mempool, sorted in nonincreasing order by priority with ties broken by
 // increasing order of arrival time.
+//
+// The transactions of a sender with nonces come in increasing order of nonce
+// instead, from the nonce after its last committed one if known, or else its
+// lowest one. The transactions after a gap in the nonces are left out, as they
+// would fail.
 func (txmp *TxMempool) allEntriesSorted() []*WrappedTx {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
+	return txmp.sortedEntries()
+}
 
-	all := make([]*WrappedTx, 0, len(txmp.txByKey))
+// sortedEntries is allEntriesSorted, with txmp.mtx held by the caller.
+func (txmp *TxMempool) sortedEntries() []*WrappedTx {
+	candidates := make(wrappedTxHeap, 0, len(txmp.txByKey))
+	bySender := make(map[string][]*WrappedTx)
 	for _, tx := range txmp.txByKey {
-		all = append(all, tx.Value.(*WrappedTx))
+		w := tx.Value.(*WrappedTx)
+		if w.hasNonce {
+			bySender[w.sender] = append(bySender[w.sender], w)
+		} else {
+			candidates = append(candidates, w)
+		}
 	}
-	sort.Slice(all, func(i, j int) bool {
-		if all[i].priority == all[j].priority {
-			return all[i].timestamp.Before(all[j].timestamp)
+
+	// Only the next transaction of each sender is a candidate, and it is
+	// followed by the one with the next nonce once selected.
+	queued := make(map[string][]*WrappedTx, len(bySender))
+	for sender, wtxs := range bySender {
+		sort.Slice(wtxs, func(i, j int) bool { return wtxs[i].nonce < wtxs[j].nonce })
+		next, ok := txmp.nextNonces[sender]
+		if !ok {
+			next = wtxs[0].nonce
 		}
-		return all[i].priority > all[j].priority // N.B. higher priorities first
-	})
+		n := 0
+		for n < len(wtxs) && wtxs[n].nonce == next+uint64(n) {
+			n++
+		}
+		if n > 0 {
+			candidates = append(candidates, wtxs[0])
+			queued[sender] = wtxs[1:n]
+		}
+	}
+
+	heap.Init(&candidates)
+	all := make([]*WrappedTx, 0, len(txmp.txByKey))
+	for candidates.Len() > 0 {
+		w := heap.Pop(&candidates).(*WrappedTx)
+		all = append(all, w)
+		if q := queued[w.sender]; w.hasNonce && len(q) > 0 {
+			heap.Push(&candidates, q[0])
+			queued[w.sender] = q[1:]
+		}
+	}
 	return all
 }
 
@@ -366,6 +614,28 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 	return keep
 }
 
+// Contents returns all the transactions with their metadata, in the order
+// they are reaped, followed by the transactions after a gap in the nonces of
+// their sender, in their order of arrival.
+func (txmp *TxMempool) Contents() []mempool.TxMetadata {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	sorted := txmp.sortedEntries()
+	reaped := make(map[*WrappedTx]bool, len(sorted))
+	txs := make([]mempool.TxMetadata, 0, txmp.txs.Len())
+	for _, w := range sorted {
+		reaped[w] = true
+		txs = append(txs, w.Metadata())
+	}
+	for e := txmp.txs.Front(); e != nil; e = e.Next() {
+		if w := e.Value.(*WrappedTx); !reaped[w] {
+			txs = append(txs, w.Metadata())
+		}
+	}
+	return txs
+}
+
 // Update removes all the given transactions from the mempool and the cache,
 // and updates the current block height. The blockTxs and deliverTxResponses
 // must have the same length with each response corresponding to the tx at the
@@ -406,23 +676,37 @@ func (txmp *TxMempool) Update(
 		// the cache unless the operator has explicitly requested we keep them.
 		if deliverTxResponses[i].Code == abci.CodeTypeOK {
 			_ = txmp.cache.Push(tx)
-		} else if !txmp.config.KeepInvalidTxsInCache {
-			txmp.cache.Remove(tx)
+		} else {
+			txmp.cacheInvalidTx(tx)
+		}
+
+		// The next nonce of the sender of a successful transaction follows its
+		// nonce.
+		if elt, ok := txmp.txByKey[tx.Key()]; ok && deliverTxResponses[i].Code == abci.CodeTypeOK {
+			w := elt.Value.(*WrappedTx)
+			if nonce, ok := w.Nonce(); ok && nonce >= txmp.nextNonces[w.sender] {
+				txmp.nextNonces[w.sender] = nonce + 1
+			}
 		}
 
 		// Regardless of success, remove the transaction from the mempool.
 		_ = txmp.removeTxByKey(tx.Key())
+		txmp.statuses.Set(tx.Key(), mempool.TxStatusCommitted, blockHeight)
 	}
 
+	txmp.purgeCommittedNonces()
 	txmp.purgeExpiredTxs(blockHeight)
+	txmp.compactJournal()
 
 	// If there any uncommitted transactions left in the mempool, we either
 	// initiate re-CheckTx per remaining transaction or notify that remaining
 	// transactions are left.
 	size := txmp.Size()
 	txmp.metrics.Size.Set(float64(size))
+	refreshPriorities := txmp.refreshPriorities
+	txmp.refreshPriorities = false
 	if size > 0 {
-		if txmp.config.Recheck {
+		if txmp.config.Recheck || refreshPriorities {
 			txmp.recheckTransactions()
 		} else {
 			txmp.notifyTxsAvailable()
@@ -467,9 +751,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 
 		// Remove the invalid transaction from the cache, unless the operator has
 		// instructed us to keep invalid transactions.
-		if !txmp.config.KeepInvalidTxsInCache {
-			txmp.cache.Remove(wtx.tx)
-		}
+		txmp.cacheInvalidTx(wtx.tx)
 
 		// If there was a post-check error, record its text in the result for
 		// debugging purposes.
@@ -481,11 +763,75 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 
 	priority := checkTxRes.Priority
 	sender := checkTxRes.Sender
+	nonce, hasNonce := mempool.TxNonce(checkTxRes)
+	hasNonce = hasNonce && sender != ""
+
+	// A transaction with a nonce replaces the transaction of its sender with
+	// the same nonce if its priority is higher, and is discarded otherwise, or
+	// if its nonce is already committed.
+	if hasNonce {
+		if next, ok := txmp.nextNonces[sender]; ok && nonce < next {
+			txmp.logger.Debug(
+				"rejected valid incoming transaction; nonce already committed",
+				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"sender", sender,
+				"nonce", nonce,
+			)
+			checkTxRes.MempoolError =
+				fmt.Sprintf("rejected valid incoming transaction; nonce %d already committed for sender %q (%X)",
+					nonce, sender, wtx.tx.Hash())
+			txmp.metrics.RejectedTxs.Add(1)
+			return
+		}
+		if elt, ok := txmp.txBySenderNonce[senderNonce{sender: sender, nonce: nonce}]; ok {
+			w := elt.Value.(*WrappedTx)
+			if w.Priority() >= priority {
+				txmp.logger.Debug(
+					"rejected valid incoming transaction; tx already exists for sender and nonce",
+					"tx", fmt.Sprintf("%X", w.tx.Hash()),
+					"sender", sender,
+					"nonce", nonce,
+				)
+				mempool.Reject(checkTxRes, mempool.CodeFeeTooLow,
+					fmt.Sprintf("rejected valid incoming transaction; tx with a priority not lower already exists for sender %q and nonce %d (%X)",
+						sender, nonce, w.tx.Hash()))
+				txmp.metrics.RejectedTxs.Add(1)
+				return
+			}
+			txmp.logger.Debug(
+				"replaced valid existing transaction; higher priority for sender and nonce",
+				"old_tx", fmt.Sprintf("%X", w.tx.Hash()),
+				"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"new_priority", priority,
+			)
+			txmp.removeTxByElement(elt)
+			txmp.statuses.Set(w.hash, mempool.TxStatusEvicted, txmp.height)
+			txmp.metrics.EvictedTxs.Add(1)
+		}
+
+		// A sender has at most max_txs_per_sender transactions with a nonce in
+		// the mempool, the replaced one above not counting. The senders without
+		// nonces are already limited to a single transaction.
+		if max := txmp.config.MaxTxsPerSender; max > 0 && txmp.numNonceTxs[sender] >= max {
+			txmp.cache.Remove(wtx.tx)
+			txmp.logger.Debug(
+				"rejected valid incoming transaction; too many txs for sender",
+				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"sender", sender,
+				"max_txs_per_sender", max,
+			)
+			checkTxRes.MempoolError =
+				fmt.Sprintf("rejected valid incoming transaction; sender %q already has %d txs (%X)",
+					sender, max, wtx.tx.Hash())
+			txmp.metrics.RateLimitedTxs.With("reason", mempool.RateLimitReasonSender).Add(1)
+			return
+		}
+	}
 
 	// Disallow multiple concurrent transactions from the same sender assigned
-	// by the ABCI application. As a special case, an empty sender is not
-	// restricted.
-	if sender != "" {
+	// by the ABCI application, unless they have nonces. As a special case, an
+	// empty sender is not restricted.
+	if sender != "" && !hasNonce {
 		elt, ok := txmp.txBySender[sender]
 		if ok {
 			w := elt.Value.(*WrappedTx)
@@ -521,7 +867,8 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 
 		// If there are no suitable eviction candidates, or the total size of
 		// those candidates is not enough to make room for the new transaction,
-		// drop the new one.
+		// drop the new one: its priority is too low to evict any transaction in
+		// the former case.
 		if len(victims) == 0 || victimBytes < wtx.Size() {
 			txmp.cache.Remove(wtx.tx)
 			txmp.logger.Error(
@@ -529,9 +876,13 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
 				"err", err.Error(),
 			)
-			checkTxRes.MempoolError =
+			code := mempool.CodeMempoolFull
+			if len(victims) == 0 {
+				code = mempool.CodeFeeTooLow
+			}
+			mempool.Reject(checkTxRes, code,
 				fmt.Sprintf("rejected valid incoming transaction; mempool is full (%X)",
-					wtx.tx.Hash())
+					wtx.tx.Hash()))
 			txmp.metrics.RejectedTxs.Add(1)
 			return
 		}
@@ -564,6 +915,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 			)
 			txmp.removeTxByElement(vic)
 			txmp.cache.Remove(w.tx)
+			txmp.statuses.Set(w.hash, mempool.TxStatusEvicted, txmp.height)
 			txmp.metrics.EvictedTxs.Add(1)
 
 			// We may not need to evict all the eligible transactions.  Bail out
@@ -578,7 +930,11 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	wtx.SetGasWanted(checkTxRes.GasWanted)
 	wtx.SetPriority(priority)
 	wtx.SetSender(sender)
+	if hasNonce {
+		wtx.SetNonce(nonce)
+	}
 	txmp.insertTx(wtx)
+	txmp.statuses.Set(wtx.hash, mempool.TxStatusReceived, txmp.height)
 
 	txmp.metrics.TxSizeBytes.Observe(float64(wtx.Size()))
 	txmp.metrics.Size.Set(float64(txmp.Size()))
@@ -596,10 +952,22 @@ func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	elt := txmp.txs.PushBack(wtx)
 	txmp.txByKey[wtx.tx.Key()] = elt
 	if s := wtx.Sender(); s != "" {
-		txmp.txBySender[s] = elt
+		if nonce, ok := wtx.Nonce(); ok {
+			txmp.txBySenderNonce[senderNonce{sender: s, nonce: nonce}] = elt
+			txmp.numNonceTxs[s]++
+		} else {
+			txmp.txBySender[s] = elt
+		}
 	}
 
 	atomic.AddInt64(&txmp.txsBytes, wtx.Size())
+
+	if txmp.journal != nil {
+		if err := txmp.journal.Append(wtx.tx); err != nil {
+			txmp.logger.Error("failed to persist the transaction in the mempool journal",
+				"tx", fmt.Sprintf("%X", wtx.tx.Hash()), "err", err)
+		}
+	}
 }
 
 // handleRecheckResult handles the responses from ABCI CheckTx calls issued
@@ -641,13 +1009,26 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, checkTxRes *abci.Respons
 		"code", checkTxRes.Code,
 	)
 	txmp.removeTxByElement(elt)
+	txmp.statuses.Set(wtx.hash, mempool.TxStatusFailedRecheck, txmp.height)
 	txmp.metrics.FailedTxs.Add(1)
-	if !txmp.config.KeepInvalidTxsInCache {
-		txmp.cache.Remove(wtx.tx)
-	}
+	txmp.cacheInvalidTx(wtx.tx)
 	txmp.metrics.Size.Set(float64(txmp.Size()))
 }
 
+// cacheInvalidTx removes tx, found invalid by the application, from the cache
+// so that it can be resubmitted, unless the operator has instructed us to keep
+// invalid transactions. Those kept are moved to the cache of the invalid
+// transactions, if any.
+func (txmp *TxMempool) cacheInvalidTx(tx types.Tx) {
+	switch {
+	case !txmp.config.KeepInvalidTxsInCache:
+		txmp.cache.Remove(tx)
+	case txmp.config.InvalidCacheSize > 0:
+		txmp.cache.Remove(tx)
+		txmp.invalidCache.Push(tx)
+	}
+}
+
 // recheckTransactions initiates re-CheckTx ABCI calls for all the transactions
 // currently in the mempool. It reports the number of recheck calls that were
 // successfully initiated.
@@ -669,20 +1050,28 @@ func (txmp *TxMempool) recheckTransactions() {
 	for e := txmp.txs.Front(); e != nil; e = e.Next() {
 		wtxs = append(wtxs, e.Value.(*WrappedTx))
 	}
+	checkTxCtx := txmp.checkTxCtx
 
 	// Issue CheckTx calls for each remaining transaction, and when all the
 	// rechecks are complete signal watchers that transactions may be available.
 	go func() {
+		if len(txmp.recheckConns) > 0 {
+			if err := txmp.recheckInParallel(wtxs, checkTxCtx); err != nil {
+				txmp.logger.Error("failed to recheck transactions", "err", err)
+			}
+			txmp.mtx.Lock()
+			defer txmp.mtx.Unlock()
+			txmp.notifyTxsAvailable()
+			return
+		}
+
 		g, start := taskgroup.New(nil).Limit(2 * runtime.NumCPU())
 
 		for _, wtx := range wtxs {
 			wtx := wtx
 			start(func() error {
 				// The response for this CheckTx is handled by the default recheckTxCallback.
-				rsp, err := txmp.proxyAppConn.CheckTxSync(abci.RequestCheckTx{
-					Tx:   wtx.tx,
-					Type: abci.CheckTxType_Recheck,
-				})
+				rsp, err := txmp.proxyAppConn.CheckTxSync(checkTxCtx.Request(wtx.tx, abci.CheckTxType_Recheck))
 				if err != nil {
 					txmp.logger.Error("failed to execute CheckTx during recheck",
 						"err", err, "hash", fmt.Sprintf("%x", wtx.tx.Hash()))
@@ -702,25 +1091,74 @@ func (txmp *TxMempool) recheckTransactions() {
 	}()
 }
 
+// recheckInParallel rechecks wtxs in checkTxCtx over the recheck connections,
+// and handles their results in their order once all are rechecked. The
+// transactions left unchecked after an error are kept.
+//
+// The caller must not hold txmp.mtx.
+func (txmp *TxMempool) recheckInParallel(wtxs []*WrappedTx, checkTxCtx mempool.CheckTxContext) error {
+	txs := make(types.Txs, len(wtxs))
+	for i, wtx := range wtxs {
+		txs[i] = wtx.tx
+	}
+	responses, err := mempool.ParallelRecheck(txmp.recheckConns, txs, checkTxCtx)
+	for i, rsp := range responses {
+		if rsp != nil {
+			txmp.handleRecheckResult(txs[i], rsp)
+		}
+	}
+	return err
+}
+
 // canAddTx returns an error if we cannot insert the provided *WrappedTx into
 // the mempool due to mempool configured constraints. Otherwise, nil is
 // returned and the transaction can be inserted into the mempool.
 func (txmp *TxMempool) canAddTx(wtx *WrappedTx) error {
 	numTxs := txmp.Size()
 	txBytes := txmp.SizeBytes()
+	maxSize := int(atomic.LoadInt64(&txmp.maxSize))
+	maxTxsBytes := atomic.LoadInt64(&txmp.maxTxsBytes)
 
-	if numTxs >= txmp.config.Size || wtx.Size()+txBytes > txmp.config.MaxTxsBytes {
+	if numTxs >= maxSize || wtx.Size()+txBytes > maxTxsBytes {
 		return mempool.ErrMempoolIsFull{
 			NumTxs:      numTxs,
-			MaxTxs:      txmp.config.Size,
+			MaxTxs:      maxSize,
 			TxsBytes:    txBytes,
-			MaxTxsBytes: txmp.config.MaxTxsBytes,
+			MaxTxsBytes: maxTxsBytes,
 		}
 	}
 
 	return nil
 }
 
+// purgeCommittedNonces removes the transactions whose nonce is already
+// committed for their sender, e.g. replaced in a block proposed by another
+// node, and forgets the next nonces of the senders without transactions left.
+// Transactions removed by this operation are not removed from the cache.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) purgeCommittedNonces() {
+	if len(txmp.nextNonces) == 0 {
+		return // nothing to do
+	}
+
+	pending := make(map[string]bool, len(txmp.nextNonces))
+	for key, elt := range txmp.txBySenderNonce {
+		if next, ok := txmp.nextNonces[key.sender]; ok && key.nonce < next {
+			txmp.removeTxByElement(elt)
+			txmp.statuses.Set(elt.Value.(*WrappedTx).hash, mempool.TxStatusEvicted, txmp.height)
+			txmp.metrics.EvictedTxs.Add(1)
+			continue
+		}
+		pending[key.sender] = true
+	}
+	for sender := range txmp.nextNonces {
+		if !pending[sender] {
+			delete(txmp.nextNonces, sender)
+		}
+	}
+}
+
 // purgeExpiredTxs removes all transactions from the mempool that have exceeded
 // their respective height or time-based limits as of the given blockHeight.
 // Transactions removed by this operation are not removed from the cache.
@@ -742,16 +1180,47 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 		if txmp.config.TTLNumBlocks > 0 && (blockHeight-w.height) > txmp.config.TTLNumBlocks {
 			txmp.removeTxByElement(cur)
 			txmp.cache.Remove(w.tx)
+			txmp.statuses.Set(w.hash, mempool.TxStatusEvicted, blockHeight)
 			txmp.metrics.EvictedTxs.Add(1)
+			txmp.publishEviction(w.tx, mempool.EvictionReasonTTLNumBlocks)
 		} else if txmp.config.TTLDuration > 0 && now.Sub(w.timestamp) > txmp.config.TTLDuration {
 			txmp.removeTxByElement(cur)
 			txmp.cache.Remove(w.tx)
+			txmp.statuses.Set(w.hash, mempool.TxStatusEvicted, blockHeight)
 			txmp.metrics.EvictedTxs.Add(1)
+			txmp.publishEviction(w.tx, mempool.EvictionReasonTTLDuration)
 		}
 		cur = next
 	}
 }
 
+// compactJournal compacts the journal to the transactions left in the
+// mempool, if needed. The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) compactJournal() {
+	if txmp.journal == nil || !txmp.journal.NeedsCompaction(txmp.Size(), txmp.SizeBytes()) {
+		return
+	}
+	txs := make(types.Txs, 0, txmp.txs.Len())
+	for e := txmp.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*WrappedTx).tx)
+	}
+	if err := txmp.journal.Compact(txs); err != nil {
+		txmp.logger.Error("failed to compact the mempool journal", "err", err)
+	}
+}
+
+// publishEviction publishes the eviction of a valid tx.
+func (txmp *TxMempool) publishEviction(tx types.Tx, reason string) {
+	err := txmp.evictions.PublishEventMempoolTxEvicted(types.EventDataMempoolTxEvicted{
+		Tx:     tx,
+		Height: txmp.height,
+		Reason: reason,
+	})
+	if err != nil {
+		txmp.logger.Error("failed to publish the eviction of a tx", "tx", fmt.Sprintf("%X", tx.Hash()), "err", err)
+	}
+}
+
 func (txmp *TxMempool) notifyTxsAvailable() {
 	if txmp.Size() == 0 {
 		return // nothing to do
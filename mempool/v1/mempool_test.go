@@ -10,17 +10,20 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/code"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
@@ -40,10 +43,21 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	var (
 		priority int64
 		sender   string
+		events   []abci.Event
 	)
 
-	// infer the priority from the raw transaction value (sender=key=value)
+	// infer the priority from the raw transaction value (sender=key=value), and
+	// the nonce from an optional fourth part (sender=key=value=nonce)
 	parts := bytes.Split(req.Tx, []byte("="))
+	if len(parts) == 4 {
+		events = []abci.Event{{
+			Type: mempool.EventType,
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(mempool.NonceAttributeKey), Value: parts[3]},
+			},
+		}}
+		parts = parts[:3]
+	}
 	if len(parts) == 3 {
 		v, err := strconv.ParseInt(string(parts[2]), 10, 64)
 		if err != nil {
@@ -69,6 +83,7 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 		Sender:    sender,
 		Code:      code.CodeTypeOK,
 		GasWanted: 1,
+		Events:    events,
 	}
 }
 
@@ -208,8 +223,7 @@ func TestTxMempool_Size(t *testing.T) {
 
 func TestTxMempool_Eviction(t *testing.T) {
 	txmp := setup(t, 1000)
-	txmp.config.Size = 5
-	txmp.config.MaxTxsBytes = 60
+	txmp.SetLimits(5, 60)
 	txExists := func(spec string) bool {
 		txmp.Lock()
 		defer txmp.Unlock()
@@ -277,6 +291,45 @@ func TestTxMempool_Eviction(t *testing.T) {
 	require.False(t, txExists("key7=0006=7"))
 }
 
+func TestTxMempool_RejectCodes(t *testing.T) {
+	txmp := setup(t, 1000)
+	txmp.SetLimits(2, 30)
+	checkTx := func(spec string) *abci.ResponseCheckTx {
+		var res *abci.ResponseCheckTx
+		require.NoError(t, txmp.CheckTx([]byte(spec), func(r *abci.Response) {
+			res = r.GetCheckTx()
+		}, mempool.TxInfo{}))
+		return res
+	}
+
+	for _, spec := range []string{"a=x=10=1", "b=x=20"} {
+		require.Equal(t, abci.CodeTypeOK, checkTx(spec).Code)
+	}
+
+	// the txs rejected before the app checks them have an error with a code
+	err := txmp.CheckTx(types.Tx("b=x=20"), nil, mempool.TxInfo{})
+	code, ok := mempool.RejectCode(err)
+	require.True(t, ok)
+	require.Equal(t, mempool.CodeDuplicate, code)
+	err = txmp.CheckTx(make(types.Tx, txmp.config.MaxTxBytes+1), nil, mempool.TxInfo{})
+	code, _ = mempool.RejectCode(err)
+	require.Equal(t, mempool.CodeTxTooLarge, code)
+
+	// and those accepted by the app but rejected by the mempool have the code
+	// in their response
+	for spec, code := range map[string]uint32{
+		"a=y=5=1":     mempool.CodeFeeTooLow,   // replaces a tx with a higher priority
+		"c=x=5":       mempool.CodeFeeTooLow,   // evicts no tx of the full mempool
+		"c=xxxxxx=15": mempool.CodeMempoolFull, // doesn't fit once the lower ones are evicted
+	} {
+		res := checkTx(spec)
+		require.Equal(t, code, res.Code, spec)
+		require.Equal(t, mempool.Codespace, res.Codespace, spec)
+		require.Equal(t, res.Log, res.MempoolError, spec)
+	}
+	require.Equal(t, 2, txmp.Size())
+}
+
 func TestTxMempool_Flush(t *testing.T) {
 	txmp := setup(t, 0)
 	txs := checkTxs(t, txmp, 100, 0)
@@ -455,6 +508,317 @@ func TestTxMempool_CheckTxSameSender(t *testing.T) {
 	require.Equal(t, 1, txmp.Size())
 }
 
+func TestTxMempool_SenderNonces(t *testing.T) {
+	txmp := setup(t, 100)
+	reaped := func() []string {
+		var specs []string
+		for _, tx := range txmp.ReapMaxTxs(-1) {
+			specs = append(specs, string(tx))
+		}
+		return specs
+	}
+
+	// the txs of a sender are reaped by nonce, interleaved by priority with
+	// the others, and those after a gap are left out
+	for _, spec := range []string{
+		"a=x=30=2", "a=y=10=1", "b=x=20", "c=x=40=5", "c=y=50=7",
+	} {
+		mustCheckTx(t, txmp, spec)
+	}
+	require.Equal(t, 5, txmp.Size())
+	require.Equal(t, []string{"c=x=40=5", "b=x=20", "a=y=10=1", "a=x=30=2"}, reaped())
+
+	// a tx with the nonce of a pending one replaces it only with a higher
+	// priority
+	mustCheckTx(t, txmp, "a=z=10=1")
+	require.Equal(t, 5, txmp.Size())
+	mustCheckTx(t, txmp, "a=z=60=1")
+	require.Equal(t, 5, txmp.Size())
+	require.Equal(t, []string{"a=z=60=1", "c=x=40=5", "a=x=30=2", "b=x=20"}, reaped())
+
+	// once a nonce is committed, the next one must follow it: the older
+	// nonces are rejected or purged, and the gaps are left out
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, types.Txs{types.Tx("a=z=60=1"), types.Tx("c=x=40=5")},
+		[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: abci.CodeTypeOK}}, nil, nil))
+	txmp.Unlock()
+	require.Equal(t, []string{"a=x=30=2", "b=x=20"}, reaped())
+	mustCheckTx(t, txmp, "c=z=90=4")
+	require.Equal(t, 3, txmp.Size())
+	mustCheckTx(t, txmp, "c=z=90=6")
+	require.Equal(t, []string{"c=z=90=6", "c=y=50=7", "a=x=30=2", "b=x=20"}, reaped())
+}
+
+func TestTxMempool_MaxTxsPerSender(t *testing.T) {
+	txmp := setup(t, 100)
+	txmp.config.MaxTxsPerSender = 2
+
+	for _, spec := range []string{"a=x=10=1", "a=y=10=2", "a=z=10=3", "b=x=10=1"} {
+		mustCheckTx(t, txmp, spec)
+	}
+	require.Equal(t, 3, txmp.Size())
+
+	// a replacement doesn't count, and the sender has a slot again once a tx
+	// is committed
+	mustCheckTx(t, txmp, "a=w=20=2")
+	require.Equal(t, 3, txmp.Size())
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, types.Txs{types.Tx("a=x=10=1")},
+		[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}}, nil, nil))
+	txmp.Unlock()
+	mustCheckTx(t, txmp, "a=z=10=3")
+	require.Equal(t, types.Txs{types.Tx("a=w=20=2"), types.Tx("b=x=10=1"), types.Tx("a=z=10=3")},
+		txmp.ReapMaxTxs(-1))
+}
+
+func TestTxMempool_CheckTxBatch(t *testing.T) {
+	txmp := setup(t, 100)
+	txs := types.Txs{types.Tx("a=x=10"), types.Tx("bad"), types.Tx("a=x=10"), types.Tx("b=x=20")}
+	codes := map[int]uint32{}
+	errs := txmp.CheckTxBatch(txs, func(i int, res *abci.Response) {
+		codes[i] = res.GetCheckTx().Code
+	}, mempool.TxInfo{})
+
+	require.Len(t, errs, 4)
+	require.Equal(t, map[int]uint32{0: abci.CodeTypeOK, 1: 101, 3: abci.CodeTypeOK}, codes)
+	require.Equal(t, []error{nil, nil, mempool.ErrTxInCache, nil}, errs)
+	require.Equal(t, types.Txs{types.Tx("b=x=20"), types.Tx("a=x=10")}, txmp.ReapMaxTxs(-1))
+}
+
+func TestTxMempool_Contents(t *testing.T) {
+	txmp := setup(t, 100)
+	mustCheckTx(t, txmp, "a=x=10=1")
+	mustCheckTx(t, txmp, "a=z=10=3")
+	tx := types.Tx("b=x=20")
+	require.NoError(t, txmp.CheckTx(tx, nil, mempool.TxInfo{SenderID: 2, SenderP2PID: "peer2"}))
+	require.Equal(t, mempool.ErrTxInCache, txmp.CheckTx(tx, nil, mempool.TxInfo{SenderID: 1, SenderP2PID: "peer1"}))
+
+	// the tx after the gap in the nonces of its sender isn't reaped, and comes
+	// last
+	require.Len(t, txmp.ReapMaxTxs(-1), 2)
+	contents := txmp.Contents()
+	require.Len(t, contents, 3)
+	require.Equal(t, tx, contents[0].Tx)
+	require.Equal(t, "b", contents[0].Sender)
+	require.EqualValues(t, 20, contents[0].Priority)
+	require.EqualValues(t, 1, contents[0].GasWanted)
+	require.Equal(t, []p2p.ID{"peer1", "peer2"}, contents[0].Peers)
+	require.Equal(t, types.Tx("a=x=10=1"), contents[1].Tx)
+	require.Empty(t, contents[1].Peers)
+	require.Equal(t, types.Tx("a=z=10=3"), contents[2].Tx)
+	require.False(t, contents[2].ArrivalTime.Before(contents[1].ArrivalTime))
+}
+
+func TestTxMempool_FlushRechecks(t *testing.T) {
+	banned := map[string]bool{}
+	txmp := setup(t, 100, WithPostCheck(func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if banned[string(tx)] {
+			return errors.New("banned")
+		}
+		return nil
+	}))
+	for _, spec := range []string{"a=x=10", "b=x=20", "c=x=30"} {
+		mustCheckTx(t, txmp, spec)
+	}
+
+	// the txs banned since they were added are removed once rechecked
+	banned["b=x=20"] = true
+	require.NoError(t, txmp.FlushRechecks())
+	require.Equal(t, types.Txs{types.Tx("c=x=30"), types.Tx("a=x=10")}, txmp.ReapMaxTxs(-1))
+}
+
+func TestTxMempool_RecheckConns(t *testing.T) {
+	banned := map[string]bool{}
+	var conns []proxy.AppConnMempool
+	for i := 0; i < 2; i++ {
+		conns = append(conns, abciclient.NewLocalClient(nil, &application{kvstore.NewApplication()}))
+	}
+	txmp := setup(t, 100, WithRecheckConns(conns), WithPostCheck(func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if banned[string(tx)] {
+			return errors.New("banned")
+		}
+		return nil
+	}))
+	for _, spec := range []string{"a=x=10", "b=x=20", "c=x=30", "d=x=40"} {
+		mustCheckTx(t, txmp, spec)
+	}
+
+	// the txs banned since they were added are removed once rechecked over
+	// the recheck connections
+	banned["b=x=20"] = true
+	banned["d=x=40"] = true
+	require.NoError(t, txmp.FlushRechecks())
+	require.Equal(t, types.Txs{types.Tx("c=x=30"), types.Tx("a=x=10")}, txmp.ReapMaxTxs(-1))
+}
+
+// contextApp records the requests to check the transactions.
+type contextApp struct {
+	*application
+	reqs []abci.RequestCheckTx
+}
+
+func (app *contextApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.reqs = append(app.reqs, req)
+	return app.application.CheckTx(req)
+}
+
+func TestTxMempool_CheckTxContext(t *testing.T) {
+	app := &contextApp{application: &application{kvstore.NewApplication()}}
+	now := time.Now().UTC()
+	txmp := NewTxMempool(log.TestingLogger(), config.TestMempoolConfig(), abciclient.NewLocalClient(nil, app), 0,
+		WithCheckTxContext(mempool.CheckTxContext{Height: 1, Time: now, ProposerAddress: []byte("alice")}))
+	for _, spec := range []string{"a=x=10", "b=x=20"} {
+		mustCheckTx(t, txmp, spec)
+	}
+
+	// the transactions are rechecked in the context of the next block
+	txmp.Lock()
+	txmp.SetCheckTxContext(mempool.CheckTxContext{Height: 2, Time: now.Add(time.Second), ProposerAddress: []byte("bob")})
+	txmp.Unlock()
+	require.NoError(t, txmp.FlushRechecks())
+
+	require.Len(t, app.reqs, 4)
+	for _, req := range app.reqs[:2] {
+		require.Equal(t, abci.CheckTxType_New, req.Type)
+		require.EqualValues(t, 1, req.Height)
+		require.Equal(t, now, req.Time)
+		require.Equal(t, []byte("alice"), req.ProposerAddress)
+	}
+	for _, req := range app.reqs[2:] {
+		require.Equal(t, abci.CheckTxType_Recheck, req.Type)
+		require.EqualValues(t, 2, req.Height)
+		require.Equal(t, now.Add(time.Second), req.Time)
+		require.Equal(t, []byte("bob"), req.ProposerAddress)
+	}
+}
+
+// feeApp overrides the priorities of some transactions, and counts the
+// rechecks.
+type feeApp struct {
+	*application
+	fees     map[string]int64
+	rechecks int32
+}
+
+func (app *feeApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if req.Type == abci.CheckTxType_Recheck {
+		atomic.AddInt32(&app.rechecks, 1)
+	}
+	res := app.application.CheckTx(req)
+	if fee, ok := app.fees[string(req.Tx)]; ok {
+		res.Priority = fee
+	}
+	return res
+}
+
+func TestTxMempool_RefreshPriorities(t *testing.T) {
+	app := &feeApp{application: &application{kvstore.NewApplication()}, fees: map[string]int64{}}
+	cfg := config.TestMempoolConfig()
+	cfg.Recheck = false
+	txmp := NewTxMempool(log.TestingLogger(), cfg, abciclient.NewLocalClient(nil, app), 0)
+	for _, spec := range []string{"a=x=10", "b=x=20"} {
+		mustCheckTx(t, txmp, spec)
+	}
+	app.fees["a=x=10"] = 30
+
+	// the transactions aren't rechecked with recheck disabled
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, nil, nil, nil, nil))
+	txmp.Unlock()
+	require.Zero(t, atomic.LoadInt32(&app.rechecks))
+	require.Equal(t, types.Txs{types.Tx("b=x=20"), types.Tx("a=x=10")}, txmp.ReapMaxTxs(-1))
+
+	// but are once the app requests the refresh of their priorities
+	txmp.Lock()
+	txmp.RefreshPriorities()
+	require.NoError(t, txmp.Update(2, nil, nil, nil, nil))
+	txmp.Unlock()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&app.rechecks) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return bytes.Equal(txmp.ReapMaxTxs(-1)[0], []byte("a=x=10"))
+	}, time.Second, 10*time.Millisecond)
+
+	// for a single block
+	txmp.Lock()
+	require.NoError(t, txmp.Update(3, nil, nil, nil, nil))
+	txmp.Unlock()
+	require.EqualValues(t, 2, atomic.LoadInt32(&app.rechecks))
+}
+
+func TestTxMempool_InvalidCache(t *testing.T) {
+	banned := map[string]bool{"a=x=10": true}
+	cfg := config.TestMempoolConfig()
+	cfg.KeepInvalidTxsInCache = true
+	cfg.InvalidCacheSize = 10
+	cfg.InvalidCacheTTL = 200 * time.Millisecond
+	conn := abciclient.NewLocalClient(nil, &application{kvstore.NewApplication()})
+	txmp := NewTxMempool(log.TestingLogger(), cfg, conn, 0, WithPostCheck(func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if banned[string(tx)] {
+			return errors.New("banned")
+		}
+		return nil
+	}))
+
+	// the invalid transactions are kept apart from the valid ones
+	require.NoError(t, txmp.CheckTx(types.Tx("a=x=10"), nil, mempool.TxInfo{}))
+	require.Equal(t, mempool.ErrTxInCache, txmp.CheckTx(types.Tx("a=x=10"), nil, mempool.TxInfo{}))
+	require.False(t, txmp.cache.Has(types.Tx("a=x=10")))
+	require.True(t, txmp.invalidCache.Has(types.Tx("a=x=10")))
+
+	// until they expire
+	banned["a=x=10"] = false
+	time.Sleep(250 * time.Millisecond)
+	mustCheckTx(t, txmp, "a=x=10")
+	require.Equal(t, 1, txmp.Size())
+
+	// or are forgotten
+	banned["b=x=20"] = true
+	require.NoError(t, txmp.CheckTx(types.Tx("b=x=20"), nil, mempool.TxInfo{}))
+	require.Equal(t, mempool.ErrTxInCache, txmp.CheckTx(types.Tx("b=x=20"), nil, mempool.TxInfo{}))
+	txmp.ForgetTx(types.Tx("b=x=20").Key())
+	require.NoError(t, txmp.CheckTx(types.Tx("b=x=20"), nil, mempool.TxInfo{}))
+}
+
+func TestTxMempool_TxStatuses(t *testing.T) {
+	banned := map[string]bool{}
+	statuses := mempool.NewTxStatuses(10)
+	txmp := setup(t, 100, WithTxStatuses(statuses), WithPostCheck(func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if banned[string(tx)] {
+			return errors.New("banned")
+		}
+		return nil
+	}))
+	status := func(tx string) string {
+		s, ok := statuses.Get(types.Tx(tx).Key())
+		if !ok {
+			return mempool.TxStatusUnknown
+		}
+		return s.Status
+	}
+
+	for _, spec := range []string{"a=x=10", "b=x=20", "c=x=30", "d=x=1=0", "d=y=5=0"} {
+		mustCheckTx(t, txmp, spec)
+	}
+	require.Equal(t, mempool.TxStatusReceived, status("a=x=10"))
+	require.Equal(t, mempool.TxStatusReceived, status("d=y=5=0"))
+	require.Equal(t, mempool.TxStatusEvicted, status("d=x=1=0"), "replaced")
+
+	banned["b=x=20"] = true
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, types.Txs{types.Tx("a=x=10")},
+		[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}}, nil, nil))
+	txmp.Unlock()
+	require.NoError(t, txmp.FlushRechecks())
+	require.NoError(t, txmp.RemoveTxByKey(types.Tx("c=x=30").Key()))
+
+	require.Equal(t, mempool.TxStatusCommitted, status("a=x=10"))
+	require.Equal(t, mempool.TxStatusFailedRecheck, status("b=x=20"))
+	require.Equal(t, mempool.TxStatusEvicted, status("c=x=30"))
+	require.Equal(t, mempool.TxStatusReceived, status("d=y=5=0"))
+}
+
 func TestTxMempool_ConcurrentTxs(t *testing.T) {
 	txmp := setup(t, 100)
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -567,8 +931,19 @@ func TestTxMempool_ExpiredTxs_Timestamp(t *testing.T) {
 	}
 }
 
+// evictionRecorder records the evictions published by a mempool.
+type evictionRecorder struct {
+	evictions []types.EventDataMempoolTxEvicted
+}
+
+func (r *evictionRecorder) PublishEventMempoolTxEvicted(data types.EventDataMempoolTxEvicted) error {
+	r.evictions = append(r.evictions, data)
+	return nil
+}
+
 func TestTxMempool_ExpiredTxs_NumBlocks(t *testing.T) {
-	txmp := setup(t, 500)
+	recorder := &evictionRecorder{}
+	txmp := setup(t, 500, WithEvictionPublisher(recorder))
 	txmp.height = 100
 	txmp.config.TTLNumBlocks = 10
 
@@ -611,6 +986,13 @@ func TestTxMempool_ExpiredTxs_NumBlocks(t *testing.T) {
 	txmp.Unlock()
 
 	require.GreaterOrEqual(t, txmp.Size(), 45)
+
+	// the evictions of the expired transactions are published
+	require.Equal(t, 145-5-txmp.Size(), len(recorder.evictions))
+	for _, eviction := range recorder.evictions {
+		require.Equal(t, mempool.EvictionReasonTTLNumBlocks, eviction.Reason)
+		require.EqualValues(t, 111, eviction.Height)
+	}
 }
 
 func TestTxMempool_CheckTxPostCheckError(t *testing.T) {
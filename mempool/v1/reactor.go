@@ -25,6 +25,8 @@ type Reactor struct {
 	config  *cfg.MempoolConfig
 	mempool *TxMempool
 	ids     *mempoolIDs
+	limiter *mempool.PeerRateLimiter // of the txs received from the peers
+	peers   *mempool.BroadcastPeers  // the txs are broadcast to
 }
 
 type mempoolIDs struct {
@@ -91,11 +93,13 @@ func newMempoolIDs() *mempoolIDs {
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *TxMempool) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mp *TxMempool) *Reactor {
 	memR := &Reactor{
 		config:  config,
-		mempool: mempool,
+		mempool: mp,
 		ids:     newMempoolIDs(),
+		limiter: mempool.NewPeerRateLimiter(config.PeerMaxTxsPerSecond),
+		peers:   mempool.NewBroadcastPeers(config),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	return memR
@@ -116,6 +120,8 @@ func (memR *Reactor) SetLogger(l log.Logger) {
 func (memR *Reactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
+	} else if memR.config.BroadcastMode == cfg.MempoolBroadcastSequencer {
+		memR.Logger.Info("Txs are only broadcast to the sequencer peers", "peers", memR.config.SequencerPeerIDs)
 	}
 	return nil
 }
@@ -141,9 +147,10 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 }
 
 // AddPeer implements Reactor.
-// It starts a broadcast routine ensuring all txs are forwarded to the given peer.
+// It starts a broadcast routine ensuring all txs are forwarded to the given peer,
+// unless the txs aren't broadcast to it.
 func (memR *Reactor) AddPeer(peer p2p.Peer) {
-	if memR.config.Broadcast {
+	if memR.config.Broadcast && memR.peers.Includes(peer.ID()) {
 		go memR.broadcastTxRoutine(peer)
 	}
 }
@@ -151,6 +158,7 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
+	memR.limiter.RemovePeer(peer.ID())
 	// broadcast routine checks if peer is gone and returns
 }
 
@@ -173,6 +181,12 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		var err error
 		for _, tx := range protoTxs {
 			ntx := types.Tx(tx)
+			// the txs over the rate of the peer are dropped unchecked
+			if e.Src != nil && !memR.limiter.Allow(e.Src.ID()) {
+				memR.Logger.Debug("Dropping tx over the rate of the peer", "tx", ntx.String(), "src", e.Src)
+				memR.mempool.metrics.RateLimitedTxs.With("reason", mempool.RateLimitReasonPeer).Add(1)
+				continue
+			}
 			err = memR.mempool.CheckTx(ntx, nil, txInfo)
 			if errors.Is(err, mempool.ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", ntx.String())
@@ -270,6 +284,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 				time.Sleep(mempool.PeerCatchupSleepIntervalMS * time.Millisecond)
 				continue
 			}
+			memR.mempool.statuses.Set(memTx.hash, mempool.TxStatusGossiped, memTx.height)
 		}
 
 		select {
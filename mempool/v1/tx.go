@@ -1,9 +1,12 @@
 package v1
 
 import (
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -16,23 +19,25 @@ type WrappedTx struct {
 	timestamp time.Time   // time when transaction was entered (for TTL)
 
 	mtx       sync.Mutex
-	gasWanted int64           // app: gas required to execute this transaction
-	priority  int64           // app: priority value for this transaction
-	sender    string          // app: assigned sender label
-	peers     map[uint16]bool // peer IDs who have sent us this transaction
+	gasWanted int64             // app: gas required to execute this transaction
+	priority  int64             // app: priority value for this transaction
+	sender    string            // app: assigned sender label
+	nonce     uint64            // app: nonce among the transactions of the sender
+	hasNonce  bool              // whether the app assigned a nonce
+	peers     map[uint16]p2p.ID // peer IDs who have sent us this transaction
 }
 
 // Size reports the size of the raw transaction in bytes.
 func (w *WrappedTx) Size() int64 { return int64(len(w.tx)) }
 
-// SetPeer adds the specified peer ID as a sender of w.
-func (w *WrappedTx) SetPeer(id uint16) {
+// SetPeer adds the specified peer ID, of the given p2p ID, as a sender of w.
+func (w *WrappedTx) SetPeer(id uint16, p2pID p2p.ID) {
 	w.mtx.Lock()
 	defer w.mtx.Unlock()
 	if w.peers == nil {
-		w.peers = map[uint16]bool{id: true}
+		w.peers = map[uint16]p2p.ID{id: p2pID}
 	} else {
-		w.peers[id] = true
+		w.peers[id] = p2pID
 	}
 }
 
@@ -72,6 +77,23 @@ func (w *WrappedTx) Sender() string {
 	return w.sender
 }
 
+// SetNonce sets the application-assigned nonce of w among the transactions of
+// its sender.
+func (w *WrappedTx) SetNonce(nonce uint64) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.nonce = nonce
+	w.hasNonce = true
+}
+
+// Nonce reports the application-assigned nonce of w, and whether it was
+// assigned one.
+func (w *WrappedTx) Nonce() (uint64, bool) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.nonce, w.hasNonce
+}
+
 // SetPriority sets the application-assigned priority of w.
 func (w *WrappedTx) SetPriority(p int64) {
 	w.mtx.Lock()
@@ -85,3 +107,56 @@ func (w *WrappedTx) Priority() int64 {
 	defer w.mtx.Unlock()
 	return w.priority
 }
+
+// Metadata returns the transaction with its metadata. The transactions
+// submitted over RPC aren't received from a peer.
+func (w *WrappedTx) Metadata() mempool.TxMetadata {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	var peers []p2p.ID
+	for id, p2pID := range w.peers {
+		if id != mempool.UnknownPeerID {
+			peers = append(peers, p2pID)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	return mempool.TxMetadata{
+		Tx:          w.tx,
+		Height:      w.height,
+		ArrivalTime: w.timestamp,
+		GasWanted:   w.gasWanted,
+		Priority:    w.priority,
+		Sender:      w.sender,
+		Peers:       peers,
+	}
+}
+
+// senderNonce identifies the transaction of a sender with a nonce.
+type senderNonce struct {
+	sender string
+	nonce  uint64
+}
+
+// wrappedTxHeap is a heap of transactions, by nonincreasing priority with ties
+// broken by increasing order of arrival.
+type wrappedTxHeap []*WrappedTx
+
+func (h wrappedTxHeap) Len() int { return len(h) }
+
+func (h wrappedTxHeap) Less(i, j int) bool {
+	if h[i].priority == h[j].priority {
+		return h[i].timestamp.Before(h[j].timestamp)
+	}
+	return h[i].priority > h[j].priority // N.B. higher priorities first
+}
+
+func (h wrappedTxHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *wrappedTxHeap) Push(x interface{}) { *h = append(*h, x.(*WrappedTx)) }
+
+func (h *wrappedTxHeap) Pop() interface{} {
+	old := *h
+	w := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return w
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
@@ -22,12 +25,19 @@ import (
 	cfg "github.com/tendermint/tendermint/config"
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/batch"
+	"github.com/tendermint/tendermint/da"
 	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/finality"
+	"github.com/tendermint/tendermint/inclusion"
 
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/profiling"
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tracing"
 	"github.com/tendermint/tendermint/light"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
@@ -39,11 +49,15 @@ import (
 	rpccore "github.com/tendermint/tendermint/rpc/core"
 	grpccore "github.com/tendermint/tendermint/rpc/grpc"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	"github.com/tendermint/tendermint/sequencer"
+	"github.com/tendermint/tendermint/settlement"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
+	"github.com/tendermint/tendermint/state/indexer/sink/clickhouse"
 	"github.com/tendermint/tendermint/state/indexer/sink/psql"
+	"github.com/tendermint/tendermint/state/indexer/sink/sqlite"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/state/txindex/null"
@@ -53,7 +67,7 @@ import (
 	cmttime "github.com/tendermint/tendermint/types/time"
 	"github.com/tendermint/tendermint/version"
 
-	_ "net/http/pprof" //nolint: gosec // securely exposed on separate, optional port
+	"net/http/pprof"
 
 	_ "github.com/lib/pq" // provide the psql db driver
 )
@@ -71,11 +85,11 @@ type DBProvider func(*DBContext) (dbm.DB, error)
 
 const readHeaderTimeout = 10 * time.Second
 
-// DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the ctx.Config.
+// DefaultDBProvider returns a database using the backend and directory
+// specified for it in the ctx.Config, see DBBackendOf and DBDirOf.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
-	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	dbType := dbm.BackendType(ctx.Config.DBBackendOf(ctx.ID))
+	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDirOf(ctx.ID))
 }
 
 // GenesisDocProvider returns a GenesisDoc.
@@ -103,8 +117,14 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	// Only validators load their private validator.
+	var privValidator types.PrivValidator
+	if config.NodeMode() == cfg.ModeValidator {
+		privValidator = privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	}
+
 	return NewNode(config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		privValidator,
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
@@ -114,20 +134,29 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state, privval, txindex,
+// DA, settlement and store Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics,
+	*privval.Metrics, *txindex.Metrics, *da.Metrics, *settlement.Metrics, *store.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics,
+		*privval.Metrics, *txindex.Metrics, *da.Metrics, *settlement.Metrics, *store.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				privval.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				txindex.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				da.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				settlement.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				store.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), privval.NopMetrics(),
+			txindex.NopMetrics(), da.NopMetrics(), settlement.NopMetrics(), store.NopMetrics()
 	}
 }
 
@@ -140,6 +169,13 @@ type fastSyncReactor interface {
 	SwitchToFastSync(sm.State) error
 }
 
+// fastSyncSwitcher is implemented by fast sync reactors that support switching
+// between fast sync and consensus on operator request (only v0).
+type fastSyncSwitcher interface {
+	ForceSwitchToConsensus() error
+	ForceSwitchToFastSync() error
+}
+
 // CustomReactors allows you to add custom reactors (name -> p2p.Reactor) to
 // the node's Switch.
 //
@@ -189,6 +225,15 @@ func StateProvider(stateProvider statesync.StateProvider) Option {
 	}
 }
 
+// ConsensusTimeoutPolicy escalates the timeouts of the consensus rounds with
+// policy, instead of the timeout_escalation of the config, e.g. for the
+// validators of a chain with a high latency between them.
+func ConsensusTimeoutPolicy(policy cs.TimeoutPolicy) Option {
+	return func(n *Node) {
+		cs.StateTimeoutPolicy(policy)(n.consensusState)
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // Node is the highest level interface to a full CometBFT node.
@@ -200,6 +245,7 @@ type Node struct {
 	config        *cfg.Config
 	genesisDoc    *types.GenesisDoc   // initial validator set
 	privValidator types.PrivValidator // local node's validator key
+	auditLogPV    *privval.AuditLogPV // records the messages signed by privValidator, if enabled
 
 	// network
 	transport   *p2p.MultiplexTransport
@@ -216,6 +262,8 @@ type Node struct {
 	bcReactor         p2p.Reactor       // for fast-syncing
 	mempoolReactor    p2p.Reactor       // for gossipping transactions
 	mempool           mempl.Mempool
+	mempoolJournal    *mempl.Journal          // persists the txs of the mempool, nil if disabled
+	txStatuses        *mempl.TxStatuses       // tracks the statuses of the txs, nil if disabled
 	stateSync         bool                    // whether the node should state sync on startup
 	stateSyncReactor  *statesync.Reactor      // for hosting and restoring state sync snapshots
 	stateSyncProvider statesync.StateProvider // provides state data for bootstrapping a node
@@ -229,7 +277,22 @@ type Node struct {
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	daSubmitter       *da.Submitter      // submits the committed blocks to the DA layer, if enabled
+	settlementPoster  *settlement.Poster // posts the state updates to the hub, if enabled
+	sequencerWatcher  *sequencer.Watcher // schedules the sequencer rotations of the hub, if enabled
+	forcedTxWatcher   *inclusion.Watcher // queues the txs force-included by the hub, if enabled
+	blockPruner       *store.Pruner      // prunes the blocks in the background, if enabled
+	statePruning      *sm.PruningService // prunes the states with their own policy, if enabled
+	finalityTracker   *finality.Tracker  // tracks the finality of the blocks
 	prometheusSrv     *http.Server
+	pprofSrv          *http.Server
+	profiler          *profiling.Profiler
+	tracerProvider    *sdktrace.TracerProvider // exports the spans, if tracing is enabled
+
+	// configuration reloading
+	reloadMtx       cmtsync.Mutex
+	configLoader    func() (*cfg.Config, error)
+	swappableLogger *log.SwappableLogger // the logger passed to NewNode, if swappable
 }
 
 func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
@@ -238,7 +301,11 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	blockStore = store.NewBlockStore(blockStoreDB,
+		store.WithCompression(config.Storage.BlockCompression),
+		store.WithPruningMode(config.Storage.PruningMode),
+		store.WithCache(config.Storage.BlockCacheSize),
+	)
 
 	stateDB, err = dbProvider(&DBContext{"state", config})
 	if err != nil {
@@ -248,8 +315,16 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	config *cfg.Config,
+	logger log.Logger,
+) (proxy.AppConns, error) {
+	var options []proxy.MultiAppConnOption
+	if config.Mempool.Recheck && config.Mempool.RecheckConnections > 0 {
+		options = append(options, proxy.WithMempoolRecheckConns(config.Mempool.RecheckConnections))
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, options...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -257,6 +332,30 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
+// createAndInstallTracerProvider returns the tracer provider exporting the
+// spans of the node, installed as the global provider, or nil if tracing is
+// disabled.
+func createAndInstallTracerProvider(
+	config *cfg.Config,
+	chainID string,
+	nodeKey *p2p.NodeKey,
+) (*sdktrace.TracerProvider, error) {
+	if !config.Instrumentation.Tracing {
+		return nil, nil
+	}
+	exporter, err := tracing.NewExporter(config.Instrumentation.TracingExporter, config.Instrumentation.TracingEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	provider := tracing.NewProvider(exporter, config.Instrumentation.TracingSampleRate,
+		attribute.String("chain_id", chainID),
+		attribute.String("moniker", config.Moniker),
+		attribute.String("node_id", string(nodeKey.ID())),
+	)
+	tracing.Install(provider)
+	return provider, nil
+}
+
 func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	eventBus := types.NewEventBus()
 	eventBus.SetLogger(logger.With("module", "events"))
@@ -266,45 +365,233 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
+func createDASubmitter(
+	config *cfg.DAConfig,
+	blockStore *store.BlockStore,
+	metrics *da.Metrics,
+	logger log.Logger,
+) (*da.Submitter, error) {
+	client, err := da.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	submitter := da.NewSubmitter(client, blockStore, config, da.WithMetrics(metrics))
+	submitter.SetLogger(logger.With("module", "da", "layer", client.Layer()))
+	return submitter, nil
+}
+
+func createSettlementPoster(
+	config *cfg.SettlementConfig,
+	chainID string,
+	blockStore *store.BlockStore,
+	eventBus *types.EventBus,
+	proposerAddress types.Address,
+	metrics *settlement.Metrics,
+	logger log.Logger,
+) (*settlement.Poster, error) {
+	client, err := settlement.NewClient(config, chainID)
+	if err != nil {
+		return nil, err
+	}
+	options := []settlement.PosterOption{settlement.WithMetrics(metrics)}
+	if config.SequencerRotation {
+		options = append(options, settlement.WithProposerAddress(proposerAddress))
+	}
+	poster := settlement.NewPoster(client, blockStore, eventBus, config, options...)
+	poster.SetLogger(logger.With("module", "settlement", "hub", client.Hub()))
+	return poster, nil
+}
+
+// createFinalityTracker returns the tracker of the finality of the blocks,
+// which queries the blocks finalized by the hub if settlement is enabled.
+func createFinalityTracker(
+	config *cfg.SettlementConfig,
+	chainID string,
+	blockStore *store.BlockStore,
+	eventBus *types.EventBus,
+	logger log.Logger,
+) (*finality.Tracker, error) {
+	var options []finality.TrackerOption
+	if config.Enabled() {
+		client, err := settlement.NewQueryClient(config, chainID)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, finality.WithHub(client, config.PostInterval))
+	}
+	tracker := finality.NewTracker(blockStore, eventBus, options...)
+	tracker.SetLogger(logger.With("module", "finality"))
+	return tracker, nil
+}
+
+// createAndSyncForcedTxWatcher returns the watcher of the transactions
+// force-included by the hub, after loading those force-included so far.
+func createAndSyncForcedTxWatcher(
+	config *cfg.SettlementConfig,
+	chainID string,
+	stateDB dbm.DB,
+	txIndexer txindex.TxIndexer,
+	logger log.Logger,
+) (*inclusion.Watcher, *inclusion.Queue, error) {
+	source, err := settlement.NewForcedTxSource(config, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var txIndex inclusion.TxIndex
+	if _, ok := txIndexer.(*null.TxIndex); !ok {
+		txIndex = txIndexer
+	}
+	queue := inclusion.NewQueue(stateDB)
+	watcher := inclusion.NewWatcher(source, queue, txIndex, config.ForcedInclusionResyncInterval)
+	watcher.SetLogger(logger.With("module", "inclusion"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	if err := watcher.Sync(ctx); err != nil {
+		return nil, nil, fmt.Errorf("loading the forced transactions from the hub: %w", err)
+	}
+	return watcher, queue, nil
+}
+
+// createAndSyncSequencerWatcher returns the watcher of the sequencer rotations
+// scheduled by the hub, after loading those scheduled so far, which the
+// blocks replayed or synced must apply.
+func createAndSyncSequencerWatcher(
+	config *cfg.SettlementConfig,
+	chainID string,
+	stateDB dbm.DB,
+	blockStore *store.BlockStore,
+	logger log.Logger,
+) (*sequencer.Watcher, *sequencer.Store, error) {
+	source, err := settlement.NewRotationSource(config, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rotationStore := sequencer.NewStore(stateDB)
+	watcher := sequencer.NewWatcher(source, rotationStore, blockStore, config.RotationResyncInterval)
+	watcher.SetLogger(logger.With("module", "sequencer"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	if err := watcher.Sync(ctx); err != nil {
+		return nil, nil, fmt.Errorf("loading the sequencer rotations from the hub: %w", err)
+	}
+	return watcher, rotationStore, nil
+}
+
 func createAndStartIndexerService(
 	config *cfg.Config,
 	chainID string,
 	dbProvider DBProvider,
 	eventBus *types.EventBus,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	metrics *txindex.Metrics,
 	logger log.Logger,
 ) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
 	var (
 		txIndexer    txindex.TxIndexer
 		blockIndexer indexer.BlockIndexer
+		store        dbm.DB
+		err          error
 	)
 
 	switch config.TxIndex.Indexer {
 	case "kv":
-		store, err := dbProvider(&DBContext{"tx_index", config})
+		store, err = dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
 			return nil, nil, nil, err
 		}
 
-		txIndexer = kv.NewTxIndex(store)
+		txIndexer = kv.NewTxIndex(store, kv.WithAccountAttributes(
+			config.TxIndex.SenderAttributes,
+			config.TxIndex.RecipientAttributes,
+			config.TxIndex.FeePayerAttributes,
+		))
 		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
 
 	case "psql":
 		if config.TxIndex.PsqlConn == "" {
 			return nil, nil, nil, errors.New(`no psql-conn is set for the "psql" indexer`)
 		}
-		es, err := psql.NewEventSink(config.TxIndex.PsqlConn, chainID)
+		es, err := psql.NewEventSink(config.TxIndex.PsqlConn, chainID,
+			psql.WithFullTextAttributes(config.TxIndex.FullTextAttributes))
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("creating psql indexer: %w", err)
 		}
 		txIndexer = es.TxIndexer()
 		blockIndexer = es.BlockIndexer()
 
+	case "sqlite":
+		es, err := sqlite.NewEventSink(config.TxIndex.SqliteFile(), chainID,
+			sqlite.WithFullTextAttributes(config.TxIndex.FullTextAttributes))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("creating sqlite indexer: %w", err)
+		}
+		txIndexer = es.TxIndexer()
+		blockIndexer = es.BlockIndexer()
+
+	case "clickhouse":
+		if config.TxIndex.ClickhouseConn == "" {
+			return nil, nil, nil, errors.New(`no clickhouse-conn is set for the "clickhouse" indexer`)
+		}
+		es, err := clickhouse.NewEventSink(config.TxIndex.ClickhouseConn, chainID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("creating clickhouse indexer: %w", err)
+		}
+		txIndexer = es.TxIndexer()
+		blockIndexer = es.BlockIndexer()
+
 	default:
 		txIndexer = &null.TxIndex{}
 		blockIndexer = &blockidxnull.BlockerIndexer{}
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	// migrate the indexed data to the latest schema version before use
+	if m, ok := txIndexer.(indexer.Migrator); ok {
+		_, err := indexer.Migrate(context.Background(), m, false, func(migration indexer.Migration) {
+			logger.Info("migrated the indexer schema",
+				"version", migration.Version, "description", migration.Description)
+		})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("migrating the indexer schema: %w", err)
+		}
+	}
+
+	eventFilter, err := txindex.NewEventFilter(config.TxIndex.IndexEvents, config.TxIndex.ExcludeEvents)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	options := []txindex.IndexerServiceOption{
+		txindex.WithMetrics(metrics),
+		txindex.WithEventFilter(eventFilter),
+	}
+	var progressDB dbm.DB
+	if config.TxIndex.QueueSize > 0 || config.TxIndex.PruningEnabled() {
+		// the indexing progress is persisted along the kv indexes, or in a
+		// dedicated database for the other indexers
+		if store == nil {
+			store, err = dbProvider(&DBContext{"tx_index", config})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		progressDB = dbm.NewPrefixDB(store, []byte("indexer_progress"))
+	}
+	if config.TxIndex.QueueSize > 0 {
+		options = append(options,
+			txindex.WithQueue(config.TxIndex.QueueSize, config.TxIndex.Workers, progressDB),
+			txindex.WithBatchSize(config.TxIndex.BatchSize),
+			txindex.WithCatchUp(blockStore, stateStore))
+	}
+	if config.TxIndex.PruningEnabled() {
+		pruner := txindex.NewPruner(txIndexer, blockIndexer, blockStore, progressDB,
+			config.TxIndex.RetainBlocks, config.TxIndex.PruneWithBlockStore, config.TxIndex.PruneInterval)
+		pruner.SetLogger(logger.With("module", "txindex"))
+		options = append(options, txindex.WithPruner(pruner))
+	}
+
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false, options...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	if err := indexerService.Start(); err != nil {
@@ -321,11 +608,19 @@ func doHandshake(
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
+	sequencerRotations sm.SequencerRotations,
+	forcedTxs sm.ForcedTxs,
 	consensusLogger log.Logger,
 ) error {
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	if sequencerRotations != nil {
+		handshaker.SetSequencerRotations(sequencerRotations)
+	}
+	if forcedTxs != nil {
+		handshaker.SetForcedTxs(forcedTxs)
+	}
 	if err := handshaker.Handshake(proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
@@ -350,6 +645,10 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 		)
 	}
 
+	if pubKey == nil {
+		consensusLogger.Info("This node is not a validator")
+		return
+	}
 	addr := pubKey.Address()
 	// Log whether this node is a validator or an observer
 	if state.Validators.HasAddress(addr) {
@@ -360,7 +659,7 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 }
 
 func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
-	if state.Validators.Size() > 1 {
+	if pubKey == nil || state.Validators.Size() > 1 {
 		return false
 	}
 	addr, _ := state.Validators.GetByIndex(0)
@@ -372,10 +671,13 @@ func createMempoolAndMempoolReactor(
 	proxyApp proxy.AppConns,
 	state sm.State,
 	memplMetrics *mempl.Metrics,
+	eventBus *types.EventBus,
+	journal *mempl.Journal,
+	txStatuses *mempl.TxStatuses,
 	logger log.Logger,
 ) (mempl.Mempool, p2p.Reactor) {
 	switch config.Mempool.Version {
-	case cfg.MempoolV1:
+	case cfg.MempoolV1, cfg.MempoolPriority:
 		mp := mempoolv1.NewTxMempool(
 			logger,
 			config.Mempool,
@@ -384,6 +686,11 @@ func createMempoolAndMempoolReactor(
 			mempoolv1.WithMetrics(memplMetrics),
 			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
 			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv1.WithCheckTxContext(sm.TxCheckContext(state)),
+			mempoolv1.WithEvictionPublisher(eventBus),
+			mempoolv1.WithJournal(journal),
+			mempoolv1.WithRecheckConns(proxyApp.MempoolRecheck()),
+			mempoolv1.WithTxStatuses(txStatuses),
 		)
 
 		reactor := mempoolv1.NewReactor(
@@ -396,7 +703,7 @@ func createMempoolAndMempoolReactor(
 
 		return mp, reactor
 
-	case cfg.MempoolV0:
+	case cfg.MempoolV0, "":
 		mp := mempoolv0.NewCListMempool(
 			config.Mempool,
 			proxyApp.Mempool(),
@@ -404,6 +711,11 @@ func createMempoolAndMempoolReactor(
 			mempoolv0.WithMetrics(memplMetrics),
 			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
 			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv0.WithCheckTxContext(sm.TxCheckContext(state)),
+			mempoolv0.WithEvictionPublisher(eventBus),
+			mempoolv0.WithJournal(journal),
+			mempoolv0.WithRecheckConns(proxyApp.MempoolRecheck()),
+			mempoolv0.WithTxStatuses(txStatuses),
 		)
 
 		mp.SetLogger(logger)
@@ -423,6 +735,49 @@ func createMempoolAndMempoolReactor(
 	}
 }
 
+// loadMempoolJournal loads the journal persisting the txs of the mempool, if
+// enabled, and returns the txs persisted before the node stopped.
+func loadMempoolJournal(config *cfg.MempoolConfig, logger log.Logger) (*mempl.Journal, types.Txs, error) {
+	if !config.Persist {
+		return nil, nil, nil
+	}
+	journal := mempl.NewJournal(config.JournalFile(), config.PersistMaxBytes, logger)
+	txs, err := journal.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the mempool journal: %w", err)
+	}
+	return journal, txs, nil
+}
+
+// createTxStatuses returns the statuses of the txs, or nil if they aren't
+// tracked.
+func createTxStatuses(config *cfg.MempoolConfig) *mempl.TxStatuses {
+	if config.TxStatusSize == 0 {
+		return nil
+	}
+	return mempl.NewTxStatuses(config.TxStatusSize)
+}
+
+// restoreMempool rechecks the txs persisted by the mempool journal before the
+// node stopped, and adds back those which are still valid.
+func restoreMempool(mempool mempl.Mempool, txs types.Txs, logger log.Logger) {
+	if len(txs) == 0 {
+		return
+	}
+	for _, tx := range txs {
+		if err := mempool.CheckTx(tx, nil, mempl.TxInfo{}); err != nil {
+			logger.Debug("Failed to restore a tx of the mempool", "tx", tx.Hash(), "err", err)
+		}
+	}
+	mempool.Lock()
+	err := mempool.FlushAppConn()
+	mempool.Unlock()
+	if err != nil {
+		logger.Error("Failed to flush the mempool connection", "err", err)
+	}
+	logger.Info("Restored the mempool", "persisted", len(txs), "valid", mempool.Size())
+}
+
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger,
 ) (*evidence.Reactor, *evidence.Pool, error) {
@@ -432,7 +787,8 @@ func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 	}
 	evidenceLogger := logger.With("module", "evidence")
 	evidencePool, err := evidence.NewPool(evidenceDB, sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		DiscardABCIResponses:      config.Storage.DiscardABCIResponses,
+		ValidatorSetDeltaInterval: config.Storage.ValidatorSetDeltaInterval,
 	}), blockStore)
 	if err != nil {
 		return nil, nil, err
@@ -447,11 +803,15 @@ func createBlockchainReactor(config *cfg.Config,
 	blockExec *sm.BlockExecutor,
 	blockStore *store.BlockStore,
 	fastSync bool,
+	eventBus *types.EventBus,
 	logger log.Logger,
 ) (bcReactor p2p.Reactor, err error) {
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		bcR := bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync,
+			bcv0.WithSwitchHysteresis(config.FastSync.SwitchHysteresis))
+		bcR.SetEventBus(eventBus)
+		bcReactor = bcR
 	case "v1":
 		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
 	case "v2":
@@ -469,13 +829,29 @@ func createConsensusReactor(config *cfg.Config,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
 	mempool mempl.Mempool,
+	txStatuses *mempl.TxStatuses,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
+	blockPruner *store.Pruner,
+	externalStatePruning bool,
 	waitSync bool,
 	eventBus *types.EventBus,
 	consensusLogger log.Logger,
 ) (*cs.Reactor, *cs.State) {
+	options := []cs.StateOption{cs.StateMetrics(csMetrics)}
+	if config.NodeMode() == cfg.ModeArchive {
+		options = append(options, cs.RetainAllBlocks())
+	}
+	if blockPruner != nil {
+		options = append(options, cs.AsyncBlockPruning(blockPruner))
+	}
+	if externalStatePruning {
+		options = append(options, cs.ExternalStatePruning())
+	}
+	if txStatuses != nil {
+		options = append(options, cs.TxStatuses(txStatuses))
+	}
 	consensusState := cs.NewState(
 		config.Consensus,
 		state.Copy(),
@@ -483,7 +859,7 @@ func createConsensusReactor(config *cfg.Config,
 		blockStore,
 		mempool,
 		evidencePool,
-		cs.StateMetrics(csMetrics),
+		options...,
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
@@ -586,11 +962,14 @@ func createSwitch(config *cfg.Config,
 		p2p.SwitchPeerFilters(peerFilters...),
 	)
 	sw.SetLogger(p2pLogger)
-	sw.AddReactor("MEMPOOL", mempoolReactor)
-	sw.AddReactor("BLOCKCHAIN", bcReactor)
-	sw.AddReactor("CONSENSUS", consensusReactor)
-	sw.AddReactor("EVIDENCE", evidenceReactor)
-	sw.AddReactor("STATESYNC", stateSyncReactor)
+	// Seeds only run the PEX reactor, added later.
+	if config.NodeMode() != cfg.ModeSeed {
+		sw.AddReactor("MEMPOOL", mempoolReactor)
+		sw.AddReactor("BLOCKCHAIN", bcReactor)
+		sw.AddReactor("CONSENSUS", consensusReactor)
+		sw.AddReactor("EVIDENCE", evidenceReactor)
+		sw.AddReactor("STATESYNC", stateSyncReactor)
+	}
 
 	sw.SetNodeInfo(nodeInfo)
 	sw.SetNodeKey(nodeKey)
@@ -633,7 +1012,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 	pexReactor := pex.NewReactor(addrBook,
 		&pex.ReactorConfig{
 			Seeds:    splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
-			SeedMode: config.P2P.SeedMode,
+			SeedMode: config.NodeMode() == cfg.ModeSeed,
 			// See consensus/reactor.go: blocksToContributeToBecomeGoodPeer 10000
 			// blocks assuming 10s blocks ~ 28 hours.
 			// TODO (melekes): make it dynamic based on the actual block latencies
@@ -656,16 +1035,23 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 
 	if stateProvider == nil {
 		var err error
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		stateProvider, err = statesync.NewLightClientStateProvider(
-			ctx,
-			state.ChainID, state.Version, state.InitialHeight,
-			config.RPCServers, light.TrustOptions{
-				Period: config.TrustPeriod,
-				Height: config.TrustHeight,
-				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"))
+		trustOptions := light.TrustOptions{
+			Period: config.TrustPeriod,
+			Height: config.TrustHeight,
+			Hash:   config.TrustHashBytes(),
+		}
+		if config.UseP2P {
+			stateProvider, err = statesync.NewP2PStateProvider(
+				state.ChainID, state.Version, state.InitialHeight,
+				ssR, trustOptions, ssR.Logger.With("module", "light"))
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			stateProvider, err = statesync.NewLightClientStateProvider(
+				ctx,
+				state.ChainID, state.Version, state.InitialHeight,
+				config.RPCServers, trustOptions, ssR.Logger.With("module", "light"))
+		}
 		if err != nil {
 			return fmt.Errorf("failed to set up light client state provider: %w", err)
 		}
@@ -715,13 +1101,32 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option,
 ) (*Node, error) {
+	batch.SetMaxBatchSize(config.SigVerifyBatchSize)
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
 	}
 
+	// migrate the state store to the latest schema version before use
+	schemaVersion, err := sm.SchemaVersion(stateDB)
+	if err != nil {
+		return nil, fmt.Errorf("loading the state store schema version: %w", err)
+	}
+	applied, err := sm.Migrate(stateDB, schemaVersion, sm.LatestSchemaVersion())
+	for _, migration := range applied {
+		logger.Info("migrated the state store schema",
+			"version", migration.Version, "description", migration.Description)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("migrating the state store schema: %w", err)
+	}
+
 	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		DiscardABCIResponses:      config.Storage.DiscardABCIResponses,
+		ABCIResponsesKeepRecent:   config.Storage.ABCIResponsesKeepRecent,
+		ABCIResponsesRetention:    config.Storage.ABCIResponsesRetention,
+		ValidatorSetDeltaInterval: config.Storage.ValidatorSetDeltaInterval,
 	})
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
@@ -729,8 +1134,29 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	tracerProvider, err := createAndInstallTracerProvider(config, genDoc.ChainID, nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the sequencer rotations scheduled by the hub before any block is
+	// replayed or synced.
+	var (
+		sequencerWatcher   *sequencer.Watcher
+		sequencerRotations sm.SequencerRotations
+	)
+	if config.Settlement.Enabled() && config.Settlement.SequencerRotation {
+		var rotationStore *sequencer.Store
+		sequencerWatcher, rotationStore, err = createAndSyncSequencerWatcher(config.Settlement, genDoc.ChainID,
+			stateDB, blockStore, logger)
+		if err != nil {
+			return nil, err
+		}
+		sequencerRotations = rotationStore
+	}
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, config, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -744,25 +1170,102 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, privvalMetrics, txindexMetrics, daMetrics, settlementMetrics,
+		storeMetrics := metricsProvider(genDoc.ChainID)
+	blockStore.SetMetrics(storeMetrics)
+
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+		genDoc.ChainID, dbProvider, eventBus, blockStore, stateStore, txindexMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	// Load the transactions force-included by the hub before any block is
+	// replayed, so that the blocks replayed mark those they include.
+	var (
+		forcedTxWatcher *inclusion.Watcher
+		forcedTxs       sm.ForcedTxs
+	)
+	if config.Settlement.Enabled() && config.Settlement.ForcedInclusion {
+		var queue *inclusion.Queue
+		forcedTxWatcher, queue, err = createAndSyncForcedTxWatcher(config.Settlement, genDoc.ChainID, stateDB,
+			txIndexer, logger)
+		if err != nil {
+			return nil, err
+		}
+		forcedTxs = queue
+	}
+
+	// Only validators sign for consensus.
+	if config.NodeMode() != cfg.ModeValidator {
+		privValidator = nil
+	}
+
 	// If an address is provided, listen on the socket for a connection from an
 	// external signing process.
 	if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID,
+			privvalMetrics, logger)
 		if err != nil {
 			return nil, fmt.Errorf("error with private validator socket client: %w", err)
 		}
 	}
 
-	pubKey, err := privValidator.GetPubKey()
-	if err != nil {
-		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	// If a key share is provided, sign with the threshold signing protocol.
+	if config.PrivValidatorKeyShare != "" {
+		privValidator, err = createThresholdPrivValidator(config.BaseConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error with threshold private validator: %w", err)
+		}
+	}
+
+	// If a PKCS#11 module is provided, sign with the key in the HSM.
+	if config.PrivValidatorPKCS11Module != "" {
+		privValidator, err = privval.NewPKCS11PV(pkcs11Config(config.BaseConfig), config.PrivValidatorStateFile())
+		if err != nil {
+			return nil, fmt.Errorf("error with PKCS#11 private validator: %w", err)
+		}
+	}
+
+	// If enabled, sign with the key in the Ledger device.
+	if config.PrivValidatorLedger {
+		privValidator, err = privval.NewLedgerPV(logger.With("module", "privval"),
+			ledgerConfig(config.BaseConfig), config.PrivValidatorStateFile())
+		if err != nil {
+			return nil, fmt.Errorf("error with Ledger private validator: %w", err)
+		}
+	}
+
+	// If enabled, record the messages signed for consensus in an audit log.
+	csPrivValidator := privValidator
+	var auditLogPV *privval.AuditLogPV
+	if privValidator != nil && config.PrivValidatorAuditLog != "" {
+		auditLogPV, err = privval.NewAuditLogPV(privValidator, config.PrivValidatorAuditLogFile())
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator audit log: %w", err)
+		}
+		csPrivValidator = auditLogPV
+	}
+
+	// If enabled, guard the signing requests of consensus with a watchdog.
+	if privValidator != nil && config.PrivValidatorWatchdog {
+		csPrivValidator, err = privval.NewWatchdogPV(logger.With("module", "privval"), csPrivValidator,
+			config.PrivValidatorWatchdogStateFile(),
+			privval.WatchdogPVMaxSignRate(config.PrivValidatorMaxSignRate),
+			privval.WatchdogPVChainHeight(blockStore.Height, config.PrivValidatorMaxHeightDrift),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator watchdog: %w", err)
+		}
+	}
+
+	var pubKey crypto.PubKey
+	if privValidator != nil {
+		pubKey, err = privValidator.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("can't get pubkey: %w", err)
+		}
 	}
 
 	// Determine whether we should attempt state sync.
@@ -776,7 +1279,9 @@ func NewNode(config *cfg.Config,
 	// and replays any blocks as necessary to sync CometBFT with the app.
 	consensusLogger := logger.With("module", "consensus")
 	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, sequencerRotations,
+			forcedTxs, consensusLogger)
+		if err != nil {
 			return nil, err
 		}
 
@@ -795,10 +1300,15 @@ func NewNode(config *cfg.Config,
 
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
-
-	// Make MempoolReactor
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
+	// Make MempoolReactor, and add back the txs persisted before the node stopped
+	mempoolJournal, persistedTxs, err := loadMempoolJournal(config.Mempool, logger.With("module", "mempool"))
+	if err != nil {
+		return nil, err
+	}
+	txStatuses := createTxStatuses(config.Mempool)
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, eventBus,
+		mempoolJournal, txStatuses, logger)
+	restoreMempool(mempool, persistedTxs, logger.With("module", "mempool"))
 
 	// Make Evidence Reactor
 	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
@@ -807,17 +1317,30 @@ func NewNode(config *cfg.Config,
 	}
 
 	// make block executor for consensus and blockchain reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithBlockGas(blockStore),
+	}
+	if config.Storage.SaveBlockResults {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithBlockResults(blockStore))
+	}
+	if sequencerRotations != nil {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithSequencerRotations(sequencerRotations))
+	}
+	if forcedTxs != nil {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithForcedTxs(forcedTxs))
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
 		logger.With("module", "state"),
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger)
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, eventBus, logger)
 	if err != nil {
 		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
 	}
@@ -829,9 +1352,36 @@ func NewNode(config *cfg.Config,
 	} else if fastSync {
 		csMetrics.FastSyncing.Set(1)
 	}
+	// Prune the states with their own policy instead of along with the blocks
+	var statePruningService *sm.PruningService
+	if config.Storage.StatePruningInterval > 0 {
+		statePruningService = sm.NewPruningService(stateStore, blockStore,
+			dbm.NewPrefixDB(stateDB, []byte("statePruning:")), sm.PruningOptions{
+				KeepRecent: config.Storage.StatePruningKeepRecent,
+				KeepEvery:  config.Storage.StatePruningKeepEvery,
+				Interval:   config.Storage.StatePruningInterval,
+			})
+		statePruningService.SetLogger(logger.With("module", "state_pruning"))
+	}
+
+	// Prune the blocks in the background instead of before each new height
+	var blockPruner *store.Pruner
+	if config.Storage.AsyncPruning {
+		prunerOptions := []store.PrunerOption{
+			store.PrunerMetrics(storeMetrics),
+			store.WithRetentionPeriod(config.Storage.BlockRetentionPeriod),
+		}
+		if statePruningService == nil {
+			prunerOptions = append(prunerOptions, store.WithStatePruner(stateStore))
+		}
+		blockPruner = store.NewPruner(blockStore, prunerOptions...)
+		blockPruner.SetLogger(logger.With("module", "pruner"))
+	}
+
 	consensusReactor, consensusState := createConsensusReactor(
-		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
+		config, state, blockExec, blockStore, mempool, txStatuses, evidencePool,
+		csPrivValidator, csMetrics, blockPruner, statePruningService != nil, stateSync || fastSync,
+		eventBus, consensusLogger,
 	)
 
 	// Set up state sync reactor, and schedule a sync if requested.
@@ -843,6 +1393,7 @@ func NewNode(config *cfg.Config,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
 		config.StateSync.TempDir,
+		statesync.WithStores(stateStore, blockStore),
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
@@ -893,18 +1444,45 @@ func NewNode(config *cfg.Config,
 		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
 	}
 
-	if config.RPC.PprofListenAddress != "" {
-		go func() {
-			logger.Info("Starting pprof server", "laddr", config.RPC.PprofListenAddress)
-			//nolint:gosec,nolintlint // G114: Use of net/http serve function that has no support for setting timeouts
-			logger.Error("pprof server error", "err", http.ListenAndServe(config.RPC.PprofListenAddress, nil))
-		}()
+	var daSubmitter *da.Submitter
+	if config.DA.Enabled() {
+		daSubmitter, err = createDASubmitter(config.DA, blockStore, daMetrics, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// only the sequencer posts the state updates
+	var settlementPoster *settlement.Poster
+	if config.Settlement.Enabled() && config.NodeMode() == cfg.ModeValidator {
+		var proposerAddress types.Address
+		if pubKey != nil {
+			proposerAddress = pubKey.Address()
+		}
+		settlementPoster, err = createSettlementPoster(config.Settlement, genDoc.ChainID, blockStore, eventBus,
+			proposerAddress, settlementMetrics, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	finalityTracker, err := createFinalityTracker(config.Settlement, genDoc.ChainID, blockStore, eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiler *profiling.Profiler
+	if config.Instrumentation.ProfilingInterval > 0 {
+		profiler = profiling.NewProfiler(config.Instrumentation.ProfilingDir(),
+			config.Instrumentation.ProfilingInterval, config.Instrumentation.ProfilingRetain)
+		profiler.SetLogger(logger.With("module", "profiler"))
 	}
 
 	node := &Node{
 		config:        config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
+		auditLogPV:    auditLogPV,
 
 		transport: transport,
 		sw:        sw,
@@ -917,6 +1495,7 @@ func NewNode(config *cfg.Config,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
+		mempoolJournal:   mempoolJournal,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
 		stateSyncReactor: stateSyncReactor,
@@ -928,9 +1507,22 @@ func NewNode(config *cfg.Config,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
 		blockIndexer:     blockIndexer,
+		daSubmitter:      daSubmitter,
+		settlementPoster: settlementPoster,
+		sequencerWatcher: sequencerWatcher,
+		forcedTxWatcher:  forcedTxWatcher,
+		blockPruner:      blockPruner,
+		statePruning:     statePruningService,
+		finalityTracker:  finalityTracker,
+		txStatuses:       txStatuses,
 		eventBus:         eventBus,
+		tracerProvider:   tracerProvider,
+		profiler:         profiler,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
+	if l, ok := logger.(*log.SwappableLogger); ok {
+		node.swappableLogger = l
+	}
 
 	for _, option := range options {
 		option(node)
@@ -966,6 +1558,16 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if n.config.RPC.PprofListenAddress != "" {
+		n.pprofSrv = n.startPprofServer(n.config.RPC.PprofListenAddress)
+	}
+
+	if n.profiler != nil {
+		if err := n.profiler.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
 	if err != nil {
@@ -989,6 +1591,52 @@ func (n *Node) OnStart() error {
 		return fmt.Errorf("could not dial peers from persistent_peers field: %w", err)
 	}
 
+	// Submit the committed blocks to the DA layer
+	if n.daSubmitter != nil {
+		if err := n.daSubmitter.Start(); err != nil {
+			return fmt.Errorf("failed to start DA submitter: %w", err)
+		}
+	}
+
+	// Schedule the sequencer rotations announced by the hub
+	if n.sequencerWatcher != nil {
+		if err := n.sequencerWatcher.Start(); err != nil {
+			return fmt.Errorf("failed to start sequencer watcher: %w", err)
+		}
+	}
+
+	// Queue the transactions force-included by the hub
+	if n.forcedTxWatcher != nil {
+		if err := n.forcedTxWatcher.Start(); err != nil {
+			return fmt.Errorf("failed to start forced tx watcher: %w", err)
+		}
+	}
+
+	// Post the state updates of the blocks included in the DA layer to the hub
+	if n.settlementPoster != nil {
+		if err := n.settlementPoster.Start(); err != nil {
+			return fmt.Errorf("failed to start settlement poster: %w", err)
+		}
+	}
+
+	// Prune the blocks in the background, resuming the pruning scheduled
+	// before a restart
+	if n.blockPruner != nil {
+		if err := n.blockPruner.Start(); err != nil {
+			return fmt.Errorf("failed to start block pruner: %w", err)
+		}
+	}
+	if n.statePruning != nil {
+		if err := n.statePruning.Start(); err != nil {
+			return fmt.Errorf("failed to start state pruning: %w", err)
+		}
+	}
+
+	// Track the finality of the blocks
+	if err := n.finalityTracker.Start(); err != nil {
+		return fmt.Errorf("failed to start finality tracker: %w", err)
+	}
+
 	// Run state sync
 	if n.stateSync {
 		bcR, ok := n.bcReactor.(fastSyncReactor)
@@ -1011,15 +1659,24 @@ func (n *Node) OnStop() {
 
 	n.Logger.Info("Stopping Node")
 
-	// first stop the non-reactor services
-	if err := n.eventBus.Stop(); err != nil {
-		n.Logger.Error("Error closing eventBus", "err", err)
+	// first stop accepting RPC requests, so that no transaction or
+	// subscription comes in while the node shuts down
+	for _, l := range n.rpcListeners {
+		n.Logger.Info("Closing rpc listener", "listener", l)
+		if err := l.Close(); err != nil {
+			n.Logger.Error("Error closing listener", "listener", l, "err", err)
+		}
 	}
-	if err := n.indexerService.Stop(); err != nil {
-		n.Logger.Error("Error closing indexerService", "err", err)
+
+	// then let consensus finish its current step and flush the WAL, before the
+	// peers are disconnected and the stores closed under it
+	if n.consensusReactor.IsRunning() {
+		if err := n.consensusReactor.Stop(); err != nil {
+			n.Logger.Error("Error closing consensus reactor", "err", err)
+		}
 	}
 
-	// now stop the reactors
+	// now disconnect from the peers and stop the other reactors
 	if err := n.sw.Stop(); err != nil {
 		n.Logger.Error("Error closing switch", "err", err)
 	}
@@ -1030,19 +1687,70 @@ func (n *Node) OnStop() {
 
 	n.isListening = false
 
-	// finally stop the listeners / external services
-	for _, l := range n.rpcListeners {
-		n.Logger.Info("Closing rpc listener", "listener", l)
-		if err := l.Close(); err != nil {
-			n.Logger.Error("Error closing listener", "listener", l, "err", err)
+	// no block is committed anymore, stop indexing and submitting them
+	if err := n.indexerService.Stop(); err != nil {
+		n.Logger.Error("Error closing indexerService", "err", err)
+	}
+	if n.daSubmitter != nil && n.daSubmitter.IsRunning() {
+		if err := n.daSubmitter.Stop(); err != nil {
+			n.Logger.Error("Error stopping DA submitter", "err", err)
 		}
 	}
+	if n.settlementPoster != nil && n.settlementPoster.IsRunning() {
+		if err := n.settlementPoster.Stop(); err != nil {
+			n.Logger.Error("Error stopping settlement poster", "err", err)
+		}
+	}
+	if n.sequencerWatcher != nil && n.sequencerWatcher.IsRunning() {
+		if err := n.sequencerWatcher.Stop(); err != nil {
+			n.Logger.Error("Error stopping sequencer watcher", "err", err)
+		}
+	}
+	if n.forcedTxWatcher != nil && n.forcedTxWatcher.IsRunning() {
+		if err := n.forcedTxWatcher.Stop(); err != nil {
+			n.Logger.Error("Error stopping forced tx watcher", "err", err)
+		}
+	}
+	if n.blockPruner != nil && n.blockPruner.IsRunning() {
+		if err := n.blockPruner.Stop(); err != nil {
+			n.Logger.Error("Error stopping block pruner", "err", err)
+		}
+	}
+	if n.statePruning != nil && n.statePruning.IsRunning() {
+		if err := n.statePruning.Stop(); err != nil {
+			n.Logger.Error("Error stopping state pruning", "err", err)
+		}
+	}
+	if n.finalityTracker.IsRunning() {
+		if err := n.finalityTracker.Stop(); err != nil {
+			n.Logger.Error("Error stopping finality tracker", "err", err)
+		}
+	}
+	if err := n.eventBus.Stop(); err != nil {
+		n.Logger.Error("Error closing eventBus", "err", err)
+	}
 
+	// finally stop the external services and close the stores
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
 	}
+	if pv, ok := n.privValidator.(*privval.PKCS11PV); ok {
+		if err := pv.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
+	}
+	if pv, ok := n.privValidator.(*privval.LedgerPV); ok {
+		if err := pv.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
+	}
+	if n.auditLogPV != nil {
+		if err := n.auditLogPV.Close(); err != nil {
+			n.Logger.Error("Error closing private validator audit log", "err", err)
+		}
+	}
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -1050,25 +1758,54 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+	if n.pprofSrv != nil {
+		// don't wait for the running CPU profiles and traces
+		if err := n.pprofSrv.Close(); err != nil {
+			n.Logger.Error("pprof HTTP server Close", "err", err)
+		}
+	}
+	if n.profiler != nil && n.profiler.IsRunning() {
+		if err := n.profiler.Stop(); err != nil {
+			n.Logger.Error("Error stopping profiler", "err", err)
+		}
+	}
 	if n.blockStore != nil {
 		if err := n.blockStore.Close(); err != nil {
 			n.Logger.Error("problem closing blockstore", "err", err)
 		}
 	}
+	if n.mempoolJournal != nil {
+		if err := n.mempoolJournal.Close(); err != nil {
+			n.Logger.Error("problem closing the mempool journal", "err", err)
+		}
+	}
 	if n.stateStore != nil {
 		if err := n.stateStore.Close(); err != nil {
 			n.Logger.Error("problem closing statestore", "err", err)
 		}
 	}
+	if n.tracerProvider != nil {
+		// export the pending spans
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := n.tracerProvider.Shutdown(ctx); err != nil {
+			n.Logger.Error("problem shutting down the tracer provider", "err", err)
+		}
+	}
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
 func (n *Node) ConfigureRPC() error {
-	pubKey, err := n.privValidator.GetPubKey()
-	if err != nil {
-		return fmt.Errorf("can't get pubkey: %w", err)
+	var pubKey crypto.PubKey
+	if n.privValidator != nil {
+		var err error
+		pubKey, err = n.privValidator.GetPubKey()
+		if err != nil {
+			return fmt.Errorf("can't get pubkey: %w", err)
+		}
 	}
-	rpccore.SetEnvironment(&rpccore.Environment{
+	fsR, _ := n.bcReactor.(fastSyncSwitcher)
+	env := &rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
 
@@ -1084,13 +1821,21 @@ func (n *Node) ConfigureRPC() error {
 		TxIndexer:        n.txIndexer,
 		BlockIndexer:     n.blockIndexer,
 		ConsensusReactor: n.consensusReactor,
+		FastSyncReactor:  fsR,
+		ConfigReloader:   n,
+		FinalityTracker:  n.finalityTracker,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		TxStatuses:       n.txStatuses,
 
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
-	})
+	}
+	if n.settlementPoster != nil {
+		env.SettlementPoster = n.settlementPoster
+	}
+	rpccore.SetEnvironment(env)
 	if err := rpccore.InitGenesisChunks(); err != nil {
 		return err
 	}
@@ -1238,6 +1983,30 @@ func (n *Node) startPrometheusServer(addr string) *http.Server {
 	return srv
 }
 
+// startPprofServer starts an HTTP server serving the runtime profiles of the
+// node under /debug/pprof/ on addr.
+func (n *Node) startPprofServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	go func() {
+		n.Logger.Info("Starting pprof server", "laddr", addr)
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			// Error starting or closing listener:
+			n.Logger.Error("pprof HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
+
 // Switch returns the Node's Switch.
 func (n *Node) Switch() *p2p.Switch {
 	return n.sw
@@ -1368,6 +2137,10 @@ func makeNodeInfo(
 		},
 	}
 
+	// Seeds only exchange addresses.
+	if config.NodeMode() == cfg.ModeSeed {
+		nodeInfo.Channels = nil
+	}
 	if config.P2P.PexReactor {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
@@ -1451,14 +2224,19 @@ func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) error {
 func createAndStartPrivValidatorSocketClient(
 	listenAddr,
 	chainID string,
+	metrics *privval.Metrics,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
-	pve, err := privval.NewSignerListener(listenAddr, logger)
+	if listenAddrs := splitAndTrimEmpty(listenAddr, ",", " "); len(listenAddrs) > 1 {
+		return createAndStartFailoverSignerClient(listenAddrs, chainID, metrics, logger)
+	}
+
+	pve, err := privval.NewSignerListener(listenAddr, logger, privval.SignerListenerEndpointMetrics(metrics))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
 
-	pvsc, err := privval.NewSignerClient(pve, chainID)
+	pvsc, err := privval.NewSignerClient(pve, chainID, privval.SignerClientMetrics(metrics))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
@@ -1478,6 +2256,85 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+// createAndStartFailoverSignerClient listens for a remote signer on each of
+// the given addresses, failing over between them.
+func createAndStartFailoverSignerClient(
+	listenAddrs []string,
+	chainID string,
+	metrics *privval.Metrics,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	clients := make([]*privval.SignerClient, 0, len(listenAddrs))
+	for _, addr := range listenAddrs {
+		pve, err := privval.NewSignerListener(addr, logger, privval.SignerListenerEndpointMetrics(metrics))
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		pvsc, err := privval.NewSignerClient(pve, chainID, privval.SignerClientMetrics(metrics))
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		clients = append(clients, pvsc)
+	}
+
+	pvfc, err := privval.NewFailoverSignerClient(logger.With("module", "privval"), clients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+	if err := pvfc.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+	return pvfc, nil
+}
+
+func pkcs11Config(config cfg.BaseConfig) privval.PKCS11Config {
+	return privval.PKCS11Config{
+		Module:     config.PrivValidatorPKCS11Module,
+		TokenLabel: config.PrivValidatorPKCS11Token,
+		PIN:        config.PrivValidatorPKCS11PIN,
+		KeyLabel:   config.PrivValidatorPKCS11Key,
+	}
+}
+
+func ledgerConfig(config cfg.BaseConfig) privval.LedgerConfig {
+	return privval.LedgerConfig{
+		Device:         config.PrivValidatorLedgerDevice,
+		HDPath:         config.PrivValidatorLedgerHDPath,
+		ConfirmTimeout: config.PrivValidatorLedgerConfirmTimeout,
+	}
+}
+
+func createThresholdPrivValidator(config cfg.BaseConfig) (types.PrivValidator, error) {
+	share, err := privval.LoadKeyShare(config.PrivValidatorKeyShareFile())
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := privval.NewLocalCosigner(share, config.PrivValidatorCosignerStateFile())
+	if err != nil {
+		return nil, err
+	}
+	cosigners := []privval.Cosigner{local}
+
+	for _, c := range splitAndTrimEmpty(config.PrivValidatorCosigners, ",", " ") {
+		parts := strings.SplitN(c, "@", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid cosigner %q, expected <share ID>@<address>", c)
+		}
+		id, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cosigner share ID %q: %w", parts[0], err)
+		}
+		remote, err := privval.NewRemoteCosigner(id, parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cosigner %q: %w", c, err)
+		}
+		cosigners = append(cosigners, remote)
+	}
+
+	return privval.NewThresholdPV(share, cosigners, config.PrivValidatorStateFile(), privval.DefaultCosignerTimeout)
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -2,32 +2,49 @@ package node
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 
 	dbm "github.com/cometbft/cometbft-db"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abciserver "github.com/tendermint/tendermint/abci/server"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
+	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/finality"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/libs/tracing"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
 	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/conn"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/settlement"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
@@ -77,6 +94,371 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeGracefulStop(t *testing.T) {
+	config := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-blocksSub.Out():
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+
+	require.NoError(t, n.Stop())
+
+	// the RPC server doesn't accept connections anymore
+	_, err = net.DialTimeout("tcp", n.rpcListeners[0].Addr().String(), time.Second)
+	assert.Error(t, err)
+	assert.False(t, n.ConsensusState().IsRunning())
+	assert.False(t, n.Switch().IsRunning())
+	assert.False(t, n.EventBus().IsRunning())
+
+	// the WAL was flushed completely, up to the last height ended
+	f, err := os.Open(config.Consensus.WalFile())
+	require.NoError(t, err)
+	defer f.Close()
+	dec := cs.NewWALDecoder(f)
+	var lastHeight int64
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if m, ok := msg.Msg.(cs.EndHeightMessage); ok {
+			lastHeight = m.Height
+		}
+	}
+	assert.GreaterOrEqual(t, lastHeight, int64(2))
+}
+
+func TestNodeModes(t *testing.T) {
+	config := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	// full nodes don't sign
+	config.Mode = cfg.ModeFull
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Nil(t, n.PrivValidator())
+	assert.Len(t, n.Switch().Reactors(), 6)
+	require.NoError(t, n.Start())
+	assert.True(t, n.ConsensusReactor().IsRunning())
+	require.NoError(t, n.Stop())
+
+	// seeds only run PEX
+	config.Mode = cfg.ModeSeed
+	n, err = DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Nil(t, n.PrivValidator())
+	assert.Len(t, n.Switch().Reactors(), 1)
+	assert.NotNil(t, n.Switch().Reactor("PEX"))
+	assert.Equal(t, []byte{pex.PexChannel}, []byte(n.NodeInfo().(p2p.DefaultNodeInfo).Channels))
+	require.NoError(t, n.Start())
+	assert.False(t, n.ConsensusReactor().IsRunning())
+	assert.False(t, n.ConsensusState().IsRunning())
+	require.NoError(t, n.Stop())
+}
+
+func TestNodeProfiling(t *testing.T) {
+	config := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	config.RPC.PprofListenAddress = addr
+	config.Instrumentation.ProfilingInterval = 50 * time.Millisecond
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	// the profiles are served
+	require.Eventually(t, func() bool {
+		res, err := http.Get("http://" + addr + "/debug/pprof/goroutine?debug=1")
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+		return res.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// and captured continuously
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(config.Instrumentation.ProfilingDir())
+		return err == nil && len(entries) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, n.Stop())
+	_, err = http.Get("http://" + addr + "/debug/pprof/")
+	assert.Error(t, err)
+}
+
+func TestNodeDASubmission(t *testing.T) {
+	config := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	// an Avail light client including every submission in a block of its own
+	var (
+		mtx     sync.Mutex
+		daBlock int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if r.URL.Path == "/v2/submit" {
+			daBlock++
+			fmt.Fprintf(w, `{"block_number":%d,"hash":"0x01"}`, daBlock)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"finished"}`))
+	}))
+	defer srv.Close()
+	config.DA.Layer = cfg.DALayerAvail
+	config.DA.Address = srv.URL
+	config.DA.SubmitInterval = 50 * time.Millisecond
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	require.Eventually(t, func() bool {
+		return n.BlockStore().LoadDAPointer(2) != nil
+	}, 10*time.Second, 50*time.Millisecond)
+	ptr := n.BlockStore().LoadDAPointer(1)
+	require.NotNil(t, ptr)
+	assert.Equal(t, cfg.DALayerAvail, ptr.Layer)
+	assert.Equal(t, []byte{1}, ptr.Commitment)
+}
+
+func TestNodeSettlementPoster(t *testing.T) {
+	config := cfg.ResetTestRoot("node_settlement_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DA.Layer = cfg.DALayerAvail
+	config.Settlement.Hub = cfg.SettlementHubDymension
+	config.Settlement.HubChainID = "dymension_1100-1"
+
+	// the key of the sequencer account is required
+	_, err := createSettlementPoster(config.Settlement, "test-chain", nil, nil, nil, nil, log.TestingLogger())
+	require.Error(t, err)
+
+	key := hex.EncodeToString(cmtrand.Bytes(32))
+	require.NoError(t, os.WriteFile(config.Settlement.KeyFilePath(), []byte(key), 0o600))
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NotNil(t, n.settlementPoster)
+	assert.Equal(t, cfg.SettlementHubDymension, n.settlementPoster.Status().Hub)
+}
+
+func TestNodeFinalityTracker(t *testing.T) {
+	config := cfg.ResetTestRoot("node_finality_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	finalitySub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryFinality)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	// the blocks committed are soft final
+	select {
+	case msg := <-finalitySub.Out():
+		data := msg.Data().(types.EventDataFinality)
+		assert.Positive(t, data.SoftHeight)
+		assert.Zero(t, data.FirmHeight)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the finality of the blocks")
+	}
+	assert.Equal(t, finality.LevelSoft, n.finalityTracker.Heights().Level(1))
+}
+
+func TestNodeSequencerRotation(t *testing.T) {
+	config := cfg.ResetTestRoot("node_rotation_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	// a hub which scheduled the rotation to the sequencer nextPubKey at height 3
+	nextPubKey := ed25519.GenPrivKey().PubKey()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "tx_search" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		event := abci.Event{Type: settlement.EventTypeSequencerRotation, Attributes: []abci.EventAttribute{
+			{Key: []byte("rollapp_id"), Value: []byte(genDoc.ChainID)},
+			{Key: []byte("height"), Value: []byte("3")},
+			{Key: []byte("pub_key"), Value: []byte(base64.StdEncoding.EncodeToString(nextPubKey.Bytes()))},
+		}}
+		res := &ctypes.ResultTxSearch{TotalCount: 1, Txs: []*ctypes.ResultTx{
+			{Height: 5, TxResult: abci.ResponseDeliverTx{Events: []abci.Event{event}}},
+		}}
+		assert.NoError(t, json.NewEncoder(w).Encode(rpctypes.NewRPCSuccessResponse(req.ID, res)))
+	}))
+	defer srv.Close()
+	config.DA.Layer = cfg.DALayerAvail
+	config.Settlement.Hub = cfg.SettlementHubDymension
+	config.Settlement.HubChainID = "dymension_1100-1"
+	config.Settlement.RPCAddress = srv.URL
+	config.Settlement.SequencerRotation = true
+	key := hex.EncodeToString(cmtrand.Bytes(32))
+	require.NoError(t, os.WriteFile(config.Settlement.KeyFilePath(), []byte(key), 0o600))
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NotNil(t, n.sequencerWatcher)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	// the next sequencer is the only validator from height 3
+	require.Eventually(t, func() bool {
+		state, err := n.stateStore.Load()
+		return err == nil && state.LastBlockHeight >= 1
+	}, 10*time.Second, 50*time.Millisecond)
+	vals, err := n.stateStore.LoadValidators(3)
+	require.NoError(t, err)
+	require.Equal(t, 1, vals.Size())
+	assert.Equal(t, nextPubKey.Address(), vals.Validators[0].Address)
+}
+
+func TestNodeForcedInclusion(t *testing.T) {
+	config := cfg.ResetTestRoot("node_forced_inclusion_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	// a hub which force-included forcedTx by height 2, in its block 5 made
+	// at the genesis time
+	forcedTx := types.Tx("forced=1")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.Method == "commit" {
+			header := &types.Header{Height: 5, Time: genDoc.GenesisTime}
+			res := ctypes.NewResultCommit(header, &types.Commit{Height: 5}, true)
+			assert.NoError(t, json.NewEncoder(w).Encode(rpctypes.NewRPCSuccessResponse(req.ID, res)))
+			return
+		}
+		if req.Method != "tx_search" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		event := abci.Event{Type: settlement.EventTypeForcedInclusion, Attributes: []abci.EventAttribute{
+			{Key: []byte("rollapp_id"), Value: []byte(genDoc.ChainID)},
+			{Key: []byte("tx"), Value: []byte(base64.StdEncoding.EncodeToString(forcedTx))},
+			{Key: []byte("deadline"), Value: []byte("2")},
+		}}
+		res := &ctypes.ResultTxSearch{TotalCount: 1, Txs: []*ctypes.ResultTx{
+			{Height: 5, TxResult: abci.ResponseDeliverTx{Events: []abci.Event{event}}},
+		}}
+		assert.NoError(t, json.NewEncoder(w).Encode(rpctypes.NewRPCSuccessResponse(req.ID, res)))
+	}))
+	defer srv.Close()
+	config.DA.Layer = cfg.DALayerAvail
+	config.Settlement.Hub = cfg.SettlementHubDymension
+	config.Settlement.HubChainID = "dymension_1100-1"
+	config.Settlement.RPCAddress = srv.URL
+	config.Settlement.ForcedInclusion = true
+	key := hex.EncodeToString(cmtrand.Bytes(32))
+	require.NoError(t, os.WriteFile(config.Settlement.KeyFilePath(), []byte(key), 0o600))
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NotNil(t, n.forcedTxWatcher)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	// the sequencer proposes the forced tx in its first block
+	require.Eventually(t, func() bool {
+		return n.BlockStore().Height() >= 1
+	}, 10*time.Second, 50*time.Millisecond)
+	block := n.BlockStore().LoadBlock(1)
+	require.NotNil(t, block)
+	assert.Equal(t, types.Txs{forcedTx}, block.Txs)
+}
+
+func TestNodeTracing(t *testing.T) {
+	var (
+		mtx   sync.Mutex
+		names = make(map[string]bool)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []struct {
+						Name string `json:"name"`
+					} `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					names[span.Name] = true
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+	defer tracing.Install(trace.NewNoopTracerProvider())
+
+	config := cfg.ResetTestRoot("node_tracing_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Instrumentation.Tracing = true
+	config.Instrumentation.TracingEndpoint = srv.URL + "/v1/traces"
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-blocksSub.Out():
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+
+	// the pending spans are exported when the node stops
+	require.NoError(t, n.Stop())
+	mtx.Lock()
+	defer mtx.Unlock()
+	for _, name := range []string{
+		"consensus.height",
+		"consensus.finalize_commit",
+		"blockstore.save_block",
+		"state.apply_block",
+		"abci.begin_block",
+		"abci.end_block",
+		"abci.commit",
+		"mempool.update",
+	} {
+		assert.True(t, names[name], "span %s was not exported", name)
+	}
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -220,6 +602,68 @@ func testFreeAddr(t *testing.T) string {
 	return fmt.Sprintf("127.0.0.1:%d", ln.Addr().(*net.TCPAddr).Port)
 }
 
+func TestRestoreMempool(t *testing.T) {
+	config := cfg.ResetTestRoot("node_restore_mempool")
+	defer os.RemoveAll(config.RootDir)
+	config.Mempool.Persist = true
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+	state, _, _ := state(1, 1)
+
+	for _, version := range []string{cfg.MempoolV0, cfg.MempoolV1} {
+		config.Mempool.Version = version
+		require.NoError(t, os.RemoveAll(config.Mempool.JournalFile()))
+		start := func() (mempl.Mempool, *mempl.Journal) {
+			journal, txs, err := loadMempoolJournal(config.Mempool, log.TestingLogger())
+			require.NoError(t, err)
+			mempool, _ := createMempoolAndMempoolReactor(config, proxyApp, state, mempl.NopMetrics(),
+				types.NewEventBus(), journal, nil, log.TestingLogger())
+			restoreMempool(mempool, txs, log.TestingLogger())
+			return mempool, journal
+		}
+
+		mempool, journal := start()
+		assert.Zero(t, mempool.Size(), version)
+		txs := types.Txs{}
+		for i := 0; i < 4; i++ {
+			txs = append(txs, types.Tx(fmt.Sprintf("%s-%d=%d", version, i, i)))
+			require.NoError(t, mempool.CheckTx(txs[i], nil, mempl.TxInfo{}))
+		}
+		mempool.Lock()
+		err := mempool.Update(2, txs[:3], []*abci.ResponseDeliverTx{{}, {}, {}}, nil, nil)
+		mempool.Unlock()
+		require.NoError(t, err)
+		require.NoError(t, journal.Close())
+
+		// the txs left in the mempool are added back on restart
+		mempool, journal = start()
+		assert.Equal(t, txs[3:], mempool.ReapMaxTxs(-1), version)
+		require.NoError(t, journal.Close())
+	}
+}
+
+func TestCreateMempoolVersions(t *testing.T) {
+	config := cfg.ResetTestRoot("node_create_mempool")
+	defer os.RemoveAll(config.RootDir)
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+	state, _, _ := state(1, 1)
+
+	for version, expected := range map[string]mempl.Mempool{
+		"":                  &mempoolv0.CListMempool{},
+		cfg.MempoolV0:       &mempoolv0.CListMempool{},
+		cfg.MempoolV1:       &mempoolv1.TxMempool{},
+		cfg.MempoolPriority: &mempoolv1.TxMempool{},
+	} {
+		config.Mempool.Version = version
+		mempool, _ := createMempoolAndMempoolReactor(config, proxyApp, state, mempl.NopMetrics(),
+			types.NewEventBus(), nil, nil, log.TestingLogger())
+		assert.IsType(t, expected, mempool, version)
+	}
+}
+
 // create a proposal block using real and full
 // mempool and evidence pool and validate it.
 func TestCreateProposalBlock(t *testing.T) {
@@ -484,3 +928,112 @@ func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	}
 	return s, stateDB, privVals
 }
+
+func TestNodeReloadConfig(t *testing.T) {
+	config := cfg.ResetTestRoot("node_reload_config_test")
+	defer os.RemoveAll(config.RootDir)
+	config.TxIndex.RetainBlocks = 100
+
+	n, err := DefaultNewNode(config, log.NewSwappableLogger(log.TestingLogger()))
+	require.NoError(t, err)
+
+	_, err = n.ReloadConfig()
+	require.Error(t, err)
+
+	// the loader returns a copy of the configuration, with the changes
+	var changes func(*cfg.Config)
+	n.SetConfigLoader(func() (*cfg.Config, error) {
+		newConfig := *config
+		p2pConfig, mempoolConfig, txIndexConfig := *config.P2P, *config.Mempool, *config.TxIndex
+		newConfig.P2P, newConfig.Mempool, newConfig.TxIndex = &p2pConfig, &mempoolConfig, &txIndexConfig
+		changes(&newConfig)
+		return &newConfig, nil
+	})
+
+	changes = func(c *cfg.Config) {
+		c.LogLevel = "debug"
+		c.Mempool.Size = 10
+		c.P2P.SendRate = 1000
+		c.TxIndex.PruneInterval = time.Hour
+		c.Moniker = "reloaded"
+	}
+	changed, err := n.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log_level", "mempool.size", "p2p.send_rate", "tx_index.prune-interval"}, changed)
+	assert.Equal(t, "debug", config.LogLevel)
+	assert.Equal(t, 10, config.Mempool.Size)
+	assert.EqualValues(t, 1000, config.P2P.SendRate)
+	assert.Equal(t, time.Hour, n.indexerService.Pruner().Interval())
+	// the other settings take effect when the node restarts
+	assert.NotEqual(t, "reloaded", config.Moniker)
+
+	// nothing is applied if the configuration is invalid
+	changes = func(c *cfg.Config) {
+		c.LogLevel = "verbose"
+		c.Mempool.Size = 20
+	}
+	_, err = n.ReloadConfig()
+	require.Error(t, err)
+	assert.Equal(t, "debug", config.LogLevel)
+	assert.Equal(t, 10, config.Mempool.Size)
+}
+
+// blockingApp blocks CheckTx until released.
+type blockingApp struct {
+	abci.Application
+	release chan struct{}
+}
+
+func (app blockingApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	<-app.release
+	return app.Application.CheckTx(req)
+}
+
+func TestNodeReloadConfigDuringCheckTx(t *testing.T) {
+	config := cfg.ResetTestRoot("node_reload_config_check_tx_test")
+	defer os.RemoveAll(config.RootDir)
+
+	// the responses of a remote app are handled by the callbacks outside of
+	// the mempool lock, after the reloads below
+	app := blockingApp{Application: kvstore.NewApplication(), release: make(chan struct{})}
+	config.ProxyApp = fmt.Sprintf("unix:///tmp/reload_%v.sock", cmtrand.Str(6))
+	server := abciserver.NewSocketServer(config.ProxyApp, app)
+	server.SetLogger(log.TestingLogger())
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := n.ProxyApp().Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var size int
+	n.SetConfigLoader(func() (*cfg.Config, error) {
+		newConfig := *config
+		mempoolConfig := *config.Mempool
+		newConfig.Mempool = &mempoolConfig
+		newConfig.Mempool.Size = size
+		return &newConfig, nil
+	})
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, n.Mempool().CheckTx([]byte(fmt.Sprintf("tx%d", i)), nil, mempl.TxInfo{}))
+	}
+	for size = 100; size > 5; size-- {
+		_, err := n.ReloadConfig()
+		require.NoError(t, err)
+	}
+	close(app.release)
+	require.NoError(t, n.Mempool().FlushAppConn())
+
+	// the txs are checked against the reloaded limits
+	assert.Equal(t, 6, config.Mempool.Size)
+	assert.Equal(t, 6, n.Mempool().Size())
+}
@@ -0,0 +1,158 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cmtflags "github.com/tendermint/tendermint/libs/cli/flags"
+	"github.com/tendermint/tendermint/libs/log"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+)
+
+// mempoolLimiter is implemented by the mempools whose limits can change at
+// runtime.
+type mempoolLimiter interface {
+	SetLimits(size int, maxTxsBytes int64)
+}
+
+// SetConfigLoader sets the function loading the configuration, from the same
+// sources as when the node was created, for ReloadConfig.
+func (n *Node) SetConfigLoader(load func() (*cfg.Config, error)) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	n.configLoader = load
+}
+
+// ReloadConfig loads the configuration with the function set by
+// SetConfigLoader, and applies the settings which can change at runtime:
+//
+//   - log_level and log_format, if the node logs with a log.SwappableLogger
+//   - rpc.max_subscription_clients and rpc.max_subscriptions_per_client
+//   - mempool.size and mempool.max_txs_bytes
+//   - p2p.send_rate and p2p.recv_rate, for the current and new peers
+//   - tx_index.prune-interval
+//
+// The other settings take effect when the node restarts. It returns the
+// settings which changed. Nothing is applied if the configuration is invalid.
+func (n *Node) ReloadConfig() ([]string, error) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+
+	if n.configLoader == nil {
+		return nil, errors.New("no configuration loader is set")
+	}
+	config, err := n.configLoader()
+	if err != nil {
+		return nil, fmt.Errorf("loading the configuration: %w", err)
+	}
+	changed, err := n.applyConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	n.Logger.Info("Reloaded the configuration", "changed", changed)
+	if !reflect.DeepEqual(n.config, config) {
+		n.Logger.Info("Some changed settings take effect when the node restarts")
+	}
+	return changed, nil
+}
+
+// applyConfig applies the settings of config which can change at runtime,
+// and returns those which changed.
+func (n *Node) applyConfig(config *cfg.Config) ([]string, error) {
+	if err := config.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in config file: %w", err)
+	}
+
+	// the logger is built first, so that nothing is applied if it fails
+	var logger log.Logger
+	logChanged := config.LogLevel != n.config.LogLevel || config.LogFormat != n.config.LogFormat
+	if logChanged && n.swappableLogger != nil {
+		var err error
+		if logger, err = newLogger(config); err != nil {
+			return nil, err
+		}
+	}
+
+	var changed []string
+	if logChanged && logger == nil {
+		n.Logger.Info("The logger of the node can't be swapped, the log settings take effect when the node restarts")
+	} else if logChanged {
+		n.swappableLogger.Swap(logger)
+		if config.LogLevel != n.config.LogLevel {
+			changed = append(changed, "log_level")
+		}
+		if config.LogFormat != n.config.LogFormat {
+			changed = append(changed, "log_format")
+		}
+		n.config.LogLevel = config.LogLevel
+		n.config.LogFormat = config.LogFormat
+	}
+
+	if rpc := config.RPC; rpc.MaxSubscriptionClients != n.config.RPC.MaxSubscriptionClients ||
+		rpc.MaxSubscriptionsPerClient != n.config.RPC.MaxSubscriptionsPerClient {
+		rpccore.SetSubscriptionLimits(rpc.MaxSubscriptionClients, rpc.MaxSubscriptionsPerClient)
+		if rpc.MaxSubscriptionClients != n.config.RPC.MaxSubscriptionClients {
+			changed = append(changed, "rpc.max_subscription_clients")
+		}
+		if rpc.MaxSubscriptionsPerClient != n.config.RPC.MaxSubscriptionsPerClient {
+			changed = append(changed, "rpc.max_subscriptions_per_client")
+		}
+		n.config.RPC.MaxSubscriptionClients = rpc.MaxSubscriptionClients
+		n.config.RPC.MaxSubscriptionsPerClient = rpc.MaxSubscriptionsPerClient
+	}
+
+	if mem := config.Mempool; mem.Size != n.config.Mempool.Size || mem.MaxTxsBytes != n.config.Mempool.MaxTxsBytes {
+		if limiter, ok := n.mempool.(mempoolLimiter); ok {
+			if mem.Size != n.config.Mempool.Size {
+				changed = append(changed, "mempool.size")
+			}
+			if mem.MaxTxsBytes != n.config.Mempool.MaxTxsBytes {
+				changed = append(changed, "mempool.max_txs_bytes")
+			}
+			limiter.SetLimits(mem.Size, mem.MaxTxsBytes)
+			// the mempool reads its limits set above, not these settings
+			n.config.Mempool.Size = mem.Size
+			n.config.Mempool.MaxTxsBytes = mem.MaxTxsBytes
+		}
+	}
+
+	if p2p := config.P2P; p2p.SendRate != n.config.P2P.SendRate || p2p.RecvRate != n.config.P2P.RecvRate {
+		n.transport.SetRates(p2p.SendRate, p2p.RecvRate)
+		n.sw.SetRates(p2p.SendRate, p2p.RecvRate)
+		if p2p.SendRate != n.config.P2P.SendRate {
+			changed = append(changed, "p2p.send_rate")
+		}
+		if p2p.RecvRate != n.config.P2P.RecvRate {
+			changed = append(changed, "p2p.recv_rate")
+		}
+		n.config.P2P.SendRate = p2p.SendRate
+		n.config.P2P.RecvRate = p2p.RecvRate
+	}
+
+	if interval := config.TxIndex.PruneInterval; interval != n.config.TxIndex.PruneInterval {
+		if n.indexerService != nil && n.indexerService.Pruner() != nil {
+			n.indexerService.Pruner().SetInterval(interval)
+		}
+		changed = append(changed, "tx_index.prune-interval")
+		n.config.TxIndex.PruneInterval = interval
+	}
+
+	return changed, nil
+}
+
+// newLogger returns a logger writing to the standard output with the log
+// format and level of config.
+func newLogger(config *cfg.Config) (log.Logger, error) {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	if config.LogFormat == cfg.LogFormatJSON {
+		logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+	}
+	logger, err := cmtflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the log level: %w", err)
+	}
+	return logger, nil
+}
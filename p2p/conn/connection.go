@@ -216,6 +216,13 @@ func (c *MConnection) SetLogger(l log.Logger) {
 	}
 }
 
+// SetRates sets the maximum send and receive rates of the connection, in bytes
+// per second.
+func (c *MConnection) SetRates(sendRate, recvRate int64) {
+	atomic.StoreInt64(&c.config.SendRate, sendRate)
+	atomic.StoreInt64(&c.config.RecvRate, recvRate)
+}
+
 // OnStart implements BaseService
 func (c *MConnection) OnStart() error {
 	if err := c.BaseService.OnStart(); err != nil {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/sr25519"
 	"github.com/tendermint/tendermint/libs/async"
 	cmtos "github.com/tendermint/tendermint/libs/os"
@@ -272,18 +273,40 @@ func TestNilPubkey(t *testing.T) {
 	assert.Equal(t, "toproto: key type <nil> is not supported", err.Error())
 }
 
-func TestNonEd25519Pubkey(t *testing.T) {
+func TestSr25519Pubkey(t *testing.T) {
 	var fooConn, barConn = makeKVStoreConnPair()
 	defer fooConn.Close()
 	defer barConn.Close()
 	var fooPrvKey = ed25519.GenPrivKey()
 	var barPrvKey = sr25519.GenPrivKey()
 
-	go MakeSecretConnection(fooConn, fooPrvKey) //nolint:errcheck // ignore for tests
+	errc := make(chan error, 1)
+	go func() {
+		fooSecConn, err := MakeSecretConnection(fooConn, fooPrvKey)
+		if err == nil && !fooSecConn.RemotePubKey().Equals(barPrvKey.PubKey()) {
+			err = fmt.Errorf("unexpected remote pubkey %v", fooSecConn.RemotePubKey())
+		}
+		errc <- err
+	}()
 
-	_, err := MakeSecretConnection(barConn, barPrvKey)
+	barSecConn, err := MakeSecretConnection(barConn, barPrvKey)
+	require.NoError(t, err)
+	assert.True(t, barSecConn.RemotePubKey().Equals(fooPrvKey.PubKey()))
+	require.NoError(t, <-errc)
+}
+
+func TestUnsupportedPubkey(t *testing.T) {
+	var fooConn, barConn = makeKVStoreConnPair()
+	defer fooConn.Close()
+	defer barConn.Close()
+	var fooPrvKey = ed25519.GenPrivKey()
+	var barPrvKey = secp256k1.GenPrivKey()
+
+	go MakeSecretConnection(barConn, barPrvKey) //nolint:errcheck // ignore for tests
+
+	_, err := MakeSecretConnection(fooConn, fooPrvKey)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "is not supported")
+	assert.Contains(t, err.Error(), "expected ed25519 or sr25519 pubkey")
 }
 
 func writeLots(t *testing.T, wg *sync.WaitGroup, conn io.Writer, txt string, n int) {
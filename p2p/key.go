@@ -8,6 +8,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 )
@@ -46,8 +47,15 @@ func PubKeyToID(pubKey crypto.PubKey) ID {
 }
 
 // LoadOrGenNodeKey attempts to load the NodeKey from the given filePath. If
-// the file does not exist, it generates and saves a new NodeKey.
+// the file does not exist, it generates and saves a new ed25519 NodeKey.
 func LoadOrGenNodeKey(filePath string) (*NodeKey, error) {
+	return LoadOrGenNodeKeyWithKeyType(filePath, ed25519.KeyType)
+}
+
+// LoadOrGenNodeKeyWithKeyType attempts to load the NodeKey from the given
+// filePath. If the file does not exist, it generates and saves a new NodeKey
+// of the given type (ed25519 or sr25519).
+func LoadOrGenNodeKeyWithKeyType(filePath, keyType string) (*NodeKey, error) {
 	if cmtos.FileExists(filePath) {
 		nodeKey, err := LoadNodeKey(filePath)
 		if err != nil {
@@ -56,7 +64,15 @@ func LoadOrGenNodeKey(filePath string) (*NodeKey, error) {
 		return nodeKey, nil
 	}
 
-	privKey := ed25519.GenPrivKey()
+	var privKey crypto.PrivKey
+	switch keyType {
+	case "", ed25519.KeyType:
+		privKey = ed25519.GenPrivKey()
+	case sr25519.KeyType:
+		privKey = sr25519.GenPrivKey()
+	default:
+		return nil, fmt.Errorf("unsupported node key type %q", keyType)
+	}
 	nodeKey := &NodeKey{
 		PrivKey: privKey,
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 )
 
@@ -25,6 +26,21 @@ func TestLoadOrGenNodeKey(t *testing.T) {
 	assert.Equal(t, nodeKey, nodeKey2)
 }
 
+func TestLoadOrGenNodeKeyWithKeyType(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "node_key.json")
+
+	nodeKey, err := LoadOrGenNodeKeyWithKeyType(filePath, sr25519.KeyType)
+	require.NoError(t, err)
+	assert.Equal(t, sr25519.KeyType, nodeKey.PubKey().Type())
+
+	nodeKey2, err := LoadNodeKey(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID(), nodeKey2.ID())
+
+	_, err = LoadOrGenNodeKeyWithKeyType(filepath.Join(t.TempDir(), "node_key.json"), "secp256k1")
+	assert.Error(t, err)
+}
+
 func TestLoadNodeKey(t *testing.T) {
 	filePath := filepath.Join(os.TempDir(), cmtrand.Str(12)+"_peer_id.json")
 
@@ -250,9 +250,12 @@ func (sw *Switch) OnStop() {
 		sw.stopAndRemovePeer(p, nil)
 	}
 
-	// Stop reactors
+	// Stop reactors, unless the node stopped them already
 	sw.Logger.Debug("Switch: Stopping reactors")
 	for _, reactor := range sw.reactors {
+		if !reactor.IsRunning() {
+			continue
+		}
 		if err := reactor.Stop(); err != nil {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
@@ -361,6 +364,17 @@ func (sw *Switch) Peers() IPeerSet {
 	return sw.peers
 }
 
+// SetRates sets the maximum send and receive rates, in bytes per second, of the
+// connections to the connected peers. The rates of the connections to the
+// new peers are set by the transport.
+func (sw *Switch) SetRates(sendRate, recvRate int64) {
+	for _, p := range sw.peers.List() {
+		if p, ok := p.(*peer); ok {
+			p.mconn.SetRates(sendRate, recvRate)
+		}
+	}
+}
+
 // StopPeerForError disconnects from a peer due to external error.
 // If the peer is persistent, it will attempt to reconnect.
 // TODO: make record depending on reason.
@@ -11,6 +11,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/protoio"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 )
@@ -157,7 +158,8 @@ type MultiplexTransport struct {
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
 	// with sane defaults.
-	mConfig conn.MConnConfig
+	mConfigMtx cmtsync.Mutex
+	mConfig    conn.MConnConfig
 }
 
 // Test multiplexTransport for interface completeness.
@@ -491,6 +493,15 @@ func (mt *MultiplexTransport) upgrade(
 	return secretConn, nodeInfo, nil
 }
 
+// SetRates sets the maximum send and receive rates, in bytes per second, of the
+// connections to the peers accepted or dialed from now on.
+func (mt *MultiplexTransport) SetRates(sendRate, recvRate int64) {
+	mt.mConfigMtx.Lock()
+	defer mt.mConfigMtx.Unlock()
+	mt.mConfig.SendRate = sendRate
+	mt.mConfig.RecvRate = recvRate
+}
+
 func (mt *MultiplexTransport) wrapPeer(
 	c net.Conn,
 	ni NodeInfo,
@@ -517,9 +528,13 @@ func (mt *MultiplexTransport) wrapPeer(
 		socketAddr,
 	)
 
+	mt.mConfigMtx.Lock()
+	mConfig := mt.mConfig
+	mt.mConfigMtx.Unlock()
+
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		mConfig,
 		ni,
 		cfg.reactorsByCh,
 		cfg.msgTypeByChID,
@@ -0,0 +1,279 @@
+package privval
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+// AuditLogEntry records a message signed by the private validator.
+//
+// Every entry contains the hash of the previous one, so that any modification,
+// insertion or removal of an entry other than the last ones breaks the chain
+// of hashes.
+type AuditLogEntry struct {
+	Index         int64                  `json:"index"`
+	Type          cmtproto.SignedMsgType `json:"type"`
+	ChainID       string                 `json:"chain_id"`
+	Height        int64                  `json:"height"`
+	Round         int32                  `json:"round"`
+	SignBytesHash cmtbytes.HexBytes      `json:"sign_bytes_hash"`
+	Timestamp     time.Time              `json:"timestamp"`
+	PrevHash      cmtbytes.HexBytes      `json:"prev_hash"`
+	Hash          cmtbytes.HexBytes      `json:"hash"`
+}
+
+// ComputeHash returns the hash of the entry, computed over all its fields but
+// Hash.
+func (e AuditLogEntry) ComputeHash() []byte {
+	var buf bytes.Buffer
+	writeBytes := func(bz []byte) {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(bz)))
+		buf.Write(bz)
+	}
+	writeBytes(e.PrevHash)
+	_ = binary.Write(&buf, binary.BigEndian, e.Index)
+	_ = binary.Write(&buf, binary.BigEndian, int32(e.Type))
+	writeBytes([]byte(e.ChainID))
+	_ = binary.Write(&buf, binary.BigEndian, e.Height)
+	_ = binary.Write(&buf, binary.BigEndian, e.Round)
+	writeBytes(e.SignBytesHash)
+	_ = binary.Write(&buf, binary.BigEndian, e.Timestamp.UnixNano())
+	return tmhash.Sum(buf.Bytes())
+}
+
+// AuditLog is an append-only, hash-chained log of signed messages, stored as
+// one JSON entry per line.
+type AuditLog struct {
+	mtx      cmtsync.Mutex
+	file     *os.File
+	nextIdx  int64
+	lastHash []byte
+}
+
+// OpenAuditLog opens the audit log at filePath, creating it if it does not
+// exist. It returns an error if the existing entries do not verify.
+func OpenAuditLog(filePath string) (*AuditLog, error) {
+	var entries []AuditLogEntry
+	if _, err := os.Stat(filePath); err == nil {
+		if entries, err = VerifyAuditLog(filePath); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	al := &AuditLog{file: file}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		al.nextIdx, al.lastHash = last.Index+1, last.Hash
+	}
+	return al, nil
+}
+
+// Append records a message signed for chainID, and syncs the log to disk.
+func (al *AuditLog) Append(
+	msgType cmtproto.SignedMsgType,
+	chainID string,
+	height int64,
+	round int32,
+	signBytes []byte,
+) error {
+	al.mtx.Lock()
+	defer al.mtx.Unlock()
+
+	entry := AuditLogEntry{
+		Index:         al.nextIdx,
+		Type:          msgType,
+		ChainID:       chainID,
+		Height:        height,
+		Round:         round,
+		SignBytesHash: tmhash.Sum(signBytes),
+		Timestamp:     cmttime.Now(),
+		PrevHash:      al.lastHash,
+	}
+	entry.Hash = entry.ComputeHash()
+
+	bz, err := cmtjson.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := al.file.Write(append(bz, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := al.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+	al.nextIdx, al.lastHash = entry.Index+1, entry.Hash
+	return nil
+}
+
+// Close closes the audit log file.
+func (al *AuditLog) Close() error {
+	al.mtx.Lock()
+	defer al.mtx.Unlock()
+	return al.file.Close()
+}
+
+// VerifyAuditLog reads the audit log at filePath and checks its chain of
+// hashes. It returns the entries, or an error describing the first entry
+// which does not verify.
+func VerifyAuditLog(filePath string) ([]AuditLogEntry, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		entries  []AuditLogEntry
+		prevHash []byte
+		scanner  = bufio.NewScanner(file)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		var entry AuditLogEntry
+		if err := cmtjson.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return entries, fmt.Errorf("line %d: invalid entry: %w", line, err)
+		}
+		switch {
+		case entry.Index != int64(len(entries)):
+			return entries, fmt.Errorf("line %d: expected index %d, got %d", line, len(entries), entry.Index)
+		case !bytes.Equal(entry.PrevHash, prevHash):
+			return entries, fmt.Errorf("line %d: previous hash %X does not match the hash of the previous entry %X",
+				line, entry.PrevHash, prevHash)
+		case !bytes.Equal(entry.Hash, entry.ComputeHash()):
+			return entries, fmt.Errorf("line %d: hash %X does not match the content of the entry", line, entry.Hash)
+		}
+		entries = append(entries, entry)
+		prevHash = entry.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, err
+	}
+	return entries, nil
+}
+
+// FindAuditLogConflicts returns the pairs of entries of the same type, chain,
+// height and round, which signed different messages. Each pair indicates a
+// possible equivocation.
+func FindAuditLogConflicts(entries []AuditLogEntry) [][2]AuditLogEntry {
+	type hrs struct {
+		msgType cmtproto.SignedMsgType
+		chainID string
+		height  int64
+		round   int32
+	}
+	var (
+		conflicts [][2]AuditLogEntry
+		first     = make(map[hrs]AuditLogEntry)
+	)
+	for _, e := range entries {
+		key := hrs{e.Type, e.ChainID, e.Height, e.Round}
+		prev, ok := first[key]
+		if !ok {
+			first[key] = e
+			continue
+		}
+		if !bytes.Equal(prev.SignBytesHash, e.SignBytesHash) {
+			conflicts = append(conflicts, [2]AuditLogEntry{prev, e})
+		}
+	}
+	return conflicts
+}
+
+//-------------------------------------------------------
+
+// AuditLogPV wraps a PrivValidator, and records every message it signs in an
+// AuditLog. A message is only returned signed once it has been recorded.
+type AuditLogPV struct {
+	pv  types.PrivValidator
+	log *AuditLog
+}
+
+var _ types.KeyRotatingPrivValidator = (*AuditLogPV)(nil)
+
+// NewAuditLogPV returns an AuditLogPV wrapping pv, which records the messages
+// it signs in the audit log at filePath.
+func NewAuditLogPV(pv types.PrivValidator, filePath string) (*AuditLogPV, error) {
+	log, err := OpenAuditLog(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &AuditLogPV{pv: pv, log: log}, nil
+}
+
+// GetPubKey returns the public key of the wrapped PrivValidator.
+// Implements PrivValidator.
+func (a *AuditLogPV) GetPubKey() (crypto.PubKey, error) {
+	return a.pv.GetPubKey()
+}
+
+// SignVote signs the vote with the wrapped PrivValidator, and records it.
+// Implements PrivValidator.
+func (a *AuditLogPV) SignVote(chainID string, vote *cmtproto.Vote) error {
+	if err := a.pv.SignVote(chainID, vote); err != nil {
+		return err
+	}
+	if err := a.log.Append(vote.Type, chainID, vote.Height, vote.Round, types.VoteSignBytes(chainID, vote)); err != nil {
+		vote.Signature = nil
+		return err
+	}
+	return nil
+}
+
+// SignProposal signs the proposal with the wrapped PrivValidator, and records
+// it. Implements PrivValidator.
+func (a *AuditLogPV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	if err := a.pv.SignProposal(chainID, proposal); err != nil {
+		return err
+	}
+	if err := a.log.Append(cmtproto.ProposalType, chainID, proposal.Height, proposal.Round,
+		types.ProposalSignBytes(chainID, proposal)); err != nil {
+		proposal.Signature = nil
+		return err
+	}
+	return nil
+}
+
+// NextPubKey returns the next public key of the wrapped PrivValidator, if it
+// supports key rotation. Implements KeyRotatingPrivValidator.
+func (a *AuditLogPV) NextPubKey() (crypto.PubKey, error) {
+	if pv, ok := a.pv.(types.KeyRotatingPrivValidator); ok {
+		return pv.NextPubKey()
+	}
+	return nil, nil
+}
+
+// RotateKey rotates the key of the wrapped PrivValidator, if it supports key
+// rotation. Implements KeyRotatingPrivValidator.
+func (a *AuditLogPV) RotateKey() error {
+	if pv, ok := a.pv.(types.KeyRotatingPrivValidator); ok {
+		return pv.RotateKey()
+	}
+	return errors.New("private validator does not support key rotation")
+}
+
+// Close closes the audit log.
+func (a *AuditLogPV) Close() error {
+	return a.log.Close()
+}
+
+// String returns a string representation of the AuditLogPV.
+func (a *AuditLogPV) String() string {
+	return fmt.Sprintf("AuditLogPV{%v}", a.pv)
+}
@@ -0,0 +1,97 @@
+package privval
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestAuditLogPV(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+
+	pv, err := NewAuditLogPV(types.NewMockPV(), logFile)
+	require.NoError(t, err)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	vote := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, pv.SignVote(chainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+	require.NoError(t, pv.SignProposal(chainID, newProposal(1, 0, blockID).ToProto()))
+	require.NoError(t, pv.Close())
+
+	// the chain continues after a restart
+	pv, err = NewAuditLogPV(pv.pv, logFile)
+	require.NoError(t, err)
+	require.NoError(t, pv.SignVote(chainID, newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrecommitType,
+		blockID).ToProto()))
+	require.NoError(t, pv.Close())
+
+	entries, err := VerifyAuditLog(logFile)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, cmtproto.PrevoteType, entries[0].Type)
+	assert.Equal(t, cmtproto.ProposalType, entries[1].Type)
+	assert.EqualValues(t, 2, entries[2].Index)
+	assert.Equal(t, tmhash.Sum(types.VoteSignBytes(chainID, vote)), entries[0].SignBytesHash.Bytes())
+	assert.Empty(t, FindAuditLogConflicts(entries))
+}
+
+func TestAuditLogTampering(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	log, err := OpenAuditLog(logFile)
+	require.NoError(t, err)
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, log.Append(cmtproto.PrevoteType, "mychainid", height, 0, cmtrand.Bytes(32)))
+	}
+	require.NoError(t, log.Close())
+
+	bz, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := bytes.SplitAfter(bz, []byte("\n"))
+
+	// modifying an entry
+	tampered := bytes.Replace(bz, []byte(`"height":"2"`), []byte(`"height":"4"`), 1)
+	require.NoError(t, os.WriteFile(logFile, tampered, 0o600))
+	_, err = VerifyAuditLog(logFile)
+	assert.ErrorContains(t, err, "line 2")
+	_, err = OpenAuditLog(logFile)
+	assert.Error(t, err)
+
+	// removing an entry
+	require.NoError(t, os.WriteFile(logFile, append(append([]byte{}, lines[0]...), lines[2]...), 0o600))
+	entries, err := VerifyAuditLog(logFile)
+	assert.ErrorContains(t, err, "line 2")
+	assert.Len(t, entries, 1)
+}
+
+func TestFindAuditLogConflicts(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	log, err := OpenAuditLog(logFile)
+	require.NoError(t, err)
+	signBytes := cmtrand.Bytes(32)
+	require.NoError(t, log.Append(cmtproto.PrecommitType, "mychainid", 1, 0, signBytes))
+	// signing the same message again is fine
+	require.NoError(t, log.Append(cmtproto.PrecommitType, "mychainid", 1, 0, signBytes))
+	require.NoError(t, log.Append(cmtproto.PrecommitType, "mychainid", 1, 1, cmtrand.Bytes(32)))
+	require.NoError(t, log.Append(cmtproto.PrecommitType, "mychainid", 1, 0, cmtrand.Bytes(32)))
+	require.NoError(t, log.Close())
+
+	entries, err := VerifyAuditLog(logFile)
+	require.NoError(t, err)
+	conflicts := FindAuditLogConflicts(entries)
+	require.Len(t, conflicts, 1)
+	assert.EqualValues(t, 0, conflicts[0][0].Index)
+	assert.EqualValues(t, 3, conflicts[0][1].Index)
+}
@@ -0,0 +1,406 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DefaultSignerHealthCheckInterval is the default interval between two health
+// checks of the remote signers of a FailoverSignerClient.
+const DefaultSignerHealthCheckInterval = 2 * time.Second
+
+// ErrNoHealthySigner is returned by FailoverSignerClient when none of its
+// remote signers can take over as the leader.
+var ErrNoHealthySigner = errors.New("no healthy remote signer")
+
+// FailoverSignerClientOption sets an optional parameter on the FailoverSignerClient.
+type FailoverSignerClientOption func(*FailoverSignerClient)
+
+// FailoverSignerClientHealthCheckInterval sets the interval between two health
+// checks of the remote signers.
+//
+// Default: 2s
+func FailoverSignerClientHealthCheckInterval(interval time.Duration) FailoverSignerClientOption {
+	return func(fc *FailoverSignerClient) { fc.healthCheckInterval = interval }
+}
+
+// FailoverSignerClient implements PrivValidator on top of several remote
+// signers holding the same key, so that the failure of a signer host doesn't
+// take the validator offline.
+//
+// Only one signer, the leader, is sent sign requests at any time. The signers
+// are health checked periodically, and if the leader fails, either during a
+// health check or while signing, the first healthy signer (in the order they
+// were given) takes over. Before it does, it must complete a handshake: it must
+// serve the validator's public key, and it is sent the last message signed by
+// the previous leader again, which brings its own last sign state up to date.
+//
+// On top of that, the client refuses to send a request which conflicts with the
+// last message signed by any of the signers, so a failover cannot cause a
+// double sign even if the signers do not share their state.
+type FailoverSignerClient struct {
+	service.BaseService
+
+	clients             []*SignerClient
+	healthCheckInterval time.Duration
+
+	mtx     cmtsync.Mutex
+	healthy []bool
+	leader  int // index of the leader in clients, -1 if there is none
+	pubKey  crypto.PubKey
+	last    *signedMsg
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// signedMsg is the last vote or proposal signed through the client, along with
+// its height, round, step and sign bytes.
+type signedMsg struct {
+	chainID   string
+	height    int64
+	round     int32
+	step      int8
+	signBytes []byte
+	vote      *cmtproto.Vote
+	proposal  *cmtproto.Proposal
+}
+
+// NewFailoverSignerClient returns a FailoverSignerClient for the given signer
+// clients, in order of preference. The client must be started before use.
+func NewFailoverSignerClient(
+	logger log.Logger,
+	clients []*SignerClient,
+	options ...FailoverSignerClientOption,
+) (*FailoverSignerClient, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no signer clients")
+	}
+	fc := &FailoverSignerClient{
+		clients:             clients,
+		healthCheckInterval: DefaultSignerHealthCheckInterval,
+		healthy:             make([]bool, len(clients)),
+		leader:              -1,
+	}
+	fc.BaseService = *service.NewBaseService(logger, "FailoverSignerClient", fc)
+
+	for _, optionFunc := range options {
+		optionFunc(fc)
+	}
+
+	return fc, nil
+}
+
+// OnStart implements service.Service. It retrieves the public key from all the
+// signers, which must agree on it, and elects the first one to respond as the
+// leader.
+func (fc *FailoverSignerClient) OnStart() error {
+	pubKeys := make([]crypto.PubKey, len(fc.clients))
+	errs := make([]error, len(fc.clients))
+	var wg sync.WaitGroup
+	for i, c := range fc.clients {
+		wg.Add(1)
+		go func(i int, c *SignerClient) {
+			defer wg.Done()
+			pubKeys[i], errs[i] = c.GetPubKey()
+		}(i, c)
+	}
+	wg.Wait()
+
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	for i, pubKey := range pubKeys {
+		if errs[i] != nil {
+			fc.Logger.Error("Remote signer is unavailable", "signer", i, "err", errs[i])
+			continue
+		}
+		if fc.pubKey == nil {
+			fc.pubKey = pubKey
+			fc.leader = i
+		} else if !fc.pubKey.Equals(pubKey) {
+			return fmt.Errorf("signer %d has public key %v, while signer %d has %v",
+				i, pubKey, fc.leader, fc.pubKey)
+		}
+		fc.healthy[i] = true
+	}
+	if fc.pubKey == nil {
+		return ErrNoHealthySigner
+	}
+	fc.Logger.Info("Elected remote signer leader", "signer", fc.leader)
+
+	go fc.healthCheckRoutine()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (fc *FailoverSignerClient) OnStop() {
+	for i, c := range fc.clients {
+		if err := c.endpoint.Stop(); err != nil {
+			fc.Logger.Error("Error stopping signer endpoint", "signer", i, "err", err)
+		}
+	}
+}
+
+// Leader returns the index of the current leader, or -1 if there is none.
+func (fc *FailoverSignerClient) Leader() int {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	return fc.leader
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey returns the public key served by the signers.
+func (fc *FailoverSignerClient) GetPubKey() (crypto.PubKey, error) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	if fc.pubKey == nil {
+		return nil, ErrNoHealthySigner
+	}
+	return fc.pubKey, nil
+}
+
+// SignVote requests the leader to sign a vote, failing over to another signer
+// if the leader is unreachable.
+func (fc *FailoverSignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	msg := &signedMsg{
+		chainID:   chainID,
+		height:    vote.Height,
+		round:     vote.Round,
+		step:      voteToStep(vote),
+		signBytes: types.VoteSignBytes(chainID, vote),
+	}
+	if err := fc.checkConflict(msg); err != nil {
+		return err
+	}
+
+	signed, err := fc.signWithFailover(func(c *SignerClient) (proto.Message, error) {
+		v := proto.Clone(vote).(*cmtproto.Vote)
+		return v, c.SignVote(chainID, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	*vote = *signed.(*cmtproto.Vote)
+	msg.signBytes = types.VoteSignBytes(chainID, vote)
+	msg.vote = proto.Clone(vote).(*cmtproto.Vote)
+	fc.last = msg
+	return nil
+}
+
+// SignProposal requests the leader to sign a proposal, failing over to another
+// signer if the leader is unreachable.
+func (fc *FailoverSignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	msg := &signedMsg{
+		chainID:   chainID,
+		height:    proposal.Height,
+		round:     proposal.Round,
+		step:      stepPropose,
+		signBytes: types.ProposalSignBytes(chainID, proposal),
+	}
+	if err := fc.checkConflict(msg); err != nil {
+		return err
+	}
+
+	signed, err := fc.signWithFailover(func(c *SignerClient) (proto.Message, error) {
+		p := proto.Clone(proposal).(*cmtproto.Proposal)
+		return p, c.SignProposal(chainID, p)
+	})
+	if err != nil {
+		return err
+	}
+
+	*proposal = *signed.(*cmtproto.Proposal)
+	msg.signBytes = types.ProposalSignBytes(chainID, proposal)
+	msg.proposal = proto.Clone(proposal).(*cmtproto.Proposal)
+	fc.last = msg
+	return nil
+}
+
+// checkConflict returns an error if msg is for an earlier height, round or
+// step than the last signed message, or for the same one with different data.
+// Messages which only differ by their timestamp are left to the signer, which
+// returns the last signature.
+func (fc *FailoverSignerClient) checkConflict(msg *signedMsg) error {
+	if fc.last == nil {
+		return nil
+	}
+	lss := FilePVLastSignState{
+		Height:    fc.last.height,
+		Round:     fc.last.round,
+		Step:      fc.last.step,
+		SignBytes: fc.last.signBytes,
+		Signature: []byte{}, // only the HRS and sign bytes are checked
+	}
+	sameHRS, err := lss.CheckHRS(msg.height, msg.round, msg.step)
+	if err != nil || !sameHRS || bytes.Equal(msg.signBytes, lss.SignBytes) {
+		return err
+	}
+
+	onlyTimestamp := false
+	if msg.step == stepPropose {
+		_, onlyTimestamp = checkProposalsOnlyDifferByTimestamp(lss.SignBytes, msg.signBytes)
+	} else {
+		_, onlyTimestamp = checkVotesOnlyDifferByTimestamp(lss.SignBytes, msg.signBytes)
+	}
+	if !onlyTimestamp {
+		return errors.New("conflicting data")
+	}
+	return nil
+}
+
+// signWithFailover sends a sign request to the leader. If the leader cannot be
+// reached, it is marked unhealthy, another leader is elected and the request
+// is sent to it instead. Errors returned by a signer are not retried.
+func (fc *FailoverSignerClient) signWithFailover(
+	sign func(c *SignerClient) (proto.Message, error),
+) (proto.Message, error) {
+	for attempt := 0; attempt < len(fc.clients); attempt++ {
+		if fc.leader < 0 {
+			if err := fc.electLeader(); err != nil {
+				return nil, err
+			}
+		}
+
+		signed, err := sign(fc.clients[fc.leader])
+		if err == nil {
+			return signed, nil
+		}
+		if _, ok := err.(*RemoteSignerError); ok {
+			return nil, err
+		}
+
+		fc.Logger.Error("Remote signer leader failed, failing over", "signer", fc.leader, "err", err)
+		fc.healthy[fc.leader] = false
+		fc.leader = -1
+	}
+	return nil, ErrNoHealthySigner
+}
+
+// electLeader promotes the first healthy signer completing the handshake.
+func (fc *FailoverSignerClient) electLeader() error {
+	for i := range fc.clients {
+		if !fc.healthy[i] {
+			continue
+		}
+		if err := fc.handshake(i); err != nil {
+			fc.Logger.Error("Remote signer failed leader handshake", "signer", i, "err", err)
+			fc.healthy[i] = false
+			continue
+		}
+		fc.leader = i
+		fc.Logger.Info("Elected remote signer leader", "signer", i)
+		return nil
+	}
+	return ErrNoHealthySigner
+}
+
+// handshake checks that the signer at index i can take over as the leader: it
+// must serve the validator's public key and be sent the last signed message.
+// If the signer refuses to sign it again, because it already signed a later
+// or conflicting message, its own last sign state already protects it.
+func (fc *FailoverSignerClient) handshake(i int) error {
+	c := fc.clients[i]
+	pubKey, err := c.GetPubKey()
+	if err != nil {
+		return err
+	}
+	if !pubKey.Equals(fc.pubKey) {
+		return fmt.Errorf("signer has public key %v, expected %v", pubKey, fc.pubKey)
+	}
+
+	if fc.last == nil {
+		return nil
+	}
+	var signBytes, sig []byte
+	switch {
+	case fc.last.vote != nil:
+		v := proto.Clone(fc.last.vote).(*cmtproto.Vote)
+		err = c.SignVote(fc.last.chainID, v)
+		signBytes, sig = types.VoteSignBytes(fc.last.chainID, v), v.Signature
+	case fc.last.proposal != nil:
+		p := proto.Clone(fc.last.proposal).(*cmtproto.Proposal)
+		err = c.SignProposal(fc.last.chainID, p)
+		signBytes, sig = types.ProposalSignBytes(fc.last.chainID, p), p.Signature
+	}
+	if rerr, ok := err.(*RemoteSignerError); ok {
+		fc.Logger.Info("Remote signer refused to sign the last message again", "signer", i, "err", rerr)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !fc.pubKey.VerifySignature(signBytes, sig) {
+		return errors.New("invalid signature on the last message")
+	}
+	return nil
+}
+
+func (fc *FailoverSignerClient) healthCheckRoutine() {
+	ticker := time.NewTicker(fc.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			fc.checkHealth()
+		case <-fc.Quit():
+			return
+		}
+	}
+}
+
+// checkHealth pings all the signers, and elects a new leader if the current
+// one is unhealthy.
+func (fc *FailoverSignerClient) checkHealth() {
+	healthy := make([]bool, len(fc.clients))
+	var wg sync.WaitGroup
+	for i, c := range fc.clients {
+		wg.Add(1)
+		go func(i int, c *SignerClient) {
+			defer wg.Done()
+			res, err := c.endpoint.SendRequest(mustWrapMsg(&privvalproto.PingRequest{}))
+			healthy[i] = err == nil && res.GetPingResponse() != nil
+		}(i, c)
+	}
+	wg.Wait()
+
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	for i := range healthy {
+		if healthy[i] != fc.healthy[i] {
+			fc.Logger.Info("Remote signer health changed", "signer", i, "healthy", healthy[i])
+		}
+	}
+	fc.healthy = healthy
+
+	if fc.leader >= 0 && !fc.healthy[fc.leader] {
+		fc.Logger.Error("Remote signer leader is unhealthy, failing over", "signer", fc.leader)
+		fc.leader = -1
+	}
+	if fc.leader < 0 {
+		if err := fc.electLeader(); err != nil {
+			fc.Logger.Error("Failed to elect a remote signer leader", "err", err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package privval
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// newFailoverTestSigners starts a signer server for each of the given keys,
+// and returns the signer servers along with a started FailoverSignerClient.
+func newFailoverTestSigners(
+	t *testing.T,
+	chainID string,
+	privKeys ...crypto.PrivKey,
+) ([]*SignerServer, []*FilePV, *FailoverSignerClient, error) {
+	var (
+		servers []*SignerServer
+		pvs     []*FilePV
+		clients []*SignerClient
+	)
+	for i, privKey := range privKeys {
+		unixFilePath, err := testUnixAddr()
+		require.NoError(t, err)
+		sl, sd := getMockEndpoints(t, fmt.Sprintf("unix://%s", unixFilePath), DialUnixFn(unixFilePath))
+
+		sc, err := NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		clients = append(clients, sc)
+
+		pv := NewFilePV(privKey, "", filepath.Join(t.TempDir(), fmt.Sprintf("state%d.json", i)))
+		ss := NewSignerServer(sd, chainID, pv)
+		require.NoError(t, ss.Start())
+		servers = append(servers, ss)
+		pvs = append(pvs, pv)
+	}
+
+	fc, err := NewFailoverSignerClient(log.TestingLogger(), clients,
+		FailoverSignerClientHealthCheckInterval(testTimeoutReadWrite))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		for _, ss := range servers {
+			if ss.IsRunning() {
+				if err := ss.Stop(); err != nil {
+					t.Error(err)
+				}
+			}
+		}
+		if fc.IsRunning() {
+			if err := fc.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	return servers, pvs, fc, fc.Start()
+}
+
+func TestFailoverSignerClientFailsOver(t *testing.T) {
+	chainID := cmtrand.Str(12)
+	privKey := ed25519.GenPrivKey()
+	servers, pvs, fc, err := newFailoverTestSigners(t, chainID, privKey, privKey)
+	require.NoError(t, err)
+	require.Equal(t, 0, fc.Leader())
+
+	pubKey, err := fc.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	prevote := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, fc.SignVote(chainID, prevote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, prevote), prevote.Signature))
+
+	// take the leader down: the next request is signed by the other signer
+	require.NoError(t, servers[0].Stop())
+	precommit := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrecommitType, blockID).ToProto()
+	require.NoError(t, fc.SignVote(chainID, precommit))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, precommit), precommit.Signature))
+	assert.Equal(t, 1, fc.Leader())
+
+	// the new leader's state includes the prevote replayed during the handshake
+	assert.Equal(t, int64(1), pvs[1].LastSignState.Height)
+	assert.Equal(t, stepPrecommit, pvs[1].LastSignState.Step)
+
+	// a conflicting precommit is refused by the client
+	conflicting := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrecommitType,
+		types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}).ToProto()
+	assert.Error(t, fc.SignVote(chainID, conflicting))
+
+	// with all the signers down, signing fails
+	require.NoError(t, servers[1].Stop())
+	proposal := newProposal(2, 0, blockID).ToProto()
+	assert.Error(t, fc.SignProposal(chainID, proposal))
+}
+
+func TestFailoverSignerClientMismatchingKeys(t *testing.T) {
+	chainID := cmtrand.Str(12)
+	_, _, _, err := newFailoverTestSigners(t, chainID, ed25519.GenPrivKey(), ed25519.GenPrivKey())
+	assert.Error(t, err)
+}
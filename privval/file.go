@@ -10,7 +10,10 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtos "github.com/tendermint/tendermint/libs/os"
@@ -49,6 +52,11 @@ type FilePVKey struct {
 	PubKey  crypto.PubKey  `json:"pub_key"`
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
+	// Key the validator rotates to, once the KeyRotation announcing it takes
+	// effect.
+	NextPubKey  crypto.PubKey  `json:"next_pub_key,omitempty"`
+	NextPrivKey crypto.PrivKey `json:"next_priv_key,omitempty"`
+
 	filePath string
 }
 
@@ -172,6 +180,33 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath)
 }
 
+// GenFilePVWithKeyType generates a new validator with a randomly generated
+// private key of the given type (ed25519, secp256k1, sr25519 or bls12_381) and sets the
+// filePaths, but does not call Save().
+func GenFilePVWithKeyType(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
+	privKey, err := genPrivKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilePV(privKey, keyFilePath, stateFilePath), nil
+}
+
+// genPrivKey generates a private key of the given type, defaulting to ed25519.
+func genPrivKey(keyType string) (crypto.PrivKey, error) {
+	switch keyType {
+	case "", ed25519.KeyType:
+		return ed25519.GenPrivKey(), nil
+	case secp256k1.KeyType:
+		return secp256k1.GenPrivKey(), nil
+	case bls12381.KeyType:
+		return bls12381.GenPrivKey(), nil
+	case sr25519.KeyType:
+		return sr25519.GenPrivKey(), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
@@ -200,6 +235,9 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	// overwrite pubkey and address for convenience
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = pvKey.PubKey.Address()
+	if pvKey.NextPrivKey != nil {
+		pvKey.NextPubKey = pvKey.NextPrivKey.PubKey()
+	}
 	pvKey.filePath = keyFilePath
 
 	pvState := FilePVLastSignState{}
@@ -266,6 +304,62 @@ func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) erro
 	return nil
 }
 
+// NextPubKey returns the public key the validator will rotate to, or nil if
+// there is none. Implements KeyRotatingPrivValidator.
+func (pv *FilePV) NextPubKey() (crypto.PubKey, error) {
+	return pv.Key.NextPubKey, nil
+}
+
+// GenNextKey generates the key of the given type the validator will rotate to,
+// and persists it along with the current key. It replaces a previously
+// generated next key.
+func (pv *FilePV) GenNextKey(keyType string) (crypto.PubKey, error) {
+	privKey, err := genPrivKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	pv.Key.NextPrivKey = privKey
+	pv.Key.NextPubKey = privKey.PubKey()
+	pv.Key.Save()
+	return pv.Key.NextPubKey, nil
+}
+
+// SignKeyRotation signs the rotation from the current key to the next key of
+// the validator, effective at the given height, along with the proof of
+// possession of the next key if it needs one.
+func (pv *FilePV) SignKeyRotation(chainID string, height int64) (*types.KeyRotation, error) {
+	if pv.Key.NextPrivKey == nil {
+		return nil, errors.New("no next key to rotate to")
+	}
+	kr := types.NewKeyRotation(pv.Key.PubKey, pv.Key.NextPubKey, height)
+	sig, err := pv.Key.PrivKey.Sign(types.KeyRotationSignBytes(chainID, kr))
+	if err != nil {
+		return nil, fmt.Errorf("error signing key rotation: %v", err)
+	}
+	kr.Signature = sig
+	if kr.ProofOfPossession, err = types.ProofOfPossession(pv.Key.NextPrivKey); err != nil {
+		return nil, fmt.Errorf("error proving the possession of the next key: %v", err)
+	}
+	return kr, nil
+}
+
+// RotateKey makes the next key the current key of the validator, and persists
+// it. The last sign state is kept, so that the new key never signs at a height,
+// round and step before the last signature of the old key.
+// Implements KeyRotatingPrivValidator.
+func (pv *FilePV) RotateKey() error {
+	if pv.Key.NextPrivKey == nil {
+		return errors.New("no next key to rotate to")
+	}
+	pv.Key.PrivKey = pv.Key.NextPrivKey
+	pv.Key.PubKey = pv.Key.NextPubKey
+	pv.Key.Address = pv.Key.PubKey.Address()
+	pv.Key.NextPrivKey = nil
+	pv.Key.NextPubKey = nil
+	pv.Key.Save()
+	return nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
@@ -301,9 +395,23 @@ func (pv *FilePV) String() string {
 // It may need to set the timestamp as well if the vote is otherwise the same as
 // a previously signed vote (ie. we crashed after signing but before the vote hit the WAL).
 func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
-	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	return pv.LastSignState.signVote(chainID, vote, pv.Key.PrivKey.Sign)
+}
+
+// signProposal checks if the proposal is good to sign and sets the proposal signature.
+// It may need to set the timestamp as well if the proposal is otherwise the same as
+// a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
+func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) error {
+	return pv.LastSignState.signProposal(chainID, proposal, pv.Key.PrivKey.Sign)
+}
+
+// signFunc produces a signature over the given sign bytes.
+type signFunc func(signBytes []byte) ([]byte, error)
 
-	lss := pv.LastSignState
+// signVote checks the vote against the last sign state, signs it with sign
+// and persists the new state. See FilePV.signVote.
+func (lss *FilePVLastSignState) signVote(chainID string, vote *cmtproto.Vote, sign signFunc) error {
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -330,23 +438,20 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 	}
 
 	// It passed the checks. Sign the vote
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := sign(signBytes)
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	lss.saveSigned(height, round, step, signBytes, sig)
 	vote.Signature = sig
 	return nil
 }
 
-// signProposal checks if the proposal is good to sign and sets the proposal signature.
-// It may need to set the timestamp as well if the proposal is otherwise the same as
-// a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
-func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) error {
+// signProposal checks the proposal against the last sign state, signs it with
+// sign and persists the new state. See FilePV.signProposal.
+func (lss *FilePVLastSignState) signProposal(chainID string, proposal *cmtproto.Proposal, sign signFunc) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
-	lss := pv.LastSignState
-
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
 		return err
@@ -372,25 +477,25 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	}
 
 	// It passed the checks. Sign the proposal
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := sign(signBytes)
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	lss.saveSigned(height, round, step, signBytes, sig)
 	proposal.Signature = sig
 	return nil
 }
 
 // Persist height/round/step and signature
-func (pv *FilePV) saveSigned(height int64, round int32, step int8,
+func (lss *FilePVLastSignState) saveSigned(height int64, round int32, step int8,
 	signBytes []byte, sig []byte,
 ) {
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
-	pv.LastSignState.Save()
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.Signature = sig
+	lss.SignBytes = signBytes
+	lss.Save()
 }
 
 //-----------------------------------------------------------------------------------------
@@ -4,12 +4,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
@@ -39,6 +41,34 @@ func TestGenLoadValidator(t *testing.T) {
 	assert.Equal(height, privVal.LastSignState.Height, "expected privval.LastHeight to have been saved")
 }
 
+func TestGenFilePVWithKeyType(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+
+	for _, keyType := range []string{"ed25519", "secp256k1", "sr25519", "bls12_381"} {
+		keyType := keyType
+		t.Run(keyType, func(t *testing.T) {
+			dir := t.TempDir()
+			keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+			privVal, err := GenFilePVWithKeyType(keyFile, stateFile, keyType)
+			require.NoError(t, err)
+			privVal.Save()
+
+			privVal = LoadFilePV(keyFile, stateFile)
+			pubKey, err := privVal.GetPubKey()
+			require.NoError(t, err)
+			assert.Equal(t, keyType, pubKey.Type())
+
+			vote := newVote(privVal.Key.Address, 0, 1, 0, cmtproto.PrevoteType, blockID).ToProto()
+			require.NoError(t, privVal.SignVote(chainID, vote))
+			assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+		})
+	}
+
+	_, err := GenFilePVWithKeyType("", "", "rsa")
+	assert.Error(t, err)
+}
+
 func TestResetValidator(t *testing.T) {
 	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
 	require.Nil(t, err)
@@ -91,6 +121,67 @@ func TestLoadOrGenValidator(t *testing.T) {
 	assert.Equal(addr, privVal.GetAddress(), "expected privval addr to be the same")
 }
 
+func TestRotateKey(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	privVal := GenFilePV(keyFile, stateFile)
+	privVal.Save()
+	oldPubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	_, err = privVal.SignKeyRotation(chainID, 10)
+	assert.Error(t, err)
+	assert.Error(t, privVal.RotateKey())
+
+	nextPubKey, err := privVal.GenNextKey("secp256k1")
+	require.NoError(t, err)
+	kr, err := privVal.SignKeyRotation(chainID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, oldPubKey, kr.OldPubKey)
+	assert.Equal(t, nextPubKey, kr.NewPubKey)
+	assert.NoError(t, kr.Verify(chainID))
+
+	// the next key survives a restart
+	privVal = LoadFilePV(keyFile, stateFile)
+	pk, err := privVal.NextPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextPubKey, pk)
+
+	vote := newVote(oldPubKey.Address(), 0, 9, 0, cmtproto.PrecommitType, blockID).ToProto()
+	require.NoError(t, privVal.SignVote(chainID, vote))
+
+	require.NoError(t, privVal.RotateKey())
+	privVal = LoadFilePV(keyFile, stateFile)
+	pk, err = privVal.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextPubKey, pk)
+	assert.Equal(t, nextPubKey.Address(), privVal.GetAddress())
+	pk, err = privVal.NextPubKey()
+	require.NoError(t, err)
+	assert.Nil(t, pk)
+
+	// the new key signs from where the old key stopped
+	vote = newVote(nextPubKey.Address(), 0, 9, 0, cmtproto.PrevoteType, blockID).ToProto()
+	assert.Error(t, privVal.SignVote(chainID, vote))
+	vote = newVote(nextPubKey.Address(), 0, 10, 0, cmtproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, privVal.SignVote(chainID, vote))
+	assert.True(t, nextPubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+}
+
+func TestSignKeyRotationProofOfPossession(t *testing.T) {
+	dir := t.TempDir()
+	privVal := GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+
+	_, err := privVal.GenNextKey(bls12381.KeyType)
+	require.NoError(t, err)
+	kr, err := privVal.SignKeyRotation("mychainid", 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, kr.ProofOfPossession)
+	assert.NoError(t, kr.Verify("mychainid"))
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
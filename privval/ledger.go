@@ -0,0 +1,460 @@
+package privval
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/hd"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// DefaultLedgerHDPath is the default derivation path of the validator key
+	// in the Ledger device.
+	DefaultLedgerHDPath = "m/44'/118'/0'/0'/0'"
+	// DefaultLedgerConfirmTimeout is the default time to wait for the user to
+	// confirm a request on the Ledger device.
+	DefaultLedgerConfirmTimeout = time.Minute
+
+	// ledgerPromptDelay is the time after which a request not answered yet by
+	// the device is assumed to wait for a confirmation, and the user is asked
+	// to confirm it.
+	ledgerPromptDelay = 2 * time.Second
+)
+
+// APDU of the Tendermint validator app.
+const (
+	ledgerCLA             = 0x56
+	ledgerInsGetPublicKey = 0x01
+	ledgerInsSign         = 0x02
+
+	// payload descriptors of chunked requests, in P1
+	ledgerPayloadInit = 0x00
+	ledgerPayloadAdd  = 0x01
+	ledgerPayloadLast = 0x02
+
+	ledgerChunkSize    = 250
+	ledgerHDPathLength = 5
+)
+
+// Status words of Ledger responses.
+const (
+	ledgerSWOK             = 0x9000
+	ledgerSWRejected       = 0x6986
+	ledgerSWInvalidData    = 0x6984
+	ledgerSWAppNotOpen     = 0x6e00
+	ledgerSWAppNotOpenAlt  = 0x6e01
+	ledgerSWInsUnsupported = 0x6d00
+	ledgerSWLocked         = 0x5515
+)
+
+var (
+	// ErrLedgerConfirmationTimeout is returned when the user did not confirm a
+	// request on the Ledger device in time.
+	ErrLedgerConfirmationTimeout = errors.New("timed out waiting for confirmation on the Ledger device")
+	// ErrLedgerRejected is returned when the user or the Ledger app rejected a
+	// request.
+	ErrLedgerRejected = errors.New("request rejected on the Ledger device")
+	// ErrLedgerAppNotOpen is returned when the Tendermint validator app is not
+	// open on the Ledger device.
+	ErrLedgerAppNotOpen = errors.New("the Tendermint validator app is not open on the Ledger device")
+	// ErrLedgerLocked is returned when the Ledger device is locked.
+	ErrLedgerLocked = errors.New("the Ledger device is locked, unlock it with its PIN")
+)
+
+// LedgerConfig locates a validator key in a Ledger device.
+type LedgerConfig struct {
+	// Path of the HID device of the Ledger. If empty, the first Ledger found
+	// is used.
+	Device string
+	// Derivation path of the key in the device
+	HDPath string
+	// Time to wait for the user to confirm a request on the device
+	ConfirmTimeout time.Duration
+}
+
+// ledgerTransport exchanges APDUs with a Ledger device.
+type ledgerTransport interface {
+	// Exchange sends a command APDU, and returns the response APDU, including
+	// its status word.
+	Exchange(apdu []byte) ([]byte, error)
+	Close() error
+}
+
+// LedgerPV implements PrivValidator for an ed25519 key held in a Ledger
+// device, and used through the Tendermint validator app over USB HID.
+//
+// Like FilePV, LedgerPV persists its last sign state to a file, to prevent
+// double signing, and signing the same vote or proposal again reuses the
+// previous signature without going through the device. The Tendermint
+// validator app keeps a last sign state of its own.
+//
+// The app asks the user to confirm the first signature on the device. While a
+// request waits for a confirmation, the user is prompted in the logs, and the
+// request fails with ErrLedgerConfirmationTimeout if not confirmed in time.
+// The connection to the device is then reopened by the next request.
+type LedgerPV struct {
+	mtx cmtsync.Mutex
+
+	key           *ledgerKey
+	LastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*LedgerPV)(nil)
+
+// NewLedgerPV connects to the Ledger device described by cfg, and returns a
+// LedgerPV signing with its key and persisting its last sign state to
+// stateFilePath. The state is loaded from the file if it exists.
+func NewLedgerPV(logger log.Logger, cfg LedgerConfig, stateFilePath string) (*LedgerPV, error) {
+	key, err := openLedgerKey(logger, cfg, func() (ledgerTransport, error) {
+		return openLedgerHID(cfg.Device)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Ledger key: %w", err)
+	}
+	pv, err := newLedgerPV(key, stateFilePath)
+	if err != nil {
+		_ = key.Close()
+		return nil, err
+	}
+	return pv, nil
+}
+
+func newLedgerPV(key *ledgerKey, stateFilePath string) (*LedgerPV, error) {
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &LedgerPV{key: key, LastSignState: lss}, nil
+}
+
+// LedgerPubKey returns the public key of the key described by cfg.
+func LedgerPubKey(logger log.Logger, cfg LedgerConfig) (crypto.PubKey, error) {
+	key, err := openLedgerKey(logger, cfg, func() (ledgerTransport, error) {
+		return openLedgerHID(cfg.Device)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Ledger key: %w", err)
+	}
+	pubKey := key.PubKey()
+	return pubKey, key.Close()
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *LedgerPV) GetPubKey() (crypto.PubKey, error) {
+	return pv.key.PubKey(), nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *LedgerPV) SignVote(chainID string, vote *cmtproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if err := pv.LastSignState.signVote(chainID, vote, pv.key.Sign); err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
+	}
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *LedgerPV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if err := pv.LastSignState.signProposal(chainID, proposal, pv.key.Sign); err != nil {
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the device.
+func (pv *LedgerPV) Close() error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	return pv.key.Close()
+}
+
+// String returns a string representation of the LedgerPV.
+func (pv *LedgerPV) String() string {
+	return fmt.Sprintf("LedgerPV{%v LH:%v, LR:%v, LS:%v}", pv.key.PubKey().Address(), pv.LastSignState.Height,
+		pv.LastSignState.Round, pv.LastSignState.Step)
+}
+
+//-------------------------------------------------------------------------------
+
+// ledgerKey is an hsmKey held in a Ledger device.
+type ledgerKey struct {
+	logger         log.Logger
+	open           func() (ledgerTransport, error)
+	transport      ledgerTransport // nil after a timeout, until reopened
+	hdPath         []byte
+	confirmTimeout time.Duration
+	pubKey         crypto.PubKey
+}
+
+var _ hsmKey = (*ledgerKey)(nil)
+
+// openLedgerKey connects to the device with open, and fetches the public key
+// of the key described by cfg.
+func openLedgerKey(
+	logger log.Logger,
+	cfg LedgerConfig,
+	open func() (ledgerTransport, error),
+) (*ledgerKey, error) {
+	hdPath := cfg.HDPath
+	if hdPath == "" {
+		hdPath = DefaultLedgerHDPath
+	}
+	path, err := serializeLedgerHDPath(hdPath)
+	if err != nil {
+		return nil, err
+	}
+	confirmTimeout := cfg.ConfirmTimeout
+	if confirmTimeout <= 0 {
+		confirmTimeout = DefaultLedgerConfirmTimeout
+	}
+
+	k := &ledgerKey{
+		logger:         logger,
+		open:           open,
+		hdPath:         path,
+		confirmTimeout: confirmTimeout,
+	}
+	resp, err := k.exchange("Confirm the validator public key on the Ledger device",
+		ledgerAPDU(ledgerInsGetPublicKey, 0, 0, path))
+	if err != nil {
+		_ = k.Close()
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+	if len(resp) != ed25519.PubKeySize {
+		_ = k.Close()
+		return nil, fmt.Errorf("invalid ed25519 public key size %d", len(resp))
+	}
+	k.pubKey = ed25519.PubKey(resp)
+	return k, nil
+}
+
+// PubKey implements hsmKey.
+func (k *ledgerKey) PubKey() crypto.PubKey {
+	return k.pubKey
+}
+
+// Sign implements hsmKey. The sign bytes are sent in chunks, after the
+// derivation path of the key.
+func (k *ledgerKey) Sign(msg []byte) ([]byte, error) {
+	chunks := [][]byte{k.hdPath}
+	for len(msg) > ledgerChunkSize {
+		chunks = append(chunks, msg[:ledgerChunkSize])
+		msg = msg[ledgerChunkSize:]
+	}
+	chunks = append(chunks, msg)
+
+	apdus := make([][]byte, len(chunks))
+	for i, chunk := range chunks {
+		p1 := byte(ledgerPayloadAdd)
+		switch i {
+		case 0:
+			p1 = ledgerPayloadInit
+		case len(chunks) - 1:
+			p1 = ledgerPayloadLast
+		}
+		apdus[i] = ledgerAPDU(ledgerInsSign, p1, 0, chunk)
+	}
+
+	sig, err := k.exchange("Confirm signing on the Ledger device", apdus...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid ed25519 signature size %d", len(sig))
+	}
+	return sig, nil
+}
+
+// Close implements hsmKey.
+func (k *ledgerKey) Close() error {
+	if k.transport == nil {
+		return nil
+	}
+	err := k.transport.Close()
+	k.transport = nil
+	return err
+}
+
+// exchange sends the APDUs in order, and returns the data of the last
+// response. The user is prompted to confirm on the device if the device takes
+// time to answer. On a timeout, the connection is closed, and reopened by the
+// next exchange.
+func (k *ledgerKey) exchange(prompt string, apdus ...[]byte) ([]byte, error) {
+	if k.transport == nil {
+		transport, err := k.open()
+		if err != nil {
+			return nil, err
+		}
+		k.transport = transport
+	}
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	transport := k.transport
+	go func() {
+		var res result
+		for _, apdu := range apdus {
+			if res.data, res.err = exchangeLedgerAPDU(transport, apdu); res.err != nil {
+				break
+			}
+		}
+		done <- res
+	}()
+
+	prompted := time.NewTimer(ledgerPromptDelay)
+	defer prompted.Stop()
+	timeout := time.NewTimer(k.confirmTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case res := <-done:
+			return res.data, res.err
+		case <-prompted.C:
+			k.logger.Info(prompt, "timeout", k.confirmTimeout)
+		case <-timeout.C:
+			// the device may still answer the request: drop the connection so
+			// that the response is not mistaken for the one of the next request
+			if err := k.Close(); err != nil {
+				k.logger.Error("Error closing connection to the Ledger device", "err", err)
+			}
+			return nil, ErrLedgerConfirmationTimeout
+		}
+	}
+}
+
+// exchangeLedgerAPDU sends a command APDU, and returns the data of the
+// response, or an error if its status word is not OK.
+func exchangeLedgerAPDU(transport ledgerTransport, apdu []byte) ([]byte, error) {
+	resp, err := transport.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 {
+		return nil, fmt.Errorf("invalid Ledger response size %d", len(resp))
+	}
+	data, sw := resp[:len(resp)-2], binary.BigEndian.Uint16(resp[len(resp)-2:])
+	switch sw {
+	case ledgerSWOK:
+		return data, nil
+	case ledgerSWRejected:
+		return nil, ErrLedgerRejected
+	case ledgerSWAppNotOpen, ledgerSWAppNotOpenAlt, ledgerSWInsUnsupported:
+		return nil, ErrLedgerAppNotOpen
+	case ledgerSWLocked:
+		return nil, ErrLedgerLocked
+	case ledgerSWInvalidData:
+		return nil, fmt.Errorf("%w: invalid data (status 0x%04x)", ErrLedgerRejected, sw)
+	default:
+		return nil, fmt.Errorf("ledger error (status 0x%04x)", sw)
+	}
+}
+
+// ledgerAPDU returns a command APDU of the Tendermint validator app.
+func ledgerAPDU(ins, p1, p2 byte, data []byte) []byte {
+	apdu := make([]byte, 5, 5+len(data))
+	apdu[0], apdu[1], apdu[2], apdu[3], apdu[4] = ledgerCLA, ins, p1, p2, byte(len(data))
+	return append(apdu, data...)
+}
+
+// serializeLedgerHDPath returns the derivation path in the format of the
+// Tendermint validator app: 5 little endian indexes.
+func serializeLedgerHDPath(path string) ([]byte, error) {
+	indexes, err := hd.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) != ledgerHDPathLength {
+		return nil, fmt.Errorf("ledger derivation path %q must have %d indexes", path, ledgerHDPathLength)
+	}
+	bz := make([]byte, 4*len(indexes))
+	for i, index := range indexes {
+		if index < hd.HardenedOffset {
+			return nil, fmt.Errorf("ledger derivation path %q: %w", path, hd.ErrNonHardened)
+		}
+		binary.LittleEndian.PutUint32(bz[4*i:], index)
+	}
+	return bz, nil
+}
+
+//-------------------------------------------------------------------------------
+
+// Framing of APDUs in HID reports, as done by Ledger devices.
+const (
+	ledgerHIDChannel    = 0x0101
+	ledgerHIDTag        = 0x05
+	ledgerHIDPacketSize = 64
+)
+
+// wrapLedgerHIDCommand splits a command APDU into HID reports. Every report
+// starts with the channel, the tag and a sequence number, and the first one
+// with the size of the APDU.
+func wrapLedgerHIDCommand(apdu []byte) [][]byte {
+	data := make([]byte, 2+len(apdu))
+	binary.BigEndian.PutUint16(data, uint16(len(apdu)))
+	copy(data[2:], apdu)
+
+	var packets [][]byte
+	for seq := uint16(0); len(data) > 0 || seq == 0; seq++ {
+		packet := make([]byte, ledgerHIDPacketSize)
+		binary.BigEndian.PutUint16(packet, ledgerHIDChannel)
+		packet[2] = ledgerHIDTag
+		binary.BigEndian.PutUint16(packet[3:], seq)
+		n := copy(packet[5:], data)
+		data = data[n:]
+		packets = append(packets, packet)
+	}
+	return packets
+}
+
+// unwrapLedgerHIDResponse reassembles a response APDU from the HID reports
+// returned by read.
+func unwrapLedgerHIDResponse(read func() ([]byte, error)) ([]byte, error) {
+	var (
+		resp []byte
+		size = -1
+	)
+	for seq := uint16(0); size < 0 || len(resp) < size; seq++ {
+		packet, err := read()
+		if err != nil {
+			return nil, err
+		}
+		if len(packet) < 5 {
+			return nil, fmt.Errorf("invalid Ledger HID report size %d", len(packet))
+		}
+		if binary.BigEndian.Uint16(packet) != ledgerHIDChannel || packet[2] != ledgerHIDTag {
+			return nil, errors.New("invalid Ledger HID report header")
+		}
+		if got := binary.BigEndian.Uint16(packet[3:]); got != seq {
+			return nil, fmt.Errorf("invalid Ledger HID report sequence %d, expected %d", got, seq)
+		}
+		packet = packet[5:]
+		if seq == 0 {
+			if len(packet) < 2 {
+				return nil, errors.New("invalid first Ledger HID report")
+			}
+			size = int(binary.BigEndian.Uint16(packet))
+			packet = packet[2:]
+		}
+		resp = append(resp, packet...)
+	}
+	return resp[:size], nil
+}
@@ -0,0 +1,107 @@
+//go:build linux
+// +build linux
+
+package privval
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ledgerHIDVendorID is the USB vendor ID of Ledger devices, as found in the
+// HID_ID of their uevent.
+const ledgerHIDVendorID = "00002C97"
+
+// hidrawTransport is a ledgerTransport over a Linux hidraw device.
+type hidrawTransport struct {
+	file *os.File
+}
+
+var _ ledgerTransport = (*hidrawTransport)(nil)
+
+// openLedgerHID opens the hidraw device of a Ledger. If device is empty, the
+// first Ledger found is used.
+func openLedgerHID(device string) (ledgerTransport, error) {
+	if device == "" {
+		var err error
+		if device, err = findLedgerHID(); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(device, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Ledger device: %w", err)
+	}
+	return &hidrawTransport{file: file}, nil
+}
+
+// Exchange implements ledgerTransport.
+func (t *hidrawTransport) Exchange(apdu []byte) ([]byte, error) {
+	for _, packet := range wrapLedgerHIDCommand(apdu) {
+		// hidraw expects the report number first, 0 for devices without
+		// numbered reports
+		if _, err := t.file.Write(append([]byte{0}, packet...)); err != nil {
+			return nil, fmt.Errorf("failed to write to Ledger device: %w", err)
+		}
+	}
+	return unwrapLedgerHIDResponse(func() ([]byte, error) {
+		packet := make([]byte, ledgerHIDPacketSize)
+		n, err := t.file.Read(packet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from Ledger device: %w", err)
+		}
+		return packet[:n], nil
+	})
+}
+
+// Close implements ledgerTransport.
+func (t *hidrawTransport) Close() error {
+	return t.file.Close()
+}
+
+// findLedgerHID returns the hidraw device of the first Ledger connected over
+// USB. Ledger devices expose the APDU interface as their first interface.
+func findLedgerHID() (string, error) {
+	uevents, err := filepath.Glob("/sys/class/hidraw/*/device/uevent")
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(uevents)
+	for _, uevent := range uevents {
+		vars, err := readUevent(uevent)
+		if err != nil {
+			continue
+		}
+		id := strings.Split(vars["HID_ID"], ":")
+		if len(id) != 3 || !strings.EqualFold(id[1], ledgerHIDVendorID) ||
+			!strings.HasSuffix(vars["HID_PHYS"], "/input0") {
+			continue
+		}
+		name := filepath.Base(filepath.Dir(filepath.Dir(uevent)))
+		return filepath.Join("/dev", name), nil
+	}
+	return "", errors.New("no Ledger device found, check that it is connected and unlocked")
+}
+
+// readUevent returns the variables of a uevent file.
+func readUevent(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if k, v, ok := strings.Cut(scanner.Text(), "="); ok {
+			vars[k] = v
+		}
+	}
+	return vars, scanner.Err()
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package privval
+
+import "errors"
+
+// openLedgerHID always fails, as Ledger devices are used through the Linux
+// hidraw interface.
+func openLedgerHID(string) (ledgerTransport, error) {
+	return nil, errors.New("ledger devices are only supported on Linux")
+}
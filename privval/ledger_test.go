@@ -0,0 +1,252 @@
+package privval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fakeLedgerApp is a ledgerTransport emulating the Tendermint validator app.
+type fakeLedgerApp struct {
+	privKey ed25519.PrivKey
+	hdPath  []byte
+	signed  int
+	// if set, requests wait for it, as if waiting for a confirmation
+	confirm chan bool
+	closed  bool
+
+	pending []byte
+}
+
+func (a *fakeLedgerApp) Exchange(apdu []byte) ([]byte, error) {
+	// go through the HID framing
+	packets := wrapLedgerHIDCommand(apdu)
+	var cmd []byte
+	for i, packet := range packets {
+		if i == 0 {
+			cmd = append(cmd, packet[7:]...)
+		} else {
+			cmd = append(cmd, packet[5:]...)
+		}
+	}
+	cmd = cmd[:binary.BigEndian.Uint16(packets[0][5:])]
+
+	resp := a.handle(cmd)
+	packets = wrapLedgerHIDCommand(resp)
+	return unwrapLedgerHIDResponse(func() ([]byte, error) {
+		packet := packets[0]
+		packets = packets[1:]
+		return packet, nil
+	})
+}
+
+func (a *fakeLedgerApp) handle(cmd []byte) []byte {
+	sw := func(data []byte, sw uint16) []byte {
+		return binary.BigEndian.AppendUint16(data, sw)
+	}
+	if cmd[0] != ledgerCLA {
+		return sw(nil, ledgerSWAppNotOpen)
+	}
+	data := cmd[5:]
+	switch cmd[1] {
+	case ledgerInsGetPublicKey:
+		if !bytes.Equal(data, a.hdPath) {
+			return sw(nil, ledgerSWInvalidData)
+		}
+		return sw(a.privKey.PubKey().Bytes(), ledgerSWOK)
+	case ledgerInsSign:
+		switch cmd[2] {
+		case ledgerPayloadInit:
+			if !bytes.Equal(data, a.hdPath) {
+				return sw(nil, ledgerSWInvalidData)
+			}
+			a.pending = []byte{}
+			return sw(nil, ledgerSWOK)
+		case ledgerPayloadAdd:
+			a.pending = append(a.pending, data...)
+			return sw(nil, ledgerSWOK)
+		case ledgerPayloadLast:
+			msg := append(a.pending, data...)
+			a.pending = nil
+			if a.confirm != nil && !<-a.confirm {
+				return sw(nil, ledgerSWRejected)
+			}
+			sig, err := a.privKey.Sign(msg)
+			if err != nil {
+				panic(err)
+			}
+			a.signed++
+			return sw(sig, ledgerSWOK)
+		}
+	}
+	return sw(nil, ledgerSWInsUnsupported)
+}
+
+func (a *fakeLedgerApp) Close() error {
+	a.closed = true
+	return nil
+}
+
+func newLedgerTestKey(t *testing.T, app *fakeLedgerApp, confirmTimeout time.Duration) *ledgerKey {
+	key, err := openLedgerKey(log.TestingLogger(), LedgerConfig{ConfirmTimeout: confirmTimeout},
+		func() (ledgerTransport, error) {
+			app.closed = false
+			return app, nil
+		})
+	require.NoError(t, err)
+	return key
+}
+
+func newFakeLedgerApp(t *testing.T) *fakeLedgerApp {
+	hdPath, err := serializeLedgerHDPath(DefaultLedgerHDPath)
+	require.NoError(t, err)
+	return &fakeLedgerApp{privKey: ed25519.GenPrivKey(), hdPath: hdPath}
+}
+
+func TestLedgerPVSign(t *testing.T) {
+	app := newFakeLedgerApp(t)
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	pv, err := newLedgerPV(newLedgerTestKey(t, app, time.Second), stateFile)
+	require.NoError(t, err)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, app.privKey.PubKey(), pubKey)
+
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+
+	// sign bytes longer than a chunk
+	proposal := newProposal(1, 0, blockID).ToProto()
+	chainIDLong := string(bytes.Repeat([]byte{'c'}, 2*ledgerChunkSize))
+	require.NoError(t, pv.SignProposal(chainIDLong, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainIDLong, proposal), proposal.Signature))
+
+	vote := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, blockID)
+	v := vote.ToProto()
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature))
+	assert.Equal(t, 2, app.signed)
+
+	// signing the same vote again reuses the signature, without the device
+	sig := v.Signature
+	v = vote.ToProto()
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.Equal(t, sig, v.Signature)
+	assert.Equal(t, 2, app.signed)
+
+	// a conflicting vote is rejected, even after a restart
+	pv, err = newLedgerPV(newLedgerTestKey(t, app, time.Second), stateFile)
+	require.NoError(t, err)
+	conflicting := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, types.BlockID{})
+	assert.Error(t, pv.SignVote(chainID, conflicting.ToProto()))
+	assert.Equal(t, 2, app.signed)
+
+	require.NoError(t, pv.Close())
+	assert.True(t, app.closed)
+}
+
+func TestLedgerPVConfirmation(t *testing.T) {
+	app := newFakeLedgerApp(t)
+	pv, err := newLedgerPV(newLedgerTestKey(t, app, 50*time.Millisecond),
+		filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, err)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+
+	// the user does not confirm in time
+	app.confirm = make(chan bool, 1)
+	v := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, blockID).ToProto()
+	assert.ErrorIs(t, pv.SignVote(chainID, v), ErrLedgerConfirmationTimeout)
+	assert.Nil(t, v.Signature)
+	assert.True(t, app.closed)
+	app.confirm <- false // unblock the pending request
+
+	// the user rejects the request
+	app.confirm <- false
+	assert.ErrorIs(t, pv.SignVote(chainID, v), ErrLedgerRejected)
+	assert.Nil(t, v.Signature)
+
+	// the user confirms, the connection was reopened
+	app.confirm <- true
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature))
+	assert.False(t, app.closed)
+}
+
+func TestLedgerAppNotOpen(t *testing.T) {
+	_, err := openLedgerKey(log.TestingLogger(), LedgerConfig{}, func() (ledgerTransport, error) {
+		return ledgerTransportFunc(func([]byte) ([]byte, error) {
+			return []byte{0x6e, 0x00}, nil
+		}), nil
+	})
+	assert.ErrorIs(t, err, ErrLedgerAppNotOpen)
+
+	_, err = openLedgerKey(log.TestingLogger(), LedgerConfig{}, func() (ledgerTransport, error) {
+		return nil, errors.New("no device")
+	})
+	assert.Error(t, err)
+}
+
+// ledgerTransportFunc is a ledgerTransport answering with a function.
+type ledgerTransportFunc func([]byte) ([]byte, error)
+
+func (f ledgerTransportFunc) Exchange(apdu []byte) ([]byte, error) { return f(apdu) }
+
+func (f ledgerTransportFunc) Close() error { return nil }
+
+func TestSerializeLedgerHDPath(t *testing.T) {
+	bz, err := serializeLedgerHDPath("m/44'/118'/0'/0'/1'")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		44, 0, 0, 0x80, 118, 0, 0, 0x80, 0, 0, 0, 0x80, 0, 0, 0, 0x80, 1, 0, 0, 0x80,
+	}, bz)
+
+	_, err = serializeLedgerHDPath("m/44'/118'/0'/0'")
+	assert.Error(t, err)
+	_, err = serializeLedgerHDPath("m/44'/118'/0'/0/0")
+	assert.Error(t, err)
+}
+
+func TestLedgerHIDFraming(t *testing.T) {
+	for _, size := range []int{0, 1, 57, 58, 59, 200, 255} {
+		apdu := cmtrand.Bytes(size)
+		packets := wrapLedgerHIDCommand(apdu)
+		for _, packet := range packets {
+			assert.Len(t, packet, ledgerHIDPacketSize)
+		}
+		// the first report holds the size of the APDU, all hold 59 bytes of data
+		assert.Len(t, packets, (size+2+58)/59)
+
+		resp, err := unwrapLedgerHIDResponse(func() ([]byte, error) {
+			packet := packets[0]
+			packets = packets[1:]
+			return packet, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, apdu, resp)
+	}
+
+	// a report of another sequence is rejected
+	packets := wrapLedgerHIDCommand(cmtrand.Bytes(100))
+	_, err := unwrapLedgerHIDResponse(func() ([]byte, error) {
+		packet := packets[0]
+		return packet, nil
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Time taken by the remote signer to sign a message of a given type.
+	SignLatency metrics.Histogram
+	// Number of signing requests of a given type which timed out.
+	SignTimeouts metrics.Counter
+	// Number of signing requests of a given type refused by the remote
+	// signer, e.g. by its double signing protection.
+	SignRefusals metrics.Counter
+	// Number of times the remote signer reconnected.
+	Reconnects metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SignLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_latency_seconds",
+			Help:      "Time taken by the remote signer to sign a message of a given type.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 13),
+		}, append(labels, "message_type")).With(labelsAndValues...),
+		SignTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_timeouts",
+			Help:      "Number of signing requests of a given type which timed out.",
+		}, append(labels, "message_type")).With(labelsAndValues...),
+		SignRefusals: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_refusals",
+			Help:      "Number of signing requests of a given type refused by the remote signer.",
+		}, append(labels, "message_type")).With(labelsAndValues...),
+		Reconnects: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reconnects",
+			Help:      "Number of times the remote signer reconnected.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SignLatency:  discard.NewHistogram(),
+		SignTimeouts: discard.NewCounter(),
+		SignRefusals: discard.NewCounter(),
+		Reconnects:   discard.NewCounter(),
+	}
+}
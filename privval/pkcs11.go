@@ -0,0 +1,201 @@
+package privval
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	secp256k1 "github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// PKCS11Config locates a validator key in a PKCS#11 token.
+type PKCS11Config struct {
+	// Path to the PKCS#11 module (shared library) of the HSM
+	Module string
+	// Label of the token holding the key
+	TokenLabel string
+	// PIN of the token user
+	PIN string
+	// Label of the key pair
+	KeyLabel string
+}
+
+// hsmKey is a validator key held in an HSM, which never leaves it.
+type hsmKey interface {
+	PubKey() crypto.PubKey
+	// Sign returns a signature over msg in the format of the key type.
+	Sign(msg []byte) ([]byte, error)
+	Close() error
+}
+
+// PKCS11PV implements PrivValidator for an ed25519 or secp256k1 key held in an
+// HSM, and used through its PKCS#11 interface (e.g. YubiHSM 2, AWS CloudHSM).
+//
+// Like FilePV, PKCS11PV persists its last sign state to a file, to prevent
+// double signing. The HSM itself only ever signs canonical sign bytes of votes
+// and proposals which passed these checks.
+//
+// PKCS#11 support requires cgo, and is only compiled in with the pkcs11 build
+// tag (make build COMETBFT_BUILD_OPTIONS=pkcs11).
+type PKCS11PV struct {
+	mtx cmtsync.Mutex
+
+	key           hsmKey
+	LastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*PKCS11PV)(nil)
+
+// NewPKCS11PV opens a session with the token holding the key described by cfg,
+// and returns a PKCS11PV signing with it and persisting its last sign state to
+// stateFilePath. The state is loaded from the file if it exists.
+func NewPKCS11PV(cfg PKCS11Config, stateFilePath string) (*PKCS11PV, error) {
+	key, err := openPKCS11Key(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PKCS#11 key: %w", err)
+	}
+	pv, err := newPKCS11PV(key, stateFilePath)
+	if err != nil {
+		_ = key.Close()
+		return nil, err
+	}
+	return pv, nil
+}
+
+// PKCS11PubKey returns the public key of the key described by cfg.
+func PKCS11PubKey(cfg PKCS11Config) (crypto.PubKey, error) {
+	key, err := openPKCS11Key(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PKCS#11 key: %w", err)
+	}
+	pubKey := key.PubKey()
+	return pubKey, key.Close()
+}
+
+func newPKCS11PV(key hsmKey, stateFilePath string) (*PKCS11PV, error) {
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &PKCS11PV{key: key, LastSignState: lss}, nil
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *PKCS11PV) GetPubKey() (crypto.PubKey, error) {
+	return pv.key.PubKey(), nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *PKCS11PV) SignVote(chainID string, vote *cmtproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if err := pv.LastSignState.signVote(chainID, vote, pv.key.Sign); err != nil {
+		return fmt.Errorf("error signing vote: %v", err)
+	}
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *PKCS11PV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if err := pv.LastSignState.signProposal(chainID, proposal, pv.key.Sign); err != nil {
+		return fmt.Errorf("error signing proposal: %v", err)
+	}
+	return nil
+}
+
+// Close closes the session with the token.
+func (pv *PKCS11PV) Close() error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	return pv.key.Close()
+}
+
+// String returns a string representation of the PKCS11PV.
+func (pv *PKCS11PV) String() string {
+	return fmt.Sprintf("PKCS11PV{%v LH:%v, LR:%v, LS:%v}", pv.key.PubKey().Address(), pv.LastSignState.Height,
+		pv.LastSignState.Round, pv.LastSignState.Step)
+}
+
+//-------------------------------------------------------------------------------
+
+// secp256k1OID is the DER encoded object identifier of the secp256k1 curve, as
+// found in the CKA_EC_PARAMS attribute of secp256k1 keys.
+var secp256k1OID = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+// unwrapECPoint returns the point of a CKA_EC_POINT attribute. The attribute
+// is a DER encoded octet string, although some tokens return the raw point.
+func unwrapECPoint(bz []byte, rawSize int) ([]byte, error) {
+	if len(bz) == rawSize {
+		return bz, nil
+	}
+	var point []byte
+	rest, err := asn1.Unmarshal(bz, &point)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EC point: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("invalid EC point: %d trailing bytes", len(rest))
+	}
+	return point, nil
+}
+
+// ed25519PubKeyFromECPoint returns the ed25519 public key of a CKA_EC_POINT
+// attribute.
+func ed25519PubKeyFromECPoint(bz []byte) (crypto.PubKey, error) {
+	point, err := unwrapECPoint(bz, ed25519.PubKeySize)
+	if err != nil {
+		return nil, err
+	}
+	if len(point) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key size %d", len(point))
+	}
+	return ed25519.PubKey(point), nil
+}
+
+// secp256k1PubKeyFromECPoint returns the compressed secp256k1 public key of a
+// CKA_EC_POINT attribute, which holds an uncompressed point.
+func secp256k1PubKeyFromECPoint(bz []byte) (crypto.PubKey, error) {
+	point, err := unwrapECPoint(bz, 65)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := secp256k1.ParsePubKey(point)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+	return tmsecp256k1.PubKey(pub.SerializeCompressed()), nil
+}
+
+// normalizeECDSASignature returns the R || S signature with S in the lower
+// half of the curve order, as HSMs do not enforce it while secp256k1 public
+// keys reject signatures with a high S.
+func normalizeECDSASignature(sig []byte) ([]byte, error) {
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("invalid ECDSA signature size %d", len(sig))
+	}
+	var s secp256k1.ModNScalar
+	if overflow := s.SetByteSlice(sig[32:]); overflow {
+		return nil, fmt.Errorf("invalid ECDSA signature")
+	}
+	if !s.IsOverHalfOrder() {
+		return sig, nil
+	}
+	s.Negate()
+	normalized := make([]byte, 64)
+	copy(normalized, sig[:32])
+	s.PutBytesUnchecked(normalized[32:])
+	return normalized, nil
+}
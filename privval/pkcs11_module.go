@@ -0,0 +1,175 @@
+//go:build pkcs11
+// +build pkcs11
+
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// Constants of PKCS#11 v3.0, missing from the pkcs11 package.
+const (
+	ckkECEdwards = 0x00000040
+	ckmEdDSA     = 0x00001057
+)
+
+// pkcs11Key is an hsmKey used through a PKCS#11 module.
+type pkcs11Key struct {
+	ctx       *pkcs11.Ctx
+	session   pkcs11.SessionHandle
+	privKey   pkcs11.ObjectHandle
+	pubKey    crypto.PubKey
+	mechanism uint
+}
+
+var _ hsmKey = (*pkcs11Key)(nil)
+
+// openPKCS11Key loads the module, logs into the token and finds the key pair.
+func openPKCS11Key(cfg PKCS11Config) (hsmKey, error) {
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+
+	key := &pkcs11Key{ctx: ctx}
+	if err := key.open(cfg); err != nil {
+		_ = key.Close()
+		return nil, err
+	}
+	return key, nil
+}
+
+func (k *pkcs11Key) open(cfg PKCS11Config) error {
+	slot, err := k.findSlot(cfg.TokenLabel)
+	if err != nil {
+		return err
+	}
+	k.session, err = k.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	if err := k.ctx.Login(k.session, pkcs11.CKU_USER, cfg.PIN); err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+
+	k.privKey, err = k.findObject(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	pubKey, err := k.findObject(pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	attrs, err := k.ctx.GetAttributeValue(k.session, pubKey, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+	keyType, ecParams, ecPoint := attrs[0].Value, attrs[1].Value, attrs[2].Value
+
+	switch {
+	case bytes.Equal(keyType, ulong(ckkECEdwards)):
+		k.pubKey, err = ed25519PubKeyFromECPoint(ecPoint)
+		k.mechanism = ckmEdDSA
+	case bytes.Equal(keyType, ulong(pkcs11.CKK_EC)) && bytes.Equal(ecParams, secp256k1OID):
+		k.pubKey, err = secp256k1PubKeyFromECPoint(ecPoint)
+		k.mechanism = pkcs11.CKM_ECDSA
+	default:
+		err = errors.New("unsupported key type, expected ed25519 or secp256k1")
+	}
+	return err
+}
+
+// findSlot returns the slot of the token with the given label.
+func (k *pkcs11Key) findSlot(label string) (uint, error) {
+	slots, err := k.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := k.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read token info: %w", err)
+		}
+		if info.Label == label {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("token %q not found", label)
+}
+
+// findObject returns the only object with the given class and label.
+func (k *pkcs11Key) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := k.ctx.FindObjectsInit(k.session, template); err != nil {
+		return 0, fmt.Errorf("failed to find key %q: %w", label, err)
+	}
+	objs, _, err := k.ctx.FindObjects(k.session, 2)
+	if ferr := k.ctx.FindObjectsFinal(k.session); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find key %q: %w", label, err)
+	}
+	if len(objs) != 1 {
+		return 0, fmt.Errorf("found %d keys labeled %q, expected 1", len(objs), label)
+	}
+	return objs[0], nil
+}
+
+// PubKey implements hsmKey.
+func (k *pkcs11Key) PubKey() crypto.PubKey {
+	return k.pubKey
+}
+
+// Sign implements hsmKey. secp256k1 signatures are made over the SHA-256 hash
+// of msg, and normalized to a low S.
+func (k *pkcs11Key) Sign(msg []byte) ([]byte, error) {
+	if k.mechanism == pkcs11.CKM_ECDSA {
+		msg = crypto.Sha256(msg)
+	}
+	if err := k.ctx.SignInit(k.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(k.mechanism, nil)},
+		k.privKey); err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	sig, err := k.ctx.Sign(k.session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	if k.mechanism == pkcs11.CKM_ECDSA {
+		return normalizeECDSASignature(sig)
+	}
+	return sig, nil
+}
+
+// Close implements hsmKey.
+func (k *pkcs11Key) Close() error {
+	if k.session != 0 {
+		_ = k.ctx.Logout(k.session)
+		_ = k.ctx.CloseSession(k.session)
+	}
+	err := k.ctx.Finalize()
+	k.ctx.Destroy()
+	return err
+}
+
+// ulong encodes v as a CK_ULONG attribute value.
+func ulong(v uint) []byte {
+	return pkcs11.NewAttribute(0, v).Value
+}
@@ -0,0 +1,11 @@
+//go:build !pkcs11
+// +build !pkcs11
+
+package privval
+
+import "errors"
+
+// openPKCS11Key always fails, as PKCS#11 support is not compiled in.
+func openPKCS11Key(PKCS11Config) (hsmKey, error) {
+	return nil, errors.New("PKCS#11 support is not compiled in, build with COMETBFT_BUILD_OPTIONS=pkcs11")
+}
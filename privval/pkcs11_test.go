@@ -0,0 +1,130 @@
+package privval
+
+import (
+	"encoding/asn1"
+	"path/filepath"
+	"testing"
+
+	secp256k1 "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fakeHSMKey is an hsmKey holding its private key in memory.
+type fakeHSMKey struct {
+	crypto.PrivKey
+	signed int
+}
+
+func (k *fakeHSMKey) Sign(msg []byte) ([]byte, error) {
+	k.signed++
+	return k.PrivKey.Sign(msg)
+}
+
+func (k *fakeHSMKey) Close() error { return nil }
+
+func TestPKCS11PVSign(t *testing.T) {
+	key := &fakeHSMKey{PrivKey: ed25519.GenPrivKey()}
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	pv, err := newPKCS11PV(key, stateFile)
+	require.NoError(t, err)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+
+	proposal := newProposal(1, 0, blockID).ToProto()
+	require.NoError(t, pv.SignProposal(chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	vote := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, blockID)
+	v := vote.ToProto()
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature))
+	assert.Equal(t, 2, key.signed)
+
+	// signing the same vote again reuses the signature, without the HSM
+	sig := v.Signature
+	v = vote.ToProto()
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.Equal(t, sig, v.Signature)
+	assert.Equal(t, 2, key.signed)
+
+	// a conflicting vote is rejected, even after a restart
+	pv, err = newPKCS11PV(key, stateFile)
+	require.NoError(t, err)
+	conflicting := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, types.BlockID{})
+	assert.Error(t, pv.SignVote(chainID, conflicting.ToProto()))
+	assert.Equal(t, 2, key.signed)
+}
+
+func TestPKCS11PVDisabled(t *testing.T) {
+	// the tests run without the pkcs11 build tag
+	_, err := NewPKCS11PV(PKCS11Config{}, filepath.Join(t.TempDir(), "state.json"))
+	assert.Error(t, err)
+}
+
+func TestEd25519PubKeyFromECPoint(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	wrapped, err := asn1.Marshal(pubKey.Bytes())
+	require.NoError(t, err)
+	for _, bz := range [][]byte{wrapped, pubKey.Bytes()} {
+		pk, err := ed25519PubKeyFromECPoint(bz)
+		require.NoError(t, err)
+		assert.Equal(t, pubKey, pk)
+	}
+
+	_, err = ed25519PubKeyFromECPoint(wrapped[:len(wrapped)-1])
+	assert.Error(t, err)
+}
+
+func TestSecp256k1PubKeyFromECPoint(t *testing.T) {
+	privKey, err := secp256k1.NewPrivateKey()
+	require.NoError(t, err)
+	uncompressed := privKey.PubKey().SerializeUncompressed()
+	expected := tmsecp256k1.PubKey(privKey.PubKey().SerializeCompressed())
+
+	wrapped, err := asn1.Marshal(uncompressed)
+	require.NoError(t, err)
+	for _, bz := range [][]byte{wrapped, uncompressed} {
+		pk, err := secp256k1PubKeyFromECPoint(bz)
+		require.NoError(t, err)
+		assert.Equal(t, expected, pk)
+	}
+}
+
+func TestNormalizeECDSASignature(t *testing.T) {
+	privKey := tmsecp256k1.GenPrivKey()
+	msg := []byte("hello")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	// negate S, as an HSM may return
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(sig[32:])
+	s.Negate()
+	highS := make([]byte, 64)
+	copy(highS, sig[:32])
+	s.PutBytesUnchecked(highS[32:])
+	assert.False(t, privKey.PubKey().VerifySignature(msg, highS))
+
+	for _, bz := range [][]byte{sig, highS} {
+		normalized, err := normalizeECDSASignature(bz)
+		require.NoError(t, err)
+		assert.Equal(t, sig, normalized)
+		assert.True(t, privKey.PubKey().VerifySignature(msg, normalized))
+	}
+
+	_, err = normalizeECDSASignature(sig[:63])
+	assert.Error(t, err)
+}
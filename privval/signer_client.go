@@ -1,6 +1,7 @@
 package privval
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,25 +12,42 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// SignerClientOption sets an optional parameter on the SignerClient.
+type SignerClientOption func(*SignerClient)
+
+// SignerClientMetrics sets the metrics updated by the SignerClient.
+func SignerClientMetrics(metrics *Metrics) SignerClientOption {
+	return func(sc *SignerClient) { sc.metrics = metrics }
+}
+
 // SignerClient implements PrivValidator.
 // Handles remote validator connections that provide signing services
 type SignerClient struct {
 	endpoint *SignerListenerEndpoint
 	chainID  string
+	metrics  *Metrics
 }
 
 var _ types.PrivValidator = (*SignerClient)(nil)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
-func NewSignerClient(endpoint *SignerListenerEndpoint, chainID string) (*SignerClient, error) {
+func NewSignerClient(
+	endpoint *SignerListenerEndpoint,
+	chainID string,
+	options ...SignerClientOption,
+) (*SignerClient, error) {
 	if !endpoint.IsRunning() {
 		if err := endpoint.Start(); err != nil {
 			return nil, fmt.Errorf("failed to start listener endpoint: %w", err)
 		}
 	}
 
-	return &SignerClient{endpoint: endpoint, chainID: chainID}, nil
+	sc := &SignerClient{endpoint: endpoint, chainID: chainID, metrics: NopMetrics()}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc, nil
 }
 
 // Close closes the underlying connection
@@ -92,7 +110,8 @@ func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
 
 // SignVote requests a remote signer to sign a vote
 func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
-	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
+	response, err := sc.sendSignRequest("vote",
+		mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
 	if err != nil {
 		return err
 	}
@@ -102,6 +121,7 @@ func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
 		return ErrUnexpectedResponse
 	}
 	if resp.Error != nil {
+		sc.metrics.SignRefusals.With("message_type", "vote").Add(1)
 		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
 	}
 
@@ -112,7 +132,7 @@ func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
 
 // SignProposal requests a remote signer to sign a proposal
 func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
-	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+	response, err := sc.sendSignRequest("proposal", mustWrapMsg(
 		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
 	))
 	if err != nil {
@@ -124,6 +144,7 @@ func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal
 		return ErrUnexpectedResponse
 	}
 	if resp.Error != nil {
+		sc.metrics.SignRefusals.With("message_type", "proposal").Add(1)
 		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
 	}
 
@@ -131,3 +152,18 @@ func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal
 
 	return nil
 }
+
+// sendSignRequest sends a signing request for a message of the given type,
+// recording its latency, or whether it timed out.
+func (sc *SignerClient) sendSignRequest(msgType string, request privvalproto.Message) (*privvalproto.Message, error) {
+	start := time.Now()
+	response, err := sc.endpoint.SendRequest(request)
+	if err != nil {
+		if errors.Is(err, ErrReadTimeout) || errors.Is(err, ErrWriteTimeout) || errors.Is(err, ErrConnectionTimeout) {
+			sc.metrics.SignTimeouts.With("message_type", msgType).Add(1)
+		}
+		return nil, err
+	}
+	sc.metrics.SignLatency.With("message_type", msgType).Observe(time.Since(start).Seconds())
+	return response, nil
+}
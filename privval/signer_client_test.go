@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -389,6 +390,63 @@ func TestSignerSignVoteErrors(t *testing.T) {
 	}
 }
 
+// countingMetric counts the values added to, or observed by, a metric and all
+// its labeled variants.
+type countingMetric struct {
+	count *int
+}
+
+func (m countingMetric) Add(float64)     { *m.count++ }
+func (m countingMetric) Observe(float64) { *m.count++ }
+
+type countingCounter struct{ countingMetric }
+
+func (c countingCounter) With(...string) metrics.Counter { return c }
+
+type countingHistogram struct{ countingMetric }
+
+func (h countingHistogram) With(...string) metrics.Histogram { return h }
+
+func TestSignerClientMetrics(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		var signed, refused int
+		tc.signerClient.metrics = NopMetrics()
+		tc.signerClient.metrics.SignLatency = countingHistogram{countingMetric{&signed}}
+		tc.signerClient.metrics.SignRefusals = countingCounter{countingMetric{&refused}}
+
+		hash := cmtrand.Bytes(tmhash.Size)
+		vote := &types.Vote{
+			Type:             cmtproto.PrecommitType,
+			Height:           1,
+			Round:            2,
+			BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+			Timestamp:        time.Now(),
+			ValidatorAddress: cmtrand.Bytes(crypto.AddressSize),
+			ValidatorIndex:   1,
+		}
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, vote.ToProto()))
+		assert.Equal(t, 1, signed)
+		assert.Equal(t, 0, refused)
+
+		tc.signerServer.privVal = types.NewErroringMockPV()
+		require.Error(t, tc.signerClient.SignVote(tc.chainID, vote.ToProto()))
+		assert.Equal(t, 2, signed)
+		assert.Equal(t, 1, refused)
+	}
+}
+
 func brokenHandler(privVal types.PrivValidator, request privvalproto.Message,
 	chainID string) (privvalproto.Message, error) {
 	var res privvalproto.Message
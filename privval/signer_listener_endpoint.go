@@ -22,6 +22,12 @@ func SignerListenerEndpointTimeoutReadWrite(timeout time.Duration) SignerListene
 	return func(sl *SignerListenerEndpoint) { sl.signerEndpoint.timeoutReadWrite = timeout }
 }
 
+// SignerListenerEndpointMetrics sets the metrics updated by the
+// SignerListenerEndpoint.
+func SignerListenerEndpointMetrics(metrics *Metrics) SignerListenerEndpointOption {
+	return func(sl *SignerListenerEndpoint) { sl.metrics = metrics }
+}
+
 // SignerListenerEndpoint listens for an external process to dial in and keeps
 // the connection alive by dropping and reconnecting.
 //
@@ -39,6 +45,8 @@ type SignerListenerEndpoint struct {
 	pingInterval  time.Duration
 
 	instanceMtx cmtsync.Mutex // Ensures instance public methods access, i.e. SendRequest
+
+	metrics *Metrics
 }
 
 // NewSignerListenerEndpoint returns an instance of SignerListenerEndpoint.
@@ -50,6 +58,7 @@ func NewSignerListenerEndpoint(
 	sl := &SignerListenerEndpoint{
 		listener:      listener,
 		timeoutAccept: defaultTimeoutAcceptSeconds * time.Second,
+		metrics:       NopMetrics(),
 	}
 
 	sl.BaseService = *service.NewBaseService(logger, "SignerListenerEndpoint", sl)
@@ -178,6 +187,7 @@ func (sl *SignerListenerEndpoint) triggerReconnect() {
 }
 
 func (sl *SignerListenerEndpoint) serviceLoop() {
+	connected := false
 	for {
 		select {
 		case <-sl.connectRequestCh:
@@ -185,6 +195,10 @@ func (sl *SignerListenerEndpoint) serviceLoop() {
 				conn, err := sl.acceptNewConnection()
 				if err == nil {
 					sl.Logger.Info("SignerListener: Connected")
+					if connected {
+						sl.metrics.Reconnects.Add(1)
+					}
+					connected = true
 
 					// We have a good connection, wait for someone that needs one otherwise cancellation
 					select {
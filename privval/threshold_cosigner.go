@@ -0,0 +1,263 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/threshold"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/protoio"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tempfile"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// CosignerCommitRequest asks a cosigner for a nonce commitment for the given
+// height, round and step (first round of the threshold signing protocol).
+type CosignerCommitRequest struct {
+	Height int64 `json:"height"`
+	Round  int32 `json:"round"`
+	Step   int8  `json:"step"`
+}
+
+// CosignerSignRequest asks a cosigner for its signature share over SignBytes,
+// using the nonce it committed to for the same height, round and step (second
+// round of the threshold signing protocol).
+type CosignerSignRequest struct {
+	ChainID     string                 `json:"chain_id"`
+	Height      int64                  `json:"height"`
+	Round       int32                  `json:"round"`
+	Step        int8                   `json:"step"`
+	SignBytes   []byte                 `json:"sign_bytes"`
+	Commitments []threshold.Commitment `json:"commitments"`
+}
+
+// Cosigner holds a share of a threshold validator key. See
+// spec/consensus/threshold-signing.md for the protocol.
+type Cosigner interface {
+	// ID returns the ID of the cosigner's key share.
+	ID() int
+	// Commit returns a commitment to a fresh nonce.
+	Commit(ctx context.Context, req CosignerCommitRequest) (threshold.Commitment, error)
+	// Sign returns a signature share, provided the sign bytes do not conflict
+	// with anything the cosigner signed before.
+	Sign(ctx context.Context, req CosignerSignRequest) (threshold.SignatureShare, error)
+}
+
+type hrs struct {
+	height int64
+	round  int32
+	step   int8
+}
+
+func (a hrs) less(b hrs) bool {
+	if a.height != b.height {
+		return a.height < b.height
+	}
+	if a.round != b.round {
+		return a.round < b.round
+	}
+	return a.step < b.step
+}
+
+// LocalCosigner is a Cosigner holding its key share in memory. It keeps its
+// own last sign state, so it refuses to take part in a double sign even if the
+// coordinator asking for signature shares is compromised.
+type LocalCosigner struct {
+	mtx           cmtsync.Mutex
+	share         threshold.KeyShare
+	lastSignState FilePVLastSignState
+	nonces        map[hrs]*threshold.Nonce
+}
+
+var _ Cosigner = (*LocalCosigner)(nil)
+
+// NewLocalCosigner returns a LocalCosigner for the given key share, which
+// persists its last sign state to stateFilePath. The state is loaded from the
+// file if it exists.
+func NewLocalCosigner(share threshold.KeyShare, stateFilePath string) (*LocalCosigner, error) {
+	if err := share.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid key share: %w", err)
+	}
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalCosigner{
+		share:         share,
+		lastSignState: lss,
+		nonces:        make(map[hrs]*threshold.Nonce),
+	}, nil
+}
+
+// ID implements Cosigner.
+func (c *LocalCosigner) ID() int {
+	return c.share.ID
+}
+
+// Commit implements Cosigner. A new commitment for the same height, round and
+// step replaces the previous one.
+func (c *LocalCosigner) Commit(_ context.Context, req CosignerCommitRequest) (threshold.Commitment, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, err := c.lastSignState.CheckHRS(req.Height, req.Round, req.Step); err != nil {
+		return threshold.Commitment{}, err
+	}
+
+	nonce, err := threshold.NewNonce(c.share.ID, crypto.CReader())
+	if err != nil {
+		return threshold.Commitment{}, err
+	}
+
+	key := hrs{req.Height, req.Round, req.Step}
+	for k := range c.nonces {
+		if k.less(key) {
+			delete(c.nonces, k)
+		}
+	}
+	c.nonces[key] = nonce
+	return nonce.Commitment(), nil
+}
+
+// Sign implements Cosigner. The nonce committed to for the request's height,
+// round and step is consumed, whether signing succeeds or not.
+func (c *LocalCosigner) Sign(_ context.Context, req CosignerSignRequest) (threshold.SignatureShare, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := hrs{req.Height, req.Round, req.Step}
+	nonce, ok := c.nonces[key]
+	if !ok {
+		return threshold.SignatureShare{}, fmt.Errorf("no nonce committed for height %d round %d step %d",
+			req.Height, req.Round, req.Step)
+	}
+	delete(c.nonces, key)
+
+	if !containsCommitment(req.Commitments, nonce.Commitment()) {
+		return threshold.SignatureShare{}, errors.New("commitments do not include ours")
+	}
+	if err := checkSignBytes(req); err != nil {
+		return threshold.SignatureShare{}, err
+	}
+
+	sameHRS, err := c.lastSignState.CheckHRS(req.Height, req.Round, req.Step)
+	if err != nil {
+		return threshold.SignatureShare{}, err
+	}
+	if sameHRS && !bytes.Equal(req.SignBytes, c.lastSignState.SignBytes) {
+		return threshold.SignatureShare{}, errors.New("conflicting data")
+	}
+
+	share, err := threshold.Sign(c.share, nonce, req.SignBytes, req.Commitments)
+	if err != nil {
+		return threshold.SignatureShare{}, err
+	}
+	c.lastSignState.saveSigned(req.Height, req.Round, req.Step, req.SignBytes, share.Z)
+	return share, nil
+}
+
+func containsCommitment(commitments []threshold.Commitment, c threshold.Commitment) bool {
+	for _, other := range commitments {
+		if other.ID == c.ID {
+			return bytes.Equal(other.Hiding, c.Hiding) && bytes.Equal(other.Binding, c.Binding)
+		}
+	}
+	return false
+}
+
+// checkSignBytes verifies that the sign bytes are a canonical vote or proposal
+// for the chain, height, round and step of the request, so that the cosigner's
+// last sign state tracks what it actually signs.
+func checkSignBytes(req CosignerSignRequest) error {
+	var (
+		height  int64
+		round   int64
+		chainID string
+		msgType cmtproto.SignedMsgType
+	)
+	if req.Step == stepPropose {
+		var proposal cmtproto.CanonicalProposal
+		if err := protoio.UnmarshalDelimited(req.SignBytes, &proposal); err != nil {
+			return fmt.Errorf("sign bytes are not a proposal: %w", err)
+		}
+		height, round, chainID, msgType = proposal.Height, proposal.Round, proposal.ChainID, proposal.Type
+	} else {
+		var vote cmtproto.CanonicalVote
+		if err := protoio.UnmarshalDelimited(req.SignBytes, &vote); err != nil {
+			return fmt.Errorf("sign bytes are not a vote: %w", err)
+		}
+		height, round, chainID, msgType = vote.Height, vote.Round, vote.ChainID, vote.Type
+	}
+
+	switch {
+	case chainID != req.ChainID:
+		return fmt.Errorf("sign bytes are for chain %q, expected %q", chainID, req.ChainID)
+	case height != req.Height || round != int64(req.Round):
+		return fmt.Errorf("sign bytes are for height %d round %d, expected height %d round %d",
+			height, round, req.Height, req.Round)
+	case msgType != stepToMsgType(req.Step):
+		return fmt.Errorf("sign bytes are of type %v, expected step %d", msgType, req.Step)
+	}
+	return nil
+}
+
+func stepToMsgType(step int8) cmtproto.SignedMsgType {
+	switch step {
+	case stepPropose:
+		return cmtproto.ProposalType
+	case stepPrevote:
+		return cmtproto.PrevoteType
+	case stepPrecommit:
+		return cmtproto.PrecommitType
+	default:
+		return cmtproto.UnknownType
+	}
+}
+
+// loadOrGenLastSignState loads the last sign state from stateFilePath, or
+// creates and saves an empty one if the file does not exist.
+func loadOrGenLastSignState(stateFilePath string) (FilePVLastSignState, error) {
+	lss := FilePVLastSignState{Step: stepNone, filePath: stateFilePath}
+	if !cmtos.FileExists(stateFilePath) {
+		lss.Save()
+		return lss, nil
+	}
+	stateJSONBytes, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return lss, err
+	}
+	if err := cmtjson.Unmarshal(stateJSONBytes, &lss); err != nil {
+		return lss, fmt.Errorf("error reading last sign state from %v: %w", stateFilePath, err)
+	}
+	lss.filePath = stateFilePath
+	return lss, nil
+}
+
+// LoadKeyShare reads a threshold key share from a JSON file.
+func LoadKeyShare(filePath string) (threshold.KeyShare, error) {
+	var share threshold.KeyShare
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return share, err
+	}
+	if err := cmtjson.Unmarshal(bz, &share); err != nil {
+		return share, fmt.Errorf("error reading key share from %v: %w", filePath, err)
+	}
+	return share, share.ValidateBasic()
+}
+
+// SaveKeyShare writes a threshold key share to a JSON file, readable only by
+// its owner.
+func SaveKeyShare(share threshold.KeyShare, filePath string) error {
+	bz, err := cmtjson.MarshalIndent(share, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, bz, 0o600)
+}
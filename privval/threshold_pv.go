@@ -0,0 +1,233 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/threshold"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DefaultCosignerTimeout is the default time allowed for each round of the
+// threshold signing protocol.
+const DefaultCosignerTimeout = 3 * time.Second
+
+// ThresholdPV implements PrivValidator for an ed25519 key which is split
+// between several cosigners, so that the key never exists in one place. It
+// acts as the coordinator of the signing protocol: any threshold of the
+// cosigners produce a standard ed25519 signature together.
+//
+// Like FilePV, ThresholdPV persists its last sign state to prevent double
+// signing. Every cosigner keeps its own state as well, so an attacker
+// controlling the coordinator cannot obtain a double sign either.
+type ThresholdPV struct {
+	mtx cmtsync.Mutex
+
+	pubKey             crypto.PubKey
+	threshold          int
+	verificationShares [][]byte
+	cosigners          []Cosigner
+	timeout            time.Duration
+
+	LastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*ThresholdPV)(nil)
+
+// NewThresholdPV returns a ThresholdPV for the group key of share, signing with
+// the given cosigners and persisting its last sign state to stateFilePath.
+// Only the public parameters of share are used: the local share, if any, must
+// be passed as a LocalCosigner.
+func NewThresholdPV(
+	share threshold.KeyShare,
+	cosigners []Cosigner,
+	stateFilePath string,
+	timeout time.Duration,
+) (*ThresholdPV, error) {
+	if err := share.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid key share: %w", err)
+	}
+	if len(cosigners) < share.Threshold {
+		return nil, fmt.Errorf("need at least %d cosigners, got %d", share.Threshold, len(cosigners))
+	}
+	ids := make(map[int]bool, len(cosigners))
+	for _, c := range cosigners {
+		if c.ID() < 1 || c.ID() > share.Total {
+			return nil, fmt.Errorf("invalid cosigner ID %d", c.ID())
+		}
+		if ids[c.ID()] {
+			return nil, fmt.Errorf("duplicate cosigner ID %d", c.ID())
+		}
+		ids[c.ID()] = true
+	}
+
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ThresholdPV{
+		pubKey:             ed25519.PubKey(share.PubKey),
+		threshold:          share.Threshold,
+		verificationShares: share.VerificationShares,
+		cosigners:          cosigners,
+		timeout:            timeout,
+		LastSignState:      lss,
+	}, nil
+}
+
+// GetPubKey returns the group public key.
+// Implements PrivValidator.
+func (pv *ThresholdPV) GetPubKey() (crypto.PubKey, error) {
+	return pv.pubKey, nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *ThresholdPV) SignVote(chainID string, vote *cmtproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	sign := pv.thresholdSign(chainID, vote.Height, vote.Round, voteToStep(vote))
+	if err := pv.LastSignState.signVote(chainID, vote, sign); err != nil {
+		return fmt.Errorf("error signing vote: %v", err)
+	}
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *ThresholdPV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	sign := pv.thresholdSign(chainID, proposal.Height, proposal.Round, stepPropose)
+	if err := pv.LastSignState.signProposal(chainID, proposal, sign); err != nil {
+		return fmt.Errorf("error signing proposal: %v", err)
+	}
+	return nil
+}
+
+// String returns a string representation of the ThresholdPV.
+func (pv *ThresholdPV) String() string {
+	return fmt.Sprintf("ThresholdPV{%v %d/%d LH:%v, LR:%v, LS:%v}", pv.pubKey.Address(), pv.threshold,
+		len(pv.verificationShares), pv.LastSignState.Height, pv.LastSignState.Round, pv.LastSignState.Step)
+}
+
+// thresholdSign returns a signFunc running the two rounds of the signing
+// protocol: collecting nonce commitments from the first threshold cosigners
+// to respond, then collecting their signature shares and aggregating them.
+func (pv *ThresholdPV) thresholdSign(chainID string, height int64, round int32, step int8) signFunc {
+	return func(signBytes []byte) ([]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), pv.timeout)
+		defer cancel()
+
+		signers, commitments, err := pv.collectCommitments(ctx, CosignerCommitRequest{
+			Height: height,
+			Round:  round,
+			Step:   step,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		req := CosignerSignRequest{
+			ChainID:     chainID,
+			Height:      height,
+			Round:       round,
+			Step:        step,
+			SignBytes:   signBytes,
+			Commitments: commitments,
+		}
+		shares := make([]threshold.SignatureShare, len(signers))
+		errs := make([]error, len(signers))
+		var wg sync.WaitGroup
+		for i, c := range signers {
+			wg.Add(1)
+			go func(i int, c Cosigner) {
+				defer wg.Done()
+				shares[i], errs[i] = c.Sign(ctx, req)
+			}(i, c)
+		}
+		wg.Wait()
+
+		var failed []string
+		for i, err := range errs {
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("cosigner %d: %v", signers[i].ID(), err))
+			}
+		}
+		if len(failed) > 0 {
+			return nil, fmt.Errorf("failed to collect signature shares: %s", strings.Join(failed, "; "))
+		}
+
+		return threshold.Aggregate(pv.pubKey.Bytes(), pv.verificationShares, signBytes, commitments, shares)
+	}
+}
+
+// collectCommitments asks all cosigners for a nonce commitment, and returns the
+// first threshold of them to respond along with their commitments.
+func (pv *ThresholdPV) collectCommitments(
+	ctx context.Context,
+	req CosignerCommitRequest,
+) ([]Cosigner, []threshold.Commitment, error) {
+	type result struct {
+		cosigner   Cosigner
+		commitment threshold.Commitment
+		err        error
+	}
+	results := make(chan result, len(pv.cosigners))
+	for _, c := range pv.cosigners {
+		go func(c Cosigner) {
+			commitment, err := c.Commit(ctx, req)
+			if err == nil && commitment.ID != c.ID() {
+				err = errors.New("commitment has the wrong ID")
+			}
+			results <- result{c, commitment, err}
+		}(c)
+	}
+
+	var (
+		signers     []Cosigner
+		commitments []threshold.Commitment
+		failed      []string
+	)
+	for i := 0; i < len(pv.cosigners) && len(signers) < pv.threshold; i++ {
+		res := <-results
+		if res.err != nil {
+			failed = append(failed, fmt.Sprintf("cosigner %d: %v", res.cosigner.ID(), res.err))
+			continue
+		}
+		signers = append(signers, res.cosigner)
+		commitments = append(commitments, res.commitment)
+	}
+	if len(signers) < pv.threshold {
+		return nil, nil, fmt.Errorf("only %d of %d required cosigners responded: %s",
+			len(signers), pv.threshold, strings.Join(failed, "; "))
+	}
+
+	// the signing package expects commitments ordered by ID
+	sort.Sort(cosignersByID{signers, commitments})
+	return signers, commitments, nil
+}
+
+type cosignersByID struct {
+	cosigners   []Cosigner
+	commitments []threshold.Commitment
+}
+
+func (s cosignersByID) Len() int           { return len(s.cosigners) }
+func (s cosignersByID) Less(i, j int) bool { return s.cosigners[i].ID() < s.cosigners[j].ID() }
+func (s cosignersByID) Swap(i, j int) {
+	s.cosigners[i], s.cosigners[j] = s.cosigners[j], s.cosigners[i]
+	s.commitments[i], s.commitments[j] = s.commitments[j], s.commitments[i]
+}
@@ -0,0 +1,177 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/threshold"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+type failingCosigner struct {
+	id int
+}
+
+func (fc failingCosigner) ID() int { return fc.id }
+
+func (fc failingCosigner) Commit(context.Context, CosignerCommitRequest) (threshold.Commitment, error) {
+	return threshold.Commitment{}, errors.New("unavailable")
+}
+
+func (fc failingCosigner) Sign(context.Context, CosignerSignRequest) (threshold.SignatureShare, error) {
+	return threshold.SignatureShare{}, errors.New("unavailable")
+}
+
+func newTestThresholdPV(t *testing.T, cosigners func(shares []threshold.KeyShare) []Cosigner) *ThresholdPV {
+	shares, err := threshold.GenKey(2, 3)
+	require.NoError(t, err)
+	pv, err := NewThresholdPV(shares[0], cosigners(shares), filepath.Join(t.TempDir(), "state.json"),
+		DefaultCosignerTimeout)
+	require.NoError(t, err)
+	return pv
+}
+
+func newTestLocalCosigner(t *testing.T, share threshold.KeyShare) *LocalCosigner {
+	c, err := NewLocalCosigner(share, filepath.Join(t.TempDir(), "cosigner_state.json"))
+	require.NoError(t, err)
+	return c
+}
+
+func TestThresholdPVSign(t *testing.T) {
+	pv := newTestThresholdPV(t, func(shares []threshold.KeyShare) []Cosigner {
+		return []Cosigner{
+			newTestLocalCosigner(t, shares[0]),
+			failingCosigner{id: 2},
+			newTestLocalCosigner(t, shares[2]),
+		}
+	})
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+
+	proposal := newProposal(1, 0, blockID)
+	pbp := proposal.ToProto()
+	require.NoError(t, pv.SignProposal(chainID, pbp))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, pbp), pbp.Signature))
+
+	vote := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType, blockID)
+	v := vote.ToProto()
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature))
+
+	// signing the same vote again returns the same signature
+	sig := v.Signature
+	v = vote.ToProto()
+	require.NoError(t, pv.SignVote(chainID, v))
+	assert.Equal(t, sig, v.Signature)
+
+	// a conflicting vote is rejected
+	conflicting := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrevoteType,
+		types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}})
+	assert.Error(t, pv.SignVote(chainID, conflicting.ToProto()))
+}
+
+func TestThresholdPVNotEnoughCosigners(t *testing.T) {
+	pv := newTestThresholdPV(t, func(shares []threshold.KeyShare) []Cosigner {
+		return []Cosigner{
+			newTestLocalCosigner(t, shares[0]),
+			failingCosigner{id: 2},
+			failingCosigner{id: 3},
+		}
+	})
+
+	vote := newVote(cmtrand.Bytes(20), 0, 1, 0, cmtproto.PrevoteType, types.BlockID{})
+	assert.Error(t, pv.SignVote("mychainid", vote.ToProto()))
+}
+
+func TestLocalCosignerRejectsDoubleSign(t *testing.T) {
+	shares, err := threshold.GenKey(2, 2)
+	require.NoError(t, err)
+	c1, c2 := newTestLocalCosigner(t, shares[0]), newTestLocalCosigner(t, shares[1])
+
+	chainID := "mychainid"
+	sign := func(vote *types.Vote) error {
+		ctx := context.Background()
+		commitReq := CosignerCommitRequest{Height: vote.Height, Round: vote.Round, Step: stepPrevote}
+		cm1, err := c1.Commit(ctx, commitReq)
+		require.NoError(t, err)
+		cm2, err := c2.Commit(ctx, commitReq)
+		require.NoError(t, err)
+		_, err = c1.Sign(ctx, CosignerSignRequest{
+			ChainID:     chainID,
+			Height:      vote.Height,
+			Round:       vote.Round,
+			Step:        stepPrevote,
+			SignBytes:   types.VoteSignBytes(chainID, vote.ToProto()),
+			Commitments: []threshold.Commitment{cm1, cm2},
+		})
+		return err
+	}
+
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	vote := newVote(cmtrand.Bytes(20), 0, 2, 0, cmtproto.PrevoteType, blockID)
+	require.NoError(t, sign(vote))
+	require.NoError(t, sign(vote))
+
+	// the coordinator bypassing its own checks must not get a conflicting share
+	vote.BlockID = types.BlockID{}
+	assert.Error(t, sign(vote))
+
+	// nor a nonce for an earlier height
+	ctx := context.Background()
+	_, err = c1.Commit(ctx, CosignerCommitRequest{Height: 1, Step: stepPrevote})
+	assert.Error(t, err)
+
+	// nor a share for sign bytes not matching the request
+	cm, err := c1.Commit(ctx, CosignerCommitRequest{Height: 3, Step: stepPrevote})
+	require.NoError(t, err)
+	vote = newVote(cmtrand.Bytes(20), 0, 3, 0, cmtproto.PrecommitType, blockID)
+	_, err = c1.Sign(ctx, CosignerSignRequest{
+		ChainID:     chainID,
+		Height:      3,
+		Step:        stepPrevote,
+		SignBytes:   types.VoteSignBytes(chainID, vote.ToProto()),
+		Commitments: []threshold.Commitment{cm},
+	})
+	assert.Error(t, err)
+}
+
+func TestRemoteCosigner(t *testing.T) {
+	pv := newTestThresholdPV(t, func(shares []threshold.KeyShare) []Cosigner {
+		cosigners := []Cosigner{newTestLocalCosigner(t, shares[0])}
+		for _, share := range shares[1:] {
+			srv := NewCosignerServer(log.TestingLogger(), "tcp://127.0.0.1:0", newTestLocalCosigner(t, share))
+			require.NoError(t, srv.Start())
+			t.Cleanup(func() {
+				if err := srv.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+			rc, err := NewRemoteCosigner(share.ID, "tcp://"+srv.Addr().String())
+			require.NoError(t, err)
+			cosigners = append(cosigners, rc)
+		}
+		return cosigners
+	})
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	for _, typ := range []cmtproto.SignedMsgType{cmtproto.PrevoteType, cmtproto.PrecommitType} {
+		v := newVote(pubKey.Address(), 0, 1, 0, typ, blockID).ToProto()
+		require.NoError(t, pv.SignVote(chainID, v))
+		assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature))
+	}
+}
@@ -0,0 +1,139 @@
+package privval
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/tendermint/tendermint/crypto/threshold"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const (
+	cosignerCommitMethod = "cosigner_commit"
+	cosignerSignMethod   = "cosigner_sign"
+)
+
+// RemoteCosigner is a Cosigner reached over JSON-RPC, served by a
+// CosignerServer.
+type RemoteCosigner struct {
+	id     int
+	client *rpcclient.Client
+}
+
+var _ Cosigner = (*RemoteCosigner)(nil)
+
+// NewRemoteCosigner returns a RemoteCosigner for the cosigner with the given
+// share ID, listening on addr (e.g. tcp://10.0.0.2:26660).
+func NewRemoteCosigner(id int, addr string) (*RemoteCosigner, error) {
+	client, err := rpcclient.New(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteCosigner{id: id, client: client}, nil
+}
+
+// ID implements Cosigner.
+func (rc *RemoteCosigner) ID() int {
+	return rc.id
+}
+
+// Commit implements Cosigner.
+func (rc *RemoteCosigner) Commit(ctx context.Context, req CosignerCommitRequest) (threshold.Commitment, error) {
+	var commitment threshold.Commitment
+	_, err := rc.client.Call(ctx, cosignerCommitMethod, map[string]interface{}{"request": req}, &commitment)
+	return commitment, err
+}
+
+// Sign implements Cosigner.
+func (rc *RemoteCosigner) Sign(ctx context.Context, req CosignerSignRequest) (threshold.SignatureShare, error) {
+	var share threshold.SignatureShare
+	_, err := rc.client.Call(ctx, cosignerSignMethod, map[string]interface{}{"request": req}, &share)
+	return share, err
+}
+
+//-------------------------------------------------------------------------------
+
+// CosignerServer exposes a Cosigner over JSON-RPC, so a ThresholdPV on
+// another host can use it as a RemoteCosigner.
+//
+// NOTE: the server does not authenticate its clients. It must only be
+// reachable from the validator node, e.g. through a private network or a VPN.
+type CosignerServer struct {
+	service.BaseService
+
+	listenAddr string
+	cosigner   Cosigner
+	listener   net.Listener
+}
+
+// NewCosignerServer returns a CosignerServer serving cosigner on listenAddr
+// (e.g. tcp://0.0.0.0:26660).
+func NewCosignerServer(logger log.Logger, listenAddr string, cosigner Cosigner) *CosignerServer {
+	cs := &CosignerServer{
+		listenAddr: listenAddr,
+		cosigner:   cosigner,
+	}
+	cs.BaseService = *service.NewBaseService(logger, "CosignerServer", cs)
+	return cs
+}
+
+// OnStart implements service.Service.
+func (cs *CosignerServer) OnStart() error {
+	config := rpcserver.DefaultConfig()
+	listener, err := rpcserver.Listen(cs.listenAddr, config)
+	if err != nil {
+		return err
+	}
+	cs.listener = listener
+
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		cosignerCommitMethod: rpcserver.NewRPCFunc(cs.commit, "request"),
+		cosignerSignMethod:   rpcserver.NewRPCFunc(cs.sign, "request"),
+	}, cs.Logger)
+
+	go func() {
+		if err := rpcserver.Serve(listener, mux, cs.Logger, config); err != nil && cs.IsRunning() {
+			cs.Logger.Error("Cosigner server stopped", "err", err)
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (cs *CosignerServer) OnStop() {
+	if err := cs.listener.Close(); err != nil {
+		cs.Logger.Error("Error closing cosigner listener", "err", err)
+	}
+}
+
+// Addr returns the address the server is listening on. It is only valid once
+// the server has started.
+func (cs *CosignerServer) Addr() net.Addr {
+	return cs.listener.Addr()
+}
+
+func (cs *CosignerServer) commit(ctx *rpctypes.Context, req CosignerCommitRequest) (*threshold.Commitment, error) {
+	commitment, err := cs.cosigner.Commit(ctx.Context(), req)
+	if err != nil {
+		cs.Logger.Error("Failed to commit to nonce", "height", req.Height, "round", req.Round, "step", req.Step,
+			"err", err)
+		return nil, err
+	}
+	return &commitment, nil
+}
+
+func (cs *CosignerServer) sign(ctx *rpctypes.Context, req CosignerSignRequest) (*threshold.SignatureShare, error) {
+	share, err := cs.cosigner.Sign(ctx.Context(), req)
+	if err != nil {
+		cs.Logger.Error("Failed to sign", "height", req.Height, "round", req.Round, "step", req.Step, "err", err)
+		return nil, err
+	}
+	cs.Logger.Info("Signed", "height", req.Height, "round", req.Round, "step", req.Step)
+	return &share, nil
+}
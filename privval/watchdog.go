@@ -0,0 +1,259 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// DefaultWatchdogMaxSignRate is the default maximum number of signatures
+	// per second.
+	DefaultWatchdogMaxSignRate = 50
+	// DefaultWatchdogMaxHeightDrift is the default maximum distance between
+	// the height of a signed message and the height the node is at.
+	DefaultWatchdogMaxHeightDrift = 1
+)
+
+var (
+	// ErrWatchdogHalted is returned by a WatchdogPV which detected an anomaly,
+	// and refuses to sign anything until the node is restarted.
+	ErrWatchdogHalted = errors.New("signing watchdog halted")
+	// ErrSignRateExceeded is returned when signing would exceed the maximum
+	// signing rate.
+	ErrSignRateExceeded = errors.New("maximum signing rate exceeded")
+)
+
+// WatchdogAlert describes a signing request refused by a WatchdogPV.
+type WatchdogAlert struct {
+	Height int64
+	Round  int32
+	Step   int8
+	Reason error
+	// Halted is true if the WatchdogPV halted because of the request.
+	Halted bool
+}
+
+// WatchdogPVOption sets an optional parameter on the WatchdogPV.
+type WatchdogPVOption func(*WatchdogPV)
+
+// WatchdogPVMaxSignRate sets the maximum number of signatures per second.
+// 0 disables the limit.
+func WatchdogPVMaxSignRate(rate int) WatchdogPVOption {
+	return func(w *WatchdogPV) { w.maxSignRate = rate }
+}
+
+// WatchdogPVChainHeight sets the function returning the height of the latest
+// block committed by the node, and the maximum distance between the height of
+// a signed message and the height following it. Without it, the height of
+// signed messages is not checked against the node's view of the chain.
+func WatchdogPVChainHeight(chainHeight func() int64, maxDrift int64) WatchdogPVOption {
+	return func(w *WatchdogPV) {
+		w.chainHeight = chainHeight
+		w.maxHeightDrift = maxDrift
+	}
+}
+
+// WatchdogPVAlertFunc sets a function called with every refused request,
+// in addition to logging it.
+func WatchdogPVAlertFunc(fn func(WatchdogAlert)) WatchdogPVOption {
+	return func(w *WatchdogPV) { w.alertFunc = fn }
+}
+
+// WatchdogPV wraps a PrivValidator, and refuses to forward signing requests
+// which could lead to an equivocation, whatever the safety guarantees of the
+// wrapped PrivValidator. In particular, it:
+//
+//   - persists the last (height, round, step) it let through, and halts on a
+//     regression, or on a conflicting message at the same (height, round, step);
+//   - halts when the height of a message is too far from the height of the
+//     node, which indicates the signer and the node disagree on the chain;
+//   - refuses to sign more than a maximum number of messages per second.
+//
+// Once halted, the WatchdogPV refuses to sign anything until it is recreated,
+// so that an operator can investigate. Every refusal is logged as an error,
+// and passed to the alert function if any.
+type WatchdogPV struct {
+	mtx    cmtsync.Mutex
+	logger log.Logger
+
+	pv            types.PrivValidator
+	LastSignState FilePVLastSignState
+	halted        bool
+
+	maxSignRate    int
+	signTimes      []time.Time // ring buffer of the last maxSignRate signing times
+	nextSignTime   int
+	chainHeight    func() int64
+	maxHeightDrift int64
+	alertFunc      func(WatchdogAlert)
+}
+
+var _ types.KeyRotatingPrivValidator = (*WatchdogPV)(nil)
+
+// NewWatchdogPV returns a WatchdogPV wrapping pv, which persists its last sign
+// state to stateFilePath. The state is loaded from the file if it exists.
+func NewWatchdogPV(
+	logger log.Logger,
+	pv types.PrivValidator,
+	stateFilePath string,
+	options ...WatchdogPVOption,
+) (*WatchdogPV, error) {
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	w := &WatchdogPV{
+		logger:        logger,
+		pv:            pv,
+		LastSignState: lss,
+		maxSignRate:   DefaultWatchdogMaxSignRate,
+	}
+	for _, option := range options {
+		option(w)
+	}
+	if w.maxSignRate > 0 {
+		w.signTimes = make([]time.Time, w.maxSignRate)
+	}
+	return w, nil
+}
+
+// Halted returns true if the WatchdogPV detected an anomaly, and refuses to
+// sign.
+func (w *WatchdogPV) Halted() bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.halted
+}
+
+// GetPubKey returns the public key of the wrapped PrivValidator.
+// Implements PrivValidator.
+func (w *WatchdogPV) GetPubKey() (crypto.PubKey, error) {
+	return w.pv.GetPubKey()
+}
+
+// SignVote forwards the vote to the wrapped PrivValidator if it passes the
+// checks of the watchdog. Implements PrivValidator.
+func (w *WatchdogPV) SignVote(chainID string, vote *cmtproto.Vote) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	if err := w.check(height, round, step, types.VoteSignBytes(chainID, vote),
+		checkVotesOnlyDifferByTimestamp); err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
+	}
+	if err := w.pv.SignVote(chainID, vote); err != nil {
+		return err
+	}
+	w.LastSignState.saveSigned(height, round, step, types.VoteSignBytes(chainID, vote), vote.Signature)
+	return nil
+}
+
+// SignProposal forwards the proposal to the wrapped PrivValidator if it passes
+// the checks of the watchdog. Implements PrivValidator.
+func (w *WatchdogPV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	height, round, step := proposal.Height, proposal.Round, stepPropose
+	if err := w.check(height, round, step, types.ProposalSignBytes(chainID, proposal),
+		checkProposalsOnlyDifferByTimestamp); err != nil {
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+	if err := w.pv.SignProposal(chainID, proposal); err != nil {
+		return err
+	}
+	w.LastSignState.saveSigned(height, round, step, types.ProposalSignBytes(chainID, proposal), proposal.Signature)
+	return nil
+}
+
+// NextPubKey returns the next public key of the wrapped PrivValidator, if it
+// supports key rotation. Implements KeyRotatingPrivValidator.
+func (w *WatchdogPV) NextPubKey() (crypto.PubKey, error) {
+	if pv, ok := w.pv.(types.KeyRotatingPrivValidator); ok {
+		return pv.NextPubKey()
+	}
+	return nil, nil
+}
+
+// RotateKey rotates the key of the wrapped PrivValidator, if it supports key
+// rotation. Implements KeyRotatingPrivValidator.
+func (w *WatchdogPV) RotateKey() error {
+	if pv, ok := w.pv.(types.KeyRotatingPrivValidator); ok {
+		return pv.RotateKey()
+	}
+	return errors.New("private validator does not support key rotation")
+}
+
+// String returns a string representation of the WatchdogPV.
+func (w *WatchdogPV) String() string {
+	return fmt.Sprintf("WatchdogPV{%v LH:%v, LR:%v, LS:%v}", w.pv, w.LastSignState.Height,
+		w.LastSignState.Round, w.LastSignState.Step)
+}
+
+// check returns an error if a message with the given height, round, step and
+// sign bytes must not be signed, halting on anomalies.
+func (w *WatchdogPV) check(
+	height int64,
+	round int32,
+	step int8,
+	signBytes []byte,
+	onlyDifferByTimestamp func(lastSignBytes, newSignBytes []byte) (time.Time, bool),
+) error {
+	if w.halted {
+		return ErrWatchdogHalted
+	}
+
+	lss := &w.LastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return w.halt(height, round, step, err)
+	}
+	if sameHRS && !bytes.Equal(signBytes, lss.SignBytes) {
+		if _, ok := onlyDifferByTimestamp(lss.SignBytes, signBytes); !ok {
+			return w.halt(height, round, step, errors.New("conflicting data"))
+		}
+	}
+
+	if w.chainHeight != nil {
+		nodeHeight := w.chainHeight() + 1
+		if height > nodeHeight+w.maxHeightDrift || height < nodeHeight-w.maxHeightDrift {
+			return w.halt(height, round, step,
+				fmt.Errorf("height %d diverges from node height %d", height, nodeHeight))
+		}
+	}
+
+	if w.maxSignRate > 0 {
+		now := time.Now()
+		if oldest := w.signTimes[w.nextSignTime]; !oldest.IsZero() && now.Sub(oldest) < time.Second {
+			w.alert(WatchdogAlert{Height: height, Round: round, Step: step, Reason: ErrSignRateExceeded})
+			return ErrSignRateExceeded
+		}
+		w.signTimes[w.nextSignTime] = now
+		w.nextSignTime = (w.nextSignTime + 1) % len(w.signTimes)
+	}
+	return nil
+}
+
+// halt stops all signing, and returns the reason as an error.
+func (w *WatchdogPV) halt(height int64, round int32, step int8, reason error) error {
+	w.halted = true
+	w.alert(WatchdogAlert{Height: height, Round: round, Step: step, Reason: reason, Halted: true})
+	return fmt.Errorf("%w: %v", ErrWatchdogHalted, reason)
+}
+
+func (w *WatchdogPV) alert(alert WatchdogAlert) {
+	w.logger.Error("Signing watchdog refused to sign", "height", alert.Height, "round", alert.Round,
+		"step", alert.Step, "reason", alert.Reason, "halted", alert.Halted)
+	if w.alertFunc != nil {
+		w.alertFunc(alert)
+	}
+}
@@ -0,0 +1,112 @@
+package privval
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newWatchdogTestPV(t *testing.T, stateFile string, options ...WatchdogPVOption) (*WatchdogPV, *[]WatchdogAlert) {
+	var alerts []WatchdogAlert
+	options = append(options, WatchdogPVAlertFunc(func(alert WatchdogAlert) {
+		alerts = append(alerts, alert)
+	}))
+	// MockPV has no double signing protection of its own
+	w, err := NewWatchdogPV(log.TestingLogger(), types.NewMockPV(), stateFile, options...)
+	require.NoError(t, err)
+	return w, &alerts
+}
+
+func TestWatchdogPVHaltsOnRegression(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	stateFile := filepath.Join(t.TempDir(), "watchdog_state.json")
+	w, alerts := newWatchdogTestPV(t, stateFile)
+	pubKey, err := w.GetPubKey()
+	require.NoError(t, err)
+
+	vote := newVote(pubKey.Address(), 0, 2, 0, cmtproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, w.SignVote(chainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+
+	// the same vote can be signed again
+	require.NoError(t, w.SignVote(chainID, newVote(pubKey.Address(), 0, 2, 0, cmtproto.PrevoteType,
+		blockID).ToProto()))
+	assert.Empty(t, *alerts)
+
+	// the regression survives a restart
+	w, alerts = newWatchdogTestPV(t, stateFile)
+	proposal := newProposal(1, 0, blockID).ToProto()
+	assert.ErrorIs(t, w.SignProposal(chainID, proposal), ErrWatchdogHalted)
+	assert.Nil(t, proposal.Signature)
+	assert.True(t, w.Halted())
+	require.Len(t, *alerts, 1)
+	assert.True(t, (*alerts)[0].Halted)
+	assert.EqualValues(t, 1, (*alerts)[0].Height)
+
+	// once halted, nothing is signed
+	vote = newVote(pubKey.Address(), 0, 3, 0, cmtproto.PrevoteType, blockID).ToProto()
+	assert.ErrorIs(t, w.SignVote(chainID, vote), ErrWatchdogHalted)
+	assert.Nil(t, vote.Signature)
+}
+
+func TestWatchdogPVHaltsOnConflictingData(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	w, alerts := newWatchdogTestPV(t, filepath.Join(t.TempDir(), "watchdog_state.json"))
+	pubKey, err := w.GetPubKey()
+	require.NoError(t, err)
+
+	require.NoError(t, w.SignVote(chainID, newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrecommitType,
+		blockID).ToProto()))
+	conflicting := newVote(pubKey.Address(), 0, 1, 0, cmtproto.PrecommitType, types.BlockID{}).ToProto()
+	assert.ErrorIs(t, w.SignVote(chainID, conflicting), ErrWatchdogHalted)
+	assert.Nil(t, conflicting.Signature)
+	assert.Len(t, *alerts, 1)
+}
+
+func TestWatchdogPVHaltsOnHeightDivergence(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	chainHeight := int64(10)
+	w, alerts := newWatchdogTestPV(t, filepath.Join(t.TempDir(), "watchdog_state.json"),
+		WatchdogPVChainHeight(func() int64 { return chainHeight }, 1))
+	pubKey, err := w.GetPubKey()
+	require.NoError(t, err)
+
+	require.NoError(t, w.SignVote(chainID, newVote(pubKey.Address(), 0, 11, 0, cmtproto.PrevoteType,
+		blockID).ToProto()))
+	require.NoError(t, w.SignVote(chainID, newVote(pubKey.Address(), 0, 12, 0, cmtproto.PrevoteType,
+		blockID).ToProto()))
+	assert.ErrorIs(t, w.SignVote(chainID, newVote(pubKey.Address(), 0, 13, 0, cmtproto.PrevoteType,
+		blockID).ToProto()), ErrWatchdogHalted)
+	assert.Len(t, *alerts, 1)
+}
+
+func TestWatchdogPVMaxSignRate(t *testing.T) {
+	chainID := "mychainid"
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	w, alerts := newWatchdogTestPV(t, filepath.Join(t.TempDir(), "watchdog_state.json"),
+		WatchdogPVMaxSignRate(2))
+	pubKey, err := w.GetPubKey()
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 2; height++ {
+		require.NoError(t, w.SignVote(chainID, newVote(pubKey.Address(), 0, height, 0, cmtproto.PrevoteType,
+			blockID).ToProto()))
+	}
+	vote := newVote(pubKey.Address(), 0, 3, 0, cmtproto.PrevoteType, blockID).ToProto()
+	assert.ErrorIs(t, w.SignVote(chainID, vote), ErrSignRateExceeded)
+	assert.Nil(t, vote.Signature)
+	assert.False(t, w.Halted())
+	require.Len(t, *alerts, 1)
+	assert.False(t, (*alerts)[0].Halted)
+}
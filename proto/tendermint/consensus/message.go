@@ -16,6 +16,7 @@ var _ p2p.Wrapper = &NewValidBlock{}
 var _ p2p.Wrapper = &NewRoundStep{}
 var _ p2p.Wrapper = &HasVote{}
 var _ p2p.Wrapper = &BlockPart{}
+var _ p2p.Wrapper = &BlockParityPart{}
 
 func (m *VoteSetBits) Wrap() proto.Message {
 	cm := &Message{}
@@ -48,6 +49,12 @@ func (m *BlockPart) Wrap() proto.Message {
 	return cm
 }
 
+func (m *BlockParityPart) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_BlockParityPart{BlockParityPart: m}
+	return cm
+}
+
 func (m *ProposalPOL) Wrap() proto.Message {
 	cm := &Message{}
 	cm.Sum = &Message_ProposalPol{ProposalPol: m}
@@ -103,6 +110,9 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_BlockParityPart:
+		return m.GetBlockParityPart(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -349,6 +349,68 @@ func (m *BlockPart) GetPart() types.Part {
 	return types.Part{}
 }
 
+// BlockParityPart is sent when gossipping a parity part of the erasure coded
+// proposed block.
+type BlockParityPart struct {
+	Height int64      `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32      `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Part   types.Part `protobuf:"bytes,3,opt,name=part,proto3" json:"part"`
+}
+
+func (m *BlockParityPart) Reset()         { *m = BlockParityPart{} }
+func (m *BlockParityPart) String() string { return proto.CompactTextString(m) }
+func (*BlockParityPart) ProtoMessage()    {}
+func (*BlockParityPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{5}
+}
+func (m *BlockParityPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockParityPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockParityPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockParityPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockParityPart.Merge(m, src)
+}
+func (m *BlockParityPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockParityPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockParityPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockParityPart proto.InternalMessageInfo
+
+func (m *BlockParityPart) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetPart() types.Part {
+	if m != nil {
+		return m.Part
+	}
+	return types.Part{}
+}
+
 // Vote is sent when voting for a proposal (or lack thereof).
 type Vote struct {
 	Vote *types.Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
@@ -358,7 +420,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{5}
+	return fileDescriptor_81a22d2efc008981, []int{6}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -406,7 +468,7 @@ func (m *HasVote) Reset()         { *m = HasVote{} }
 func (m *HasVote) String() string { return proto.CompactTextString(m) }
 func (*HasVote) ProtoMessage()    {}
 func (*HasVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{6}
+	return fileDescriptor_81a22d2efc008981, []int{7}
 }
 func (m *HasVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -475,7 +537,7 @@ func (m *VoteSetMaj23) Reset()         { *m = VoteSetMaj23{} }
 func (m *VoteSetMaj23) String() string { return proto.CompactTextString(m) }
 func (*VoteSetMaj23) ProtoMessage()    {}
 func (*VoteSetMaj23) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{7}
+	return fileDescriptor_81a22d2efc008981, []int{8}
 }
 func (m *VoteSetMaj23) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -545,7 +607,7 @@ func (m *VoteSetBits) Reset()         { *m = VoteSetBits{} }
 func (m *VoteSetBits) String() string { return proto.CompactTextString(m) }
 func (*VoteSetBits) ProtoMessage()    {}
 func (*VoteSetBits) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{8}
+	return fileDescriptor_81a22d2efc008981, []int{9}
 }
 func (m *VoteSetBits) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -620,6 +682,7 @@ type Message struct {
 	//	*Message_HasVote
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
+	//	*Message_BlockParityPart
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -627,7 +690,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{9}
+	return fileDescriptor_81a22d2efc008981, []int{10}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -689,16 +752,20 @@ type Message_VoteSetMaj23 struct {
 type Message_VoteSetBits struct {
 	VoteSetBits *VoteSetBits `protobuf:"bytes,9,opt,name=vote_set_bits,json=voteSetBits,proto3,oneof" json:"vote_set_bits,omitempty"`
 }
+type Message_BlockParityPart struct {
+	BlockParityPart *BlockParityPart `protobuf:"bytes,10,opt,name=block_parity_part,json=blockParityPart,proto3,oneof" json:"block_parity_part,omitempty"`
+}
 
-func (*Message_NewRoundStep) isMessage_Sum()  {}
-func (*Message_NewValidBlock) isMessage_Sum() {}
-func (*Message_Proposal) isMessage_Sum()      {}
-func (*Message_ProposalPol) isMessage_Sum()   {}
-func (*Message_BlockPart) isMessage_Sum()     {}
-func (*Message_Vote) isMessage_Sum()          {}
-func (*Message_HasVote) isMessage_Sum()       {}
-func (*Message_VoteSetMaj23) isMessage_Sum()  {}
-func (*Message_VoteSetBits) isMessage_Sum()   {}
+func (*Message_NewRoundStep) isMessage_Sum()    {}
+func (*Message_NewValidBlock) isMessage_Sum()   {}
+func (*Message_Proposal) isMessage_Sum()        {}
+func (*Message_ProposalPol) isMessage_Sum()     {}
+func (*Message_BlockPart) isMessage_Sum()       {}
+func (*Message_Vote) isMessage_Sum()            {}
+func (*Message_HasVote) isMessage_Sum()         {}
+func (*Message_VoteSetMaj23) isMessage_Sum()    {}
+func (*Message_VoteSetBits) isMessage_Sum()     {}
+func (*Message_BlockParityPart) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -770,6 +837,13 @@ func (m *Message) GetVoteSetBits() *VoteSetBits {
 	return nil
 }
 
+func (m *Message) GetBlockParityPart() *BlockParityPart {
+	if x, ok := m.GetSum().(*Message_BlockParityPart); ok {
+		return x.BlockParityPart
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -782,6 +856,7 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_HasVote)(nil),
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
+		(*Message_BlockParityPart)(nil),
 	}
 }
 
@@ -791,6 +866,7 @@ func init() {
 	proto.RegisterType((*Proposal)(nil), "tendermint.consensus.Proposal")
 	proto.RegisterType((*ProposalPOL)(nil), "tendermint.consensus.ProposalPOL")
 	proto.RegisterType((*BlockPart)(nil), "tendermint.consensus.BlockPart")
+	proto.RegisterType((*BlockParityPart)(nil), "tendermint.consensus.BlockParityPart")
 	proto.RegisterType((*Vote)(nil), "tendermint.consensus.Vote")
 	proto.RegisterType((*HasVote)(nil), "tendermint.consensus.HasVote")
 	proto.RegisterType((*VoteSetMaj23)(nil), "tendermint.consensus.VoteSetMaj23")
@@ -801,61 +877,63 @@ func init() {
 func init() { proto.RegisterFile("tendermint/consensus/types.proto", fileDescriptor_81a22d2efc008981) }
 
 var fileDescriptor_81a22d2efc008981 = []byte{
-	// 853 bytes of a gzipped FileDescriptorProto
+	// 883 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
-	0x14, 0xb7, 0x59, 0x67, 0x93, 0x7d, 0xde, 0xec, 0xc2, 0x68, 0x5b, 0x85, 0x00, 0x49, 0x30, 0x97,
-	0x15, 0x42, 0x0e, 0xca, 0x1e, 0x90, 0x0a, 0x12, 0x60, 0xfe, 0xd4, 0xad, 0x9a, 0x36, 0x72, 0x4a,
-	0x85, 0xb8, 0x58, 0x4e, 0x3c, 0x4a, 0x86, 0xc6, 0x1e, 0xcb, 0x33, 0xc9, 0xb2, 0x57, 0x3e, 0x01,
-	0x1f, 0x80, 0xaf, 0x81, 0xc4, 0x47, 0xe8, 0xb1, 0x47, 0x4e, 0x15, 0xca, 0x7e, 0x04, 0x04, 0x67,
-	0x34, 0xe3, 0x49, 0x3c, 0xa1, 0xde, 0x85, 0xbd, 0x20, 0xf5, 0x36, 0xe3, 0xf7, 0xde, 0x6f, 0xde,
-	0xfc, 0xde, 0x7b, 0x3f, 0x0f, 0xf4, 0x38, 0x4e, 0x63, 0x9c, 0x27, 0x24, 0xe5, 0xfd, 0x29, 0x4d,
-	0x19, 0x4e, 0xd9, 0x92, 0xf5, 0xf9, 0x45, 0x86, 0x99, 0x9b, 0xe5, 0x94, 0x53, 0x74, 0x52, 0x7a,
-	0xb8, 0x5b, 0x8f, 0xf6, 0xc9, 0x8c, 0xce, 0xa8, 0x74, 0xe8, 0x8b, 0x55, 0xe1, 0xdb, 0x7e, 0x5b,
-	0x43, 0x93, 0x18, 0x3a, 0x52, 0x5b, 0x3f, 0x6b, 0x41, 0x26, 0xac, 0x3f, 0x21, 0x7c, 0xc7, 0xc3,
-	0xf9, 0xc5, 0x84, 0xc3, 0x87, 0xf8, 0x3c, 0xa0, 0xcb, 0x34, 0x1e, 0x73, 0x9c, 0xa1, 0xdb, 0xb0,
-	0x3f, 0xc7, 0x64, 0x36, 0xe7, 0x2d, 0xb3, 0x67, 0x9e, 0xee, 0x05, 0x6a, 0x87, 0x4e, 0xa0, 0x96,
-	0x0b, 0xa7, 0xd6, 0x6b, 0x3d, 0xf3, 0xb4, 0x16, 0x14, 0x1b, 0x84, 0xc0, 0x62, 0x1c, 0x67, 0xad,
-	0xbd, 0x9e, 0x79, 0xda, 0x0c, 0xe4, 0x1a, 0x7d, 0x04, 0x2d, 0x86, 0xa7, 0x34, 0x8d, 0x59, 0xc8,
-	0x48, 0x3a, 0xc5, 0x21, 0xe3, 0x51, 0xce, 0x43, 0x4e, 0x12, 0xdc, 0xb2, 0x24, 0xe6, 0x2d, 0x65,
-	0x1f, 0x0b, 0xf3, 0x58, 0x58, 0x1f, 0x93, 0x04, 0xa3, 0xf7, 0xe1, 0x8d, 0x45, 0xc4, 0x78, 0x38,
-	0xa5, 0x49, 0x42, 0x78, 0x58, 0x1c, 0x57, 0x93, 0xc7, 0x1d, 0x0b, 0xc3, 0x17, 0xf2, 0xbb, 0x4c,
-	0xd5, 0xf9, 0xd3, 0x84, 0xe6, 0x43, 0x7c, 0xfe, 0x24, 0x5a, 0x90, 0xd8, 0x5b, 0xd0, 0xe9, 0xd3,
-	0x1b, 0x26, 0xfe, 0x2d, 0xdc, 0x9a, 0x88, 0xb0, 0x30, 0x13, 0xb9, 0x31, 0xcc, 0xc3, 0x39, 0x8e,
-	0x62, 0x9c, 0xcb, 0x9b, 0xd8, 0x83, 0xae, 0xab, 0xd5, 0xa0, 0xe0, 0x6b, 0x14, 0xe5, 0x7c, 0x8c,
-	0xb9, 0x2f, 0xdd, 0x3c, 0xeb, 0xd9, 0x8b, 0xae, 0x11, 0x20, 0x89, 0xb1, 0x63, 0x41, 0x9f, 0x82,
-	0x5d, 0x22, 0x33, 0x79, 0x63, 0x7b, 0xd0, 0xd1, 0xf1, 0x44, 0x25, 0x5c, 0x51, 0x09, 0xd7, 0x23,
-	0xfc, 0xf3, 0x3c, 0x8f, 0x2e, 0x02, 0xd8, 0x02, 0x31, 0xf4, 0x16, 0x1c, 0x10, 0xa6, 0x48, 0x90,
-	0xd7, 0x6f, 0x04, 0x0d, 0xc2, 0x8a, 0xcb, 0x3b, 0x3e, 0x34, 0x46, 0x39, 0xcd, 0x28, 0x8b, 0x16,
-	0xe8, 0x13, 0x68, 0x64, 0x6a, 0x2d, 0xef, 0x6c, 0x0f, 0xda, 0x15, 0x69, 0x2b, 0x0f, 0x95, 0xf1,
-	0x36, 0xc2, 0xf9, 0xd9, 0x04, 0x7b, 0x63, 0x1c, 0x3d, 0x7a, 0x70, 0x25, 0x7f, 0x1f, 0x00, 0xda,
-	0xc4, 0x84, 0x19, 0x5d, 0x84, 0x3a, 0x99, 0xaf, 0x6f, 0x2c, 0x23, 0xba, 0x90, 0x75, 0x41, 0x77,
-	0xe1, 0x50, 0xf7, 0x56, 0x74, 0xfe, 0xcb, 0xf5, 0x55, 0x6e, 0xb6, 0x86, 0xe6, 0x3c, 0x85, 0x03,
-	0x6f, 0xc3, 0xc9, 0x0d, 0x6b, 0xfb, 0x21, 0x58, 0x82, 0x7b, 0x75, 0xf6, 0xed, 0xea, 0x52, 0xaa,
-	0x33, 0xa5, 0xa7, 0x33, 0x00, 0xeb, 0x09, 0xe5, 0xa2, 0x03, 0xad, 0x15, 0xe5, 0x58, 0xb1, 0x59,
-	0x11, 0x29, 0xbc, 0x02, 0xe9, 0xe3, 0xfc, 0x68, 0x42, 0xdd, 0x8f, 0x98, 0x8c, 0xbb, 0x59, 0x7e,
-	0x67, 0x60, 0x09, 0x34, 0x99, 0xdf, 0x51, 0x55, 0xab, 0x8d, 0xc9, 0x2c, 0xc5, 0xf1, 0x90, 0xcd,
-	0x1e, 0x5f, 0x64, 0x38, 0x90, 0xce, 0x02, 0x8a, 0xa4, 0x31, 0xfe, 0x41, 0x36, 0x54, 0x2d, 0x28,
-	0x36, 0xce, 0xaf, 0x26, 0x1c, 0x8a, 0x0c, 0xc6, 0x98, 0x0f, 0xa3, 0xef, 0x07, 0x67, 0xff, 0x47,
-	0x26, 0x5f, 0x41, 0xa3, 0x68, 0x70, 0x12, 0xab, 0xee, 0x7e, 0xf3, 0xe5, 0x40, 0x59, 0xbb, 0x7b,
-	0x5f, 0x7a, 0xc7, 0x82, 0xe5, 0xf5, 0x8b, 0x6e, 0x5d, 0x7d, 0x08, 0xea, 0x32, 0xf6, 0x5e, 0xec,
-	0xfc, 0x61, 0x82, 0xad, 0x52, 0xf7, 0x08, 0x67, 0xaf, 0x4e, 0xe6, 0xe8, 0x0e, 0xd4, 0x44, 0x07,
-	0x30, 0x39, 0x9c, 0xff, 0xb5, 0xb9, 0x8b, 0x10, 0xe7, 0x2f, 0x0b, 0xea, 0x43, 0xcc, 0x58, 0x34,
-	0xc3, 0xe8, 0x3e, 0x1c, 0xa5, 0xf8, 0xbc, 0x18, 0xa8, 0x50, 0xca, 0x68, 0xd1, 0x77, 0x8e, 0x5b,
-	0xf5, 0x03, 0x70, 0x75, 0x99, 0xf6, 0x8d, 0xe0, 0x30, 0xd5, 0x65, 0x7b, 0x08, 0xc7, 0x02, 0x6b,
-	0x25, 0xf4, 0x30, 0x94, 0x89, 0x4a, 0xbe, 0xec, 0xc1, 0x7b, 0x57, 0x82, 0x95, 0xda, 0xe9, 0x1b,
-	0x41, 0x33, 0xdd, 0x11, 0x53, 0x5d, 0x5a, 0x2a, 0x46, 0xb8, 0xc4, 0xd9, 0x28, 0x88, 0xaf, 0x49,
-	0x0b, 0xfa, 0xfa, 0x1f, 0x22, 0x50, 0x70, 0xfd, 0xee, 0xf5, 0x08, 0xa3, 0x47, 0x0f, 0xfc, 0x5d,
-	0x0d, 0x40, 0x9f, 0x01, 0x94, 0x52, 0xaa, 0xd8, 0xee, 0x56, 0xa3, 0x6c, 0xb5, 0xc2, 0x37, 0x82,
-	0x83, 0xad, 0x98, 0x0a, 0x29, 0x90, 0x03, 0xbd, 0xff, 0xb2, 0x3c, 0x96, 0xb1, 0xa2, 0x0b, 0x7d,
-	0xa3, 0x18, 0x6b, 0x74, 0x07, 0x1a, 0xf3, 0x88, 0x85, 0x32, 0xaa, 0x2e, 0xa3, 0xde, 0xa9, 0x8e,
-	0x52, 0xb3, 0xef, 0x1b, 0x41, 0x7d, 0xae, 0x64, 0xe0, 0x3e, 0x1c, 0x89, 0x38, 0xf9, 0x3b, 0x49,
-	0xc4, 0x38, 0xb6, 0x1a, 0xd7, 0x15, 0x54, 0x1f, 0x5c, 0x51, 0xd0, 0x95, 0x3e, 0xc8, 0x77, 0xa1,
-	0xb9, 0xc5, 0x12, 0xfd, 0xd4, 0x3a, 0xb8, 0x8e, 0x44, 0x6d, 0x90, 0x04, 0x89, 0xab, 0x72, 0xeb,
-	0xd5, 0x60, 0x8f, 0x2d, 0x13, 0xef, 0x9b, 0x67, 0xeb, 0x8e, 0xf9, 0x7c, 0xdd, 0x31, 0x7f, 0x5f,
-	0x77, 0xcc, 0x9f, 0x2e, 0x3b, 0xc6, 0xf3, 0xcb, 0x8e, 0xf1, 0xdb, 0x65, 0xc7, 0xf8, 0xee, 0xe3,
-	0x19, 0xe1, 0xf3, 0xe5, 0xc4, 0x9d, 0xd2, 0xa4, 0xaf, 0xbf, 0x26, 0xca, 0x65, 0xf1, 0xea, 0xa8,
-	0x7a, 0xb7, 0x4c, 0xf6, 0xa5, 0xed, 0xec, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x5c, 0x91,
-	0x04, 0xd6, 0x08, 0x00, 0x00,
+	0x14, 0xb7, 0xd9, 0x78, 0x93, 0x7d, 0xde, 0x6d, 0xe8, 0x68, 0x5b, 0x99, 0x05, 0xb2, 0x8b, 0x11,
+	0xd2, 0x0a, 0x21, 0x07, 0x65, 0x0f, 0x48, 0x05, 0x09, 0x30, 0x7f, 0xea, 0xa2, 0x6e, 0x1b, 0x4d,
+	0x4a, 0x85, 0xb8, 0x58, 0x4e, 0x3c, 0x4a, 0x86, 0xc6, 0x1e, 0xe3, 0x99, 0xcd, 0x92, 0x2b, 0x9f,
+	0x80, 0x0f, 0xc0, 0xd7, 0x40, 0xe2, 0x23, 0x54, 0xe2, 0xd2, 0x23, 0xa7, 0x0a, 0x65, 0x3f, 0x02,
+	0xe2, 0x8e, 0x66, 0xec, 0xc4, 0x13, 0xea, 0x8d, 0xc8, 0xa5, 0x52, 0x6f, 0x33, 0xf3, 0xde, 0xfb,
+	0xcd, 0x9b, 0xdf, 0x7b, 0xef, 0x67, 0xc3, 0x89, 0x20, 0x69, 0x4c, 0xf2, 0x84, 0xa6, 0xa2, 0x3b,
+	0x62, 0x29, 0x27, 0x29, 0xbf, 0xe0, 0x5d, 0x31, 0xcf, 0x08, 0xf7, 0xb2, 0x9c, 0x09, 0x86, 0x0e,
+	0x2b, 0x0f, 0x6f, 0xe5, 0x71, 0x74, 0x38, 0x66, 0x63, 0xa6, 0x1c, 0xba, 0x72, 0x55, 0xf8, 0x1e,
+	0xbd, 0xa5, 0xa1, 0x29, 0x0c, 0x1d, 0xe9, 0x48, 0xbf, 0x6b, 0x4a, 0x87, 0xbc, 0x3b, 0xa4, 0x62,
+	0xcd, 0xc3, 0xfd, 0xcd, 0x84, 0xfd, 0x07, 0xe4, 0x12, 0xb3, 0x8b, 0x34, 0x1e, 0x08, 0x92, 0xa1,
+	0xdb, 0xb0, 0x3b, 0x21, 0x74, 0x3c, 0x11, 0x8e, 0x79, 0x62, 0x9e, 0xee, 0xe0, 0x72, 0x87, 0x0e,
+	0xc1, 0xca, 0xa5, 0x93, 0xf3, 0xda, 0x89, 0x79, 0x6a, 0xe1, 0x62, 0x83, 0x10, 0x34, 0xb8, 0x20,
+	0x99, 0xb3, 0x73, 0x62, 0x9e, 0x1e, 0x60, 0xb5, 0x46, 0x1f, 0x81, 0xc3, 0xc9, 0x88, 0xa5, 0x31,
+	0x0f, 0x39, 0x4d, 0x47, 0x24, 0xe4, 0x22, 0xca, 0x45, 0x28, 0x68, 0x42, 0x9c, 0x86, 0xc2, 0xbc,
+	0x55, 0xda, 0x07, 0xd2, 0x3c, 0x90, 0xd6, 0x47, 0x34, 0x21, 0xe8, 0x7d, 0xb8, 0x39, 0x8d, 0xb8,
+	0x08, 0x47, 0x2c, 0x49, 0xa8, 0x08, 0x8b, 0xeb, 0x2c, 0x75, 0x5d, 0x5b, 0x1a, 0xbe, 0x50, 0xe7,
+	0x2a, 0x55, 0xf7, 0x1f, 0x13, 0x0e, 0x1e, 0x90, 0xcb, 0xc7, 0xd1, 0x94, 0xc6, 0xfe, 0x94, 0x8d,
+	0x9e, 0x6c, 0x99, 0xf8, 0x77, 0x70, 0x6b, 0x28, 0xc3, 0xc2, 0x4c, 0xe6, 0xc6, 0x89, 0x08, 0x27,
+	0x24, 0x8a, 0x49, 0xae, 0x5e, 0x62, 0xf7, 0x8e, 0x3d, 0xad, 0x06, 0x05, 0x5f, 0xfd, 0x28, 0x17,
+	0x03, 0x22, 0x02, 0xe5, 0xe6, 0x37, 0x9e, 0x3e, 0x3f, 0x36, 0x30, 0x52, 0x18, 0x6b, 0x16, 0xf4,
+	0x29, 0xd8, 0x15, 0x32, 0x57, 0x2f, 0xb6, 0x7b, 0x1d, 0x1d, 0x4f, 0x56, 0xc2, 0x93, 0x95, 0xf0,
+	0x7c, 0x2a, 0x3e, 0xcf, 0xf3, 0x68, 0x8e, 0x61, 0x05, 0xc4, 0xd1, 0x9b, 0xb0, 0x47, 0x79, 0x49,
+	0x82, 0x7a, 0x7e, 0x0b, 0xb7, 0x28, 0x2f, 0x1e, 0xef, 0x06, 0xd0, 0xea, 0xe7, 0x2c, 0x63, 0x3c,
+	0x9a, 0xa2, 0x4f, 0xa0, 0x95, 0x95, 0x6b, 0xf5, 0x66, 0xbb, 0x77, 0x54, 0x93, 0x76, 0xe9, 0x51,
+	0x66, 0xbc, 0x8a, 0x70, 0x7f, 0x35, 0xc1, 0x5e, 0x1a, 0xfb, 0x0f, 0xef, 0x5f, 0xcb, 0xdf, 0x07,
+	0x80, 0x96, 0x31, 0x61, 0xc6, 0xa6, 0xa1, 0x4e, 0xe6, 0xeb, 0x4b, 0x4b, 0x9f, 0x4d, 0x55, 0x5d,
+	0xd0, 0x5d, 0xd8, 0xd7, 0xbd, 0x9d, 0x9d, 0xff, 0xf3, 0xfc, 0x32, 0x37, 0x5b, 0x43, 0x73, 0x9f,
+	0xc0, 0x9e, 0xbf, 0xe4, 0x64, 0xcb, 0xda, 0x7e, 0x08, 0x0d, 0xc9, 0x7d, 0x79, 0xf7, 0xed, 0xfa,
+	0x52, 0x96, 0x77, 0x2a, 0x4f, 0xf7, 0x47, 0x68, 0x2f, 0x2f, 0xa3, 0x62, 0xfe, 0x52, 0xae, 0xec,
+	0x41, 0xe3, 0x31, 0x13, 0xb2, 0xe9, 0x1b, 0x33, 0x26, 0x88, 0x63, 0x5e, 0x17, 0x29, 0xbd, 0xb0,
+	0xf2, 0x71, 0x7f, 0x36, 0xa1, 0x19, 0x44, 0x5c, 0xc5, 0x6d, 0x97, 0xdf, 0x19, 0x34, 0x24, 0x9a,
+	0xca, 0xef, 0x46, 0x5d, 0x77, 0x0f, 0xe8, 0x38, 0x25, 0xf1, 0x39, 0x1f, 0x3f, 0x9a, 0x67, 0x04,
+	0x2b, 0x67, 0x09, 0x45, 0xd3, 0x98, 0xfc, 0xa4, 0x7a, 0xd8, 0xc2, 0xc5, 0xc6, 0xfd, 0xdd, 0x84,
+	0x7d, 0x99, 0xc1, 0x80, 0x88, 0xf3, 0xe8, 0x87, 0xde, 0xd9, 0xcb, 0xc8, 0xe4, 0x2b, 0x68, 0x15,
+	0x33, 0x45, 0xe3, 0x72, 0xa0, 0xde, 0x78, 0x31, 0x50, 0x55, 0xf0, 0xde, 0x97, 0x7e, 0x5b, 0xb2,
+	0xbc, 0x78, 0x7e, 0xdc, 0x2c, 0x0f, 0x70, 0x53, 0xc5, 0xde, 0x8b, 0xdd, 0xbf, 0x4d, 0xb0, 0xcb,
+	0xd4, 0x7d, 0x2a, 0xf8, 0xab, 0x93, 0x39, 0xba, 0x03, 0x96, 0xec, 0x00, 0xee, 0x58, 0x5b, 0xcc,
+	0x53, 0x11, 0xe2, 0xfe, 0x61, 0x41, 0xf3, 0x9c, 0x70, 0x1e, 0x8d, 0x09, 0xfa, 0x06, 0x6e, 0xa4,
+	0xe4, 0xb2, 0x98, 0xe1, 0x50, 0x29, 0x77, 0xd1, 0x77, 0xae, 0x57, 0xf7, 0xcd, 0xf1, 0xf4, 0x2f,
+	0x43, 0x60, 0xe0, 0xfd, 0x54, 0xdb, 0xa3, 0x73, 0x68, 0x4b, 0xac, 0x99, 0x94, 0xe0, 0x50, 0x25,
+	0xaa, 0xf8, 0xb2, 0x7b, 0xef, 0x5e, 0x0b, 0x56, 0xc9, 0x75, 0x60, 0xe0, 0x83, 0x54, 0x3f, 0x58,
+	0x53, 0xb3, 0x1a, 0xd5, 0xa8, 0x70, 0x96, 0xa2, 0x15, 0x68, 0x6a, 0x86, 0xbe, 0xfe, 0x8f, 0xee,
+	0x14, 0x5c, 0xbf, 0xb3, 0x19, 0xa1, 0xff, 0xf0, 0x7e, 0xb0, 0x2e, 0x3b, 0xe8, 0x33, 0x80, 0x4a,
+	0xbd, 0x4b, 0xb6, 0x8f, 0xeb, 0x51, 0x56, 0xf2, 0x14, 0x18, 0x78, 0x6f, 0xa5, 0xdf, 0x52, 0x0a,
+	0xd4, 0x40, 0xef, 0xbe, 0xa8, 0xc8, 0x55, 0xac, 0xec, 0xc2, 0xc0, 0x28, 0xc6, 0x1a, 0xdd, 0x81,
+	0xd6, 0x24, 0xe2, 0xa1, 0x8a, 0x6a, 0xaa, 0xa8, 0xb7, 0xeb, 0xa3, 0xca, 0xd9, 0x0f, 0x0c, 0xdc,
+	0x9c, 0x14, 0x4b, 0x59, 0x50, 0x19, 0xa7, 0xbe, 0x60, 0x89, 0x1c, 0x47, 0xa7, 0xb5, 0xa9, 0xa0,
+	0xfa, 0xe0, 0xca, 0x82, 0xce, 0xf4, 0x41, 0xbe, 0x0b, 0x07, 0x2b, 0x2c, 0xd9, 0x4f, 0xce, 0xde,
+	0x26, 0x12, 0xb5, 0x41, 0x92, 0x24, 0xce, 0xaa, 0x2d, 0x1a, 0xc0, 0xcd, 0x15, 0x89, 0x54, 0xcc,
+	0x0b, 0x2e, 0x41, 0x81, 0xbd, 0xb7, 0x99, 0xcb, 0x52, 0x7d, 0x03, 0x03, 0xb7, 0x87, 0xeb, 0x47,
+	0xbe, 0x05, 0x3b, 0xfc, 0x22, 0xf1, 0xbf, 0x7d, 0xba, 0xe8, 0x98, 0xcf, 0x16, 0x1d, 0xf3, 0xaf,
+	0x45, 0xc7, 0xfc, 0xe5, 0xaa, 0x63, 0x3c, 0xbb, 0xea, 0x18, 0x7f, 0x5e, 0x75, 0x8c, 0xef, 0x3f,
+	0x1e, 0x53, 0x31, 0xb9, 0x18, 0x7a, 0x23, 0x96, 0x74, 0xf5, 0xbf, 0xa2, 0x6a, 0x59, 0xfc, 0x3d,
+	0xd5, 0xfd, 0x7f, 0x0d, 0x77, 0x95, 0xed, 0xec, 0xdf, 0x01, 0x00, 0x35, 0xce, 0x2f, 0x51, 0x9e,
+	0x09, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1090,6 +1168,49 @@ func (m *BlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BlockParityPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Part.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Vote) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1495,6 +1616,27 @@ func (m *Message_VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_BlockParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockParityPart != nil {
+		{
+			size, err := m.BlockParityPart.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1599,6 +1741,23 @@ func (m *BlockPart) Size() (n int) {
 	return n
 }
 
+func (m *BlockParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.Part.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func (m *Vote) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1795,6 +1954,18 @@ func (m *Message_VoteSetBits) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_BlockParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockParityPart != nil {
+		l = m.BlockParityPart.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -2449,6 +2620,127 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *BlockParityPart) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockParityPart: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockParityPart: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Vote) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3318,6 +3610,41 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_VoteSetBits{v}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParityPart", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockParityPart{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_BlockParityPart{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
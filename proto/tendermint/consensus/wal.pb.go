@@ -10,7 +10,7 @@ import (
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	_ "github.com/golang/protobuf/ptypes/duration"
-	types "github.com/tendermint/tendermint/proto/tendermint/types"
+	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -33,6 +33,9 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type MsgInfo struct {
 	Msg    Message `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg"`
 	PeerID string  `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// the time the message was received, so that a proposal replayed is judged
+	// timely as it was at first
+	ReceiveTime *time.Time `protobuf:"bytes,3,opt,name=receive_time,json=receiveTime,proto3,stdtime" json:"receive_time,omitempty"`
 }
 
 func (m *MsgInfo) Reset()         { *m = MsgInfo{} }
@@ -82,6 +85,13 @@ func (m *MsgInfo) GetPeerID() string {
 	return ""
 }
 
+func (m *MsgInfo) GetReceiveTime() *time.Time {
+	if m != nil {
+		return m.ReceiveTime
+	}
+	return nil
+}
+
 // TimeoutInfo internally generated messages which may update the state
 type TimeoutInfo struct {
 	Duration time.Duration `protobuf:"bytes,1,opt,name=duration,proto3,stdduration" json:"duration"`
@@ -246,7 +256,7 @@ type isWALMessage_Sum interface {
 }
 
 type WALMessage_EventDataRoundState struct {
-	EventDataRoundState *types.EventDataRoundState `protobuf:"bytes,1,opt,name=event_data_round_state,json=eventDataRoundState,proto3,oneof" json:"event_data_round_state,omitempty"`
+	EventDataRoundState *types1.EventDataRoundState `protobuf:"bytes,1,opt,name=event_data_round_state,json=eventDataRoundState,proto3,oneof" json:"event_data_round_state,omitempty"`
 }
 type WALMessage_MsgInfo struct {
 	MsgInfo *MsgInfo `protobuf:"bytes,2,opt,name=msg_info,json=msgInfo,proto3,oneof" json:"msg_info,omitempty"`
@@ -270,7 +280,7 @@ func (m *WALMessage) GetSum() isWALMessage_Sum {
 	return nil
 }
 
-func (m *WALMessage) GetEventDataRoundState() *types.EventDataRoundState {
+func (m *WALMessage) GetEventDataRoundState() *types1.EventDataRoundState {
 	if x, ok := m.GetSum().(*WALMessage_EventDataRoundState); ok {
 		return x.EventDataRoundState
 	}
@@ -372,41 +382,43 @@ func init() {
 func init() { proto.RegisterFile("tendermint/consensus/wal.proto", fileDescriptor_ed0b60c2d348ab09) }
 
 var fileDescriptor_ed0b60c2d348ab09 = []byte{
-	// 539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xdd, 0x8a, 0xd3, 0x40,
-	0x14, 0xce, 0x6c, 0xff, 0x4f, 0x15, 0x21, 0x96, 0xa5, 0x16, 0x36, 0x8d, 0x5d, 0x84, 0x5e, 0x25,
-	0xb0, 0x22, 0x88, 0x5e, 0xa8, 0xa5, 0x2b, 0x2d, 0xb8, 0x20, 0xe3, 0x8a, 0x20, 0x42, 0x48, 0x37,
-	0xa7, 0x69, 0x60, 0x33, 0x53, 0x32, 0x13, 0xc5, 0x2b, 0x5f, 0xa1, 0x97, 0xbe, 0x89, 0xaf, 0xb0,
-	0x97, 0x7b, 0xe9, 0xd5, 0x2a, 0xed, 0x8b, 0x48, 0x66, 0xd2, 0x36, 0xb8, 0xd9, 0xbb, 0x39, 0x73,
-	0xbe, 0x73, 0xbe, 0x73, 0xbe, 0x6f, 0x06, 0x2c, 0x89, 0x2c, 0xc0, 0x24, 0x8e, 0x98, 0x74, 0x2f,
-	0x38, 0x13, 0xc8, 0x44, 0x2a, 0xdc, 0x6f, 0xfe, 0xa5, 0xb3, 0x4c, 0xb8, 0xe4, 0x66, 0x67, 0x9f,
-	0x77, 0x76, 0xf9, 0x5e, 0x27, 0xe4, 0x21, 0x57, 0x00, 0x37, 0x3b, 0x69, 0x6c, 0xcf, 0x2e, 0xed,
-	0x25, 0xbf, 0x2f, 0x51, 0xe4, 0x88, 0xa3, 0x02, 0x42, 0xdd, 0xbb, 0xf8, 0x15, 0x99, 0xdc, 0xa6,
-	0xad, 0x90, 0xf3, 0xf0, 0x12, 0x5d, 0x15, 0xcd, 0xd2, 0xb9, 0x1b, 0xa4, 0x89, 0x2f, 0x23, 0xce,
-	0xf2, 0x7c, 0xff, 0xff, 0xbc, 0x8c, 0x62, 0x14, 0xd2, 0x8f, 0x97, 0x1a, 0x30, 0x40, 0x68, 0x9c,
-	0x89, 0x70, 0xca, 0xe6, 0xdc, 0x7c, 0x06, 0x95, 0x58, 0x84, 0x5d, 0x62, 0x93, 0x61, 0xfb, 0xe4,
-	0xc8, 0x29, 0x5b, 0xc3, 0x39, 0x43, 0x21, 0xfc, 0x10, 0x47, 0xd5, 0xab, 0x9b, 0xbe, 0x41, 0x33,
-	0xbc, 0x79, 0x0c, 0x8d, 0x25, 0x62, 0xe2, 0x45, 0x41, 0xf7, 0xc0, 0x26, 0xc3, 0xd6, 0x08, 0xd6,
-	0x37, 0xfd, 0xfa, 0x7b, 0xc4, 0x64, 0x3a, 0xa6, 0xf5, 0x2c, 0x35, 0x0d, 0x06, 0x2b, 0x02, 0xed,
-	0xf3, 0x28, 0x46, 0x9e, 0x4a, 0xc5, 0xf5, 0x0a, 0x9a, 0xdb, 0x49, 0x73, 0xc2, 0x47, 0x8e, 0x1e,
-	0xd5, 0xd9, 0x8e, 0xea, 0x8c, 0x73, 0xc0, 0xa8, 0x99, 0x91, 0xfd, 0xfc, 0xd3, 0x27, 0x74, 0x57,
-	0x64, 0x1e, 0x42, 0x7d, 0x81, 0x51, 0xb8, 0x90, 0x8a, 0xb4, 0x42, 0xf3, 0xc8, 0xec, 0x40, 0x2d,
-	0xe1, 0x29, 0x0b, 0xba, 0x15, 0x9b, 0x0c, 0x6b, 0x54, 0x07, 0xa6, 0x09, 0x55, 0x21, 0x71, 0xd9,
-	0xad, 0xda, 0x64, 0x78, 0x9f, 0xaa, 0xf3, 0xe0, 0x18, 0x5a, 0xa7, 0x2c, 0x98, 0xe8, 0xb2, 0x7d,
-	0x3b, 0x52, 0x6c, 0x37, 0xf8, 0x75, 0x00, 0xf0, 0xe9, 0xcd, 0xbb, 0x7c, 0x6d, 0xf3, 0x0b, 0x1c,
-	0x2a, 0xf9, 0xbd, 0xc0, 0x97, 0xbe, 0xa7, 0x7a, 0x7b, 0x42, 0xfa, 0x12, 0xf3, 0x25, 0x9e, 0x14,
-	0x55, 0xd3, 0x36, 0x9e, 0x66, 0xf8, 0xb1, 0x2f, 0x7d, 0x9a, 0xa1, 0x3f, 0x64, 0xe0, 0x89, 0x41,
-	0x1f, 0xe2, 0xed, 0x6b, 0xf3, 0x05, 0x34, 0x63, 0x11, 0x7a, 0x11, 0x9b, 0x73, 0xb5, 0xd5, 0xdd,
-	0x2e, 0x68, 0xc7, 0x26, 0x06, 0x6d, 0xc4, 0xb9, 0x79, 0x6f, 0xe1, 0x9e, 0xd4, 0xfa, 0xea, 0xfa,
-	0x8a, 0xaa, 0x7f, 0x5c, 0x5e, 0x5f, 0x70, 0x62, 0x62, 0xd0, 0xb6, 0x2c, 0x18, 0xf3, 0x1a, 0x00,
-	0x59, 0xe0, 0xe5, 0x62, 0x54, 0x55, 0x97, 0x7e, 0x79, 0x97, 0x9d, 0x7a, 0x13, 0x83, 0xb6, 0x70,
-	0x1b, 0x8c, 0x6a, 0x50, 0x11, 0x69, 0x3c, 0xf8, 0x01, 0x0f, 0x32, 0x9a, 0xa0, 0xa0, 0xde, 0x73,
-	0xa8, 0x66, 0x54, 0xb9, 0x56, 0xbd, 0x5b, 0x86, 0x9f, 0x6f, 0xdf, 0xa6, 0x76, 0x7c, 0x95, 0x39,
-	0xae, 0x2a, 0xcc, 0x13, 0xfd, 0x34, 0xb5, 0x28, 0x76, 0xf9, 0x38, 0x7b, 0x22, 0xf5, 0x2e, 0x47,
-	0x1f, 0xaf, 0xd6, 0x16, 0xb9, 0x5e, 0x5b, 0xe4, 0xef, 0xda, 0x22, 0xab, 0x8d, 0x65, 0x5c, 0x6f,
-	0x2c, 0xe3, 0xf7, 0xc6, 0x32, 0x3e, 0xbf, 0x0c, 0x23, 0xb9, 0x48, 0x67, 0xce, 0x05, 0x8f, 0xdd,
-	0xe2, 0xf7, 0xda, 0x1f, 0xf5, 0x47, 0x2d, 0xfb, 0x9c, 0xb3, 0xba, 0xca, 0x3d, 0xfd, 0x17, 0x00,
-	0x00, 0xff, 0xff, 0x0b, 0xad, 0x1c, 0x1b, 0x07, 0x04, 0x00, 0x00,
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xdf, 0x8a, 0xd3, 0x4e,
+	0x14, 0xce, 0x6c, 0xd3, 0x7f, 0xd3, 0xfd, 0xf1, 0x83, 0xb1, 0x2c, 0xb5, 0xb0, 0x69, 0xed, 0x22,
+	0xf4, 0x2a, 0x81, 0x15, 0x41, 0xf4, 0x42, 0x8d, 0x5d, 0x69, 0xc1, 0x05, 0x89, 0x8a, 0x20, 0x42,
+	0x48, 0x9b, 0xd3, 0x34, 0xb0, 0x99, 0x29, 0x99, 0xc9, 0x8a, 0x57, 0xbe, 0x42, 0x2f, 0x7d, 0x06,
+	0x5f, 0xc0, 0x57, 0xd8, 0xcb, 0xbd, 0xf4, 0x6a, 0x95, 0xf6, 0x45, 0x64, 0x66, 0xd2, 0x36, 0xb8,
+	0x41, 0xef, 0xce, 0x99, 0xf3, 0x9d, 0x6f, 0xbe, 0xf9, 0xce, 0x19, 0x6c, 0x09, 0xa0, 0x21, 0xa4,
+	0x49, 0x4c, 0x85, 0x33, 0x63, 0x94, 0x03, 0xe5, 0x19, 0x77, 0x3e, 0x05, 0x17, 0xf6, 0x32, 0x65,
+	0x82, 0x91, 0xf6, 0xbe, 0x6e, 0xef, 0xea, 0xdd, 0x76, 0xc4, 0x22, 0xa6, 0x00, 0x8e, 0x8c, 0x34,
+	0xb6, 0xdb, 0x2f, 0xe5, 0x12, 0x9f, 0x97, 0xc0, 0x73, 0xc4, 0x71, 0x01, 0xa1, 0xce, 0x1d, 0xb8,
+	0x04, 0x2a, 0xb6, 0x65, 0x2b, 0x62, 0x2c, 0xba, 0x00, 0x47, 0x65, 0xd3, 0x6c, 0xee, 0x84, 0x59,
+	0x1a, 0x88, 0x98, 0xd1, 0xbc, 0xde, 0xfb, 0xb3, 0x2e, 0xe2, 0x04, 0xb8, 0x08, 0x92, 0xa5, 0x06,
+	0x0c, 0xbe, 0x21, 0x5c, 0x3f, 0xe7, 0xd1, 0x84, 0xce, 0x19, 0x79, 0x88, 0x2b, 0x09, 0x8f, 0x3a,
+	0xa8, 0x8f, 0x86, 0xad, 0xd3, 0x63, 0xbb, 0xec, 0x1d, 0xf6, 0x39, 0x70, 0x1e, 0x44, 0xe0, 0x9a,
+	0x57, 0x37, 0x3d, 0xc3, 0x93, 0x78, 0x72, 0x82, 0xeb, 0x4b, 0x80, 0xd4, 0x8f, 0xc3, 0xce, 0x41,
+	0x1f, 0x0d, 0x9b, 0x2e, 0x5e, 0xdf, 0xf4, 0x6a, 0xaf, 0x01, 0xd2, 0xc9, 0xc8, 0xab, 0xc9, 0xd2,
+	0x24, 0x24, 0x2f, 0xf0, 0x61, 0x0a, 0x33, 0x88, 0x2f, 0xc1, 0x97, 0x12, 0x3a, 0x15, 0x75, 0x49,
+	0xd7, 0xd6, 0xfa, 0xec, 0xad, 0x3e, 0xfb, 0xed, 0x56, 0x9f, 0x6b, 0xae, 0x7e, 0xf6, 0x90, 0xd7,
+	0xca, 0xbb, 0xe4, 0xf9, 0x60, 0x85, 0x70, 0x4b, 0x06, 0x2c, 0x13, 0x4a, 0xf0, 0x53, 0xdc, 0xd8,
+	0xbe, 0x37, 0x57, 0x7d, 0xf7, 0x16, 0xe1, 0x28, 0x07, 0xb8, 0x0d, 0xa9, 0xf8, 0xab, 0xe4, 0xdc,
+	0x35, 0x91, 0x23, 0x5c, 0x5b, 0x40, 0x1c, 0x2d, 0x84, 0x52, 0x5e, 0xf1, 0xf2, 0x8c, 0xb4, 0x71,
+	0x35, 0x65, 0x19, 0x0d, 0x95, 0xcc, 0xaa, 0xa7, 0x13, 0x42, 0xb0, 0xc9, 0x05, 0x2c, 0x3b, 0x66,
+	0x1f, 0x0d, 0xff, 0xf3, 0x54, 0x3c, 0x38, 0xc1, 0xcd, 0x33, 0x1a, 0x8e, 0x75, 0xdb, 0x9e, 0x0e,
+	0x15, 0xe9, 0x06, 0xdf, 0x0f, 0x30, 0x7e, 0xff, 0xfc, 0x55, 0xee, 0x1d, 0xf9, 0x88, 0x8f, 0xd4,
+	0x10, 0xfd, 0x30, 0x10, 0x81, 0xaf, 0xb8, 0x7d, 0x2e, 0x02, 0x01, 0xf9, 0x23, 0xee, 0x17, 0xad,
+	0xd7, 0xcb, 0x70, 0x26, 0xf1, 0xa3, 0x40, 0x04, 0x9e, 0x44, 0xbf, 0x91, 0xe0, 0xb1, 0xe1, 0xdd,
+	0x81, 0xdb, 0xc7, 0xe4, 0x31, 0x6e, 0x24, 0x3c, 0xf2, 0x63, 0x3a, 0x67, 0x9d, 0x83, 0xbf, 0x8e,
+	0x52, 0x8f, 0x7d, 0x6c, 0x78, 0xf5, 0x44, 0x87, 0xe4, 0x25, 0x3e, 0x14, 0xda, 0x5f, 0xdd, 0xaf,
+	0xa7, 0x74, 0xaf, 0xbc, 0xbf, 0x30, 0x89, 0xb1, 0xe1, 0xb5, 0xc4, 0x3e, 0x25, 0xcf, 0x30, 0x06,
+	0x1a, 0xfa, 0xb9, 0x19, 0xa6, 0x62, 0xe9, 0x95, 0xb3, 0xec, 0xdc, 0x1b, 0x1b, 0x5e, 0x13, 0xb6,
+	0x89, 0x5b, 0xc5, 0x15, 0x9e, 0x25, 0x83, 0x2f, 0xf8, 0x7f, 0x79, 0x4d, 0x58, 0x70, 0xef, 0x11,
+	0x36, 0xd5, 0x06, 0xa1, 0x7f, 0x6e, 0x90, 0x9a, 0xb8, 0xda, 0x22, 0xd5, 0x41, 0x4e, 0xf5, 0x7e,
+	0x6b, 0x53, 0xfa, 0xe5, 0x72, 0xf6, 0x17, 0xa9, 0xe5, 0x76, 0xdf, 0x5d, 0xad, 0x2d, 0x74, 0xbd,
+	0xb6, 0xd0, 0xaf, 0xb5, 0x85, 0x56, 0x1b, 0xcb, 0xb8, 0xde, 0x58, 0xc6, 0x8f, 0x8d, 0x65, 0x7c,
+	0x78, 0x12, 0xc5, 0x62, 0x91, 0x4d, 0xed, 0x19, 0x4b, 0x9c, 0xe2, 0x27, 0xdd, 0x87, 0xfa, 0xbb,
+	0x97, 0x7d, 0xf1, 0x69, 0x4d, 0xd5, 0x1e, 0xfc, 0x1e, 0x00, 0xde, 0x23, 0x25, 0xbd, 0x4d, 0x04,
+	0x00, 0x00,
 }
 
 func (m *MsgInfo) Marshal() (dAtA []byte, err error) {
@@ -429,6 +441,16 @@ func (m *MsgInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ReceiveTime != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ReceiveTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ReceiveTime):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintWal(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.PeerID) > 0 {
 		i -= len(m.PeerID)
 		copy(dAtA[i:], m.PeerID)
@@ -484,12 +506,12 @@ func (m *TimeoutInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x10
 	}
-	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration):])
-	if err2 != nil {
-		return 0, err2
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration):])
+	if err3 != nil {
+		return 0, err3
 	}
-	i -= n2
-	i = encodeVarintWal(dAtA, i, uint64(n2))
+	i -= n3
+	i = encodeVarintWal(dAtA, i, uint64(n3))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -671,12 +693,12 @@ func (m *TimedWALMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err8 != nil {
-		return 0, err8
+	n9, err9 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err9 != nil {
+		return 0, err9
 	}
-	i -= n8
-	i = encodeVarintWal(dAtA, i, uint64(n8))
+	i -= n9
+	i = encodeVarintWal(dAtA, i, uint64(n9))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -705,6 +727,10 @@ func (m *MsgInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWal(uint64(l))
 	}
+	if m.ReceiveTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.ReceiveTime)
+		n += 1 + l + sovWal(uint64(l))
+	}
 	return n
 }
 
@@ -915,6 +941,42 @@ func (m *MsgInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.PeerID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceiveTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceiveTime == nil {
+				m.ReceiveTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.ReceiveTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWal(dAtA[iNdEx:])
@@ -1203,7 +1265,7 @@ func (m *WALMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.EventDataRoundState{}
+			v := &types1.EventDataRoundState{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -10,6 +10,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -133,13 +134,14 @@ func (m *CanonicalPartSetHeader) GetHash() []byte {
 }
 
 type CanonicalProposal struct {
-	Type      SignedMsgType     `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
-	Height    int64             `protobuf:"fixed64,2,opt,name=height,proto3" json:"height,omitempty"`
-	Round     int64             `protobuf:"fixed64,3,opt,name=round,proto3" json:"round,omitempty"`
-	POLRound  int64             `protobuf:"varint,4,opt,name=pol_round,json=polRound,proto3" json:"pol_round,omitempty"`
-	BlockID   *CanonicalBlockID `protobuf:"bytes,5,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
-	Timestamp time.Time         `protobuf:"bytes,6,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	ChainID   string            `protobuf:"bytes,7,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Type                SignedMsgType        `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
+	Height              int64                `protobuf:"fixed64,2,opt,name=height,proto3" json:"height,omitempty"`
+	Round               int64                `protobuf:"fixed64,3,opt,name=round,proto3" json:"round,omitempty"`
+	POLRound            int64                `protobuf:"varint,4,opt,name=pol_round,json=polRound,proto3" json:"pol_round,omitempty"`
+	BlockID             *CanonicalBlockID    `protobuf:"bytes,5,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Timestamp           time.Time            `protobuf:"bytes,6,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	ChainID             string               `protobuf:"bytes,7,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ParityPartSetHeader *ParityPartSetHeader `protobuf:"bytes,8,opt,name=parity_part_set_header,json=parityPartSetHeader,proto3" json:"parity_part_set_header,omitempty"`
 }
 
 func (m *CanonicalProposal) Reset()         { *m = CanonicalProposal{} }
@@ -224,6 +226,13 @@ func (m *CanonicalProposal) GetChainID() string {
 	return ""
 }
 
+func (m *CanonicalProposal) GetParityPartSetHeader() *ParityPartSetHeader {
+	if m != nil {
+		return m.ParityPartSetHeader
+	}
+	return nil
+}
+
 type CanonicalVote struct {
 	Type      SignedMsgType     `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
 	Height    int64             `protobuf:"fixed64,2,opt,name=height,proto3" json:"height,omitempty"`
@@ -308,48 +317,124 @@ func (m *CanonicalVote) GetChainID() string {
 	return ""
 }
 
+type CanonicalKeyRotation struct {
+	Height    int64            `protobuf:"fixed64,1,opt,name=height,proto3" json:"height,omitempty"`
+	OldPubKey crypto.PublicKey `protobuf:"bytes,2,opt,name=old_pub_key,json=oldPubKey,proto3" json:"old_pub_key"`
+	NewPubKey crypto.PublicKey `protobuf:"bytes,3,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key"`
+	ChainID   string           `protobuf:"bytes,4,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *CanonicalKeyRotation) Reset()         { *m = CanonicalKeyRotation{} }
+func (m *CanonicalKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*CanonicalKeyRotation) ProtoMessage()    {}
+func (*CanonicalKeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8d1a1a84ff7267ed, []int{4}
+}
+func (m *CanonicalKeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalKeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalKeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalKeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalKeyRotation.Merge(m, src)
+}
+func (m *CanonicalKeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalKeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalKeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalKeyRotation proto.InternalMessageInfo
+
+func (m *CanonicalKeyRotation) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CanonicalKeyRotation) GetOldPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.OldPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *CanonicalKeyRotation) GetNewPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *CanonicalKeyRotation) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CanonicalBlockID)(nil), "tendermint.types.CanonicalBlockID")
 	proto.RegisterType((*CanonicalPartSetHeader)(nil), "tendermint.types.CanonicalPartSetHeader")
 	proto.RegisterType((*CanonicalProposal)(nil), "tendermint.types.CanonicalProposal")
 	proto.RegisterType((*CanonicalVote)(nil), "tendermint.types.CanonicalVote")
+	proto.RegisterType((*CanonicalKeyRotation)(nil), "tendermint.types.CanonicalKeyRotation")
 }
 
 func init() { proto.RegisterFile("tendermint/types/canonical.proto", fileDescriptor_8d1a1a84ff7267ed) }
 
 var fileDescriptor_8d1a1a84ff7267ed = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0x3d, 0x6f, 0xd3, 0x40,
-	0x18, 0xce, 0xa5, 0x4e, 0xe2, 0x5c, 0x1b, 0x08, 0xa7, 0xaa, 0xb2, 0x22, 0x64, 0x5b, 0x1e, 0x90,
-	0x59, 0x6c, 0xa9, 0x1d, 0xd8, 0x5d, 0x06, 0x82, 0x40, 0x94, 0x6b, 0xd5, 0x81, 0x25, 0xba, 0xd8,
-	0x87, 0x6d, 0xe1, 0xf8, 0x4e, 0xf6, 0x65, 0xe8, 0xc2, 0x6f, 0xe8, 0xef, 0xe0, 0x97, 0x74, 0xec,
-	0x08, 0x4b, 0x40, 0xce, 0x1f, 0x41, 0x77, 0x4e, 0xec, 0xa8, 0x01, 0x16, 0x10, 0xcb, 0xe9, 0xfd,
-	0x78, 0xee, 0x79, 0x1f, 0x3d, 0xaf, 0x5e, 0x68, 0x0b, 0x9a, 0x47, 0xb4, 0x58, 0xa4, 0xb9, 0xf0,
-	0xc5, 0x0d, 0xa7, 0xa5, 0x1f, 0x92, 0x9c, 0xe5, 0x69, 0x48, 0x32, 0x8f, 0x17, 0x4c, 0x30, 0x34,
-	0x6e, 0x11, 0x9e, 0x42, 0x4c, 0x8e, 0x63, 0x16, 0x33, 0xd5, 0xf4, 0x65, 0x54, 0xe3, 0x26, 0x4f,
-	0xf7, 0x98, 0xd4, 0xbb, 0xe9, 0x5a, 0x31, 0x63, 0x71, 0x46, 0x7d, 0x95, 0xcd, 0x97, 0x1f, 0x7d,
-	0x91, 0x2e, 0x68, 0x29, 0xc8, 0x82, 0xd7, 0x00, 0xe7, 0x33, 0x1c, 0x9f, 0x6f, 0x27, 0x07, 0x19,
-	0x0b, 0x3f, 0x4d, 0x5f, 0x22, 0x04, 0xb5, 0x84, 0x94, 0x89, 0x01, 0x6c, 0xe0, 0x1e, 0x61, 0x15,
-	0xa3, 0x6b, 0xf8, 0x98, 0x93, 0x42, 0xcc, 0x4a, 0x2a, 0x66, 0x09, 0x25, 0x11, 0x2d, 0x8c, 0xae,
-	0x0d, 0xdc, 0xc3, 0x53, 0xd7, 0x7b, 0x28, 0xd4, 0x6b, 0x08, 0x2f, 0x48, 0x21, 0x2e, 0xa9, 0x78,
-	0xa5, 0xf0, 0x81, 0x76, 0xb7, 0xb2, 0x3a, 0x78, 0xc4, 0x77, 0x8b, 0x4e, 0x00, 0x4f, 0x7e, 0x0d,
-	0x47, 0xc7, 0xb0, 0x27, 0x98, 0x20, 0x99, 0x92, 0x31, 0xc2, 0x75, 0xd2, 0x68, 0xeb, 0xb6, 0xda,
-	0x9c, 0x6f, 0x5d, 0xf8, 0xa4, 0x25, 0x29, 0x18, 0x67, 0x25, 0xc9, 0xd0, 0x19, 0xd4, 0xa4, 0x1c,
-	0xf5, 0xfd, 0xd1, 0xa9, 0xb5, 0x2f, 0xf3, 0x32, 0x8d, 0x73, 0x1a, 0xbd, 0x2d, 0xe3, 0xab, 0x1b,
-	0x4e, 0xb1, 0x02, 0xa3, 0x13, 0xd8, 0x4f, 0x68, 0x1a, 0x27, 0x42, 0x0d, 0x18, 0xe3, 0x4d, 0x26,
-	0xc5, 0x14, 0x6c, 0x99, 0x47, 0xc6, 0x81, 0x2a, 0xd7, 0x09, 0x7a, 0x0e, 0x87, 0x9c, 0x65, 0xb3,
-	0xba, 0xa3, 0xd9, 0xc0, 0x3d, 0x08, 0x8e, 0xaa, 0x95, 0xa5, 0x5f, 0xbc, 0x7b, 0x83, 0x65, 0x0d,
-	0xeb, 0x9c, 0x65, 0x2a, 0x42, 0xaf, 0xa1, 0x3e, 0x97, 0xf6, 0xce, 0xd2, 0xc8, 0xe8, 0x29, 0xe3,
-	0x9c, 0x3f, 0x18, 0xb7, 0xd9, 0x44, 0x70, 0x58, 0xad, 0xac, 0xc1, 0x26, 0xc1, 0x03, 0x45, 0x30,
-	0x8d, 0x50, 0x00, 0x87, 0xcd, 0x1a, 0x8d, 0xbe, 0x22, 0x9b, 0x78, 0xf5, 0xa2, 0xbd, 0xed, 0xa2,
-	0xbd, 0xab, 0x2d, 0x22, 0xd0, 0xa5, 0xef, 0xb7, 0xdf, 0x2d, 0x80, 0xdb, 0x6f, 0xe8, 0x19, 0xd4,
-	0xc3, 0x84, 0xa4, 0xb9, 0xd4, 0x33, 0xb0, 0x81, 0x3b, 0xac, 0x67, 0x9d, 0xcb, 0x9a, 0x9c, 0xa5,
-	0x9a, 0xd3, 0xc8, 0xf9, 0xd2, 0x85, 0xa3, 0x46, 0xd6, 0x35, 0x13, 0xf4, 0x7f, 0xf8, 0xba, 0x6b,
-	0x96, 0xf6, 0x2f, 0xcd, 0xea, 0xfd, 0xbd, 0x59, 0xfd, 0xdf, 0x9b, 0x15, 0xbc, 0xbf, 0xab, 0x4c,
-	0x70, 0x5f, 0x99, 0xe0, 0x47, 0x65, 0x82, 0xdb, 0xb5, 0xd9, 0xb9, 0x5f, 0x9b, 0x9d, 0xaf, 0x6b,
-	0xb3, 0xf3, 0xe1, 0x45, 0x9c, 0x8a, 0x64, 0x39, 0xf7, 0x42, 0xb6, 0xf0, 0x77, 0x0f, 0xb6, 0x0d,
-	0xeb, 0xc3, 0x7e, 0x78, 0xcc, 0xf3, 0xbe, 0xaa, 0x9f, 0xfd, 0x0c, 0x00, 0x00, 0xff, 0xff, 0x6d,
-	0xdd, 0x12, 0x5d, 0x31, 0x04, 0x00, 0x00,
+	// 601 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0x41, 0x6f, 0x9b, 0x30,
+	0x18, 0x0d, 0x0d, 0x4d, 0x89, 0xdb, 0x6e, 0x9d, 0x17, 0x45, 0x51, 0x54, 0x41, 0x84, 0xb4, 0x29,
+	0xbb, 0x80, 0xd4, 0x1e, 0x76, 0xa7, 0x3b, 0x2c, 0xcb, 0xa6, 0x65, 0xb4, 0xea, 0xa1, 0x17, 0x64,
+	0xc0, 0x03, 0x14, 0x82, 0x2d, 0x30, 0xaa, 0xb8, 0xec, 0x37, 0xf4, 0x77, 0xec, 0xbe, 0xff, 0xd0,
+	0x63, 0x8f, 0x3b, 0x65, 0x53, 0xf2, 0x47, 0x26, 0x0c, 0x21, 0x34, 0x69, 0xa7, 0x49, 0x9b, 0x76,
+	0x41, 0xfe, 0x3e, 0x3f, 0x3f, 0xbf, 0xe7, 0x87, 0x0d, 0x06, 0x0c, 0x47, 0x2e, 0x8e, 0x67, 0x41,
+	0xc4, 0x74, 0x96, 0x51, 0x9c, 0xe8, 0x0e, 0x8a, 0x48, 0x14, 0x38, 0x28, 0xd4, 0x68, 0x4c, 0x18,
+	0x81, 0x47, 0x6b, 0x84, 0xc6, 0x11, 0xfd, 0x8e, 0x47, 0x3c, 0xc2, 0x27, 0xf5, 0x7c, 0x54, 0xe0,
+	0xfa, 0xc7, 0x5b, 0x4c, 0xfc, 0x5b, 0xce, 0x2a, 0x1e, 0x21, 0x5e, 0x88, 0x75, 0x5e, 0xd9, 0xe9,
+	0x67, 0x9d, 0x05, 0x33, 0x9c, 0x30, 0x34, 0xa3, 0x0f, 0x2c, 0x77, 0xe2, 0x8c, 0x32, 0xa2, 0x4f,
+	0x71, 0x56, 0x2e, 0x57, 0xbf, 0x80, 0xa3, 0xb3, 0x95, 0x2e, 0x23, 0x24, 0xce, 0x74, 0xf4, 0x06,
+	0x42, 0x20, 0xfa, 0x28, 0xf1, 0x7b, 0xc2, 0x40, 0x18, 0x1e, 0x98, 0x7c, 0x0c, 0x2f, 0xc1, 0x53,
+	0x8a, 0x62, 0x66, 0x25, 0x98, 0x59, 0x3e, 0x46, 0x2e, 0x8e, 0x7b, 0x3b, 0x03, 0x61, 0xb8, 0x7f,
+	0x32, 0xd4, 0x36, 0x6d, 0x68, 0x15, 0xe1, 0x04, 0xc5, 0xec, 0x1c, 0xb3, 0xb7, 0x1c, 0x6f, 0x88,
+	0xb7, 0x73, 0xa5, 0x61, 0x1e, 0xd2, 0x7a, 0x53, 0x35, 0x40, 0xf7, 0x61, 0x38, 0xec, 0x80, 0x5d,
+	0x46, 0x18, 0x0a, 0xb9, 0x8c, 0x43, 0xb3, 0x28, 0x2a, 0x6d, 0x3b, 0x6b, 0x6d, 0xea, 0xb7, 0x26,
+	0x78, 0xb6, 0x26, 0x89, 0x09, 0x25, 0x09, 0x0a, 0xe1, 0x29, 0x10, 0x73, 0x39, 0x7c, 0xf9, 0x93,
+	0x13, 0x65, 0x5b, 0xe6, 0x79, 0xe0, 0x45, 0xd8, 0xfd, 0x90, 0x78, 0x17, 0x19, 0xc5, 0x26, 0x07,
+	0xc3, 0x2e, 0x68, 0xf9, 0x38, 0xf0, 0x7c, 0xc6, 0x37, 0x38, 0x32, 0xcb, 0x2a, 0x17, 0x13, 0x93,
+	0x34, 0x72, 0x7b, 0x4d, 0xde, 0x2e, 0x0a, 0xf8, 0x0a, 0xb4, 0x29, 0x09, 0xad, 0x62, 0x46, 0x1c,
+	0x08, 0xc3, 0xa6, 0x71, 0xb0, 0x98, 0x2b, 0xd2, 0xe4, 0xe3, 0x7b, 0x33, 0xef, 0x99, 0x12, 0x25,
+	0x21, 0x1f, 0xc1, 0x77, 0x40, 0xb2, 0xf3, 0xe3, 0xb5, 0x02, 0xb7, 0xb7, 0xcb, 0x0f, 0x4e, 0xfd,
+	0xcd, 0xc1, 0x95, 0x49, 0x18, 0xfb, 0x8b, 0xb9, 0xb2, 0x57, 0x16, 0xe6, 0x1e, 0x27, 0x18, 0xb9,
+	0xd0, 0x00, 0xed, 0x2a, 0xe4, 0x5e, 0x8b, 0x93, 0xf5, 0xb5, 0xe2, 0x37, 0xd0, 0x56, 0xbf, 0x81,
+	0x76, 0xb1, 0x42, 0x18, 0x52, 0x7e, 0xee, 0x37, 0x3f, 0x14, 0xc1, 0x5c, 0x2f, 0x83, 0x2f, 0x81,
+	0xe4, 0xf8, 0x28, 0x88, 0x72, 0x3d, 0x7b, 0x03, 0x61, 0xd8, 0x2e, 0xf6, 0x3a, 0xcb, 0x7b, 0xf9,
+	0x5e, 0x7c, 0x72, 0xe4, 0xc2, 0x2b, 0xd0, 0xa5, 0x28, 0x0e, 0x58, 0x66, 0x6d, 0xc6, 0x2f, 0xf1,
+	0x8d, 0x5f, 0x6c, 0xbb, 0x98, 0x70, 0xfc, 0xbd, 0x30, 0xcd, 0xe7, 0x74, 0xbb, 0xa9, 0x7e, 0xdd,
+	0x01, 0x87, 0x95, 0xe5, 0x4b, 0xc2, 0xf0, 0xff, 0xc8, 0xac, 0x1e, 0x84, 0xf8, 0x2f, 0x83, 0xd8,
+	0xfd, 0xfb, 0x20, 0x5a, 0x8f, 0x07, 0xa1, 0xce, 0x05, 0xd0, 0xa9, 0x64, 0x8d, 0x71, 0x66, 0x12,
+	0x86, 0x58, 0x40, 0xa2, 0x9a, 0x7d, 0xe1, 0x9e, 0x7d, 0x03, 0xec, 0x93, 0xd0, 0xb5, 0x68, 0x6a,
+	0x5b, 0x53, 0x9c, 0x95, 0xb7, 0xf5, 0xb8, 0xee, 0xb5, 0x78, 0x0d, 0xb4, 0x49, 0x6a, 0x87, 0x81,
+	0x33, 0xc6, 0x59, 0x79, 0x43, 0xdb, 0x24, 0x74, 0x27, 0xa9, 0x3d, 0xc6, 0x59, 0xce, 0x11, 0xe1,
+	0xeb, 0x8a, 0xa3, 0xf9, 0xe7, 0x1c, 0x11, 0xbe, 0x2e, 0x39, 0xea, 0x06, 0xc5, 0xc7, 0x0d, 0x1a,
+	0x9f, 0x6e, 0x17, 0xb2, 0x70, 0xb7, 0x90, 0x85, 0x9f, 0x0b, 0x59, 0xb8, 0x59, 0xca, 0x8d, 0xbb,
+	0xa5, 0xdc, 0xf8, 0xbe, 0x94, 0x1b, 0x57, 0xaf, 0xbd, 0x80, 0xf9, 0xa9, 0xad, 0x39, 0x64, 0xa6,
+	0xd7, 0xdf, 0xc2, 0xf5, 0xb0, 0x78, 0x33, 0x37, 0xdf, 0x49, 0xbb, 0xc5, 0xfb, 0xa7, 0xbf, 0x06,
+	0x00, 0xfd, 0x3f, 0xca, 0x90, 0x8c, 0x05, 0x00, 0x00,
 }
 
 func (m *CanonicalBlockID) Marshal() (dAtA []byte, err error) {
@@ -447,6 +532,18 @@ func (m *CanonicalProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ParityPartSetHeader != nil {
+		{
+			size, err := m.ParityPartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCanonical(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
 	if len(m.ChainID) > 0 {
 		i -= len(m.ChainID)
 		copy(dAtA[i:], m.ChainID)
@@ -454,12 +551,12 @@ func (m *CanonicalProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x3a
 	}
-	n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err2 != nil {
-		return 0, err2
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err3 != nil {
+		return 0, err3
 	}
-	i -= n2
-	i = encodeVarintCanonical(dAtA, i, uint64(n2))
+	i -= n3
+	i = encodeVarintCanonical(dAtA, i, uint64(n3))
 	i--
 	dAtA[i] = 0x32
 	if m.BlockID != nil {
@@ -526,12 +623,12 @@ func (m *CanonicalVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x32
 	}
-	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err4 != nil {
-		return 0, err4
+	n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err5 != nil {
+		return 0, err5
 	}
-	i -= n4
-	i = encodeVarintCanonical(dAtA, i, uint64(n4))
+	i -= n5
+	i = encodeVarintCanonical(dAtA, i, uint64(n5))
 	i--
 	dAtA[i] = 0x2a
 	if m.BlockID != nil {
@@ -566,6 +663,62 @@ func (m *CanonicalVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CanonicalKeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalKeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalKeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	{
+		size, err := m.NewPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintCanonical(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.OldPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintCanonical(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.Height != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Height))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCanonical(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCanonical(v)
 	base := offset
@@ -636,6 +789,10 @@ func (m *CanonicalProposal) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCanonical(uint64(l))
 	}
+	if m.ParityPartSetHeader != nil {
+		l = m.ParityPartSetHeader.Size()
+		n += 1 + l + sovCanonical(uint64(l))
+	}
 	return n
 }
 
@@ -667,6 +824,26 @@ func (m *CanonicalVote) Size() (n int) {
 	return n
 }
 
+func (m *CanonicalKeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 9
+	}
+	l = m.OldPubKey.Size()
+	n += 1 + l + sovCanonical(uint64(l))
+	l = m.NewPubKey.Size()
+	n += 1 + l + sovCanonical(uint64(l))
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	return n
+}
+
 func sovCanonical(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1081,6 +1258,42 @@ func (m *CanonicalProposal) Unmarshal(dAtA []byte) error {
 			}
 			m.ChainID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParityPartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ParityPartSetHeader == nil {
+				m.ParityPartSetHeader = &ParityPartSetHeader{}
+			}
+			if err := m.ParityPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCanonical(dAtA[iNdEx:])
@@ -1292,6 +1505,164 @@ func (m *CanonicalVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CanonicalKeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCanonical
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalKeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalKeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Height = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.OldPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NewPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCanonical(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCanonical(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -704,13 +704,15 @@ func (m *CommitSig) GetSignature() []byte {
 }
 
 type Proposal struct {
-	Type      SignedMsgType `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
-	Height    int64         `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
-	Round     int32         `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
-	PolRound  int32         `protobuf:"varint,4,opt,name=pol_round,json=polRound,proto3" json:"pol_round,omitempty"`
-	BlockID   BlockID       `protobuf:"bytes,5,opt,name=block_id,json=blockId,proto3" json:"block_id"`
-	Timestamp time.Time     `protobuf:"bytes,6,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	Signature []byte        `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	Type                SignedMsgType        `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
+	Height              int64                `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Round               int32                `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	PolRound            int32                `protobuf:"varint,4,opt,name=pol_round,json=polRound,proto3" json:"pol_round,omitempty"`
+	BlockID             BlockID              `protobuf:"bytes,5,opt,name=block_id,json=blockId,proto3" json:"block_id"`
+	Timestamp           time.Time            `protobuf:"bytes,6,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature           []byte               `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	// the parity parts of the block, if erasure coded
+	ParityPartSetHeader *ParityPartSetHeader `protobuf:"bytes,8,opt,name=parity_part_set_header,json=parityPartSetHeader,proto3" json:"parity_part_set_header,omitempty"`
 }
 
 func (m *Proposal) Reset()         { *m = Proposal{} }
@@ -795,6 +797,13 @@ func (m *Proposal) GetSignature() []byte {
 	return nil
 }
 
+func (m *Proposal) GetParityPartSetHeader() *ParityPartSetHeader {
+	if m != nil {
+		return m.ParityPartSetHeader
+	}
+	return nil
+}
+
 type SignedHeader struct {
 	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Commit *Commit `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
@@ -1028,6 +1037,69 @@ func (m *TxProof) GetProof() *crypto.Proof {
 	return nil
 }
 
+// ParityPartSetHeader commits to the parity parts of an erasure coded proposed
+// block: the merkle root of the parity parts, and the size of the last part of
+// the block.
+type ParityPartSetHeader struct {
+	Total        uint32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Hash         []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	LastPartSize uint32 `protobuf:"varint,3,opt,name=last_part_size,json=lastPartSize,proto3" json:"last_part_size,omitempty"`
+}
+
+func (m *ParityPartSetHeader) Reset()         { *m = ParityPartSetHeader{} }
+func (m *ParityPartSetHeader) String() string { return proto.CompactTextString(m) }
+func (*ParityPartSetHeader) ProtoMessage()    {}
+func (*ParityPartSetHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{13}
+}
+func (m *ParityPartSetHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ParityPartSetHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ParityPartSetHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ParityPartSetHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParityPartSetHeader.Merge(m, src)
+}
+func (m *ParityPartSetHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *ParityPartSetHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParityPartSetHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParityPartSetHeader proto.InternalMessageInfo
+
+func (m *ParityPartSetHeader) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ParityPartSetHeader) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ParityPartSetHeader) GetLastPartSize() uint32 {
+	if m != nil {
+		return m.LastPartSize
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("tendermint.types.BlockIDFlag", BlockIDFlag_name, BlockIDFlag_value)
 	proto.RegisterEnum("tendermint.types.SignedMsgType", SignedMsgType_name, SignedMsgType_value)
@@ -1044,95 +1116,99 @@ func init() {
 	proto.RegisterType((*LightBlock)(nil), "tendermint.types.LightBlock")
 	proto.RegisterType((*BlockMeta)(nil), "tendermint.types.BlockMeta")
 	proto.RegisterType((*TxProof)(nil), "tendermint.types.TxProof")
+	proto.RegisterType((*ParityPartSetHeader)(nil), "tendermint.types.ParityPartSetHeader")
 }
 
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xda, 0x9b, 0xd8, 0x7e, 0xb6, 0x13, 0x67, 0x95, 0xb6, 0xae, 0xdb, 0x38, 0x2b, 0x23,
-	0x20, 0x2d, 0x68, 0x53, 0x52, 0xc4, 0x9f, 0x03, 0x07, 0xdb, 0x49, 0x5b, 0xab, 0x89, 0x63, 0xd6,
-	0x6e, 0x11, 0x5c, 0x56, 0x6b, 0xef, 0xd4, 0x5e, 0xba, 0xde, 0x59, 0xed, 0x8c, 0x43, 0xd2, 0x4f,
-	0x80, 0x72, 0xea, 0x89, 0x5b, 0x4e, 0x70, 0xe0, 0xce, 0x17, 0x40, 0x9c, 0x7a, 0xec, 0x0d, 0x2e,
-	0x14, 0x94, 0x4a, 0x88, 0x8f, 0x81, 0xe6, 0x8f, 0xd7, 0xeb, 0x38, 0x86, 0xaa, 0xaa, 0xb8, 0x58,
-	0x3b, 0xef, 0xfd, 0xde, 0xcc, 0x7b, 0xbf, 0xf7, 0x9b, 0x3f, 0x86, 0xeb, 0x14, 0xf9, 0x0e, 0x0a,
-	0x87, 0xae, 0x4f, 0xb7, 0xe8, 0x71, 0x80, 0x88, 0xf8, 0x35, 0x82, 0x10, 0x53, 0xac, 0x15, 0x26,
-	0x5e, 0x83, 0xdb, 0x4b, 0x6b, 0x7d, 0xdc, 0xc7, 0xdc, 0xb9, 0xc5, 0xbe, 0x04, 0xae, 0xb4, 0xd1,
-	0xc7, 0xb8, 0xef, 0xa1, 0x2d, 0x3e, 0xea, 0x8e, 0x1e, 0x6d, 0x51, 0x77, 0x88, 0x08, 0xb5, 0x87,
-	0x81, 0x04, 0xac, 0xc7, 0x96, 0xe9, 0x85, 0xc7, 0x01, 0xc5, 0x0c, 0x8b, 0x1f, 0x49, 0x77, 0x39,
-	0xe6, 0x3e, 0x44, 0x21, 0x71, 0xb1, 0x1f, 0xcf, 0xa3, 0xa4, 0xcf, 0x64, 0x79, 0x68, 0x7b, 0xae,
-	0x63, 0x53, 0x1c, 0x0a, 0x44, 0xe5, 0x53, 0xc8, 0xb7, 0xec, 0x90, 0xb6, 0x11, 0xbd, 0x87, 0x6c,
-	0x07, 0x85, 0xda, 0x1a, 0x2c, 0x52, 0x4c, 0x6d, 0xaf, 0xa8, 0xe8, 0xca, 0x66, 0xde, 0x14, 0x03,
-	0x4d, 0x03, 0x75, 0x60, 0x93, 0x41, 0x31, 0xa1, 0x2b, 0x9b, 0x39, 0x93, 0x7f, 0x57, 0x06, 0xa0,
-	0xb2, 0x50, 0x16, 0xe1, 0xfa, 0x0e, 0x3a, 0x1a, 0x47, 0xf0, 0x01, 0xb3, 0x76, 0x8f, 0x29, 0x22,
-	0x32, 0x44, 0x0c, 0xb4, 0x0f, 0x61, 0x91, 0xe7, 0x5f, 0x4c, 0xea, 0xca, 0x66, 0x76, 0xbb, 0x68,
-	0xc4, 0x88, 0x12, 0xf5, 0x19, 0x2d, 0xe6, 0xaf, 0xa9, 0xcf, 0x5e, 0x6c, 0x2c, 0x98, 0x02, 0x5c,
-	0xf1, 0x20, 0x55, 0xf3, 0x70, 0xef, 0x71, 0x63, 0x27, 0x4a, 0x44, 0x99, 0x24, 0xa2, 0xed, 0xc3,
-	0x4a, 0x60, 0x87, 0xd4, 0x22, 0x88, 0x5a, 0x03, 0x5e, 0x05, 0x5f, 0x34, 0xbb, 0xbd, 0x61, 0x9c,
-	0xef, 0x83, 0x31, 0x55, 0xac, 0x5c, 0x25, 0x1f, 0xc4, 0x8d, 0x95, 0xbf, 0x54, 0x58, 0x92, 0x64,
-	0x7c, 0x06, 0x29, 0x49, 0x2b, 0x5f, 0x30, 0xbb, 0xbd, 0x1e, 0x9f, 0x51, 0xba, 0x8c, 0x3a, 0xf6,
-	0x09, 0xf2, 0xc9, 0x88, 0xc8, 0xf9, 0xc6, 0x31, 0xda, 0x3b, 0x90, 0xee, 0x0d, 0x6c, 0xd7, 0xb7,
-	0x5c, 0x87, 0x67, 0x94, 0xa9, 0x65, 0xcf, 0x5e, 0x6c, 0xa4, 0xea, 0xcc, 0xd6, 0xd8, 0x31, 0x53,
-	0xdc, 0xd9, 0x70, 0xb4, 0xcb, 0xb0, 0x34, 0x40, 0x6e, 0x7f, 0x40, 0x39, 0x2d, 0x49, 0x53, 0x8e,
-	0xb4, 0x4f, 0x40, 0x65, 0x82, 0x28, 0xaa, 0x7c, 0xed, 0x92, 0x21, 0xd4, 0x62, 0x8c, 0xd5, 0x62,
-	0x74, 0xc6, 0x6a, 0xa9, 0xa5, 0xd9, 0xc2, 0x4f, 0xff, 0xd8, 0x50, 0x4c, 0x1e, 0xa1, 0xd5, 0x21,
-	0xef, 0xd9, 0x84, 0x5a, 0x5d, 0x46, 0x1b, 0x5b, 0x7e, 0x91, 0x4f, 0x71, 0x75, 0x96, 0x10, 0x49,
-	0xac, 0x4c, 0x3d, 0xcb, 0xa2, 0x84, 0xc9, 0xd1, 0x36, 0xa1, 0xc0, 0x27, 0xe9, 0xe1, 0xe1, 0xd0,
-	0xa5, 0x16, 0xe7, 0x7d, 0x89, 0xf3, 0xbe, 0xcc, 0xec, 0x75, 0x6e, 0xbe, 0xc7, 0x3a, 0x70, 0x0d,
-	0x32, 0x8e, 0x4d, 0x6d, 0x01, 0x49, 0x71, 0x48, 0x9a, 0x19, 0xb8, 0xf3, 0x5d, 0x58, 0x89, 0x54,
-	0x47, 0x04, 0x24, 0x2d, 0x66, 0x99, 0x98, 0x39, 0xf0, 0x16, 0xac, 0xf9, 0xe8, 0x88, 0x5a, 0xe7,
-	0xd1, 0x19, 0x8e, 0xd6, 0x98, 0xef, 0xe1, 0x74, 0xc4, 0xdb, 0xb0, 0xdc, 0x1b, 0x93, 0x2f, 0xb0,
-	0xc0, 0xb1, 0xf9, 0xc8, 0xca, 0x61, 0x57, 0x21, 0x6d, 0x07, 0x81, 0x00, 0x64, 0x39, 0x20, 0x65,
-	0x07, 0x01, 0x77, 0xdd, 0x84, 0x55, 0x5e, 0x63, 0x88, 0xc8, 0xc8, 0xa3, 0x72, 0x92, 0x1c, 0xc7,
-	0xac, 0x30, 0x87, 0x29, 0xec, 0x1c, 0xfb, 0x16, 0xe4, 0xd1, 0xa1, 0xeb, 0x20, 0xbf, 0x87, 0x04,
-	0x2e, 0xcf, 0x71, 0xb9, 0xb1, 0x91, 0x83, 0x6e, 0x40, 0x21, 0x08, 0x71, 0x80, 0x09, 0x0a, 0x2d,
-	0xdb, 0x71, 0x42, 0x44, 0x48, 0x71, 0x59, 0xcc, 0x37, 0xb6, 0x57, 0x85, 0xb9, 0x52, 0x04, 0x75,
-	0xc7, 0xa6, 0xb6, 0x56, 0x80, 0x24, 0x3d, 0x22, 0x45, 0x45, 0x4f, 0x6e, 0xe6, 0x4c, 0xf6, 0x59,
-	0xf9, 0x3b, 0x01, 0xea, 0x43, 0x4c, 0x91, 0x76, 0x1b, 0x54, 0xd6, 0x26, 0xae, 0xbe, 0xe5, 0x8b,
-	0xf4, 0xdc, 0x76, 0xfb, 0x3e, 0x72, 0xf6, 0x49, 0xbf, 0x73, 0x1c, 0x20, 0x93, 0x83, 0x63, 0x72,
-	0x4a, 0x4c, 0xc9, 0x69, 0x0d, 0x16, 0x43, 0x3c, 0xf2, 0x1d, 0xae, 0xb2, 0x45, 0x53, 0x0c, 0xb4,
-	0x5d, 0x48, 0x47, 0x2a, 0x51, 0xff, 0x4b, 0x25, 0x2b, 0x4c, 0x25, 0x4c, 0xc3, 0xd2, 0x60, 0xa6,
-	0xba, 0x52, 0x2c, 0x35, 0xc8, 0x44, 0x87, 0x97, 0x54, 0xdb, 0xab, 0x09, 0x76, 0x12, 0xa6, 0xbd,
-	0x07, 0xab, 0x51, 0xef, 0x23, 0xf2, 0x84, 0xe2, 0x0a, 0x91, 0x43, 0xb2, 0x37, 0x25, 0x2b, 0x4b,
-	0x1c, 0x40, 0x29, 0x5e, 0xd7, 0x44, 0x56, 0x0d, 0x7e, 0x12, 0x5d, 0x87, 0x0c, 0x71, 0xfb, 0xbe,
-	0x4d, 0x47, 0x21, 0x92, 0xca, 0x9b, 0x18, 0x2a, 0x3f, 0x2b, 0xb0, 0x24, 0x94, 0x1c, 0xe3, 0x4d,
-	0xb9, 0x98, 0xb7, 0xc4, 0x3c, 0xde, 0x92, 0xaf, 0xcf, 0x5b, 0x15, 0x20, 0x4a, 0x86, 0x14, 0x55,
-	0x3d, 0xb9, 0x99, 0xdd, 0xbe, 0x36, 0x3b, 0x91, 0x48, 0xb1, 0xed, 0xf6, 0xe5, 0x46, 0x8d, 0x05,
-	0x55, 0x7e, 0x57, 0x20, 0x13, 0xf9, 0xb5, 0x2a, 0xe4, 0xc7, 0x79, 0x59, 0x8f, 0x3c, 0xbb, 0x2f,
-	0xb5, 0xb3, 0x3e, 0x37, 0xb9, 0x3b, 0x9e, 0xdd, 0x37, 0xb3, 0x32, 0x1f, 0x36, 0xb8, 0xb8, 0x0f,
-	0x89, 0x39, 0x7d, 0x98, 0x6a, 0x7c, 0xf2, 0xf5, 0x1a, 0x3f, 0xd5, 0x22, 0xf5, 0x7c, 0x8b, 0x7e,
-	0x4a, 0x40, 0xba, 0xc5, 0xf7, 0x8e, 0xed, 0xfd, 0x1f, 0x3b, 0xe2, 0x1a, 0x64, 0x02, 0xec, 0x59,
-	0xc2, 0xa3, 0x72, 0x4f, 0x3a, 0xc0, 0x9e, 0x39, 0xd3, 0xf6, 0xc5, 0x37, 0xb4, 0x5d, 0x96, 0xde,
-	0x00, 0x6b, 0xa9, 0xf3, 0xac, 0x85, 0x90, 0x13, 0x54, 0xc8, 0xbb, 0xec, 0x16, 0xe3, 0x80, 0x5f,
-	0x8e, 0xca, 0xec, 0xdd, 0x2b, 0xd2, 0x16, 0x48, 0x53, 0xe2, 0x58, 0x84, 0x38, 0xfa, 0xe5, 0x75,
-	0x5a, 0x9c, 0x27, 0x4b, 0x53, 0xe2, 0x2a, 0xdf, 0x29, 0x00, 0x7b, 0x8c, 0x59, 0x5e, 0x2f, 0xbb,
-	0x85, 0x08, 0x4f, 0xc1, 0x9a, 0x5a, 0xb9, 0x3c, 0xaf, 0x69, 0x72, 0xfd, 0x1c, 0x89, 0xe7, 0x5d,
-	0x87, 0xfc, 0x44, 0x8c, 0x04, 0x8d, 0x93, 0xb9, 0x60, 0x92, 0xe8, 0x72, 0x68, 0x23, 0x6a, 0xe6,
-	0x0e, 0x63, 0xa3, 0xca, 0x2f, 0x0a, 0x64, 0x78, 0x4e, 0xfb, 0x88, 0xda, 0x53, 0x3d, 0x54, 0x5e,
-	0xbf, 0x87, 0xeb, 0x00, 0x62, 0x1a, 0xe2, 0x3e, 0x41, 0x52, 0x59, 0x19, 0x6e, 0x69, 0xbb, 0x4f,
-	0x90, 0xf6, 0x51, 0x44, 0x78, 0xf2, 0xdf, 0x09, 0x97, 0x5b, 0x7a, 0x4c, 0xfb, 0x15, 0x48, 0xf9,
-	0xa3, 0xa1, 0xc5, 0xae, 0x04, 0x55, 0xa8, 0xd5, 0x1f, 0x0d, 0x3b, 0x47, 0xa4, 0xf2, 0x35, 0xa4,
-	0x3a, 0x47, 0xfc, 0x79, 0xc4, 0x24, 0x1a, 0x62, 0x2c, 0xef, 0x64, 0xf1, 0x16, 0x4a, 0x33, 0x03,
-	0xbf, 0x82, 0x34, 0x50, 0xd9, 0xe5, 0x3b, 0x7e, 0xac, 0xb1, 0x6f, 0xcd, 0x78, 0xc5, 0x87, 0x97,
-	0x7c, 0x72, 0xdd, 0xfc, 0x55, 0x81, 0x6c, 0xec, 0x7c, 0xd0, 0x3e, 0x80, 0x4b, 0xb5, 0xbd, 0x83,
-	0xfa, 0x7d, 0xab, 0xb1, 0x63, 0xdd, 0xd9, 0xab, 0xde, 0xb5, 0x1e, 0x34, 0xef, 0x37, 0x0f, 0xbe,
-	0x68, 0x16, 0x16, 0x4a, 0x97, 0x4f, 0x4e, 0x75, 0x2d, 0x86, 0x7d, 0xe0, 0x3f, 0xf6, 0xf1, 0x37,
-	0xbe, 0xb6, 0x05, 0x6b, 0xd3, 0x21, 0xd5, 0x5a, 0x7b, 0xb7, 0xd9, 0x29, 0x28, 0xa5, 0x4b, 0x27,
-	0xa7, 0xfa, 0x6a, 0x2c, 0xa2, 0xda, 0x25, 0xc8, 0xa7, 0xb3, 0x01, 0xf5, 0x83, 0xfd, 0xfd, 0x46,
-	0xa7, 0x90, 0x98, 0x09, 0x90, 0x07, 0xf6, 0x0d, 0x58, 0x9d, 0x0e, 0x68, 0x36, 0xf6, 0x0a, 0xc9,
-	0x92, 0x76, 0x72, 0xaa, 0x2f, 0xc7, 0xd0, 0x4d, 0xd7, 0x2b, 0xa5, 0xbf, 0xfd, 0xbe, 0xbc, 0xf0,
-	0xe3, 0x0f, 0x65, 0x85, 0x55, 0x96, 0x9f, 0x3a, 0x23, 0xb4, 0xf7, 0xe1, 0x4a, 0xbb, 0x71, 0xb7,
-	0xb9, 0xbb, 0x63, 0xed, 0xb7, 0xef, 0x5a, 0x9d, 0x2f, 0x5b, 0xbb, 0xb1, 0xea, 0x56, 0x4e, 0x4e,
-	0xf5, 0xac, 0x2c, 0x69, 0x1e, 0xba, 0x65, 0xee, 0x3e, 0x3c, 0xe8, 0xec, 0x16, 0x14, 0x81, 0x6e,
-	0x85, 0xe8, 0x10, 0x53, 0xc4, 0xd1, 0xb7, 0xe0, 0xea, 0x05, 0xe8, 0xa8, 0xb0, 0xd5, 0x93, 0x53,
-	0x3d, 0xdf, 0x0a, 0x91, 0xd8, 0x3f, 0x3c, 0xc2, 0x80, 0xe2, 0x6c, 0xc4, 0x41, 0xeb, 0xa0, 0x5d,
-	0xdd, 0x2b, 0xe8, 0xa5, 0xc2, 0xc9, 0xa9, 0x9e, 0x1b, 0x1f, 0x86, 0x0c, 0x3f, 0xa9, 0xac, 0xf6,
-	0xf9, 0xb3, 0xb3, 0xb2, 0xf2, 0xfc, 0xac, 0xac, 0xfc, 0x79, 0x56, 0x56, 0x9e, 0xbe, 0x2c, 0x2f,
-	0x3c, 0x7f, 0x59, 0x5e, 0xf8, 0xed, 0x65, 0x79, 0xe1, 0xab, 0x8f, 0xfb, 0x2e, 0x1d, 0x8c, 0xba,
-	0x46, 0x0f, 0x0f, 0xb7, 0xe2, 0x7f, 0x09, 0x26, 0x9f, 0xe2, 0xaf, 0xc9, 0xf9, 0xbf, 0x0b, 0xdd,
-	0x25, 0x6e, 0xbf, 0xfd, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x78, 0x43, 0xdf, 0xef, 0x0c,
-	0x00, 0x00,
+	// 1365 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x6f, 0x1a, 0x47,
+	0x14, 0xf7, 0xc2, 0xf2, 0xef, 0x01, 0x36, 0x9e, 0x38, 0x09, 0x21, 0x31, 0x46, 0xb4, 0x69, 0x9d,
+	0xb4, 0xc2, 0xa9, 0x53, 0xf5, 0xcf, 0xa1, 0x07, 0xc0, 0x4e, 0x82, 0x62, 0x63, 0xba, 0x90, 0x54,
+	0xcd, 0x65, 0xb5, 0xc0, 0x04, 0xb6, 0x81, 0xdd, 0xd5, 0xee, 0xe0, 0xda, 0xf9, 0x04, 0x95, 0x2f,
+	0xcd, 0xa9, 0x37, 0x9f, 0xda, 0x43, 0x3f, 0x46, 0xd5, 0x53, 0x8e, 0xb9, 0xb5, 0x97, 0xa6, 0x95,
+	0x23, 0x55, 0xfd, 0x18, 0xd5, 0xbc, 0x19, 0x96, 0xc5, 0xe0, 0x36, 0xb5, 0xa2, 0x5e, 0xd0, 0xce,
+	0x7b, 0xbf, 0xf7, 0xe6, 0xcd, 0xef, 0xfd, 0x76, 0xdf, 0x00, 0xd7, 0x18, 0xb5, 0xba, 0xd4, 0x1d,
+	0x9a, 0x16, 0xdb, 0x60, 0x87, 0x0e, 0xf5, 0xc4, 0x6f, 0xc9, 0x71, 0x6d, 0x66, 0x93, 0xcc, 0xc4,
+	0x5b, 0x42, 0x7b, 0x6e, 0xa5, 0x67, 0xf7, 0x6c, 0x74, 0x6e, 0xf0, 0x27, 0x81, 0xcb, 0xad, 0xf5,
+	0x6c, 0xbb, 0x37, 0xa0, 0x1b, 0xb8, 0x6a, 0x8f, 0x1e, 0x6f, 0x30, 0x73, 0x48, 0x3d, 0x66, 0x0c,
+	0x1d, 0x09, 0x58, 0x0d, 0x6c, 0xd3, 0x71, 0x0f, 0x1d, 0x66, 0x73, 0xac, 0xfd, 0x58, 0xba, 0xf3,
+	0x01, 0xf7, 0x3e, 0x75, 0x3d, 0xd3, 0xb6, 0x82, 0x75, 0xe4, 0x0a, 0x33, 0x55, 0xee, 0x1b, 0x03,
+	0xb3, 0x6b, 0x30, 0xdb, 0x15, 0x88, 0xe2, 0xa7, 0x90, 0x6e, 0x18, 0x2e, 0x6b, 0x52, 0x76, 0x8f,
+	0x1a, 0x5d, 0xea, 0x92, 0x15, 0x88, 0x30, 0x9b, 0x19, 0x83, 0xac, 0x52, 0x50, 0xd6, 0xd3, 0x9a,
+	0x58, 0x10, 0x02, 0x6a, 0xdf, 0xf0, 0xfa, 0xd9, 0x50, 0x41, 0x59, 0x4f, 0x69, 0xf8, 0x5c, 0xec,
+	0x83, 0xca, 0x43, 0x79, 0x84, 0x69, 0x75, 0xe9, 0xc1, 0x38, 0x02, 0x17, 0xdc, 0xda, 0x3e, 0x64,
+	0xd4, 0x93, 0x21, 0x62, 0x41, 0x3e, 0x84, 0x08, 0xd6, 0x9f, 0x0d, 0x17, 0x94, 0xf5, 0xe4, 0x66,
+	0xb6, 0x14, 0x20, 0x4a, 0x9c, 0xaf, 0xd4, 0xe0, 0xfe, 0x8a, 0xfa, 0xfc, 0xe5, 0xda, 0x82, 0x26,
+	0xc0, 0xc5, 0x01, 0xc4, 0x2a, 0x03, 0xbb, 0xf3, 0xa4, 0xb6, 0xe5, 0x17, 0xa2, 0x4c, 0x0a, 0x21,
+	0xbb, 0xb0, 0xe4, 0x18, 0x2e, 0xd3, 0x3d, 0xca, 0xf4, 0x3e, 0x9e, 0x02, 0x37, 0x4d, 0x6e, 0xae,
+	0x95, 0x4e, 0xf7, 0xa1, 0x34, 0x75, 0x58, 0xb9, 0x4b, 0xda, 0x09, 0x1a, 0x8b, 0x7f, 0xaa, 0x10,
+	0x95, 0x64, 0x7c, 0x06, 0x31, 0x49, 0x2b, 0x6e, 0x98, 0xdc, 0x5c, 0x0d, 0x66, 0x94, 0xae, 0x52,
+	0xd5, 0xb6, 0x3c, 0x6a, 0x79, 0x23, 0x4f, 0xe6, 0x1b, 0xc7, 0x90, 0x77, 0x20, 0xde, 0xe9, 0x1b,
+	0xa6, 0xa5, 0x9b, 0x5d, 0xac, 0x28, 0x51, 0x49, 0x9e, 0xbc, 0x5c, 0x8b, 0x55, 0xb9, 0xad, 0xb6,
+	0xa5, 0xc5, 0xd0, 0x59, 0xeb, 0x92, 0x4b, 0x10, 0xed, 0x53, 0xb3, 0xd7, 0x67, 0x48, 0x4b, 0x58,
+	0x93, 0x2b, 0xf2, 0x09, 0xa8, 0x5c, 0x10, 0x59, 0x15, 0xf7, 0xce, 0x95, 0x84, 0x5a, 0x4a, 0x63,
+	0xb5, 0x94, 0x5a, 0x63, 0xb5, 0x54, 0xe2, 0x7c, 0xe3, 0x67, 0xbf, 0xaf, 0x29, 0x1a, 0x46, 0x90,
+	0x2a, 0xa4, 0x07, 0x86, 0xc7, 0xf4, 0x36, 0xa7, 0x8d, 0x6f, 0x1f, 0xc1, 0x14, 0x57, 0x66, 0x09,
+	0x91, 0xc4, 0xca, 0xd2, 0x93, 0x3c, 0x4a, 0x98, 0xba, 0x64, 0x1d, 0x32, 0x98, 0xa4, 0x63, 0x0f,
+	0x87, 0x26, 0xd3, 0x91, 0xf7, 0x28, 0xf2, 0xbe, 0xc8, 0xed, 0x55, 0x34, 0xdf, 0xe3, 0x1d, 0xb8,
+	0x0a, 0x89, 0xae, 0xc1, 0x0c, 0x01, 0x89, 0x21, 0x24, 0xce, 0x0d, 0xe8, 0x7c, 0x17, 0x96, 0x7c,
+	0xd5, 0x79, 0x02, 0x12, 0x17, 0x59, 0x26, 0x66, 0x04, 0xde, 0x82, 0x15, 0x8b, 0x1e, 0x30, 0xfd,
+	0x34, 0x3a, 0x81, 0x68, 0xc2, 0x7d, 0x0f, 0xa7, 0x23, 0xae, 0xc3, 0x62, 0x67, 0x4c, 0xbe, 0xc0,
+	0x02, 0x62, 0xd3, 0xbe, 0x15, 0x61, 0x57, 0x20, 0x6e, 0x38, 0x8e, 0x00, 0x24, 0x11, 0x10, 0x33,
+	0x1c, 0x07, 0x5d, 0x37, 0x61, 0x19, 0xcf, 0xe8, 0x52, 0x6f, 0x34, 0x60, 0x32, 0x49, 0x0a, 0x31,
+	0x4b, 0xdc, 0xa1, 0x09, 0x3b, 0x62, 0xdf, 0x82, 0x34, 0xdd, 0x37, 0xbb, 0xd4, 0xea, 0x50, 0x81,
+	0x4b, 0x23, 0x2e, 0x35, 0x36, 0x22, 0xe8, 0x06, 0x64, 0x1c, 0xd7, 0x76, 0x6c, 0x8f, 0xba, 0xba,
+	0xd1, 0xed, 0xba, 0xd4, 0xf3, 0xb2, 0x8b, 0x22, 0xdf, 0xd8, 0x5e, 0x16, 0xe6, 0x62, 0x16, 0xd4,
+	0x2d, 0x83, 0x19, 0x24, 0x03, 0x61, 0x76, 0xe0, 0x65, 0x95, 0x42, 0x78, 0x3d, 0xa5, 0xf1, 0xc7,
+	0xe2, 0x5f, 0x21, 0x50, 0x1f, 0xda, 0x8c, 0x92, 0xdb, 0xa0, 0xf2, 0x36, 0xa1, 0xfa, 0x16, 0xe7,
+	0xe9, 0xb9, 0x69, 0xf6, 0x2c, 0xda, 0xdd, 0xf5, 0x7a, 0xad, 0x43, 0x87, 0x6a, 0x08, 0x0e, 0xc8,
+	0x29, 0x34, 0x25, 0xa7, 0x15, 0x88, 0xb8, 0xf6, 0xc8, 0xea, 0xa2, 0xca, 0x22, 0x9a, 0x58, 0x90,
+	0x6d, 0x88, 0xfb, 0x2a, 0x51, 0xff, 0x4d, 0x25, 0x4b, 0x5c, 0x25, 0x5c, 0xc3, 0xd2, 0xa0, 0xc5,
+	0xda, 0x52, 0x2c, 0x15, 0x48, 0xf8, 0x1f, 0xaf, 0x6c, 0xe4, 0x3f, 0x08, 0x76, 0x12, 0x46, 0xde,
+	0x83, 0x65, 0xbf, 0xf7, 0x3e, 0x79, 0x42, 0x71, 0x19, 0xdf, 0x21, 0xd9, 0x9b, 0x92, 0x95, 0x2e,
+	0x3e, 0x40, 0x31, 0x3c, 0xd7, 0x44, 0x56, 0x35, 0x6e, 0x25, 0xd7, 0x20, 0xe1, 0x99, 0x3d, 0xcb,
+	0x60, 0x23, 0x97, 0x4a, 0xe5, 0x4d, 0x0c, 0xc5, 0x9f, 0x14, 0x88, 0x0a, 0x25, 0x07, 0x78, 0x53,
+	0xe6, 0xf3, 0x16, 0x3a, 0x8b, 0xb7, 0xf0, 0xf9, 0x79, 0x2b, 0x03, 0xf8, 0xc5, 0x78, 0x59, 0xb5,
+	0x10, 0x5e, 0x4f, 0x6e, 0x5e, 0x9d, 0x4d, 0x24, 0x4a, 0x6c, 0x9a, 0x3d, 0xf9, 0xa2, 0x06, 0x82,
+	0x8a, 0xbf, 0x29, 0x90, 0xf0, 0xfd, 0xa4, 0x0c, 0xe9, 0x71, 0x5d, 0xfa, 0xe3, 0x81, 0xd1, 0x93,
+	0xda, 0x59, 0x3d, 0xb3, 0xb8, 0x3b, 0x03, 0xa3, 0xa7, 0x25, 0x65, 0x3d, 0x7c, 0x31, 0xbf, 0x0f,
+	0xa1, 0x33, 0xfa, 0x30, 0xd5, 0xf8, 0xf0, 0xf9, 0x1a, 0x3f, 0xd5, 0x22, 0xf5, 0x74, 0x8b, 0xbe,
+	0x0d, 0x43, 0xbc, 0x81, 0xef, 0x8e, 0x31, 0xf8, 0x3f, 0xde, 0x88, 0xab, 0x90, 0x70, 0xec, 0x81,
+	0x2e, 0x3c, 0x2a, 0x7a, 0xe2, 0x8e, 0x3d, 0xd0, 0x66, 0xda, 0x1e, 0x79, 0x43, 0xaf, 0x4b, 0xf4,
+	0x0d, 0xb0, 0x16, 0x3b, 0xc5, 0x1a, 0x79, 0x04, 0x97, 0x1c, 0xc3, 0x35, 0xd9, 0xa1, 0x7e, 0x7a,
+	0x38, 0xc6, 0x71, 0xbb, 0xeb, 0x73, 0x87, 0xa3, 0xc9, 0x0e, 0xa7, 0x46, 0xa4, 0x76, 0xc1, 0x99,
+	0x35, 0x16, 0x5d, 0x48, 0x09, 0x9a, 0xc5, 0x9a, 0xdc, 0xe2, 0xfc, 0x62, 0x6e, 0x65, 0x76, 0xae,
+	0x8b, 0xdc, 0x32, 0x5d, 0xb4, 0xef, 0x47, 0x88, 0xb1, 0x92, 0x0d, 0x9d, 0x15, 0x21, 0x24, 0xad,
+	0x49, 0x5c, 0xf1, 0x3b, 0x05, 0x60, 0x87, 0x77, 0x0d, 0xb9, 0xe4, 0x13, 0xce, 0xc3, 0x12, 0xf4,
+	0xa9, 0x9d, 0xf3, 0x67, 0x09, 0x42, 0xee, 0x9f, 0xf2, 0x82, 0x75, 0x57, 0x21, 0x3d, 0x11, 0xba,
+	0x47, 0xc7, 0xc5, 0xcc, 0x49, 0xe2, 0x0f, 0x9e, 0x26, 0x65, 0x5a, 0x6a, 0x3f, 0xb0, 0x2a, 0xfe,
+	0xac, 0x40, 0x02, 0x6b, 0xda, 0xa5, 0xcc, 0x98, 0xd2, 0x87, 0x72, 0x7e, 0x7d, 0xac, 0x02, 0x88,
+	0x34, 0x9e, 0xf9, 0x94, 0x4a, 0xd5, 0x26, 0xd0, 0xd2, 0x34, 0x9f, 0x52, 0xf2, 0x91, 0x4f, 0x78,
+	0xf8, 0x9f, 0x09, 0x97, 0x9f, 0x8b, 0x31, 0xed, 0x97, 0x21, 0x66, 0x8d, 0x86, 0x3a, 0x1f, 0x37,
+	0xaa, 0x78, 0x13, 0xac, 0xd1, 0xb0, 0x75, 0xe0, 0x15, 0xbf, 0x82, 0x58, 0xeb, 0x00, 0xaf, 0x5e,
+	0x5c, 0xfe, 0xae, 0x6d, 0xcb, 0x79, 0x2f, 0xee, 0x59, 0x71, 0x6e, 0xc0, 0xf1, 0x46, 0x40, 0xe5,
+	0x83, 0x7d, 0x7c, 0x11, 0xe4, 0xcf, 0xa4, 0xf4, 0x9a, 0x97, 0xba, 0xf1, 0x75, 0x8e, 0xc2, 0x85,
+	0x39, 0x4a, 0x7b, 0xfd, 0x9b, 0x27, 0x79, 0x1b, 0xf0, 0x02, 0x22, 0x85, 0xcd, 0x09, 0x0a, 0x63,
+	0x48, 0x8a, 0x5b, 0x31, 0xa9, 0xf9, 0x94, 0xde, 0xfc, 0x45, 0x81, 0x64, 0xe0, 0x13, 0x47, 0x3e,
+	0x80, 0x8b, 0x95, 0x9d, 0xbd, 0xea, 0x7d, 0xbd, 0xb6, 0xa5, 0xdf, 0xd9, 0x29, 0xdf, 0xd5, 0x1f,
+	0xd4, 0xef, 0xd7, 0xf7, 0xbe, 0xa8, 0x67, 0x16, 0x72, 0x97, 0x8e, 0x8e, 0x0b, 0x24, 0x80, 0x7d,
+	0x60, 0x3d, 0xb1, 0xec, 0xaf, 0x2d, 0xb2, 0x01, 0x2b, 0xd3, 0x21, 0xe5, 0x4a, 0x73, 0xbb, 0xde,
+	0xca, 0x28, 0xb9, 0x8b, 0x47, 0xc7, 0x85, 0xe5, 0x40, 0x44, 0xb9, 0xed, 0x51, 0x8b, 0xcd, 0x06,
+	0x54, 0xf7, 0x76, 0x77, 0x6b, 0xad, 0x4c, 0x68, 0x26, 0x40, 0xce, 0x9c, 0x1b, 0xb0, 0x3c, 0x1d,
+	0x50, 0xaf, 0xed, 0x64, 0xc2, 0x39, 0x72, 0x74, 0x5c, 0x58, 0x0c, 0xa0, 0xeb, 0xe6, 0x20, 0x17,
+	0xff, 0xe6, 0xfb, 0xfc, 0xc2, 0x8f, 0x3f, 0xe4, 0x15, 0x7e, 0xb2, 0xf4, 0xd4, 0x67, 0x8e, 0xbc,
+	0x0f, 0x97, 0x9b, 0xb5, 0xbb, 0xf5, 0xed, 0x2d, 0x7d, 0xb7, 0x79, 0x57, 0x6f, 0x7d, 0xd9, 0xd8,
+	0x0e, 0x9c, 0x6e, 0xe9, 0xe8, 0xb8, 0x90, 0x94, 0x47, 0x3a, 0x0b, 0xdd, 0xd0, 0xb6, 0x1f, 0xee,
+	0xb5, 0xb6, 0x33, 0x8a, 0x40, 0x37, 0x5c, 0xba, 0x6f, 0x33, 0x8a, 0xe8, 0x5b, 0x70, 0x65, 0x0e,
+	0xda, 0x3f, 0xd8, 0xf2, 0xd1, 0x71, 0x21, 0xdd, 0x70, 0xa9, 0x78, 0x4d, 0x31, 0xa2, 0x04, 0xd9,
+	0xd9, 0x88, 0xbd, 0xc6, 0x5e, 0xb3, 0xbc, 0x93, 0x29, 0xe4, 0x32, 0x47, 0xc7, 0x85, 0xd4, 0xf8,
+	0x7b, 0xce, 0xf1, 0x93, 0x93, 0x55, 0x3e, 0x7f, 0x7e, 0x92, 0x57, 0x5e, 0x9c, 0xe4, 0x95, 0x3f,
+	0x4e, 0xf2, 0xca, 0xb3, 0x57, 0xf9, 0x85, 0x17, 0xaf, 0xf2, 0x0b, 0xbf, 0xbe, 0xca, 0x2f, 0x3c,
+	0xfa, 0xb8, 0x67, 0xb2, 0xfe, 0xa8, 0x5d, 0xea, 0xd8, 0xc3, 0x8d, 0xe0, 0xbf, 0x9a, 0xc9, 0xa3,
+	0xf8, 0x77, 0x75, 0xfa, 0x1f, 0x4f, 0x3b, 0x8a, 0xf6, 0xdb, 0x7f, 0x0f, 0x00, 0x1a, 0xe0, 0x33,
+	0x63, 0xb2, 0x0d, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1615,6 +1691,18 @@ func (m *Proposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ParityPartSetHeader != nil {
+		{
+			size, err := m.ParityPartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
 	if len(m.Signature) > 0 {
 		i -= len(m.Signature)
 		copy(dAtA[i:], m.Signature)
@@ -1622,12 +1710,12 @@ func (m *Proposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x3a
 	}
-	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err10 != nil {
-		return 0, err10
+	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err11 != nil {
+		return 0, err11
 	}
-	i -= n10
-	i = encodeVarintTypes(dAtA, i, uint64(n10))
+	i -= n11
+	i = encodeVarintTypes(dAtA, i, uint64(n11))
 	i--
 	dAtA[i] = 0x32
 	{
@@ -1859,6 +1947,46 @@ func (m *TxProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ParityPartSetHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ParityPartSetHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ParityPartSetHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastPartSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastPartSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Total != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -2096,6 +2224,10 @@ func (m *Proposal) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.ParityPartSetHeader != nil {
+		l = m.ParityPartSetHeader.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -2173,6 +2305,25 @@ func (m *TxProof) Size() (n int) {
 	return n
 }
 
+func (m *ParityPartSetHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovTypes(uint64(m.Total))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.LastPartSize != 0 {
+		n += 1 + sovTypes(uint64(m.LastPartSize))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -3913,6 +4064,42 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 				m.Signature = []byte{}
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParityPartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ParityPartSetHeader == nil {
+				m.ParityPartSetHeader = &ParityPartSetHeader{}
+			}
+			if err := m.ParityPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -4486,6 +4673,128 @@ func (m *TxProof) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ParityPartSetHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ParityPartSetHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ParityPartSetHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastPartSize", wireType)
+			}
+			m.LastPartSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastPartSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -20,6 +20,10 @@ type AppConnConsensus interface {
 	DeliverTxAsync(types.RequestDeliverTx) *abcicli.ReqRes
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
 	CommitSync() (*types.ResponseCommit, error)
+
+	GetAppHashSync(types.RequestGetAppHash) (*types.ResponseGetAppHash, error)
+	GenerateFraudProofSync(types.RequestGenerateFraudProof) (*types.ResponseGenerateFraudProof, error)
+	VerifyFraudProofSync(types.RequestVerifyFraudProof) (*types.ResponseVerifyFraudProof, error)
 }
 
 type AppConnMempool interface {
@@ -93,6 +97,21 @@ func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
 	return app.appConn.CommitSync()
 }
 
+func (app *appConnConsensus) GetAppHashSync(
+	req types.RequestGetAppHash) (*types.ResponseGetAppHash, error) {
+	return app.appConn.GetAppHashSync(req)
+}
+
+func (app *appConnConsensus) GenerateFraudProofSync(
+	req types.RequestGenerateFraudProof) (*types.ResponseGenerateFraudProof, error) {
+	return app.appConn.GenerateFraudProofSync(req)
+}
+
+func (app *appConnConsensus) VerifyFraudProofSync(
+	req types.RequestVerifyFraudProof) (*types.ResponseVerifyFraudProof, error) {
+	return app.appConn.VerifyFraudProofSync(req)
+}
+
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
@@ -14,6 +14,8 @@ const (
 	connMempool   = "mempool"
 	connQuery     = "query"
 	connSnapshot  = "snapshot"
+
+	connMempoolRecheck = "mempool-recheck"
 )
 
 // AppConns is the CometBFT's interface to the application that consists of
@@ -29,11 +31,23 @@ type AppConns interface {
 	Query() AppConnQuery
 	// Snapshot connection
 	Snapshot() AppConnSnapshot
+	// Dedicated connections to recheck the txs of the mempool in parallel, if
+	// any
+	MempoolRecheck() []AppConnMempool
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
+}
+
+// MultiAppConnOption sets an optional parameter on the multiAppConn.
+type MultiAppConnOption func(*multiAppConn)
+
+// WithMempoolRecheckConns opens n dedicated connections to recheck the txs of
+// the mempool in parallel.
+func WithMempoolRecheckConns(n int) MultiAppConnOption {
+	return func(app *multiAppConn) { app.numRecheckConns = n }
 }
 
 // multiAppConn implements AppConns.
@@ -54,15 +68,22 @@ type multiAppConn struct {
 	queryConnClient     abcicli.Client
 	snapshotConnClient  abcicli.Client
 
+	numRecheckConns    int
+	recheckConns       []AppConnMempool
+	recheckConnClients []abcicli.Client
+
 	clientCreator ClientCreator
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator) AppConns {
+func NewMultiAppConn(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
 	multiAppConn := &multiAppConn{
 		clientCreator: clientCreator,
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	return multiAppConn
 }
 
@@ -82,6 +103,10 @@ func (app *multiAppConn) Snapshot() AppConnSnapshot {
 	return app.snapshotConn
 }
 
+func (app *multiAppConn) MempoolRecheck() []AppConnMempool {
+	return app.recheckConns
+}
+
 func (app *multiAppConn) OnStart() error {
 	c, err := app.abciClientFor(connQuery)
 	if err != nil {
@@ -114,8 +139,21 @@ func (app *multiAppConn) OnStart() error {
 	app.consensusConnClient = c
 	app.consensusConn = NewAppConnConsensus(c)
 
+	for i := 0; i < app.numRecheckConns; i++ {
+		c, err = app.abciClientFor(fmt.Sprintf("%s-%d", connMempoolRecheck, i))
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.recheckConnClients = append(app.recheckConnClients, c)
+		app.recheckConns = append(app.recheckConns, NewAppConnMempool(c))
+	}
+
 	// Kill CometBFT if the ABCI application crashes.
 	go app.killTMOnClientError()
+	for _, c := range app.recheckConnClients {
+		go app.killTMOnRecheckClientError(c)
+	}
 
 	return nil
 }
@@ -124,37 +162,46 @@ func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
 }
 
-func (app *multiAppConn) killTMOnClientError() {
-	killFn := func(conn string, err error, logger cmtlog.Logger) {
-		logger.Error(
-			fmt.Sprintf("%s connection terminated. Did the application crash? Please restart CometBFT", conn),
-			"err", err)
-		killErr := cmtos.Kill()
-		if killErr != nil {
-			logger.Error("Failed to kill this process - please do so manually", "err", killErr)
-		}
+func killTM(conn string, err error, logger cmtlog.Logger) {
+	logger.Error(
+		fmt.Sprintf("%s connection terminated. Did the application crash? Please restart CometBFT", conn),
+		"err", err)
+	killErr := cmtos.Kill()
+	if killErr != nil {
+		logger.Error("Failed to kill this process - please do so manually", "err", killErr)
 	}
+}
 
+func (app *multiAppConn) killTMOnClientError() {
 	select {
 	case <-app.consensusConnClient.Quit():
 		if err := app.consensusConnClient.Error(); err != nil {
-			killFn(connConsensus, err, app.Logger)
+			killTM(connConsensus, err, app.Logger)
 		}
 	case <-app.mempoolConnClient.Quit():
 		if err := app.mempoolConnClient.Error(); err != nil {
-			killFn(connMempool, err, app.Logger)
+			killTM(connMempool, err, app.Logger)
 		}
 	case <-app.queryConnClient.Quit():
 		if err := app.queryConnClient.Error(); err != nil {
-			killFn(connQuery, err, app.Logger)
+			killTM(connQuery, err, app.Logger)
 		}
 	case <-app.snapshotConnClient.Quit():
 		if err := app.snapshotConnClient.Error(); err != nil {
-			killFn(connSnapshot, err, app.Logger)
+			killTM(connSnapshot, err, app.Logger)
 		}
 	}
 }
 
+// killTMOnRecheckClientError kills CometBFT if the recheck connection c
+// terminates with an error.
+func (app *multiAppConn) killTMOnRecheckClientError(c abcicli.Client) {
+	<-c.Quit()
+	if err := c.Error(); err != nil {
+		killTM(connMempoolRecheck, err, app.Logger)
+	}
+}
+
 func (app *multiAppConn) stopAllClients() {
 	if app.consensusConnClient != nil {
 		if err := app.consensusConnClient.Stop(); err != nil {
@@ -176,6 +223,11 @@ func (app *multiAppConn) stopAllClients() {
 			app.Logger.Error("error while stopping snapshot client", "error", err)
 		}
 	}
+	for _, c := range app.recheckConnClients {
+		if err := c.Stop(); err != nil {
+			app.Logger.Error("error while stopping mempool recheck client", "error", err)
+		}
+	}
 }
 
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
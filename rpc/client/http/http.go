@@ -271,6 +271,18 @@ func (c *baseRPCClient) BroadcastTxSync(
 	return c.broadcastTX(ctx, "broadcast_tx_sync", tx)
 }
 
+func (c *baseRPCClient) BroadcastTxBatch(
+	ctx context.Context,
+	txs []types.Tx,
+) (*ctypes.ResultBroadcastTxBatch, error) {
+	result := new(ctypes.ResultBroadcastTxBatch)
+	_, err := c.caller.Call(ctx, "broadcast_tx_batch", map[string]interface{}{"txs": txs}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) broadcastTX(
 	ctx context.Context,
 	route string,
@@ -300,6 +312,36 @@ func (c *baseRPCClient) UnconfirmedTxs(
 	return result, nil
 }
 
+func (c *baseRPCClient) MempoolContents(
+	ctx context.Context,
+	sender string,
+	page,
+	perPage *int,
+) (*ctypes.ResultMempoolContents, error) {
+	result := new(ctypes.ResultMempoolContents)
+	params := map[string]interface{}{"sender": sender}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "mempool_contents", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	result := new(ctypes.ResultTxStatus)
+	_, err := c.caller.Call(ctx, "tx_status", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
 	result := new(ctypes.ResultUnconfirmedTxs)
 	_, err := c.caller.Call(ctx, "num_unconfirmed_txs", map[string]interface{}{}, result)
@@ -134,7 +134,10 @@ type EventsClient interface {
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
+	MempoolContents(ctx context.Context, sender string, page, perPage *int) (*ctypes.ResultMempoolContents, error)
+	TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
+	BroadcastTxBatch(context.Context, []types.Tx) (*ctypes.ResultBroadcastTxBatch, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
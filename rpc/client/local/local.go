@@ -95,6 +95,10 @@ func (c *Local) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.Resu
 	return core.BroadcastTxAsync(c.ctx, tx)
 }
 
+func (c *Local) BroadcastTxBatch(ctx context.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+	return core.BroadcastTxBatch(c.ctx, txs)
+}
+
 func (c *Local) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return core.BroadcastTxSync(c.ctx, tx)
 }
@@ -107,6 +111,19 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return core.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) MempoolContents(
+	ctx context.Context,
+	sender string,
+	page,
+	perPage *int,
+) (*ctypes.ResultMempoolContents, error) {
+	return core.MempoolContents(c.ctx, sender, page, perPage)
+}
+
+func (c *Local) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	return core.TxStatus(c.ctx, hash)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return core.CheckTx(c.ctx, tx)
 }
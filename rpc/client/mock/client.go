@@ -107,6 +107,10 @@ func (c Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.Resu
 	return core.BroadcastTxAsync(&rpctypes.Context{}, tx)
 }
 
+func (c Client) BroadcastTxBatch(ctx context.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+	return core.BroadcastTxBatch(&rpctypes.Context{}, txs)
+}
+
 func (c Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return core.BroadcastTxSync(&rpctypes.Context{}, tx)
 }
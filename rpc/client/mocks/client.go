@@ -252,6 +252,29 @@ func (_m *Client) BroadcastTxAsync(_a0 context.Context, _a1 types.Tx) (*coretype
 	return r0, r1
 }
 
+// BroadcastTxBatch provides a mock function with given fields: _a0, _a1
+func (_m *Client) BroadcastTxBatch(_a0 context.Context, _a1 []types.Tx) (*coretypes.ResultBroadcastTxBatch, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *coretypes.ResultBroadcastTxBatch
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Tx) *coretypes.ResultBroadcastTxBatch); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastTxBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.Tx) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastTxCommit provides a mock function with given fields: _a0, _a1
 func (_m *Client) BroadcastTxCommit(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
 	ret := _m.Called(_a0, _a1)
@@ -496,6 +519,29 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// MempoolContents provides a mock function with given fields: ctx, sender, page, perPage
+func (_m *Client) MempoolContents(ctx context.Context, sender string, page *int, perPage *int) (*coretypes.ResultMempoolContents, error) {
+	ret := _m.Called(ctx, sender, page, perPage)
+
+	var r0 *coretypes.ResultMempoolContents
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *int) *coretypes.ResultMempoolContents); ok {
+		r0 = rf(ctx, sender, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultMempoolContents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *int) error); ok {
+		r1 = rf(ctx, sender, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -751,6 +797,29 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	return r0, r1
 }
 
+// TxStatus provides a mock function with given fields: ctx, hash
+func (_m *Client) TxStatus(ctx context.Context, hash []byte) (*coretypes.ResultTxStatus, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxStatus
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultTxStatus); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnconfirmedTxs provides a mock function with given fields: ctx, limit
 func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(ctx, limit)
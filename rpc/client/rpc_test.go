@@ -350,6 +350,27 @@ func TestBroadcastTxSync(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxBatch(t *testing.T) {
+	mempool := node.Mempool()
+	for i, c := range GetClients() {
+		// a single tx reaches the mempool, as the blocks of the other tests
+		// hold one tx each
+		_, _, tx := MakeTxKV()
+		bres, err := c.BroadcastTxBatch(context.Background(), []types.Tx{tx, tx})
+		require.NoError(t, err, "%d", i)
+		require.Len(t, bres.Txs, 2)
+		assert.Equal(t, abci.CodeTypeOK, bres.Txs[0].Code)
+		assert.Empty(t, bres.Txs[0].Error)
+		assert.EqualValues(t, types.Tx(tx).Hash(), bres.Txs[1].Hash)
+		// the repeated tx is already in the cache
+		assert.Equal(t, mempl.ErrTxInCache.Error(), bres.Txs[1].Error)
+
+		// the tx may be committed meanwhile
+		assert.LessOrEqual(t, mempool.Size(), 1)
+		mempool.Flush()
+	}
+}
+
 func TestBroadcastTxCommit(t *testing.T) {
 	require := require.New(t)
 
@@ -395,6 +416,49 @@ func TestUnconfirmedTxs(t *testing.T) {
 	mempool.Flush()
 }
 
+func TestMempoolContents(t *testing.T) {
+	// the txs of the mempool may be committed at any time, see the tests of
+	// rpc/core for the pagination
+	for _, c := range GetClients() {
+		mc := c.(client.MempoolClient)
+
+		// the kvstore app returns no sender
+		res, err := mc.MempoolContents(context.Background(), "alice", nil, nil)
+		require.NoError(t, err)
+		assert.Zero(t, res.Count)
+		assert.Zero(t, res.Total)
+		assert.Empty(t, res.Txs)
+
+		page := 2
+		_, err = mc.MempoolContents(context.Background(), "alice", &page, nil)
+		assert.Error(t, err)
+	}
+}
+
+func TestTxStatus(t *testing.T) {
+	for _, c := range GetClients() {
+		mc := c.(client.MempoolClient)
+
+		_, _, tx := MakeTxKV()
+		bres, err := c.BroadcastTxCommit(context.Background(), tx)
+		require.NoError(t, err)
+		res, err := mc.TxStatus(context.Background(), bres.Hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, bres.Hash, res.Hash)
+		assert.Equal(t, mempl.TxStatusCommitted, res.Status)
+		assert.Equal(t, bres.Height, res.Height)
+		assert.False(t, res.Time.IsZero())
+
+		_, _, tx = MakeTxKV()
+		res, err = mc.TxStatus(context.Background(), types.Tx(tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, mempl.TxStatusUnknown, res.Status)
+
+		_, err = mc.TxStatus(context.Background(), []byte("short"))
+		assert.Error(t, err)
+	}
+}
+
 func TestNumUnconfirmedTxs(t *testing.T) {
 	_, _, tx := MakeTxKV()
 
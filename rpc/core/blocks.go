@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
@@ -40,7 +42,10 @@ func BlockchainInfo(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.
 
 	blockMetas := []*types.BlockMeta{}
 	for height := maxHeight; height >= minHeight; height-- {
-		blockMeta := env.BlockStore.LoadBlockMeta(height)
+		blockMeta, err := loadBlockMeta(height)
+		if err != nil {
+			return nil, err
+		}
 		blockMetas = append(blockMetas, blockMeta)
 	}
 
@@ -91,8 +96,14 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 		return nil, err
 	}
 
-	block := env.BlockStore.LoadBlock(height)
-	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	block, err := loadBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	blockMeta, err := loadBlockMeta(height)
+	if err != nil {
+		return nil, err
+	}
 	if blockMeta == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: block}, nil
 	}
@@ -102,12 +113,51 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 // BlockByHash gets block by hash.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/block_by_hash
 func BlockByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error) {
-	block := env.BlockStore.LoadBlockByHash(hash)
+	block, err := loadBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
 	if block == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
 	}
 	// If block is not nil, then blockMeta can't be nil.
-	blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
+	blockMeta, err := loadBlockMeta(block.Height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+}
+
+// BlockByTime gets the block at the given time, in RFC3339 format: the latest
+// block whose time is at or before it. No block is returned if all the blocks
+// are after it.
+func BlockByTime(ctx *rpctypes.Context, timeStr string) (*ctypes.ResultBlock, error) {
+	bs, ok := env.BlockStore.(blockTimeIndex)
+	if !ok {
+		return nil, errors.New("the block store doesn't index the block times")
+	}
+	t, err := time.Parse(time.RFC3339Nano, timeStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q: %w", timeStr, err)
+	}
+	height, err := bs.HeightByTime(t)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
+	}
+	block, err := loadBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	blockMeta, err := loadBlockMeta(height)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || blockMeta == nil {
+		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
+	}
 	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
 }
 
@@ -120,27 +170,34 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 		return nil, err
 	}
 
-	blockMeta := env.BlockStore.LoadBlockMeta(height)
-	if blockMeta == nil {
-		return nil, nil
+	blockMeta, err := loadBlockMeta(height)
+	if err != nil || blockMeta == nil {
+		return nil, err
 	}
 	header := blockMeta.Header
 
 	// If the next block has not been committed yet,
 	// use a non-canonical commit
 	if height == env.BlockStore.Height() {
-		commit := env.BlockStore.LoadSeenCommit(height)
+		commit, err := loadCommit(height, true)
+		if err != nil {
+			return nil, err
+		}
 		return ctypes.NewResultCommit(&header, commit, false), nil
 	}
 
 	// Return the canonical commit (comes from the block at height+1)
-	commit := env.BlockStore.LoadBlockCommit(height)
+	commit, err := loadCommit(height, false)
+	if err != nil {
+		return nil, err
+	}
 	return ctypes.NewResultCommit(&header, commit, true), nil
 }
 
 // BlockResults gets ABCIResults at a given height.
 // If no height is provided, it will fetch results for the latest block.
-// When DiscardABCIResponses is enabled, an error will be returned.
+// When DiscardABCIResponses is enabled, an error will be returned, unless the
+// results are saved in the block store with SaveBlockResults.
 //
 // Results are for the height of the block containing the txs.
 // Thus response.results.deliver_tx[5] is the results of executing
@@ -152,7 +209,7 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 		return nil, err
 	}
 
-	results, err := env.StateStore.LoadABCIResponses(height)
+	results, err := loadBlockResults(height)
 	if err != nil {
 		return nil, err
 	}
@@ -199,6 +256,9 @@ func BlockSearch(
 	if err != nil {
 		return nil, err
 	}
+	if q.OrderBy() != nil {
+		return nil, errors.New("ORDER BY is not supported by block_search, use order_by")
+	}
 
 	results, err := env.BlockIndexer.Search(ctx.Context(), q)
 	if err != nil {
@@ -231,9 +291,15 @@ func BlockSearch(
 
 	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
 	for i := skipCount; i < skipCount+pageSize; i++ {
-		block := env.BlockStore.LoadBlock(results[i])
+		block, err := loadBlock(results[i])
+		if err != nil {
+			return nil, err
+		}
 		if block != nil {
-			blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
+			blockMeta, err := loadBlockMeta(block.Height)
+			if err != nil {
+				return nil, err
+			}
 			if blockMeta != nil {
 				apiResults = append(apiResults, &ctypes.ResultBlock{
 					Block:   block,
@@ -245,3 +311,84 @@ func BlockSearch(
 
 	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
 }
+
+// blockStoreE is implemented by the block stores returning the errors of
+// their loads, e.g. of corrupted entries, instead of panicking.
+type blockStoreE interface {
+	LoadBlockE(height int64) (*types.Block, error)
+	LoadBlockByHashE(hash []byte) (*types.Block, error)
+	LoadBlockMetaE(height int64) (*types.BlockMeta, error)
+	LoadBlockCommitE(height int64) (*types.Commit, error)
+	LoadSeenCommitE(height int64) (*types.Commit, error)
+}
+
+// blockTimeIndex is implemented by the block stores indexing the blocks by
+// time.
+type blockTimeIndex interface {
+	HeightByTime(t time.Time) (int64, error)
+}
+
+// blockResultsStore is implemented by the block stores saving the results
+// of the blocks.
+type blockResultsStore interface {
+	LoadBlockResults(height int64) (*cmtstate.ABCIResponses, error)
+}
+
+// loadBlockResults loads the results of the block at height from the state
+// store, or from the block store if the state store doesn't have them, e.g.
+// if it discards them.
+func loadBlockResults(height int64) (*cmtstate.ABCIResponses, error) {
+	results, err := env.StateStore.LoadABCIResponses(height)
+	if err == nil {
+		return results, nil
+	}
+	bs, ok := env.BlockStore.(blockResultsStore)
+	if !ok {
+		return nil, err
+	}
+	saved, bsErr := bs.LoadBlockResults(height)
+	if bsErr != nil {
+		return nil, bsErr
+	}
+	if saved == nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func loadBlock(height int64) (*types.Block, error) {
+	if bs, ok := env.BlockStore.(blockStoreE); ok {
+		return bs.LoadBlockE(height)
+	}
+	return env.BlockStore.LoadBlock(height), nil
+}
+
+func loadBlockByHash(hash []byte) (*types.Block, error) {
+	if bs, ok := env.BlockStore.(blockStoreE); ok {
+		return bs.LoadBlockByHashE(hash)
+	}
+	return env.BlockStore.LoadBlockByHash(hash), nil
+}
+
+func loadBlockMeta(height int64) (*types.BlockMeta, error) {
+	if bs, ok := env.BlockStore.(blockStoreE); ok {
+		return bs.LoadBlockMetaE(height)
+	}
+	return env.BlockStore.LoadBlockMeta(height), nil
+}
+
+// loadCommit loads the canonical commit of height, or its seen commit if seen
+// is set.
+func loadCommit(height int64, seen bool) (*types.Commit, error) {
+	bs, ok := env.BlockStore.(blockStoreE)
+	switch {
+	case ok && seen:
+		return bs.LoadSeenCommitE(height)
+	case ok:
+		return bs.LoadBlockCommitE(height)
+	case seen:
+		return env.BlockStore.LoadSeenCommit(height), nil
+	default:
+		return env.BlockStore.LoadBlockCommit(height), nil
+	}
+}
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +12,8 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -117,6 +121,117 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestBlockResultsFromBlockStore(t *testing.T) {
+	results := &cmtstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: 0, Data: []byte{0x01}, Log: "ok"}},
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: true,
+	})
+	env.BlockStore = mockBlockStore{height: 100}
+	height := int64(100)
+	_, err := BlockResults(&rpctypes.Context{}, &height)
+	assert.Equal(t, sm.ErrABCIResponsesNotPersisted, err)
+
+	// the results discarded by the state store are served from the block store
+	env.BlockStore = resultsBlockStore{mockBlockStore{height: 100}, map[int64]*cmtstate.ABCIResponses{100: results}}
+	res, err := BlockResults(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	assert.Equal(t, results.DeliverTxs, res.TxsResults)
+
+	height = 99
+	_, err = BlockResults(&rpctypes.Context{}, &height)
+	assert.Equal(t, sm.ErrABCIResponsesNotPersisted, err)
+}
+
+// resultsBlockStore holds the results of some blocks.
+type resultsBlockStore struct {
+	mockBlockStore
+	results map[int64]*cmtstate.ABCIResponses
+}
+
+func (store resultsBlockStore) LoadBlockResults(height int64) (*cmtstate.ABCIResponses, error) {
+	return store.results[height], nil
+}
+
+func TestCorruptedBlockStore(t *testing.T) {
+	env = &Environment{Logger: log.NewNopLogger()}
+	env.BlockStore = corruptedBlockStore{mockBlockStore{height: 100}}
+
+	// the errors of the block store are returned instead of panicking
+	height := int64(50)
+	_, err := Block(&rpctypes.Context{}, &height)
+	assert.ErrorIs(t, err, errCorrupted)
+	_, err = BlockByHash(&rpctypes.Context{}, []byte("hash"))
+	assert.ErrorIs(t, err, errCorrupted)
+	_, err = Commit(&rpctypes.Context{}, &height)
+	assert.ErrorIs(t, err, errCorrupted)
+	_, err = BlockchainInfo(&rpctypes.Context{}, 1, 100)
+	assert.ErrorIs(t, err, errCorrupted)
+}
+
+var errCorrupted = errors.New("corrupted")
+
+// corruptedBlockStore is a block store whose entries are all corrupted.
+func TestBlockByTime(t *testing.T) {
+	env = &Environment{Logger: log.NewNopLogger()}
+	env.BlockStore = mockBlockStore{height: 100}
+	_, err := BlockByTime(&rpctypes.Context{}, "2023-01-01T00:00:00Z")
+	assert.EqualError(t, err, "the block store doesn't index the block times")
+
+	env.BlockStore = timeIndexedBlockStore{mockBlockStore{height: 100}}
+	res, err := BlockByTime(&rpctypes.Context{}, "2023-01-01T00:00:10.5Z")
+	require.NoError(t, err)
+	require.NotNil(t, res.Block)
+	assert.EqualValues(t, 11, res.Block.Height)
+	assert.EqualValues(t, 11, res.BlockID.PartSetHeader.Total)
+
+	res, err = BlockByTime(&rpctypes.Context{}, "2022-12-31T23:59:59Z")
+	require.NoError(t, err)
+	assert.Nil(t, res.Block)
+
+	_, err = BlockByTime(&rpctypes.Context{}, "yesterday")
+	assert.Error(t, err)
+}
+
+// timeIndexedBlockStore holds blocks a second apart from the start of 2023.
+type timeIndexedBlockStore struct {
+	mockBlockStore
+}
+
+var genesisTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (store timeIndexedBlockStore) HeightByTime(t time.Time) (int64, error) {
+	if t.Before(genesisTime) {
+		return 0, nil
+	}
+	return cmtmath.MinInt64(int64(t.Sub(genesisTime)/time.Second)+1, store.height), nil
+}
+
+func (timeIndexedBlockStore) LoadBlock(height int64) *types.Block {
+	return &types.Block{Header: types.Header{Height: height}}
+}
+
+func (timeIndexedBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return &types.BlockMeta{
+		BlockID: types.BlockID{PartSetHeader: types.PartSetHeader{Total: uint32(height)}},
+		Header:  types.Header{Height: height},
+	}
+}
+
+type corruptedBlockStore struct {
+	mockBlockStore
+}
+
+func (corruptedBlockStore) LoadBlockE(int64) (*types.Block, error)         { return nil, errCorrupted }
+func (corruptedBlockStore) LoadBlockByHashE([]byte) (*types.Block, error)  { return nil, errCorrupted }
+func (corruptedBlockStore) LoadBlockMetaE(int64) (*types.BlockMeta, error) { return nil, errCorrupted }
+func (corruptedBlockStore) LoadBlockCommitE(int64) (*types.Commit, error)  { return nil, errCorrupted }
+func (corruptedBlockStore) LoadSeenCommitE(int64) (*types.Commit, error)   { return nil, errCorrupted }
+
 type mockBlockStore struct {
 	height int64
 }
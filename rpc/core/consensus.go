@@ -1,10 +1,13 @@
 package core
 
 import (
+	"fmt"
+
 	cm "github.com/tendermint/tendermint/consensus"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -106,3 +109,85 @@ func ConsensusParams(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCon
 		BlockHeight:     height,
 		ConsensusParams: consensusParams}, nil
 }
+
+// ConsensusParamsHistory gets the consensus parameters in effect from height
+// from to height to, inclusive, and the heights at which they changed, without
+// loading every height. from defaults to the lowest height available, and to
+// to the latest one.
+func ConsensusParamsHistory(ctx *rpctypes.Context, fromPtr, toPtr *int64) (*ctypes.ResultConsensusParamsHistory, error) {
+	latest := latestUncommittedHeight()
+	to, err := getHeight(latest, toPtr)
+	if err != nil {
+		return nil, err
+	}
+	from := env.BlockStore.Base()
+	if fromPtr != nil {
+		if from, err = getHeight(latest, fromPtr); err != nil {
+			return nil, err
+		}
+	} else if from == 0 {
+		from = 1
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d must be less than or equal to to height %d", from, to)
+	}
+
+	changes, err := env.StateStore.LoadConsensusParamsRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	result := &ctypes.ResultConsensusParamsHistory{Changes: make([]ctypes.ResultConsensusParams, len(changes))}
+	for i, change := range changes {
+		result.Changes[i] = ctypes.ResultConsensusParams{
+			BlockHeight:     change.Height,
+			ConsensusParams: change.ConsensusParams,
+		}
+	}
+	return result, nil
+}
+
+// maxProposerHistory is the maximum number of heights of a proposer history.
+const maxProposerHistory = 100
+
+// ProposerHistory gets the addresses of the proposers of the blocks from
+// height from to height to, inclusive, from the proposer index of the state
+// store, without loading their validator sets. to defaults to the latest
+// height, and from to the lowest height available, up to 100 heights below to.
+// The heights missing from the index, e.g. committed before it or synced with
+// state sync, are read from the block headers.
+func ProposerHistory(ctx *rpctypes.Context, fromPtr, toPtr *int64) (*ctypes.ResultProposerHistory, error) {
+	latest := env.BlockStore.Height()
+	to, err := getHeight(latest, toPtr)
+	if err != nil {
+		return nil, err
+	}
+	from := cmtmath.MaxInt64(env.BlockStore.Base(), to-maxProposerHistory+1)
+	if fromPtr != nil {
+		if from, err = getHeight(latest, fromPtr); err != nil {
+			return nil, err
+		}
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d must be less than or equal to to height %d", from, to)
+	}
+	if to-from >= maxProposerHistory {
+		return nil, fmt.Errorf("at most %d heights can be requested, got %d", maxProposerHistory, to-from+1)
+	}
+
+	result := &ctypes.ResultProposerHistory{Proposers: make([]ctypes.ResultProposer, 0, to-from+1)}
+	for height := from; height <= to; height++ {
+		address, err := env.StateStore.LoadProposer(height)
+		if _, ok := err.(sm.ErrNoProposerForHeight); ok {
+			meta := env.BlockStore.LoadBlockMeta(height)
+			if meta == nil {
+				continue
+			}
+			address, err = meta.Header.ProposerAddress, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Proposers = append(result.Proposers, ctypes.ResultProposer{Height: height, Address: address})
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestProposerHistory(t *testing.T) {
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	// the proposers of the first 50 heights predate the index, and only the
+	// headers of the first 20 are stored
+	env.BlockStore = headerBlockStore{mockBlockStore{height: 150}, 20}
+	for h := int64(51); h <= 150; h++ {
+		require.NoError(t, env.StateStore.SaveProposer(h, proposerAt(h)))
+	}
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	res, err := ProposerHistory(&rpctypes.Context{}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Proposers, 100)
+	assert.EqualValues(t, 51, res.Proposers[0].Height)
+	assert.EqualValues(t, 150, res.Proposers[99].Height)
+
+	res, err = ProposerHistory(&rpctypes.Context{}, int64Ptr(10), int64Ptr(60))
+	require.NoError(t, err)
+	require.Len(t, res.Proposers, 21)
+	assert.EqualValues(t, 20, res.Proposers[10].Height)
+	assert.EqualValues(t, 51, res.Proposers[11].Height)
+	for _, p := range res.Proposers {
+		assert.Equal(t, proposerAt(p.Height), p.Address, p.Height)
+	}
+
+	for _, tc := range []struct{ from, to *int64 }{
+		{int64Ptr(10), int64Ptr(9)},
+		{int64Ptr(1), int64Ptr(150)},
+		{nil, int64Ptr(151)},
+		{int64Ptr(0), nil},
+	} {
+		_, err = ProposerHistory(&rpctypes.Context{}, tc.from, tc.to)
+		assert.Error(t, err)
+	}
+}
+
+func proposerAt(height int64) types.Address {
+	return types.Address{byte(height), 1, 2, 3}
+}
+
+// headerBlockStore holds the headers of the blocks up to a height.
+type headerBlockStore struct {
+	mockBlockStore
+	headers int64
+}
+
+func (store headerBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	if height > store.headers {
+		return nil
+	}
+	return &types.BlockMeta{Header: types.Header{Height: height, ProposerAddress: proposerAt(height)}}
+}
@@ -1,8 +1,13 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 // UnsafeFlushMempool removes all transactions from the mempool.
@@ -10,3 +15,78 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeRemoveTx removes a transaction from the mempool by its hash, e.g. a
+// stuck transaction. It stays in the cache, so that it isn't added back when
+// the peers send it again.
+func UnsafeRemoveTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnsafeRemoveTx, error) {
+	if len(hash) != tmhash.Size {
+		return nil, fmt.Errorf("invalid tx hash %X: expected %d bytes", hash, tmhash.Size)
+	}
+	var key types.TxKey
+	copy(key[:], hash)
+	if err := env.Mempool.RemoveTxByKey(key); err != nil {
+		return nil, fmt.Errorf("failed to remove tx %X: %w", hash, err)
+	}
+	return &ctypes.ResultUnsafeRemoveTx{}, nil
+}
+
+// UnsafeForgetTx removes a transaction from the caches of the mempool by its
+// hash, so that it can be resubmitted, e.g. an invalid transaction which
+// became valid, or one removed with UnsafeRemoveTx.
+func UnsafeForgetTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnsafeForgetTx, error) {
+	if len(hash) != tmhash.Size {
+		return nil, fmt.Errorf("invalid tx hash %X: expected %d bytes", hash, tmhash.Size)
+	}
+	var key types.TxKey
+	copy(key[:], hash)
+	env.Mempool.ForgetTx(key)
+	return &ctypes.ResultUnsafeForgetTx{}, nil
+}
+
+// UnsafeFlushRechecks rechecks all the transactions of the mempool against the
+// current state of the app, and returns once those which are no longer valid
+// are removed.
+func UnsafeFlushRechecks(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushRechecks, error) {
+	if err := env.Mempool.FlushRechecks(); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsafeFlushRechecks{NumTxs: env.Mempool.Size()}, nil
+}
+
+// UnsafeReloadConfig reloads the configuration, applying the settings which can
+// change at runtime, and returns those which changed.
+func UnsafeReloadConfig(ctx *rpctypes.Context) (*ctypes.ResultUnsafeReloadConfig, error) {
+	if env.ConfigReloader == nil {
+		return nil, errors.New("the node does not support reloading its configuration")
+	}
+	changed, err := env.ConfigReloader.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsafeReloadConfig{Changed: changed}, nil
+}
+
+// UnsafeSwitchToConsensus makes a fast syncing node switch to consensus,
+// regardless of whether it has caught up with its peers.
+func UnsafeSwitchToConsensus(ctx *rpctypes.Context) (*ctypes.ResultUnsafeSwitchToConsensus, error) {
+	if env.FastSyncReactor == nil {
+		return nil, errors.New("fast sync reactor does not support switching modes")
+	}
+	if err := env.FastSyncReactor.ForceSwitchToConsensus(); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsafeSwitchToConsensus{}, nil
+}
+
+// UnsafeSwitchToFastSync stops consensus and makes the node fast sync from
+// its latest committed height.
+func UnsafeSwitchToFastSync(ctx *rpctypes.Context) (*ctypes.ResultUnsafeSwitchToFastSync, error) {
+	if env.FastSyncReactor == nil {
+		return nil, errors.New("fast sync reactor does not support switching modes")
+	}
+	if err := env.FastSyncReactor.ForceSwitchToFastSync(); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsafeSwitchToFastSync{}, nil
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/mempool"
+	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
+	"github.com/tendermint/tendermint/proxy"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestUnsafeRemoveTx(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewApplication()).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	t.Cleanup(func() { require.NoError(t, appConn.Stop()) })
+	mp := mempoolv0.NewCListMempool(config.TestMempoolConfig(), appConn, 0)
+	env = &Environment{Mempool: mp}
+
+	tx := types.Tx("a=1")
+	require.NoError(t, mp.CheckTx(tx, nil, mempool.TxInfo{}))
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, tx.Hash()[:8])
+	assert.Error(t, err)
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, tx.Hash())
+	require.NoError(t, err)
+	assert.Zero(t, mp.Size())
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, tx.Hash())
+	assert.Error(t, err)
+
+	// the tx isn't added back when received again
+	assert.Equal(t, mempool.ErrTxInCache, mp.CheckTx(tx, nil, mempool.TxInfo{}))
+
+	res, err := UnsafeFlushRechecks(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Zero(t, res.NumTxs)
+
+	// the tx is added back once forgotten
+	_, err = UnsafeForgetTx(&rpctypes.Context{}, tx.Hash()[:8])
+	assert.Error(t, err)
+	_, err = UnsafeForgetTx(&rpctypes.Context{}, tx.Hash())
+	require.NoError(t, err)
+	require.NoError(t, mp.CheckTx(tx, nil, mempool.TxInfo{}))
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, 1, mp.Size())
+}
@@ -25,6 +25,7 @@ Available endpoints:
 /health
 /unconfirmed_txs
 /unsafe_flush_mempool
+/unsafe_flush_rechecks
 /validators
 
 Endpoints that require arguments:
@@ -32,13 +33,18 @@ Endpoints that require arguments:
 /block?height=_
 /blockchain?minHeight=_&maxHeight=_
 /broadcast_tx_async?tx=_
+/broadcast_tx_batch?txs=_
 /broadcast_tx_commit?tx=_
 /broadcast_tx_sync?tx=_
 /commit?height=_
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
+/mempool_contents?sender=_&page=_&per_page=_
 /subscribe?event=_
 /tx?hash=_&prove=_
+/tx_status?hash=_
+/unsafe_forget_tx?hash=_
+/unsafe_remove_tx?hash=_
 /unsubscribe?event=_
 ```
 */
@@ -8,11 +8,14 @@ import (
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/finality"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/settlement"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
@@ -36,6 +39,9 @@ const (
 var (
 	// set by Node
 	env *Environment
+
+	// guards the settings of env.Config which can change at runtime
+	configMtx cmtsync.RWMutex
 )
 
 // SetEnvironment sets up the given Environment.
@@ -44,6 +50,34 @@ func SetEnvironment(e *Environment) {
 	env = e
 }
 
+// SetSubscriptionLimits sets the maximum number of clients subscribed to
+// events and of subscriptions per client, e.g. when the configuration is
+// reloaded. The current subscriptions are kept.
+func SetSubscriptionLimits(maxClients, maxPerClient int) {
+	if env == nil {
+		return
+	}
+	configMtx.Lock()
+	defer configMtx.Unlock()
+	env.Config.MaxSubscriptionClients = maxClients
+	env.Config.MaxSubscriptionsPerClient = maxPerClient
+}
+
+// checkSubscriptionLimits returns an error if the client at addr can't
+// subscribe to more events.
+func checkSubscriptionLimits(addr string) error {
+	configMtx.RLock()
+	maxClients, maxPerClient := env.Config.MaxSubscriptionClients, env.Config.MaxSubscriptionsPerClient
+	configMtx.RUnlock()
+
+	if env.EventBus.NumClients() >= maxClients {
+		return fmt.Errorf("max_subscription_clients %d reached", maxClients)
+	} else if env.EventBus.NumClientSubscriptions(addr) >= maxPerClient {
+		return fmt.Errorf("max_subscriptions_per_client %d reached", maxPerClient)
+	}
+	return nil
+}
+
 //----------------------------------------------
 // These interfaces are used by RPC and must be thread safe
 
@@ -53,6 +87,12 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	SigningHalted() bool
+}
+
+type fastSyncSwitcher interface {
+	ForceSwitchToConsensus() error
+	ForceSwitchToFastSync() error
 }
 
 type transport interface {
@@ -61,6 +101,18 @@ type transport interface {
 	NodeInfo() p2p.NodeInfo
 }
 
+type configReloader interface {
+	ReloadConfig() ([]string, error)
+}
+
+type settlementPoster interface {
+	Status() settlement.Status
+}
+
+type finalityTracker interface {
+	Heights() finality.Heights
+}
+
 type peers interface {
 	AddPersistentPeers([]string) error
 	AddUnconditionalPeerIDs([]string) error
@@ -91,8 +143,13 @@ type Environment struct {
 	TxIndexer        txindex.TxIndexer
 	BlockIndexer     indexer.BlockIndexer
 	ConsensusReactor *consensus.Reactor
+	FastSyncReactor  fastSyncSwitcher
+	ConfigReloader   configReloader
+	SettlementPoster settlementPoster
+	FinalityTracker  finalityTracker
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
+	TxStatuses       *mempl.TxStatuses // nil if the statuses of the txs aren't tracked
 
 	Logger log.Logger
 
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"errors"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// Finality returns the level of finality of the block at the given height, or
+// the latest one if no height is provided: soft once committed, da_included
+// once included in the DA layer, and firm once finalized by the settlement
+// hub. It also returns the latest heights at each level.
+func Finality(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultFinality, error) {
+	if env.FinalityTracker == nil {
+		return nil, errors.New("finality tracking is not enabled")
+	}
+	latest := env.BlockStore.Height()
+	height, err := getHeight(latest, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	heights := env.FinalityTracker.Heights()
+	// the tracker may not have seen the latest blocks committed yet
+	if heights.Soft < latest {
+		heights.Soft = latest
+	}
+	return &ctypes.ResultFinality{
+		Height:           height,
+		Level:            heights.Level(height),
+		SoftHeight:       heights.Soft,
+		DAIncludedHeight: heights.DAIncluded,
+		FirmHeight:       heights.Firm,
+	}, nil
+}
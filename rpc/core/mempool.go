@@ -6,13 +6,21 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	abci "github.com/tendermint/tendermint/abci/types"
+	cmtmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/libs/tracing"
 	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
+var tracer = tracing.Tracer("rpc")
+
 //-----------------------------------------------------------------------------
 // NOTE: tx should be signed, but this is only checked at the app level (not by CometBFT!)
 
@@ -20,9 +28,12 @@ import (
 // CheckTx nor DeliverTx results.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_async
 func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	err := env.Mempool.CheckTx(tx, nil, mempl.TxInfo{})
+	err := checkTx(ctx, tx, nil)
 
 	if err != nil {
+		if res, ok := rejectedTx(tx, err); ok {
+			return res, nil
+		}
 		return nil, err
 	}
 	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
@@ -33,14 +44,16 @@ func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadca
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_sync
 func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	resCh := make(chan *abci.Response, 1)
-	err := env.Mempool.CheckTx(tx, func(res *abci.Response) {
+	err := checkTx(ctx, tx, func(res *abci.Response) {
 		select {
 		case <-ctx.Context().Done():
 		case resCh <- res:
 		}
-
-	}, mempl.TxInfo{})
+	})
 	if err != nil {
+		if res, ok := rejectedTx(tx, err); ok {
+			return res, nil
+		}
 		return nil, err
 	}
 
@@ -54,20 +67,59 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 			Data:      r.Data,
 			Log:       r.Log,
 			Codespace: r.Codespace,
+			Reason:    mempl.RejectReason(r.Codespace, r.Code),
 			Hash:      tx.Hash(),
 		}, nil
 	}
 }
 
+// BroadcastTxBatch returns with the responses from CheckTx of a batch of txs,
+// checked in a single round trip to the application. Does not wait for
+// DeliverTx results.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_batch
+func BroadcastTxBatch(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("no txs to broadcast")
+	}
+	_, span := tracer.Start(ctx.Context(), "mempool.check_tx_batch",
+		trace.WithAttributes(attribute.Int("num_txs", len(txs))))
+	defer span.End()
+
+	results := make([]ctypes.ResultBroadcastTxBatchTx, len(txs))
+	errs := env.Mempool.CheckTxBatch(txs, func(i int, res *abci.Response) {
+		r := res.GetCheckTx()
+		results[i] = ctypes.ResultBroadcastTxBatchTx{
+			Code:      r.Code,
+			Data:      r.Data,
+			Log:       r.Log,
+			Codespace: r.Codespace,
+			Reason:    mempl.RejectReason(r.Codespace, r.Code),
+		}
+	}, mempl.TxInfo{})
+	for i, tx := range txs {
+		if errs[i] != nil {
+			if res, ok := rejectedTx(tx, errs[i]); ok {
+				results[i] = ctypes.ResultBroadcastTxBatchTx{
+					Code:      res.Code,
+					Log:       res.Log,
+					Codespace: res.Codespace,
+					Reason:    res.Reason,
+				}
+			}
+			results[i].Error = errs[i].Error()
+		}
+		results[i].Hash = tx.Hash()
+	}
+	return &ctypes.ResultBroadcastTxBatch{Txs: results}, nil
+}
+
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_commit
 func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	subscriber := ctx.RemoteAddr()
 
-	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
-	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	if err := checkSubscriptionLimits(subscriber); err != nil {
+		return nil, err
 	}
 
 	// Subscribe to tx being committed in block.
@@ -88,13 +140,21 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 
 	// Broadcast tx and wait for CheckTx result
 	checkTxResCh := make(chan *abci.Response, 1)
-	err = env.Mempool.CheckTx(tx, func(res *abci.Response) {
+	err = checkTx(ctx, tx, func(res *abci.Response) {
 		select {
 		case <-ctx.Context().Done():
 		case checkTxResCh <- res:
 		}
-	}, mempl.TxInfo{})
+	})
 	if err != nil {
+		if res, ok := rejectedTx(tx, err); ok {
+			return &ctypes.ResultBroadcastTxCommit{
+				CheckTx:   abci.ResponseCheckTx{Code: res.Code, Log: res.Log, Codespace: res.Codespace},
+				DeliverTx: abci.ResponseDeliverTx{},
+				Hash:      res.Hash,
+				Reason:    res.Reason,
+			}, nil
+		}
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
 		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
 	}
@@ -108,6 +168,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 				CheckTx:   *checkTxRes,
 				DeliverTx: abci.ResponseDeliverTx{},
 				Hash:      tx.Hash(),
+				Reason:    mempl.RejectReason(checkTxRes.Codespace, checkTxRes.Code),
 			}, nil
 		}
 
@@ -162,6 +223,52 @@ func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfi
 		Txs:        txs}, nil
 }
 
+// MempoolContents gets the unconfirmed transactions with their metadata, in
+// the order they are reaped, optionally filtered by the sender returned by the
+// app in CheckTx, and paginated.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Info/mempool_contents
+func MempoolContents(
+	ctx *rpctypes.Context,
+	sender string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultMempoolContents, error) {
+	contents := env.Mempool.Contents()
+	if sender != "" {
+		filtered := contents[:0]
+		for _, md := range contents {
+			if md.Sender == sender {
+				filtered = append(filtered, md)
+			}
+		}
+		contents = filtered
+	}
+
+	totalCount := len(contents)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
+
+	txs := make([]*ctypes.MempoolTx, 0, pageSize)
+	for _, md := range contents[skipCount : skipCount+pageSize] {
+		txs = append(txs, &ctypes.MempoolTx{
+			Tx:          md.Tx,
+			Hash:        md.Tx.Hash(),
+			Height:      md.Height,
+			ArrivalTime: md.ArrivalTime,
+			GasWanted:   md.GasWanted,
+			Priority:    md.Priority,
+			Sender:      md.Sender,
+			Lane:        md.Lane,
+			Peers:       md.Peers,
+		})
+	}
+	return &ctypes.ResultMempoolContents{Count: len(txs), Total: totalCount, Txs: txs}, nil
+}
+
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/num_unconfirmed_txs
 func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -171,13 +278,53 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		TotalBytes: env.Mempool.SizeBytes()}, nil
 }
 
-// CheckTx checks the transaction without executing it. The transaction won't
-// be added to the mempool either.
+// CheckTx checks the transaction without executing it, in the context of the
+// next block. The transaction won't be added to the mempool either.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/check_tx
 func CheckTx(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
-	res, err := env.ProxyAppMempool.CheckTxSync(abci.RequestCheckTx{Tx: tx})
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	res, err := env.ProxyAppMempool.CheckTxSync(sm.TxCheckContext(state).Request(tx, abci.CheckTxType_New))
 	if err != nil {
 		return nil, err
 	}
 	return &ctypes.ResultCheckTx{ResponseCheckTx: *res}, nil
 }
+
+// checkTx adds tx to the mempool in a span of the request, ended when the
+// application responds, and passes the response to cb, if not nil.
+func checkTx(ctx *rpctypes.Context, tx types.Tx, cb func(*abci.Response)) error {
+	_, span := tracer.Start(ctx.Context(), "mempool.check_tx",
+		trace.WithAttributes(attribute.String("tx_hash", fmt.Sprintf("%X", tx.Hash()))))
+	err := env.Mempool.CheckTx(tx, func(res *abci.Response) {
+		if r := res.GetCheckTx(); r != nil {
+			span.SetAttributes(attribute.Int64("code", int64(r.Code)))
+		}
+		span.End()
+		if cb != nil {
+			cb(res)
+		}
+	}, mempl.TxInfo{})
+	if err != nil {
+		tracing.EndSpan(span, err)
+	}
+	return err
+}
+
+// rejectedTx returns the result of a tx rejected by the mempool before the app
+// checked it, if the error of its check has a rejection code.
+func rejectedTx(tx types.Tx, err error) (*ctypes.ResultBroadcastTx, bool) {
+	code, ok := mempl.RejectCode(err)
+	if !ok {
+		return nil, false
+	}
+	return &ctypes.ResultBroadcastTx{
+		Code:      code,
+		Log:       err.Error(),
+		Codespace: mempl.Codespace,
+		Reason:    mempl.RejectReason(mempl.Codespace, code),
+		Hash:      tx.Hash(),
+	}, true
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool"
+	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
+	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
+	"github.com/tendermint/tendermint/proxy"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// senderApp returns the part of a tx before "=" as its sender.
+type senderApp struct {
+	abci.BaseApplication
+}
+
+func (senderApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Sender: strings.Split(string(req.Tx), "=")[0]}
+}
+
+func TestMempoolContents(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(senderApp{}).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	t.Cleanup(func() { require.NoError(t, appConn.Stop()) })
+	mp := mempoolv0.NewCListMempool(config.TestMempoolConfig(), appConn, 0)
+	env = &Environment{Mempool: mp}
+
+	txs := types.Txs{types.Tx("alice=1"), types.Tx("bob=1"), types.Tx("alice=2")}
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTx(tx, nil, mempool.TxInfo{}))
+	}
+
+	page, perPage := 2, 2
+	res, err := MempoolContents(&rpctypes.Context{}, "", &page, &perPage)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Count)
+	assert.Equal(t, 3, res.Total)
+	require.Len(t, res.Txs, 1)
+	assert.Equal(t, txs[2], res.Txs[0].Tx)
+	assert.EqualValues(t, txs[2].Hash(), res.Txs[0].Hash)
+	assert.Equal(t, "alice", res.Txs[0].Sender)
+	assert.False(t, res.Txs[0].ArrivalTime.IsZero())
+
+	res, err = MempoolContents(&rpctypes.Context{}, "alice", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	require.Len(t, res.Txs, 2)
+	assert.Equal(t, txs[0], res.Txs[0].Tx)
+	assert.Equal(t, txs[2], res.Txs[1].Tx)
+
+	page = 3
+	_, err = MempoolContents(&rpctypes.Context{}, "", &page, &perPage)
+	assert.Error(t, err)
+}
+
+func TestBroadcastTxRejected(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(senderApp{}).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	t.Cleanup(func() { require.NoError(t, appConn.Stop()) })
+	cfg := config.TestMempoolConfig()
+	cfg.Size = 2
+	env = &Environment{Mempool: mempoolv0.NewCListMempool(cfg, appConn, 0)}
+	ctx := &rpctypes.Context{}
+
+	// the txs rejected by the mempool have a code and a reason, not an error
+	for _, tc := range []struct {
+		tx     string
+		reason string
+	}{
+		{"alice=1", ""},
+		{"alice=1", mempool.RejectReasonDuplicate},
+		{strings.Repeat("x", cfg.MaxTxBytes+1), mempool.RejectReasonTooLarge},
+	} {
+		res, err := BroadcastTxSync(ctx, types.Tx(tc.tx))
+		require.NoError(t, err)
+		assert.Equal(t, tc.reason, res.Reason)
+		assert.EqualValues(t, types.Tx(tc.tx).Hash(), res.Hash)
+		if tc.reason == "" {
+			assert.Equal(t, abci.CodeTypeOK, res.Code)
+			continue
+		}
+		assert.Equal(t, mempool.Codespace, res.Codespace)
+		assert.Equal(t, tc.reason, mempool.RejectReason(res.Codespace, res.Code))
+	}
+
+	res, err := BroadcastTxAsync(ctx, types.Tx("alice=1"))
+	require.NoError(t, err)
+	assert.Equal(t, mempool.CodeDuplicate, res.Code)
+
+	batch, err := BroadcastTxBatch(ctx, types.Txs{types.Tx("alice=1")})
+	require.NoError(t, err)
+	require.Len(t, batch.Txs, 1)
+	assert.Equal(t, mempool.CodeDuplicate, batch.Txs[0].Code)
+	assert.Equal(t, mempool.RejectReasonDuplicate, batch.Txs[0].Reason)
+	assert.Equal(t, mempool.ErrTxInCache.Error(), batch.Txs[0].Error)
+
+	_, err = BroadcastTxSync(ctx, types.Tx("bob=1"))
+	require.NoError(t, err)
+	res, err = BroadcastTxSync(ctx, types.Tx("carol=1"))
+	require.NoError(t, err)
+	assert.Equal(t, mempool.CodeMempoolFull, res.Code)
+	assert.Equal(t, mempool.RejectReasonMempoolFull, res.Reason)
+}
+
+func TestBroadcastTxBatch(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(senderApp{}).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	t.Cleanup(func() { require.NoError(t, appConn.Stop()) })
+
+	for name, mp := range map[string]mempool.Mempool{
+		"v0": mempoolv0.NewCListMempool(config.TestMempoolConfig(), appConn, 0),
+		"v1": mempoolv1.NewTxMempool(log.NewNopLogger(), config.TestMempoolConfig(), appConn, 0),
+	} {
+		env = &Environment{Mempool: mp}
+		txs := types.Txs{types.Tx("alice=1"), types.Tx("bob=1"), types.Tx("carol=1"), types.Tx("dave=1")}
+
+		res, err := BroadcastTxBatch(&rpctypes.Context{}, txs)
+		require.NoError(t, err, name)
+
+		// the results are in the order of the txs, which are all admitted, as
+		// their senders differ
+		require.Len(t, res.Txs, len(txs), name)
+		for i, tx := range txs {
+			assert.Equal(t, abci.CodeTypeOK, res.Txs[i].Code, name)
+			assert.Empty(t, res.Txs[i].Error, name)
+			assert.Empty(t, res.Txs[i].Reason, name)
+			assert.EqualValues(t, tx.Hash(), res.Txs[i].Hash, name)
+		}
+		assert.Equal(t, len(txs), mp.Size(), name)
+		assert.ElementsMatch(t, txs, mp.ReapMaxTxs(-1), name)
+	}
+}
@@ -14,31 +14,39 @@ var Routes = map[string]*rpc.RPCFunc{
 	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
 
 	// info API
-	"health":               rpc.NewRPCFunc(Health, ""),
-	"status":               rpc.NewRPCFunc(Status, ""),
-	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-	"genesis":              rpc.NewRPCFunc(Genesis, "", rpc.Cacheable()),
-	"genesis_chunked":      rpc.NewRPCFunc(GenesisChunked, "chunk", rpc.Cacheable()),
-	"block":                rpc.NewRPCFunc(Block, "height", rpc.Cacheable("height")),
-	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash", rpc.Cacheable()),
-	"block_results":        rpc.NewRPCFunc(BlockResults, "height", rpc.Cacheable("height")),
-	"commit":               rpc.NewRPCFunc(Commit, "height", rpc.Cacheable("height")),
-	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
-	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove", rpc.Cacheable()),
-	"tx_search":            rpc.NewRPCFunc(TxSearchMatchEvents, "query,prove,page,per_page,order_by,match_events"),
-	"block_search":         rpc.NewRPCFunc(BlockSearchMatchEvents, "query,page,per_page,order_by,match_events"),
-	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page", rpc.Cacheable("height")),
-	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
-	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
-	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height", rpc.Cacheable("height")),
-	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
-	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"health":                   rpc.NewRPCFunc(Health, ""),
+	"status":                   rpc.NewRPCFunc(Status, ""),
+	"net_info":                 rpc.NewRPCFunc(NetInfo, ""),
+	"blockchain":               rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
+	"genesis":                  rpc.NewRPCFunc(Genesis, "", rpc.Cacheable()),
+	"genesis_chunked":          rpc.NewRPCFunc(GenesisChunked, "chunk", rpc.Cacheable()),
+	"block":                    rpc.NewRPCFunc(Block, "height", rpc.Cacheable("height")),
+	"block_by_hash":            rpc.NewRPCFunc(BlockByHash, "hash", rpc.Cacheable()),
+	"block_by_time":            rpc.NewRPCFunc(BlockByTime, "time"),
+	"block_results":            rpc.NewRPCFunc(BlockResults, "height", rpc.Cacheable("height")),
+	"commit":                   rpc.NewRPCFunc(Commit, "height", rpc.Cacheable("height")),
+	"check_tx":                 rpc.NewRPCFunc(CheckTx, "tx"),
+	"tx":                       rpc.NewRPCFunc(Tx, "hash,prove", rpc.Cacheable()),
+	"tx_search":                rpc.NewRPCFunc(TxSearchMatchEvents, "query,prove,page,per_page,order_by,match_events"),
+	"block_search":             rpc.NewRPCFunc(BlockSearchMatchEvents, "query,page,per_page,order_by,match_events"),
+	"validators":               rpc.NewRPCFunc(Validators, "height,page,per_page", rpc.Cacheable("height")),
+	"dump_consensus_state":     rpc.NewRPCFunc(DumpConsensusState, ""),
+	"consensus_state":          rpc.NewRPCFunc(ConsensusState, ""),
+	"consensus_params":         rpc.NewRPCFunc(ConsensusParams, "height", rpc.Cacheable("height")),
+	"consensus_params_history": rpc.NewRPCFunc(ConsensusParamsHistory, "from,to"),
+	"proposer_history":         rpc.NewRPCFunc(ProposerHistory, "from,to"),
+	"unconfirmed_txs":          rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
+	"num_unconfirmed_txs":      rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"mempool_contents":         rpc.NewRPCFunc(MempoolContents, "sender,page,per_page"),
+	"tx_status":                rpc.NewRPCFunc(TxStatus, "hash"),
+	"settlement_status":        rpc.NewRPCFunc(SettlementStatus, ""),
+	"finality":                 rpc.NewRPCFunc(Finality, "height"),
 
 	// tx broadcast API
 	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
 	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
 	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_tx_batch":  rpc.NewRPCFunc(BroadcastTxBatch, "txs"),
 
 	// abci API
 	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
@@ -54,4 +62,10 @@ func AddUnsafeRoutes() {
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["unsafe_flush_rechecks"] = rpc.NewRPCFunc(UnsafeFlushRechecks, "")
+	Routes["unsafe_forget_tx"] = rpc.NewRPCFunc(UnsafeForgetTx, "hash")
+	Routes["unsafe_remove_tx"] = rpc.NewRPCFunc(UnsafeRemoveTx, "hash")
+	Routes["unsafe_reload_config"] = rpc.NewRPCFunc(UnsafeReloadConfig, "")
+	Routes["unsafe_switch_to_consensus"] = rpc.NewRPCFunc(UnsafeSwitchToConsensus, "")
+	Routes["unsafe_switch_to_fast_sync"] = rpc.NewRPCFunc(UnsafeSwitchToFastSync, "")
 }
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"errors"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// SettlementStatus returns the settlement status of the chain: the latest
+// heights whose state update was posted to the hub, and accepted and finalized
+// by it.
+func SettlementStatus(ctx *rpctypes.Context) (*ctypes.ResultSettlementStatus, error) {
+	if env.SettlementPoster == nil {
+		return nil, errors.New("settlement is not enabled")
+	}
+	status := env.SettlementPoster.Status()
+	return &ctypes.ResultSettlementStatus{
+		Hub:             status.Hub,
+		PostedHeight:    status.PostedHeight,
+		AcceptedHeight:  status.AcceptedHeight,
+		FinalizedHeight: status.FinalizedHeight,
+		PendingTxHash:   status.PendingTxHash,
+	}, nil
+}
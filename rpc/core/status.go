@@ -64,17 +64,26 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
 		},
-		ValidatorInfo: ctypes.ValidatorInfo{
-			Address:     env.PubKey.Address(),
-			PubKey:      env.PubKey,
-			VotingPower: votingPower,
-		},
+	}
+
+	// Nodes without a private validator, e.g. in full mode, have no validator
+	// info.
+	if env.PubKey != nil {
+		result.ValidatorInfo = ctypes.ValidatorInfo{
+			Address:       env.PubKey.Address(),
+			PubKey:        env.PubKey,
+			VotingPower:   votingPower,
+			SigningHalted: env.ConsensusState.SigningHalted(),
+		}
 	}
 
 	return result, nil
 }
 
 func validatorAtHeight(h int64) *types.Validator {
+	if env.PubKey == nil {
+		return nil
+	}
 	vals, err := env.StateStore.LoadValidators(h)
 	if err != nil {
 		return nil
@@ -4,9 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/state/txindex/null"
@@ -37,7 +42,10 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 
 	var proof types.TxProof
 	if prove {
-		block := env.BlockStore.LoadBlock(height)
+		block, err := loadBlock(height)
+		if err != nil {
+			return nil, err
+		}
 		proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 	}
 
@@ -51,6 +59,40 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 	}, nil
 }
 
+// TxStatus gets the status of a transaction along its lifecycle: received in
+// the mempool, gossiped to a peer, proposed in a block, then committed, evicted
+// from the mempool or rejected when rechecked. The committed transactions no
+// longer tracked are looked up in the tx index, and the others are unknown.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Info/tx_status
+func TxStatus(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	if env.TxStatuses == nil {
+		return nil, errors.New("the statuses of the transactions aren't tracked (set 'tx_status_size' in config)")
+	}
+	if len(hash) != tmhash.Size {
+		return nil, fmt.Errorf("invalid tx hash %X: expected %d bytes", hash, tmhash.Size)
+	}
+	var key types.TxKey
+	copy(key[:], hash)
+	if status, ok := env.TxStatuses.Get(key); ok {
+		return &ctypes.ResultTxStatus{Hash: hash, Status: status.Status, Height: status.Height, Time: status.Time}, nil
+	}
+
+	if _, ok := env.TxIndexer.(*null.TxIndex); !ok && env.TxIndexer != nil {
+		r, err := env.TxIndexer.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			result := &ctypes.ResultTxStatus{Hash: hash, Status: mempl.TxStatusCommitted, Height: r.Height}
+			if meta := env.BlockStore.LoadBlockMeta(r.Height); meta != nil {
+				result.Time = meta.Header.Time
+			}
+			return result, nil
+		}
+	}
+	return &ctypes.ResultTxStatus{Hash: hash, Status: mempl.TxStatusUnknown}, nil
+}
+
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/tx_search
@@ -98,6 +140,10 @@ func TxSearch(
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
+	// then by the attribute of the ORDER BY clause, if any
+	if order := q.OrderBy(); order != nil {
+		sortByAttribute(results, order)
+	}
 
 	// paginate results
 	totalCount := len(results)
@@ -117,7 +163,10 @@ func TxSearch(
 
 		var proof types.TxProof
 		if prove {
-			block := env.BlockStore.LoadBlock(r.Height)
+			block, err := loadBlock(r.Height)
+			if err != nil {
+				return nil, err
+			}
 			proof = block.Data.Txs.Proof(int(r.Index)) // XXX: overflow on 32-bit machines
 		}
 
@@ -155,3 +204,66 @@ func TxSearchMatchEvents(
 	return TxSearch(ctx, query, prove, pagePtr, perPagePtr, orderBy)
 
 }
+
+// sortByAttribute sorts the results by the value of the attribute of the ORDER
+// BY clause, keeping the order of the results with the same value. Numeric
+// values are compared as numbers, and other values as strings. The results
+// without the attribute come last.
+func sortByAttribute(results []*abci.TxResult, order *cmtquery.Order) {
+	values := make(map[*abci.TxResult]string, len(results))
+	for _, r := range results {
+		if v, ok := attributeValue(r, order.CompositeKey); ok {
+			values[r] = v
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		vi, oki := values[results[i]]
+		vj, okj := values[results[j]]
+		if !oki || !okj {
+			return oki && !okj
+		}
+		c := compareValues(vi, vj)
+		if order.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// attributeValue returns the value of the first attribute of the result with
+// the composite key.
+func attributeValue(r *abci.TxResult, compositeKey string) (string, bool) {
+	switch compositeKey {
+	case types.TxHeightKey:
+		return strconv.FormatInt(r.Height, 10), true
+	case types.TxHashKey:
+		return fmt.Sprintf("%X", types.Tx(r.Tx).Hash()), true
+	}
+	for _, event := range r.Result.Events {
+		for _, attr := range event.Attributes {
+			if event.Type+"."+string(attr.Key) == compositeKey {
+				return string(attr.Value), true
+			}
+		}
+	}
+	return "", false
+}
+
+// compareValues compares a and b as numbers if both are, and as strings
+// otherwise.
+func compareValues(a, b string) int {
+	fa, erra := strconv.ParseFloat(a, 64)
+	fb, errb := strconv.ParseFloat(b, 64)
+	if erra == nil && errb == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+func TestSortByAttribute(t *testing.T) {
+	txResult := func(height int64, amount string) *abci.TxResult {
+		r := &abci.TxResult{Height: height}
+		if amount != "" {
+			r.Result.Events = []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: []byte("amount"), Value: []byte(amount), Index: true},
+			}}}
+		}
+		return r
+	}
+	heights := func(results []*abci.TxResult) []int64 {
+		heights := make([]int64, len(results))
+		for i, r := range results {
+			heights[i] = r.Height
+		}
+		return heights
+	}
+
+	results := []*abci.TxResult{
+		txResult(1, "20"), txResult(2, ""), txResult(3, "3"), txResult(4, "20"), txResult(5, "100"),
+	}
+	sortByAttribute(results, &cmtquery.Order{CompositeKey: "transfer.amount"})
+	assert.Equal(t, []int64{3, 1, 4, 5, 2}, heights(results))
+
+	sortByAttribute(results, &cmtquery.Order{CompositeKey: "transfer.amount", Descending: true})
+	assert.Equal(t, []int64{5, 1, 4, 3, 2}, heights(results))
+
+	sortByAttribute(results, &cmtquery.Order{CompositeKey: "tx.height", Descending: true})
+	assert.Equal(t, []int64{5, 4, 3, 2, 1}, heights(results))
+
+	// non numeric values are compared as strings
+	results = []*abci.TxResult{txResult(1, "b"), txResult(2, "a"), txResult(3, "10stake")}
+	sortByAttribute(results, &cmtquery.Order{CompositeKey: "transfer.amount"})
+	assert.Equal(t, []int64{3, 2, 1}, heights(results))
+}
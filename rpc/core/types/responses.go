@@ -89,6 +89,9 @@ type ValidatorInfo struct {
 	Address     bytes.HexBytes `json:"address"`
 	PubKey      crypto.PubKey  `json:"pub_key"`
 	VotingPower int64          `json:"voting_power"`
+	// SigningHalted is true once the node refused to sign a vote or a proposal
+	// conflicting with one it signed before, and stopped signing.
+	SigningHalted bool `json:"signing_halted"`
 }
 
 // Node Status
@@ -114,6 +117,11 @@ type ResultNetInfo struct {
 	Peers     []Peer   `json:"peers"`
 }
 
+// Settings changed by reloading the configuration
+type ResultUnsafeReloadConfig struct {
+	Changed []string `json:"changed"`
+}
+
 // Log from dialing seeds
 type ResultDialSeeds struct {
 	Log string `json:"log"`
@@ -148,6 +156,23 @@ type ResultConsensusParams struct {
 	ConsensusParams cmtproto.ConsensusParams `json:"consensus_params"`
 }
 
+// ConsensusParams in effect over a range of heights, from the heights at which
+// they changed
+type ResultConsensusParamsHistory struct {
+	Changes []ResultConsensusParams `json:"changes"`
+}
+
+// Proposer of the block at a height
+type ResultProposer struct {
+	Height  int64         `json:"height"`
+	Address types.Address `json:"address"`
+}
+
+// Proposers of the blocks over a range of heights
+type ResultProposerHistory struct {
+	Proposers []ResultProposer `json:"proposers"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
@@ -166,22 +191,44 @@ type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 }
 
-// CheckTx result
+// CheckTx result, or the rejection of the tx by the mempool, of codespace
+// "mempool" and of reason too-large, fee-too-low, mempool-full or duplicate
 type ResultBroadcastTx struct {
 	Code      uint32         `json:"code"`
 	Data      bytes.HexBytes `json:"data"`
 	Log       string         `json:"log"`
 	Codespace string         `json:"codespace"`
+	Reason    string         `json:"reason,omitempty"`
+
+	Hash bytes.HexBytes `json:"hash"`
+}
+
+// CheckTx results of a batch of txs, in their order
+type ResultBroadcastTxBatch struct {
+	Txs []ResultBroadcastTxBatchTx `json:"txs"`
+}
+
+// CheckTx result of a tx of a batch, or the error which rejected it before
+// CheckTx, e.g. it is already in the cache
+type ResultBroadcastTxBatchTx struct {
+	Code      uint32         `json:"code"`
+	Data      bytes.HexBytes `json:"data"`
+	Log       string         `json:"log"`
+	Codespace string         `json:"codespace"`
+	Reason    string         `json:"reason,omitempty"`
+	Error     string         `json:"error,omitempty"`
 
 	Hash bytes.HexBytes `json:"hash"`
 }
 
-// CheckTx and DeliverTx results
+// CheckTx and DeliverTx results, with the reason of the rejection of the tx by
+// the mempool, if any
 type ResultBroadcastTxCommit struct {
 	CheckTx   abci.ResponseCheckTx   `json:"check_tx"`
 	DeliverTx abci.ResponseDeliverTx `json:"deliver_tx"`
 	Hash      bytes.HexBytes         `json:"hash"`
 	Height    int64                  `json:"height"`
+	Reason    string                 `json:"reason,omitempty"`
 }
 
 // ResultCheckTx wraps abci.ResponseCheckTx.
@@ -219,6 +266,34 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// Page of the mempool txs with their metadata
+type ResultMempoolContents struct {
+	Count int          `json:"n_txs"`
+	Total int          `json:"total"`
+	Txs   []*MempoolTx `json:"txs"`
+}
+
+// A mempool tx with its metadata
+type MempoolTx struct {
+	Tx          types.Tx       `json:"tx"`
+	Hash        bytes.HexBytes `json:"hash"`
+	Height      int64          `json:"height"`
+	ArrivalTime time.Time      `json:"arrival_time"`
+	GasWanted   int64          `json:"gas_wanted"`
+	Priority    int64          `json:"priority"`
+	Sender      string         `json:"sender,omitempty"`
+	Lane        string         `json:"lane,omitempty"`
+	Peers       []p2p.ID       `json:"peers"`
+}
+
+// Status of a tx along its lifecycle, with the height and time it was reached
+type ResultTxStatus struct {
+	Hash   bytes.HexBytes `json:"hash"`
+	Status string         `json:"status"`
+	Height int64          `json:"height"`
+	Time   time.Time      `json:"time"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -229,18 +304,45 @@ type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
 }
 
+// Settlement status of the chain on its hub
+type ResultSettlementStatus struct {
+	Hub             string         `json:"hub"`
+	PostedHeight    int64          `json:"posted_height"`
+	AcceptedHeight  int64          `json:"accepted_height"`
+	FinalizedHeight int64          `json:"finalized_height"`
+	PendingTxHash   bytes.HexBytes `json:"pending_tx_hash"`
+}
+
+// Finality of a block, and latest heights at each level of finality
+type ResultFinality struct {
+	Height           int64  `json:"height"`
+	Level            string `json:"level"`
+	SoftHeight       int64  `json:"soft_height"`
+	DAIncludedHeight int64  `json:"da_included_height"`
+	FirmHeight       int64  `json:"firm_height"`
+}
+
 // Result of broadcasting evidence
 type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of UnsafeFlushRechecks: the number of txs left in the mempool
+type ResultUnsafeFlushRechecks struct {
+	NumTxs int `json:"n_txs"`
+}
+
 // empty results
 type (
-	ResultUnsafeFlushMempool struct{}
-	ResultUnsafeProfile      struct{}
-	ResultSubscribe          struct{}
-	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
+	ResultUnsafeFlushMempool      struct{}
+	ResultUnsafeRemoveTx          struct{}
+	ResultUnsafeForgetTx          struct{}
+	ResultUnsafeProfile           struct{}
+	ResultUnsafeSwitchToConsensus struct{}
+	ResultUnsafeSwitchToFastSync  struct{}
+	ResultSubscribe               struct{}
+	ResultUnsubscribe             struct{}
+	ResultHealth                  struct{}
 )
 
 // Event data from a subscription
@@ -105,8 +105,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				cache = false
 			}
 
-			returns := rpcFunc.f.Call(args)
-			result, err := unreflectResult(returns)
+			result, err := rpcFunc.call(ctx, request.Method, args)
 			if err != nil {
 				responses = append(responses, types.RPCInternalError(request.ID, err))
 				continue
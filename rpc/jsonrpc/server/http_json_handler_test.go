@@ -11,8 +11,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/tracing"
 	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
@@ -277,3 +281,33 @@ func TestRPCResponseCache(t *testing.T) {
 	res.Body.Close()
 	require.Nil(t, err, "reading from the body should not give back an error")
 }
+
+func TestRPCTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.Install(trace.NewNoopTracerProvider())
+
+	var spanCtx trace.SpanContext
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context) (string, error) {
+			spanCtx = trace.SpanContextFromContext(ctx.Context())
+			return "foo", nil
+		}, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger())
+
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(`{"jsonrpc": "2.0", "method": "c", "id": "0"}`))
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// the span of the call continues the trace of the caller
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "rpc.c", spans[0].Name())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", spans[0].Parent().SpanID().String())
+	assert.Equal(t, spans[0].SpanContext(), spanCtx)
+}
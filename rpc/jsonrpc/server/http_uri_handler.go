@@ -51,10 +51,9 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 		args = append(args, fnArgs...)
 
-		returns := rpcFunc.f.Call(args)
+		result, err := rpcFunc.call(ctx, strings.TrimPrefix(r.URL.Path, "/"), args)
 
-		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
-		result, err := unreflectResult(returns)
+		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "result", result, "err", err)
 		if err != nil {
 			if err := WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
 				types.RPCInternalError(dummyID, err)); err != nil {
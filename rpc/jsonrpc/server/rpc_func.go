@@ -6,9 +6,17 @@ import (
 	"reflect"
 	"strings"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/tracing"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
+var tracer = tracing.Tracer("rpc")
+
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
@@ -95,6 +103,23 @@ func (f *RPCFunc) cacheableWithArgs(args []reflect.Value) bool {
 	return true
 }
 
+// call calls the function with args, whose first is ctx, in a span of the
+// method. The span continues the trace of the HTTP request, if any, and is the
+// span of ctx.Context() during the call.
+func (f *RPCFunc) call(ctx *types.Context, method string, args []reflect.Value) (interface{}, error) {
+	parent := ctx.Context()
+	if ctx.HTTPReq != nil {
+		parent = otel.GetTextMapPropagator().Extract(parent, propagation.HeaderCarrier(ctx.HTTPReq.Header))
+	}
+	spanCtx, span := tracer.Start(parent, "rpc."+method, trace.WithSpanKind(trace.SpanKindServer))
+	if ctx.HTTPReq != nil {
+		ctx.HTTPReq = ctx.HTTPReq.WithContext(spanCtx)
+	}
+	result, err := unreflectResult(f.f.Call(args))
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
 func newRPCFunc(f interface{}, args string, options ...Option) *RPCFunc {
 	var argNames []string
 	if args != "" {
@@ -378,12 +378,11 @@ func (wsc *wsConnection) readRoutine() {
 				args = append(args, fnArgs...)
 			}
 
-			returns := rpcFunc.f.Call(args)
+			result, err := rpcFunc.call(ctx, request.Method, args)
 
 			// TODO: Need to encode args/returns to string if we want to log them
 			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
 
-			result, err := unreflectResult(returns)
 			if err != nil {
 				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
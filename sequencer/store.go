@@ -0,0 +1,51 @@
+package sequencer
+
+import (
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Store persists the sequencer rotations scheduled by the settlement hub, so
+// that the blocks are replayed with the same validator sets.
+type Store struct {
+	db dbm.DB
+}
+
+// NewStore returns a Store of the rotations in db.
+func NewStore(db dbm.DB) *Store {
+	return &Store{db: db}
+}
+
+// SaveSequencerRotation saves rotation, replacing the one at the same height.
+func (s *Store) SaveSequencerRotation(rotation *types.SequencerRotation) error {
+	bz, err := cmtjson.Marshal(rotation)
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(calcRotationKey(rotation.Height), bz)
+}
+
+// LoadSequencerRotation returns the rotation at height, or nil if there is
+// none. Panics if it can't be read.
+func (s *Store) LoadSequencerRotation(height int64) *types.SequencerRotation {
+	bz, err := s.db.Get(calcRotationKey(height))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return nil
+	}
+	rotation := new(types.SequencerRotation)
+	if err := cmtjson.Unmarshal(bz, rotation); err != nil {
+		panic(fmt.Errorf("error reading sequencer rotation: %w", err))
+	}
+	return rotation
+}
+
+func calcRotationKey(height int64) []byte {
+	return []byte(fmt.Sprintf("sequencerRotation:%v", height))
+}
@@ -0,0 +1,143 @@
+// Package sequencer schedules the rotations of the sequencer of the chain,
+// i.e. of its single validator proposing all the blocks, decided by its
+// settlement hub.
+package sequencer
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Source reads the sequencer rotations scheduled by the settlement hub.
+type Source interface {
+	// Rotations returns all the rotations scheduled so far.
+	Rotations(ctx context.Context) ([]*types.SequencerRotation, error)
+
+	// Subscribe returns the rotations scheduled from now on, until ctx is
+	// done.
+	Subscribe(ctx context.Context) (<-chan *types.SequencerRotation, error)
+}
+
+// BlockStore is the block store used by the Watcher.
+type BlockStore interface {
+	Base() int64
+	Height() int64
+}
+
+// Watcher is a service saving the sequencer rotations scheduled by the hub
+// to the Store, as they are announced, for the BlockExecutor to apply them. A
+// rotation must be saved before the block two heights below it is executed,
+// so the rotations announced too late are logged as errors. The rotations
+// are also loaded again every resync interval, in case an announcement was
+// missed.
+type Watcher struct {
+	service.BaseService
+
+	source         Source
+	store          *Store
+	blockStore     BlockStore
+	resyncInterval time.Duration
+}
+
+// NewWatcher returns a Watcher of the rotations of source, saved to store.
+func NewWatcher(source Source, store *Store, blockStore BlockStore, resyncInterval time.Duration) *Watcher {
+	w := &Watcher{
+		source:         source,
+		store:          store,
+		blockStore:     blockStore,
+		resyncInterval: resyncInterval,
+	}
+	w.BaseService = *service.NewBaseService(nil, "SequencerWatcher", w)
+	return w
+}
+
+// Sync saves all the rotations scheduled so far. It must be called before
+// the stored blocks are replayed, so that they are replayed with their
+// rotations.
+func (w *Watcher) Sync(ctx context.Context) error {
+	rotations, err := w.source.Rotations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rotation := range rotations {
+		w.save(rotation)
+	}
+	return nil
+}
+
+// OnStart implements service.Service.
+func (w *Watcher) OnStart() error {
+	go w.run()
+	return nil
+}
+
+func (w *Watcher) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.Quit()
+		cancel()
+	}()
+
+	var rotations <-chan *types.SequencerRotation
+	subscribe := func() {
+		var err error
+		if rotations, err = w.source.Subscribe(ctx); err != nil && ctx.Err() == nil {
+			w.Logger.Error("failed to subscribe to the sequencer rotations", "err", err)
+		}
+	}
+	subscribe()
+
+	ticker := time.NewTicker(w.resyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case rotation, ok := <-rotations:
+			if !ok {
+				rotations = nil
+				continue
+			}
+			w.save(rotation)
+		case <-ticker.C:
+			if rotations == nil {
+				subscribe()
+			}
+			if err := w.Sync(ctx); err != nil && ctx.Err() == nil {
+				w.Logger.Error("failed to load the sequencer rotations", "err", err)
+			}
+		case <-w.Quit():
+			return
+		}
+	}
+}
+
+// save saves rotation, unless it is known already or announced too late.
+func (w *Watcher) save(rotation *types.SequencerRotation) {
+	if err := rotation.ValidateBasic(); err != nil {
+		w.Logger.Error("invalid sequencer rotation", "rotation", rotation, "err", err)
+		return
+	}
+	if saved := w.store.LoadSequencerRotation(rotation.Height); saved != nil &&
+		bytes.Equal(saved.PubKey.Bytes(), rotation.PubKey.Bytes()) {
+		return
+	}
+
+	// the validator set of a height is set by the block two heights below
+	if height := w.blockStore.Height(); rotation.Height <= height+2 {
+		if rotation.Height > w.blockStore.Base() {
+			w.Logger.Error("sequencer rotation announced too late, the chain may halt",
+				"rotation", rotation, "height", height)
+		}
+		return
+	}
+	if err := w.store.SaveSequencerRotation(rotation); err != nil {
+		w.Logger.Error("failed to save the sequencer rotation", "rotation", rotation, "err", err)
+		return
+	}
+	w.Logger.Info("sequencer rotation scheduled", "height", rotation.Height,
+		"proposer", rotation.PubKey.Address())
+}
@@ -0,0 +1,84 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mockSource struct {
+	rotations []*types.SequencerRotation
+	sub       chan *types.SequencerRotation
+}
+
+func (s *mockSource) Rotations(context.Context) ([]*types.SequencerRotation, error) {
+	return s.rotations, nil
+}
+
+func (s *mockSource) Subscribe(context.Context) (<-chan *types.SequencerRotation, error) {
+	return s.sub, nil
+}
+
+type mockBlockStore struct {
+	base, height int64
+}
+
+func (bs mockBlockStore) Base() int64   { return bs.base }
+func (bs mockBlockStore) Height() int64 { return bs.height }
+
+func rotation(height int64) *types.SequencerRotation {
+	return &types.SequencerRotation{Height: height, PubKey: ed25519.GenPrivKey().PubKey()}
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	assert.Nil(t, store.LoadSequencerRotation(10))
+
+	r := rotation(10)
+	require.NoError(t, store.SaveSequencerRotation(r))
+	assert.Equal(t, r, store.LoadSequencerRotation(10))
+	assert.Nil(t, store.LoadSequencerRotation(11))
+}
+
+func TestWatcherSync(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	past, known, tooLate, future := rotation(5), rotation(20), rotation(32), rotation(33)
+	require.NoError(t, store.SaveSequencerRotation(known))
+	source := &mockSource{rotations: []*types.SequencerRotation{
+		past, known, tooLate, future, {Height: 40},
+	}}
+	w := NewWatcher(source, store, mockBlockStore{base: 10, height: 30}, time.Minute)
+	w.SetLogger(log.TestingLogger())
+
+	require.NoError(t, w.Sync(context.Background()))
+	assert.Nil(t, store.LoadSequencerRotation(5))
+	assert.Equal(t, known, store.LoadSequencerRotation(20))
+	// the validator set of height 32 was set by the block at height 30
+	assert.Nil(t, store.LoadSequencerRotation(32))
+	assert.Equal(t, future, store.LoadSequencerRotation(33))
+	assert.Nil(t, store.LoadSequencerRotation(40))
+}
+
+func TestWatcherSubscription(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	source := &mockSource{sub: make(chan *types.SequencerRotation)}
+	w := NewWatcher(source, store, mockBlockStore{height: 1}, time.Minute)
+	w.SetLogger(log.TestingLogger())
+	require.NoError(t, w.Start())
+	defer w.Stop() //nolint:errcheck // ignore for tests
+
+	r := rotation(100)
+	source.sub <- r
+	require.Eventually(t, func() bool {
+		return store.LoadSequencerRotation(100) != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, r, store.LoadSequencerRotation(100))
+}
@@ -0,0 +1,97 @@
+// Package settlement posts the state updates of the chain, i.e. the state
+// roots of its blocks and their location on the DA layer, to a settlement hub
+// which accepts and then finalizes them, and keeps track of their settlement.
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockDescriptor describes a block in a state update.
+type BlockDescriptor struct {
+	Height int64
+	// StateRoot is the app hash after the block is executed.
+	StateRoot []byte
+	Time      time.Time
+}
+
+// Batch is the state update of consecutive blocks, which are included in the
+// DA layer batch DA.
+type Batch struct {
+	Blocks []BlockDescriptor
+	DA     *types.DAPointer
+}
+
+// FirstHeight returns the height of the first block of the batch.
+func (b *Batch) FirstHeight() int64 {
+	return b.Blocks[0].Height
+}
+
+// LastHeight returns the height of the last block of the batch.
+func (b *Batch) LastHeight() int64 {
+	return b.Blocks[len(b.Blocks)-1].Height
+}
+
+// ErrRejected is returned when the hub rejected a state update.
+var ErrRejected = errors.New("state update rejected by the hub")
+
+// QueryClient queries the settlement of the state updates by a hub.
+type QueryClient interface {
+	// Hub returns the name of the hub, e.g. "dymension".
+	Hub() string
+
+	// LatestHeight returns the latest height accepted by the hub, or the
+	// latest height finalized by it if finalized is true, or 0 if none is.
+	LatestHeight(ctx context.Context, finalized bool) (int64, error)
+}
+
+// Client posts the state updates to a settlement hub and queries their
+// settlement.
+type Client interface {
+	QueryClient
+
+	// PostBatch posts the state update of batch, and returns the hash of the
+	// transaction posting it.
+	PostBatch(ctx context.Context, batch *Batch) (txHash []byte, err error)
+
+	// Accepted returns whether the transaction posting a state update was
+	// included in the hub, and an ErrRejected error if the hub rejected it.
+	Accepted(ctx context.Context, txHash []byte) (bool, error)
+}
+
+// NewClient returns the client of the hub configured, for the chain chainID.
+func NewClient(config *cfg.SettlementConfig, chainID string) (Client, error) {
+	switch config.Hub {
+	case cfg.SettlementHubDymension:
+		return NewDymensionClient(config, rollappID(config, chainID))
+	default:
+		return nil, fmt.Errorf("unknown settlement hub %q", config.Hub)
+	}
+}
+
+// NewQueryClient returns the query client of the hub configured, for the
+// chain chainID. Unlike NewClient, it doesn't need the key of the sequencer
+// account, so any node can use it.
+func NewQueryClient(config *cfg.SettlementConfig, chainID string) (QueryClient, error) {
+	switch config.Hub {
+	case cfg.SettlementHubDymension:
+		return NewDymensionQueryClient(config, rollappID(config, chainID))
+	default:
+		return nil, fmt.Errorf("unknown settlement hub %q", config.Hub)
+	}
+}
+
+// rollappID returns the ID of the chain on the hub, which defaults to its
+// chain ID.
+func rollappID(config *cfg.SettlementConfig, chainID string) string {
+	if config.RollappID != "" {
+		return config.RollappID
+	}
+	return chainID
+}
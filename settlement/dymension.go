@@ -0,0 +1,210 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/bytes"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	typeURLMsgUpdateState = "/dymensionxyz.dymension.rollapp.MsgUpdateState"
+	typeURLEthAccount     = "/ethermint.types.v1.EthAccount"
+
+	queryAccountPath      = "/cosmos.auth.v1beta1.Query/Account"
+	queryLatestHeightPath = "/dymensionxyz.dymension.rollapp.Query/LatestHeight"
+)
+
+// hubRPC is the RPC of a hub node used by the DymensionClient.
+type hubRPC interface {
+	ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (*ctypes.ResultABCIQuery, error)
+	BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
+}
+
+// DymensionClient posts the state updates to the Dymension hub, with
+// MsgUpdateState transactions signed by the sequencer account, through the
+// RPC of a hub node.
+type DymensionClient struct {
+	rpc       hubRPC
+	signer    *signer
+	rollappID string
+	chainID   string
+	gasLimit  uint64
+	fees      []coin
+}
+
+var _ Client = (*DymensionClient)(nil)
+
+// NewDymensionClient returns a client of the Dymension hub for the rollapp
+// rollappID.
+func NewDymensionClient(config *cfg.SettlementConfig, rollappID string) (*DymensionClient, error) {
+	s, err := loadSigner(config.KeyFilePath(), config.KeyType, config.AddressPrefix)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := parseFees(config.Fees)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewDymensionQueryClient(config, rollappID)
+	if err != nil {
+		return nil, err
+	}
+	c.signer = s
+	c.fees = fees
+	return c, nil
+}
+
+// NewDymensionQueryClient returns a client of the Dymension hub for the
+// rollapp rollappID, which can only query the hub: it has no key to post the
+// state updates with.
+func NewDymensionQueryClient(config *cfg.SettlementConfig, rollappID string) (*DymensionClient, error) {
+	rpc, err := rpchttp.NewWithTimeout(config.RPCAddress, "/websocket", uint(config.Timeout.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("creating the hub RPC client: %w", err)
+	}
+	return &DymensionClient{
+		rpc:       rpc,
+		rollappID: rollappID,
+		chainID:   config.HubChainID,
+		gasLimit:  config.GasLimit,
+	}, nil
+}
+
+// Hub implements Client.
+func (c *DymensionClient) Hub() string {
+	return cfg.SettlementHubDymension
+}
+
+// PostBatch implements Client.
+func (c *DymensionClient) PostBatch(ctx context.Context, batch *Batch) ([]byte, error) {
+	if c.signer == nil {
+		return nil, errors.New("no key to post the state update with")
+	}
+	accountNumber, sequence, err := c.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := buildTx(c.signer, c.msgUpdateState(batch), txParams{
+		chainID:       c.chainID,
+		accountNumber: accountNumber,
+		sequence:      sequence,
+		gasLimit:      c.gasLimit,
+		fees:          c.fees,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.rpc.BroadcastTxSync(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("broadcasting the state update: %w", err)
+	}
+	if res.Code != 0 {
+		return nil, fmt.Errorf("state update refused by the hub (code %d): %s", res.Code, res.Log)
+	}
+	return res.Hash, nil
+}
+
+// Accepted implements Client.
+func (c *DymensionClient) Accepted(ctx context.Context, txHash []byte) (bool, error) {
+	res, err := c.rpc.Tx(ctx, txHash, false)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return false, nil
+		}
+		return false, err
+	}
+	if res.TxResult.Code != 0 {
+		return false, fmt.Errorf("%w (code %d): %s", ErrRejected, res.TxResult.Code, res.TxResult.Log)
+	}
+	return true, nil
+}
+
+// LatestHeight implements Client.
+func (c *DymensionClient) LatestHeight(ctx context.Context, finalized bool) (int64, error) {
+	req := appendString(nil, 1, c.rollappID)
+	if finalized {
+		req = appendVarint(req, 2, 1)
+	}
+	res, err := c.query(ctx, queryLatestHeightPath, req)
+	if err != nil {
+		// no state update was accepted yet
+		if strings.Contains(err.Error(), "not found") {
+			return 0, nil
+		}
+		return 0, err
+	}
+	f, err := decodeFields(res)
+	if err != nil {
+		return 0, fmt.Errorf("decoding the latest height: %w", err)
+	}
+	return int64(f.varints[1]), nil
+}
+
+// msgUpdateState returns the MsgUpdateState of batch, encoded as an Any.
+func (c *DymensionClient) msgUpdateState(batch *Batch) []byte {
+	var bds []byte
+	for _, b := range batch.Blocks {
+		ts := appendVarint(nil, 1, uint64(b.Time.Unix()))
+		ts = appendVarint(ts, 2, uint64(b.Time.Nanosecond()))
+		bd := appendVarint(nil, 1, uint64(b.Height)) // BlockDescriptor.height
+		bd = appendBytes(bd, 2, b.StateRoot)
+		bd = appendBytes(bd, 3, ts)
+		bds = appendBytes(bds, 1, bd) // BlockDescriptors.BD
+	}
+	msg := appendString(nil, 1, c.signer.address) // MsgUpdateState.creator
+	msg = appendString(msg, 2, c.rollappID)
+	msg = appendVarint(msg, 3, uint64(batch.FirstHeight()))
+	msg = appendVarint(msg, 4, uint64(len(batch.Blocks)))
+	msg = appendString(msg, 5, DAPath(batch.DA))
+	msg = appendBytes(msg, 7, bds)
+	return encodeAny(typeURLMsgUpdateState, msg)
+}
+
+// account returns the account number and sequence of the sequencer account.
+func (c *DymensionClient) account(ctx context.Context) (uint64, uint64, error) {
+	res, err := c.query(ctx, queryAccountPath, appendString(nil, 1, c.signer.address))
+	if err != nil {
+		return 0, 0, fmt.Errorf("querying the account %s: %w", c.signer.address, err)
+	}
+	f, err := decodeFields(res) // QueryAccountResponse
+	if err == nil {
+		f, err = decodeFields(f.bytes[1]) // Any
+	}
+	var account fields
+	if err == nil {
+		typeURL, value := string(f.bytes[1]), f.bytes[2]
+		account, err = decodeFields(value)
+		if err == nil && typeURL == typeURLEthAccount {
+			account, err = decodeFields(account.bytes[1]) // EthAccount.base_account
+		}
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("decoding the account %s: %w", c.signer.address, err)
+	}
+	return account.varints[3], account.varints[4], nil
+}
+
+func (c *DymensionClient) query(ctx context.Context, path string, data []byte) ([]byte, error) {
+	res, err := c.rpc.ABCIQuery(ctx, path, data)
+	if err != nil {
+		return nil, err
+	}
+	if res.Response.Code != 0 {
+		return nil, fmt.Errorf("query %s failed (code %d): %s", path, res.Response.Code, res.Response.Log)
+	}
+	return res.Response.Value, nil
+}
+
+// DAPath returns the location on the DA layer posted in the state updates,
+// as "<layer>|<DA height>|<hex commitment>".
+func DAPath(ptr *types.DAPointer) string {
+	return fmt.Sprintf("%s|%d|%X", ptr.Layer, ptr.Height, ptr.Commitment)
+}
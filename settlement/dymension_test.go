@@ -0,0 +1,172 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/bytes"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// mockHubRPC is a hub node holding a single account and the latest heights of
+// a rollapp.
+type mockHubRPC struct {
+	account   []byte // encoded as an Any
+	accepted  uint64
+	finalized uint64
+
+	broadcast []types.Tx
+	txs       map[string]*abci.ResponseDeliverTx
+}
+
+func (m *mockHubRPC) ABCIQuery(_ context.Context, path string, data bytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	req, err := decodeFields(data)
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	switch path {
+	case queryAccountPath:
+		value = appendBytes(nil, 1, m.account)
+	case queryLatestHeightPath:
+		if string(req.bytes[1]) != "rollapp_1-1" {
+			return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: 22, Log: "rollapp: not found"}}, nil
+		}
+		height := m.accepted
+		if req.varints[2] == 1 {
+			height = m.finalized
+		}
+		value = appendVarint(nil, 1, height)
+	default:
+		return nil, errors.New("unknown path")
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: value}}, nil
+}
+
+func (m *mockHubRPC) BroadcastTxSync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	m.broadcast = append(m.broadcast, tx)
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func (m *mockHubRPC) Tx(_ context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	res, ok := m.txs[string(hash)]
+	if !ok {
+		return nil, errors.New("tx not found")
+	}
+	return &ctypes.ResultTx{Hash: hash, TxResult: *res}, nil
+}
+
+func newTestDymensionClient(t *testing.T, rpc hubRPC) *DymensionClient {
+	s, err := newSigner(testKey, cfg.SettlementKeyEthSecp256k1, "dym")
+	require.NoError(t, err)
+	return &DymensionClient{
+		rpc:       rpc,
+		signer:    s,
+		rollappID: "rollapp_1-1",
+		chainID:   "dymension_1100-1",
+		gasLimit:  400000,
+	}
+}
+
+func TestDymensionClientPostBatch(t *testing.T) {
+	baseAccount := appendVarint(nil, 3, 12) // account_number
+	baseAccount = appendVarint(baseAccount, 4, 5)
+	rpc := &mockHubRPC{
+		account: encodeAny(typeURLEthAccount, appendBytes(nil, 1, baseAccount)),
+		txs:     make(map[string]*abci.ResponseDeliverTx),
+	}
+	c := newTestDymensionClient(t, rpc)
+
+	now := time.Now()
+	batch := &Batch{
+		Blocks: []BlockDescriptor{
+			{Height: 4, StateRoot: []byte{4}, Time: now},
+			{Height: 5, StateRoot: []byte{5}, Time: now.Add(time.Second)},
+		},
+		DA: &types.DAPointer{Layer: cfg.DALayerCelestia, Height: 100, Commitment: []byte{0xab}, FirstHeight: 1, LastHeight: 5},
+	}
+	txHash, err := c.PostBatch(context.Background(), batch)
+	require.NoError(t, err)
+	require.Len(t, rpc.broadcast, 1)
+	assert.EqualValues(t, rpc.broadcast[0].Hash(), txHash)
+
+	// the transaction holds the MsgUpdateState of the batch, for the account
+	raw, err := decodeFields(rpc.broadcast[0])
+	require.NoError(t, err)
+	authInfo, err := decodeFields(raw.bytes[2])
+	require.NoError(t, err)
+	signerInfo, err := decodeFields(authInfo.bytes[1])
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, signerInfo.varints[3])
+
+	body, err := decodeFields(raw.bytes[1])
+	require.NoError(t, err)
+	anyMsg, err := decodeFields(body.bytes[1])
+	require.NoError(t, err)
+	assert.Equal(t, typeURLMsgUpdateState, string(anyMsg.bytes[1]))
+	msg, err := decodeFields(anyMsg.bytes[2])
+	require.NoError(t, err)
+	assert.Equal(t, c.signer.address, string(msg.bytes[1]))
+	assert.Equal(t, "rollapp_1-1", string(msg.bytes[2]))
+	assert.EqualValues(t, 4, msg.varints[3])
+	assert.EqualValues(t, 2, msg.varints[4])
+	assert.Equal(t, "celestia|100|AB", string(msg.bytes[5]))
+	bds, err := decodeFields(msg.bytes[7])
+	require.NoError(t, err)
+	bd, err := decodeFields(bds.bytes[1]) // the last one
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, bd.varints[1])
+	assert.Equal(t, []byte{5}, bd.bytes[2])
+
+	// accepted once included, rejected if it failed
+	accepted, err := c.Accepted(context.Background(), txHash)
+	require.NoError(t, err)
+	assert.False(t, accepted)
+
+	rpc.txs[string(txHash)] = &abci.ResponseDeliverTx{}
+	accepted, err = c.Accepted(context.Background(), txHash)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	rpc.txs[string(txHash)] = &abci.ResponseDeliverTx{Code: 5, Log: "wrong start height"}
+	_, err = c.Accepted(context.Background(), txHash)
+	assert.ErrorIs(t, err, ErrRejected)
+}
+
+func TestDymensionClientLatestHeight(t *testing.T) {
+	rpc := &mockHubRPC{accepted: 20, finalized: 10}
+	c := newTestDymensionClient(t, rpc)
+
+	height, err := c.LatestHeight(context.Background(), false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 20, height)
+	height, err = c.LatestHeight(context.Background(), true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, height)
+
+	// no state update for an unknown rollapp
+	c.rollappID = "other_2-1"
+	height, err = c.LatestHeight(context.Background(), false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, height)
+}
+
+func TestDymensionQueryClient(t *testing.T) {
+	config := cfg.TestSettlementConfig()
+	config.Hub = cfg.SettlementHubDymension
+	c, err := NewQueryClient(config, "rollapp_1-1")
+	require.NoError(t, err)
+	assert.Equal(t, cfg.SettlementHubDymension, c.Hub())
+
+	// it has no key to post with
+	_, err = c.(*DymensionClient).PostBatch(context.Background(), &Batch{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// eventsPerPage is the number of transactions per page of the searches.
+const eventsPerPage = 100
+
+// hubEventsRPC is the RPC of a hub node used to read the events of the hub
+// transactions.
+type hubEventsRPC interface {
+	Start() error
+	IsRunning() bool
+	TxSearch(ctx context.Context, query string, prove bool, page, perPage *int,
+		orderBy string) (*ctypes.ResultTxSearch, error)
+	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
+	Unsubscribe(ctx context.Context, subscriber, query string) error
+	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+}
+
+// hubEvent is an event of a hub transaction.
+type hubEvent struct {
+	// height is that of the hub block of the transaction.
+	height int64
+	attrs  map[string]string
+}
+
+// searchEvents returns the events of type eventType of the rollapp rollappID,
+// emitted by the past hub transactions, in order.
+func searchEvents(ctx context.Context, rpc hubEventsRPC, eventType, rollappID string) ([]hubEvent, error) {
+	query := fmt.Sprintf("%s.rollapp_id='%s'", eventType, rollappID)
+	var events []hubEvent
+	perPage := eventsPerPage
+	for page, fetched := 1, 0; ; page++ {
+		res, err := rpc.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range res.Txs {
+			for _, attrs := range filterEvents(tx.TxResult.Events, eventType, rollappID) {
+				events = append(events, hubEvent{height: tx.Height, attrs: attrs})
+			}
+		}
+		fetched += len(res.Txs)
+		if len(res.Txs) == 0 || fetched >= res.TotalCount {
+			return events, nil
+		}
+	}
+}
+
+// subscribeEvents returns the events of type eventType of the rollapp
+// rollappID, emitted by the hub transactions from now on, until ctx is done.
+func subscribeEvents(
+	ctx context.Context,
+	rpc hubEventsRPC,
+	subscriber, eventType, rollappID string,
+) (<-chan hubEvent, error) {
+	if !rpc.IsRunning() {
+		if err := rpc.Start(); err != nil {
+			return nil, fmt.Errorf("connecting to the hub: %w", err)
+		}
+	}
+	query := fmt.Sprintf("%s AND %s.rollapp_id='%s'", types.EventQueryTx, eventType, rollappID)
+	events, err := rpc.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan hubEvent)
+	go func() {
+		defer close(out)
+		defer rpc.Unsubscribe(context.Background(), subscriber, query) //nolint:errcheck // best effort
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				data, ok := event.Data.(types.EventDataTx)
+				if !ok {
+					continue
+				}
+				for _, attrs := range filterEvents(data.Result.Events, eventType, rollappID) {
+					select {
+					case out <- hubEvent{height: data.Height, attrs: attrs}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// filterEvents returns the attributes of the events of type eventType of the
+// rollapp rollappID among events.
+func filterEvents(events []abci.Event, eventType, rollappID string) []map[string]string {
+	var filtered []map[string]string
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		attrs := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		if attrs["rollapp_id"] != rollappID {
+			continue
+		}
+		filtered = append(filtered, attrs)
+	}
+	return filtered
+}
@@ -0,0 +1,125 @@
+package settlement
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/inclusion"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/types"
+)
+
+// EventTypeForcedInclusion is the type of the events of the hub transactions
+// force-including a transaction in the rollapp, with the attributes
+// rollapp_id, tx (base64) and deadline, the last height of the rollapp which
+// can include it.
+const EventTypeForcedInclusion = "forced_inclusion"
+
+// ForcedTxSource reads the transactions force-included in a rollapp from the
+// forced_inclusion events of the hub transactions, through the RPC of a hub
+// node: they are searched in the past transactions and subscribed to. Each
+// transaction is given the time of the hub block which announced it.
+type ForcedTxSource struct {
+	rpc       hubEventsRPC
+	rollappID string
+}
+
+var _ inclusion.Source = (*ForcedTxSource)(nil)
+
+// NewForcedTxSource returns a ForcedTxSource of the transactions
+// force-included in the rollapp configured, or in the chain chainID if the
+// rollapp ID isn't configured.
+func NewForcedTxSource(config *cfg.SettlementConfig, chainID string) (*ForcedTxSource, error) {
+	rpc, err := rpchttp.NewWithTimeout(config.RPCAddress, "/websocket", uint(config.Timeout.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("creating the hub RPC client: %w", err)
+	}
+	return &ForcedTxSource{rpc: rpc, rollappID: rollappID(config, chainID)}, nil
+}
+
+// ForcedTxs implements inclusion.Source.
+func (s *ForcedTxSource) ForcedTxs(ctx context.Context) ([]*types.ForcedTx, error) {
+	events, err := searchEvents(ctx, s.rpc, EventTypeForcedInclusion, s.rollappID)
+	if err != nil {
+		return nil, fmt.Errorf("searching the forced transactions: %w", err)
+	}
+	var (
+		ftxs     []*types.ForcedTx
+		hubTimes = make(map[int64]time.Time)
+	)
+	for _, event := range events {
+		// the events which can't be parsed are skipped
+		ftx, err := parseForcedTx(event.attrs)
+		if err != nil {
+			continue
+		}
+		hubTime, ok := hubTimes[event.height]
+		if !ok {
+			if hubTime, err = s.hubTime(ctx, event.height); err != nil {
+				return nil, err
+			}
+			hubTimes[event.height] = hubTime
+		}
+		ftx.HubTime = hubTime
+		ftxs = append(ftxs, ftx)
+	}
+	return ftxs, nil
+}
+
+// Subscribe implements inclusion.Source.
+func (s *ForcedTxSource) Subscribe(ctx context.Context) (<-chan *types.ForcedTx, error) {
+	events, err := subscribeEvents(ctx, s.rpc, "inclusion", EventTypeForcedInclusion, s.rollappID)
+	if err != nil {
+		return nil, fmt.Errorf("subscribing to the forced transactions: %w", err)
+	}
+	out := make(chan *types.ForcedTx)
+	go func() {
+		defer close(out)
+		for event := range events {
+			ftx, err := parseForcedTx(event.attrs)
+			if err != nil {
+				continue
+			}
+			// a transaction whose hub time can't be read is left to the
+			// next search
+			if ftx.HubTime, err = s.hubTime(ctx, event.height); err != nil {
+				continue
+			}
+			select {
+			case out <- ftx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// hubTime returns the time of the hub block at height.
+func (s *ForcedTxSource) hubTime(ctx context.Context, height int64) (time.Time, error) {
+	res, err := s.rpc.Commit(ctx, &height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading the hub block %d: %w", height, err)
+	}
+	if res.Header == nil {
+		return time.Time{}, fmt.Errorf("no header of the hub block %d", height)
+	}
+	return res.Header.Time, nil
+}
+
+func parseForcedTx(attrs map[string]string) (*types.ForcedTx, error) {
+	tx, err := base64.StdEncoding.DecodeString(attrs["tx"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	deadline, err := strconv.ParseInt(attrs["deadline"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deadline: %w", err)
+	}
+	ftx := &types.ForcedTx{Tx: tx, Deadline: deadline}
+	return ftx, ftx.ValidateBasic()
+}
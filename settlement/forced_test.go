@@ -0,0 +1,73 @@
+package settlement
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func forcedInclusionEvent(rollappID string, tx types.Tx, deadline int64) abci.Event {
+	return abci.Event{
+		Type: EventTypeForcedInclusion,
+		Attributes: []abci.EventAttribute{
+			{Key: []byte("rollapp_id"), Value: []byte(rollappID)},
+			{Key: []byte("tx"), Value: []byte(base64.StdEncoding.EncodeToString(tx))},
+			{Key: []byte("deadline"), Value: []byte(strconv.FormatInt(deadline, 10))},
+		},
+	}
+}
+
+func TestForcedTxSourceForcedTxs(t *testing.T) {
+	rpc := &mockHubEventsRPC{txs: []*ctypes.ResultTx{
+		{Height: 3, TxResult: abci.ResponseDeliverTx{Events: []abci.Event{
+			forcedInclusionEvent("rollapp_1-1", types.Tx("tx1"), 10),
+			forcedInclusionEvent("other_2-1", types.Tx("tx2"), 10),
+		}}},
+		{Height: 4, TxResult: abci.ResponseDeliverTx{Events: []abci.Event{
+			forcedInclusionEvent("rollapp_1-1", types.Tx("tx3"), 0),
+			forcedInclusionEvent("rollapp_1-1", types.Tx("tx4"), 12),
+		}}},
+	}}
+	s := &ForcedTxSource{rpc: rpc, rollappID: "rollapp_1-1"}
+
+	ftxs, err := s.ForcedTxs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*types.ForcedTx{
+		{Tx: types.Tx("tx1"), Deadline: 10, HubTime: hubTime(3)},
+		{Tx: types.Tx("tx4"), Deadline: 12, HubTime: hubTime(4)},
+	}, ftxs)
+}
+
+func TestForcedTxSourceSubscribe(t *testing.T) {
+	rpc := &mockHubEventsRPC{events: make(chan ctypes.ResultEvent, 1)}
+	s := &ForcedTxSource{rpc: rpc, rollappID: "rollapp_1-1"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ftxs, err := s.Subscribe(ctx)
+	require.NoError(t, err)
+
+	rpc.events <- ctypes.ResultEvent{Data: types.EventDataTx{TxResult: abci.TxResult{
+		Height: 7,
+		Result: abci.ResponseDeliverTx{Events: []abci.Event{forcedInclusionEvent("rollapp_1-1", types.Tx("tx"), 5)}},
+	}}}
+	select {
+	case ftx := <-ftxs:
+		assert.Equal(t, &types.ForcedTx{Tx: types.Tx("tx"), Deadline: 5, HubTime: hubTime(7)}, ftx)
+	case <-time.After(time.Second):
+		t.Fatal("no forced transaction received")
+	}
+
+	cancel()
+	_, ok := <-ftxs
+	assert.False(t, ok)
+}
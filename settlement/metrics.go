@@ -0,0 +1,82 @@
+package settlement
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "settlement"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Latest height whose state update was posted to the hub.
+	PostedHeight metrics.Gauge
+	// Latest height accepted by the hub.
+	AcceptedHeight metrics.Gauge
+	// Latest height finalized by the hub.
+	FinalizedHeight metrics.Gauge
+	// Number of failed postings and hub queries.
+	Failures metrics.Counter
+	// Time taken by the hub to accept a state update, from its posting.
+	AcceptanceTime metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		PostedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "posted_height",
+			Help:      "Latest height whose state update was posted to the hub.",
+		}, labels).With(labelsAndValues...),
+		AcceptedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "accepted_height",
+			Help:      "Latest height accepted by the hub.",
+		}, labels).With(labelsAndValues...),
+		FinalizedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "finalized_height",
+			Help:      "Latest height finalized by the hub.",
+		}, labels).With(labelsAndValues...),
+		Failures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "failures",
+			Help:      "Number of failed postings and hub queries.",
+		}, labels).With(labelsAndValues...),
+		AcceptanceTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "acceptance_time_seconds",
+			Help:      "Time taken by the hub to accept a state update, from its posting.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 10),
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		PostedHeight:    discard.NewGauge(),
+		AcceptedHeight:  discard.NewGauge(),
+		FinalizedHeight: discard.NewGauge(),
+		Failures:        discard.NewCounter(),
+		AcceptanceTime:  discard.NewHistogram(),
+	}
+}
@@ -0,0 +1,283 @@
+package settlement
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockStore is the block store used by the Poster.
+type BlockStore interface {
+	Height() int64
+	LoadBlockMeta(height int64) *types.BlockMeta
+	LoadDAPointer(height int64) *types.DAPointer
+}
+
+// StatusPublisher publishes the changes of the settlement status.
+type StatusPublisher interface {
+	PublishEventSettlementStatus(types.EventDataSettlementStatus) error
+}
+
+// Status is the settlement status of the chain.
+type Status struct {
+	Hub string `json:"hub"`
+	// PostedHeight is the latest height whose state update was posted,
+	// whether or not it is accepted yet.
+	PostedHeight int64 `json:"posted_height"`
+	// AcceptedHeight is the latest height accepted by the hub.
+	AcceptedHeight int64 `json:"accepted_height"`
+	// FinalizedHeight is the latest height finalized by the hub, after its
+	// dispute period.
+	FinalizedHeight int64 `json:"finalized_height"`
+	// PendingTxHash is the hash of the transaction posting the state update
+	// not yet accepted, if any.
+	PendingTxHash cmtbytes.HexBytes `json:"pending_tx_hash"`
+}
+
+// Poster is a service posting the state updates of the blocks included in the
+// DA layer to a settlement hub, one batch at a time, and tracking their
+// acceptance and finalization by the hub. It resumes from the latest height
+// accepted by the hub, and a state update which isn't accepted in time is
+// posted again.
+type Poster struct {
+	service.BaseService
+
+	client            Client
+	blockStore        BlockStore
+	publisher         StatusPublisher
+	interval          time.Duration
+	acceptanceTimeout time.Duration
+	metrics           *Metrics
+	proposerAddress   types.Address // if set, only the blocks it proposed are posted
+
+	mtx    cmtsync.RWMutex
+	status Status
+
+	// accessed by the run routine
+	synced   bool // whether the heights were loaded from the hub
+	pending  *Batch
+	postedAt time.Time
+}
+
+// PosterOption sets an optional parameter on the Poster.
+type PosterOption func(*Poster)
+
+// NewPoster returns a Poster of the state updates of the blocks of blockStore
+// to the hub of client, publishing the changes of the status to publisher.
+func NewPoster(
+	client Client,
+	blockStore BlockStore,
+	publisher StatusPublisher,
+	config *cfg.SettlementConfig,
+	options ...PosterOption,
+) *Poster {
+	p := &Poster{
+		client:            client,
+		blockStore:        blockStore,
+		publisher:         publisher,
+		interval:          config.PostInterval,
+		acceptanceTimeout: config.AcceptanceTimeout,
+		metrics:           NopMetrics(),
+		status:            Status{Hub: client.Hub()},
+	}
+	p.BaseService = *service.NewBaseService(nil, "SettlementPoster", p)
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) PosterOption {
+	return func(p *Poster) { p.metrics = metrics }
+}
+
+// WithProposerAddress makes the Poster post the state updates of the blocks
+// proposed by address only, i.e. by this node as the sequencer. At a sequencer
+// rotation, the previous sequencer hands over by posting its blocks up to the
+// rotation, and the next sequencer posts its own once the hub accepted them.
+func WithProposerAddress(address types.Address) PosterOption {
+	return func(p *Poster) { p.proposerAddress = address }
+}
+
+// Status returns the settlement status.
+func (p *Poster) Status() Status {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.status
+}
+
+// OnStart implements service.Service.
+func (p *Poster) OnStart() error {
+	go p.run()
+	return nil
+}
+
+func (p *Poster) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.Quit()
+		cancel()
+	}()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.post(ctx); err != nil && ctx.Err() == nil {
+				p.metrics.Failures.Add(1)
+				p.Logger.Error("failed to settle the state updates", "err", err)
+			}
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// post updates the settlement status from the hub, and posts the next state
+// update once the pending one is accepted.
+func (p *Poster) post(ctx context.Context) error {
+	if !p.synced {
+		accepted, err := p.client.LatestHeight(ctx, false)
+		if err != nil {
+			return fmt.Errorf("querying the latest height accepted: %w", err)
+		}
+		p.update(func(s *Status) {
+			s.AcceptedHeight = accepted
+			s.PostedHeight = accepted
+		})
+		p.synced = true
+	}
+
+	finalized, err := p.client.LatestHeight(ctx, true)
+	if err != nil {
+		return fmt.Errorf("querying the latest height finalized: %w", err)
+	}
+	p.update(func(s *Status) { s.FinalizedHeight = finalized })
+
+	if p.pending != nil {
+		accepted, err := p.checkPending(ctx)
+		if err != nil || !accepted {
+			return err
+		}
+	}
+
+	batch := p.nextBatch()
+	if batch == nil {
+		return nil
+	}
+	txHash, err := p.client.PostBatch(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("posting the state update of blocks %d to %d: %w",
+			batch.FirstHeight(), batch.LastHeight(), err)
+	}
+	p.pending = batch
+	p.postedAt = time.Now()
+	p.update(func(s *Status) {
+		s.PostedHeight = batch.LastHeight()
+		s.PendingTxHash = txHash
+	})
+	p.metrics.PostedHeight.Set(float64(batch.LastHeight()))
+	p.Logger.Debug("state update posted", "first", batch.FirstHeight(), "last", batch.LastHeight(),
+		"tx", cmtbytes.HexBytes(txHash))
+	return nil
+}
+
+// checkPending checks whether the pending state update is accepted. It
+// returns whether the next state update can be posted.
+func (p *Poster) checkPending(ctx context.Context) (bool, error) {
+	batch := p.pending
+	accepted, err := p.client.Accepted(ctx, p.Status().PendingTxHash)
+	switch {
+	case errors.Is(err, ErrRejected):
+		p.Logger.Error("state update rejected, posting it again", "first", batch.FirstHeight(),
+			"last", batch.LastHeight(), "err", err)
+	case err != nil:
+		return false, err
+	case !accepted:
+		if time.Since(p.postedAt) < p.acceptanceTimeout {
+			return false, nil
+		}
+		p.Logger.Error("state update not accepted in time, posting it again",
+			"first", batch.FirstHeight(), "last", batch.LastHeight())
+	default:
+		p.update(func(s *Status) { s.AcceptedHeight = batch.LastHeight() })
+		p.metrics.AcceptanceTime.Observe(time.Since(p.postedAt).Seconds())
+		p.Logger.Info("state update accepted", "first", batch.FirstHeight(), "last", batch.LastHeight())
+	}
+	p.pending = nil
+	p.update(func(s *Status) {
+		s.PostedHeight = s.AcceptedHeight
+		s.PendingTxHash = nil
+	})
+	return true, nil
+}
+
+// nextBatch returns the state update of the blocks following the accepted
+// ones, up to the end of their DA batch or to the first block proposed by
+// another sequencer, or nil if they aren't included in the DA layer yet. The
+// state root of a block is the app hash of the next one.
+func (p *Poster) nextBatch() *Batch {
+	first := p.Status().AcceptedHeight + 1
+	ptr := p.blockStore.LoadDAPointer(first)
+	if ptr == nil {
+		return nil
+	}
+	last := ptr.LastHeight
+	if height := p.blockStore.Height(); last >= height {
+		last = height - 1
+	}
+
+	batch := &Batch{DA: ptr}
+	for h := first; h <= last; h++ {
+		meta, next := p.blockStore.LoadBlockMeta(h), p.blockStore.LoadBlockMeta(h+1)
+		if meta == nil || next == nil {
+			break
+		}
+		if p.proposerAddress != nil && !bytes.Equal(meta.Header.ProposerAddress, p.proposerAddress) {
+			break
+		}
+		batch.Blocks = append(batch.Blocks, BlockDescriptor{
+			Height:    h,
+			StateRoot: next.Header.AppHash,
+			Time:      meta.Header.Time,
+		})
+	}
+	if len(batch.Blocks) == 0 {
+		return nil
+	}
+	return batch
+}
+
+// update updates the status, and publishes it if the accepted or finalized
+// height changed.
+func (p *Poster) update(fn func(s *Status)) {
+	p.mtx.Lock()
+	old := p.status
+	fn(&p.status)
+	status := p.status
+	p.mtx.Unlock()
+
+	if status.AcceptedHeight == old.AcceptedHeight && status.FinalizedHeight == old.FinalizedHeight {
+		return
+	}
+	p.metrics.AcceptedHeight.Set(float64(status.AcceptedHeight))
+	p.metrics.FinalizedHeight.Set(float64(status.FinalizedHeight))
+	if err := p.publisher.PublishEventSettlementStatus(types.EventDataSettlementStatus{
+		Hub:             status.Hub,
+		AcceptedHeight:  status.AcceptedHeight,
+		FinalizedHeight: status.FinalizedHeight,
+	}); err != nil {
+		p.Logger.Error("failed to publish the settlement status", "err", err)
+	}
+}
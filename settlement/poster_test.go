@@ -0,0 +1,186 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// mockClient accepts the state updates posted once accept is set.
+type mockClient struct {
+	batches   []*Batch
+	accept    bool
+	rejected  bool
+	finalized int64
+	queryErr  error
+}
+
+func (c *mockClient) Hub() string { return "mock" }
+
+func (c *mockClient) PostBatch(_ context.Context, batch *Batch) ([]byte, error) {
+	c.batches = append(c.batches, batch)
+	return []byte{byte(len(c.batches))}, nil
+}
+
+func (c *mockClient) Accepted(_ context.Context, _ []byte) (bool, error) {
+	if c.rejected {
+		return false, ErrRejected
+	}
+	return c.accept, c.queryErr
+}
+
+func (c *mockClient) LatestHeight(_ context.Context, finalized bool) (int64, error) {
+	if c.queryErr != nil {
+		return 0, c.queryErr
+	}
+	if finalized {
+		return c.finalized, nil
+	}
+	return 0, nil
+}
+
+type mockPublisher struct {
+	events []types.EventDataSettlementStatus
+}
+
+func (p *mockPublisher) PublishEventSettlementStatus(data types.EventDataSettlementStatus) error {
+	p.events = append(p.events, data)
+	return nil
+}
+
+// makeBlockStore returns a block store of height blocks, the ones up to
+// daHeight included in DA batches of two blocks.
+func makeBlockStore(t *testing.T, height, daHeight int64) *store.BlockStore {
+	bs := store.NewBlockStore(dbm.NewMemDB())
+	for h := int64(1); h <= height; h++ {
+		block := types.MakeBlock(h, []types.Tx{types.Tx("tx")}, new(types.Commit), nil)
+		block.ProposerAddress = cmtrand.Bytes(crypto.AddressSize)
+		block.AppHash = []byte{byte(h)}
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		bs.SaveBlock(block, partSet, &types.Commit{Height: h})
+	}
+	for h := int64(1); h <= daHeight; h += 2 {
+		last := h + 1
+		if last > daHeight {
+			last = daHeight
+		}
+		require.NoError(t, bs.SaveDAPointer(&types.DAPointer{
+			Layer:       "mock",
+			Height:      uint64(h),
+			Commitment:  []byte{byte(h)},
+			FirstHeight: h,
+			LastHeight:  last,
+		}))
+	}
+	return bs
+}
+
+func newTestPoster(client Client, bs BlockStore, publisher StatusPublisher) *Poster {
+	p := NewPoster(client, bs, publisher, cfg.TestSettlementConfig())
+	p.SetLogger(log.TestingLogger())
+	return p
+}
+
+func TestPoster(t *testing.T) {
+	bs := makeBlockStore(t, 6, 4)
+	client := &mockClient{}
+	publisher := &mockPublisher{}
+	p := newTestPoster(client, bs, publisher)
+
+	require.NoError(t, p.post(context.Background()))
+	require.Len(t, client.batches, 1)
+	batch := client.batches[0]
+	assert.Equal(t, int64(1), batch.FirstHeight())
+	assert.Equal(t, int64(2), batch.LastHeight())
+	assert.EqualValues(t, 1, batch.DA.Height)
+	// the state root of a block is the app hash of the next one
+	assert.Equal(t, []byte{2}, batch.Blocks[0].StateRoot)
+	assert.Equal(t, bs.LoadBlockMeta(1).Header.Time, batch.Blocks[0].Time)
+
+	status := p.Status()
+	assert.EqualValues(t, 2, status.PostedHeight)
+	assert.EqualValues(t, 0, status.AcceptedHeight)
+	assert.NotEmpty(t, status.PendingTxHash)
+
+	// nothing is posted until the pending state update is accepted
+	require.NoError(t, p.post(context.Background()))
+	assert.Len(t, client.batches, 1)
+	assert.Empty(t, publisher.events)
+
+	client.accept = true
+	client.finalized = 2
+	require.NoError(t, p.post(context.Background()))
+	require.Len(t, client.batches, 2)
+	assert.Equal(t, int64(3), client.batches[1].FirstHeight())
+	assert.Equal(t, int64(4), client.batches[1].LastHeight())
+	require.NotEmpty(t, publisher.events)
+	assert.Equal(t, types.EventDataSettlementStatus{Hub: "mock", AcceptedHeight: 2, FinalizedHeight: 2},
+		publisher.events[len(publisher.events)-1])
+
+	// blocks 5 and 6 aren't included in the DA layer yet
+	require.NoError(t, p.post(context.Background()))
+	assert.Len(t, client.batches, 2)
+	status = p.Status()
+	assert.EqualValues(t, 4, status.AcceptedHeight)
+	assert.EqualValues(t, 4, status.PostedHeight)
+	assert.Empty(t, status.PendingTxHash)
+}
+
+func TestPosterRepost(t *testing.T) {
+	bs := makeBlockStore(t, 3, 2)
+	client := &mockClient{}
+	p := newTestPoster(client, bs, &mockPublisher{})
+
+	require.NoError(t, p.post(context.Background()))
+	require.Len(t, client.batches, 1)
+
+	// posted again once the acceptance times out
+	p.postedAt = time.Now().Add(-p.acceptanceTimeout)
+	require.NoError(t, p.post(context.Background()))
+	require.Len(t, client.batches, 2)
+	assert.Equal(t, client.batches[0], client.batches[1])
+
+	// and right away if it is rejected
+	client.rejected = true
+	require.NoError(t, p.post(context.Background()))
+	assert.Len(t, client.batches, 3)
+
+	// a failing hub leaves the pending state update as is
+	client.rejected = false
+	client.queryErr = errors.New("hub unreachable")
+	assert.Error(t, p.post(context.Background()))
+	assert.Len(t, client.batches, 3)
+	assert.EqualValues(t, 2, p.Status().PostedHeight)
+}
+
+func TestPosterProposerAddress(t *testing.T) {
+	// blocks 1 and 2 are proposed by the previous sequencer
+	bs := makeBlockStore(t, 5, 4)
+	client := &mockClient{accept: true}
+	address := bs.LoadBlockMeta(3).Header.ProposerAddress
+	p := NewPoster(client, bs, &mockPublisher{}, cfg.TestSettlementConfig(), WithProposerAddress(address))
+	p.SetLogger(log.TestingLogger())
+
+	// nothing is posted until the hub accepted the previous sequencer's blocks
+	require.NoError(t, p.post(context.Background()))
+	assert.Empty(t, client.batches)
+
+	p.update(func(s *Status) { s.AcceptedHeight = 2 })
+	require.NoError(t, p.post(context.Background()))
+	require.Len(t, client.batches, 1)
+	assert.Equal(t, int64(3), client.batches[0].FirstHeight())
+	// block 4 is proposed by another sequencer
+	assert.Equal(t, int64(3), client.batches[0].LastHeight())
+}
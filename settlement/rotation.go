@@ -0,0 +1,109 @@
+package settlement
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/sequencer"
+	"github.com/tendermint/tendermint/types"
+)
+
+// EventTypeSequencerRotation is the type of the events of the hub
+// transactions scheduling a sequencer rotation, with the attributes
+// rollapp_id, height, pub_key (base64) and pub_key_type.
+const EventTypeSequencerRotation = "sequencer_rotation"
+
+// RotationSource reads the sequencer rotations of a rollapp from the
+// sequencer_rotation events of the hub transactions, through the RPC of a hub
+// node: they are searched in the past transactions and subscribed to.
+type RotationSource struct {
+	rpc       hubEventsRPC
+	rollappID string
+}
+
+var _ sequencer.Source = (*RotationSource)(nil)
+
+// NewRotationSource returns a RotationSource of the rotations of the rollapp
+// rollappID, or of the chain chainID if the rollapp ID isn't configured.
+func NewRotationSource(config *cfg.SettlementConfig, chainID string) (*RotationSource, error) {
+	rpc, err := rpchttp.NewWithTimeout(config.RPCAddress, "/websocket", uint(config.Timeout.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("creating the hub RPC client: %w", err)
+	}
+	return &RotationSource{rpc: rpc, rollappID: rollappID(config, chainID)}, nil
+}
+
+// Rotations implements sequencer.Source.
+func (s *RotationSource) Rotations(ctx context.Context) ([]*types.SequencerRotation, error) {
+	events, err := searchEvents(ctx, s.rpc, EventTypeSequencerRotation, s.rollappID)
+	if err != nil {
+		return nil, fmt.Errorf("searching the sequencer rotations: %w", err)
+	}
+	var rotations []*types.SequencerRotation
+	for _, event := range events {
+		// the events which can't be parsed are skipped
+		if rotation, err := parseRotation(event.attrs); err == nil {
+			rotations = append(rotations, rotation)
+		}
+	}
+	return rotations, nil
+}
+
+// Subscribe implements sequencer.Source.
+func (s *RotationSource) Subscribe(ctx context.Context) (<-chan *types.SequencerRotation, error) {
+	events, err := subscribeEvents(ctx, s.rpc, "sequencer", EventTypeSequencerRotation, s.rollappID)
+	if err != nil {
+		return nil, fmt.Errorf("subscribing to the sequencer rotations: %w", err)
+	}
+	out := make(chan *types.SequencerRotation)
+	go func() {
+		defer close(out)
+		for event := range events {
+			rotation, err := parseRotation(event.attrs)
+			if err != nil {
+				continue
+			}
+			select {
+			case out <- rotation:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func parseRotation(attrs map[string]string) (*types.SequencerRotation, error) {
+	height, err := strconv.ParseInt(attrs["height"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid height: %w", err)
+	}
+	bz, err := base64.StdEncoding.DecodeString(attrs["pub_key"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	var pubKey crypto.PubKey
+	switch keyType := attrs["pub_key_type"]; keyType {
+	case "", ed25519.KeyType:
+		if len(bz) != ed25519.PubKeySize {
+			return nil, fmt.Errorf("invalid ed25519 public key size %d", len(bz))
+		}
+		pubKey = ed25519.PubKey(bz)
+	case secp256k1.KeyType:
+		if len(bz) != secp256k1.PubKeySize {
+			return nil, fmt.Errorf("invalid secp256k1 public key size %d", len(bz))
+		}
+		pubKey = secp256k1.PubKey(bz)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %q", keyType)
+	}
+	rotation := &types.SequencerRotation{Height: height, PubKey: pubKey}
+	return rotation, rotation.ValidateBasic()
+}
@@ -0,0 +1,144 @@
+package settlement
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// mockHubEventsRPC is a hub node holding the transactions txs, and streaming
+// the events of events to its subscriber.
+type mockHubEventsRPC struct {
+	txs     []*ctypes.ResultTx
+	events  chan ctypes.ResultEvent
+	running bool
+}
+
+func (m *mockHubEventsRPC) Start() error    { m.running = true; return nil }
+func (m *mockHubEventsRPC) IsRunning() bool { return m.running }
+
+func (m *mockHubEventsRPC) TxSearch(_ context.Context, _ string, _ bool, page, perPage *int,
+	_ string) (*ctypes.ResultTxSearch, error) {
+	start := (*page - 1) * *perPage
+	end := start + *perPage
+	if end > len(m.txs) {
+		end = len(m.txs)
+	}
+	return &ctypes.ResultTxSearch{Txs: m.txs[start:end], TotalCount: len(m.txs)}, nil
+}
+
+func (m *mockHubEventsRPC) Subscribe(context.Context, string, string, ...int) (<-chan ctypes.ResultEvent, error) {
+	return m.events, nil
+}
+
+func (m *mockHubEventsRPC) Unsubscribe(context.Context, string, string) error { return nil }
+
+// Commit returns the hub block at height, of time hubTime(height).
+func (m *mockHubEventsRPC) Commit(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	header := &types.Header{Height: *height, Time: hubTime(*height)}
+	return &ctypes.ResultCommit{SignedHeader: types.SignedHeader{Header: header}}, nil
+}
+
+func hubTime(height int64) time.Time {
+	return time.Unix(height, 0).UTC()
+}
+
+func rotationEvent(rollappID string, height int64, pubKey crypto.PubKey) abci.Event {
+	return abci.Event{
+		Type: EventTypeSequencerRotation,
+		Attributes: []abci.EventAttribute{
+			{Key: []byte("rollapp_id"), Value: []byte(rollappID)},
+			{Key: []byte("height"), Value: []byte(strconv.FormatInt(height, 10))},
+			{Key: []byte("pub_key"), Value: []byte(base64.StdEncoding.EncodeToString(pubKey.Bytes()))},
+			{Key: []byte("pub_key_type"), Value: []byte(pubKey.Type())},
+		},
+	}
+}
+
+func TestRotationSourceRotations(t *testing.T) {
+	rpc := &mockHubEventsRPC{}
+	var pubKeys []crypto.PubKey
+	for i := 0; i < eventsPerPage+5; i++ {
+		pubKey := ed25519.GenPrivKey().PubKey()
+		pubKeys = append(pubKeys, pubKey)
+		rpc.txs = append(rpc.txs, &ctypes.ResultTx{TxResult: abci.ResponseDeliverTx{
+			Events: []abci.Event{
+				{Type: "message"},
+				rotationEvent("rollapp_1-1", int64(i+10), pubKey),
+				rotationEvent("other_2-1", int64(i+10), pubKey),
+			},
+		}})
+	}
+	s := &RotationSource{rpc: rpc, rollappID: "rollapp_1-1"}
+
+	rotations, err := s.Rotations(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rotations, len(pubKeys))
+	for i, r := range rotations {
+		assert.EqualValues(t, i+10, r.Height)
+		assert.Equal(t, pubKeys[i], r.PubKey)
+	}
+}
+
+func TestRotationSourceSubscribe(t *testing.T) {
+	rpc := &mockHubEventsRPC{events: make(chan ctypes.ResultEvent, 1)}
+	s := &RotationSource{rpc: rpc, rollappID: "rollapp_1-1"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rotations, err := s.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.True(t, rpc.IsRunning())
+
+	pubKey := secp256k1.GenPrivKey().PubKey()
+	rpc.events <- ctypes.ResultEvent{Data: types.EventDataTx{TxResult: abci.TxResult{
+		Result: abci.ResponseDeliverTx{Events: []abci.Event{rotationEvent("rollapp_1-1", 50, pubKey)}},
+	}}}
+	select {
+	case r := <-rotations:
+		assert.EqualValues(t, 50, r.Height)
+		assert.Equal(t, pubKey, r.PubKey)
+	case <-time.After(time.Second):
+		t.Fatal("no rotation received")
+	}
+
+	cancel()
+	_, ok := <-rotations
+	assert.False(t, ok)
+}
+
+func TestParseRotation(t *testing.T) {
+	pubKey := base64.StdEncoding.EncodeToString(ed25519.GenPrivKey().PubKey().Bytes())
+	testCases := []struct {
+		name  string
+		attrs map[string]string
+		ok    bool
+	}{
+		{"ed25519 by default", map[string]string{"height": "5", "pub_key": pubKey}, true},
+		{"invalid height", map[string]string{"height": "x", "pub_key": pubKey}, false},
+		{"zero height", map[string]string{"height": "0", "pub_key": pubKey}, false},
+		{"invalid key", map[string]string{"height": "5", "pub_key": "!"}, false},
+		{"wrong key size", map[string]string{"height": "5", "pub_key": pubKey, "pub_key_type": "secp256k1"}, false},
+		{"unknown key type", map[string]string{"height": "5", "pub_key": pubKey, "pub_key_type": "sr25519"}, false},
+	}
+	for _, tc := range testCases {
+		_, err := parseRotation(tc.attrs)
+		if tc.ok {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.Error(t, err, tc.name)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package settlement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"golang.org/x/crypto/ripemd160" //nolint: staticcheck // used by the Cosmos SDK addresses
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// The hub is a Cosmos SDK chain: its transactions and queries are encoded
+// with protobuf, field by field, since the node doesn't depend on the SDK.
+
+const (
+	typeURLSecp256k1PubKey    = "/cosmos.crypto.secp256k1.PubKey"
+	typeURLEthSecp256k1PubKey = "/ethermint.crypto.v1.ethsecp256k1.PubKey"
+
+	// signModeDirect signs the protobuf encoding of the transaction.
+	signModeDirect = 1
+)
+
+// signer signs the hub transactions with the key of the sequencer account.
+type signer struct {
+	keyType string
+	privKey *btcec.PrivateKey
+	address string
+}
+
+// loadSigner loads the hex encoded private key of type keyType from the file
+// at path.
+func loadSigner(path, keyType, addressPrefix string) (*signer, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the key file: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(bz)), "0x"))
+	if err != nil || len(key) != btcec.PrivKeyBytesLen {
+		return nil, errors.New("the key file must hold a hex encoded 32 bytes private key")
+	}
+	return newSigner(key, keyType, addressPrefix)
+}
+
+func newSigner(key []byte, keyType, addressPrefix string) (*signer, error) {
+	privKey, pubKey := btcec.PrivKeyFromBytes(key)
+	var addr []byte
+	switch keyType {
+	case cfg.SettlementKeyEthSecp256k1:
+		addr = keccak256(pubKey.SerializeUncompressed()[1:])[12:]
+	case cfg.SettlementKeySecp256k1:
+		sha := sha256.Sum256(pubKey.SerializeCompressed())
+		hasher := ripemd160.New()
+		hasher.Write(sha[:]) //nolint:errcheck // never errors
+		addr = hasher.Sum(nil)
+	default:
+		return nil, fmt.Errorf("unknown key type %q", keyType)
+	}
+	conv, err := bech32.ConvertBits(addr, 8, 5, true)
+	if err != nil {
+		return nil, err
+	}
+	address, err := bech32.Encode(addressPrefix, conv)
+	if err != nil {
+		return nil, err
+	}
+	return &signer{keyType: keyType, privKey: privKey, address: address}, nil
+}
+
+// pubKey returns the public key of the signer, encoded as an Any.
+func (s *signer) pubKey() []byte {
+	typeURL := typeURLSecp256k1PubKey
+	if s.keyType == cfg.SettlementKeyEthSecp256k1 {
+		typeURL = typeURLEthSecp256k1PubKey
+	}
+	return encodeAny(typeURL, appendBytes(nil, 1, s.privKey.PubKey().SerializeCompressed()))
+}
+
+// sign signs msg: with ECDSA over its SHA-256 hash, as r || s, for secp256k1
+// keys, and over its Keccak-256 hash, as r || s || v, for eth_secp256k1 keys.
+func (s *signer) sign(msg []byte) ([]byte, error) {
+	var hash []byte
+	if s.keyType == cfg.SettlementKeyEthSecp256k1 {
+		hash = keccak256(msg)
+	} else {
+		sha := sha256.Sum256(msg)
+		hash = sha[:]
+	}
+	// the compact signature is v || r || s, with v = 27 + the recovery ID
+	sig, err := ecdsa.SignCompact(s.privKey, hash, false)
+	if err != nil {
+		return nil, err
+	}
+	if s.keyType == cfg.SettlementKeyEthSecp256k1 {
+		return append(sig[1:], sig[0]-27), nil
+	}
+	return sig[1:], nil
+}
+
+func keccak256(bz []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(bz) //nolint:errcheck // never errors
+	return hasher.Sum(nil)
+}
+
+// coin is a Cosmos SDK coin, e.g. "100adym".
+type coin struct {
+	denom  string
+	amount string
+}
+
+var coinRegexp = regexp.MustCompile(`^([0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]{2,127})$`)
+
+// parseFees parses the fees of the transactions, empty or a single coin.
+func parseFees(fees string) ([]coin, error) {
+	if fees == "" {
+		return nil, nil
+	}
+	m := coinRegexp.FindStringSubmatch(fees)
+	if m == nil {
+		return nil, fmt.Errorf("invalid fees %q, expected an amount followed by a denom", fees)
+	}
+	return []coin{{denom: m[2], amount: m[1]}}, nil
+}
+
+// txParams are the parameters of a hub transaction, besides its message.
+type txParams struct {
+	chainID       string
+	accountNumber uint64
+	sequence      uint64
+	gasLimit      uint64
+	fees          []coin
+}
+
+// buildTx returns the signed transaction of msg, a message encoded as an Any,
+// in the TxRaw encoding broadcast to the hub.
+func buildTx(s *signer, msg []byte, params txParams) ([]byte, error) {
+	body := appendBytes(nil, 1, msg) // TxBody.messages
+
+	var fee []byte
+	for _, c := range params.fees {
+		coin := appendString(nil, 1, c.denom)
+		coin = appendString(coin, 2, c.amount)
+		fee = appendBytes(fee, 1, coin) // Fee.amount
+	}
+	fee = appendVarint(fee, 2, params.gasLimit) // Fee.gas_limit
+
+	single := appendVarint(nil, 1, signModeDirect) // ModeInfo.Single.mode
+	modeInfo := appendBytes(nil, 1, single)        // ModeInfo.single
+	signerInfo := appendBytes(nil, 1, s.pubKey())  // SignerInfo.public_key
+	signerInfo = appendBytes(signerInfo, 2, modeInfo)
+	signerInfo = appendVarint(signerInfo, 3, params.sequence)
+	authInfo := appendBytes(nil, 1, signerInfo) // AuthInfo.signer_infos
+	authInfo = appendBytes(authInfo, 2, fee)
+
+	signDoc := appendBytes(nil, 1, body) // SignDoc.body_bytes
+	signDoc = appendBytes(signDoc, 2, authInfo)
+	signDoc = appendString(signDoc, 3, params.chainID)
+	signDoc = appendVarint(signDoc, 4, params.accountNumber)
+	sig, err := s.sign(signDoc)
+	if err != nil {
+		return nil, fmt.Errorf("signing the transaction: %w", err)
+	}
+
+	tx := appendBytes(nil, 1, body) // TxRaw.body_bytes
+	tx = appendBytes(tx, 2, authInfo)
+	return appendBytes(tx, 3, sig), nil
+}
+
+// encodeAny returns the encoding of an Any holding value of type typeURL.
+func encodeAny(typeURL string, value []byte) []byte {
+	return appendBytes(appendString(nil, 1, typeURL), 2, value)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	return appendBytes(b, num, []byte(v))
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// fields are the fields of a decoded message, with the last value of each.
+type fields struct {
+	bytes   map[protowire.Number][]byte
+	varints map[protowire.Number]uint64
+}
+
+func decodeFields(b []byte) (fields, error) {
+	f := fields{
+		bytes:   make(map[protowire.Number][]byte),
+		varints: make(map[protowire.Number]uint64),
+	}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return f, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			f.varints[num] = v
+		case protowire.BytesType:
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			f.bytes[num] = v
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return f, protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return f, nil
+}
@@ -0,0 +1,137 @@
+package settlement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// testKey is the private key 1, whose addresses are well known.
+var testKey = append(make([]byte, 31), 1)
+
+func TestSignerAddress(t *testing.T) {
+	testCases := []struct {
+		keyType string
+		address string
+	}{
+		{cfg.SettlementKeyEthSecp256k1, "7e5f4552091a69125d5dfcb7b8c2659029395bdf"},
+		{cfg.SettlementKeySecp256k1, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.keyType, func(t *testing.T) {
+			s, err := newSigner(testKey, tc.keyType, "dym")
+			require.NoError(t, err)
+
+			prefix, data, err := bech32.Decode(s.address)
+			require.NoError(t, err)
+			assert.Equal(t, "dym", prefix)
+			addr, err := bech32.ConvertBits(data, 5, 8, false)
+			require.NoError(t, err)
+			assert.Equal(t, tc.address, hex.EncodeToString(addr))
+		})
+	}
+
+	_, err := newSigner(testKey, "ed25519", "dym")
+	assert.Error(t, err)
+}
+
+func TestLoadSigner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.hex")
+	require.NoError(t, os.WriteFile(path, []byte("0x"+hex.EncodeToString(testKey)+"\n"), 0o600))
+	s, err := loadSigner(path, cfg.SettlementKeySecp256k1, "dym")
+	require.NoError(t, err)
+	assert.Equal(t, testKey, s.privKey.Serialize())
+
+	require.NoError(t, os.WriteFile(path, []byte("0102"), 0o600))
+	_, err = loadSigner(path, cfg.SettlementKeySecp256k1, "dym")
+	assert.Error(t, err)
+
+	_, err = loadSigner(filepath.Join(t.TempDir(), "missing"), cfg.SettlementKeySecp256k1, "dym")
+	assert.Error(t, err)
+}
+
+func TestParseFees(t *testing.T) {
+	fees, err := parseFees("")
+	require.NoError(t, err)
+	assert.Empty(t, fees)
+
+	fees, err = parseFees("4000000000000000adym")
+	require.NoError(t, err)
+	assert.Equal(t, []coin{{denom: "adym", amount: "4000000000000000"}}, fees)
+
+	for _, fees := range []string{"adym", "100", "-1adym", "1.5adym", "100adym,1udym"} {
+		_, err := parseFees(fees)
+		assert.Error(t, err, fees)
+	}
+}
+
+func TestBuildTx(t *testing.T) {
+	for _, keyType := range []string{cfg.SettlementKeyEthSecp256k1, cfg.SettlementKeySecp256k1} {
+		t.Run(keyType, func(t *testing.T) {
+			s, err := newSigner(testKey, keyType, "dym")
+			require.NoError(t, err)
+			msg := encodeAny("/test.Msg", []byte("msg"))
+			params := txParams{
+				chainID:       "dymension_1100-1",
+				accountNumber: 7,
+				sequence:      3,
+				gasLimit:      400000,
+				fees:          []coin{{denom: "adym", amount: "100"}},
+			}
+			tx, err := buildTx(s, msg, params)
+			require.NoError(t, err)
+
+			raw, err := decodeFields(tx)
+			require.NoError(t, err)
+			body, err := decodeFields(raw.bytes[1])
+			require.NoError(t, err)
+			assert.Equal(t, msg, body.bytes[1])
+
+			authInfo, err := decodeFields(raw.bytes[2])
+			require.NoError(t, err)
+			signerInfo, err := decodeFields(authInfo.bytes[1])
+			require.NoError(t, err)
+			assert.Equal(t, s.pubKey(), signerInfo.bytes[1])
+			assert.EqualValues(t, 3, signerInfo.varints[3])
+			fee, err := decodeFields(authInfo.bytes[2])
+			require.NoError(t, err)
+			assert.EqualValues(t, 400000, fee.varints[2])
+
+			// the signature is over the sign doc of the transaction
+			signDoc := appendBytes(nil, 1, raw.bytes[1])
+			signDoc = appendBytes(signDoc, 2, raw.bytes[2])
+			signDoc = appendString(signDoc, 3, params.chainID)
+			signDoc = appendVarint(signDoc, 4, params.accountNumber)
+			var hash []byte
+			sig := raw.bytes[3]
+			if keyType == cfg.SettlementKeyEthSecp256k1 {
+				hash = keccak256(signDoc)
+				require.Len(t, sig, 65)
+				sig = append([]byte{sig[64] + 27}, sig[:64]...)
+			} else {
+				sha := sha256.Sum256(signDoc)
+				hash = sha[:]
+				require.Len(t, sig, 64)
+				// the recovery ID isn't part of the signature, try both
+				if pk, _, err := ecdsa.RecoverCompact(append([]byte{27}, sig...), hash); err != nil ||
+					!pk.IsEqual(s.privKey.PubKey()) {
+					sig = append([]byte{28}, sig...)
+				} else {
+					sig = append([]byte{27}, sig...)
+				}
+			}
+			pubKey, _, err := ecdsa.RecoverCompact(sig, hash)
+			require.NoError(t, err)
+			assert.True(t, pubKey.IsEqual(s.privKey.PubKey()))
+		})
+	}
+}
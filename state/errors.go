@@ -51,6 +51,10 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	ErrNoProposerForHeight struct {
+		Height int64
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -103,4 +107,8 @@ func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
 
+func (e ErrNoProposerForHeight) Error() string {
+	return fmt.Sprintf("could not find proposer for height #%d", e.Height)
+}
+
 var ErrABCIResponsesNotPersisted = errors.New("node is not persisting abci responses")
@@ -1,14 +1,20 @@
 package state
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/fail"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/tracing"
 	mempl "github.com/tendermint/tendermint/mempool"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -16,6 +22,8 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+var tracer = tracing.Tracer("state")
+
 //-----------------------------------------------------------------------------
 // BlockExecutor handles block execution and state updates.
 // It exposes ApplyBlock(), which validates & executes the block, updates state w/ ABCI responses,
@@ -40,6 +48,60 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// rotations of the sequencer scheduled by the settlement hub, if any
+	sequencerRotations SequencerRotations
+
+	// transactions force-included by the settlement hub, if any
+	forcedTxs ForcedTxs
+
+	// statistics of the blocks to save their gas to, if any
+	blockGas BlockGas
+
+	// store of the blocks to save their results to, if any
+	blockResults BlockResults
+}
+
+// SequencerRotations holds the rotations of the sequencer of the chain,
+// scheduled by its settlement hub.
+type SequencerRotations interface {
+	// LoadSequencerRotation returns the rotation at height, or nil if there is
+	// none.
+	LoadSequencerRotation(height int64) *types.SequencerRotation
+}
+
+// ForcedTxs holds the transactions force-included by the settlement hub,
+// which the blocks must include by their deadline.
+type ForcedTxs interface {
+	// Pending returns the forced transactions not included yet, by deadline.
+	Pending() []types.ForcedTx
+
+	// Update marks the forced transactions among txs, those of the block at
+	// height, as included.
+	Update(height int64, txs types.Txs) error
+}
+
+// BlockGas saves the gas of the transactions of the blocks, once executed.
+type BlockGas interface {
+	SaveBlockGas(height, gasWanted, gasUsed int64) error
+}
+
+// BlockResults saves the ABCI responses of the blocks, once executed, along
+// with the blocks.
+type BlockResults interface {
+	SaveBlockResults(height int64, results *cmtstate.ABCIResponses) error
+}
+
+// checkTxContextSetter is implemented by the mempools passing the context of
+// the next block to the app along with the txs to check.
+type checkTxContextSetter interface {
+	SetCheckTxContext(c mempl.CheckTxContext)
+}
+
+// priorityRefresher is implemented by the mempools which can refresh the
+// priorities of their txs on the request of the app.
+type priorityRefresher interface {
+	RefreshPriorities()
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -50,6 +112,41 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithSequencerRotations makes the BlockExecutor hand the chain
+// over to the next sequencer at each of the rotations: the validator set of
+// the rotation height becomes the next sequencer alone, with the total voting
+// power of the previous set.
+func BlockExecutorWithSequencerRotations(rotations SequencerRotations) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.sequencerRotations = rotations
+	}
+}
+
+// BlockExecutorWithForcedTxs makes the BlockExecutor propose the pending
+// forced transactions first, and keep track of their inclusion.
+func BlockExecutorWithForcedTxs(forcedTxs ForcedTxs) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.forcedTxs = forcedTxs
+	}
+}
+
+// BlockExecutorWithBlockGas makes the BlockExecutor save the gas wanted and
+// used by the transactions of the blocks it executes to blockGas.
+func BlockExecutorWithBlockGas(blockGas BlockGas) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.blockGas = blockGas
+	}
+}
+
+// BlockExecutorWithBlockResults makes the BlockExecutor save the ABCI
+// responses of the blocks it executes to blockResults too, so that they're
+// kept even if the state store discards them.
+func BlockExecutorWithBlockResults(blockResults BlockResults) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.blockResults = blockResults
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -90,7 +187,8 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
-// The rest is given to txs, up to the max gas.
+// The rest is given to txs, up to the max gas, starting with the pending forced
+// txs, if any: those due at height first, then the others, by deadline.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
@@ -103,11 +201,91 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
 	// Fetch a limited amount of valid txs
-	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
+	maxDataBytes := types.MaxDataBytesForKeyTypes(maxBytes, evSize, state.Validators.Size(),
+		state.ConsensusParams.Validator.PubKeyTypes)
+
+	// The forced txs due depend on the time of the block.
+	timestamp := state.blockTime(height, commit)
+
+	var forcedTxs types.Txs
+	if blockExec.forcedTxs != nil {
+		forcedTxs = blockExec.proposedForcedTxs(state, height, timestamp, maxDataBytes)
+		maxDataBytes -= types.ComputeProtoSizeForTxs(forcedTxs)
+	}
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	if len(forcedTxs) > 0 {
+		for _, tx := range txs {
+			if forcedTxs.Index(tx) < 0 {
+				forcedTxs = append(forcedTxs, tx)
+			}
+		}
+		txs = forcedTxs
+	}
+
+	return state.makeBlock(height, txs, commit, evidence, proposerAddr, timestamp)
+}
+
+// proposedForcedTxs returns the pending forced txs proposed in the block at
+// height, of time blockTime: the due ones, then the others which fit in
+// maxDataBytes, by deadline.
+func (blockExec *BlockExecutor) proposedForcedTxs(
+	state State,
+	height int64,
+	blockTime time.Time,
+	maxDataBytes int64,
+) types.Txs {
+	var txs types.Txs
+	for _, ftx := range blockExec.dueForcedTxs(state, height, blockTime) {
+		txs = append(txs, ftx.Tx)
+	}
+	for _, ftx := range blockExec.forcedTxs.Pending() {
+		if txs.Index(ftx.Tx) >= 0 {
+			continue
+		}
+		if types.ComputeProtoSizeForTxs(append(txs, ftx.Tx)) > maxDataBytes {
+			break
+		}
+		txs = append(txs, ftx.Tx)
+	}
+	return txs
+}
 
-	return state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+// dueForcedTxs returns the pending forced txs which the block at height, of
+// time blockTime, must include: those due, by deadline, as long as they fit in
+// the data of a block with the most evidence. The next ones are deferred to
+// the next blocks, and those which can't fit in any block are skipped, so that a block always fits the txs it must include, and
+// the proposer and the validators agree on them whatever evidence they know.
+func (blockExec *BlockExecutor) dueForcedTxs(state State, height int64, blockTime time.Time) []types.ForcedTx {
+	maxBytes := state.ConsensusParams.Block.MaxBytes -
+		types.MaxOverheadForBlock -
+		types.MaxHeaderBytes -
+		types.MaxCommitBytesForKeyTypes(state.Validators.Size(), state.ConsensusParams.Validator.PubKeyTypes) -
+		state.ConsensusParams.Evidence.MaxBytes
+
+	var (
+		due  []types.ForcedTx
+		size int64
+	)
+	for _, ftx := range blockExec.forcedTxs.Pending() {
+		if ftx.Deadline > height {
+			break
+		}
+		if !ftx.IsDue(height, blockTime) {
+			continue
+		}
+		txSize := types.ComputeProtoSizeForTxs(types.Txs{ftx.Tx})
+		if txSize > maxBytes {
+			// it can't be required, but mustn't hold the next ones back
+			continue
+		}
+		if size+txSize > maxBytes {
+			break
+		}
+		size += txSize
+		due = append(due, ftx)
+	}
+	return due
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -122,6 +300,26 @@ func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) e
 	return blockExec.evpool.CheckEvidence(block.Evidence.Evidence)
 }
 
+// ValidateForcedTxs returns an error if the given block, proposed on top of
+// state, skips a forced tx due: past its deadline, announced by the hub at the
+// time of the block at the latest, and among the first ones which fit in the
+// block (see dueForcedTxs). The rule only depends on the block and the hub
+// history, so the validators synced with the hub agree on it, while those
+// behind only miss the latest forced txs. Still, unlike ValidateBlock, it
+// depends on the forced txs known to this node, so a proposal failing it is
+// only refused, while a committed block is still applied.
+func (blockExec *BlockExecutor) ValidateForcedTxs(state State, block *types.Block) error {
+	if blockExec.forcedTxs == nil {
+		return nil
+	}
+	for _, ftx := range blockExec.dueForcedTxs(state, block.Height, block.Time) {
+		if block.Txs.Index(ftx.Tx) < 0 {
+			return fmt.Errorf("forced tx %X with deadline %d is not included", ftx.Tx.Hash(), ftx.Deadline)
+		}
+	}
+	return nil
+}
+
 // ApplyBlock validates the block against the state, executes it against the app,
 // fires the relevant events, commits the app, and saves the new state and responses.
 // It returns the new state and the block height to retain (pruning older blocks).
@@ -131,6 +329,20 @@ func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) e
 func (blockExec *BlockExecutor) ApplyBlock(
 	state State, blockID types.BlockID, block *types.Block,
 ) (State, int64, error) {
+	return blockExec.ApplyBlockWithContext(context.Background(), state, blockID, block)
+}
+
+// ApplyBlockWithContext is ApplyBlock in a span of the trace of ctx, whose
+// children are the spans of the ABCI calls.
+func (blockExec *BlockExecutor) ApplyBlockWithContext(
+	ctx context.Context, state State, blockID types.BlockID, block *types.Block,
+) (_ State, _ int64, err error) {
+	ctx, span := tracer.Start(ctx, "state.apply_block", trace.WithAttributes(
+		attribute.Int64("height", block.Height),
+		attribute.Int("num_txs", len(block.Txs)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+	logger := tracing.Logger(ctx, blockExec.logger)
 
 	if err := validateBlock(state, block); err != nil {
 		return state, 0, ErrInvalidBlock(err)
@@ -138,7 +350,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		ctx, logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -148,10 +360,29 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	fail.Fail() // XXX
 
-	// Save the results before we commit.
+	// Save the results before we commit. The proposer is written first, to be
+	// synced along with the responses.
+	if err := blockExec.store.SaveProposer(block.Height, block.ProposerAddress); err != nil {
+		return state, 0, err
+	}
 	if err := blockExec.store.SaveABCIResponses(block.Height, abciResponses); err != nil {
 		return state, 0, err
 	}
+	if blockExec.blockResults != nil {
+		if err := blockExec.blockResults.SaveBlockResults(block.Height, abciResponses); err != nil {
+			return state, 0, err
+		}
+	}
+	if blockExec.blockGas != nil {
+		var gasWanted, gasUsed int64
+		for _, res := range abciResponses.DeliverTxs {
+			gasWanted += res.GasWanted
+			gasUsed += res.GasUsed
+		}
+		if err := blockExec.blockGas.SaveBlockGas(block.Height, gasWanted, gasUsed); err != nil {
+			logger.Error("failed to save the gas of the block", "height", block.Height, "err", err)
+		}
+	}
 
 	fail.Fail() // XXX
 
@@ -161,23 +392,55 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	if err != nil {
 		return state, 0, fmt.Errorf("error in validator updates: %v", err)
 	}
+	if err := ValidateProofsOfPossession(abciValUpdates, state.NextValidators); err != nil {
+		return state, 0, fmt.Errorf("error in validator updates: %v", err)
+	}
 
 	validatorUpdates, err := types.PB2TM.ValidatorUpdates(abciValUpdates)
 	if err != nil {
 		return state, 0, err
 	}
 	if len(validatorUpdates) > 0 {
-		blockExec.logger.Debug("updates to validators", "updates", types.ValidatorListString(validatorUpdates))
+		logger.Debug("updates to validators", "updates", types.ValidatorListString(validatorUpdates))
+	}
+
+	// at a sequencer rotation, the updates of the application are replaced
+	responses := abciResponses
+	if rotation := blockExec.sequencerRotation(state, block.Height); rotation != nil {
+		if len(validatorUpdates) > 0 || len(abciResponses.EndBlock.KeyRotations) > 0 {
+			logger.Error("ignoring the validator updates and key rotations of the application at the sequencer rotation",
+				"rotation", rotation)
+		}
+		validatorUpdates = sequencerRotationUpdates(state, rotation)
+		endBlock := *abciResponses.EndBlock
+		endBlock.KeyRotations = nil
+		responses = &cmtstate.ABCIResponses{
+			DeliverTxs: abciResponses.DeliverTxs,
+			EndBlock:   &endBlock,
+			BeginBlock: abciResponses.BeginBlock,
+		}
+		logger.Info("rotating the sequencer", "rotation", rotation)
+	}
+
+	// validate the key rotations
+	keyRotations, err := validateKeyRotations(state, block.Height, responses.EndBlock.KeyRotations, validatorUpdates)
+	if err != nil {
+		return state, 0, fmt.Errorf("error in key rotations: %v", err)
+	}
+	for _, kr := range keyRotations {
+		logger.Info("rotating validator key", "rotation", kr)
 	}
 
 	// Update the state with the block and responses.
-	state, err = updateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
+	state, err = updateState(state, blockID, &block.Header, responses, validatorUpdates)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
 
 	// Lock mempool, commit app state, update mempoool.
-	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
+	refreshPriorities := mempl.RefreshPrioritiesRequested(abciResponses.EndBlock)
+	appHash, retainHeight, err := blockExec.commit(ctx, logger, state, block, abciResponses.DeliverTxs,
+		refreshPriorities)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
@@ -185,6 +448,14 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	// Update evpool with the latest state.
 	blockExec.evpool.Update(state, block.Evidence.Evidence)
 
+	// Mark the forced txs of the block as included, before the state is saved
+	// so that a block replayed marks them again.
+	if blockExec.forcedTxs != nil {
+		if err := blockExec.forcedTxs.Update(block.Height, block.Txs); err != nil {
+			return state, 0, fmt.Errorf("error updating the forced txs: %v", err)
+		}
+	}
+
 	fail.Fail() // XXX
 
 	// Update the app hash and save the state.
@@ -197,7 +468,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates)
+	fireEvents(logger, blockExec.eventBus, block, abciResponses, validatorUpdates)
 
 	return state, retainHeight, nil
 }
@@ -212,6 +483,17 @@ func (blockExec *BlockExecutor) Commit(
 	state State,
 	block *types.Block,
 	deliverTxResponses []*abci.ResponseDeliverTx,
+) ([]byte, int64, error) {
+	return blockExec.commit(context.Background(), blockExec.logger, state, block, deliverTxResponses, false)
+}
+
+func (blockExec *BlockExecutor) commit(
+	ctx context.Context,
+	logger log.Logger,
+	state State,
+	block *types.Block,
+	deliverTxResponses []*abci.ResponseDeliverTx,
+	refreshPriorities bool,
 ) ([]byte, int64, error) {
 	blockExec.mempool.Lock()
 	defer blockExec.mempool.Unlock()
@@ -220,26 +502,36 @@ func (blockExec *BlockExecutor) Commit(
 	// in the ABCI app before Commit.
 	err := blockExec.mempool.FlushAppConn()
 	if err != nil {
-		blockExec.logger.Error("client error during mempool.FlushAppConn", "err", err)
+		logger.Error("client error during mempool.FlushAppConn", "err", err)
 		return nil, 0, err
 	}
 
 	// Commit block, get hash back
+	_, span := tracer.Start(ctx, "abci.commit")
 	res, err := blockExec.proxyApp.CommitSync()
+	tracing.EndSpan(span, err)
 	if err != nil {
-		blockExec.logger.Error("client error during proxyAppConn.CommitSync", "err", err)
+		logger.Error("client error during proxyAppConn.CommitSync", "err", err)
 		return nil, 0, err
 	}
 
 	// ResponseCommit has no error code - just data
-	blockExec.logger.Info(
+	logger.Info(
 		"committed state",
 		"height", block.Height,
 		"num_txs", len(block.Txs),
 		"app_hash", fmt.Sprintf("%X", res.Data),
 	)
 
-	// Update mempool.
+	// Update mempool, rechecking its txs in the context of the next block.
+	if setter, ok := blockExec.mempool.(checkTxContextSetter); ok {
+		setter.SetCheckTxContext(TxCheckContext(state))
+	}
+	if refresher, ok := blockExec.mempool.(priorityRefresher); ok && refreshPriorities {
+		logger.Info("refreshing the priorities of the mempool txs on the request of the app")
+		refresher.RefreshPriorities()
+	}
+	_, span = tracer.Start(ctx, "mempool.update")
 	err = blockExec.mempool.Update(
 		block.Height,
 		block.Txs,
@@ -247,6 +539,7 @@ func (blockExec *BlockExecutor) Commit(
 		TxPreCheck(state),
 		TxPostCheck(state),
 	)
+	tracing.EndSpan(span, err)
 
 	return res.Data, res.RetainHeight, err
 }
@@ -257,6 +550,7 @@ func (blockExec *BlockExecutor) Commit(
 // Executes block's transactions on proxyAppConn.
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
+	ctx context.Context,
 	logger log.Logger,
 	proxyAppConn proxy.AppConnConsensus,
 	block *types.Block,
@@ -270,6 +564,9 @@ func execBlockOnProxyApp(
 	dtxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
 	abciResponses.DeliverTxs = dtxs
 
+	// the span of the txs ends with the response of the last one
+	deliverTxsSpan := trace.SpanFromContext(context.Background())
+
 	// Execute transactions and get hash.
 	proxyCb := func(req *abci.Request, res *abci.Response) {
 		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
@@ -286,6 +583,9 @@ func execBlockOnProxyApp(
 
 			abciResponses.DeliverTxs[txIndex] = txRes
 			txIndex++
+			if txIndex == len(block.Txs) {
+				deliverTxsSpan.End()
+			}
 		}
 	}
 	proxyAppConn.SetResponseCallback(proxyCb)
@@ -304,18 +604,25 @@ func execBlockOnProxyApp(
 		return nil, errors.New("nil header")
 	}
 
+	_, span := tracer.Start(ctx, "abci.begin_block")
 	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(abci.RequestBeginBlock{
 		Hash:                block.Hash(),
 		Header:              *pbh,
 		LastCommitInfo:      commitInfo,
 		ByzantineValidators: byzVals,
 	})
+	tracing.EndSpan(span, err)
 	if err != nil {
 		logger.Error("error in proxyAppConn.BeginBlock", "err", err)
 		return nil, err
 	}
 
 	// run txs of block
+	if len(block.Txs) > 0 {
+		_, deliverTxsSpan = tracer.Start(ctx, "abci.deliver_txs",
+			trace.WithAttributes(attribute.Int("num_txs", len(block.Txs))))
+		defer deliverTxsSpan.End()
+	}
 	for _, tx := range block.Txs {
 		proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx})
 		if err := proxyAppConn.Error(); err != nil {
@@ -324,7 +631,9 @@ func execBlockOnProxyApp(
 	}
 
 	// End block.
+	_, span = tracer.Start(ctx, "abci.end_block")
 	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(abci.RequestEndBlock{Height: block.Height})
+	tracing.EndSpan(span, err)
 	if err != nil {
 		logger.Error("error in proxyAppConn.EndBlock", "err", err)
 		return nil, err
@@ -399,6 +708,115 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	return nil
 }
 
+// ValidateProofsOfPossession checks the proofs of possession of the keys of
+// the validator updates entering vals, the validator set they update.
+func ValidateProofsOfPossession(abciUpdates []abci.ValidatorUpdate, vals *types.ValidatorSet) error {
+	for _, valUpdate := range abciUpdates {
+		if valUpdate.GetPower() <= 0 {
+			continue
+		}
+		pk, err := cryptoenc.PubKeyFromProto(valUpdate.PubKey)
+		if err != nil {
+			return err
+		}
+		if vals != nil && vals.HasAddress(pk.Address()) {
+			continue
+		}
+		if err := types.VerifyProofOfPossession(pk, valUpdate.ProofOfPossession); err != nil {
+			return fmt.Errorf("validator %v: %w", valUpdate, err)
+		}
+	}
+	return nil
+}
+
+// validateKeyRotations checks the key rotations returned by the application
+// for the block at the given height, and converts them to CometBFT types. A
+// rotation must be signed by the old key of a validator of the next validator
+// set, and is scheduled KeyRotationDelay blocks later, as validator updates.
+func validateKeyRotations(
+	state State,
+	height int64,
+	abciRotations []abci.KeyRotation,
+	validatorUpdates []*types.Validator,
+) ([]*types.KeyRotation, error) {
+	if len(abciRotations) == 0 {
+		return nil, nil
+	}
+	updated := make(map[string]bool, len(validatorUpdates))
+	for _, val := range validatorUpdates {
+		updated[string(val.Address)] = true
+	}
+
+	rotations := make([]*types.KeyRotation, 0, len(abciRotations))
+	rotated := make(map[string]bool, len(abciRotations))
+	for _, abciRotation := range abciRotations {
+		kr, err := types.KeyRotationFromProto(abciRotation)
+		if err != nil {
+			return nil, err
+		}
+		if kr.Height != height+types.KeyRotationDelay {
+			return nil, fmt.Errorf("key rotation %v must be scheduled at height %d",
+				kr, height+types.KeyRotationDelay)
+		}
+		oldAddr, newAddr := kr.OldPubKey.Address(), kr.NewPubKey.Address()
+		if !state.NextValidators.HasAddress(oldAddr) {
+			return nil, fmt.Errorf("key rotation %v of unknown validator", kr)
+		}
+		if state.NextValidators.HasAddress(newAddr) || rotated[string(newAddr)] {
+			return nil, fmt.Errorf("key rotation %v to the key of a validator", kr)
+		}
+		if updated[string(oldAddr)] || updated[string(newAddr)] || rotated[string(oldAddr)] {
+			return nil, fmt.Errorf("key rotation %v of a validator updated at the same height", kr)
+		}
+		if !types.IsValidPubkeyType(state.ConsensusParams.Validator, kr.NewPubKey.Type()) {
+			return nil, fmt.Errorf("key rotation %v is using pubkey %s, which is unsupported for consensus",
+				kr, kr.NewPubKey.Type())
+		}
+		if err := kr.Verify(state.ChainID); err != nil {
+			return nil, fmt.Errorf("key rotation %v: %w", kr, err)
+		}
+		rotated[string(oldAddr)] = true
+		rotated[string(newAddr)] = true
+		rotations = append(rotations, kr)
+	}
+	return rotations, nil
+}
+
+// sequencerRotation returns the sequencer rotation which the validator updates
+// of the block at height must schedule, if any. Like the updates of the
+// application, they change the validator set of the block at height+2. A
+// rotation to a key type unsupported for consensus is skipped.
+func (blockExec *BlockExecutor) sequencerRotation(state State, height int64) *types.SequencerRotation {
+	if blockExec.sequencerRotations == nil {
+		return nil
+	}
+	rotation := blockExec.sequencerRotations.LoadSequencerRotation(height + 2)
+	if rotation == nil {
+		return nil
+	}
+	if !types.IsValidPubkeyType(state.ConsensusParams.Validator, rotation.PubKey.Type()) {
+		blockExec.logger.Error("skipping the sequencer rotation to a key unsupported for consensus",
+			"rotation", rotation, "type", rotation.PubKey.Type())
+		return nil
+	}
+	return rotation
+}
+
+// sequencerRotationUpdates returns the validator updates replacing the next
+// validator set with the sequencer of rotation, with the same total power.
+func sequencerRotationUpdates(state State, rotation *types.SequencerRotation) []*types.Validator {
+	address := rotation.PubKey.Address()
+	updates := []*types.Validator{
+		types.NewValidator(rotation.PubKey, state.NextValidators.TotalVotingPower()),
+	}
+	for _, val := range state.NextValidators.Validators {
+		if !bytes.Equal(val.Address, address) {
+			updates = append(updates, types.NewValidator(val.PubKey, 0))
+		}
+	}
+	return updates
+}
+
 // updateState returns a new State updated according to the header and responses.
 func updateState(
 	state State,
@@ -423,6 +841,18 @@ func updateState(
 		lastHeightValsChanged = header.Height + 1 + 1
 	}
 
+	// Apply the key rotations, which were validated along with the updates.
+	for _, abciRotation := range abciResponses.EndBlock.KeyRotations {
+		kr, err := types.KeyRotationFromProto(abciRotation)
+		if err != nil {
+			return state, fmt.Errorf("error rotating validator key: %v", err)
+		}
+		if err := nValSet.RotateKey(kr.OldPubKey.Address(), kr.NewPubKey, kr.ProofOfPossession); err != nil {
+			return state, fmt.Errorf("error rotating validator key: %v", err)
+		}
+		lastHeightValsChanged = header.Height + 1 + 1
+	}
+
 	// Update validator proposer priority and set state variables.
 	nValSet.IncrementProposerPriority(1)
 
@@ -534,7 +964,7 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	_, err := execBlockOnProxyApp(context.Background(), logger, appConnConsensus, block, store, initialHeight)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -11,11 +11,14 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
 	mmock "github.com/tendermint/tendermint/mempool/mock"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	cmtversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/proxy"
@@ -59,6 +62,66 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// contextMempool records the context of the checks of the txs set on commit,
+// and the refreshes of the priorities requested.
+type contextMempool struct {
+	mmock.Mempool
+	checkTxCtx mempl.CheckTxContext
+	refreshes  int
+}
+
+func (mp *contextMempool) SetCheckTxContext(c mempl.CheckTxContext) { mp.checkTxCtx = c }
+func (mp *contextMempool) RefreshPriorities()                       { mp.refreshes++ }
+
+func TestApplyBlockSetsCheckTxContext(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(2, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	mp := &contextMempool{}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mp, sm.EmptyEvidencePool{})
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	state, _, err := blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	// the txs left are rechecked in the context of the next block
+	assert.EqualValues(t, 2, mp.checkTxCtx.Height)
+	assert.Equal(t, block.Time, mp.checkTxCtx.Time)
+	assert.EqualValues(t, state.Validators.GetProposer().Address, mp.checkTxCtx.ProposerAddress)
+	assert.Equal(t, sm.TxCheckContext(state), mp.checkTxCtx)
+	assert.Zero(t, mp.refreshes)
+}
+
+func TestApplyBlockRefreshesPriorities(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(2, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	mp := &contextMempool{}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mp, sm.EmptyEvidencePool{})
+
+	// the app requests a refresh of the priorities in the end of the block
+	app.EndBlockEvents = []abci.Event{{
+		Type:       mempl.EventType,
+		Attributes: []abci.EventAttribute{{Key: []byte(mempl.RefreshPrioritiesAttributeKey), Value: []byte("true")}},
+	}}
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, _, err := blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mp.refreshes)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -277,6 +340,72 @@ func TestValidateValidatorUpdates(t *testing.T) {
 	}
 }
 
+func TestValidateProofsOfPossession(t *testing.T) {
+	privKey1 := bls12381.GenPrivKey()
+	privKey2 := bls12381.GenPrivKey()
+	pk1, err := cryptoenc.PubKeyToProto(privKey1.PubKey())
+	require.NoError(t, err)
+	pk2, err := cryptoenc.PubKeyToProto(privKey2.PubKey())
+	require.NoError(t, err)
+	pk3, err := cryptoenc.PubKeyToProto(ed25519.GenPrivKey().PubKey())
+	require.NoError(t, err)
+	proof1, err := types.ProofOfPossession(privKey1)
+	require.NoError(t, err)
+	proof2, err := types.ProofOfPossession(privKey2)
+	require.NoError(t, err)
+
+	vals := types.NewValidatorSet([]*types.Validator{types.NewValidator(privKey1.PubKey(), 10)})
+
+	testCases := []struct {
+		name        string
+		abciUpdates []abci.ValidatorUpdate
+		shouldErr   bool
+	}{
+		{
+			"adding a BLS validator with its proof is OK",
+			[]abci.ValidatorUpdate{{PubKey: pk2, Power: 20, ProofOfPossession: proof2}},
+			false,
+		},
+		{
+			"adding a BLS validator without a proof results in error",
+			[]abci.ValidatorUpdate{{PubKey: pk2, Power: 20}},
+			true,
+		},
+		{
+			"adding a BLS validator with the proof of another key results in error",
+			[]abci.ValidatorUpdate{{PubKey: pk2, Power: 20, ProofOfPossession: proof1}},
+			true,
+		},
+		{
+			"updating the power of a BLS validator needs no proof",
+			[]abci.ValidatorUpdate{{PubKey: pk1, Power: 20}},
+			false,
+		},
+		{
+			"removing a BLS validator needs no proof",
+			[]abci.ValidatorUpdate{{PubKey: pk2, Power: 0}},
+			false,
+		},
+		{
+			"adding an ed25519 validator needs no proof",
+			[]abci.ValidatorUpdate{{PubKey: pk3, Power: 20}},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := sm.ValidateProofsOfPossession(tc.abciUpdates, vals)
+			if tc.shouldErr {
+				assert.ErrorIs(t, err, types.ErrInvalidProofOfPossession)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestUpdateValidators(t *testing.T) {
 	pubkey1 := ed25519.GenPrivKey().PubKey()
 	val1 := types.NewValidator(pubkey1, 10)
@@ -459,6 +588,316 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotEmpty(t, state.NextValidators.Validators)
 }
 
+func TestEndBlockKeyRotations(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(2, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	oldPrivKey := ed25519.GenPrivKeyFromSecret([]byte("test0"))
+	newPrivKey := ed25519.GenPrivKey()
+	signedRotation := func(privKey crypto.PrivKey, height int64) abci.KeyRotation {
+		kr := types.NewKeyRotation(oldPrivKey.PubKey(), newPrivKey.PubKey(), height)
+		kr.Signature, err = privKey.Sign(types.KeyRotationSignBytes(chainID, kr))
+		require.NoError(t, err)
+		pbkr, err := kr.ToProto()
+		require.NoError(t, err)
+		return pbkr
+	}
+
+	testCases := []struct {
+		name     string
+		rotation abci.KeyRotation
+	}{
+		{"wrong height", signedRotation(oldPrivKey, 2)},
+		{"signed by the new key", signedRotation(newPrivKey, 3)},
+	}
+	for _, tc := range testCases {
+		app.KeyRotations = []abci.KeyRotation{tc.rotation}
+		_, _, err = blockExec.ApplyBlock(state, blockID, block)
+		assert.Error(t, err, tc.name)
+	}
+
+	app.KeyRotations = []abci.KeyRotation{signedRotation(oldPrivKey, 3)}
+	newState, _, err := blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	// the rotation only applies to the validators of height 3
+	assert.True(t, newState.Validators.HasAddress(oldPrivKey.PubKey().Address()))
+	assert.False(t, newState.NextValidators.HasAddress(oldPrivKey.PubKey().Address()))
+	_, val := newState.NextValidators.GetByAddress(newPrivKey.PubKey().Address())
+	require.NotNil(t, val)
+	assert.Equal(t, newPrivKey.PubKey(), val.PubKey)
+	assert.EqualValues(t, 1000, val.VotingPower)
+	assert.Equal(t, newState.Validators.TotalVotingPower(), newState.NextValidators.TotalVotingPower())
+	assert.EqualValues(t, 3, newState.LastHeightValidatorsChanged)
+}
+
+// sequencerRotations is a schedule of sequencer rotations by height.
+type sequencerRotations map[int64]*types.SequencerRotation
+
+func (r sequencerRotations) LoadSequencerRotation(height int64) *types.SequencerRotation {
+	return r[height]
+}
+
+func TestSequencerRotation(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(2, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	nextPubKey := ed25519.GenPrivKey().PubKey()
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithSequencerRotations(sequencerRotations{
+			3: {Height: 3, PubKey: nextPubKey},
+		}),
+	)
+
+	// the updates of the application are ignored at the rotation
+	app.ValidatorUpdates = []abci.ValidatorUpdate{
+		abci.UpdateValidator(ed25519.GenPrivKey().PubKey().Bytes(), 10, ""),
+	}
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	newState, _, err := blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	// the next sequencer is the only validator from height 3
+	assert.Equal(t, 2, newState.Validators.Size())
+	require.Equal(t, 1, newState.NextValidators.Size())
+	_, val := newState.NextValidators.GetByAddress(nextPubKey.Address())
+	require.NotNil(t, val)
+	assert.Equal(t, state.NextValidators.TotalVotingPower(), val.VotingPower)
+	assert.Equal(t, nextPubKey.Address(), newState.NextValidators.GetProposer().Address)
+	assert.EqualValues(t, 3, newState.LastHeightValidatorsChanged)
+}
+
+// forcedTxs are forced txs, with the heights including them.
+type forcedTxs struct {
+	txs      []types.ForcedTx
+	included map[string]int64
+}
+
+func (f *forcedTxs) Pending() []types.ForcedTx {
+	var pending []types.ForcedTx
+	for _, ftx := range f.txs {
+		if f.included[string(ftx.Tx)] == 0 {
+			pending = append(pending, ftx)
+		}
+	}
+	return pending
+}
+
+func (f *forcedTxs) Update(height int64, txs types.Txs) error {
+	for _, tx := range txs {
+		f.included[string(tx)] = height
+	}
+	return nil
+}
+
+func TestForcedTxs(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	ftxs := &forcedTxs{
+		txs: []types.ForcedTx{
+			{Tx: types.Tx("forced1"), Deadline: 1},
+			{Tx: types.Tx("forced2"), Deadline: 5},
+		},
+		included: make(map[string]int64),
+	}
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithForcedTxs(ftxs),
+	)
+
+	// a block skipping the forced tx due at its height is refused
+	block := makeBlock(state, 1)
+	assert.Error(t, blockExec.ValidateForcedTxs(state, block))
+
+	// the proposals include the pending forced txs, by deadline
+	proposerAddr := state.Validators.GetProposer().Address
+	block, _ = blockExec.CreateProposalBlock(1, state, new(types.Commit), proposerAddr)
+	assert.Equal(t, types.Txs{types.Tx("forced1"), types.Tx("forced2")}, block.Txs)
+	require.NoError(t, blockExec.ValidateForcedTxs(state, block))
+
+	// and they are included once the block is applied
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, _, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, ftxs.included["forced1"])
+	assert.Empty(t, ftxs.Pending())
+}
+
+func TestForcedTxsDue(t *testing.T) {
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	// a block with the most evidence has room for two of the txs below
+	state.ConsensusParams.Block.MaxBytes = types.MaxOverheadForBlock + types.MaxHeaderBytes +
+		types.MaxCommitBytesForKeyTypes(1, state.ConsensusParams.Validator.PubKeyTypes) +
+		state.ConsensusParams.Evidence.MaxBytes + 250
+
+	forcedTx := func(name string, size int, deadline int64, hubTime time.Time) types.ForcedTx {
+		tx := make(types.Tx, size)
+		copy(tx, name)
+		return types.ForcedTx{Tx: tx, Deadline: deadline, HubTime: hubTime}
+	}
+	genesisTime := state.LastBlockTime
+	var (
+		huge  = forcedTx("huge", 300, 1, genesisTime)
+		tx1   = forcedTx("tx1", 100, 1, genesisTime)
+		later = forcedTx("later", 100, 1, genesisTime.Add(time.Hour))
+		tx2   = forcedTx("tx2", 100, 1, genesisTime)
+		tx3   = forcedTx("tx3", 100, 1, genesisTime)
+		tx4   = forcedTx("tx4", 100, 5, genesisTime)
+	)
+	ftxs := &forcedTxs{
+		txs:      []types.ForcedTx{huge, tx1, later, tx2, tx3, tx4},
+		included: make(map[string]int64),
+	}
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		nil,
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithForcedTxs(ftxs),
+	)
+	proposerAddr := state.Validators.GetProposer().Address
+
+	// the first block must include the first forced txs past their deadline
+	// and announced by its time which fit, the next ones are deferred
+	block, _ := state.MakeBlock(1, types.Txs{tx1.Tx, tx2.Tx}, new(types.Commit), nil, proposerAddr)
+	require.NoError(t, blockExec.ValidateForcedTxs(state, block))
+	block, _ = state.MakeBlock(1, types.Txs{tx1.Tx, tx3.Tx}, new(types.Commit), nil, proposerAddr)
+	assert.Error(t, blockExec.ValidateForcedTxs(state, block))
+
+	// the proposals include them first, then the other pending forced txs
+	block, _ = blockExec.CreateProposalBlock(1, state, new(types.Commit), proposerAddr)
+	assert.Equal(t, types.Txs{tx1.Tx, tx2.Tx, huge.Tx, later.Tx, tx3.Tx, tx4.Tx}, block.Txs)
+}
+
+// blockGas is the gas of the blocks, by height.
+type blockGas map[int64][2]int64
+
+func (g blockGas) SaveBlockGas(height, gasWanted, gasUsed int64) error {
+	g[height] = [2]int64{gasWanted, gasUsed}
+	return nil
+}
+
+func TestBlockGas(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	gas := make(blockGas)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithBlockGas(gas),
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, _, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	// the test app wants the size of each tx, and uses 1
+	assert.Equal(t, [2]int64{int64(2 * len(block.Txs)), int64(len(block.Txs))}, gas[1])
+}
+
+// blockResults are the results of the blocks, by height.
+type blockResults map[int64]*cmtstate.ABCIResponses
+
+func (r blockResults) SaveBlockResults(height int64, results *cmtstate.ABCIResponses) error {
+	r[height] = results
+	return nil
+}
+
+func TestBlockResults(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	// the results are saved even if the state store discards them
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: true,
+	})
+	results := make(blockResults)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithBlockResults(results),
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, _, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	require.NotNil(t, results[1])
+	assert.Len(t, results[1].DeliverTxs, len(block.Txs))
+	_, err = stateStore.LoadABCIResponses(1)
+	assert.Equal(t, sm.ErrABCIResponsesNotPersisted, err)
+}
+
 func makeBlockID(hash []byte, partSetSize uint32, partSetHash []byte) types.BlockID {
 	var (
 		h   = make([]byte, tmhash.Size)
@@ -0,0 +1,76 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ExportedState is the state of a chain once the block at Height is committed:
+// the app hash and results hash of the block, and the validators and consensus
+// params of the next height. It holds what CometBFT needs, besides the app
+// state, to start a new chain from the state, e.g. the genesis of a hard fork.
+type ExportedState struct {
+	ChainID         string                   `json:"chain_id"`
+	Height          int64                    `json:"height"`
+	AppHash         cmtbytes.HexBytes        `json:"app_hash"`
+	LastResultsHash cmtbytes.HexBytes        `json:"last_results_hash"`
+	Validators      []types.GenesisValidator `json:"validators"`
+	ConsensusParams cmtproto.ConsensusParams `json:"consensus_params"`
+}
+
+// Export returns the state of the chain at height, or at the latest height of
+// the state store if 0. The hashes of the heights below the latest one are
+// those of the headers of the next blocks of blockStore. The document is
+// deterministic: the same state is exported the same way by all the nodes.
+func Export(stateStore Store, blockStore BlockStore, height int64) (*ExportedState, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading the state: %w", err)
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+	if height == 0 {
+		height = state.LastBlockHeight
+	}
+	if height < state.InitialHeight || height > state.LastBlockHeight {
+		return nil, fmt.Errorf("invalid export height %d, the state is at heights %d to %d",
+			height, state.InitialHeight, state.LastBlockHeight)
+	}
+
+	exported := &ExportedState{
+		ChainID:         state.ChainID,
+		Height:          height,
+		AppHash:         state.AppHash,
+		LastResultsHash: state.LastResultsHash,
+	}
+	if height < state.LastBlockHeight {
+		meta := blockStore.LoadBlockMeta(height + 1)
+		if meta == nil {
+			return nil, fmt.Errorf("the block of height %d isn't in the block store", height+1)
+		}
+		exported.AppHash = meta.Header.AppHash
+		exported.LastResultsHash = meta.Header.LastResultsHash
+	}
+
+	vals, err := stateStore.LoadValidators(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("loading the validators of height %d: %w", height+1, err)
+	}
+	for _, val := range vals.Validators {
+		exported.Validators = append(exported.Validators, types.GenesisValidator{
+			Address:           val.Address,
+			PubKey:            val.PubKey,
+			Power:             val.VotingPower,
+			ProofOfPossession: val.ProofOfPossession,
+		})
+	}
+	if exported.ConsensusParams, err = stateStore.LoadConsensusParams(height + 1); err != nil {
+		return nil, fmt.Errorf("loading the consensus params of height %d: %w", height+1, err)
+	}
+	return exported, nil
+}
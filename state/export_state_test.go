@@ -0,0 +1,102 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// metaBlockStore is a block store holding the metas of some blocks.
+type metaBlockStore struct {
+	sm.BlockStore
+	metas map[int64]*types.BlockMeta
+}
+
+func (bs metaBlockStore) LoadBlockMeta(height int64) *types.BlockMeta { return bs.metas[height] }
+
+func TestExport(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+	_, err := sm.Export(stateStore, nil, 0)
+	assert.Error(t, err, "no state")
+
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	blockStore := metaBlockStore{metas: map[int64]*types.BlockMeta{}}
+	// the params change at height 4
+	params := func(height int64) (cmtproto.ConsensusParams, int64) {
+		if height < 4 {
+			return cmtproto.ConsensusParams{Block: cmtproto.BlockParams{MaxBytes: 1000}}, 1
+		}
+		return cmtproto.ConsensusParams{Block: cmtproto.BlockParams{MaxBytes: 2000}}, 4
+	}
+	for h := int64(0); h <= 5; h++ {
+		consensusParams, changed := params(h + 1)
+		require.NoError(t, stateStore.Save(sm.State{
+			ChainID:                          "test-chain",
+			InitialHeight:                    1,
+			LastBlockHeight:                  h,
+			LastValidators:                   vals,
+			Validators:                       vals,
+			NextValidators:                   vals,
+			ConsensusParams:                  consensusParams,
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: changed,
+			AppHash:                          []byte{byte(h)},
+			LastResultsHash:                  []byte{byte(h), 1},
+		}))
+		blockStore.metas[h+1] = &types.BlockMeta{Header: types.Header{
+			AppHash:         []byte{byte(h)},
+			LastResultsHash: []byte{byte(h), 1},
+		}}
+	}
+	// the block store is behind the state
+	delete(blockStore.metas, 6)
+
+	latest, err := sm.Export(stateStore, blockStore, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "test-chain", latest.ChainID)
+	assert.EqualValues(t, 5, latest.Height)
+	assert.EqualValues(t, []byte{5}, latest.AppHash)
+	assert.EqualValues(t, []byte{5, 1}, latest.LastResultsHash)
+	require.Len(t, latest.Validators, 1)
+	assert.Equal(t, val.Address, latest.Validators[0].Address)
+	assert.Equal(t, val.PubKey, latest.Validators[0].PubKey)
+	assert.Equal(t, val.VotingPower, latest.Validators[0].Power)
+	assert.EqualValues(t, 2000, latest.ConsensusParams.Block.MaxBytes)
+
+	// the hashes of the previous heights are those of the next headers
+	exported, err := sm.Export(stateStore, blockStore, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, exported.Height)
+	assert.EqualValues(t, []byte{2}, exported.AppHash)
+	assert.EqualValues(t, []byte{2, 1}, exported.LastResultsHash)
+	assert.EqualValues(t, 1000, exported.ConsensusParams.Block.MaxBytes)
+	exported, err = sm.Export(stateStore, blockStore, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2000, exported.ConsensusParams.Block.MaxBytes)
+
+	// the export is deterministic
+	again, err := sm.Export(stateStore, blockStore, 3)
+	require.NoError(t, err)
+	bz, err := cmtjson.Marshal(exported)
+	require.NoError(t, err)
+	bz2, err := cmtjson.Marshal(again)
+	require.NoError(t, err)
+	assert.Equal(t, bz, bz2)
+
+	for _, height := range []int64{-1, 6} {
+		_, err = sm.Export(stateStore, blockStore, height)
+		assert.Error(t, err, height)
+	}
+	delete(blockStore.metas, 4)
+	_, err = sm.Export(stateStore, blockStore, 3)
+	assert.Error(t, err, "no block 4")
+}
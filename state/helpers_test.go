@@ -238,6 +238,8 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+	KeyRotations        []abci.KeyRotation
+	EndBlockEvents      []abci.Event
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -255,13 +257,15 @@ func (app *testApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlo
 func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: app.ValidatorUpdates,
+		KeyRotations:     app.KeyRotations,
+		Events:           app.EndBlockEvents,
 		ConsensusParamUpdates: &abci.ConsensusParams{
 			Version: &cmtproto.VersionParams{
 				AppVersion: 1}}}
 }
 
 func (app *testApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
-	return abci.ResponseDeliverTx{Events: []abci.Event{}}
+	return abci.ResponseDeliverTx{Events: []abci.Event{}, GasWanted: int64(len(req.Tx)), GasUsed: 1}
 }
 
 func (app *testApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
@@ -18,7 +18,14 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
-var _ indexer.BlockIndexer = (*BlockerIndexer)(nil)
+var (
+	_ indexer.BlockIndexer = (*BlockerIndexer)(nil)
+	_ indexer.Pruner       = (*BlockerIndexer)(nil)
+)
+
+// pruneBatchSize is the maximum number of keys deleted in a single batch when
+// pruning.
+const pruneBatchSize = 10000
 
 // BlockerIndexer implements a block indexer, indexing BeginBlock and EndBlock
 // events with an underlying KV store. Block events are indexed by their height,
@@ -87,7 +94,45 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 // one or more block heights. In the case of height queries, i.e. block.height=H,
 // if the height is indexed, that height alone will be returned. An error and
 // nil slice is returned. Otherwise, a non-nil slice and nil error is returned.
+//
+// The queries using OR or NOT are searched by combining the results of their
+// conjunctions of conditions (see indexer.SearchExpr).
 func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	e := q.Expression()
+	if e.Kind == query.ExprConditions {
+		return idx.search(ctx, e.Query)
+	}
+
+	keys, err := indexer.SearchExpr(ctx, e, func(ctx context.Context, q *query.Query) (map[string]struct{}, error) {
+		heights, err := idx.search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		keys := make(map[string]struct{}, len(heights))
+		for _, height := range heights {
+			keys[strconv.FormatInt(height, 10)] = struct{}{}
+		}
+		return keys, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]int64, 0, len(keys))
+	for key := range keys {
+		height, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, height)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	return results, nil
+}
+
+// search performs a query for block heights that match the given conjunction
+// of conditions.
+func (idx *BlockerIndexer) search(ctx context.Context, q *query.Query) ([]int64, error) {
 	results := make([]int64, 0)
 	select {
 	case <-ctx.Done():
@@ -554,6 +599,74 @@ func (idx *BlockerIndexer) match(
 	return filteredHeights, nil
 }
 
+// Prune deletes the primary and event keys of the blocks from fromHeight up
+// to, but not including, retainHeight, and returns the number of keys deleted.
+// It implements indexer.Pruner.
+//
+// As the keys are sorted by event attribute, Prune scans the whole index.
+func (idx *BlockerIndexer) Prune(fromHeight, retainHeight int64) (uint64, error) {
+	var (
+		pruned uint64
+		start  []byte
+	)
+	for {
+		keys, next, err := idx.prunableKeys(start, fromHeight, retainHeight)
+		if err != nil {
+			return pruned, err
+		}
+
+		if len(keys) > 0 {
+			batch := idx.store.NewBatch()
+			for _, key := range keys {
+				if err := batch.Delete(key); err != nil {
+					batch.Close()
+					return pruned, err
+				}
+			}
+			err = batch.WriteSync()
+			batch.Close()
+			if err != nil {
+				return pruned, err
+			}
+			pruned += uint64(len(keys))
+		}
+
+		if next == nil {
+			return pruned, nil
+		}
+		start = next
+	}
+}
+
+// prunableKeys returns up to pruneBatchSize keys of the blocks between
+// fromHeight and retainHeight, scanning the index from start, and the key to
+// resume the scan from, or nil if the scan is complete.
+func (idx *BlockerIndexer) prunableKeys(start []byte, fromHeight, retainHeight int64) ([][]byte, []byte, error) {
+	it, err := idx.store.Iterator(start, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(keys) == pruneBatchSize {
+			return keys, key, nil
+		}
+
+		height, err := parseHeightFromKey(key)
+		if err != nil {
+			// not an index key
+			continue
+		}
+		if height >= fromHeight && height < retainHeight {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil, it.Error()
+}
+
 func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) error {
 	heightBz := int64ToBytes(height)
 
@@ -358,3 +358,63 @@ func TestBlockIndexerMulti(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockIndexerPrune(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			ResultBeginBlock: abci.ResponseBeginBlock{
+				Events: []abci.Event{{Type: "begin_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("proposer"), Value: []byte("FCAA001"), Index: true},
+				}}},
+			},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{{Type: "end_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("foo"), Value: []byte(fmt.Sprint(h)), Index: true},
+				}}},
+			},
+		}))
+	}
+
+	// the primary and event keys of the blocks are deleted
+	pruned, err := indexer.Prune(1, 3)
+	require.NoError(t, err)
+	require.EqualValues(t, 6, pruned)
+
+	for h := int64(1); h <= 3; h++ {
+		ok, err := indexer.Has(h)
+		require.NoError(t, err)
+		require.Equal(t, h == 3, ok)
+	}
+	results, err := indexer.Search(context.Background(), query.MustParse("begin_event.proposer = 'FCAA001'"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, results)
+}
+
+func TestBlockIndexerSearchExpression(t *testing.T) {
+	indexer := blockidxkv.New(db.NewPrefixDB(db.NewMemDB(), []byte("block_events")))
+
+	for h := int64(1); h <= 4; h++ {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{{Type: "end_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("foo"), Value: []byte(fmt.Sprint(h % 2)), Index: true},
+				}}},
+			},
+		}))
+	}
+
+	results, err := indexer.Search(context.Background(),
+		query.MustParse("end_event.foo = 1 OR block.height = 2"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3}, results)
+
+	results, err = indexer.Search(context.Background(),
+		query.MustParse("end_event.foo = 1 AND NOT block.height = 3"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, results)
+}
@@ -106,6 +106,26 @@ func parseHeightFromEventKey(key []byte) (int64, error) {
 	return height, nil
 }
 
+// parseHeightFromKey returns the height of a primary or event key.
+func parseHeightFromKey(key []byte) (int64, error) {
+	var (
+		compositeKey string
+		height       int64
+	)
+
+	remaining, err := orderedcode.Parse(string(key), &compositeKey)
+	if err != nil {
+		return -1, fmt.Errorf("failed to parse key: %w", err)
+	}
+	if compositeKey != types.BlockHeightKey {
+		return parseHeightFromEventKey(key)
+	}
+	if _, err := orderedcode.Parse(remaining, &height); err != nil {
+		return -1, fmt.Errorf("failed to parse primary key: %w", err)
+	}
+	return height, nil
+}
+
 func parseEventSeqFromEventKey(key []byte) (int64, error) {
 	var (
 		compositeKey, typ, eventValue string
@@ -0,0 +1,87 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+// ErrUnboundedNot is returned when searching a negation which is not an
+// argument of a conjunction with at least one argument which is not negated.
+var ErrUnboundedNot = errors.New("NOT must be combined with AND to a condition which is not negated")
+
+// SearchExpr searches the expression of a query, searching its conjunctions of
+// conditions with search, and returns the keys of the matching results. The
+// results of OR are the union of those of its arguments, and the results of
+// AND are the intersection of those of its arguments, minus those of its
+// negated arguments. As the results of a negation alone are not indexed, NOT
+// must be an argument of such an AND.
+func SearchExpr(
+	ctx context.Context,
+	e *query.Expr,
+	search func(context.Context, *query.Query) (map[string]struct{}, error),
+) (map[string]struct{}, error) {
+	switch e.Kind {
+	case query.ExprConditions:
+		return search(ctx, e.Query)
+
+	case query.ExprOr:
+		union := make(map[string]struct{})
+		for _, arg := range e.Args {
+			keys, err := SearchExpr(ctx, arg, search)
+			if err != nil {
+				return nil, err
+			}
+			for key := range keys {
+				union[key] = struct{}{}
+			}
+		}
+		return union, nil
+
+	case query.ExprAnd:
+		var (
+			intersection map[string]struct{}
+			negated      []*query.Expr
+		)
+		for _, arg := range e.Args {
+			if arg.Kind == query.ExprNot {
+				negated = append(negated, arg.Args[0])
+				continue
+			}
+			keys, err := SearchExpr(ctx, arg, search)
+			if err != nil {
+				return nil, err
+			}
+			if intersection == nil {
+				intersection = keys
+				continue
+			}
+			for key := range intersection {
+				if _, ok := keys[key]; !ok {
+					delete(intersection, key)
+				}
+			}
+		}
+		if intersection == nil {
+			return nil, ErrUnboundedNot
+		}
+
+		for _, arg := range negated {
+			if len(intersection) == 0 {
+				break
+			}
+			keys, err := SearchExpr(ctx, arg, search)
+			if err != nil {
+				return nil, err
+			}
+			for key := range keys {
+				delete(intersection, key)
+			}
+		}
+		return intersection, nil
+
+	default:
+		return nil, ErrUnboundedNot
+	}
+}
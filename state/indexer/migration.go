@@ -0,0 +1,79 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+)
+
+// Migration upgrades the data of an indexer from the previous schema version.
+type Migration struct {
+	// Version is the schema version of the migrated data.
+	Version uint64
+	// Description is a human readable summary of the changes.
+	Description string
+	// Apply migrates the data from the previous version and records the new
+	// version, atomically if the backend allows it.
+	Apply func(ctx context.Context) error
+}
+
+// Migrator is implemented by the indexers with a versioned schema.
+type Migrator interface {
+	// SchemaVersion returns the schema version of the indexed data. An empty
+	// index is at the latest version.
+	SchemaVersion() (uint64, error)
+	// Migrations returns the migrations of the schema, by increasing version.
+	Migrations() []Migration
+}
+
+// LatestSchemaVersion returns the version of the schema after all the
+// migrations.
+func LatestSchemaVersion(m Migrator) uint64 {
+	migrations := m.Migrations()
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// PendingMigrations returns the migrations to apply to the indexed data, by
+// increasing version.
+func PendingMigrations(m Migrator) ([]Migration, error) {
+	version, err := m.SchemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("loading the schema version: %w", err)
+	}
+	if latest := LatestSchemaVersion(m); version > latest {
+		return nil, fmt.Errorf("the schema version %d is newer than the latest version %d supported", version, latest)
+	}
+	var pending []Migration
+	for _, migration := range m.Migrations() {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations to the indexed data, calling applied
+// after each of them, and returns the number applied. If dryRun is set, applied
+// is called for each pending migration without applying it.
+func Migrate(ctx context.Context, m Migrator, dryRun bool, applied func(Migration)) (int, error) {
+	pending, err := PendingMigrations(m)
+	if err != nil {
+		return 0, err
+	}
+	for i, migration := range pending {
+		if !dryRun {
+			if err := ctx.Err(); err != nil {
+				return i, err
+			}
+			if err := migration.Apply(ctx); err != nil {
+				return i, fmt.Errorf("migrating to schema version %d: %w", migration.Version, err)
+			}
+		}
+		if applied != nil {
+			applied(migration)
+		}
+	}
+	return len(pending), nil
+}
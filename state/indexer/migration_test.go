@@ -0,0 +1,72 @@
+package indexer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/state/indexer"
+)
+
+type testMigrator struct {
+	version uint64
+	fail    uint64
+}
+
+func (m *testMigrator) SchemaVersion() (uint64, error) { return m.version, nil }
+
+func (m *testMigrator) Migrations() []indexer.Migration {
+	var migrations []indexer.Migration
+	for v := uint64(1); v <= 3; v++ {
+		v := v
+		migrations = append(migrations, indexer.Migration{
+			Version: v,
+			Apply: func(context.Context) error {
+				if v == m.fail {
+					return errors.New("failed")
+				}
+				m.version = v
+				return nil
+			},
+		})
+	}
+	return migrations
+}
+
+func TestMigrate(t *testing.T) {
+	m := &testMigrator{version: 1}
+	assert.EqualValues(t, 3, indexer.LatestSchemaVersion(m))
+
+	var versions []uint64
+	applied := func(migration indexer.Migration) { versions = append(versions, migration.Version) }
+
+	n, err := indexer.Migrate(context.Background(), m, true, applied)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []uint64{2, 3}, versions)
+	assert.EqualValues(t, 1, m.version)
+
+	m.fail = 3
+	versions = nil
+	n, err = indexer.Migrate(context.Background(), m, false, applied)
+	require.Error(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []uint64{2}, versions)
+	assert.EqualValues(t, 2, m.version)
+
+	m.fail = 0
+	n, err = indexer.Migrate(context.Background(), m, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	pending, err := indexer.PendingMigrations(m)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// the data is newer than supported
+	m.version = 4
+	_, err = indexer.PendingMigrations(m)
+	require.Error(t, err)
+}
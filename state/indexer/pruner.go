@@ -0,0 +1,9 @@
+package indexer
+
+// Pruner is implemented by the indexers able to delete the entries of old
+// blocks, to bound the size of their indexes.
+type Pruner interface {
+	// Prune deletes the entries of the blocks from fromHeight up to, but not
+	// including, retainHeight, and returns the number of entries deleted.
+	Prune(fromHeight, retainHeight int64) (uint64, error)
+}
@@ -0,0 +1,330 @@
+// Package clickhouse implements an event sink backed by a ClickHouse database.
+//
+// The sink is meant for explorer-scale analytics: events are written in bulk,
+// one insert per table and block, through the HTTP interface of ClickHouse,
+// into tables sorted by attribute. Like the psql sink, it does not serve
+// searches through the RPC, which are expected to be made with SQL directly.
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	_ "embed" // for the schema
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	tableBlocks    = "blocks"
+	tableTxResults = "tx_results"
+	tableEvents    = "events"
+
+	// timeLayout is the layout of DateTime64(3) values.
+	timeLayout = "2006-01-02 15:04:05.000"
+
+	requestTimeout = 30 * time.Second
+)
+
+// schema is installed when the sink starts.
+//
+//go:embed schema.sql
+var schema string
+
+// EventSink is an indexer backend providing the tx/block index services. This
+// implementation stores records in a ClickHouse database using the schema
+// defined in state/indexer/sink/clickhouse/schema.sql.
+type EventSink struct {
+	client   *http.Client
+	endpoint string
+	database string
+	user     *url.Userinfo
+	chainID  string
+}
+
+// NewEventSink constructs an event sink associated with the ClickHouse
+// database specified by connStr, of the form
+// http://<user>:<password>@<host>:<port>/<db>, and installs the schema in the
+// database. Events written to the sink are attributed to the specified
+// chainID.
+func NewEventSink(connStr, chainID string) (*EventSink, error) {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clickhouse connection URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid clickhouse connection URL: unsupported scheme %q", u.Scheme)
+	}
+
+	es := &EventSink{
+		client:   &http.Client{Timeout: requestTimeout},
+		endpoint: (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}).String(),
+		database: strings.Trim(u.Path, "/"),
+		user:     u.User,
+		chainID:  chainID,
+	}
+	for _, stmt := range strings.Split(schema, ";") {
+		if strings.TrimSpace(stripComments(stmt)) == "" {
+			continue
+		}
+		if err := es.exec(context.Background(), stmt, nil); err != nil {
+			return nil, fmt.Errorf("installing schema: %w", err)
+		}
+	}
+	return es, nil
+}
+
+// stripComments removes the comments of an SQL statement.
+func stripComments(stmt string) string {
+	var b strings.Builder
+	for len(stmt) > 0 {
+		switch {
+		case strings.HasPrefix(stmt, "--"):
+			i := strings.IndexByte(stmt, '\n')
+			if i < 0 {
+				return b.String()
+			}
+			stmt = stmt[i:]
+		case strings.HasPrefix(stmt, "/*"):
+			i := strings.Index(stmt, "*/")
+			if i < 0 {
+				return b.String()
+			}
+			stmt = stmt[i+2:]
+		default:
+			b.WriteByte(stmt[0])
+			stmt = stmt[1:]
+		}
+	}
+	return b.String()
+}
+
+// exec executes the SQL statement through the HTTP interface, with body as
+// the data of an INSERT statement, if any.
+func (es *EventSink) exec(ctx context.Context, stmt string, body []byte) error {
+	params := url.Values{"query": {stmt}}
+	if es.database != "" {
+		params.Set("database", es.database)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, es.endpoint+"?"+params.Encode(),
+		bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if es.user != nil {
+		password, _ := es.user.Password()
+		req.SetBasicAuth(es.user.Username(), password)
+	}
+
+	resp, err := es.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("clickhouse error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// insert inserts the rows into the table in a single statement. rows are
+// encoded as JSON objects, one per line.
+func (es *EventSink) insert(table string, rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+	return es.exec(context.Background(), "INSERT INTO "+table+" FORMAT JSONEachRow", body.Bytes())
+}
+
+type blockRow struct {
+	ChainID   string `json:"chain_id"`
+	Height    int64  `json:"height"`
+	CreatedAt string `json:"created_at"`
+}
+
+type txResultRow struct {
+	ChainID   string `json:"chain_id"`
+	Height    int64  `json:"height"`
+	Index     uint32 `json:"index"`
+	TxHash    string `json:"tx_hash"`
+	TxResult  string `json:"tx_result"`
+	CreatedAt string `json:"created_at"`
+}
+
+type eventRow struct {
+	ChainID      string `json:"chain_id"`
+	Height       int64  `json:"height"`
+	TxIndex      int64  `json:"tx_index"`
+	EventIndex   int    `json:"event_index"`
+	Type         string `json:"type"`
+	Key          string `json:"key"`
+	CompositeKey string `json:"composite_key"`
+	Value        string `json:"value"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// eventRows returns the rows of the events, for the transaction at txIndex
+// in the block at height, or for the block if txIndex is -1.
+func (es *EventSink) eventRows(height, txIndex int64, evts []abci.Event, createdAt string) []interface{} {
+	var rows []interface{}
+	for i, evt := range evts {
+		// Skip events with an empty type.
+		if evt.Type == "" {
+			continue
+		}
+
+		row := eventRow{
+			ChainID:    es.chainID,
+			Height:     height,
+			TxIndex:    txIndex,
+			EventIndex: i,
+			Type:       evt.Type,
+			CreatedAt:  createdAt,
+		}
+		indexed := false
+		for _, attr := range evt.Attributes {
+			if !attr.Index {
+				continue
+			}
+			indexed = true
+			row.Key = string(attr.Key)
+			row.CompositeKey = evt.Type + "." + string(attr.Key)
+			row.Value = string(attr.Value)
+			rows = append(rows, row)
+		}
+		if !indexed {
+			row.CompositeKey = evt.Type
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// makeIndexedEvent constructs an event from the specified composite key and
+// value. If the key has the form "type.name", the event will have a single
+// attribute with that name and the value; otherwise the event will have only
+// a type and no attributes.
+func makeIndexedEvent(compositeKey, value string) abci.Event {
+	i := strings.Index(compositeKey, ".")
+	if i < 0 {
+		return abci.Event{Type: compositeKey}
+	}
+	return abci.Event{Type: compositeKey[:i], Attributes: []abci.EventAttribute{
+		{Key: []byte(compositeKey[i+1:]), Value: []byte(value), Index: true},
+	}}
+}
+
+// IndexBlockEvents indexes the specified block header, part of the
+// indexer.EventSink interface. The events of the block are written before the
+// block itself, so that a recorded block is always completely indexed.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	ts := time.Now().UTC().Format(timeLayout)
+	height := h.Header.Height
+
+	var evts []abci.Event
+	evts = append(evts, makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(height)))
+	evts = append(evts, h.ResultBeginBlock.Events...)
+	evts = append(evts, h.ResultEndBlock.Events...)
+	if err := es.insert(tableEvents, es.eventRows(height, -1, evts, ts)); err != nil {
+		return fmt.Errorf("indexing block events: %w", err)
+	}
+
+	if err := es.insert(tableBlocks, []interface{}{blockRow{
+		ChainID:   es.chainID,
+		Height:    height,
+		CreatedAt: ts,
+	}}); err != nil {
+		return fmt.Errorf("indexing block header: %w", err)
+	}
+	return nil
+}
+
+// IndexTxEvents indexes the specified transaction results, part of the
+// indexer.EventSink interface. The results and their events are written with
+// one insert per table.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	ts := time.Now().UTC().Format(timeLayout)
+
+	var txRows, eventRows []interface{}
+	for _, txr := range txrs {
+		// Encode the result message in protobuf wire format for indexing.
+		resultData, err := proto.Marshal(txr)
+		if err != nil {
+			return fmt.Errorf("marshaling tx_result: %w", err)
+		}
+
+		// Index the hash of the underlying transaction as a hex string.
+		txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+		txRows = append(txRows, txResultRow{
+			ChainID:   es.chainID,
+			Height:    txr.Height,
+			Index:     txr.Index,
+			TxHash:    txHash,
+			TxResult:  base64.StdEncoding.EncodeToString(resultData),
+			CreatedAt: ts,
+		})
+
+		var evts []abci.Event
+		evts = append(evts,
+			makeIndexedEvent(types.TxHashKey, txHash),
+			makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)))
+		evts = append(evts, txr.Result.Events...)
+		eventRows = append(eventRows, es.eventRows(txr.Height, int64(txr.Index), evts, ts)...)
+	}
+
+	if err := es.insert(tableEvents, eventRows); err != nil {
+		return fmt.Errorf("indexing transaction events: %w", err)
+	}
+	if err := es.insert(tableTxResults, txRows); err != nil {
+		return fmt.Errorf("indexing tx_result: %w", err)
+	}
+	return nil
+}
+
+// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	return nil, errors.New("block search is not supported via the clickhouse event sink")
+}
+
+// SearchTxEvents is not implemented by this sink, and reports an error for all queries.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	return nil, errors.New("tx search is not supported via the clickhouse event sink")
+}
+
+// GetTxByHash is not implemented by this sink, and reports an error for all queries.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	return nil, errors.New("getTxByHash is not supported via the clickhouse event sink")
+}
+
+// HasBlock is not implemented by this sink, and reports an error for all queries.
+func (es *EventSink) HasBlock(h int64) (bool, error) {
+	return false, errors.New("hasBlock is not supported via the clickhouse event sink")
+}
+
+// Stop closes the idle connections to the ClickHouse server.
+func (es *EventSink) Stop() error {
+	es.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,171 @@
+package clickhouse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = "test-chainID"
+
+// fakeServer records the statements received through the HTTP interface, and
+// the rows inserted in each table.
+type fakeServer struct {
+	mtx        sync.Mutex
+	statements []string
+	rows       map[string][]map[string]interface{}
+	fail       bool
+}
+
+func newFakeServer(t *testing.T) (*fakeServer, *httptest.Server) {
+	s := &fakeServer{rows: make(map[string][]map[string]interface{})}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+
+		user, password, _ := r.BasicAuth()
+		if user != "default" || password != "secret" || r.URL.Query().Get("database") != "cometbft" {
+			http.Error(w, "Code: 516. Authentication failed", http.StatusUnauthorized)
+			return
+		}
+		if s.fail {
+			http.Error(w, "Code: 241. Memory limit exceeded", http.StatusInternalServerError)
+			return
+		}
+
+		stmt := r.URL.Query().Get("query")
+		s.statements = append(s.statements, stmt)
+		if strings.HasPrefix(stmt, "INSERT INTO ") {
+			table := strings.Fields(stmt)[2]
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			scanner := bufio.NewScanner(bytes.NewReader(body))
+			for scanner.Scan() {
+				row := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+				s.rows[table] = append(s.rows[table], row)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func TestIndexing(t *testing.T) {
+	s, srv := newFakeServer(t)
+	es, err := NewEventSink(strings.Replace(srv.URL, "http://", "http://default:secret@", 1)+"/cometbft",
+		chainID)
+	require.NoError(t, err)
+	defer es.Stop()
+
+	// the schema is installed, one statement at a time
+	require.Len(t, s.statements, 3)
+	for i, table := range []string{tableBlocks, tableTxResults, tableEvents} {
+		assert.Contains(t, s.statements[i], "CREATE TABLE IF NOT EXISTS "+table+" (")
+	}
+
+	require.NoError(t, es.BlockIndexer().Index(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{
+				makeIndexedEvent("begin_event.proposer", "FCAA001"),
+				{Type: "no_attributes"},
+			},
+		},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{makeIndexedEvent("end_event.foo", "100")},
+		},
+	}))
+
+	txr := &abci.TxResult{
+		Height: 1,
+		Index:  0,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ResponseDeliverTx{
+			Events: []abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{
+					{Key: []byte("number"), Value: []byte("1"), Index: true},
+					{Key: []byte("owner"), Value: []byte("Ivan"), Index: true},
+					{Key: []byte("not_indexed"), Value: []byte("x")},
+				}},
+				{Type: "", Attributes: []abci.EventAttribute{{Key: []byte("empty"), Value: []byte("type")}}},
+			},
+		},
+	}
+	require.NoError(t, es.TxIndexer().AddBatch(&txindex.Batch{Ops: []*abci.TxResult{txr}}))
+
+	// one insert per table and block
+	assert.Equal(t, []string{
+		"INSERT INTO events FORMAT JSONEachRow",
+		"INSERT INTO blocks FORMAT JSONEachRow",
+		"INSERT INTO events FORMAT JSONEachRow",
+		"INSERT INTO tx_results FORMAT JSONEachRow",
+	}, s.statements[3:])
+
+	require.Len(t, s.rows[tableBlocks], 1)
+	assert.Equal(t, chainID, s.rows[tableBlocks][0]["chain_id"])
+	assert.EqualValues(t, 1, s.rows[tableBlocks][0]["height"])
+
+	var blockEvents, txEvents []string
+	for _, row := range s.rows[tableEvents] {
+		ev := row["composite_key"].(string) + "=" + row["value"].(string)
+		if row["tx_index"].(float64) < 0 {
+			blockEvents = append(blockEvents, ev)
+		} else {
+			txEvents = append(txEvents, ev)
+		}
+	}
+	assert.Equal(t, []string{"block.height=1", "begin_event.proposer=FCAA001", "no_attributes=",
+		"end_event.foo=100"}, blockEvents)
+	assert.Equal(t, []string{fmt.Sprintf("tx.hash=%X", types.Tx("HELLO WORLD").Hash()), "tx.height=1",
+		"account.number=1", "account.owner=Ivan"}, txEvents)
+
+	require.Len(t, s.rows[tableTxResults], 1)
+	resultData, err := base64.StdEncoding.DecodeString(s.rows[tableTxResults][0]["tx_result"].(string))
+	require.NoError(t, err)
+	got := new(abci.TxResult)
+	require.NoError(t, proto.Unmarshal(resultData, got))
+	assert.Equal(t, txr.Tx, got.Tx)
+
+	// search is left to SQL
+	_, err = es.TxIndexer().Search(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestIndexingError(t *testing.T) {
+	s, srv := newFakeServer(t)
+	_, err := NewEventSink(srv.URL+"/cometbft", chainID)
+	assert.ErrorContains(t, err, "Authentication failed")
+
+	es, err := NewEventSink(strings.Replace(srv.URL, "http://", "http://default:secret@", 1)+"/cometbft",
+		chainID)
+	require.NoError(t, err)
+	s.fail = true
+	err = es.BlockIndexer().Index(types.EventDataNewBlockHeader{Header: types.Header{Height: 1}})
+	assert.ErrorContains(t, err, "Memory limit exceeded")
+
+	_, err = NewEventSink("tcp://localhost:9000", chainID)
+	assert.Error(t, err)
+}
+
+func TestStripComments(t *testing.T) {
+	assert.Equal(t, "\n\nCREATE TABLE t (\n  a String \n)",
+		stripComments("/* schema */\n-- table\nCREATE TABLE t (\n  a String -- comment\n)"))
+}
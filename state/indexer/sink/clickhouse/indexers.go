@@ -0,0 +1,76 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxIndexer returns the transaction indexer backed by es.
+func (es *EventSink) TxIndexer() TxIndexer {
+	return TxIndexer{clickhouse: es}
+}
+
+// TxIndexer implements the txindex.TxIndexer interface by delegating indexing
+// operations to an underlying ClickHouse event sink.
+type TxIndexer struct{ clickhouse *EventSink }
+
+var _ txindex.TxIndexer = TxIndexer{}
+
+// AddBatch indexes a batch of transactions in ClickHouse, as part of
+// TxIndexer.
+func (t TxIndexer) AddBatch(batch *txindex.Batch) error {
+	return t.clickhouse.IndexTxEvents(batch.Ops)
+}
+
+// Index indexes a single transaction result in ClickHouse, as part of
+// TxIndexer.
+func (t TxIndexer) Index(txr *abci.TxResult) error {
+	return t.clickhouse.IndexTxEvents([]*abci.TxResult{txr})
+}
+
+// Get is implemented to satisfy the TxIndexer interface, but is not supported
+// by the clickhouse event sink and reports an error for all inputs.
+func (TxIndexer) Get([]byte) (*abci.TxResult, error) {
+	return nil, errors.New("the TxIndexer.Get method is not supported")
+}
+
+// Search is implemented to satisfy the TxIndexer interface, but it is not
+// supported by the clickhouse event sink and reports an error for all inputs.
+func (TxIndexer) Search(context.Context, *query.Query) ([]*abci.TxResult, error) {
+	return nil, errors.New("the TxIndexer.Search method is not supported")
+}
+
+// BlockIndexer returns the block indexer backed by es.
+func (es *EventSink) BlockIndexer() BlockIndexer {
+	return BlockIndexer{clickhouse: es}
+}
+
+// BlockIndexer implements the indexer.BlockIndexer interface by delegating
+// indexing operations to an underlying ClickHouse event sink.
+type BlockIndexer struct{ clickhouse *EventSink }
+
+var _ indexer.BlockIndexer = BlockIndexer{}
+
+// Has is implemented to satisfy the BlockIndexer interface, but it is not
+// supported by the clickhouse event sink and reports an error for all inputs.
+func (BlockIndexer) Has(height int64) (bool, error) {
+	return false, errors.New("the BlockIndexer.Has method is not supported")
+}
+
+// Index indexes block begin and end events for the specified block, as part
+// of BlockIndexer.
+func (b BlockIndexer) Index(block types.EventDataNewBlockHeader) error {
+	return b.clickhouse.IndexBlockEvents(block)
+}
+
+// Search is implemented to satisfy the BlockIndexer interface, but it is not
+// supported by the clickhouse event sink and reports an error for all inputs.
+func (BlockIndexer) Search(context.Context, *query.Query) ([]int64, error) {
+	return nil, errors.New("the BlockIndexer.Search method is not supported")
+}
@@ -0,0 +1,59 @@
+/*
+  This file defines the database schema for the ClickHouse ("clickhouse") event
+  sink implementation in CometBFT. The sink installs it when it starts, so the
+  operator only needs to create the database.
+
+  The tables are denormalized for analytical queries: every indexed attribute
+  is a row of the events table, which is sorted by attribute, so that events
+  by attribute can be found over millions of blocks without joins. Rows
+  written twice, e.g. when re-indexing, are deduplicated in the background.
+ */
+
+-- The blocks table records each indexed block. A block is recorded once all
+-- its events were written.
+CREATE TABLE IF NOT EXISTS blocks (
+  chain_id   LowCardinality(String),
+  height     UInt64,
+
+  -- When this block header was logged into the sink, in UTC.
+  created_at DateTime64(3, 'UTC')
+) ENGINE = ReplacingMergeTree
+ORDER BY (chain_id, height);
+
+-- The tx_results table records transaction results.
+CREATE TABLE IF NOT EXISTS tx_results (
+  chain_id   LowCardinality(String),
+  height     UInt64,
+  -- The sequential index of the transaction within the block.
+  index      UInt32,
+  -- The hex-encoded hash of the transaction.
+  tx_hash    String,
+  -- The base64 encoding of the protobuf wire encoding of the TxResult message.
+  tx_result  String,
+  -- When this result record was logged into the sink, in UTC.
+  created_at DateTime64(3, 'UTC'),
+
+  INDEX idx_tx_hash tx_hash TYPE bloom_filter GRANULARITY 4
+) ENGINE = ReplacingMergeTree
+ORDER BY (chain_id, height, index);
+
+-- The events table records the indexed attributes of block and transaction
+-- events, one per row. Events without indexed attributes are recorded as a
+-- single row with an empty key, and their type as composite key.
+CREATE TABLE IF NOT EXISTS events (
+  chain_id      LowCardinality(String),
+  height        UInt64,
+  -- The index of the transaction within the block, or -1 for block events.
+  tx_index      Int32,
+  -- The index of the event within the block or transaction events.
+  event_index   UInt32,
+  -- The application-defined type label for the event.
+  type          LowCardinality(String),
+  key           String, -- bare key
+  composite_key LowCardinality(String), -- composed type.key
+  value         String,
+  -- When this event was logged into the sink, in UTC.
+  created_at    DateTime64(3, 'UTC')
+) ENGINE = ReplacingMergeTree
+PARTITION BY intDiv(height, 1000000)
+ORDER BY (chain_id, composite_key, value, height, tx_index, event_index, key);
@@ -47,6 +47,12 @@ func (b BackportTxIndexer) Index(txr *abci.TxResult) error {
 	return b.psql.IndexTxEvents([]*abci.TxResult{txr})
 }
 
+// IndexBlocks indexes the blocks and their transaction results in Postgres, in
+// a single database transaction, as part of BulkIndexer.
+func (b BackportTxIndexer) IndexBlocks(blocks []txindex.BlockEvents) error {
+	return b.psql.IndexBlocks(blocks)
+}
+
 // Get is implemented to satisfy the TxIndexer interface, but is not supported
 // by the psql event sink and reports an error for all inputs.
 func (BackportTxIndexer) Get([]byte) (*abci.TxResult, error) {
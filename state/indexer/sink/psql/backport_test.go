@@ -8,4 +8,5 @@ import (
 var (
 	_ indexer.BlockIndexer = BackportBlockIndexer{}
 	_ txindex.TxIndexer    = BackportTxIndexer{}
+	_ txindex.BulkIndexer  = BackportTxIndexer{}
 )
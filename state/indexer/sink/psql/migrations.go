@@ -0,0 +1,93 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tendermint/tendermint/state/indexer"
+)
+
+const tableSchemaMigrations = "schema_migrations"
+
+var (
+	_ indexer.Migrator = (*EventSink)(nil)
+	_ indexer.Migrator = BackportTxIndexer{}
+)
+
+// migrations are the changes to the schema since it was versioned, by
+// increasing version. The schema installed by schema.sql is at the latest
+// version, which it records.
+var migrations = []struct {
+	version     uint64
+	description string
+	statements  string
+}{
+	{
+		version:     1,
+		description: "record the schema version",
+		statements: `
+CREATE TABLE IF NOT EXISTS ` + tableSchemaMigrations + ` (
+  version     BIGINT PRIMARY KEY,
+  description VARCHAR NOT NULL,
+  applied_at  TIMESTAMPTZ NOT NULL
+);
+`,
+	},
+	{
+		version:     2,
+		description: "index the attributes by composite key and value, and the transactions by hash",
+		statements: `
+CREATE INDEX IF NOT EXISTS idx_attributes_composite_key_value ON ` + tableAttributes + `(composite_key, value);
+CREATE INDEX IF NOT EXISTS idx_tx_results_tx_hash ON ` + tableTxResults + `(tx_hash);
+`,
+	},
+}
+
+// SchemaVersion returns the schema version of the database, 0 if it was
+// installed before the schema was versioned. It implements indexer.Migrator.
+func (es *EventSink) SchemaVersion() (uint64, error) {
+	var table sql.NullString
+	if err := es.store.QueryRow(`SELECT to_regclass($1)::text;`, tableSchemaMigrations).Scan(&table); err != nil {
+		return 0, err
+	}
+	if !table.Valid {
+		return 0, nil
+	}
+	var version uint64
+	err := es.store.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM ` + tableSchemaMigrations + `;`).Scan(&version)
+	return version, err
+}
+
+// Migrations returns the migrations of the schema of the database, each
+// applied in a transaction. It implements indexer.Migrator.
+func (es *EventSink) Migrations() []indexer.Migration {
+	ms := make([]indexer.Migration, len(migrations))
+	for i, m := range migrations {
+		m := m
+		ms[i] = indexer.Migration{
+			Version:     m.version,
+			Description: m.description,
+			Apply: func(ctx context.Context) error {
+				return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+					if _, err := dbtx.ExecContext(ctx, m.statements); err != nil {
+						return err
+					}
+					_, err := dbtx.ExecContext(ctx, `
+INSERT INTO `+tableSchemaMigrations+` (version, description, applied_at) VALUES ($1, $2, $3);
+`, m.version, m.description, time.Now().UTC())
+					return err
+				})
+			},
+		}
+	}
+	return ms
+}
+
+// SchemaVersion returns the schema version of the underlying database, as
+// part of indexer.Migrator.
+func (b BackportTxIndexer) SchemaVersion() (uint64, error) { return b.psql.SchemaVersion() }
+
+// Migrations returns the migrations of the schema of the underlying database,
+// as part of indexer.Migrator.
+func (b BackportTxIndexer) Migrations() []indexer.Migration { return b.psql.Migrations() }
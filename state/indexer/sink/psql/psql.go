@@ -3,6 +3,7 @@ package psql
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -10,9 +11,11 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/lib/pq"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -30,21 +33,62 @@ const (
 type EventSink struct {
 	store   *sql.DB
 	chainID string
+
+	// composite keys of the attributes whose values are full-text indexed
+	fullText []string
+}
+
+// Option sets an optional parameter on the EventSink.
+type Option func(*EventSink)
+
+// WithFullTextAttributes sets the composite keys of the attributes, e.g.
+// "tx.memo", whose values are full-text indexed. A partial GIN index on the
+// tsvector of the values is created for each of them, which is used by the
+// queries of the form:
+//
+//	composite_key = 'tx.memo' AND to_tsvector('simple', value) @@ plainto_tsquery('simple', 'birthday')
+func WithFullTextAttributes(compositeKeys []string) Option {
+	return func(es *EventSink) {
+		es.fullText = append(es.fullText, compositeKeys...)
+	}
 }
 
 // NewEventSink constructs an event sink associated with the PostgreSQL
 // database specified by connStr. Events written to the sink are attributed to
 // the specified chainID.
-func NewEventSink(connStr, chainID string) (*EventSink, error) {
+func NewEventSink(connStr, chainID string, options ...Option) (*EventSink, error) {
 	db, err := sql.Open(driverName, connStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &EventSink{
+	es := &EventSink{
 		store:   db,
 		chainID: chainID,
-	}, nil
+	}
+	for _, option := range options {
+		option(es)
+	}
+	if err := es.createFullTextIndexes(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("creating the full-text indexes: %w", err)
+	}
+	return es, nil
+}
+
+// createFullTextIndexes creates the full-text indexes of the attributes which
+// do not have one.
+func (es *EventSink) createFullTextIndexes() error {
+	for _, key := range es.fullText {
+		hash := sha256.Sum256([]byte(key))
+		if _, err := es.store.Exec(fmt.Sprintf(`
+CREATE INDEX IF NOT EXISTS idx_attributes_fts_%x ON `+tableAttributes+`
+  USING GIN (to_tsvector('simple', value)) WHERE composite_key = %s;
+`, hash[:8], pq.QuoteLiteral(key))); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DB returns the underlying Postgres connection used by the sink.
@@ -143,94 +187,124 @@ func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
 	ts := time.Now().UTC()
 
 	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
-		// Add the block to the blocks table and report back its row ID for use
-		// in indexing the events for the block.
-		blockID, err := queryWithID(dbtx, `
-INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
-  VALUES ($1, $2, $3)
-  ON CONFLICT DO NOTHING
-  RETURNING rowid;
-`, h.Header.Height, es.chainID, ts)
-		if err == sql.ErrNoRows {
-			return nil // we already saw this block; quietly succeed
-		} else if err != nil {
-			return fmt.Errorf("indexing block header: %w", err)
-		}
+		return es.indexBlockEvents(dbtx, ts, h)
+	})
+}
 
-		// Insert the special block meta-event for height.
-		if err := insertEvents(dbtx, blockID, 0, []abci.Event{
-			makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	ts := time.Now().UTC()
+
+	for _, txr := range txrs {
+		if err := runInTransaction(es.store, func(dbtx *sql.Tx) error {
+			return es.indexTxEvents(dbtx, ts, txr)
 		}); err != nil {
-			return fmt.Errorf("block meta-events: %w", err)
-		}
-		// Insert all the block events. Order is important here,
-		if err := insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events); err != nil {
-			return fmt.Errorf("begin-block events: %w", err)
+			return err
 		}
-		if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
-			return fmt.Errorf("end-block events: %w", err)
+	}
+	return nil
+}
+
+// IndexBlocks indexes the events of the blocks and of their transactions in a
+// single database transaction, which is cheaper than a transaction per block
+// and per transaction when catching up.
+func (es *EventSink) IndexBlocks(blocks []txindex.BlockEvents) error {
+	ts := time.Now().UTC()
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		for _, b := range blocks {
+			if err := es.indexBlockEvents(dbtx, ts, b.Header); err != nil {
+				return err
+			}
+			if b.Txs == nil {
+				continue
+			}
+			for _, txr := range b.Txs.Ops {
+				if err := es.indexTxEvents(dbtx, ts, txr); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
 }
 
-func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
-	ts := time.Now().UTC()
+func (es *EventSink) indexBlockEvents(dbtx *sql.Tx, ts time.Time, h types.EventDataNewBlockHeader) error {
+	// Add the block to the blocks table and report back its row ID for use
+	// in indexing the events for the block.
+	blockID, err := queryWithID(dbtx, `
+INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
+  VALUES ($1, $2, $3)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;
+`, h.Header.Height, es.chainID, ts)
+	if err == sql.ErrNoRows {
+		return nil // we already saw this block; quietly succeed
+	} else if err != nil {
+		return fmt.Errorf("indexing block header: %w", err)
+	}
 
-	for _, txr := range txrs {
-		// Encode the result message in protobuf wire format for indexing.
-		resultData, err := proto.Marshal(txr)
-		if err != nil {
-			return fmt.Errorf("marshaling tx_result: %w", err)
-		}
+	// Insert the special block meta-event for height.
+	if err := insertEvents(dbtx, blockID, 0, []abci.Event{
+		makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
+	}); err != nil {
+		return fmt.Errorf("block meta-events: %w", err)
+	}
+	// Insert all the block events. Order is important here,
+	if err := insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events); err != nil {
+		return fmt.Errorf("begin-block events: %w", err)
+	}
+	if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
+		return fmt.Errorf("end-block events: %w", err)
+	}
+	return nil
+}
 
-		// Index the hash of the underlying transaction as a hex string.
-		txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+func (es *EventSink) indexTxEvents(dbtx *sql.Tx, ts time.Time, txr *abci.TxResult) error {
+	// Encode the result message in protobuf wire format for indexing.
+	resultData, err := proto.Marshal(txr)
+	if err != nil {
+		return fmt.Errorf("marshaling tx_result: %w", err)
+	}
 
-		if err := runInTransaction(es.store, func(dbtx *sql.Tx) error {
-			// Find the block associated with this transaction. The block header
-			// must have been indexed prior to the transactions belonging to it.
-			blockID, err := queryWithID(dbtx, `
+	// Index the hash of the underlying transaction as a hex string.
+	txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+	// Find the block associated with this transaction. The block header
+	// must have been indexed prior to the transactions belonging to it.
+	blockID, err := queryWithID(dbtx, `
 SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
 `, txr.Height, es.chainID)
-			if err != nil {
-				return fmt.Errorf("finding block ID: %w", err)
-			}
+	if err != nil {
+		return fmt.Errorf("finding block ID: %w", err)
+	}
 
-			// Insert a record for this tx_result and capture its ID for indexing events.
-			txID, err := queryWithID(dbtx, `
+	// Insert a record for this tx_result and capture its ID for indexing events.
+	txID, err := queryWithID(dbtx, `
 INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
   VALUES ($1, $2, $3, $4, $5)
   ON CONFLICT DO NOTHING
   RETURNING rowid;
 `, blockID, txr.Index, ts, txHash, resultData)
-			if err == sql.ErrNoRows {
-				return nil // we already saw this transaction; quietly succeed
-			} else if err != nil {
-				return fmt.Errorf("indexing tx_result: %w", err)
-			}
-
-			// Insert the special transaction meta-events for hash and height.
-			if err := insertEvents(dbtx, blockID, txID, []abci.Event{
-				makeIndexedEvent(types.TxHashKey, txHash),
-				makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
-			}); err != nil {
-				return fmt.Errorf("indexing transaction meta-events: %w", err)
-			}
-			// Index any events packaged with the transaction.
-			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
-				return fmt.Errorf("indexing transaction events: %w", err)
-			}
-			return nil
+	if err == sql.ErrNoRows {
+		return nil // we already saw this transaction; quietly succeed
+	} else if err != nil {
+		return fmt.Errorf("indexing tx_result: %w", err)
+	}
 
-		}); err != nil {
-			return err
-		}
+	// Insert the special transaction meta-events for hash and height.
+	if err := insertEvents(dbtx, blockID, txID, []abci.Event{
+		makeIndexedEvent(types.TxHashKey, txHash),
+		makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
+	}); err != nil {
+		return fmt.Errorf("indexing transaction meta-events: %w", err)
+	}
+	// Index any events packaged with the transaction.
+	if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
+		return fmt.Errorf("indexing transaction events: %w", err)
 	}
 	return nil
 }
 
-// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
 func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
 	return nil, errors.New("block search is not supported via the postgres event sink")
 }
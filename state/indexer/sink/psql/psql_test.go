@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 
@@ -248,6 +249,52 @@ func TestIndexing(t *testing.T) {
 	})
 }
 
+func TestSchemaMigrations(t *testing.T) {
+	es := &EventSink{store: testDB()}
+
+	// the installed schema is at the latest version
+	version, err := es.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, indexer.LatestSchemaVersion(es), version)
+	pending, err := indexer.PendingMigrations(es)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	// the migrations of a database installed before versioning are applied
+	_, err = testDB().Exec(`DROP TABLE schema_migrations;
+DROP INDEX idx_attributes_composite_key_value, idx_tx_results_tx_hash;`)
+	require.NoError(t, err)
+	version, err = es.SchemaVersion()
+	require.NoError(t, err)
+	require.Zero(t, version)
+
+	n, err := indexer.Migrate(context.Background(), es, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, len(es.Migrations()), n)
+	version, err = es.SchemaVersion()
+	require.NoError(t, err)
+	require.Zero(t, version)
+
+	_, err = indexer.Migrate(context.Background(), es, false, nil)
+	require.NoError(t, err)
+	pending, err = indexer.PendingMigrations(es)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func TestFullTextIndexes(t *testing.T) {
+	es := &EventSink{store: testDB(), fullText: []string{"tx.memo", "it's"}}
+	require.NoError(t, es.createFullTextIndexes())
+	// the indexes are created once
+	require.NoError(t, es.createFullTextIndexes())
+
+	var n int
+	require.NoError(t, testDB().QueryRow(`
+SELECT count(*) FROM pg_indexes WHERE tablename = 'attributes' AND indexname LIKE 'idx_attributes_fts_%';
+`).Scan(&n))
+	assert.Equal(t, 2, n)
+}
+
 func TestStop(t *testing.T) {
 	indexer := &EventSink{store: testDB()}
 	require.NoError(t, indexer.Stop())
@@ -289,7 +336,7 @@ func readSchema() ([]*schema.Migration, error) {
 
 // resetDB drops all the data from the test database.
 func resetDatabase(db *sql.DB) error {
-	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes CASCADE;`)
+	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes,schema_migrations CASCADE;`)
 	if err != nil {
 		return fmt.Errorf("dropping tables: %v", err)
 	}
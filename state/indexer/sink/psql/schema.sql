@@ -2,8 +2,23 @@
   This file defines the database schema for the PostgresQL ("psql") event sink
   implementation in CometBFT. The operator must create a database and install
   this schema before using the database to index events.
+
+  The schema is versioned: the databases installed with an earlier version are
+  upgraded with the migrate-indexer command, which applies the migrations
+  defined in migrations.go.
  */
 
+-- The schema_migrations table records the schema versions applied.
+CREATE TABLE schema_migrations (
+  version     BIGINT PRIMARY KEY,
+  description VARCHAR NOT NULL,
+  applied_at  TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO schema_migrations (version, description, applied_at) VALUES
+  (1, 'record the schema version', NOW()),
+  (2, 'index the attributes by composite key and value, and the transactions by hash', NOW());
+
 -- The blocks table records metadata about each block.
 -- The block record does not include its events or transactions (see tx_results).
 CREATE TABLE blocks (
@@ -83,3 +98,8 @@ CREATE VIEW tx_events AS
   FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id)
   JOIN event_attributes ON (tx_results.rowid = event_attributes.tx_id)
   WHERE event_attributes.tx_id IS NOT NULL;
+
+-- Index the attributes by composite key and value, to search the events, and
+-- the transactions by hash (schema version 2).
+CREATE INDEX idx_attributes_composite_key_value ON attributes(composite_key, value);
+CREATE INDEX idx_tx_results_tx_hash ON tx_results(tx_hash);
@@ -0,0 +1,22 @@
+//go:build sqlite
+// +build sqlite
+
+package sqlite
+
+import (
+	"net/url"
+
+	// Register the SQLite database driver.
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const driverName = "sqlite3"
+
+// checkDriver always succeeds, as SQLite support is compiled in.
+func checkDriver() error { return nil }
+
+// dataSourceName returns the data source name of the database at path. The
+// database uses a write-ahead log, so that searches do not block indexing.
+func dataSourceName(path string) string {
+	return "file:" + url.PathEscape(path) + "?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on&_txlock=immediate"
+}
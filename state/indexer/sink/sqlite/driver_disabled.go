@@ -0,0 +1,15 @@
+//go:build !sqlite
+// +build !sqlite
+
+package sqlite
+
+import "errors"
+
+const driverName = "sqlite3"
+
+// checkDriver always fails, as SQLite support is not compiled in.
+func checkDriver() error {
+	return errors.New("SQLite support is not compiled in, build with COMETBFT_BUILD_OPTIONS=sqlite")
+}
+
+func dataSourceName(path string) string { return path }
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxIndexer returns the transaction indexer backed by es.
+func (es *EventSink) TxIndexer() TxIndexer {
+	return TxIndexer{sqlite: es}
+}
+
+// TxIndexer implements the txindex.TxIndexer interface by delegating to an
+// underlying SQLite event sink.
+type TxIndexer struct{ sqlite *EventSink }
+
+var (
+	_ txindex.TxIndexer   = TxIndexer{}
+	_ txindex.BulkIndexer = TxIndexer{}
+)
+
+// AddBatch indexes a batch of transactions in SQLite, as part of TxIndexer.
+func (t TxIndexer) AddBatch(batch *txindex.Batch) error {
+	return t.sqlite.IndexTxEvents(batch.Ops)
+}
+
+// Index indexes a single transaction result in SQLite, as part of TxIndexer.
+func (t TxIndexer) Index(txr *abci.TxResult) error {
+	return t.sqlite.IndexTxEvents([]*abci.TxResult{txr})
+}
+
+// IndexBlocks indexes the blocks and their transaction results in SQLite, in a
+// single database transaction, as part of BulkIndexer.
+func (t TxIndexer) IndexBlocks(blocks []txindex.BlockEvents) error {
+	return t.sqlite.IndexBlocks(blocks)
+}
+
+// Get returns the result of the transaction with the given hash, or nil if it
+// is not indexed, as part of TxIndexer.
+func (t TxIndexer) Get(hash []byte) (*abci.TxResult, error) {
+	return t.sqlite.GetTxByHash(hash)
+}
+
+// Search returns the results of the transactions matching q, as part of
+// TxIndexer.
+func (t TxIndexer) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	return t.sqlite.SearchTxEvents(ctx, q)
+}
+
+// BlockIndexer returns the block indexer backed by es.
+func (es *EventSink) BlockIndexer() BlockIndexer {
+	return BlockIndexer{sqlite: es}
+}
+
+// BlockIndexer implements the indexer.BlockIndexer interface by delegating to
+// an underlying SQLite event sink.
+type BlockIndexer struct{ sqlite *EventSink }
+
+var _ indexer.BlockIndexer = BlockIndexer{}
+
+// Has returns true if the block at the given height is indexed, as part of
+// BlockIndexer.
+func (b BlockIndexer) Has(height int64) (bool, error) {
+	return b.sqlite.HasBlock(height)
+}
+
+// Index indexes block begin and end events for the specified block, as part
+// of BlockIndexer.
+func (b BlockIndexer) Index(block types.EventDataNewBlockHeader) error {
+	return b.sqlite.IndexBlockEvents(block)
+}
+
+// Search returns the heights of the blocks matching q, as part of
+// BlockIndexer.
+func (b BlockIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return b.sqlite.SearchBlockEvents(ctx, q)
+}
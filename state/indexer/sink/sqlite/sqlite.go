@@ -0,0 +1,518 @@
+// Package sqlite implements an event sink backed by a SQLite database.
+//
+// Unlike the psql sink, the sqlite sink also serves searches, and is a middle
+// ground between the kv indexer and operating a PostgreSQL server: the index
+// is a single file, which can be queried with SQL by other tools.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed" // for the schema
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	tableBlocks          = "blocks"
+	tableTxResults       = "tx_results"
+	tableEvents          = "events"
+	tableAttributes      = "attributes"
+	tableAttributeValues = "attribute_values"
+)
+
+// schema is installed when opening the database.
+//
+//go:embed schema.sql
+var schema string
+
+// EventSink is an indexer backend providing the tx/block index services. This
+// implementation stores records in a SQLite database using the schema defined
+// in state/indexer/sink/sqlite/schema.sql.
+type EventSink struct {
+	store   *sql.DB
+	chainID string
+
+	// composite keys of the attributes whose values are full-text indexed
+	fullText map[string]struct{}
+}
+
+// Option sets an optional parameter on the EventSink.
+type Option func(*EventSink)
+
+// WithFullTextAttributes sets the composite keys of the attributes, e.g.
+// "tx.memo", whose values are full-text indexed. The CONTAINS conditions on
+// these attributes match the values containing the words of the operand, in
+// order and case insensitively, using the index, instead of scanning the
+// values for the operand.
+func WithFullTextAttributes(compositeKeys []string) Option {
+	return func(es *EventSink) {
+		for _, key := range compositeKeys {
+			es.fullText[key] = struct{}{}
+		}
+	}
+}
+
+// NewEventSink opens the SQLite database at path, creating it and its schema
+// if needed. Events written to the sink are attributed to the specified
+// chainID.
+//
+// SQLite support requires cgo, and is only compiled in with the sqlite build
+// tag (make build COMETBFT_BUILD_OPTIONS=sqlite).
+func NewEventSink(path, chainID string, options ...Option) (*EventSink, error) {
+	if err := checkDriver(); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(driverName, dataSourceName(path))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("installing schema: %w", err)
+	}
+
+	es := &EventSink{
+		store:    db,
+		chainID:  chainID,
+		fullText: make(map[string]struct{}),
+	}
+	for _, option := range options {
+		option(es)
+	}
+	if err := es.indexFullText(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("full-text indexing: %w", err)
+	}
+	return es, nil
+}
+
+// indexFullText indexes the values of the full-text attributes indexed
+// before they were set as such.
+func (es *EventSink) indexFullText() error {
+	for key := range es.fullText {
+		if _, err := es.store.Exec(`
+INSERT INTO `+tableAttributeValues+` (docid, value)
+  SELECT rowid, value FROM `+tableAttributes+`
+  WHERE composite_key = ? AND rowid NOT IN (SELECT docid FROM `+tableAttributeValues+`);
+`, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DB returns the underlying SQLite connection used by the sink.
+// This is exported to support testing.
+func (es *EventSink) DB() *sql.DB { return es.store }
+
+// runInTransaction executes query in a fresh database transaction.
+// If query reports an error, the transaction is rolled back and the
+// error from query is reported to the caller.
+// Otherwise, the result of committing the transaction is returned.
+func runInTransaction(db *sql.DB, query func(*sql.Tx) error) error {
+	dbtx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := query(dbtx); err != nil {
+		_ = dbtx.Rollback() // report the initial error, not the rollback
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// queryWithID executes the specified SQL query with the given arguments,
+// expecting a single-row, single-column result containing an ID. If the query
+// succeeds, the ID from the result is returned.
+func queryWithID(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	var id int64
+	if err := tx.QueryRow(query, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// insertEvents inserts a slice of events and any indexed attributes of those
+// events into the database associated with dbtx.
+//
+// If txID > 0, the event is attributed to the transaction with that
+// ID; otherwise it is recorded as a block event.
+func (es *EventSink) insertEvents(dbtx *sql.Tx, blockID, txID int64, evts []abci.Event) error {
+	// Populate the transaction ID field iff one is defined (> 0).
+	var txIDArg interface{}
+	if txID > 0 {
+		txIDArg = txID
+	}
+
+	for _, evt := range evts {
+		// Skip events with an empty type.
+		if evt.Type == "" {
+			continue
+		}
+
+		eid, err := queryWithID(dbtx, `
+INSERT INTO `+tableEvents+` (block_id, tx_id, type) VALUES (?, ?, ?)
+  RETURNING rowid;
+`, blockID, txIDArg, evt.Type)
+		if err != nil {
+			return err
+		}
+
+		// Add any attributes flagged for indexing.
+		for _, attr := range evt.Attributes {
+			if !attr.Index {
+				continue
+			}
+			compositeKey := evt.Type + "." + string(attr.Key)
+			aid, err := queryWithID(dbtx, `
+INSERT INTO `+tableAttributes+` (event_id, key, composite_key, value)
+  VALUES (?, ?, ?, ?)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;
+`, eid, string(attr.Key), compositeKey, string(attr.Value))
+			if err == sql.ErrNoRows {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			if _, ok := es.fullText[compositeKey]; ok {
+				if _, err := dbtx.Exec(`
+INSERT INTO `+tableAttributeValues+` (docid, value) VALUES (?, ?);
+`, aid, string(attr.Value)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// makeIndexedEvent constructs an event from the specified composite key and
+// value. If the key has the form "type.name", the event will have a single
+// attribute with that name and the value; otherwise the event will have only
+// a type and no attributes.
+func makeIndexedEvent(compositeKey, value string) abci.Event {
+	i := strings.Index(compositeKey, ".")
+	if i < 0 {
+		return abci.Event{Type: compositeKey}
+	}
+	return abci.Event{Type: compositeKey[:i], Attributes: []abci.EventAttribute{
+		{Key: []byte(compositeKey[i+1:]), Value: []byte(value), Index: true},
+	}}
+}
+
+// IndexBlockEvents indexes the specified block header, part of the
+// indexer.EventSink interface.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	ts := time.Now().UTC()
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		return es.indexBlockEvents(dbtx, ts, h)
+	})
+}
+
+// IndexBlocks indexes the blocks and their transaction results in a single
+// database transaction.
+func (es *EventSink) IndexBlocks(blocks []txindex.BlockEvents) error {
+	ts := time.Now().UTC()
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		for _, b := range blocks {
+			if err := es.indexBlockEvents(dbtx, ts, b.Header); err != nil {
+				return err
+			}
+			if b.Txs == nil {
+				continue
+			}
+			if err := es.indexTxEvents(dbtx, ts, b.Txs.Ops); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// indexBlockEvents indexes the block header in dbtx.
+func (es *EventSink) indexBlockEvents(dbtx *sql.Tx, ts time.Time, h types.EventDataNewBlockHeader) error {
+	// Add the block to the blocks table and report back its row ID for use
+	// in indexing the events for the block.
+	blockID, err := queryWithID(dbtx, `
+INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
+  VALUES (?, ?, ?)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;
+`, h.Header.Height, es.chainID, ts)
+	if err == sql.ErrNoRows {
+		return nil // we already saw this block; quietly succeed
+	} else if err != nil {
+		return fmt.Errorf("indexing block header: %w", err)
+	}
+
+	// Insert the special block meta-event for height.
+	if err := es.insertEvents(dbtx, blockID, 0, []abci.Event{
+		makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
+	}); err != nil {
+		return fmt.Errorf("block meta-events: %w", err)
+	}
+	if err := es.insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events); err != nil {
+		return fmt.Errorf("begin-block events: %w", err)
+	}
+	if err := es.insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
+		return fmt.Errorf("end-block events: %w", err)
+	}
+	return nil
+}
+
+// IndexTxEvents indexes the specified transaction results, part of the
+// indexer.EventSink interface. The results are indexed in a single database
+// transaction, and the block of each result must have been indexed before.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	ts := time.Now().UTC()
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		return es.indexTxEvents(dbtx, ts, txrs)
+	})
+}
+
+// indexTxEvents indexes the transaction results in dbtx.
+func (es *EventSink) indexTxEvents(dbtx *sql.Tx, ts time.Time, txrs []*abci.TxResult) error {
+	for _, txr := range txrs {
+		// Encode the result message in protobuf wire format for indexing.
+		resultData, err := proto.Marshal(txr)
+		if err != nil {
+			return fmt.Errorf("marshaling tx_result: %w", err)
+		}
+
+		// Index the hash of the underlying transaction as a hex string.
+		txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+		// Find the block associated with this transaction.
+		blockID, err := queryWithID(dbtx, `
+SELECT rowid FROM `+tableBlocks+` WHERE height = ? AND chain_id = ?;
+`, txr.Height, es.chainID)
+		if err != nil {
+			return fmt.Errorf("finding block ID: %w", err)
+		}
+
+		// Insert a record for this tx_result and capture its ID for indexing events.
+		txID, err := queryWithID(dbtx, `
+INSERT INTO `+tableTxResults+` (block_id, "index", created_at, tx_hash, tx_result)
+  VALUES (?, ?, ?, ?, ?)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;
+`, blockID, txr.Index, ts, txHash, resultData)
+		if err == sql.ErrNoRows {
+			continue // we already saw this transaction; quietly succeed
+		} else if err != nil {
+			return fmt.Errorf("indexing tx_result: %w", err)
+		}
+
+		// Insert the special transaction meta-events for hash and height.
+		if err := es.insertEvents(dbtx, blockID, txID, []abci.Event{
+			makeIndexedEvent(types.TxHashKey, txHash),
+			makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
+		}); err != nil {
+			return fmt.Errorf("indexing transaction meta-events: %w", err)
+		}
+		// Index any events packaged with the transaction.
+		if err := es.insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
+			return fmt.Errorf("indexing transaction events: %w", err)
+		}
+	}
+	return nil
+}
+
+// SearchBlockEvents returns the heights of the blocks with events matching q,
+// in ascending order.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	filter, args, err := es.matchingRowsSQL(q, tableBlocks+".rowid", "block_id", "tx_id IS NULL")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := es.store.QueryContext(ctx, `
+SELECT height FROM `+tableBlocks+`
+  WHERE chain_id = ?`+filter+`
+  ORDER BY height;
+`, append([]interface{}{es.chainID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var heights []int64
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, err
+		}
+		heights = append(heights, height)
+	}
+	return heights, rows.Err()
+}
+
+// SearchTxEvents returns the results of the transactions with events matching
+// q, ordered by height and index.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	filter, args, err := es.matchingRowsSQL(q, tableTxResults+".rowid", "tx_id", "tx_id IS NOT NULL")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := es.store.QueryContext(ctx, `
+SELECT tx_result FROM `+tableTxResults+` JOIN `+tableBlocks+` ON (`+tableBlocks+`.rowid = block_id)
+  WHERE chain_id = ?`+filter+`
+  ORDER BY height, "index";
+`, append([]interface{}{es.chainID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*abci.TxResult
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, err
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		results = append(results, txr)
+	}
+	return results, rows.Err()
+}
+
+// GetTxByHash returns the result of the transaction with the given hash, or
+// nil if it is not indexed.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	if len(hash) == 0 {
+		return nil, txindex.ErrorEmptyHash
+	}
+
+	var resultData []byte
+	err := es.store.QueryRow(`
+SELECT tx_result FROM `+tableTxResults+` JOIN `+tableBlocks+` ON (`+tableBlocks+`.rowid = block_id)
+  WHERE tx_hash = ? AND chain_id = ?;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&resultData)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting tx_result: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+	}
+	return txr, nil
+}
+
+// HasBlock returns true if the block at height h has been indexed.
+func (es *EventSink) HasBlock(h int64) (bool, error) {
+	var exists bool
+	err := es.store.QueryRow(`
+SELECT EXISTS (SELECT 1 FROM `+tableBlocks+` WHERE height = ? AND chain_id = ?);
+`, h, es.chainID).Scan(&exists)
+	return exists, err
+}
+
+// Stop closes the underlying SQLite database.
+func (es *EventSink) Stop() error { return es.store.Close() }
+
+// matchingRowsSQL translates the conditions of q into SQL filters, each
+// restricting the rows identified by rowColumn to those with an event matching
+// the condition. idColumn is the column of the events table referencing the
+// rows, and eventFilter restricts the events to block or transaction events.
+func (es *EventSink) matchingRowsSQL(q *query.Query, rowColumn, idColumn, eventFilter string) (string, []interface{}, error) {
+	conditions, err := q.Conditions()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(conditions) == 0 {
+		return "", nil, errors.New("the query has no conditions")
+	}
+
+	var (
+		filter strings.Builder
+		args   []interface{}
+	)
+	for _, c := range conditions {
+		valueFilter, valueArgs, err := es.valueSQL(c)
+		if err != nil {
+			return "", nil, err
+		}
+		fmt.Fprintf(&filter, `
+    AND %s IN (SELECT %s FROM %s JOIN %s ON (%s.rowid = event_id)
+      WHERE %s AND composite_key = ?%s)`,
+			rowColumn, idColumn, tableEvents, tableAttributes, tableEvents, eventFilter, valueFilter)
+		args = append(args, c.CompositeKey)
+		args = append(args, valueArgs...)
+	}
+	return filter.String(), args, nil
+}
+
+// valueSQL translates the operator and operand of a condition into an SQL
+// filter on the value of an attribute. Numbers are compared to the number
+// the value starts with, and times to the time the value holds, as the kv
+// indexer does. The values of the full-text attributes are matched using the
+// full-text index.
+func (es *EventSink) valueSQL(c query.Condition) (string, []interface{}, error) {
+	if c.Op == query.OpExists {
+		return "", nil, nil
+	}
+	if c.Op == query.OpContains {
+		operand, ok := c.Operand.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("%s: CONTAINS requires a string operand", c.CompositeKey)
+		}
+		if _, ok := es.fullText[c.CompositeKey]; ok {
+			return " AND " + tableAttributes + ".rowid IN (SELECT docid FROM " + tableAttributeValues +
+				" WHERE value MATCH ?)", []interface{}{fullTextPhrase(operand)}, nil
+		}
+		return " AND instr(value, ?) > 0", []interface{}{operand}, nil
+	}
+
+	var op string
+	switch c.Op {
+	case query.OpEqual:
+		op = "="
+	case query.OpLess:
+		op = "<"
+	case query.OpLessEqual:
+		op = "<="
+	case query.OpGreater:
+		op = ">"
+	case query.OpGreaterEqual:
+		op = ">="
+	default:
+		return "", nil, fmt.Errorf("%s: unsupported operator %d", c.CompositeKey, c.Op)
+	}
+
+	switch operand := c.Operand.(type) {
+	case string:
+		return " AND value " + op + " ?", []interface{}{operand}, nil
+	case int64, float64:
+		return " AND CAST(value AS REAL) " + op + " ?", []interface{}{operand}, nil
+	case time.Time:
+		return " AND julianday(value) " + op + " julianday(?)",
+			[]interface{}{operand.UTC().Format(time.RFC3339Nano)}, nil
+	default:
+		return "", nil, fmt.Errorf("%s: unsupported operand %v", c.CompositeKey, c.Operand)
+	}
+}
+
+// fullTextPhrase returns the full-text query matching the words of s in order.
+func fullTextPhrase(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, " ") + `"`
+}
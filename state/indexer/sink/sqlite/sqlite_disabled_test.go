@@ -0,0 +1,17 @@
+//go:build !sqlite
+// +build !sqlite
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteDisabled(t *testing.T) {
+	// the tests run without the sqlite build tag
+	_, err := NewEventSink(filepath.Join(t.TempDir(), "tx_index.sqlite"), "test-chainID")
+	assert.Error(t, err)
+}
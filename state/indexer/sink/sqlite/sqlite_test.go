@@ -0,0 +1,246 @@
+//go:build sqlite
+// +build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = "test-chainID"
+
+func newTestSink(t *testing.T) *EventSink {
+	es, err := NewEventSink(filepath.Join(t.TempDir(), "tx_index.sqlite"), chainID)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = es.Stop() })
+	return es
+}
+
+func TestIndexing(t *testing.T) {
+	es := newTestSink(t)
+	ctx := context.Background()
+
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, es.BlockIndexer().Index(newTestBlockHeader(height)))
+
+		batch := txindex.NewBatch(2)
+		for i := uint32(0); i < 2; i++ {
+			require.NoError(t, batch.Add(txResultWithEvents(height, i, []abci.Event{
+				makeIndexedEvent("account.number", fmt.Sprint(height*10+int64(i))),
+				makeIndexedEvent("account.owner", fmt.Sprintf("Ivan-%d", i)),
+				makeIndexedEvent("transfer.amount", fmt.Sprintf("%dstake", height*100)),
+				makeIndexedEvent("time.at", time.Date(2023, 1, int(height), 0, 0, 0, 0, time.UTC).Format(time.RFC3339)),
+				{Type: "", Attributes: []abci.EventAttribute{{Key: []byte("not"), Value: []byte("indexed")}}},
+			})))
+		}
+		require.NoError(t, es.TxIndexer().AddBatch(batch))
+	}
+
+	// indexing again is a no-op
+	require.NoError(t, es.BlockIndexer().Index(newTestBlockHeader(1)))
+	require.NoError(t, es.TxIndexer().Index(txResultWithEvents(1, 0, nil)))
+
+	t.Run("Get", func(t *testing.T) {
+		txr := txResultWithEvents(2, 1, nil)
+		got, err := es.TxIndexer().Get(types.Tx(txr.Tx).Hash())
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.EqualValues(t, 2, got.Height)
+		assert.EqualValues(t, 1, got.Index)
+
+		got, err = es.TxIndexer().Get(types.Tx("missing").Hash())
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		_, err = es.TxIndexer().Get(nil)
+		assert.ErrorIs(t, err, txindex.ErrorEmptyHash)
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		ok, err := es.BlockIndexer().Has(3)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = es.BlockIndexer().Has(4)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	txTests := []struct {
+		query string
+		want  [][2]int64 // height and index
+	}{
+		{"tx.height = 2", [][2]int64{{2, 0}, {2, 1}}},
+		{"tx.height >= 2 AND account.owner = 'Ivan-1'", [][2]int64{{2, 1}, {3, 1}}},
+		{"account.number > 20", [][2]int64{{2, 1}, {3, 0}, {3, 1}}},
+		{"account.number < 11.5", [][2]int64{{1, 0}, {1, 1}}},
+		{"transfer.amount <= 100", [][2]int64{{1, 0}, {1, 1}}},
+		{"account.owner CONTAINS 'an-0'", [][2]int64{{1, 0}, {2, 0}, {3, 0}}},
+		{"account.owner EXISTS AND tx.height = 3", [][2]int64{{3, 0}, {3, 1}}},
+		{"time.at > TIME 2023-01-02T00:00:00Z", [][2]int64{{3, 0}, {3, 1}}},
+		{"time.at <= DATE 2023-01-01", [][2]int64{{1, 0}, {1, 1}}},
+		{"account.owner = 'Ivan-2'", nil},
+		{"begin_event.proposer = 'FCAA001'", nil},
+	}
+	for _, tc := range txTests {
+		t.Run(tc.query, func(t *testing.T) {
+			results, err := es.TxIndexer().Search(ctx, query.MustParse(tc.query))
+			require.NoError(t, err)
+			var got [][2]int64
+			for _, txr := range results {
+				got = append(got, [2]int64{txr.Height, int64(txr.Index)})
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	blockTests := []struct {
+		query string
+		want  []int64
+	}{
+		{"block.height > 1", []int64{2, 3}},
+		{"begin_event.proposer = 'FCAA001' AND end_event.foo <= 200", []int64{1, 2}},
+		{"thingy.whatzit EXISTS", []int64{1, 2, 3}},
+		{"account.owner EXISTS", nil},
+	}
+	for _, tc := range blockTests {
+		t.Run(tc.query, func(t *testing.T) {
+			heights, err := es.BlockIndexer().Search(ctx, query.MustParse(tc.query))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, heights)
+		})
+	}
+}
+
+func TestFullTextSearch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tx_index.sqlite")
+	ctx := context.Background()
+	search := func(es *EventSink, q string) []uint32 {
+		results, err := es.TxIndexer().Search(ctx, query.MustParse(q))
+		require.NoError(t, err)
+		indexes := make([]uint32, len(results))
+		for i, r := range results {
+			indexes[i] = r.Index
+		}
+		return indexes
+	}
+
+	es, err := NewEventSink(path, chainID)
+	require.NoError(t, err)
+	require.NoError(t, es.BlockIndexer().Index(newTestBlockHeader(1)))
+	batch := txindex.NewBatch(3)
+	for i, memo := range []string{"Happy birthday Alice!", "Rent for March", "birthday gift"} {
+		require.NoError(t, batch.Add(txResultWithEvents(1, uint32(i), []abci.Event{
+			makeIndexedEvent("tx.memo", memo),
+		})))
+	}
+	require.NoError(t, es.TxIndexer().AddBatch(batch))
+
+	// the values are scanned for the operand without the full-text index
+	assert.Equal(t, []uint32{0, 2}, search(es, "tx.memo CONTAINS 'birth'"))
+	require.NoError(t, es.Stop())
+
+	// the values indexed before are full-text indexed when opening the sink
+	es, err = NewEventSink(path, chainID, WithFullTextAttributes([]string{"tx.memo"}))
+	require.NoError(t, err)
+	defer es.Stop()
+	require.NoError(t, es.BlockIndexer().Index(newTestBlockHeader(2)))
+	require.NoError(t, es.TxIndexer().Index(txResultWithEvents(2, 3, []abci.Event{
+		makeIndexedEvent("tx.memo", "a BIRTHDAY party"),
+	})))
+
+	assert.Equal(t, []uint32{0, 2, 3}, search(es, "tx.memo CONTAINS 'birthday'"))
+	assert.Equal(t, []uint32{0}, search(es, "tx.memo CONTAINS 'birthday alice'"))
+	assert.Equal(t, []uint32{3}, search(es, "tx.memo CONTAINS 'birthday party' AND tx.height = 2"))
+	assert.Empty(t, search(es, "tx.memo CONTAINS 'birth'"))
+	assert.Empty(t, search(es, "tx.memo CONTAINS 'alice birthday'"))
+}
+
+func TestIndexTxWithoutBlock(t *testing.T) {
+	es := newTestSink(t)
+	assert.Error(t, es.TxIndexer().Index(txResultWithEvents(1, 0, nil)))
+}
+
+func TestIndexBlocks(t *testing.T) {
+	es := newTestSink(t)
+	ctx := context.Background()
+
+	var blocks []txindex.BlockEvents
+	for height := int64(1); height <= 3; height++ {
+		batch := txindex.NewBatch(1)
+		require.NoError(t, batch.Add(txResultWithEvents(height, 0, []abci.Event{
+			makeIndexedEvent("account.owner", fmt.Sprintf("Ivan-%d", height)),
+		})))
+		blocks = append(blocks, txindex.BlockEvents{Header: newTestBlockHeader(height), Txs: batch})
+	}
+	require.NoError(t, es.TxIndexer().IndexBlocks(blocks))
+	// indexing again is a no-op
+	require.NoError(t, es.TxIndexer().IndexBlocks(blocks[1:]))
+
+	heights, err := es.BlockIndexer().Search(ctx, query.MustParse("block.height >= 2"))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, heights)
+
+	txrs, err := es.TxIndexer().Search(ctx, query.MustParse("account.owner = 'Ivan-2'"))
+	require.NoError(t, err)
+	require.Len(t, txrs, 1)
+	assert.EqualValues(t, 2, txrs[0].Height)
+
+	// a failing block rolls back the whole batch
+	batch := txindex.NewBatch(1)
+	require.NoError(t, batch.Add(txResultWithEvents(5, 0, nil)))
+	err = es.TxIndexer().IndexBlocks([]txindex.BlockEvents{
+		{Header: newTestBlockHeader(4), Txs: txindex.NewBatch(0)},
+		{Header: types.EventDataNewBlockHeader{}, Txs: batch},
+	})
+	require.Error(t, err)
+	ok, err := es.BlockIndexer().Has(4)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// newTestBlockHeader constructs a fresh copy of a block header containing
+// known test values to exercise the indexer.
+func newTestBlockHeader(height int64) types.EventDataNewBlockHeader {
+	return types.EventDataNewBlockHeader{
+		Header: types.Header{Height: height},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{
+				makeIndexedEvent("begin_event.proposer", "FCAA001"),
+				makeIndexedEvent("thingy.whatzit", "O.O"),
+			},
+		},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{
+				makeIndexedEvent("end_event.foo", fmt.Sprint(100*height)),
+				makeIndexedEvent("thingy.whatzit", "-.O"),
+			},
+		},
+	}
+}
+
+// txResultWithEvents constructs a fresh transaction result with fixed values
+// for testing, that includes the specified events.
+func txResultWithEvents(height int64, index uint32, events []abci.Event) *abci.TxResult {
+	return &abci.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     types.Tx(fmt.Sprintf("HELLO WORLD %d/%d", height, index)),
+		Result: abci.ResponseDeliverTx{
+			Data:   []byte{0},
+			Code:   abci.CodeTypeOK,
+			Events: events,
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+var schemaVersionKey = []byte("schemaVersion")
+
+// Migration upgrades the data of the state store from the previous schema
+// version, e.g. to change the format of its keys.
+type Migration struct {
+	// Version is the schema version of the migrated data.
+	Version uint64
+	// Description is a human readable summary of the changes.
+	Description string
+	// Apply migrates the data of db from the previous version. The version is
+	// recorded by Migrate once applied.
+	Apply func(db dbm.DB) error
+}
+
+// migrations are the registered migrations of the schema, by increasing
+// version.
+var migrations []Migration
+
+func init() {
+	RegisterMigration(Migration{
+		Version:     1,
+		Description: "record the schema version",
+		Apply:       func(dbm.DB) error { return nil },
+	})
+}
+
+// RegisterMigration registers the migration of the state store schema to the
+// next version. It panics if the version doesn't follow the latest one
+// registered, so the migrations must be registered in order, e.g. from init.
+func RegisterMigration(migration Migration) {
+	if migration.Version != LatestSchemaVersion()+1 {
+		panic(fmt.Sprintf("registering the state store migration to version %d after version %d",
+			migration.Version, LatestSchemaVersion()))
+	}
+	if migration.Apply == nil {
+		panic(fmt.Sprintf("no Apply for the state store migration to version %d", migration.Version))
+	}
+	migrations = append(migrations, migration)
+}
+
+// LatestSchemaVersion returns the version of the state store schema after all
+// the registered migrations.
+func LatestSchemaVersion() uint64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the schema version of the state store of db.
+//
+// The stores created before the schema was versioned are at version 0, and an
+// empty store is initialized at the latest version.
+func SchemaVersion(db dbm.DB) (uint64, error) {
+	bz, err := db.Get(schemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) > 0 {
+		if len(bz) != 8 {
+			return 0, fmt.Errorf("invalid schema version length %d", len(bz))
+		}
+		return binary.BigEndian.Uint64(bz), nil
+	}
+
+	it, err := db.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	empty := !it.Valid()
+	it.Close()
+	if !empty {
+		return 0, nil
+	}
+	latest := LatestSchemaVersion()
+	return latest, setSchemaVersion(db, latest)
+}
+
+// Migrate applies the registered migrations of the state store of db from
+// fromVersion, the current version of the store, to toVersion, in order, and
+// returns those applied. The version is recorded after each migration, so
+// that an interrupted migration resumes from the last one applied.
+func Migrate(db dbm.DB, fromVersion, toVersion uint64) ([]Migration, error) {
+	if latest := LatestSchemaVersion(); fromVersion > latest {
+		return nil, fmt.Errorf("the schema version %d is newer than the latest version %d supported", fromVersion, latest)
+	} else if toVersion > latest {
+		return nil, fmt.Errorf("no migration to schema version %d, the latest version is %d", toVersion, latest)
+	}
+	if fromVersion > toVersion {
+		return nil, fmt.Errorf("can't migrate the schema down from version %d to %d", fromVersion, toVersion)
+	}
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return nil, fmt.Errorf("loading the schema version: %w", err)
+	}
+	if version != fromVersion {
+		return nil, fmt.Errorf("the schema version is %d, not %d", version, fromVersion)
+	}
+
+	var applied []Migration
+	for _, migration := range migrations[fromVersion:toVersion] {
+		if err := migration.Apply(db); err != nil {
+			return applied, fmt.Errorf("migrating to schema version %d: %w", migration.Version, err)
+		}
+		if err := setSchemaVersion(db, migration.Version); err != nil {
+			return applied, fmt.Errorf("recording schema version %d: %w", migration.Version, err)
+		}
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+// setSchemaVersion records the schema version of the state store of db.
+func setSchemaVersion(db dbm.DB, version uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+	return db.SetSync(schemaVersionKey, bz)
+}
@@ -0,0 +1,83 @@
+package state
+
+import (
+	"errors"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	registered := migrations
+	t.Cleanup(func() { migrations = registered })
+
+	// an empty store is at the latest version
+	db := dbm.NewMemDB()
+	version, err := SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, version)
+
+	// and a store created before the versioning at version 0
+	db = dbm.NewMemDB()
+	require.NoError(t, db.Set(stateKey, []byte{1}))
+	version, err = SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, version)
+
+	RegisterMigration(Migration{
+		Version:     2,
+		Description: "rename the state key",
+		Apply: func(db dbm.DB) error {
+			bz, err := db.Get(stateKey)
+			if err != nil {
+				return err
+			}
+			if err := db.Set([]byte("state"), bz); err != nil {
+				return err
+			}
+			return db.Delete(stateKey)
+		},
+	})
+	failed := errors.New("failed")
+	RegisterMigration(Migration{
+		Version: 3,
+		Apply:   func(dbm.DB) error { return failed },
+	})
+	assert.Panics(t, func() { RegisterMigration(Migration{Version: 5, Apply: func(dbm.DB) error { return nil }}) })
+	assert.EqualValues(t, 3, LatestSchemaVersion())
+
+	_, err = Migrate(db, 1, 2)
+	assert.Error(t, err, "the store is at version 0")
+	_, err = Migrate(db, 0, 4)
+	assert.Error(t, err, "no migration to version 4")
+
+	applied, err := Migrate(db, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.EqualValues(t, 1, applied[0].Version)
+	assert.EqualValues(t, 2, applied[1].Version)
+	bz, err := db.Get([]byte("state"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, bz)
+	version, err = SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, version)
+
+	// the version of the last migration applied is kept on failure
+	applied, err = Migrate(db, 2, 3)
+	assert.ErrorIs(t, err, failed)
+	assert.Empty(t, applied)
+	version, err = SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, version)
+
+	_, err = Migrate(db, 2, 1)
+	assert.Error(t, err, "can't migrate down")
+
+	// a store newer than the latest version supported isn't used
+	migrations = registered
+	_, err = Migrate(db, 2, LatestSchemaVersion())
+	assert.Error(t, err)
+}
@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	crypto "github.com/tendermint/tendermint/crypto"
+
 	mock "github.com/stretchr/testify/mock"
+
 	state "github.com/tendermint/tendermint/state"
 
 	tendermintstate "github.com/tendermint/tendermint/proto/tendermint/state"
@@ -111,6 +114,29 @@ func (_m *Store) LoadConsensusParams(_a0 int64) (types.ConsensusParams, error) {
 	return r0, r1
 }
 
+// LoadConsensusParamsRange provides a mock function with given fields: _a0, _a1
+func (_m *Store) LoadConsensusParamsRange(_a0 int64, _a1 int64) ([]state.ConsensusParamsChange, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []state.ConsensusParamsChange
+	if rf, ok := ret.Get(0).(func(int64, int64) []state.ConsensusParamsChange); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.ConsensusParamsChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadFromDBOrGenesisDoc provides a mock function with given fields: _a0
 func (_m *Store) LoadFromDBOrGenesisDoc(_a0 *tenderminttypes.GenesisDoc) (state.State, error) {
 	ret := _m.Called(_a0)
@@ -176,6 +202,29 @@ func (_m *Store) LoadLastABCIResponse(_a0 int64) (*tendermintstate.ABCIResponses
 	return r0, r1
 }
 
+// LoadProposer provides a mock function with given fields: _a0
+func (_m *Store) LoadProposer(_a0 int64) (crypto.Address, error) {
+	ret := _m.Called(_a0)
+
+	var r0 crypto.Address
+	if rf, ok := ret.Get(0).(func(int64) crypto.Address); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(crypto.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*tenderminttypes.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -213,6 +262,20 @@ func (_m *Store) PruneStates(_a0 int64, _a1 int64) error {
 	return r0
 }
 
+// Rollback provides a mock function with given fields: _a0
+func (_m *Store) Rollback(_a0 state.State) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(state.State) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: _a0
 func (_m *Store) Save(_a0 state.State) error {
 	ret := _m.Called(_a0)
@@ -241,6 +304,20 @@ func (_m *Store) SaveABCIResponses(_a0 int64, _a1 *tendermintstate.ABCIResponses
 	return r0
 }
 
+// SaveProposer provides a mock function with given fields: _a0, _a1
+func (_m *Store) SaveProposer(_a0 int64, _a1 crypto.Address) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, crypto.Address) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewStore interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,173 @@
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+var statesPrunedHeightKey = []byte("prunedHeight")
+
+// PruningOptions are the policy of the PruningService, like the pruning
+// options of the Cosmos SDK.
+type PruningOptions struct {
+	// KeepRecent is the number of the latest heights whose states are kept.
+	KeepRecent int64
+	// KeepEvery keeps the states of the heights multiple of it, if not 0.
+	KeepEvery int64
+	// Interval is the interval between two runs of the service.
+	Interval time.Duration
+}
+
+// ValidateBasic performs basic validation of the options.
+func (o PruningOptions) ValidateBasic() error {
+	if o.KeepRecent < 0 {
+		return errors.New("keep recent can't be negative")
+	}
+	if o.KeepEvery < 0 {
+		return errors.New("keep every can't be negative")
+	}
+	if o.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	return nil
+}
+
+// PruningService is a service pruning periodically the states of the store,
+// the validator sets, consensus params and ABCI responses, below the retain
+// height of its policy, instead of along with the blocks. The states of the
+// blocks of the block store are never pruned, so that their validator sets
+// remain available, e.g. for the light clients.
+type PruningService struct {
+	service.BaseService
+
+	store      Store
+	blockStore BlockStore
+	db         dbm.DB
+	options    PruningOptions
+}
+
+// NewPruningService returns a service pruning the states of store with the
+// policy of options. The height up to which the states are pruned is
+// persisted in db.
+func NewPruningService(store Store, blockStore BlockStore, db dbm.DB, options PruningOptions) *PruningService {
+	ps := &PruningService{
+		store:      store,
+		blockStore: blockStore,
+		db:         db,
+		options:    options,
+	}
+	ps.BaseService = *service.NewBaseService(nil, "StatePruningService", ps)
+	return ps
+}
+
+// OnStart implements service.Service by starting to prune periodically.
+func (ps *PruningService) OnStart() error {
+	go ps.pruneRoutine()
+	return nil
+}
+
+func (ps *PruningService) pruneRoutine() {
+	ticker := time.NewTicker(ps.options.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := ps.Prune(); err != nil {
+				ps.Logger.Error("failed to prune the states", "err", err)
+			}
+		case <-ps.Quit():
+			return
+		}
+	}
+}
+
+// RetainHeight returns the height of the first state retained: the first of
+// the KeepRecent latest heights, and at most the base of the block store, or
+// 0 if all are retained.
+func (ps *PruningService) RetainHeight() int64 {
+	base, height := ps.blockStore.Base(), ps.blockStore.Height()
+	if base == 0 {
+		return 0
+	}
+	retainHeight := height - ps.options.KeepRecent + 1
+	if retainHeight > base {
+		retainHeight = base
+	}
+	if retainHeight < 0 {
+		return 0
+	}
+	return retainHeight
+}
+
+// Prune prunes the states from the height pruned last up to, but not
+// including, the retain height, except those of the heights multiple of
+// KeepEvery, and returns the height up to which the states are pruned.
+func (ps *PruningService) Prune() (int64, error) {
+	prunedHeight, err := ps.PrunedHeight()
+	if err != nil {
+		return 0, err
+	}
+	retainHeight := ps.RetainHeight()
+	keepEvery := ps.options.KeepEvery
+
+	// the states are pruned between the heights kept, so that PruneStates keeps
+	// what they need
+	for from := prunedHeight + 1; from < retainHeight; {
+		to := retainHeight
+		if keepEvery > 0 {
+			if next := (from/keepEvery + 1) * keepEvery; next < to {
+				to = next
+			}
+		}
+		start := from
+		if keepEvery > 0 && from%keepEvery == 0 { // kept
+			start++
+		}
+		if start < to {
+			if err := ps.store.PruneStates(start, to); err != nil {
+				return prunedHeight, fmt.Errorf("pruning the states from %d to %d: %w", start, to, err)
+			}
+		}
+		prunedHeight = to - 1
+		if err := saveStatesPrunedHeight(ps.db, prunedHeight); err != nil {
+			return prunedHeight, err
+		}
+		from = to
+
+		select {
+		case <-ps.Quit():
+			return prunedHeight, nil
+		default:
+		}
+	}
+	ps.Logger.Debug("pruned the states", "pruned_height", prunedHeight, "retain_height", retainHeight)
+	return prunedHeight, nil
+}
+
+// PrunedHeight returns the height up to which the states are pruned, or 0 if
+// none are.
+func (ps *PruningService) PrunedHeight() (int64, error) {
+	bz, err := ps.db.Get(statesPrunedHeightKey)
+	if err != nil {
+		return 0, fmt.Errorf("loading the pruned height: %w", err)
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("loading the pruned height: invalid length %d", len(bz))
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+func saveStatesPrunedHeight(db dbm.DB, height int64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return db.SetSync(statesPrunedHeightKey, bz)
+}
@@ -0,0 +1,99 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// rangeBlockStore is a block store holding the blocks from base to height.
+type rangeBlockStore struct {
+	sm.BlockStore
+	base, height int64
+}
+
+func (bs *rangeBlockStore) Base() int64   { return bs.base }
+func (bs *rangeBlockStore) Height() int64 { return bs.height }
+
+func TestPruningService(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	for h := int64(1); h <= 50; h++ {
+		require.NoError(t, stateStore.Save(sm.State{
+			InitialHeight:                    1,
+			LastBlockHeight:                  h - 1,
+			LastValidators:                   vals,
+			Validators:                       vals,
+			NextValidators:                   vals,
+			ConsensusParams:                  cmtproto.ConsensusParams{Block: cmtproto.BlockParams{MaxBytes: 10e6}},
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: 1,
+		}))
+		require.NoError(t, stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Data: []byte{1}}},
+		}))
+	}
+	retained := func(h int64) bool {
+		_, err := stateStore.LoadABCIResponses(h)
+		return err == nil
+	}
+
+	blockStore := &rangeBlockStore{base: 30, height: 50}
+	ps := sm.NewPruningService(stateStore, blockStore, dbm.NewMemDB(), sm.PruningOptions{
+		KeepRecent: 10,
+		KeepEvery:  20,
+		Interval:   time.Hour,
+	})
+	ps.SetLogger(log.TestingLogger())
+
+	// the states of the blocks of the block store are kept
+	assert.EqualValues(t, 30, ps.RetainHeight())
+	prunedHeight, err := ps.Prune()
+	require.NoError(t, err)
+	assert.EqualValues(t, 29, prunedHeight)
+	for h := int64(1); h <= 50; h++ {
+		assert.Equal(t, h == 20 || h >= 30, retained(h), h)
+	}
+	for _, h := range []int64{20, 30, 50} {
+		_, err := stateStore.LoadValidators(h)
+		assert.NoError(t, err, h)
+		_, err = stateStore.LoadConsensusParams(h)
+		assert.NoError(t, err, h)
+	}
+
+	// and the latest KeepRecent ones
+	blockStore.base = 45
+	assert.EqualValues(t, 41, ps.RetainHeight())
+	prunedHeight, err = ps.Prune()
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, prunedHeight)
+	for h := int64(30); h <= 50; h++ {
+		assert.Equal(t, h == 40 || h >= 41, retained(h), h)
+	}
+	_, err = stateStore.LoadValidators(40)
+	assert.NoError(t, err)
+
+	// the pruned height is persisted
+	prunedHeight, err = ps.PrunedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, prunedHeight)
+	prunedHeight, err = ps.Prune()
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, prunedHeight)
+
+	assert.Error(t, sm.PruningOptions{Interval: 0}.ValidateBasic())
+	assert.Error(t, sm.PruningOptions{KeepRecent: -1, Interval: time.Minute}.ValidateBasic())
+	assert.NoError(t, sm.PruningOptions{KeepRecent: 100, Interval: time.Minute}.ValidateBasic())
+}
@@ -110,3 +110,120 @@ func Rollback(bs BlockStore, ss Store) (int64, []byte, error) {
 
 	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
 }
+
+// RollbackBlockStore is the block store of a rollback over several heights,
+// whose blocks above the height rolled back to are deleted.
+type RollbackBlockStore interface {
+	BlockStore
+	DeleteBlocksFrom(height int64) error
+}
+
+// RollbackToHeight rolls back the CometBFT state (height n) to the state of a
+// previous height, unwinding the validator sets, consensus params and
+// ABCIResponses of the heights above it, and deletes the blocks above it from
+// the block store, e.g. to recover from a bad batch of blocks.
+// Note that this function does not affect application state, which must be
+// rolled back to the same height.
+//
+// The state and the blocks are each rolled back atomically, the state first:
+// if the blocks fail to be deleted, the rollback can be run again to delete
+// them.
+func RollbackToHeight(bs RollbackBlockStore, ss Store, height int64) (int64, []byte, error) {
+	invalidState, err := ss.Load()
+	if err != nil {
+		return -1, nil, err
+	}
+	if invalidState.IsEmpty() {
+		return -1, nil, errors.New("no state found")
+	}
+	if height < invalidState.InitialHeight || height < bs.Base() || height > invalidState.LastBlockHeight {
+		return -1, nil, fmt.Errorf("cannot roll back to height %d, not between the initial height %d, "+
+			"the base of the block store %d and the height of the state %d",
+			height, invalidState.InitialHeight, bs.Base(), invalidState.LastBlockHeight)
+	}
+	// the blocks may be left behind by a rollback to height which failed to
+	// delete them
+	storeHeight := bs.Height()
+	if storeHeight < invalidState.LastBlockHeight ||
+		(storeHeight > invalidState.LastBlockHeight+1 && height != invalidState.LastBlockHeight) {
+		return -1, nil, fmt.Errorf("statestore height (%d) is not one below or equal to blockstore height (%d)",
+			invalidState.LastBlockHeight, storeHeight)
+	}
+
+	rolledBackState := invalidState
+	if height < invalidState.LastBlockHeight {
+		if rolledBackState, err = rolledBackStateAt(bs, ss, invalidState, height); err != nil {
+			return -1, nil, err
+		}
+		if err := ss.Rollback(rolledBackState); err != nil {
+			return -1, nil, fmt.Errorf("failed to save rolled back state: %w", err)
+		}
+	}
+	if storeHeight > height {
+		if err := bs.DeleteBlocksFrom(height + 1); err != nil {
+			return -1, nil, fmt.Errorf("failed to delete the blocks above height %d: %w", height, err)
+		}
+	}
+
+	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
+}
+
+// rolledBackStateAt builds the state of height from the stores, below the
+// invalid state.
+func rolledBackStateAt(bs BlockStore, ss Store, invalidState State, height int64) (State, error) {
+	rollbackBlock := bs.LoadBlockMeta(height)
+	if rollbackBlock == nil {
+		return State{}, fmt.Errorf("block at height %d not found", height)
+	}
+	// The app hash and last results hash are only agreed upon in the following
+	// block.
+	nextBlock := bs.LoadBlockMeta(height + 1)
+	if nextBlock == nil {
+		return State{}, fmt.Errorf("block at height %d not found", height+1)
+	}
+
+	lastValidators, err := ss.LoadValidators(height)
+	if err != nil {
+		return State{}, err
+	}
+	validators, err := ss.LoadValidators(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	nextValidators, err := ss.LoadValidators(height + 2)
+	if err != nil {
+		return State{}, err
+	}
+	params, err := ss.LoadConsensusParams(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{
+				Block: version.BlockProtocol,
+				App:   params.Version.AppVersion,
+			},
+			Software: version.TMCoreSemVer,
+		},
+		// immutable fields
+		ChainID:       invalidState.ChainID,
+		InitialHeight: invalidState.InitialHeight,
+
+		LastBlockHeight: rollbackBlock.Header.Height,
+		LastBlockID:     rollbackBlock.BlockID,
+		LastBlockTime:   rollbackBlock.Header.Time,
+
+		// the heights at which the validators and params last changed are
+		// set by the store
+		NextValidators: nextValidators,
+		Validators:     validators,
+		LastValidators: lastValidators,
+
+		ConsensusParams: params,
+
+		LastResultsHash: nextBlock.Header.LastResultsHash,
+		AppHash:         nextBlock.Header.AppHash,
+	}, nil
+}
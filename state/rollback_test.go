@@ -2,11 +2,15 @@ package state_test
 
 import (
 	"crypto/rand"
+	"errors"
 	"testing"
+	"time"
 
 	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
@@ -164,3 +168,156 @@ func makeBlockIDRandom() types.BlockID {
 		},
 	}
 }
+
+// rollbackBlockStore is a block store holding the metas of some blocks, whose
+// latest blocks can be deleted.
+type rollbackBlockStore struct {
+	state.BlockStore
+	base, height int64
+	metas        map[int64]*types.BlockMeta
+	deleteErr    error
+}
+
+func (bs *rollbackBlockStore) Base() int64   { return bs.base }
+func (bs *rollbackBlockStore) Height() int64 { return bs.height }
+
+func (bs *rollbackBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	if height < bs.base || height > bs.height {
+		return nil
+	}
+	return bs.metas[height]
+}
+
+func (bs *rollbackBlockStore) DeleteBlocksFrom(height int64) error {
+	if bs.deleteErr != nil {
+		return bs.deleteErr
+	}
+	bs.height = height - 1
+	return nil
+}
+
+func TestRollbackToHeight(t *testing.T) {
+	const lastHeight = 10
+	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{})
+	blockStore := &rollbackBlockStore{base: 1, height: lastHeight, metas: map[int64]*types.BlockMeta{}}
+
+	// the validators change in blocks 4 and 8, and the params in blocks 3 and 9
+	vals, _ := types.RandValidatorSet(4, 10)
+	params := *types.DefaultConsensusParams()
+	st := state.State{
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{Block: version.BlockProtocol, App: params.Version.AppVersion},
+			Software:  version.TMCoreSemVer,
+		},
+		ChainID:                          "test-chain",
+		InitialHeight:                    1,
+		LastValidators:                   types.NewValidatorSet(nil),
+		Validators:                       vals,
+		NextValidators:                   vals.CopyIncrementProposerPriority(1),
+		LastHeightValidatorsChanged:      1,
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: 1,
+	}
+	states := make([]state.State, 0, lastHeight+1)
+	for h := int64(0); h <= lastHeight; h++ {
+		require.NoError(t, stateStore.Save(st))
+		states = append(states, st)
+		if h > 0 {
+			require.NoError(t, stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
+				BeginBlock: &abci.ResponseBeginBlock{},
+				EndBlock:   &abci.ResponseEndBlock{},
+			}))
+			require.NoError(t, stateStore.SaveProposer(h, st.Validators.GetProposer().Address))
+		}
+
+		next := st.Copy()
+		next.LastBlockHeight = h + 1
+		next.LastBlockID = makeBlockIDRandom()
+		next.LastBlockTime = time.Unix(h+1, 0).UTC()
+		next.LastValidators = st.Validators
+		next.Validators = st.NextValidators
+		next.NextValidators = st.NextValidators.CopyIncrementProposerPriority(1)
+		if h+1 == 4 || h+1 == 8 {
+			nextVals := st.NextValidators.Copy()
+			val := nextVals.Validators[0]
+			require.NoError(t, nextVals.UpdateWithChangeSet([]*types.Validator{
+				types.NewValidator(val.PubKey, val.VotingPower+1),
+			}))
+			nextVals.IncrementProposerPriority(1)
+			next.NextValidators = nextVals
+			next.LastHeightValidatorsChanged = h + 3
+		}
+		if h+1 == 3 || h+1 == 9 {
+			next.ConsensusParams.Block.MaxBytes += 1000
+			next.LastHeightConsensusParamsChanged = h + 2
+		}
+		next.AppHash = tmhash.Sum([]byte{byte(h + 1)})
+		next.LastResultsHash = tmhash.Sum([]byte{byte(h + 1), 1})
+		blockStore.metas[h+1] = &types.BlockMeta{
+			BlockID: next.LastBlockID,
+			Header: types.Header{
+				Height:          h + 1,
+				Time:            next.LastBlockTime,
+				AppHash:         st.AppHash,
+				LastResultsHash: st.LastResultsHash,
+			},
+		}
+		st = next
+	}
+
+	for _, height := range []int64{0, 11} {
+		_, _, err := state.RollbackToHeight(blockStore, stateStore, height)
+		require.Error(t, err, height)
+	}
+
+	// the state is rolled back even if the blocks fail to be deleted, and the
+	// rollback completes when run again
+	blockStore.deleteErr = errors.New("failed")
+	_, _, err := state.RollbackToHeight(blockStore, stateStore, 6)
+	require.Error(t, err)
+	blockStore.deleteErr = nil
+	height, appHash, err := state.RollbackToHeight(blockStore, stateStore, 6)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, height)
+	assert.EqualValues(t, states[6].AppHash, appHash)
+	assert.EqualValues(t, 6, blockStore.Height())
+
+	loaded, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, states[6], loaded)
+
+	// the data of the heights above the state are deleted
+	_, err = stateStore.LoadValidators(9)
+	assert.Error(t, err)
+	_, err = stateStore.LoadConsensusParams(8)
+	assert.Error(t, err)
+	_, err = stateStore.LoadABCIResponses(7)
+	assert.Error(t, err)
+	_, err = stateStore.LoadABCIResponses(6)
+	assert.NoError(t, err)
+	_, err = stateStore.LoadProposer(7)
+	assert.Error(t, err)
+	_, err = stateStore.LoadProposer(6)
+	assert.NoError(t, err)
+	_, err = stateStore.LoadLastABCIResponse(10)
+	assert.Error(t, err)
+
+	// and the chain continues from the state
+	for h := 7; h <= lastHeight; h++ {
+		require.NoError(t, stateStore.Save(states[h]))
+	}
+	for h := int64(1); h <= lastHeight+2; h++ {
+		loadedVals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err, h)
+		expected := states[lastHeight].NextValidators
+		if h <= lastHeight+1 {
+			expected = states[h-1].Validators
+		}
+		assert.Equal(t, expected.Hash(), loadedVals.Hash(), h)
+	}
+	for h := int64(1); h <= lastHeight+1; h++ {
+		loadedParams, err := stateStore.LoadConsensusParams(h)
+		require.NoError(t, err, h)
+		assert.Equal(t, states[h-1].ConsensusParams, loadedParams, h)
+	}
+}
@@ -238,16 +238,32 @@ func (state State) MakeBlock(
 	evidence []types.Evidence,
 	proposerAddress []byte,
 ) (*types.Block, *types.PartSet) {
-	// Build base block with block data.
-	block := types.MakeBlock(height, txs, commit, evidence)
+	return state.makeBlock(height, txs, commit, evidence, proposerAddress, state.blockTime(height, commit))
+}
 
-	// Set time.
-	var timestamp time.Time
-	if height == state.InitialHeight {
-		timestamp = state.LastBlockTime // genesis time
-	} else {
-		timestamp = MedianTime(commit, state.LastValidators)
+// blockTime returns the time of a block proposed at height on top of commit.
+func (state State) blockTime(height int64, commit *types.Commit) time.Time {
+	switch {
+	case types.IsPBTSEnabled(state.ConsensusParams):
+		return ProposerTime(state, height)
+	case height == state.InitialHeight:
+		return state.LastBlockTime // genesis time
+	default:
+		return MedianTime(commit, state.LastValidators)
 	}
+}
+
+// makeBlock is MakeBlock with the time of the block.
+func (state State) makeBlock(
+	height int64,
+	txs []types.Tx,
+	commit *types.Commit,
+	evidence []types.Evidence,
+	proposerAddress []byte,
+	timestamp time.Time,
+) (*types.Block, *types.PartSet) {
+	// Build base block with block data.
+	block := types.MakeBlock(height, txs, commit, evidence)
 
 	// Fill rest of header with state data.
 	block.Header.Populate(
@@ -261,6 +277,23 @@ func (state State) MakeBlock(
 	return block, block.MakePartSet(types.BlockPartSizeBytes)
 }
 
+// ProposerTime returns the time of a block proposed at height with
+// proposer-based timestamps: the time of the proposer, but no earlier than the
+// genesis time for the first block, and later than the previous block for the
+// next ones, in case the clock of the proposer is behind.
+func ProposerTime(state State, height int64) time.Time {
+	now := cmttime.Now()
+	switch {
+	case height == state.InitialHeight:
+		if now.Before(state.LastBlockTime) {
+			return state.LastBlockTime
+		}
+	case !now.After(state.LastBlockTime):
+		return state.LastBlockTime.Add(time.Millisecond)
+	}
+	return now
+}
+
 // MedianTime computes a median time for a given Commit (based on Timestamp field of votes messages) and the
 // corresponding validator set. The computed time is always between timestamps of
 // the votes sent by honest processes, i.e., a faulty processes can not arbitrarily increase or decrease the
@@ -327,6 +360,7 @@ func MakeGenesisState(genDoc *types.GenesisDoc) (State, error) {
 		validators := make([]*types.Validator, len(genDoc.Validators))
 		for i, val := range genDoc.Validators {
 			validators[i] = types.NewValidator(val.PubKey, val.Power)
+			validators[i].ProofOfPossession = val.ProofOfPossession
 		}
 		validatorSet = types.NewValidatorSet(validators)
 		nextValidatorSet = types.NewValidatorSet(validators).CopyIncrementProposerPriority(1)
@@ -8,6 +8,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
@@ -37,12 +38,30 @@ func calcABCIResponsesKey(height int64) []byte {
 	return []byte(fmt.Sprintf("abciResponsesKey:%v", height))
 }
 
+func calcProposerKey(height int64) []byte {
+	return []byte(fmt.Sprintf("proposerKey:%v", height))
+}
+
 //----------------------
 
 var (
 	lastABCIResponseKey = []byte("lastABCIResponseKey")
 )
 
+// The retention policies of the ABCIResponses, see
+// StoreOptions.ABCIResponsesRetention.
+const (
+	// ABCIResponsesRetainAll retains the whole responses.
+	ABCIResponsesRetainAll = "all"
+	// ABCIResponsesRetainTxResults retains the results of the txs, with their
+	// events, and the validator and consensus params updates, but not the
+	// events of BeginBlock and EndBlock.
+	ABCIResponsesRetainTxResults = "tx_results"
+	// ABCIResponsesRetainNoEvents retains the responses without any of their
+	// events.
+	ABCIResponsesRetainNoEvents = "no_events"
+)
+
 //go:generate ../scripts/mockery_generate.sh Store
 
 // Store defines the state store interface
@@ -66,12 +85,22 @@ type Store interface {
 	LoadLastABCIResponse(int64) (*cmtstate.ABCIResponses, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (cmtproto.ConsensusParams, error)
+	// LoadConsensusParamsRange loads the consensus params in effect between
+	// two heights, inclusive, and the heights at which they changed
+	LoadConsensusParamsRange(int64, int64) ([]ConsensusParamsChange, error)
 	// Save overwrites the previous state with the updated one
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *cmtstate.ABCIResponses) error
+	// LoadProposer loads the address of the proposer of the block at a given height
+	LoadProposer(int64) (crypto.Address, error)
+	// SaveProposer saves the address of the proposer of the block at a given height
+	SaveProposer(int64, crypto.Address) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
+	// Rollback overwrites the state with the state of a previous height, and
+	// deletes the data of the heights above it
+	Rollback(State) error
 	// PruneStates takes the height from which to start prning and which height stop at
 	PruneStates(int64, int64) error
 	// Close closes the connection with the database
@@ -92,6 +121,23 @@ type StoreOptions struct {
 	// the store will maintain only the response object from the latest
 	// height.
 	DiscardABCIResponses bool
+
+	// ABCIResponsesKeepRecent is the number of the latest heights whose
+	// ABCIResponses are retained, if not 0: the responses of the older heights
+	// are deleted as the new ones are saved.
+	ABCIResponsesKeepRecent int64
+
+	// ABCIResponsesRetention is what is retained of the ABCIResponses:
+	// ABCIResponsesRetainAll (or empty), ABCIResponsesRetainTxResults or
+	// ABCIResponsesRetainNoEvents. The last response is always saved whole,
+	// for the crash recovery.
+	ABCIResponsesRetention string
+
+	// ValidatorSetDeltaInterval is the number of changes of the validator set
+	// between two sets stored in full. The changes in between are stored as
+	// deltas from the set of the previous height, and rebuilt by
+	// LoadValidators. If 0, the sets are stored in full.
+	ValidatorSetDeltaInterval int64
 }
 
 var _ Store = (*dbStore)(nil)
@@ -232,6 +278,72 @@ func (store dbStore) Bootstrap(state State) error {
 	return store.db.SetSync(stateKey, state.Bytes())
 }
 
+// Rollback overwrites the current state with rolledBack, the state of a
+// previous height, e.g. to recover from a bad block, and deletes the validator
+// sets, consensus params, ABCIResponses and proposers of the heights above it,
+// along with the last ABCIResponse, in a single atomic batch. The heights at which its
+// validators and consensus params last changed are those stored with its
+// validator sets and consensus params, which are kept.
+func (store dbStore) Rollback(rolledBack State) error {
+	current, err := store.Load()
+	if err != nil {
+		return err
+	}
+	if current.IsEmpty() {
+		return errors.New("no state found")
+	}
+	height := rolledBack.LastBlockHeight
+	if height < rolledBack.InitialHeight || height >= current.LastBlockHeight {
+		return fmt.Errorf("cannot roll back the state of height %d to height %d", current.LastBlockHeight, height)
+	}
+
+	// the validators of the next height and the consensus params of the
+	// current height are stored along with the state
+	nextHeight := height + 1
+	valInfo, err := loadValidatorsInfo(store.db, nextHeight+1)
+	if err != nil {
+		return fmt.Errorf("validators at height %v not found: %w", nextHeight+1, err)
+	}
+	paramsInfo, err := store.loadConsensusParamsInfo(nextHeight)
+	if err != nil {
+		return fmt.Errorf("consensus params at height %v not found: %w", nextHeight, err)
+	}
+	rolledBack.LastHeightValidatorsChanged = valInfo.LastHeightChanged
+	rolledBack.LastHeightConsensusParamsChanged = paramsInfo.LastHeightChanged
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for h := nextHeight + 2; h <= current.LastBlockHeight+2; h++ {
+		if err := batch.Delete(calcValidatorsKey(h)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcValidatorsDeltaKey(h)); err != nil {
+			return err
+		}
+	}
+	for h := nextHeight + 1; h <= current.LastBlockHeight+1; h++ {
+		if err := batch.Delete(calcConsensusParamsKey(h)); err != nil {
+			return err
+		}
+	}
+	for h := nextHeight; h <= current.LastBlockHeight; h++ {
+		if err := batch.Delete(calcABCIResponsesKey(h)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcProposerKey(h)); err != nil {
+			return err
+		}
+	}
+	// the last ABCIResponse is of the latest height, above the state
+	if err := batch.Delete(lastABCIResponseKey); err != nil {
+		return err
+	}
+	if err := batch.Set(stateKey, rolledBack.Bytes()); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
 // PruneStates deletes states between the given heights (including from, excluding to). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at to must also exist.
@@ -270,6 +382,14 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 	defer batch.Close()
 	pruned := uint64(0)
 
+	// The validator set at to, if stored as a delta, is rebuilt from the heights
+	// pruned, so it's stored in full.
+	if valInfo.ValidatorSet == nil && valInfo.LastHeightChanged == to {
+		if err := store.setFullValidators(batch, to); err != nil {
+			return err
+		}
+	}
+
 	// We have to delete in reverse order, to avoid deleting previous heights that have validator
 	// sets and consensus params that we may need to retrieve.
 	for h := to - 1; h >= from; h-- {
@@ -279,25 +399,7 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 		if keepVals[h] {
 			v, err := loadValidatorsInfo(store.db, h)
 			if err != nil || v.ValidatorSet == nil {
-				vip, err := store.LoadValidators(h)
-				if err != nil {
-					return err
-				}
-
-				pvi, err := vip.ToProto()
-				if err != nil {
-					return err
-				}
-
-				v.ValidatorSet = pvi
-				v.LastHeightChanged = h
-
-				bz, err := v.Marshal()
-				if err != nil {
-					return err
-				}
-				err = batch.Set(calcValidatorsKey(h), bz)
-				if err != nil {
+				if err := store.setFullValidators(batch, h); err != nil {
 					return err
 				}
 			}
@@ -306,6 +408,10 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 			if err != nil {
 				return err
 			}
+			err = batch.Delete(calcValidatorsDeltaKey(h))
+			if err != nil {
+				return err
+			}
 		}
 
 		if keepParams[h] {
@@ -342,6 +448,10 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 		if err != nil {
 			return err
 		}
+		err = batch.Delete(calcProposerKey(h))
+		if err != nil {
+			return err
+		}
 		pruned++
 
 		// avoid batches growing too large by flushing to database regularly
@@ -453,13 +563,18 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *cmtstate.ABC
 	// If the flag is false then we save the ABCIResponse. This can be used for the /BlockResults
 	// query or to reindex an event using the command line.
 	if !store.DiscardABCIResponses {
-		bz, err := abciResponses.Marshal()
+		bz, err := retainedABCIResponses(abciResponses, store.ABCIResponsesRetention).Marshal()
 		if err != nil {
 			return err
 		}
 		if err := store.db.Set(calcABCIResponsesKey(height), bz); err != nil {
 			return err
 		}
+		if keepRecent := store.ABCIResponsesKeepRecent; keepRecent > 0 && height > keepRecent {
+			if err := store.db.Delete(calcABCIResponsesKey(height - keepRecent)); err != nil {
+				return err
+			}
+		}
 	}
 
 	// We always save the last ABCI response for crash recovery.
@@ -476,6 +591,59 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *cmtstate.ABC
 	return store.db.SetSync(lastABCIResponseKey, bz)
 }
 
+// retainedABCIResponses returns what is retained of abciResponses with the
+// retention policy, without modifying them.
+func retainedABCIResponses(abciResponses *cmtstate.ABCIResponses, retention string) *cmtstate.ABCIResponses {
+	if retention != ABCIResponsesRetainTxResults && retention != ABCIResponsesRetainNoEvents {
+		return abciResponses
+	}
+	retained := *abciResponses
+	if abciResponses.BeginBlock != nil {
+		retained.BeginBlock = &abci.ResponseBeginBlock{}
+	}
+	if abciResponses.EndBlock != nil {
+		endBlock := *abciResponses.EndBlock
+		endBlock.Events = nil
+		retained.EndBlock = &endBlock
+	}
+	if retention == ABCIResponsesRetainNoEvents {
+		retained.DeliverTxs = make([]*abci.ResponseDeliverTx, len(abciResponses.DeliverTxs))
+		for i, tx := range abciResponses.DeliverTxs {
+			txResult := *tx
+			txResult.Events = nil
+			retained.DeliverTxs[i] = &txResult
+		}
+	}
+	return &retained
+}
+
+//-----------------------------------------------------------------------------
+
+// LoadProposer loads the address of the proposer of the block at height, from
+// the proposer index, without loading its validator set. If not found,
+// ErrNoProposerForHeight is returned, e.g. for the heights pruned or synced
+// with state sync.
+func (store dbStore) LoadProposer(height int64) (crypto.Address, error) {
+	bz, err := store.db.Get(calcProposerKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, ErrNoProposerForHeight{height}
+	}
+	return crypto.Address(bz), nil
+}
+
+// SaveProposer persists the address of the proposer of the block at height
+// to the proposer index. It is pruned and rolled back along with the
+// ABCIResponses.
+func (store dbStore) SaveProposer(height int64, address crypto.Address) error {
+	if len(address) == 0 {
+		return fmt.Errorf("empty proposer address for height %d", height)
+	}
+	return store.db.Set(calcProposerKey(height), address)
+}
+
 //-----------------------------------------------------------------------------
 
 // LoadValidators loads the ValidatorSet for a given height.
@@ -488,6 +656,13 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	if valInfo.ValidatorSet == nil {
 		lastStoredHeight := lastStoredHeightFor(height, valInfo.LastHeightChanged)
 		valInfo2, err := loadValidatorsInfo(store.db, lastStoredHeight)
+		if err == nil && valInfo2.ValidatorSet == nil {
+			// the set may be stored as a delta from the previous height
+			var vs *types.ValidatorSet
+			if vs, err = store.loadValidatorsFromDelta(lastStoredHeight); err == nil && vs != nil {
+				valInfo2.ValidatorSet, err = vs.ToProto()
+			}
+		}
 		if err != nil || valInfo2.ValidatorSet == nil {
 			return nil,
 				fmt.Errorf("couldn't find validators at height %d (height %d was originally requested): %w",
@@ -502,7 +677,9 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 			return nil, err
 		}
 
-		vs.IncrementProposerPriority(cmtmath.SafeConvertInt32(height - lastStoredHeight)) // mutate
+		if height > lastStoredHeight { // the delta heights are stored as of themselves
+			vs.IncrementProposerPriority(cmtmath.SafeConvertInt32(height - lastStoredHeight)) // mutate
+		}
 		vi2, err := vs.ToProto()
 		if err != nil {
 			return nil, err
@@ -575,6 +752,10 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 		return err
 	}
 
+	if store.ValidatorSetDeltaInterval > 0 && height == lastHeightChanged {
+		return store.saveValidatorsDeltaOrInfo(height, valSet, bz)
+	}
+
 	err = store.db.Set(calcValidatorsKey(height), bz)
 	if err != nil {
 		return err
@@ -583,6 +764,31 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 	return nil
 }
 
+// saveValidatorsDeltaOrInfo persists the validator set changed at height as a
+// delta from the previous height if it can be, and otherwise in full, from its
+// encoded ValidatorsInfo bz, replacing any previous delta.
+func (store dbStore) saveValidatorsDeltaOrInfo(height int64, valSet *types.ValidatorSet, bz []byte) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	delta, err := store.encodeValidatorsDelta(height, valSet, len(bz))
+	if err != nil {
+		return err
+	}
+	if delta != nil {
+		if err := store.setValidatorsDelta(batch, height, delta); err != nil {
+			return err
+		}
+		return batch.Write()
+	}
+	if err := batch.Set(calcValidatorsKey(height), bz); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcValidatorsDeltaKey(height)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
 //-----------------------------------------------------------------------------
 
 // ConsensusParamsInfo represents the latest consensus params, or the last height it changed
@@ -613,6 +819,53 @@ func (store dbStore) LoadConsensusParams(height int64) (cmtproto.ConsensusParams
 	return paramsInfo.ConsensusParams, nil
 }
 
+// ConsensusParamsChange is the consensus params in effect from a height.
+type ConsensusParamsChange struct {
+	Height          int64
+	ConsensusParams cmtproto.ConsensusParams
+}
+
+// LoadConsensusParamsRange loads the consensus params in effect from height
+// from to height to, inclusive: the params at from, and each change up to to,
+// in increasing order of height. The params are found from their changes, so
+// the heights in between aren't loaded.
+func (store dbStore) LoadConsensusParamsRange(from, to int64) ([]ConsensusParamsChange, error) {
+	if from <= 0 || from > to {
+		return nil, fmt.Errorf("invalid range from height %d to height %d", from, to)
+	}
+	var changes []ConsensusParamsChange
+	for height := to; height >= from; {
+		paramsInfo, err := store.loadConsensusParamsInfo(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+		}
+		changed := paramsInfo.LastHeightChanged
+		if changed <= 0 || changed > height {
+			changed = height
+		}
+		params, err := store.LoadConsensusParams(changed)
+		if err != nil {
+			return nil, err
+		}
+		if changed < from {
+			changed = from
+		}
+		changes = append(changes, ConsensusParamsChange{Height: changed, ConsensusParams: params})
+		height = changed - 1
+	}
+
+	// the changes are found from the latest, and the params kept by the
+	// pruning look like changes, so they're merged with the previous ones
+	merged := make([]ConsensusParamsChange, 0, len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		if n := len(merged); n > 0 && merged[n-1].ConsensusParams.Equal(&changes[i].ConsensusParams) {
+			continue
+		}
+		merged = append(merged, changes[i])
+	}
+	return merged, nil
+}
+
 func (store dbStore) loadConsensusParamsInfo(height int64) (*cmtstate.ConsensusParamsInfo, error) {
 	buf, err := store.db.Get(calcConsensusParamsKey(height))
 	if err != nil {
@@ -161,6 +161,7 @@ func TestPruneStates(t *testing.T) {
 					},
 				})
 				require.NoError(t, err)
+				require.NoError(t, stateStore.SaveProposer(h, validator.Address))
 			}
 
 			// Test assertions
@@ -201,11 +202,76 @@ func TestPruneStates(t *testing.T) {
 					require.Error(t, err, "abci height %v", h)
 					require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err)
 				}
+
+				// the proposers are pruned along with the ABCI responses
+				proposer, err := stateStore.LoadProposer(h)
+				if expectABCI[h] {
+					require.NoError(t, err, "proposer height %v", h)
+					require.Equal(t, validator.Address, proposer)
+				} else {
+					require.Equal(t, sm.ErrNoProposerForHeight{Height: h}, err)
+				}
 			}
 		})
 	}
 }
 
+func TestLoadConsensusParamsRange(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	params := func(changed int64) cmtproto.ConsensusParams {
+		return cmtproto.ConsensusParams{Block: cmtproto.BlockParams{MaxBytes: 10e6 + changed}}
+	}
+
+	// the params change at the heights ending with 5
+	paramsChanged := int64(1)
+	for h := int64(1); h <= 30; h++ {
+		if h%10 == 5 {
+			paramsChanged = h
+		}
+		require.NoError(t, stateStore.Save(sm.State{
+			InitialHeight:                    1,
+			LastBlockHeight:                  h - 1,
+			LastValidators:                   vals,
+			Validators:                       vals,
+			NextValidators:                   vals,
+			ConsensusParams:                  params(paramsChanged),
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: paramsChanged,
+		}))
+	}
+
+	changes, err := stateStore.LoadConsensusParamsRange(3, 26)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.ConsensusParamsChange{
+		{Height: 3, ConsensusParams: params(1)},
+		{Height: 5, ConsensusParams: params(5)},
+		{Height: 15, ConsensusParams: params(15)},
+		{Height: 25, ConsensusParams: params(25)},
+	}, changes)
+
+	changes, err = stateStore.LoadConsensusParamsRange(16, 16)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.ConsensusParamsChange{{Height: 16, ConsensusParams: params(15)}}, changes)
+
+	// the params kept by the pruning aren't changes
+	require.NoError(t, stateStore.PruneStates(1, 20))
+	changes, err = stateStore.LoadConsensusParamsRange(20, 30)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.ConsensusParamsChange{
+		{Height: 20, ConsensusParams: params(15)},
+		{Height: 25, ConsensusParams: params(25)},
+	}, changes)
+
+	_, err = stateStore.LoadConsensusParamsRange(10, 30)
+	assert.Error(t, err)
+	_, err = stateStore.LoadConsensusParamsRange(5, 4)
+	assert.Error(t, err)
+	_, err = stateStore.LoadConsensusParamsRange(1, 31)
+	assert.Error(t, err)
+}
+
 func TestABCIResponsesResultsHash(t *testing.T) {
 	responses := &cmtstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
@@ -304,3 +370,61 @@ func TestLastABCIResponses(t *testing.T) {
 	})
 
 }
+
+func TestABCIResponsesRetention(t *testing.T) {
+	events := []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte("k"), Value: []byte("v")}}}}
+	responses := func() *cmtstate.ABCIResponses {
+		return &cmtstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{Events: events},
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: 1, Data: []byte("data"), Events: events}},
+			EndBlock: &abci.ResponseEndBlock{
+				ValidatorUpdates: []abci.ValidatorUpdate{{Power: 10}},
+				Events:           events,
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		retention             string
+		blockEvents, txEvents bool
+	}{
+		{"", true, true},
+		{sm.ABCIResponsesRetainAll, true, true},
+		{sm.ABCIResponsesRetainTxResults, false, true},
+		{sm.ABCIResponsesRetainNoEvents, false, false},
+	} {
+		stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+			ABCIResponsesKeepRecent: 3,
+			ABCIResponsesRetention:  tc.retention,
+		})
+		for h := int64(1); h <= 5; h++ {
+			saved := responses()
+			require.NoError(t, stateStore.SaveABCIResponses(h, saved))
+			// the responses saved aren't modified
+			assert.Equal(t, responses(), saved)
+		}
+
+		// the responses of the latest heights only are kept
+		for h := int64(1); h <= 2; h++ {
+			_, err := stateStore.LoadABCIResponses(h)
+			assert.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err, tc.retention)
+		}
+		for h := int64(3); h <= 5; h++ {
+			loaded, err := stateStore.LoadABCIResponses(h)
+			require.NoError(t, err, tc.retention)
+			assert.Equal(t, tc.blockEvents, len(loaded.BeginBlock.Events) > 0, tc.retention)
+			assert.Equal(t, tc.blockEvents, len(loaded.EndBlock.Events) > 0, tc.retention)
+			assert.Equal(t, tc.txEvents, len(loaded.DeliverTxs[0].Events) > 0, tc.retention)
+			assert.EqualValues(t, 1, loaded.DeliverTxs[0].Code)
+			assert.Equal(t, []byte("data"), loaded.DeliverTxs[0].Data)
+			assert.Len(t, loaded.EndBlock.ValidatorUpdates, 1)
+			// the results hash doesn't depend on the events
+			assert.Equal(t, sm.ABCIResponsesResultsHash(responses()), sm.ABCIResponsesResultsHash(loaded))
+		}
+
+		// the last response is kept whole for the crash recovery
+		last, err := stateStore.LoadLastABCIResponse(5)
+		require.NoError(t, err)
+		assert.Equal(t, responses(), last)
+	}
+}
@@ -8,9 +8,10 @@ import (
 // TxPreCheck returns a function to filter transactions before processing.
 // The function limits the size of a transaction to the block's maximum data size.
 func TxPreCheck(state State) mempl.PreCheckFunc {
-	maxDataBytes := types.MaxDataBytesNoEvidence(
+	maxDataBytes := types.MaxDataBytesNoEvidenceForKeyTypes(
 		state.ConsensusParams.Block.MaxBytes,
 		state.Validators.Size(),
+		state.ConsensusParams.Validator.PubKeyTypes,
 	)
 	return mempl.PreCheckMaxBytes(maxDataBytes)
 }
@@ -20,3 +21,20 @@ func TxPreCheck(state State) mempl.PreCheckFunc {
 func TxPostCheck(state State) mempl.PostCheckFunc {
 	return mempl.PostCheckMaxGas(state.ConsensusParams.Block.MaxGas)
 }
+
+// TxCheckContext returns the context of the checks of the transactions until
+// the next block: its height, the time of the latest block, and the address of
+// the proposer of the next block in its first round.
+func TxCheckContext(state State) mempl.CheckTxContext {
+	c := mempl.CheckTxContext{
+		Height: state.LastBlockHeight + 1,
+		Time:   state.LastBlockTime,
+	}
+	if state.InitialHeight > c.Height {
+		c.Height = state.InitialHeight
+	}
+	if proposer := state.Validators.GetProposer(); proposer != nil {
+		c.ProposerAddress = proposer.Address
+	}
+	return c
+}
@@ -10,6 +10,7 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	mempl "github.com/tendermint/tendermint/mempool"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
@@ -47,3 +48,21 @@ func TestTxFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestTxCheckContext(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.InitialHeight = 5
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+	require.NoError(t, err)
+
+	// before the first block, the txs are checked at the initial height
+	assert.Equal(t, mempl.CheckTxContext{
+		Height:          5,
+		Time:            genDoc.GenesisTime,
+		ProposerAddress: genDoc.Validators[0].Address,
+	}, sm.TxCheckContext(state))
+
+	state.LastBlockHeight = 7
+	assert.EqualValues(t, 8, sm.TxCheckContext(state).Height)
+}
@@ -0,0 +1,128 @@
+package txindex
+
+import (
+	"fmt"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// EventFilter selects the event attributes to index, among those the
+// application marks as indexed. Attributes are designated by their event type,
+// e.g. "transfer", matching all the attributes of the event, or by their
+// composite key, e.g. "transfer.sender".
+//
+// The filter only affects indexing: the attributes filtered out are kept in the
+// stored results, with their index flag unset.
+type EventFilter struct {
+	include map[string]struct{}
+	exclude map[string]struct{}
+}
+
+// NewEventFilter returns a filter indexing only the attributes matching
+// include, if not empty, and not matching exclude.
+func NewEventFilter(include, exclude []string) (*EventFilter, error) {
+	f := &EventFilter{}
+	var err error
+	if f.include, err = eventFilterSet(include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = eventFilterSet(exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func eventFilterSet(keys []string) (map[string]struct{}, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key == "" || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") {
+			return nil, fmt.Errorf("invalid event type or attribute key %q", key)
+		}
+		set[key] = struct{}{}
+	}
+	return set, nil
+}
+
+// IsEmpty reports whether the filter indexes all the attributes.
+func (f *EventFilter) IsEmpty() bool {
+	return f == nil || (len(f.include) == 0 && len(f.exclude) == 0)
+}
+
+// Indexed reports whether the attribute with the key of an event of type
+// eventType is indexed.
+func (f *EventFilter) Indexed(eventType, key string) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	compositeKey := eventType + "." + key
+	if len(f.include) > 0 && !matchesEventSet(f.include, eventType, compositeKey) {
+		return false
+	}
+	return !matchesEventSet(f.exclude, eventType, compositeKey)
+}
+
+func matchesEventSet(set map[string]struct{}, eventType, compositeKey string) bool {
+	if _, ok := set[eventType]; ok {
+		return true
+	}
+	_, ok := set[compositeKey]
+	return ok
+}
+
+// Events returns a copy of the events, with the index flag unset on the
+// attributes filtered out. The events are returned as is if nothing is
+// filtered out.
+func (f *EventFilter) Events(events []abci.Event) []abci.Event {
+	if f.IsEmpty() {
+		return events
+	}
+
+	filtered := make([]abci.Event, len(events))
+	for i, event := range events {
+		filtered[i] = abci.Event{
+			Type:       event.Type,
+			Attributes: make([]abci.EventAttribute, len(event.Attributes)),
+		}
+		for j, attr := range event.Attributes {
+			if attr.Index && !f.Indexed(event.Type, string(attr.Key)) {
+				attr.Index = false
+			}
+			filtered[i].Attributes[j] = attr
+		}
+	}
+	return filtered
+}
+
+// BlockHeader returns the block header event with its begin and end block
+// events filtered.
+func (f *EventFilter) BlockHeader(h types.EventDataNewBlockHeader) types.EventDataNewBlockHeader {
+	if f.IsEmpty() {
+		return h
+	}
+	h.ResultBeginBlock.Events = f.Events(h.ResultBeginBlock.Events)
+	h.ResultEndBlock.Events = f.Events(h.ResultEndBlock.Events)
+	return h
+}
+
+// Batch returns a copy of the batch with the events of its transaction results
+// filtered.
+func (f *EventFilter) Batch(b *Batch) *Batch {
+	if f.IsEmpty() {
+		return b
+	}
+	filtered := NewBatch(int64(len(b.Ops)))
+	for i, txr := range b.Ops {
+		if txr == nil {
+			continue
+		}
+		txr := *txr
+		txr.Result.Events = f.Events(txr.Result.Events)
+		filtered.Ops[i] = &txr
+	}
+	return filtered
+}
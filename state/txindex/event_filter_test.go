@@ -0,0 +1,78 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEventFilter(t *testing.T) {
+	_, err := NewEventFilter([]string{"transfer."}, nil)
+	require.Error(t, err)
+	_, err = NewEventFilter(nil, []string{""})
+	require.Error(t, err)
+
+	testCases := []struct {
+		name      string
+		include   []string
+		exclude   []string
+		eventType string
+		key       string
+		indexed   bool
+	}{
+		{"no filter", nil, nil, "transfer", "sender", true},
+		{"included type", []string{"transfer"}, nil, "transfer", "sender", true},
+		{"included key", []string{"transfer.sender"}, nil, "transfer", "sender", true},
+		{"not included key", []string{"transfer.sender"}, nil, "transfer", "amount", false},
+		{"not included type", []string{"transfer"}, nil, "message", "sender", false},
+		{"excluded type", nil, []string{"message"}, "message", "sender", false},
+		{"excluded key", nil, []string{"message.module"}, "message", "module", false},
+		{"not excluded key", nil, []string{"message.module"}, "message", "sender", true},
+		{"included and excluded", []string{"transfer"}, []string{"transfer.amount"}, "transfer", "amount", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewEventFilter(tc.include, tc.exclude)
+			require.NoError(t, err)
+			assert.Equal(t, tc.indexed, f.Indexed(tc.eventType, tc.key))
+		})
+	}
+}
+
+func TestEventFilterBatch(t *testing.T) {
+	f, err := NewEventFilter(nil, []string{"message"})
+	require.NoError(t, err)
+
+	events := []abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: []byte("sender"), Value: []byte("foo"), Index: true},
+			{Key: []byte("amount"), Value: []byte("1"), Index: false},
+		}},
+		{Type: "message", Attributes: []abci.EventAttribute{
+			{Key: []byte("module"), Value: []byte("bank"), Index: true},
+		}},
+	}
+	batch := NewBatch(1)
+	require.NoError(t, batch.Add(&abci.TxResult{
+		Tx:     types.Tx("tx"),
+		Result: abci.ResponseDeliverTx{Events: events},
+	}))
+
+	filtered := f.Batch(batch)
+	got := filtered.Ops[0].Result.Events
+	assert.True(t, got[0].Attributes[0].Index)
+	assert.False(t, got[0].Attributes[1].Index)
+	assert.False(t, got[1].Attributes[0].Index)
+
+	// the original events are left untouched
+	assert.True(t, events[1].Attributes[0].Index)
+	assert.True(t, batch.Ops[0].Result.Events[1].Attributes[0].Index)
+
+	// the batch is returned as is without a filter
+	var nilFilter *EventFilter
+	assert.Same(t, batch, nilFilter.Batch(batch))
+}
@@ -6,6 +6,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
 )
 
 // XXX/TODO: These types should be moved to the indexer package.
@@ -28,6 +29,22 @@ type TxIndexer interface {
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
 }
 
+// BlockEvents are the events of a block, and the results of its transactions,
+// to index.
+type BlockEvents struct {
+	Header types.EventDataNewBlockHeader
+	Txs    *Batch
+}
+
+// BulkIndexer is implemented by the tx indexers able to index several blocks
+// at once, along with their block events, e.g. in a single database
+// transaction, which speeds up catching up with the chain.
+type BulkIndexer interface {
+	// IndexBlocks indexes the blocks, by increasing height, and the results
+	// of their transactions.
+	IndexBlocks(blocks []BlockEvents) error
+}
+
 // Batch groups together multiple Index operations to be performed at the same time.
 // NOTE: Batch is NOT thread-safe and must not be modified after starting its execution.
 type Batch struct {
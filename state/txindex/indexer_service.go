@@ -2,20 +2,45 @@ package txindex
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
 
+	dbm "github.com/cometbft/cometbft-db"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tracing"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/types"
 )
 
+var tracer = tracing.Tracer("txindex")
+
 // XXX/TODO: These types should be moved to the indexer package.
 
 const (
 	subscriber = "IndexerService"
+
+	// maxRetryDelay is the maximum delay between two attempts to index a block
+	// when indexing asynchronously.
+	maxRetryDelay = 10 * time.Second
 )
 
+var indexedHeightKey = []byte("indexedHeight")
+
 // IndexerService connects event bus, transaction and block indexers together in
 // order to index transactions and blocks coming from the event bus.
+//
+// By default, blocks are indexed synchronously, as they are published on the
+// event bus, which delays block commits until they are indexed. With WithQueue,
+// blocks are queued and indexed by workers instead, and block commits only
+// wait for the indexer when the queue is full.
 type IndexerService struct {
 	service.BaseService
 
@@ -23,21 +48,115 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+	metrics          *Metrics
+	eventFilter      *EventFilter
+	pruner           *Pruner
+
+	// asynchronous indexing
+	queueSize  int
+	workers    int
+	batchSize  int
+	progressDB dbm.DB
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	queue      chan *blockBatch
+
+	mtx     cmtsync.Mutex
+	latest  int64              // latest height received
+	indexed int64              // height up to which all blocks are indexed, -1 if unknown
+	pending map[int64]struct{} // heights above indexed already indexed
+}
+
+// blockBatch is a block to index, with the results of its transactions.
+type blockBatch struct {
+	header types.EventDataNewBlockHeader
+	txs    *Batch
 }
 
+// IndexerServiceOption sets an optional parameter on the IndexerService.
+type IndexerServiceOption func(*IndexerService)
+
 // NewIndexerService returns a new service instance.
 func NewIndexerService(
 	txIdxr TxIndexer,
 	blockIdxr indexer.BlockIndexer,
 	eventBus *types.EventBus,
 	terminateOnError bool,
+	options ...IndexerServiceOption,
 ) *IndexerService {
 
-	is := &IndexerService{txIdxr: txIdxr, blockIdxr: blockIdxr, eventBus: eventBus, terminateOnError: terminateOnError}
+	is := &IndexerService{
+		txIdxr:           txIdxr,
+		blockIdxr:        blockIdxr,
+		eventBus:         eventBus,
+		terminateOnError: terminateOnError,
+		metrics:          NopMetrics(),
+		batchSize:        1,
+		indexed:          -1,
+		pending:          make(map[int64]struct{}),
+	}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
+	for _, option := range options {
+		option(is)
+	}
 	return is
 }
 
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) IndexerServiceOption {
+	return func(is *IndexerService) { is.metrics = metrics }
+}
+
+// WithEventFilter sets the filter selecting the event attributes to index.
+func WithEventFilter(filter *EventFilter) IndexerServiceOption {
+	return func(is *IndexerService) { is.eventFilter = filter }
+}
+
+// WithPruner sets the pruner, started and stopped along with the service.
+func WithPruner(pruner *Pruner) IndexerServiceOption {
+	return func(is *IndexerService) { is.pruner = pruner }
+}
+
+// WithQueue indexes blocks asynchronously: at most size blocks wait to be
+// indexed by the given number of workers, and a block failing to be indexed is
+// retried until it succeeds, unless the service terminates on errors. The
+// height up to which all the blocks are indexed is persisted in db.
+func WithQueue(size, workers int, db dbm.DB) IndexerServiceOption {
+	return func(is *IndexerService) {
+		is.queueSize = size
+		is.workers = workers
+		is.progressDB = db
+	}
+}
+
+// WithBatchSize sets the maximum number of queued blocks a worker indexes at
+// once, when the tx indexer implements BulkIndexer. Workers only take the
+// blocks already queued, so blocks are batched when the indexer falls behind,
+// e.g. while the node syncs, and indexed one by one otherwise. It only applies
+// with WithQueue.
+func WithBatchSize(size int) IndexerServiceOption {
+	return func(is *IndexerService) {
+		if size > 0 {
+			is.batchSize = size
+		}
+	}
+}
+
+// WithCatchUp indexes, when the service starts, the blocks committed after the
+// persisted indexed height, loaded from the block and state stores. It only
+// applies with WithQueue.
+func WithCatchUp(blockStore sm.BlockStore, stateStore sm.Store) IndexerServiceOption {
+	return func(is *IndexerService) {
+		is.blockStore = blockStore
+		is.stateStore = stateStore
+	}
+}
+
+// Pruner returns the pruner set with WithPruner, if any.
+func (is *IndexerService) Pruner() *Pruner {
+	return is.pruner
+}
+
 // OnStart implements service.Service by subscribing for all transactions
 // and indexing them by events.
 func (is *IndexerService) OnStart() error {
@@ -57,9 +176,36 @@ func (is *IndexerService) OnStart() error {
 		return err
 	}
 
+	if is.pruner != nil {
+		if err := is.pruner.Start(); err != nil {
+			return err
+		}
+	}
+
+	if is.queueSize > 0 {
+		indexed, err := loadIndexedHeight(is.progressDB)
+		if err != nil {
+			return err
+		}
+		is.indexed = indexed
+		is.queue = make(chan *blockBatch, is.queueSize)
+		for i := 0; i < is.workers; i++ {
+			go is.worker()
+		}
+	}
+
 	go func() {
+		if is.queueSize > 0 {
+			is.catchUp()
+		}
+
 		for {
-			msg := <-blockHeadersSub.Out()
+			var msg pubsub.Message
+			select {
+			case msg = <-blockHeadersSub.Out():
+			case <-is.Quit():
+				return
+			}
 			eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
 			height := eventDataHeader.Header.Height
 			batch := NewBatch(eventDataHeader.NumTxs)
@@ -85,29 +231,28 @@ func (is *IndexerService) OnStart() error {
 				}
 			}
 
-			if err := is.blockIdxr.Index(eventDataHeader); err != nil {
-				is.Logger.Error("failed to index block", "height", height, "err", err)
-				if is.terminateOnError {
-					if err := is.Stop(); err != nil {
-						is.Logger.Error("failed to stop", "err", err)
-					}
+			b := &blockBatch{header: eventDataHeader, txs: batch}
+			if is.queueSize > 0 {
+				if !is.enqueue(b) {
 					return
 				}
-			} else {
-				is.Logger.Info("indexed block exents", "height", height)
+				continue
 			}
 
-			if err = is.txIdxr.AddBatch(batch); err != nil {
-				is.Logger.Error("failed to index block txs", "height", height, "err", err)
+			// blocks indexed synchronously are not retried, so they count as
+			// indexed even when they fail to
+			is.received(height)
+			if err := is.indexBlock(b); err != nil {
+				is.metrics.Failures.Add(1)
+				is.Logger.Error("failed to index block", "height", height, "err", err)
 				if is.terminateOnError {
 					if err := is.Stop(); err != nil {
 						is.Logger.Error("failed to stop", "err", err)
 					}
 					return
 				}
-			} else {
-				is.Logger.Debug("indexed transactions", "height", height, "num_txs", eventDataHeader.NumTxs)
 			}
+			is.markIndexed(height)
 		}
 	}()
 	return nil
@@ -118,4 +263,335 @@ func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
+	if is.pruner != nil && is.pruner.IsRunning() {
+		if err := is.pruner.Stop(); err != nil {
+			is.Logger.Error("failed to stop the pruner", "err", err)
+		}
+	}
+}
+
+// IndexedHeight returns the height up to which all the blocks received by the
+// service are indexed, or -1 if no block was received yet.
+func (is *IndexerService) IndexedHeight() int64 {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	return is.indexed
+}
+
+// indexBlock indexes the block and its transactions.
+func (is *IndexerService) indexBlock(b *blockBatch) (err error) {
+	start := time.Now()
+	height := b.header.Header.Height
+	_, span := tracer.Start(context.Background(), "txindex.index_block", trace.WithAttributes(
+		attribute.Int64("height", height),
+		attribute.Int64("num_txs", b.header.NumTxs),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := is.blockIdxr.Index(is.eventFilter.BlockHeader(b.header)); err != nil {
+		return fmt.Errorf("indexing block events: %w", err)
+	}
+	is.Logger.Info("indexed block events", "height", height)
+
+	if err := is.txIdxr.AddBatch(is.eventFilter.Batch(b.txs)); err != nil {
+		return fmt.Errorf("indexing block txs: %w", err)
+	}
+	is.Logger.Debug("indexed transactions", "height", height, "num_txs", b.header.NumTxs)
+
+	is.metrics.BlockIndexingTime.Observe(time.Since(start).Seconds())
+	is.metrics.BatchSize.Observe(1)
+	return nil
+}
+
+// indexBlocks indexes the blocks and their transactions, at once if the tx
+// indexer implements BulkIndexer, in which case it also indexes the block
+// events in place of the block indexer.
+func (is *IndexerService) indexBlocks(bs []*blockBatch) error {
+	bulk, ok := is.txIdxr.(BulkIndexer)
+	if !ok || len(bs) == 1 {
+		for _, b := range bs {
+			if err := is.indexBlock(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := time.Now()
+	_, span := tracer.Start(context.Background(), "txindex.index_blocks", trace.WithAttributes(
+		attribute.Int64("from", bs[0].header.Header.Height),
+		attribute.Int64("to", bs[len(bs)-1].header.Header.Height),
+	))
+	defer span.End()
+	blocks := make([]BlockEvents, len(bs))
+	for i, b := range bs {
+		blocks[i] = BlockEvents{
+			Header: is.eventFilter.BlockHeader(b.header),
+			Txs:    is.eventFilter.Batch(b.txs),
+		}
+	}
+	if err := bulk.IndexBlocks(blocks); err != nil {
+		tracing.EndSpan(span, err)
+		return fmt.Errorf("indexing blocks: %w", err)
+	}
+	is.Logger.Info("indexed blocks", "from", bs[0].header.Header.Height,
+		"to", bs[len(bs)-1].header.Header.Height)
+
+	elapsed := time.Since(start).Seconds() / float64(len(bs))
+	for range bs {
+		is.metrics.BlockIndexingTime.Observe(elapsed)
+	}
+	is.metrics.BatchSize.Observe(float64(len(bs)))
+	return nil
+}
+
+// enqueue queues the block to be indexed by the workers, blocking while the
+// queue is full. It returns false if the service stopped in the meantime.
+// Blocks already indexed are skipped.
+func (is *IndexerService) enqueue(b *blockBatch) bool {
+	height := b.header.Header.Height
+	if !is.received(height) {
+		return true
+	}
+
+	select {
+	case is.queue <- b:
+	case <-is.Quit():
+		return false
+	}
+	is.metrics.QueueSize.Set(float64(len(is.queue)))
+	return true
+}
+
+// received records that the block at height was received, and reports
+// whether it still needs to be indexed.
+func (is *IndexerService) received(height int64) bool {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+
+	if is.indexed < 0 {
+		is.indexed = height - 1
+	}
+	if height > is.latest {
+		is.latest = height
+		is.metrics.LatestHeight.Set(float64(height))
+		is.metrics.Lag.Set(float64(is.latest - is.indexed))
+	}
+	return height > is.indexed
+}
+
+// worker indexes the queued blocks until the service stops, taking up to
+// batchSize blocks at once.
+func (is *IndexerService) worker() {
+	for {
+		var b *blockBatch
+		select {
+		case b = <-is.queue:
+		case <-is.Quit():
+			return
+		}
+		bs := []*blockBatch{b}
+	BATCH:
+		for len(bs) < is.batchSize {
+			select {
+			case b := <-is.queue:
+				bs = append(bs, b)
+			default:
+				break BATCH
+			}
+		}
+		is.metrics.QueueSize.Set(float64(len(is.queue)))
+
+		height := bs[0].header.Header.Height
+		for attempt := 0; ; attempt++ {
+			err := is.indexBlocks(bs)
+			if err == nil {
+				break
+			}
+			is.metrics.Failures.Add(1)
+			is.Logger.Error("failed to index blocks", "height", height, "num_blocks", len(bs),
+				"attempt", attempt+1, "err", err)
+			if is.terminateOnError {
+				if err := is.Stop(); err != nil {
+					is.Logger.Error("failed to stop", "err", err)
+				}
+				return
+			}
+
+			select {
+			case <-time.After(retryDelay(attempt)):
+			case <-is.Quit():
+				return
+			}
+		}
+		for _, b := range bs {
+			is.markIndexed(b.header.Header.Height)
+		}
+	}
+}
+
+// retryDelay returns the delay before retrying to index a block after the
+// given failed attempt, doubling from 100ms up to maxRetryDelay.
+func retryDelay(attempt int) time.Duration {
+	delay := 100 * time.Millisecond
+	for i := 0; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// markIndexed records that the block at height is indexed, and advances the
+// indexed height, persisting it, once all the blocks below are indexed too.
+func (is *IndexerService) markIndexed(height int64) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+
+	if height <= is.indexed {
+		return
+	}
+	is.pending[height] = struct{}{}
+	indexed := is.indexed
+	for {
+		if _, ok := is.pending[indexed+1]; !ok {
+			break
+		}
+		delete(is.pending, indexed+1)
+		indexed++
+	}
+	if indexed == is.indexed {
+		return
+	}
+
+	is.setIndexed(indexed)
+}
+
+// setIndexed sets and persists the indexed height. It must be called with the
+// mutex held.
+func (is *IndexerService) setIndexed(height int64) {
+	is.indexed = height
+	for h := range is.pending {
+		if h <= height {
+			delete(is.pending, h)
+		}
+	}
+	if is.progressDB != nil {
+		if err := saveIndexedHeight(is.progressDB, height); err != nil {
+			is.Logger.Error("failed to save the indexed height", "height", height, "err", err)
+		}
+	}
+	is.metrics.IndexedHeight.Set(float64(height))
+	if is.latest > height {
+		is.metrics.Lag.Set(float64(is.latest - height))
+	} else {
+		is.metrics.Lag.Set(0)
+	}
+}
+
+// catchUp queues the blocks committed after the persisted indexed height, up
+// to the last height of the state, for example because the node stopped before
+// they were indexed. Later blocks are published on the event bus by the
+// handshake or consensus.
+func (is *IndexerService) catchUp() {
+	if is.blockStore == nil || is.stateStore == nil || is.indexed < 0 {
+		return
+	}
+	state, err := is.stateStore.Load()
+	if err != nil {
+		is.Logger.Error("failed to load the state to catch up", "err", err)
+		return
+	}
+
+	from, to := is.indexed+1, state.LastBlockHeight
+	if base := is.blockStore.Base(); from < base {
+		is.Logger.Error("blocks below the block store base can't be indexed",
+			"from", from, "to", base-1)
+		from = base
+		is.mtx.Lock()
+		is.setIndexed(from - 1)
+		is.mtx.Unlock()
+	}
+	if from > to {
+		return
+	}
+
+	is.Logger.Info("indexing the blocks committed since the indexer stopped", "from", from, "to", to)
+	for height := from; height <= to; height++ {
+		b, err := is.loadBlockBatch(height)
+		if err != nil {
+			is.Logger.Error("failed to load the block to index, use the reindex command to index the missing blocks",
+				"from", height, "to", to, "err", err)
+			is.mtx.Lock()
+			if is.indexed < to {
+				is.setIndexed(to)
+			}
+			is.mtx.Unlock()
+			return
+		}
+		if !is.enqueue(b) {
+			return
+		}
+	}
+}
+
+// loadBlockBatch loads the block at height and the results of its
+// transactions from the block and state stores.
+func (is *IndexerService) loadBlockBatch(height int64) (*blockBatch, error) {
+	block := is.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	responses, err := is.stateStore.LoadABCIResponses(height)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &blockBatch{
+		header: types.EventDataNewBlockHeader{
+			Header:           block.Header,
+			NumTxs:           int64(len(block.Txs)),
+			ResultBeginBlock: *responses.BeginBlock,
+			ResultEndBlock:   *responses.EndBlock,
+		},
+		txs: NewBatch(int64(len(block.Txs))),
+	}
+	for i, tx := range block.Txs {
+		if err := b.txs.Add(&abci.TxResult{
+			Height: height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *responses.DeliverTxs[i],
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// loadIndexedHeight loads the persisted indexed height from db, or -1 if
+// there is none.
+func loadIndexedHeight(db dbm.DB) (int64, error) {
+	if db == nil {
+		return -1, nil
+	}
+	bz, err := db.Get(indexedHeightKey)
+	if err != nil {
+		return -1, fmt.Errorf("loading the indexed height: %w", err)
+	}
+	if len(bz) == 0 {
+		return -1, nil
+	}
+	if len(bz) != 8 {
+		return -1, fmt.Errorf("loading the indexed height: invalid length %d", len(bz))
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// saveIndexedHeight persists the indexed height in db.
+func saveIndexedHeight(db dbm.DB, height int64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return db.SetSync(indexedHeightKey, bz)
 }
@@ -1,6 +1,8 @@
 package txindex_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -9,7 +11,10 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/types"
@@ -79,3 +84,178 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
 }
+
+func TestIndexerServiceQueue(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+	progressDB := db.NewMemDB()
+
+	// the block at height 1 is committed while the indexer is stopped
+	blockStore := &smmocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("LoadBlock", int64(1)).Return(types.MakeBlock(1, []types.Tx{types.Tx("foo")}, nil, nil))
+	stateStore := &smmocks.Store{}
+	stateStore.On("Load").Return(sm.State{LastBlockHeight: 1}, nil)
+	stateStore.On("LoadABCIResponses", int64(1)).Return(&cmtstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: 0}},
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}, nil)
+
+	newService := func() *txindex.IndexerService {
+		service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false,
+			txindex.WithQueue(2, 2, progressDB),
+			txindex.WithCatchUp(blockStore, stateStore))
+		service.SetLogger(log.TestingLogger())
+		require.NoError(t, service.Start())
+		t.Cleanup(func() {
+			if service.IsRunning() {
+				if err := service.Stop(); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+		return service
+	}
+
+	// without a persisted indexed height, the service starts from the first
+	// block it receives
+	service := newService()
+	require.EqualValues(t, -1, service.IndexedHeight())
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 0},
+	}))
+	require.Eventually(t, func() bool { return service.IndexedHeight() == 0 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, service.Stop())
+
+	// once restarted, the service catches up with the committed blocks
+	service = newService()
+	require.Eventually(t, func() bool { return service.IndexedHeight() == 1 }, time.Second, 10*time.Millisecond)
+	res, err := txIndexer.Get(types.Tx("foo").Hash())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.Height)
+
+	for h := int64(2); h <= 10; h++ {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			NumTxs: 1,
+		}))
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: h,
+			Tx:     types.Tx(fmt.Sprintf("tx%d", h)),
+		}}))
+	}
+	require.Eventually(t, func() bool { return service.IndexedHeight() == 10 }, time.Second, 10*time.Millisecond)
+	for h := int64(2); h <= 10; h++ {
+		ok, err := blockIndexer.Has(h)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+}
+
+// bulkTxIndexer is a tx indexer implementing txindex.BulkIndexer, which waits
+// for release to be closed before indexing.
+type bulkTxIndexer struct {
+	*kv.TxIndex
+	blockIndexer *blockidxkv.BlockerIndexer
+	release      chan struct{}
+
+	mtx   sync.Mutex
+	sizes []int
+}
+
+func (txi *bulkTxIndexer) AddBatch(batch *txindex.Batch) error {
+	<-txi.release
+	return txi.TxIndex.AddBatch(batch)
+}
+
+func (txi *bulkTxIndexer) IndexBlocks(blocks []txindex.BlockEvents) error {
+	<-txi.release
+	txi.mtx.Lock()
+	txi.sizes = append(txi.sizes, len(blocks))
+	txi.mtx.Unlock()
+	for _, b := range blocks {
+		if err := txi.blockIndexer.Index(b.Header); err != nil {
+			return err
+		}
+		if err := txi.TxIndex.AddBatch(b.Txs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestIndexerServiceBatches(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+	txIndexer := &bulkTxIndexer{
+		TxIndex:      kv.NewTxIndex(store),
+		blockIndexer: blockIndexer,
+		release:      make(chan struct{}),
+	}
+
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false,
+		txindex.WithQueue(10, 1, db.NewMemDB()),
+		txindex.WithBatchSize(4))
+	service.SetLogger(log.TestingLogger())
+	require.NoError(t, service.Start())
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the worker waits on the first blocks while the others are queued
+	for h := int64(1); h <= 9; h++ {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			NumTxs: 1,
+		}))
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: h,
+			Tx:     types.Tx(fmt.Sprintf("tx%d", h)),
+		}}))
+	}
+	// the service receives the next block once the previous one is queued
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 10},
+	}))
+	close(txIndexer.release)
+
+	require.Eventually(t, func() bool { return service.IndexedHeight() == 10 }, time.Second, 10*time.Millisecond)
+	for h := int64(1); h <= 9; h++ {
+		ok, err := blockIndexer.Has(h)
+		require.NoError(t, err)
+		require.True(t, ok)
+		res, err := txIndexer.Get(types.Tx(fmt.Sprintf("tx%d", h)).Hash())
+		require.NoError(t, err)
+		require.EqualValues(t, h, res.Height)
+	}
+
+	// the blocks queued while the worker waits are indexed in batches
+	txIndexer.mtx.Lock()
+	defer txIndexer.mtx.Unlock()
+	require.Contains(t, txIndexer.sizes, 4)
+	for _, size := range txIndexer.sizes {
+		require.LessOrEqual(t, size, 4)
+	}
+}
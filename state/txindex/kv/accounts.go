@@ -0,0 +1,206 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/google/orderedcode"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
+)
+
+// accountIndexPrefix is the prefix of the keys of the account indexes.
+const accountIndexPrefix = "tx.account"
+
+// TxIndexOption sets an optional parameter on the TxIndex.
+type TxIndexOption func(*TxIndex)
+
+// WithAccountAttributes sets the composite keys of the event attributes, e.g.
+// "transfer.sender", holding the senders, recipients and fee payers of the
+// transactions. Each transaction is indexed by these accounts, which can be
+// searched with the tx.sender, tx.recipient and tx.fee_payer keys without
+// scanning the event keys.
+//
+// Only the attributes marked as indexed are indexed. A key with no attributes
+// is searched among the events, as any other key.
+func WithAccountAttributes(senders, recipients, feePayers []string) TxIndexOption {
+	return func(txi *TxIndex) {
+		for role, attrs := range map[string][]string{
+			types.TxSenderKey:    senders,
+			types.TxRecipientKey: recipients,
+			types.TxFeePayerKey:  feePayers,
+		} {
+			for _, attr := range attrs {
+				if txi.accountAttrs == nil {
+					txi.accountAttrs = make(map[string][]string)
+				}
+				txi.accountAttrs[attr] = append(txi.accountAttrs[attr], role)
+				txi.accountRoles[role] = true
+			}
+		}
+	}
+}
+
+// accountKey returns the key indexing the transaction at height and index by
+// the account with the role.
+func accountKey(role, account string, height int64, index uint32) []byte {
+	key, err := orderedcode.Append(nil, accountIndexPrefix, role, account, height, int64(index))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// accountKeys returns the keys indexing the transaction by its accounts.
+func (txi *TxIndex) accountKeys(result *abci.TxResult) [][]byte {
+	if len(txi.accountAttrs) == 0 {
+		return nil
+	}
+	var keys [][]byte
+	seen := make(map[string]struct{})
+	for _, event := range result.Result.Events {
+		for _, attr := range event.Attributes {
+			if !attr.GetIndex() {
+				continue
+			}
+			for _, role := range txi.accountAttrs[event.Type+"."+string(attr.Key)] {
+				key := accountKey(role, string(attr.Value), result.Height, result.Index)
+				if _, ok := seen[string(key)]; !ok {
+					seen[string(key)] = struct{}{}
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// indexAccounts indexes the transaction by its accounts.
+func (txi *TxIndex) indexAccounts(result *abci.TxResult, hash []byte, store dbm.Batch) error {
+	for _, key := range txi.accountKeys(result) {
+		if err := store.Set(key, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitAccountConditions separates the conditions on the indexed accounts from
+// the others.
+func (txi *TxIndex) splitAccountConditions(conditions []query.Condition) (accounts, others []query.Condition, err error) {
+	for _, c := range conditions {
+		if !txi.accountRoles[c.CompositeKey] {
+			others = append(others, c)
+			continue
+		}
+		if c.Op != query.OpEqual {
+			return nil, nil, fmt.Errorf("only the = operator is supported with %s", c.CompositeKey)
+		}
+		accounts = append(accounts, c)
+	}
+	return accounts, others, nil
+}
+
+// matchAccounts returns the hashes of the transactions matching all the
+// account conditions, within the height bounds of the conditions.
+func (txi *TxIndex) matchAccounts(
+	ctx context.Context,
+	accounts []query.Condition,
+	conditions []query.Condition,
+) (map[string][]byte, error) {
+	minHeight, maxHeight := heightBounds(conditions)
+	if minHeight > maxHeight {
+		return map[string][]byte{}, nil
+	}
+	var hashes map[string][]byte
+	for _, c := range accounts {
+		account := fmt.Sprint(c.Operand)
+		it, err := txi.store.Iterator(
+			accountKey(c.CompositeKey, account, minHeight, 0),
+			accountKey(c.CompositeKey, account, maxHeight+1, 0),
+		)
+		if err != nil {
+			return nil, err
+		}
+		matched := make(map[string][]byte)
+		for ; it.Valid(); it.Next() {
+			hash := it.Value()
+			if hashes == nil || hashes[string(hash)] != nil {
+				matched[string(hash)] = hash
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, err
+		}
+		hashes = matched
+		if len(hashes) == 0 {
+			break
+		}
+	}
+	return hashes, nil
+}
+
+// heightBounds returns the lowest and highest heights allowed by the
+// conditions on tx.height.
+func heightBounds(conditions []query.Condition) (minHeight, maxHeight int64) {
+	minHeight, maxHeight = 0, math.MaxInt64-1
+	for _, c := range conditions {
+		if c.CompositeKey != types.TxHeightKey {
+			continue
+		}
+		height, ok := c.Operand.(int64)
+		if !ok {
+			continue
+		}
+		switch c.Op {
+		case query.OpEqual:
+			minHeight, maxHeight = max64(minHeight, height), min64(maxHeight, height)
+		case query.OpGreater:
+			minHeight = max64(minHeight, height+1)
+		case query.OpGreaterEqual:
+			minHeight = max64(minHeight, height)
+		case query.OpLess:
+			maxHeight = min64(maxHeight, height-1)
+		case query.OpLessEqual:
+			maxHeight = min64(maxHeight, height)
+		}
+	}
+	return minHeight, maxHeight
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// onlyHeightConditions reports whether the conditions only bound the height,
+// and so are all handled by the account lookups.
+func onlyHeightConditions(conditions []query.Condition) bool {
+	for _, c := range conditions {
+		if c.CompositeKey == types.MatchEventKey {
+			continue
+		}
+		if _, ok := c.Operand.(int64); c.CompositeKey != types.TxHeightKey || !ok || c.Op == query.OpContains || c.Op == query.OpExists {
+			return false
+		}
+	}
+	return true
+}
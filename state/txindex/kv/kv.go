@@ -23,20 +23,32 @@ const (
 	eventSeqSeparator = "$es$"
 )
 
-var _ txindex.TxIndexer = (*TxIndex)(nil)
+var (
+	_ txindex.TxIndexer = (*TxIndex)(nil)
+	_ indexer.Pruner    = (*TxIndex)(nil)
+)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
 type TxIndex struct {
 	store dbm.DB
 	// Number the events in the event list
 	eventSeq int64
+
+	// roles of the accounts held by the attributes, by composite key
+	accountAttrs map[string][]string
+	accountRoles map[string]bool
 }
 
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
-		store: store,
+func NewTxIndex(store dbm.DB, options ...TxIndexOption) *TxIndex {
+	txi := &TxIndex{
+		store:        store,
+		accountRoles: make(map[string]bool),
+	}
+	for _, option := range options {
+		option(txi)
 	}
+	return txi
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -80,6 +92,12 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 			return err
 		}
 
+		// index tx by accounts
+		err = txi.indexAccounts(result, hash, storeBatch)
+		if err != nil {
+			return err
+		}
+
 		// index by height (always)
 		err = storeBatch.Set(keyForHeight(result), hash)
 		if err != nil {
@@ -134,6 +152,12 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 		return err
 	}
 
+	// index tx by accounts
+	err = txi.indexAccounts(result, hash, b)
+	if err != nil {
+		return err
+	}
+
 	// index by height (always)
 	err = b.Set(keyForHeight(result), hash)
 	if err != nil {
@@ -180,6 +204,120 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 	return nil
 }
 
+// Prune deletes the transactions of the blocks from fromHeight up to, but not
+// including, retainHeight, along with their event keys, and returns the number
+// of keys deleted. It implements indexer.Pruner.
+//
+// A transaction indexed again at a later height, with the same hash, is kept,
+// but the event keys of its earlier occurrence can't be found and are left.
+func (txi *TxIndex) Prune(fromHeight, retainHeight int64) (uint64, error) {
+	var pruned uint64
+	for height := fromHeight; height < retainHeight; height++ {
+		n, err := txi.pruneHeight(height)
+		if err != nil {
+			return pruned, fmt.Errorf("pruning height %d: %w", height, err)
+		}
+		pruned += n
+	}
+	return pruned, nil
+}
+
+// pruneHeight deletes the transactions of the block at height.
+func (txi *TxIndex) pruneHeight(height int64) (uint64, error) {
+	it, err := dbm.IteratePrefix(txi.store, startKey(types.TxHeightKey, height, height))
+	if err != nil {
+		return 0, err
+	}
+	var heightKeys, hashes [][]byte
+	for ; it.Valid(); it.Next() {
+		heightKeys = append(heightKeys, it.Key())
+		hashes = append(hashes, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return 0, err
+	}
+	it.Close()
+	if len(heightKeys) == 0 {
+		return 0, nil
+	}
+
+	var deleted [][]byte
+	for i, hash := range hashes {
+		deleted = append(deleted, heightKeys[i])
+
+		result, err := txi.Get(hash)
+		if err != nil {
+			return 0, err
+		}
+		if result == nil || result.Height != height {
+			continue
+		}
+		deleted = append(deleted, hash)
+
+		eventKeys, err := txi.eventKeys(result)
+		if err != nil {
+			return 0, err
+		}
+		deleted = append(deleted, eventKeys...)
+		deleted = append(deleted, txi.accountKeys(result)...)
+	}
+
+	batch := txi.store.NewBatch()
+	defer batch.Close()
+	seen := make(map[string]struct{}, len(deleted))
+	for _, key := range deleted {
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		seen[string(key)] = struct{}{}
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return uint64(len(seen)), nil
+}
+
+// eventKeys returns the keys indexing the transaction by its events. The keys
+// are looked up by prefix, as they end with a sequence number which is not
+// stored.
+func (txi *TxIndex) eventKeys(result *abci.TxResult) ([][]byte, error) {
+	var keys [][]byte
+	for _, event := range result.Result.Events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 || !attr.GetIndex() {
+				continue
+			}
+
+			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+			key := []byte(fmt.Sprintf("%s/%s/%d/%d", compositeTag, attr.Value, result.Height, result.Index))
+			it, err := dbm.IteratePrefix(txi.store, key)
+			if err != nil {
+				return nil, err
+			}
+			for ; it.Valid(); it.Next() {
+				// keys without sequence number, or with one
+				k := it.Key()
+				if len(k) == len(key) || bytes.HasPrefix(k[len(key):], []byte(eventSeqSeparator)) {
+					keys = append(keys, k)
+				}
+			}
+			err = it.Error()
+			it.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return keys, nil
+}
+
 // Search performs a search using the given query.
 //
 // It breaks the query into conditions (like "tx.height > 5"). For each
@@ -189,9 +327,44 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 // performing a full scan. Results from querying indexes are then intersected
 // and returned to the caller, in no particular order.
 //
+// The queries using OR or NOT are searched by combining the results of their
+// conjunctions of conditions (see indexer.SearchExpr).
+//
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	e := q.Expression()
+	if e.Kind == query.ExprConditions {
+		return txi.search(ctx, e.Query)
+	}
+
+	found := make(map[string]*abci.TxResult)
+	hashes, err := indexer.SearchExpr(ctx, e, func(ctx context.Context, q *query.Query) (map[string]struct{}, error) {
+		results, err := txi.search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		hashes := make(map[string]struct{}, len(results))
+		for _, r := range results {
+			hash := string(types.Tx(r.Tx).Hash())
+			found[hash] = r
+			hashes[hash] = struct{}{}
+		}
+		return hashes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*abci.TxResult, 0, len(hashes))
+	for hash := range hashes {
+		results = append(results, found[hash])
+	}
+	return results, nil
+}
+
+// search performs a search using the given conjunction of conditions.
+func (txi *TxIndex) search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	select {
 	case <-ctx.Done():
 		return make([]*abci.TxResult, 0), nil
@@ -199,9 +372,6 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	default:
 	}
 
-	var hashesInitialized bool
-	filteredHashes := make(map[string][]byte)
-
 	// get a list of conditions (like "tx.height > 5")
 	conditions, err := q.Conditions()
 	if err != nil {
@@ -224,6 +394,42 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
+	// the conditions on the indexed accounts are looked up in the account
+	// indexes, and the others as usual
+	accounts, conditions, err := txi.splitAccountConditions(conditions)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) > 0 {
+		accountHashes, err := txi.matchAccounts(ctx, accounts, conditions)
+		if err != nil {
+			return nil, err
+		}
+		if len(accountHashes) == 0 || onlyHeightConditions(conditions) {
+			return txi.getAll(ctx, accountHashes)
+		}
+		results, err := txi.searchConditions(ctx, conditions)
+		if err != nil {
+			return nil, err
+		}
+		filtered := results[:0]
+		for _, r := range results {
+			if _, ok := accountHashes[string(types.Tx(r.Tx).Hash())]; ok {
+				filtered = append(filtered, r)
+			}
+		}
+		return filtered, nil
+	}
+
+	return txi.searchConditions(ctx, conditions)
+}
+
+// searchConditions searches the transactions matching the conditions among
+// the event keys.
+func (txi *TxIndex) searchConditions(ctx context.Context, conditions []query.Condition) ([]*abci.TxResult, error) {
+	var hashesInitialized bool
+	filteredHashes := make(map[string][]byte)
+
 	var matchEvents bool
 	var matchEventIdx int
 
@@ -312,9 +518,14 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
+	return txi.getAll(ctx, filteredHashes)
+}
+
+// getAll returns the results of the transactions with the hashes.
+func (txi *TxIndex) getAll(ctx context.Context, hashes map[string][]byte) ([]*abci.TxResult, error) {
+	results := make([]*abci.TxResult, 0, len(hashes))
 	resultMap := make(map[string]struct{})
-	for _, h := range filteredHashes {
+	for _, h := range hashes {
 		res, err := txi.Get(h)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
@@ -15,6 +15,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 )
@@ -596,6 +597,182 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchExpression(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	for i, owner := range []string{"Ivan", "Igor", "Pavel"} {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{
+				{Key: []byte("number"), Value: []byte(fmt.Sprint(i + 1)), Index: true},
+				{Key: []byte("owner"), Value: []byte(owner), Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(owner)
+		txResult.Height = int64(i + 1)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q       string
+		heights []int64
+		err     bool
+	}{
+		{"account.owner = 'Ivan' OR account.owner = 'Pavel'", []int64{1, 3}, false},
+		{"account.number > 1 AND NOT account.owner = 'Pavel'", []int64{2}, false},
+		{"(account.owner = 'Ivan' OR account.number >= 2) AND NOT (account.number = 2 OR tx.height = 3)", []int64{1}, false},
+		{"account.owner = 'Ivan' OR NOT account.owner = 'Igor'", nil, true},
+		{"NOT account.owner = 'Igor'", nil, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), query.MustParse(tc.q))
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			heights := make([]int64, len(results))
+			for i, r := range results {
+				heights[i] = r.Height
+			}
+			assert.ElementsMatch(t, tc.heights, heights)
+		})
+	}
+}
+
+func TestTxSearchAccounts(t *testing.T) {
+	attr := func(key, value string) abci.EventAttribute {
+		return abci.EventAttribute{Key: []byte(key), Value: []byte(value), Index: true}
+	}
+	txResults := []*abci.TxResult{
+		txResultWithEvents([]abci.Event{
+			{Type: "message", Attributes: []abci.EventAttribute{attr("sender", "A")}},
+			{Type: "transfer", Attributes: []abci.EventAttribute{attr("recipient", "B")}},
+			{Type: "tx", Attributes: []abci.EventAttribute{attr("fee_payer", "A")}},
+		}),
+		txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{attr("sender", "B"), attr("recipient", "A")}},
+		}),
+		txResultWithEvents([]abci.Event{
+			{Type: "message", Attributes: []abci.EventAttribute{attr("sender", "A")}},
+			{Type: "transfer", Attributes: []abci.EventAttribute{attr("sender", "A"), attr("recipient", "C")}},
+		}),
+	}
+	for i, txResult := range txResults {
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i+1))
+		txResult.Height = int64(i + 1)
+	}
+
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store, WithAccountAttributes(
+		[]string{"message.sender", "transfer.sender"},
+		[]string{"transfer.recipient"},
+		[]string{"tx.fee_payer"},
+	))
+	for _, txResult := range txResults {
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q       string
+		heights []int64
+	}{
+		{"tx.sender = 'A'", []int64{1, 3}},
+		{"tx.recipient = 'A'", []int64{2}},
+		{"tx.fee_payer = 'A'", []int64{1}},
+		{"tx.sender = 'D'", []int64{}},
+		{"tx.sender = 'A' AND tx.height > 1", []int64{3}},
+		{"tx.sender = 'A' AND tx.height >= 2 AND tx.height < 3", []int64{}},
+		{"tx.sender = 'A' AND tx.fee_payer = 'A'", []int64{1}},
+		{"tx.sender = 'A' AND transfer.recipient = 'C'", []int64{3}},
+		{"tx.sender = 'A' OR tx.recipient = 'A'", []int64{1, 2, 3}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), query.MustParse(tc.q))
+			require.NoError(t, err)
+			heights := make([]int64, len(results))
+			for i, r := range results {
+				heights[i] = r.Height
+			}
+			assert.ElementsMatch(t, tc.heights, heights)
+		})
+	}
+
+	_, err := indexer.Search(context.Background(), query.MustParse("tx.sender CONTAINS 'A'"))
+	require.Error(t, err)
+
+	// without the account indexes, the keys are searched among the events
+	results, err := NewTxIndex(store).Search(context.Background(), query.MustParse("tx.fee_payer = 'A'"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	results, err = NewTxIndex(store).Search(context.Background(), query.MustParse("tx.sender = 'A'"))
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// the account keys are pruned with the transactions
+	_, err = indexer.Prune(1, 3)
+	require.NoError(t, err)
+	results, err = indexer.Search(context.Background(), query.MustParse("tx.sender = 'A'"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.EqualValues(t, 3, results[0].Height)
+	it, err := store.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		// the keys with a hash as value refer to a retained transaction
+		if v := it.Value(); len(v) == 32 {
+			result, err := indexer.Get(v)
+			require.NoError(t, err)
+			require.NotNil(t, result, "key %q refers to a pruned transaction", it.Key())
+		}
+	}
+}
+
+func TestTxIndexPrune(t *testing.T) {
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store)
+
+	for h := int64(1); h <= 3; h++ {
+		batch := txindex.NewBatch(2)
+		for i := uint32(0); i < 2; i++ {
+			txResult := txResultWithEvents([]abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("number"), Value: []byte("1"), Index: true}}},
+				{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("owner"), Value: []byte("Ivan"), Index: false}}},
+			})
+			txResult.Height = h
+			txResult.Index = i
+			txResult.Tx = types.Tx(fmt.Sprintf("tx%d-%d", h, i))
+			require.NoError(t, batch.Add(txResult))
+		}
+		require.NoError(t, indexer.AddBatch(batch))
+	}
+
+	// the hash, height and event keys of the transactions are deleted
+	pruned, err := indexer.Prune(1, 3)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, pruned)
+
+	for h := int64(1); h <= 3; h++ {
+		res, err := indexer.Get(types.Tx(fmt.Sprintf("tx%d-0", h)).Hash())
+		require.NoError(t, err)
+		require.Equal(t, h == 3, res != nil, h)
+	}
+
+	results, err := indexer.Search(context.Background(), query.MustParse("account.number = 1"))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		require.EqualValues(t, 3, res.Height)
+	}
+
+	// pruning again is a no-op
+	pruned, err = indexer.Prune(1, 3)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -656,3 +833,29 @@ func BenchmarkTxIndex500(b *testing.B)   { benchmarkTxIndex(500, b) }
 func BenchmarkTxIndex1000(b *testing.B)  { benchmarkTxIndex(1000, b) }
 func BenchmarkTxIndex2000(b *testing.B)  { benchmarkTxIndex(2000, b) }
 func BenchmarkTxIndex10000(b *testing.B) { benchmarkTxIndex(10000, b) }
+
+func TestTxIndexSchemaVersion(t *testing.T) {
+	// an empty store is at the latest version
+	store := db.NewMemDB()
+	version, err := NewTxIndex(store).SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, indexer.LatestSchemaVersion(NewTxIndex(store)), version)
+	bz, err := store.Get(schemaVersionKey)
+	require.NoError(t, err)
+	require.NotNil(t, bz)
+
+	// an index created before versioning is at version 0
+	store = db.NewMemDB()
+	txIndexer := NewTxIndex(store)
+	require.NoError(t, txIndexer.Index(txResultWithEvents(nil)))
+	version, err = txIndexer.SchemaVersion()
+	require.NoError(t, err)
+	assert.Zero(t, version)
+
+	n, err := indexer.Migrate(context.Background(), txIndexer, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, len(txIndexer.Migrations()), n)
+	version, err = txIndexer.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, indexer.LatestSchemaVersion(txIndexer), version)
+}
@@ -0,0 +1,66 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tendermint/tendermint/state/indexer"
+)
+
+var (
+	_ indexer.Migrator = (*TxIndex)(nil)
+
+	schemaVersionKey = []byte("schemaVersion")
+)
+
+// SchemaVersion returns the schema version of the index, shared by the tx and
+// block indexes of the store. It implements indexer.Migrator.
+//
+// The indexes created before the schema was versioned are at version 0, and an
+// empty store is initialized at the latest version.
+func (txi *TxIndex) SchemaVersion() (uint64, error) {
+	bz, err := txi.store.Get(schemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) > 0 {
+		if len(bz) != 8 {
+			return 0, fmt.Errorf("invalid schema version length %d", len(bz))
+		}
+		return binary.BigEndian.Uint64(bz), nil
+	}
+
+	it, err := txi.store.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	empty := !it.Valid()
+	it.Close()
+	if !empty {
+		return 0, nil
+	}
+	latest := indexer.LatestSchemaVersion(txi)
+	return latest, txi.setSchemaVersion(latest)
+}
+
+// Migrations returns the migrations of the schema of the index. It implements
+// indexer.Migrator.
+func (txi *TxIndex) Migrations() []indexer.Migration {
+	return []indexer.Migration{
+		{
+			Version:     1,
+			Description: "record the schema version",
+			Apply: func(context.Context) error {
+				return txi.setSchemaVersion(1)
+			},
+		},
+	}
+}
+
+// setSchemaVersion records the schema version of the index.
+func (txi *TxIndex) setSchemaVersion(version uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+	return txi.store.SetSync(schemaVersionKey, bz)
+}
@@ -0,0 +1,101 @@
+package txindex
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "indexer"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of blocks waiting to be indexed.
+	QueueSize metrics.Gauge
+	// Latest height received by the indexer.
+	LatestHeight metrics.Gauge
+	// Height up to which all the blocks are indexed.
+	IndexedHeight metrics.Gauge
+	// Number of blocks received but not yet indexed.
+	Lag metrics.Gauge
+	// Time taken to index a block and its transactions.
+	BlockIndexingTime metrics.Histogram
+	// Number of failed attempts to index a block.
+	Failures metrics.Counter
+	// Number of blocks indexed at once.
+	BatchSize metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		QueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_size",
+			Help:      "Number of blocks waiting to be indexed.",
+		}, labels).With(labelsAndValues...),
+		LatestHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "latest_height",
+			Help:      "Latest height received by the indexer.",
+		}, labels).With(labelsAndValues...),
+		IndexedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "indexed_height",
+			Help:      "Height up to which all the blocks are indexed.",
+		}, labels).With(labelsAndValues...),
+		Lag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "lag",
+			Help:      "Number of blocks received but not yet indexed.",
+		}, labels).With(labelsAndValues...),
+		BlockIndexingTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_indexing_time_seconds",
+			Help:      "Time taken to index a block and its transactions.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 15),
+		}, labels).With(labelsAndValues...),
+		Failures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "failures",
+			Help:      "Number of failed attempts to index a block.",
+		}, labels).With(labelsAndValues...),
+		BatchSize: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "batch_size",
+			Help:      "Number of blocks indexed at once.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 10),
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		QueueSize:         discard.NewGauge(),
+		LatestHeight:      discard.NewGauge(),
+		IndexedHeight:     discard.NewGauge(),
+		Lag:               discard.NewGauge(),
+		BlockIndexingTime: discard.NewHistogram(),
+		Failures:          discard.NewCounter(),
+		BatchSize:         discard.NewHistogram(),
+	}
+}
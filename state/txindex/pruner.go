@@ -0,0 +1,185 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/indexer"
+)
+
+var prunedHeightKey = []byte("prunedHeight")
+
+// Pruner is a service deleting periodically the index entries of the blocks
+// which are no longer retained: those older than the retained number of
+// blocks, and, if aligned with the block store, those below its base. Only the
+// indexers implementing indexer.Pruner are pruned.
+type Pruner struct {
+	service.BaseService
+
+	txIdxr       TxIndexer
+	blockIdxr    indexer.BlockIndexer
+	blockStore   sm.BlockStore
+	db           dbm.DB
+	retainBlocks uint64
+	withStore    bool
+
+	mtx      cmtsync.Mutex
+	interval time.Duration
+}
+
+// NewPruner returns a pruner keeping the index entries of the last
+// retainBlocks blocks, if not 0, and of the blocks retained by the block store,
+// if withStore is set, pruning every interval. The height up to which the
+// blocks are pruned is persisted in db.
+func NewPruner(
+	txIdxr TxIndexer,
+	blockIdxr indexer.BlockIndexer,
+	blockStore sm.BlockStore,
+	db dbm.DB,
+	retainBlocks uint64,
+	withStore bool,
+	interval time.Duration,
+) *Pruner {
+	p := &Pruner{
+		txIdxr:       txIdxr,
+		blockIdxr:    blockIdxr,
+		blockStore:   blockStore,
+		db:           db,
+		retainBlocks: retainBlocks,
+		withStore:    withStore,
+		interval:     interval,
+	}
+	p.BaseService = *service.NewBaseService(nil, "IndexerPruner", p)
+	return p
+}
+
+// OnStart implements service.Service by starting to prune periodically.
+func (p *Pruner) OnStart() error {
+	if _, ok := p.txIdxr.(indexer.Pruner); !ok {
+		p.Logger.Info("the tx indexer does not support pruning")
+	}
+	if _, ok := p.blockIdxr.(indexer.Pruner); !ok {
+		p.Logger.Info("the block indexer does not support pruning")
+	}
+	go p.pruneRoutine()
+	return nil
+}
+
+func (p *Pruner) pruneRoutine() {
+	for {
+		timer := time.NewTimer(p.Interval())
+		select {
+		case <-timer.C:
+			if err := p.Prune(); err != nil {
+				p.Logger.Error("failed to prune the indexes", "err", err)
+			}
+		case <-p.Quit():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// Interval returns the interval between two runs of the pruner.
+func (p *Pruner) Interval() time.Duration {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.interval
+}
+
+// SetInterval sets the interval between two runs of the pruner. The pending
+// run keeps the previous interval.
+func (p *Pruner) SetInterval(interval time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.interval = interval
+}
+
+// RetainHeight returns the height of the first block whose index entries are
+// retained, or 0 if all are.
+func (p *Pruner) RetainHeight() int64 {
+	var retainHeight int64
+	if p.retainBlocks > 0 {
+		retainHeight = p.blockStore.Height() - int64(p.retainBlocks) + 1
+	}
+	if base := p.blockStore.Base(); p.withStore && base > retainHeight {
+		retainHeight = base
+	}
+	if retainHeight < 0 {
+		return 0
+	}
+	return retainHeight
+}
+
+// Prune deletes the index entries of the blocks below the retain height,
+// from the height pruned last.
+func (p *Pruner) Prune() error {
+	_, err := p.PruneTo(p.RetainHeight())
+	return err
+}
+
+// PruneTo deletes the index entries of the blocks from the height pruned last
+// up to, but not including, retainHeight, and returns the number of entries
+// deleted.
+func (p *Pruner) PruneTo(retainHeight int64) (uint64, error) {
+	prunedHeight, err := loadPrunedHeight(p.db)
+	if err != nil {
+		return 0, err
+	}
+	if retainHeight <= prunedHeight+1 {
+		return 0, nil
+	}
+
+	from := prunedHeight + 1
+	var pruned uint64
+	for _, idx := range []interface{}{p.blockIdxr, p.txIdxr} {
+		if pruner, ok := idx.(indexer.Pruner); ok {
+			n, err := pruner.Prune(from, retainHeight)
+			pruned += n
+			if err != nil {
+				return pruned, err
+			}
+		}
+	}
+
+	if err := savePrunedHeight(p.db, retainHeight-1); err != nil {
+		return pruned, err
+	}
+	p.Logger.Info("pruned the indexes", "from", from, "retain_height", retainHeight, "pruned", pruned)
+	return pruned, nil
+}
+
+// PrunedHeight returns the height up to which the blocks are pruned, or 0 if
+// none is.
+func (p *Pruner) PrunedHeight() (int64, error) {
+	return loadPrunedHeight(p.db)
+}
+
+// loadPrunedHeight loads the height up to which the blocks are pruned from db,
+// or 0 if none is.
+func loadPrunedHeight(db dbm.DB) (int64, error) {
+	bz, err := db.Get(prunedHeightKey)
+	if err != nil {
+		return 0, fmt.Errorf("loading the pruned height: %w", err)
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("loading the pruned height: invalid length %d", len(bz))
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// savePrunedHeight persists the height up to which the blocks are pruned in db.
+func savePrunedHeight(db dbm.DB, height int64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return db.SetSync(prunedHeightKey, bz)
+}
@@ -0,0 +1,78 @@
+package txindex_test
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPruner(t *testing.T) {
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+	for h := int64(1); h <= 10; h++ {
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockHeader{Header: types.Header{Height: h}}))
+	}
+
+	blockStore := &smmocks.BlockStore{}
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("Base").Return(int64(6))
+
+	testCases := []struct {
+		name         string
+		retainBlocks uint64
+		withStore    bool
+		retainHeight int64
+	}{
+		{"retain all", 0, false, 0},
+		{"retain blocks", 3, false, 8},
+		{"with store", 0, true, 6},
+		{"retain blocks and with store", 3, true, 8},
+		{"retain more blocks than the store", 7, true, 6},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pruner := txindex.NewPruner(txIndexer, blockIndexer, blockStore, db.NewMemDB(),
+				tc.retainBlocks, tc.withStore, time.Minute)
+			require.Equal(t, tc.retainHeight, pruner.RetainHeight())
+		})
+	}
+
+	pruner := txindex.NewPruner(txIndexer, blockIndexer, blockStore, db.NewMemDB(), 0, true, time.Minute)
+	pruner.SetLogger(log.TestingLogger())
+	require.NoError(t, pruner.Prune())
+	for h := int64(1); h <= 10; h++ {
+		ok, err := blockIndexer.Has(h)
+		require.NoError(t, err)
+		require.Equal(t, h >= 6, ok)
+	}
+	prunedHeight, err := pruner.PrunedHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), prunedHeight)
+
+	// pruning to a retain height already pruned is a no-op
+	pruned, err := pruner.PruneTo(4)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	pruned, err = pruner.PruneTo(8)
+	require.NoError(t, err)
+	require.NotZero(t, pruned)
+	for h := int64(1); h <= 10; h++ {
+		ok, err := blockIndexer.Has(h)
+		require.NoError(t, err)
+		require.Equal(t, h >= 8, ok)
+	}
+	prunedHeight, err = pruner.PrunedHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), prunedHeight)
+}
@@ -120,6 +120,11 @@ func validateBlock(state State, block *types.Block) error {
 				state.LastBlockTime,
 			)
 		}
+		// with proposer-based timestamps, the time is validated against the
+		// clocks of the validators when proposed, and only has to increase
+		if types.IsPBTSEnabled(state.ConsensusParams) {
+			break
+		}
 		medianTime := MedianTime(block.LastCommit, state.LastValidators)
 		if !block.Time.Equal(medianTime) {
 			return fmt.Errorf("invalid block time. Expected %v, got %v",
@@ -128,6 +133,15 @@ func validateBlock(state State, block *types.Block) error {
 			)
 		}
 
+	case block.Height == state.InitialHeight && types.IsPBTSEnabled(state.ConsensusParams):
+		genesisTime := state.LastBlockTime
+		if block.Time.Before(genesisTime) {
+			return fmt.Errorf("block time %v is before genesis time %v",
+				block.Time,
+				genesisTime,
+			)
+		}
+
 	case block.Height == state.InitialHeight:
 		genesisTime := state.LastBlockTime
 		if !block.Time.Equal(genesisTime) {
@@ -95,6 +95,46 @@ func TestValidateBlockHeader(t *testing.T) {
 	}
 }
 
+func TestValidateBlockTimePBTS(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(3, 1)
+	state.ConsensusParams.Synchrony = cmtproto.SynchronyParams{
+		Precision:    500 * time.Millisecond,
+		MessageDelay: 2 * time.Second,
+	}
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		memmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+
+	for height := int64(1); height < 4; height++ {
+		proposerAddr := state.Validators.GetProposer().Address
+
+		// the time of the proposer is taken, rather than the genesis or median
+		// time, as long as it increases
+		block, _ := state.MakeBlock(height, makeTxs(height), lastCommit, nil, proposerAddr)
+		assert.True(t, block.Time.After(state.LastBlockTime))
+		block.Time = state.LastBlockTime.Add(time.Hour)
+		require.NoError(t, blockExec.ValidateBlock(state, block))
+		block.Time = state.LastBlockTime.Add(-time.Millisecond)
+		require.Error(t, blockExec.ValidateBlock(state, block))
+
+		var err error
+		state, _, lastCommit, err = makeAndCommitGoodBlock(state, height, lastCommit, proposerAddr, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+	}
+}
+
 func TestValidateBlockCommit(t *testing.T) {
 	proxyApp := newTestApp()
 	require.NoError(t, proxyApp.Start())
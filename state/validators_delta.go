@@ -0,0 +1,320 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func calcValidatorsDeltaKey(height int64) []byte {
+	return []byte(fmt.Sprintf("validatorsDeltaKey:%v", height))
+}
+
+// validatorsDelta is the change of the validator set at a height from the set
+// of the previous height: the set is rebuilt by applying the changes to the
+// previous set and incrementing the proposer priorities, as updateState does.
+//
+// It is encoded as the uvarint of its depth, followed by the changes, as
+// length-prefixed protobuf validators.
+type validatorsDelta struct {
+	// depth is the number of deltas since the last set stored in full,
+	// including this one.
+	depth uint64
+	// changes are the validators added or updated, and those removed, with a
+	// 0 voting power.
+	changes []*types.Validator
+}
+
+// newValidatorsDelta returns the delta of vals from prev, of depth depth.
+func newValidatorsDelta(prev, vals *types.ValidatorSet, depth uint64) *validatorsDelta {
+	delta := &validatorsDelta{depth: depth}
+	for _, val := range vals.Validators {
+		_, prevVal := prev.GetByAddress(val.Address)
+		if prevVal == nil || prevVal.VotingPower != val.VotingPower || !prevVal.PubKey.Equals(val.PubKey) {
+			delta.changes = append(delta.changes, types.NewValidator(val.PubKey, val.VotingPower))
+		}
+	}
+	for _, prevVal := range prev.Validators {
+		if !vals.HasAddress(prevVal.Address) {
+			delta.changes = append(delta.changes, types.NewValidator(prevVal.PubKey, 0))
+		}
+	}
+	return delta
+}
+
+// apply returns the validator set of the delta, from the set of the previous
+// height.
+func (delta *validatorsDelta) apply(prev *types.ValidatorSet) (*types.ValidatorSet, error) {
+	vals := prev.Copy()
+	if len(delta.changes) > 0 {
+		changes := make([]*types.Validator, len(delta.changes))
+		for i, val := range delta.changes {
+			changes[i] = val.Copy()
+		}
+		if err := vals.UpdateWithChangeSet(changes); err != nil {
+			return nil, err
+		}
+	}
+	vals.IncrementProposerPriority(1)
+	return vals, nil
+}
+
+func (delta *validatorsDelta) encode() ([]byte, error) {
+	bz := binary.AppendUvarint(nil, delta.depth)
+	for _, val := range delta.changes {
+		pv, err := val.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		vbz, err := pv.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bz = binary.AppendUvarint(bz, uint64(len(vbz)))
+		bz = append(bz, vbz...)
+	}
+	return bz, nil
+}
+
+func decodeValidatorsDelta(bz []byte) (*validatorsDelta, error) {
+	depth, n := binary.Uvarint(bz)
+	if n <= 0 {
+		return nil, errors.New("invalid depth")
+	}
+	delta := &validatorsDelta{depth: depth}
+	for bz = bz[n:]; len(bz) > 0; {
+		size, n := binary.Uvarint(bz)
+		if n <= 0 || uint64(len(bz)-n) < size {
+			return nil, errors.New("invalid validator length")
+		}
+		pv := new(cmtproto.Validator)
+		if err := pv.Unmarshal(bz[n : n+int(size)]); err != nil {
+			return nil, err
+		}
+		val, err := types.ValidatorFromProto(pv)
+		if err != nil {
+			return nil, err
+		}
+		delta.changes = append(delta.changes, val)
+		bz = bz[n+int(size):]
+	}
+	return delta, nil
+}
+
+// loadValidatorsDelta loads the delta of the validator set at height, or nil
+// if the set isn't stored as a delta.
+func loadValidatorsDelta(db dbm.DB, height int64) (*validatorsDelta, error) {
+	bz, err := db.Get(calcValidatorsDeltaKey(height))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	delta, err := decodeValidatorsDelta(bz)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the validators delta of height %d: %w", height, err)
+	}
+	return delta, nil
+}
+
+// loadValidatorsFromDelta returns the validator set of height rebuilt from its
+// delta, or nil if it isn't stored as a delta.
+func (store dbStore) loadValidatorsFromDelta(height int64) (*types.ValidatorSet, error) {
+	delta, err := loadValidatorsDelta(store.db, height)
+	if err != nil || delta == nil {
+		return nil, err
+	}
+	prev, err := store.LoadValidators(height - 1)
+	if err != nil {
+		return nil, fmt.Errorf("loading the validators of height %d the delta of height %d applies to: %w",
+			height-1, height, err)
+	}
+	return delta.apply(prev)
+}
+
+// validatorsDeltaDepth returns the number of deltas since the last validator
+// set stored in full, at height.
+func (store dbStore) validatorsDeltaDepth(height int64) (uint64, error) {
+	valInfo, err := loadValidatorsInfo(store.db, height)
+	if err != nil {
+		return 0, err
+	}
+	if valInfo.ValidatorSet != nil {
+		return 0, nil
+	}
+	lastStoredHeight := lastStoredHeightFor(height, valInfo.LastHeightChanged)
+	if lastStoredHeight != height {
+		if valInfo, err = loadValidatorsInfo(store.db, lastStoredHeight); err != nil {
+			return 0, err
+		}
+		if valInfo.ValidatorSet != nil {
+			return 0, nil
+		}
+	}
+	delta, err := loadValidatorsDelta(store.db, lastStoredHeight)
+	if err != nil || delta == nil {
+		return 0, err
+	}
+	return delta.depth, nil
+}
+
+// encodeValidatorsDelta returns the encoded delta of the validator set vals
+// of height from the set of the previous height, or nil if it must be stored
+// in full: at a checkpoint, when the previous set isn't stored, e.g. at the
+// initial height, every ValidatorSetDeltaInterval changes, when the delta
+// doesn't rebuild the set exactly, e.g. after a key rotation, or isn't smaller
+// than the set, of encoded size fullSize.
+func (store dbStore) encodeValidatorsDelta(height int64, vals *types.ValidatorSet, fullSize int) ([]byte, error) {
+	if store.ValidatorSetDeltaInterval <= 0 || height%valSetCheckpointInterval == 0 {
+		return nil, nil
+	}
+	prev, err := store.LoadValidators(height - 1)
+	if err != nil {
+		return nil, nil
+	}
+	depth, err := store.validatorsDeltaDepth(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	if depth+1 >= uint64(store.ValidatorSetDeltaInterval) {
+		return nil, nil
+	}
+
+	delta := newValidatorsDelta(prev, vals, depth+1)
+	rebuilt, err := delta.apply(prev)
+	if err != nil {
+		return nil, nil
+	}
+	if equal, err := equalValidatorSets(rebuilt, vals); err != nil || !equal {
+		return nil, err
+	}
+	bz, err := delta.encode()
+	if err != nil || len(bz) >= fullSize {
+		return nil, err
+	}
+	return bz, nil
+}
+
+// equalValidatorSets returns whether two validator sets are equal, including
+// their proposer priorities and proposer.
+func equalValidatorSets(a, b *types.ValidatorSet) (bool, error) {
+	pa, err := a.ToProto()
+	if err != nil {
+		return false, err
+	}
+	pb, err := b.ToProto()
+	if err != nil {
+		return false, err
+	}
+	bza, err := pa.Marshal()
+	if err != nil {
+		return false, err
+	}
+	bzb, err := pb.Marshal()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(bza, bzb), nil
+}
+
+// setFullValidators stores the validator set of height in full in batch, in
+// place of its delta.
+func (store dbStore) setFullValidators(batch dbm.Batch, height int64) error {
+	vals, err := store.LoadValidators(height)
+	if err != nil {
+		return err
+	}
+	pv, err := vals.ToProto()
+	if err != nil {
+		return err
+	}
+	bz, err := (&cmtstate.ValidatorsInfo{ValidatorSet: pv, LastHeightChanged: height}).Marshal()
+	if err != nil {
+		return err
+	}
+	if err := batch.Set(calcValidatorsKey(height), bz); err != nil {
+		return err
+	}
+	return batch.Delete(calcValidatorsDeltaKey(height))
+}
+
+// MigrateValidatorSets converts the validator sets stored in db between two
+// heights, inclusive, to the format of options: the sets stored in full at the
+// heights they changed are stored as deltas if ValidatorSetDeltaInterval is
+// set, and the deltas are stored in full otherwise. The heights without sets
+// stored, e.g. pruned, are skipped. It returns the number of sets converted.
+func MigrateValidatorSets(db dbm.DB, options StoreOptions, from, to int64) (int64, error) {
+	if from <= 0 || from > to {
+		return 0, fmt.Errorf("invalid heights %d to %d", from, to)
+	}
+	store := dbStore{db, options}
+	var converted int64
+	for height := from; height <= to; height++ {
+		ok, err := store.migrateValidatorSet(height)
+		if err != nil {
+			return converted, fmt.Errorf("converting the validators of height %d: %w", height, err)
+		}
+		if ok {
+			converted++
+		}
+	}
+	return converted, nil
+}
+
+// migrateValidatorSet converts the validator set of height to the format of
+// the options of the store, if it changed at height, and returns whether it
+// was converted.
+func (store dbStore) migrateValidatorSet(height int64) (bool, error) {
+	valInfo, err := loadValidatorsInfo(store.db, height)
+	if err != nil || valInfo.LastHeightChanged != height {
+		return false, nil
+	}
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	if store.ValidatorSetDeltaInterval > 0 {
+		if valInfo.ValidatorSet == nil {
+			return false, nil
+		}
+		vals, err := types.ValidatorSetFromProto(valInfo.ValidatorSet)
+		if err != nil {
+			return false, err
+		}
+		bz, err := store.encodeValidatorsDelta(height, vals, valInfo.Size())
+		if err != nil || bz == nil {
+			return false, err
+		}
+		if err := store.setValidatorsDelta(batch, height, bz); err != nil {
+			return false, err
+		}
+	} else {
+		if valInfo.ValidatorSet != nil {
+			return false, nil
+		}
+		delta, err := loadValidatorsDelta(store.db, height)
+		if err != nil || delta == nil {
+			return false, err
+		}
+		if err := store.setFullValidators(batch, height); err != nil {
+			return false, err
+		}
+	}
+	return true, batch.Write()
+}
+
+// setValidatorsDelta stores the encoded delta of the validator set of height
+// in batch, in place of the set.
+func (store dbStore) setValidatorsDelta(batch dbm.Batch, height int64, delta []byte) error {
+	bz, err := (&cmtstate.ValidatorsInfo{LastHeightChanged: height}).Marshal()
+	if err != nil {
+		return err
+	}
+	if err := batch.Set(calcValidatorsKey(height), bz); err != nil {
+		return err
+	}
+	return batch.Set(calcValidatorsDeltaKey(height), delta)
+}
@@ -0,0 +1,143 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// dbSize returns the number of keys of db with prefix, and the size of all
+// its values.
+func dbSize(t *testing.T, db dbm.DB, prefix string) (keys int, size int) {
+	t.Helper()
+	var start, end []byte
+	if prefix != "" {
+		start, end = []byte(prefix), []byte(prefix)
+		end[len(end)-1]++
+	}
+	it, err := db.Iterator(start, end)
+	require.NoError(t, err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		keys++
+		size += len(it.Value())
+	}
+	require.NoError(t, it.Error())
+	return keys, size
+}
+
+func requireSameValidators(t *testing.T, expected, actual sm.Store, from, to int64) {
+	t.Helper()
+	for h := from; h <= to; h++ {
+		exp, err := expected.LoadValidators(h)
+		require.NoError(t, err, h)
+		act, err := actual.LoadValidators(h)
+		require.NoError(t, err, h)
+		pexp, err := exp.ToProto()
+		require.NoError(t, err)
+		pact, err := act.ToProto()
+		require.NoError(t, err)
+		require.Equal(t, pexp, pact, h)
+	}
+}
+
+func TestValidatorSetDeltas(t *testing.T) {
+	const lastHeight = 40
+	fullDB, deltaDB := dbm.NewMemDB(), dbm.NewMemDB()
+	full := sm.NewStore(fullDB, sm.StoreOptions{})
+	deltas := sm.NewStore(deltaDB, sm.StoreOptions{ValidatorSetDeltaInterval: 8})
+
+	vals, _ := types.RandValidatorSet(30, 10)
+	state := sm.State{
+		ChainID:                          "test-chain",
+		InitialHeight:                    1,
+		LastValidators:                   types.NewValidatorSet(nil),
+		Validators:                       vals,
+		NextValidators:                   vals.CopyIncrementProposerPriority(1),
+		LastHeightValidatorsChanged:      1,
+		ConsensusParams:                  cmtproto.ConsensusParams{Block: cmtproto.BlockParams{MaxBytes: 10e6}},
+		LastHeightConsensusParamsChanged: 1,
+	}
+	// the validators change as with updateState: power changes, additions,
+	// removals and a key rotation
+	for h := int64(0); h <= lastHeight; h++ {
+		require.NoError(t, full.Save(state))
+		require.NoError(t, deltas.Save(state))
+
+		next := state.NextValidators.Copy()
+		var changes []*types.Validator
+		switch {
+		case h == 10:
+			val, _ := types.RandValidator(false, 50)
+			changes = append(changes, val)
+		case h == 20:
+			changes = append(changes, types.NewValidator(next.Validators[3].PubKey, 0))
+		case h%3 == 0:
+			val := next.Validators[int(h)%next.Size()]
+			changes = append(changes, types.NewValidator(val.PubKey, val.VotingPower+1))
+		}
+		lastHeightChanged := state.LastHeightValidatorsChanged
+		if len(changes) > 0 {
+			require.NoError(t, next.UpdateWithChangeSet(changes))
+			lastHeightChanged = h + 3
+		}
+		if h == 25 {
+			require.NoError(t, next.RotateKey(next.Validators[0].Address, ed25519.GenPrivKey().PubKey(), nil))
+			lastHeightChanged = h + 3
+		}
+		next.IncrementProposerPriority(1)
+
+		state = sm.State{
+			ChainID:                          state.ChainID,
+			InitialHeight:                    1,
+			LastBlockHeight:                  h + 1,
+			LastValidators:                   state.Validators,
+			Validators:                       state.NextValidators,
+			NextValidators:                   next,
+			LastHeightValidatorsChanged:      lastHeightChanged,
+			ConsensusParams:                  state.ConsensusParams,
+			LastHeightConsensusParamsChanged: 1,
+		}
+	}
+
+	// the sets are rebuilt from the deltas, with a full set every 8 changes
+	requireSameValidators(t, full, deltas, 1, lastHeight+2)
+	numDeltas, _ := dbSize(t, deltaDB, "validatorsDeltaKey:")
+	assert.Greater(t, numDeltas, 10)
+	assert.Less(t, numDeltas, 16)
+	_, fullSize := dbSize(t, fullDB, "")
+	_, deltaSize := dbSize(t, deltaDB, "")
+	assert.Less(t, deltaSize, fullSize/2)
+
+	// the stored sets are converted to deltas and back
+	converted, err := sm.MigrateValidatorSets(fullDB, sm.StoreOptions{ValidatorSetDeltaInterval: 8}, 1, lastHeight+2)
+	require.NoError(t, err)
+	assert.EqualValues(t, numDeltas, converted)
+	requireSameValidators(t, deltas, full, 1, lastHeight+2)
+	_, size := dbSize(t, fullDB, "")
+	assert.Equal(t, deltaSize, size)
+
+	converted, err = sm.MigrateValidatorSets(fullDB, sm.StoreOptions{}, 1, lastHeight+2)
+	require.NoError(t, err)
+	assert.EqualValues(t, numDeltas, converted)
+	requireSameValidators(t, deltas, full, 1, lastHeight+2)
+	n, _ := dbSize(t, fullDB, "validatorsDeltaKey:")
+	assert.Zero(t, n)
+
+	// the sets remaining after pruning are rebuilt without the pruned deltas
+	for _, to := range []int64{14, 30, 39} {
+		require.NoError(t, full.PruneStates(1, to))
+		require.NoError(t, deltas.PruneStates(1, to))
+		requireSameValidators(t, full, deltas, to, lastHeight+2)
+	}
+	n, _ = dbSize(t, deltaDB, "validatorsDeltaKey:")
+	assert.Less(t, n, numDeltas)
+}
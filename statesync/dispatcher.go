@@ -0,0 +1,195 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// metadataTimeout is the timeout while waiting for the metadata of a height
+// from a peer.
+const metadataTimeout = 10 * time.Second
+
+// dispatchKey identifies a metadata request.
+type dispatchKey struct {
+	peer   p2p.ID
+	height uint64
+	index  uint32
+}
+
+// dispatcher sends the metadata requests to the peers and routes their
+// responses back to the callers.
+type dispatcher struct {
+	mtx   cmtsync.Mutex
+	calls map[dispatchKey]chan *ssproto.ChunkResponse
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{calls: make(map[dispatchKey]chan *ssproto.ChunkResponse)}
+}
+
+// request requests the metadata of a reserved chunk index at height from
+// peer, and waits for the response. It returns nil if the peer doesn't hold
+// it.
+func (d *dispatcher) request(
+	ctx context.Context,
+	logger log.Logger,
+	peer p2p.Peer,
+	height uint64,
+	index uint32,
+) ([]byte, error) {
+	key := dispatchKey{peer: peer.ID(), height: height, index: index}
+	ch := make(chan *ssproto.ChunkResponse, 1)
+	d.mtx.Lock()
+	if _, ok := d.calls[key]; ok {
+		d.mtx.Unlock()
+		return nil, fmt.Errorf("a request of chunk %d at height %d is already in flight to peer %v",
+			index, height, peer.ID())
+	}
+	d.calls[key] = ch
+	d.mtx.Unlock()
+	defer func() {
+		d.mtx.Lock()
+		delete(d.calls, key)
+		d.mtx.Unlock()
+	}()
+
+	sent := p2p.TrySendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: ChunkChannel,
+		Message:   &ssproto.ChunkRequest{Height: height, Index: index},
+	}, logger)
+	if !sent {
+		return nil, fmt.Errorf("failed to send the request to peer %v", peer.ID())
+	}
+	select {
+	case resp := <-ch:
+		if resp.Missing {
+			return nil, nil
+		}
+		return resp.Chunk, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// respond routes the response of a metadata request from peer to its caller,
+// and returns whether it was awaited.
+func (d *dispatcher) respond(peer p2p.ID, resp *ssproto.ChunkResponse) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	ch, ok := d.calls[dispatchKey{peer: peer, height: resp.Height, index: resp.Index}]
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- resp:
+	default: // duplicate response
+	}
+	return true
+}
+
+// blockProvider is a light block provider fetching the light blocks from a
+// peer, through the dispatcher.
+type blockProvider struct {
+	logger     log.Logger
+	chainID    string
+	peer       p2p.Peer
+	dispatcher *dispatcher
+}
+
+var _ lightprovider.Provider = (*blockProvider)(nil)
+
+func newBlockProvider(logger log.Logger, chainID string, peer p2p.Peer, dispatcher *dispatcher) *blockProvider {
+	return &blockProvider{logger: logger, chainID: chainID, peer: peer, dispatcher: dispatcher}
+}
+
+// ChainID implements lightprovider.Provider.
+func (p *blockProvider) ChainID() string {
+	return p.chainID
+}
+
+// LightBlock implements lightprovider.Provider. The latest light block isn't
+// served, as the heights of the requests can't be 0.
+func (p *blockProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		return nil, lightprovider.ErrLightBlockNotFound
+	}
+	bz, err := p.fetch(ctx, uint64(height), lightBlockChunkIndex)
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, lightprovider.ErrLightBlockNotFound
+	}
+	pb := new(cmtproto.LightBlock)
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	lb, err := types.LightBlockFromProto(pb)
+	if err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	if lb.Height != height {
+		return nil, lightprovider.ErrBadLightBlock{
+			Reason: fmt.Errorf("expected light block of height %d, got %d", height, lb.Height),
+		}
+	}
+	return lb, nil
+}
+
+// ConsensusParams fetches the consensus params of the height of header, and
+// verifies them against its consensus hash: the header must be trusted.
+func (p *blockProvider) ConsensusParams(ctx context.Context, header *types.Header) (*cmtproto.ConsensusParams, error) {
+	bz, err := p.fetch(ctx, uint64(header.Height), consensusParamsChunkIndex)
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("peer %v doesn't hold the consensus params of height %d", p.peer.ID(), header.Height)
+	}
+	params := new(cmtproto.ConsensusParams)
+	if err := params.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("invalid consensus params from peer %v: %w", p.peer.ID(), err)
+	}
+	if hash := types.HashConsensusParams(*params); !bytes.Equal(hash, header.ConsensusHash) {
+		return nil, fmt.Errorf("consensus params from peer %v don't match the consensus hash %X of height %d",
+			p.peer.ID(), header.ConsensusHash, header.Height)
+	}
+	return params, nil
+}
+
+// fetch fetches the metadata of a reserved chunk index at height, or nil if
+// the peer doesn't hold it.
+func (p *blockProvider) fetch(ctx context.Context, height uint64, index uint32) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+	bz, err := p.dispatcher.request(ctx, p.logger, p.peer, height, index)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return nil, lightprovider.ErrNoResponse
+	}
+	return bz, err
+}
+
+// ReportEvidence implements lightprovider.Provider. The evidence isn't
+// reported over the state sync channels.
+func (p *blockProvider) ReportEvidence(context.Context, types.Evidence) error {
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (p *blockProvider) String() string {
+	return fmt.Sprintf("peer %v", p.peer.ID())
+}
@@ -0,0 +1,108 @@
+package statesync
+
+import (
+	"fmt"
+	"math"
+
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The chunk requests and responses of these reserved indices carry the
+// CometBFT metadata of a height, rather than a chunk of an app snapshot: the
+// light block, i.e. the header, commit and validator set, and the consensus
+// params. No app snapshot has that many chunks, and the nodes which don't
+// serve the metadata report the chunks as missing, or don't respond.
+const (
+	lightBlockChunkIndex      = uint32(math.MaxUint32)
+	consensusParamsChunkIndex = uint32(math.MaxUint32 - 1)
+)
+
+// isMetadataChunk returns whether a chunk index is reserved for the metadata.
+func isMetadataChunk(index uint32) bool {
+	return index == lightBlockChunkIndex || index == consensusParamsChunkIndex
+}
+
+// metadataStore generates the CometBFT-side metadata of the snapshots from the
+// state and block stores, for the light clients of the state-syncing nodes to
+// verify them. The metadata of a height is the same on all the nodes: the
+// commits are the canonical ones, stored in the next block, rather than the
+// commits seen by the node, so the light block of the latest height isn't
+// available.
+type metadataStore struct {
+	stateStore sm.Store
+	blockStore sm.BlockStore
+}
+
+// LightBlock returns the light block of height, or nil if the stores don't
+// hold it, e.g. it is pruned or not committed yet.
+func (ms metadataStore) LightBlock(height int64) (*types.LightBlock, error) {
+	meta := ms.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, nil
+	}
+	commit := ms.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		return nil, nil
+	}
+	vals, err := ms.stateStore.LoadValidators(height)
+	if err != nil {
+		if _, ok := err.(sm.ErrNoValSetForHeight); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+		ValidatorSet: vals,
+	}
+	if err := lb.ValidateBasic(meta.Header.ChainID); err != nil {
+		return nil, fmt.Errorf("invalid light block of height %d: %w", height, err)
+	}
+	return lb, nil
+}
+
+// ConsensusParams returns the consensus params of height, or nil if the
+// stores don't hold them.
+func (ms metadataStore) ConsensusParams(height int64) (*cmtproto.ConsensusParams, error) {
+	if ms.blockStore.LoadBlockMeta(height) == nil {
+		return nil, nil
+	}
+	params, err := ms.stateStore.LoadConsensusParams(height)
+	if err != nil {
+		if _, ok := err.(sm.ErrNoConsensusParamsForHeight); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &params, nil
+}
+
+// LoadChunk returns the encoded metadata of a reserved chunk index at height,
+// or nil if the stores don't hold it.
+func (ms metadataStore) LoadChunk(height uint64, index uint32) ([]byte, error) {
+	if height > math.MaxInt64 {
+		return nil, nil
+	}
+	switch index {
+	case lightBlockChunkIndex:
+		lb, err := ms.LightBlock(int64(height))
+		if err != nil || lb == nil {
+			return nil, err
+		}
+		pb, err := lb.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		return pb.Marshal()
+	case consensusParamsChunkIndex:
+		params, err := ms.ConsensusParams(int64(height))
+		if err != nil || params == nil {
+			return nil, err
+		}
+		return params.Marshal()
+	default:
+		return nil, fmt.Errorf("chunk index %d isn't reserved for the metadata", index)
+	}
+}
@@ -0,0 +1,262 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/p2p"
+	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	cmtversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// metaBlockStore is a block store holding the metas and commits of some
+// blocks.
+type metaBlockStore struct {
+	sm.BlockStore
+	metas   map[int64]*types.BlockMeta
+	commits map[int64]*types.Commit
+}
+
+func (bs metaBlockStore) LoadBlockMeta(height int64) *types.BlockMeta { return bs.metas[height] }
+func (bs metaBlockStore) LoadBlockCommit(height int64) *types.Commit  { return bs.commits[height] }
+
+// makeMetadataStores returns the stores of a chain of lastHeight blocks, and
+// its headers.
+func makeMetadataStores(t *testing.T, chainID string, lastHeight int64) (sm.Store, metaBlockStore, []*types.Header) {
+	t.Helper()
+	vals, privVals := types.RandValidatorSet(4, 10)
+	params := types.DefaultConsensusParams()
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	blockStore := metaBlockStore{metas: map[int64]*types.BlockMeta{}, commits: map[int64]*types.Commit{}}
+	headers := []*types.Header{nil}
+
+	var lastBlockID types.BlockID
+	now := time.Now().Add(-time.Hour)
+	for h := int64(1); h <= lastHeight; h++ {
+		require.NoError(t, stateStore.Save(sm.State{
+			ChainID:                          chainID,
+			InitialHeight:                    1,
+			LastBlockHeight:                  h - 1,
+			LastValidators:                   vals,
+			Validators:                       vals,
+			NextValidators:                   vals,
+			LastHeightValidatorsChanged:      1,
+			ConsensusParams:                  *params,
+			LastHeightConsensusParamsChanged: 1,
+		}))
+		header := &types.Header{
+			Version:            cmtversion.Consensus{Block: version.BlockProtocol},
+			ChainID:            chainID,
+			Height:             h,
+			Time:               now.Add(time.Duration(h) * time.Second),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			ConsensusHash:      types.HashConsensusParams(*params),
+			AppHash:            []byte{byte(h)},
+			ProposerAddress:    vals.Validators[0].Address,
+		}
+		if h > 1 {
+			header.LastCommitHash = blockStore.commits[h-1].Hash()
+		}
+		blockID := types.BlockID{
+			Hash:          header.Hash(),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte{byte(h)})},
+		}
+		blockStore.metas[h] = &types.BlockMeta{BlockID: blockID, Header: *header}
+		voteSet := types.NewVoteSet(chainID, h, 0, cmtproto.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, h, 0, voteSet, privVals, header.Time.Add(time.Second))
+		require.NoError(t, err)
+		blockStore.commits[h] = commit
+		headers = append(headers, header)
+		lastBlockID = blockID
+	}
+	// the commit of the last block is only seen, not stored in a block
+	delete(blockStore.commits, lastHeight)
+	return stateStore, blockStore, headers
+}
+
+func TestMetadataStore(t *testing.T) {
+	stateStore, blockStore, headers := makeMetadataStores(t, "test-chain", 4)
+	ms := metadataStore{stateStore: stateStore, blockStore: blockStore}
+
+	for h := int64(1); h <= 3; h++ {
+		lb, err := ms.LightBlock(h)
+		require.NoError(t, err)
+		require.NotNil(t, lb, h)
+		assert.Equal(t, headers[h].Hash(), lb.Hash())
+		assert.EqualValues(t, h, lb.Commit.Height)
+
+		params, err := ms.ConsensusParams(h)
+		require.NoError(t, err)
+		assert.EqualValues(t, headers[h].ConsensusHash, types.HashConsensusParams(*params))
+	}
+	// the latest block has no canonical commit, and the next ones aren't committed
+	for _, h := range []int64{4, 5} {
+		lb, err := ms.LightBlock(h)
+		require.NoError(t, err)
+		assert.Nil(t, lb, h)
+	}
+	params, err := ms.ConsensusParams(5)
+	require.NoError(t, err)
+	assert.Nil(t, params)
+
+	bz, err := ms.LoadChunk(2, lightBlockChunkIndex)
+	require.NoError(t, err)
+	pb := new(cmtproto.LightBlock)
+	require.NoError(t, pb.Unmarshal(bz))
+	lb, err := types.LightBlockFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), lb.Hash())
+	bz, err = ms.LoadChunk(9, consensusParamsChunkIndex)
+	require.NoError(t, err)
+	assert.Nil(t, bz)
+	_, err = ms.LoadChunk(2, 0)
+	assert.Error(t, err)
+}
+
+// connectReactors returns the server as a mock peer of the client: the
+// requests sent to it are received by the server, and its responses by the
+// client.
+func connectReactors(t *testing.T, client, server *Reactor, id p2p.ID) *p2pmocks.PeerEnvelopeSender {
+	serverPeer := &p2pmocks.PeerEnvelopeSender{}
+	serverPeer.On("ID").Return(id)
+	clientPeer := &p2pmocks.PeerEnvelopeSender{}
+	clientPeer.On("ID").Return(p2p.ID("client"))
+	serverPeer.On("TrySendEnvelope", mock.Anything).Run(func(args mock.Arguments) {
+		server.ReceiveEnvelope(roundtrip(t, args[0].(p2p.Envelope), clientPeer))
+	}).Return(true)
+	clientPeer.On("SendEnvelope", mock.Anything).Run(func(args mock.Arguments) {
+		client.ReceiveEnvelope(roundtrip(t, args[0].(p2p.Envelope), serverPeer))
+	}).Return(true)
+	return serverPeer
+}
+
+// roundtrip marshals the message of e to simulate a wire roundtrip, and sets
+// its source.
+func roundtrip(t *testing.T, e p2p.Envelope, src p2p.Peer) p2p.Envelope {
+	bz, err := proto.Marshal(e.Message)
+	require.NoError(t, err)
+	msg := proto.Clone(e.Message)
+	require.NoError(t, proto.Unmarshal(bz, msg))
+	return p2p.Envelope{ChannelID: e.ChannelID, Src: src, Message: msg}
+}
+
+func startReactor(t *testing.T, options ...ReactorOption) *Reactor {
+	r := NewReactor(*config.DefaultStateSyncConfig(), nil, nil, "", options...)
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return r
+}
+
+func TestBlockProvider(t *testing.T) {
+	stateStore, blockStore, headers := makeMetadataStores(t, "test-chain", 4)
+	client := startReactor(t)
+	server := startReactor(t, WithStores(stateStore, blockStore))
+	ctx := context.Background()
+	logger := log.TestingLogger()
+
+	provider := newBlockProvider(logger, "test-chain", connectReactors(t, client, server, "server"), client.dispatcher)
+	lb, err := provider.LightBlock(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), lb.Hash())
+	for _, height := range []int64{0, 4} {
+		_, err = provider.LightBlock(ctx, height)
+		assert.Equal(t, lightprovider.ErrLightBlockNotFound, err, height)
+	}
+	params, err := provider.ConsensusParams(ctx, headers[3])
+	require.NoError(t, err)
+	assert.Equal(t, *types.DefaultConsensusParams(), *params)
+
+	// the consensus params must match the header
+	header := *headers[3]
+	header.ConsensusHash = tmhash.Sum([]byte("other"))
+	_, err = provider.ConsensusParams(ctx, &header)
+	assert.Error(t, err)
+
+	// the light blocks of another chain are rejected
+	other := newBlockProvider(logger, "other-chain", connectReactors(t, client, server, "server"), client.dispatcher)
+	_, err = other.LightBlock(ctx, 2)
+	assert.IsType(t, lightprovider.ErrBadLightBlock{}, err)
+
+	// the nodes without the stores report the metadata as missing
+	provider = newBlockProvider(logger, "test-chain", connectReactors(t, client, startReactor(t), "old"),
+		client.dispatcher)
+	_, err = provider.LightBlock(ctx, 2)
+	assert.Equal(t, lightprovider.ErrLightBlockNotFound, err)
+
+	// and the peers which don't respond time out
+	silent := &p2pmocks.PeerEnvelopeSender{}
+	silent.On("ID").Return(p2p.ID("silent"))
+	silent.On("TrySendEnvelope", mock.Anything).Return(true)
+	provider = newBlockProvider(logger, "test-chain", silent, client.dispatcher)
+	tctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = provider.LightBlock(tctx, 2)
+	assert.Equal(t, lightprovider.ErrNoResponse, err)
+}
+
+func TestP2PStateProvider(t *testing.T) {
+	stateStore, blockStore, headers := makeMetadataStores(t, "test-chain", 4)
+	client := startReactor(t)
+	var peers []p2p.Peer
+	for _, id := range []p2p.ID{"a", "b"} {
+		server := startReactor(t, WithStores(stateStore, blockStore))
+		peers = append(peers, connectReactors(t, client, server, id))
+	}
+	stateProvider := &p2pStateProvider{
+		chainID:       "test-chain",
+		initialHeight: 1,
+		trustOptions:  light.TrustOptions{Period: time.Hour * 24, Height: 1, Hash: headers[1].Hash()},
+		logger:        log.TestingLogger(),
+		peers:         func() []p2p.Peer { return peers[:1] },
+		dispatcher:    client.dispatcher,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := stateProvider.AppHash(ctx, 1)
+	assert.Error(t, err, "a single peer")
+
+	stateProvider.peers = func() []p2p.Peer { return peers }
+	ctx = context.Background()
+	appHash, err := stateProvider.AppHash(ctx, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, []byte{2}, appHash)
+
+	commit, err := stateProvider.Commit(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), commit.BlockID.Hash)
+
+	state, err := stateProvider.State(ctx, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, state.LastBlockHeight)
+	assert.Equal(t, headers[1].Hash(), state.LastBlockID.Hash)
+	assert.EqualValues(t, headers[3].ValidatorsHash, state.NextValidators.Hash())
+	assert.Equal(t, *types.DefaultConsensusParams(), state.ConsensusParams)
+	assert.EqualValues(t, 2, state.LastHeightConsensusParamsChanged)
+
+	// the light block of height 3 needs the commit stored in block 4
+	_, err = stateProvider.AppHash(ctx, 2)
+	assert.Error(t, err)
+}
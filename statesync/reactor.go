@@ -36,26 +36,49 @@ type Reactor struct {
 	connQuery proxy.AppConnQuery
 	tempDir   string
 
+	// metadata serves the CometBFT-side metadata of the snapshots, if set, and
+	// dispatcher routes the metadata received to the p2p state providers.
+	metadata   *metadataStore
+	dispatcher *dispatcher
+
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
 }
 
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithStores sets the state and block stores the reactor generates the
+// CometBFT-side metadata of the snapshots from: the light blocks and consensus
+// params the peers verify the snapshots with, when syncing with
+// NewP2PStateProvider. Without them, the metadata is reported as missing.
+func WithStores(stateStore sm.Store, blockStore sm.BlockStore) ReactorOption {
+	return func(r *Reactor) {
+		r.metadata = &metadataStore{stateStore: stateStore, blockStore: blockStore}
+	}
+}
+
 // NewReactor creates a new state sync reactor.
 func NewReactor(
 	cfg config.StateSyncConfig,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	tempDir string,
+	options ...ReactorOption,
 ) *Reactor {
 
 	r := &Reactor{
-		cfg:       cfg,
-		conn:      conn,
-		connQuery: connQuery,
+		cfg:        cfg,
+		conn:       conn,
+		connQuery:  connQuery,
+		dispatcher: newDispatcher(),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
+	for _, option := range options {
+		option(r)
+	}
 
 	return r
 }
@@ -169,6 +192,10 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 	case ChunkChannel:
 		switch msg := e.Message.(type) {
 		case *ssproto.ChunkRequest:
+			if isMetadataChunk(msg.Index) {
+				r.sendMetadata(e.Src, msg)
+				return
+			}
 			r.Logger.Debug("Received chunk request", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
 			resp, err := r.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
@@ -195,6 +222,13 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			}, r.Logger)
 
 		case *ssproto.ChunkResponse:
+			if isMetadataChunk(msg.Index) {
+				if !r.dispatcher.respond(e.Src.ID(), msg) {
+					r.Logger.Debug("Received unexpected metadata", "height", msg.Height, "chunk", msg.Index,
+						"peer", e.Src.ID())
+				}
+				return
+			}
 			r.mtx.RLock()
 			defer r.mtx.RUnlock()
 			if r.syncer == nil {
@@ -243,6 +277,32 @@ func (r *Reactor) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {
 	})
 }
 
+// sendMetadata sends the metadata of a reserved chunk index requested by peer,
+// or reports it as missing if the stores don't hold it.
+func (r *Reactor) sendMetadata(peer p2p.Peer, msg *ssproto.ChunkRequest) {
+	var bz []byte
+	if r.metadata != nil {
+		var err error
+		bz, err = r.metadata.LoadChunk(msg.Height, msg.Index)
+		if err != nil {
+			r.Logger.Error("Failed to load metadata", "height", msg.Height, "chunk", msg.Index, "err", err)
+			return
+		}
+	}
+	r.Logger.Debug("Sending metadata", "height", msg.Height, "chunk", msg.Index, "missing", bz == nil,
+		"peer", peer.ID())
+	p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		ChannelID: ChunkChannel,
+		Message: &ssproto.ChunkResponse{
+			Height:  msg.Height,
+			Format:  msg.Format,
+			Index:   msg.Index,
+			Chunk:   bz,
+			Missing: bz == nil,
+		},
+	}, r.Logger)
+}
+
 // recentSnapshots fetches the n most recent snapshots from the app
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	resp, err := r.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
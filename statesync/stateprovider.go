@@ -15,6 +15,7 @@ import (
 	lighthttp "github.com/tendermint/tendermint/light/provider/http"
 	lightrpc "github.com/tendermint/tendermint/light/rpc"
 	lightdb "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/p2p"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	sm "github.com/tendermint/tendermint/state"
@@ -135,6 +136,188 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 		state.InitialHeight = 1
 	}
 
+	currentLightBlock, nextLightBlock, err := verifyStateLightBlocks(ctx, s.lc, &state, height)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	// We'll also need to fetch consensus params via RPC, using light client verification.
+	primaryURL, ok := s.providers[s.lc.Primary()]
+	if !ok || primaryURL == "" {
+		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
+	}
+	primaryRPC, err := rpcClient(primaryURL)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
+	}
+	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
+	result, err := rpcclient.ConsensusParams(ctx, &currentLightBlock.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
+			nextLightBlock.Height, err)
+	}
+	state.ConsensusParams = result.ConsensusParams
+	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
+
+	return state, nil
+}
+
+// p2pStateProvider is a state provider using the light client with the light
+// blocks and consensus params served by the peers, rather than by RPC servers.
+// The light client is created on first use, once peers are connected, with
+// one of them as the primary and the others as witnesses.
+type p2pStateProvider struct {
+	cmtsync.Mutex // light.Client is not concurrency-safe
+	lc            *light.Client
+	chainID       string
+	version       cmtstate.Version
+	initialHeight int64
+	trustOptions  light.TrustOptions
+	logger        log.Logger
+	peers         func() []p2p.Peer
+	dispatcher    *dispatcher
+}
+
+// NewP2PStateProvider creates a new StateProvider using a light client and the
+// peers of the state sync reactor.
+func NewP2PStateProvider(
+	chainID string,
+	version cmtstate.Version,
+	initialHeight int64,
+	reactor *Reactor,
+	trustOptions light.TrustOptions,
+	logger log.Logger,
+) (StateProvider, error) {
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid trust options: %w", err)
+	}
+	return &p2pStateProvider{
+		chainID:       chainID,
+		version:       version,
+		initialHeight: initialHeight,
+		trustOptions:  trustOptions,
+		logger:        logger,
+		peers: func() []p2p.Peer {
+			return reactor.Switch.Peers().List()
+		},
+		dispatcher: reactor.dispatcher,
+	}, nil
+}
+
+// client returns the light client, creating it if needed once at least two
+// peers are connected.
+func (s *p2pStateProvider) client(ctx context.Context) (*light.Client, error) {
+	if s.lc != nil {
+		return s.lc, nil
+	}
+	peers := s.peers()
+	for len(peers) < 2 {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("at least 2 peers are required, got %v", len(peers))
+		case <-time.After(100 * time.Millisecond):
+		}
+		peers = s.peers()
+	}
+
+	providers := make([]lightprovider.Provider, 0, len(peers))
+	for _, peer := range peers {
+		providers = append(providers, newBlockProvider(s.logger, s.chainID, peer, s.dispatcher))
+	}
+	lc, err := light.NewClient(ctx, s.chainID, s.trustOptions, providers[0], providers[1:],
+		lightdb.New(dbm.NewMemDB(), ""), light.Logger(s.logger), light.MaxRetryAttempts(5))
+	if err != nil {
+		return nil, err
+	}
+	s.lc = lc
+	return lc, nil
+}
+
+// AppHash implements StateProvider.
+func (s *p2pStateProvider) AppHash(ctx context.Context, height uint64) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+	lc, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// We have to fetch the next height, which contains the app hash for the previous height.
+	header, err := lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// As lightClientStateProvider does, we also verify height+2, needed to build the state.
+	_, err = lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return header.AppHash, nil
+}
+
+// Commit implements StateProvider.
+func (s *p2pStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
+	s.Lock()
+	defer s.Unlock()
+	lc, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return header.Commit, nil
+}
+
+// State implements StateProvider.
+func (s *p2pStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
+	s.Lock()
+	defer s.Unlock()
+	lc, err := s.client(ctx)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	state := sm.State{
+		ChainID:       s.chainID,
+		Version:       s.version,
+		InitialHeight: s.initialHeight,
+	}
+	if state.InitialHeight == 0 {
+		state.InitialHeight = 1
+	}
+	currentLightBlock, _, err := verifyStateLightBlocks(ctx, lc, &state, height)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	// The consensus params are fetched from the primary, and verified against the
+	// consensus hash of the verified header.
+	primary, ok := lc.Primary().(*blockProvider)
+	if !ok {
+		return sm.State{}, fmt.Errorf("unexpected primary light client provider %v", lc.Primary())
+	}
+	params, err := primary.ConsensusParams(ctx, currentLightBlock.Header)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
+			currentLightBlock.Height, err)
+	}
+	state.ConsensusParams = *params
+	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
+
+	return state, nil
+}
+
+// verifyStateLightBlocks verifies the light blocks of the snapshot height and
+// the two next heights with the light client, and sets the fields of state
+// they hold. It returns the light blocks of the current and next heights.
+func verifyStateLightBlocks(
+	ctx context.Context,
+	lc *light.Client,
+	state *sm.State,
+	height uint64,
+) (*types.LightBlock, *types.LightBlock, error) {
 	// The snapshot height maps onto the state heights as follows:
 	//
 	// height: last block, i.e. the snapshotted height
@@ -143,17 +326,17 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	//
 	// We need to fetch the NextValidators from height+2 because if the application changed
 	// the validator set at the snapshot height then this only takes effect at height+2.
-	lastLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	lastLightBlock, err := lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
 	if err != nil {
-		return sm.State{}, err
+		return nil, nil, err
 	}
-	currentLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	currentLightBlock, err := lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
 	if err != nil {
-		return sm.State{}, err
+		return nil, nil, err
 	}
-	nextLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
+	nextLightBlock, err := lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
 	if err != nil {
-		return sm.State{}, err
+		return nil, nil, err
 	}
 
 	state.Version = cmtstate.Version{
@@ -169,26 +352,7 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	state.Validators = currentLightBlock.ValidatorSet
 	state.NextValidators = nextLightBlock.ValidatorSet
 	state.LastHeightValidatorsChanged = nextLightBlock.Height
-
-	// We'll also need to fetch consensus params via RPC, using light client verification.
-	primaryURL, ok := s.providers[s.lc.Primary()]
-	if !ok || primaryURL == "" {
-		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
-	}
-	primaryRPC, err := rpcClient(primaryURL)
-	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
-	}
-	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
-	result, err := rpcclient.ConsensusParams(ctx, &currentLightBlock.Height)
-	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
-			nextLightBlock.Height, err)
-	}
-	state.ConsensusParams = result.ConsensusParams
-	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
-
-	return state, nil
+	return currentLightBlock, nextLightBlock, nil
 }
 
 // rpcClient sets up a new RPC client
@@ -60,6 +60,10 @@ type syncer struct {
 	chunkFetchers int32
 	retryTimeout  time.Duration
 
+	witnessServers      []string
+	minWitnessAgreement int
+	witnesses           *witnessChecker // created on first use, once the chain ID is known
+
 	mtx    cmtsync.RWMutex
 	chunks *chunkQueue
 }
@@ -83,6 +87,9 @@ func newSyncer(
 		tempDir:       tempDir,
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
+
+		witnessServers:      cfg.WitnessServers,
+		minWitnessAgreement: cfg.MinWitnessAgreement,
 	}
 }
 
@@ -310,6 +317,11 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, err
 	}
 
+	// Cross-check the restored state against independent witnesses, if any
+	if err := s.verifyWitnesses(snapshot, state); err != nil {
+		return sm.State{}, nil, err
+	}
+
 	// Done! 🎉
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
 		"hash", snapshot.Hash)
@@ -514,3 +526,22 @@ func (s *syncer) verifyApp(snapshot *snapshot, appVersion uint64) error {
 	s.logger.Info("Verified ABCI app", "height", snapshot.Height, "appHash", snapshot.trustedAppHash)
 	return nil
 }
+
+// verifyWitnesses cross-checks the restored state against the configured
+// witnesses. It is a no-op if no witnesses are configured.
+func (s *syncer) verifyWitnesses(snapshot *snapshot, state sm.State) error {
+	if s.witnesses == nil {
+		if len(s.witnessServers) == 0 {
+			return nil
+		}
+		wc, err := newWitnessChecker(s.logger, state.ChainID, s.witnessServers, s.minWitnessAgreement)
+		if err != nil {
+			return err
+		}
+		s.witnesses = wc
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	return s.witnesses.Check(ctx, snapshot.Height, state, snapshot.trustedAppHash)
+}
@@ -0,0 +1,109 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// witnessChecker cross-checks the state restored from a snapshot against a set
+// of independent witnesses before the node joins consensus. This catches
+// poisoned snapshots served by colluding peers and RPC servers, as long as
+// the witnesses are operated independently of them.
+type witnessChecker struct {
+	logger       log.Logger
+	witnesses    []lightprovider.Provider
+	minAgreement int
+}
+
+// newWitnessChecker creates a witnessChecker using HTTP light block providers
+// for the given servers.
+func newWitnessChecker(
+	logger log.Logger,
+	chainID string,
+	servers []string,
+	minAgreement int,
+) (*witnessChecker, error) {
+	witnesses := make([]lightprovider.Provider, 0, len(servers))
+	for _, server := range servers {
+		client, err := rpcClient(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up RPC client for witness %v: %w", server, err)
+		}
+		witnesses = append(witnesses, lighthttp.NewWithClient(chainID, client))
+	}
+	return &witnessChecker{
+		logger:       logger,
+		witnesses:    witnesses,
+		minAgreement: minAgreement,
+	}, nil
+}
+
+// Check verifies that the witnesses agree with the restored state at the given
+// height, i.e. that their header at height+1 commits to the same previous block
+// and to the app hash reported by the application. Any conflicting witness
+// fails the check, and so does not reaching the minimum agreement. Witnesses
+// which fail to respond, or respond with a header not committed by the
+// validators of the restored state, are skipped.
+func (wc *witnessChecker) Check(ctx context.Context, height uint64, state sm.State, appHash []byte) error {
+	agreed := 0
+	for _, witness := range wc.witnesses {
+		lb, err := witness.LightBlock(ctx, int64(height+1))
+		if err != nil {
+			wc.logger.Info("failed to fetch light block from witness", "witness", witness, "height", height+1,
+				"err", err)
+			continue
+		}
+		if err := verifyWitnessLightBlock(lb, int64(height+1), state); err != nil {
+			wc.logger.Info("witness sent invalid light block", "witness", witness, "height", height+1,
+				"err", err)
+			continue
+		}
+		if !bytes.Equal(lb.LastBlockID.Hash, state.LastBlockID.Hash) {
+			wc.logger.Error("witness reported a conflicting header", "witness", witness,
+				"height", height, "expected", state.LastBlockID.Hash, "actual", lb.LastBlockID.Hash)
+			return errVerifyFailed
+		}
+		if !bytes.Equal(lb.AppHash, appHash) {
+			wc.logger.Error("witness reported a conflicting app hash", "witness", witness,
+				"height", height, "expected", appHash, "actual", lb.AppHash)
+			return errVerifyFailed
+		}
+		agreed++
+	}
+
+	if agreed < wc.minAgreement {
+		wc.logger.Error("not enough witnesses confirmed the restored state", "agreed", agreed,
+			"required", wc.minAgreement)
+		return errVerifyFailed
+	}
+
+	wc.logger.Info("Verified restored state against witnesses", "height", height, "agreed", agreed)
+	return nil
+}
+
+// verifyWitnessLightBlock checks that lb is the light block at height
+// committed by the validators of state, which the light client verified, so
+// that a witness can't fail the check with a forged header.
+func verifyWitnessLightBlock(lb *types.LightBlock, height int64, state sm.State) error {
+	if lb == nil {
+		return errors.New("missing light block")
+	}
+	if err := lb.ValidateBasic(state.ChainID); err != nil {
+		return err
+	}
+	if lb.Height != height {
+		return fmt.Errorf("expected height %d, got %d", height, lb.Height)
+	}
+	if !bytes.Equal(lb.ValidatorsHash, state.Validators.Hash()) {
+		return fmt.Errorf("expected validators hash %X, got %X", state.Validators.Hash(), lb.ValidatorsHash)
+	}
+	return state.Validators.VerifyCommitLight(state.ChainID, lb.Commit.BlockID, height, lb.Commit)
+}
@@ -0,0 +1,122 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	cmtversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+type witnessStub struct {
+	lb  *types.LightBlock
+	err error
+}
+
+func (w witnessStub) ChainID() string { return "chain" }
+
+func (w witnessStub) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	return w.lb, w.err
+}
+
+func (w witnessStub) ReportEvidence(context.Context, types.Evidence) error { return nil }
+
+// witnessLightBlock returns the light block at height 2 of chainID, committed
+// by privVals, the validators of vals.
+func witnessLightBlock(
+	t *testing.T,
+	chainID string,
+	vals *types.ValidatorSet,
+	privVals []types.PrivValidator,
+	lastBlockHash, appHash []byte,
+) *types.LightBlock {
+	t.Helper()
+	header := &types.Header{
+		Version:            cmtversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            chainID,
+		Height:             2,
+		Time:               time.Now(),
+		LastBlockID:        types.BlockID{Hash: lastBlockHash},
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		AppHash:            appHash,
+		ProposerAddress:    vals.Validators[0].Address,
+	}
+	blockID := types.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+	}
+	voteSet := types.NewVoteSet(chainID, 2, 0, cmtproto.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, 2, 0, voteSet, privVals, header.Time)
+	require.NoError(t, err)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
+
+func TestWitnessChecker_Check(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(2, 10)
+	otherVals, otherPrivVals := types.RandValidatorSet(2, 10)
+	blockHash, otherHash := tmhash.Sum([]byte("block")), tmhash.Sum([]byte("other"))
+	state := sm.State{
+		ChainID:     "chain",
+		LastBlockID: types.BlockID{Hash: blockHash},
+		Validators:  vals,
+	}
+	appHash := []byte("app_hash")
+
+	good := witnessStub{lb: witnessLightBlock(t, "chain", vals, privVals, blockHash, appHash)}
+	badHeader := witnessStub{lb: witnessLightBlock(t, "chain", vals, privVals, otherHash, appHash)}
+	badAppHash := witnessStub{lb: witnessLightBlock(t, "chain", vals, privVals, blockHash, []byte("other_hash"))}
+	otherChain := witnessStub{lb: witnessLightBlock(t, "other", vals, privVals, otherHash, []byte("other_hash"))}
+	forged := witnessStub{lb: witnessLightBlock(t, "chain", otherVals, otherPrivVals, otherHash, appHash)}
+	unsigned := witnessStub{lb: witnessLightBlock(t, "chain", vals, privVals, otherHash, appHash)}
+	unsigned.lb.Commit.Signatures[0] = types.NewCommitSigAbsent()
+	unsigned.lb.Commit.Signatures[1] = types.NewCommitSigAbsent()
+	dead := witnessStub{err: errors.New("unreachable")}
+
+	testcases := map[string]struct {
+		witnesses    []lightprovider.Provider
+		minAgreement int
+		expectErr    bool
+	}{
+		"all agree":            {[]lightprovider.Provider{good, good}, 2, false},
+		"conflicting header":   {[]lightprovider.Provider{good, badHeader}, 1, true},
+		"conflicting app hash": {[]lightprovider.Provider{badAppHash, good}, 1, true},
+		"dead witness skipped": {[]lightprovider.Provider{good, dead}, 1, false},
+		"wrong chain skipped":  {[]lightprovider.Provider{otherChain, good}, 1, false},
+		"forged skipped":       {[]lightprovider.Provider{forged, good}, 1, false},
+		"unsigned skipped":     {[]lightprovider.Provider{unsigned, good}, 1, false},
+		"forged not agreeing":  {[]lightprovider.Provider{forged, good}, 2, true},
+		"not enough agree":     {[]lightprovider.Provider{good, dead}, 2, true},
+		"no agreement needed":  {[]lightprovider.Provider{dead}, 0, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			wc := &witnessChecker{
+				logger:       log.NewNopLogger(),
+				witnesses:    tc.witnesses,
+				minAgreement: tc.minAgreement,
+			}
+			err := wc.Check(context.Background(), 1, state, appHash)
+			if tc.expectErr {
+				assert.ErrorIs(t, err, errVerifyFailed)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/libs/protoio"
+	cmtstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// archiveMagic starts the archives of blocks written by Export, followed by
+// the version of their format.
+var archiveMagic = []byte("CMTBLOCKS\x01")
+
+// archiveMaxMsgSize is the maximum size of the messages of an archive.
+const archiveMaxMsgSize = types.MaxBlockSizeBytes
+
+// Export writes the blocks between from and to (inclusive), which must be
+// between the base and the height of the store, to w as an archive read by
+// Import, e.g. to bootstrap the block store of another node.
+//
+// The archive starts with archiveMagic and a BlockStoreState holding the
+// range of heights, followed by a varint length-prefixed protobuf message for
+// each BlockMeta, Part and Commit of the blocks, by height: the meta of a
+// block, its parts, and its canonical commit, or its seen commit at the
+// latest height. The blocks are written uncompressed, whatever the
+// compression of the store.
+func (bs *BlockStore) Export(w io.Writer, from, to int64) error {
+	base, height := bs.Base(), bs.Height()
+	if base == 0 {
+		return errors.New("the block store is empty")
+	}
+	if from < base || to > height || from > to {
+		return fmt.Errorf("the heights %d to %d must be between the base %d and the height %d",
+			from, to, base, height)
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(archiveMagic); err != nil {
+		return err
+	}
+	pw := protoio.NewDelimitedWriter(bw)
+	if _, err := pw.WriteMsg(&cmtstore.BlockStoreState{Base: from, Height: to}); err != nil {
+		return err
+	}
+	for h := from; h <= to; h++ {
+		meta := bs.LoadBlockMeta(h)
+		if meta == nil {
+			return fmt.Errorf("no block meta at height %d", h)
+		}
+		if _, err := pw.WriteMsg(meta.ToProto()); err != nil {
+			return err
+		}
+		for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+			part := bs.LoadBlockPart(h, i)
+			if part == nil {
+				return fmt.Errorf("no part %d of the block at height %d", i, h)
+			}
+			pbp, err := part.ToProto()
+			if err != nil {
+				return err
+			}
+			if _, err := pw.WriteMsg(pbp); err != nil {
+				return err
+			}
+		}
+		commit := bs.LoadBlockCommit(h)
+		if h == height {
+			commit = bs.LoadSeenCommit(h)
+		}
+		if commit == nil {
+			return fmt.Errorf("no commit of the block at height %d", h)
+		}
+		if _, err := pw.WriteMsg(commit.ToProto()); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Import saves the blocks of an archive written by Export to the store, after
+// checking that they are complete, match their metas and commits, and chain
+// with the blocks of the store. The signatures of the commits aren't
+// verified, so the archive must come from a trusted source. The blocks at or
+// below the height of the store are skipped, if they match those of the
+// store, and the others must follow it, unless the store is empty.
+func (bs *BlockStore) Import(r io.Reader) error {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return fmt.Errorf("reading the archive header: %w", err)
+	}
+	if !bytes.Equal(magic, archiveMagic) {
+		return errors.New("not a block archive, or of an unknown version")
+	}
+	pr := protoio.NewDelimitedReader(br, archiveMaxMsgSize)
+	var bss cmtstore.BlockStoreState
+	if _, err := pr.ReadMsg(&bss); err != nil {
+		return fmt.Errorf("reading the archive header: %w", err)
+	}
+	if bss.Base <= 0 || bss.Height < bss.Base {
+		return fmt.Errorf("invalid range of heights %d to %d", bss.Base, bss.Height)
+	}
+	if height := bs.Height(); height > 0 && bss.Base > height+1 {
+		return fmt.Errorf("the archive starts at height %d, after the height %d of the store", bss.Base, height)
+	}
+
+	var lastBlockID *types.BlockID
+	if meta := bs.LoadBlockMeta(bss.Base - 1); meta != nil {
+		lastBlockID = &meta.BlockID
+	}
+	for h := bss.Base; h <= bss.Height; h++ {
+		block, parts, commit, err := readArchivedBlock(pr, h)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", h, err)
+		}
+		if lastBlockID != nil && !block.LastBlockID.Equals(*lastBlockID) {
+			return fmt.Errorf("block %d: last block ID %v, not %v", h, block.LastBlockID, *lastBlockID)
+		}
+		lastBlockID = &commit.BlockID
+		if h <= bs.Height() {
+			if meta := bs.LoadBlockMeta(h); meta != nil && !meta.BlockID.Equals(commit.BlockID) {
+				return fmt.Errorf("block %d: %v conflicts with the block %v of the store", h, commit.BlockID, meta.BlockID)
+			}
+			continue
+		}
+		bs.SaveBlock(block, parts, commit)
+	}
+	return nil
+}
+
+// readArchivedBlock reads the meta, parts and commit of the block at height
+// from an archive, and checks them.
+func readArchivedBlock(pr protoio.Reader, height int64) (*types.Block, *types.PartSet, *types.Commit, error) {
+	var pbm cmtproto.BlockMeta
+	if _, err := pr.ReadMsg(&pbm); err != nil {
+		return nil, nil, nil, fmt.Errorf("reading the meta: %w", err)
+	}
+	meta, err := types.BlockMetaFromProto(&pbm)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if meta.Header.Height != height {
+		return nil, nil, nil, fmt.Errorf("meta of height %d", meta.Header.Height)
+	}
+
+	parts := types.NewPartSetFromHeader(meta.BlockID.PartSetHeader)
+	for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+		var pbp cmtproto.Part
+		if _, err := pr.ReadMsg(&pbp); err != nil {
+			return nil, nil, nil, fmt.Errorf("reading part %d: %w", i, err)
+		}
+		part, err := types.PartFromProto(&pbp)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("part %d: %w", i, err)
+		}
+		if added, err := parts.AddPart(part); err != nil || !added {
+			return nil, nil, nil, fmt.Errorf("part %d doesn't match the meta: %v", i, err)
+		}
+	}
+	bz, err := io.ReadAll(parts.GetReader())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	block, err := unmarshalBlock(bz)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return nil, nil, nil, err
+	}
+	if !bytes.Equal(block.Hash(), meta.BlockID.Hash) {
+		return nil, nil, nil, fmt.Errorf("block hash %X, not %v", block.Hash(), meta.BlockID.Hash)
+	}
+
+	var pbc cmtproto.Commit
+	if _, err := pr.ReadMsg(&pbc); err != nil {
+		return nil, nil, nil, fmt.Errorf("reading the commit: %w", err)
+	}
+	commit, err := types.CommitFromProto(&pbc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if commit.Height != height || !commit.BlockID.Equals(meta.BlockID) {
+		return nil, nil, nil, fmt.Errorf("commit of block %v at height %d", commit.BlockID, commit.Height)
+	}
+	return block, parts, commit, nil
+}
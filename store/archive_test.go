@@ -0,0 +1,72 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportBlocks(t *testing.T, bs *BlockStore, from, to int64) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, bs.Export(&buf, from, to))
+	return buf.Bytes()
+}
+
+func TestBlockStoreExportImport(t *testing.T) {
+	src, _ := makeBlockStoreWithBlocks(t, 20, WithCompression(CompressionSnappy))
+	first, all := exportBlocks(t, src, 1, 10), exportBlocks(t, src, 1, 20)
+
+	// the blocks are imported into an empty store, whatever its compression
+	dst := NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, dst.Import(bytes.NewReader(first)))
+	assert.EqualValues(t, 1, dst.Base())
+	assert.EqualValues(t, 10, dst.Height())
+
+	// and then the following ones, skipping those of the store
+	require.NoError(t, dst.Import(bytes.NewReader(all)))
+	assert.EqualValues(t, 20, dst.Height())
+	for h := int64(1); h <= 20; h++ {
+		assert.Equal(t, src.LoadBlockMeta(h), dst.LoadBlockMeta(h))
+		assert.Equal(t, src.LoadBlock(h).Hash(), dst.LoadBlock(h).Hash())
+		if h < 20 {
+			assert.Equal(t, src.LoadBlockCommit(h), dst.LoadBlockCommit(h))
+		}
+	}
+	assert.Equal(t, src.LoadSeenCommit(20), dst.LoadSeenCommit(20))
+	problems, err := dst.Verify(1, 20)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// the blocks can start above 1 in an empty store
+	dst = NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, dst.Import(bytes.NewReader(exportBlocks(t, src, 15, 20))))
+	assert.EqualValues(t, 15, dst.Base())
+	assert.EqualValues(t, 20, dst.Height())
+
+	// but they must follow those of the store
+	dst = NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, dst.Import(bytes.NewReader(first)))
+	assert.Error(t, dst.Import(bytes.NewReader(exportBlocks(t, src, 15, 20))))
+	assert.EqualValues(t, 10, dst.Height())
+
+	// and chain with them
+	other, _ := makeBlockStoreWithBlocks(t, 5)
+	assert.Error(t, other.Import(bytes.NewReader(all)))
+	assert.EqualValues(t, 5, other.Height())
+
+	// the corrupted or truncated archives are refused
+	corrupted := append([]byte{}, all...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	dst = NewBlockStore(dbm.NewMemDB())
+	assert.Error(t, dst.Import(bytes.NewReader(corrupted)))
+	assert.Error(t, dst.Import(bytes.NewReader(all[:len(all)-10])))
+	assert.Error(t, dst.Import(bytes.NewReader([]byte("not an archive"))))
+
+	assert.Error(t, src.Export(&bytes.Buffer{}, 0, 10))
+	assert.Error(t, src.Export(&bytes.Buffer{}, 10, 21))
+	assert.Error(t, src.Export(&bytes.Buffer{}, 10, 9))
+}
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"container/list"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// WithCache caches the blocks and block parts loaded most recently, up to
+// maxBytes of their encoded size, e.g. for the gossip of the parts and the
+// RPC queries of the recent blocks. The cache is disabled if maxBytes is 0, and
+// for the read-only stores.
+//
+// The blocks and parts cached are shared by the callers of the load
+// methods, which must not modify them.
+func WithCache(maxBytes int64) BlockStoreOption {
+	return func(bs *BlockStore) {
+		if maxBytes > 0 {
+			bs.cache = newBlockCache(maxBytes)
+		}
+	}
+}
+
+// blockCacheKey is the key of a block part in the cache, or of a whole block
+// if its index is -1.
+type blockCacheKey struct {
+	height int64
+	index  int
+}
+
+type blockCacheEntry struct {
+	key   blockCacheKey
+	value interface{}
+	size  int64
+}
+
+// blockCache is a thread-safe LRU cache of blocks and block parts, bounded
+// by the sum of their sizes.
+type blockCache struct {
+	mtx      cmtsync.Mutex
+	maxBytes int64
+	bytes    int64
+	entries  map[blockCacheKey]*list.Element
+	list     *list.List // of *blockCacheEntry, the most recent at the back
+}
+
+func newBlockCache(maxBytes int64) *blockCache {
+	return &blockCache{
+		maxBytes: maxBytes,
+		entries:  make(map[blockCacheKey]*list.Element),
+		list:     list.New(),
+	}
+}
+
+func (c *blockCache) get(key blockCacheKey) (interface{}, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToBack(e)
+	return e.Value.(*blockCacheEntry).value, true
+}
+
+// add adds value of size to the cache, evicting the least recently used
+// values beyond maxBytes. The values larger than maxBytes aren't cached.
+func (c *blockCache) add(key blockCacheKey, value interface{}, size int64) {
+	if size > c.maxBytes {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	for c.bytes+size > c.maxBytes {
+		c.remove(c.list.Front())
+	}
+	c.entries[key] = c.list.PushBack(&blockCacheEntry{key: key, value: value, size: size})
+	c.bytes += size
+}
+
+// removeHeights removes the values of the heights for which remove returns
+// true.
+func (c *blockCache) removeHeights(remove func(height int64) bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for e := c.list.Front(); e != nil; {
+		next := e.Next()
+		if remove(e.Value.(*blockCacheEntry).key.height) {
+			c.remove(e)
+		}
+		e = next
+	}
+}
+
+func (c *blockCache) remove(e *list.Element) {
+	entry := c.list.Remove(e).(*blockCacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+func (c *blockCache) size() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.bytes
+}
+
+// cachedBlock returns the block at height if it's cached.
+func (bs *BlockStore) cachedBlock(height int64) *types.Block {
+	if bs.cache == nil {
+		return nil
+	}
+	if block, ok := bs.cache.get(blockCacheKey{height: height, index: -1}); ok {
+		bs.metrics.BlockCacheHits.With("type", "block").Add(1)
+		return block.(*types.Block)
+	}
+	bs.metrics.BlockCacheMisses.With("type", "block").Add(1)
+	return nil
+}
+
+// cacheBlock caches block, of size bytes encoded, unless it was pruned or
+// deleted since it was loaded.
+func (bs *BlockStore) cacheBlock(block *types.Block, size int) {
+	if bs.cache == nil || !bs.cacheable(block.Height) {
+		return
+	}
+	// the hashes are computed lazily, so they're computed before the block
+	// is shared
+	block.Hash()
+	block.Data.Hash()
+	block.Evidence.Hash()
+	block.Evidence.ByteSize()
+	if block.LastCommit != nil {
+		block.LastCommit.Hash()
+		block.LastCommit.BitArray()
+	}
+	bs.cache.add(blockCacheKey{height: block.Height, index: -1}, block, int64(size))
+	bs.metrics.BlockCacheSize.Set(float64(bs.cache.size()))
+}
+
+// cachedBlockPart returns the part at index of the block at height if it's
+// cached.
+func (bs *BlockStore) cachedBlockPart(height int64, index int) *types.Part {
+	if bs.cache == nil {
+		return nil
+	}
+	if part, ok := bs.cache.get(blockCacheKey{height: height, index: index}); ok {
+		bs.metrics.BlockCacheHits.With("type", "part").Add(1)
+		return part.(*types.Part)
+	}
+	bs.metrics.BlockCacheMisses.With("type", "part").Add(1)
+	return nil
+}
+
+// cacheBlockPart caches part of the block at height, of size bytes, unless
+// it was pruned or deleted since it was loaded.
+func (bs *BlockStore) cacheBlockPart(height int64, part *types.Part, size int) {
+	if bs.cache == nil || !bs.cacheable(height) {
+		return
+	}
+	bs.cache.add(blockCacheKey{height: height, index: int(part.Index)}, part, int64(size))
+	bs.metrics.BlockCacheSize.Set(float64(bs.cache.size()))
+}
+
+// cacheable returns whether the blocks at height are still in the store.
+func (bs *BlockStore) cacheable(height int64) bool {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return height >= bs.base && height <= bs.height
+}
+
+// uncacheHeights removes the blocks and parts of the heights for which remove
+// returns true from the cache, e.g. once deleted.
+func (bs *BlockStore) uncacheHeights(remove func(height int64) bool) {
+	if bs.cache == nil {
+		return
+	}
+	bs.cache.removeHeights(remove)
+	bs.metrics.BlockCacheSize.Set(float64(bs.cache.size()))
+}
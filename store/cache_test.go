@@ -0,0 +1,83 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCache(t *testing.T) {
+	c := newBlockCache(10)
+	key := func(height int64) blockCacheKey { return blockCacheKey{height: height, index: -1} }
+
+	c.add(key(1), "a", 4)
+	c.add(key(2), "b", 4)
+	v, ok := c.get(key(1))
+	require.True(t, ok)
+	assert.Equal(t, "a", v)
+	assert.EqualValues(t, 8, c.size())
+
+	// the least recently used value is evicted
+	c.add(key(3), "c", 4)
+	_, ok = c.get(key(2))
+	assert.False(t, ok)
+	_, ok = c.get(key(1))
+	assert.True(t, ok)
+	assert.EqualValues(t, 8, c.size())
+
+	// a value is replaced
+	c.add(key(3), "d", 2)
+	v, _ = c.get(key(3))
+	assert.Equal(t, "d", v)
+	assert.EqualValues(t, 6, c.size())
+
+	// the values larger than the cache aren't cached
+	c.add(key(4), "e", 11)
+	_, ok = c.get(key(4))
+	assert.False(t, ok)
+	_, ok = c.get(key(1))
+	assert.True(t, ok)
+
+	c.removeHeights(func(height int64) bool { return height < 3 })
+	_, ok = c.get(key(1))
+	assert.False(t, ok)
+	_, ok = c.get(key(3))
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, c.size())
+}
+
+func TestBlockStoreCache(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 10, WithCache(1<<20))
+
+	block := bs.LoadBlock(5)
+	require.NotNil(t, block)
+	assert.Same(t, block, bs.LoadBlock(5))
+	part := bs.LoadBlockPart(5, 0)
+	require.NotNil(t, part)
+	assert.Same(t, part, bs.LoadBlockPart(5, 0))
+
+	// the cached block and part are loaded without the DB
+	require.NoError(t, db.Delete(calcBlockPartKey(5, 0)))
+	assert.Same(t, block, bs.LoadBlock(5))
+	assert.Same(t, part, bs.LoadBlockPart(5, 0))
+
+	// the pruned blocks are removed from the cache
+	require.NotNil(t, bs.LoadBlock(4))
+	_, err := bs.PruneBlocks(5)
+	require.NoError(t, err)
+	assert.Nil(t, bs.LoadBlock(4))
+	assert.Same(t, block, bs.LoadBlock(5))
+
+	// and so are the deleted ones
+	require.NotNil(t, bs.LoadBlock(10))
+	require.NotNil(t, bs.LoadBlockPart(10, 0))
+	require.NoError(t, bs.DeleteLatestBlock())
+	assert.Nil(t, bs.LoadBlock(10))
+	assert.Nil(t, bs.LoadBlockPart(10, 0))
+	assert.NotNil(t, bs.LoadBlock(9))
+
+	// the blocks aren't shared without the cache
+	bs, _ = makeBlockStoreWithBlocks(t, 10)
+	assert.NotSame(t, bs.LoadBlock(5), bs.LoadBlock(5))
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/golang/snappy"
+)
+
+// The compressions of the block parts and metas saved to the BlockStore.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+)
+
+// compressedMarker prefixes the encoded values, followed by their codec. A
+// protobuf message can't start with a zero byte, since the field numbers start
+// at 1, so the values saved raw, e.g. before the compression or the checksums,
+// are read as is.
+const compressedMarker byte = 0x00
+
+// The codecs of the encoded values, persisted.
+const (
+	codecNone   byte = 0x00
+	codecSnappy byte = 0x01
+)
+
+// codecChecksum flags the codec of the values followed by the CRC-32C of the
+// rest of the value, big-endian, to detect their corruption on disk.
+const codecChecksum byte = 0x80
+
+// checksumHeaderSize is the size of the marker, codec and checksum of the
+// checksummed values.
+const checksumHeaderSize = 2 + crc32.Size
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrChecksumMismatch is returned when a block part or meta doesn't match its
+// checksum, e.g. after a silent corruption of the disk.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// recompressBatchSize is the number of blocks rewritten at once by
+// RecompressBlocks.
+const recompressBatchSize = 1000
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithCompression compresses the block parts and metas saved with
+// compression, CompressionNone (or empty) or CompressionSnappy. The blocks are
+// read whatever their compression.
+func WithCompression(compression string) BlockStoreOption {
+	switch compression {
+	case "":
+		compression = CompressionNone
+	case CompressionNone, CompressionSnappy:
+	default:
+		panic(fmt.Sprintf("unknown block compression %q", compression))
+	}
+	return func(bs *BlockStore) { bs.compression = compression }
+}
+
+// encodeValue returns bz compressed with the compression of the store,
+// prefixed with its checksum.
+func (bs *BlockStore) encodeValue(bz []byte) []byte {
+	var dst []byte
+	switch bs.compression {
+	case CompressionSnappy:
+		dst = make([]byte, checksumHeaderSize+snappy.MaxEncodedLen(len(bz)))
+		dst = dst[:checksumHeaderSize+len(snappy.Encode(dst[checksumHeaderSize:], bz))]
+	default:
+		dst = make([]byte, checksumHeaderSize+len(bz))
+		copy(dst[checksumHeaderSize:], bz)
+	}
+	dst[0], dst[1] = compressedMarker, bs.codec()|codecChecksum
+	binary.BigEndian.PutUint32(dst[2:], crc32.Checksum(dst[checksumHeaderSize:], crc32c))
+	return dst
+}
+
+// codec returns the codec of the compression of the store.
+func (bs *BlockStore) codec() byte {
+	if bs.compression == CompressionSnappy {
+		return codecSnappy
+	}
+	return codecNone
+}
+
+// decodeValue returns bz decompressed, or as is if it isn't encoded, after
+// checking its checksum if it has one. It returns ErrChecksumMismatch if the
+// checksum doesn't match.
+func decodeValue(bz []byte) ([]byte, error) {
+	if len(bz) == 0 || bz[0] != compressedMarker {
+		return bz, nil
+	}
+	if len(bz) < 2 {
+		return nil, errors.New("encoded value without codec")
+	}
+	codec, payload := bz[1], bz[2:]
+	if codec&codecChecksum != 0 {
+		if len(bz) < checksumHeaderSize {
+			return nil, errors.New("encoded value without checksum")
+		}
+		payload = bz[checksumHeaderSize:]
+		if binary.BigEndian.Uint32(bz[2:]) != crc32.Checksum(payload, crc32c) {
+			return nil, ErrChecksumMismatch
+		}
+		codec &^= codecChecksum
+	}
+	switch codec {
+	case codecNone:
+		return payload, nil
+	case codecSnappy:
+		return snappy.Decode(nil, payload)
+	default:
+		return nil, fmt.Errorf("unknown compression codec %d", codec)
+	}
+}
+
+// RecompressBlocks rewrites the parts and metas of the blocks of the store
+// with its compression, e.g. to compress the blocks saved before it was
+// enabled, or to decompress them all, and checksums those saved before the
+// checksums. progress, if not nil, is called with each height rewritten. It
+// returns the number of blocks rewritten. It must not run concurrently with
+// writes to the store, e.g. with the node stopped.
+func (bs *BlockStore) RecompressBlocks(progress func(height int64)) (int64, error) {
+	var rewritten int64
+	batch := bs.db.NewBatch()
+	defer func() { batch.Close() }()
+
+	recompress := func(key []byte) (bool, error) {
+		bz, err := bs.db.Get(key)
+		if err != nil || len(bz) == 0 {
+			return false, err
+		}
+		raw, err := decodeValue(bz)
+		if err != nil {
+			return false, fmt.Errorf("decoding %s: %w", key, err)
+		}
+		if bs.isEncoded(bz) {
+			return false, nil
+		}
+		return true, batch.Set(key, bs.encodeValue(raw))
+	}
+
+	for h := bs.Base(); h > 0 && h <= bs.Height(); h++ {
+		metaKey := calcBlockMetaKey(h)
+		bz, err := bs.db.Get(metaKey)
+		if err != nil {
+			return rewritten, err
+		}
+		if len(bz) == 0 {
+			continue
+		}
+		meta := decodeBlockMeta(bz)
+		changed := false
+		for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+			partChanged, err := recompress(calcBlockPartKey(h, i))
+			if err != nil {
+				return rewritten, err
+			}
+			changed = changed || partChanged
+		}
+		metaChanged, err := recompress(metaKey)
+		if err != nil {
+			return rewritten, err
+		}
+		if !changed && !metaChanged {
+			continue
+		}
+		rewritten++
+		if progress != nil {
+			progress(h)
+		}
+
+		if rewritten%recompressBatchSize == 0 {
+			if err := batch.WriteSync(); err != nil {
+				return rewritten, err
+			}
+			batch.Close()
+			batch = bs.db.NewBatch()
+		}
+	}
+	return rewritten, batch.WriteSync()
+}
+
+// isEncoded returns whether the value bz is compressed with the compression
+// of the store, and checksummed, already.
+func (bs *BlockStore) isEncoded(bz []byte) bool {
+	return len(bz) >= checksumHeaderSize && bz[0] == compressedMarker && bz[1] == bs.codec()|codecChecksum
+}
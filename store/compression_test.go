@@ -0,0 +1,141 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+func TestBlockStoreCompression(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 5, WithCompression(CompressionSnappy))
+	blocks := make(map[int64]cmtbytes.HexBytes)
+	for h := int64(1); h <= 5; h++ {
+		blocks[h] = bs.LoadBlock(h).Hash()
+	}
+
+	isCompressed := func(key []byte) bool {
+		bz, err := db.Get(key)
+		require.NoError(t, err)
+		require.NotEmpty(t, bz)
+		return bz[0] == compressedMarker && bz[1]&^codecChecksum == codecSnappy
+	}
+	assert.True(t, isCompressed(calcBlockMetaKey(3)))
+	assert.True(t, isCompressed(calcBlockPartKey(3, 0)))
+	// the commits aren't compressed
+	assert.False(t, isCompressed(calcSeenCommitKey(3)))
+
+	// the blocks are read whatever the compression of the store
+	bs = NewBlockStore(db)
+	for h := int64(1); h <= 5; h++ {
+		assert.Equal(t, blocks[h], bs.LoadBlock(h).Hash())
+		assert.Equal(t, blocks[h], bs.LoadBlockMeta(h).BlockID.Hash)
+	}
+	assert.Equal(t, heightRange(1, 5), iterateHeights(t, bs, 1, 5))
+
+	// the blocks are rewritten uncompressed
+	rewritten, err := bs.RecompressBlocks(nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, rewritten)
+	assert.False(t, isCompressed(calcBlockMetaKey(3)))
+	assert.False(t, isCompressed(calcBlockPartKey(3, 1)))
+	for h := int64(1); h <= 5; h++ {
+		assert.Equal(t, blocks[h], bs.LoadBlock(h).Hash())
+	}
+	rewritten, err = bs.RecompressBlocks(nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, rewritten)
+
+	// and compressed again
+	bs = NewBlockStore(db, WithCompression(CompressionSnappy))
+	var heights []int64
+	rewritten, err = bs.RecompressBlocks(func(height int64) { heights = append(heights, height) })
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, rewritten)
+	assert.Equal(t, heightRange(1, 5), heights)
+	assert.True(t, isCompressed(calcBlockMetaKey(3)))
+	assert.Equal(t, blocks[5], bs.LoadBlock(5).Hash())
+
+	assert.Panics(t, func() { WithCompression("gzip") })
+}
+
+func TestDecodeValue(t *testing.T) {
+	bz, err := decodeValue([]byte{0x0a, 0x01})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x01}, bz)
+
+	for _, compression := range []string{CompressionNone, CompressionSnappy} {
+		bs := &BlockStore{compression: compression}
+		encoded := bs.encodeValue([]byte("block"))
+		assert.True(t, bs.isEncoded(encoded), compression)
+		bz, err = decodeValue(encoded)
+		require.NoError(t, err, compression)
+		assert.Equal(t, []byte("block"), bz, compression)
+
+		// a flipped bit doesn't match the checksum
+		encoded[len(encoded)-1] ^= 0x01
+		_, err = decodeValue(encoded)
+		assert.Equal(t, ErrChecksumMismatch, err, compression)
+	}
+
+	// the values compressed before the checksums are read
+	bz, err = decodeValue(append([]byte{compressedMarker, codecSnappy}, snappy.Encode(nil, []byte("block"))...))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("block"), bz)
+
+	_, err = decodeValue([]byte{compressedMarker})
+	assert.Error(t, err)
+	_, err = decodeValue([]byte{compressedMarker, 0x7f, 0x01})
+	assert.Error(t, err)
+	_, err = decodeValue([]byte{compressedMarker, codecChecksum, 0x01})
+	assert.Error(t, err)
+}
+
+func TestBlockStoreChecksums(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 5)
+
+	// a corrupted part is reported with its height and index
+	key := calcBlockPartKey(3, 1)
+	bz, err := db.Get(key)
+	require.NoError(t, err)
+	bz[len(bz)-1] ^= 0x01
+	require.NoError(t, db.Set(key, bz))
+	_, err = bs.LoadBlockE(3)
+	var corruptPart ErrCorruptBlockPart
+	require.ErrorAs(t, err, &corruptPart)
+	assert.Equal(t, ErrCorruptBlockPart{Height: 3, Index: 1}, corruptPart)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	var corruptEntry ErrCorruptedEntry
+	require.ErrorAs(t, err, &corruptEntry)
+	assert.Equal(t, string(key), corruptEntry.Key)
+	_, err = bs.LoadBlockPartE(3, 1)
+	assert.ErrorAs(t, err, &corruptPart)
+
+	// and so is a corrupted meta
+	key = calcBlockMetaKey(4)
+	bz, err = db.Get(key)
+	require.NoError(t, err)
+	bz[len(bz)-1] ^= 0x01
+	require.NoError(t, db.Set(key, bz))
+	_, err = bs.LoadBlockMetaE(4)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// the blocks saved before the checksums are checksummed when rewritten
+	bs, db = makeBlockStoreWithBlocks(t, 5)
+	key = calcBlockPartKey(5, 0)
+	bz, err = db.Get(key)
+	require.NoError(t, err)
+	raw, err := decodeValue(bz)
+	require.NoError(t, err)
+	require.NoError(t, db.Set(key, raw))
+	require.NotNil(t, bs.LoadBlock(5))
+	rewritten, err := bs.RecompressBlocks(nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, rewritten)
+	bz, err = db.Get(key)
+	require.NoError(t, err)
+	assert.True(t, bs.isEncoded(bz))
+}
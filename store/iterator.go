@@ -0,0 +1,200 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxHeightDigits is the number of decimal digits of the largest height.
+const maxHeightDigits = 19
+
+// BlockIterator is a forward-only cursor over the blocks of a BlockStore, by
+// increasing height, skipping the missing ones, e.g. pruned. It reads the
+// block metas sequentially with a DB iterator instead of a lookup per height.
+//
+// A BlockIterator isn't goroutine-safe, and must be closed once done with.
+// Like the BlockStore, it panics if it fails to decode a block meta.
+type BlockIterator struct {
+	bs   *BlockStore
+	it   *heightIterator
+	meta *types.BlockMeta
+}
+
+// Iterator returns a BlockIterator over the blocks between start and end
+// (inclusive), up to the height of the store, which must be positioned with
+// Next before reading the first block.
+func (bs *BlockStore) Iterator(start, end int64) (*BlockIterator, error) {
+	it, err := bs.heightIterator("H:", start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockIterator{bs: bs, it: it}, nil
+}
+
+// Next moves the cursor to the next block, and returns whether there is one.
+// It returns false once done, or on error, returned by Error.
+func (bi *BlockIterator) Next() bool {
+	bi.meta = nil
+	if !bi.it.Next() {
+		return false
+	}
+	bi.meta = decodeBlockMeta(bi.it.value)
+	return true
+}
+
+// Height returns the height of the current block.
+func (bi *BlockIterator) Height() int64 {
+	return bi.meta.Header.Height
+}
+
+// BlockMeta returns the meta of the current block.
+func (bi *BlockIterator) BlockMeta() *types.BlockMeta {
+	return bi.meta
+}
+
+// Block loads the current block, or returns nil if it was deleted since its
+// meta was read.
+func (bi *BlockIterator) Block() *types.Block {
+	return bi.bs.LoadBlock(bi.Height())
+}
+
+// Error returns the error which stopped the cursor, if any.
+func (bi *BlockIterator) Error() error {
+	return bi.it.err
+}
+
+// Close releases the DB iterator.
+func (bi *BlockIterator) Close() error {
+	return bi.it.Close()
+}
+
+// heightIterator iterates over the values of the keys made of prefix and a
+// height, by increasing height.
+//
+// These keys aren't lexicographically ordered by height (see
+// https://github.com/tendermint/tendermint/issues/4567), but the keys of the
+// heights with the same number of decimal digits are, so it reads them with a
+// single DB iterator per number of digits, skipping the keys of the other
+// heights in its range.
+type heightIterator struct {
+	db     dbm.DB
+	prefix string
+	end    int64
+
+	it     dbm.Iterator // over the heights of digits digits from next
+	next   int64
+	digits int
+	height int64
+	value  []byte
+	err    error
+}
+
+// heightIterator returns a heightIterator over the keys of prefix between
+// start and end (inclusive), up to the height of the store.
+func (bs *BlockStore) heightIterator(prefix string, start, end int64) (*heightIterator, error) {
+	if start <= 0 {
+		return nil, fmt.Errorf("start height must be greater than 0, got %d", start)
+	}
+	if end < start {
+		return nil, fmt.Errorf("end height %d is lower than the start height %d", end, start)
+	}
+	if height := bs.Height(); end > height {
+		end = height
+	}
+	return &heightIterator{db: bs.db, prefix: prefix, end: end, next: start}, nil
+}
+
+// Next moves to the next key, and returns whether there is one. It returns
+// false once done, or on error.
+func (hi *heightIterator) Next() bool {
+	hi.height, hi.value = 0, nil
+	for hi.err == nil {
+		if hi.it == nil {
+			if hi.next > hi.end {
+				return false
+			}
+			if hi.err = hi.open(); hi.err != nil {
+				return false
+			}
+		}
+
+		for ; hi.it.Valid(); hi.it.Next() {
+			key := hi.it.Key()
+			if len(key)-len(hi.prefix) != hi.digits {
+				continue
+			}
+			height, err := strconv.ParseInt(string(key[len(hi.prefix):]), 10, 64)
+			if err != nil {
+				hi.err = fmt.Errorf("invalid key %q: %w", key, err)
+				return false
+			}
+			hi.height, hi.value = height, hi.it.Value()
+			hi.next = height + 1
+			hi.it.Next()
+			return true
+		}
+
+		hi.err = hi.it.Error()
+		if err := hi.it.Close(); hi.err == nil {
+			hi.err = err
+		}
+		hi.it = nil
+		if hi.digits == maxHeightDigits {
+			return false
+		}
+		hi.next = pow10(hi.digits)
+	}
+	return false
+}
+
+// open opens the DB iterator over the heights from next up to the end with
+// the same number of decimal digits.
+func (hi *heightIterator) open() error {
+	hi.digits = len(strconv.FormatInt(hi.next, 10))
+	last := hi.end
+	if hi.digits < maxHeightDigits && last >= pow10(hi.digits) {
+		last = pow10(hi.digits) - 1
+	}
+	// the keys of the longer heights prefixed by the last one sort after the
+	// zero byte.
+	it, err := hi.db.Iterator(hi.key(hi.next), append(hi.key(last), 0))
+	if err != nil {
+		return err
+	}
+	hi.it = it
+	return nil
+}
+
+func (hi *heightIterator) key(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%v", hi.prefix, height))
+}
+
+// Close releases the DB iterator.
+func (hi *heightIterator) Close() error {
+	if hi.it == nil {
+		return nil
+	}
+	err := hi.it.Close()
+	hi.it = nil
+	return err
+}
+
+func decodeBlockMeta(bz []byte) *types.BlockMeta {
+	blockMeta, err := unmarshalBlockMeta(bz)
+	if err != nil {
+		panic(err)
+	}
+	return blockMeta
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+// makeBlockStoreWithBlocks returns a BlockStore with options holding the
+// blocks from 1 to height, and its DB.
+func makeBlockStoreWithBlocks(t *testing.T, height int64, options ...BlockStoreOption) (*BlockStore, dbm.DB) {
+	t.Helper()
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, options...)
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		block := makeBlock(h, state, lastCommit)
+		// the blocks are a second apart, like those of a chain
+		block.Time = state.LastBlockTime.Add(time.Duration(h) * time.Second)
+		partSet := block.MakePartSet(2)
+		lastCommit = makeTestCommit(h, cmttime.Now())
+		lastCommit.BlockID = types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		bs.SaveBlock(block, partSet, lastCommit)
+		state.LastBlockID = lastCommit.BlockID
+	}
+	return bs, db
+}
+
+func iterateHeights(t *testing.T, bs *BlockStore, start, end int64) []int64 {
+	t.Helper()
+	it, err := bs.Iterator(start, end)
+	require.NoError(t, err)
+	defer it.Close()
+
+	var heights []int64
+	for it.Next() {
+		assert.Equal(t, it.Height(), it.BlockMeta().Header.Height)
+		heights = append(heights, it.Height())
+	}
+	require.NoError(t, it.Error())
+	return heights
+}
+
+func heightRange(from, to int64, skip ...int64) []int64 {
+	var heights []int64
+	for h := from; h <= to; h++ {
+		skipped := false
+		for _, s := range skip {
+			skipped = skipped || s == h
+		}
+		if !skipped {
+			heights = append(heights, h)
+		}
+	}
+	return heights
+}
+
+func TestBlockIterator(t *testing.T) {
+	// the heights cross the changes of their number of digits
+	bs, db := makeBlockStoreWithBlocks(t, 105)
+
+	assert.Equal(t, heightRange(1, 105), iterateHeights(t, bs, 1, 105))
+	assert.Equal(t, heightRange(8, 12), iterateHeights(t, bs, 8, 12))
+	assert.Equal(t, heightRange(10, 10), iterateHeights(t, bs, 10, 10))
+	// the end is capped at the height of the store
+	assert.Equal(t, heightRange(95, 105), iterateHeights(t, bs, 95, 1000))
+	assert.Empty(t, iterateHeights(t, bs, 106, 200))
+
+	// the pruned blocks and the gaps are skipped
+	_, err := bs.PruneBlocks(5)
+	require.NoError(t, err)
+	require.NoError(t, db.Delete(calcBlockMetaKey(10)))
+	require.NoError(t, db.Delete(calcBlockMetaKey(50)))
+	require.NoError(t, db.Delete(calcBlockMetaKey(100)))
+	assert.Equal(t, heightRange(5, 105, 10, 50, 100), iterateHeights(t, bs, 1, 105))
+	assert.Equal(t, heightRange(99, 101, 100), iterateHeights(t, bs, 99, 101))
+	assert.Empty(t, iterateHeights(t, bs, 1, 4))
+
+	// the blocks are loaded from the cursor
+	it, err := bs.Iterator(20, 21)
+	require.NoError(t, err)
+	for it.Next() {
+		assert.Equal(t, bs.LoadBlock(it.Height()).Hash(), it.Block().Hash())
+	}
+	require.NoError(t, it.Error())
+	require.NoError(t, it.Close())
+
+	// a block deleted after its meta was read is missing
+	it, err = bs.Iterator(30, 31)
+	require.NoError(t, err)
+	require.True(t, it.Next())
+	require.NoError(t, db.Delete(calcBlockPartKey(30, 0)))
+	assert.Nil(t, it.Block())
+	require.NoError(t, it.Close())
+
+	_, err = bs.Iterator(0, 10)
+	assert.Error(t, err)
+	_, err = bs.Iterator(10, 9)
+	assert.Error(t, err)
+}
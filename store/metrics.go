@@ -0,0 +1,151 @@
+package store
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "store"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height of the first block of the block store.
+	BaseHeight metrics.Gauge
+	// Height below which the blocks are pruned in the background.
+	PruneRetainHeight metrics.Gauge
+	// Number of blocks pruned, in the background or not.
+	PrunedBlocks metrics.Counter
+	// Time taken to prune a batch of blocks in the background.
+	PruningTime metrics.Histogram
+	// Number of blocks and block parts loaded from the cache, by type.
+	BlockCacheHits metrics.Counter
+	// Number of blocks and block parts loaded from the DB, missing from the
+	// cache, by type.
+	BlockCacheMisses metrics.Counter
+	// Size of the blocks and block parts in the cache, in bytes.
+	BlockCacheSize metrics.Gauge
+	// Size of the blocks saved, in bytes.
+	BlockSize metrics.Histogram
+	// Time taken to save a block.
+	SaveBlockTime metrics.Histogram
+	// Time taken to prune the blocks below a height, in the background or
+	// not.
+	PruneBlocksTime metrics.Histogram
+	// Time taken to load a block, part, meta or commit, by method.
+	LoadTime metrics.Histogram
+	// Estimated size of the block store database, in bytes, with goleveldb.
+	DBSize metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		BaseHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "base_height",
+			Help:      "Height of the first block of the block store.",
+		}, labels).With(labelsAndValues...),
+		PruneRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prune_retain_height",
+			Help:      "Height below which the blocks are pruned in the background.",
+		}, labels).With(labelsAndValues...),
+		PrunedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruned_blocks",
+			Help:      "Number of blocks pruned, in the background or not.",
+		}, labels).With(labelsAndValues...),
+		PruningTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruning_time_seconds",
+			Help:      "Time taken to prune a batch of blocks in the background.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 12),
+		}, labels).With(labelsAndValues...),
+		BlockCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_cache_hits",
+			Help:      "Number of blocks and block parts loaded from the cache, by type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		BlockCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_cache_misses",
+			Help:      "Number of blocks and block parts missing from the cache, by type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		BlockCacheSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_cache_size_bytes",
+			Help:      "Size of the blocks and block parts in the cache, in bytes.",
+		}, labels).With(labelsAndValues...),
+		BlockSize: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_size_bytes",
+			Help:      "Size of the blocks saved, in bytes.",
+			Buckets:   stdprometheus.ExponentialBuckets(1024, 4, 10),
+		}, labels).With(labelsAndValues...),
+		SaveBlockTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "save_block_seconds",
+			Help:      "Time taken to save a block.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, labels).With(labelsAndValues...),
+		PruneBlocksTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prune_blocks_seconds",
+			Help:      "Time taken to prune the blocks below a height, in the background or not.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 12),
+		}, labels).With(labelsAndValues...),
+		LoadTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "load_seconds",
+			Help:      "Time taken to load a block, part, meta or commit, by method.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.00005, 2, 16),
+		}, append(labels, "method")).With(labelsAndValues...),
+		DBSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "db_size_bytes",
+			Help:      "Estimated size of the block store database, in bytes, with goleveldb.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		BaseHeight:        discard.NewGauge(),
+		PruneRetainHeight: discard.NewGauge(),
+		PrunedBlocks:      discard.NewCounter(),
+		PruningTime:       discard.NewHistogram(),
+		BlockCacheHits:    discard.NewCounter(),
+		BlockCacheMisses:  discard.NewCounter(),
+		BlockCacheSize:    discard.NewGauge(),
+		BlockSize:         discard.NewHistogram(),
+		SaveBlockTime:     discard.NewHistogram(),
+		PruneBlocksTime:   discard.NewHistogram(),
+		LoadTime:          discard.NewHistogram(),
+		DBSize:            discard.NewGauge(),
+	}
+}
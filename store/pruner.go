@@ -0,0 +1,224 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// defaultPruneBatchSize is the number of blocks pruned at once by the Pruner,
+// which can stop between the batches.
+const defaultPruneBatchSize = 1000
+
+// defaultRetentionInterval is the interval at which the Pruner checks the
+// blocks which are past the retention period, which moves with the time.
+const defaultRetentionInterval = time.Minute
+
+// PruneBlocksAsync schedules the pruning of the blocks below retainHeight by
+// the Pruner, off the caller's path. The retain height is persisted, so that
+// the pruning resumes after a restart. A retain height lower than the one
+// scheduled already is ignored.
+func (bs *BlockStore) PruneBlocksAsync(retainHeight int64) error {
+	if retainHeight <= 0 {
+		return fmt.Errorf("retain height must be greater than 0")
+	}
+	if height := bs.Height(); retainHeight > height {
+		return fmt.Errorf("cannot prune beyond the latest height %v", height)
+	}
+	scheduled, err := bs.PruneRetainHeight()
+	if err != nil {
+		return err
+	}
+	if retainHeight <= scheduled {
+		return nil
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(retainHeight))
+	if err := bs.db.SetSync(pruneRetainHeightKey, bz); err != nil {
+		return err
+	}
+	select {
+	case bs.pruneNotify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// PruneRetainHeight returns the retain height scheduled by PruneBlocksAsync,
+// or 0 if none was.
+func (bs *BlockStore) PruneRetainHeight() (int64, error) {
+	bz, err := bs.db.Get(pruneRetainHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid prune retain height of %d bytes", len(bz))
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// StatePruner prunes the states along with the blocks.
+type StatePruner interface {
+	PruneStates(from int64, to int64) error
+}
+
+// Pruner is a service pruning the blocks of a BlockStore below the retain
+// height scheduled by PruneBlocksAsync, in batches, in the background. It
+// resumes the pruning scheduled before a restart when started.
+type Pruner struct {
+	service.BaseService
+
+	bs                *BlockStore
+	statePruner       StatePruner
+	batchSize         int64
+	retentionPeriod   time.Duration
+	retentionInterval time.Duration
+	metrics           *Metrics
+}
+
+// PrunerOption sets an optional parameter on the Pruner.
+type PrunerOption func(*Pruner)
+
+// NewPruner returns a Pruner of the blocks of bs.
+func NewPruner(bs *BlockStore, options ...PrunerOption) *Pruner {
+	p := &Pruner{
+		bs:                bs,
+		batchSize:         defaultPruneBatchSize,
+		retentionInterval: defaultRetentionInterval,
+		metrics:           NopMetrics(),
+	}
+	p.BaseService = *service.NewBaseService(nil, "Pruner", p)
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// PrunerMetrics sets the metrics.
+func PrunerMetrics(metrics *Metrics) PrunerOption {
+	return func(p *Pruner) { p.metrics = metrics }
+}
+
+// WithStatePruner makes the Pruner prune the states of the blocks it prunes
+// with statePruner.
+func WithStatePruner(statePruner StatePruner) PrunerOption {
+	return func(p *Pruner) { p.statePruner = statePruner }
+}
+
+// PruneBatchSize sets the number of blocks pruned at once.
+func PruneBatchSize(size int64) PrunerOption {
+	return func(p *Pruner) { p.batchSize = size }
+}
+
+// WithRetentionPeriod keeps the blocks younger than period, by their time,
+// even below the scheduled retain height, which is the more conservative of
+// the two bounds. The blocks are pruned as they age. The times of the blocks
+// must be indexed, see BlockStore.IndexBlockTimes.
+func WithRetentionPeriod(period time.Duration) PrunerOption {
+	return func(p *Pruner) { p.retentionPeriod = period }
+}
+
+// PruneBlocksAsync schedules the pruning of the blocks below retainHeight, see
+// BlockStore.PruneBlocksAsync.
+func (p *Pruner) PruneBlocksAsync(retainHeight int64) error {
+	return p.bs.PruneBlocksAsync(retainHeight)
+}
+
+// OnStart implements service.Service.
+func (p *Pruner) OnStart() error {
+	go p.run()
+	return nil
+}
+
+func (p *Pruner) run() {
+	// the blocks past the retention period are checked periodically, since
+	// the retain height may not change
+	var retentionC <-chan time.Time
+	if p.retentionPeriod > 0 {
+		ticker := time.NewTicker(p.retentionInterval)
+		defer ticker.Stop()
+		retentionC = ticker.C
+	}
+
+	p.prune()
+	for {
+		select {
+		case <-p.bs.pruneNotify:
+			p.prune()
+		case <-retentionC:
+			p.prune()
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// prune prunes the blocks below the scheduled retain height, batch by batch,
+// until done or stopped.
+func (p *Pruner) prune() {
+	retainHeight, err := p.bs.PruneRetainHeight()
+	if err != nil {
+		p.Logger.Error("failed to load the prune retain height", "err", err)
+		return
+	}
+	if p.retentionPeriod > 0 && retainHeight > 0 {
+		retentionHeight, err := p.retentionRetainHeight()
+		if err != nil {
+			p.Logger.Error("failed to find the blocks past the retention period", "err", err)
+			return
+		}
+		if retentionHeight < retainHeight {
+			retainHeight = retentionHeight
+		}
+	}
+	p.metrics.PruneRetainHeight.Set(float64(retainHeight))
+
+	for {
+		base := p.bs.Base()
+		p.metrics.BaseHeight.Set(float64(base))
+		if base == 0 || base >= retainHeight {
+			return
+		}
+		to := base + p.batchSize
+		if to > retainHeight {
+			to = retainHeight
+		}
+
+		start := time.Now()
+		pruned, err := p.bs.PruneBlocks(to)
+		if err != nil {
+			p.Logger.Error("failed to prune blocks", "retain_height", to, "err", err)
+			return
+		}
+		if p.statePruner != nil {
+			if err := p.statePruner.PruneStates(base, to); err != nil {
+				p.Logger.Error("failed to prune states", "retain_height", to, "err", err)
+				return
+			}
+		}
+		p.metrics.PruningTime.Observe(time.Since(start).Seconds())
+		p.Logger.Debug("pruned blocks", "pruned", pruned, "base", to, "retain_height", retainHeight)
+
+		select {
+		case <-p.Quit():
+			return
+		default:
+		}
+	}
+}
+
+// retentionRetainHeight returns the height of the oldest block younger than
+// the retention period, or the height above the latest block if none is.
+func (p *Pruner) retentionRetainHeight() (int64, error) {
+	cutoff := time.Now().Add(-p.retentionPeriod)
+	height, err := p.bs.HeightByTime(cutoff.Add(-time.Nanosecond))
+	if err != nil {
+		return 0, err
+	}
+	return height + 1, nil
+}
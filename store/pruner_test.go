@@ -0,0 +1,121 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+type mockStatePruner struct {
+	mtx    cmtsync.Mutex
+	ranges [][2]int64
+}
+
+func (sp *mockStatePruner) PruneStates(from int64, to int64) error {
+	sp.mtx.Lock()
+	defer sp.mtx.Unlock()
+	sp.ranges = append(sp.ranges, [2]int64{from, to})
+	return nil
+}
+
+func (sp *mockStatePruner) Ranges() [][2]int64 {
+	sp.mtx.Lock()
+	defer sp.mtx.Unlock()
+	return sp.ranges
+}
+
+func TestPruneBlocksAsync(t *testing.T) {
+	bs, _ := makeBlockStoreWithBlocks(t, 10)
+
+	retainHeight, err := bs.PruneRetainHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, retainHeight)
+
+	require.Error(t, bs.PruneBlocksAsync(0))
+	require.Error(t, bs.PruneBlocksAsync(11))
+
+	require.NoError(t, bs.PruneBlocksAsync(5))
+	// a lower retain height is ignored
+	require.NoError(t, bs.PruneBlocksAsync(3))
+	retainHeight, err = bs.PruneRetainHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, retainHeight)
+
+	// nothing is pruned until the Pruner runs
+	assert.EqualValues(t, 1, bs.Base())
+	assert.NotNil(t, bs.LoadBlock(1))
+}
+
+func TestPruner(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 25)
+	statePruner := &mockStatePruner{}
+	pruner := NewPruner(bs, WithStatePruner(statePruner), PruneBatchSize(10))
+	pruner.SetLogger(log.TestingLogger())
+	require.NoError(t, pruner.Start())
+
+	require.NoError(t, pruner.PruneBlocksAsync(22))
+	require.Eventually(t, func() bool {
+		return bs.Base() == 22
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, pruner.Stop())
+
+	assert.Nil(t, bs.LoadBlock(21))
+	assert.NotNil(t, bs.LoadBlock(22))
+	assert.Equal(t, [][2]int64{{1, 11}, {11, 21}, {21, 22}}, statePruner.Ranges())
+	assert.EqualValues(t, 22, LoadBlockStoreState(db).Base)
+}
+
+func TestPrunerResumes(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 10)
+	require.NoError(t, bs.PruneBlocksAsync(8))
+
+	// the pruning scheduled before a restart resumes when the Pruner starts
+	bs = NewBlockStore(db)
+	pruner := NewPruner(bs)
+	pruner.SetLogger(log.TestingLogger())
+	require.NoError(t, pruner.Start())
+	defer pruner.Stop() //nolint:errcheck // ignore for tests
+
+	require.Eventually(t, func() bool {
+		return bs.Base() == 8
+	}, time.Second, 10*time.Millisecond)
+	assert.Nil(t, bs.LoadBlock(7))
+}
+
+func TestPrunerRetentionPeriod(t *testing.T) {
+	bs, _ := makeBlockStoreWithBlocks(t, 10)
+	// the blocks are a second apart, so the retention period ends between them
+	retentionPeriod := func(height int64) time.Duration {
+		return time.Since(bs.LoadBlockMeta(height).Header.Time) + 500*time.Millisecond
+	}
+	startPruner := func(height int64) *Pruner {
+		pruner := NewPruner(bs, WithRetentionPeriod(retentionPeriod(height)))
+		pruner.retentionInterval = 10 * time.Millisecond
+		pruner.SetLogger(log.TestingLogger())
+		require.NoError(t, pruner.Start())
+		return pruner
+	}
+
+	// the blocks from 4 are within the retention period, which is the more
+	// conservative bound
+	pruner := startPruner(4)
+	require.NoError(t, pruner.PruneBlocksAsync(8))
+	require.Eventually(t, func() bool {
+		return bs.Base() == 4
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, pruner.Stop())
+	assert.Nil(t, bs.LoadBlock(3))
+
+	// the retain height is, once the blocks are older
+	pruner = startPruner(10)
+	defer pruner.Stop() //nolint:errcheck // ignore for tests
+	require.Eventually(t, func() bool {
+		return bs.Base() == 8
+	}, time.Second, 10*time.Millisecond)
+	assert.NotNil(t, bs.LoadBlock(8))
+}
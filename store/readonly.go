@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// ErrReadOnly is returned by the writes to a read-only block store.
+var ErrReadOnly = errors.New("the block store is read-only")
+
+// NewReadOnlyBlockStore returns a BlockStore of db which refuses the writes,
+// with ErrReadOnly, or a panic for the methods panicking on the errors, e.g.
+// SaveBlock, for the tools inspecting the blocks, e.g. explorers. The base and
+// height are reloaded from db when read, so that the store follows the blocks
+// saved and pruned by a node running on the same db, with the backends which
+// allow it. For the same reason, the blocks aren't cached: WithCache is
+// ignored, since the node may prune or roll back the blocks cached.
+func NewReadOnlyBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bs := NewBlockStore(readOnlyDB{DB: db}, options...)
+	bs.readOnly = true
+	bs.cache = nil
+	return bs
+}
+
+// refresh reloads the base and height of a read-only store from the DB, since
+// another store may be writing to it.
+func (bs *BlockStore) refresh() {
+	if !bs.readOnly {
+		return
+	}
+	bss := LoadBlockStoreState(bs.db)
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.base, bs.height = bss.Base, bss.Height
+}
+
+// readOnlyDB is a DB refusing the writes.
+type readOnlyDB struct {
+	dbm.DB
+}
+
+var _ dbm.DB = readOnlyDB{}
+
+func (readOnlyDB) Set([]byte, []byte) error     { return ErrReadOnly }
+func (readOnlyDB) SetSync([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyDB) Delete([]byte) error          { return ErrReadOnly }
+func (readOnlyDB) DeleteSync([]byte) error      { return ErrReadOnly }
+func (readOnlyDB) NewBatch() dbm.Batch          { return readOnlyBatch{} }
+
+// readOnlyBatch is the batch of a readOnlyDB, refusing the writes.
+type readOnlyBatch struct{}
+
+var _ dbm.Batch = readOnlyBatch{}
+
+func (readOnlyBatch) Set([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyBatch) Delete([]byte) error      { return ErrReadOnly }
+func (readOnlyBatch) Write() error             { return ErrReadOnly }
+func (readOnlyBatch) WriteSync() error         { return ErrReadOnly }
+func (readOnlyBatch) Close() error             { return nil }
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyBlockStore(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 10)
+	roBS := NewReadOnlyBlockStore(db, WithCache(1<<20))
+	assert.EqualValues(t, 1, roBS.Base())
+	assert.EqualValues(t, 10, roBS.Height())
+	assert.Equal(t, bs.LoadBlock(5).Hash(), roBS.LoadBlock(5).Hash())
+
+	// the writes are refused
+	_, err := roBS.PruneBlocks(5)
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.ErrorIs(t, roBS.DeleteBlocksFrom(8), ErrReadOnly)
+	assert.ErrorIs(t, roBS.SaveSeenCommit(10, bs.LoadSeenCommit(10)), ErrReadOnly)
+	assert.ErrorIs(t, roBS.PruneBlocksAsync(5), ErrReadOnly)
+	assert.EqualValues(t, 10, roBS.Height())
+	assert.NotNil(t, bs.LoadBlock(1))
+
+	// the blocks pruned and deleted by the node are followed, even those
+	// loaded already
+	require.NotNil(t, roBS.LoadBlock(2))
+	require.NotNil(t, roBS.LoadBlock(9))
+	block, seenCommit := bs.LoadBlock(9), bs.LoadSeenCommit(9)
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	require.NoError(t, bs.DeleteBlocksFrom(9))
+	assert.EqualValues(t, 3, roBS.Base())
+	assert.EqualValues(t, 8, roBS.Height())
+	assert.EqualValues(t, 6, roBS.Size())
+	assert.EqualValues(t, 3, roBS.LoadBaseMeta().Header.Height)
+	assert.Nil(t, roBS.LoadBlock(2))
+	assert.Nil(t, roBS.LoadBlock(9))
+
+	// and the blocks can't be saved
+	assert.PanicsWithError(t, ErrReadOnly.Error(), func() {
+		roBS.SaveBlock(block, block.MakePartSet(2), seenCommit)
+	})
+	assert.EqualValues(t, 8, roBS.Height())
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"fmt"
+
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
+
+// SaveBlockResults saves the ABCI responses of the block at height, once
+// executed, so that they're served with the block even if the state store
+// discards them. They're pruned and deleted with the block, and compressed
+// like its parts.
+func (bs *BlockStore) SaveBlockResults(height int64, results *cmtstate.ABCIResponses) error {
+	bz, err := results.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshaling the results of block %d: %w", height, err)
+	}
+	return bs.db.Set(calcBlockResultsKey(height), bs.encodeValue(bz))
+}
+
+// LoadBlockResults returns the ABCI responses of the block at height, or nil
+// if they weren't saved. It returns ErrCorruptedEntry if they can't be
+// decoded.
+func (bs *BlockStore) LoadBlockResults(height int64) (*cmtstate.ABCIResponses, error) {
+	key := calcBlockResultsKey(height)
+	bz, err := bs.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	bz, err = decodeValue(bz)
+	if err != nil {
+		return nil, ErrCorruptedEntry{Key: string(key), Err: err}
+	}
+	results := new(cmtstate.ABCIResponses)
+	if err := results.Unmarshal(bz); err != nil {
+		return nil, ErrCorruptedEntry{Key: string(key), Err: err}
+	}
+	return results, nil
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
+
+func TestBlockResults(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 10, WithCompression(CompressionSnappy))
+	for h := int64(1); h <= 10; h++ {
+		require.NoError(t, bs.SaveBlockResults(h, &cmtstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: 0, GasUsed: h}, {Code: 1, Log: "failed"}},
+			EndBlock:   &abci.ResponseEndBlock{},
+			BeginBlock: &abci.ResponseBeginBlock{},
+		}))
+	}
+	results, err := bs.LoadBlockResults(5)
+	require.NoError(t, err)
+	require.Len(t, results.DeliverTxs, 2)
+	assert.EqualValues(t, 5, results.DeliverTxs[0].GasUsed)
+	assert.Equal(t, "failed", results.DeliverTxs[1].Log)
+
+	// the results are pruned with the blocks
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	results, err = bs.LoadBlockResults(2)
+	require.NoError(t, err)
+	assert.Nil(t, results)
+	results, err = bs.LoadBlockResults(3)
+	require.NoError(t, err)
+	assert.NotNil(t, results)
+
+	// and deleted with them
+	require.NoError(t, bs.DeleteBlocksFrom(9))
+	results, err = bs.LoadBlockResults(9)
+	require.NoError(t, err)
+	assert.Nil(t, results)
+
+	require.NoError(t, db.Set(calcBlockResultsKey(4), []byte("corrupted")))
+	_, err = bs.LoadBlockResults(4)
+	assert.ErrorAs(t, err, &ErrCorruptedEntry{})
+}
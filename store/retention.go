@@ -0,0 +1,35 @@
+package store
+
+import "fmt"
+
+// The pruning modes of the BlockStore.
+const (
+	// PruningModeBlocks prunes the whole blocks.
+	PruningModeBlocks = "blocks"
+	// PruningModeBodies prunes the parts of the blocks only, keeping their
+	// meta, holding their header, and their commit, for all the heights.
+	PruningModeBodies = "bodies"
+)
+
+// WithPruningMode makes PruneBlocks prune with mode, PruningModeBlocks (or
+// empty) or PruningModeBodies.
+func WithPruningMode(mode string) BlockStoreOption {
+	switch mode {
+	case "":
+		mode = PruningModeBlocks
+	case PruningModeBlocks, PruningModeBodies:
+	default:
+		panic(fmt.Sprintf("unknown pruning mode %q", mode))
+	}
+	return func(bs *BlockStore) { bs.pruningMode = mode }
+}
+
+// ErrBlockBodyPruned is returned by LoadBlockE for a block whose body was
+// pruned with PruningModeBodies, its meta and commit being kept.
+type ErrBlockBodyPruned struct {
+	Height int64
+}
+
+func (e ErrBlockBodyPruned) Error() string {
+	return fmt.Sprintf("the body of block %d was pruned", e.Height)
+}
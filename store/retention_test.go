@@ -0,0 +1,64 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBlockBodies(t *testing.T) {
+	// more than 1000 blocks, to test batch deletions
+	bs, _ := makeBlockStoreWithBlocks(t, 1200, WithPruningMode(PruningModeBodies))
+
+	pruned, err := bs.PruneBlocks(1100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1099, pruned)
+	assert.EqualValues(t, 1100, bs.Base())
+	assert.EqualValues(t, 1200, bs.Height())
+	assert.EqualValues(t, 101, bs.Size())
+
+	// the bodies are pruned, but the metas and commits are kept
+	for _, h := range []int64{1, 999, 1000, 1001, 1099} {
+		assert.Nil(t, bs.LoadBlock(h), h)
+		assert.Nil(t, bs.LoadBlockPart(h, 0), h)
+		assert.Nil(t, bs.LoadSeenCommit(h), h)
+		meta := bs.LoadBlockMeta(h)
+		require.NotNil(t, meta, h)
+		assert.EqualValues(t, h, meta.Header.Height)
+		commit := bs.LoadBlockCommit(h)
+		require.NotNil(t, commit, h)
+		assert.Equal(t, meta.BlockID, commit.BlockID)
+
+		block, err := bs.LoadBlockE(h)
+		assert.Nil(t, block)
+		assert.Equal(t, ErrBlockBodyPruned{Height: h}, err)
+	}
+	block, err := bs.LoadBlockE(1100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1100, block.Height)
+	block, err = bs.LoadBlockE(1201)
+	require.NoError(t, err)
+	assert.Nil(t, block)
+
+	// the pruning resumes from the base
+	pruned, err = bs.PruneBlocks(1150)
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, pruned)
+	assert.NotNil(t, bs.LoadBlockMeta(1149))
+
+	// the headers are iterated over below the base
+	assert.Equal(t, heightRange(1, 1200), iterateHeights(t, bs, 1, 1200))
+
+	// the whole blocks are pruned by default
+	bs, _ = makeBlockStoreWithBlocks(t, 10)
+	_, err = bs.PruneBlocks(5)
+	require.NoError(t, err)
+	assert.Nil(t, bs.LoadBlockMeta(4))
+	assert.Nil(t, bs.LoadBlockCommit(4))
+	block, err = bs.LoadBlockE(4)
+	require.NoError(t, err)
+	assert.Nil(t, block)
+
+	assert.Panics(t, func() { WithPruningMode("headers") })
+}
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockStats are the statistics of the transactions of a block, saved along
+// with it so that the throughput of the chain can be computed without loading
+// the blocks.
+type BlockStats struct {
+	TxCount int64
+	TxBytes int64
+	// the gas of the transactions, known once the block is executed and saved
+	// with SaveBlockGas, 0 until then.
+	GasWanted int64
+	GasUsed   int64
+}
+
+// BlockStatsSum is the sum of the statistics of the blocks of a range of
+// heights.
+type BlockStatsSum struct {
+	BlockStats
+	// the number of blocks with statistics in the range, those saved before
+	// the statistics were kept having none.
+	Blocks int64
+}
+
+func newBlockStats(block *types.Block) BlockStats {
+	stats := BlockStats{TxCount: int64(len(block.Txs))}
+	for _, tx := range block.Txs {
+		stats.TxBytes += int64(len(tx))
+	}
+	return stats
+}
+
+func (s BlockStats) encode() []byte {
+	bz := make([]byte, 32)
+	binary.BigEndian.PutUint64(bz[0:], uint64(s.TxCount))
+	binary.BigEndian.PutUint64(bz[8:], uint64(s.TxBytes))
+	binary.BigEndian.PutUint64(bz[16:], uint64(s.GasWanted))
+	binary.BigEndian.PutUint64(bz[24:], uint64(s.GasUsed))
+	return bz
+}
+
+func decodeBlockStats(bz []byte) (*BlockStats, error) {
+	if len(bz) != 32 {
+		return nil, fmt.Errorf("invalid block stats of %d bytes", len(bz))
+	}
+	return &BlockStats{
+		TxCount:   int64(binary.BigEndian.Uint64(bz[0:])),
+		TxBytes:   int64(binary.BigEndian.Uint64(bz[8:])),
+		GasWanted: int64(binary.BigEndian.Uint64(bz[16:])),
+		GasUsed:   int64(binary.BigEndian.Uint64(bz[24:])),
+	}, nil
+}
+
+// LoadBlockStats returns the statistics of the block at height, or nil if
+// there are none, e.g. if the block was saved before they were kept.
+func (bs *BlockStore) LoadBlockStats(height int64) (*BlockStats, error) {
+	bz, err := bs.db.Get(calcBlockStatsKey(height))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	return decodeBlockStats(bz)
+}
+
+// SaveBlockGas saves the gas of the transactions of the block at height to
+// its statistics, once executed. It does nothing if the block has no
+// statistics.
+func (bs *BlockStore) SaveBlockGas(height, gasWanted, gasUsed int64) error {
+	stats, err := bs.LoadBlockStats(height)
+	if err != nil || stats == nil {
+		return err
+	}
+	stats.GasWanted, stats.GasUsed = gasWanted, gasUsed
+	return bs.db.Set(calcBlockStatsKey(height), stats.encode())
+}
+
+// SumBlockStats returns the sum of the statistics of the blocks between from
+// and to (inclusive), up to the height of the store. It reads them with a DB
+// iterator, without loading the blocks.
+func (bs *BlockStore) SumBlockStats(from, to int64) (*BlockStatsSum, error) {
+	it, err := bs.heightIterator("ST:", from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	sum := new(BlockStatsSum)
+	for it.Next() {
+		stats, err := decodeBlockStats(it.value)
+		if err != nil {
+			return nil, fmt.Errorf("height %d: %w", it.height, err)
+		}
+		sum.Blocks++
+		sum.TxCount += stats.TxCount
+		sum.TxBytes += stats.TxBytes
+		sum.GasWanted += stats.GasWanted
+		sum.GasUsed += stats.GasUsed
+	}
+	if it.err != nil {
+		return nil, it.err
+	}
+	return sum, nil
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStats(t *testing.T) {
+	// the heights cross the changes of their number of digits
+	bs, db := makeBlockStoreWithBlocks(t, 105)
+
+	var want BlockStatsSum
+	for h := int64(1); h <= 105; h++ {
+		block := bs.LoadBlock(h)
+		stats, err := bs.LoadBlockStats(h)
+		require.NoError(t, err)
+		require.NotNil(t, stats)
+		assert.EqualValues(t, len(block.Txs), stats.TxCount)
+		var size int64
+		for _, tx := range block.Txs {
+			size += int64(len(tx))
+		}
+		assert.Equal(t, size, stats.TxBytes)
+		assert.Zero(t, stats.GasWanted)
+		assert.Zero(t, stats.GasUsed)
+
+		want.Blocks++
+		want.TxCount += stats.TxCount
+		want.TxBytes += stats.TxBytes
+	}
+	require.NotZero(t, want.TxCount)
+
+	// the gas is saved once the block is executed
+	require.NoError(t, bs.SaveBlockGas(10, 20, 15))
+	stats, err := bs.LoadBlockStats(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 20, stats.GasWanted)
+	assert.EqualValues(t, 15, stats.GasUsed)
+	want.GasWanted, want.GasUsed = 20, 15
+
+	// the range is capped at the height of the store
+	sum, err := bs.SumBlockStats(1, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, want, *sum)
+
+	sum, err = bs.SumBlockStats(10, 10)
+	require.NoError(t, err)
+	assert.Equal(t, BlockStatsSum{BlockStats: *stats, Blocks: 1}, *sum)
+
+	// the blocks without statistics, e.g. saved before they were kept, are
+	// skipped
+	require.NoError(t, db.Delete(calcBlockStatsKey(10)))
+	stats, err = bs.LoadBlockStats(10)
+	require.NoError(t, err)
+	assert.Nil(t, stats)
+	require.NoError(t, bs.SaveBlockGas(10, 20, 15))
+	stats, err = bs.LoadBlockStats(10)
+	require.NoError(t, err)
+	assert.Nil(t, stats)
+	sum, err = bs.SumBlockStats(9, 11)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, sum.Blocks)
+
+	// the statistics are pruned with the blocks
+	_, err = bs.PruneBlocks(50)
+	require.NoError(t, err)
+	stats, err = bs.LoadBlockStats(49)
+	require.NoError(t, err)
+	assert.Nil(t, stats)
+	sum, err = bs.SumBlockStats(1, 105)
+	require.NoError(t, err)
+	assert.EqualValues(t, 56, sum.Blocks)
+
+	// and deleted with the latest block
+	require.NoError(t, bs.DeleteLatestBlock())
+	stats, err = bs.LoadBlockStats(105)
+	require.NoError(t, err)
+	assert.Nil(t, stats)
+
+	_, err = bs.SumBlockStats(0, 10)
+	assert.Error(t, err)
+	_, err = bs.SumBlockStats(10, 9)
+	assert.Error(t, err)
+}
@@ -1,12 +1,21 @@
 package store
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"runtime"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/gogo/protobuf/proto"
+	"github.com/syndtr/goleveldb/leveldb/util"
 
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	cmtstore "github.com/tendermint/tendermint/proto/tendermint/store"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -20,12 +29,17 @@ There are three types of information stored:
   - BlockMeta:   Meta information about each block
   - Block part:  Parts of each block, aggregated w/ PartSet
   - Commit:      The commit part of each block, for gossiping precommit votes
+  - DA pointer:  The location of each block on the data availability layer,
+    once submitted to it
+  - Results:     The ABCI responses of each block, once executed, if saved
+    with SaveBlockResults
 
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
 
 The store can be assumed to contain all contiguous blocks between base and height (inclusive).
+With PruningModeBodies, the metas and commits of the blocks pruned are kept below the base.
 
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
@@ -41,21 +55,60 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+
+	// pruneNotify signals the Pruner of a new retain height.
+	pruneNotify chan struct{}
+
+	// compression of the block parts and metas saved
+	compression string
+
+	// pruningMode of PruneBlocks
+	pruningMode string
+
+	// cache of the blocks and parts loaded recently, nil if disabled
+	cache   *blockCache
+	metrics *Metrics
+
+	// readOnly is set by NewReadOnlyBlockStore
+	readOnly bool
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	bs := LoadBlockStoreState(db)
-	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
-		db:     db,
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bss := LoadBlockStoreState(db)
+	bs := &BlockStore{
+		base:        bss.Base,
+		height:      bss.Height,
+		db:          db,
+		pruneNotify: make(chan struct{}, 1),
+		compression: CompressionNone,
+		pruningMode: PruningModeBlocks,
+		metrics:     NopMetrics(),
+	}
+	for _, option := range options {
+		option(bs)
 	}
+	return bs
+}
+
+// WithMetrics sets the metrics of the block store.
+func WithMetrics(metrics *Metrics) BlockStoreOption {
+	return func(bs *BlockStore) { bs.metrics = metrics }
+}
+
+// SetMetrics sets the metrics of the block store, e.g. once the chain is
+// known, after it was opened. It must be called before the block store is
+// used concurrently.
+func (bs *BlockStore) SetMetrics(metrics *Metrics) {
+	bs.metrics = metrics
+	bs.metrics.BaseHeight.Set(float64(bs.Base()))
+	bs.updateDBSize()
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
 func (bs *BlockStore) Base() int64 {
+	bs.refresh()
 	bs.mtx.RLock()
 	defer bs.mtx.RUnlock()
 	return bs.base
@@ -63,6 +116,7 @@ func (bs *BlockStore) Base() int64 {
 
 // Height returns the last known contiguous block height, or 0 for empty block stores.
 func (bs *BlockStore) Height() int64 {
+	bs.refresh()
 	bs.mtx.RLock()
 	defer bs.mtx.RUnlock()
 	return bs.height
@@ -70,6 +124,7 @@ func (bs *BlockStore) Height() int64 {
 
 // Size returns the number of blocks in the block store.
 func (bs *BlockStore) Size() int64 {
+	bs.refresh()
 	bs.mtx.RLock()
 	defer bs.mtx.RUnlock()
 	if bs.height == 0 {
@@ -80,6 +135,7 @@ func (bs *BlockStore) Size() int64 {
 
 // LoadBase atomically loads the base block meta, or returns nil if no base is found.
 func (bs *BlockStore) LoadBaseMeta() *types.BlockMeta {
+	bs.refresh()
 	bs.mtx.RLock()
 	defer bs.mtx.RUnlock()
 	if bs.base == 0 {
@@ -88,163 +144,314 @@ func (bs *BlockStore) LoadBaseMeta() *types.BlockMeta {
 	return bs.LoadBlockMeta(bs.base)
 }
 
+// ErrCorruptedEntry is returned by the error-returning load methods, e.g.
+// LoadBlockE, when an entry of the store can't be decoded, probably corrupted
+// on disk.
+type ErrCorruptedEntry struct {
+	Key string
+	Err error
+}
+
+func (e ErrCorruptedEntry) Error() string {
+	return fmt.Sprintf("corrupted entry %s of the block store: %v", e.Key, e.Err)
+}
+
+func (e ErrCorruptedEntry) Unwrap() error {
+	return e.Err
+}
+
+// ErrCorruptBlockPart is the error of the entries of the block parts which
+// don't match their checksum, wrapped in ErrCorruptedEntry, so that the block
+// can be fetched again from the peers.
+type ErrCorruptBlockPart struct {
+	Height int64
+	Index  int
+}
+
+func (e ErrCorruptBlockPart) Error() string {
+	return fmt.Sprintf("part %d of block %d: %v", e.Index, e.Height, ErrChecksumMismatch)
+}
+
+func (e ErrCorruptBlockPart) Unwrap() error {
+	return ErrChecksumMismatch
+}
+
 // LoadBlock returns the block with the given height.
 // If no block is found for that height, it returns nil.
+// Panics if the block can't be loaded, see LoadBlockE.
 func (bs *BlockStore) LoadBlock(height int64) *types.Block {
-	var blockMeta = bs.LoadBlockMeta(height)
-	if blockMeta == nil {
-		return nil
+	block, err := bs.LoadBlockE(height)
+	var pruned ErrBlockBodyPruned
+	if err != nil && !errors.As(err, &pruned) {
+		panic(err)
+	}
+	return block
+}
+
+// LoadBlockE is LoadBlock returning an error instead of panicking, an
+// ErrCorruptedEntry if the block can't be decoded. It returns
+// ErrBlockBodyPruned if only the meta and commit of the block were kept,
+// below the base of the store.
+func (bs *BlockStore) LoadBlockE(height int64) (*types.Block, error) {
+	defer bs.observeLoad("block", time.Now())
+	return bs.loadBlock(height)
+}
+
+func (bs *BlockStore) loadBlock(height int64) (*types.Block, error) {
+	if block := bs.cachedBlock(height); block != nil {
+		return block, nil
+	}
+	blockMeta, err := bs.loadBlockMeta(height)
+	if err != nil || blockMeta == nil {
+		return nil, err
 	}
 
-	pbb := new(cmtproto.Block)
-	buf := []byte{}
-	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
-		part := bs.LoadBlockPart(height, i)
-		// If the part is missing (e.g. since it has been deleted after we
-		// loaded the block meta) we consider the whole block to be missing.
-		if part == nil {
-			return nil
+	parts, err := bs.readBlockParts(height, int(blockMeta.BlockID.PartSetHeader.Total))
+	if err != nil {
+		return nil, err
+	}
+	// If a part is missing (e.g. since it has been deleted after we loaded
+	// the block meta) we consider the whole block to be missing, unless only
+	// its body was pruned.
+	if parts == nil {
+		if height < bs.Base() {
+			return nil, ErrBlockBodyPruned{Height: height}
 		}
+		return nil, nil
+	}
+	size := 0
+	for _, part := range parts {
+		size += len(part.Bytes)
+	}
+	buf := make([]byte, 0, size)
+	for _, part := range parts {
 		buf = append(buf, part.Bytes...)
 	}
-	err := proto.Unmarshal(buf, pbb)
+	block, err := unmarshalBlock(buf)
 	if err != nil {
 		// NOTE: The existence of meta should imply the existence of the
 		// block. So, make sure meta is only saved after blocks are saved.
-		panic(fmt.Sprintf("Error reading block: %v", err))
+		return nil, ErrCorruptedEntry{Key: fmt.Sprintf("P:%v:*", height), Err: err}
 	}
-
-	block, err := types.BlockFromProto(pbb)
-	if err != nil {
-		panic(fmt.Errorf("error from proto block: %w", err))
-	}
-
-	return block
+	bs.cacheBlock(block, len(buf))
+	return block, nil
 }
 
 // LoadBlockByHash returns the block with the given hash.
 // If no block is found for that hash, it returns nil.
 // Panics if it fails to parse height associated with the given hash.
 func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
-	bz, err := bs.db.Get(calcBlockHashKey(hash))
-	if err != nil {
+	block, err := bs.LoadBlockByHashE(hash)
+	var pruned ErrBlockBodyPruned
+	if err != nil && !errors.As(err, &pruned) {
 		panic(err)
 	}
-	if len(bz) == 0 {
-		return nil
+	return block
+}
+
+// LoadBlockByHashE is LoadBlockByHash returning an error instead of
+// panicking, like LoadBlockE.
+func (bs *BlockStore) LoadBlockByHashE(hash []byte) (*types.Block, error) {
+	defer bs.observeLoad("block_by_hash", time.Now())
+	key := calcBlockHashKey(hash)
+	bz, err := bs.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
 	}
 
 	s := string(bz)
 	height, err := strconv.ParseInt(s, 10, 64)
-
 	if err != nil {
-		panic(fmt.Sprintf("failed to extract height from %s: %v", s, err))
+		return nil, ErrCorruptedEntry{Key: string(key), Err: fmt.Errorf("failed to extract height from %s: %w", s, err)}
 	}
-	return bs.LoadBlock(height)
+	return bs.loadBlock(height)
 }
 
 // LoadBlockPart returns the Part at the given index
 // from the block at the given height.
 // If no part is found for the given height and index, it returns nil.
+// Panics if the part can't be loaded, see LoadBlockPartE.
 func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
-	var pbpart = new(cmtproto.Part)
-
-	bz, err := bs.db.Get(calcBlockPartKey(height, index))
+	part, err := bs.LoadBlockPartE(height, index)
 	if err != nil {
 		panic(err)
 	}
-	if len(bz) == 0 {
-		return nil
+	return part
+}
+
+// LoadBlockPartE is LoadBlockPart returning an error instead of panicking, an
+// ErrCorruptedEntry if the part can't be decoded.
+func (bs *BlockStore) LoadBlockPartE(height int64, index int) (*types.Part, error) {
+	defer bs.observeLoad("block_part", time.Now())
+	if part := bs.cachedBlockPart(height, index); part != nil {
+		return part, nil
+	}
+	part, err := bs.readBlockPart(height, index)
+	if err != nil || part == nil {
+		return nil, err
 	}
+	bs.cacheBlockPart(height, part, len(part.Bytes))
+	return part, nil
+}
 
-	err = proto.Unmarshal(bz, pbpart)
-	if err != nil {
-		panic(fmt.Errorf("unmarshal to cmtproto.Part failed: %w", err))
+// readBlockPart reads the part at index of the block at height from the DB,
+// bypassing the cache.
+func (bs *BlockStore) readBlockPart(height int64, index int) (*types.Part, error) {
+	key := calcBlockPartKey(height, index)
+	bz, err := bs.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	part, err := unmarshalPart(bz)
+	if errors.Is(err, ErrChecksumMismatch) {
+		err = ErrCorruptBlockPart{Height: height, Index: index}
 	}
-	part, err := types.PartFromProto(pbpart)
 	if err != nil {
-		panic(fmt.Sprintf("Error reading block part: %v", err))
+		return nil, ErrCorruptedEntry{Key: string(key), Err: err}
 	}
+	return part, nil
+}
 
-	return part
+// maxBlockPartsReaders bounds the number of goroutines reading the parts of
+// a block concurrently.
+const maxBlockPartsReaders = 8
+
+// readBlockParts reads the total parts of the block at height from the DB,
+// bypassing the cache, concurrently for the blocks of several parts. Like
+// reading them in order, it returns the error of the first part which can't
+// be read, or no parts if the first one which can't be read is missing.
+func (bs *BlockStore) readBlockParts(height int64, total int) ([]*types.Part, error) {
+	parts := make([]*types.Part, total)
+	errs := make([]error, total)
+	var (
+		next   atomic.Int64 // the next index to read
+		failed atomic.Bool
+		wg     sync.WaitGroup
+	)
+	// the indexes are read in increasing order, so all those below a failed
+	// part are read before the readers stop
+	read := func() {
+		defer wg.Done()
+		for !failed.Load() {
+			i := int(next.Add(1) - 1)
+			if i >= total {
+				return
+			}
+			parts[i], errs[i] = bs.readBlockPart(height, i)
+			if errs[i] != nil || parts[i] == nil {
+				failed.Store(true)
+			}
+		}
+	}
+	readers := cmtmath.MinInt(runtime.GOMAXPROCS(0), maxBlockPartsReaders)
+	readers = cmtmath.MaxInt(1, cmtmath.MinInt(readers, total))
+	wg.Add(readers)
+	for r := 1; r < readers; r++ {
+		go read()
+	}
+	read()
+	wg.Wait()
+
+	for i := range parts {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if parts[i] == nil {
+			return nil, nil
+		}
+	}
+	return parts, nil
 }
 
 // LoadBlockMeta returns the BlockMeta for the given height.
 // If no block is found for the given height, it returns nil.
+// Panics if the meta can't be loaded, see LoadBlockMetaE.
 func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
-	var pbbm = new(cmtproto.BlockMeta)
-	bz, err := bs.db.Get(calcBlockMetaKey(height))
-
+	blockMeta, err := bs.LoadBlockMetaE(height)
 	if err != nil {
 		panic(err)
 	}
+	return blockMeta
+}
 
-	if len(bz) == 0 {
-		return nil
-	}
+// LoadBlockMetaE is LoadBlockMeta returning an error instead of panicking, an
+// ErrCorruptedEntry if the meta can't be decoded.
+func (bs *BlockStore) LoadBlockMetaE(height int64) (*types.BlockMeta, error) {
+	defer bs.observeLoad("block_meta", time.Now())
+	return bs.loadBlockMeta(height)
+}
 
-	err = proto.Unmarshal(bz, pbbm)
-	if err != nil {
-		panic(fmt.Errorf("unmarshal to cmtproto.BlockMeta: %w", err))
+func (bs *BlockStore) loadBlockMeta(height int64) (*types.BlockMeta, error) {
+	key := calcBlockMetaKey(height)
+	bz, err := bs.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
 	}
-
-	blockMeta, err := types.BlockMetaFromProto(pbbm)
+	blockMeta, err := unmarshalBlockMeta(bz)
 	if err != nil {
-		panic(fmt.Errorf("error from proto blockMeta: %w", err))
+		return nil, ErrCorruptedEntry{Key: string(key), Err: err}
 	}
-
-	return blockMeta
+	return blockMeta, nil
 }
 
 // LoadBlockCommit returns the Commit for the given height.
 // This commit consists of the +2/3 and other Precommit-votes for block at `height`,
 // and it comes from the block.LastCommit for `height+1`.
 // If no commit is found for the given height, it returns nil.
+// Panics if the commit can't be loaded, see LoadBlockCommitE.
 func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
-	var pbc = new(cmtproto.Commit)
-	bz, err := bs.db.Get(calcBlockCommitKey(height))
+	commit, err := bs.LoadBlockCommitE(height)
 	if err != nil {
 		panic(err)
 	}
-	if len(bz) == 0 {
-		return nil
-	}
-	err = proto.Unmarshal(bz, pbc)
-	if err != nil {
-		panic(fmt.Errorf("error reading block commit: %w", err))
-	}
-	commit, err := types.CommitFromProto(pbc)
-	if err != nil {
-		panic(fmt.Sprintf("Error reading block commit: %v", err))
-	}
 	return commit
 }
 
+// LoadBlockCommitE is LoadBlockCommit returning an error instead of
+// panicking, an ErrCorruptedEntry if the commit can't be decoded.
+func (bs *BlockStore) LoadBlockCommitE(height int64) (*types.Commit, error) {
+	defer bs.observeLoad("block_commit", time.Now())
+	return bs.loadCommit(calcBlockCommitKey(height), "block commit")
+}
+
 // LoadSeenCommit returns the locally seen Commit for the given height.
 // This is useful when we've seen a commit, but there has not yet been
 // a new block at `height + 1` that includes this commit in its block.LastCommit.
+// Panics if the commit can't be loaded, see LoadSeenCommitE.
 func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
-	var pbc = new(cmtproto.Commit)
-	bz, err := bs.db.Get(calcSeenCommitKey(height))
+	commit, err := bs.LoadSeenCommitE(height)
 	if err != nil {
 		panic(err)
 	}
-	if len(bz) == 0 {
-		return nil
-	}
-	err = proto.Unmarshal(bz, pbc)
-	if err != nil {
-		panic(fmt.Sprintf("error reading block seen commit: %v", err))
-	}
+	return commit
+}
+
+// LoadSeenCommitE is LoadSeenCommit returning an error instead of panicking,
+// an ErrCorruptedEntry if the commit can't be decoded.
+func (bs *BlockStore) LoadSeenCommitE(height int64) (*types.Commit, error) {
+	defer bs.observeLoad("seen_commit", time.Now())
+	return bs.loadCommit(calcSeenCommitKey(height), "block seen commit")
+}
 
-	commit, err := types.CommitFromProto(pbc)
+// observeLoad records the time taken by the load method started at start.
+func (bs *BlockStore) observeLoad(method string, start time.Time) {
+	bs.metrics.LoadTime.With("method", method).Observe(time.Since(start).Seconds())
+}
+
+func (bs *BlockStore) loadCommit(key []byte, name string) (*types.Commit, error) {
+	bz, err := bs.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	commit, err := unmarshalCommit(bz)
 	if err != nil {
-		panic(fmt.Errorf("error from proto commit: %w", err))
+		return nil, ErrCorruptedEntry{Key: string(key), Err: fmt.Errorf("error reading %s: %w", name, err)}
 	}
-	return commit
+	return commit, nil
 }
 
 // PruneBlocks removes block up to (but not including) a height. It returns number of blocks pruned.
+// With PruningModeBodies, only the parts, the seen commits and the results of the blocks are removed.
 func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	if height <= 0 {
 		return 0, fmt.Errorf("height must be greater than 0")
@@ -261,6 +468,7 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 			height, base)
 	}
 
+	start := time.Now()
 	pruned := uint64(0)
 	batch := bs.db.NewBatch()
 	defer batch.Close()
@@ -285,16 +493,10 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		if meta == nil { // assume already deleted
 			continue
 		}
-		if err := batch.Delete(calcBlockMetaKey(h)); err != nil {
-			return 0, err
-		}
-		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
-			return 0, err
-		}
-		if err := batch.Delete(calcBlockCommitKey(h)); err != nil {
+		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
 			return 0, err
 		}
-		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
+		if err := batch.Delete(calcBlockResultsKey(h)); err != nil {
 			return 0, err
 		}
 		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
@@ -302,6 +504,12 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 				return 0, err
 			}
 		}
+		// the meta and commit of the block are kept when pruning the bodies
+		if bs.pruningMode == PruningModeBlocks {
+			if err := bs.pruneBlockHeader(batch, h, meta); err != nil {
+				return 0, err
+			}
+		}
 		pruned++
 
 		// flush every 1000 blocks to avoid batches becoming too large
@@ -319,9 +527,36 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
+	bs.uncacheHeights(func(h int64) bool { return h < height })
+
+	bs.metrics.BaseHeight.Set(float64(height))
+	bs.metrics.PrunedBlocks.Add(float64(pruned))
+	bs.metrics.PruneBlocksTime.Observe(time.Since(start).Seconds())
+	bs.updateDBSize()
 	return pruned, nil
 }
 
+// pruneBlockHeader deletes the meta, the commit, and the other records kept
+// with the header of the block at height.
+func (bs *BlockStore) pruneBlockHeader(batch dbm.Batch, height int64, meta *types.BlockMeta) error {
+	if err := batch.Delete(calcBlockMetaKey(height)); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcBlockCommitKey(height)); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcDAPointerKey(height)); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcBlockTimeKey(meta.Header.Time, height)); err != nil {
+		return err
+	}
+	return batch.Delete(calcBlockStatsKey(height))
+}
+
 // SaveBlock persists the given block, blockParts, and seenCommit to the underlying db.
 // blockParts: Must be parts of the block
 // seenCommit: The +2/3 precommits that were seen which committed at height.
@@ -333,6 +568,7 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	if block == nil {
 		panic("BlockStore can only save a non-nil block")
 	}
+	start := time.Now()
 
 	height := block.Height
 	hash := block.Hash()
@@ -344,13 +580,15 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic("BlockStore can only save complete block part sets")
 	}
 
-	// Save block parts. This must be done before the block meta, since callers
-	// typically load the block meta first as an indication that the block exists
-	// and then go on to load block parts - we must make sure the block is
-	// complete as soon as the block meta is written.
+	// The block is saved with a single atomic batch, along with the new
+	// BlockStoreState, so that a crash can't leave it partially saved.
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	// Save block parts.
 	for i := 0; i < int(blockParts.Total()); i++ {
 		part := blockParts.GetPart(i)
-		bs.saveBlockPart(height, i, part)
+		bs.saveBlockPart(batch, height, i, part)
 	}
 
 	// Save block meta
@@ -359,18 +597,24 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	if pbm == nil {
 		panic("nil blockmeta")
 	}
-	metaBytes := mustEncode(pbm)
-	if err := bs.db.Set(calcBlockMetaKey(height), metaBytes); err != nil {
+	metaBytes := bs.encodeValue(mustEncode(pbm))
+	if err := batch.Set(calcBlockMetaKey(height), metaBytes); err != nil {
+		panic(err)
+	}
+	if err := batch.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+		panic(err)
+	}
+	if err := batch.Set(calcBlockStatsKey(height), newBlockStats(block).encode()); err != nil {
 		panic(err)
 	}
-	if err := bs.db.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+	if err := setBlockTime(batch, block.Time, height); err != nil {
 		panic(err)
 	}
 
 	// Save block commit (duplicate and separate from the Block)
 	pbc := block.LastCommit.ToProto()
 	blockCommitBytes := mustEncode(pbc)
-	if err := bs.db.Set(calcBlockCommitKey(height-1), blockCommitBytes); err != nil {
+	if err := batch.Set(calcBlockCommitKey(height-1), blockCommitBytes); err != nil {
 		panic(err)
 	}
 
@@ -378,40 +622,67 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	// NOTE: we can delete this at a later height
 	pbsc := seenCommit.ToProto()
 	seenCommitBytes := mustEncode(pbsc)
-	if err := bs.db.Set(calcSeenCommitKey(height), seenCommitBytes); err != nil {
+	if err := batch.Set(calcSeenCommitKey(height), seenCommitBytes); err != nil {
 		panic(err)
 	}
 
-	// Done!
+	base := bs.writeBlockBatch(batch, height)
+
+	bs.metrics.BaseHeight.Set(float64(base))
+	bs.metrics.BlockSize.Observe(float64(blockParts.ByteSize()))
+	bs.metrics.SaveBlockTime.Observe(time.Since(start).Seconds())
+	if height%dbSizeInterval == 0 {
+		bs.updateDBSize()
+	}
+}
+
+// writeBlockBatch saves the new BlockStoreState descriptor, with the latest
+// block at height, with the batch of the block, flushes the database, and
+// returns the base. The lock is held until the batch is written, so that a
+// concurrent save of the state, e.g. by the Pruner, can't persist a stale one.
+func (bs *BlockStore) writeBlockBatch(batch dbm.Batch, height int64) int64 {
 	bs.mtx.Lock()
-	bs.height = height
-	if bs.base == 0 {
-		bs.base = height
+	defer bs.mtx.Unlock()
+	bss := cmtstore.BlockStoreState{Base: bs.base, Height: height}
+	if bss.Base == 0 {
+		bss.Base = height
+	}
+	bssBytes, err := proto.Marshal(&bss)
+	if err != nil {
+		panic(fmt.Sprintf("Could not marshal state bytes: %v", err))
+	}
+	if err := batch.Set(blockStoreKey, bssBytes); err != nil {
+		panic(err)
+	}
+	if err := batch.WriteSync(); err != nil {
+		panic(err)
 	}
-	bs.mtx.Unlock()
 
-	// Save new BlockStoreState descriptor. This also flushes the database.
-	bs.saveState()
+	// Done!
+	bs.base, bs.height = bss.Base, bss.Height
+	return bs.base
 }
 
-func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
+func (bs *BlockStore) saveBlockPart(batch dbm.Batch, height int64, index int, part *types.Part) {
 	pbp, err := part.ToProto()
 	if err != nil {
 		panic(fmt.Errorf("unable to make part into proto: %w", err))
 	}
-	partBytes := mustEncode(pbp)
-	if err := bs.db.Set(calcBlockPartKey(height, index), partBytes); err != nil {
+	partBytes := bs.encodeValue(mustEncode(pbp))
+	if err := batch.Set(calcBlockPartKey(height, index), partBytes); err != nil {
 		panic(err)
 	}
 }
 
+// saveState persists the base and height. The lock is held while saving them,
+// so that a concurrent save, e.g. by the Pruner, can't persist stale ones.
 func (bs *BlockStore) saveState() {
-	bs.mtx.RLock()
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
 	bss := cmtstore.BlockStoreState{
 		Base:   bs.base,
 		Height: bs.height,
 	}
-	bs.mtx.RUnlock()
 	SaveBlockStoreState(&bss, bs.db)
 }
 
@@ -425,6 +696,225 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 	return bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
 }
 
+// DeleteLatestBlock deletes the latest block, e.g. when it's corrupted, and
+// its seen commit. The parts of the block are deleted even if its meta can't
+// be decoded. The state must be rolled back below the block first.
+func (bs *BlockStore) DeleteLatestBlock() error {
+	height := bs.Height()
+	if height == 0 {
+		return fmt.Errorf("the block store is empty")
+	}
+	return bs.DeleteBlocksFrom(height)
+}
+
+// DeleteBlocksFrom deletes the blocks from height, between the base and the
+// height of the store, to the latest one, e.g. to recover from a fork, along
+// with their commits, stats and DA pointers, with a single atomic batch. The
+// store is emptied if height is its base. The parts of the blocks are deleted
+// even if their meta can't be decoded. The state must be rolled back below
+// height first.
+func (bs *BlockStore) DeleteBlocksFrom(height int64) error {
+	if err := bs.deleteBlocksFrom(height); err != nil {
+		return err
+	}
+	bs.uncacheHeights(func(h int64) bool { return h >= height })
+	bs.metrics.BaseHeight.Set(float64(bs.Base()))
+	return nil
+}
+
+func (bs *BlockStore) deleteBlocksFrom(height int64) error {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if bs.height == 0 {
+		return fmt.Errorf("the block store is empty")
+	}
+	if height < bs.base || height > bs.height {
+		return fmt.Errorf("cannot delete the blocks from height %v, not between the base %v and the height %v",
+			height, bs.base, bs.height)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	for h := height; h <= bs.height; h++ {
+		if err := bs.deleteBlock(batch, h); err != nil {
+			return fmt.Errorf("failed to delete block %v: %w", h, err)
+		}
+	}
+	daHeight, err := bs.DAHeight()
+	if err != nil {
+		return err
+	}
+	if daHeight >= height {
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, uint64(height-1))
+		if err := batch.Set(daHeightKey, bz); err != nil {
+			return err
+		}
+	}
+	// the state is written along with the deletions, holding the lock, like
+	// in SaveBlock
+	bss := cmtstore.BlockStoreState{Base: bs.base, Height: height - 1}
+	if height == bs.base {
+		bss = cmtstore.BlockStoreState{}
+	}
+	bssBytes, err := proto.Marshal(&bss)
+	if err != nil {
+		return err
+	}
+	if err := batch.Set(blockStoreKey, bssBytes); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return fmt.Errorf("failed to delete the blocks from height %v: %w", height, err)
+	}
+	bs.base, bs.height = bss.Base, bss.Height
+	return nil
+}
+
+// deleteBlock adds the deletion of the block at height, its commits, stats
+// and DA pointer to batch. The canonical commit of the previous block,
+// included in the block, is kept.
+func (bs *BlockStore) deleteBlock(batch dbm.Batch, height int64) error {
+	bz, err := bs.db.Get(calcBlockMetaKey(height))
+	if err != nil {
+		return err
+	}
+	pbbm := new(cmtproto.BlockMeta)
+	if bz, err := decodeValue(bz); err == nil && len(bz) > 0 && proto.Unmarshal(bz, pbbm) == nil {
+		if err := batch.Delete(calcBlockHashKey(pbbm.BlockID.Hash)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcBlockTimeKey(pbbm.Header.Time, height)); err != nil {
+			return err
+		}
+	}
+	// the parts are found by their key, since the meta may be corrupted
+	prefix := []byte(fmt.Sprintf("P:%v:", height))
+	it, err := dbm.IteratePrefix(bs.db, prefix)
+	if err != nil {
+		return err
+	}
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			it.Close()
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	it.Close()
+	for _, key := range [][]byte{
+		calcBlockCommitKey(height),
+		calcSeenCommitKey(height),
+		calcBlockStatsKey(height),
+		calcBlockResultsKey(height),
+		calcDAPointerKey(height),
+		// the meta is deleted last, as the blocks are looked up by their meta
+		calcBlockMetaKey(height),
+	} {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResetSeenCommit replaces the seen commit of a height, e.g. when it's
+// corrupted, with the canonical commit of the block, included in the next
+// block.
+func (bs *BlockStore) ResetSeenCommit(height int64) error {
+	commit := bs.LoadBlockCommit(height)
+	if commit == nil {
+		return fmt.Errorf("no canonical commit for height %v", height)
+	}
+	return bs.SaveSeenCommit(height, commit)
+}
+
+// SaveDAPointer saves the pointer to the data availability layer of the blocks
+// of its batch, and records them as submitted.
+func (bs *BlockStore) SaveDAPointer(ptr *types.DAPointer) error {
+	if err := ptr.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid DA pointer: %w", err)
+	}
+	bz, err := cmtjson.Marshal(ptr)
+	if err != nil {
+		return fmt.Errorf("unable to marshal DA pointer: %w", err)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	for h := ptr.FirstHeight; h <= ptr.LastHeight; h++ {
+		if err := batch.Set(calcDAPointerKey(h), bz); err != nil {
+			return err
+		}
+	}
+	daHeight, err := bs.DAHeight()
+	if err != nil {
+		return err
+	}
+	if ptr.LastHeight > daHeight {
+		hbz := make([]byte, 8)
+		binary.BigEndian.PutUint64(hbz, uint64(ptr.LastHeight))
+		if err := batch.Set(daHeightKey, hbz); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+// LoadDAPointer returns the pointer to the data availability layer of the
+// block at height, or nil if the block wasn't submitted to it.
+func (bs *BlockStore) LoadDAPointer(height int64) *types.DAPointer {
+	bz, err := bs.db.Get(calcDAPointerKey(height))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return nil
+	}
+	ptr := new(types.DAPointer)
+	if err := cmtjson.Unmarshal(bz, ptr); err != nil {
+		panic(fmt.Errorf("error reading DA pointer: %w", err))
+	}
+	return ptr
+}
+
+// DAHeight returns the height of the latest block submitted to the data
+// availability layer, or 0 if none was.
+func (bs *BlockStore) DAHeight() (int64, error) {
+	bz, err := bs.db.Get(daHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid DA height of %d bytes", len(bz))
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// dbSizeInterval is the number of blocks saved between the updates of the
+// estimate of the size of the DB.
+const dbSizeInterval = 100
+
+// updateDBSize updates the estimate of the size of the DB, only known with
+// goleveldb.
+func (bs *BlockStore) updateDBSize() {
+	db, ok := bs.db.(*dbm.GoLevelDB)
+	if !ok {
+		return
+	}
+	sizes, err := db.DB().SizeOf([]util.Range{{}})
+	if err != nil {
+		return
+	}
+	bs.metrics.DBSize.Set(float64(sizes.Sum()))
+}
+
 func (bs *BlockStore) Close() error {
 	return bs.db.Close()
 }
@@ -451,6 +941,22 @@ func calcBlockHashKey(hash []byte) []byte {
 	return []byte(fmt.Sprintf("BH:%x", hash))
 }
 
+func calcDAPointerKey(height int64) []byte {
+	return []byte(fmt.Sprintf("DA:%v", height))
+}
+
+func calcBlockStatsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("ST:%v", height))
+}
+
+func calcBlockResultsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("BR:%v", height))
+}
+
+var daHeightKey = []byte("daHeight")
+
+var pruneRetainHeightKey = []byte("pruneRetainHeight")
+
 //-----------------------------------------------------------------------------
 
 var blockStoreKey = []byte("blockStore")
@@ -493,6 +999,36 @@ func LoadBlockStoreState(db dbm.DB) cmtstore.BlockStoreState {
 	return bsj
 }
 
+// RebuildBlockStoreState returns the BlockStoreState of the blocks found in
+// db, e.g. when the persisted one is corrupted, and the number of blocks
+// found, which is less than the number of heights between the base and the
+// height if some blocks are missing.
+func RebuildBlockStoreState(db dbm.DB) (cmtstore.BlockStoreState, int64, error) {
+	var (
+		bss   cmtstore.BlockStoreState
+		count int64
+	)
+	it, err := dbm.IteratePrefix(db, []byte("H:"))
+	if err != nil {
+		return bss, 0, err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		height, err := strconv.ParseInt(string(it.Key()[2:]), 10, 64)
+		if err != nil {
+			return bss, 0, fmt.Errorf("invalid block meta key %q: %w", it.Key(), err)
+		}
+		if bss.Base == 0 || height < bss.Base {
+			bss.Base = height
+		}
+		if height > bss.Height {
+			bss.Height = height
+		}
+		count++
+	}
+	return bss, count, it.Error()
+}
+
 // mustEncode proto encodes a proto.message and panics if fails
 func mustEncode(pb proto.Message) []byte {
 	bz, err := proto.Marshal(pb)
@@ -337,7 +337,7 @@ func TestBlockStoreSaveLoadBlock(t *testing.T) {
 		if subStr := tuple.wantPanic; subStr != "" {
 			if panicErr == nil {
 				t.Errorf("#%d: want a non-nil panic", i)
-			} else if got := fmt.Sprintf("%#v", panicErr); !strings.Contains(got, subStr) {
+			} else if got := fmt.Sprintf("%v", panicErr); !strings.Contains(got, subStr) {
 				t.Errorf("#%d:\n\tgotErr: %q\nwant substring: %q", i, got, subStr)
 			}
 			continue
@@ -460,6 +460,9 @@ func TestPruneBlocks(t *testing.T) {
 	assert.EqualValues(t, 1500, bs.Size())
 
 	prunedBlock := bs.LoadBlock(1199)
+	require.NoError(t, bs.SaveDAPointer(&types.DAPointer{
+		Layer: "celestia", Height: 1, Commitment: []byte{1}, FirstHeight: 1199, LastHeight: 1200,
+	}))
 
 	// Check that basic pruning works
 	pruned, err := bs.PruneBlocks(1200)
@@ -479,6 +482,8 @@ func TestPruneBlocks(t *testing.T) {
 	require.Nil(t, bs.LoadBlockCommit(1199))
 	require.Nil(t, bs.LoadBlockMeta(1199))
 	require.Nil(t, bs.LoadBlockPart(1199, 1))
+	require.Nil(t, bs.LoadDAPointer(1199))
+	require.NotNil(t, bs.LoadDAPointer(1200))
 
 	for i := int64(1); i < 1200; i++ {
 		require.Nil(t, bs.LoadBlock(i))
@@ -515,6 +520,134 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestBlockStoreRepair(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+	require.Error(t, bs.DeleteLatestBlock())
+
+	for h := int64(1); h <= 5; h++ {
+		lastCommit := new(types.Commit)
+		if h > 1 {
+			lastCommit = makeTestCommit(h-1, cmttime.Now())
+		}
+		block := makeBlock(h, state, lastCommit)
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, cmttime.Now()))
+	}
+
+	// the seen commit is replaced with the canonical one
+	require.NoError(t, db.Set(calcSeenCommitKey(3), []byte("corrupted")))
+	require.NoError(t, bs.ResetSeenCommit(3))
+	assert.Equal(t, bs.LoadBlockCommit(3).Hash(), bs.LoadSeenCommit(3).Hash())
+	require.Error(t, bs.ResetSeenCommit(5))
+
+	latest := bs.LoadBlock(5)
+	require.NoError(t, bs.DeleteLatestBlock())
+	assert.EqualValues(t, 4, bs.Height())
+	assert.Nil(t, bs.LoadBlockMeta(5))
+	assert.Nil(t, bs.LoadBlockByHash(latest.Hash()))
+	assert.Nil(t, bs.LoadBlockPart(5, 0))
+	assert.Nil(t, bs.LoadSeenCommit(5))
+
+	// the latest block is deleted even if its meta is corrupted
+	require.NoError(t, db.Set(calcBlockMetaKey(4), []byte("corrupted")))
+	require.NoError(t, bs.DeleteLatestBlock())
+	assert.EqualValues(t, 3, bs.Height())
+	assert.Nil(t, bs.LoadBlockMeta(4))
+	assert.Nil(t, bs.LoadBlockPart(4, 0))
+	assert.NotNil(t, bs.LoadBlock(3))
+	assert.Equal(t, cmtstore.BlockStoreState{Base: 1, Height: 3}, LoadBlockStoreState(db))
+
+	// the state is rebuilt from the blocks found
+	SaveBlockStoreState(&cmtstore.BlockStoreState{Base: 1, Height: 100}, db)
+	bss, count, err := RebuildBlockStoreState(db)
+	require.NoError(t, err)
+	assert.Equal(t, cmtstore.BlockStoreState{Base: 1, Height: 3}, bss)
+	assert.EqualValues(t, 3, count)
+}
+
+func TestDeleteBlocksFrom(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 10, WithCache(1<<20))
+	ptr := &types.DAPointer{Layer: "celestia", Height: 7, Commitment: []byte{1}, FirstHeight: 5, LastHeight: 8}
+	require.NoError(t, bs.SaveDAPointer(ptr))
+	forked := bs.LoadBlock(6)
+	require.NotNil(t, forked)
+	require.NotNil(t, bs.LoadBlockPart(7, 0))
+
+	require.Error(t, bs.DeleteBlocksFrom(0))
+	require.Error(t, bs.DeleteBlocksFrom(11))
+
+	require.NoError(t, bs.DeleteBlocksFrom(6))
+	assert.EqualValues(t, 1, bs.Base())
+	assert.EqualValues(t, 5, bs.Height())
+	assert.Equal(t, cmtstore.BlockStoreState{Base: 1, Height: 5}, LoadBlockStoreState(db))
+	for h := int64(6); h <= 10; h++ {
+		assert.Nil(t, bs.LoadBlockMeta(h), h)
+		assert.Nil(t, bs.LoadBlock(h), h)
+		assert.Nil(t, bs.LoadBlockPart(h, 0), h)
+		assert.Nil(t, bs.LoadBlockCommit(h), h)
+		assert.Nil(t, bs.LoadSeenCommit(h), h)
+		assert.Nil(t, bs.LoadDAPointer(h), h)
+		stats, err := bs.LoadBlockStats(h)
+		require.NoError(t, err)
+		assert.Nil(t, stats, h)
+	}
+	assert.Nil(t, bs.LoadBlockByHash(forked.Hash()))
+	daHeight, err := bs.DAHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, daHeight)
+
+	// the blocks below are kept, with the commit of the latest one
+	assert.NotNil(t, bs.LoadBlock(5))
+	assert.NotNil(t, bs.LoadBlockCommit(5))
+	assert.NotNil(t, bs.LoadSeenCommit(5))
+	assert.Equal(t, ptr, bs.LoadDAPointer(5))
+	problems, err := bs.Verify(1, 5)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// the blocks are deleted down to the base
+	require.NoError(t, bs.DeleteBlocksFrom(1))
+	assert.EqualValues(t, 0, bs.Base())
+	assert.EqualValues(t, 0, bs.Height())
+	assert.Nil(t, bs.LoadBlock(1))
+	require.Error(t, bs.DeleteBlocksFrom(1))
+}
+
+func TestBlockStoreDAPointer(t *testing.T) {
+	bs, _ := freshBlockStore()
+	daHeight, err := bs.DAHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, daHeight)
+	assert.Nil(t, bs.LoadDAPointer(1))
+
+	require.Error(t, bs.SaveDAPointer(&types.DAPointer{Layer: "celestia", Height: 7, FirstHeight: 1, LastHeight: 3}))
+
+	ptr := &types.DAPointer{Layer: "celestia", Height: 7, Commitment: []byte{1, 2}, FirstHeight: 1, LastHeight: 3}
+	require.NoError(t, bs.SaveDAPointer(ptr))
+	for h := int64(1); h <= 3; h++ {
+		assert.Equal(t, ptr, bs.LoadDAPointer(h))
+	}
+	assert.Nil(t, bs.LoadDAPointer(4))
+	daHeight, err = bs.DAHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, daHeight)
+
+	// resubmitting earlier blocks doesn't lower the DA height
+	ptr = &types.DAPointer{Layer: "celestia", Height: 9, Commitment: []byte{3}, FirstHeight: 2, LastHeight: 2}
+	require.NoError(t, bs.SaveDAPointer(ptr))
+	assert.Equal(t, ptr, bs.LoadDAPointer(2))
+	daHeight, err = bs.DAHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, daHeight)
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
@@ -582,6 +715,28 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	require.Nil(t, blockAtHeightPlus2, "expecting an unsuccessful load of Height()+2")
 }
 
+func TestLoadBlockByHash(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 3)
+
+	for h := int64(1); h <= 3; h++ {
+		block := bs.LoadBlock(h)
+		byHash := bs.LoadBlockByHash(block.Hash())
+		require.NotNil(t, byHash)
+		assert.Equal(t, h, byHash.Height)
+		assert.Equal(t, block.Hash(), byHash.Hash())
+	}
+	assert.Nil(t, bs.LoadBlockByHash([]byte("unknown")))
+
+	// the hash index is kept with the compressed blocks
+	bs, _ = makeBlockStoreWithBlocks(t, 2, WithCompression(CompressionSnappy))
+	block := bs.LoadBlock(2)
+	assert.Equal(t, block.Hash(), bs.LoadBlockByHash(block.Hash()).Hash())
+
+	// a corrupted index entry panics
+	require.NoError(t, db.Set(calcBlockHashKey([]byte("corrupted")), []byte("height")))
+	assert.Panics(t, func() { NewBlockStore(db).LoadBlockByHash([]byte("corrupted")) })
+}
+
 func doFn(fn func() (interface{}, error)) (res interface{}, err error, panicErr error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -610,3 +765,176 @@ func newBlock(hdr types.Header, lastCommit *types.Commit) *types.Block {
 		LastCommit: lastCommit,
 	}
 }
+
+func BenchmarkSaveBlock(b *testing.B) {
+	for _, txs := range []int{16, 1024} {
+		// blocks of 16 KB, of a single part, and of 1 MB, of 16 parts
+		b.Run(fmt.Sprintf("txs=%d", txs), func(b *testing.B) {
+			benchmarkSaveBlock(b, txs)
+		})
+	}
+}
+
+func benchmarkSaveBlock(b *testing.B, numTxs int) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(b, err)
+	db, err := dbm.NewGoLevelDB("blockstore", b.TempDir())
+	require.NoError(b, err)
+	defer db.Close()
+	bs := NewBlockStore(db)
+
+	txs := make([]types.Tx, numTxs)
+	for i := range txs {
+		txs[i] = cmtrand.Bytes(1024)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		height := int64(i + 1)
+		lastCommit := new(types.Commit)
+		if height > 1 {
+			lastCommit = makeTestCommit(height-1, cmttime.Now())
+		}
+		block, _ := state.MakeBlock(height, txs, lastCommit, nil, state.Validators.GetProposer().Address)
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		seenCommit := makeTestCommit(height, cmttime.Now())
+		b.StartTimer()
+
+		bs.SaveBlock(block, partSet, seenCommit)
+	}
+}
+
+func TestLoadCorruptedEntries(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 3)
+	meta := bs.LoadBlockMeta(2)
+	require.NotNil(t, meta)
+
+	testCases := []struct {
+		key   []byte
+		load  func() error
+		panic func()
+	}{
+		{
+			calcBlockMetaKey(2),
+			func() error { _, err := bs.LoadBlockMetaE(2); return err },
+			func() { bs.LoadBlockMeta(2) },
+		},
+		{
+			calcBlockPartKey(2, 0),
+			func() error { _, err := bs.LoadBlockPartE(2, 0); return err },
+			func() { bs.LoadBlockPart(2, 0) },
+		},
+		{
+			calcBlockPartKey(2, 0),
+			func() error { _, err := bs.LoadBlockE(2); return err },
+			func() { bs.LoadBlock(2) },
+		},
+		{
+			calcBlockHashKey(meta.BlockID.Hash),
+			func() error { _, err := bs.LoadBlockByHashE(meta.BlockID.Hash); return err },
+			func() { bs.LoadBlockByHash(meta.BlockID.Hash) },
+		},
+		{
+			calcBlockCommitKey(2),
+			func() error { _, err := bs.LoadBlockCommitE(2); return err },
+			func() { bs.LoadBlockCommit(2) },
+		},
+		{
+			calcSeenCommitKey(2),
+			func() error { _, err := bs.LoadSeenCommitE(2); return err },
+			func() { bs.LoadSeenCommit(2) },
+		},
+	}
+	for _, tc := range testCases {
+		bz, err := db.Get(tc.key)
+		require.NoError(t, err)
+		require.NoError(t, tc.load(), string(tc.key))
+
+		require.NoError(t, db.Set(tc.key, []byte("bogus")))
+		var corrupted ErrCorruptedEntry
+		err = tc.load()
+		if assert.ErrorAs(t, err, &corrupted, string(tc.key)) {
+			assert.Equal(t, string(tc.key), corrupted.Key)
+		}
+		assert.Panics(t, tc.panic, string(tc.key))
+
+		require.NoError(t, db.Set(tc.key, bz))
+	}
+
+	// the block made of parts which don't decode as a block is corrupted
+	partSet := types.NewPartSetFromData([]byte("bogus block"), 2)
+	for i := 0; i < int(partSet.Total()); i++ {
+		pbp, err := partSet.GetPart(i).ToProto()
+		require.NoError(t, err)
+		require.NoError(t, db.Set(calcBlockPartKey(2, i), mustEncode(pbp)))
+	}
+	meta.BlockID.PartSetHeader = partSet.Header()
+	require.NoError(t, db.Set(calcBlockMetaKey(2), mustEncode(meta.ToProto())))
+	_, err := bs.LoadBlockE(2)
+	var corrupted ErrCorruptedEntry
+	require.ErrorAs(t, err, &corrupted)
+	assert.Equal(t, "P:2:*", corrupted.Key)
+}
+
+func TestLoadBlockMissingOrCorruptedParts(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 3)
+	meta := bs.LoadBlockMeta(2)
+	require.NotNil(t, meta)
+	total := int(meta.BlockID.PartSetHeader.Total)
+	require.Greater(t, total, 4)
+
+	// the first part which can't be read determines the result, whatever the
+	// order in which the parts are read
+	require.NoError(t, db.Set(calcBlockPartKey(2, 3), []byte("bogus")))
+	require.NoError(t, db.Delete(calcBlockPartKey(2, total-1)))
+	_, err := bs.LoadBlockE(2)
+	var corrupted ErrCorruptedEntry
+	require.ErrorAs(t, err, &corrupted)
+	assert.Equal(t, string(calcBlockPartKey(2, 3)), corrupted.Key)
+
+	require.NoError(t, db.Delete(calcBlockPartKey(2, 1)))
+	block, err := bs.LoadBlockE(2)
+	require.NoError(t, err)
+	assert.Nil(t, block)
+}
+
+func BenchmarkLoadBlock(b *testing.B) {
+	for _, txs := range []int{16, 1024, 16384} {
+		// blocks of 16 KB, 1 MB and 16 MB, of 1, 16 and 256 parts
+		b.Run(fmt.Sprintf("txs=%d", txs), func(b *testing.B) {
+			benchmarkLoadBlock(b, txs)
+		})
+	}
+}
+
+func benchmarkLoadBlock(b *testing.B, numTxs int) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(b, err)
+	db, err := dbm.NewGoLevelDB("blockstore", b.TempDir())
+	require.NoError(b, err)
+	defer db.Close()
+	bs := NewBlockStore(db)
+
+	txs := make([]types.Tx, numTxs)
+	for i := range txs {
+		txs[i] = cmtrand.Bytes(1024)
+	}
+	block, _ := state.MakeBlock(1, txs, new(types.Commit), nil, state.Validators.GetProposer().Address)
+	bs.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), makeTestCommit(1, cmttime.Now()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if bs.LoadBlock(1) == nil {
+			b.Fatal("no block")
+		}
+	}
+}
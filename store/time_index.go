@@ -0,0 +1,120 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// blockTimePrefix prefixes the keys of the index of the blocks by time, made
+// of the time of a block and its height, big-endian, so that they're ordered
+// by time, and then by height. The values hold the height too, since some DBs
+// don't tell the empty values from the missing ones.
+const blockTimePrefix = "BT:"
+
+// ErrBlockTimesNotIndexed is returned by HeightByTime if the times of the
+// blocks of the store, saved before they were indexed, aren't indexed yet.
+var ErrBlockTimesNotIndexed = errors.New("the block times aren't indexed, see IndexBlockTimes")
+
+func calcBlockTimeKey(t time.Time, height int64) []byte {
+	key := make([]byte, len(blockTimePrefix)+16)
+	copy(key, blockTimePrefix)
+	// the sign bit is flipped for the times before 1970 to sort first
+	binary.BigEndian.PutUint64(key[len(blockTimePrefix):], uint64(t.UnixNano())^(1<<63))
+	binary.BigEndian.PutUint64(key[len(blockTimePrefix)+8:], uint64(height))
+	return key
+}
+
+// setBlockTime adds the time of the block at height to the index with batch.
+func setBlockTime(batch dbm.Batch, t time.Time, height int64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return batch.Set(calcBlockTimeKey(t, height), bz)
+}
+
+// HeightByTime returns the height of the latest block whose time is at or
+// before t, which is the block at that time, or 0 if all the blocks are after
+// t. The heights below the base are returned only if their metas are kept,
+// see PruningModeBodies. It returns ErrBlockTimesNotIndexed if the store
+// holds blocks saved before the times were indexed, until IndexBlockTimes.
+func (bs *BlockStore) HeightByTime(t time.Time) (int64, error) {
+	base, height := bs.Base(), bs.Height()
+	if height == 0 {
+		return 0, nil
+	}
+	meta, err := bs.LoadBlockMetaE(base)
+	if err != nil {
+		return 0, err
+	}
+	if meta != nil {
+		indexed, err := bs.db.Has(calcBlockTimeKey(meta.Header.Time, base))
+		if err != nil {
+			return 0, err
+		}
+		if !indexed {
+			return 0, ErrBlockTimesNotIndexed
+		}
+	}
+
+	it, err := bs.db.ReverseIterator([]byte(blockTimePrefix), calcBlockTimeKey(t, math.MaxInt64))
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(key) != len(blockTimePrefix)+16 {
+			return 0, ErrCorruptedEntry{Key: string(key), Err: errors.New("invalid key length")}
+		}
+		// the blocks deleted with a corrupted meta may still be indexed
+		if h := int64(binary.BigEndian.Uint64(key[len(blockTimePrefix)+8:])); h <= height {
+			return h, nil
+		}
+	}
+	return 0, it.Error()
+}
+
+// IndexBlockTimes indexes the times of all the blocks of the store, including
+// those saved before the times were indexed, for HeightByTime, and returns the
+// number of blocks indexed.
+func (bs *BlockStore) IndexBlockTimes() (int64, error) {
+	height := bs.Height()
+	if height == 0 {
+		return 0, nil
+	}
+	it, err := bs.Iterator(1, height)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	indexed := int64(0)
+	for it.Next() {
+		if err := setBlockTime(batch, it.BlockMeta().Header.Time, it.Height()); err != nil {
+			return 0, err
+		}
+		indexed++
+		// flush every 1000 blocks to avoid batches becoming too large
+		if indexed%1000 == 0 {
+			if err := batch.WriteSync(); err != nil {
+				return 0, err
+			}
+			batch.Close()
+			batch = bs.db.NewBatch()
+			defer batch.Close()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate over the blocks: %w", err)
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return indexed, nil
+}
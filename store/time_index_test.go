@@ -0,0 +1,63 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func heightByTime(t *testing.T, bs *BlockStore, tm time.Time) int64 {
+	t.Helper()
+	height, err := bs.HeightByTime(tm)
+	require.NoError(t, err)
+	return height
+}
+
+func TestHeightByTime(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 10)
+	blockTime := func(height int64) time.Time { return bs.LoadBlockMeta(height).Header.Time }
+
+	for h := int64(1); h <= 10; h++ {
+		assert.EqualValues(t, h, heightByTime(t, bs, blockTime(h)), h)
+		assert.EqualValues(t, h-1, heightByTime(t, bs, blockTime(h).Add(-time.Nanosecond)), h)
+	}
+	assert.EqualValues(t, 10, heightByTime(t, bs, blockTime(10).Add(time.Hour)))
+
+	// the deleted blocks are removed from the index
+	last := blockTime(10)
+	require.NoError(t, bs.DeleteBlocksFrom(8))
+	assert.EqualValues(t, 7, heightByTime(t, bs, last))
+
+	// and so are the pruned ones
+	_, err := bs.PruneBlocks(3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, heightByTime(t, bs, blockTime(3).Add(-time.Nanosecond)))
+	assert.EqualValues(t, 3, heightByTime(t, bs, blockTime(3)))
+
+	// the blocks saved before the times were indexed are indexed on demand
+	it, err := db.Iterator([]byte(blockTimePrefix), []byte("BU"))
+	require.NoError(t, err)
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	require.NoError(t, it.Close())
+	require.Len(t, keys, 5)
+	for _, key := range keys[:2] {
+		require.NoError(t, db.Delete(key))
+	}
+	_, err = bs.HeightByTime(last)
+	assert.Equal(t, ErrBlockTimesNotIndexed, err)
+	indexed, err := bs.IndexBlockTimes()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, indexed)
+	assert.EqualValues(t, 4, heightByTime(t, bs, blockTime(4)))
+
+	// the headers kept below the base are indexed
+	bs, _ = makeBlockStoreWithBlocks(t, 10, WithPruningMode(PruningModeBodies))
+	_, err = bs.PruneBlocks(5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, heightByTime(t, bs, bs.LoadBlockMeta(2).Header.Time))
+}
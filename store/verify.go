@@ -0,0 +1,304 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/gogo/protobuf/proto"
+
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The kinds of problems found by Verify.
+const (
+	ProblemMissingMeta       = "missing_meta"
+	ProblemCorruptMeta       = "corrupt_meta"
+	ProblemMissingPart       = "missing_part"
+	ProblemCorruptPart       = "corrupt_part"
+	ProblemCorruptBlock      = "corrupt_block"
+	ProblemMissingCommit     = "missing_commit"
+	ProblemCorruptCommit     = "corrupt_commit"
+	ProblemMissingSeenCommit = "missing_seen_commit"
+	ProblemCorruptSeenCommit = "corrupt_seen_commit"
+)
+
+// Problem is an inconsistency of the block store at a height.
+type Problem struct {
+	Height int64
+	Kind   string
+	Detail string
+	// Repairable is true if the problem can be repaired with RepairBlockMeta,
+	// the meta being missing or corrupted but the parts complete.
+	Repairable bool
+}
+
+// String implements fmt.Stringer.
+func (p Problem) String() string {
+	s := fmt.Sprintf("height %d: %s", p.Height, p.Kind)
+	if p.Detail != "" {
+		s += ": " + p.Detail
+	}
+	if p.Repairable {
+		s += " (repairable)"
+	}
+	return s
+}
+
+// Verify checks the consistency of the blocks between from and to
+// (inclusive), which must be between the base and the height of the store:
+// each height must have a meta, all the parts of its block, matching the
+// meta, and a canonical commit of the block, or a seen commit at the latest
+// height. It returns the problems found, by height, or an error if the store
+// couldn't be read.
+func (bs *BlockStore) Verify(from, to int64) ([]Problem, error) {
+	base, height := bs.Base(), bs.Height()
+	if base == 0 {
+		return nil, errors.New("the block store is empty")
+	}
+	if from < base || to > height || from > to {
+		return nil, fmt.Errorf("the heights %d to %d must be between the base %d and the height %d",
+			from, to, base, height)
+	}
+
+	var problems []Problem
+	for h := from; h <= to; h++ {
+		found, err := bs.verifyHeight(h, h == height)
+		if err != nil {
+			return problems, err
+		}
+		problems = append(problems, found...)
+	}
+	return problems, nil
+}
+
+func (bs *BlockStore) verifyHeight(height int64, latest bool) ([]Problem, error) {
+	var problems []Problem
+	report := func(kind, detail string) {
+		problems = append(problems, Problem{Height: height, Kind: kind, Detail: detail})
+	}
+
+	meta, err := bs.verifyMeta(height)
+	switch {
+	case err != nil && !errors.Is(err, errCorruptValue):
+		return nil, err
+	case err != nil:
+		report(ProblemCorruptMeta, err.Error())
+	case meta == nil:
+		report(ProblemMissingMeta, "")
+	default:
+		found, err := bs.verifyBlock(height, meta)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, found...)
+	}
+	if len(problems) > 0 {
+		// a missing or corrupted meta can be rebuilt from complete parts
+		if _, err := bs.rebuildBlockMeta(height); err == nil {
+			problems[0].Repairable = true
+		}
+	}
+
+	commitKey, missing, corrupt := calcBlockCommitKey(height), ProblemMissingCommit, ProblemCorruptCommit
+	if latest {
+		commitKey, missing, corrupt = calcSeenCommitKey(height), ProblemMissingSeenCommit, ProblemCorruptSeenCommit
+	}
+	bz, err := bs.db.Get(commitKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		report(missing, "")
+		return problems, nil
+	}
+	commit, err := unmarshalCommit(bz)
+	switch {
+	case err != nil:
+		report(corrupt, err.Error())
+	case commit.Height != height:
+		report(corrupt, fmt.Sprintf("commit of height %d", commit.Height))
+	case meta != nil && !commit.BlockID.Equals(meta.BlockID):
+		report(corrupt, fmt.Sprintf("commit of block %v, not %v", commit.BlockID, meta.BlockID))
+	}
+	return problems, nil
+}
+
+// errCorruptValue is returned when a value can't be decoded.
+var errCorruptValue = errors.New("corrupt value")
+
+func (bs *BlockStore) verifyMeta(height int64) (*types.BlockMeta, error) {
+	bz, err := bs.db.Get(calcBlockMetaKey(height))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	meta, err := unmarshalBlockMeta(bz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCorruptValue, err)
+	}
+	if meta.Header.Height != height {
+		return nil, fmt.Errorf("%w: meta of height %d", errCorruptValue, meta.Header.Height)
+	}
+	return meta, nil
+}
+
+// verifyBlock checks that the parts of the block at height are complete,
+// match the meta, and make up the block of the meta.
+func (bs *BlockStore) verifyBlock(height int64, meta *types.BlockMeta) ([]Problem, error) {
+	var problems []Problem
+	report := func(kind, detail string) {
+		problems = append(problems, Problem{Height: height, Kind: kind, Detail: detail})
+	}
+
+	psh := meta.BlockID.PartSetHeader
+	var buf []byte
+	for i := 0; i < int(psh.Total); i++ {
+		bz, err := bs.db.Get(calcBlockPartKey(height, i))
+		if err != nil {
+			return nil, err
+		}
+		if len(bz) == 0 {
+			report(ProblemMissingPart, fmt.Sprintf("part %d of %d", i, psh.Total))
+			continue
+		}
+		part, err := unmarshalPart(bz)
+		if err == nil {
+			err = part.Proof.Verify(psh.Hash, part.Bytes)
+		}
+		if err != nil {
+			report(ProblemCorruptPart, fmt.Sprintf("part %d of %d: %v", i, psh.Total, err))
+			continue
+		}
+		buf = append(buf, part.Bytes...)
+	}
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	block, err := unmarshalBlock(buf)
+	switch {
+	case err != nil:
+		report(ProblemCorruptBlock, err.Error())
+	case !bytes.Equal(block.Hash(), meta.BlockID.Hash):
+		report(ProblemCorruptBlock, fmt.Sprintf("block hash %X, not %v", block.Hash(), meta.BlockID.Hash))
+	}
+	return problems, nil
+}
+
+// RepairBlockMeta rebuilds the meta of the block at height, e.g. when it's
+// missing or corrupted, from its parts, which must be complete, and saves it.
+func (bs *BlockStore) RepairBlockMeta(height int64) error {
+	meta, err := bs.rebuildBlockMeta(height)
+	if err != nil {
+		return err
+	}
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(calcBlockMetaKey(height), bs.encodeValue(mustEncode(meta.ToProto()))); err != nil {
+		return err
+	}
+	if err := batch.Set(calcBlockHashKey(meta.BlockID.Hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+// rebuildBlockMeta returns the meta of the block at height rebuilt from its
+// parts, found by their key, since the meta may be corrupted.
+func (bs *BlockStore) rebuildBlockMeta(height int64) (*types.BlockMeta, error) {
+	it, err := dbm.IteratePrefix(bs.db, []byte(fmt.Sprintf("P:%v:", height)))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var parts []*types.Part
+	for ; it.Valid(); it.Next() {
+		part, err := unmarshalPart(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("part %s: %w", it.Key(), err)
+		}
+		parts = append(parts, part)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("no parts of block %d", height)
+	}
+	// the part keys aren't ordered by index
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Index < parts[j].Index })
+
+	psh := types.PartSetHeader{
+		Total: uint32(parts[0].Proof.Total),
+		Hash:  parts[0].Proof.ComputeRootHash(),
+	}
+	partSet := types.NewPartSetFromHeader(psh)
+	for _, part := range parts {
+		if _, err := partSet.AddPart(part); err != nil {
+			return nil, fmt.Errorf("part %d: %w", part.Index, err)
+		}
+	}
+	if !partSet.IsComplete() {
+		return nil, fmt.Errorf("%d parts of block %d out of %d", len(parts), height, psh.Total)
+	}
+
+	var buf []byte
+	for _, part := range parts {
+		buf = append(buf, part.Bytes...)
+	}
+	block, err := unmarshalBlock(buf)
+	if err != nil {
+		return nil, err
+	}
+	if block.Height != height {
+		return nil, fmt.Errorf("the parts of height %d make up block %d", height, block.Height)
+	}
+	return types.NewBlockMeta(block, partSet), nil
+}
+
+func unmarshalBlockMeta(bz []byte) (*types.BlockMeta, error) {
+	bz, err := decodeValue(bz)
+	if err != nil {
+		return nil, err
+	}
+	pbbm := new(cmtproto.BlockMeta)
+	if err := proto.Unmarshal(bz, pbbm); err != nil {
+		return nil, fmt.Errorf("unmarshal to cmtproto.BlockMeta: %w", err)
+	}
+	blockMeta, err := types.BlockMetaFromProto(pbbm)
+	if err != nil {
+		return nil, fmt.Errorf("error from proto blockMeta: %w", err)
+	}
+	return blockMeta, nil
+}
+
+func unmarshalPart(bz []byte) (*types.Part, error) {
+	bz, err := decodeValue(bz)
+	if err != nil {
+		return nil, err
+	}
+	pbpart := new(cmtproto.Part)
+	if err := proto.Unmarshal(bz, pbpart); err != nil {
+		return nil, fmt.Errorf("unmarshal to cmtproto.Part failed: %w", err)
+	}
+	return types.PartFromProto(pbpart)
+}
+
+func unmarshalBlock(bz []byte) (*types.Block, error) {
+	pbb := new(cmtproto.Block)
+	if err := proto.Unmarshal(bz, pbb); err != nil {
+		return nil, fmt.Errorf("error reading block: %w", err)
+	}
+	return types.BlockFromProto(pbb)
+}
+
+func unmarshalCommit(bz []byte) (*types.Commit, error) {
+	pbc := new(cmtproto.Commit)
+	if err := proto.Unmarshal(bz, pbc); err != nil {
+		return nil, err
+	}
+	return types.CommitFromProto(pbc)
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStoreVerify(t *testing.T) {
+	bs, db := makeBlockStoreWithBlocks(t, 6)
+	problems, err := bs.Verify(1, 6)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	hash3 := bs.LoadBlock(3).Hash()
+	require.NoError(t, db.Delete(calcBlockMetaKey(3)))
+	require.NoError(t, db.Set(calcBlockMetaKey(4), []byte("corrupted")))
+	require.NoError(t, db.Delete(calcBlockPartKey(5, 1)))
+	require.NoError(t, db.Set(calcBlockPartKey(2, 0), []byte("corrupted")))
+	require.NoError(t, db.Delete(calcBlockCommitKey(1)))
+	require.NoError(t, db.Delete(calcSeenCommitKey(6)))
+
+	problems, err = bs.Verify(1, 6)
+	require.NoError(t, err)
+	kinds := make(map[int64][]string)
+	for _, p := range problems {
+		kinds[p.Height] = append(kinds[p.Height], p.Kind)
+		// only the metas are repairable, if the parts are complete
+		assert.Equal(t, p.Height == 3 || p.Height == 4, p.Repairable, p.String())
+	}
+	assert.Equal(t, map[int64][]string{
+		1: {ProblemMissingCommit},
+		2: {ProblemCorruptPart},
+		3: {ProblemMissingMeta},
+		4: {ProblemCorruptMeta},
+		5: {ProblemMissingPart},
+		6: {ProblemMissingSeenCommit},
+	}, kinds)
+
+	// the metas are rebuilt from the parts
+	require.NoError(t, bs.RepairBlockMeta(3))
+	require.NoError(t, bs.RepairBlockMeta(4))
+	assert.Error(t, bs.RepairBlockMeta(5))
+	assert.Equal(t, hash3, bs.LoadBlock(3).Hash())
+	assert.Equal(t, hash3, bs.LoadBlockByHash(hash3).Hash())
+	problems, err = bs.Verify(3, 4)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	_, err = bs.Verify(0, 6)
+	assert.Error(t, err)
+	_, err = bs.Verify(1, 7)
+	assert.Error(t, err)
+	_, err = NewBlockStore(dbm.NewMemDB()).Verify(1, 1)
+	assert.Error(t, err)
+}
@@ -47,7 +47,7 @@ type Config struct {
 	RetainBlocks uint64 `toml:"retain_blocks"`
 
 	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// Options are ed25519, secp256k1, sr25519 & bls12_381
 	KeyType string `toml:"key_type"`
 
 	// PersistInterval specifies the height interval at which the application
@@ -70,6 +70,11 @@ type Config struct {
 	//
 	// height <-> pubkey <-> voting power
 	ValidatorUpdates map[string]map[string]uint8 `toml:"validator_update"`
+
+	// ProofsOfPossession maps the base64 pubkeys of the validator updates to
+	// the base64 proofs of possession of their private keys, which BLS12-381
+	// keys need to enter the validator set.
+	ProofsOfPossession map[string]string `toml:"proofs_of_possession"`
 }
 
 func DefaultConfig(dir string) *Config {
@@ -275,7 +280,14 @@ func (app *Application) validatorUpdates(height uint64) (abci.ValidatorUpdates,
 		if err != nil {
 			return nil, fmt.Errorf("invalid base64 pubkey value %q: %w", keyString, err)
 		}
-		valUpdates = append(valUpdates, abci.UpdateValidator(keyBytes, int64(power), app.cfg.KeyType))
+		valUpdate := abci.UpdateValidator(keyBytes, int64(power), app.cfg.KeyType)
+		if proof, ok := app.cfg.ProofsOfPossession[keyString]; ok {
+			valUpdate.ProofOfPossession, err = base64.StdEncoding.DecodeString(proof)
+			if err != nil {
+				return nil, fmt.Errorf("invalid base64 proof of possession %q: %w", proof, err)
+			}
+		}
+		valUpdates = append(valUpdates, valUpdate)
 	}
 	return valUpdates, nil
 }
@@ -11,31 +11,33 @@ import (
 
 // Config is the application configuration.
 type Config struct {
-	ChainID          string `toml:"chain_id"`
-	Listen           string
-	Protocol         string
-	Dir              string
-	Mode             string                      `toml:"mode"`
-	PersistInterval  uint64                      `toml:"persist_interval"`
-	SnapshotInterval uint64                      `toml:"snapshot_interval"`
-	RetainBlocks     uint64                      `toml:"retain_blocks"`
-	ValidatorUpdates map[string]map[string]uint8 `toml:"validator_update"`
-	PrivValServer    string                      `toml:"privval_server"`
-	PrivValKey       string                      `toml:"privval_key"`
-	PrivValState     string                      `toml:"privval_state"`
-	Misbehaviors     map[string]string           `toml:"misbehaviors"`
-	KeyType          string                      `toml:"key_type"`
+	ChainID            string `toml:"chain_id"`
+	Listen             string
+	Protocol           string
+	Dir                string
+	Mode               string                      `toml:"mode"`
+	PersistInterval    uint64                      `toml:"persist_interval"`
+	SnapshotInterval   uint64                      `toml:"snapshot_interval"`
+	RetainBlocks       uint64                      `toml:"retain_blocks"`
+	ValidatorUpdates   map[string]map[string]uint8 `toml:"validator_update"`
+	ProofsOfPossession map[string]string           `toml:"proofs_of_possession"`
+	PrivValServer      string                      `toml:"privval_server"`
+	PrivValKey         string                      `toml:"privval_key"`
+	PrivValState       string                      `toml:"privval_state"`
+	Misbehaviors       map[string]string           `toml:"misbehaviors"`
+	KeyType            string                      `toml:"key_type"`
 }
 
 // App extracts out the application specific configuration parameters
 func (cfg *Config) App() *app.Config {
 	return &app.Config{
-		Dir:              cfg.Dir,
-		SnapshotInterval: cfg.SnapshotInterval,
-		RetainBlocks:     cfg.RetainBlocks,
-		KeyType:          cfg.KeyType,
-		ValidatorUpdates: cfg.ValidatorUpdates,
-		PersistInterval:  cfg.PersistInterval,
+		Dir:                cfg.Dir,
+		SnapshotInterval:   cfg.SnapshotInterval,
+		RetainBlocks:       cfg.RetainBlocks,
+		KeyType:            cfg.KeyType,
+		ValidatorUpdates:   cfg.ValidatorUpdates,
+		ProofsOfPossession: cfg.ProofsOfPossession,
+		PersistInterval:    cfg.PersistInterval,
 	}
 }
 
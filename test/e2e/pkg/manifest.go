@@ -48,7 +48,7 @@ type Manifest struct {
 	Nodes map[string]*ManifestNode `toml:"node"`
 
 	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// Options are ed25519, secp256k1, sr25519 & bls12_381
 	KeyType string `toml:"key_type"`
 
 	// ABCIProtocol specifies the protocol used to communicate with the ABCI
@@ -111,8 +111,8 @@ type ManifestNode struct {
 	// Defaults to disabled.
 	FastSync string `toml:"fast_sync"`
 
-	// Mempool specifies which version of mempool to use. Either "v0" or "v1"
-	// This defaults to v0.
+	// Mempool specifies which version of mempool to use. Either "v0", "v1" or
+	// its alias "priority". This defaults to v0.
 	Mempool string `toml:"mempool_version"`
 
 	// StateSync enables state sync. The runner automatically configures trusted
@@ -141,7 +141,9 @@ func loadProcess(ctx context.Context, txCh <-chan types.Tx, chSuccess chan<- str
 				continue
 			}
 		}
-		if _, err = client.BroadcastTxSync(ctx, tx); err != nil {
+		// the txs rejected by the mempool, e.g. full, aren't loaded
+		res, err := client.BroadcastTxSync(ctx, tx)
+		if err != nil || res.Reason != "" {
 			continue
 		}
 		chSuccess <- s
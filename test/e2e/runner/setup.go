@@ -131,12 +131,20 @@ func MakeGenesis(testnet *e2e.Testnet) (types.GenesisDoc, error) {
 	}
 	// set the app version to 1
 	genesis.ConsensusParams.Version.AppVersion = 1
+	if testnet.KeyType != "" {
+		genesis.ConsensusParams.Validator.PubKeyTypes = []string{testnet.KeyType}
+	}
 	for validator, power := range testnet.Validators {
+		proof, err := types.ProofOfPossession(validator.PrivvalKey)
+		if err != nil {
+			return genesis, err
+		}
 		genesis.Validators = append(genesis.Validators, types.GenesisValidator{
-			Name:    validator.Name,
-			Address: validator.PrivvalKey.PubKey().Address(),
-			PubKey:  validator.PrivvalKey.PubKey(),
-			Power:   power,
+			Name:              validator.Name,
+			Address:           validator.PrivvalKey.PubKey().Address(),
+			PubKey:            validator.PrivvalKey.PubKey(),
+			Power:             power,
+			ProofOfPossession: proof,
 		})
 	}
 	// The validator set will be sorted internally by CometBFT ranked by power,
@@ -308,14 +316,24 @@ func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 
 	if len(node.Testnet.ValidatorUpdates) > 0 {
 		validatorUpdates := map[string]map[string]int64{}
+		proofs := map[string]string{}
 		for height, validators := range node.Testnet.ValidatorUpdates {
 			updateVals := map[string]int64{}
 			for node, power := range validators {
-				updateVals[base64.StdEncoding.EncodeToString(node.PrivvalKey.PubKey().Bytes())] = power
+				pubKey := base64.StdEncoding.EncodeToString(node.PrivvalKey.PubKey().Bytes())
+				updateVals[pubKey] = power
+				proof, err := types.ProofOfPossession(node.PrivvalKey)
+				if err != nil {
+					return nil, err
+				}
+				if proof != nil {
+					proofs[pubKey] = base64.StdEncoding.EncodeToString(proof)
+				}
 			}
 			validatorUpdates[fmt.Sprintf("%v", height)] = updateVals
 		}
 		cfg["validator_update"] = validatorUpdates
+		cfg["proofs_of_possession"] = proofs
 	}
 
 	var buf bytes.Buffer
@@ -124,7 +124,7 @@ func DoublePrevoteMisbehavior() Misbehavior {
 func defaultEnterPropose(cs *State, height int64, round int32) {
 	logger := cs.Logger.With("height", height, "round", round)
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.proposeTimeout(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
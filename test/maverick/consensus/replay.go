@@ -305,6 +305,7 @@ func (h *Handshaker) ReplayBlocks(
 		validators := make([]*types.Validator, len(h.genDoc.Validators))
 		for i, val := range h.genDoc.Validators {
 			validators[i] = types.NewValidator(val.PubKey, val.Power)
+			validators[i].ProofOfPossession = val.ProofOfPossession
 		}
 		validatorSet := types.NewValidatorSet(validators)
 		nextVals := types.TM2PB.ValidatorUpdates(validatorSet)
@@ -333,6 +334,9 @@ func (h *Handshaker) ReplayBlocks(
 			}
 			// If the app returned validators or consensus params, update the state.
 			if len(res.Validators) > 0 {
+				if err := sm.ValidateProofsOfPossession(res.Validators, validatorSet); err != nil {
+					return nil, fmt.Errorf("error in the validators of InitChain: %w", err)
+				}
 				vals, err := types.PB2TM.ValidatorUpdates(res.Validators)
 				if err != nil {
 					return nil, err
@@ -22,7 +22,11 @@ func (emptyMempool) SizeBytes() int64 { return 0 }
 func (emptyMempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo) error {
 	return nil
 }
+func (emptyMempool) CheckTxBatch(txs types.Txs, _ func(int, *abci.Response), _ mempl.TxInfo) []error {
+	return make([]error, len(txs))
+}
 func (emptyMempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
+func (emptyMempool) ForgetTx(txKey types.TxKey)              {}
 func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
 func (emptyMempool) Update(
@@ -36,6 +40,8 @@ func (emptyMempool) Update(
 }
 func (emptyMempool) Flush()                        {}
 func (emptyMempool) FlushAppConn() error           { return nil }
+func (emptyMempool) FlushRechecks() error          { return nil }
+func (emptyMempool) Contents() []mempl.TxMetadata  { return nil }
 func (emptyMempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (emptyMempool) EnableTxsAvailable()           {}
 func (emptyMempool) TxsBytes() int64               { return 0 }
@@ -542,6 +542,12 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cmtjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// SigningHalted returns false: the maverick signs whatever its misbehaviors
+// make it sign.
+func (cs *State) SigningHalted() bool {
+	return false
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -796,6 +802,24 @@ func (cs *State) scheduleTimeout(duration time.Duration, height int64, round int
 	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
 }
 
+// proposeTimeout returns the time to wait for a proposal at round.
+func (cs *State) proposeTimeout(round int32) time.Duration {
+	return cmtcon.TimeoutPolicyFromConfig(cs.config).Timeout(cs.config.TimeoutPropose, cs.config.TimeoutProposeDelta,
+		round)
+}
+
+// prevoteTimeout returns the time to wait for straggler prevotes at round.
+func (cs *State) prevoteTimeout(round int32) time.Duration {
+	return cmtcon.TimeoutPolicyFromConfig(cs.config).Timeout(cs.config.TimeoutPrevote, cs.config.TimeoutPrevoteDelta,
+		round)
+}
+
+// precommitTimeout returns the time to wait for straggler precommits at round.
+func (cs *State) precommitTimeout(round int32) time.Duration {
+	return cmtcon.TimeoutPolicyFromConfig(cs.config).Timeout(cs.config.TimeoutPrecommit, cs.config.TimeoutPrecommitDelta,
+		round)
+}
+
 // send a msg into the receiveRoutine regarding our own proposal, block part, or vote
 func (cs *State) sendInternalMessage(mi msgInfo) {
 	select {
@@ -1164,9 +1188,8 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	// we may need an empty "proof" block, and enterPropose immediately.
 	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
 	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
-				cstypes.RoundStepNewRound)
+		if interval := cs.config.EmptyBlocksInterval(); interval > 0 {
+			cs.scheduleTimeout(interval, height, round, cstypes.RoundStepNewRound)
 		}
 	} else {
 		cs.enterPropose(height, round)
@@ -1318,7 +1341,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.prevoteTimeout(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: any +2/3 precommits for next round.
@@ -1348,7 +1371,7 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// Wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.precommitTimeout(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
@@ -383,7 +383,7 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 	state sm.State, memplMetrics *mempl.Metrics, logger log.Logger,
 ) (p2p.Reactor, mempl.Mempool) {
 	switch config.Mempool.Version {
-	case cfg.MempoolV1:
+	case cfg.MempoolV1, cfg.MempoolPriority:
 		mp := mempoolv1.NewTxMempool(
 			logger,
 			config.Mempool,
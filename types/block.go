@@ -276,10 +276,18 @@ func BlockFromProto(bp *cmtproto.Block) (*Block, error) {
 //
 // XXX: Panics on negative result.
 func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
+	return MaxDataBytesForKeyTypes(maxBytes, evidenceBytes, valsCount, nil)
+}
+
+// MaxDataBytesForKeyTypes returns the maximum size of block's data, with the
+// commit of validators whose keys are of pubKeyTypes.
+//
+// XXX: Panics on negative result.
+func MaxDataBytesForKeyTypes(maxBytes, evidenceBytes int64, valsCount int, pubKeyTypes []string) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
-		MaxCommitBytes(valsCount) -
+		MaxCommitBytesForKeyTypes(valsCount, pubKeyTypes) -
 		evidenceBytes
 
 	if maxDataBytes < 0 {
@@ -299,10 +307,19 @@ func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
 //
 // XXX: Panics on negative result.
 func MaxDataBytesNoEvidence(maxBytes int64, valsCount int) int64 {
+	return MaxDataBytesNoEvidenceForKeyTypes(maxBytes, valsCount, nil)
+}
+
+// MaxDataBytesNoEvidenceForKeyTypes returns the maximum size of block's data
+// when evidence count is unknown, with the commit of validators whose keys are
+// of pubKeyTypes.
+//
+// XXX: Panics on negative result.
+func MaxDataBytesNoEvidenceForKeyTypes(maxBytes int64, valsCount int, pubKeyTypes []string) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
-		MaxCommitBytes(valsCount)
+		MaxCommitBytesForKeyTypes(valsCount, pubKeyTypes)
 
 	if maxDataBytes < 0 {
 		panic(fmt.Sprintf(
@@ -589,6 +606,9 @@ const (
 	// Commit sig size is made up of 64 bytes for the signature, 20 bytes for the address,
 	// 1 byte for the flag and 14 bytes for the timestamp
 	MaxCommitSigBytes int64 = 109
+	// MaxCommitSigBytesBls12381 is MaxCommitSigBytes with a 96 bytes BLS12-381
+	// signature.
+	MaxCommitSigBytesBls12381 int64 = 141
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -615,6 +635,21 @@ func MaxCommitBytes(valCount int) int64 {
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
+// MaxCommitBytesForKeyTypes returns the max size of a commit of valCount
+// validators whose keys are of pubKeyTypes, the key types allowed by the
+// consensus params. Only the BLS12-381 signatures are larger than
+// MaxCommitBytes allows for.
+func MaxCommitBytesForKeyTypes(valCount int, pubKeyTypes []string) int64 {
+	for _, keyType := range pubKeyTypes {
+		if keyType == ABCIPubKeyTypeBls12381 {
+			// From the repeated commit sig field, with 2 bytes for the length
+			var protoEncodingOverhead int64 = 3
+			return MaxCommitOverheadBytes + ((MaxCommitSigBytesBls12381 + protoEncodingOverhead) * int64(valCount))
+		}
+	}
+	return MaxCommitBytes(valCount)
+}
+
 // NewCommitSigAbsent returns new CommitSig with BlockIDFlagAbsent. Other
 // fields are all empty.
 func NewCommitSigAbsent() CommitSig {
@@ -16,6 +16,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/bits"
@@ -266,7 +268,7 @@ func TestMaxCommitBytes(t *testing.T) {
 		BlockIDFlag:      BlockIDFlagNil,
 		ValidatorAddress: crypto.AddressHash([]byte("validator_address")),
 		Timestamp:        timestamp,
-		Signature:        crypto.CRandBytes(MaxSignatureSize),
+		Signature:        crypto.CRandBytes(ed25519.SignatureSize),
 	}
 
 	pbSig := cs.ToProto()
@@ -302,6 +304,38 @@ func TestMaxCommitBytes(t *testing.T) {
 
 }
 
+func TestMaxCommitBytesForKeyTypes(t *testing.T) {
+	timestamp := time.Date(math.MaxInt64, 0, 0, 0, 0, 0, math.MaxInt64, time.UTC)
+	cs := CommitSig{
+		BlockIDFlag:      BlockIDFlagNil,
+		ValidatorAddress: crypto.AddressHash([]byte("validator_address")),
+		Timestamp:        timestamp,
+		Signature:        crypto.CRandBytes(bls12381.SignatureSize),
+	}
+	assert.EqualValues(t, MaxCommitSigBytesBls12381, cs.ToProto().Size())
+
+	commit := &Commit{
+		Height: math.MaxInt64,
+		Round:  math.MaxInt32,
+		BlockID: BlockID{
+			Hash: tmhash.Sum([]byte("blockID_hash")),
+			PartSetHeader: PartSetHeader{
+				Total: math.MaxInt32,
+				Hash:  tmhash.Sum([]byte("blockID_part_set_header_hash")),
+			},
+		},
+	}
+	for i := 0; i < MaxVotesCount; i++ {
+		commit.Signatures = append(commit.Signatures, cs)
+	}
+	keyTypes := []string{ABCIPubKeyTypeEd25519, ABCIPubKeyTypeBls12381}
+	assert.EqualValues(t, MaxCommitBytesForKeyTypes(MaxVotesCount, keyTypes), commit.ToProto().Size())
+
+	// the commits of the other key types are sized as before
+	assert.Equal(t, MaxCommitBytes(10), MaxCommitBytesForKeyTypes(10, []string{ABCIPubKeyTypeEd25519}))
+	assert.Equal(t, MaxCommitBytes(10), MaxCommitBytesForKeyTypes(10, nil))
+}
+
 func TestHeaderHash(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -503,6 +537,17 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}
 }
 
+func TestBlockMaxDataBytesForKeyTypes(t *testing.T) {
+	bls := []string{ABCIPubKeyTypeBls12381}
+	assert.Panics(t, func() { MaxDataBytesForKeyTypes(874, 0, 1, bls) })
+	assert.EqualValues(t, 0, MaxDataBytesForKeyTypes(875, 0, 1, bls))
+	assert.EqualValues(t, 1, MaxDataBytesNoEvidenceForKeyTypes(876, 1, bls))
+	assert.EqualValues(t, 1, MaxDataBytesForKeyTypes(1020, 0, 2, bls))
+
+	assert.Equal(t, MaxDataBytes(1053, 100, 2),
+		MaxDataBytesForKeyTypes(1053, 100, 2, []string{ABCIPubKeyTypeEd25519}))
+}
+
 func TestCommitToVoteSet(t *testing.T) {
 	lastID := makeBlockIDRandom()
 	h := int64(3)
@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	cmttime "github.com/tendermint/tendermint/types/time"
 )
@@ -48,6 +49,8 @@ func CanonicalizeProposal(chainID string, proposal *cmtproto.Proposal) cmtproto.
 		BlockID:   CanonicalizeBlockID(proposal.BlockID),
 		Timestamp: proposal.Timestamp,
 		ChainID:   chainID,
+
+		ParityPartSetHeader: proposal.ParityPartSetHeader,
 	}
 }
 
@@ -64,6 +67,25 @@ func CanonicalizeVote(chainID string, vote *cmtproto.Vote) cmtproto.CanonicalVot
 	}
 }
 
+// CanonicalizeKeyRotation transforms the given KeyRotation to a
+// CanonicalKeyRotation, which does not contain the signature.
+func CanonicalizeKeyRotation(chainID string, kr *KeyRotation) (cmtproto.CanonicalKeyRotation, error) {
+	oldPubKey, err := cryptoenc.PubKeyToProto(kr.OldPubKey)
+	if err != nil {
+		return cmtproto.CanonicalKeyRotation{}, err
+	}
+	newPubKey, err := cryptoenc.PubKeyToProto(kr.NewPubKey)
+	if err != nil {
+		return cmtproto.CanonicalKeyRotation{}, err
+	}
+	return cmtproto.CanonicalKeyRotation{
+		Height:    kr.Height, // encoded as sfixed64
+		OldPubKey: oldPubKey,
+		NewPubKey: newPubKey,
+		ChainID:   chainID,
+	}, nil
+}
+
 // CanonicalTime can be used to stringify time in a canonical way.
 func CanonicalTime(t time.Time) string {
 	// Note that sending time over amino resets it to
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DAPointer locates on a data availability (DA) layer the batch of blocks
+// FirstHeight to LastHeight, submitted as a single blob: the blob is included
+// in the DA block at Height, and identified in it by Commitment, e.g. the blob
+// commitment on Celestia or the extrinsic hash on Avail.
+type DAPointer struct {
+	Layer       string `json:"layer"`
+	Height      uint64 `json:"height"`
+	Commitment  []byte `json:"commitment"`
+	FirstHeight int64  `json:"first_height"`
+	LastHeight  int64  `json:"last_height"`
+}
+
+// ValidateBasic performs basic validation.
+func (p *DAPointer) ValidateBasic() error {
+	if p.Layer == "" {
+		return errors.New("empty layer")
+	}
+	if p.Height == 0 {
+		return errors.New("zero DA height")
+	}
+	if len(p.Commitment) == 0 {
+		return errors.New("empty commitment")
+	}
+	if p.FirstHeight <= 0 {
+		return fmt.Errorf("non-positive first height %d", p.FirstHeight)
+	}
+	if p.LastHeight < p.FirstHeight {
+		return fmt.Errorf("last height %d is lower than first height %d", p.LastHeight, p.FirstHeight)
+	}
+	return nil
+}
+
+// Contains returns whether the batch includes the block at height.
+func (p *DAPointer) Contains(height int64) bool {
+	return p.FirstHeight <= height && height <= p.LastHeight
+}
+
+// String returns a string representation of the DAPointer.
+func (p *DAPointer) String() string {
+	return fmt.Sprintf("DAPointer{%s %d %X [%d, %d]}", p.Layer, p.Height, p.Commitment,
+		p.FirstHeight, p.LastHeight)
+}
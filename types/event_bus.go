@@ -162,6 +162,18 @@ func (b *EventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return b.Publish(EventNewEvidence, evidence)
 }
 
+func (b *EventBus) PublishEventFastSyncStatus(data EventDataFastSyncStatus) error {
+	return b.Publish(EventFastSyncStatus, data)
+}
+
+func (b *EventBus) PublishEventFinality(data EventDataFinality) error {
+	return b.Publish(EventFinality, data)
+}
+
+func (b *EventBus) PublishEventSettlementStatus(data EventDataSettlementStatus) error {
+	return b.Publish(EventSettlementStatus, data)
+}
+
 func (b *EventBus) PublishEventVote(data EventDataVote) error {
 	return b.Publish(EventVote, data)
 }
@@ -187,6 +199,17 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
+// PublishEventMempoolTxEvicted publishes the eviction of a tx from the
+// mempool, with the TxHashKey of the tx.
+func (b *EventBus) PublishEventMempoolTxEvicted(data EventDataMempoolTxEvicted) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+	return b.pubsub.PublishWithEvents(ctx, data, map[string][]string{
+		EventTypeKey: {EventMempoolTxEvicted},
+		TxHashKey:    {fmt.Sprintf("%X", data.Tx.Hash())},
+	})
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return b.Publish(EventNewRoundStep, data)
 }
@@ -211,6 +234,18 @@ func (b *EventBus) PublishEventPolka(data EventDataRoundState) error {
 	return b.Publish(EventPolka, data)
 }
 
+func (b *EventBus) PublishEventProposalReceived(data EventDataProposalReceived) error {
+	return b.Publish(EventProposalReceived, data)
+}
+
+func (b *EventBus) PublishEventBlockPartReceived(data EventDataBlockPartReceived) error {
+	return b.Publish(EventBlockPartReceived, data)
+}
+
+func (b *EventBus) PublishEventCommit(data EventDataCommit) error {
+	return b.Publish(EventCommit, data)
+}
+
 func (b *EventBus) PublishEventUnlock(data EventDataRoundState) error {
 	return b.Publish(EventUnlock, data)
 }
@@ -259,6 +294,18 @@ func (NopEventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return nil
 }
 
+func (NopEventBus) PublishEventFastSyncStatus(data EventDataFastSyncStatus) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventFinality(data EventDataFinality) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventSettlementStatus(data EventDataSettlementStatus) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventVote(data EventDataVote) error {
 	return nil
 }
@@ -267,6 +314,10 @@ func (NopEventBus) PublishEventTx(data EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventMempoolTxEvicted(data EventDataMempoolTxEvicted) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return nil
 }
@@ -291,6 +342,18 @@ func (NopEventBus) PublishEventPolka(data EventDataRoundState) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventProposalReceived(data EventDataProposalReceived) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventBlockPartReceived(data EventDataBlockPartReceived) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventCommit(data EventDataCommit) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventUnlock(data EventDataRoundState) error {
 	return nil
 }
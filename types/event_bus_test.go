@@ -313,6 +313,33 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventMempoolTxEvicted(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	query := fmt.Sprintf("tm.event='MempoolTxEvicted' AND tx.hash='%X'", tx.Hash())
+	evictedSub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustParse(query))
+	require.NoError(t, err)
+
+	evicted := EventDataMempoolTxEvicted{Tx: tx, Height: 5, Reason: "ttl-num-blocks"}
+	require.NoError(t, eventBus.PublishEventMempoolTxEvicted(EventDataMempoolTxEvicted{Tx: Tx("bar")}))
+	require.NoError(t, eventBus.PublishEventMempoolTxEvicted(evicted))
+
+	select {
+	case msg := <-evictedSub.Out():
+		assert.Equal(t, evicted, msg.Data())
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive the eviction after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -323,7 +350,7 @@ func TestEventBusPublish(t *testing.T) {
 		}
 	})
 
-	const numEventsExpected = 14
+	const numEventsExpected = 17
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.Empty{}, numEventsExpected)
 	require.NoError(t, err)
@@ -360,6 +387,12 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventPolka(EventDataRoundState{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventProposalReceived(EventDataProposalReceived{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventBlockPartReceived(EventDataBlockPartReceived{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventCommit(EventDataCommit{})
+	require.NoError(t, err)
 	err = eventBus.PublishEventUnlock(EventDataRoundState{})
 	require.NoError(t, err)
 	err = eventBus.PublishEventRelock(EventDataRoundState{})
@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bits"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
@@ -19,23 +21,30 @@ const (
 	EventNewBlock            = "NewBlock"
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventNewEvidence         = "NewEvidence"
+	EventFastSyncStatus      = "FastSyncStatus"
+	EventFinality            = "Finality"
+	EventMempoolTxEvicted    = "MempoolTxEvicted"
+	EventSettlementStatus    = "SettlementStatus"
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
-	EventCompleteProposal = "CompleteProposal"
-	EventLock             = "Lock"
-	EventNewRound         = "NewRound"
-	EventNewRoundStep     = "NewRoundStep"
-	EventPolka            = "Polka"
-	EventRelock           = "Relock"
-	EventTimeoutPropose   = "TimeoutPropose"
-	EventTimeoutWait      = "TimeoutWait"
-	EventUnlock           = "Unlock"
-	EventValidBlock       = "ValidBlock"
-	EventVote             = "Vote"
+	EventBlockPartReceived = "BlockPartReceived"
+	EventCommit            = "Commit"
+	EventCompleteProposal  = "CompleteProposal"
+	EventLock              = "Lock"
+	EventNewRound          = "NewRound"
+	EventNewRoundStep      = "NewRoundStep"
+	EventPolka             = "Polka"
+	EventProposalReceived  = "ProposalReceived"
+	EventRelock            = "Relock"
+	EventTimeoutPropose    = "TimeoutPropose"
+	EventTimeoutWait       = "TimeoutWait"
+	EventUnlock            = "Unlock"
+	EventValidBlock        = "ValidBlock"
+	EventVote              = "Vote"
 )
 
 // ENCODING / DECODING
@@ -59,8 +68,15 @@ func init() {
 	cmtjson.RegisterType(EventDataRoundState{}, "tendermint/event/RoundState")
 	cmtjson.RegisterType(EventDataNewRound{}, "tendermint/event/NewRound")
 	cmtjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
+	cmtjson.RegisterType(EventDataProposalReceived{}, "tendermint/event/ProposalReceived")
+	cmtjson.RegisterType(EventDataBlockPartReceived{}, "tendermint/event/BlockPartReceived")
+	cmtjson.RegisterType(EventDataCommit{}, "tendermint/event/Commit")
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
+	cmtjson.RegisterType(EventDataFastSyncStatus{}, "tendermint/event/FastSyncStatus")
+	cmtjson.RegisterType(EventDataSettlementStatus{}, "tendermint/event/SettlementStatus")
+	cmtjson.RegisterType(EventDataFinality{}, "tendermint/event/Finality")
+	cmtjson.RegisterType(EventDataMempoolTxEvicted{}, "tendermint/event/MempoolTxEvicted")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 }
 
@@ -106,11 +122,23 @@ type EventDataTx struct {
 	abci.TxResult
 }
 
-// NOTE: This goes into the replay WAL
+// NOTE: This goes into the replay WAL, but for Votes
 type EventDataRoundState struct {
 	Height int64  `json:"height"`
 	Round  int32  `json:"round"`
 	Step   string `json:"step"`
+
+	// Votes are the votes of the rounds of the height so far, set for the
+	// NewRoundStep and Polka events.
+	Votes []RoundVotes `json:"votes,omitempty"`
+}
+
+// RoundVotes are the prevotes and the precommits of a round, as bit arrays
+// over the validators.
+type RoundVotes struct {
+	Round      int32          `json:"round"`
+	Prevotes   *bits.BitArray `json:"prevotes"`
+	Precommits *bits.BitArray `json:"precommits"`
 }
 
 type ValidatorInfo struct {
@@ -134,12 +162,82 @@ type EventDataCompleteProposal struct {
 	BlockID BlockID `json:"block_id"`
 }
 
+// EventDataProposalReceived is fired when the proposal of a round is received,
+// before its block parts.
+type EventDataProposalReceived struct {
+	Height    int64     `json:"height"`
+	Round     int32     `json:"round"`
+	POLRound  int32     `json:"pol_round"`
+	BlockID   BlockID   `json:"block_id"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Proposer ValidatorInfo `json:"proposer"`
+}
+
+// EventDataBlockPartReceived is fired for each new part of the proposed block,
+// with the number of its parts received so far. Peer is empty for the parts of
+// the node itself.
+type EventDataBlockPartReceived struct {
+	Height   int64  `json:"height"`
+	Round    int32  `json:"round"`
+	Index    uint32 `json:"index"`
+	Received uint32 `json:"received"`
+	Total    uint32 `json:"total"`
+	Peer     string `json:"peer"`
+}
+
+// EventDataCommit is fired when +2/3 of the validators precommitted a block,
+// with their precommits.
+type EventDataCommit struct {
+	Height     int64          `json:"height"`
+	Round      int32          `json:"round"`
+	BlockID    BlockID        `json:"block_id"`
+	Precommits *bits.BitArray `json:"precommits"`
+}
+
 type EventDataVote struct {
 	Vote *Vote
 }
 
 type EventDataString string
 
+// EventDataFastSyncStatus is fired on every transition between fast sync and
+// consensus. Complete is true when the node has switched to consensus.
+type EventDataFastSyncStatus struct {
+	Complete bool  `json:"complete"`
+	Height   int64 `json:"height"`
+	// Forced is true when the transition was requested by an operator rather
+	// than triggered by the node catching up.
+	Forced bool `json:"forced"`
+}
+
+// EventDataSettlementStatus is fired when the settlement hub accepts or
+// finalizes the state updates of more blocks.
+type EventDataSettlementStatus struct {
+	Hub             string `json:"hub"`
+	AcceptedHeight  int64  `json:"accepted_height"`
+	FinalizedHeight int64  `json:"finalized_height"`
+}
+
+// EventDataFinality is fired when more blocks reach a level of finality: the
+// blocks up to SoftHeight are committed by the sequencer, those up to
+// DAIncludedHeight are included in the DA layer, and those up to FirmHeight
+// are finalized by the settlement hub.
+type EventDataFinality struct {
+	SoftHeight       int64 `json:"soft_height"`
+	DAIncludedHeight int64 `json:"da_included_height"`
+	FirmHeight       int64 `json:"firm_height"`
+}
+
+// EventDataMempoolTxEvicted is fired when a valid tx is evicted from the
+// mempool before being committed, e.g. when it expires, so that its clients
+// learn that it was dropped. Height is the height of the mempool at that time.
+type EventDataMempoolTxEvicted struct {
+	Tx     Tx     `json:"tx"`
+	Height int64  `json:"height"`
+	Reason string `json:"reason"`
+}
+
 type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
@@ -155,6 +253,11 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// TxSenderKey, TxRecipientKey and TxFeePayerKey are reserved keys used to
+	// search transactions by the accounts indexed from their events.
+	TxSenderKey    = "tx.sender"
+	TxRecipientKey = "tx.recipient"
+	TxFeePayerKey  = "tx.fee_payer"
 
 	// BlockHeightKey is a reserved key used for indexing BeginBlock and Endblock
 	// events.
@@ -167,15 +270,22 @@ const (
 )
 
 var (
+	EventQueryBlockPartReceived   = QueryForEvent(EventBlockPartReceived)
+	EventQueryCommit              = QueryForEvent(EventCommit)
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryFastSyncStatus      = QueryForEvent(EventFastSyncStatus)
+	EventQueryFinality            = QueryForEvent(EventFinality)
 	EventQueryLock                = QueryForEvent(EventLock)
+	EventQueryMempoolTxEvicted    = QueryForEvent(EventMempoolTxEvicted)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
 	EventQueryNewRound            = QueryForEvent(EventNewRound)
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
 	EventQueryPolka               = QueryForEvent(EventPolka)
+	EventQueryProposalReceived    = QueryForEvent(EventProposalReceived)
 	EventQueryRelock              = QueryForEvent(EventRelock)
+	EventQuerySettlementStatus    = QueryForEvent(EventSettlementStatus)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
 	EventQueryTx                  = QueryForEvent(EventTx)
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ForcedTx is a transaction force-included by the settlement hub, e.g. by a
+// user censored by the sequencer, which the chain must include in a block at
+// a height up to Deadline.
+type ForcedTx struct {
+	Tx       Tx    `json:"tx"`
+	Deadline int64 `json:"deadline"`
+	// HubTime is the time of the hub block which announced the transaction.
+	// The blocks older than it don't have to include the transaction, even
+	// past its deadline. It is zero if unknown.
+	HubTime time.Time `json:"hub_time"`
+}
+
+// IsDue returns whether the block at height, of time blockTime, must include
+// ftx: it is past the deadline and the hub announced ftx by blockTime.
+func (ftx *ForcedTx) IsDue(height int64, blockTime time.Time) bool {
+	return ftx.Deadline <= height && !ftx.HubTime.After(blockTime)
+}
+
+// ValidateBasic performs basic validation.
+func (ftx *ForcedTx) ValidateBasic() error {
+	if len(ftx.Tx) == 0 {
+		return errors.New("empty transaction")
+	}
+	if ftx.Deadline <= 0 {
+		return fmt.Errorf("non-positive deadline %d", ftx.Deadline)
+	}
+	return nil
+}
+
+// String returns a string representation of the ForcedTx.
+func (ftx *ForcedTx) String() string {
+	return fmt.Sprintf("ForcedTx{%X %d}", ftx.Tx.Hash(), ftx.Deadline)
+}
@@ -33,6 +33,9 @@ type GenesisValidator struct {
 	PubKey  crypto.PubKey `json:"pub_key"`
 	Power   int64         `json:"power"`
 	Name    string        `json:"name"`
+	// ProofOfPossession proves the possession of the private key of PubKey,
+	// required for the BLS12-381 keys.
+	ProofOfPossession cmtbytes.HexBytes `json:"proof_of_possession,omitempty"`
 }
 
 // GenesisDoc defines the initial conditions for a CometBFT blockchain, in particular its validator set.
@@ -91,6 +94,13 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if v.Power == 0 {
 			return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
 		}
+		if !IsValidPubkeyType(genDoc.ConsensusParams.Validator, v.PubKey.Type()) {
+			return fmt.Errorf("validator %v in the genesis file has a key of type %s, which is not allowed by the"+
+				" consensus params %v", v, v.PubKey.Type(), genDoc.ConsensusParams.Validator.PubKeyTypes)
+		}
+		if err := VerifyProofOfPossession(v.PubKey, v.ProofOfPossession); err != nil {
+			return fmt.Errorf("validator %v in the genesis file: %w", v, err)
+		}
 		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
 			return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
 		}
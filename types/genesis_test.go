@@ -7,7 +7,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmttime "github.com/tendermint/tendermint/types/time"
 )
@@ -80,7 +83,7 @@ func TestGenesisGood(t *testing.T) {
 	// create a base gendoc from struct
 	baseGenDoc := &GenesisDoc{
 		ChainID:    "abc",
-		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 	}
 	genDocBytes, err = cmtjson.Marshal(baseGenDoc)
 	assert.NoError(t, err, "error marshaling genDoc")
@@ -120,6 +123,76 @@ func TestGenesisGood(t *testing.T) {
 	}
 }
 
+func TestGenesisValidatorKeyType(t *testing.T) {
+	for keyType, privKey := range map[string]crypto.PrivKey{
+		ABCIPubKeyTypeBls12381: bls12381.GenPrivKey(),
+		ABCIPubKeyTypeSr25519:  sr25519.GenPrivKey(),
+	} {
+		proof, err := ProofOfPossession(privKey)
+		require.NoError(t, err)
+		genDoc := &GenesisDoc{
+			ChainID: "abc",
+			Validators: []GenesisValidator{{
+				PubKey:            privKey.PubKey(),
+				Power:             10,
+				ProofOfPossession: proof,
+			}},
+		}
+
+		// the default consensus params only allow ed25519 keys
+		require.Error(t, genDoc.ValidateAndComplete(), keyType)
+
+		genDoc.ConsensusParams = DefaultConsensusParams()
+		genDoc.ConsensusParams.Validator.PubKeyTypes = []string{keyType}
+		require.NoError(t, genDoc.ValidateAndComplete(), keyType)
+
+		// and the key survives a JSON round trip
+		genDocBytes, err := cmtjson.Marshal(genDoc)
+		require.NoError(t, err)
+		genDoc2, err := GenesisDocFromJSON(genDocBytes)
+		require.NoError(t, err)
+		assert.True(t, genDoc.Validators[0].PubKey.Equals(genDoc2.Validators[0].PubKey), keyType)
+		assert.Equal(t, genDoc.Validators[0].ProofOfPossession, genDoc2.Validators[0].ProofOfPossession, keyType)
+	}
+}
+
+func TestGenesisValidatorProofOfPossession(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	proof, err := ProofOfPossession(privKey)
+	require.NoError(t, err)
+	otherProof, err := ProofOfPossession(bls12381.GenPrivKey())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		proof   []byte
+		wantErr bool
+	}{
+		{"missing proof", nil, true},
+		{"proof of another key", otherProof, true},
+		{"garbage proof", []byte{1, 2, 3}, true},
+		{"valid proof", proof, false},
+	}
+	for _, tc := range testCases {
+		genDoc := &GenesisDoc{
+			ChainID:         "abc",
+			ConsensusParams: DefaultConsensusParams(),
+			Validators: []GenesisValidator{{
+				PubKey:            privKey.PubKey(),
+				Power:             10,
+				ProofOfPossession: tc.proof,
+			}},
+		}
+		genDoc.ConsensusParams.Validator.PubKeyTypes = []string{ABCIPubKeyTypeBls12381}
+		err := genDoc.ValidateAndComplete()
+		if tc.wantErr {
+			assert.ErrorIs(t, err, ErrInvalidProofOfPossession, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+	}
+}
+
 func TestGenesisSaveAs(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "genesis")
 	require.NoError(t, err)
@@ -157,7 +230,7 @@ func randomGenesisDoc() *GenesisDoc {
 		GenesisTime:     cmttime.Now(),
 		ChainID:         "abc",
 		InitialHeight:   1000,
-		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 		ConsensusParams: DefaultConsensusParams(),
 		AppHash:         []byte{1, 2, 3},
 	}
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/libs/protoio"
+)
+
+// KeyRotationDelay is the number of blocks after which a key rotation returned
+// by the application in EndBlock takes effect. Like validator updates, a
+// rotation returned for block H changes the validator set of block H+2, which
+// must be the height of the rotation.
+const KeyRotationDelay = 2
+
+var (
+	ErrKeyRotationInvalidSignature = errors.New("invalid key rotation signature")
+	ErrKeyRotationSameKey          = errors.New("key rotation to the same key")
+)
+
+// KeyRotation is the announcement by a validator that it switches its
+// consensus key to NewPubKey from Height on, while keeping its voting power.
+// The rotation is signed by the old key, so that only the current key holder
+// can hand the validator over, and comes with the proof of possession of the
+// new key if it needs one.
+type KeyRotation struct {
+	OldPubKey         crypto.PubKey `json:"old_pub_key"`
+	NewPubKey         crypto.PubKey `json:"new_pub_key"`
+	Height            int64         `json:"height"`
+	Signature         []byte        `json:"signature"`
+	ProofOfPossession []byte        `json:"proof_of_possession,omitempty"`
+}
+
+// NewKeyRotation returns an unsigned KeyRotation from oldPubKey to newPubKey at
+// the given height.
+func NewKeyRotation(oldPubKey, newPubKey crypto.PubKey, height int64) *KeyRotation {
+	return &KeyRotation{
+		OldPubKey: oldPubKey,
+		NewPubKey: newPubKey,
+		Height:    height,
+	}
+}
+
+// KeyRotationSignBytes returns the bytes signed by the old key of a rotation.
+// Panics if the public keys can't be encoded.
+func KeyRotationSignBytes(chainID string, kr *KeyRotation) []byte {
+	pb, err := CanonicalizeKeyRotation(chainID, kr)
+	if err != nil {
+		panic(err)
+	}
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// ValidateBasic performs basic validation.
+func (kr *KeyRotation) ValidateBasic() error {
+	if kr.OldPubKey == nil {
+		return errors.New("missing old public key")
+	}
+	if kr.NewPubKey == nil {
+		return errors.New("missing new public key")
+	}
+	if kr.Height <= 0 {
+		return errors.New("non positive height")
+	}
+	if bytes.Equal(kr.OldPubKey.Bytes(), kr.NewPubKey.Bytes()) {
+		return ErrKeyRotationSameKey
+	}
+	if len(kr.Signature) == 0 {
+		return errors.New("signature is missing")
+	}
+	if len(kr.Signature) > MaxSignatureSize {
+		return fmt.Errorf("signature is too big (max: %d)", MaxSignatureSize)
+	}
+	return nil
+}
+
+// Verify checks the signature of the rotation by the old key, and the proof of
+// possession of the new key.
+func (kr *KeyRotation) Verify(chainID string) error {
+	if !kr.OldPubKey.VerifySignature(KeyRotationSignBytes(chainID, kr), kr.Signature) {
+		return ErrKeyRotationInvalidSignature
+	}
+	return VerifyProofOfPossession(kr.NewPubKey, kr.ProofOfPossession)
+}
+
+// String returns a string representation of the KeyRotation.
+func (kr *KeyRotation) String() string {
+	if kr == nil {
+		return "nil-KeyRotation"
+	}
+	return fmt.Sprintf("KeyRotation{%v -> %v @ %d}", kr.OldPubKey.Address(), kr.NewPubKey.Address(), kr.Height)
+}
+
+// ToProto converts KeyRotation to protobuf.
+func (kr *KeyRotation) ToProto() (abci.KeyRotation, error) {
+	oldPubKey, err := cryptoenc.PubKeyToProto(kr.OldPubKey)
+	if err != nil {
+		return abci.KeyRotation{}, err
+	}
+	newPubKey, err := cryptoenc.PubKeyToProto(kr.NewPubKey)
+	if err != nil {
+		return abci.KeyRotation{}, err
+	}
+	return abci.KeyRotation{
+		OldPubKey:         oldPubKey,
+		NewPubKey:         newPubKey,
+		Height:            kr.Height,
+		Signature:         kr.Signature,
+		ProofOfPossession: kr.ProofOfPossession,
+	}, nil
+}
+
+// KeyRotationFromProto converts a protobuf KeyRotation to KeyRotation.
+func KeyRotationFromProto(pb abci.KeyRotation) (*KeyRotation, error) {
+	oldPubKey, err := cryptoenc.PubKeyFromProto(pb.OldPubKey)
+	if err != nil {
+		return nil, err
+	}
+	newPubKey, err := cryptoenc.PubKeyFromProto(pb.NewPubKey)
+	if err != nil {
+		return nil, err
+	}
+	kr := &KeyRotation{
+		OldPubKey:         oldPubKey,
+		NewPubKey:         newPubKey,
+		Height:            pb.Height,
+		Signature:         pb.Signature,
+		ProofOfPossession: pb.ProofOfPossession,
+	}
+	return kr, kr.ValidateBasic()
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestKeyRotationSignAndVerify(t *testing.T) {
+	oldPrivKey := ed25519.GenPrivKey()
+	newPrivKey := secp256k1.GenPrivKey()
+
+	kr := NewKeyRotation(oldPrivKey.PubKey(), newPrivKey.PubKey(), 10)
+	sig, err := oldPrivKey.Sign(KeyRotationSignBytes("test_chain_id", kr))
+	require.NoError(t, err)
+	kr.Signature = sig
+	require.NoError(t, kr.ValidateBasic())
+	assert.NoError(t, kr.Verify("test_chain_id"))
+
+	// the signature commits to the chain and the height
+	assert.ErrorIs(t, kr.Verify("other_chain_id"), ErrKeyRotationInvalidSignature)
+	kr.Height++
+	assert.ErrorIs(t, kr.Verify("test_chain_id"), ErrKeyRotationInvalidSignature)
+
+	// only the old key can sign the rotation
+	kr.Height--
+	kr.Signature, err = newPrivKey.Sign(KeyRotationSignBytes("test_chain_id", kr))
+	require.NoError(t, err)
+	assert.ErrorIs(t, kr.Verify("test_chain_id"), ErrKeyRotationInvalidSignature)
+}
+
+func TestKeyRotationProofOfPossession(t *testing.T) {
+	oldPrivKey := ed25519.GenPrivKey()
+	newPrivKey := bls12381.GenPrivKey()
+
+	kr := NewKeyRotation(oldPrivKey.PubKey(), newPrivKey.PubKey(), 10)
+	sig, err := oldPrivKey.Sign(KeyRotationSignBytes("test_chain_id", kr))
+	require.NoError(t, err)
+	kr.Signature = sig
+
+	// a BLS12-381 key needs a proof of possession to rotate to
+	assert.ErrorIs(t, kr.Verify("test_chain_id"), ErrInvalidProofOfPossession)
+	kr.ProofOfPossession, err = ProofOfPossession(bls12381.GenPrivKey())
+	require.NoError(t, err)
+	assert.ErrorIs(t, kr.Verify("test_chain_id"), ErrInvalidProofOfPossession)
+	kr.ProofOfPossession, err = ProofOfPossession(newPrivKey)
+	require.NoError(t, err)
+	assert.NoError(t, kr.Verify("test_chain_id"))
+}
+
+func TestKeyRotationValidateBasic(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	testCases := []struct {
+		name     string
+		malleate func(*KeyRotation)
+		expErr   bool
+	}{
+		{"valid", func(kr *KeyRotation) {}, false},
+		{"missing old key", func(kr *KeyRotation) { kr.OldPubKey = nil }, true},
+		{"missing new key", func(kr *KeyRotation) { kr.NewPubKey = nil }, true},
+		{"same key", func(kr *KeyRotation) { kr.NewPubKey = kr.OldPubKey }, true},
+		{"zero height", func(kr *KeyRotation) { kr.Height = 0 }, true},
+		{"missing signature", func(kr *KeyRotation) { kr.Signature = nil }, true},
+		{"signature too big", func(kr *KeyRotation) { kr.Signature = make([]byte, MaxSignatureSize+1) }, true},
+	}
+	for _, tc := range testCases {
+		kr := NewKeyRotation(pubKey, ed25519.GenPrivKey().PubKey(), 1)
+		kr.Signature = []byte{1}
+		tc.malleate(kr)
+		assert.Equal(t, tc.expErr, kr.ValidateBasic() != nil, tc.name)
+	}
+}
+
+func TestKeyRotationProtoBuf(t *testing.T) {
+	kr := NewKeyRotation(ed25519.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), 5)
+	kr.Signature = []byte("signature")
+	kr.ProofOfPossession = []byte("proof")
+
+	pb, err := kr.ToProto()
+	require.NoError(t, err)
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, pb.Unmarshal(bz))
+
+	kr2, err := KeyRotationFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, kr, kr2)
+
+	pb.Signature = nil
+	_, err = KeyRotationFromProto(pb)
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -72,6 +73,33 @@ func IsValidPubkeyType(params cmtproto.ValidatorParams, pubkeyType string) bool
 	return false
 }
 
+// IsPBTSEnabled returns whether the blocks of params use proposer-based
+// timestamps, i.e. whether their synchrony params have a message delay, rather
+// than the median of the times of the precommits of the previous block.
+func IsPBTSEnabled(params cmtproto.ConsensusParams) bool {
+	return params.Synchrony.MessageDelay > 0
+}
+
+// IsErasureCodingEnabled returns whether the proposals of height commit to the
+// erasure coded parity parts of their blocks, which changes their sign bytes.
+func IsErasureCodingEnabled(params cmtproto.ConsensusParams, height int64) bool {
+	return params.Feature.ErasureCodingEnableHeight > 0 && height >= params.Feature.ErasureCodingEnableHeight
+}
+
+// SynchronyParamsInRound returns the synchrony params of the proposals of a
+// round: the message delay grows by 10% each round, for the validators to
+// agree on a block eventually even if it was set too low.
+func SynchronyParamsInRound(params cmtproto.SynchronyParams, round int32) cmtproto.SynchronyParams {
+	for i := int32(0); i < round; i++ {
+		delta := params.MessageDelay / 10
+		if delta == 0 || params.MessageDelay > math.MaxInt64-delta {
+			break
+		}
+		params.MessageDelay += delta
+	}
+	return params
+}
+
 // Validate validates the ConsensusParams to ensure all values are within their
 // allowed limits, and returns an error if they are not.
 func ValidateConsensusParams(params cmtproto.ConsensusParams) error {
@@ -118,6 +146,20 @@ func ValidateConsensusParams(params cmtproto.ConsensusParams) error {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
 
+	if params.Timeout.Propose < 0 || params.Timeout.Prevote < 0 ||
+		params.Timeout.Precommit < 0 || params.Timeout.Commit < 0 {
+		return fmt.Errorf("timeout params must be non negative. Got: %v", params.Timeout)
+	}
+
+	if params.Synchrony.Precision < 0 || params.Synchrony.MessageDelay < 0 {
+		return fmt.Errorf("synchrony params must be non negative. Got: %v", params.Synchrony)
+	}
+
+	if params.Feature.ErasureCodingEnableHeight < 0 {
+		return fmt.Errorf("feature.ErasureCodingEnableHeight must be non negative. Got: %d",
+			params.Feature.ErasureCodingEnableHeight)
+	}
+
 	// Check if keyType is a known ABCIPubKeyType
 	for i := 0; i < len(params.Validator.PubKeyTypes); i++ {
 		keyType := params.Validator.PubKeyTypes[i]
@@ -181,5 +223,14 @@ func UpdateConsensusParams(params cmtproto.ConsensusParams, params2 *abci.Consen
 	if params2.Version != nil {
 		res.Version.AppVersion = params2.Version.AppVersion
 	}
+	if params2.Timeout != nil {
+		res.Timeout = *params2.Timeout
+	}
+	if params2.Synchrony != nil {
+		res.Synchrony = *params2.Synchrony
+	}
+	if params2.Feature != nil {
+		res.Feature = *params2.Feature
+	}
 	return res
 }
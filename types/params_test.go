@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"math"
 	"sort"
 	"testing"
 	"time"
@@ -149,3 +150,55 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 
 	assert.EqualValues(t, 1, updated.Version.AppVersion)
 }
+
+func TestConsensusParamsUpdate_Timeout(t *testing.T) {
+	params := makeParams(1, 2, 10, 3, 0, valEd25519)
+	timeout := cmtproto.TimeoutParams{Propose: time.Second, Commit: 500 * time.Millisecond}
+
+	updated := UpdateConsensusParams(params, &abci.ConsensusParams{Timeout: &timeout})
+	assert.Equal(t, timeout, updated.Timeout)
+	assert.Equal(t, timeout, UpdateConsensusParams(updated, &abci.ConsensusParams{}).Timeout)
+	assert.NoError(t, ValidateConsensusParams(updated))
+
+	updated.Timeout.Prevote = -1
+	assert.Error(t, ValidateConsensusParams(updated))
+}
+
+func TestConsensusParamsUpdate_Synchrony(t *testing.T) {
+	params := makeParams(1, 2, 10, 3, 0, valEd25519)
+	assert.False(t, IsPBTSEnabled(params))
+
+	synchrony := cmtproto.SynchronyParams{Precision: 500 * time.Millisecond, MessageDelay: 2 * time.Second}
+	updated := UpdateConsensusParams(params, &abci.ConsensusParams{Synchrony: &synchrony})
+	assert.Equal(t, synchrony, updated.Synchrony)
+	assert.True(t, IsPBTSEnabled(updated))
+	assert.NoError(t, ValidateConsensusParams(updated))
+
+	updated.Synchrony.Precision = -1
+	assert.Error(t, ValidateConsensusParams(updated))
+}
+
+func TestConsensusParamsUpdate_Feature(t *testing.T) {
+	params := makeParams(1, 2, 10, 3, 0, valEd25519)
+	assert.False(t, IsErasureCodingEnabled(params, 100))
+
+	feature := cmtproto.FeatureParams{ErasureCodingEnableHeight: 10}
+	updated := UpdateConsensusParams(params, &abci.ConsensusParams{Feature: &feature})
+	assert.Equal(t, feature, updated.Feature)
+	assert.False(t, IsErasureCodingEnabled(updated, 9))
+	assert.True(t, IsErasureCodingEnabled(updated, 10))
+	assert.NoError(t, ValidateConsensusParams(updated))
+
+	updated.Feature.ErasureCodingEnableHeight = -1
+	assert.Error(t, ValidateConsensusParams(updated))
+}
+
+func TestSynchronyParamsInRound(t *testing.T) {
+	params := cmtproto.SynchronyParams{Precision: time.Second, MessageDelay: 10 * time.Second}
+	assert.Equal(t, params, SynchronyParamsInRound(params, 0))
+	assert.Equal(t, 12100*time.Millisecond, SynchronyParamsInRound(params, 2).MessageDelay)
+	assert.Equal(t, time.Second, SynchronyParamsInRound(params, 2).Precision)
+
+	// the message delay doesn't overflow
+	assert.Positive(t, SynchronyParamsInRound(params, math.MaxInt32).MessageDelay)
+}
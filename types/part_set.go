@@ -147,6 +147,91 @@ func PartSetHeaderFromProto(ppsh *cmtproto.PartSetHeader) (*PartSetHeader, error
 
 //-------------------------------------
 
+// ParityPartSetHeader commits to the parity parts of an erasure coded block,
+// signed with its proposal so that each parity part gossiped can be checked
+// on its own: Hash is the merkle root of the Total parity parts, and
+// LastPartSize the size of the last part of the block, the parity parts being
+// encoded from the parts padded to the size of the others.
+type ParityPartSetHeader struct {
+	Total        uint32            `json:"total"`
+	Hash         cmtbytes.HexBytes `json:"hash"`
+	LastPartSize uint32            `json:"last_part_size"`
+}
+
+// NewParityPartSetHeader returns the header of the parity parts of a block
+// whose last part is of lastPartSize bytes.
+func NewParityPartSetHeader(parity [][]byte, lastPartSize uint32) ParityPartSetHeader {
+	return ParityPartSetHeader{
+		Total:        uint32(len(parity)),
+		Hash:         merkle.HashFromByteSlices(parity),
+		LastPartSize: lastPartSize,
+	}
+}
+
+// String returns a string representation of ParityPartSetHeader.
+func (ppsh ParityPartSetHeader) String() string {
+	return fmt.Sprintf("%v:%X:%v", ppsh.Total, cmtbytes.Fingerprint(ppsh.Hash), ppsh.LastPartSize)
+}
+
+// ValidateBasic performs basic validation.
+func (ppsh ParityPartSetHeader) ValidateBasic() error {
+	if ppsh.Total == 0 {
+		return errors.New("no parity parts")
+	}
+	if len(ppsh.Hash) == 0 {
+		return errors.New("missing Hash")
+	}
+	if err := ValidateHash(ppsh.Hash); err != nil {
+		return fmt.Errorf("wrong Hash: %w", err)
+	}
+	if ppsh.LastPartSize == 0 || ppsh.LastPartSize > BlockPartSizeBytes {
+		return fmt.Errorf("wrong LastPartSize %d, max: %d", ppsh.LastPartSize, BlockPartSizeBytes)
+	}
+	return nil
+}
+
+// VerifyPart checks that part is the parity part of its index, with its proof
+// against the hash.
+func (ppsh ParityPartSetHeader) VerifyPart(part *Part) error {
+	if part.Index >= ppsh.Total ||
+		part.Proof.Index != int64(part.Index) || part.Proof.Total != int64(ppsh.Total) {
+		return ErrPartSetUnexpectedIndex
+	}
+	if part.Proof.Verify(ppsh.Hash, part.Bytes) != nil {
+		return ErrPartSetInvalidProof
+	}
+	return nil
+}
+
+// ToProto converts ParityPartSetHeader to protobuf
+func (ppsh *ParityPartSetHeader) ToProto() *cmtproto.ParityPartSetHeader {
+	if ppsh == nil {
+		return nil
+	}
+
+	return &cmtproto.ParityPartSetHeader{
+		Total:        ppsh.Total,
+		Hash:         ppsh.Hash,
+		LastPartSize: ppsh.LastPartSize,
+	}
+}
+
+// ParityPartSetHeaderFromProto sets a protobuf ParityPartSetHeader to the
+// given pointer, nil if nil.
+func ParityPartSetHeaderFromProto(pppsh *cmtproto.ParityPartSetHeader) (*ParityPartSetHeader, error) {
+	if pppsh == nil {
+		return nil, nil
+	}
+	ppsh := new(ParityPartSetHeader)
+	ppsh.Total = pppsh.Total
+	ppsh.Hash = pppsh.Hash
+	ppsh.LastPartSize = pppsh.LastPartSize
+
+	return ppsh, ppsh.ValidateBasic()
+}
+
+//-------------------------------------
+
 type PartSet struct {
 	total uint32
 	hash  []byte
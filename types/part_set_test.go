@@ -138,6 +138,89 @@ func TestPartValidateBasic(t *testing.T) {
 	}
 }
 
+// parityParts returns the parity parts of total parts of partSize bytes, with
+// their header.
+func parityParts(total int, partSize int) ([]*Part, ParityPartSetHeader) {
+	parity := make([][]byte, total)
+	for i := range parity {
+		parity[i] = cmtrand.Bytes(partSize)
+	}
+	header := NewParityPartSetHeader(parity, 100)
+	_, proofs := merkle.ProofsFromByteSlices(parity)
+	parts := make([]*Part, total)
+	for i := range parts {
+		parts[i] = &Part{Index: uint32(i), Bytes: parity[i], Proof: *proofs[i]}
+	}
+	return parts, header
+}
+
+func TestParityPartSetHeaderValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		malleateHeader func(*ParityPartSetHeader)
+		expectErr      bool
+	}{
+		{"Good Header", func(header *ParityPartSetHeader) {}, false},
+		{"No Parity Parts", func(header *ParityPartSetHeader) { header.Total = 0 }, true},
+		{"Missing Hash", func(header *ParityPartSetHeader) { header.Hash = nil }, true},
+		{"Invalid Hash", func(header *ParityPartSetHeader) { header.Hash = make([]byte, 1) }, true},
+		{"Zero LastPartSize", func(header *ParityPartSetHeader) { header.LastPartSize = 0 }, true},
+		{"Too Big LastPartSize", func(header *ParityPartSetHeader) { header.LastPartSize = BlockPartSizeBytes + 1 }, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			_, header := parityParts(3, 16)
+			tc.malleateHeader(&header)
+			assert.Equal(t, tc.expectErr, header.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
+func TestParityPartSetHeaderVerifyPart(t *testing.T) {
+	parts, header := parityParts(3, 16)
+	for _, part := range parts {
+		require.NoError(t, header.VerifyPart(part))
+	}
+
+	wrongIndex := *parts[1]
+	wrongIndex.Index = 2
+	assert.ErrorIs(t, header.VerifyPart(&wrongIndex), ErrPartSetUnexpectedIndex)
+	outOfRange := *parts[2]
+	outOfRange.Index, outOfRange.Proof.Index = 3, 3
+	assert.ErrorIs(t, header.VerifyPart(&outOfRange), ErrPartSetUnexpectedIndex)
+	wrongBytes := *parts[0]
+	wrongBytes.Bytes = cmtrand.Bytes(16)
+	assert.ErrorIs(t, header.VerifyPart(&wrongBytes), ErrPartSetInvalidProof)
+
+	// the parity parts of another block
+	other, _ := parityParts(3, 16)
+	assert.ErrorIs(t, header.VerifyPart(other[0]), ErrPartSetInvalidProof)
+}
+
+func TestParityPartSetHeaderProtoBuf(t *testing.T) {
+	_, header := parityParts(2, 16)
+	testCases := []struct {
+		msg     string
+		h1      *ParityPartSetHeader
+		expPass bool
+	}{
+		{"success", &header, true},
+		{"nil", nil, true},
+		{"empty header failure validatebasic", &ParityPartSetHeader{}, false},
+	}
+
+	for _, tc := range testCases {
+		h, err := ParityPartSetHeaderFromProto(tc.h1.ToProto())
+		if tc.expPass {
+			require.NoError(t, err, tc.msg)
+			require.Equal(t, tc.h1, h, tc.msg)
+		} else {
+			require.Error(t, err, tc.msg)
+		}
+	}
+}
+
 func TestParSetHeaderProtoBuf(t *testing.T) {
 	testCases := []struct {
 		msg     string
@@ -19,6 +19,18 @@ type PrivValidator interface {
 	SignProposal(chainID string, proposal *cmtproto.Proposal) error
 }
 
+// KeyRotatingPrivValidator is a PrivValidator which can switch its consensus
+// key to a new key, prepared in advance and announced with a KeyRotation.
+type KeyRotatingPrivValidator interface {
+	PrivValidator
+
+	// NextPubKey returns the public key the validator will rotate to, or nil if
+	// there is none.
+	NextPubKey() (crypto.PubKey, error)
+	// RotateKey makes the next key the current key of the validator.
+	RotateKey() error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
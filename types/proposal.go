@@ -6,6 +6,7 @@ import (
 	"time"
 
 	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/erasure"
 	"github.com/tendermint/tendermint/libs/protoio"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	cmttime "github.com/tendermint/tendermint/types/time"
@@ -30,6 +31,9 @@ type Proposal struct {
 	BlockID   BlockID   `json:"block_id"`
 	Timestamp time.Time `json:"timestamp"`
 	Signature []byte    `json:"signature"`
+
+	// the parity parts of the block, if erasure coded
+	ParityPartSetHeader *ParityPartSetHeader `json:"parity_part_set_header,omitempty"`
 }
 
 // NewProposal returns a new Proposal.
@@ -66,6 +70,14 @@ func (p *Proposal) ValidateBasic() error {
 	if !p.BlockID.IsComplete() {
 		return fmt.Errorf("expected a complete, non-empty BlockID, got: %v", p.BlockID)
 	}
+	if p.ParityPartSetHeader != nil {
+		if err := p.ParityPartSetHeader.ValidateBasic(); err != nil {
+			return fmt.Errorf("wrong ParityPartSetHeader: %v", err)
+		}
+		if total := int(p.BlockID.PartSetHeader.Total) + int(p.ParityPartSetHeader.Total); total > erasure.MaxShards {
+			return fmt.Errorf("too many parts and parity parts: %d, max: %d", total, erasure.MaxShards)
+		}
+	}
 
 	// NOTE: Timestamp validation is subtle and handled elsewhere.
 
@@ -79,6 +91,17 @@ func (p *Proposal) ValidateBasic() error {
 	return nil
 }
 
+// IsTimely returns whether the proposal was received in time for its timestamp
+// with proposer-based timestamps: received at most params.Precision before it,
+// the clocks being that far apart, and at most params.MessageDelay after it
+// on top, as grown by the round of the proposal.
+func (p *Proposal) IsTimely(recvTime time.Time, params cmtproto.SynchronyParams) bool {
+	params = SynchronyParamsInRound(params, p.Round)
+	lower := p.Timestamp.Add(-params.Precision)
+	upper := p.Timestamp.Add(params.MessageDelay).Add(params.Precision)
+	return !recvTime.Before(lower) && !recvTime.After(upper)
+}
+
 // String returns a string representation of the Proposal.
 //
 // 1. height
@@ -131,6 +154,7 @@ func (p *Proposal) ToProto() *cmtproto.Proposal {
 	pb.PolRound = p.POLRound
 	pb.Timestamp = p.Timestamp
 	pb.Signature = p.Signature
+	pb.ParityPartSetHeader = p.ParityPartSetHeader.ToProto()
 
 	return pb
 }
@@ -149,7 +173,13 @@ func ProposalFromProto(pp *cmtproto.Proposal) (*Proposal, error) {
 		return nil, err
 	}
 
+	parityPartSetHeader, err := ParityPartSetHeaderFromProto(pp.ParityPartSetHeader)
+	if err != nil {
+		return nil, err
+	}
+
 	p.BlockID = *blockID
+	p.ParityPartSetHeader = parityPartSetHeader
 	p.Type = pp.Type
 	p.Height = pp.Height
 	p.Round = pp.Round
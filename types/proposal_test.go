@@ -46,6 +46,24 @@ func TestProposalSignable(t *testing.T) {
 	require.Equal(t, expected, signBytes, "Got unexpected sign bytes for Proposal")
 }
 
+func TestProposalSignableParityPartSetHeader(t *testing.T) {
+	chainID := "test_chain_id"
+	privVal := NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	prop := NewProposal(4, 2, 2, makeBlockID(tmhash.Sum([]byte("blockhash")), 2, tmhash.Sum([]byte("partshash"))))
+	p := prop.ToProto()
+	require.NoError(t, privVal.SignProposal(chainID, p))
+	require.Nil(t, CanonicalizeProposal(chainID, p).ParityPartSetHeader)
+
+	// the parity parts are committed to by the signature
+	prop.ParityPartSetHeader = &ParityPartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parity")), LastPartSize: 2}
+	signBytes := ProposalSignBytes(chainID, prop.ToProto())
+	assert.NotEqual(t, ProposalSignBytes(chainID, p), signBytes)
+	assert.False(t, pubKey.VerifySignature(signBytes, p.Signature))
+}
+
 func TestProposalString(t *testing.T) {
 	str := testProposal.String()
 	expected := `Proposal{12345/23456 (2D2D4A756E655F31355F323032305F616D696E6F5F7761735F72656D6F766564:111:2D2D4A756E65, -1) 000000000000 @ 2018-02-11T07:09:22.765Z}` //nolint:lll // ignore line length for tests
@@ -144,6 +162,18 @@ func TestProposalValidateBasic(t *testing.T) {
 		{"Too big Signature", func(p *Proposal) {
 			p.Signature = make([]byte, MaxSignatureSize+1)
 		}, true},
+		{"Good ParityPartSetHeader", func(p *Proposal) {
+			p.BlockID.PartSetHeader.Total = 10
+			p.ParityPartSetHeader = &ParityPartSetHeader{Total: 5, Hash: tmhash.Sum([]byte("parity")), LastPartSize: 1}
+		}, false},
+		{"Invalid ParityPartSetHeader", func(p *Proposal) {
+			p.BlockID.PartSetHeader.Total = 10
+			p.ParityPartSetHeader = &ParityPartSetHeader{Total: 5, Hash: []byte("parity"), LastPartSize: 1}
+		}, true},
+		{"Too many parity parts", func(p *Proposal) {
+			p.BlockID.PartSetHeader.Total = 200
+			p.ParityPartSetHeader = &ParityPartSetHeader{Total: 100, Hash: tmhash.Sum([]byte("parity")), LastPartSize: 1}
+		}, true},
 	}
 	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
 
@@ -167,6 +197,9 @@ func TestProposalProtoBuf(t *testing.T) {
 	proposal := NewProposal(1, 2, 3, makeBlockID([]byte("hash"), 2, []byte("part_set_hash")))
 	proposal.Signature = []byte("sig")
 	proposal2 := NewProposal(1, 2, 3, BlockID{})
+	proposal3 := NewProposal(1, 2, 3, makeBlockID([]byte("hash"), 2, []byte("part_set_hash")))
+	proposal3.ParityPartSetHeader = &ParityPartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parity")), LastPartSize: 2}
+	proposal3.Signature = []byte("sig")
 
 	testCases := []struct {
 		msg     string
@@ -175,6 +208,7 @@ func TestProposalProtoBuf(t *testing.T) {
 	}{
 		{"success", proposal, true},
 		{"success", proposal2, false}, // blcokID cannot be empty
+		{"success with parity parts", proposal3, true},
 		{"empty proposal failure validatebasic", &Proposal{}, false},
 		{"nil proposal", nil, false},
 	}
@@ -190,3 +224,19 @@ func TestProposalProtoBuf(t *testing.T) {
 		}
 	}
 }
+
+func TestProposalIsTimely(t *testing.T) {
+	params := cmtproto.SynchronyParams{Precision: time.Second, MessageDelay: 10 * time.Second}
+	ts := time.Now()
+	proposal := &Proposal{Timestamp: ts}
+
+	assert.True(t, proposal.IsTimely(ts, params))
+	assert.True(t, proposal.IsTimely(ts.Add(-time.Second), params))
+	assert.False(t, proposal.IsTimely(ts.Add(-2*time.Second), params))
+	assert.True(t, proposal.IsTimely(ts.Add(11*time.Second), params))
+	assert.False(t, proposal.IsTimely(ts.Add(12*time.Second), params))
+
+	// the message delay grows with the rounds
+	proposal.Round = 2
+	assert.True(t, proposal.IsTimely(ts.Add(13*time.Second), params))
+}
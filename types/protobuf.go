@@ -3,9 +3,11 @@ package types
 import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -15,6 +17,8 @@ import (
 const (
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
+	ABCIPubKeyTypeBls12381  = bls12381.KeyType
+	ABCIPubKeyTypeSr25519   = sr25519.KeyType
 )
 
 // TODO: Make non-global by allowing for registration of more pubkey types
@@ -22,6 +26,8 @@ const (
 var ABCIPubKeyTypesToNames = map[string]string{
 	ABCIPubKeyTypeEd25519:   ed25519.PubKeyName,
 	ABCIPubKeyTypeSecp256k1: secp256k1.PubKeyName,
+	ABCIPubKeyTypeBls12381:  bls12381.PubKeyName,
+	ABCIPubKeyTypeSr25519:   sr25519.PubKeyName,
 }
 
 //-------------------------------------------------------
@@ -83,8 +89,9 @@ func (tm2pb) ValidatorUpdate(val *Validator) abci.ValidatorUpdate {
 		panic(err)
 	}
 	return abci.ValidatorUpdate{
-		PubKey: pk,
-		Power:  val.VotingPower,
+		PubKey:            pk,
+		Power:             val.VotingPower,
+		ProofOfPossession: val.ProofOfPossession,
 	}
 }
 
@@ -105,6 +112,9 @@ func (tm2pb) ConsensusParams(params *cmtproto.ConsensusParams) *abci.ConsensusPa
 		},
 		Evidence:  &params.Evidence,
 		Validator: &params.Validator,
+		Timeout:   &params.Timeout,
+		Synchrony: &params.Synchrony,
+		Feature:   &params.Feature,
 	}
 }
 
@@ -136,6 +146,7 @@ func (pb2tm) ValidatorUpdates(vals []abci.ValidatorUpdate) ([]*Validator, error)
 			return nil, err
 		}
 		tmVals[i] = NewValidator(pub, v.Power)
+		tmVals[i].ProofOfPossession = v.ProofOfPossession
 	}
 	return tmVals, nil
 }
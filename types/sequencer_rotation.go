@@ -0,0 +1,32 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// SequencerRotation is the handover of the chain, scheduled by its settlement
+// hub, to the sequencer of consensus key PubKey, which proposes the blocks
+// from Height on. The previous sequencer proposes the blocks up to Height-1.
+type SequencerRotation struct {
+	Height int64         `json:"height"`
+	PubKey crypto.PubKey `json:"pub_key"`
+}
+
+// ValidateBasic performs basic validation.
+func (r *SequencerRotation) ValidateBasic() error {
+	if r.Height <= 0 {
+		return fmt.Errorf("non-positive height %d", r.Height)
+	}
+	if r.PubKey == nil {
+		return errors.New("missing public key")
+	}
+	return nil
+}
+
+// String returns a string representation of the SequencerRotation.
+func (r *SequencerRotation) String() string {
+	return fmt.Sprintf("SequencerRotation{%d %v}", r.Height, r.PubKey)
+}
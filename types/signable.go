@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 )
@@ -9,7 +10,7 @@ var (
 	// MaxSignatureSize is a maximum allowed signature size for the Proposal
 	// and Vote.
 	// XXX: secp256k1 does not have Size nor MaxSize defined.
-	MaxSignatureSize = cmtmath.MaxInt(ed25519.SignatureSize, 64)
+	MaxSignatureSize = cmtmath.MaxInt(cmtmath.MaxInt(ed25519.SignatureSize, 64), bls12381.SignatureSize)
 )
 
 // Signable is an interface for all signable things.
@@ -7,11 +7,16 @@ import (
 	"strings"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	ce "github.com/tendermint/tendermint/crypto/encoding"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
+// ErrInvalidProofOfPossession is returned when a key entering the validator set
+// comes without a valid proof of possession of its private key.
+var ErrInvalidProofOfPossession = errors.New("missing or invalid proof of possession")
+
 // Volatile state for each Validator
 // NOTE: The ProposerPriority is not included in Validator.Hash();
 // make sure to update that method if changes are made here
@@ -21,6 +26,10 @@ type Validator struct {
 	VotingPower int64         `json:"voting_power"`
 
 	ProposerPriority int64 `json:"proposer_priority"`
+
+	// ProofOfPossession proves the possession of the private key of PubKey.
+	// It is only set for BLS12-381 keys, which need it to enter the set.
+	ProofOfPossession []byte `json:"proof_of_possession,omitempty"`
 }
 
 // NewValidator returns a new validator with the given pubkey and voting power.
@@ -33,6 +42,28 @@ func NewValidator(pubKey crypto.PubKey, votingPower int64) *Validator {
 	}
 }
 
+// ProofOfPossession returns the proof of possession of privKey to publish with
+// its public key entering the validator set, or nil if the keys of its type
+// don't need one.
+func ProofOfPossession(privKey crypto.PrivKey) ([]byte, error) {
+	if blsKey, ok := privKey.(bls12381.PrivKey); ok {
+		return blsKey.PopProve()
+	}
+	return nil, nil
+}
+
+// VerifyProofOfPossession checks the proof of possession of the private key of
+// pubKey, entering the validator set. Only the BLS12-381 keys, whose
+// signatures are meant to be aggregated, need one: the aggregate of the
+// signatures of a rogue key chosen from the keys of the others, without its
+// private key, would be a forgery.
+func VerifyProofOfPossession(pubKey crypto.PubKey, proof []byte) error {
+	if blsKey, ok := pubKey.(bls12381.PubKey); ok && !blsKey.PopVerify(proof) {
+		return ErrInvalidProofOfPossession
+	}
+	return nil
+}
+
 // ValidateBasic performs basic validation.
 func (v *Validator) ValidateBasic() error {
 	if v == nil {
@@ -144,10 +175,11 @@ func (v *Validator) ToProto() (*cmtproto.Validator, error) {
 	}
 
 	vp := cmtproto.Validator{
-		Address:          v.Address,
-		PubKey:           pk,
-		VotingPower:      v.VotingPower,
-		ProposerPriority: v.ProposerPriority,
+		Address:           v.Address,
+		PubKey:            pk,
+		VotingPower:       v.VotingPower,
+		ProposerPriority:  v.ProposerPriority,
+		ProofOfPossession: v.ProofOfPossession,
 	}
 
 	return &vp, nil
@@ -169,6 +201,7 @@ func ValidatorFromProto(vp *cmtproto.Validator) (*Validator, error) {
 	v.PubKey = pk
 	v.VotingPower = vp.GetVotingPower()
 	v.ProposerPriority = vp.GetProposerPriority()
+	v.ProofOfPossession = vp.GetProofOfPossession()
 
 	return v, nil
 }
@@ -9,6 +9,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/batch"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -515,7 +517,11 @@ func (vals *ValidatorSet) applyUpdates(updates []*Validator) {
 			// Apply add or update.
 			merged[i] = updates[0]
 			if bytes.Equal(existing[0].Address, updates[0].Address) {
-				// Validator is present in both, advance existing.
+				// Validator is present in both, advance existing, keeping the
+				// proof of possession it entered the set with.
+				if len(updates[0].ProofOfPossession) == 0 {
+					merged[i].ProofOfPossession = existing[0].ProofOfPossession
+				}
 				existing = existing[1:]
 			}
 			updates = updates[1:]
@@ -657,6 +663,33 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 	return vals.updateWithChangeSet(changes, true)
 }
 
+// RotateKey replaces the public key of the validator with the given address
+// by pubKey, with its proof of possession. The validator keeps its voting power
+// and proposer priority, so the rotation doesn't affect the proposer selection.
+//
+// If an error is detected, it is returned and the validator set is not
+// changed.
+func (vals *ValidatorSet) RotateKey(address Address, pubKey crypto.PubKey, proofOfPossession []byte) error {
+	idx, val := vals.GetByAddress(address)
+	if val == nil {
+		return fmt.Errorf("validator %v is not in the validator set", address)
+	}
+	if vals.HasAddress(pubKey.Address()) {
+		return fmt.Errorf("validator %v is already in the validator set", pubKey.Address())
+	}
+
+	val.PubKey = pubKey
+	val.Address = pubKey.Address()
+	val.ProofOfPossession = proofOfPossession
+	vals.Validators[idx] = val
+	if vals.Proposer != nil && bytes.Equal(vals.Proposer.Address, address) {
+		vals.Proposer = val
+	}
+
+	sort.Sort(ValidatorsByVotingPower(vals.Validators))
+	return nil
+}
+
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
 //
 // It checks all the signatures! While it's safe to exit as soon as we have
@@ -682,6 +715,7 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 
 	talliedVotingPower := int64(0)
 	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	sigs := newCommitSigVerifier(chainID, commit)
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some signatures can be absent.
@@ -691,12 +725,7 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 		// This means we don't need the validator address or to do any lookup.
 		val := vals.Validators[idx]
 
-		// Validate signature.
-		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-		}
-		// Good!
+		sigs.add(idx, val.PubKey)
 		if commitSig.ForBlock() {
 			talliedVotingPower += val.VotingPower
 		}
@@ -706,6 +735,11 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 		// }
 	}
 
+	// Validate signatures.
+	if err := sigs.verify(); err != nil {
+		return err
+	}
+
 	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
 		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
 	}
@@ -737,6 +771,7 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 
 	talliedVotingPower := int64(0)
 	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	sigs := newCommitSigVerifier(chainID, commit)
 	for idx, commitSig := range commit.Signatures {
 		// No need to verify absent or nil votes.
 		if !commitSig.ForBlock() {
@@ -747,20 +782,18 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 		// This means we don't need the validator address or to do any lookup.
 		val := vals.Validators[idx]
 
-		// Validate signature.
-		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-		}
-
+		sigs.add(idx, val.PubKey)
 		talliedVotingPower += val.VotingPower
 
 		// return as soon as +2/3 of the signatures are verified
 		if talliedVotingPower > votingPowerNeeded {
-			return nil
+			return sigs.verify()
 		}
 	}
 
+	if err := sigs.verify(); err != nil {
+		return err
+	}
 	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
 }
 
@@ -789,6 +822,7 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 		return errors.New("int64 overflow while calculating voting power needed. please provide smaller trustLevel numerator")
 	}
 	votingPowerNeeded := totalVotingPowerMulByNumerator / int64(trustLevel.Denominator)
+	sigs := newCommitSigVerifier(chainID, commit)
 
 	for idx, commitSig := range commit.Signatures {
 		// No need to verify absent or nil votes.
@@ -808,23 +842,109 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 			}
 			seenVals[valIdx] = idx
 
-			// Validate signature.
-			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-			if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-				return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-			}
-
+			sigs.add(idx, val.PubKey)
 			talliedVotingPower += val.VotingPower
 
 			if talliedVotingPower > votingPowerNeeded {
-				return nil
+				return sigs.verify()
 			}
 		}
 	}
 
+	if err := sigs.verify(); err != nil {
+		return err
+	}
 	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
 }
 
+// batchVerifyThreshold is the minimum number of signatures for which a batch
+// verifier is used. Below it, verifying the signatures one by one is as fast.
+const batchVerifyThreshold = 2
+
+// commitSigVerifier collects the signatures of a commit to verify, so they can
+// be verified in a batch when the validators' keys support it.
+type commitSigVerifier struct {
+	chainID string
+	commit  *Commit
+	idxs    []int
+	pubKeys []crypto.PubKey
+}
+
+func newCommitSigVerifier(chainID string, commit *Commit) *commitSigVerifier {
+	return &commitSigVerifier{chainID: chainID, commit: commit}
+}
+
+// add queues the signature at index idx of the commit for verification
+// against pubKey.
+func (v *commitSigVerifier) add(idx int, pubKey crypto.PubKey) {
+	v.idxs = append(v.idxs, idx)
+	v.pubKeys = append(v.pubKeys, pubKey)
+}
+
+// verify verifies all queued signatures. Signatures are verified in batches of
+// at most batch.MaxBatchSize() if there are enough of them and all keys are of
+// the same type supporting batch verification, and one by one otherwise. In
+// both cases, the error reports the first invalid signature.
+func (v *commitSigVerifier) verify() error {
+	if maxSize := batch.MaxBatchSize(); maxSize > 0 && len(v.idxs) >= batchVerifyThreshold && v.sameKeyType() &&
+		batch.SupportsBatchVerifier(v.pubKeys[0]) {
+		for start := 0; start < len(v.idxs); start += maxSize {
+			end := start + maxSize
+			if end > len(v.idxs) {
+				end = len(v.idxs)
+			}
+			bv, _ := batch.CreateBatchVerifier(v.pubKeys[0])
+			if err := v.verifyBatch(bv, start, end); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, idx := range v.idxs {
+		if !v.pubKeys[i].VerifySignature(v.commit.VoteSignBytes(v.chainID, int32(idx)), v.signature(idx)) {
+			return v.wrongSignature(idx)
+		}
+	}
+	return nil
+}
+
+// verifyBatch verifies the queued signatures from start to end (excluded) with
+// bv.
+func (v *commitSigVerifier) verifyBatch(bv crypto.BatchVerifier, start, end int) error {
+	for i := start; i < end; i++ {
+		idx := v.idxs[i]
+		if err := bv.Add(v.pubKeys[i], v.commit.VoteSignBytes(v.chainID, int32(idx)), v.signature(idx)); err != nil {
+			return v.wrongSignature(idx)
+		}
+	}
+	if ok, valid := bv.Verify(); !ok {
+		for i, ok := range valid {
+			if !ok {
+				return v.wrongSignature(v.idxs[start+i])
+			}
+		}
+	}
+	return nil
+}
+
+func (v *commitSigVerifier) sameKeyType() bool {
+	for _, pk := range v.pubKeys[1:] {
+		if pk.Type() != v.pubKeys[0].Type() {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *commitSigVerifier) signature(idx int) []byte {
+	return v.commit.Signatures[idx].Signature
+}
+
+func (v *commitSigVerifier) wrongSignature(idx int) error {
+	return fmt.Errorf("wrong signature (#%d): %X", idx, v.signature(idx))
+}
+
 // findPreviousProposer reverses the compare proposer priority function to find the validator
 // with the lowest proposer priority which would have been the previous proposer.
 //
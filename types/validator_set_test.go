@@ -14,7 +14,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/batch"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -768,6 +771,128 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	}
 }
 
+func TestValidatorSetRotateKey(t *testing.T) {
+	vals, _ := RandValidatorSet(4, 10)
+	vals.IncrementProposerPriority(1)
+	orig := vals.Copy()
+	proposer := vals.GetProposer()
+	newPubKey := ed25519.GenPrivKey().PubKey()
+
+	require.NoError(t, vals.RotateKey(proposer.Address, newPubKey, nil))
+	assert.False(t, vals.HasAddress(proposer.Address))
+	_, val := vals.GetByAddress(newPubKey.Address())
+	require.NotNil(t, val)
+	assert.Equal(t, newPubKey, val.PubKey)
+	assert.Equal(t, proposer.VotingPower, val.VotingPower)
+	assert.Equal(t, proposer.ProposerPriority, val.ProposerPriority)
+	assert.Equal(t, newPubKey.Address(), vals.GetProposer().Address)
+	assert.Equal(t, orig.TotalVotingPower(), vals.TotalVotingPower())
+	assert.NoError(t, vals.ValidateBasic())
+
+	// the copy is not affected
+	assert.True(t, orig.HasAddress(proposer.Address))
+	assert.Equal(t, proposer.Address, orig.GetProposer().Address)
+
+	// unknown validator, or rotation to a key of the set
+	assert.Error(t, vals.RotateKey(proposer.Address, ed25519.GenPrivKey().PubKey(), nil))
+	assert.Error(t, vals.RotateKey(newPubKey.Address(), vals.Validators[0].PubKey, nil))
+}
+
+func TestValidatorSetUpdateKeepsProofOfPossession(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	proof, err := ProofOfPossession(privKey)
+	require.NoError(t, err)
+	val := NewValidator(privKey.PubKey(), 10)
+	val.ProofOfPossession = proof
+	vals := NewValidatorSet([]*Validator{val, NewValidator(ed25519.GenPrivKey().PubKey(), 10)})
+
+	// a power change carries no proof, the validator keeps the one it entered with
+	require.NoError(t, vals.UpdateWithChangeSet([]*Validator{NewValidator(privKey.PubKey(), 20)}))
+	_, val = vals.GetByAddress(privKey.PubKey().Address())
+	require.NotNil(t, val)
+	assert.EqualValues(t, 20, val.VotingPower)
+	assert.Equal(t, proof, val.ProofOfPossession)
+
+	// and it survives the protobuf round trip
+	pb, err := vals.ToProto()
+	require.NoError(t, err)
+	vals2, err := ValidatorSetFromProto(pb)
+	require.NoError(t, err)
+	_, val = vals2.GetByAddress(privKey.PubKey().Address())
+	require.NotNil(t, val)
+	assert.Equal(t, proof, val.ProofOfPossession)
+}
+
+func TestValidatorSet_VerifyCommit_BatchSize(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	valSet, vals := RandValidatorSet(7, 10)
+	voteSet := NewVoteSet(chainID, h, 0, cmtproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// malleate 6th signature, in the third batch of two signatures
+	vote := voteSet.GetByIndex(5)
+	v := vote.ToProto()
+	require.NoError(t, vals[5].SignVote("CentaurusA", v))
+	vote.Signature = v.Signature
+	commit.Signatures[5] = vote.CommitSig()
+
+	t.Cleanup(func() { batch.SetMaxBatchSize(batch.DefaultMaxBatchSize) })
+	for _, size := range []int{0, 2, batch.DefaultMaxBatchSize} {
+		batch.SetMaxBatchSize(size)
+		err = valSet.VerifyCommit(chainID, blockID, h, commit)
+		if assert.Error(t, err, "batch size %d", size) {
+			assert.Contains(t, err.Error(), "wrong signature (#5)")
+		}
+	}
+}
+
+func TestValidatorSet_VerifyCommit_Secp256k1Batch(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+		valz    = make([]*Validator, 6)
+		vals    = make([]PrivValidator, 6)
+	)
+	for i := range vals {
+		pv := NewMockPVWithParams(secp256k1.GenPrivKey(), false, false)
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		valz[i], vals[i] = NewValidator(pubKey, 10), pv
+	}
+	sort.Sort(PrivValidatorsByAddress(vals))
+	valSet := NewValidatorSet(valz)
+
+	voteSet := NewVoteSet(chainID, h, 0, cmtproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLightTrusting(chainID, commit, cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// malleate 2nd signature
+	vote := voteSet.GetByIndex(1)
+	v := vote.ToProto()
+	require.NoError(t, vals[1].SignVote("CentaurusA", v))
+	vote.Signature = v.Signature
+	commit.Signatures[1] = vote.CommitSig()
+
+	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#1)")
+	}
+	err = valSet.VerifyCommitLight(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#1)")
+	}
+}
+
 func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"